@@ -100,9 +100,9 @@ func TestCLIMatrix(t *testing.T) {
 		{"root help", []string{"--help"}, 0,
 			[]string{"Available Commands", "cluster", "app", "bootstrap", "prerequisites", "update"}, nil},
 		{"app help lists subcommands", []string{"app", "--help"}, 0,
-			[]string{"install", "upgrade", "status", "access", "uninstall"}, nil},
+			[]string{"install", "upgrade", "status", "access", "uninstall", "add-repo-credentials"}, nil},
 		{"cluster help lists subcommands", []string{"cluster", "--help"}, 0,
-			[]string{"create", "delete", "list", "status", "cleanup"}, nil},
+			[]string{"create", "delete", "list", "status", "cleanup", "idle-watch"}, nil},
 		{"update help lists subcommands", []string{"update", "--help"}, 0,
 			[]string{"check", "rollback"}, nil},
 		{"completion bash", []string{"completion", "bash"}, 0, []string{"openframe"}, nil},
@@ -0,0 +1,276 @@
+// Package addon implements `openframe addon`: installing, removing and
+// listing the add-ons — local emulators and other services declared in YAML —
+// that run next to the platform on a k3d cluster.
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetAddonCmd returns the `openframe addon` command tree.
+func GetAddonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "addon",
+		Aliases: []string{"addons"},
+		Short:   "Install, remove and list cluster add-ons",
+		Long: `Install, remove and list add-ons: services deployed next to the platform on a
+k3d cluster, such as the MinIO, LocalStack and MailHog emulators built into the
+CLI. 'openframe cluster create --addons' installs them with a new cluster.
+
+An add-on is a YAML definition: a pinned helm chart or a set of manifests, a
+health check on its pods, the ports it serves (shown by 'openframe services')
+and hooks — shell commands run on this machine after it is installed and before
+it is removed or its cluster deleted. Definitions are read from
+~/.openframe/addons/NAME.yaml, then the built-in ones, then a registry: a base
+URL serving NAME.yaml, given with --registry or as addons.registry in
+~/.openframe/config.json.`,
+		Example: `  openframe addon list
+  openframe addon install minio mailhog
+  openframe addon install ./keycloak.yaml --cluster openframe-dev
+  openframe addon install vault --registry https://addons.example.com
+  openframe addon remove minio`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newInstallCmd(), newRemoveCmd(), newListCmd())
+	return cmd
+}
+
+func newInstallCmd() *cobra.Command {
+	var (
+		clusterName, registry string
+		force                 bool
+	)
+	cmd := &cobra.Command{
+		Use:   "install NAME|FILE|URL...",
+		Short: "Install add-ons on a cluster",
+		Long: `Install add-ons on a k3d cluster, in order: deploy each, wait for its health
+check and run its post-install hooks. Installing one again upgrades it.
+
+A definition given as a file or URL, or fetched from the registry, is saved in
+~/.openframe/addons so it can be installed again by name and its hooks run when
+its cluster is deleted. The hooks of a definition fetched from a URL or
+registry are shown for confirmation first, unless --force is given. Hooks are
+shell commands, so add-ons that have them fail under --sandbox enforce.`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.Names(-1, addonNames),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addons, err := addon.Resolve(cmd.Context(), args, registry)
+			if err != nil {
+				return err
+			}
+			if !force {
+				if err := addon.ConfirmHooks(addons, "--force"); err != nil {
+					return err
+				}
+			}
+			if err := addon.Save(addons); err != nil {
+				return err
+			}
+			name, err := targetCluster(clusterName)
+			if err != nil {
+				return err
+			}
+			installer := addon.NewInstaller(newExecutor(cmd), name, nil)
+			for _, a := range addons {
+				pterm.Info.Printf("Installing the %s add-on on cluster %s...\n", a.Name, name)
+				if err := installer.Install(cmd.Context(), a); err != nil {
+					return err
+				}
+				pterm.Success.Printf("%s installed\n", a.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "k3d cluster to install on (default: the only one)")
+	cmd.Flags().StringVar(&registry, "registry", "", "Base URL to fetch add-ons not found locally from, as <registry>/<name>.yaml")
+	cmd.Flags().BoolVar(&force, "force", false, "Do not ask before installing add-ons whose hooks have not been seen on this machine")
+	_ = cmd.RegisterFlagCompletionFunc("cluster", completion.ClusterFlag())
+	return cmd
+}
+
+func newRemoveCmd() *cobra.Command {
+	var clusterName string
+	cmd := &cobra.Command{
+		Use:   "remove NAME...",
+		Short: "Remove add-ons from a cluster",
+		Long: `Remove add-ons from a k3d cluster: run each one's pre-remove hooks, then delete
+what it deployed. A hook that fails stops the removal.`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.Names(-1, addonNames),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var addons []addon.Addon
+			for _, n := range args {
+				a, err := addon.Load(n)
+				if err != nil {
+					return err
+				}
+				addons = append(addons, a)
+			}
+			name, err := targetCluster(clusterName)
+			if err != nil {
+				return err
+			}
+			installer := addon.NewInstaller(newExecutor(cmd), name, nil)
+			for _, a := range addons {
+				pterm.Info.Printf("Removing the %s add-on from cluster %s...\n", a.Name, name)
+				if err := installer.Remove(cmd.Context(), a); err != nil {
+					return err
+				}
+				pterm.Success.Printf("%s removed\n", a.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "k3d cluster to remove from (default: the only one)")
+	_ = cmd.RegisterFlagCompletionFunc("cluster", completion.ClusterFlag())
+	return cmd
+}
+
+// listEntry is an add-on as `addon list -o json` prints it.
+type listEntry struct {
+	addon.Addon
+	Source      string   `json:"source"`
+	InstalledOn []string `json:"installedOn"`
+}
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the available add-ons and where they are installed",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			addons, err := addon.List()
+			if err != nil {
+				return err
+			}
+			installed, err := addon.Installed()
+			if err != nil {
+				return err
+			}
+			entries := make([]listEntry, len(addons))
+			for i, a := range addons {
+				entries[i] = listEntry{Addon: a, Source: a.Source, InstalledOn: installedOn(installed, a.Name)}
+			}
+			if out, _ := cmd.Flags().GetString("output"); out == "json" {
+				b, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			} else if out != "text" {
+				return fmt.Errorf("invalid --output %q (want \"text\" or \"json\")", out)
+			}
+			table := pterm.TableData{{"NAME", "SOURCE", "DEPLOYS", "PORTS", "INSTALLED ON"}}
+			for _, e := range entries {
+				table = append(table, []string{e.Name, e.Source, deploys(e.Addon), orDash(ports(e.Addon)), orDash(strings.Join(e.InstalledOn, ", "))})
+			}
+			return pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+		},
+	}
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	return cmd
+}
+
+// targetCluster is the k3d cluster add-ons go to: the one named, or the only
+// one there is.
+func targetCluster(name string) (string, error) {
+	clusters, err := cluster.NewClusterService(executor.NewRealCommandExecutor(false, false)).ListClusters()
+	if err != nil {
+		return "", err
+	}
+	var k3d []string
+	for _, c := range clusters {
+		if c.Type == models.ClusterTypeK3d {
+			k3d = append(k3d, c.Name)
+		}
+	}
+	if name != "" {
+		for _, n := range k3d {
+			if n == name {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("no k3d cluster %q; add-ons run on the clusters 'openframe cluster create' makes", name)
+	}
+	switch len(k3d) {
+	case 0:
+		return "", fmt.Errorf("no k3d cluster; create one with 'openframe cluster create'")
+	case 1:
+		return k3d[0], nil
+	}
+	return "", fmt.Errorf("%d k3d clusters (%s); pick one with --cluster", len(k3d), strings.Join(k3d, ", "))
+}
+
+func newExecutor(cmd *cobra.Command) executor.CommandExecutor {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	return executor.NewRealCommandExecutor(false, verbose)
+}
+
+// addonNames lists the add-ons known locally, for completion.
+func addonNames(_ context.Context, _ *cobra.Command) ([]string, error) {
+	addons, err := addon.List()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(addons))
+	for i, a := range addons {
+		names[i] = a.Name
+	}
+	return names, nil
+}
+
+func installedOn(installed map[string][]string, name string) []string {
+	clusters := []string{}
+	for c, names := range installed {
+		for _, n := range names {
+			if n == name {
+				clusters = append(clusters, c)
+			}
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+func deploys(a addon.Addon) string {
+	if a.Chart != nil {
+		return a.Chart.Name + "@" + a.Chart.Version
+	}
+	if len(a.Manifests) == 1 {
+		return "1 manifest"
+	}
+	return strconv.Itoa(len(a.Manifests)) + " manifests"
+}
+
+func ports(a addon.Addon) string {
+	out := make([]string, len(a.Ports))
+	for i, p := range a.Ports {
+		out[i] = fmt.Sprintf("%s:%d", p.Service, p.Port)
+	}
+	return strings.Join(out, ", ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,27 @@
+package addon
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddonContract(t *testing.T) {
+	cmd := GetAddonCmd()
+	testutil.AssertSubcommands(t, cmd, "install", "remove", "list")
+
+	install := testutil.FindSubcommand(t, cmd, "install")
+	testutil.AssertFlags(t, install, []testutil.FlagSpec{
+		{Name: "cluster", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "registry", Type: "string", Default: ""},
+		{Name: "force", Type: "bool", Default: "false"},
+	})
+	testutil.AssertFlags(t, testutil.FindSubcommand(t, cmd, "remove"), []testutil.FlagSpec{
+		{Name: "cluster", Shorthand: "c", Type: "string", Default: ""},
+	})
+
+	list := testutil.FindSubcommand(t, cmd, "list")
+	assert.Equal(t, "true", list.Annotations["readonly"])
+	assert.NotEqual(t, "true", install.Annotations["readonly"])
+}
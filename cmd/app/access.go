@@ -35,7 +35,7 @@ Examples:
 
 func runAccessCommand(cmd *cobra.Command, _ []string) error {
 	verbose := getVerboseFlag(cmd)
-	contextName, _ := cmd.Flags().GetString("context")
+	contextName := contextFlag(cmd)
 	format, err := outputFormat(cmd)
 	if err != nil {
 		return sharedErrors.HandleGlobalError(err, verbose)
@@ -62,10 +62,17 @@ func runAccessCommand(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// contextFlag returns the --context value, with the name of an attached
+// external cluster (`cluster attach`) mapped to its kube-context.
+func contextFlag(cmd *cobra.Command) string {
+	contextName, _ := cmd.Flags().GetString("context")
+	return k8s.ResolveContextName(contextName)
+}
+
 // resolveRestConfig builds a rest.Config for the given kube-context (empty means
 // the current context). Shared by the status and access commands.
 func resolveRestConfig(contextName string) (*rest.Config, error) {
-	return k8s.RestConfigForContext(k8s.DefaultKubeconfigPath(), contextName)
+	return k8s.RestConfigForContext(k8s.KubeconfigForContext(contextName), contextName)
 }
 
 // newArgoCDManager builds an ArgoCD manager bound to the given context.
@@ -85,4 +92,5 @@ func printAccess(password string) {
 	pterm.Info.Println("Open the ArgoCD UI:")
 	pterm.Printf("  1. kubectl port-forward -n argocd svc/argocd-server 8080:443\n")
 	pterm.Printf("  2. open https://localhost:8080\n")
+	pterm.Info.Println("Or run `openframe dashboard`, which does both.")
 }
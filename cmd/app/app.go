@@ -1,6 +1,10 @@
 package app
 
 import (
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ci"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +18,13 @@ func GetAppCmd() *cobra.Command {
 
 This command group deploys the OpenFrame application onto a Kubernetes cluster:
   • install - Install ArgoCD and the app-of-apps
+  • add-repo-credentials - Let ArgoCD pull from a private Git repository
+  • validate - Render and check the app-of-apps chart without a cluster
+  • diff - Show how the live applications drifted from git
+  • sync - Trigger an ArgoCD sync of applications
+  • refresh - Make ArgoCD re-compare applications with git
+  • history - Show which applications are flaky across installs
+  • tree - Show the resources of an application and their health
 
 Requires an existing, online cluster — one created with 'openframe cluster
 create', made by you directly, or any other reachable cluster.
@@ -23,10 +34,24 @@ Examples:
   openframe app install my-cluster`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// This command group defines its own PersistentPreRunE, which shadows
-			// the root's, so honor --silent here too.
+			// the root's, so honor --silent, --ci and --read-only here too.
 			if s, _ := cmd.Flags().GetBool("silent"); s {
 				ui.SetSilent()
 			}
+			ci.Apply()
+			if err := readonly.Check(cmd); err != nil {
+				return err
+			}
+			if err := sharedconfig.ApplyCABundle(); err != nil {
+				return err
+			}
+			// Every app subcommand but validate and history talks to the
+			// cluster: start it first if idle-watch paused it.
+			if cmd.Use != "app" && cmd.Name() != "validate" && cmd.Name() != "history" {
+				if err := resumeTarget(cmd, args); err != nil {
+					return err
+				}
+			}
 			// Machine output (json/yaml): no logo, clean stdout for scripts.
 			if isMachineOutput(cmd) {
 				return nil
@@ -41,6 +66,8 @@ Examples:
 			// only needs helm + a reachable cluster, not the cert/k3d installer).
 			return nil
 		},
+		// On its own the command only prints help, which read-only mode allows.
+		Annotations: map[string]string{"readonly": "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Show logo when no subcommand is provided
 			ui.ShowLogoWithContext(cmd.Context())
@@ -53,5 +80,24 @@ Examples:
 	cmd.AddCommand(getStatusCmd())
 	cmd.AddCommand(getAccessCmd())
 	cmd.AddCommand(getUninstallCmd())
+	cmd.AddCommand(getAddRepoCredentialsCmd())
+	cmd.AddCommand(getValidateCmd())
+	cmd.AddCommand(getDiffCmd())
+	cmd.AddCommand(getSyncCmd())
+	cmd.AddCommand(getRefreshCmd())
+	cmd.AddCommand(getHistoryCmd())
+	cmd.AddCommand(getTreeCmd())
+	registerCompletions(cmd)
 	return cmd
 }
+
+// resumeTarget starts the cluster cmd works on if idle-watch paused it: the
+// cluster install and upgrade name, else the one behind --context or the
+// current context. Other paused clusters stay paused.
+func resumeTarget(cmd *cobra.Command, args []string) error {
+	quiet := isMachineOutput(cmd)
+	if (cmd.Name() == "install" || cmd.Name() == "upgrade") && len(args) > 0 {
+		return cluster.ResumeIdleCluster(cmd.Context(), args[0], quiet)
+	}
+	return cluster.ResumeIdleContext(cmd.Context(), contextFlag(cmd), quiet)
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/spf13/cobra"
+)
+
+// registerCompletions completes every app subcommand's --context flag with the
+// kubeconfig's contexts.
+func registerCompletions(app *cobra.Command) {
+	for _, sub := range app.Commands() {
+		if sub.Flags().Lookup("context") != nil {
+			_ = sub.RegisterFlagCompletionFunc("context", completion.KubeContexts())
+		}
+	}
+}
+
+// applicationNames lists the ArgoCD applications in the cluster selected by
+// the command's --context flag (the current context when unset).
+func applicationNames(ctx context.Context, cmd *cobra.Command) ([]string, error) {
+	contextName := contextFlag(cmd)
+	mgr, err := newArgoCDManager(contextName, false)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := mgr.ListApplications(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(apps))
+	for _, a := range apps {
+		names = append(names, a.Name)
+	}
+	return names, nil
+}
@@ -18,7 +18,7 @@ func TestAppContract_RootShape(t *testing.T) {
 	assert.Empty(t, app.Aliases, "the chart/c aliases were removed — only 'openframe app' is supported")
 	assert.NotEmpty(t, app.Short)
 
-	testutil.AssertSubcommands(t, app, "install", "upgrade", "status", "access", "uninstall")
+	testutil.AssertSubcommands(t, app, "install", "upgrade", "status", "access", "uninstall", "add-repo-credentials", "validate", "diff", "sync", "refresh", "history", "tree")
 }
 
 func TestAppContract_UpgradeFlags(t *testing.T) {
@@ -52,6 +52,25 @@ func TestAppContract_InstallFlags(t *testing.T) {
 		{Name: "cert-dir", Type: "string", Default: ""},
 		{Name: "non-interactive", Type: "bool", Default: "false"},
 		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "registry-auth", Type: "stringArray", Default: "[]"},
+		{Name: "registry-auth-file", Type: "string", Default: ""},
+		{Name: "recovery-policy", Type: "string", Default: ""},
+		{Name: "gitops-engine", Type: "string", Default: "argocd"},
+		{Name: "flux-path", Type: "string", Default: "./manifests/flux"},
+		{Name: "readiness-gates", Type: "string", Default: "openframe-readiness-gates.yaml"},
+		{Name: "skip-verify", Type: "bool", Default: "false"},
+		{Name: "size", Type: "string", Default: "auto"},
+		{Name: "resume", Type: "bool", Default: "false"},
+		{Name: "pause-sync-on-cancel", Type: "bool", Default: "false"},
+		{Name: "skip-repo-update", Type: "bool", Default: "false"},
+		{Name: "skip-repo-check", Type: "bool", Default: "false"},
+		{Name: "only", Type: "stringSlice", Default: "[]"},
+		{Name: "skip", Type: "stringSlice", Default: "[]"},
+		{Name: "status-file", Type: "string", Default: ""},
+		{Name: "webhook-url", Type: "string", Default: ""},
+		{Name: "notify", Type: "stringArray", Default: "[]"},
+		{Name: "notify-template", Type: "string", Default: ""},
+		{Name: "metrics-listen", Type: "string", Default: ""},
 	})
 }
 
@@ -78,3 +97,89 @@ func TestAppContract_UninstallFlags(t *testing.T) {
 		{Name: "delete-namespace", Type: "bool", Default: "false"},
 	})
 }
+
+func TestAppContract_AddRepoCredentialsFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "add-repo-credentials")
+
+	// Writes a secret into the cluster → not marked read-only.
+	assert.NotEqual(t, "true", cmd.Annotations["readonly"], "add-repo-credentials is not read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "username", Type: "string", Default: ""},
+		{Name: "token", Type: "string", Default: ""},
+		{Name: "ssh-key-file", Type: "string", Default: ""},
+		{Name: "insecure", Type: "bool", Default: "false"},
+		{Name: "skip-verify", Type: "bool", Default: "false"},
+	})
+}
+
+func TestAppContract_HistoryFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "history")
+
+	// Reads the local sync history, never touches a cluster.
+	assert.Equal(t, "true", cmd.Annotations["readonly"], "history must be annotated read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "cluster", Type: "string", Default: ""},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestAppContract_TreeFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "tree")
+
+	// Reads the application's resources, never changes them.
+	assert.Equal(t, "true", cmd.Annotations["readonly"], "tree must be annotated read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestAppContract_ValidateFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "validate")
+
+	// Renders locally; at most reads the current cluster's version.
+	assert.Equal(t, "true", cmd.Annotations["readonly"], "validate must be annotated read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "github-repo", Type: "string", Default: "https://github.com/flamingo-stack/openframe-oss-tenant"},
+		{Name: "ref", Shorthand: "r", Type: "string", Default: "main"},
+		{Name: "values", Type: "string", Default: "openframe-helm-values.yaml"},
+		{Name: "kube-version", Type: "string", Default: ""},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestAppContract_DiffFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "diff")
+
+	// --sync patches Applications → not marked read-only.
+	assert.NotEqual(t, "true", cmd.Annotations["readonly"], "diff --sync is not read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "sync", Type: "bool", Default: "false"},
+		{Name: "prune", Type: "bool", Default: "false"},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestAppContract_SyncFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "sync")
+
+	assert.NotEqual(t, "true", cmd.Annotations["readonly"], "sync is not read-only")
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "prune", Type: "bool", Default: "false"},
+		{Name: "force", Type: "bool", Default: "false"},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestAppContract_RefreshFlags(t *testing.T) {
+	cmd := testutil.FindSubcommand(t, GetAppCmd(), "refresh")
+
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "hard", Type: "bool", Default: "false"},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
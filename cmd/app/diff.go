@@ -0,0 +1,151 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getDiffCmd returns the diff subcommand.
+func getDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [APPLICATION...]",
+		Short: "Show how the live applications drifted from git",
+		Long: `Compare the live state of the ArgoCD applications with what the app-of-apps
+renders from git, and list every resource that drifted with the fields that
+differ.
+
+Without names, every application that is not Synced is compared. The
+comparison is ArgoCD's own (its managed-resources API, as in the UI diff), so
+no argocd CLI is needed. --sync then syncs just the drifted resources;
+resources no longer in git are only deleted with --prune.
+
+Examples:
+  openframe app diff
+  openframe app diff openframe-api -o json
+  openframe app diff --sync --context k3d-openframe-dev`,
+		RunE:              runDiffCommand,
+		ValidArgsFunction: completion.Names(-1, applicationNames),
+	}
+	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().Bool("sync", false, "Sync the drifted resources after showing the diff")
+	cmd.Flags().Bool("prune", false, "With --sync, delete resources that are no longer in git")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runDiffCommand(cmd *cobra.Command, args []string) error {
+	verbose := getVerboseFlag(cmd)
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+	sync, _ := cmd.Flags().GetBool("sync")
+	prune, _ := cmd.Flags().GetBool("prune")
+	if prune && !sync {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("--prune only applies with --sync"), verbose)
+	}
+
+	mgr, err := newArgoCDManager(contextFlag(cmd), verbose)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not connect to the cluster: %w", err), verbose)
+	}
+	diffs, err := mgr.DiffApplications(cmd.Context(), args)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not compute the diff: %w", err), verbose)
+	}
+
+	if format != "text" {
+		if diffs == nil {
+			diffs = []argocd.ResourceDiff{}
+		}
+		if err := renderMachine(format, diffs); err != nil {
+			return err
+		}
+	} else {
+		renderDiff(diffs)
+	}
+
+	if !sync || len(diffs) == 0 {
+		return nil
+	}
+	for _, app := range diffApps(diffs) {
+		if err := mgr.SyncResources(cmd.Context(), app, diffs, prune); err != nil {
+			return sharedErrors.HandleGlobalError(err, verbose)
+		}
+		if format == "text" {
+			pterm.Success.Printf("Sync of %s started for its drifted resources\n", app)
+		}
+	}
+	return nil
+}
+
+// diffApps returns the applications in diffs, in order of first appearance.
+func diffApps(diffs []argocd.ResourceDiff) []string {
+	var apps []string
+	seen := map[string]bool{}
+	for _, d := range diffs {
+		if !seen[d.App] {
+			seen[d.App] = true
+			apps = append(apps, d.App)
+		}
+	}
+	return apps
+}
+
+func renderDiff(diffs []argocd.ResourceDiff) {
+	if len(diffs) == 0 {
+		pterm.Success.Println("No drift: the live applications match git")
+		return
+	}
+	app := ""
+	for _, d := range diffs {
+		if d.App != app {
+			app = d.App
+			pterm.DefaultSection.Println(app)
+		}
+		pterm.Printf("%s %s\n", driftMarker(d.State), resourceRef(d))
+		for _, f := range d.Fields {
+			pterm.Printf("    %s\n", pterm.Bold.Sprint(f.Path))
+			pterm.Printf("      %s %s\n", pterm.Red("-"), orAbsent(f.Live))
+			pterm.Printf("      %s %s\n", pterm.Green("+"), orAbsent(f.Desired))
+		}
+	}
+	pterm.Warning.Printf("%d resource(s) in %d application(s) drifted from git\n", len(diffs), len(diffApps(diffs)))
+}
+
+func driftMarker(state string) string {
+	switch state {
+	case argocd.DriftMissing:
+		return pterm.Green("  + missing ")
+	case argocd.DriftExtra:
+		return pterm.Red("  - extra   ")
+	default:
+		return pterm.Yellow("  ~ modified")
+	}
+}
+
+// resourceRef renders a resource as kind.group/namespace/name.
+func resourceRef(d argocd.ResourceDiff) string {
+	kind := d.Kind
+	if d.Group != "" {
+		kind += "." + d.Group
+	}
+	parts := []string{kind}
+	if d.Namespace != "" {
+		parts = append(parts, d.Namespace)
+	}
+	return strings.Join(append(parts, d.Name), "/")
+}
+
+func orAbsent(v string) string {
+	if v == "" {
+		return pterm.Gray("(absent)")
+	}
+	return v
+}
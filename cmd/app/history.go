@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/synchistory"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getHistoryCmd returns the history subcommand: how each application fared
+// in the recorded installs and upgrades.
+func getHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [APP]",
+		Short: "Show which applications are flaky across installs",
+		Long: `Show how each ArgoCD application fared in the last 20 installs and upgrades
+that waited for the applications: in how many runs it needed more than one
+clean sync, the sync operations ArgoCD started for it and how many failed or
+were retried, how often the CLI had to sync or hard-refresh it, and how long
+it took to become Healthy and Synced. The flakiest applications come first.
+
+With APP, every recorded run of that application is listed instead. Nothing
+is read from a cluster; the runs are kept in ~/.openframe/state.`,
+		Example: `  openframe app history
+  openframe app history openframe-api
+  openframe app history --cluster openframe-dev -o json`,
+		Args:         cobra.MaximumNArgs(1),
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE:         runHistoryCommand,
+	}
+	cmd.Flags().String("cluster", "", "Only count the runs on this cluster")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// appRun is one recorded run of a single application.
+type appRun struct {
+	Cluster string    `json:"cluster"`
+	Started time.Time `json:"started"`
+	CI      bool      `json:"ci"`
+	synchistory.App
+}
+
+func runHistoryCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	runs, err := synchistory.Load()
+	if err != nil {
+		return fmt.Errorf("reading the sync history: %w", err)
+	}
+	if cluster, _ := cmd.Flags().GetString("cluster"); cluster != "" {
+		var kept []synchistory.Run
+		for _, r := range runs {
+			if r.Cluster == cluster {
+				kept = append(kept, r)
+			}
+		}
+		runs = kept
+	}
+
+	if len(args) == 1 {
+		appRuns := runsOf(runs, args[0])
+		if format != "text" {
+			return renderMachine(format, appRuns)
+		}
+		if len(appRuns) == 0 {
+			pterm.Info.Printf("No recorded run includes application %s.\n", args[0])
+			return nil
+		}
+		renderAppRuns(appRuns)
+		return nil
+	}
+
+	stats := synchistory.Aggregate(runs)
+	if format != "text" {
+		return renderMachine(format, stats)
+	}
+	if len(stats) == 0 {
+		pterm.Info.Println("No application syncs recorded yet. Run `openframe app install` or `openframe bootstrap` first.")
+		return nil
+	}
+	renderStats(stats, len(runs))
+	return nil
+}
+
+// runsOf returns the runs of the named application, newest first.
+func runsOf(runs []synchistory.Run, name string) []appRun {
+	var out []appRun
+	for i := len(runs) - 1; i >= 0; i-- {
+		for _, a := range runs[i].Apps {
+			if a.Name == name {
+				out = append(out, appRun{Cluster: runs[i].Cluster, Started: runs[i].Started, CI: runs[i].CI, App: a})
+			}
+		}
+	}
+	return out
+}
+
+func renderStats(stats []synchistory.Stats, runs int) {
+	pterm.Info.Printf("%d recorded run(s), flakiest applications first\n", runs)
+	table := pterm.TableData{{"APPLICATION", "TROUBLED", "NOT READY", "ATTEMPTS", "FAILED", "RETRIES", "NUDGES", "MEAN", "SLOWEST"}}
+	for _, s := range stats {
+		table = append(table, []string{
+			s.Name, fmt.Sprintf("%d/%d", s.Troubled, s.Runs), fmt.Sprint(s.NotReady),
+			fmt.Sprint(s.Attempts), fmt.Sprint(s.Failures), fmt.Sprint(s.Retries), fmt.Sprint(s.Nudges),
+			roundDuration(s.MeanDuration), roundDuration(s.Slowest),
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+}
+
+func renderAppRuns(runs []appRun) {
+	table := pterm.TableData{{"STARTED", "CLUSTER", "RESULT", "ATTEMPTS", "FAILED", "RETRIES", "NUDGES", "TOOK"}}
+	for _, r := range runs {
+		result := "ready"
+		if !r.Ready {
+			result = "not ready"
+		}
+		if r.CI {
+			result += " (CI)"
+		}
+		table = append(table, []string{
+			r.Started.Local().Format("2006-01-02 15:04"), r.Cluster, result,
+			fmt.Sprint(r.Attempts), fmt.Sprint(r.Failures), fmt.Sprint(r.Retries), fmt.Sprint(r.Nudges),
+			roundDuration(r.Duration),
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+}
+
+func roundDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
@@ -1,17 +1,32 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	"github.com/flamingo-stack/openframe-cli/internal/app/target"
 	chartmodels "github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/flux"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/gitops"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/readiness"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/services"
+	chartUI "github.com/flamingo-stack/openframe-cli/internal/chart/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster"
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/notify"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
@@ -35,10 +50,15 @@ Examples:
   openframe app install my-cluster                        # Install on specific cluster
   openframe app install --non-interactive                 # Use existing openframe-helm-values.yaml (CI/CD)
   openframe app install --ref develop                     # Deploy a branch
-  openframe app install --ref v1.2.3                      # Deploy a release tag`, argocd.ArgoCDChartVersion),
-		RunE:          runInstallCommand,
-		SilenceErrors: true, // Errors are handled by our custom error handler
-		SilenceUsage:  true, // Don't show usage on errors
+  openframe app install --ref v1.2.3                      # Deploy a release tag
+  openframe app install --registry-auth registry.acme.io=robot:s3cret  # Authenticated mirror
+  openframe app install --gitops-engine flux              # Deploy with Flux instead of ArgoCD
+  openframe app install --only argocd                     # Iterate on one layer of the pipeline
+  openframe app install --skip certificates,verify        # Leave phases out`, argocd.ArgoCDChartVersion),
+		RunE:              runInstallCommand,
+		ValidArgsFunction: completion.ClusterNames(),
+		SilenceErrors:     true, // Errors are handled by our custom error handler
+		SilenceUsage:      true, // Don't show usage on errors
 	}
 
 	// Add flags directly
@@ -56,19 +76,33 @@ func runInstallCommand(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := installstatus.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+		return err
+	}
+	if err := notify.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+		return err
+	}
+	if err := metrics.Start(cmd.Flags()); err != nil {
+		return err
+	}
 
 	// Get verbose flag (with fallback)
 	verbose := getVerboseFlag(cmd)
 
+	if err := addStoredRegistries(cmd.Context(), flags); err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
 	req, err := buildInstallRequest(cmd, args, flags, verbose, "Installing")
 	if err != nil {
 		return sharedErrors.HandleGlobalError(err, verbose)
 	}
 
-	if err := services.InstallChartsWithConfigContext(cmd.Context(), req); err != nil {
+	result, err := services.InstallChartsWithResult(cmd.Context(), req)
+	if err != nil {
 		// Use shared error handler for consistent error display
 		return sharedErrors.HandleGlobalError(err, verbose)
 	}
+	chartUI.NewDisplayService().ShowInstallResult(result)
 	return nil
 }
 
@@ -87,9 +121,21 @@ func buildInstallRequest(cmd *cobra.Command, args []string, flags *InstallFlags,
 		GitHubRepo:   flags.GitHubRepo,
 		GitHubBranch: flags.resolvedRef(),
 		// An explicitly set ref must win over the branch baked into openframe-helm-values.yaml.
-		GitHubRefExplicit: cmd.Flags().Changed("ref"),
-		CertDir:           flags.CertDir,
-		NonInteractive:    flags.NonInteractive,
+		GitHubRefExplicit:  cmd.Flags().Changed("ref"),
+		CertDir:            flags.CertDir,
+		NonInteractive:     flags.NonInteractive,
+		RegistryAuth:       flags.RegistryAuth,
+		RepoServerRecovery: flags.RecoveryPolicy,
+		GitOpsEngine:       flags.GitOpsEngine,
+		FluxPath:           flags.FluxPath,
+		ReadinessGates:     flags.ReadinessGates,
+		SkipVerify:         flags.SkipVerify,
+		Size:               flags.Size,
+		Resume:             flags.Resume,
+		PauseSyncOnCancel:  flags.PauseSyncOnCancel,
+		SkipRepoUpdate:     flags.SkipRepoUpdate,
+		SkipRepoCheck:      flags.SkipRepoCheck,
+		Phases:             flags.Phases,
 		// Inject cluster access from the command layer (composition root) so the
 		// app subsystem stays isolated from cluster-creation code (req 18/19).
 		ClusterAccess: cluster.NewClusterService(executor.NewRealCommandExecutor(false, verbose)),
@@ -97,8 +143,8 @@ func buildInstallRequest(cmd *cobra.Command, args []string, flags *InstallFlags,
 
 	// Explicit --context targets a specific cluster directly (scriptable, skips
 	// interactive selection). Its rest.Config is resolved here at the command layer.
-	if contextName, _ := cmd.Flags().GetString("context"); contextName != "" {
-		cfg, cerr := k8s.RestConfigForContext(k8s.DefaultKubeconfigPath(), contextName)
+	if contextName := contextFlag(cmd); contextName != "" {
+		cfg, cerr := k8s.RestConfigForContext(k8s.KubeconfigForContext(contextName), contextName)
 		if cerr != nil {
 			return req, fmt.Errorf("could not use context %q: %w", contextName, cerr)
 		}
@@ -114,6 +160,9 @@ func buildInstallRequest(cmd *cobra.Command, args []string, flags *InstallFlags,
 	// --non-interactive, --dry-run, or --context all skip this.
 	if req.KubeConfig == nil && !flags.NonInteractive && !flags.DryRun && len(args) == 0 {
 		sel := target.NewSelector(target.UIPrompter{}, recommendedRequirements())
+		sel.Prepare = func(ctx context.Context, contextName string) error {
+			return cluster.ResumeIdleContext(ctx, contextName, false)
+		}
 		res, serr := sel.Select(cmd.Context())
 		if serr != nil {
 			return req, serr
@@ -152,6 +201,25 @@ type InstallFlags struct {
 	Ref            string
 	CertDir        string
 	NonInteractive bool
+	// RegistryAuth merges --registry-auth-file with the --registry-auth flags
+	// (flags win per host); nil when neither was given.
+	RegistryAuth *chartmodels.RegistryAuthConfig
+	// RecoveryPolicy is the --recovery-policy file's policy; nil when unset.
+	RecoveryPolicy *chartmodels.RepoServerRecoveryPolicy
+	GitOpsEngine   string
+	FluxPath       string
+	// ReadinessGates is the gates file to wait for after the applications.
+	ReadinessGates string
+	SkipVerify     bool
+	Size           string
+	// Resume and PauseSyncOnCancel drive the handling of an install
+	// cancelled during the application wait.
+	Resume            bool
+	PauseSyncOnCancel bool
+	SkipRepoUpdate    bool
+	SkipRepoCheck     bool
+	// Phases is the --only/--skip selection; nil runs every phase.
+	Phases *chartmodels.PhaseSelection
 }
 
 // resolvedRef returns the git ref to deploy: --ref when set, otherwise the
@@ -192,9 +260,127 @@ func extractInstallFlags(cmd *cobra.Command) (*InstallFlags, error) {
 		return nil, err
 	}
 
+	if flags.RegistryAuth, err = extractRegistryAuth(cmd); err != nil {
+		return nil, err
+	}
+
+	if file, _ := cmd.Flags().GetString("recovery-policy"); file != "" {
+		if flags.RecoveryPolicy, err = chartmodels.LoadRecoveryPolicyFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	engine, _ := cmd.Flags().GetString("gitops-engine")
+	if flags.GitOpsEngine, err = gitops.ParseEngine(engine); err != nil {
+		return nil, err
+	}
+	if flags.FluxPath, err = cmd.Flags().GetString("flux-path"); err != nil {
+		return nil, err
+	}
+
+	// The default gates file is optional; one named explicitly must exist.
+	if flags.ReadinessGates, err = cmd.Flags().GetString("readiness-gates"); err != nil {
+		return nil, err
+	}
+	if flags.SkipVerify, err = cmd.Flags().GetBool("skip-verify"); err != nil {
+		return nil, err
+	}
+	if cmd.Flags().Changed("readiness-gates") {
+		if _, serr := os.Stat(flags.ReadinessGates); serr != nil {
+			return nil, fmt.Errorf("readiness gates file: %w", serr)
+		}
+	}
+
+	size, _ := cmd.Flags().GetString("size")
+	if flags.Size, err = sizing.ParseSize(size); err != nil {
+		return nil, err
+	}
+	if flags.Resume, err = cmd.Flags().GetBool("resume"); err != nil {
+		return nil, err
+	}
+	if flags.PauseSyncOnCancel, err = cmd.Flags().GetBool("pause-sync-on-cancel"); err != nil {
+		return nil, err
+	}
+	if flags.SkipRepoUpdate, err = cmd.Flags().GetBool("skip-repo-update"); err != nil {
+		return nil, err
+	}
+	if flags.SkipRepoCheck, err = cmd.Flags().GetBool("skip-repo-check"); err != nil {
+		return nil, err
+	}
+	only, _ := cmd.Flags().GetStringSlice("only")
+	skip, _ := cmd.Flags().GetStringSlice("skip")
+	if flags.Phases, err = chartmodels.ParsePhaseSelection(only, skip); err != nil {
+		return nil, err
+	}
+	if flags.Resume && flags.Force {
+		return nil, fmt.Errorf("--resume and --force cannot be combined: --force redoes every step")
+	}
+
 	return flags, nil
 }
 
+// extractRegistryAuth builds the registry credentials from --registry-auth-file
+// and the repeatable --registry-auth host=user:pass flags. Every password is
+// registered for redaction as soon as it is read.
+func extractRegistryAuth(cmd *cobra.Command) (*chartmodels.RegistryAuthConfig, error) {
+	file, err := cmd.Flags().GetString("registry-auth-file")
+	if err != nil {
+		return nil, err
+	}
+	specs, err := cmd.Flags().GetStringArray("registry-auth")
+	if err != nil {
+		return nil, err
+	}
+	if file == "" && len(specs) == 0 {
+		return nil, nil
+	}
+
+	auth := &chartmodels.RegistryAuthConfig{}
+	if file != "" {
+		loaded, lerr := chartmodels.LoadRegistryAuthFile(file)
+		if lerr != nil {
+			return nil, lerr
+		}
+		auth.Merge(loaded)
+	}
+	for _, spec := range specs {
+		cred, perr := chartmodels.ParseRegistryAuth(spec)
+		if perr != nil {
+			return nil, perr
+		}
+		auth.Merge(&chartmodels.RegistryAuthConfig{Registries: []chartmodels.RegistryCredential{cred}})
+	}
+	for _, r := range auth.Registries {
+		redact.RegisterSecret(r.Password)
+	}
+	return auth, nil
+}
+
+// addStoredRegistries lays the registry logins from --registry-auth and
+// --registry-auth-file over the ones saved with `openframe credentials set
+// registry/<host>`, so a flag overrides the keychain for its host.
+func addStoredRegistries(ctx context.Context, flags *InstallFlags) error {
+	logins, err := credentials.Registries(ctx)
+	if err != nil {
+		return fmt.Errorf("reading stored registry credentials: %w", err)
+	}
+	if len(logins) == 0 {
+		return nil
+	}
+	stored := &chartmodels.RegistryAuthConfig{}
+	for host, login := range logins {
+		cred, perr := chartmodels.ParseRegistryAuth(host + "=" + login)
+		if perr != nil {
+			return fmt.Errorf("stored %s: %w", credentials.RegistryName(host), perr)
+		}
+		stored.Registries = append(stored.Registries, cred)
+	}
+	sort.Slice(stored.Registries, func(i, j int) bool { return stored.Registries[i].Host < stored.Registries[j].Host })
+	stored.Merge(flags.RegistryAuth)
+	flags.RegistryAuth = stored
+	return nil
+}
+
 // getVerboseFlag extracts verbose flag with fallback
 func getVerboseFlag(cmd *cobra.Command) bool {
 	// Try root command first
@@ -215,11 +401,32 @@ func getVerboseFlag(cmd *cobra.Command) bool {
 
 // addInstallFlags adds all install flags to the command
 func addInstallFlags(cmd *cobra.Command) {
-	cmd.Flags().BoolP("force", "f", false, "Force installation even if charts already exist")
+	cmd.Flags().BoolP("force", "f", false, "Force installation even if charts already exist (redo steps a previous run completed)")
 	cmd.Flags().Bool("dry-run", false, "Show what would be installed without executing")
 	cmd.Flags().String("github-repo", chartmodels.RepoOSSTenant, "GitHub repository URL")
 	cmd.Flags().StringP("ref", "r", "", "Git ref (branch or release tag, e.g. v1.2.3) to deploy")
 	cmd.Flags().String("cert-dir", "", "Certificate directory (auto-detected if not provided)")
 	cmd.Flags().Bool("non-interactive", false, "Skip all prompts, use existing openframe-helm-values.yaml")
 	cmd.Flags().StringP("context", "c", "", "Kube-context to install into (skips interactive selection)")
+	cmd.Flags().StringArray("registry-auth", nil, "Private registry credentials as host=user:pass, injected as imagePullSecrets (repeatable)")
+	cmd.Flags().String("registry-auth-file", "", "YAML file with private registry credentials (and extra namespaces) to inject")
+	cmd.Flags().String("recovery-policy", "", "YAML file controlling the ArgoCD repo-server recovery: action (restart|patch|off), maxRestarts, interval, memoryLimit, parallelism")
+	cmd.Flags().String("gitops-engine", gitops.EngineArgoCD, "GitOps engine that deploys the platform: "+strings.Join(gitops.Engines, "|"))
+	cmd.Flags().String("flux-path", flux.DefaultPath, "Repository directory the Flux root Kustomization applies (--gitops-engine flux)")
+	cmd.Flags().String("readiness-gates", readiness.DefaultFile, "YAML file of extra readiness gates (Jobs, URLs, resource phases) to wait for after the applications")
+	cmd.Flags().Bool("skip-verify", false, "Skip the post-install smoke test of the ArgoCD API, ingress TLS and gateway health")
+	cmd.Flags().Bool("resume", false, "Resume an install interrupted during the application wait (restores auto-sync paused on cancel)")
+	cmd.Flags().Bool("pause-sync-on-cancel", false, "On Ctrl+C during the application wait, pause app-of-apps auto-sync so nothing keeps rolling out")
+	cmd.Flags().Bool("skip-repo-update", false, "Use the cached Helm repository index without refreshing it (offline installs)")
+	cmd.Flags().Bool("skip-repo-check", false, "Skip checking the applications' repositories are reachable from this machine and the cluster before installing")
+	cmd.Flags().String("size", sizing.Auto, "Scale ArgoCD and platform resources for the host: "+strings.Join(sizing.Sizes, "|")+" (auto detects memory and CPUs, including WSL limits)")
+	cmd.Flags().StringSlice("only", nil, "Run only these install phases (comma-separated): "+strings.Join(chartmodels.InstallPhases, "|"))
+	cmd.Flags().StringSlice("skip", nil, "Skip these install phases (comma-separated): "+strings.Join(chartmodels.InstallPhases, "|"))
+	installstatus.AddFlags(cmd.Flags())
+	notify.AddFlags(cmd.Flags())
+	metrics.AddFlags(cmd.Flags())
+	_ = cmd.RegisterFlagCompletionFunc("size", cobra.FixedCompletions(sizing.Sizes, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("only", cobra.FixedCompletions(chartmodels.InstallPhases, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("skip", cobra.FixedCompletions(chartmodels.InstallPhases, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("gitops-engine", cobra.FixedCompletions(gitops.Engines, cobra.ShellCompDirectiveNoFileComp))
 }
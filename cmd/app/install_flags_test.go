@@ -45,3 +45,41 @@ func TestExtractInstallFlags_NoDeploymentModeFlag(t *testing.T) {
 		t.Fatal("--deployment-mode flag should have been removed")
 	}
 }
+
+func TestExtractInstallFlags_RegistryAuth(t *testing.T) {
+	cmd := getInstallCmd()
+	for _, v := range []string{"ghcr.io=bot:one", "registry.acme.io=robot:two", "ghcr.io=bot:three"} {
+		if err := cmd.Flags().Set("registry-auth", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	flags, err := extractInstallFlags(cmd)
+	if err != nil {
+		t.Fatalf("valid --registry-auth rejected: %v", err)
+	}
+	regs := flags.RegistryAuth.Registries
+	if len(regs) != 2 || regs[0].Host != "ghcr.io" || regs[0].Password != "three" || regs[1].Host != "registry.acme.io" {
+		t.Fatalf("unexpected registries (a repeated host should keep the last value): %+v", regs)
+	}
+}
+
+func TestExtractInstallFlags_RegistryAuthInvalid(t *testing.T) {
+	cmd := getInstallCmd()
+	if err := cmd.Flags().Set("registry-auth", "ghcr.io"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := extractInstallFlags(cmd); err == nil {
+		t.Fatal("expected an error for a --registry-auth value without credentials")
+	}
+}
+
+func TestExtractInstallFlags_NoRegistryAuthByDefault(t *testing.T) {
+	flags, err := extractInstallFlags(getInstallCmd())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags.RegistryAuth != nil {
+		t.Fatalf("RegistryAuth = %+v, want nil without flags", flags.RegistryAuth)
+	}
+}
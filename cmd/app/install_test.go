@@ -91,10 +91,14 @@ func TestInstallCommandFlagHandling(t *testing.T) {
 			name:  "default flags",
 			flags: map[string]string{},
 			expectedArgs: InstallFlags{
-				Force:      false,
-				DryRun:     false,
-				GitHubRepo: "https://github.com/flamingo-stack/openframe-oss-tenant",
-				CertDir:    "",
+				Force:          false,
+				DryRun:         false,
+				GitHubRepo:     "https://github.com/flamingo-stack/openframe-oss-tenant",
+				CertDir:        "",
+				GitOpsEngine:   "argocd",
+				FluxPath:       "./manifests/flux",
+				ReadinessGates: "openframe-readiness-gates.yaml",
+				Size:           "auto",
 			},
 		},
 		{
@@ -105,11 +109,15 @@ func TestInstallCommandFlagHandling(t *testing.T) {
 				"ref":     "develop",
 			},
 			expectedArgs: InstallFlags{
-				Force:      true,
-				DryRun:     true,
-				GitHubRepo: "https://github.com/flamingo-stack/openframe-oss-tenant",
-				Ref:        "develop",
-				CertDir:    "",
+				Force:          true,
+				DryRun:         true,
+				GitHubRepo:     "https://github.com/flamingo-stack/openframe-oss-tenant",
+				Ref:            "develop",
+				CertDir:        "",
+				GitOpsEngine:   "argocd",
+				FluxPath:       "./manifests/flux",
+				ReadinessGates: "openframe-readiness-gates.yaml",
+				Size:           "auto",
 			},
 		},
 	}
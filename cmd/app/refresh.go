@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+// getRefreshCmd returns the refresh subcommand.
+func getRefreshCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refresh [APPLICATION...]",
+		Short: "Make ArgoCD re-compare applications with git",
+		Long: `Ask ArgoCD to re-compare the named applications, or every application when
+none is named, with git right away instead of at its next poll. Nothing is
+deployed: an application that turns OutOfSync still needs 'openframe app sync'
+(or auto-sync).
+
+A normal refresh compares against the manifests ArgoCD already rendered;
+--hard also drops that cache and re-reads git, for a branch whose HEAD moved.
+
+Examples:
+  openframe app refresh
+  openframe app refresh openframe-api --hard`,
+		RunE:              runRefreshCommand,
+		ValidArgsFunction: completion.Names(-1, applicationNames),
+	}
+	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().Bool("hard", false, "Also discard ArgoCD's manifest cache and re-read git")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runRefreshCommand(cmd *cobra.Command, args []string) error {
+	verbose := getVerboseFlag(cmd)
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+	hard, _ := cmd.Flags().GetBool("hard")
+
+	mgr, err := newArgoCDManager(contextFlag(cmd), verbose)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not connect to the cluster: %w", err), verbose)
+	}
+	results, err := mgr.RefreshApplications(cmd.Context(), args, hard)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not refresh: %w", err), verbose)
+	}
+	return sharedErrors.HandleGlobalError(reportTriggered(format, "Refresh requested", results), verbose)
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
+	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// repoTokenEnv is read when --token is not given, so a token never has to
+// appear on the command line (and in shell history / `ps`).
+const repoTokenEnv = "OPENFRAME_GITHUB_TOKEN"
+
+// getAddRepoCredentialsCmd returns the add-repo-credentials subcommand.
+func getAddRepoCredentialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-repo-credentials <repo-url>",
+		Short: "Give ArgoCD access to a private Git repository",
+		Long: `Register credentials for a private Git repository with ArgoCD.
+
+Creates (or updates) an ArgoCD repository secret so applications that
+reference the repository can sync. HTTPS URLs authenticate with a token
+(--token, the ` + repoTokenEnv + ` environment variable, or a token stored
+with 'openframe credentials set git/<host>'); SSH URLs authenticate with a
+private key (--ssh-key-file).
+
+After writing the secret, ArgoCD is asked to connect to the repository and
+the result is reported. Use --skip-verify to only write the secret.
+
+Examples:
+  openframe app add-repo-credentials https://github.com/acme/platform --token ghp_xxx
+  ` + repoTokenEnv + `=ghp_xxx openframe app add-repo-credentials https://github.com/acme/platform
+  openframe app add-repo-credentials git@github.com:acme/platform.git --ssh-key-file ~/.ssh/id_ed25519`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAddRepoCredentialsCommand,
+	}
+	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().String("username", "", "Username for HTTPS auth (defaults to x-access-token)")
+	cmd.Flags().String("token", "", "Token or password for HTTPS auth (defaults to $"+repoTokenEnv+")")
+	cmd.Flags().String("ssh-key-file", "", "Path to the SSH private key for SSH URLs")
+	cmd.Flags().Bool("insecure", false, "Skip TLS / host-key verification for this repository")
+	cmd.Flags().Bool("skip-verify", false, "Write the secret without checking that ArgoCD can connect")
+	return cmd
+}
+
+func runAddRepoCredentialsCommand(cmd *cobra.Command, args []string) error {
+	verbose := getVerboseFlag(cmd)
+	contextName := contextFlag(cmd)
+	skipVerify, _ := cmd.Flags().GetBool("skip-verify")
+
+	creds, err := repoCredentialsFromFlags(cmd, args[0])
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+	if err := creds.Validate(); err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+
+	mgr, err := newArgoCDManager(contextName, verbose)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not connect to the cluster: %w", err), verbose)
+	}
+
+	name, err := mgr.AddRepoCredentials(cmd.Context(), creds)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(
+			fmt.Errorf("could not write the repository secret — is OpenFrame installed? (%w)", err), verbose)
+	}
+	pterm.Success.Printf("Saved credentials for %s (secret %s/%s)\n", creds.URL, argocd.ArgoCDNamespace, name)
+
+	if skipVerify {
+		return nil
+	}
+	state, err := mgr.VerifyRepoConnection(cmd.Context(), creds.URL)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not verify the repository connection: %w", err), verbose)
+	}
+	if !state.Successful() {
+		return sharedErrors.HandleGlobalError(
+			fmt.Errorf("ArgoCD cannot connect to %s: %s", creds.URL, redact.Redact(state.Message)), verbose)
+	}
+	pterm.Success.Printf("ArgoCD connected to %s\n", creds.URL)
+	return nil
+}
+
+// repoCredentialsFromFlags assembles the credentials from the flags, reading
+// the SSH key file and falling back to the token environment variable, then to
+// a token stored for the host with `openframe credentials set`. The token is
+// registered for redaction before any output can echo it; the SSH key is only
+// written into the repository secret.
+func repoCredentialsFromFlags(cmd *cobra.Command, repoURL string) (argocd.RepoCredentials, error) {
+	creds := argocd.RepoCredentials{URL: strings.TrimSpace(repoURL)}
+	creds.Username, _ = cmd.Flags().GetString("username")
+	creds.Token, _ = cmd.Flags().GetString("token")
+	creds.Insecure, _ = cmd.Flags().GetBool("insecure")
+
+	if keyFile, _ := cmd.Flags().GetString("ssh-key-file"); keyFile != "" {
+		key, err := os.ReadFile(keyFile) // #nosec G304 -- user-supplied key path is the point
+		if err != nil {
+			return creds, fmt.Errorf("reading SSH key: %w", err)
+		}
+		creds.SSHPrivateKey = string(key)
+	}
+	if creds.Token == "" && !creds.IsSSH() {
+		creds.Token = os.Getenv(repoTokenEnv)
+	}
+	if creds.Token == "" && !creds.IsSSH() {
+		creds.Token = credentials.GitToken(cmd.Context(), creds.URL)
+	}
+	redact.RegisterSecret(creds.Token)
+	return creds, nil
+}
@@ -2,7 +2,9 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	appstatus "github.com/flamingo-stack/openframe-cli/internal/app/status"
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
@@ -13,27 +15,30 @@ import (
 // getStatusCmd returns the status subcommand.
 func getStatusCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "status",
+		Use:   "status [APPLICATION...]",
 		Short: "Show the OpenFrame platform status (cluster, apps, access)",
 		Long: `Report whether OpenFrame is up and running on a cluster.
 
 Checks the cluster is reachable, lists the ArgoCD applications with their
-sync/health, summarizes overall readiness, and prints how to sign in.
+sync/health, summarizes overall readiness, and prints how to sign in. Name
+one or more applications to report only those.
 
 Examples:
   openframe app status
+  openframe app status openframe-api openframe-ui
   openframe app status --context k3d-openframe-dev`,
-		RunE:        runStatusCommand,
-		Annotations: map[string]string{"readonly": "true"},
+		RunE:              runStatusCommand,
+		ValidArgsFunction: completion.Names(-1, applicationNames),
+		Annotations:       map[string]string{"readonly": "true"},
 	}
 	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
 	addOutputFlag(cmd)
 	return cmd
 }
 
-func runStatusCommand(cmd *cobra.Command, _ []string) error {
+func runStatusCommand(cmd *cobra.Command, args []string) error {
 	verbose := getVerboseFlag(cmd)
-	contextName, _ := cmd.Flags().GetString("context")
+	contextName := contextFlag(cmd)
 	format, err := outputFormat(cmd)
 	if err != nil {
 		return sharedErrors.HandleGlobalError(err, verbose)
@@ -57,6 +62,12 @@ func runStatusCommand(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return sharedErrors.HandleGlobalError(fmt.Errorf("could not read platform status: %w", err), verbose)
 	}
+	if len(args) > 0 {
+		var missing []string
+		if rep, missing = rep.Only(args); len(missing) > 0 {
+			return sharedErrors.HandleGlobalError(fmt.Errorf("no ArgoCD application named %s", strings.Join(missing, ", ")), verbose)
+		}
+	}
 
 	if format != "text" {
 		return renderMachine(format, statusToJSON(rep))
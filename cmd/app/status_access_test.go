@@ -8,8 +8,8 @@ import (
 
 func TestStatusCommand_Wiring(t *testing.T) {
 	cmd := getStatusCmd()
-	if cmd.Use != "status" {
-		t.Fatalf("Use = %q, want status", cmd.Use)
+	if cmd.Name() != "status" {
+		t.Fatalf("Name() = %q, want status", cmd.Name())
 	}
 	if cmd.RunE == nil {
 		t.Fatal("status command has no RunE")
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getSyncCmd returns the sync subcommand.
+func getSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [APPLICATION...]",
+		Short: "Trigger an ArgoCD sync of applications",
+		Long: `Start an ArgoCD sync of the named applications, or of every application when
+none is named — what 'argocd app sync' does, without the argocd CLI.
+
+The sync is only started; follow it with 'openframe app status'. An
+application that is already syncing is skipped. Resources that are no longer
+in git are only deleted with --prune; --force replaces resources whose update
+is rejected (e.g. a changed immutable field) instead of failing.
+
+To roll out a moved git ref in deploy order, use 'openframe app upgrade --sync'.
+
+Examples:
+  openframe app sync
+  openframe app sync openframe-api openframe-ui --prune
+  openframe app sync openframe-kafka --force --context k3d-openframe-dev`,
+		RunE:              runSyncCommand,
+		ValidArgsFunction: completion.Names(-1, applicationNames),
+	}
+	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().Bool("prune", false, "Delete resources that are no longer in git")
+	cmd.Flags().Bool("force", false, "Replace resources whose update is rejected")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runSyncCommand(cmd *cobra.Command, args []string) error {
+	verbose := getVerboseFlag(cmd)
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+	prune, _ := cmd.Flags().GetBool("prune")
+	force, _ := cmd.Flags().GetBool("force")
+
+	mgr, err := newArgoCDManager(contextFlag(cmd), verbose)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not connect to the cluster: %w", err), verbose)
+	}
+	results, err := mgr.SyncApplications(cmd.Context(), args, prune, force)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not sync: %w", err), verbose)
+	}
+	return sharedErrors.HandleGlobalError(reportTriggered(format, "Sync started", results), verbose)
+}
+
+// reportTriggered prints the outcome of a sync or refresh per application and
+// errors when any patch failed.
+func reportTriggered(format, done string, results []argocd.TriggerResult) error {
+	if format != "text" {
+		if err := renderMachine(format, results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		pterm.Info.Println("No ArgoCD applications found")
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+			if format == "text" {
+				pterm.Error.Println(r.Error)
+			}
+		case r.Skipped != "":
+			if format == "text" {
+				pterm.Warning.Printf("%s skipped: %s\n", r.App, r.Skipped)
+			}
+		default:
+			if format == "text" {
+				pterm.Success.Printf("%s: %s\n", done, r.App)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d application(s) failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getTreeCmd returns the tree subcommand.
+func getTreeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree APPLICATION",
+		Short: "Show the resources of an application and their health",
+		Long: `Show every resource of an ArgoCD application as a tree: the resources it
+manages, with their sync status, and below them what those own — ReplicaSets,
+Pods, Jobs — each with its health and, for pods, the ready containers and
+restarts. The unhealthy resource is found by following the tree down.
+
+The tree is ArgoCD's own (its resource-tree API, as the UI draws it); when the
+ArgoCD server cannot be reached, owner references are followed instead.
+
+Examples:
+  openframe app tree openframe-api
+  openframe app tree openframe-api -o json
+  openframe app tree ingress-nginx --context k3d-openframe-dev`,
+		Args:              cobra.ExactArgs(1),
+		Annotations:       map[string]string{"readonly": "true"},
+		RunE:              runTreeCommand,
+		ValidArgsFunction: completion.Names(1, applicationNames),
+	}
+	cmd.Flags().StringP("context", "c", "", "Kube-context to use (defaults to the current context)")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runTreeCommand(cmd *cobra.Command, args []string) error {
+	verbose := getVerboseFlag(cmd)
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+	mgr, err := newArgoCDManager(contextFlag(cmd), verbose)
+	if err != nil {
+		return sharedErrors.HandleGlobalError(fmt.Errorf("could not connect to the cluster: %w", err), verbose)
+	}
+	roots, err := mgr.ResourceTree(cmd.Context(), args[0])
+	if err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
+
+	if format != "text" {
+		if roots == nil {
+			roots = []*argocd.ResourceNode{}
+		}
+		return renderMachine(format, roots)
+	}
+	if len(roots) == 0 {
+		pterm.Info.Printf("Application %s has no resources yet.\n", args[0])
+		return nil
+	}
+	pterm.Println(args[0])
+	for _, line := range argocd.TreeLines(roots) {
+		pterm.Println(line)
+	}
+	return nil
+}
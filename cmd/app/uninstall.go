@@ -37,7 +37,7 @@ Examples:
 
 func runUninstallCommand(cmd *cobra.Command, _ []string) error {
 	verbose := getVerboseFlag(cmd)
-	contextName, _ := cmd.Flags().GetString("context")
+	contextName := contextFlag(cmd)
 	skipConfirm, _ := cmd.Flags().GetBool("yes")
 	deleteNS, _ := cmd.Flags().GetBool("delete-namespace")
 
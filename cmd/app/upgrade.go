@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	"github.com/flamingo-stack/openframe-cli/internal/app/target"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/services"
@@ -12,6 +13,9 @@ import (
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/notify"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/rest"
@@ -44,9 +48,10 @@ Examples:
   openframe app upgrade --ref v1.3.0             # Upgrade to a release tag
   openframe app upgrade --ref main --dry-run     # Preview a ref change
   openframe app upgrade my-cluster --context k3d-my-cluster`,
-		RunE:          runUpgradeCommand,
-		SilenceErrors: true,
-		SilenceUsage:  true,
+		RunE:              runUpgradeCommand,
+		ValidArgsFunction: completion.ClusterNames(),
+		SilenceErrors:     true,
+		SilenceUsage:      true,
 	}
 
 	addInstallFlags(cmd)
@@ -62,6 +67,15 @@ func runUpgradeCommand(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := installstatus.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+		return err
+	}
+	if err := notify.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+		return err
+	}
+	if err := metrics.Start(cmd.Flags()); err != nil {
+		return err
+	}
 	verbose := getVerboseFlag(cmd)
 	sync, _ := cmd.Flags().GetBool("sync")
 	refChanged := cmd.Flags().Changed("ref")
@@ -94,6 +108,9 @@ func runUpgradeChangeRef(cmd *cobra.Command, args []string, flags *InstallFlags,
 	flags.Force = true
 	flags.NonInteractive = true
 
+	if err := addStoredRegistries(cmd.Context(), flags); err != nil {
+		return sharedErrors.HandleGlobalError(err, verbose)
+	}
 	req, err := buildInstallRequest(cmd, args, flags, verbose, "Upgrading")
 	if err != nil {
 		return sharedErrors.HandleGlobalError(err, verbose)
@@ -191,8 +208,8 @@ func previewOutOfSync(ctx context.Context, manager *argocd.Manager, verbose, pru
 func resolveUpgradeTarget(cmd *cobra.Command, args []string, flags *InstallFlags, verbose bool) (*rest.Config, string, error) {
 	path := k8s.DefaultKubeconfigPath()
 
-	if contextName, _ := cmd.Flags().GetString("context"); contextName != "" {
-		cfg, err := k8s.RestConfigForContext(path, contextName)
+	if contextName := contextFlag(cmd); contextName != "" {
+		cfg, err := k8s.RestConfigForContext(k8s.KubeconfigForContext(contextName), contextName)
 		if err != nil {
 			return nil, "", fmt.Errorf("could not use context %q: %w", contextName, err)
 		}
@@ -200,7 +217,8 @@ func resolveUpgradeTarget(cmd *cobra.Command, args []string, flags *InstallFlags
 	}
 
 	if name := clusterNameArg(args); name != "" {
-		cfg, err := k8s.RestConfigForContext(path, k8s.ResolveContextForCluster(path, name))
+		clusterPath := k8s.KubeconfigForCluster(name)
+		cfg, err := k8s.RestConfigForContext(clusterPath, k8s.ResolveContextForCluster(clusterPath, name))
 		if err != nil {
 			return nil, "", fmt.Errorf("could not use cluster %q: %w", name, err)
 		}
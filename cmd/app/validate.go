@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	chartmodels "github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/services"
+	chartconfig "github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/providers/k3d"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/discovery"
+)
+
+// getValidateCmd returns the validate subcommand: render the app-of-apps chart
+// with the install's values and report problems before touching a cluster.
+func getValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Render and validate the app-of-apps chart without installing",
+		Long: `Render the app-of-apps chart with your values file and check it — no cluster
+needed, nothing is installed.
+
+The chart repository is cloned at --ref, then:
+  1. the values file is checked against the chart's values.schema.json (or
+     the CLI's bundled schema): unknown keys and wrong types, by line
+  2. helm lint runs with the values file
+  3. helm template renders what 'app install' would deploy
+  4. every rendered object is checked against the Kubernetes API types
+     (unknown fields, wrong types, apiVersions removed by the cluster's
+     Kubernetes version)
+  5. every ArgoCD Application is checked for a project, a destination and a
+     usable source
+
+The Kubernetes version is --kube-version, else that of the current
+kube-context's cluster when it answers, else the one new clusters run.
+
+Exits non-zero when anything is found.`,
+		Example: `  openframe app validate
+  openframe app validate --ref v1.2.3 --values my-values.yaml
+  openframe app validate --kube-version 1.30
+  openframe app validate -o json`,
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE:         runValidateCommand,
+	}
+	cmd.Flags().String("github-repo", chartmodels.RepoOSSTenant, "GitHub repository URL")
+	cmd.Flags().StringP("ref", "r", chartmodels.DefaultGitBranch, "Git ref (branch or release tag) to render")
+	cmd.Flags().String("values", chartconfig.DefaultHelmValuesFile, "Helm values file to render with")
+	cmd.Flags().String("kube-version", "", "Kubernetes version of the target cluster, e.g. 1.31 (default: the current kube-context's cluster, or the version new clusters run)")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runValidateCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	verbose := getVerboseFlag(cmd)
+
+	cfg := chartmodels.NewAppOfAppsConfig()
+	cfg.GitHubRepo, _ = cmd.Flags().GetString("github-repo")
+	cfg.GitHubBranch, _ = cmd.Flags().GetString("ref")
+	cfg.ValuesFile, _ = cmd.Flags().GetString("values")
+	if _, err := os.Stat(cfg.ValuesFile); err != nil {
+		return fmt.Errorf("values file %s: %w (run 'openframe app install' once to generate it, or pass --values)", cfg.ValuesFile, err)
+	}
+
+	kubeMinor, err := validateKubeMinor(cmd)
+	if err != nil {
+		return err
+	}
+
+	helmManager, err := helm.NewHelmManager(executor.NewRealCommandExecutor(false, verbose), nil, verbose)
+	if err != nil {
+		return err
+	}
+
+	var sp *spinner.Spinner
+	if format == "text" {
+		sp = spinner.Start(fmt.Sprintf("Rendering the app-of-apps chart (ref %s)...", cfg.GitHubBranch))
+	}
+	report, err := services.ValidateAppOfAppsRender(cmd.Context(), helmManager, git.NewRepository(), cfg, kubeMinor)
+	if err != nil {
+		if sp != nil {
+			sp.Fail("Could not render the app-of-apps chart")
+		}
+		return err
+	}
+
+	if format != "text" {
+		if err := renderMachine(format, report); err != nil {
+			return err
+		}
+	} else {
+		if report.OK() {
+			sp.Success(fmt.Sprintf("Chart renders cleanly: %d objects, %d ArgoCD applications.", report.Objects, report.Applications))
+		} else {
+			sp.Fail(fmt.Sprintf("%d problem(s) in %d rendered objects", len(report.Findings), report.Objects))
+			table := pterm.TableData{{"OBJECT", "PROBLEM"}}
+			for _, f := range report.Findings {
+				table = append(table, []string{f.Object, f.Problem})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+		}
+		if verbose && report.Lint != "" {
+			pterm.Info.Println("helm lint output:")
+			fmt.Println(report.Lint)
+		}
+	}
+	if !report.OK() {
+		return fmt.Errorf("app-of-apps validation found %d problem(s)", len(report.Findings))
+	}
+	return nil
+}
+
+// validateKubeMinor is the Kubernetes minor version validate checks for:
+// --kube-version, else the server version of the current kube-context's
+// cluster, else the version new clusters run.
+func validateKubeMinor(cmd *cobra.Command) (int, error) {
+	if v, _ := cmd.Flags().GetString("kube-version"); v != "" {
+		return manifest.KubernetesMinor(v)
+	}
+	if cfg, err := k8s.RestConfigForContext(k8s.DefaultKubeconfigPath(), ""); err == nil {
+		cfg.Timeout = 5 * time.Second
+		if dc, err := discovery.NewDiscoveryClientForConfig(cfg); err == nil {
+			if v, err := dc.ServerVersion(); err == nil {
+				if minor, err := manifest.KubernetesMinor(v.GitVersion); err == nil {
+					return minor, nil
+				}
+			}
+		}
+	}
+	return manifest.KubernetesMinor(k3d.DefaultKubernetesVersion())
+}
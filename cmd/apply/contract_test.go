@@ -0,0 +1,26 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Freezes the `apply` flags: power users script them.
+
+func TestApplyContract_Flags(t *testing.T) {
+	cmd := GetApplyCmd()
+	require.NotNil(t, cmd.RunE)
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "filename", Shorthand: "f", Type: "stringSlice", Default: "[]"},
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "namespace", Shorthand: "n", Type: "string", Default: ""},
+		{Name: "set", Type: "string", Default: ""},
+		{Name: "prune", Type: "bool", Default: "false"},
+		{Name: "delete", Type: "bool", Default: "false"},
+		{Name: "wait", Type: "bool", Default: "false"},
+		{Name: "wait-timeout", Type: "duration", Default: "5m0s"},
+		{Name: "dry-run", Type: "bool", Default: "false"},
+	})
+}
@@ -3,9 +3,14 @@ package bootstrap
 import (
 	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	"github.com/flamingo-stack/openframe-cli/internal/bootstrap"
 	clustermodels "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/installmanifest"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/notify"
 	"github.com/spf13/cobra"
 )
 
@@ -23,9 +28,18 @@ This command performs a complete OpenFrame setup by running:
 This is equivalent to running both commands sequentially but provides
 a streamlined experience for getting started with OpenFrame.
 
+A successful bootstrap writes openframe-manifest.yaml (--manifest picks
+another path): the cluster profile, the charts' ref and the commit installed,
+and the images the cluster runs, with the helm values the install used in
+openframe-manifest.values.yaml. Check both in; --from-manifest replays them
+non-interactively, installing that commit with those values, and refuses to
+start when the values changed.
+
 Examples:
   openframe bootstrap                                    # Interactive mode (default)
   openframe bootstrap my-cluster                        # Bootstrap with custom cluster name
+  openframe bootstrap --env demo                        # Bring up a named environment (see 'openframe environment')
+  openframe bootstrap --from-manifest openframe-manifest.yaml  # Replay the install a teammate recorded
   openframe bootstrap --non-interactive                 # Use existing openframe-helm-values.yaml (CI/CD)
   openframe bootstrap --verbose                         # Show detailed logs including ArgoCD sync progress`,
 		Args: cobra.MaximumNArgs(1),
@@ -38,12 +52,30 @@ Examples:
 					return sharedErrors.HandleGlobalError(err, verbose)
 				}
 			}
+			if err := installstatus.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+				return err
+			}
+			if err := notify.Start(cmd.Flags(), cmd.CommandPath()); err != nil {
+				return err
+			}
+			if err := metrics.Start(cmd.Flags()); err != nil {
+				return err
+			}
 			// Logo will be shown by cluster wrapper before prerequisites
 			return bootstrap.NewService().Execute(cmd, args)
 		},
 	}
 
 	cmd.Flags().Bool("non-interactive", false, "Skip all prompts, use existing openframe-helm-values.yaml")
+	cmd.Flags().String("env", "", "Bring up a named environment: its cluster, profile and values overlays")
+	_ = cmd.RegisterFlagCompletionFunc("env", completion.EnvironmentFlag())
+	cmd.Flags().String("manifest", installmanifest.DefaultFile, "Write the install manifest here after a successful bootstrap (empty to skip)")
+	cmd.Flags().String("from-manifest", "", "Replay an install manifest: its cluster, chart commit, size and values")
+	_ = cmd.MarkFlagFilename("manifest", "yaml", "yml")
+	_ = cmd.MarkFlagFilename("from-manifest", "yaml", "yml")
+	installstatus.AddFlags(cmd.Flags())
+	notify.AddFlags(cmd.Flags())
+	metrics.AddFlags(cmd.Flags())
 	// --verbose/-v is the root persistent flag; read here via cmd.Flags().GetBool.
 
 	return cmd
@@ -17,6 +17,14 @@ func TestBootstrapContract_Flags(t *testing.T) {
 	assert.Equal(t, "bootstrap", cmd.Name())
 	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
 		{Name: "non-interactive", Type: "bool", Default: "false"},
+		{Name: "env", Type: "string", Default: ""},
+		{Name: "manifest", Type: "string", Default: "openframe-manifest.yaml"},
+		{Name: "from-manifest", Type: "string", Default: ""},
+		{Name: "status-file", Type: "string", Default: ""},
+		{Name: "webhook-url", Type: "string", Default: ""},
+		{Name: "notify", Type: "stringArray", Default: "[]"},
+		{Name: "notify-template", Type: "string", Default: ""},
+		{Name: "metrics-listen", Type: "string", Default: ""},
 		// verbose/-v is now inherited from the root persistent flag, not local.
 	})
 	assert.Nil(t, cmd.Flags().Lookup("deployment-mode"), "--deployment-mode must be removed")
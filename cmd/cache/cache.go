@@ -0,0 +1,93 @@
+// Package cache implements `openframe cache`: housekeeping for the node image
+// caches that `cluster create --image-cache` keeps across recreations.
+package cache
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetCacheCmd returns the `openframe cache` command.
+func GetCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the node image caches kept across cluster recreations",
+		Long: `Manage the image caches created by 'cluster create --image-cache'.
+
+With --image-cache each node keeps its container images in a Docker volume
+(or a host directory) that outlives the cluster, so a recreated cluster of the
+same name starts with its images instead of downloading gigabytes again.`,
+	}
+	cmd.AddCommand(getPruneCmd())
+	return cmd
+}
+
+func getPruneCmd() *cobra.Command {
+	var all, force bool
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove image cache volumes of clusters that no longer exist",
+		Long: `Remove the image cache volumes of deleted clusters, freeing their disk space.
+
+The caches of existing clusters are kept; with --all they are removed too,
+except those still mounted by a node (delete the cluster first). Host directory
+caches (--image-cache <dir>) are not tracked: remove the directory yourself.`,
+		Example: `  openframe cache prune
+  openframe cache prune --all --force`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			service := cluster.NewClusterServiceSuppressed(executor.NewRealCommandExecutor(false, verbose))
+
+			caches, err := service.ListImageCaches(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list image caches: %w", err)
+			}
+			var prune []models.ImageCache
+			for _, c := range caches {
+				if all || !c.Live {
+					prune = append(prune, c)
+				}
+			}
+			if len(prune) == 0 {
+				pterm.Success.Println("Nothing to prune: no image caches of deleted clusters found")
+				return nil
+			}
+
+			pterm.Info.Printf("Found %d image cache volume(s):\n", len(prune))
+			for _, c := range prune {
+				state := "deleted"
+				if c.Live {
+					state = "exists"
+				}
+				pterm.Printf("  %s %s\n", c.Volume, pterm.Gray(fmt.Sprintf("(cluster %s, %s)", c.Cluster, state)))
+			}
+			if !force {
+				ok, err := ui.RequireConfirmation(fmt.Sprintf("Remove these %d image cache(s)?", len(prune)), "--force", false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					pterm.Info.Println("Prune cancelled.")
+					return nil
+				}
+			}
+
+			removed, err := service.RemoveImageCaches(cmd.Context(), prune)
+			if removed > 0 {
+				pterm.Success.Printf("Removed %d image cache(s)\n", removed)
+			}
+			return err
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Also remove the caches of existing clusters that are not mounted")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip the confirmation prompt")
+	return cmd
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheContract(t *testing.T) {
+	cmd := GetCacheCmd()
+	testutil.AssertSubcommands(t, cmd, "prune")
+
+	prune := testutil.FindSubcommand(t, cmd, "prune")
+	require.NotNil(t, prune.RunE)
+	assert.NotEqual(t, "true", prune.Annotations["readonly"], "prune is not read-only")
+	testutil.AssertFlags(t, prune, []testutil.FlagSpec{
+		{Name: "all", Type: "bool", Default: "false"},
+		{Name: "force", Shorthand: "f", Type: "bool", Default: "false"},
+	})
+}
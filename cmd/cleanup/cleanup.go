@@ -0,0 +1,124 @@
+// Package cleanup implements `openframe cleanup`: reclaiming the disk that
+// container images take up in the cluster's nodes and on the Docker host.
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/diskguard"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetCleanupCmd returns the `openframe cleanup` command.
+func GetCleanupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Reclaim disk space used by container images",
+		Long: `Reclaim disk space used by container images.
+
+A full install pulls several GB of images into the cluster's nodes, on the
+same disk as Docker — on Windows and macOS a virtual disk that fills up long
+before the host's does. When it runs low the kubelet evicts pods with
+DiskPressure. Installs prune unused images on their own when that happens;
+this command does it on demand.`,
+	}
+	cmd.AddCommand(getImagesCmd())
+	return cmd
+}
+
+func getImagesCmd() *cobra.Command {
+	var all, force bool
+	cmd := &cobra.Command{
+		Use:   "images [CLUSTER]",
+		Short: "Remove container images nothing uses from the nodes and the Docker host",
+		Long: `Remove container images that no container uses.
+
+In every node of the cluster (or of every k3d cluster when none is named) the
+images no pod runs are removed with 'crictl rmi --prune'; the cluster pulls
+them again if it needs them. On the Docker host dangling images are removed;
+with --all every image no container uses is, which may include images you
+pulled yourself, so it asks first.`,
+		Example: `  openframe cleanup images
+  openframe cleanup images openframe-dev
+  openframe cleanup images --all --force`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			ex := executor.NewRealCommandExecutor(false, verbose)
+			ctx := cmd.Context()
+
+			names := args
+			if len(names) == 0 {
+				clusters, err := cluster.NewClusterServiceSuppressed(ex).ListClusters()
+				if err != nil {
+					return fmt.Errorf("failed to list clusters: %w", err)
+				}
+				for _, c := range clusters {
+					if c.Type == models.ClusterTypeK3d {
+						names = append(names, c.Name)
+					}
+				}
+			}
+
+			if all && !force {
+				ok, err := ui.RequireConfirmation("Remove every image on the Docker host that no container uses?", "--force", false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					pterm.Info.Println("Cleanup cancelled.")
+					return nil
+				}
+			}
+
+			var failed error
+			for _, name := range names {
+				nodes, err := diskguard.Nodes(ctx, ex, name)
+				if err != nil {
+					failed = err
+					pterm.Warning.Printfln("%s: %v", name, err)
+					continue
+				}
+				if len(nodes) == 0 {
+					pterm.Info.Printfln("%s: no running nodes", name)
+					continue
+				}
+				before, errBefore := diskguard.Measure(ctx, ex, nodes)
+				if err := diskguard.PruneNodes(ctx, ex, nodes, nil); err != nil {
+					failed = err
+					pterm.Warning.Printfln("%s: %v", name, err)
+					continue
+				}
+				after, err := diskguard.Measure(ctx, ex, nodes)
+				switch {
+				case errBefore != nil || err != nil:
+					pterm.Success.Printfln("%s: removed unused images from %d node(s)", name, len(nodes))
+				case after.Free > before.Free:
+					pterm.Success.Printfln("%s: freed %s; %s", name, diskguard.Size(after.Free-before.Free), after)
+				default:
+					pterm.Success.Printfln("%s: no unused images; %s", name, after)
+				}
+			}
+
+			reclaimed, err := diskguard.PruneHost(ctx, ex, all)
+			if err != nil {
+				return err
+			}
+			if reclaimed != "" {
+				pterm.Success.Printfln("Docker host: reclaimed %s", reclaimed)
+			}
+			return failed
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "On the Docker host, remove every image no container uses, not only dangling ones")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip the confirmation prompt")
+	return cmd
+}
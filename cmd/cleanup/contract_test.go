@@ -0,0 +1,22 @@
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupContract(t *testing.T) {
+	cmd := GetCleanupCmd()
+	testutil.AssertSubcommands(t, cmd, "images")
+
+	images := testutil.FindSubcommand(t, cmd, "images")
+	require.NotNil(t, images.RunE)
+	assert.NotEqual(t, "true", images.Annotations["readonly"], "images is not read-only")
+	testutil.AssertFlags(t, images, []testutil.FlagSpec{
+		{Name: "all", Type: "bool", Default: "false"},
+		{Name: "force", Shorthand: "f", Type: "bool", Default: "false"},
+	})
+}
@@ -0,0 +1,105 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getAttachCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	attachCmd := &cobra.Command{
+		Use:   "attach NAME --context CONTEXT",
+		Short: "Register an existing cluster by kube-context",
+		Long: `Attach an existing cluster (a shared dev cluster, a cloud cluster) under a
+name, so OpenFrame can deploy to it without creating it.
+
+The cluster is listed with type "external". 'openframe app install NAME' and
+the other app commands reach it through its kube-context, while create,
+delete and cleanup refuse it: OpenFrame never manages its lifecycle.
+Detaching only forgets the name.
+
+Examples:
+  openframe cluster attach shared --context gke_acme_europe-west1_dev
+  openframe cluster attach staging --context staging --kubeconfig ~/staging.yaml
+  openframe app install shared
+  openframe cluster detach shared`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: runAttachCluster,
+	}
+
+	attachCmd.Flags().StringP("context", "c", "", "Kube-context that reaches the cluster (required)")
+	attachCmd.Flags().String("kubeconfig", "", "Kubeconfig file defining the context (default: your kubeconfig)")
+	_ = attachCmd.MarkFlagRequired("context")
+	_ = attachCmd.RegisterFlagCompletionFunc("context", completion.KubeContexts())
+
+	return attachCmd
+}
+
+func getDetachCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	return &cobra.Command{
+		Use:   "detach NAME",
+		Short: "Forget an attached external cluster",
+		Long: `Forget a cluster registered with 'openframe cluster attach'. The cluster and
+its kubeconfig are left untouched.
+
+Examples:
+  openframe cluster detach shared`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: runDetachCluster,
+	}
+}
+
+func runAttachCluster(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if err := models.ValidateClusterName(name); err != nil {
+		return err
+	}
+	contextName, _ := cmd.Flags().GetString("context")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	// A managed cluster of the same name would make every lookup ambiguous.
+	if info, err := utils.GetCommandService().GetClusterStatus(name); err == nil && info.Type != models.ClusterTypeExternal {
+		return fmt.Errorf("a %s cluster named %q already exists; attach under another name", info.Type, name)
+	}
+
+	if err := k8s.AttachExternalCluster(k8s.ExternalCluster{Name: name, Context: contextName, Kubeconfig: kubeconfig}); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Attached external cluster %s (context %s)\n", pterm.Cyan(name), contextName)
+	pterm.Info.Printf("Deploy to it with: openframe app install %s\n", name)
+	return nil
+}
+
+func runDetachCluster(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	found, err := k8s.DetachExternalCluster(name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%q is not an attached external cluster", name)
+	}
+	pterm.Success.Printf("Detached external cluster %s\n", pterm.Cyan(name))
+	return nil
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"fmt"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
@@ -28,8 +29,9 @@ Examples:
   openframe cluster cleanup
   openframe cluster cleanup my-cluster
   openframe cluster cleanup my-cluster --force`,
-		Args:    cobra.MaximumNArgs(1),
-		Aliases: []string{"c"},
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		Aliases:           []string{"c"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
 			if err := utils.ValidateGlobalFlags(); err != nil {
@@ -1,9 +1,13 @@
 package cluster
 
 import (
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ci"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/spf13/cobra"
 )
@@ -24,30 +28,52 @@ This command group provides cluster lifecycle management functionality:
   • delete - Remove a cluster and clean up resources  
   • list - Show all managed clusters
   • status - Display detailed cluster information
+  • rename - Give a cluster a new name, keeping its data
   • cleanup - Remove unused images and resources
+  • idle-watch - Pause a cluster after a period without activity
+  • prune - Remove leftovers of clusters that no longer exist
+  • attach/detach - Register an existing cluster by kube-context
 
-Supports K3d clusters for local development.
+Supports K3d clusters for local development, and existing clusters attached
+as "external".
 
 Examples:
   openframe cluster create
   openframe cluster delete`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// This command group defines its own PersistentPreRunE, which shadows
-			// the root's, so honor --silent here too.
+			// the root's, so honor --silent, --ci and --read-only here too.
 			if s, _ := cmd.Flags().GetBool("silent"); s {
 				ui.SetSilent()
 			}
+			ci.Apply()
+			if err := readonly.Check(cmd); err != nil {
+				return err
+			}
+			if err := sharedconfig.ApplyCABundle(); err != nil {
+				return err
+			}
 			// Machine output (json/yaml) is machine mode: no logo, no prerequisite
 			// gate, so stdout stays clean for scripts.
 			if out, _ := cmd.Flags().GetString("output"); out == "json" || out == "yaml" {
-				return nil
+				return resumeIdleForStatus(cmd, args, true)
 			}
 			// Show logo for subcommands, but not for the root cluster command
 			if cmd.Use != "cluster" {
 				ui.ShowLogoWithContext(cmd.Context())
 			}
-			return prerequisites.CheckPrerequisites()
+			// attach/detach only edit a local registry; an external cluster
+			// needs neither Docker nor k3d.
+			if !needsLocalTooling(cmd) {
+				return nil
+			}
+			if err := prerequisites.CheckPrerequisites(); err != nil {
+				return err
+			}
+			return resumeIdleForStatus(cmd, args, false)
 		},
+		// On its own the command only prints help, which read-only mode allows.
+		Annotations: map[string]string{"readonly": "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Show logo when no subcommand is provided
 			ui.ShowLogoWithContext(cmd.Context())
@@ -61,7 +87,12 @@ Examples:
 		getDeleteCmd(),
 		getListCmd(),
 		getStatusCmd(),
+		getRenameCmd(),
 		getCleanupCmd(),
+		getIdleWatchCmd(),
+		getAttachCmd(),
+		getDetachCmd(),
+		getPruneCmd(),
 	)
 
 	// Add global flags
@@ -69,3 +100,19 @@ Examples:
 
 	return clusterCmd
 }
+
+// needsLocalTooling reports whether cmd drives k3d and so needs the
+// prerequisite check.
+func needsLocalTooling(cmd *cobra.Command) bool {
+	return cmd.Name() != "attach" && cmd.Name() != "detach"
+}
+
+// resumeIdleForStatus starts the cluster `status` names if idle-watch paused
+// it; status is the only cluster subcommand that needs the cluster running.
+// Without a name the cluster is picked later, and is resumed once picked.
+func resumeIdleForStatus(cmd *cobra.Command, args []string, quiet bool) error {
+	if cmd.Name() != "status" || len(args) == 0 {
+		return nil
+	}
+	return cluster.ResumeIdleCluster(cmd.Context(), args[0], quiet)
+}
@@ -18,7 +18,7 @@ func TestClusterContract_RootShape(t *testing.T) {
 	assert.Equal(t, "cluster", cluster.Name())
 	assert.ElementsMatch(t, []string{"k"}, cluster.Aliases, "k alias is part of the contract")
 
-	testutil.AssertSubcommands(t, cluster, "create", "list", "delete", "status", "cleanup")
+	testutil.AssertSubcommands(t, cluster, "create", "list", "delete", "status", "rename", "cleanup", "idle-watch", "attach", "detach", "prune")
 }
 
 func TestClusterContract_Flags(t *testing.T) {
@@ -32,21 +32,53 @@ func TestClusterContract_Flags(t *testing.T) {
 		{Name: "nodes", Shorthand: "n", Type: "int", Default: "3"},
 		{Name: "version", Type: "string", Default: ""},
 		{Name: "skip-wizard", Type: "bool", Default: "false"},
+		{Name: "registry-mirror", Type: "stringArray", Default: "[]"},
+		{Name: "node-label", Type: "stringArray", Default: "[]"},
+		{Name: "label", Type: "stringArray", Default: "[]"},
+		{Name: "node-taint", Type: "stringArray", Default: "[]"},
+		{Name: "ingress", Type: "string", Default: "none"},
+		{Name: "k3s-arg", Type: "stringArray", Default: "[]"},
+		{Name: "gpus", Type: "string", Default: ""},
+		{Name: "persist-sysctl", Type: "bool", Default: "false"},
+		{Name: "wait-for", Type: "string", Default: "all"},
+		{Name: "image-cache", Type: "string", Default: ""},
+		{Name: "addons", Type: "stringSlice", Default: "[]"},
+		{Name: "no-prepull", Type: "bool", Default: "false"},
+		{Name: "adopt", Type: "bool", Default: "false"},
+		{Name: "recreate", Type: "bool", Default: "false"},
 	})
 
 	list := testutil.FindSubcommand(t, cluster, "list")
-	testutil.AssertFlag(t, list, testutil.FlagSpec{Name: "output", Shorthand: "o", Type: "string", Default: "text"})
+	testutil.AssertFlags(t, list, []testutil.FlagSpec{
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+		{Name: "selector", Shorthand: "l", Type: "string", Default: ""},
+	})
 
 	del := testutil.FindSubcommand(t, cluster, "delete")
 	testutil.AssertFlag(t, del, testutil.FlagSpec{Name: "force", Shorthand: "f", Type: "bool", Default: "false"})
 
+	rename := testutil.FindSubcommand(t, cluster, "rename")
+	testutil.AssertFlag(t, rename, testutil.FlagSpec{Name: "force", Shorthand: "f", Type: "bool", Default: "false"})
+
 	status := testutil.FindSubcommand(t, cluster, "status")
 	testutil.AssertFlags(t, status, []testutil.FlagSpec{
 		{Name: "detailed", Shorthand: "d", Type: "bool", Default: "false"},
 		{Name: "no-apps", Type: "bool", Default: "false"},
 	})
 
+	idleWatch := testutil.FindSubcommand(t, cluster, "idle-watch")
+	testutil.AssertFlag(t, idleWatch, testutil.FlagSpec{Name: "after", Type: "duration", Default: "30m0s"})
+
+	attach := testutil.FindSubcommand(t, cluster, "attach")
+	testutil.AssertFlags(t, attach, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "kubeconfig", Type: "string", Default: ""},
+	})
+
 	cleanup := testutil.FindSubcommand(t, cluster, "cleanup")
 	assert.ElementsMatch(t, []string{"c"}, cleanup.Aliases, "cleanup keeps the c alias")
 	testutil.AssertFlag(t, cleanup, testutil.FlagSpec{Name: "force", Shorthand: "f", Type: "bool", Default: "false"})
+
+	prune := testutil.FindSubcommand(t, cluster, "prune")
+	testutil.AssertFlag(t, prune, testutil.FlagSpec{Name: "force", Shorthand: "f", Type: "bool", Default: "false"})
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
@@ -24,15 +26,24 @@ By default, shows a selection menu where you can choose:
 2. Interactive configuration wizard - step-by-step cluster customization
 
 Creates a local cluster for OpenFrame development. If a cluster with the same
-name already exists it is left untouched and reused — delete it first to start
-from scratch. Use the bootstrap command to install OpenFrame components after
-creation.
+name already exists create stops and leaves it untouched: --adopt reuses it once
+its API answers with a Ready node (and records it as adopted), --recreate
+deletes it and creates it again. Use the bootstrap command to install OpenFrame
+components after creation.
 
 Examples:
   openframe cluster create                    # Show creation mode selection
   openframe cluster create my-cluster        # Show selection with custom name
   openframe cluster create --skip-wizard     # Direct creation with defaults
-  openframe cluster create --nodes 3 --type k3d --skip-wizard`,
+  openframe cluster create --nodes 3 --type k3d --skip-wizard
+  openframe cluster create --skip-wizard --registry-mirror docker.io=https://mirror.example.com
+  openframe cluster create --skip-wizard --node-label workload=db@agent:0 --node-taint dedicated=db:NoSchedule@agent:0
+  openframe cluster create --skip-wizard --gpus all        # NVIDIA GPU passthrough
+  openframe cluster create --skip-wizard --ingress nginx   # Start with ingress-nginx
+  openframe cluster create --skip-wizard --addons minio,mailhog   # Add local S3 and SMTP emulators
+  openframe cluster create my-cluster --skip-wizard --adopt      # Reuse my-cluster if it already exists
+  openframe cluster create my-cluster --skip-wizard --recreate   # Start my-cluster from scratch
+  openframe cluster create --skip-wizard --k3s-arg=--disable=servicelb --k3s-arg=--kube-proxy-arg=proxy-mode=ipvs`,
 		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
@@ -41,7 +52,10 @@ Examples:
 			}
 			globalFlags := utils.GetGlobalFlags()
 			if globalFlags != nil && globalFlags.Create != nil {
-				return models.ValidateCreateFlags(globalFlags.Create)
+				if err := models.ValidateCreateFlags(globalFlags.Create); err != nil {
+					return err
+				}
+				return models.CheckKubernetesVersion(globalFlags.Create.K8sVersion, argocd.MinKubernetesVersion())
 			}
 			return nil
 		},
@@ -58,7 +72,7 @@ Examples:
 }
 
 func runCreateCluster(cmd *cobra.Command, args []string) error {
-	service := utils.GetCommandService()
+	service := utils.GetCommandService().WithPrePullCharts(argocd.PrePullChart())
 	globalFlags := utils.GetGlobalFlags()
 
 	var config models.ClusterConfig
@@ -119,6 +133,60 @@ func runCreateCluster(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Mirrors, labels, taints, k3s args, ingress, GPUs and add-ons come from flags in
+	// both modes; the wizard does not ask for them.
+	mirrors, err := models.ParseRegistryMirrors(globalFlags.Create.RegistryMirrors)
+	if err != nil {
+		return err
+	}
+	config.RegistryMirrors = mirrors
+	if config.NodeLabels, err = models.ParseNodeLabels(globalFlags.Create.NodeLabels); err != nil {
+		return err
+	}
+	if config.NodeTaints, err = models.ParseNodeTaints(globalFlags.Create.NodeTaints); err != nil {
+		return err
+	}
+	if config.Labels, err = models.ParseClusterLabels(globalFlags.Create.Labels); err != nil {
+		return err
+	}
+	configured, err := models.ConfiguredK3sArgs()
+	if err != nil {
+		return err
+	}
+	flagArgs, err := models.ParseK3sArgs(globalFlags.Create.K3sArgs)
+	if err != nil {
+		return err
+	}
+	config.K3sArgs = append(configured, flagArgs...)
+	if config.Ingress, err = models.ParseIngress(globalFlags.Create.Ingress); err != nil {
+		return err
+	}
+	if config.Existing, err = models.ParseExisting(globalFlags.Create.Adopt, globalFlags.Create.Recreate); err != nil {
+		return err
+	}
+	config.GPUs = globalFlags.Create.GPUs
+	config.PersistSysctl = globalFlags.Create.PersistSysctl
+	config.SkipImagePrePull = globalFlags.Create.NoPrePull
+	if config.ImageCache, err = models.ParseImageCache(globalFlags.Create.ImageCache); err != nil {
+		return err
+	}
+	if config.WaitFor, err = models.ParseWaitFor(globalFlags.Create.WaitFor); err != nil {
+		return err
+	}
+	addons, err := addon.Resolve(cmd.Context(), globalFlags.Create.Addons, "")
+	if err != nil {
+		return err
+	}
+	if err := addon.ConfirmHooks(addons, "'openframe addon install --force' first"); err != nil {
+		return err
+	}
+	if err := addon.Save(addons); err != nil {
+		return err
+	}
+	for _, a := range addons {
+		config.Addons = append(config.Addons, a.Name)
+	}
+
 	// Show configuration summary for dry-run or skip-wizard modes
 	if globalFlags.Create.DryRun || globalFlags.Create.SkipWizard || globalFlags.Global.Verbose {
 		operationsUI := ui.NewOperationsUI()
@@ -132,6 +200,6 @@ func runCreateCluster(cmd *cobra.Command, args []string) error {
 
 	// Execute cluster creation through service layer
 	// We ignore the returned rest.Config as it's not needed for standalone cluster creation
-	_, err := service.CreateCluster(cmd.Context(), config)
+	_, err = service.CreateCluster(cmd.Context(), config)
 	return err
 }
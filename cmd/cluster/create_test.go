@@ -23,17 +23,18 @@ func TestCreateCommand(t *testing.T) {
 	testutil.TestClusterCommand(t, "create", getCreateCmd, setupFunc, teardownFunc)
 }
 
-// TestCreateHelp_DoesNotPromiseRecreation (M2.5): the help text used to say
-// existing clusters "will be recreated". CreateCluster does the opposite — it
-// warns, reuses the existing cluster, and returns its rest.Config. A user who
-// trusted the help would think a stale cluster had been rebuilt.
-func TestCreateHelp_DoesNotPromiseRecreation(t *testing.T) {
+// TestCreateHelp_DescribesExistingCluster (M2.5): the help text once said
+// existing clusters "will be recreated" when create reused them. It must say
+// what create does with a taken name: stop, unless --adopt or --recreate.
+func TestCreateHelp_DescribesExistingCluster(t *testing.T) {
 	long := getCreateCmd().Long
 
-	if strings.Contains(long, "recreated") {
-		t.Errorf("create --help must not promise recreation; CreateCluster reuses an existing cluster:\n%s", long)
+	if !strings.Contains(long, "stops and leaves it untouched") {
+		t.Errorf("create --help must state that an existing cluster stops create by default:\n%s", long)
 	}
-	if !strings.Contains(long, "reused") {
-		t.Errorf("create --help must state that an existing cluster is reused:\n%s", long)
+	for _, flag := range []string{"--adopt", "--recreate"} {
+		if !strings.Contains(long, flag) {
+			t.Errorf("create --help must describe %s:\n%s", flag, long)
+		}
 	}
 }
@@ -3,6 +3,7 @@ package cluster
 import (
 	"fmt"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
@@ -26,7 +27,8 @@ Examples:
   openframe cluster delete my-cluster
   openframe cluster delete my-cluster --force
   openframe cluster delete  # interactive selection`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
 			if err := utils.ValidateGlobalFlags(); err != nil {
@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/spf13/cobra"
+)
+
+// defaultIdleAfter is how long a cluster may sit without host traffic before
+// idle-watch pauses it.
+const defaultIdleAfter = 30 * time.Minute
+
+func getIdleWatchCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	idleCmd := &cobra.Command{
+		Use:   "idle-watch [NAME]",
+		Short: "Pause a cluster when it goes idle",
+		Long: `Watch a cluster and stop it after a period without activity, saving
+battery and memory on laptops.
+
+Activity is any kubectl/API or ingress request from this machine. Once the
+cluster has been idle for --after, it is stopped with 'k3d cluster stop' and
+the watcher exits. The next 'openframe app ...', 'openframe bootstrap' or
+'openframe cluster status' starts it again automatically.
+
+The watcher runs in the foreground; background it with your shell.
+
+Examples:
+  openframe cluster idle-watch my-cluster
+  openframe cluster idle-watch my-cluster --after 1h
+  nohup openframe cluster idle-watch my-cluster >/dev/null 2>&1 &`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runIdleWatch),
+	}
+
+	idleCmd.Flags().Duration("after", defaultIdleAfter, "Pause the cluster after this long without activity")
+
+	return idleCmd
+}
+
+func runIdleWatch(cmd *cobra.Command, args []string) error {
+	after, _ := cmd.Flags().GetDuration("after")
+	if after <= 0 {
+		return fmt.Errorf("--after must be positive, got %s", after)
+	}
+
+	service := utils.GetCommandService()
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := ui.NewOperationsUI().SelectClusterForOperation(clusters, args, "watch for idleness")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	return service.WatchIdle(cmd.Context(), clusterName, after)
+}
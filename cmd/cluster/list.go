@@ -3,9 +3,13 @@ package cluster
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
 )
@@ -22,8 +26,18 @@ func getListCmd() *cobra.Command {
 Displays cluster information including name, type, status, and node count
 from all registered providers in a formatted table.
 
+With --selector (-l), only clusters whose labels match are shown, using
+kubectl's label selector syntax; labels are given at create time with
+'openframe cluster create --label key=value'.
+
+With --env, each cluster is shown with the named environment running on it,
+including environments whose cluster does not exist yet (see
+'openframe environment').
+
 Examples:
   openframe cluster list
+  openframe cluster list --env
+  openframe cluster list --selector team=payments
   openframe cluster list --verbose
   openframe cluster list --quiet`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -37,7 +51,8 @@ Examples:
 			}
 			return nil
 		},
-		RunE: utils.WrapCommandWithCommonSetup(runListClusters),
+		Annotations: map[string]string{"readonly": "true"},
+		RunE:        utils.WrapCommandWithCommonSetup(runListClusters),
 	}
 
 	// Add list-specific flags
@@ -46,26 +61,50 @@ Examples:
 		models.AddListFlags(listCmd, globalFlags.List)
 	}
 	listCmd.Flags().StringP("output", "o", "text", "Output format: text, json, or yaml")
+	listCmd.Flags().Bool("env", false, "Show the named environment running on each cluster")
 
 	return listCmd
 }
 
 func runListClusters(cmd *cobra.Command, args []string) error {
-	service := utils.GetCommandService()
+	service := utils.GetCommandService().WithMinKubernetesVersion(argocd.MinKubernetesVersion())
 
 	// Get all clusters
 	clusters, err := service.ListClusters()
 	if err != nil {
 		return fmt.Errorf("failed to list clusters: %w", err)
 	}
+	globalFlags := utils.GetGlobalFlags()
+	selector, err := models.ParseClusterSelector(globalFlags.List.Selector)
+	if err != nil {
+		return err
+	}
+	clusters = models.FilterClusters(clusters, selector)
+
+	if withEnv, _ := cmd.Flags().GetBool("env"); withEnv {
+		envs, err := environment.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load environments: %w", err)
+		}
+		rows := environmentRows(clusters, envs)
+		switch out, _ := cmd.Flags().GetString("output"); out {
+		case "json":
+			return printJSON(rows)
+		case "yaml":
+			return printYAML(rows)
+		case "", "text":
+			return printEnvironmentTable(rows)
+		default:
+			return fmt.Errorf("invalid --output %q (want \"text\", \"json\", or \"yaml\")", out)
+		}
+	}
 
 	switch out, _ := cmd.Flags().GetString("output"); out {
 	case "json":
-		return printClustersJSON(clusters)
+		return printJSON(clustersToJSON(clusters))
 	case "yaml":
-		return printClustersYAML(clusters)
+		return printYAML(clustersToJSON(clusters))
 	case "", "text":
-		globalFlags := utils.GetGlobalFlags()
 		return service.DisplayClusterList(clusters, globalFlags.List.Quiet, globalFlags.Global.Verbose)
 	default:
 		return fmt.Errorf("invalid --output %q (want \"text\", \"json\", or \"yaml\")", out)
@@ -79,6 +118,10 @@ type clusterJSON struct {
 	Status     string `json:"status"`
 	NodeCount  int    `json:"nodeCount"`
 	K8sVersion string `json:"k8sVersion,omitempty"`
+	// Labels are the cluster's --label labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Environment is set with --env: the named environment on the cluster.
+	Environment string `json:"environment,omitempty"`
 }
 
 func clustersToJSON(clusters []models.ClusterInfo) []clusterJSON {
@@ -90,13 +133,49 @@ func clustersToJSON(clusters []models.ClusterInfo) []clusterJSON {
 			Status:     c.Status,
 			NodeCount:  c.NodeCount,
 			K8sVersion: c.K8sVersion,
+			Labels:     c.Labels,
 		})
 	}
 	return out
 }
 
-func printClustersJSON(clusters []models.ClusterInfo) error {
-	b, err := json.MarshalIndent(clustersToJSON(clusters), "", "  ")
+// environmentRows is the cluster list with each cluster's environment, plus
+// a "not created" row for every environment whose cluster does not exist.
+func environmentRows(clusters []models.ClusterInfo, envs []environment.Environment) []clusterJSON {
+	rows := clustersToJSON(clusters)
+	byCluster := make(map[string]string, len(envs))
+	for _, e := range envs {
+		byCluster[e.ClusterName()] = e.Name
+	}
+	for i := range rows {
+		rows[i].Environment = byCluster[rows[i].Name]
+		delete(byCluster, rows[i].Name)
+	}
+	for _, e := range envs {
+		if _, missing := byCluster[e.ClusterName()]; missing {
+			rows = append(rows, clusterJSON{Name: e.ClusterName(), Type: string(models.ClusterTypeK3d), Status: "not created", Environment: e.Name})
+		}
+	}
+	return rows
+}
+
+func printEnvironmentTable(rows []clusterJSON) error {
+	table := pterm.TableData{{"ENVIRONMENT", "CLUSTER", "STATUS", "NODES"}}
+	for _, r := range rows {
+		env, nodes := r.Environment, strconv.Itoa(r.NodeCount)
+		if env == "" {
+			env = "-"
+		}
+		if r.Status == "not created" {
+			nodes = "-"
+		}
+		table = append(table, []string{env, r.Name, r.Status, nodes})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+}
+
+func printJSON(v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return fmt.Errorf("encoding JSON: %w", err)
 	}
@@ -104,10 +183,10 @@ func printClustersJSON(clusters []models.ClusterInfo) error {
 	return nil
 }
 
-// printClustersYAML writes the cluster list as YAML. sigs.k8s.io/yaml reuses the
+// printYAML writes the cluster list as YAML. sigs.k8s.io/yaml reuses the
 // same `json:` struct tags, so the field names match the JSON output.
-func printClustersYAML(clusters []models.ClusterInfo) error {
-	b, err := yaml.Marshal(clustersToJSON(clusters))
+func printYAML(v any) error {
+	b, err := yaml.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("encoding YAML: %w", err)
 	}
@@ -1,9 +1,11 @@
 package cluster
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
 )
 
 func TestClustersToJSON(t *testing.T) {
@@ -29,3 +31,21 @@ func TestListCommandHasOutputFlag(t *testing.T) {
 		t.Fatal("cluster list is missing the --output flag")
 	}
 }
+
+func TestEnvironmentRows(t *testing.T) {
+	clusters := []models.ClusterInfo{
+		{Name: "openframe-dev", Type: models.ClusterTypeK3d, Status: "running", NodeCount: 4},
+		{Name: "scratch", Type: models.ClusterTypeK3d, Status: "stopped", NodeCount: 1},
+	}
+	envs := []environment.Environment{{Name: "demo"}, {Name: "dev"}}
+
+	rows := environmentRows(clusters, envs)
+	want := []clusterJSON{
+		{Name: "openframe-dev", Type: "k3d", Status: "running", NodeCount: 4, Environment: "dev"},
+		{Name: "scratch", Type: "k3d", Status: "stopped", NodeCount: 1},
+		{Name: "openframe-demo", Type: "k3d", Status: "not created", Environment: "demo"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getPruneCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove leftovers of clusters that no longer exist",
+		Long: `Find and remove what failed creates and forced deletes leave behind.
+
+Lists the k3d containers, networks and volumes (registries included) whose
+cluster no longer exists, k3d config files left in the temp directory, and
+isolated kubeconfigs of deleted clusters, then removes them after
+confirmation. Live clusters and their resources are never touched.
+
+Examples:
+  openframe cluster prune
+  openframe cluster prune --force`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runPruneClusters),
+	}
+
+	pruneCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt")
+
+	return pruneCmd
+}
+
+func runPruneClusters(cmd *cobra.Command, _ []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	service := utils.GetCommandService()
+
+	orphans, err := service.FindOrphans(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to look for leftovers: %w", err)
+	}
+	if len(orphans) == 0 {
+		pterm.Success.Println("Nothing to prune: no leftovers of deleted clusters found")
+		return nil
+	}
+
+	pterm.Info.Printf("Found %d leftover(s) of deleted clusters:\n", len(orphans))
+	for _, o := range orphans {
+		pterm.Printf("  %-9s %s%s\n", o.Kind, o.Name, orphanCluster(o))
+	}
+	if !force {
+		ok, err := ui.RequireConfirmation(fmt.Sprintf("Remove these %d leftover(s)?", len(orphans)), "--force", false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			pterm.Info.Println("Prune cancelled.")
+			return nil
+		}
+	}
+
+	removed, err := service.RemoveOrphans(cmd.Context(), orphans)
+	if removed > 0 {
+		pterm.Success.Printf("Removed %d leftover(s)\n", removed)
+	}
+	return err
+}
+
+func orphanCluster(o models.Orphan) string {
+	if o.Cluster == "" {
+		return ""
+	}
+	return pterm.Gray(fmt.Sprintf("  (cluster %s)", o.Cluster))
+}
@@ -0,0 +1,99 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getRenameCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	renameCmd := &cobra.Command{
+		Use:   "rename OLD NEW",
+		Short: "Give a cluster a new name, keeping its data",
+		Long: `Rename a cluster, keeping everything that runs on it.
+
+k3d cannot rename a cluster, so a k3d cluster is recreated under the new name
+on its own data: the old cluster is stopped, a new one is created whose nodes
+mount the old nodes' k3s volumes — datastore, certificates, images and
+local-path volumes — and keep their Kubernetes node names, and the old
+containers are removed once it is up. If the new cluster cannot be created,
+the old one is started again as it was. The kube-context becomes k3d-NEW, the
+cluster may get other host ports ('openframe cluster status NEW' shows them),
+and create options kept outside the nodes' data (registry mirrors, GPUs, the
+image cache) are not carried over.
+
+An attached external cluster only has its name changed. Either way the CLI's
+records of the cluster — its add-ons, the environments on it — follow the
+new name.
+
+Examples:
+  openframe cluster rename opnframe-dev openframe-dev
+  openframe cluster rename shared staging --force`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.ClusterNames(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runRenameCluster),
+	}
+
+	renameCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt")
+
+	return renameCmd
+}
+
+func runRenameCluster(cmd *cobra.Command, args []string) error {
+	oldName, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	if err := models.ValidateClusterName(newName); err != nil {
+		return err
+	}
+	service := utils.GetCommandService()
+
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var clusterType models.ClusterType
+	for _, c := range clusters {
+		switch c.Name {
+		case oldName:
+			clusterType = c.Type
+		case newName:
+			return fmt.Errorf("a %s cluster named %q already exists", c.Type, newName)
+		}
+	}
+	if clusterType == "" {
+		return fmt.Errorf("cluster '%s' not found", oldName)
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	if clusterType != models.ClusterTypeExternal && !force {
+		ok, err := ui.RequireConfirmation(fmt.Sprintf("Recreate cluster %s as %s? Its workloads restart.", oldName, newName), "--force", false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			pterm.Info.Println("Rename cancelled.")
+			return nil
+		}
+	}
+
+	if err := service.RenameCluster(cmd.Context(), oldName, newName, clusterType); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Renamed cluster %s to %s\n", oldName, pterm.Cyan(newName))
+	if clusterType != models.ClusterTypeExternal {
+		pterm.Info.Printf("Its kube-context is now k3d-%s\n", newName)
+	}
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/utils"
@@ -28,7 +31,8 @@ Examples:
   openframe cluster status  # interactive selection
   openframe cluster status my-cluster --detailed
   openframe cluster status my-cluster -o json`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
 			if err := utils.ValidateGlobalFlags(); err != nil {
@@ -36,7 +40,8 @@ Examples:
 			}
 			return models.ValidateStatusFlags(utils.GetGlobalFlags().Status)
 		},
-		RunE: utils.WrapCommandWithCommonSetup(runClusterStatus),
+		Annotations: map[string]string{"readonly": "true"},
+		RunE:        utils.WrapCommandWithCommonSetup(runClusterStatus),
 	}
 
 	// Add status-specific flags
@@ -47,7 +52,7 @@ Examples:
 }
 
 func runClusterStatus(cmd *cobra.Command, args []string) error {
-	service := utils.GetCommandService()
+	service := utils.GetCommandService().WithMinKubernetesVersion(argocd.MinKubernetesVersion())
 	operationsUI := ui.NewOperationsUI()
 
 	// Get all available clusters
@@ -83,6 +88,12 @@ func runClusterStatus(cmd *cobra.Command, args []string) error {
 		if clusterName == "" {
 			return nil
 		}
+		// A named cluster was resumed up front; resume a picked one now.
+		if len(args) == 0 {
+			if err := cluster.ResumeIdleCluster(cmd.Context(), clusterName, false); err != nil {
+				return err
+			}
+		}
 	}
 
 	switch output {
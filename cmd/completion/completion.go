@@ -0,0 +1,202 @@
+// Package completion implements `openframe completion` and the dynamic
+// completion functions the other command groups attach to their arguments and
+// flags, so `openframe cluster delete <TAB>` offers the clusters that exist.
+package completion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/component"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/spf13/cobra"
+)
+
+// lookupTimeout bounds a dynamic lookup: a TAB press must never hang on a
+// stopped Docker daemon or an unreachable cluster.
+const lookupTimeout = 5 * time.Second
+
+// GetCompletionCmd returns the `openframe completion` command. It replaces
+// cobra's default one (disable it with CompletionOptions.DisableDefaultCmd) so
+// the install instructions name this CLI.
+func GetCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script",
+		Long: `Generate the completion script for your shell. Cluster names, kube-contexts
+and ArgoCD application names are completed live from your machine.
+
+Bash (needs the bash-completion package):
+  source <(openframe completion bash)
+  # permanently: openframe completion bash > /etc/bash_completion.d/openframe
+
+Zsh:
+  openframe completion zsh > "${fpath[1]}/_openframe"   # then start a new shell
+
+Fish:
+  openframe completion fish > ~/.config/fish/completions/openframe.fish
+
+PowerShell:
+  openframe completion powershell | Out-String | Invoke-Expression
+  # permanently: add the line above to $PROFILE`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		SilenceUsage:          true,
+		Annotations:           map[string]string{"readonly": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generate(cmd.Root(), args[0], cmd.OutOrStdout())
+		},
+	}
+}
+
+func generate(root *cobra.Command, shell string, out io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(out)
+	}
+	return fmt.Errorf("unsupported shell %q", shell)
+}
+
+// Names completes positional arguments from list, offering only names not
+// already given and matching what has been typed. maxArgs caps how many
+// arguments are completed (-1 for no cap). A failed lookup completes nothing
+// rather than falling back to file names.
+func Names(maxArgs int, list func(ctx context.Context, cmd *cobra.Command) ([]string, error)) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if maxArgs >= 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, cancel := context.WithTimeout(commandContext(cmd), lookupTimeout)
+		defer cancel()
+		names, err := list(ctx, cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return filter(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Flag adapts list into a flag completion function.
+func Flag(list func(ctx context.Context, cmd *cobra.Command) ([]string, error)) cobra.CompletionFunc {
+	return Names(-1, list)
+}
+
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// filter drops names already used and those not starting with prefix.
+func filter(names, used []string, prefix string) []string {
+	taken := make(map[string]bool, len(used))
+	for _, u := range used {
+		taken[u] = true
+	}
+	var out []string
+	for _, n := range names {
+		if !taken[n] && strings.HasPrefix(n, prefix) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// listClusters is swapped in tests.
+var listClusters = func(context.Context) ([]string, error) {
+	clusters, err := cluster.NewClusterService(executor.NewRealCommandExecutor(false, false)).ListClusters()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+// ClusterNames completes a single cluster-name argument with the clusters that
+// exist on this machine.
+func ClusterNames() cobra.CompletionFunc {
+	return Names(1, func(ctx context.Context, _ *cobra.Command) ([]string, error) {
+		return listClusters(ctx)
+	})
+}
+
+// ClusterFlag completes a --cluster flag with the clusters that exist on this
+// machine.
+func ClusterFlag() cobra.CompletionFunc {
+	return Flag(func(ctx context.Context, _ *cobra.Command) ([]string, error) {
+		return listClusters(ctx)
+	})
+}
+
+// environments lists the named environments.
+func environments(context.Context, *cobra.Command) ([]string, error) {
+	envs, err := environment.Load()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(envs))
+	for _, e := range envs {
+		names = append(names, e.Name)
+	}
+	return names, nil
+}
+
+// EnvironmentNames completes a single environment-name argument.
+func EnvironmentNames() cobra.CompletionFunc { return Names(1, environments) }
+
+// EnvironmentFlag completes an --env flag.
+func EnvironmentFlag() cobra.CompletionFunc { return Flag(environments) }
+
+// KubeContexts completes a --context flag with the kubeconfig's contexts.
+func KubeContexts() cobra.CompletionFunc {
+	return Flag(func(context.Context, *cobra.Command) ([]string, error) {
+		contexts, _, err := k8s.LoadAllContexts()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(contexts))
+		for _, c := range contexts {
+			names = append(names, c.Name)
+		}
+		return names, nil
+	})
+}
+
+// Components completes an application-or-component argument with the ArgoCD
+// applications and their workloads in the kube-context *contextName (read
+// when completing, so a --context given before it counts).
+func Components(contextName *string) cobra.CompletionFunc {
+	return Names(1, func(ctx context.Context, _ *cobra.Command) ([]string, error) {
+		cfg, err := k8s.RestConfigForContextFlag(*contextName)
+		if err != nil {
+			return nil, err
+		}
+		mgr, err := argocd.NewManagerWithConfig(executor.NewRealCommandExecutor(false, false), cfg)
+		if err != nil {
+			return nil, err
+		}
+		workloads, err := mgr.ApplicationWorkloads(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return component.Names(workloads), nil
+	})
+}
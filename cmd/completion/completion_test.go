@@ -0,0 +1,55 @@
+package completion
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_AllShells(t *testing.T) {
+	root := &cobra.Command{Use: "openframe"}
+	root.AddCommand(GetCompletionCmd())
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		var out bytes.Buffer
+		require.NoError(t, generate(root, shell, &out), shell)
+		assert.Contains(t, out.String(), "openframe", shell)
+	}
+	assert.Error(t, generate(root, "tcsh", &bytes.Buffer{}))
+}
+
+func TestClusterNames(t *testing.T) {
+	orig := listClusters
+	t.Cleanup(func() { listClusters = orig })
+	listClusters = func(context.Context) ([]string, error) {
+		return []string{"dev", "demo", "prod"}, nil
+	}
+	fn := ClusterNames()
+	cmd := &cobra.Command{}
+
+	got, dir := fn(cmd, nil, "de")
+	assert.Equal(t, []string{"dev", "demo"}, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, dir)
+
+	got, _ = fn(cmd, []string{"dev"}, "")
+	assert.Empty(t, got, "only one cluster name is accepted")
+}
+
+func TestNames_SkipsUsedAndSurvivesErrors(t *testing.T) {
+	fn := Names(-1, func(context.Context, *cobra.Command) ([]string, error) {
+		return []string{"api", "ui", "db"}, nil
+	})
+	got, _ := fn(&cobra.Command{}, []string{"ui"}, "")
+	assert.Equal(t, []string{"api", "db"}, got)
+
+	failing := Names(-1, func(context.Context, *cobra.Command) ([]string, error) {
+		return nil, errors.New("cluster unreachable")
+	})
+	got, dir := failing(&cobra.Command{}, nil, "")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, dir, "never fall back to file names")
+}
@@ -24,6 +24,48 @@ func TestRootContract_PersistentFlags(t *testing.T) {
 		assert.Equal(t, "bool", silent.Value.Type())
 		assert.Equal(t, "false", silent.DefValue)
 	}
+
+	noSudo := root.PersistentFlags().Lookup("no-sudo")
+	if assert.NotNil(t, noSudo, "root must expose a persistent --no-sudo") {
+		assert.Equal(t, "bool", noSudo.Value.Type())
+		assert.Equal(t, "false", noSudo.DefValue)
+	}
+
+	sandbox := root.PersistentFlags().Lookup("sandbox")
+	if assert.NotNil(t, sandbox, "root must expose a persistent --sandbox") {
+		assert.Equal(t, "string", sandbox.Value.Type())
+		assert.Equal(t, "off", sandbox.DefValue)
+	}
+
+	audit := root.PersistentFlags().Lookup("audit")
+	if assert.NotNil(t, audit, "root must expose a persistent --audit") {
+		assert.Equal(t, "bool", audit.Value.Type())
+		assert.Equal(t, "false", audit.DefValue)
+	}
+
+	insecure := root.PersistentFlags().Lookup("insecure-skip-tls-verify")
+	if assert.NotNil(t, insecure, "root must expose a persistent --insecure-skip-tls-verify") {
+		assert.Equal(t, "bool", insecure.Value.Type())
+		assert.Equal(t, "false", insecure.DefValue)
+	}
+
+	timeout := root.PersistentFlags().Lookup("timeout")
+	if assert.NotNil(t, timeout, "root must expose a persistent --timeout") {
+		assert.Equal(t, "duration", timeout.Value.Type())
+		assert.Equal(t, "0s", timeout.DefValue)
+	}
+
+	ciFlag := root.PersistentFlags().Lookup("ci")
+	if assert.NotNil(t, ciFlag, "root must expose a persistent --ci") {
+		assert.Equal(t, "bool", ciFlag.Value.Type())
+		assert.Equal(t, "false", ciFlag.DefValue)
+	}
+
+	readOnly := root.PersistentFlags().Lookup("read-only")
+	if assert.NotNil(t, readOnly, "root must expose a persistent --read-only") {
+		assert.Equal(t, "bool", readOnly.Value.Type())
+		assert.Equal(t, "true", readOnly.NoOptDefVal, "--read-only alone turns the mode on")
+	}
 }
 
 func TestRootContract_TopLevelSubcommands(t *testing.T) {
@@ -32,7 +74,7 @@ func TestRootContract_TopLevelSubcommands(t *testing.T) {
 	// Subset check (cobra may inject help/completion), so assert each is present
 	// rather than an exact count. `update` is here too: it rewrites the running
 	// binary, so its surface must never drift or vanish unnoticed.
-	for _, name := range []string{"cluster", "app", "bootstrap", "prerequisites", "update"} {
+	for _, name := range []string{"cluster", "app", "bootstrap", "prerequisites", "update", "telemetry", "completion", "diagnostics", "timeline", "apply", "env", "watch", "logs", "exec", "services", "volumes", "status", "cache", "cleanup", "dns", "use", "credentials", "environment", "addon", "plugin"} {
 		testutil.FindSubcommand(t, root, name)
 	}
 }
@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Freezes the `credentials` command tree: scripts store secrets by name.
+
+func TestCredentialsContract_Shape(t *testing.T) {
+	cmd := GetCredentialsCmd()
+
+	assert.Equal(t, "credentials", cmd.Name())
+	testutil.AssertSubcommands(t, cmd, "set", "get", "delete")
+	for _, name := range []string{"set", "get", "delete"} {
+		sub := testutil.FindSubcommand(t, cmd, name)
+		require.NotNil(t, sub.RunE)
+		assert.Error(t, sub.Args(sub, nil), "%s takes a name", name)
+	}
+}
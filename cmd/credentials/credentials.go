@@ -0,0 +1,140 @@
+// Package credentials implements `openframe credentials`: keep registry
+// logins and git tokens in the OS keychain instead of flags and files.
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	chartmodels "github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// GetCredentialsCmd returns the `openframe credentials` command tree.
+func GetCredentialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Store registry logins, git tokens and the ArgoCD password in the OS keychain",
+		Long: `Keep the secrets an install needs in the OS keychain — the macOS Keychain, the
+Windows Credential Manager (also from inside WSL, through powershell.exe), or
+the Secret Service (GNOME Keyring, KWallet) through secret-tool on Linux —
+instead of flags, environment variables or files.
+
+These names are used by 'app install' without being asked:
+
+  ` + credentials.RegistryPrefix + `<host>  user:pass for a private registry, injected as an
+                   imagePullSecret like --registry-auth (which overrides it)
+  ` + credentials.GitPrefix + `<host>       a token for https git repositories on host, used to
+                   clone the chart repository and by 'app add-repo-credentials'
+  ` + credentials.ArgoCDAdminName + `     the password ArgoCD's admin user is installed with,
+                   instead of a generated one
+
+Secrets are read from standard input — typed without echo, or piped — and
+never from the command line. The names stored (not the secrets) are recorded
+in ~/.openframe/credentials.json.`,
+		Example: `  openframe credentials set git/github.com
+  echo "robot:$REGISTRY_TOKEN" | openframe credentials set registry/ghcr.io
+  openframe credentials set argocd/admin
+  openframe credentials get git/github.com
+  openframe credentials delete registry/ghcr.io`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newSetCmd(), newGetCmd(), newDeleteCmd())
+	return cmd
+}
+
+func newSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "set <name>",
+		Short:        "Store a secret, read from standard input",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := credentials.ValidateName(name); err != nil {
+				return err
+			}
+			secret, err := readSecret(cmd, name)
+			if err != nil {
+				return err
+			}
+			if host, ok := strings.CutPrefix(name, credentials.RegistryPrefix); ok {
+				if _, err := chartmodels.ParseRegistryAuth(host + "=" + secret); err != nil {
+					return fmt.Errorf("%s must hold user:pass: %w", name, err)
+				}
+			}
+			if err := credentials.Set(cmd.Context(), name, secret); err != nil {
+				return err
+			}
+			pterm.Success.Printf("Stored %s in the keychain\n", name)
+			return nil
+		},
+	}
+}
+
+func newGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "get <name>",
+		Short:        "Print a stored secret",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			secret, err := credentials.Get(cmd.Context(), args[0])
+			if errors.Is(err, credentials.ErrNotFound) {
+				return fmt.Errorf("nothing is stored as %s", args[0])
+			}
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), secret)
+			return err
+		},
+	}
+}
+
+func newDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "delete <name>",
+		Short:        "Remove a stored secret",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			found, err := credentials.Delete(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if !found {
+				pterm.Info.Printf("Nothing was stored as %s\n", args[0])
+				return nil
+			}
+			pterm.Success.Printf("Removed %s from the keychain\n", args[0])
+			return nil
+		},
+	}
+}
+
+// readSecret prompts for the secret without echo on a terminal, and otherwise
+// reads the first line piped in.
+func readSecret(cmd *cobra.Command, name string) (string, error) {
+	if f, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(f.Fd())) { //nolint:gosec // G115: file descriptors fit in int
+		fmt.Fprintf(os.Stderr, "Secret for %s: ", name)
+		b, err := term.ReadPassword(int(f.Fd())) //nolint:gosec // G115: file descriptors fit in int
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("reading the secret: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading the secret from standard input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
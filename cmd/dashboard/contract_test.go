@@ -0,0 +1,37 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/dashboard"
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardContract_Flags(t *testing.T) {
+	cmd := GetDashboardCmd()
+	testutil.AssertSubcommands(t, cmd, "argocd", "grafana", "openframe", "list")
+
+	openFlags := []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "port", Type: "int", Default: "0"},
+		{Name: "no-browser", Type: "bool", Default: "false"},
+		{Name: "port-forward", Type: "bool", Default: "false"},
+	}
+	// Opening a dashboard only reads the cluster.
+	for _, c := range append([]*cobra.Command{cmd}, cmd.Commands()...) {
+		assert.Equalf(t, "true", c.Annotations["readonly"], "%s must be annotated read-only", c.Name())
+	}
+	testutil.AssertFlags(t, cmd, openFlags)
+	testutil.AssertFlags(t, testutil.FindSubcommand(t, cmd, "grafana"), openFlags)
+	testutil.AssertFlags(t, testutil.FindSubcommand(t, cmd, "list"), []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestIngressURL(t *testing.T) {
+	assert.Equal(t, "", ingressURL(dashboard.Dashboard{}))
+	assert.Equal(t, "https://argocd.localhost/", ingressURL(dashboard.Dashboard{URLs: []string{"http://argocd.localhost/", "https://argocd.localhost/"}}))
+}
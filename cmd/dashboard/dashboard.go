@@ -0,0 +1,251 @@
+// Package dashboard implements `openframe dashboard`: open the ArgoCD UI, or
+// another dashboard the installed applications serve, in the browser — with
+// the route to it and the credentials to sign in taken care of.
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/dashboard"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// openOptions are the flags of the commands that open a dashboard.
+type openOptions struct {
+	contextName string
+	port        int
+	noBrowser   bool
+	portForward bool
+}
+
+func (o *openOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.contextName, "context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().IntVar(&o.port, "port", 0, "Local port of the port-forward (0 picks a free one)")
+	cmd.Flags().BoolVar(&o.noBrowser, "no-browser", false, "Print the URL and credentials without opening the browser")
+	cmd.Flags().BoolVar(&o.portForward, "port-forward", false, "Port-forward even when an Ingress routes to the dashboard")
+}
+
+// GetDashboardCmd returns the `openframe dashboard` command.
+func GetDashboardCmd() *cobra.Command {
+	var opts openOptions
+	var names []string
+	for _, k := range dashboard.Known {
+		names = append(names, k.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Open the ArgoCD UI or another dashboard in the browser",
+		Long: `Open the ArgoCD UI in the browser.
+
+The dashboard is reached through the Ingress that routes to it when there is
+one, otherwise through a port-forward to its Service that runs until Ctrl+C.
+Its sign-in credentials are read from the cluster and printed.
+
+The subcommands open the other dashboards, when the applications that install
+them are deployed: ` + strings.Join(names[1:], ", ") + `. 'openframe dashboard list'
+shows the ones the cluster has.`,
+		Example: `  openframe dashboard
+  openframe dashboard grafana
+  openframe dashboard openframe --no-browser
+  openframe dashboard --context k3d-openframe-dev --port 8080`,
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return open(cmd.Context(), dashboard.Known[0], opts)
+		},
+	}
+	opts.addFlags(cmd)
+
+	for _, k := range dashboard.Known[1:] {
+		cmd.AddCommand(getOpenCmd(k))
+	}
+	// ArgoCD has its own subcommand too, so every dashboard is named the same way.
+	cmd.AddCommand(getOpenCmd(dashboard.Known[0]))
+	cmd.AddCommand(getListCmd())
+	return cmd
+}
+
+// getOpenCmd returns the subcommand opening dashboard k.
+func getOpenCmd(k dashboard.Kind) *cobra.Command {
+	var opts openOptions
+	cmd := &cobra.Command{
+		Use:          k.Name,
+		Short:        "Open the " + k.Title + " dashboard in the browser",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return open(cmd.Context(), k, opts)
+		},
+	}
+	opts.addFlags(cmd)
+	return cmd
+}
+
+func getListCmd() *cobra.Command {
+	var contextName, output string
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the dashboards the cluster serves",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output format %q (use text or json)", output)
+			}
+			ctx := cmd.Context()
+			cfg, cs, err := connect(ctx, contextName, output != "text")
+			if err != nil {
+				return err
+			}
+			apps, err := listApplications(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			found, err := dashboard.Discover(ctx, cs, apps)
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				b, err := json.MarshalIndent(found, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			if len(found) == 0 {
+				pterm.Info.Println("No dashboards found. Is OpenFrame installed? Check with `openframe app status`.")
+				return nil
+			}
+			data := pterm.TableData{{"NAME", "DASHBOARD", "SERVICE", "REACHED THROUGH"}}
+			for _, d := range found {
+				route := "port-forward"
+				if len(d.URLs) > 0 {
+					route = d.URLs[0]
+				}
+				data = append(data, []string{d.Kind, d.Title, fmt.Sprintf("%s/%s:%d", d.Namespace, d.Service, d.Port), route})
+			}
+			return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// open makes dashboard k reachable, prints how to sign in and opens the
+// browser. A port-forward keeps running until ctx is cancelled (Ctrl+C).
+func open(ctx context.Context, k dashboard.Kind, opts openOptions) error {
+	cfg, cs, err := connect(ctx, opts.contextName, false)
+	if err != nil {
+		return err
+	}
+	var apps []argocd.Application
+	if k.App != "" {
+		if apps, err = listApplications(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	d, ok, err := dashboard.Find(ctx, cs, k, apps)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if k.App == "" {
+			return fmt.Errorf("%s is not installed in this cluster — is OpenFrame installed? Check with `openframe app status`", k.Title)
+		}
+		return fmt.Errorf("%s is not installed in this cluster (no %q application); `openframe dashboard list` shows the dashboards there are", k.Title, k.App)
+	}
+
+	url := ingressURL(d)
+	var forwarding <-chan error
+	if url == "" || opts.portForward {
+		if url, forwarding, err = dashboard.Forward(ctx, cfg, cs, d, opts.port); err != nil {
+			return err
+		}
+	}
+
+	pterm.DefaultSection.Println(d.Title)
+	pterm.Printf("  URL:      %s\n", url)
+	if user, password, ok, err := dashboard.Credentials(ctx, cs, d); err != nil {
+		pterm.Warning.Printf("Could not read the sign-in credentials: %v\n", err)
+	} else if ok {
+		pterm.Printf("  Username: %s\n", user)
+		pterm.Printf("  Password: %s\n", password)
+	}
+	if !opts.noBrowser {
+		if err := dashboard.OpenBrowser(ctx, executor.NewRealCommandExecutor(false, false), url); err != nil {
+			pterm.Warning.Printf("Could not open the browser (%v); open %s yourself\n", err, url)
+		}
+	}
+	if forwarding == nil {
+		return nil
+	}
+
+	pterm.Info.Printf("Port-forwarding to %s/%s — press Ctrl+C to stop\n", d.Namespace, d.Service)
+	err = <-forwarding
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err == nil {
+		err = errors.New("the connection to the cluster was closed")
+	}
+	return fmt.Errorf("port-forward stopped: %w", err)
+}
+
+// ingressURL picks the route to d, HTTPS first; "" when it has none.
+func ingressURL(d dashboard.Dashboard) string {
+	for _, u := range d.URLs {
+		if strings.HasPrefix(u, "https://") {
+			return u
+		}
+	}
+	if len(d.URLs) > 0 {
+		return d.URLs[0]
+	}
+	return ""
+}
+
+// connect resumes the cluster if it is idle-paused and builds the clients for
+// contextName.
+func connect(ctx context.Context, contextName string, quiet bool) (*rest.Config, kubernetes.Interface, error) {
+	if err := cluster.ResumeIdleContext(ctx, contextName, quiet); err != nil {
+		return nil, nil, err
+	}
+	cfg, err := k8s.RestConfigForContextFlag(contextName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to the cluster: %w", err)
+	}
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to the cluster: %w", err)
+	}
+	return cfg, cs, nil
+}
+
+// listApplications lists the ArgoCD applications, which tell what is installed.
+func listApplications(ctx context.Context, cfg *rest.Config) ([]argocd.Application, error) {
+	mgr, err := argocd.NewManagerWithConfig(executor.NewRealCommandExecutor(false, false), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the cluster: %w", err)
+	}
+	apps, err := mgr.ListApplications(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("listing ArgoCD applications — is OpenFrame installed? (%w)", err)
+	}
+	return apps, nil
+}
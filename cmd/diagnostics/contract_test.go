@@ -0,0 +1,22 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Freezes the `diagnostics` command tree: issue templates tell users to run it.
+
+func TestDiagnosticsContract_Collect(t *testing.T) {
+	cmd := GetDiagnosticsCmd("v1.0.0")
+	testutil.AssertSubcommands(t, cmd, "collect")
+
+	collect := testutil.FindSubcommand(t, cmd, "collect")
+	require.NotNil(t, collect.RunE)
+	testutil.AssertFlags(t, collect, []testutil.FlagSpec{
+		{Name: "file", Shorthand: "f", Type: "string", Default: ""},
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+	})
+}
@@ -0,0 +1,73 @@
+// Package diagnostics implements `openframe diagnostics`: collect a sanitized
+// support bundle to attach to a GitHub issue.
+package diagnostics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/diagnostics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetDiagnosticsCmd returns the `openframe diagnostics` command tree.
+// currentVersion is recorded in the bundle.
+func GetDiagnosticsCmd(currentVersion string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnostics",
+		Short: "Collect a support bundle for bug reports",
+		Long: `Collect everything needed to debug a failed install into one tar.gz.
+
+The bundle contains the CLI version, Docker/k3d (and WSL) status, cluster
+nodes, pods and events, ArgoCD application states and the CLI's own state
+files. Secrets are never read, environment values in pod specs are dropped,
+and tokens, passwords, keys and URL credentials are redacted — but please
+look through the archive before attaching it to a public issue.`,
+		Example: `  openframe diagnostics collect
+  openframe diagnostics collect --context k3d-openframe-dev -f bundle.tar.gz`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newCollectCmd(currentVersion))
+	return cmd
+}
+
+func newCollectCmd(currentVersion string) *cobra.Command {
+	var (
+		file        string
+		contextName string
+	)
+	cmd := &cobra.Command{
+		Use:          "collect",
+		Short:        "Write a sanitized diagnostics bundle (tar.gz)",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations:  map[string]string{"readonly": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			if file == "" {
+				file = diagnostics.DefaultFileName(time.Now())
+			}
+
+			c, err := diagnostics.NewCollectorForContext(executor.NewRealCommandExecutor(false, verbose), currentVersion, contextName)
+			if err != nil {
+				pterm.Warning.Printf("No cluster to inspect (%v); collecting host information only.\n", err)
+			}
+
+			sp := spinner.Start("Collecting diagnostics...")
+			bundle := c.Collect(cmd.Context())
+			if err := bundle.WriteFile(file); err != nil {
+				sp.Fail("Could not write the bundle")
+				return err
+			}
+			sp.Success(fmt.Sprintf("Diagnostics written to %s (%d files).", file, len(bundle.Names())))
+			pterm.Info.Println("Review it, then attach it to your GitHub issue.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Output path (default openframe-diagnostics-<timestamp>.tar.gz)")
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Kube-context to inspect (defaults to the current context)")
+	return cmd
+}
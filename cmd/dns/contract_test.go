@@ -0,0 +1,37 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSContract_Subcommands(t *testing.T) {
+	testutil.AssertSubcommands(t, GetDNSCmd(), "serve", "setup")
+}
+
+func TestDNSContract_ServeFlags(t *testing.T) {
+	serve := testutil.FindSubcommand(t, GetDNSCmd(), "serve")
+	require.NotNil(t, serve.RunE)
+	assert.Equal(t, "true", serve.Annotations["readonly"])
+	testutil.AssertFlags(t, serve, []testutil.FlagSpec{
+		{Name: "domain", Type: "string", Default: "openframe.local"},
+		{Name: "ip", Type: "string", Default: "127.0.0.1"},
+		{Name: "listen", Type: "string", Default: "127.0.0.1:15353"},
+	})
+}
+
+func TestDNSContract_SetupFlags(t *testing.T) {
+	setup := testutil.FindSubcommand(t, GetDNSCmd(), "setup")
+	require.NotNil(t, setup.RunE)
+	assert.NotEqual(t, "true", setup.Annotations["readonly"], "setup --write changes system files")
+	testutil.AssertFlags(t, setup, []testutil.FlagSpec{
+		{Name: "domain", Type: "string", Default: "openframe.local"},
+		{Name: "ip", Type: "string", Default: "127.0.0.1"},
+		{Name: "listen", Type: "string", Default: "127.0.0.1:15353"},
+		{Name: "mode", Type: "string", Default: "server"},
+		{Name: "write", Type: "bool", Default: "false"},
+	})
+}
@@ -0,0 +1,154 @@
+// Package dns implements `openframe dns`: wildcard DNS for local ingress
+// testing, so every subdomain of a local domain reaches the cluster ingress.
+package dns
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/localdns"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetDNSCmd returns the `openframe dns` command.
+func GetDNSCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns",
+		Short: "Resolve a wildcard local domain to the cluster ingress",
+		Long: `Resolve every name under a local domain (*.openframe.local by default) to the
+cluster ingress, so multi-subdomain ingress setups work without a hosts-file
+entry per host.
+
+Either run the embedded DNS server ('dns serve') and point the OS resolver at
+it for the domain, or let dnsmasq answer for the domain; 'dns setup' prints
+(or writes) the resolver configuration for this OS.`,
+	}
+	cmd.AddCommand(getServeCmd(), getSetupCmd())
+	return cmd
+}
+
+// target holds the flags serve and setup share.
+type target struct {
+	domain string
+	ip     string
+	listen string
+}
+
+func (t *target) bind(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&t.domain, "domain", localdns.DefaultDomain, "Domain whose names resolve to the ingress, subdomains included")
+	cmd.Flags().StringVar(&t.ip, "ip", localdns.DefaultIP, "Ingress address the names resolve to")
+	cmd.Flags().StringVar(&t.listen, "listen", localdns.DefaultListen, "Address the DNS server listens on (UDP)")
+}
+
+func (t *target) validate() (net.IP, error) {
+	t.domain = strings.ToLower(strings.Trim(t.domain, "."))
+	if t.domain == "" || strings.ContainsAny(t.domain, "*/ ") {
+		return nil, fmt.Errorf("--domain must be a domain name such as %s", localdns.DefaultDomain)
+	}
+	ip := net.ParseIP(t.ip)
+	if ip == nil {
+		return nil, fmt.Errorf("--ip must be an IP address, got %q", t.ip)
+	}
+	if _, _, err := net.SplitHostPort(t.listen); err != nil {
+		return nil, fmt.Errorf("--listen must be host:port, got %q", t.listen)
+	}
+	return ip, nil
+}
+
+func getServeCmd() *cobra.Command {
+	var t target
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the wildcard DNS server",
+		Long: `Answer DNS queries for the domain and every name under it with the ingress
+address, until interrupted. Queries for other names are refused: the server is
+meant to be the OS resolver's server for this domain only (see 'dns setup').
+
+The default port, 15353, needs no root and stays clear of mDNS (5353), which
+avahi and mDNSResponder hold; Windows resolvers only use port 53.`,
+		Example: `  openframe dns serve
+  openframe dns serve --domain dev.test --ip 192.168.1.20`,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ip, err := t.validate()
+			if err != nil {
+				return err
+			}
+			server := &localdns.Server{Domain: t.domain, IP: ip}
+			return server.ListenAndServe(cmd.Context(), t.listen, func(addr net.Addr) {
+				pterm.Info.Printf("Resolving *.%s to %s on %s; press Ctrl+C to stop\n", t.domain, ip, addr)
+			})
+		},
+	}
+	t.bind(cmd)
+	return cmd
+}
+
+func getSetupCmd() *cobra.Command {
+	var (
+		t     target
+		mode  string
+		write bool
+	)
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Show or write the OS resolver configuration for the domain",
+		Long: `Show the resolver configuration that sends the domain's queries to the
+'dns serve' server (--mode server), or that has dnsmasq answer for the domain
+itself (--mode dnsmasq), for this OS:
+
+  macOS    /etc/resolver/<domain>
+  Linux    a systemd-resolved drop-in, or a dnsmasq rule
+  Windows  a Name Resolution Policy Table rule (server on port 53 only)
+
+With --write the files are written (run with sudo); the commands that apply
+them are always printed for you to run.`,
+		Example: `  openframe dns setup
+  sudo openframe dns setup --write
+  openframe dns setup --mode dnsmasq --domain dev.test`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := t.validate(); err != nil {
+				return err
+			}
+			setup, err := localdns.ResolverSetup(runtime.GOOS, mode, t.domain, t.listen, t.ip)
+			if err != nil {
+				return err
+			}
+			for _, f := range setup.Files {
+				if !write {
+					pterm.Info.Printf("%s:\n", f.Path)
+					pterm.Println(strings.TrimRight(f.Content, "\n"))
+					continue
+				}
+				if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil { //nolint:gosec // G301: system resolver directories are world-readable
+					return fmt.Errorf("writing %s (run with sudo?): %w", f.Path, err)
+				}
+				if err := os.WriteFile(f.Path, []byte(f.Content), 0o644); err != nil { //nolint:gosec // G306: resolvers read these as other users
+					return fmt.Errorf("writing %s (run with sudo?): %w", f.Path, err)
+				}
+				pterm.Success.Printf("Wrote %s\n", f.Path)
+			}
+			if len(setup.Commands) > 0 {
+				pterm.Info.Println("Then run:")
+				for _, c := range setup.Commands {
+					pterm.Println("  " + c)
+				}
+			}
+			if mode == localdns.ModeServer {
+				pterm.Info.Printf("Keep 'openframe dns serve --listen %s' running while you use *.%s\n", t.listen, t.domain)
+			}
+			return nil
+		},
+	}
+	t.bind(cmd)
+	cmd.Flags().StringVar(&mode, "mode", localdns.ModeServer, "How the domain is answered: "+strings.Join(localdns.Modes, "|"))
+	cmd.Flags().BoolVar(&write, "write", false, "Write the files instead of printing them (needs root)")
+	return cmd
+}
@@ -0,0 +1,67 @@
+// Package env implements `openframe env <cluster>`: the shell line that points
+// kubectl, helm and k9s at a cluster's kubeconfig.
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/spf13/cobra"
+)
+
+// Shells lists the supported --shell values.
+var Shells = []string{"sh", "fish", "powershell", "cmd"}
+
+// GetEnvCmd returns the `openframe env` command.
+func GetEnvCmd() *cobra.Command {
+	var shell string
+	cmd := &cobra.Command{
+		Use:   "env <cluster>",
+		Short: "Print the shell line that points kubectl and helm at a cluster",
+		Long: `Print the line that sets KUBECONFIG for a cluster, to be evaluated by your shell.
+
+Clusters created with ` + k8s.IsolationEnv + `=1 keep their kubeconfig in
+~/.openframe/kubeconfigs/<cluster>.yaml instead of ~/.kube/config; this is how
+other tools find it. For other clusters it prints the default kubeconfig.`,
+		Example: `  eval "$(openframe env dev)"
+  openframe env dev --shell fish | source
+  openframe env dev --shell powershell | Invoke-Expression`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		Annotations:       map[string]string{"readonly": "true"},
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := k8s.KubeconfigForCluster(args[0])
+			if !k8s.IsIsolated(path) {
+				fmt.Fprintf(os.Stderr, "# %s has no isolated kubeconfig; its context (usually k3d-%s) is in %s\n", args[0], args[0], path)
+			}
+			line, err := ExportLine(shell, path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), line)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&shell, "shell", "sh", "Shell syntax: "+strings.Join(Shells, "|"))
+	_ = cmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(Shells, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// ExportLine renders the KUBECONFIG assignment for shell.
+func ExportLine(shell, path string) (string, error) {
+	switch shell {
+	case "sh", "bash", "zsh", "":
+		return "export KUBECONFIG='" + strings.ReplaceAll(path, "'", `'\''`) + "'", nil
+	case "fish":
+		return "set -gx KUBECONFIG '" + strings.ReplaceAll(path, "'", `\'`) + "'", nil
+	case "powershell", "pwsh":
+		return "$env:KUBECONFIG = '" + strings.ReplaceAll(path, "'", "''") + "'", nil
+	case "cmd":
+		return "set KUBECONFIG=" + path, nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (use %s)", shell, strings.Join(Shells, ", "))
+}
@@ -0,0 +1,34 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvContract_Flags(t *testing.T) {
+	cmd := GetEnvCmd()
+	require.NotNil(t, cmd.RunE)
+	assert.Equal(t, "true", cmd.Annotations["readonly"])
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "shell", Type: "string", Default: "sh"},
+	})
+}
+
+func TestExportLine(t *testing.T) {
+	path := "/home/o'neil/.openframe/kubeconfigs/dev.yaml"
+	for shell, want := range map[string]string{
+		"sh":         `export KUBECONFIG='/home/o'\''neil/.openframe/kubeconfigs/dev.yaml'`,
+		"fish":       `set -gx KUBECONFIG '/home/o\'neil/.openframe/kubeconfigs/dev.yaml'`,
+		"powershell": `$env:KUBECONFIG = '/home/o''neil/.openframe/kubeconfigs/dev.yaml'`,
+		"cmd":        `set KUBECONFIG=/home/o'neil/.openframe/kubeconfigs/dev.yaml`,
+	} {
+		got, err := ExportLine(shell, path)
+		require.NoError(t, err, shell)
+		assert.Equal(t, want, got, shell)
+	}
+	_, err := ExportLine("tcsh", path)
+	assert.Error(t, err)
+}
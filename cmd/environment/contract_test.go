@@ -0,0 +1,27 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentContract(t *testing.T) {
+	cmd := GetEnvironmentCmd()
+	testutil.AssertSubcommands(t, cmd, "set", "list", "delete")
+
+	set := testutil.FindSubcommand(t, cmd, "set")
+	testutil.AssertFlags(t, set, []testutil.FlagSpec{
+		{Name: "cluster", Type: "string", Default: ""},
+		{Name: "nodes", Type: "int", Default: "0"},
+		{Name: "k8s-version", Type: "string", Default: ""},
+		{Name: "size", Type: "string", Default: ""},
+		{Name: "ref", Type: "string", Default: ""},
+		{Name: "values", Shorthand: "f", Type: "stringArray", Default: "[]"},
+	})
+
+	list := testutil.FindSubcommand(t, cmd, "list")
+	assert.Equal(t, "true", list.Annotations["readonly"])
+	assert.NotEqual(t, "true", testutil.FindSubcommand(t, cmd, "delete").Annotations["readonly"])
+}
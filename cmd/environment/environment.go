@@ -0,0 +1,189 @@
+// Package environment implements `openframe environment`: named
+// environments that pair a cluster profile with chart values overlays, brought
+// up with `openframe bootstrap --env <name>`.
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetEnvironmentCmd returns the `openframe environment` command tree.
+func GetEnvironmentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "environment",
+		Aliases: []string{"environments"},
+		Short:   "Manage named environments (dev, demo, test, ...)",
+		Long: `Manage named environments. An environment is a cluster with its own profile —
+node count, Kubernetes version, platform size, chart ref — and the values files
+laid over openframe-helm-values.yaml when the platform is installed on it, so
+'openframe bootstrap --env demo' builds the same configuration every time.
+
+Environments are kept in ~/.openframe/state/environments.json. Each runs on
+its own cluster, openframe-<name> unless --cluster says otherwise;
+'openframe cluster list --env' shows which environment runs where.`,
+		Example: `  openframe environment set demo --nodes 2 --size small -f demo-values.yaml
+  openframe bootstrap --env demo
+  openframe environment list
+  openframe environment delete demo`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newSetCmd(), newListCmd(), newDeleteCmd())
+	return cmd
+}
+
+func newSetCmd() *cobra.Command {
+	var (
+		clusterName, k8sVersion, size, ref string
+		nodes                              int
+		values                             []string
+	)
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create an environment or change some of its settings",
+		Long: `Create an environment, or change the settings of an existing one given as
+flags; the others are kept. -f replaces the environment's values files; the
+files are applied in order, each over the previous, like helm's -f.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.EnvironmentNames(),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := environment.Get(args[0])
+			if err != nil {
+				e = environment.Environment{Name: args[0]}
+			}
+			flags := cmd.Flags()
+			if flags.Changed("cluster") {
+				e.Cluster = clusterName
+			}
+			if flags.Changed("nodes") {
+				e.Profile.Nodes = nodes
+			}
+			if flags.Changed("k8s-version") {
+				e.Profile.K8sVersion = k8sVersion
+			}
+			if flags.Changed("size") {
+				e.Profile.Size = size
+			}
+			if flags.Changed("ref") {
+				e.Ref = ref
+			}
+			if flags.Changed("values") {
+				e.Values = values
+			}
+			if err := environment.Set(e); err != nil {
+				return err
+			}
+			pterm.Success.Printf("Environment %s runs on cluster %s; bring it up with 'openframe bootstrap --env %s'\n", e.Name, e.ClusterName(), e.Name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&clusterName, "cluster", "", "Cluster the environment runs on (default openframe-<name>)")
+	cmd.Flags().IntVar(&nodes, "nodes", 0, "Number of cluster nodes (0 keeps the bootstrap default)")
+	cmd.Flags().StringVar(&k8sVersion, "k8s-version", "", "Kubernetes version of the cluster")
+	cmd.Flags().StringVar(&size, "size", "", "Platform size: "+strings.Join(sizing.Sizes, "|"))
+	cmd.Flags().StringVar(&ref, "ref", "", "Git ref of the platform charts")
+	cmd.Flags().StringArrayVarP(&values, "values", "f", nil, "Values file laid over openframe-helm-values.yaml (repeatable)")
+	_ = cmd.RegisterFlagCompletionFunc("size", cobra.FixedCompletions(sizing.Sizes, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the environments",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			envs, err := environment.Load()
+			if err != nil {
+				return err
+			}
+			if out, _ := cmd.Flags().GetString("output"); out == "json" {
+				if envs == nil {
+					envs = []environment.Environment{}
+				}
+				b, err := json.MarshalIndent(envs, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			} else if out != "text" {
+				return fmt.Errorf("invalid --output %q (want \"text\" or \"json\")", out)
+			}
+			if len(envs) == 0 {
+				pterm.Info.Println("No environments; create one with 'openframe environment set <name>'")
+				return nil
+			}
+			table := pterm.TableData{{"NAME", "CLUSTER", "NODES", "SIZE", "REF", "VALUES", "LAST UP"}}
+			for _, e := range envs {
+				table = append(table, []string{e.Name, e.ClusterName(), orDash(nodeCount(e.Profile.Nodes)), orDash(e.Profile.Size), orDash(e.Ref), orDash(baseNames(e.Values)), lastUp(e.UpAt)})
+			}
+			return pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+		},
+	}
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	return cmd
+}
+
+func newDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete <name>",
+		Short:             "Forget an environment (its cluster is kept)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.EnvironmentNames(),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleted, err := environment.Delete(args[0])
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				return fmt.Errorf("no environment %q", args[0])
+			}
+			pterm.Success.Printf("Forgot environment %s; delete its cluster with 'openframe cluster delete'\n", args[0])
+			return nil
+		},
+	}
+}
+
+func nodeCount(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func baseNames(paths []string) string {
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = filepath.Base(p)
+	}
+	return strings.Join(names, ", ")
+}
+
+func lastUp(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecContract_Flags(t *testing.T) {
+	cmd := GetExecCmd()
+	require.NotNil(t, cmd.RunE)
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "container", Type: "string", Default: ""},
+		{Name: "stdin", Shorthand: "i", Type: "bool", Default: "false"},
+	})
+}
+
+func TestExecContract_Args(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{"openframe-api"}, true},
+		{[]string{"mongodb", "--", "mongosh", "--quiet"}, true},
+		{[]string{}, false},
+		{[]string{"mongodb", "mongosh"}, false},
+		{[]string{"--", "ls"}, false},
+	} {
+		cmd := GetExecCmd()
+		require.NoError(t, cmd.ParseFlags(tc.args))
+		err := cmd.ValidateArgs(cmd.Flags().Args())
+		assert.Equal(t, tc.ok, err == nil, "args %v: %v", tc.args, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package exec implements `openframe exec`: open a shell, or run a command,
+// in the pod behind an OpenFrame component without kubectl.
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/component"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/podexec"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetExecCmd returns the `openframe exec` command.
+func GetExecCmd() *cobra.Command {
+	var (
+		contextName string
+		container   string
+		stdin       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "exec COMPONENT [-- COMMAND [ARG...]]",
+		Short: "Open a shell or run a command in a component's pod",
+		Long: `Open an interactive shell in the pod behind an OpenFrame application or
+component, or run the command given after --.
+
+The name is resolved like 'openframe logs': an ArgoCD application first, then
+one of the Deployments, StatefulSets or DaemonSets it manages. Of its pods, the
+first ready one is used, and its first container unless --container is set.
+The shell is bash when the image has it, sh otherwise. A command gets no stdin
+unless -i is given; the command's exit code becomes openframe's.`,
+		Example: `  openframe exec openframe-api
+  openframe exec mongodb -- mongosh --eval 'db.adminCommand("ping")'
+  openframe exec kafka --container kafka -- kafka-topics.sh --bootstrap-server localhost:9092 --list
+  cat dump.sql | openframe exec postgres -i -- psql -U postgres`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if n := cmd.ArgsLenAtDash(); n == 1 || (n == -1 && len(args) == 1) {
+				return nil
+			}
+			return fmt.Errorf("expected one component name, then -- and the command to run")
+		},
+		ValidArgsFunction: completion.Components(&contextName),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, command := args[0], args[1:]
+			interactive := len(command) == 0
+			if interactive {
+				if !term.IsTerminal(int(os.Stdin.Fd())) { //nolint:gosec // G115: file descriptors fit in int
+					return fmt.Errorf("an interactive shell needs a terminal; pass a command after --")
+				}
+				command = podexec.Shell
+			}
+			if err := cluster.ResumeIdleContext(cmd.Context(), contextName, false); err != nil {
+				return err
+			}
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			cfg, err := k8s.RestConfigForContextFlag(contextName)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			mgr, err := argocd.NewManagerWithConfig(executor.NewRealCommandExecutor(false, verbose), cfg)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			workloads, err := mgr.ApplicationWorkloads(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("could not read the ArgoCD applications: %w", err)
+			}
+			selected, err := component.Select(workloads, name)
+			if err != nil {
+				return err
+			}
+			cs, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			pods, err := component.Pods(cmd.Context(), cs, selected)
+			if err != nil {
+				return err
+			}
+			pod, err := podexec.Primary(pods)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			opts := podexec.Options{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Container: container,
+				Command:   command,
+				Stdout:    os.Stdout,
+				Stderr:    os.Stderr,
+				TTYFd:     -1,
+			}
+			if interactive || stdin {
+				opts.Stdin = os.Stdin
+				if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) && term.IsTerminal(int(os.Stdout.Fd())) { //nolint:gosec // G115: file descriptors fit in int
+					opts.TTYFd = fd
+				}
+			}
+			if interactive {
+				pterm.Info.Printf("Connected to %s/%s; exit the shell to return\n", pod.Namespace, pod.Name)
+			}
+			return podexec.Run(cmd.Context(), cfg, cs, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().StringVar(&container, "container", "", "Container to run in (defaults to the pod's first)")
+	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "Pass stdin to the command")
+	return cmd
+}
@@ -0,0 +1,22 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsContract_Flags(t *testing.T) {
+	cmd := GetLogsCmd()
+	require.NotNil(t, cmd.RunE)
+	assert.Equal(t, "true", cmd.Annotations["readonly"])
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "follow", Shorthand: "f", Type: "bool", Default: "false"},
+		{Name: "tail", Type: "int64", Default: "100"},
+		{Name: "since", Type: "duration", Default: "0s"},
+		{Name: "container", Type: "string", Default: ""},
+	})
+}
@@ -0,0 +1,98 @@
+// Package logs implements `openframe logs`: stream the pod logs of an
+// OpenFrame application or component without looking up namespaces and pod
+// names by hand.
+package logs
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/component"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/logs"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetLogsCmd returns the `openframe logs` command.
+func GetLogsCmd() *cobra.Command {
+	var (
+		contextName string
+		follow      bool
+		tail        int64
+		since       time.Duration
+		container   string
+	)
+	cmd := &cobra.Command{
+		Use:   "logs APPLICATION|COMPONENT",
+		Short: "Stream the logs of an application's pods",
+		Long: `Stream the logs of every pod behind an OpenFrame application or component.
+
+The name is looked up among the ArgoCD applications first, then among the
+Deployments, StatefulSets and DaemonSets they manage, so both
+'openframe logs openframe-api' and 'openframe logs kafka' work without knowing
+the namespace or pod names. Lines from all pods are merged, each prefixed with
+its pod (and container, for pods with several) in its own color.`,
+		Example: `  openframe logs openframe-api
+  openframe logs openframe-api -f --tail 20
+  openframe logs kafka --since 10m --context k3d-openframe-dev`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Components(&contextName),
+		Annotations:       map[string]string{"readonly": "true"},
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since < 0 {
+				return fmt.Errorf("--since must not be negative, got %s", since)
+			}
+			opts := logs.Options{Follow: follow, TailLines: tail, Since: since}
+			if err := cluster.ResumeIdleContext(cmd.Context(), contextName, false); err != nil {
+				return err
+			}
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			cfg, err := k8s.RestConfigForContextFlag(contextName)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			mgr, err := argocd.NewManagerWithConfig(executor.NewRealCommandExecutor(false, verbose), cfg)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			workloads, err := mgr.ApplicationWorkloads(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("could not read the ArgoCD applications: %w", err)
+			}
+			selected, err := component.Select(workloads, args[0])
+			if err != nil {
+				return err
+			}
+			cs, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			sources, err := logs.Sources(cmd.Context(), cs, selected, container)
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			if follow {
+				pterm.Info.Printf("Streaming %d container log(s) of %s; press Ctrl+C to stop\n", len(sources), args[0])
+			}
+			if err := logs.Stream(cmd.Context(), cs, sources, opts, os.Stdout); err != nil && cmd.Context().Err() == nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming new log lines")
+	cmd.Flags().Int64Var(&tail, "tail", 100, "Lines of each log to show first (-1 for all)")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show lines newer than this, e.g. 5m or 1h")
+	cmd.Flags().StringVar(&container, "container", "", "Only stream containers with this name")
+	return cmd
+}
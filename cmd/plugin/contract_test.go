@@ -0,0 +1,31 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginContract(t *testing.T) {
+	cmd := GetPluginCmd()
+	testutil.AssertSubcommands(t, cmd, "list")
+
+	list := testutil.FindSubcommand(t, cmd, "list")
+	assert.Equal(t, "true", list.Annotations["readonly"])
+	testutil.AssertFlags(t, list, []testutil.FlagSpec{
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
+
+func TestBuiltinFor(t *testing.T) {
+	root := &cobra.Command{Use: "openframe"}
+	cluster := &cobra.Command{Use: "cluster"}
+	cluster.AddCommand(&cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(cluster, &cobra.Command{Use: "dns-check", Run: func(*cobra.Command, []string) {}})
+
+	assert.Equal(t, "cluster list", builtinFor(root, "cluster-list"))
+	assert.Equal(t, "dns-check", builtinFor(root, "dns-check"))
+	assert.Equal(t, "", builtinFor(root, "db-dump"))
+}
@@ -0,0 +1,111 @@
+// Package plugin implements `openframe plugin`: listing the openframe-*
+// executables on PATH that run as openframe subcommands.
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/plugin"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetPluginCmd returns the `openframe plugin` command tree.
+func GetPluginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "plugin",
+		Aliases: []string{"plugins"},
+		Short:   "List the plugins that extend openframe",
+		Long: `Any executable named openframe-<name> on PATH (or in ~/.openframe/bin) is an
+openframe command: 'openframe <name> ARGS...' runs it with ARGS. Dashes nest,
+so openframe-db-dump runs as 'openframe db dump'. Built-in commands always win
+over a plugin of the same name.
+
+A plugin runs with openframe's environment plus:
+
+  OPENFRAME_BIN        the openframe executable, to call back into the CLI
+  OPENFRAME_VERSION    its version
+  OPENFRAME_HOME       ~/.openframe
+  OPENFRAME_STATE_DIR  ~/.openframe/state, where openframe keeps its state
+  OPENFRAME_CLUSTER    the current cluster, as openframe commands name it
+  OPENFRAME_CONTEXT    its kube-context
+  KUBECONFIG           the kubeconfig that defines that context
+  OPENFRAME_PLUGIN     the plugin's name
+
+The cluster variables are empty when no kube-context is current. The plugin's
+exit code is openframe's.`,
+		Example: `  openframe plugin list
+  openframe plugin list -o json`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newListCmd())
+	return cmd
+}
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the plugins on PATH",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			plugins := plugin.List()
+			if out, _ := cmd.Flags().GetString("output"); out == "json" {
+				if plugins == nil {
+					plugins = []plugin.Plugin{}
+				}
+				b, err := json.MarshalIndent(plugins, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			} else if out != "text" {
+				return fmt.Errorf("invalid --output %q (want \"text\" or \"json\")", out)
+			}
+			if len(plugins) == 0 {
+				pterm.Info.Println("No plugins; put an executable named openframe-<name> on PATH to add 'openframe <name>'")
+				return nil
+			}
+			table := pterm.TableData{{"COMMAND", "PATH", "NOTES"}}
+			for _, p := range plugins {
+				table = append(table, []string{"openframe " + strings.ReplaceAll(p.Name, "-", " "), p.Path, notes(cmd.Root(), p)})
+			}
+			return pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+		},
+	}
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	return cmd
+}
+
+// notes warns about a plugin that does not run: one a built-in command
+// hides, or copies of it later on PATH.
+func notes(root *cobra.Command, p plugin.Plugin) string {
+	var out []string
+	if builtin := builtinFor(root, p.Name); builtin != "" {
+		out = append(out, "hidden by the built-in 'openframe "+builtin+"'")
+	}
+	for _, s := range p.Shadowed {
+		out = append(out, "shadows "+s)
+	}
+	if len(out) == 0 {
+		return "-"
+	}
+	return strings.Join(out, "; ")
+}
+
+// builtinFor is the built-in command that runs instead of the plugin called
+// name, or "" when there is none.
+func builtinFor(root *cobra.Command, name string) string {
+	words := strings.Split(name, "-")
+	if found, _, err := root.Find(words); err == nil && found != root {
+		return strings.TrimPrefix(found.CommandPath(), root.Name()+" ")
+	}
+	if found, _, err := root.Find([]string{name}); err == nil && found != root {
+		return strings.TrimPrefix(found.CommandPath(), root.Name()+" ")
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package prerequisites
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/firewall"
+	"github.com/pterm/pterm"
+)
+
+// ingressPorts are the ports `cluster create` publishes the ingress on when
+// they are free; it falls back to 8080/8443 and warns about those itself.
+var ingressPorts = []int{80, 443}
+
+// reportFirewall lists active firewalls that stop other machines from reaching
+// the ingress ports, with the commands that would open them. It only reports:
+// the firewall is the user's, and this machine reaches the ports on localhost
+// either way. It returns the findings.
+func reportFirewall(ctx context.Context, ex executor.CommandExecutor) ([]firewall.Finding, error) {
+	if runtime.GOOS != "linux" {
+		return nil, nil
+	}
+	findings, err := firewall.Detect(ctx, firewall.ExecRunner(ex), ingressPorts)
+	if err != nil || len(findings) == 0 {
+		return nil, err
+	}
+	fmt.Println()
+	pterm.Warning.Println("Host firewall:")
+	for _, f := range findings {
+		if f.Unreadable {
+			pterm.Warning.Printfln("%s is active; its rules need root to read, so it may stop other machines reaching ports %s", f.Firewall, portList(f.Ports))
+		} else {
+			pterm.Warning.Printfln("%s stops other machines reaching ports %s; this machine still reaches the ingress on localhost", f.Firewall, portList(f.Ports))
+		}
+		for _, argv := range f.Commands() {
+			pterm.DefaultBasicText.Printfln("  sudo %s", strings.Join(argv, " "))
+		}
+		if f.Firewall == firewall.IPTables {
+			pterm.DefaultBasicText.Println("  (iptables rules last until the next reboot unless saved, e.g. with netfilter-persistent save)")
+		}
+	}
+	return findings, nil
+}
+
+func portList(ports []int) string {
+	s := make([]string, len(ports))
+	for i, p := range ports {
+		s[i] = fmt.Sprint(p)
+	}
+	return strings.Join(s, ", ")
+}
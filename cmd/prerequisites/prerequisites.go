@@ -4,10 +4,16 @@
 package prerequisites
 
 import (
+	"context"
 	"fmt"
+	"runtime"
+	"strconv"
 
 	clusterprereq "github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites"
 	fw "github.com/flamingo-stack/openframe-cli/internal/prerequisites"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
@@ -20,16 +26,28 @@ func GetPrerequisitesCmd() *cobra.Command {
 		Short:   "Check and install the tools OpenFrame needs",
 		Long: `Prerequisites - check and install the tools OpenFrame needs
 
-Verifies that Docker, kubectl, k3d, and helm are available (and Docker running).
+Verifies that Docker, k3d, and helm are available (and Docker running). kubectl
+is not needed: the CLI talks to Kubernetes through its built-in client.
+On Linux (including WSL), check also reports the kernel limits the cluster
+needs: the running value, the wanted one, and whether it is persisted, and
+any host firewall that stops other machines reaching the ingress ports 80
+and 443 — the firewalld zone of Docker's interfaces, or a DROP in iptables'
+DOCKER-USER chain — with the commands that would open them. ufw and the
+INPUT chain do not apply: Docker forwards published ports around them. Under WSL2, check
+also compares the WSL clock with NTP (or the Windows clock): it stops while
+the laptop sleeps, and a clock that has fallen behind breaks TLS.
 
   • check   - report what is installed, without changing anything
   • install - install anything missing (macOS/Linux); on Windows, print the docs
-              links to install them manually
+              links to install them manually. Reports the host firewall as
+              check does, without changing it, and resets a drifted WSL
+              clock with hwclock -s
 
 Examples:
   openframe prerequisites check
   openframe prerequisites install`,
-		RunE: func(cmd *cobra.Command, _ []string) error { return cmd.Help() },
+		Annotations: map[string]string{"readonly": "true"},
+		RunE:        func(cmd *cobra.Command, _ []string) error { return cmd.Help() },
 	}
 	cmd.AddCommand(checkCmd(), installCmd())
 	return cmd
@@ -41,10 +59,16 @@ func checkCmd() *cobra.Command {
 		Short:         "Report which prerequisites are installed (no changes)",
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		Annotations:   map[string]string{"readonly": "true"},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			set := clusterprereq.ClusterSet()
 			res := fw.NewRunner().Check(set)
 			printResult(res)
+			printKernelLimits(cmd.Context())
+			if _, err := reportFirewall(cmd.Context(), executor.NewRealCommandExecutor(false, false)); err != nil {
+				return err
+			}
+			clusterprereq.ReportClock(cmd.Context(), executor.NewRealCommandExecutor(false, false))
 			if !res.OK() {
 				return fmt.Errorf("%d prerequisite(s) missing — run 'openframe prerequisites install'", len(res.Missing))
 			}
@@ -67,6 +91,12 @@ func installCmd() *cobra.Command {
 			}
 			res := runner.Run(cmd.Context(), set)
 			printResult(res)
+			if _, err := reportFirewall(cmd.Context(), executor.NewRealCommandExecutor(false, false)); err != nil {
+				return err
+			}
+			if err := clusterprereq.FixClock(cmd.Context(), executor.NewRealCommandExecutor(false, false), ui.IsNonInteractive()); err != nil {
+				return err
+			}
 			if !res.OK() {
 				return fmt.Errorf("%d prerequisite(s) still missing", len(res.Missing))
 			}
@@ -102,3 +132,39 @@ func printResult(res fw.Result) {
 		pterm.Success.Println("All prerequisites are satisfied.")
 	}
 }
+
+// printKernelLimits reports the inotify limits on Linux: what the running
+// kernel has, what the cluster wants, and what is persisted for the next boot.
+// Informational: `cluster create` raises low limits itself.
+func printKernelLimits(ctx context.Context) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	statuses := sysctl.Report(ctx, sysctl.ExecReader(executor.NewRealCommandExecutor(false, false)), sysctl.PersistFile, sysctl.Inotify)
+	table := pterm.TableData{{"SETTING", "CURRENT", "WANTED", "PERSISTED"}}
+	durable := true
+	for _, st := range statuses {
+		current := "unknown"
+		if st.Current >= 0 {
+			current = strconv.Itoa(st.Current)
+		}
+		if !st.OK() {
+			current = pterm.Yellow(current)
+		}
+		persisted := "no"
+		if st.Persisted > 0 {
+			persisted = strconv.Itoa(st.Persisted)
+		}
+		durable = durable && st.Durable()
+		table = append(table, []string{st.Key, current, strconv.Itoa(st.Want), persisted})
+	}
+	fmt.Println()
+	pterm.Info.Println("Kernel limits:")
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+	switch {
+	case len(sysctl.Low(statuses)) > 0:
+		pterm.Warning.Println("Some limits are low; 'openframe cluster create' raises them (add --persist-sysctl to keep them across reboots).")
+	case !durable:
+		pterm.Info.Printfln("The limits reset on reboot; 'openframe cluster create --persist-sysctl' writes them to %s.", sysctl.PersistFile)
+	}
+}
@@ -2,19 +2,58 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
+	addoncmd "github.com/flamingo-stack/openframe-cli/cmd/addon"
 	"github.com/flamingo-stack/openframe-cli/cmd/app"
+	"github.com/flamingo-stack/openframe-cli/cmd/apply"
 	"github.com/flamingo-stack/openframe-cli/cmd/bootstrap"
+	cachecmd "github.com/flamingo-stack/openframe-cli/cmd/cache"
+	cleanupcmd "github.com/flamingo-stack/openframe-cli/cmd/cleanup"
 	"github.com/flamingo-stack/openframe-cli/cmd/cluster"
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	credentialscmd "github.com/flamingo-stack/openframe-cli/cmd/credentials"
+	dashboardcmd "github.com/flamingo-stack/openframe-cli/cmd/dashboard"
+	"github.com/flamingo-stack/openframe-cli/cmd/diagnostics"
+	dnscmd "github.com/flamingo-stack/openframe-cli/cmd/dns"
+	envcmd "github.com/flamingo-stack/openframe-cli/cmd/env"
+	environmentcmd "github.com/flamingo-stack/openframe-cli/cmd/environment"
+	execcmd "github.com/flamingo-stack/openframe-cli/cmd/exec"
+	logscmd "github.com/flamingo-stack/openframe-cli/cmd/logs"
+	plugincmd "github.com/flamingo-stack/openframe-cli/cmd/plugin"
 	"github.com/flamingo-stack/openframe-cli/cmd/prerequisites"
+	servicescmd "github.com/flamingo-stack/openframe-cli/cmd/services"
+	statuscmd "github.com/flamingo-stack/openframe-cli/cmd/status"
+	telemetrycmd "github.com/flamingo-stack/openframe-cli/cmd/telemetry"
+	timelinecmd "github.com/flamingo-stack/openframe-cli/cmd/timeline"
 	"github.com/flamingo-stack/openframe-cli/cmd/update"
+	usecmd "github.com/flamingo-stack/openframe-cli/cmd/use"
+	volumescmd "github.com/flamingo-stack/openframe-cli/cmd/volumes"
+	watchcmd "github.com/flamingo-stack/openframe-cli/cmd/watch"
+	diagbundle "github.com/flamingo-stack/openframe-cli/internal/diagnostics"
+	"github.com/flamingo-stack/openframe-cli/internal/plugin"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ci"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/dockerhost"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/download"
+	sharederrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/notify"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/selfupdate"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/tracing"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/wsllauncher"
 	"github.com/pterm/pterm"
@@ -89,8 +128,14 @@ operation for automation and power users.`,
 			if v, _ := cmd.Flags().GetBool("verbose"); v && !silent {
 				pterm.EnableDebugMessages()
 			}
-			return nil
+			ci.Apply()
+			if err := readonly.Check(cmd); err != nil {
+				return err
+			}
+			return config.ApplyCABundle()
 		},
+		// On its own the command only prints help, which read-only mode allows.
+		Annotations: map[string]string{"readonly": "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Show logo when no subcommand is provided
 			ui.ShowLogo()
@@ -104,10 +149,40 @@ operation for automation and power users.`,
 	rootCmd.AddCommand(getBootstrapCmd())
 	rootCmd.AddCommand(getPrerequisitesCmd())
 	rootCmd.AddCommand(getUpdateCmd(versionInfo.Version))
+	rootCmd.AddCommand(getTelemetryCmd())
+	rootCmd.AddCommand(getDiagnosticsCmd(versionInfo.Version))
+	rootCmd.AddCommand(getTimelineCmd())
+	rootCmd.AddCommand(getApplyCmd())
+	rootCmd.AddCommand(getEnvCmd())
+	rootCmd.AddCommand(getWatchCmd())
+	rootCmd.AddCommand(getLogsCmd())
+	rootCmd.AddCommand(getExecCmd())
+	rootCmd.AddCommand(getServicesCmd())
+	rootCmd.AddCommand(getDashboardCmd())
+	rootCmd.AddCommand(getVolumesCmd())
+	rootCmd.AddCommand(getStatusCmd())
+	rootCmd.AddCommand(getCacheCmd())
+	rootCmd.AddCommand(getCleanupCmd())
+	rootCmd.AddCommand(getDNSCmd())
+	rootCmd.AddCommand(getUseCmd())
+	rootCmd.AddCommand(getCredentialsCmd())
+	rootCmd.AddCommand(getEnvironmentCmd())
+	rootCmd.AddCommand(getAddonCmd())
+	rootCmd.AddCommand(getPluginCmd())
+	// Our own completion command (with install instructions and dynamic
+	// cluster/context/application completion) replaces cobra's default one.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completion.GetCompletionCmd())
 
 	// Add global flags following cluster pattern
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().Bool("silent", false, "Suppress all output except errors")
+	privilege.BindFlags(rootCmd.PersistentFlags())
+	executor.BindSandboxFlags(rootCmd.PersistentFlags())
+	config.BindTLSFlags(rootCmd.PersistentFlags())
+	config.BindTimeoutFlags(rootCmd.PersistentFlags())
+	ci.BindFlags(rootCmd.PersistentFlags())
+	readonly.BindFlags(rootCmd.PersistentFlags())
 
 	// Version template
 	rootCmd.SetVersionTemplate(`{{printf "%s\n" .Version}}`)
@@ -173,13 +248,69 @@ func ExecuteWithVersion(versionInfo VersionInfo) error {
 		download.PrependToPath(binDir)
 	}
 
+	// k3d and the tools it drives read only DOCKER_HOST, not the active docker
+	// context (Docker Desktop on Linux, Colima, rootless), so export the
+	// endpoint the docker CLI itself would use before any of them run.
+	if _, err := dockerhost.Apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
 	// Run with a signal-cancelled context so Ctrl-C / SIGTERM cancels every
 	// command via cmd.Context(). This replaces the per-operation signal handlers
 	// that individual services used to install by hand.
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	err := rootCmd.ExecuteContext(ctx)
+	// `openframe foo` with no built-in foo runs an openframe-foo plugin from
+	// PATH, kubectl-style, and exits with its code.
+	if path, args, ok := findPlugin(rootCmd, os.Args[1:]); ok {
+		// Nothing tells what a plugin changes, so an observer runs none.
+		if readonly.Enabled() {
+			return fmt.Errorf("%w: plugin %s does not run", readonly.ErrReadOnly, plugin.NameOf(path))
+		}
+		code, err := plugin.Run(path, args, plugin.Env(plugin.NameOf(path), versionInfo.Version))
+		if err != nil {
+			return fmt.Errorf("running plugin %s: %w", path, err)
+		}
+		stop()
+		os.Exit(code)
+	}
+
+	started := time.Now()
+	timeline.Begin()
+	if err := tracing.Begin(versionInfo.Version); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not tracing: %v\n", err)
+	}
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	if executed != nil {
+		timeline.Finish(executed.CommandPath(), err)
+		tracing.Finish(executed.CommandPath(), err)
+		// Errors no handler displayed would reach main as a raw chain; show a
+		// known cause with its remedy instead.
+		verbose, _ := executed.Flags().GetBool("verbose")
+		err = sharederrors.PresentRemedy(err, verbose)
+	}
+
+	// --ci: close the last log group; on failure raise an error annotation and
+	// leave a diagnostics bundle for the workflow to upload.
+	if ci.Enabled() {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			ci.Fail(err, telemetry.CurrentPhase())
+			exportDiagnostics(versionInfo.Version)
+		} else {
+			ci.Finish()
+		}
+	}
+	installstatus.Finish(err)
+	notify.Finish(err, telemetry.CurrentPhase())
+	metrics.Finish()
+
+	// Opt-in anonymous telemetry (off unless `openframe telemetry on`): one
+	// event per command, sent best-effort under a short timeout. Toggling
+	// telemetry itself is not reported.
+	if executed != nil && executed.Parent() != nil && executed.Name() != "telemetry" && executed.Parent().Name() != "telemetry" {
+		telemetry.Report(context.Background(), telemetry.NewEvent(executed.CommandPath(), versionInfo.Version, started, err))
+	}
 
 	// Post-command self-update handling, best-effort and printed to stderr so it
 	// never blocks the command, changes its exit code, or corrupts machine output
@@ -198,6 +329,27 @@ func ExecuteWithVersion(versionInfo VersionInfo) error {
 	return err
 }
 
+// exportDiagnostics writes a diagnostics bundle to the working directory after
+// a failed --ci run and publishes its path as the diagnostics-bundle step
+// output.
+func exportDiagnostics(version string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	c, _ := diagbundle.NewCollectorForContext(executor.NewRealCommandExecutor(false, false), version, "")
+	path, err := filepath.Abs(diagbundle.DefaultFileName(time.Now()))
+	if err == nil {
+		err = c.Collect(ctx).WriteFile(path)
+	}
+	if err != nil {
+		pterm.Warning.Printf("Could not write a diagnostics bundle: %v\n", err)
+		return
+	}
+	ci.Notice("Diagnostics bundle", path)
+	if err := ci.SetOutput("diagnostics-bundle", path); err != nil {
+		pterm.Warning.Printf("Could not publish the diagnostics bundle path: %v\n", err)
+	}
+}
+
 // getClusterCmd returns the cluster command
 func getClusterCmd() *cobra.Command {
 	return cluster.GetClusterCmd()
@@ -222,3 +374,119 @@ func getPrerequisitesCmd() *cobra.Command {
 func getUpdateCmd(currentVersion string) *cobra.Command {
 	return update.GetUpdateCmd(currentVersion)
 }
+
+// getTelemetryCmd returns the telemetry opt-in command.
+func getTelemetryCmd() *cobra.Command {
+	return telemetrycmd.GetTelemetryCmd()
+}
+
+// getDiagnosticsCmd returns the support-bundle command.
+func getDiagnosticsCmd(currentVersion string) *cobra.Command {
+	return diagnostics.GetDiagnosticsCmd(currentVersion)
+}
+
+// getTimelineCmd returns the install-timeline command.
+func getTimelineCmd() *cobra.Command {
+	return timelinecmd.GetTimelineCmd()
+}
+
+// getApplyCmd returns the extra-manifests command.
+func getApplyCmd() *cobra.Command {
+	return apply.GetApplyCmd()
+}
+
+// getEnvCmd returns the kubeconfig export command.
+func getEnvCmd() *cobra.Command {
+	return envcmd.GetEnvCmd()
+}
+
+// getWatchCmd returns the cluster monitor command.
+func getWatchCmd() *cobra.Command {
+	return watchcmd.GetWatchCmd()
+}
+
+// getLogsCmd returns the log streaming command.
+func getLogsCmd() *cobra.Command {
+	return logscmd.GetLogsCmd()
+}
+
+// getExecCmd returns the component shell command.
+func getExecCmd() *cobra.Command {
+	return execcmd.GetExecCmd()
+}
+
+// getServicesCmd returns the datastore connection cheat-sheet command.
+func getServicesCmd() *cobra.Command {
+	return servicescmd.GetServicesCmd()
+}
+
+// getDashboardCmd returns the command that opens the ArgoCD UI and other dashboards.
+func getDashboardCmd() *cobra.Command {
+	return dashboardcmd.GetDashboardCmd()
+}
+
+// getVolumesCmd returns the volume backup and restore command.
+func getVolumesCmd() *cobra.Command {
+	return volumescmd.GetVolumesCmd()
+}
+
+// getStatusCmd returns the machine-readable readiness command.
+func getStatusCmd() *cobra.Command {
+	return statuscmd.GetStatusCmd()
+}
+
+// getCacheCmd returns the node image cache command.
+func getCacheCmd() *cobra.Command {
+	return cachecmd.GetCacheCmd()
+}
+
+// getCleanupCmd returns the disk cleanup command.
+func getCleanupCmd() *cobra.Command {
+	return cleanupcmd.GetCleanupCmd()
+}
+
+// getDNSCmd returns the wildcard local DNS command.
+func getDNSCmd() *cobra.Command {
+	return dnscmd.GetDNSCmd()
+}
+
+// getCredentialsCmd returns the keychain secrets command.
+func getCredentialsCmd() *cobra.Command {
+	return credentialscmd.GetCredentialsCmd()
+}
+
+// getEnvironmentCmd returns the named environments command.
+func getEnvironmentCmd() *cobra.Command {
+	return environmentcmd.GetEnvironmentCmd()
+}
+
+// getAddonCmd returns the add-ons command.
+func getAddonCmd() *cobra.Command {
+	return addoncmd.GetAddonCmd()
+}
+
+// getPluginCmd returns the plugins command.
+func getPluginCmd() *cobra.Command {
+	return plugincmd.GetPluginCmd()
+}
+
+// findPlugin resolves args to a plugin when they do not name a built-in
+// command; built-ins always win.
+func findPlugin(root *cobra.Command, args []string) (string, []string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", nil, false
+	}
+	switch args[0] {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return "", nil, false
+	}
+	if found, _, err := root.Find(args); err == nil && found != root {
+		return "", nil, false
+	}
+	return plugin.Find(args)
+}
+
+// getUseCmd returns the context switcher command.
+func getUseCmd() *cobra.Command {
+	return usecmd.GetUseCmd()
+}
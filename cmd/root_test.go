@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
 
 	"github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/flamingo-stack/openframe-cli/tests/testutil"
 )
@@ -154,3 +158,79 @@ func TestVerboseEnablesDebugOutput(t *testing.T) {
 		t.Errorf("--silent must suppress debug output even with --verbose; got %q", out)
 	}
 }
+
+func TestFindPlugin_BuiltinsWin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by extension on Windows")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"openframe-cluster", "openframe-db"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+	root := GetRootCmd(DefaultVersionInfo)
+
+	if _, _, ok := findPlugin(root, []string{"cluster", "list"}); ok {
+		t.Error("a built-in command must not run a plugin")
+	}
+	if _, _, ok := findPlugin(root, []string{"--verbose", "db"}); ok {
+		t.Error("a flag first is not a plugin")
+	}
+	path, rest, ok := findPlugin(root, []string{"db", "dump"})
+	if !ok || path != filepath.Join(dir, "openframe-db") || len(rest) != 1 || rest[0] != "dump" {
+		t.Errorf("findPlugin(db dump) = %q, %v, %v", path, rest, ok)
+	}
+}
+
+// TestReadOnly_ObserverCommands pins which commands an observer may run: the
+// ones that only look. A command missing from allowed fails read-only users;
+// one wrongly in it lets them change the machine.
+func TestReadOnly_ObserverCommands(t *testing.T) {
+	root := GetRootCmd(DefaultVersionInfo)
+	allowed := map[string]bool{
+		"openframe": true, "openframe cluster": true, "openframe app": true, "openframe prerequisites": true,
+		"openframe cluster list": true, "openframe cluster status": true,
+		"openframe app status": true, "openframe app access": true, "openframe app validate": true,
+		"openframe app history": true,
+		"openframe app tree":    true,
+		"openframe addon list":  true, "openframe environment list": true, "openframe plugin list": true,
+		"openframe prerequisites check": true, "openframe diagnostics collect": true,
+		"openframe telemetry status": true, "openframe timeline": true, "openframe update check": true,
+		"openframe completion": true, "openframe env": true, "openframe services": true, "openframe logs": true,
+		"openframe watch": true, "openframe status serve": true, "openframe dns serve": true,
+		"openframe volumes backup": true, "openframe dashboard": true, "openframe dashboard list": true,
+		"openframe dashboard argocd": true, "openframe dashboard grafana": true, "openframe dashboard openframe": true,
+	}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Runnable() {
+			if got := readonly.Allowed(c); got != allowed[c.CommandPath()] {
+				t.Errorf("%s: runs in read-only mode = %v, want %v", c.CommandPath(), got, allowed[c.CommandPath()])
+			}
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+func TestReadOnly_RefusesMutatingCommands(t *testing.T) {
+	t.Setenv(readonly.Env, "1")
+	// cluster and app shadow the root's PersistentPreRunE, so each is checked.
+	for _, args := range [][]string{
+		{"cluster", "delete", "dev", "--force"},
+		{"app", "uninstall", "dev", "--yes"},
+		{"dns", "setup", "--write"},
+	} {
+		root := GetRootCmd(DefaultVersionInfo)
+		root.SetArgs(args)
+		root.SetOut(io.Discard)
+		_, err := root.ExecuteC()
+		if !errors.Is(err, readonly.ErrReadOnly) {
+			t.Errorf("%v: got %v, want a read-only refusal", args, err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServicesContract_Flags(t *testing.T) {
+	cmd := GetServicesCmd()
+	require.NotNil(t, cmd.RunE)
+	assert.Equal(t, "true", cmd.Annotations["readonly"])
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "context", Shorthand: "c", Type: "string", Default: ""},
+		{Name: "show-secrets", Type: "bool", Default: "false"},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
@@ -0,0 +1,144 @@
+// Package services implements `openframe services`: a connection cheat-sheet
+// for the datastores OpenFrame deploys, so reaching MongoDB, Redis or Kafka
+// after an install needs no digging through Services and Secrets.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/connections"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// hidden stands in for a secret value unless --show-secrets is given.
+const hidden = "******"
+
+// GetServicesCmd returns the `openframe services` command.
+func GetServicesCmd() *cobra.Command {
+	var (
+		contextName string
+		showSecrets bool
+		output      string
+	)
+	cmd := &cobra.Command{
+		Use:   "services",
+		Short: "Print connection details for the deployed datastores",
+		Long: `Print how to connect to the datastores the OpenFrame charts deploy: MongoDB,
+Redis, Kafka, Cassandra, PostgreSQL, NATS, Pinot and ZooKeeper, and to the
+ports the add-ons declare, such as MinIO, LocalStack and MailHog (see
+'openframe addon list').
+
+For each one found, the in-cluster address, any node port, load balancer or
+Ingress host, and a port-forward command are listed, with the Secret keys its
+pods read credentials from. Secret values are masked unless --show-secrets is
+given.`,
+		Example: `  openframe services
+  openframe services --show-secrets
+  openframe services -o json --context k3d-openframe-dev`,
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{"readonly": "true"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output format %q (use text or json)", output)
+			}
+			if err := cluster.ResumeIdleContext(cmd.Context(), contextName, output != "text"); err != nil {
+				return err
+			}
+			cfg, err := k8s.RestConfigForContextFlag(contextName)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			cs, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				return fmt.Errorf("could not connect to the cluster: %w", err)
+			}
+			conns, err := connections.Discover(cmd.Context(), cs, showSecrets, addonKinds()...)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				b, err := json.MarshalIndent(conns, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			if len(conns) == 0 {
+				pterm.Info.Println("No known datastores found. Is OpenFrame installed? Check with `openframe app status`.")
+				return nil
+			}
+			render(conns, showSecrets)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Kube-context to use (defaults to the current context)")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print credential values instead of masking them")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// addonKinds are the ports the known add-ons declare. A definition that does
+// not parse leaves them out rather than failing the cheat-sheet.
+func addonKinds() []connections.Kind {
+	addons, err := addon.List()
+	if err != nil {
+		return nil
+	}
+	var kinds []connections.Kind
+	for _, a := range addons {
+		for _, p := range a.Ports {
+			name := p.Name
+			if name == "" {
+				name = a.Name
+			}
+			kinds = append(kinds, connections.Kind{Name: name, Match: p.Service, Port: p.Port, Scheme: p.Scheme})
+		}
+	}
+	return kinds
+}
+
+func render(conns []connections.Connection, showSecrets bool) {
+	for _, c := range conns {
+		pterm.DefaultSection.Printf("%s (%s/%s)\n", c.Kind, c.Namespace, c.Service)
+		pterm.Printf("  In cluster:   %s\n", c.InCluster)
+		if c.NodePort != 0 {
+			pterm.Printf("  Node port:    %d\n", c.NodePort)
+		}
+		for _, e := range c.External {
+			pterm.Printf("  External:     %s\n", e)
+		}
+		pterm.Printf("  Port-forward: %s\n", c.PortForward())
+		for _, s := range c.Secrets {
+			value := hidden
+			if showSecrets {
+				value = s.Value
+			}
+			label := s.Key
+			if s.Env != "" {
+				label = s.Env
+			}
+			pterm.Printf("  %s = %s  (secret %s, key %s)\n", label, value, s.Secret, s.Key)
+		}
+	}
+	if !showSecrets && hasSecrets(conns) {
+		pterm.Info.Println("Credential values are masked; rerun with --show-secrets to print them.")
+	}
+}
+
+func hasSecrets(conns []connections.Connection) bool {
+	for _, c := range conns {
+		if len(c.Secrets) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusContract_Subcommands(t *testing.T) {
+	testutil.AssertSubcommands(t, GetStatusCmd(), "serve")
+}
+
+func TestStatusContract_ServeFlags(t *testing.T) {
+	serve := testutil.FindSubcommand(t, GetStatusCmd(), "serve")
+	require.NotNil(t, serve.RunE)
+	assert.Equal(t, "true", serve.Annotations["readonly"])
+	testutil.AssertFlags(t, serve, []testutil.FlagSpec{
+		{Name: "port", Type: "int", Default: "8090"},
+		{Name: "address", Type: "string", Default: "127.0.0.1"},
+	})
+}
@@ -0,0 +1,147 @@
+// Package status implements `openframe status`: cluster and platform
+// readiness for machines rather than people.
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/idle"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/statusserver"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// GetStatusCmd returns the `openframe status` command.
+func GetStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Expose cluster and platform readiness to CI and scripts",
+		Long: `Report the readiness of the managed clusters and their OpenFrame applications
+in a form CI jobs and scripts can poll, instead of parsing console output.`,
+	}
+	cmd.AddCommand(getServeCmd())
+	return cmd
+}
+
+func getServeCmd() *cobra.Command {
+	var (
+		port    int
+		address string
+	)
+	cmd := &cobra.Command{
+		Use:   "serve [CLUSTER...]",
+		Short: "Serve /healthz and /status over HTTP",
+		Long: `Serve the readiness of the managed clusters (all of them, or the ones named)
+over HTTP until interrupted.
+
+  GET /status   200 with JSON: each cluster's nodes, ArgoCD applications
+                (sync and health) and whether it is ready
+  GET /healthz  200 {"ready": true} when every cluster is ready, 503 otherwise
+
+A cluster is ready when all its nodes are Ready and all its applications are
+Synced and Healthy. Each request probes the clusters afresh; clusters paused by
+'cluster idle-watch' are reported as paused, not woken. The server listens on
+localhost unless --address says otherwise.`,
+		Example: `  openframe status serve
+  openframe status serve dev --port 9000
+  until curl -fs localhost:8090/healthz; do sleep 10; done`,
+		ValidArgsFunction: completion.ClusterNames(),
+		Annotations:       map[string]string{"readonly": "true"},
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if port < 1 || port > 65535 {
+				return fmt.Errorf("--port must be between 1 and 65535, got %d", port)
+			}
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			exec := executor.NewRealCommandExecutor(false, verbose)
+			service := cluster.NewClusterServiceSuppressed(exec)
+			collector := statusserver.Collector{
+				Clusters: func(context.Context) ([]models.ClusterInfo, error) { return service.ListClusters() },
+				Paused:   idle.PausedClusters,
+				Client: func(ctx context.Context, name string) (kubernetes.Interface, error) {
+					cfg, err := restConfig(ctx, name)
+					if err != nil {
+						return nil, err
+					}
+					return kubernetes.NewForConfig(cfg)
+				},
+				Applications: func(ctx context.Context, name string) ([]argocd.Application, error) {
+					cfg, err := restConfig(ctx, name)
+					if err != nil {
+						return nil, err
+					}
+					mgr, err := argocd.NewManagerWithConfig(exec, cfg)
+					if err != nil {
+						return nil, err
+					}
+					return mgr.ListApplications(ctx, false)
+				},
+				Only: args,
+			}
+			return serve(cmd.Context(), net.JoinHostPort(address, strconv.Itoa(port)), statusserver.NewHandler(collector.Collect))
+		},
+	}
+	cmd.Flags().IntVar(&port, "port", 8090, "Port to listen on")
+	cmd.Flags().StringVar(&address, "address", "127.0.0.1", "Address to listen on (0.0.0.0 for every interface)")
+	return cmd
+}
+
+// dialTimeout bounds the check that a cluster's API server accepts
+// connections, so a stopped cluster is reported at once.
+const dialTimeout = 5 * time.Second
+
+// restConfig reads the client config of the cluster called name and checks
+// its API server is up. It only reads the kubeconfig: serving status must not
+// rewrite it or switch the current context.
+func restConfig(ctx context.Context, name string) (*rest.Config, error) {
+	cfg, err := k8s.RestConfigForCluster(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := k8s.DialAPI(ctx, cfg, dialTimeout); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// serve runs handler on addr until ctx is cancelled, then shuts down
+// gracefully.
+func serve(ctx context.Context, addr string, handler http.Handler) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	pterm.Info.Printf("Serving http://%s/healthz and /status; press Ctrl+C to stop\n", ln.Addr())
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-done; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Freezes the `telemetry` command tree: scripts and docs toggle it by name.
+
+func TestTelemetryContract_Shape(t *testing.T) {
+	cmd := GetTelemetryCmd()
+
+	assert.Equal(t, "telemetry", cmd.Name())
+	testutil.AssertSubcommands(t, cmd, "on", "off", "status")
+
+	on := testutil.FindSubcommand(t, cmd, "on")
+	require.NotNil(t, on.RunE)
+	testutil.AssertFlags(t, on, []testutil.FlagSpec{
+		{Name: "endpoint", Type: "string", Default: ""},
+	})
+
+	status := testutil.FindSubcommand(t, cmd, "status")
+	require.NotNil(t, status.RunE)
+	testutil.AssertFlags(t, status, []testutil.FlagSpec{
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
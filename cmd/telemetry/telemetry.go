@@ -0,0 +1,116 @@
+// Package telemetry implements `openframe telemetry`: opt in to, opt out of,
+// or inspect the anonymous install telemetry.
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetTelemetryCmd returns the `openframe telemetry` command tree.
+func GetTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage anonymous install telemetry (off by default)",
+		Long: `Anonymous install telemetry is OFF unless you turn it on. When on, each
+command reports only: the command name (no arguments), its duration, OS and
+architecture, CLI version, whether it succeeded, and — on failure — the phase
+it failed in (e.g. cluster-create, argocd-sync). No cluster names, paths, error
+messages or machine identifiers are sent; events carry a random ID created
+when you opt in and discarded when you opt out.
+
+OPENFRAME_TELEMETRY=0|1 overrides the saved choice for one environment, and
+DO_NOT_TRACK=1 always disables it. OPENFRAME_TELEMETRY_ENDPOINT overrides the
+endpoint events are sent to.`,
+		Example: `  openframe telemetry status
+  openframe telemetry on
+  openframe telemetry on --endpoint https://telemetry.example.com/v1/events
+  openframe telemetry off`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newOnCmd(), newOffCmd(), newStatusCmd())
+	return cmd
+}
+
+func newOnCmd() *cobra.Command {
+	var endpoint string
+	cmd := &cobra.Command{
+		Use:          "on",
+		Short:        "Opt in to anonymous install telemetry",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := telemetry.Enable(endpoint); err != nil {
+				return fmt.Errorf("enabling telemetry: %w", err)
+			}
+			pterm.Success.Println("Telemetry enabled. Thank you — it shows us where installs fail.")
+			warnInactive(telemetry.Current())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Send events to this URL instead of the default")
+	return cmd
+}
+
+func newOffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "off",
+		Short:        "Opt out of telemetry and forget the anonymous ID",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := telemetry.Disable(); err != nil {
+				return fmt.Errorf("disabling telemetry: %w", err)
+			}
+			pterm.Success.Println("Telemetry disabled.")
+			return nil
+		},
+	}
+}
+
+func newStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Show whether telemetry is on and where events go",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations:  map[string]string{"readonly": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st := telemetry.Current()
+			if format, _ := cmd.Flags().GetString("output"); format == "json" {
+				b, err := json.MarshalIndent(st, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			state := "off"
+			if st.Enabled {
+				state = "on"
+			}
+			pterm.Info.Printf("Telemetry is %s (decided by %s).\n", state, st.Source)
+			if st.Endpoint != "" {
+				pterm.Info.Printf("Endpoint: %s\n", st.Endpoint)
+			}
+			warnInactive(st)
+			return nil
+		},
+	}
+	cmd.Flags().StringP("output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// warnInactive explains an opt-in that sends nothing.
+func warnInactive(st telemetry.Status) {
+	switch {
+	case !st.Enabled && st.Source != "settings":
+		pterm.Warning.Printf("%s is overriding the saved choice; nothing is sent.\n", st.Source)
+	case st.Enabled && st.Endpoint == "":
+		pterm.Warning.Printf("No endpoint is configured for this build; nothing is sent until you pass --endpoint or set %s.\n", telemetry.EndpointEnv)
+	}
+}
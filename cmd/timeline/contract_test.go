@@ -0,0 +1,17 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineContract_Flags(t *testing.T) {
+	cmd := GetTimelineCmd()
+	require.NotNil(t, cmd.RunE)
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "all", Type: "bool", Default: "false"},
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text"},
+	})
+}
@@ -0,0 +1,109 @@
+// Package timeline implements `openframe timeline`: how long each phase of
+// recent install runs took.
+package timeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetTimelineCmd returns the `openframe timeline` command.
+func GetTimelineCmd() *cobra.Command {
+	var (
+		all    bool
+		output string
+	)
+	cmd := &cobra.Command{
+		Use:   "timeline",
+		Short: "Show how long each phase of the last install took",
+		Long: `Show the milestones of the most recent install run (prerequisites, cluster
+created, ArgoCD ready, each application synced) and how long each phase took.
+The last 20 runs that reached a milestone are kept; --all lists them, marked
+CI or local, so a slow CI run can be compared with a local one.`,
+		Example: `  openframe timeline
+  openframe timeline --all
+  openframe timeline -o json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations:  map[string]string{"readonly": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runs, err := timeline.Load()
+			if err != nil {
+				return fmt.Errorf("reading the timeline: %w", err)
+			}
+			if !all && len(runs) > 0 {
+				runs = runs[len(runs)-1:]
+			}
+			switch output {
+			case "json":
+				b, err := json.MarshalIndent(runs, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			case "text":
+			default:
+				return fmt.Errorf("unsupported output format %q (use text or json)", output)
+			}
+			if len(runs) == 0 {
+				pterm.Info.Println("No install runs recorded yet. Run `openframe bootstrap` or `openframe cluster create` first.")
+				return nil
+			}
+			if all {
+				renderRuns(runs)
+				return nil
+			}
+			renderRun(runs[0])
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "List every recorded run instead of the latest")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+func renderRun(r timeline.Run) {
+	pterm.Info.Printf("%s — %s, %s (%s)\n", r.Command, r.Started.Local().Format("2006-01-02 15:04"), result(r), where(r))
+	table := pterm.TableData{{"MILESTONE", "PHASE", "AT"}}
+	for _, p := range r.Phases() {
+		table = append(table, []string{p.Name, round(p.Duration), "+" + round(p.Offset)})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+	pterm.Info.Printf("Total: %s\n", round(r.Total()))
+}
+
+func renderRuns(runs []timeline.Run) {
+	table := pterm.TableData{{"STARTED", "COMMAND", "RESULT", "WHERE", "MILESTONES", "TOTAL"}}
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		table = append(table, []string{
+			r.Started.Local().Format("2006-01-02 15:04"), r.Command, result(r), where(r),
+			fmt.Sprint(len(r.Milestones)), round(r.Total()),
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+}
+
+func result(r timeline.Run) string {
+	if r.Success {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+func where(r timeline.Run) string {
+	if r.CI {
+		return "CI, " + r.Platform
+	}
+	return "local, " + r.Platform
+}
+
+func round(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
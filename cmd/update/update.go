@@ -73,6 +73,7 @@ func newCheckCmd(current string) *cobra.Command {
 		Short:        "Report whether an update is available, without changing anything",
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
+		Annotations:  map[string]string{"readonly": "true"},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			u := selfupdate.Updater{Current: current, Client: selfupdate.Client{Token: selfupdate.GitHubToken()}}
 
@@ -0,0 +1,97 @@
+// Package use implements `openframe use <cluster>`: switch kubectl to a
+// cluster and check that it is actually up.
+package use
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// probeTimeout bounds each half of the reachability check: the TCP dial and
+// the API request.
+const probeTimeout = 5 * time.Second
+
+// GetUseCmd returns the `openframe use` command.
+func GetUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <cluster>",
+		Short: "Switch the kubectl context to a cluster and check it is reachable",
+		Long: `Make a cluster's context the current-context of its kubeconfig, then check that
+its API server accepts connections and answers, and report its nodes.
+
+A cluster paused by 'cluster idle-watch' is resumed first. A cluster with an
+isolated kubeconfig (` + k8s.IsolationEnv + `) is switched in that file;
+point kubectl at it with 'openframe env'. The switch is kept even when the
+cluster turns out to be unreachable, which is reported as an error.`,
+		Example: `  openframe use dev
+  openframe use dev && kubectl get pods -A`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cluster.ResumeIdleCluster(cmd.Context(), args[0], false); err != nil {
+				pterm.Warning.Printf("Could not resume idle-paused cluster '%s': %v\n", args[0], err)
+			}
+			return Use(cmd.Context(), args[0])
+		},
+	}
+}
+
+// Use switches to name's context and reports whether the cluster answers.
+func Use(ctx context.Context, name string) error {
+	path := k8s.KubeconfigForCluster(name)
+	contextName := k8s.ResolveContextForCluster(path, name)
+	previous, err := k8s.UseContext(path, contextName)
+	if err != nil {
+		return fmt.Errorf("switching to cluster '%s': %w", name, err)
+	}
+	switch previous {
+	case contextName:
+		pterm.Success.Printf("Context %s was already current\n", contextName)
+	case "":
+		pterm.Success.Printf("Switched to context %s\n", contextName)
+	default:
+		pterm.Success.Printf("Switched to context %s (was %s)\n", contextName, previous)
+	}
+	if k8s.IsIsolated(path) {
+		pterm.Info.Printf("Context set in %s; run eval \"$(openframe env %s)\" for kubectl to use it\n", path, name)
+	}
+
+	restConfig, err := k8s.RestConfigForContext(path, contextName)
+	if err != nil {
+		return err
+	}
+	restConfig = sharedconfig.ApplyLocalTLSConfig(restConfig)
+	if err := k8s.DialAPI(ctx, restConfig, probeTimeout); err != nil {
+		return fmt.Errorf("cluster '%s' is not reachable: %w", name, err)
+	}
+	accessor, err := k8s.NewAccessorForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	health, err := accessor.CheckHealth(probeCtx)
+	if err != nil {
+		return fmt.Errorf("cluster '%s' is not reachable: %w", name, err)
+	}
+
+	status := fmt.Sprintf("API server %s reachable; %d/%d nodes Ready", restConfig.Host, health.NodesReady, health.NodesTotal)
+	if health.ServerVersion != "" {
+		status += " (Kubernetes " + health.ServerVersion + ")"
+	}
+	if health.NodesReady < health.NodesTotal || health.NodesTotal == 0 {
+		pterm.Warning.Println(status)
+	} else {
+		pterm.Success.Println(status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package use
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate points the kubeconfig at a file with a k3d-dev context for server
+// and keeps the user's ~/.openframe out of the lookups.
+func isolate(t *testing.T, server string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: v1
+kind: Config
+current-context: other
+clusters:
+- {name: k3d-dev, cluster: {server: `+server+`}}
+- {name: other, cluster: {server: https://other.example}}
+contexts:
+- {name: k3d-dev, context: {cluster: k3d-dev, user: admin}}
+- {name: other, context: {cluster: other, user: admin}}
+users:
+- {name: admin, user: {token: t}}
+`), 0o600))
+	t.Setenv("KUBECONFIG", path)
+	return path
+}
+
+func currentContext(t *testing.T, path string) string {
+	t.Helper()
+	_, current, err := k8s.LoadContexts(path)
+	require.NoError(t, err)
+	return current
+}
+
+func TestUseCmd_Contract(t *testing.T) {
+	cmd := GetUseCmd()
+	assert.Equal(t, "use <cluster>", cmd.Use)
+	require.NotNil(t, cmd.RunE)
+	assert.NotEqual(t, "true", cmd.Annotations["readonly"], "use rewrites the kubeconfig")
+	assert.Error(t, cmd.Args(cmd, nil))
+}
+
+func TestUse_SwitchesAndReportsNodes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/nodes", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"k3d-dev-server-0"},"status":{"conditions":[{"type":"Ready","status":"True"}]}}]}`))
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"gitVersion":"v1.31.5+k3s1"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	path := isolate(t, srv.URL)
+
+	require.NoError(t, Use(context.Background(), "dev"))
+	assert.Equal(t, "k3d-dev", currentContext(t, path))
+}
+
+func TestUse_UnreachableKeepsSwitch(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	path := isolate(t, "https://"+addr)
+
+	err = Use(context.Background(), "dev")
+	assert.ErrorContains(t, err, "cluster 'dev' is not reachable")
+	assert.Equal(t, "k3d-dev", currentContext(t, path))
+}
+
+func TestUse_UnknownCluster(t *testing.T) {
+	path := isolate(t, "https://127.0.0.1:6550")
+
+	err := Use(context.Background(), "missing")
+	assert.ErrorContains(t, err, `context "k3d-missing" not found`)
+	assert.Equal(t, "other", currentContext(t, path))
+}
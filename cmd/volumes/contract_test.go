@@ -0,0 +1,29 @@
+package volumes
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumesContract(t *testing.T) {
+	cmd := GetVolumesCmd()
+	testutil.AssertSubcommands(t, cmd, "backup", "restore")
+
+	backup := testutil.FindSubcommand(t, cmd, "backup")
+	require.NotNil(t, backup.RunE)
+	assert.Equal(t, "true", backup.Annotations["readonly"])
+	testutil.AssertFlags(t, backup, []testutil.FlagSpec{
+		{Name: "file", Shorthand: "f", Type: "string", Default: ""},
+	})
+
+	restore := testutil.FindSubcommand(t, cmd, "restore")
+	require.NotNil(t, restore.RunE)
+	assert.NotEqual(t, "true", restore.Annotations["readonly"], "restore is not read-only")
+	testutil.AssertFlags(t, restore, []testutil.FlagSpec{
+		{Name: "file", Shorthand: "f", Type: "string", Default: ""},
+		{Name: "yes", Shorthand: "y", Type: "bool", Default: "false"},
+	})
+}
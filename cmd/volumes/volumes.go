@@ -0,0 +1,186 @@
+// Package volumes implements `openframe volumes`: back up the persistent
+// volume data of a k3d cluster to a local archive and restore it into another,
+// so recreating a cluster does not lose developer data.
+package volumes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/flamingo-stack/openframe-cli/internal/volumes"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetVolumesCmd returns the `openframe volumes` command.
+func GetVolumesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volumes",
+		Short: "Back up and restore the persistent volumes of a cluster",
+		Long: `Back up and restore the data of a cluster's persistent volumes.
+
+k3d clusters keep volume data (MongoDB, Kafka, Redis, ...) inside their node
+containers, so deleting a cluster deletes it too. 'backup' copies every
+local-path volume into one archive; 'restore' copies it into the volumes of the
+same claims in another cluster — typically one just recreated and bootstrapped.`,
+	}
+	cmd.AddCommand(getBackupCmd(), getRestoreCmd())
+	return cmd
+}
+
+func getBackupCmd() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "backup <cluster>",
+		Short: "Archive the persistent volume data of a cluster",
+		Long: `Archive the data of every local-path persistent volume of a k3d cluster.
+
+Each volume is copied out of its node container with tar and stored under its
+claim's namespace and name, which is how 'restore' matches it in another
+cluster. The archive is written to --file, by default
+<cluster>-volumes-<timestamp>.tar.gz in the current directory. Applications
+keep running; for a consistent copy of a database, stop writing to it first.`,
+		Example: `  openframe volumes backup dev
+  openframe volumes backup dev -f ~/backups/dev.tar.gz`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		Annotations:       map[string]string{"readonly": "true"},
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cs, exec, err := connect(cmd, name)
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				file = fmt.Sprintf("%s-volumes-%s.tar.gz", name, time.Now().Format("20060102-150405"))
+			}
+
+			// Write next to the target and rename, so a failed backup never
+			// leaves a truncated archive under the final name.
+			tmp, err := os.CreateTemp(filepath.Dir(file), ".openframe-volumes-*")
+			if err != nil {
+				return fmt.Errorf("creating the archive: %w", err)
+			}
+			defer func() { _ = os.Remove(tmp.Name()) }()
+			m, err := volumes.Backup(cmd.Context(), exec, cs, name, tmp, func(v volumes.Volume) {
+				pterm.Info.Printf("Backing up %s\n", v.Key())
+			})
+			if closeErr := tmp.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			if len(m.Volumes) == 0 {
+				pterm.Info.Printf("Cluster %s has no local-path volumes; nothing to back up\n", name)
+				return nil
+			}
+			if err := os.Rename(tmp.Name(), file); err != nil {
+				return fmt.Errorf("saving the archive: %w", err)
+			}
+			pterm.Success.Printf("Backed up %d volume(s) of %s to %s\n", len(m.Volumes), name, file)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Archive to write (default <cluster>-volumes-<timestamp>.tar.gz)")
+	return cmd
+}
+
+func getRestoreCmd() *cobra.Command {
+	var (
+		file string
+		yes  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "restore <cluster>",
+		Short: "Restore an archive made by backup into a cluster's volumes",
+		Long: `Restore the volume data archived by 'openframe volumes backup' into a cluster.
+
+Each archived volume replaces the data of the volume bound to the claim of the
+same namespace and name, wherever it now lives; claims the cluster does not
+have yet are skipped, so restore after the applications are installed. The
+StatefulSets and Deployments using a restored volume are scaled to zero while
+its data is replaced, then scaled back and waited for until Ready; any other
+pod using it is restarted to pick up the data.`,
+		Example: `  openframe volumes restore dev -f dev-volumes-20260101-120000.tar.gz
+  openframe volumes restore dev -f backup.tar.gz --yes`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ClusterNames(),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			f, err := os.Open(file) //nolint:gosec // G304: the archive path is the user's choice
+			if err != nil {
+				return fmt.Errorf("opening the archive: %w", err)
+			}
+			defer func() { _ = f.Close() }()
+			if !yes {
+				ok, err := ui.RequireConfirmation(
+					fmt.Sprintf("Replace the volume data of cluster %s with the contents of %s?", name, file), "--yes", false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					pterm.Info.Println("Restore cancelled.")
+					return nil
+				}
+			}
+			cs, exec, err := connect(cmd, name)
+			if err != nil {
+				return err
+			}
+			res, err := volumes.Restore(cmd.Context(), exec, cs, f, func(v volumes.Volume) {
+				pterm.Info.Printf("Restoring %s\n", v.Key())
+			})
+			if err != nil {
+				return err
+			}
+			for _, key := range res.Skipped {
+				pterm.Warning.Printf("Skipped %s: no such claim in %s (is its application installed?)\n", key, name)
+			}
+			pterm.Success.Printf("Restored %d volume(s) from the %s backup of %s; restarted %d workload(s) and %d other pod(s)\n",
+				len(res.Restored), res.Manifest.Created.Local().Format(time.DateTime), res.Manifest.Cluster, len(res.Stopped), res.Restarted)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Archive to restore")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// connect resumes the cluster if idle and returns a client and a Docker
+// executor for it. Only clusters openframe created with k3d have their volumes
+// in reach.
+func connect(cmd *cobra.Command, name string) (kubernetes.Interface, executor.CommandExecutor, error) {
+	if err := models.ValidateClusterName(name); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := k8s.LookupExternalCluster(name); ok {
+		return nil, nil, fmt.Errorf("%s is an attached cluster; volumes can only be backed up from k3d clusters openframe created", name)
+	}
+	if err := cluster.ResumeIdleCluster(cmd.Context(), name, false); err != nil {
+		return nil, nil, err
+	}
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	path := k8s.KubeconfigForCluster(name)
+	cfg, err := k8s.RestConfigForContext(path, k8s.ResolveContextForCluster(path, name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to cluster %s: %w", name, err)
+	}
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to cluster %s: %w", name, err)
+	}
+	return cs, executor.NewRealCommandExecutor(false, verbose), nil
+}
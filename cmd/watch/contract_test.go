@@ -0,0 +1,20 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchContract_Flags(t *testing.T) {
+	cmd := GetWatchCmd()
+	require.NotNil(t, cmd.RunE)
+	assert.Equal(t, "true", cmd.Annotations["readonly"])
+	testutil.AssertFlags(t, cmd, []testutil.FlagSpec{
+		{Name: "interval", Type: "duration", Default: "1m0s"},
+		{Name: "notify", Type: "bool", Default: "true"},
+		{Name: "log-file", Type: "string", Default: ""},
+	})
+}
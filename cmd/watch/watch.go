@@ -0,0 +1,145 @@
+// Package watch implements `openframe watch`: a foreground monitor that
+// reports when a local cluster degrades.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/cmd/completion"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/idle"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/watch"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetWatchCmd returns the `openframe watch` command.
+func GetWatchCmd() *cobra.Command {
+	var (
+		interval time.Duration
+		notify   bool
+		logFile  string
+	)
+	cmd := &cobra.Command{
+		Use:   "watch [CLUSTER...]",
+		Short: "Monitor clusters and report when one degrades",
+		Long: `Watch your clusters and report as soon as one degrades, so a broken local
+environment shows up before the next deploy fails.
+
+Every --interval each cluster (all of them, or the ones named) is checked
+for an API server that stops answering, nodes that drop out of Ready or come
+under disk pressure, and ArgoCD applications that drift out of sync or turn
+Degraded. Changes are printed, appended to --log-file, and shown as desktop
+notifications (notify-send on Linux, osascript on macOS). A cluster that
+stays broken is reported once, and again when it recovers. Clusters paused
+by 'cluster idle-watch' are skipped.
+
+The watcher runs in the foreground; background it with your shell.`,
+		Example: `  openframe watch
+  openframe watch dev --interval 30s
+  nohup openframe watch --log-file ~/.openframe/logs/watch.log >/dev/null 2>&1 &`,
+		ValidArgsFunction: completion.ClusterNames(),
+		Annotations:       map[string]string{"readonly": "true"},
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive, got %s", interval)
+			}
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			exec := executor.NewRealCommandExecutor(false, verbose)
+			service := cluster.NewClusterServiceSuppressed(exec)
+
+			notifiers := []watch.Notifier{consoleNotifier{}}
+			if logFile != "" {
+				f, err := openLog(logFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				notifiers = append(notifiers, watch.NewLogNotifier(f))
+			}
+			if notify {
+				if desktop, ok := watch.NewDesktopNotifier(exec); ok {
+					notifiers = append(notifiers, desktop)
+				} else {
+					pterm.Info.Println("Desktop notifications are unavailable here (no notify-send/osascript); reporting to the terminal only")
+				}
+			}
+
+			w := watch.NewWatcher(clusterLister(service, args), watch.KubeProber{RestConfig: k8s.RestConfigForCluster, Exec: exec}, notifiers...)
+			w.Interval = interval
+
+			target := "all clusters"
+			if len(args) > 0 {
+				target = fmt.Sprint(args)
+			}
+			pterm.Info.Printf("Watching %s every %s; press Ctrl+C to stop\n", target, interval)
+			if err := w.Run(cmd.Context()); err != nil && cmd.Context().Err() == nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", watch.DefaultInterval, "How often to check each cluster")
+	cmd.Flags().BoolVar(&notify, "notify", true, "Show desktop notifications")
+	cmd.Flags().StringVar(&logFile, "log-file", "", "Also append every change to this file")
+	return cmd
+}
+
+// clusterLister returns the clusters to watch each round: the named ones, or
+// every cluster, minus the ones idle-watch paused on purpose.
+func clusterLister(service *cluster.ClusterService, only []string) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		clusters, err := service.ListClusters()
+		if err != nil {
+			return nil, err
+		}
+		paused, _ := idle.PausedClusters()
+		skip := map[string]bool{}
+		for _, name := range paused {
+			skip[name] = true
+		}
+		wanted := map[string]bool{}
+		for _, name := range only {
+			wanted[name] = true
+		}
+
+		var names []string
+		for _, c := range clusters {
+			if skip[c.Name] || (len(only) > 0 && !wanted[c.Name]) {
+				continue
+			}
+			names = append(names, c.Name)
+		}
+		return names, nil
+	}
+}
+
+func openLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("creating log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // G304: user-chosen log file
+	if err != nil {
+		return nil, fmt.Errorf("opening log file: %w", err)
+	}
+	return f, nil
+}
+
+// consoleNotifier prints events to the terminal.
+type consoleNotifier struct{}
+
+func (consoleNotifier) Notify(e watch.Event) {
+	line := fmt.Sprintf("%s [%s] %s: %s", e.Time.Format("15:04:05"), e.Check, e.Cluster, e.Message)
+	if e.Degraded {
+		pterm.Warning.Println(line)
+	} else {
+		pterm.Success.Println(line)
+	}
+}
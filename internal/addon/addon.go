@@ -0,0 +1,186 @@
+// Package addon deploys optional services next to the platform — local
+// emulators like MinIO, LocalStack and MailHog, or anything a team needs for
+// development. An add-on is declared in YAML: a pinned helm chart or a set of
+// manifests, a health check, the ports it serves and hooks that run on this
+// machine when it is installed and before it is removed or its cluster
+// deleted.
+//
+// Definitions come from three places, in order: ~/.openframe/addons/NAME.yaml,
+// the ones built into the CLI, and a registry — a base URL serving NAME.yaml,
+// set with --registry or addons.registry in ~/.openframe/config.json. A
+// definition fetched from a file, URL or registry is saved in
+// ~/.openframe/addons, so the hooks it declares still run when its cluster is
+// deleted.
+package addon
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultNamespace holds every add-on that names no namespace of its own, out
+// of the platform's namespaces.
+const DefaultNamespace = "openframe-addons"
+
+// defaultHealthTimeout bounds the health check when the definition sets none.
+const defaultHealthTimeout = 5 * time.Minute
+
+var validName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Addon is one add-on definition.
+type Addon struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Namespace is where it is installed; DefaultNamespace when empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Chart and Manifests are what it deploys; exactly one is set.
+	Chart *Chart `json:"chart,omitempty"`
+	// Manifests are files, directories or URLs, as for `openframe apply -f`.
+	// Relative paths are relative to the definition.
+	Manifests   []string     `json:"manifests,omitempty"`
+	Healthcheck *Healthcheck `json:"healthcheck,omitempty"`
+	Ports       []Port       `json:"ports,omitempty"`
+	Hooks       Hooks        `json:"hooks,omitempty"`
+
+	// Source is where the definition was read from: "built-in", a file or a
+	// URL. Fetched is set when Resolve has just downloaded it, so its hooks
+	// have not been seen on this machine yet.
+	Source  string `json:"-"`
+	Fetched bool   `json:"-"`
+
+	// saveFrom is the file or URL Resolve read a definition from that Save
+	// is to keep; "" for one already in ~/.openframe/addons or built in.
+	saveFrom string
+}
+
+// Chart is a pinned helm chart.
+type Chart struct {
+	Repo    string `json:"repo"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Values are passed to helm as a values file.
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// Healthcheck is how to tell the add-on is up: every pod Selector matches in
+// its namespace is Ready, and there is at least one.
+type Healthcheck struct {
+	Selector string `json:"selector"`
+	// Timeout is a duration such as 3m; five minutes when empty.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Port is an endpoint the add-on serves, shown by `openframe addon list` and
+// `openframe services`.
+type Port struct {
+	// Name labels the endpoint; the add-on's name when empty.
+	Name    string `json:"name,omitempty"`
+	Service string `json:"service"`
+	Port    int32  `json:"port"`
+	// Scheme is the URL scheme of its clients, empty for plain host:port.
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Hooks are shell commands run on this machine with sh -c, in order, with
+// OPENFRAME_CLUSTER, OPENFRAME_ADDON, ADDON_NAMESPACE and KUBE_CONTEXT set.
+type Hooks struct {
+	// PostInstall runs once the add-on is healthy, at `addon install` and at
+	// `cluster create --addons`.
+	PostInstall []string `json:"postInstall,omitempty"`
+	// PreRemove runs before the add-on is removed and before its cluster is
+	// deleted, e.g. to export data.
+	PreRemove []string `json:"preRemove,omitempty"`
+}
+
+// Parse reads a definition; source is recorded as its Source.
+func Parse(data []byte, source string) (Addon, error) {
+	var a Addon
+	if err := yaml.UnmarshalStrict(data, &a); err != nil {
+		return Addon{}, fmt.Errorf("%s: %w", source, err)
+	}
+	a.Source = source
+	if err := a.validate(); err != nil {
+		return Addon{}, fmt.Errorf("%s: %w", source, err)
+	}
+	return a, nil
+}
+
+func (a Addon) validate() error {
+	if !validName.MatchString(a.Name) || len(a.Name) > 53 {
+		return fmt.Errorf("name %q must be lowercase letters, digits and dashes, at most 53 characters", a.Name)
+	}
+	switch {
+	case a.Chart == nil && len(a.Manifests) == 0:
+		return fmt.Errorf("add-on %s deploys nothing: set chart or manifests", a.Name)
+	case a.Chart != nil && len(a.Manifests) > 0:
+		return fmt.Errorf("add-on %s sets both chart and manifests; use one", a.Name)
+	case a.Chart != nil && (a.Chart.Repo == "" || a.Chart.Name == "" || a.Chart.Version == ""):
+		return fmt.Errorf("add-on %s: chart needs repo, name and version", a.Name)
+	}
+	if a.Healthcheck != nil {
+		if a.Healthcheck.Selector == "" {
+			return fmt.Errorf("add-on %s: healthcheck needs a selector", a.Name)
+		}
+		if _, err := a.healthTimeout(); err != nil {
+			return err
+		}
+	}
+	for _, p := range a.Ports {
+		if p.Service == "" || p.Port <= 0 {
+			return fmt.Errorf("add-on %s: every port needs a service and a port number", a.Name)
+		}
+	}
+	return nil
+}
+
+// namespace is where a is installed.
+func (a Addon) namespace() string {
+	if a.Namespace != "" {
+		return a.Namespace
+	}
+	return DefaultNamespace
+}
+
+func (a Addon) healthTimeout() (time.Duration, error) {
+	if a.Healthcheck == nil || a.Healthcheck.Timeout == "" {
+		return defaultHealthTimeout, nil
+	}
+	d, err := time.ParseDuration(a.Healthcheck.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("add-on %s: invalid healthcheck timeout %q", a.Name, a.Healthcheck.Timeout)
+	}
+	return d, nil
+}
+
+// chartValues is the chart's values as YAML, empty when it sets none.
+func (a Addon) chartValues() (string, error) {
+	if a.Chart == nil || len(a.Chart.Values) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(a.Chart.Values)
+	if err != nil {
+		return "", fmt.Errorf("add-on %s: encoding chart values: %w", a.Name, err)
+	}
+	return string(data), nil
+}
+
+// PrePullChart is a's chart for the image pre-pull of cluster create; ok is
+// false for an add-on made of manifests.
+func (a Addon) PrePullChart() (prepull.Chart, bool) {
+	if a.Chart == nil {
+		return prepull.Chart{}, false
+	}
+	values, _ := a.chartValues() // validated when the definition was parsed
+	return prepull.Chart{
+		Release:   a.Name,
+		Name:      a.Chart.Name,
+		Repo:      a.Chart.Repo,
+		Version:   a.Chart.Version,
+		Namespace: a.namespace(),
+		Values:    values,
+	}, true
+}
@@ -0,0 +1,258 @@
+package addon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// useTempHome points the add-on directory, state and config at a temp dir.
+func useTempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	origDir, origState, origConfig := userDir, stateFile, sharedconfig.UserConfigFile
+	userDir = func() (string, error) { return filepath.Join(home, "addons"), nil }
+	stateFile = func() (string, error) { return filepath.Join(home, "state", "addons.json"), nil }
+	sharedconfig.UserConfigFile = func() (string, error) { return filepath.Join(home, "config.json"), nil }
+	t.Cleanup(func() { userDir, stateFile, sharedconfig.UserConfigFile = origDir, origState, origConfig })
+	return home
+}
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const keycloak = `name: keycloak
+manifests: [keycloak.yaml]
+healthcheck:
+  selector: app=keycloak
+ports:
+  - service: keycloak
+    port: 8080
+    scheme: http
+hooks:
+  preRemove: ["./export-realms.sh"]
+`
+
+func TestParse_Validates(t *testing.T) {
+	for _, tc := range []struct{ yaml, want string }{
+		{"name: Bad_Name\nmanifests: [a.yaml]\n", "lowercase"},
+		{"name: x\n", "deploys nothing"},
+		{"name: x\nmanifests: [a.yaml]\nchart: {repo: r, name: n, version: v}\n", "both chart and manifests"},
+		{"name: x\nchart: {repo: r, name: n}\n", "repo, name and version"},
+		{"name: x\nmanifests: [a.yaml]\nhealthcheck: {timeout: 1m}\n", "needs a selector"},
+		{"name: x\nmanifests: [a.yaml]\nhealthcheck: {selector: a=b, timeout: soon}\n", "invalid healthcheck timeout"},
+		{"name: x\nmanifests: [a.yaml]\nports: [{service: x}]\n", "service and a port number"},
+		{"name: x\nmanifests: [a.yaml]\nsurprise: 1\n", "unknown field"},
+	} {
+		_, err := Parse([]byte(tc.yaml), "test.yaml")
+		assert.ErrorContains(t, err, tc.want, tc.yaml)
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	addons, err := builtins()
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, a := range addons {
+		names[a.Name] = true
+		_, ok := a.PrePullChart()
+		assert.True(t, ok, "%s is a pinned chart", a.Name)
+	}
+	assert.Equal(t, map[string]bool{"minio": true, "localstack": true, "mailhog": true}, names)
+}
+
+func TestListAndLoad_UserDefinitionsReplaceBuiltins(t *testing.T) {
+	home := useTempHome(t)
+	writeFile(t, filepath.Join(home, "addons", "mailhog.yaml"), "name: mailhog\nmanifests: [mailhog/]\n")
+	writeFile(t, filepath.Join(home, "addons", "keycloak.yaml"), keycloak)
+
+	a, err := Load("mailhog")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(home, "addons", "mailhog")}, a.Manifests, "relative to the definition")
+
+	addons, err := List()
+	require.NoError(t, err)
+	var names []string
+	for _, a := range addons {
+		names = append(names, a.Name+"="+filepath.Base(a.Source))
+	}
+	assert.Equal(t, []string{"keycloak=keycloak.yaml", "localstack=built-in", "mailhog=mailhog.yaml", "minio=built-in"}, names)
+
+	_, err = Load("../config")
+	assert.ErrorContains(t, err, "invalid add-on name")
+	_, err = Load("vault")
+	assert.ErrorContains(t, err, "unknown add-on")
+}
+
+func TestResolve_FileIsSaved(t *testing.T) {
+	home := useTempHome(t)
+	src := writeFile(t, filepath.Join(t.TempDir(), "keycloak.yaml"), keycloak)
+
+	addons, err := Resolve(context.Background(), []string{src, "minio", "minio"}, "")
+	require.NoError(t, err)
+	require.Len(t, addons, 2, "no repeats")
+	assert.False(t, addons[0].Fetched)
+	assert.Equal(t, []string{filepath.Join(filepath.Dir(src), "keycloak.yaml")}, addons[0].Manifests)
+	_, err = Load("keycloak")
+	require.ErrorIs(t, err, errUnknown, "nothing is saved before Save")
+
+	require.NoError(t, Save(addons))
+	saved, err := Load("keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "addons", "keycloak.yaml"), saved.Source)
+	assert.Equal(t, addons[0].Manifests, saved.Manifests, "the saved copy still finds the manifests")
+}
+
+func TestResolve_FetchesFromRegistry(t *testing.T) {
+	home := useTempHome(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/addons/keycloak.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(keycloak))
+	}))
+	defer srv.Close()
+
+	_, err := Resolve(context.Background(), []string{"keycloak"}, "")
+	assert.ErrorContains(t, err, "or pass --registry")
+
+	writeFile(t, filepath.Join(home, "config.json"), `{"addons": {"registry": "`+srv.URL+`/addons/"}}`)
+	addons, err := Resolve(context.Background(), []string{"keycloak"}, "")
+	require.NoError(t, err)
+	require.Len(t, addons, 1)
+	assert.True(t, addons[0].Fetched)
+	assert.Equal(t, []string{srv.URL + "/addons/keycloak.yaml"}, addons[0].Manifests, "relative to the definition's URL")
+
+	_, err = Resolve(context.Background(), []string{"vault"}, srv.URL)
+	assert.ErrorContains(t, err, "404")
+
+	require.NoError(t, Save(addons))
+	saved, err := Load("keycloak")
+	require.NoError(t, err)
+	assert.False(t, saved.Fetched, "installed again by name, its hooks are not new")
+	assert.Equal(t, addons[0].Manifests, saved.Manifests)
+}
+
+func TestResolve_BrokenLocalDefinitionIsNotReplaced(t *testing.T) {
+	home := useTempHome(t)
+	fetched := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		_, _ = w.Write([]byte(keycloak))
+	}))
+	defer srv.Close()
+	writeFile(t, filepath.Join(home, "addons", "keycloak.yaml"), "name: [broken")
+
+	_, err := Resolve(context.Background(), []string{"keycloak"}, srv.URL)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errUnknown)
+	assert.False(t, fetched, "a local definition that does not parse is reported, not overwritten")
+}
+
+func readyPod(name, app string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace, Labels: map[string]string{"app": app}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+}
+
+func TestResolve_HooksFailUnderSandboxEnforce(t *testing.T) {
+	useTempHome(t)
+	src := writeFile(t, filepath.Join(t.TempDir(), "keycloak.yaml"), keycloak)
+	t.Setenv(executor.SandboxEnv, executor.SandboxEnforce)
+
+	_, err := Resolve(context.Background(), []string{src}, "")
+	assert.ErrorContains(t, err, "--sandbox enforce refuses")
+	_, err = Resolve(context.Background(), []string{"minio"}, "")
+	assert.NoError(t, err, "an add-on without hooks is fine")
+
+	t.Setenv(executor.SandboxEnv, executor.SandboxLog)
+	_, err = Resolve(context.Background(), []string{src}, "")
+	assert.NoError(t, err)
+}
+
+func TestInstaller_InstallAndRemoveChart(t *testing.T) {
+	useTempHome(t)
+	a, err := Parse([]byte(`name: minio
+chart: {repo: https://charts.min.io, name: minio, version: 5.4.0, values: {mode: standalone}}
+healthcheck: {selector: app=minio}
+hooks:
+  postInstall: ["mc alias set local http://localhost:9000"]
+  preRemove: ["./backup.sh"]
+`), "test")
+	require.NoError(t, err)
+	exec := executor.NewMockCommandExecutor()
+	installer := NewInstaller(exec, "dev", nil).WithClient(fake.NewClientset(readyPod("minio-0", "minio")))
+
+	require.NoError(t, installer.Install(context.Background(), a))
+	cmds := exec.Commands()
+	require.Len(t, cmds, 2)
+	assert.Equal(t, "helm upgrade --install minio minio --repo https://charts.min.io --version 5.4.0 --namespace openframe-addons --create-namespace --kube-context k3d-dev --wait --timeout 5m -f -", cmds[0].String())
+	assert.Equal(t, "mode: standalone\n", string(cmds[0].Stdin))
+	assert.Equal(t, "sh -c mc alias set local http://localhost:9000", cmds[1].String())
+	assert.Equal(t, map[string]string{"OPENFRAME_CLUSTER": "dev", "OPENFRAME_ADDON": "minio", "ADDON_NAMESPACE": "openframe-addons", "KUBE_CONTEXT": "k3d-dev"}, cmds[1].Env)
+	installed, err := InstalledOn("dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"minio"}, installed)
+
+	exec.SetResponse("backup.sh", &executor.CommandResult{ExitCode: 1})
+	assert.ErrorContains(t, installer.Remove(context.Background(), a), `hook "./backup.sh" failed`)
+	assert.Len(t, exec.Commands(), 3, "a failed pre-remove hook stops the removal")
+
+	exec.SetResponse("backup.sh", &executor.CommandResult{})
+	require.NoError(t, installer.Remove(context.Background(), a))
+	assert.Equal(t, "helm uninstall minio --namespace openframe-addons --kube-context k3d-dev --ignore-not-found --wait", exec.Commands()[4].String())
+	installed, err = InstalledOn("dev")
+	require.NoError(t, err)
+	assert.Empty(t, installed)
+}
+
+func TestInstaller_UnhealthyAddon(t *testing.T) {
+	useTempHome(t)
+	a, err := Parse([]byte("name: minio\nchart: {repo: r, name: minio, version: 1}\nhealthcheck: {selector: app=minio, timeout: 10ms}\nhooks: {postInstall: [true]}\n"), "test")
+	require.NoError(t, err)
+	exec := executor.NewMockCommandExecutor()
+	notReady := readyPod("minio-0", "minio")
+	notReady.Status = corev1.PodStatus{Phase: corev1.PodPending}
+
+	err = NewInstaller(exec, "dev", nil).WithClient(fake.NewClientset(notReady)).Install(context.Background(), a)
+	assert.ErrorContains(t, err, "not healthy after 10ms: pod minio-0 is Pending")
+	assert.Len(t, exec.Commands(), 1, "no hooks for an unhealthy add-on")
+	installed, err := InstalledOn("dev")
+	require.NoError(t, err)
+	assert.Empty(t, installed)
+}
+
+func TestForget(t *testing.T) {
+	useTempHome(t)
+	require.NoError(t, markInstalled("dev", "minio"))
+	require.NoError(t, markInstalled("dev", "mailhog"))
+	require.NoError(t, markInstalled("demo", "minio"))
+	require.NoError(t, Forget("dev"))
+
+	installed, err := Installed()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"demo": {"minio"}}, installed)
+}
+
+func TestConfirmHooks_OnlyAsksForNewHooks(t *testing.T) {
+	seen := Addon{Name: "a", Hooks: Hooks{PostInstall: []string{"true"}}}
+	noHooks := Addon{Name: "b", Fetched: true}
+	assert.NoError(t, ConfirmHooks([]Addon{seen, noHooks}, "--force"))
+}
@@ -0,0 +1,295 @@
+package addon
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"sigs.k8s.io/yaml"
+)
+
+// BuiltinSource is the Source of the definitions built into the CLI.
+const BuiltinSource = "built-in"
+
+// maxDefinitionSize caps a fetched definition.
+const maxDefinitionSize = 1 << 20
+
+// fetchTimeout bounds fetching a definition from a URL or registry.
+const fetchTimeout = 30 * time.Second
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// userDir is where definitions are kept; a variable so tests can redirect it.
+var userDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "addons"), nil
+}
+
+// errUnknown is wrapped by Load's error for a name it does not know.
+var errUnknown = errors.New("unknown add-on")
+
+// List returns every add-on known locally, sorted by name: the ones in
+// ~/.openframe/addons and the built-in ones they do not replace.
+func List() ([]Addon, error) {
+	byName := map[string]Addon{}
+	builtin, err := builtins()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range builtin {
+		byName[a.Name] = a
+	}
+	dir, err := userDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		a, err := readFile(f)
+		if err != nil {
+			return nil, err
+		}
+		byName[a.Name] = a
+	}
+	out := make([]Addon, 0, len(byName))
+	for _, a := range byName {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Load returns the add-on called name from ~/.openframe/addons or the
+// built-in ones.
+func Load(name string) (Addon, error) {
+	if !validName.MatchString(name) {
+		return Addon{}, fmt.Errorf("invalid add-on name %q", name)
+	}
+	dir, err := userDir()
+	if err != nil {
+		return Addon{}, err
+	}
+	a, err := readFile(filepath.Join(dir, name+".yaml"))
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return a, err
+	}
+	builtin, err := builtins()
+	if err != nil {
+		return Addon{}, err
+	}
+	for _, a := range builtin {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return Addon{}, fmt.Errorf("%w %q; 'openframe addon list' shows the available ones", errUnknown, name)
+}
+
+// Resolve finds each add-on given by name, definition file or URL, in order
+// and without repeats. A name not known locally is fetched from registry
+// (or the configured one) when there is one. An add-on with hooks fails
+// under --sandbox enforce, which would refuse them. Nothing is saved: once the
+// hooks are confirmed, Save keeps the definitions that did not come from
+// ~/.openframe/addons or the CLI.
+func Resolve(ctx context.Context, refs []string, registry string) ([]Addon, error) {
+	var out []Addon
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		a, err := resolve(ctx, ref, registry)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSandbox(a); err != nil {
+			return nil, err
+		}
+		if !seen[a.Name] {
+			seen[a.Name] = true
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func resolve(ctx context.Context, ref, registry string) (Addon, error) {
+	switch {
+	case strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://"):
+		return fetch(ctx, ref)
+	case strings.HasSuffix(ref, ".yaml") || strings.HasSuffix(ref, ".yml"):
+		a, err := readFile(ref)
+		a.saveFrom = ref
+		return a, err
+	}
+	if !validName.MatchString(ref) {
+		return Addon{}, fmt.Errorf("invalid add-on %q: give a name, a .yaml file or a URL", ref)
+	}
+	a, err := Load(ref)
+	if !errors.Is(err, errUnknown) {
+		return a, err // found, or a local definition that does not read
+	}
+	if registry == "" {
+		if registry, err = configuredRegistry(); err != nil {
+			return Addon{}, err
+		}
+	}
+	if registry == "" {
+		return Addon{}, fmt.Errorf("unknown add-on %q; 'openframe addon list' shows the available ones, or pass --registry", ref)
+	}
+	return fetch(ctx, strings.TrimSuffix(registry, "/")+"/"+ref+".yaml")
+}
+
+// builtins parses the definitions built into the CLI.
+func builtins() ([]Addon, error) {
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Addon, 0, len(entries))
+	for _, e := range entries {
+		data, err := builtinFS.ReadFile(path.Join("builtin", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		a, err := Parse(data, BuiltinSource)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// readFile parses the definition at p, making its relative manifest paths
+// absolute.
+func readFile(p string) (Addon, error) {
+	data, err := os.ReadFile(p) // #nosec G304 -- an add-on definition the user named or keeps in ~/.openframe/addons
+	if err != nil {
+		return Addon{}, err
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return Addon{}, err
+	}
+	a, err := Parse(data, abs)
+	if err != nil {
+		return Addon{}, err
+	}
+	for i, m := range a.Manifests {
+		if !strings.Contains(m, "://") && !filepath.IsAbs(m) {
+			a.Manifests[i] = filepath.Join(filepath.Dir(abs), m)
+		}
+	}
+	return a, nil
+}
+
+// fetch downloads the definition at rawURL and makes its relative manifest
+// paths URLs next to it.
+func fetch(ctx context.Context, rawURL string) (Addon, error) {
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return Addon{}, fmt.Errorf("invalid add-on URL %q: %w", rawURL, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return Addon{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Addon{}, fmt.Errorf("fetching add-on %s: %w", base.Redacted(), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return Addon{}, fmt.Errorf("fetching add-on %s: %s", base.Redacted(), resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDefinitionSize+1))
+	if err != nil {
+		return Addon{}, fmt.Errorf("fetching add-on %s: %w", base.Redacted(), err)
+	}
+	if len(data) > maxDefinitionSize {
+		return Addon{}, fmt.Errorf("add-on %s is larger than %d bytes", base.Redacted(), maxDefinitionSize)
+	}
+	a, err := Parse(data, base.Redacted())
+	if err != nil {
+		return Addon{}, err
+	}
+	for i, m := range a.Manifests {
+		if ref, err := url.Parse(m); err == nil && !ref.IsAbs() {
+			a.Manifests[i] = base.ResolveReference(ref).String()
+		}
+	}
+	a.Fetched = true
+	a.saveFrom = base.Redacted()
+	return a, nil
+}
+
+// Save keeps the add-ons Resolve read from a file or URL in
+// ~/.openframe/addons, so they can be installed again by name and their hooks
+// run when their cluster is deleted. Call it only once their hooks are
+// confirmed: a saved definition's hooks run without asking again.
+func Save(addons []Addon) error {
+	for _, a := range addons {
+		if a.saveFrom == "" {
+			continue
+		}
+		if err := save(a, a.saveFrom); err != nil {
+			return fmt.Errorf("saving add-on %s: %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+// save writes a to ~/.openframe/addons/NAME.yaml, noting where it came from.
+func save(a Addon, from string) error {
+	dir, err := userDir()
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(dir, a.Name+".yaml")
+	if abs, err := filepath.Abs(from); err == nil && abs == target {
+		return nil // already there
+	}
+	data, err := yaml.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	header := "# Saved by openframe addon from " + from + "\n"
+	return os.WriteFile(target, append([]byte(header), data...), 0o600)
+}
+
+// configuredRegistry reads addons.registry from the user config. A missing
+// file or key means none.
+func configuredRegistry() (string, error) {
+	var cfg struct {
+		Addons struct {
+			Registry string `json:"registry"`
+		} `json:"addons"`
+	}
+	err := sharedconfig.LoadUserConfig(&cfg)
+	return cfg.Addons.Registry, err
+}
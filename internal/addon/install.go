@@ -0,0 +1,292 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ConfirmHooks shows the hooks of the add-ons Resolve just fetched, which
+// would run on this machine, and asks before going on. flagHint is how to
+// skip the question, for the error of a non-interactive session.
+func ConfirmHooks(addons []Addon, flagHint string) error {
+	var fetched []Addon
+	for _, a := range addons {
+		if a.Fetched && len(a.Hooks.PostInstall)+len(a.Hooks.PreRemove) > 0 {
+			fetched = append(fetched, a)
+		}
+	}
+	if len(fetched) == 0 {
+		return nil
+	}
+	for _, a := range fetched {
+		pterm.Warning.Printf("The %s add-on from %s runs these commands on this machine:\n", a.Name, a.Source)
+		for _, hook := range append(append([]string(nil), a.Hooks.PostInstall...), a.Hooks.PreRemove...) {
+			pterm.Printf("  %s\n", hook)
+		}
+	}
+	ok, err := ui.RequireConfirmation("Install and allow these commands?", flagHint, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("add-on install cancelled")
+	}
+	return nil
+}
+
+// healthPollInterval spaces the health check's looks at the pods.
+const healthPollInterval = 3 * time.Second
+
+// hookTimeout bounds one hook command.
+const hookTimeout = 10 * time.Minute
+
+// Installer installs and removes add-ons on one k3d cluster.
+type Installer struct {
+	ex          executor.CommandExecutor
+	cluster     string
+	kubeContext string
+
+	// config and client reach the cluster for manifests and health checks;
+	// resolved from the kube-context on first use unless given.
+	config *rest.Config
+	client kubernetes.Interface
+}
+
+// NewInstaller returns an installer for the k3d cluster named cluster.
+// config may be nil; it is then read from the cluster's kube-context.
+func NewInstaller(ex executor.CommandExecutor, cluster string, config *rest.Config) *Installer {
+	return &Installer{ex: ex, cluster: cluster, kubeContext: "k3d-" + cluster, config: config}
+}
+
+// WithClient sets the client health checks and namespaces go through, in
+// place of one built from the kube-context. Returns the installer for
+// chaining.
+func (i *Installer) WithClient(client kubernetes.Interface) *Installer {
+	i.client = client
+	return i
+}
+
+// Install deploys a, waits for its health check, runs its post-install hooks
+// and records it as installed on the cluster.
+func (i *Installer) Install(ctx context.Context, a Addon) error {
+	if a.Chart != nil {
+		if err := i.installChart(ctx, a); err != nil {
+			return err
+		}
+	} else if err := i.applyManifests(ctx, a, false); err != nil {
+		return err
+	}
+	if err := i.waitHealthy(ctx, a); err != nil {
+		return err
+	}
+	if err := i.RunHooks(ctx, a, a.Hooks.PostInstall); err != nil {
+		return err
+	}
+	return markInstalled(i.cluster, a.Name)
+}
+
+// Remove runs a's pre-remove hooks, deletes what it deployed and forgets it.
+// A hook that fails stops the removal, since it is usually saving data.
+func (i *Installer) Remove(ctx context.Context, a Addon) error {
+	if err := i.RunHooks(ctx, a, a.Hooks.PreRemove); err != nil {
+		return err
+	}
+	if a.Chart != nil {
+		if _, err := i.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "helm",
+			Args:    []string{"uninstall", a.Name, "--namespace", a.namespace(), "--kube-context", i.kubeContext, "--ignore-not-found", "--wait"},
+			Timeout: sharedconfig.Timeout(sharedconfig.LongRunning),
+		}); err != nil {
+			return fmt.Errorf("removing %s from cluster %s: %w", a.Name, i.cluster, err)
+		}
+	} else if err := i.applyManifests(ctx, a, true); err != nil {
+		return err
+	}
+	return markRemoved(i.cluster, a.Name)
+}
+
+// RunHooks runs hooks for a in order and stops at the first that fails.
+func (i *Installer) RunHooks(ctx context.Context, a Addon, hooks []string) error {
+	if len(hooks) > 0 {
+		if err := checkSandbox(a); err != nil {
+			return err
+		}
+	}
+	env := map[string]string{
+		"OPENFRAME_CLUSTER": i.cluster,
+		"OPENFRAME_ADDON":   a.Name,
+		"ADDON_NAMESPACE":   a.namespace(),
+		"KUBE_CONTEXT":      i.kubeContext,
+	}
+	for _, hook := range hooks {
+		pterm.Info.Printf("%s: running %s\n", a.Name, hook)
+		if _, err := i.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "sh",
+			Args:    []string{"-c", hook},
+			Env:     env,
+			Timeout: hookTimeout,
+		}); err != nil {
+			return fmt.Errorf("add-on %s: hook %q failed: %w", a.Name, hook, err)
+		}
+	}
+	return nil
+}
+
+// checkSandbox fails for an add-on with hooks under --sandbox enforce: hooks
+// are shell commands, run with `sh -c`, which the sandbox refuses.
+func checkSandbox(a Addon) error {
+	if executor.SandboxMode() != executor.SandboxEnforce || len(a.Hooks.PostInstall)+len(a.Hooks.PreRemove) == 0 {
+		return nil
+	}
+	return fmt.Errorf("add-on %s has hooks, which run shell commands that --sandbox enforce refuses; use --sandbox log to run them with a warning", a.Name)
+}
+
+func (i *Installer) installChart(ctx context.Context, a Addon) error {
+	values, err := a.chartValues()
+	if err != nil {
+		return err
+	}
+	_, err = i.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "helm",
+		Args: []string{
+			"upgrade", "--install", a.Name, a.Chart.Name,
+			"--repo", a.Chart.Repo,
+			"--version", a.Chart.Version,
+			"--namespace", a.namespace(), "--create-namespace",
+			"--kube-context", i.kubeContext,
+			"--wait", "--timeout", "5m",
+			"-f", "-",
+		},
+		Stdin:   []byte(values),
+		Timeout: sharedconfig.Timeout(sharedconfig.LongRunning),
+	})
+	if err != nil {
+		return fmt.Errorf("installing %s into cluster %s: %w", a.Name, i.cluster, err)
+	}
+	return nil
+}
+
+// applyManifests applies (or deletes) a's manifests into its namespace,
+// labelled as the add-on's apply set.
+func (i *Installer) applyManifests(ctx context.Context, a Addon, remove bool) error {
+	objs, err := manifest.Read(ctx, a.Manifests, nil)
+	if err != nil {
+		return fmt.Errorf("add-on %s: %w", a.Name, err)
+	}
+	if err := i.connect(true); err != nil {
+		return err
+	}
+	engine, err := manifest.NewEngine(i.config)
+	if err != nil {
+		return err
+	}
+	opts := manifest.Options{Namespace: a.namespace(), Set: "addon-" + a.Name, Wait: true, Timeout: defaultHealthTimeout}
+	if remove {
+		_, err = engine.Delete(ctx, objs, opts)
+	} else {
+		if err := i.ensureNamespace(ctx, a.namespace()); err != nil {
+			return err
+		}
+		_, err = engine.Apply(ctx, manifest.Sort(objs), opts)
+	}
+	if err != nil {
+		return fmt.Errorf("add-on %s on cluster %s: %w", a.Name, i.cluster, err)
+	}
+	return nil
+}
+
+func (i *Installer) ensureNamespace(ctx context.Context, name string) error {
+	_, err := i.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	_, err = i.client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// waitHealthy waits until every pod the health check selects is Ready.
+func (i *Installer) waitHealthy(ctx context.Context, a Addon) error {
+	if a.Healthcheck == nil {
+		return nil
+	}
+	if err := i.connect(false); err != nil {
+		return err
+	}
+	timeout, err := a.healthTimeout()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status := "no pods yet"
+	for {
+		pods, err := i.client.CoreV1().Pods(a.namespace()).List(ctx, metav1.ListOptions{LabelSelector: a.Healthcheck.Selector})
+		if err == nil {
+			var ready bool
+			if ready, status = podsReady(pods.Items); ready {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("add-on %s is not healthy after %s: %s", a.Name, timeout, status)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// podsReady reports whether there are pods and all are Ready, or which is
+// not.
+func podsReady(pods []corev1.Pod) (bool, string) {
+	if len(pods) == 0 {
+		return false, "no pods yet"
+	}
+	for _, p := range pods {
+		ready := false
+		for _, c := range p.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			return false, fmt.Sprintf("pod %s is %s", p.Name, p.Status.Phase)
+		}
+	}
+	return true, ""
+}
+
+// connect resolves the cluster's client, and its rest.Config when
+// withConfig is set, on first use.
+func (i *Installer) connect(withConfig bool) error {
+	if i.config == nil && (withConfig || i.client == nil) {
+		cfg, err := k8s.RestConfigForContext(k8s.KubeconfigForContext(i.kubeContext), i.kubeContext)
+		if err != nil {
+			return fmt.Errorf("could not connect to cluster %s: %w", i.cluster, err)
+		}
+		i.config = cfg
+	}
+	if i.client == nil {
+		client, err := kubernetes.NewForConfig(i.config)
+		if err != nil {
+			return fmt.Errorf("could not connect to cluster %s: %w", i.cluster, err)
+		}
+		i.client = client
+	}
+	return nil
+}
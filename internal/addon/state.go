@@ -0,0 +1,118 @@
+package addon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// stateFile records which add-ons are installed on which cluster; a variable
+// so tests can redirect it.
+var stateFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "addons.json"), nil
+}
+
+// Installed returns every cluster's installed add-ons, by cluster name.
+func Installed() (map[string][]string, error) {
+	p, err := stateFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	installed := map[string][]string{}
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", p, err)
+	}
+	return installed, nil
+}
+
+// InstalledOn returns the add-ons installed on cluster, sorted.
+func InstalledOn(cluster string) ([]string, error) {
+	installed, err := Installed()
+	if err != nil {
+		return nil, err
+	}
+	return installed[cluster], nil
+}
+
+// Forget drops the records of cluster, once it is deleted.
+func Forget(cluster string) error {
+	installed, err := InstalledOn(cluster)
+	if err != nil || len(installed) == 0 {
+		return err
+	}
+	return update(func(installed map[string][]string) { delete(installed, cluster) })
+}
+
+// Rename moves the records of cluster to its new name.
+func Rename(cluster, newName string) error {
+	installed, err := InstalledOn(cluster)
+	if err != nil || len(installed) == 0 {
+		return err
+	}
+	return update(func(all map[string][]string) {
+		all[newName] = all[cluster]
+		delete(all, cluster)
+	})
+}
+
+func markInstalled(cluster, name string) error {
+	return update(func(installed map[string][]string) {
+		for _, n := range installed[cluster] {
+			if n == name {
+				return
+			}
+		}
+		installed[cluster] = append(installed[cluster], name)
+		sort.Strings(installed[cluster])
+	})
+}
+
+func markRemoved(cluster, name string) error {
+	return update(func(installed map[string][]string) {
+		kept := installed[cluster][:0]
+		for _, n := range installed[cluster] {
+			if n != name {
+				kept = append(kept, n)
+			}
+		}
+		if len(kept) == 0 {
+			delete(installed, cluster)
+			return
+		}
+		installed[cluster] = kept
+	})
+}
+
+func update(change func(map[string][]string)) error {
+	installed, err := Installed()
+	if err != nil {
+		return err
+	}
+	change(installed)
+	p, err := stateFile()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(installed, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o600)
+}
@@ -58,6 +58,27 @@ func (r Report) Summary() string {
 	return fmt.Sprintf("%d/%d synced, %d/%d healthy — %s", r.Synced, r.Total, r.Healthy, r.Total, state)
 }
 
+// Only narrows the report to the named applications and recounts the totals.
+// It also returns the names that matched no application.
+func (r Report) Only(names []string) (Report, []string) {
+	byName := make(map[string]argocd.Application, len(r.Apps))
+	for _, a := range r.Apps {
+		byName[a.Name] = a
+	}
+	var apps []argocd.Application
+	var missing []string
+	for _, n := range names {
+		if a, ok := byName[n]; ok {
+			apps = append(apps, a)
+		} else {
+			missing = append(missing, n)
+		}
+	}
+	r.Apps = apps
+	r.Total, r.Synced, r.Healthy = summarize(apps)
+	return r, missing
+}
+
 // Service aggregates platform status from its injected sources.
 type Service struct {
 	lister   Lister
@@ -129,3 +129,23 @@ func TestReport_NoAppsSummary(t *testing.T) {
 		t.Fatalf("Summary = %q", got)
 	}
 }
+
+func TestReport_Only(t *testing.T) {
+	svc := NewService(fakeLister{apps: []argocd.Application{
+		app("api", "Healthy", "Synced"),
+		app("ui", "Degraded", "Synced"),
+		app("db", "Healthy", "OutOfSync"),
+	}}, fakeHealth{h: k8s.Health{Reachable: true, NodesReady: 1}}, nil)
+	rep, _ := svc.Report(context.Background(), false)
+
+	only, missing := rep.Only([]string{"api", "ghost"})
+	if len(missing) != 1 || missing[0] != "ghost" {
+		t.Fatalf("missing = %v, want [ghost]", missing)
+	}
+	if only.Total != 1 || only.Synced != 1 || only.Healthy != 1 || !only.Ready() {
+		t.Fatalf("filtered counts = (%d,%d,%d), summary %q", only.Total, only.Synced, only.Healthy, only.Summary())
+	}
+	if rep.Total != 3 {
+		t.Fatalf("Only must not modify the original report (Total = %d)", rep.Total)
+	}
+}
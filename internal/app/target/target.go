@@ -26,6 +26,9 @@ type Selector struct {
 	Prompter       k8s.Prompter
 	Requirements   k8s.Requirements
 	KubeconfigPath string
+	// Prepare, when set, runs on the chosen context before the cluster is
+	// checked: the command layer resumes a cluster idle-watch paused there.
+	Prepare func(ctx context.Context, contextName string) error
 
 	// Injectable seams; NewSelector wires the production implementations.
 	loadContexts func(path string) ([]k8s.ContextInfo, string, error)
@@ -39,8 +42,12 @@ func NewSelector(prompter k8s.Prompter, req k8s.Requirements) *Selector {
 		Prompter:       prompter,
 		Requirements:   req,
 		KubeconfigPath: k8s.DefaultKubeconfigPath(),
-		loadContexts:   k8s.LoadContexts,
-		buildConfig:    k8s.RestConfigForContext,
+		// Contexts of isolated per-cluster kubeconfigs are offered alongside
+		// the default kubeconfig's; each is built from the file defining it.
+		loadContexts: func(string) ([]k8s.ContextInfo, string, error) { return k8s.LoadAllContexts() },
+		buildConfig: func(_, ctxName string) (*rest.Config, error) {
+			return k8s.RestConfigForContext(k8s.KubeconfigForContext(ctxName), ctxName)
+		},
 		newChecker: func(c *rest.Config) (clusterChecker, error) {
 			return k8s.NewAccessorForConfig(c)
 		},
@@ -69,6 +76,11 @@ func (s *Selector) Select(ctx context.Context) (Result, error) {
 	if err != nil {
 		return Result{}, err
 	}
+	if s.Prepare != nil {
+		if err := s.Prepare(ctx, chosen); err != nil {
+			return Result{}, err
+		}
+	}
 
 	cfg, err := s.buildConfig(s.KubeconfigPath, chosen)
 	if err != nil {
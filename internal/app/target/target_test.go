@@ -88,3 +88,19 @@ func TestSelect_ContextLoadError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "kubeconfig")
 }
+
+func TestSelect_PreparesTheChosenContext(t *testing.T) {
+	s := selectorWith(t, fakeChecker{health: healthy(), ok: true})
+	var prepared string
+	s.Prepare = func(_ context.Context, contextName string) error {
+		prepared = contextName
+		return nil
+	}
+	_, err := s.Select(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-a", prepared)
+
+	s.Prepare = func(context.Context, string) error { return errors.New("resume failed") }
+	_, err = s.Select(context.Background())
+	assert.ErrorContains(t, err, "resume failed")
+}
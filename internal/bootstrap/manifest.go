@@ -0,0 +1,143 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chartmodels "github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	utilTypes "github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	clustermodels "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/installmanifest"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// manifestTimeout bounds what writing the manifest asks of the network and
+// the cluster: resolving the ref and listing the pods.
+const manifestTimeout = 30 * time.Second
+
+// loadReplay reads the manifest at path for --from-manifest and refuses one
+// this CLI cannot reproduce: a different pinned ArgoCD chart, or values that
+// changed since it was written.
+func loadReplay(path, cliVersion string) (*installmanifest.Manifest, error) {
+	m, err := installmanifest.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if m.Chart.ArgoCDVersion != argocd.ArgoCDChartVersion {
+		return nil, fmt.Errorf("%s was written with ArgoCD chart %s, but this openframe installs %s; replay it with the openframe version that wrote it (%s)",
+			path, m.Chart.ArgoCDVersion, argocd.ArgoCDChartVersion, m.CLIVersion)
+	}
+	if err := m.VerifyValues(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if m.CLIVersion != "" && cliVersion != "" && m.CLIVersion != cliVersion {
+		pterm.Warning.Printf("%s was written by openframe %s; this is %s\n", path, m.CLIVersion, cliVersion)
+	}
+	return &m, nil
+}
+
+// applyManifest sets up the cluster and the install as m recorded them: the
+// recorded values as they are, and the recorded commit rather than wherever
+// the ref has moved since.
+func applyManifest(m installmanifest.Manifest, config *clustermodels.ClusterConfig, req *utilTypes.InstallationRequest) {
+	config.NodeCount = m.Cluster.Nodes
+	config.K8sVersion = m.Cluster.K8sVersion
+	req.Size = m.Cluster.Size
+	req.ValuesFile = m.Values.Path
+	req.ValuesOverlays = nil
+	req.GitHubRepo = m.Chart.Repo
+	req.GitHubBranch = m.Chart.Ref
+	if m.Chart.Commit != "" {
+		req.GitHubBranch = m.Chart.Commit
+		pterm.Info.Printf("Installing commit %s, recorded from %s\n", shortCommit(m.Chart.Commit), m.Chart.Ref)
+	} else {
+		pterm.Warning.Printf("The manifest records no commit; installing %s as it is now\n", m.Chart.Ref)
+	}
+	req.GitHubRefExplicit = true
+}
+
+// reportImageDrift lists the images a replayed install runs differently from
+// the manifest.
+func reportImageDrift(ctx context.Context, m *installmanifest.Manifest, kubeConfig *rest.Config) {
+	if len(m.Images) == 0 || kubeConfig == nil {
+		return
+	}
+	client, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, manifestTimeout)
+	defer cancel()
+	images, err := installmanifest.RunningImages(ctx, client)
+	if err != nil {
+		return
+	}
+	missing, extra := installmanifest.DiffImages(m.Images, images)
+	if len(missing) == 0 && len(extra) == 0 {
+		pterm.Success.Println("The cluster runs the images the manifest recorded")
+		return
+	}
+	pterm.Warning.Println("The cluster's images differ from the manifest:")
+	for _, image := range missing {
+		pterm.Warning.Printf("  - %s\n", image)
+	}
+	for _, image := range extra {
+		pterm.Warning.Printf("  + %s\n", image)
+	}
+}
+
+// writeManifest records the install that just finished at path: result
+// carries the values it used and the commit it installed.
+func writeManifest(ctx context.Context, path string, config clustermodels.ClusterConfig, req utilTypes.InstallationRequest, result *chartmodels.InstallResult, kubeConfig *rest.Config, cliVersion string) error {
+	if result == nil || len(result.Values) == 0 {
+		return fmt.Errorf("the install did not report the values it used")
+	}
+	ctx, cancel := context.WithTimeout(ctx, manifestTimeout)
+	defer cancel()
+
+	m := installmanifest.Manifest{
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		CLIVersion: cliVersion,
+		Cluster: installmanifest.Cluster{
+			Name:       config.Name,
+			Nodes:      config.NodeCount,
+			K8sVersion: config.K8sVersion,
+		},
+		Chart: installmanifest.Chart{
+			Repo:          req.GitHubRepo,
+			Ref:           req.GitHubBranch,
+			ArgoCDVersion: argocd.ArgoCDChartVersion,
+		},
+	}
+	m.Cluster.Size, _ = sizing.Resolve(req.Size)
+	m.Chart.Commit = result.Commit
+	if m.Chart.Commit == "" {
+		// A previous run installed the app-of-apps; the ref is what it used.
+		if commit, err := git.NewRepository().ResolveRef(ctx, req.GitHubRepo, req.GitHubBranch); err == nil {
+			m.Chart.Commit = commit
+		}
+	}
+	if kubeConfig != nil {
+		client, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return err
+		}
+		if err := m.Observe(ctx, client); err != nil {
+			return err
+		}
+	}
+	return installmanifest.Write(path, m, result.Values)
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -6,11 +6,17 @@ import (
 	"strings"
 
 	chartmodels "github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	chartServices "github.com/flamingo-stack/openframe-cli/internal/chart/services"
+	chartUI "github.com/flamingo-stack/openframe-cli/internal/chart/ui"
 	utilTypes "github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster"
+	clustermodels "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
+	"github.com/flamingo-stack/openframe-cli/internal/installmanifest"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/rest"
@@ -19,6 +25,18 @@ import (
 // defaultClusterName is used when the user doesn't name the cluster.
 const defaultClusterName = "openframe-dev"
 
+// options are what one bootstrap run was asked to do.
+type options struct {
+	clusterName string
+	env         *environment.Environment
+	replay      *installmanifest.Manifest
+	// manifestOut is where the install manifest is written; empty skips it.
+	manifestOut    string
+	cliVersion     string
+	nonInteractive bool
+	verbose        bool
+}
+
 // Service provides bootstrap functionality
 type Service struct{}
 
@@ -51,7 +69,56 @@ func (s *Service) Execute(cmd *cobra.Command, args []string) error {
 		clusterName = strings.TrimSpace(args[0])
 	}
 
-	err = s.bootstrap(cmd.Context(), clusterName, nonInteractive, verbose)
+	// A named environment supplies the cluster, its profile and the values.
+	var env *environment.Environment
+	if name, _ := cmd.Flags().GetString("env"); name != "" {
+		e, err := environment.Get(name)
+		if err != nil {
+			return sharedErrors.HandleGlobalError(err, verbose)
+		}
+		if clusterName != "" && clusterName != e.ClusterName() {
+			return sharedErrors.HandleGlobalError(fmt.Errorf("environment %s runs on cluster %s, not %s; drop the cluster name", e.Name, e.ClusterName(), clusterName), verbose)
+		}
+		clusterName = e.ClusterName()
+		env = &e
+	}
+
+	// A manifest from an earlier bootstrap decides everything, prompts
+	// included: the values it checked are the ones installed as-is.
+	cliVersion := ""
+	if fields := strings.Fields(cmd.Root().Version); len(fields) > 0 {
+		cliVersion = fields[0]
+	}
+	manifestOut, _ := cmd.Flags().GetString("manifest")
+	var replay *installmanifest.Manifest
+	if path, _ := cmd.Flags().GetString("from-manifest"); path != "" {
+		if env != nil {
+			return sharedErrors.HandleGlobalError(fmt.Errorf("--from-manifest and --env cannot be combined"), verbose)
+		}
+		m, err := loadReplay(path, cliVersion)
+		if err != nil {
+			return sharedErrors.HandleGlobalError(err, verbose)
+		}
+		if clusterName != "" && clusterName != m.Cluster.Name {
+			return sharedErrors.HandleGlobalError(fmt.Errorf("%s creates cluster %s, not %s; drop the cluster name", path, m.Cluster.Name, clusterName), verbose)
+		}
+		clusterName = m.Cluster.Name
+		replay = m
+		nonInteractive = true
+		if !cmd.Flags().Changed("manifest") {
+			manifestOut = "" // do not rewrite the manifest being replayed
+		}
+	}
+
+	err = s.bootstrap(cmd.Context(), options{
+		clusterName:    clusterName,
+		env:            env,
+		replay:         replay,
+		manifestOut:    manifestOut,
+		cliVersion:     cliVersion,
+		nonInteractive: nonInteractive,
+		verbose:        verbose,
+	})
 	if err != nil {
 		// Use shared error handler for consistent error display (same as chart install)
 		return sharedErrors.HandleGlobalError(err, verbose)
@@ -68,22 +135,54 @@ func (s *Service) Execute(cmd *cobra.Command, args []string) error {
 // strategy — it hardcoded the "Ubuntu" distro (the launcher is distro-agnostic
 // via OPENFRAME_WSL_DISTRO) and created a `runner:runner` account with
 // NOPASSWD sudo, a CI artifact that had no business in a released binary.
-func (s *Service) bootstrap(ctx context.Context, clusterName string, nonInteractive, verbose bool) error {
+//
+// opts.env, when set, is the named environment being brought up: its profile
+// shapes the cluster and its values are laid over the user's. opts.replay is
+// a manifest being replayed instead.
+func (s *Service) bootstrap(ctx context.Context, opts options) error {
+	env, nonInteractive, verbose := opts.env, opts.nonInteractive, opts.verbose
+
 	// Normalize cluster name (use default if empty)
-	actualClusterName := clusterName
+	actualClusterName := opts.clusterName
 	if actualClusterName == "" {
 		actualClusterName = defaultClusterName
 	}
+	// bootstrap reuses an existing cluster, which must be running.
+	if err := cluster.ResumeIdleCluster(ctx, actualClusterName, false); err != nil {
+		return err
+	}
 
 	// Step 0: Pre-flight the helm values file BEFORE creating the cluster. A
 	// malformed `argocd:` override (or unparseable YAML) otherwise costs a full
 	// cluster create before the chart install rejects the same file.
+	telemetry.EnterPhase(telemetry.PhasePreflight)
 	if err := chartServices.ValidateHelmValuesFile(); err != nil {
 		return err
 	}
 
+	clusterConfig := clustermodels.ClusterConfig{Name: actualClusterName}
+	req := utilTypes.InstallationRequest{
+		GitHubRepo:   chartmodels.RepoOSSTenant,    // Default repository
+		GitHubBranch: chartmodels.DefaultGitBranch, // Default branch
+	}
+	if env != nil {
+		pterm.Info.Printf("Environment %s on cluster %s\n", env.Name, actualClusterName)
+		clusterConfig.NodeCount = env.Profile.Nodes
+		clusterConfig.K8sVersion = env.Profile.K8sVersion
+		req.Size = env.Profile.Size
+		req.ValuesOverlays = env.Values
+		if env.Ref != "" {
+			req.GitHubBranch = env.Ref
+			req.GitHubRefExplicit = true
+		}
+	}
+	if opts.replay != nil {
+		pterm.Info.Printf("Replaying the install manifest for cluster %s\n", actualClusterName)
+		applyManifest(*opts.replay, &clusterConfig, &req)
+	}
+
 	// Step 1: Create cluster with suppressed UI and get the rest.Config
-	kubeConfig, err := s.createClusterSuppressed(ctx, actualClusterName, verbose, nonInteractive)
+	kubeConfig, err := s.createClusterSuppressed(ctx, clusterConfig, verbose, nonInteractive)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster: %w", err)
 	}
@@ -95,34 +194,53 @@ func (s *Service) bootstrap(ctx context.Context, clusterName string, nonInteract
 	pterm.DefaultBasicText.Println()
 
 	// Step 2: Install charts on the created cluster
-	if err := s.installChart(ctx, actualClusterName, nonInteractive, verbose, kubeConfig); err != nil {
+	result, err := s.installChart(ctx, req, actualClusterName, nonInteractive, verbose, kubeConfig)
+	if err != nil {
 		return fmt.Errorf("failed to install charts: %w", err)
 	}
 
+	if env != nil {
+		if err := environment.MarkUp(env.Name); err != nil {
+			pterm.Warning.Printf("Could not record environment %s as up: %v\n", env.Name, err)
+		}
+	}
+	if opts.replay != nil {
+		reportImageDrift(ctx, opts.replay, kubeConfig)
+	}
+	if opts.manifestOut != "" {
+		clusterConfig.Name = actualClusterName
+		if err := writeManifest(ctx, opts.manifestOut, clusterConfig, req, result, kubeConfig, opts.cliVersion); err != nil {
+			pterm.Warning.Printf("Could not write the install manifest: %v\n", err)
+		} else {
+			pterm.Info.Printf("Install manifest written to %s; replay it with: openframe bootstrap --from-manifest %s\n", opts.manifestOut, opts.manifestOut)
+		}
+	}
 	return nil
 }
 
 // createClusterSuppressed creates a cluster with suppressed UI elements
 // Returns the *rest.Config for the created cluster
-func (s *Service) createClusterSuppressed(ctx context.Context, clusterName string, verbose bool, nonInteractive bool) (*rest.Config, error) {
+func (s *Service) createClusterSuppressed(ctx context.Context, config clustermodels.ClusterConfig, verbose bool, nonInteractive bool) (*rest.Config, error) {
 	// Use the wrapper function that includes prerequisite checks
-	return cluster.CreateClusterWithPrerequisitesNonInteractive(ctx, clusterName, verbose, nonInteractive)
+	return cluster.CreateClusterFromConfigWithPrerequisites(ctx, config, verbose, nonInteractive, argocd.PrePullChart())
 }
 
-// installChart installs charts on the created cluster
-func (s *Service) installChart(ctx context.Context, clusterName string, nonInteractive, verbose bool, kubeConfig *rest.Config) error {
-	return chartServices.InstallChartsWithConfigContext(ctx, utilTypes.InstallationRequest{
-		Args:           []string{clusterName},
-		Force:          false,
-		DryRun:         false,
-		Verbose:        verbose,
-		GitHubRepo:     chartmodels.RepoOSSTenant,    // Default repository
-		GitHubBranch:   chartmodels.DefaultGitBranch, // Default branch
-		CertDir:        "",                           // Auto-detected
-		NonInteractive: nonInteractive,
-		KubeConfig:     kubeConfig,
-		// Inject cluster access from the orchestrator (composition root) so the
-		// app subsystem stays isolated from cluster-creation code (req 18/19).
-		ClusterAccess: cluster.NewClusterService(executor.NewRealCommandExecutor(false, verbose)),
-	})
+// installChart installs charts on the created cluster. req carries the
+// repository, ref, size and values overlays; the rest is filled in here. It
+// returns what the install did.
+func (s *Service) installChart(ctx context.Context, req utilTypes.InstallationRequest, clusterName string, nonInteractive, verbose bool, kubeConfig *rest.Config) (*chartmodels.InstallResult, error) {
+	req.Args = []string{clusterName}
+	req.Verbose = verbose
+	req.CertDir = "" // Auto-detected
+	req.NonInteractive = nonInteractive
+	req.KubeConfig = kubeConfig
+	// Inject cluster access from the orchestrator (composition root) so the
+	// app subsystem stays isolated from cluster-creation code (req 18/19).
+	req.ClusterAccess = cluster.NewClusterService(executor.NewRealCommandExecutor(false, verbose))
+	result, err := chartServices.InstallChartsWithResult(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	chartUI.NewDisplayService().ShowInstallResult(result)
+	return result, nil
 }
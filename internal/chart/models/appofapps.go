@@ -10,8 +10,11 @@ import (
 type AppOfAppsConfig struct {
 	// GitHub repository configuration
 	GitHubRepo   string // Repository URL (e.g., "https://github.com/flamingo-stack/openframe-oss-tenant")
-	GitHubBranch string // Git ref to use: branch ("main", "develop") or release tag ("v1.2.3")
-	ChartPath    string // Path to chart in repository (e.g., "manifests/app-of-apps")
+	GitHubBranch string // Git ref to use: branch ("main", "develop"), release tag ("v1.2.3") or commit hash
+	// Commit is set by the install: the commit GitHubBranch pointed to when
+	// the repository was cloned.
+	Commit    string
+	ChartPath string // Path to chart in repository (e.g., "manifests/app-of-apps")
 	// Certificate configuration
 	CertDir string // Directory containing certificates for TLS configuration
 	// Values configuration
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Install phases --only and --skip select, in the order an install runs them.
+const (
+	// PhaseCertificates regenerates the local TLS certificates.
+	PhaseCertificates = "certificates"
+	// PhaseRegistryAuth injects private-registry pull credentials.
+	PhaseRegistryAuth = "registry-auth"
+	// PhaseArgoCD installs ArgoCD (the Flux controllers with --gitops-engine flux).
+	PhaseArgoCD = "argocd"
+	// PhaseAppOfApps installs the app-of-apps (the Flux platform source).
+	PhaseAppOfApps = "app-of-apps"
+	// PhaseSync waits for the applications and the readiness gates.
+	PhaseSync = "sync"
+	// PhaseVerify smoke-tests the endpoints.
+	PhaseVerify = "verify"
+)
+
+// InstallPhases lists the install phases in order.
+var InstallPhases = []string{PhaseCertificates, PhaseRegistryAuth, PhaseArgoCD, PhaseAppOfApps, PhaseSync, PhaseVerify}
+
+// PhaseSelection is the part of the install pipeline to run. A nil selection
+// runs every phase.
+type PhaseSelection struct {
+	run  map[string]bool
+	only bool
+}
+
+// ParsePhaseSelection builds the selection from --only and --skip, which are
+// comma-separated phase names (each may be repeated). Neither set returns nil.
+func ParsePhaseSelection(only, skip []string) (*PhaseSelection, error) {
+	onlyPhases, err := parsePhases("--only", only)
+	if err != nil {
+		return nil, err
+	}
+	skipPhases, err := parsePhases("--skip", skip)
+	if err != nil {
+		return nil, err
+	}
+	if len(onlyPhases) == 0 && len(skipPhases) == 0 {
+		return nil, nil
+	}
+	if len(onlyPhases) > 0 && len(skipPhases) > 0 {
+		return nil, fmt.Errorf("--only and --skip cannot be combined: name the phases to run, or the ones to leave out")
+	}
+
+	s := &PhaseSelection{run: make(map[string]bool, len(InstallPhases)), only: len(onlyPhases) > 0}
+	for _, p := range InstallPhases {
+		s.run[p] = !s.only
+	}
+	for _, p := range onlyPhases {
+		s.run[p] = true
+	}
+	for _, p := range skipPhases {
+		s.run[p] = false
+	}
+	if len(s.Phases()) == 0 {
+		return nil, fmt.Errorf("--skip leaves no phase to run")
+	}
+	return s, nil
+}
+
+func parsePhases(flag string, values []string) ([]string, error) {
+	var phases []string
+	for _, v := range values {
+		for _, p := range strings.Split(v, ",") {
+			p = strings.ToLower(strings.TrimSpace(p))
+			if p == "" {
+				continue
+			}
+			if !isInstallPhase(p) {
+				return nil, fmt.Errorf("%s: unknown phase %q (want one of: %s)", flag, p, strings.Join(InstallPhases, ", "))
+			}
+			phases = append(phases, p)
+		}
+	}
+	return phases, nil
+}
+
+func isInstallPhase(p string) bool {
+	for _, known := range InstallPhases {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs reports whether phase is part of the selection.
+func (s *PhaseSelection) Runs(phase string) bool {
+	return s == nil || s.run[phase]
+}
+
+// Requested reports whether --only named phase, so it runs even where an
+// install would normally leave it out (certificates in non-interactive mode).
+func (s *PhaseSelection) Requested(phase string) bool {
+	return s != nil && s.only && s.run[phase]
+}
+
+// InstallsCharts reports whether the selection installs ArgoCD or the
+// app-of-apps, the phases the values pre-flight checks are for.
+func (s *PhaseSelection) InstallsCharts() bool {
+	return s.Runs(PhaseArgoCD) || s.Runs(PhaseAppOfApps)
+}
+
+// Phases returns the selected phases in install order.
+func (s *PhaseSelection) Phases() []string {
+	var phases []string
+	for _, p := range InstallPhases {
+		if s.Runs(p) {
+			phases = append(phases, p)
+		}
+	}
+	return phases
+}
+
+// String describes the selection for the log: the phases that run.
+func (s *PhaseSelection) String() string {
+	return strings.Join(s.Phases(), ", ")
+}
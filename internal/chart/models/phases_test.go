@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhaseSelection(t *testing.T) {
+	s, err := ParsePhaseSelection(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+	assert.True(t, s.Runs(PhaseCertificates), "no selection runs everything")
+	assert.True(t, s.InstallsCharts())
+	assert.False(t, s.Requested(PhaseCertificates))
+
+	s, err = ParsePhaseSelection([]string{"sync, ArgoCD"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{PhaseArgoCD, PhaseSync}, s.Phases(), "install order, whatever the flag order")
+	assert.False(t, s.Runs(PhaseAppOfApps))
+	assert.True(t, s.Requested(PhaseSync))
+	assert.True(t, s.InstallsCharts())
+
+	s, err = ParsePhaseSelection([]string{"certificates,verify"}, nil)
+	require.NoError(t, err)
+	assert.False(t, s.InstallsCharts())
+
+	s, err = ParsePhaseSelection(nil, []string{"certificates", "verify"})
+	require.NoError(t, err)
+	assert.Equal(t, "registry-auth, argocd, app-of-apps, sync", s.String())
+	assert.False(t, s.Requested(PhaseArgoCD), "--skip requests nothing explicitly")
+}
+
+func TestParsePhaseSelection_Invalid(t *testing.T) {
+	_, err := ParsePhaseSelection([]string{"argo"}, nil)
+	assert.ErrorContains(t, err, `--only: unknown phase "argo"`)
+
+	_, err = ParsePhaseSelection([]string{"argocd"}, []string{"sync"})
+	assert.ErrorContains(t, err, "cannot be combined")
+
+	_, err = ParsePhaseSelection(nil, InstallPhases)
+	assert.ErrorContains(t, err, "no phase to run")
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// What the application wait does about a stuck ArgoCD repo-server.
+const (
+	// RecoveryRestart deletes the repo-server pods (the default).
+	RecoveryRestart = "restart"
+	// RecoveryPatch raises the repo-server's memory limit and lowers its
+	// manifest-generation parallelism when it was OOM-killed, and restarts it
+	// otherwise.
+	RecoveryPatch = "patch"
+	// RecoveryOff only reports the problem.
+	RecoveryOff = "off"
+)
+
+// RepoServerRecoveryPolicy controls the automatic repo-server recovery during
+// the application wait. The zero value is the built-in policy.
+type RepoServerRecoveryPolicy struct {
+	// Action is RecoveryRestart, RecoveryPatch or RecoveryOff.
+	Action string `json:"action,omitempty"`
+	// MaxRestarts bounds the pod deletions per wait (default 3).
+	MaxRestarts int `json:"maxRestarts,omitempty"`
+	// Interval is the minimum time between two recoveries, e.g. "2m".
+	Interval string `json:"interval,omitempty"`
+	// MemoryLimit and Parallelism are what RecoveryPatch sets on an
+	// OOM-killed repo-server (defaults 2Gi and 2).
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+	Parallelism int    `json:"parallelism,omitempty"`
+}
+
+// Defaults of RepoServerRecoveryPolicy.
+const (
+	DefaultRecoveryMaxRestarts = 3
+	DefaultRecoveryInterval    = 2 * time.Minute
+	DefaultRecoveryMemoryLimit = "2Gi"
+	DefaultRecoveryParallelism = 2
+)
+
+// WithDefaults returns p with every unset field filled in. A nil policy is the
+// built-in one.
+func (p *RepoServerRecoveryPolicy) WithDefaults() RepoServerRecoveryPolicy {
+	var out RepoServerRecoveryPolicy
+	if p != nil {
+		out = *p
+	}
+	if out.Action == "" {
+		out.Action = RecoveryRestart
+	}
+	if out.MaxRestarts <= 0 {
+		out.MaxRestarts = DefaultRecoveryMaxRestarts
+	}
+	if out.Interval == "" {
+		out.Interval = DefaultRecoveryInterval.String()
+	}
+	if out.MemoryLimit == "" {
+		out.MemoryLimit = DefaultRecoveryMemoryLimit
+	}
+	if out.Parallelism <= 0 {
+		out.Parallelism = DefaultRecoveryParallelism
+	}
+	return out
+}
+
+// IntervalDuration is Interval parsed; DefaultRecoveryInterval when unset or
+// invalid (Validate rejects invalid values up front).
+func (p RepoServerRecoveryPolicy) IntervalDuration() time.Duration {
+	d, err := time.ParseDuration(p.Interval)
+	if err != nil || d <= 0 {
+		return DefaultRecoveryInterval
+	}
+	return d
+}
+
+// Validate checks the fields that are set.
+func (p RepoServerRecoveryPolicy) Validate() error {
+	switch p.Action {
+	case "", RecoveryRestart, RecoveryPatch, RecoveryOff:
+	default:
+		return fmt.Errorf("action %q: want %s, %s or %s", p.Action, RecoveryRestart, RecoveryPatch, RecoveryOff)
+	}
+	if p.MaxRestarts < 0 || p.Parallelism < 0 {
+		return fmt.Errorf("maxRestarts and parallelism must not be negative")
+	}
+	if p.Interval != "" {
+		if d, err := time.ParseDuration(p.Interval); err != nil || d <= 0 {
+			return fmt.Errorf("interval %q: want a positive duration such as 2m", p.Interval)
+		}
+	}
+	return nil
+}
+
+// LoadRecoveryPolicyFile reads a repo-server recovery policy (YAML or JSON):
+//
+//	action: patch        # restart | patch | off
+//	maxRestarts: 2
+//	interval: 5m
+//	memoryLimit: 3Gi
+//	parallelism: 1
+func LoadRecoveryPolicyFile(path string) (*RepoServerRecoveryPolicy, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-supplied config path, read as invoking user
+	if err != nil {
+		return nil, fmt.Errorf("reading recovery policy: %w", err)
+	}
+	var p RepoServerRecoveryPolicy
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing recovery policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("recovery policy %s: %w", path, err)
+	}
+	return &p, nil
+}
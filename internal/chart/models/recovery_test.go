@@ -0,0 +1,43 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoServerRecoveryPolicy_WithDefaults(t *testing.T) {
+	var p *RepoServerRecoveryPolicy
+	d := p.WithDefaults()
+	assert.Equal(t, RecoveryRestart, d.Action)
+	assert.Equal(t, DefaultRecoveryMaxRestarts, d.MaxRestarts)
+	assert.Equal(t, DefaultRecoveryInterval, d.IntervalDuration())
+
+	d = (&RepoServerRecoveryPolicy{Action: RecoveryPatch, Interval: "5m"}).WithDefaults()
+	assert.Equal(t, RecoveryPatch, d.Action)
+	assert.Equal(t, 5*time.Minute, d.IntervalDuration())
+	assert.Equal(t, DefaultRecoveryMemoryLimit, d.MemoryLimit)
+}
+
+func TestLoadRecoveryPolicyFile(t *testing.T) {
+	write := func(body string) string {
+		p := filepath.Join(t.TempDir(), "policy.yaml")
+		require.NoError(t, os.WriteFile(p, []byte(body), 0o600))
+		return p
+	}
+
+	p, err := LoadRecoveryPolicyFile(write("action: patch\nmaxRestarts: 1\nmemoryLimit: 3Gi\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &RepoServerRecoveryPolicy{Action: RecoveryPatch, MaxRestarts: 1, MemoryLimit: "3Gi"}, p)
+
+	_, err = LoadRecoveryPolicyFile(write("action: reboot\n"))
+	assert.ErrorContains(t, err, `action "reboot"`)
+	_, err = LoadRecoveryPolicyFile(write("interval: soon\n"))
+	assert.ErrorContains(t, err, "positive duration")
+	_, err = LoadRecoveryPolicyFile(write("maxRestart: 1\n"))
+	assert.Error(t, err, "unknown keys are rejected")
+}
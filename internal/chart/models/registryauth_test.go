@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegistryAuth(t *testing.T) {
+	cred, err := ParseRegistryAuth("registry.example.com:5000=robot:pa:ss=word")
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com:5000", cred.Host)
+	assert.Equal(t, "robot", cred.Username)
+	assert.Equal(t, "pa:ss=word", cred.Password, "the password may contain ':' and '='")
+}
+
+func TestParseRegistryAuth_Invalid(t *testing.T) {
+	for _, spec := range []string{"registry.example.com", "registry.example.com=robot", "=robot:pw", "host=:pw", "host=robot:"} {
+		_, err := ParseRegistryAuth(spec)
+		assert.Errorf(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+func TestParseRegistryAuth_ErrorDoesNotEchoPassword(t *testing.T) {
+	_, err := ParseRegistryAuth("bad host=robot:topsecret")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "topsecret")
+}
+
+func TestLoadRegistryAuthFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`registries:
+  - host: ghcr.io
+    username: bot
+    password: ghp_x
+namespaces: [monitoring]
+`), 0o600))
+
+	cfg, err := LoadRegistryAuthFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []RegistryCredential{{Host: "ghcr.io", Username: "bot", Password: "ghp_x"}}, cfg.Registries)
+	assert.Equal(t, []string{"monitoring"}, cfg.Namespaces)
+}
+
+func TestLoadRegistryAuthFile_RejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("registry:\n  - host: ghcr.io\n"), 0o600))
+	_, err := LoadRegistryAuthFile(path)
+	assert.Error(t, err, "a typo'd top-level key must not silently inject nothing")
+}
+
+func TestRegistryAuthConfig_MergeFlagOverridesFile(t *testing.T) {
+	cfg := &RegistryAuthConfig{Registries: []RegistryCredential{{Host: "ghcr.io", Username: "a", Password: "1"}}}
+	cfg.Merge(&RegistryAuthConfig{Registries: []RegistryCredential{
+		{Host: "ghcr.io", Username: "b", Password: "2"},
+		{Host: "quay.io", Username: "c", Password: "3"},
+	}})
+	assert.Equal(t, []RegistryCredential{
+		{Host: "ghcr.io", Username: "b", Password: "2"},
+		{Host: "quay.io", Username: "c", Password: "3"},
+	}, cfg.Registries)
+}
+
+func TestRegistryAuthConfig_DockerConfigJSON(t *testing.T) {
+	cfg := &RegistryAuthConfig{Registries: []RegistryCredential{{Host: "ghcr.io", Username: "bot", Password: "pw"}}}
+	raw, err := cfg.DockerConfigJSON()
+	require.NoError(t, err)
+
+	var parsed struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &parsed))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("bot:pw")), parsed.Auths["ghcr.io"].Auth)
+}
+
+func TestRegistryAuthConfig_IsEmpty(t *testing.T) {
+	var nilCfg *RegistryAuthConfig
+	assert.True(t, nilCfg.IsEmpty())
+	assert.True(t, (&RegistryAuthConfig{}).IsEmpty())
+	assert.False(t, (&RegistryAuthConfig{Registries: []RegistryCredential{{Host: "h"}}}).IsEmpty())
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
+)
+
+// InstallResult is what an install did: the phases it ran and how long each
+// took, the applications it left behind, the endpoint checks, where the
+// credentials are and what went wrong without failing it. The CLI renders it;
+// an embedding program inspects it.
+//
+// Its methods do nothing on a nil result, so the steps filling it in need
+// not care whether anyone asked for one.
+type InstallResult struct {
+	Cluster     string `json:"cluster,omitempty"`
+	KubeContext string `json:"kubeContext,omitempty"`
+	// Engine is the GitOps engine that deployed the platform.
+	Engine string `json:"engine"`
+	// Commit is the chart repository commit the app-of-apps was installed
+	// from; empty when this run did not install it.
+	Commit       string              `json:"commit,omitempty"`
+	DryRun       bool                `json:"dryRun,omitempty"`
+	Started      time.Time           `json:"started"`
+	Finished     time.Time           `json:"finished"`
+	Phases       []PhaseResult       `json:"phases,omitempty"`
+	Applications []ApplicationResult `json:"applications,omitempty"`
+	Endpoints    []EndpointResult    `json:"endpoints,omitempty"`
+	Credentials  []CredentialRef     `json:"credentials,omitempty"`
+	Warnings     []string            `json:"warnings,omitempty"`
+	// Values are the helm values the install used: the values file with the
+	// wizard's answers, the overlays and the ref pin applied. They stay out of
+	// the JSON report, since they can hold credentials.
+	Values []byte `json:"-"`
+}
+
+// PhaseResult is one install phase (telemetry phase names: argocd-install,
+// app-of-apps, argocd-sync, ...). A skipped phase was left in place by a
+// previous run.
+type PhaseResult struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Skipped  bool          `json:"skipped,omitempty"`
+}
+
+// ApplicationResult is a deployed application as the install left it.
+type ApplicationResult struct {
+	Name   string `json:"name"`
+	Sync   string `json:"sync"`
+	Health string `json:"health"`
+}
+
+// EndpointResult is one post-install endpoint check.
+type EndpointResult struct {
+	Check   string `json:"check"`
+	Target  string `json:"target"`
+	Outcome string `json:"outcome"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// CredentialRef says where a credential is kept, never the credential itself:
+// a key of a Secret in the cluster or, with Keychain set, the name it is
+// stored under with `openframe credentials`.
+type CredentialRef struct {
+	Description string `json:"description"`
+	Namespace   string `json:"namespace,omitempty"`
+	Secret      string `json:"secret,omitempty"`
+	Key         string `json:"key,omitempty"`
+	Keychain    string `json:"keychain,omitempty"`
+}
+
+// ArgoCDAdminCredential is where ArgoCD keeps its initial admin password.
+var ArgoCDAdminCredential = CredentialRef{
+	Description: "ArgoCD admin password (user admin)",
+	Namespace:   "argocd",
+	Secret:      "argocd-initial-admin-secret",
+	Key:         "password",
+}
+
+// ArgoCDStoredAdminCredential is the ArgoCD admin password the install set
+// from the keychain; ArgoCD then keeps no initial admin secret.
+var ArgoCDStoredAdminCredential = CredentialRef{
+	Description: "ArgoCD admin password (user admin)",
+	Keychain:    credentials.ArgoCDAdminName,
+}
+
+// AddPhase records phase name as having run since started.
+func (r *InstallResult) AddPhase(name string, started time.Time, skipped bool) {
+	if r == nil {
+		return
+	}
+	r.Phases = append(r.Phases, PhaseResult{Name: name, Duration: time.Since(started), Skipped: skipped})
+}
+
+// Warn records a problem that did not fail the install.
+func (r *InstallResult) Warn(format string, args ...any) {
+	if r == nil {
+		return
+	}
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// AddCredential records where a credential is kept, once.
+func (r *InstallResult) AddCredential(ref CredentialRef) {
+	if r == nil {
+		return
+	}
+	for _, c := range r.Credentials {
+		if c == ref {
+			return
+		}
+	}
+	r.Credentials = append(r.Credentials, ref)
+}
+
+// Duration is the install's wall-clock time, zero until it finished.
+func (r *InstallResult) Duration() time.Duration {
+	if r == nil || r.Finished.IsZero() {
+		return 0
+	}
+	return r.Finished.Sub(r.Started)
+}
+
+// ResetAttempt forgets what a failed attempt recorded, before a retry.
+func (r *InstallResult) ResetAttempt() {
+	if r == nil {
+		return
+	}
+	r.Phases, r.Applications, r.Credentials, r.Warnings = nil, nil, nil, nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallResult_NilIsNoOp(t *testing.T) {
+	var r *InstallResult
+	r.AddPhase("argocd-install", time.Now(), false)
+	r.Warn("ignored %d", 1)
+	r.AddCredential(ArgoCDAdminCredential)
+	r.ResetAttempt()
+	assert.Zero(t, r.Duration())
+}
+
+func TestInstallResult(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	r := &InstallResult{Started: started}
+	assert.Zero(t, r.Duration(), "not finished yet")
+
+	r.AddPhase("argocd-install", started, true)
+	r.Warn("smoke test %s failed", "ui")
+	r.AddCredential(ArgoCDAdminCredential)
+	r.AddCredential(ArgoCDAdminCredential)
+	assert.True(t, r.Phases[0].Skipped)
+	assert.GreaterOrEqual(t, r.Phases[0].Duration, time.Minute)
+	assert.Equal(t, []string{"smoke test ui failed"}, r.Warnings)
+	assert.Len(t, r.Credentials, 1, "recorded once")
+
+	r.Finished = started.Add(90 * time.Second)
+	assert.Equal(t, 90*time.Second, r.Duration())
+
+	r.ResetAttempt()
+	assert.Empty(t, r.Phases)
+	assert.Empty(t, r.Credentials)
+	assert.Empty(t, r.Warnings)
+	assert.Equal(t, started, r.Started, "the install's start survives a retry")
+}
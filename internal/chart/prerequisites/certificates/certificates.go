@@ -12,6 +12,7 @@ import (
 
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/download"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
 	"github.com/pterm/pterm"
 )
 
@@ -172,15 +173,17 @@ func (c *CertificateInstaller) generateCertificates() error {
 		}
 
 	case "linux":
+		// Root-only steps go through the shared privilege check: a hidden sudo
+		// password prompt here used to stall the install silently.
+		ctx, sudo := context.Background(), privilege.Default()
+
 		// Optional: install certutil for browser NSS
 		if !commandExists("certutil") {
 			if commandExists("apt-get") {
-				updateCmd := exec.Command("sudo", "apt-get", "update", "-y")
-				if err := updateCmd.Run(); err != nil {
+				if err := sudo.Run(ctx, "apt-get", "update", "-y"); err != nil {
 					pterm.Debug.Printf("apt-get update failed (certutil install is optional): %v\n", err)
 				}
-				installCmd := exec.Command("sudo", "apt-get", "install", "-y", "libnss3-tools", "ca-certificates")
-				if err := installCmd.Run(); err != nil {
+				if err := sudo.Run(ctx, "apt-get", "install", "-y", "libnss3-tools", "ca-certificates"); err != nil {
 					pterm.Debug.Printf("apt-get install of certutil/ca-certificates failed (optional): %v\n", err)
 				}
 			}
@@ -236,14 +239,12 @@ func (c *CertificateInstaller) generateCertificates() error {
 
 		// Refresh trust stores
 		if commandExists("update-ca-certificates") {
-			updateCmd := exec.Command("sudo", "update-ca-certificates")
-			if err := updateCmd.Run(); err != nil {
+			if err := sudo.Run(ctx, "update-ca-certificates"); err != nil {
 				pterm.Debug.Printf("update-ca-certificates failed: %v\n", err)
 			}
 		}
 		if commandExists("update-ca-trust") {
-			updateCmd := exec.Command("sudo", "update-ca-trust", "extract")
-			if err := updateCmd.Run(); err != nil {
+			if err := sudo.Run(ctx, "update-ca-trust", "extract"); err != nil {
 				pterm.Debug.Printf("update-ca-trust extract failed: %v\n", err)
 			}
 		}
@@ -20,8 +20,8 @@ func commandExists(cmd string) bool {
 }
 
 func isHelmInstalled() bool {
-	// On Windows, check helm in WSL2
-	if runtime.GOOS == "windows" {
+	// On Windows (unless in Docker Desktop native mode), check helm in WSL2
+	if platform.UsesWSL() {
 		return wsllauncher.CommandAvailable("helm")
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -32,6 +33,38 @@ func TestManager_AdminPassword_SecretMissing(t *testing.T) {
 	}
 }
 
+func TestManager_AdminPassword_Stored(t *testing.T) {
+	orig := storedAdminPassword
+	storedAdminPassword = func(context.Context) string { return "from-keychain" }
+	t.Cleanup(func() { storedAdminPassword = orig })
+
+	m := &Manager{kubeClient: fake.NewSimpleClientset()}
+	pw, err := m.AdminPassword(context.Background())
+	if err != nil {
+		t.Fatalf("AdminPassword: %v", err)
+	}
+	if pw != "from-keychain" {
+		t.Fatalf("AdminPassword = %q, want the stored password when ArgoCD kept no initial secret", pw)
+	}
+}
+
+func TestWithAdminPassword(t *testing.T) {
+	overlay, err := WithAdminPassword(map[string]interface{}{
+		"configs": map[string]interface{}{"params": map[string]interface{}{"reposerver.parallelism.limit": "1"}},
+	}, "hunter2")
+	if err != nil {
+		t.Fatalf("WithAdminPassword: %v", err)
+	}
+	configs := overlay["configs"].(map[string]interface{})
+	if _, ok := configs["params"]; !ok {
+		t.Fatal("the sizing overlay was replaced instead of merged")
+	}
+	hash, _ := configs["secret"].(map[string]interface{})["argocdServerAdminPassword"].(string)
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte("hunter2")) != nil {
+		t.Fatalf("argocdServerAdminPassword = %q, want the bcrypt hash of the password", hash)
+	}
+}
+
 func TestManager_AdminPassword_NoPasswordField(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Name: "argocd-initial-admin-secret", Namespace: "argocd"},
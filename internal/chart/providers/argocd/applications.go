@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -14,6 +12,7 @@ import (
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
 	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/pterm/pterm"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -70,6 +69,10 @@ type Manager struct {
 	// to converge before triggering the next one. Zero means the default
 	// (defaultGroupWait). Tests set a tiny value for speed.
 	groupWait time.Duration
+
+	// argoAPI overrides how requests reach the argocd-server API (normally the
+	// Kubernetes service proxy). Tests set it to fake the API server.
+	argoAPI func(ctx context.Context, verb, path, token string, body []byte) ([]byte, error)
 }
 
 // WithWaitTimeout sets a custom WaitForApplications timeout and returns the
@@ -101,10 +104,7 @@ func NewManagerWithConfig(exec executor.CommandExecutor, config *rest.Config) (*
 		return nil, fmt.Errorf("rest.Config cannot be nil")
 	}
 
-	// CRITICAL FIX: Bypass TLS Verification for local k3d clusters
-	// Uses Insecure=true with CA data cleared, preserving client cert authentication.
-	// Applied here as defense-in-depth in case the caller's config doesn't have it set.
-	config = sharedconfig.ApplyInsecureTLSConfig(config)
+	config = sharedconfig.ApplyLocalTLSConfig(config)
 
 	m := &Manager{
 		executor:   exec,
@@ -144,7 +144,7 @@ func (m *Manager) initKubernetesClients() error {
 	}
 
 	// Build kubeconfig path
-	kubeconfigPath := getKubeconfigPath()
+	kubeconfigPath := k8s.KubeconfigForCluster(m.clusterName)
 
 	// Build config with explicit context if cluster name is set
 	var kubeContext string
@@ -163,9 +163,7 @@ func (m *Manager) initKubernetesClients() error {
 		return fmt.Errorf("failed to build kubeconfig: %w", err)
 	}
 
-	// CRITICAL FIX: Bypass TLS Verification for local k3d clusters
-	// Uses custom HTTP transport to bypass TLS at the deepest level.
-	config = sharedconfig.ApplyInsecureTransport(config)
+	config = sharedconfig.ApplyLocalTLSConfig(config)
 
 	// On Windows, normalize the host to 127.0.0.1 if needed
 	if runtime.GOOS == "windows" && strings.Contains(config.Host, "host.docker.internal") {
@@ -204,20 +202,6 @@ func (m *Manager) initKubernetesClients() error {
 	return nil
 }
 
-// getKubeconfigPath returns the kubeconfig file path
-func getKubeconfigPath() string {
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		return kubeconfig
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return clientcmd.RecommendedHomeFile
-	}
-
-	return filepath.Join(homeDir, ".kube", "config")
-}
-
 // SetClusterName sets the cluster name for explicit context usage
 func (m *Manager) SetClusterName(name string) {
 	m.clusterName = name
@@ -234,10 +218,13 @@ type Application struct {
 	ConditionType    string // Type of condition (e.g., "ComparisonError", "InvalidSpecError")
 	OperationPhase   string // Operation phase (e.g., "Running", "Failed", "Succeeded")
 	OperationMessage string // Operation error message
+	OperationStarted string // When the operation started (RFC 3339); identifies it across polls
+	RetryCount       int64  // How many times ArgoCD has retried the operation
 	RepoURL          string // Source repository URL
 	Path             string // Path in repository
 	TargetRevision   string // Target revision (branch/tag)
 	ReconciledAt     string // Last reconciliation time
+	Namespace        string // Destination namespace of the app's resources
 }
 
 // argoApp represents the minimal ArgoCD application structure for JSON parsing.
@@ -261,13 +248,25 @@ type argoApp struct {
 			Message string `json:"message"`
 		} `json:"conditions"`
 		OperationState struct {
-			Phase   string `json:"phase"`
-			Message string `json:"message"`
+			Phase      string `json:"phase"`
+			Message    string `json:"message"`
+			StartedAt  string `json:"startedAt"`
+			RetryCount int64  `json:"retryCount"`
 		} `json:"operationState"`
 		// Resources are the child resources planned/managed by an app (used to
-		// count Applications created by the app-of-apps).
+		// count Applications created by the app-of-apps and to find its
+		// workloads, and as the roots of its resource tree).
 		Resources []struct {
-			Kind string `json:"kind"`
+			Group     string `json:"group"`
+			Version   string `json:"version"`
+			Kind      string `json:"kind"`
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+			Status    string `json:"status"`
+			Health    *struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"health"`
 		} `json:"resources"`
 		ReconciledAt string `json:"reconciledAt"`
 	} `json:"status"`
@@ -330,10 +329,13 @@ func applicationFromArgoApp(item argoApp) Application {
 		ConditionType:    conditionType,
 		OperationPhase:   item.Status.OperationState.Phase,
 		OperationMessage: item.Status.OperationState.Message,
+		OperationStarted: item.Status.OperationState.StartedAt,
+		RetryCount:       item.Status.OperationState.RetryCount,
 		RepoURL:          item.Spec.Source.RepoURL,
 		Path:             item.Spec.Source.Path,
 		TargetRevision:   item.Spec.Source.TargetRevision,
 		ReconciledAt:     item.Status.ReconciledAt,
+		Namespace:        item.Spec.Destination.Namespace,
 	}
 }
 
@@ -407,8 +409,44 @@ func (m *Manager) ListApplications(ctx context.Context, verbose bool) ([]Applica
 	return m.parseApplications(ctx, verbose)
 }
 
+// DestinationNamespaces returns the distinct destination namespaces of the
+// ArgoCD applications currently in the cluster (empty destinations skipped).
+func (m *Manager) DestinationNamespaces(ctx context.Context) ([]string, error) {
+	if m.dynamicClient == nil {
+		if err := m.initKubernetesClients(); err != nil {
+			return nil, err
+		}
+	}
+	if m.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not available")
+	}
+	list, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing applications: %w", err)
+	}
+	seen := map[string]bool{}
+	var namespaces []string
+	for i := range list.Items {
+		item, cerr := argoAppFromObject(list.Items[i].Object)
+		if cerr != nil {
+			continue
+		}
+		if ns := item.Spec.Destination.Namespace; ns != "" && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// storedAdminPassword is the admin password saved with `openframe credentials
+// set argocd/admin`, which the install sets instead of a generated one; a
+// variable so tests can stub it.
+var storedAdminPassword = credentials.ArgoCDAdminPassword
+
 // AdminPassword returns the initial ArgoCD admin password read from the
-// argocd-initial-admin-secret. It errors if the secret is absent (ArgoCD not
+// argocd-initial-admin-secret, or the stored one ArgoCD was installed with,
+// which leaves no such secret. It errors if there is neither (ArgoCD not
 // installed, or the secret was rotated/removed).
 func (m *Manager) AdminPassword(ctx context.Context) (string, error) {
 	if m.kubeClient == nil {
@@ -420,6 +458,11 @@ func (m *Manager) AdminPassword(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("kubernetes client not available")
 	}
 	secret, err := m.kubeClient.CoreV1().Secrets(ArgoCDNamespace).Get(ctx, "argocd-initial-admin-secret", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if pw := storedAdminPassword(ctx); pw != "" {
+			return pw, nil
+		}
+	}
 	if err != nil {
 		return "", fmt.Errorf("reading argocd-initial-admin-secret: %w", err)
 	}
@@ -539,6 +582,9 @@ func (m *Manager) parseApplications(ctx context.Context, verbose bool) ([]Applic
 		return nil, fmt.Errorf("kubernetes dynamic client unavailable: cannot reach the cluster to list ArgoCD applications")
 	}
 
+	if err := executor.InjectedFault(executor.FaultTargetKubeAPI); err != nil {
+		return []Application{}, fmt.Errorf("native ArgoCD client list failed: %w", err)
+	}
 	// Use the dynamic client to list Application CRDs
 	list, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
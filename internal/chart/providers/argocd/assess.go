@@ -11,6 +11,7 @@ type appAssessment struct {
 	healthyNames  []string // names of currently-Healthy apps
 	notReady      []string // "name (status)" labels for apps not yet ready (display)
 	notReadyNames []string // bare names of apps not yet ready (for kubectl commands)
+	newlyReady    []string // apps ready for the first time this session
 }
 
 // appNames returns the names of the given applications, preserving order.
@@ -33,6 +34,9 @@ func assessApplications(apps []Application, everReady map[string]bool) appAssess
 		}
 		if app.Health == ArgoCDHealthHealthy && app.Sync == ArgoCDSyncSynced {
 			a.ready++
+			if !everReady[app.Name] {
+				a.newlyReady = append(a.newlyReady, app.Name)
+			}
 			// Once marked, apps stay counted even if they go out of sync later.
 			everReady[app.Name] = true
 			continue
@@ -0,0 +1,54 @@
+package argocd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// WSL recovery in WaitForApplications. usesWSL and wslSettle are variables so
+// tests can run the recovery off Windows and without waiting; the failures
+// themselves come from executor.FaultsEnv.
+var (
+	usesWSL = platform.UsesWSL
+
+	// wslSettle is how long a restarted WSL gets before the cluster is
+	// queried again.
+	wslSettle = 3 * time.Second
+)
+
+// isConnectivityError reports whether err, from an application query, means
+// the cluster did not answer — as opposed to an answer that could not be
+// used. DNS failures count: under WSL the cluster's host name is resolved
+// through a resolver that stops answering when the VM does.
+func isConnectivityError(err error) bool {
+	msg := err.Error()
+	for _, s := range []string{
+		"connection refused", "cluster unreachable", "was refused", "Unable to connect", "WSL error",
+		"no such host", "i/o timeout",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// wslRecovered is the health probe's last resort on a WSL-backed host: one
+// failure short of giving up, restart WSL (and Docker in it) and probe again.
+// It reports whether the cluster answers again; the error is the context's.
+func (m *Manager) wslRecovered(ctx context.Context, failures, limit int) (bool, error) {
+	if !usesWSL() || failures < limit-1 {
+		return false, nil
+	}
+	if err := executor.TryRecoverWSL(); err != nil {
+		return false, nil
+	}
+	if err := sleepContext(ctx, wslSettle); err != nil {
+		return false, err
+	}
+	return m.checkClusterConnectivity(ctx, false) == nil, nil
+}
@@ -0,0 +1,84 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// onWSLHost runs the WSL recovery as on a WSL-backed Windows host, without
+// the settle wait.
+func onWSLHost(t *testing.T) {
+	t.Helper()
+	origUses, origSettle := usesWSL, wslSettle
+	usesWSL, wslSettle = func() bool { return true }, 0
+	t.Cleanup(func() { usesWSL, wslSettle = origUses, origSettle })
+}
+
+// TestIsConnectivityError_InjectedFaults checks every injected failure of the
+// Kubernetes API is taken for an unreachable cluster, so the wait loop counts
+// it and reaches its recovery.
+func TestIsConnectivityError_InjectedFaults(t *testing.T) {
+	m := fakeManager()
+	for _, kind := range []string{executor.FaultWSLExit, executor.FaultDNSTimeout, executor.FaultDockerDown} {
+		t.Setenv(executor.FaultsEnv, kind+":kube-api")
+		_, err := m.parseApplications(context.Background(), false)
+		require.Error(t, err, kind)
+		assert.True(t, isConnectivityError(err), "%s: %v", kind, err)
+	}
+	assert.False(t, isConnectivityError(errors.New(`applications.argoproj.io is forbidden`)))
+}
+
+func TestWSLRecovered(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TryRecoverWSL restarts the real distribution on Windows")
+	}
+	onWSLHost(t)
+	m := &Manager{kubeClient: fake.NewSimpleClientset(), clientsInitialized: true}
+	ctx := context.Background()
+
+	t.Run("not yet", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "dns-timeout:kube-api")
+		recovered, err := m.wslRecovered(ctx, 3, 5)
+		require.NoError(t, err)
+		assert.False(t, recovered, "recovery waits until one failure short of the limit")
+	})
+
+	t.Run("outage clears", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "dns-timeout:kube-api:1")
+		require.Error(t, m.checkClusterConnectivity(ctx, false))
+		recovered, err := m.wslRecovered(ctx, 4, 5)
+		require.NoError(t, err)
+		assert.True(t, recovered)
+	})
+
+	t.Run("cluster stays down", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "dns-timeout:kube-api")
+		recovered, err := m.wslRecovered(ctx, 4, 5)
+		require.NoError(t, err)
+		assert.False(t, recovered)
+	})
+
+	t.Run("WSL does not come back", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "wsl-exit:wsl-recovery")
+		recovered, err := m.wslRecovered(ctx, 4, 5)
+		require.NoError(t, err)
+		assert.False(t, recovered, "no probe after a failed restart")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "")
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		wslSettle = time.Hour
+		_, err := m.wslRecovered(cancelled, 4, 5)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
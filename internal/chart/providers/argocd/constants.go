@@ -1,5 +1,7 @@
 package argocd
 
+import clustermodels "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+
 // ArgoCD install identity — single source of truth for the pinned chart and the
 // names the CLI installs it under. A chart bump or rename is a one-line change
 // here instead of a scattered find-and-replace.
@@ -12,8 +14,18 @@ const (
 	ArgoCDChartRef     = "argo/argo-cd"
 	ArgoCDChartVersion = "10.1.4"
 	ArgoHelmRepoURL    = "https://argoproj.github.io/argo-helm"
+
+	// ArgoCDChartKubeVersion is the kubeVersion constraint in the pinned
+	// chart's Chart.yaml; bump it with ArgoCDChartVersion.
+	ArgoCDChartKubeVersion = ">=1.30.0-0"
 )
 
+// MinKubernetesVersion is the oldest Kubernetes the pinned chart installs on,
+// and so the oldest a cluster may run: v1.30 for ArgoCDChartKubeVersion.
+func MinKubernetesVersion() string {
+	return clustermodels.MinKubernetesVersion(ArgoCDChartKubeVersion)
+}
+
 // ArgoCD Application health and sync status values. These are compared for
 // equality to decide when the install is complete, so a typo would silently
 // break the completion logic — always use these constants, never the raw string.
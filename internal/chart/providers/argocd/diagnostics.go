@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/pterm/pterm"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -104,6 +105,9 @@ func (m *Manager) checkClusterConnectivity(ctx context.Context, verbose bool) er
 	if m.kubeClient == nil {
 		return fmt.Errorf("kubernetes client unavailable")
 	}
+	if err := executor.InjectedFault(executor.FaultTargetKubeAPI); err != nil {
+		return fmt.Errorf("cluster unreachable: %w", err)
+	}
 	// A namespace GET is a cheap call that requires a reachable API server.
 	if _, err := m.kubeClient.CoreV1().Namespaces().Get(ctx, ArgoCDNamespace, metav1.GetOptions{}); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -175,13 +179,13 @@ func (m *Manager) checkRepoServerHealth(ctx context.Context, _ bool) *RepoServer
 // manifests from git and bypass its manifest cache. A "normal" refresh only
 // re-compares against that cache — worthless right after a repo-server restart,
 // where the cache is exactly what's stale (apps sit in Unknown because manifest
-// generation failed). Best-effort: returns the number successfully patched;
+// generation failed). Best-effort: returns the names successfully patched;
 // per-app failures are logged at debug and skipped.
-func (m *Manager) hardRefreshApplications(ctx context.Context, names []string) int {
+func (m *Manager) hardRefreshApplications(ctx context.Context, names []string) []string {
 	if m.dynamicClient == nil {
-		return 0
+		return nil
 	}
-	var refreshed int
+	var refreshed []string
 	for _, name := range names {
 		if name == "" {
 			continue
@@ -191,7 +195,7 @@ func (m *Manager) hardRefreshApplications(ctx context.Context, names []string) i
 			pterm.Debug.Printf("best-effort hard refresh of application %s failed: %v\n", name, err)
 			continue
 		}
-		refreshed++
+		refreshed = append(refreshed, name)
 	}
 	return refreshed
 }
@@ -208,8 +212,12 @@ func (m *Manager) triggerRepoServerRecovery(ctx context.Context, appName string)
 		metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: repoServerSelector}); err != nil {
 		return false
 	}
+	return m.waitRepoServerRecovered(ctx, appName)
+}
 
-	// Wait for repo-server to come back up (max ~60s).
+// waitRepoServerRecovered waits (max ~60s) for the repo-server to report
+// healthy after a restart or a rollout, then hard-refreshes appName.
+func (m *Manager) waitRepoServerRecovered(ctx context.Context, appName string) bool {
 	for i := 0; i < 20; i++ {
 		select {
 		case <-ctx.Done():
@@ -0,0 +1,299 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Resource drift states.
+const (
+	DriftModified = "modified" // live differs from git
+	DriftMissing  = "missing"  // in git, not in the cluster
+	DriftExtra    = "extra"    // in the cluster, no longer in git (pruned only with --prune)
+)
+
+// ResourceDiff is one managed resource whose live state differs from what its
+// Application renders from git.
+type ResourceDiff struct {
+	App       string      `json:"app"`
+	Group     string      `json:"group,omitempty"`
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name"`
+	State     string      `json:"state"`
+	Fields    []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff is one field whose live value differs from the desired one. An
+// empty side means the field is absent there.
+type FieldDiff struct {
+	Path    string `json:"path"`
+	Live    string `json:"live,omitempty"`
+	Desired string `json:"desired,omitempty"`
+}
+
+// maxDiffValue bounds how much of a changed value is shown.
+const maxDiffValue = 120
+
+// ignoredPaths are fields the cluster owns; they always differ from git.
+var ignoredPaths = map[string]bool{
+	"status":                     true,
+	"metadata.managedFields":     true,
+	"metadata.resourceVersion":   true,
+	"metadata.uid":               true,
+	"metadata.generation":        true,
+	"metadata.creationTimestamp": true,
+	"metadata.annotations.kubectl.kubernetes.io/last-applied-configuration": true,
+}
+
+// DiffApplications compares the live state of each named Application's
+// resources with what it renders from git, using the ArgoCD managed-resources
+// API (the same comparison the ArgoCD UI diff shows). Without names it looks
+// at every Application that is not Synced. Unknown names are an error.
+func (m *Manager) DiffApplications(ctx context.Context, names []string) ([]ResourceDiff, error) {
+	apps, err := m.ListApplications(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := diffTargets(apps, names)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+
+	token, err := m.argoSessionToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []ResourceDiff
+	for _, app := range targets {
+		raw, err := m.argoServerRequest(ctx, "GET", "/api/v1/applications/"+app+"/managed-resources", token, nil)
+		if err != nil {
+			return nil, fmt.Errorf("reading managed resources of %s: %w", app, err)
+		}
+		d, err := parseManagedResources(app, raw)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d...)
+	}
+	return diffs, nil
+}
+
+// diffTargets picks the Applications to diff: names, checked against apps, or
+// every app not Synced.
+func diffTargets(apps []Application, names []string) ([]string, error) {
+	if len(names) == 0 {
+		var out []string
+		for _, a := range apps {
+			if a.Sync != ArgoCDSyncSynced {
+				out = append(out, a.Name)
+			}
+		}
+		sort.Strings(out)
+		return out, nil
+	}
+	if err := checkApplicationNames(apps, names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// checkApplicationNames errors on the names that are not among apps.
+func checkApplicationNames(apps []Application, names []string) error {
+	known := make(map[string]bool, len(apps))
+	for _, a := range apps {
+		known[a.Name] = true
+	}
+	var missing []string
+	for _, n := range names {
+		if !known[n] {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no ArgoCD application named %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// parseManagedResources turns a managed-resources API response into the
+// resources of app that drifted, with their field-level differences.
+func parseManagedResources(app string, raw []byte) ([]ResourceDiff, error) {
+	var resp struct {
+		Items []struct {
+			Group               string `json:"group"`
+			Kind                string `json:"kind"`
+			Namespace           string `json:"namespace"`
+			Name                string `json:"name"`
+			TargetState         string `json:"targetState"`
+			NormalizedLiveState string `json:"normalizedLiveState"`
+			PredictedLiveState  string `json:"predictedLiveState"`
+			Hook                bool   `json:"hook"`
+			Modified            bool   `json:"modified"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("parsing managed resources of %s: %w", app, err)
+	}
+
+	var diffs []ResourceDiff
+	for _, item := range resp.Items {
+		if item.Hook {
+			continue
+		}
+		desired := decodeState(item.PredictedLiveState)
+		if desired == nil {
+			desired = decodeState(item.TargetState)
+		}
+		live := decodeState(item.NormalizedLiveState)
+
+		d := ResourceDiff{App: app, Group: item.Group, Kind: item.Kind, Namespace: item.Namespace, Name: item.Name}
+		switch {
+		case desired == nil && live == nil:
+			continue
+		case live == nil:
+			d.State = DriftMissing
+		case desired == nil:
+			d.State = DriftExtra
+		default:
+			d.Fields = diffFields("", live, desired)
+			if len(d.Fields) == 0 && !item.Modified {
+				continue
+			}
+			d.State = DriftModified
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// decodeState parses a JSON-encoded manifest; empty and "null" are nil.
+func decodeState(s string) map[string]any {
+	if s == "" || s == "null" {
+		return nil
+	}
+	var obj map[string]any
+	if json.Unmarshal([]byte(s), &obj) != nil {
+		return nil
+	}
+	return obj
+}
+
+// diffFields lists the fields of desired that live does not match, by dotted
+// path (list elements as [i]). Fields only live has are defaults and
+// controller-set values, not drift, so they are not walked.
+func diffFields(path string, live, desired any) []FieldDiff {
+	if ignoredPaths[path] {
+		return nil
+	}
+	switch d := desired.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var out []FieldDiff
+		for _, k := range keys {
+			out = append(out, diffFields(joinPath(path, k), l[k], d[k])...)
+		}
+		return out
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(l) != len(d) {
+			break
+		}
+		var out []FieldDiff
+		for i := range d {
+			out = append(out, diffFields(path+"["+strconv.Itoa(i)+"]", l[i], d[i])...)
+		}
+		return out
+	}
+	if reflect.DeepEqual(live, desired) {
+		return nil
+	}
+	return []FieldDiff{{Path: path, Live: renderValue(live), Desired: renderValue(desired)}}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// renderValue is v as compact JSON, shortened for display; nil is empty.
+func renderValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(b)
+	if r := []rune(s); len(r) > maxDiffValue {
+		s = string(r[:maxDiffValue-1]) + "…"
+	}
+	return s
+}
+
+// SyncResources starts a sync of app limited to the given resources — the
+// targeted sync `argocd app sync --resource` performs. Resources that are no
+// longer in git are only deleted when prune is set.
+func (m *Manager) SyncResources(ctx context.Context, app string, resources []ResourceDiff, prune bool) error {
+	if m.dynamicClient == nil {
+		if err := m.initKubernetesClients(); err != nil {
+			return err
+		}
+	}
+	type syncResource struct {
+		Group     string `json:"group"`
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	}
+	var patch struct {
+		Operation struct {
+			InitiatedBy struct {
+				Username string `json:"username"`
+			} `json:"initiatedBy"`
+			Sync struct {
+				Prune     bool           `json:"prune"`
+				Resources []syncResource `json:"resources"`
+			} `json:"sync"`
+		} `json:"operation"`
+	}
+	patch.Operation.InitiatedBy.Username = "openframe-cli"
+	patch.Operation.Sync.Prune = prune
+	for _, r := range resources {
+		if r.App == app {
+			patch.Operation.Sync.Resources = append(patch.Operation.Sync.Resources,
+				syncResource{Group: r.Group, Kind: r.Kind, Name: r.Name, Namespace: r.Namespace})
+		}
+	}
+	if len(patch.Operation.Sync.Resources) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	if _, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).
+		Patch(ctx, app, types.MergePatchType, body, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("triggering sync of %s: %w", app, err)
+	}
+	return nil
+}
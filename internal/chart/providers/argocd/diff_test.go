@@ -0,0 +1,146 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// managedResource builds one managed-resources API item.
+func managedResource(kind, name, live, desired string, modified bool) map[string]any {
+	return map[string]any{
+		"kind": kind, "namespace": "openframe", "name": name,
+		"normalizedLiveState": live, "predictedLiveState": desired, "modified": modified,
+	}
+}
+
+func managedResources(t *testing.T, items ...map[string]any) []byte {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{"items": items})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseManagedResources_FieldLevelDiff(t *testing.T) {
+	live := `{"metadata":{"name":"api","resourceVersion":"7"},"spec":{"replicas":1,"template":{"spec":{"containers":[{"image":"api:1.0","name":"api"}]}}},"status":{"readyReplicas":1}}`
+	desired := `{"metadata":{"name":"api","resourceVersion":"9"},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"api:1.1","name":"api"}]}}}}`
+	raw := managedResources(t,
+		managedResource("Deployment", "api", live, desired, true),
+		managedResource("ConfigMap", "same", `{"data":{"a":"1"}}`, `{"data":{"a":"1"}}`, false),
+		managedResource("Service", "new", "", `{"spec":{}}`, true),
+		managedResource("Secret", "old", `{"data":{}}`, "null", true),
+	)
+
+	diffs, err := parseManagedResources("openframe-api", raw)
+	if err != nil {
+		t.Fatalf("parseManagedResources: %v", err)
+	}
+	if len(diffs) != 3 {
+		t.Fatalf("got %d diffs, want 3 (the in-sync ConfigMap is left out): %+v", len(diffs), diffs)
+	}
+
+	dep := diffs[0]
+	if dep.State != DriftModified || dep.App != "openframe-api" {
+		t.Fatalf("deployment diff = %+v", dep)
+	}
+	want := []FieldDiff{
+		{Path: "spec.replicas", Live: "1", Desired: "2"},
+		{Path: "spec.template.spec.containers[0].image", Live: `"api:1.0"`, Desired: `"api:1.1"`},
+	}
+	if len(dep.Fields) != len(want) {
+		t.Fatalf("fields = %+v, want %+v (resourceVersion and status are ignored)", dep.Fields, want)
+	}
+	for i := range want {
+		if dep.Fields[i] != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, dep.Fields[i], want[i])
+		}
+	}
+	if diffs[1].State != DriftMissing || diffs[2].State != DriftExtra {
+		t.Errorf("states = %s, %s; want missing, extra", diffs[1].State, diffs[2].State)
+	}
+}
+
+func TestDiffFields_ListLengthChangeIsOneField(t *testing.T) {
+	got := diffFields("", map[string]any{"args": []any{"a"}}, map[string]any{"args": []any{"a", "b"}})
+	if len(got) != 1 || got[0].Path != "args" || got[0].Desired != `["a","b"]` {
+		t.Fatalf("diff = %+v", got)
+	}
+}
+
+func TestDiffTargets(t *testing.T) {
+	apps := []Application{
+		{Name: "b", Sync: ArgoCDSyncOutOfSync},
+		{Name: "a", Sync: ArgoCDSyncSynced},
+		{Name: "c", Sync: "Unknown"},
+	}
+	got, err := diffTargets(apps, nil)
+	if err != nil || strings.Join(got, ",") != "b,c" {
+		t.Fatalf("default targets = %v, %v; want the apps not Synced", got, err)
+	}
+	if _, err := diffTargets(apps, []string{"a", "zz"}); err == nil || !strings.Contains(err.Error(), "zz") {
+		t.Fatalf("unknown name error = %v", err)
+	}
+}
+
+func TestDiffApplications_UsesManagedResourcesAPI(t *testing.T) {
+	admin := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-initial-admin-secret", Namespace: "argocd"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	m := fakeManager(
+		appObj("openframe-api", ArgoCDHealthHealthy, ArgoCDSyncOutOfSync),
+		appObj("openframe-ui", ArgoCDHealthHealthy, ArgoCDSyncSynced),
+	)
+	m.kubeClient = fake.NewSimpleClientset(admin)
+	var paths []string
+	m.argoAPI = func(_ context.Context, _, path, _ string, _ []byte) ([]byte, error) {
+		paths = append(paths, path)
+		if path == "/api/v1/session" {
+			return []byte(`{"token":"jwt"}`), nil
+		}
+		return managedResources(t, managedResource("Deployment", "api", `{"spec":{"replicas":1}}`, `{"spec":{"replicas":2}}`, true)), nil
+	}
+
+	diffs, err := m.DiffApplications(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("DiffApplications: %v", err)
+	}
+	if len(diffs) != 1 || diffs[0].App != "openframe-api" {
+		t.Fatalf("diffs = %+v", diffs)
+	}
+	if strings.Join(paths, " ") != "/api/v1/session /api/v1/applications/openframe-api/managed-resources" {
+		t.Fatalf("API calls = %v; the Synced app must not be queried", paths)
+	}
+}
+
+func TestSyncResources_PatchesOnlyTheAppsResources(t *testing.T) {
+	m := fakeManager(appObj("openframe-api", ArgoCDHealthHealthy, ArgoCDSyncOutOfSync))
+	diffs := []ResourceDiff{
+		{App: "openframe-api", Group: "apps", Kind: "Deployment", Namespace: "openframe", Name: "api", State: DriftModified},
+		{App: "openframe-ui", Kind: "Service", Namespace: "openframe", Name: "ui", State: DriftModified},
+	}
+
+	if err := m.SyncResources(context.Background(), "openframe-api", diffs, true); err != nil {
+		t.Fatalf("SyncResources: %v", err)
+	}
+	obj, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(context.Background(), "openframe-api", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources, _, _ := unstructured.NestedSlice(obj.Object, "operation", "sync", "resources")
+	prune, _, _ := unstructured.NestedBool(obj.Object, "operation", "sync", "prune")
+	if len(resources) != 1 || !prune {
+		t.Fatalf("operation = %v, want one resource with prune", obj.Object["operation"])
+	}
+	if r := resources[0].(map[string]any); r["kind"] != "Deployment" || r["name"] != "api" {
+		t.Fatalf("synced resource = %v", r)
+	}
+}
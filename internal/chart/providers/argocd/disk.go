@@ -0,0 +1,78 @@
+package argocd
+
+import (
+	"context"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/diskguard"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
+)
+
+// Disk pressure during WaitForApplications. The images a full install pulls
+// fill the nodes' disk — on Windows and macOS a virtual disk with little
+// headroom — and the kubelet then evicts pods with DiskPressure while the
+// applications just stay Progressing. The wait measures free space every
+// diskCheckInterval, prunes unused images when it runs low, and says how long
+// the disk has left at the rate it is filling.
+
+// diskWatch is the disk state carried across checks. nodes is nil until the
+// first check and empty for a cluster with no k3d nodes, which turns the
+// watch off.
+type diskWatch struct {
+	cluster string
+	nodes   []string
+	monitor diskguard.Monitor
+}
+
+func newDiskWatch(cluster string) *diskWatch { return &diskWatch{cluster: cluster} }
+
+// checkDiskPressure measures the nodes' free space and warns when it is low
+// or falling fast; when it is low it also prunes unused images. Measuring failures are
+// silent: the wait has more direct ways to notice a cluster in trouble.
+func (m *Manager) checkDiskPressure(ctx context.Context, d *diskWatch, out frontend.UI) {
+	if d.nodes == nil {
+		if d.cluster == "" {
+			d.nodes = []string{}
+			return
+		}
+		nodes, err := diskguard.Nodes(ctx, m.executor, d.cluster)
+		if err != nil || len(nodes) == 0 {
+			d.nodes = []string{}
+			return
+		}
+		d.nodes = nodes
+	}
+	if len(d.nodes) == 0 {
+		return
+	}
+	usage, err := diskguard.Measure(ctx, m.executor, d.nodes)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	reading := d.monitor.Observe(now, usage)
+	if !d.monitor.PruneDue(now, reading) {
+		if d.monitor.WarnDue(now, reading) {
+			out.Warn("Cluster disk: %s. Pods are evicted when it runs out.", reading)
+		}
+		return
+	}
+
+	out.Warn("Cluster disk: %s. Pods are evicted when it runs out; removing unused images...", reading)
+	// The images pre-pulled at creation are unused until their pods start;
+	// pruning them mid-install would only make the cluster pull them again.
+	record, _ := clusterstate.Get(d.cluster)
+	_ = diskguard.PruneNodes(ctx, m.executor, d.nodes, record.Prepulled)
+	_, _ = diskguard.PruneHost(ctx, m.executor, false)
+	after, err := diskguard.Measure(ctx, m.executor, d.nodes)
+	if err != nil {
+		return
+	}
+	if after.Free > usage.Free {
+		out.Info("Freed %s; %s.", diskguard.Size(after.Free-usage.Free), after)
+	}
+	if after.Low() {
+		out.Warn("Still low on disk. Free space on the Docker host (or enlarge the WSL/Docker Desktop disk), or run 'openframe cleanup images --all'.")
+	}
+}
@@ -0,0 +1,63 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDiskPressure(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("docker ps", &executor.CommandResult{Stdout: "k3d-dev-server-0\tserver\nk3d-dev-serverlb\tloadbalancer\n"})
+	ex.SetResponse("df -Pk", &executor.CommandResult{Stdout: "Filesystem 1024-blocks Used Available Capacity Mounted on\noverlay 100000000 92000000 8000000 92% /\n"})
+	t.Setenv("HOME", t.TempDir())
+	m := NewManagerWithCluster(ex, "dev")
+	var out bytes.Buffer
+	ui := frontend.NewPlain(&out)
+	d := newDiskWatch("dev")
+
+	m.checkDiskPressure(context.Background(), d, ui)
+	assert.Contains(t, ex.GetExecutedCommands(), "docker exec k3d-dev-server-0 crictl rmi --prune")
+	assert.Contains(t, ex.GetExecutedCommands(), "docker image prune --force")
+	assert.Contains(t, out.String(), "Cluster disk: 7.6 GiB free of 95.4 GiB (8%)")
+	assert.Contains(t, out.String(), "Still low on disk")
+
+	// Within the cooldown the disk is measured but not pruned again.
+	before := len(ex.GetExecutedCommands())
+	m.checkDiskPressure(context.Background(), d, ui)
+	assert.Equal(t, []string{"docker exec k3d-dev-server-0 df -Pk /var/lib/rancher/k3s/agent/containerd"}, ex.GetExecutedCommands()[before:])
+}
+
+// TestCheckDiskPressure_KeepsPrepulled checks a prune mid-install leaves the
+// images pre-pulled at creation, which the install is about to start.
+func TestCheckDiskPressure_KeepsPrepulled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, clusterstate.SetPrepulled("dev", []string{"quay.io/argoproj/argocd:v3.0.0"}))
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("docker ps", &executor.CommandResult{Stdout: "k3d-dev-server-0\tserver\n"})
+	ex.SetResponse("df -Pk", &executor.CommandResult{Stdout: "Filesystem 1024-blocks Used Available Capacity Mounted on\noverlay 100000000 92000000 8000000 92% /\n"})
+	ex.SetResponse("crictl images", &executor.CommandResult{Stdout: `{"images": [{"id": "sha256:aaa", "repoTags": ["quay.io/argoproj/argocd:v3.0.0"]}]}`})
+	ex.SetResponse("crictl ps", &executor.CommandResult{Stdout: `{"containers": []}`})
+
+	NewManagerWithCluster(ex, "dev").checkDiskPressure(context.Background(), newDiskWatch("dev"), frontend.NewPlain(&bytes.Buffer{}))
+	assert.Contains(t, ex.GetExecutedCommands(), "docker exec k3d-dev-server-0 crictl images -o json")
+	for _, cmd := range ex.GetExecutedCommands() {
+		assert.NotContains(t, cmd, "crictl rmi", "the pre-pulled image is kept")
+	}
+}
+
+func TestCheckDiskPressure_NoK3dNodes(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	m := NewManager(ex)
+	d := newDiskWatch("external")
+
+	m.checkDiskPressure(context.Background(), d, frontend.NewPlain(&bytes.Buffer{}))
+	m.checkDiskPressure(context.Background(), d, frontend.NewPlain(&bytes.Buffer{}))
+	assert.Len(t, ex.GetExecutedCommands(), 1, "a cluster without k3d nodes is looked up once and then left alone")
+}
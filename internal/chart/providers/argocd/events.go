@@ -0,0 +1,211 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Warning events during WaitForApplications. A pod that cannot be scheduled,
+// mount its volume or pull its image says so in a Kubernetes event the moment
+// it happens, while the application it belongs to just stays Progressing; the
+// wait used to notice only when the stuck-app summary came round minutes
+// later. The watcher streams Warning events from every namespace and hands the
+// loop each new (object, problem) pair once, counting repeats instead of
+// reprinting them.
+
+const (
+	// maxLiveWarnings caps how many distinct problems are printed as they
+	// happen; a cluster short of memory fails to schedule every pending pod,
+	// and the rest are listed if the wait fails.
+	maxLiveWarnings = 20
+
+	// maxWarningMessage bounds one printed event message.
+	maxWarningMessage = 240
+
+	// eventRewatchDelay spaces attempts to re-open a closed or refused watch.
+	eventRewatchDelay = 5 * time.Second
+)
+
+// warningReasons are the event reasons that mean a workload is blocked rather
+// than starting up. Probe failures (Unhealthy) are left out: every pod has a
+// few while it warms up.
+var warningReasons = map[string]bool{
+	"FailedScheduling":       true,
+	"FailedMount":            true,
+	"FailedAttachVolume":     true,
+	"FailedCreate":           true,
+	"FailedCreatePodSandBox": true,
+	"ProvisioningFailed":     true,
+	"Evicted":                true,
+	"Failed":                 true,
+	"BackOff":                true,
+}
+
+// warningEvent is one problem of one object, aggregated over its events.
+type warningEvent struct {
+	Object  string // "Pod openframe/mongodb-0"
+	Problem string // the event reason, or ImagePullBackOff / CrashLoopBackOff
+	Message string // the first message seen
+	Count   int
+}
+
+func (w warningEvent) String() string {
+	s := fmt.Sprintf("%s: %s: %s", w.Object, w.Problem, w.Message)
+	if w.Count > 1 {
+		s += fmt.Sprintf(" (x%d)", w.Count)
+	}
+	return s
+}
+
+// eventWatcher aggregates Warning events per object and problem. C receives
+// each pair the first time it is seen.
+type eventWatcher struct {
+	C     chan warningEvent
+	since time.Time
+
+	mu    sync.Mutex
+	seen  map[string]*warningEvent
+	order []string
+}
+
+func newEventWatcher(since time.Time) *eventWatcher {
+	return &eventWatcher{C: make(chan warningEvent, maxLiveWarnings), since: since, seen: map[string]*warningEvent{}}
+}
+
+// watchWarningEvents starts streaming Warning events until ctx ends. Without
+// a Kubernetes client the watcher stays silent; the wait works as before.
+func (m *Manager) watchWarningEvents(ctx context.Context) *eventWatcher {
+	w := newEventWatcher(time.Now().Truncate(time.Second)) // event timestamps have whole seconds
+	if err := m.initKubernetesClients(); err != nil || m.kubeClient == nil {
+		return w
+	}
+	go w.run(ctx, m.kubeClient)
+	return w
+}
+
+// run keeps a watch open, re-opening it when the API server closes it. It
+// starts from the current resource version so earlier events are not
+// replayed; after a restart without one, events older than the watcher are
+// skipped by time instead.
+func (w *eventWatcher) run(ctx context.Context, client kubernetes.Interface) {
+	opts := metav1.ListOptions{FieldSelector: "type=" + corev1.EventTypeWarning}
+	if list, err := client.CoreV1().Events("").List(ctx, metav1.ListOptions{FieldSelector: opts.FieldSelector, Limit: 1}); err == nil {
+		opts.ResourceVersion = list.ResourceVersion
+	}
+	for ctx.Err() == nil {
+		wi, err := client.CoreV1().Events("").Watch(ctx, opts)
+		if err != nil {
+			opts.ResourceVersion = ""
+			if sleepContext(ctx, eventRewatchDelay) != nil {
+				return
+			}
+			continue
+		}
+		for e := range wi.ResultChan() {
+			ev, ok := e.Object.(*corev1.Event)
+			if !ok || (e.Type != watch.Added && e.Type != watch.Modified) {
+				if e.Type == watch.Error {
+					opts.ResourceVersion = "" // expired; start over, filtered by time
+				}
+				continue
+			}
+			opts.ResourceVersion = ev.ResourceVersion
+			if found, isNew := w.observe(ev); isNew {
+				select {
+				case w.C <- found:
+				case <-ctx.Done():
+					wi.Stop()
+					return
+				}
+			}
+		}
+		wi.Stop()
+	}
+}
+
+// observe records ev and reports whether its object and problem are new.
+func (w *eventWatcher) observe(ev *corev1.Event) (warningEvent, bool) {
+	if ev.Type != corev1.EventTypeWarning || !warningReasons[ev.Reason] || eventTime(ev).Before(w.since) {
+		return warningEvent{}, false
+	}
+	obj := ev.InvolvedObject
+	object := obj.Kind + " " + obj.Name
+	if obj.Namespace != "" {
+		object = obj.Kind + " " + obj.Namespace + "/" + obj.Name
+	}
+	problem := eventProblem(ev.Reason, ev.Message)
+	key := object + "|" + problem
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if agg, ok := w.seen[key]; ok {
+		agg.Count++
+		return *agg, false
+	}
+	msg := strings.Join(strings.Fields(ev.Message), " ")
+	if len(msg) > maxWarningMessage {
+		msg = msg[:maxWarningMessage] + "..."
+	}
+	agg := &warningEvent{Object: object, Problem: problem, Message: msg, Count: 1}
+	w.seen[key] = agg
+	w.order = append(w.order, key)
+	return *agg, true
+}
+
+// summary returns every problem seen, in the order they first appeared.
+func (w *eventWatcher) summary() []warningEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]warningEvent, 0, len(w.order))
+	for _, key := range w.order {
+		out = append(out, *w.seen[key])
+	}
+	return out
+}
+
+// eventProblem names what an event is about. The kubelet reports an image
+// that cannot be pulled as both Failed and BackOff events, and a crashing
+// container as BackOff too; both are folded into the status kubectl shows.
+func eventProblem(reason, message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case (reason == "Failed" || reason == "BackOff") && (strings.Contains(lower, "pull") || strings.Contains(lower, "image")):
+		return "ImagePullBackOff"
+	case reason == "BackOff" && strings.Contains(lower, "restarting"):
+		return "CrashLoopBackOff"
+	}
+	return reason
+}
+
+// eventTime is when ev last happened, by whichever timestamp its source set.
+func eventTime(ev *corev1.Event) time.Time {
+	for _, t := range []time.Time{ev.LastTimestamp.Time, ev.EventTime.Time, ev.FirstTimestamp.Time} {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return ev.CreationTimestamp.Time
+}
+
+// printWarningSummary lists the warning events seen during a wait, whether it
+// timed out or the applications recovered, most repeated first.
+func printWarningSummary(events []warningEvent) {
+	if len(events) == 0 {
+		return
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Count > events[j].Count })
+	pterm.Warning.Println("Warning events during the wait:")
+	for _, ev := range events {
+		pterm.Warning.Printf("  %s\n", ev)
+	}
+}
@@ -0,0 +1,91 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func warning(pod, reason, message string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "openframe", Name: pod},
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func TestEventWatcherObserve(t *testing.T) {
+	start := time.Now()
+	w := newEventWatcher(start)
+
+	ev, isNew := w.observe(warning("mongodb-0", "FailedScheduling", "0/1 nodes are available:\n 1 Insufficient memory.", start))
+	require.True(t, isNew)
+	assert.Equal(t, "Pod openframe/mongodb-0: FailedScheduling: 0/1 nodes are available: 1 Insufficient memory.", ev.String())
+
+	_, isNew = w.observe(warning("mongodb-0", "FailedScheduling", "0/1 nodes are available: 1 Insufficient cpu.", start))
+	assert.False(t, isNew, "the same object and problem is counted, not reported again")
+
+	// The kubelet reports an unpullable image as Failed and BackOff events.
+	_, isNew = w.observe(warning("api-1", "Failed", "Failed to pull image \"ghcr.io/x:1\": not found", start))
+	assert.True(t, isNew)
+	_, isNew = w.observe(warning("api-1", "BackOff", "Back-off pulling image \"ghcr.io/x:1\"", start))
+	assert.False(t, isNew)
+
+	_, isNew = w.observe(warning("api-1", "BackOff", "Back-off restarting failed container api", start))
+	assert.True(t, isNew, "a crash loop is a different problem")
+
+	_, isNew = w.observe(warning("api-1", "Unhealthy", "Readiness probe failed", start))
+	assert.False(t, isNew, "probe failures are normal during startup")
+	_, isNew = w.observe(warning("old-0", "FailedMount", "timed out", start.Add(-time.Minute)))
+	assert.False(t, isNew, "events from before the wait are not news")
+	normal := warning("api-1", "FailedMount", "x", start)
+	normal.Type = corev1.EventTypeNormal
+	_, isNew = w.observe(normal)
+	assert.False(t, isNew)
+
+	summary := w.summary()
+	require.Len(t, summary, 3)
+	assert.Equal(t, []string{"FailedScheduling", "ImagePullBackOff", "CrashLoopBackOff"},
+		[]string{summary[0].Problem, summary[1].Problem, summary[2].Problem})
+	assert.Equal(t, 2, summary[0].Count)
+	assert.Equal(t, 2, summary[1].Count)
+}
+
+func TestEventWatcherRun(t *testing.T) {
+	client := fake.NewClientset()
+	fw := watch.NewFake()
+	client.PrependWatchReactor("events", k8stesting.DefaultWatchReactor(fw, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := newEventWatcher(time.Now().Add(-time.Second))
+	go w.run(ctx, client)
+
+	fw.Add(warning("mongodb-0", "FailedMount", "MountVolume.SetUp failed", time.Now()))
+	fw.Modify(warning("mongodb-0", "FailedMount", "MountVolume.SetUp failed", time.Now()))
+	fw.Add(warning("redis-0", "FailedScheduling", "0/1 nodes are available", time.Now()))
+
+	select {
+	case ev := <-w.C:
+		assert.Equal(t, "Pod openframe/mongodb-0", ev.Object)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no warning delivered")
+	}
+	select {
+	case ev := <-w.C:
+		assert.Equal(t, "Pod openframe/redis-0", ev.Object, "the repeat was aggregated, not delivered")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no second warning delivered")
+	}
+	assert.Equal(t, 2, w.summary()[0].Count)
+}
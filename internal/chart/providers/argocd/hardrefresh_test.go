@@ -39,7 +39,7 @@ func TestHardRefreshApplications_PatchesHardNotNormal(t *testing.T) {
 	)
 	bodies := capturePatches(m)
 
-	n := m.hardRefreshApplications(context.Background(), []string{"ingress-nginx", "openframe-config"})
+	n := len(m.hardRefreshApplications(context.Background(), []string{"ingress-nginx", "openframe-config"}))
 	if n != 2 {
 		t.Fatalf("both apps must be refreshed, got %d", n)
 	}
@@ -65,9 +65,9 @@ func TestHardRefreshApplications_SkipsEmptyNames(t *testing.T) {
 	m := fakeManager(appObj("real", ArgoCDStatusUnknown, ArgoCDStatusUnknown))
 	bodies := capturePatches(m)
 
-	n := m.hardRefreshApplications(context.Background(), []string{"", "real", ""})
-	if n != 1 {
-		t.Fatalf("only the named app may be refreshed, got %d", n)
+	refreshed := m.hardRefreshApplications(context.Background(), []string{"", "real", ""})
+	if len(refreshed) != 1 || refreshed[0] != "real" {
+		t.Fatalf("only the named app may be refreshed, got %v", refreshed)
 	}
 	if len(*bodies) != 1 {
 		t.Errorf("empty names must not produce patches, got %d", len(*bodies))
@@ -78,7 +78,7 @@ func TestHardRefreshApplications_SkipsEmptyNames(t *testing.T) {
 // (native init unavailable) the call is a safe no-op rather than a panic.
 func TestHardRefreshApplications_NilDynamicClient(t *testing.T) {
 	m := &Manager{}
-	if n := m.hardRefreshApplications(context.Background(), []string{"a"}); n != 0 {
+	if n := len(m.hardRefreshApplications(context.Background(), []string{"a"})); n != 0 {
 		t.Errorf("nil dynamic client must refresh nothing, got %d", n)
 	}
 }
@@ -105,3 +105,22 @@ func TestGetTotalExpectedApplications_UnknownWhenNoClient(t *testing.T) {
 		t.Fatalf("want 0 (unknown), got %d", got)
 	}
 }
+
+func TestDestinationNamespaces_Distinct(t *testing.T) {
+	withDest := func(name, ns string) *unstructured.Unstructured {
+		o := appObj(name, ArgoCDHealthHealthy, ArgoCDSyncSynced)
+		if ns != "" {
+			o.Object["spec"] = map[string]interface{}{"destination": map[string]interface{}{"namespace": ns}}
+		}
+		return o
+	}
+	m := fakeManager(withDest("a", "platform"), withDest("b", "platform"), withDest("c", "monitoring"), withDest("d", ""))
+
+	got, err := m.DestinationNamespaces(context.Background())
+	if err != nil {
+		t.Fatalf("DestinationNamespaces: %v", err)
+	}
+	if len(got) != 2 || got[0] != "platform" || got[1] != "monitoring" {
+		t.Fatalf("DestinationNamespaces = %v, want [platform monitoring]", got)
+	}
+}
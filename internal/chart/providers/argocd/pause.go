@@ -0,0 +1,80 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// pauseAutoSyncPatch drops the automated sync policy; a merge patch removes
+// a key set to null. CONSTANT JSON — no interpolation.
+const pauseAutoSyncPatch = `{"spec":{"syncPolicy":{"automated":null}}}`
+
+// PauseAutoSync turns off auto-sync on the root app-of-apps, so an abandoned
+// install stops rolling out new child Applications, and returns the policy it
+// removed as JSON for ResumeAutoSync. It returns "" when the root was not
+// auto-synced or does not exist yet; either way there is nothing to undo.
+func (m *Manager) PauseAutoSync(ctx context.Context) (string, error) {
+	if m.dynamicClient == nil {
+		if err := m.initKubernetesClients(); err != nil {
+			return "", err
+		}
+	}
+	if m.dynamicClient == nil {
+		return "", fmt.Errorf("dynamic client not available")
+	}
+	apps := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace)
+
+	obj, err := apps.Get(ctx, AppOfAppsName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", AppOfAppsName, err)
+	}
+	automated, found, err := unstructured.NestedFieldNoCopy(obj.Object, "spec", "syncPolicy", "automated")
+	if err != nil || !found || automated == nil {
+		return "", nil
+	}
+	policy, err := json.Marshal(automated)
+	if err != nil {
+		return "", err
+	}
+	if _, err := apps.Patch(ctx, AppOfAppsName, types.MergePatchType, []byte(pauseAutoSyncPatch), metav1.PatchOptions{}); err != nil {
+		return "", fmt.Errorf("pausing auto-sync of %s: %w", AppOfAppsName, err)
+	}
+	return string(policy), nil
+}
+
+// ResumeAutoSync puts back the automated sync policy PauseAutoSync removed.
+func (m *Manager) ResumeAutoSync(ctx context.Context, policy string) error {
+	if policy == "" {
+		return nil
+	}
+	var automated map[string]any
+	if err := json.Unmarshal([]byte(policy), &automated); err != nil {
+		return fmt.Errorf("invalid recorded sync policy: %w", err)
+	}
+	if m.dynamicClient == nil {
+		if err := m.initKubernetesClients(); err != nil {
+			return err
+		}
+	}
+	if m.dynamicClient == nil {
+		return fmt.Errorf("dynamic client not available")
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"syncPolicy": map[string]any{"automated": automated}}})
+	if err != nil {
+		return err
+	}
+	if _, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).
+		Patch(ctx, AppOfAppsName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("resuming auto-sync of %s: %w", AppOfAppsName, err)
+	}
+	return nil
+}
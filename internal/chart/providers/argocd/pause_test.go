@@ -0,0 +1,42 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPauseAndResumeAutoSync(t *testing.T) {
+	root := appObj(AppOfAppsName, ArgoCDHealthProgressing, ArgoCDSyncOutOfSync)
+	require.NoError(t, unstructured.SetNestedMap(root.Object, map[string]any{"prune": true, "selfHeal": true}, "spec", "syncPolicy", "automated"))
+	m := fakeManager(root)
+	apps := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace)
+
+	policy, err := m.PauseAutoSync(context.Background())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"prune": true, "selfHeal": true}`, policy)
+	obj, err := apps.Get(context.Background(), AppOfAppsName, metav1.GetOptions{})
+	require.NoError(t, err)
+	_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "syncPolicy", "automated")
+	assert.False(t, found, "auto-sync is off")
+
+	require.NoError(t, m.ResumeAutoSync(context.Background(), policy))
+	obj, err = apps.Get(context.Background(), AppOfAppsName, metav1.GetOptions{})
+	require.NoError(t, err)
+	selfHeal, _, _ := unstructured.NestedBool(obj.Object, "spec", "syncPolicy", "automated", "selfHeal")
+	assert.True(t, selfHeal, "the recorded policy is restored as it was")
+}
+
+func TestPauseAutoSync_NothingToPause(t *testing.T) {
+	policy, err := fakeManager().PauseAutoSync(context.Background())
+	require.NoError(t, err, "no app-of-apps yet")
+	assert.Empty(t, policy)
+
+	policy, err = fakeManager(appObj(AppOfAppsName, ArgoCDHealthHealthy, ArgoCDSyncSynced)).PauseAutoSync(context.Background())
+	require.NoError(t, err, "a manual-sync root")
+	assert.Empty(t, policy)
+}
@@ -0,0 +1,83 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/podexec"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LsRemoteFromCluster runs `git ls-remote` for repoURL inside the ArgoCD
+// repo-server — the component that will fetch it — so the answer reflects the
+// cluster's DNS, proxy and egress rather than this machine's. The repo-server
+// holds no git credentials of its own (ArgoCD passes them per request), so a
+// private repository comes back as git.ErrRemoteAuth: reachable, but not
+// proof that ArgoCD can read it. repoURL must not embed credentials; they
+// would end up on the pod's command line.
+func (m *Manager) LsRemoteFromCluster(ctx context.Context, repoURL string) error {
+	if err := m.initKubernetesClients(); err != nil {
+		return fmt.Errorf("failed to initialize the Kubernetes client: %w", err)
+	}
+	if m.kubeClient == nil || m.kubeConfig == nil {
+		return fmt.Errorf("kubernetes client unavailable")
+	}
+	pods, err := m.kubeClient.CoreV1().Pods(ArgoCDNamespace).List(ctx, metav1.ListOptions{LabelSelector: repoServerSelector})
+	if err != nil {
+		return fmt.Errorf("listing the ArgoCD repo-server pods: %w", err)
+	}
+	pod, err := podexec.Primary(pods.Items)
+	if err != nil {
+		return fmt.Errorf("ArgoCD repo-server: %w", err)
+	}
+
+	// ssh checks host keys against the known hosts ArgoCD itself uses, from
+	// argocd-ssh-known-hosts-cm, not the pod user's empty ~/.ssh.
+	sshCommand := "ssh -o BatchMode=yes -o UserKnownHostsFile=" + knownHostsFile(pod)
+	var out bytes.Buffer
+	err = podexec.Run(ctx, m.kubeConfig, m.kubeClient, podexec.Options{
+		Namespace: ArgoCDNamespace,
+		Pod:       pod.Name,
+		Command:   []string{"env", "GIT_TERMINAL_PROMPT=0", "GIT_SSH_COMMAND=" + sshCommand, "git", "ls-remote", repoURL, "HEAD"},
+		Stdout:    &bytes.Buffer{},
+		Stderr:    &out,
+		TTYFd:     -1,
+	})
+	var exit *executor.CommandError
+	if errors.As(err, &exit) {
+		return git.LsRemoteError(out.String())
+	}
+	return err
+}
+
+// knownHostsConfigMap holds the SSH known hosts ArgoCD checks repositories
+// against; the repo-server mounts it at defaultKnownHostsDir.
+const (
+	knownHostsConfigMap  = "argocd-ssh-known-hosts-cm"
+	defaultKnownHostsDir = "/app/config/ssh"
+)
+
+// knownHostsFile is where pod has argocd-ssh-known-hosts-cm mounted, as its
+// ssh_known_hosts file; the stock path when the pod spec does not say.
+func knownHostsFile(pod *corev1.Pod) string {
+	dir := defaultKnownHostsDir
+	for _, v := range pod.Spec.Volumes {
+		if v.ConfigMap == nil || v.ConfigMap.Name != knownHostsConfigMap {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, mount := range c.VolumeMounts {
+				if mount.Name == v.Name {
+					dir = mount.MountPath
+				}
+			}
+		}
+	}
+	return path.Join(dir, "ssh_known_hosts")
+}
@@ -0,0 +1,26 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestKnownHostsFile(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "gpg-keys", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-gpg-keys-cm"}}}},
+			{Name: "known-hosts", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: knownHostsConfigMap}}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "repo-server",
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "gpg-keys", MountPath: "/app/config/gpg/source"},
+				{Name: "known-hosts", MountPath: "/etc/argocd/ssh"},
+			},
+		}},
+	}}
+	assert.Equal(t, "/etc/argocd/ssh/ssh_known_hosts", knownHostsFile(pod))
+	assert.Equal(t, "/app/config/ssh/ssh_known_hosts", knownHostsFile(&corev1.Pod{}), "the stock mount when the spec does not say")
+}
@@ -0,0 +1,142 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// repoServerDeployment and repoServerContainer are the argo-cd chart's
+	// names for the repo-server workload.
+	repoServerDeployment = "argocd-repo-server"
+	repoServerContainer  = "repo-server"
+	// cmdParamsConfigMap holds the component flags; the repo-server reads its
+	// manifest-generation parallelism from repoServerParallelismKey at start.
+	cmdParamsConfigMap       = "argocd-cmd-params-cm"
+	repoServerParallelismKey = "reposerver.parallelism.limit"
+)
+
+// repoServerRecovery is the per-wait state of the recovery policy.
+type repoServerRecovery struct {
+	policy   models.RepoServerRecoveryPolicy
+	restarts int
+	patched  bool
+}
+
+func newRepoServerRecovery(p *models.RepoServerRecoveryPolicy) *repoServerRecovery {
+	return &repoServerRecovery{policy: p.WithDefaults()}
+}
+
+// enabled reports whether the policy takes any action at all.
+func (r *repoServerRecovery) enabled() bool { return r.policy.Action != models.RecoveryOff }
+
+// exhausted reports whether every allowed restart was used.
+func (r *repoServerRecovery) exhausted() bool { return r.restarts >= r.policy.MaxRestarts }
+
+// shouldPatch reports whether the next recovery is a resource patch: the
+// policy asks for it, it has not been done in this wait, and the repo-server
+// was OOM-killed (restarting an OOM-killed pod only replays the kill).
+func (r *repoServerRecovery) shouldPatch(oomKilled bool) bool {
+	return r.policy.Action == models.RecoveryPatch && !r.patched && oomKilled
+}
+
+// recoverRepoServer runs one recovery step under the policy for the app that
+// triggered it (may be empty) and reports whether the repo-server is healthy
+// again. A patch rolls the Deployment itself and does not count as a restart.
+func (m *Manager) recoverRepoServer(ctx context.Context, r *repoServerRecovery, appName string) bool {
+	if r.shouldPatch(m.repoServerOOMKilled(ctx)) {
+		r.patched = true
+		if err := m.patchRepoServerResources(ctx, r.policy); err != nil {
+			return false
+		}
+		return m.waitRepoServerRecovered(ctx, appName)
+	}
+	r.restarts++
+	metrics.Retry("repo-server-restart")
+	return m.triggerRepoServerRecovery(ctx, appName)
+}
+
+// repoServerOOMKilled reports whether a repo-server container is, or last
+// terminated, OOMKilled.
+func (m *Manager) repoServerOOMKilled(ctx context.Context) bool {
+	if m.kubeClient == nil {
+		return false
+	}
+	pods, err := m.kubeClient.CoreV1().Pods(ArgoCDNamespace).List(ctx, metav1.ListOptions{LabelSelector: repoServerSelector})
+	if err != nil {
+		return false
+	}
+	for i := range pods.Items {
+		for _, cs := range pods.Items[i].Status.ContainerStatuses {
+			if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+				return true
+			}
+			if t := cs.State.Terminated; t != nil && t.Reason == "OOMKilled" {
+				return true
+			}
+			if w := cs.State.Waiting; w != nil && w.Reason == "OOMKilled" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// patchRepoServerResources gives an OOM-killed repo-server the policy's memory
+// limit and parallelism. The parallelism goes into argocd-cmd-params-cm (the
+// container reads it from there at start); the memory limit goes into the
+// Deployment, whose rollout restarts the pods with both. The next helm upgrade
+// of ArgoCD reverts both — persist them as argocd: overrides in the values.
+func (m *Manager) patchRepoServerResources(ctx context.Context, p models.RepoServerRecoveryPolicy) error {
+	if m.kubeClient == nil {
+		return fmt.Errorf("native Kubernetes client unavailable")
+	}
+	cmPatch, err := json.Marshal(map[string]any{
+		"data": map[string]string{repoServerParallelismKey: fmt.Sprint(p.Parallelism)},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := m.kubeClient.CoreV1().ConfigMaps(ArgoCDNamespace).Patch(ctx, cmdParamsConfigMap,
+		types.MergePatchType, cmPatch, metav1.PatchOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("setting repo-server parallelism: %w", err)
+	}
+
+	depPatch, err := repoServerResourcePatch(p.MemoryLimit)
+	if err != nil {
+		return err
+	}
+	if _, err := m.kubeClient.AppsV1().Deployments(ArgoCDNamespace).Patch(ctx, repoServerDeployment,
+		types.StrategicMergePatchType, depPatch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("raising repo-server memory limit: %w", err)
+	}
+	return nil
+}
+
+// repoServerResourcePatch is the strategic-merge patch raising the repo-server
+// container's memory limit and request. The request is raised too so the
+// scheduler does not place it where the limit cannot be met anyway.
+func repoServerResourcePatch(memoryLimit string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []map[string]any{{
+						"name": repoServerContainer,
+						"resources": map[string]any{
+							"limits":   map[string]string{"memory": memoryLimit},
+							"requests": map[string]string{"memory": memoryLimit},
+						},
+					}},
+				},
+			},
+		},
+	})
+}
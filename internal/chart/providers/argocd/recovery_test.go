@@ -0,0 +1,101 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func oomKilledRepoServer() *corev1.Pod {
+	pod := repoServerPod("repo", time.Now().Add(-10*time.Minute))
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 repoServerContainer,
+		RestartCount:         2,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}
+	return pod
+}
+
+func repoServerDeploymentObj() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: repoServerDeployment, Namespace: ArgoCDNamespace},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  repoServerContainer,
+				Image: "quay.io/argoproj/argocd",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				}},
+			}},
+		}}},
+	}
+}
+
+func TestRepoServerOOMKilled(t *testing.T) {
+	m := &Manager{kubeClient: fake.NewClientset(oomKilledRepoServer())}
+	if !m.repoServerOOMKilled(context.Background()) {
+		t.Fatal("expected the last OOMKilled termination to be detected")
+	}
+	m = &Manager{kubeClient: fake.NewClientset(repoServerPod("repo", time.Now()))}
+	if m.repoServerOOMKilled(context.Background()) {
+		t.Fatal("a healthy repo-server is not OOM-killed")
+	}
+}
+
+func TestPatchRepoServerResources(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmdParamsConfigMap, Namespace: ArgoCDNamespace}}
+	client := fake.NewClientset(repoServerDeploymentObj(), cm)
+	m := &Manager{kubeClient: client}
+	policy := (&models.RepoServerRecoveryPolicy{Action: models.RecoveryPatch, MemoryLimit: "3Gi", Parallelism: 1}).WithDefaults()
+
+	if err := m.patchRepoServerResources(context.Background(), policy); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+
+	dep, _ := client.AppsV1().Deployments(ArgoCDNamespace).Get(context.Background(), repoServerDeployment, metav1.GetOptions{})
+	c := dep.Spec.Template.Spec.Containers[0]
+	if got := c.Resources.Limits.Memory().String(); got != "3Gi" {
+		t.Errorf("memory limit = %s, want 3Gi", got)
+	}
+	if c.Image == "" {
+		t.Error("the strategic merge must keep the rest of the container")
+	}
+	got, _ := client.CoreV1().ConfigMaps(ArgoCDNamespace).Get(context.Background(), cmdParamsConfigMap, metav1.GetOptions{})
+	if got.Data[repoServerParallelismKey] != "1" {
+		t.Errorf("parallelism = %q, want 1", got.Data[repoServerParallelismKey])
+	}
+}
+
+func TestRepoServerRecovery_Policy(t *testing.T) {
+	r := newRepoServerRecovery(nil)
+	if !r.enabled() || r.policy.MaxRestarts != 3 || r.shouldPatch(true) {
+		t.Fatalf("nil policy must be the built-in restart policy, got %+v", r.policy)
+	}
+
+	r = newRepoServerRecovery(&models.RepoServerRecoveryPolicy{Action: models.RecoveryPatch, MaxRestarts: 1})
+	if r.shouldPatch(false) {
+		t.Error("patch only applies to an OOM-killed repo-server")
+	}
+	if !r.shouldPatch(true) {
+		t.Error("an OOM-killed repo-server is patched under the patch policy")
+	}
+	r.patched = true
+	if r.shouldPatch(true) {
+		t.Error("the patch is applied once per wait")
+	}
+	r.restarts = 1
+	if !r.exhausted() {
+		t.Error("maxRestarts bounds the restarts")
+	}
+
+	if newRepoServerRecovery(&models.RepoServerRecoveryPolicy{Action: models.RecoveryOff}).enabled() {
+		t.Error("off disables recovery")
+	}
+}
@@ -0,0 +1,264 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArgoCD discovers repository credentials from Secrets in its namespace that
+// carry this label; the value selects a single repository ("repository") as
+// opposed to a URL-prefix credential template ("repo-creds").
+const (
+	repoSecretTypeLabel = "argocd.argoproj.io/secret-type"
+	repoSecretTypeRepo  = "repository"
+	managedByLabel      = "app.kubernetes.io/managed-by"
+	managedByValue      = "openframe-cli"
+)
+
+// argoServerProxyPath reaches the argocd-server API through the Kubernetes API
+// server's service proxy, so no port-forward (and no kubectl) is needed.
+const argoServerProxyPath = "/api/v1/namespaces/" + ArgoCDNamespace + "/services/https:argocd-server:443/proxy"
+
+// RepoCredentials describes a private Git repository ArgoCD must be able to
+// pull from. Exactly one auth method is used: an SSH private key for ssh/scp
+// URLs, or a username + token (password) for HTTPS URLs.
+type RepoCredentials struct {
+	URL           string
+	Username      string // HTTPS only; defaults to the conventional token user
+	Token         string // HTTPS password / personal access token
+	SSHPrivateKey string // PEM-encoded private key for SSH URLs
+	Insecure      bool   // skip host-key / TLS verification for this repo
+}
+
+// IsSSH reports whether the repository URL uses the SSH transport
+// (ssh://host/repo or the scp-like git@host:org/repo form).
+func (c RepoCredentials) IsSSH() bool {
+	if strings.HasPrefix(c.URL, "ssh://") {
+		return true
+	}
+	return !strings.Contains(c.URL, "://") && strings.Contains(c.URL, "@") && strings.Contains(c.URL, ":")
+}
+
+// Validate checks the credentials are usable before anything is written to the
+// cluster: the URL must be set and the auth method must match its transport.
+func (c RepoCredentials) Validate() error {
+	if strings.TrimSpace(c.URL) == "" {
+		return fmt.Errorf("repository URL is required")
+	}
+	if c.IsSSH() {
+		if c.SSHPrivateKey == "" {
+			return fmt.Errorf("repository %s uses SSH: an SSH private key is required", c.URL)
+		}
+		if c.Token != "" {
+			return fmt.Errorf("repository %s uses SSH: a token cannot be used, provide an SSH private key", c.URL)
+		}
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("repository URL %q must be an https:// or SSH URL", c.URL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("repository URL must not embed credentials; pass the token separately")
+	}
+	if c.Token == "" {
+		return fmt.Errorf("repository %s uses HTTPS: a token is required", c.URL)
+	}
+	if c.SSHPrivateKey != "" {
+		return fmt.Errorf("repository %s uses HTTPS: an SSH key cannot be used, provide a token", c.URL)
+	}
+	return nil
+}
+
+// RepoSecretName returns the deterministic Secret name for a repository URL, so
+// re-running the command updates the same Secret instead of piling up copies.
+// It mirrors ArgoCD's own "repo-<fnv32a>" naming.
+func RepoSecretName(repoURL string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(normalizeRepoURL(repoURL)))
+	return fmt.Sprintf("repo-%d", h.Sum32())
+}
+
+// repoSecret builds the ArgoCD repository Secret for creds.
+func repoSecret(creds RepoCredentials) *corev1.Secret {
+	data := map[string]string{
+		"type": "git",
+		"url":  creds.URL,
+	}
+	if creds.IsSSH() {
+		data["sshPrivateKey"] = creds.SSHPrivateKey
+	} else {
+		user := creds.Username
+		if user == "" {
+			user = git.GitTokenUser
+		}
+		data["username"] = user
+		data["password"] = creds.Token
+	}
+	if creds.Insecure {
+		data["insecure"] = "true"
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RepoSecretName(creds.URL),
+			Namespace: ArgoCDNamespace,
+			Labels: map[string]string{
+				repoSecretTypeLabel: repoSecretTypeRepo,
+				managedByLabel:      managedByValue,
+			},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: data,
+	}
+}
+
+// AddRepoCredentials server-side applies the ArgoCD repository Secret for
+// creds and returns its name. ArgoCD watches these Secrets, so applications
+// referencing the repository can sync right away.
+func (m *Manager) AddRepoCredentials(ctx context.Context, creds RepoCredentials) (string, error) {
+	if err := creds.Validate(); err != nil {
+		return "", err
+	}
+	if m.kubeClient == nil {
+		if err := m.initKubernetesClients(); err != nil {
+			return "", err
+		}
+	}
+	if m.kubeClient == nil {
+		return "", fmt.Errorf("kubernetes client not available")
+	}
+
+	// Server-side apply drops the keys an earlier apply set and this one does
+	// not, so switching auth method (token -> SSH) removes the old credential.
+	// A key written by a plain create (older CLI versions) is not ours and
+	// stays until the secret is deleted.
+	want := repoSecret(creds)
+	if _, err := k8s.ApplySecret(ctx, m.kubeClient, want); err != nil {
+		return "", fmt.Errorf("applying repository secret %q: %w", want.Name, err)
+	}
+	return want.Name, nil
+}
+
+// RepoConnectionState is ArgoCD's own verdict on whether it can reach a
+// repository with the credentials it holds.
+type RepoConnectionState struct {
+	Status  string `json:"status"` // "Successful" or "Failed"
+	Message string `json:"message"`
+}
+
+// Successful reports whether ArgoCD connected to the repository.
+func (s RepoConnectionState) Successful() bool {
+	return s.Status == "Successful"
+}
+
+// VerifyRepoConnection asks the ArgoCD API server to (re)test the connection to
+// repoURL and returns the result. It signs in as admin with the initial admin
+// password and goes through the Kubernetes service proxy, so it reflects what
+// ArgoCD itself sees — not merely whether the CLI host can reach the repo.
+func (m *Manager) VerifyRepoConnection(ctx context.Context, repoURL string) (RepoConnectionState, error) {
+	token, err := m.argoSessionToken(ctx)
+	if err != nil {
+		return RepoConnectionState{}, err
+	}
+
+	// List rather than GET /repositories/<url>: the URL-encoded repo in the path
+	// does not survive the service proxy's path handling intact.
+	raw, err := m.argoServerRequest(ctx, "GET", "/api/v1/repositories?forceRefresh=true", token, nil)
+	if err != nil {
+		return RepoConnectionState{}, fmt.Errorf("querying ArgoCD repositories: %w", err)
+	}
+	return parseRepoConnectionState(raw, repoURL)
+}
+
+// parseRepoConnectionState finds repoURL in an ArgoCD repository-list API
+// response and returns its connectionState.
+func parseRepoConnectionState(raw []byte, repoURL string) (RepoConnectionState, error) {
+	var list struct {
+		Items []struct {
+			Repo            string              `json:"repo"`
+			ConnectionState RepoConnectionState `json:"connectionState"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return RepoConnectionState{}, fmt.Errorf("parsing repository list: %w", err)
+	}
+	for _, item := range list.Items {
+		if normalizeRepoURL(item.Repo) == normalizeRepoURL(repoURL) {
+			if item.ConnectionState.Status == "" {
+				return RepoConnectionState{}, fmt.Errorf("ArgoCD did not report a connection state for %s", repoURL)
+			}
+			return item.ConnectionState, nil
+		}
+	}
+	return RepoConnectionState{}, fmt.Errorf("ArgoCD does not list repository %s yet", repoURL)
+}
+
+// argoSessionToken signs in to the ArgoCD API as admin with the initial admin
+// password and returns the session token.
+func (m *Manager) argoSessionToken(ctx context.Context) (string, error) {
+	password, err := m.AdminPassword(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, _ := json.Marshal(map[string]string{"username": "admin", "password": password})
+	raw, err := m.argoServerRequest(ctx, "POST", "/api/v1/session", "", body)
+	if err != nil {
+		return "", fmt.Errorf("signing in to the ArgoCD API: %w", err)
+	}
+	var session struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(raw, &session); err != nil || session.Token == "" {
+		return "", fmt.Errorf("signing in to the ArgoCD API: no session token returned")
+	}
+	return session.Token, nil
+}
+
+// argoServerRequest sends one request to the argocd-server API via the service
+// proxy. Tests replace it through the argoAPI field.
+func (m *Manager) argoServerRequest(ctx context.Context, verb, path, token string, body []byte) ([]byte, error) {
+	if m.argoAPI != nil {
+		return m.argoAPI(ctx, verb, path, token, body)
+	}
+	if m.kubeClient == nil {
+		return nil, fmt.Errorf("kubernetes client not available")
+	}
+	rc := m.kubeClient.CoreV1().RESTClient()
+	if rc == nil {
+		return nil, fmt.Errorf("kubernetes REST client not available")
+	}
+	// The service proxy forwards the query string unchanged; split it off so
+	// AbsPath does not escape the "?".
+	p, query, _ := strings.Cut(path, "?")
+	req := rc.Verb(verb).AbsPath(argoServerProxyPath+p).SetHeader("Content-Type", "application/json")
+	if query != "" {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		for k, vs := range values {
+			for _, v := range vs {
+				req = req.Param(k, v)
+			}
+		}
+	}
+	if token != "" {
+		// The Kubernetes proxy consumes the Authorization header itself, so
+		// ArgoCD's token goes in its session cookie instead.
+		req = req.SetHeader("Cookie", "argocd.token="+token)
+	}
+	if body != nil {
+		req = req.Body(body)
+	}
+	return req.DoRaw(ctx)
+}
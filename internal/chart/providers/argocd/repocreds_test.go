@@ -0,0 +1,170 @@
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRepoCredentials_Validate(t *testing.T) {
+	cases := []struct {
+		name    string
+		creds   RepoCredentials
+		wantErr string
+	}{
+		{"https token", RepoCredentials{URL: "https://github.com/org/repo", Token: "ghp_x"}, ""},
+		{"ssh key", RepoCredentials{URL: "git@github.com:org/repo.git", SSHPrivateKey: "KEY"}, ""},
+		{"ssh scheme", RepoCredentials{URL: "ssh://git@github.com/org/repo", SSHPrivateKey: "KEY"}, ""},
+		{"missing url", RepoCredentials{Token: "t"}, "URL is required"},
+		{"https without token", RepoCredentials{URL: "https://github.com/org/repo"}, "token is required"},
+		{"ssh without key", RepoCredentials{URL: "git@github.com:org/repo"}, "SSH private key is required"},
+		{"ssh with token", RepoCredentials{URL: "git@github.com:org/repo", SSHPrivateKey: "K", Token: "t"}, "token cannot be used"},
+		{"https with key", RepoCredentials{URL: "https://github.com/org/repo", Token: "t", SSHPrivateKey: "K"}, "SSH key cannot be used"},
+		{"embedded creds", RepoCredentials{URL: "https://u:p@github.com/org/repo", Token: "t"}, "must not embed credentials"},
+		{"bad scheme", RepoCredentials{URL: "ftp://github.com/org/repo", Token: "t"}, "https:// or SSH"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.creds.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoSecretName_StableAcrossSpellings(t *testing.T) {
+	a := RepoSecretName("https://github.com/Org/Repo.git")
+	b := RepoSecretName("https://github.com/org/repo")
+	if a != b {
+		t.Fatalf("secret names differ for the same repo: %q vs %q", a, b)
+	}
+	if !strings.HasPrefix(a, "repo-") {
+		t.Fatalf("secret name %q should start with repo-", a)
+	}
+}
+
+func TestManager_AddRepoCredentials_CreatesThenReplaces(t *testing.T) {
+	client := fake.NewClientset()
+	m := &Manager{kubeClient: client}
+	ctx := context.Background()
+
+	name, err := m.AddRepoCredentials(ctx, RepoCredentials{URL: "https://github.com/org/private", Token: "ghp_secret"})
+	if err != nil {
+		t.Fatalf("AddRepoCredentials: %v", err)
+	}
+	got, err := client.CoreV1().Secrets("argocd").Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("secret not created: %v", err)
+	}
+	if got.Labels[repoSecretTypeLabel] != "repository" {
+		t.Fatalf("secret-type label = %q, want repository", got.Labels[repoSecretTypeLabel])
+	}
+	if string(got.Data["password"]) != "ghp_secret" || string(got.Data["username"]) != "x-access-token" {
+		t.Fatalf("unexpected HTTPS credential payload: %v", got.Data)
+	}
+
+	// Re-running with the same URL updates the same Secret in place.
+	name2, err := m.AddRepoCredentials(ctx, RepoCredentials{URL: "https://github.com/org/private.git", Token: "ghp_rotated", Username: "bot"})
+	if err != nil {
+		t.Fatalf("second AddRepoCredentials: %v", err)
+	}
+	if name2 != name {
+		t.Fatalf("secret name changed on re-run: %q -> %q", name, name2)
+	}
+	got, _ = client.CoreV1().Secrets("argocd").Get(ctx, name, metav1.GetOptions{})
+	if string(got.Data["password"]) != "ghp_rotated" || string(got.Data["username"]) != "bot" {
+		t.Fatalf("secret not updated: %v", got.Data)
+	}
+}
+
+func TestManager_AddRepoCredentials_DropsStaleKeysKeepsForeignOnes(t *testing.T) {
+	client := fake.NewClientset()
+	m := &Manager{kubeClient: client}
+	ctx := context.Background()
+
+	name, err := m.AddRepoCredentials(ctx, RepoCredentials{URL: "https://github.com/org/private", Token: "ghp_secret", Insecure: true})
+	if err != nil {
+		t.Fatalf("AddRepoCredentials: %v", err)
+	}
+	// Another writer (e.g. a controller) annotates the Secret in between.
+	got, _ := client.CoreV1().Secrets("argocd").Get(ctx, name, metav1.GetOptions{})
+	got.Annotations = map[string]string{"example.com/rotated-by": "vault"}
+	if _, err := client.CoreV1().Secrets("argocd").Update(ctx, got, metav1.UpdateOptions{FieldManager: "vault"}); err != nil {
+		t.Fatalf("foreign update: %v", err)
+	}
+
+	if _, err := m.AddRepoCredentials(ctx, RepoCredentials{URL: "https://github.com/org/private", Token: "ghp_secret"}); err != nil {
+		t.Fatalf("second AddRepoCredentials: %v", err)
+	}
+	got, _ = client.CoreV1().Secrets("argocd").Get(ctx, name, metav1.GetOptions{})
+	if _, ok := got.Data["insecure"]; ok {
+		t.Fatalf("keys the CLI no longer applies must be dropped: %v", got.Data)
+	}
+	if got.Annotations["example.com/rotated-by"] != "vault" {
+		t.Fatalf("annotations set by other writers must survive: %v", got.Annotations)
+	}
+}
+
+func TestManager_AddRepoCredentials_InvalidWritesNothing(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	m := &Manager{kubeClient: client}
+	if _, err := m.AddRepoCredentials(context.Background(), RepoCredentials{URL: "https://github.com/org/repo"}); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	list, _ := client.CoreV1().Secrets("argocd").List(context.Background(), metav1.ListOptions{})
+	if len(list.Items) != 0 {
+		t.Fatalf("invalid credentials must not create a secret, found %d", len(list.Items))
+	}
+}
+
+func TestManager_VerifyRepoConnection(t *testing.T) {
+	admin := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-initial-admin-secret", Namespace: "argocd"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	var sawToken string
+	m := &Manager{
+		kubeClient: fake.NewSimpleClientset(admin),
+		argoAPI: func(_ context.Context, verb, path, token string, body []byte) ([]byte, error) {
+			if path == "/api/v1/session" {
+				if !strings.Contains(string(body), "hunter2") {
+					t.Errorf("session request did not carry the admin password: %s", body)
+				}
+				return []byte(`{"token":"jwt"}`), nil
+			}
+			sawToken = token
+			return []byte(`{"items":[
+				{"repo":"https://github.com/org/other","connectionState":{"status":"Successful"}},
+				{"repo":"https://github.com/org/private.git","connectionState":{"status":"Failed","message":"authentication required"}}
+			]}`), nil
+		},
+	}
+
+	state, err := m.VerifyRepoConnection(context.Background(), "https://github.com/org/private")
+	if err != nil {
+		t.Fatalf("VerifyRepoConnection: %v", err)
+	}
+	if sawToken != "jwt" {
+		t.Fatalf("repository query used token %q, want the session token", sawToken)
+	}
+	if state.Successful() || state.Message != "authentication required" {
+		t.Fatalf("state = %+v, want the Failed entry for the private repo", state)
+	}
+}
+
+func TestParseRepoConnectionState_RepoMissing(t *testing.T) {
+	if _, err := parseRepoConnectionState([]byte(`{"items":[]}`), "https://github.com/org/repo"); err == nil {
+		t.Fatal("expected an error when ArgoCD does not list the repository")
+	}
+}
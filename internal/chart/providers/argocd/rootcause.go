@@ -0,0 +1,285 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Root-cause kinds, in the order they are reported: a cause earlier in the
+// list usually explains the later ones (a missing secret keeps pods from
+// starting, which keeps the app Progressing).
+const (
+	CauseWebhook       = "WebhookFailure"
+	CauseMissingSecret = "MissingSecret"
+	CauseImagePull     = "ImagePullBackOff"
+	CausePVCPending    = "PVCPending"
+	CauseOOMKilled     = "OOMKilled"
+	CauseCrashLoop     = "CrashLoopBackOff"
+)
+
+var causeOrder = []string{CauseWebhook, CauseMissingSecret, CauseImagePull, CausePVCPending, CauseOOMKilled, CauseCrashLoop}
+
+// instanceLabel is the label ArgoCD's default resource tracking puts on every
+// object an application manages.
+const instanceLabel = "app.kubernetes.io/instance"
+
+// listConcurrency bounds how many namespaces root-cause analysis reads at
+// once. A large install spreads its stuck applications over many namespaces,
+// and reading them one after another made each pass take minutes.
+const listConcurrency = 4
+
+// maxObjectsPerCause bounds the examples listed under one root cause.
+const maxObjectsPerCause = 3
+
+// RootCause is one classified reason an application is not becoming healthy.
+type RootCause struct {
+	Kind   string
+	App    string
+	Object string // e.g. "pod/openframe/api-7d9f" ("" for app-level causes)
+	Detail string
+}
+
+// analyzeRootCauses inspects the pods and PVCs behind every application that
+// is not Healthy and classifies why. It replaces reading raw events and logs:
+// the handful of failure modes below account for nearly every stuck install.
+// Best-effort — an unreadable namespace is skipped.
+func (m *Manager) analyzeRootCauses(ctx context.Context, apps []Application) []RootCause {
+	var stuck []Application
+	for _, app := range apps {
+		if app.Health != ArgoCDHealthHealthy {
+			stuck = append(stuck, app)
+		}
+	}
+	if len(stuck) == 0 {
+		return nil
+	}
+
+	causes := appLevelCauses(stuck)
+	if m.kubeClient == nil {
+		return causes
+	}
+
+	byNamespace := map[string][]Application{}
+	var namespaces []string
+	for _, app := range stuck {
+		if app.Namespace == "" {
+			continue
+		}
+		if _, ok := byNamespace[app.Namespace]; !ok {
+			namespaces = append(namespaces, app.Namespace)
+		}
+		byNamespace[app.Namespace] = append(byNamespace[app.Namespace], app)
+	}
+
+	// One pod and one PVC listing per namespace, the namespaces read
+	// concurrently; results are merged in namespace order.
+	results := make([][]RootCause, len(namespaces))
+	sem := make(chan struct{}, listConcurrency)
+	var wg sync.WaitGroup
+	for i, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = m.namespaceCauses(ctx, ns, byNamespace[ns])
+		}()
+	}
+	wg.Wait()
+	for _, r := range results {
+		causes = append(causes, r...)
+	}
+	return causes
+}
+
+// namespaceCauses lists the pods and PVCs of ns and classifies them. An
+// unreadable namespace yields nothing.
+func (m *Manager) namespaceCauses(ctx context.Context, ns string, apps []Application) []RootCause {
+	pods, err := m.kubeClient.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	pvcs, err := m.kubeClient.CoreV1().PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		pvcs = &corev1.PersistentVolumeClaimList{}
+	}
+	return classifyNamespace(apps, pods.Items, pvcs.Items)
+}
+
+// appLevelCauses classifies what the Application status itself says.
+func appLevelCauses(apps []Application) []RootCause {
+	var causes []RootCause
+	for _, app := range apps {
+		for _, msg := range []string{app.Condition, app.OperationMessage, app.HealthMessage} {
+			if strings.Contains(msg, "failed calling webhook") {
+				causes = append(causes, RootCause{Kind: CauseWebhook, App: app.Name, Detail: firstLine(msg)})
+				break
+			}
+		}
+	}
+	return causes
+}
+
+// classifyNamespace classifies the pods and PVCs of one namespace, attributing
+// each object to the application that manages it.
+func classifyNamespace(apps []Application, pods []corev1.Pod, pvcs []corev1.PersistentVolumeClaim) []RootCause {
+	owner := func(labels map[string]string) string {
+		if name := labels[instanceLabel]; name != "" {
+			for _, app := range apps {
+				if app.Name == name {
+					return name
+				}
+			}
+			return ""
+		}
+		if len(apps) == 1 {
+			return apps[0].Name
+		}
+		return ""
+	}
+
+	var causes []RootCause
+	for i := range pods {
+		pod := pods[i]
+		app := owner(pod.Labels)
+		if app == "" {
+			continue
+		}
+		if c, ok := classifyPod(pod); ok {
+			c.App = app
+			causes = append(causes, c)
+		}
+	}
+	for i := range pvcs {
+		pvc := pvcs[i]
+		app := owner(pvc.Labels)
+		if app == "" || pvc.Status.Phase != corev1.ClaimPending {
+			continue
+		}
+		detail := "claim is not bound"
+		if sc := pvc.Spec.StorageClassName; sc != nil && *sc != "" {
+			detail = fmt.Sprintf("claim is not bound (storage class %q)", *sc)
+		}
+		causes = append(causes, RootCause{Kind: CausePVCPending, App: app, Object: "pvc/" + pvc.Namespace + "/" + pvc.Name, Detail: detail})
+	}
+	return causes
+}
+
+// classifyPod returns the most telling failure of a pod, if any.
+func classifyPod(pod corev1.Pod) (RootCause, bool) {
+	object := "pod/" + pod.Namespace + "/" + pod.Name
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		w := cs.State.Waiting
+		if w == nil {
+			continue
+		}
+		switch w.Reason {
+		case "CreateContainerConfigError":
+			if strings.Contains(w.Message, "not found") {
+				return RootCause{Kind: CauseMissingSecret, Object: object, Detail: firstLine(w.Message)}, true
+			}
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			return RootCause{Kind: CauseImagePull, Object: object, Detail: fmt.Sprintf("cannot pull %s", cs.Image)}, true
+		}
+	}
+	for _, cs := range statuses {
+		if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+			return RootCause{Kind: CauseOOMKilled, Object: object, Detail: fmt.Sprintf("container %s ran out of memory (%d restart(s))", cs.Name, cs.RestartCount)}, true
+		}
+		if t := cs.State.Terminated; t != nil && t.Reason == "OOMKilled" {
+			return RootCause{Kind: CauseOOMKilled, Object: object, Detail: fmt.Sprintf("container %s ran out of memory", cs.Name)}, true
+		}
+	}
+	for _, cs := range statuses {
+		if w := cs.State.Waiting; w != nil && w.Reason == "CrashLoopBackOff" {
+			return RootCause{Kind: CauseCrashLoop, Object: object, Detail: fmt.Sprintf("container %s keeps crashing (%d restart(s))", cs.Name, cs.RestartCount)}, true
+		}
+	}
+	if pod.Status.Phase == corev1.PodPending {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && strings.Contains(c.Message, "PersistentVolumeClaim") {
+				return RootCause{Kind: CausePVCPending, Object: object, Detail: "waiting for its volume claim to bind"}, true
+			}
+		}
+	}
+	return RootCause{}, false
+}
+
+// remediation is the suggested next step for a root-cause kind.
+func remediation(kind string) string {
+	switch kind {
+	case CauseWebhook:
+		return "The webhook's backing pods are not ready yet; check them (often cert-manager or an ingress controller) and re-sync with: openframe app upgrade --sync"
+	case CauseMissingSecret:
+		return "A referenced Secret or ConfigMap does not exist; create it in that namespace or fix the name in openframe-helm-values.yaml"
+	case CauseImagePull:
+		return "Check the image name and tag, and registry access; for a private registry pass --registry-auth to openframe app install"
+	case CausePVCPending:
+		return "No volume could be provisioned; check `kubectl get storageclass` and that the local-path provisioner in kube-system is running"
+	case CauseOOMKilled:
+		return "Raise the container's memory limit in openframe-helm-values.yaml, or give Docker (or WSL) more memory"
+	case CauseCrashLoop:
+		return "Read the crash output with: kubectl logs <pod> -n <namespace> --previous"
+	}
+	return ""
+}
+
+// printRootCauses prints a compact summary: one block per kind, naming the
+// affected applications, a few example objects, and what to do about it.
+func printRootCauses(causes []RootCause) {
+	if len(causes) == 0 {
+		return
+	}
+	byKind := map[string][]RootCause{}
+	for _, c := range causes {
+		byKind[c.Kind] = append(byKind[c.Kind], c)
+	}
+
+	pterm.Warning.Println("Likely root causes:")
+	for _, kind := range causeOrder {
+		list := byKind[kind]
+		if len(list) == 0 {
+			continue
+		}
+		appSet := map[string]bool{}
+		for _, c := range list {
+			appSet[c.App] = true
+		}
+		apps := make([]string, 0, len(appSet))
+		for a := range appSet {
+			apps = append(apps, a)
+		}
+		sort.Strings(apps)
+
+		pterm.Warning.Printf("  %s in %s\n", kind, strings.Join(apps, ", "))
+		for i, c := range list {
+			if i == maxObjectsPerCause {
+				pterm.DefaultBasicText.Printf("      … and %d more\n", len(list)-maxObjectsPerCause)
+				break
+			}
+			if c.Object != "" {
+				pterm.DefaultBasicText.Printf("      %s: %s\n", c.Object, c.Detail)
+			} else {
+				pterm.DefaultBasicText.Printf("      %s\n", c.Detail)
+			}
+		}
+		pterm.Info.Printf("    → %s\n", remediation(kind))
+	}
+}
+
+// firstLine trims a multi-line status message to its first line.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,146 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func appPod(name, app string, status corev1.PodStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openframe", Labels: map[string]string{instanceLabel: app}},
+		Status:     status,
+	}
+}
+
+func waiting(reason, message string) corev1.PodStatus {
+	return corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:  "main",
+		Image: "ghcr.io/acme/api:v9",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+	}}}
+}
+
+func causeKinds(causes []RootCause) map[string]string {
+	kinds := map[string]string{}
+	for _, c := range causes {
+		kinds[c.Object] = c.Kind
+	}
+	return kinds
+}
+
+func TestAnalyzeRootCauses_ClassifiesPods(t *testing.T) {
+	oom := corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:                 "main",
+		RestartCount:         4,
+		State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}}
+	pendingPVC := corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{
+		Type: corev1.PodScheduled, Status: corev1.ConditionFalse,
+		Message: "0/1 nodes are available: pod has unbound immediate PersistentVolumeClaims.",
+	}}}
+
+	m := &Manager{kubeClient: fake.NewSimpleClientset(
+		appPod("api-1", "api", waiting("ImagePullBackOff", "Back-off pulling image")),
+		appPod("api-2", "api", waiting("CreateContainerConfigError", `secret "api-db" not found`)),
+		appPod("db-1", "db", oom),
+		appPod("db-2", "db", pendingPVC),
+		appPod("ui-1", "ui", waiting("CrashLoopBackOff", "")),
+		appPod("healthy-1", "healthy", waiting("ImagePullBackOff", "")),
+	)}
+	apps := []Application{
+		{Name: "api", Health: "Progressing", Namespace: "openframe"},
+		{Name: "db", Health: "Degraded", Namespace: "openframe"},
+		{Name: "ui", Health: "Degraded", Namespace: "openframe"},
+		{Name: "healthy", Health: ArgoCDHealthHealthy, Namespace: "openframe"},
+	}
+
+	got := causeKinds(m.analyzeRootCauses(context.Background(), apps))
+	want := map[string]string{
+		"pod/openframe/api-1": CauseImagePull,
+		"pod/openframe/api-2": CauseMissingSecret,
+		"pod/openframe/db-1":  CauseOOMKilled,
+		"pod/openframe/db-2":  CausePVCPending,
+		"pod/openframe/ui-1":  CauseCrashLoop,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("causes = %v, want %v", got, want)
+	}
+	for obj, kind := range want {
+		if got[obj] != kind {
+			t.Errorf("%s: kind = %q, want %q", obj, got[obj], kind)
+		}
+	}
+}
+
+func TestAnalyzeRootCauses_WebhookFromAppStatus(t *testing.T) {
+	m := &Manager{}
+	causes := m.analyzeRootCauses(context.Background(), []Application{{
+		Name:             "ingress",
+		Health:           "Progressing",
+		OperationMessage: "Internal error occurred: failed calling webhook \"validate.nginx.ingress.kubernetes.io\": connection refused\nmore",
+	}})
+	if len(causes) != 1 || causes[0].Kind != CauseWebhook || causes[0].App != "ingress" {
+		t.Fatalf("causes = %+v", causes)
+	}
+	if causes[0].Detail == "" || remediation(CauseWebhook) == "" {
+		t.Fatal("webhook cause needs a detail and a remediation")
+	}
+}
+
+func TestClassifyNamespace_PendingClaim(t *testing.T) {
+	sc := "local-path"
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "openframe"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &sc},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+	// An unlabelled object is attributed to the only app in its namespace.
+	causes := classifyNamespace([]Application{{Name: "db"}}, nil, []corev1.PersistentVolumeClaim{pvc})
+	if len(causes) != 1 || causes[0].Kind != CausePVCPending || causes[0].App != "db" {
+		t.Fatalf("causes = %+v", causes)
+	}
+}
+
+func TestAnalyzeRootCauses_ListsEachNamespaceOnce(t *testing.T) {
+	var objects []runtime.Object
+	var apps []Application
+	for _, ns := range []string{"ns-a", "ns-b", "ns-c", "ns-d", "ns-e", "ns-f"} {
+		for _, name := range []string{"x", "y"} {
+			app := ns + "-" + name
+			pod := appPod(app+"-0", app, waiting("ImagePullBackOff", ""))
+			pod.Namespace = ns
+			objects = append(objects, pod)
+			apps = append(apps, Application{Name: app, Health: "Progressing", Namespace: ns})
+		}
+	}
+	cs := fake.NewSimpleClientset(objects...)
+	m := &Manager{kubeClient: cs}
+
+	causes := m.analyzeRootCauses(context.Background(), apps)
+	if len(causes) != len(apps) {
+		t.Fatalf("causes = %+v", causes)
+	}
+	for i, c := range causes {
+		if c.App != apps[i].Name {
+			t.Errorf("cause %d is for %s, want %s: results must keep namespace order", i, c.App, apps[i].Name)
+		}
+	}
+	lists := map[string]int{}
+	for _, a := range cs.Actions() {
+		lists[a.GetNamespace()+"/"+a.GetResource().Resource]++
+	}
+	for key, n := range lists {
+		if n != 1 {
+			t.Errorf("%s listed %d times, want once", key, n)
+		}
+	}
+	if len(lists) != 12 {
+		t.Errorf("listings = %v, want pods and PVCs of each of the 6 namespaces", lists)
+	}
+}
@@ -0,0 +1,80 @@
+package argocd
+
+import (
+	"context"
+	"time"
+)
+
+// Polling schedule for WaitForApplications. The loop used to wake every 10ms
+// and decide by hand whether any check was due, and a failing cluster was
+// re-queried on a short fixed interval — under WSL every query is a process
+// spawn, so an unhealthy cluster was hammered hardest exactly when it could
+// least afford it. Each concern (application status, cluster health, resource
+// checks) now has its own timer, and a failing cluster is polled less, not
+// more.
+
+// Overridden in tests.
+var (
+	// bootstrapWait is how long ArgoCD gets to create its first applications
+	// before their status is read.
+	bootstrapWait = 30 * time.Second
+
+	// appCheckInterval is how often application status is read while the
+	// cluster is healthy.
+	appCheckInterval = 2 * time.Second
+
+	// maxUnhealthyBackoff caps the wait between queries to a failing cluster.
+	maxUnhealthyBackoff = 30 * time.Second
+)
+
+const (
+	// healthCheckInterval is how often cluster connectivity is probed on its
+	// own. A successful application query proves the same thing, so the probe
+	// only spawns anything when application queries have gone quiet.
+	healthCheckInterval = 10 * time.Second
+
+	// resourceCheckInterval is how often system resources and the
+	// repo-server are inspected.
+	resourceCheckInterval = 5 * time.Minute
+
+	// diskCheckInterval is how often the nodes' free disk space is measured.
+	diskCheckInterval = time.Minute
+
+	// bootstrapHealthCheckInterval is how often connectivity is probed while
+	// ArgoCD creates its first applications.
+	bootstrapHealthCheckInterval = 5 * time.Second
+)
+
+// unhealthyBackoff is how long to hold off the next cluster query after
+// failures consecutive failures: 2s, 4s, 8s, ... capped at maxUnhealthyBackoff.
+// Zero failures means the cluster is healthy and there is nothing to back off.
+func unhealthyBackoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	d := 2 * time.Second
+	for i := 1; i < failures && d < maxUnhealthyBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxUnhealthyBackoff)
+}
+
+// healthProbeDue reports whether the standalone connectivity probe has to run:
+// an application query that succeeded within the last interval already showed
+// the API server is reachable.
+func healthProbeDue(lastAppQueryOK time.Time, interval time.Duration) bool {
+	return lastAppQueryOK.IsZero() || time.Since(lastAppQueryOK) >= interval
+}
+
+// sleepContext waits for d, returning early with the context's error when ctx
+// is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnhealthyBackoff_DoublesUpToCap(t *testing.T) {
+	var got []time.Duration
+	for failures := 0; failures <= 7; failures++ {
+		got = append(got, unhealthyBackoff(failures))
+	}
+	assert.Equal(t, []time.Duration{
+		0, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		maxUnhealthyBackoff, maxUnhealthyBackoff, maxUnhealthyBackoff,
+	}, got)
+	assert.Equal(t, maxUnhealthyBackoff, unhealthyBackoff(1000), "no overflow on a long outage")
+}
+
+func TestHealthProbeDue_SkippedAfterRecentAppQuery(t *testing.T) {
+	assert.True(t, healthProbeDue(time.Time{}, healthCheckInterval), "no application query has succeeded yet")
+	assert.False(t, healthProbeDue(time.Now().Add(-time.Second), healthCheckInterval))
+	assert.True(t, healthProbeDue(time.Now().Add(-2*healthCheckInterval), healthCheckInterval))
+}
+
+func TestSleepContext_ReturnsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	assert.ErrorIs(t, sleepContext(ctx, time.Hour), context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, sleepContext(context.Background(), time.Millisecond))
+}
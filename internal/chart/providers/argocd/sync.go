@@ -35,10 +35,11 @@ const refreshHardPatch = `{"metadata":{"annotations":{"argocd.argoproj.io/refres
 // API port is needed. `prune` controls whether ArgoCD DELETES resources no
 // longer present in git; it is off by default because a force-sync of a moved
 // ref must never silently delete workloads (deleting a child Application
-// cascades to its resources). Only the boolean is interpolated, so there is no
-// injection surface.
-func syncOperationPatch(prune bool) string {
-	return fmt.Sprintf(`{"operation":{"initiatedBy":{"username":"openframe-cli"},"sync":{"prune":%t,"syncStrategy":{"apply":{"force":false}}}}}`, prune)
+// cascades to its resources). `force` makes ArgoCD replace resources whose
+// apply is rejected (immutable fields) instead of failing the sync. Only the
+// booleans are interpolated, so there is no injection surface.
+func syncOperationPatch(prune, force bool) string {
+	return fmt.Sprintf(`{"operation":{"initiatedBy":{"username":"openframe-cli"},"sync":{"prune":%t,"syncStrategy":{"apply":{"force":%t}}}}}`, prune, force)
 }
 
 // RefreshAndSync forces ArgoCD to re-read git for the root app-of-apps
@@ -89,7 +90,7 @@ func (m *Manager) RefreshAndSync(ctx context.Context, prune bool) error {
 	}
 
 	// 3) Trigger the sync via the top-level .operation field.
-	if _, err := apps.Patch(ctx, AppOfAppsName, types.MergePatchType, []byte(syncOperationPatch(prune)), metav1.PatchOptions{}); err != nil {
+	if _, err := apps.Patch(ctx, AppOfAppsName, types.MergePatchType, []byte(syncOperationPatch(prune, false)), metav1.PatchOptions{}); err != nil {
 		return fmt.Errorf("triggering sync of %s: %w", AppOfAppsName, err)
 	}
 
@@ -204,11 +205,11 @@ func (m *Manager) syncChildApplications(ctx context.Context, prune bool) error {
 	groups, labeled := groupChildren(children)
 	if !labeled {
 		// Legacy manifests without the group label: one ungated pass over all.
-		patched, failed, firstErr = m.syncApplicationsByName(ctx, groups[0].names, prune)
+		patched, failed, firstErr = m.syncApplicationsByName(ctx, groups[0].names, prune, false)
 	} else {
 		for i, g := range groups {
 			pterm.Info.Printf("Sync group %d: syncing %d application(s): %s\n", g.number, len(g.names), strings.Join(g.names, ", "))
-			p, f, e := m.syncApplicationsByName(ctx, g.names, prune)
+			p, f, e := m.syncApplicationsByName(ctx, g.names, prune, false)
 			patched, failed = patched+p, failed+f
 			if firstErr == nil {
 				firstErr = e
@@ -314,14 +315,14 @@ func (m *Manager) waitGroupReady(ctx context.Context, names []string) []string {
 // syncApplicationsByName applies the sync-operation patch to each named
 // Application, returning how many were patched, how many failed, and the first
 // failure. Lazily initializes the Kubernetes clients like RefreshAndSync.
-func (m *Manager) syncApplicationsByName(ctx context.Context, names []string, prune bool) (patched, failed int, firstErr error) {
+func (m *Manager) syncApplicationsByName(ctx context.Context, names []string, prune, force bool) (patched, failed int, firstErr error) {
 	if m.dynamicClient == nil {
 		if err := m.initKubernetesClients(); err != nil {
 			return 0, len(names), err
 		}
 	}
 	apps := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace)
-	patch := []byte(syncOperationPatch(prune))
+	patch := []byte(syncOperationPatch(prune, force))
 	for _, name := range names {
 		if _, err := apps.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
 			failed++
@@ -51,11 +51,14 @@ func TestRefreshAndSync_PatchesAppOfApps(t *testing.T) {
 
 // TestSyncOperationPatch_PruneDefault locks that the default patch does NOT prune.
 func TestSyncOperationPatch_PruneDefault(t *testing.T) {
-	if !strings.Contains(syncOperationPatch(false), `"prune":false`) {
-		t.Errorf("default sync patch must not prune: %s", syncOperationPatch(false))
+	if !strings.Contains(syncOperationPatch(false, false), `"prune":false`) {
+		t.Errorf("default sync patch must not prune: %s", syncOperationPatch(false, false))
 	}
-	if !strings.Contains(syncOperationPatch(true), `"prune":true`) {
-		t.Errorf("prune=true patch must prune: %s", syncOperationPatch(true))
+	if !strings.Contains(syncOperationPatch(true, false), `"prune":true`) {
+		t.Errorf("prune=true patch must prune: %s", syncOperationPatch(true, false))
+	}
+	if !strings.Contains(syncOperationPatch(false, false), `"force":false`) || !strings.Contains(syncOperationPatch(false, true), `"force":true`) {
+		t.Errorf("force must follow the flag: %s / %s", syncOperationPatch(false, false), syncOperationPatch(false, true))
 	}
 }
 
@@ -0,0 +1,135 @@
+package argocd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/synchistory"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/tracing"
+)
+
+// syncRecorder follows each application through one WaitForApplications for
+// the sync history (see internal/chart/synchistory): the sync operations ArgoCD
+// ran for it, told apart by their start time, how they ended, and what the CLI
+// did to move it along.
+type syncRecorder struct {
+	cluster string
+	started time.Time
+	apps    map[string]*appSyncRecord
+	order   []string // in the order first seen
+}
+
+type appSyncRecord struct {
+	synchistory.App
+	firstSeen time.Time
+	operation string // start time of the operation last seen
+	retries   int64  // its retry count when last seen
+	failed    bool   // whether it was counted as a failure
+}
+
+func newSyncRecorder(cluster string, now time.Time) *syncRecorder {
+	return &syncRecorder{cluster: cluster, started: now, apps: make(map[string]*appSyncRecord)}
+}
+
+func (r *syncRecorder) app(name string, now time.Time) *appSyncRecord {
+	rec := r.apps[name]
+	if rec == nil {
+		rec = &appSyncRecord{App: synchistory.App{Name: name}, firstSeen: now}
+		r.apps[name] = rec
+		r.order = append(r.order, name)
+	}
+	return rec
+}
+
+// observe records the operations seen in one poll of the applications. An
+// operation that started before the wait — the last sync of an earlier
+// install — is not one of this run's attempts.
+func (r *syncRecorder) observe(apps []Application, now time.Time) {
+	for _, app := range apps {
+		rec := r.app(app.Name, now)
+		if app.OperationStarted == "" {
+			continue
+		}
+		if app.OperationStarted != rec.operation {
+			rec.operation, rec.retries, rec.failed = app.OperationStarted, 0, false
+			if started, err := time.Parse(time.RFC3339, app.OperationStarted); err == nil && started.Before(r.started) {
+				rec.failed = true // not ours to count
+				rec.retries = app.RetryCount
+				continue
+			}
+			rec.Attempts++
+		}
+		if app.RetryCount > rec.retries {
+			rec.Retries += int(app.RetryCount - rec.retries)
+			rec.retries = app.RetryCount
+		}
+		if !rec.failed && (app.OperationPhase == "Failed" || app.OperationPhase == "Error") {
+			rec.failed = true
+			rec.Failures++
+		}
+	}
+}
+
+// ready records that the named applications became Healthy and Synced.
+func (r *syncRecorder) ready(names []string, now time.Time) {
+	for _, name := range names {
+		rec := r.app(name, now)
+		if !rec.Ready {
+			rec.Ready = true
+			rec.Duration = now.Sub(rec.firstSeen)
+		}
+	}
+}
+
+// Retry operations of a nudge, for the metrics.
+const (
+	nudgeSync        = "application-sync"
+	nudgeHardRefresh = "application-hard-refresh"
+)
+
+// nudged records that the CLI synced (nudgeSync) or hard-refreshed
+// (nudgeHardRefresh) the named applications.
+func (r *syncRecorder) nudged(names []string, operation string, now time.Time) {
+	for _, name := range names {
+		r.app(name, now).Nudges++
+		metrics.Retry(operation)
+	}
+}
+
+// run is the wait as it ended at now.
+func (r *syncRecorder) run(now time.Time, err error) synchistory.Run {
+	run := synchistory.Run{
+		Cluster:  r.cluster,
+		Started:  r.started,
+		Finished: now,
+		Success:  err == nil,
+		CI:       sharedconfig.EnvBool("CI"),
+	}
+	for _, name := range r.order {
+		rec := r.apps[name]
+		if !rec.Ready {
+			rec.Duration = now.Sub(rec.firstSeen)
+		}
+		run.Apps = append(run.Apps, rec.App)
+	}
+	return run
+}
+
+// save records the wait in the sync history and the trace, one span per
+// application from when it was first seen until it was ready. Saving is
+// best-effort.
+func (r *syncRecorder) save(err error) {
+	run := r.run(time.Now(), err)
+	tracing.Span("wait applications", run.Started, run.Finished, err != nil, "openframe.cluster", r.cluster)
+	for _, a := range run.Apps {
+		start := r.apps[a.Name].firstSeen
+		tracing.Span("wait "+a.Name, start, start.Add(a.Duration), !a.Ready,
+			"argocd.application", a.Name,
+			"argocd.sync.attempts", strconv.Itoa(a.Attempts),
+			"argocd.sync.failures", strconv.Itoa(a.Failures),
+			"argocd.sync.retries", strconv.Itoa(a.Retries))
+	}
+	_ = synchistory.Record(run)
+}
@@ -0,0 +1,39 @@
+package argocd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/synchistory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRecorder(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	stamp := func(d time.Duration) string { return at(d).Format(time.RFC3339) }
+	r := newSyncRecorder("dev", start)
+
+	// api: an operation left over from an earlier install, then one that is
+	// retried twice and fails, then one that succeeds.
+	// ui: one clean sync, never ready before the wait ends.
+	r.observe([]Application{
+		{Name: "api", OperationStarted: stamp(-time.Hour), OperationPhase: "Failed", RetryCount: 4},
+		{Name: "ui", OperationStarted: stamp(time.Second), OperationPhase: "Running"},
+	}, at(0))
+	r.observe([]Application{{Name: "api", OperationStarted: stamp(10 * time.Second), OperationPhase: "Running", RetryCount: 1}}, at(20*time.Second))
+	r.observe([]Application{{Name: "api", OperationStarted: stamp(10 * time.Second), OperationPhase: "Failed", RetryCount: 2}}, at(30*time.Second))
+	r.observe([]Application{{Name: "api", OperationStarted: stamp(10 * time.Second), OperationPhase: "Failed", RetryCount: 2}}, at(40*time.Second))
+	r.nudged([]string{"api"}, nudgeSync, at(45*time.Second))
+	r.observe([]Application{{Name: "api", OperationStarted: stamp(50 * time.Second), OperationPhase: "Succeeded"}}, at(time.Minute))
+	r.ready([]string{"api"}, at(time.Minute))
+	r.ready([]string{"api"}, at(2*time.Minute))
+
+	run := r.run(at(3*time.Minute), assert.AnError)
+	assert.Equal(t, "dev", run.Cluster)
+	assert.False(t, run.Success)
+	assert.Equal(t, []synchistory.App{
+		{Name: "api", Attempts: 2, Failures: 1, Retries: 2, Nudges: 1, Ready: true, Duration: time.Minute},
+		{Name: "ui", Attempts: 1, Duration: 3 * time.Minute},
+	}, run.Apps)
+}
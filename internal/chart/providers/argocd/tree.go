@@ -0,0 +1,385 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceNode is one resource of an application, with the resources it owns.
+type ResourceNode struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Sync is set on the resources the application manages, the roots.
+	Sync    string `json:"sync,omitempty"`
+	Health  string `json:"health,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Info is what ArgoCD shows next to the resource, e.g. "Containers: 0/1".
+	Info     []string        `json:"info,omitempty"`
+	Children []*ResourceNode `json:"children,omitempty"`
+}
+
+func (n *ResourceNode) key() string {
+	return n.Group + "/" + n.Kind + "/" + n.Namespace + "/" + n.Name
+}
+
+// argoTreeNode is one node of the ArgoCD resource-tree API response.
+type argoTreeNode struct {
+	Group      string `json:"group"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+	ParentRefs []struct {
+		Group     string `json:"group"`
+		Kind      string `json:"kind"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		UID       string `json:"uid"`
+	} `json:"parentRefs"`
+	Health *struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"health"`
+	Info []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"info"`
+}
+
+// ResourceTree returns the resources of application app as a tree: the
+// resources it manages at the roots, with their sync status, and what those
+// own below them — ReplicaSets, Pods, Jobs, EndpointSlices — each with its
+// health. The tree is ArgoCD's own (its resource-tree API, as the UI draws it);
+// when the ArgoCD API cannot be reached, owner references are followed with
+// the Kubernetes client instead, which finds the ReplicaSets, Jobs and Pods.
+func (m *Manager) ResourceTree(ctx context.Context, app string) ([]*ResourceNode, error) {
+	if err := m.initKubernetesClients(); err != nil {
+		return nil, err
+	}
+	if m.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not available")
+	}
+	obj, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(ctx, app, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("no ArgoCD application named %s", app)
+		}
+		return nil, fmt.Errorf("reading application %s: %w", app, err)
+	}
+	a, err := argoAppFromObject(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("parsing application %s: %w", app, err)
+	}
+	roots := managedNodes(a)
+
+	nodes, err := m.apiResourceTree(ctx, app)
+	if err != nil {
+		pterm.Debug.Printf("ArgoCD resource tree unavailable (%v); following owner references instead\n", err)
+		if err := m.ownerTree(ctx, roots); err != nil {
+			return nil, err
+		}
+		return roots, nil
+	}
+	return buildTree(roots, nodes), nil
+}
+
+// managedNodes are the resources the application manages, from its status.
+func managedNodes(a argoApp) []*ResourceNode {
+	roots := make([]*ResourceNode, 0, len(a.Status.Resources))
+	for _, r := range a.Status.Resources {
+		n := &ResourceNode{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name, Sync: r.Status}
+		if r.Health != nil {
+			n.Health, n.Message = r.Health.Status, r.Health.Message
+		}
+		roots = append(roots, n)
+	}
+	return roots
+}
+
+// apiResourceTree reads the application's resource tree from the ArgoCD API.
+func (m *Manager) apiResourceTree(ctx context.Context, app string) ([]argoTreeNode, error) {
+	token, err := m.argoSessionToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := m.argoServerRequest(ctx, "GET", "/api/v1/applications/"+app+"/resource-tree", token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading the resource tree of %s: %w", app, err)
+	}
+	var tree struct {
+		Nodes []argoTreeNode `json:"nodes"`
+	}
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("parsing the resource tree of %s: %w", app, err)
+	}
+	return tree.Nodes, nil
+}
+
+// buildTree hangs the API's nodes under their parents. A managed resource
+// takes its health from the tree, which is fresher than the application's
+// status; one missing from the tree stays a root as it is. A node whose parent
+// is not in the tree becomes a root too.
+func buildTree(roots []*ResourceNode, nodes []argoTreeNode) []*ResourceNode {
+	byKey := make(map[string]*ResourceNode, len(roots))
+	for _, r := range roots {
+		byKey[r.key()] = r
+	}
+	byUID := make(map[string]*ResourceNode, len(nodes))
+	made := make([]*ResourceNode, len(nodes))
+	for i, tn := range nodes {
+		n := &ResourceNode{Group: tn.Group, Kind: tn.Kind, Namespace: tn.Namespace, Name: tn.Name}
+		if managed, ok := byKey[n.key()]; ok {
+			n = managed
+		} else {
+			byKey[n.key()] = n
+		}
+		if tn.Health != nil {
+			n.Health, n.Message = tn.Health.Status, tn.Health.Message
+		}
+		n.Info = nil
+		for _, info := range tn.Info {
+			n.Info = append(n.Info, info.Name+": "+info.Value)
+		}
+		made[i] = n
+		if tn.UID != "" {
+			byUID[tn.UID] = n
+		}
+	}
+
+	isRoot := make(map[*ResourceNode]bool, len(roots))
+	for _, r := range roots {
+		isRoot[r] = true
+	}
+	for i, tn := range nodes {
+		n := made[i]
+		if isRoot[n] || len(tn.ParentRefs) == 0 {
+			if !isRoot[n] {
+				roots, isRoot[n] = append(roots, n), true
+			}
+			continue
+		}
+		var parent *ResourceNode
+		for _, ref := range tn.ParentRefs {
+			if parent = byUID[ref.UID]; parent == nil {
+				parent = byKey[ref.Group+"/"+ref.Kind+"/"+ref.Namespace+"/"+ref.Name]
+			}
+			if parent != nil {
+				break
+			}
+		}
+		if parent == nil || parent == n {
+			roots, isRoot[n] = append(roots, n), true
+			continue
+		}
+		parent.Children = append(parent.Children, n)
+	}
+	for _, n := range made {
+		sortNodes(n.Children)
+	}
+	return roots
+}
+
+func sortNodes(nodes []*ResourceNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Kind != nodes[j].Kind {
+			return nodes[i].Kind < nodes[j].Kind
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+// ownedObject is a resource found by the owner-reference walk.
+type ownedObject struct {
+	node   ResourceNode
+	owners []metav1.OwnerReference
+}
+
+// ownerTree fills in the children of the managed resources by following the
+// owner references of the ReplicaSets, Jobs and Pods in their namespaces.
+func (m *Manager) ownerTree(ctx context.Context, roots []*ResourceNode) error {
+	if m.kubeClient == nil {
+		return fmt.Errorf("kubernetes client not available")
+	}
+	byNamespace := map[string][]ownedObject{}
+	for _, r := range roots {
+		if r.Namespace == "" {
+			continue
+		}
+		objs, listed := byNamespace[r.Namespace]
+		if !listed {
+			var err error
+			if objs, err = m.ownedObjects(ctx, r.Namespace); err != nil {
+				return err
+			}
+			byNamespace[r.Namespace] = objs
+		}
+		attachOwned(r, objs)
+	}
+	return nil
+}
+
+// attachOwned hangs the objects parent owns below it, and theirs below them.
+func attachOwned(parent *ResourceNode, objs []ownedObject) {
+	for _, o := range objs {
+		for _, ref := range o.owners {
+			if ref.Kind == parent.Kind && ref.Name == parent.Name && o.node.Namespace == parent.Namespace {
+				child := o.node
+				attachOwned(&child, objs)
+				parent.Children = append(parent.Children, &child)
+				break
+			}
+		}
+	}
+	sortNodes(parent.Children)
+}
+
+// ownedObjects lists the ReplicaSets, Jobs and Pods of namespace — the
+// resources workloads own — with their health.
+func (m *Manager) ownedObjects(ctx context.Context, namespace string) ([]ownedObject, error) {
+	var objs []ownedObject
+	sets, err := m.kubeClient.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing replica sets in %s: %w", namespace, err)
+	}
+	for _, rs := range sets.Items {
+		n := ResourceNode{Group: "apps", Kind: "ReplicaSet", Namespace: namespace, Name: rs.Name, Health: ArgoCDHealthHealthy}
+		if rs.Status.ReadyReplicas < rs.Status.Replicas {
+			n.Health = ArgoCDHealthProgressing
+		}
+		objs = append(objs, ownedObject{n, rs.OwnerReferences})
+	}
+	jobs, err := m.kubeClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing jobs in %s: %w", namespace, err)
+	}
+	for _, job := range jobs.Items {
+		objs = append(objs, ownedObject{jobNode(job), job.OwnerReferences})
+	}
+	pods, err := m.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods in %s: %w", namespace, err)
+	}
+	for _, pod := range pods.Items {
+		objs = append(objs, ownedObject{podNode(pod), pod.OwnerReferences})
+	}
+	return objs, nil
+}
+
+func jobNode(job batchv1.Job) ResourceNode {
+	n := ResourceNode{Group: "batch", Kind: "Job", Namespace: job.Namespace, Name: job.Name, Health: ArgoCDHealthProgressing}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			n.Health = ArgoCDHealthHealthy
+		case batchv1.JobFailed:
+			n.Health, n.Message = ArgoCDHealthDegraded, c.Message
+		}
+	}
+	return n
+}
+
+// podNode judges a pod's health the way ArgoCD does, roughly: finished or
+// running with every container ready is healthy, a container stuck waiting
+// on a crash or an image pull is degraded.
+func podNode(pod corev1.Pod) ResourceNode {
+	n := ResourceNode{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}
+	ready, total := containerReadiness(pod)
+	n.Info = append(n.Info, fmt.Sprintf("Containers: %d/%d", ready, total))
+	if restarts := totalRestarts(pod); restarts > 0 {
+		n.Info = append(n.Info, fmt.Sprintf("Restart Count: %d", restarts))
+	}
+	switch {
+	case pod.Status.Phase == corev1.PodSucceeded:
+		n.Health = ArgoCDHealthHealthy
+	case pod.Status.Phase == corev1.PodFailed:
+		n.Health, n.Message = ArgoCDHealthDegraded, pod.Status.Message
+	case pod.Status.Phase == corev1.PodRunning && total > 0 && ready == total:
+		n.Health = ArgoCDHealthHealthy
+	default:
+		n.Health = ArgoCDHealthProgressing
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if w := cs.State.Waiting; w != nil && degradedWaitReasons[w.Reason] {
+			n.Health, n.Message = ArgoCDHealthDegraded, w.Message
+			n.Info = append(n.Info, "Status Reason: "+w.Reason)
+			break
+		}
+	}
+	return n
+}
+
+// degradedWaitReasons are the container waiting reasons that will not clear
+// by themselves.
+var degradedWaitReasons = map[string]bool{
+	"CrashLoopBackOff": true, "ImagePullBackOff": true, "ErrImagePull": true,
+	"CreateContainerConfigError": true, "InvalidImageName": true,
+}
+
+// TreeLines renders nodes as the lines of a tree drawn with box-drawing
+// branches, each resource with its sync status, health and info, and the
+// message of an unhealthy one below it. Roots show their namespace, which
+// their children share.
+func TreeLines(nodes []*ResourceNode) []string {
+	return treeLines(nil, nodes, "", true)
+}
+
+func treeLines(lines []string, nodes []*ResourceNode, prefix string, roots bool) []string {
+	for i, n := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		name := n.Name
+		if roots && n.Namespace != "" {
+			name = n.Namespace + "/" + n.Name
+		}
+		line := prefix + branch + n.Kind + " " + name
+		if n.Sync != "" {
+			line += "  " + syncStatus(n.Sync)
+		}
+		if n.Health != "" {
+			line += "  " + healthStatus(n.Health)
+		}
+		if len(n.Info) > 0 {
+			line += "  " + pterm.Gray(strings.Join(n.Info, ", "))
+		}
+		lines = append(lines, line)
+		if n.Message != "" && n.Health != ArgoCDHealthHealthy {
+			lines = append(lines, prefix+indent+pterm.Gray(n.Message))
+		}
+		lines = treeLines(lines, n.Children, prefix+indent, false)
+	}
+	return lines
+}
+
+func syncStatus(s string) string {
+	if s == ArgoCDSyncSynced {
+		return pterm.Green(s)
+	}
+	return pterm.Yellow(s)
+}
+
+func healthStatus(h string) string {
+	switch h {
+	case ArgoCDHealthHealthy:
+		return pterm.Green(h)
+	case ArgoCDHealthDegraded, ArgoCDHealthMissing:
+		return pterm.Red(h)
+	default:
+		return pterm.Yellow(h)
+	}
+}
@@ -0,0 +1,137 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// appWithResources is appObj managing a Deployment and a Service in ns.
+func appWithResources(name, ns string) *unstructured.Unstructured {
+	obj := appObj(name, ArgoCDHealthDegraded, ArgoCDSyncSynced)
+	obj.Object["status"].(map[string]interface{})["resources"] = []interface{}{
+		map[string]interface{}{"group": "apps", "version": "v1", "kind": "Deployment", "namespace": ns, "name": "api",
+			"status": "Synced", "health": map[string]interface{}{"status": "Degraded"}},
+		map[string]interface{}{"kind": "Service", "namespace": ns, "name": "api", "status": "Synced",
+			"health": map[string]interface{}{"status": "Healthy"}},
+	}
+	return obj
+}
+
+func adminSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-initial-admin-secret", Namespace: ArgoCDNamespace},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+}
+
+func TestResourceTree_FromArgoCDAPI(t *testing.T) {
+	m := fakeManager(appWithResources("openframe-api", "openframe"))
+	m.kubeClient = fake.NewSimpleClientset(adminSecret())
+	m.argoAPI = func(_ context.Context, _, path, _ string, _ []byte) ([]byte, error) {
+		switch path {
+		case "/api/v1/session":
+			return []byte(`{"token":"jwt"}`), nil
+		case "/api/v1/applications/openframe-api/resource-tree":
+			return []byte(`{"nodes":[
+				{"group":"apps","kind":"Deployment","namespace":"openframe","name":"api","uid":"d1","health":{"status":"Degraded"}},
+				{"group":"apps","kind":"ReplicaSet","namespace":"openframe","name":"api-7f","uid":"r1",
+				 "parentRefs":[{"group":"apps","kind":"Deployment","namespace":"openframe","name":"api","uid":"d1"}],"health":{"status":"Degraded"}},
+				{"kind":"Pod","namespace":"openframe","name":"api-7f-b","uid":"p2","parentRefs":[{"uid":"r1"}],
+				 "health":{"status":"Degraded","message":"back-off restarting"},"info":[{"name":"Containers","value":"0/1"}]},
+				{"kind":"Pod","namespace":"openframe","name":"api-7f-a","uid":"p1","parentRefs":[{"uid":"r1"}],"health":{"status":"Healthy"}},
+				{"kind":"Service","namespace":"openframe","name":"api","uid":"s1","health":{"status":"Healthy"}},
+				{"group":"discovery.k8s.io","kind":"EndpointSlice","namespace":"openframe","name":"api-x","uid":"e1","parentRefs":[{"kind":"Service","namespace":"openframe","name":"api","uid":"s1"}]}
+			]}`), nil
+		}
+		return nil, errors.New("unexpected path " + path)
+	}
+
+	roots, err := m.ResourceTree(context.Background(), "openframe-api")
+	if err != nil {
+		t.Fatalf("ResourceTree: %v", err)
+	}
+	if len(roots) != 2 || roots[0].Kind != "Deployment" || roots[1].Kind != "Service" {
+		t.Fatalf("roots = %+v; want the managed Deployment and Service", roots)
+	}
+	if roots[0].Sync != "Synced" {
+		t.Errorf("managed resources keep their sync status; got %q", roots[0].Sync)
+	}
+	rs := roots[0].Children
+	if len(rs) != 1 || rs[0].Name != "api-7f" {
+		t.Fatalf("deployment children = %+v; want its ReplicaSet", rs)
+	}
+	pods := rs[0].Children
+	if len(pods) != 2 || pods[0].Name != "api-7f-a" || pods[1].Name != "api-7f-b" {
+		t.Fatalf("replica set children = %+v; want both pods sorted by name", pods)
+	}
+	if pods[1].Health != ArgoCDHealthDegraded || pods[1].Message != "back-off restarting" || strings.Join(pods[1].Info, ",") != "Containers: 0/1" {
+		t.Errorf("pod = %+v; want its health, message and info", pods[1])
+	}
+	if c := roots[1].Children; len(c) != 1 || c[0].Kind != "EndpointSlice" {
+		t.Errorf("service children = %+v; want its EndpointSlice", c)
+	}
+}
+
+func TestResourceTree_FallsBackToOwnerReferences(t *testing.T) {
+	owned := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name}}
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-7f", Namespace: "openframe", OwnerReferences: owned("Deployment", "api")},
+		Status:     appsv1.ReplicaSetStatus{Replicas: 1},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-7f-a", Namespace: "openframe", OwnerReferences: owned("ReplicaSet", "api-7f")},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "api", RestartCount: 4,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off"}},
+		}}},
+	}
+	stranger := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "openframe", OwnerReferences: owned("ReplicaSet", "other")},
+	}
+	m := fakeManager(appWithResources("openframe-api", "openframe"))
+	// No admin secret: the ArgoCD session cannot be opened.
+	m.kubeClient = fake.NewSimpleClientset(rs, pod, stranger)
+
+	roots, err := m.ResourceTree(context.Background(), "openframe-api")
+	if err != nil {
+		t.Fatalf("ResourceTree: %v", err)
+	}
+	if len(roots) != 2 {
+		t.Fatalf("roots = %+v; want the managed resources", roots)
+	}
+	sets := roots[0].Children
+	if len(sets) != 1 || sets[0].Name != "api-7f" || sets[0].Health != ArgoCDHealthProgressing {
+		t.Fatalf("deployment children = %+v; want the not-ready ReplicaSet", sets)
+	}
+	pods := sets[0].Children
+	if len(pods) != 1 || pods[0].Name != "api-7f-a" {
+		t.Fatalf("replica set children = %+v; want only its own pod", pods)
+	}
+	if pods[0].Health != ArgoCDHealthDegraded || pods[0].Message != "back-off" {
+		t.Errorf("crash-looping pod = %+v; want Degraded with the waiting message", pods[0])
+	}
+	if got := strings.Join(pods[0].Info, ", "); got != "Containers: 0/1, Restart Count: 4, Status Reason: CrashLoopBackOff" {
+		t.Errorf("pod info = %q", got)
+	}
+	if len(roots[1].Children) != 0 {
+		t.Errorf("service children = %+v; the fallback follows workloads only", roots[1].Children)
+	}
+}
+
+func TestResourceTree_UnknownApplication(t *testing.T) {
+	m := fakeManager()
+	if _, err := m.ResourceTree(context.Background(), "nope"); err == nil || !strings.Contains(err.Error(), "no ArgoCD application named nope") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// refreshNormalPatch asks ArgoCD to re-compare an Application against its
+// cached manifests; refreshHardPatch also drops the cache and re-reads git.
+const refreshNormalPatch = `{"metadata":{"annotations":{"argocd.argoproj.io/refresh":"normal"}}}`
+
+// TriggerResult is the outcome of a sync or refresh requested for one
+// Application. Skipped explains why nothing was patched; Error is a failed patch.
+type TriggerResult struct {
+	App     string `json:"app"`
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Triggered reports whether the Application was patched.
+func (r TriggerResult) Triggered() bool {
+	return r.Skipped == "" && r.Error == ""
+}
+
+// SyncApplications starts a sync of each named Application — every
+// Application when names is empty — through the same .operation patch as
+// `argocd app sync`. An Application with a sync already running is skipped
+// rather than clobbered. prune deletes resources no longer in git; force
+// replaces resources whose apply is rejected. Unknown names are an error.
+func (m *Manager) SyncApplications(ctx context.Context, names []string, prune, force bool) ([]TriggerResult, error) {
+	apps, err := m.ListApplications(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := triggerTargets(apps, names)
+	if err != nil {
+		return nil, err
+	}
+	running := map[string]bool{}
+	for _, a := range apps {
+		running[a.Name] = a.OperationPhase == "Running"
+	}
+
+	results := make([]TriggerResult, 0, len(targets))
+	for _, name := range targets {
+		if running[name] {
+			results = append(results, TriggerResult{App: name, Skipped: "a sync is already in progress"})
+			continue
+		}
+		_, _, err := m.syncApplicationsByName(ctx, []string{name}, prune, force)
+		results = append(results, triggerResult(name, err))
+	}
+	return results, nil
+}
+
+// RefreshApplications asks ArgoCD to re-compare each named Application — every
+// Application when names is empty — with git by setting the refresh
+// annotation, which its controller clears once done. hard also discards the
+// repo-server's manifest cache. Unknown names are an error.
+func (m *Manager) RefreshApplications(ctx context.Context, names []string, hard bool) ([]TriggerResult, error) {
+	apps, err := m.ListApplications(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := triggerTargets(apps, names)
+	if err != nil {
+		return nil, err
+	}
+	patch := []byte(refreshNormalPatch)
+	if hard {
+		patch = []byte(refreshHardPatch)
+	}
+
+	client := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace)
+	results := make([]TriggerResult, 0, len(targets))
+	for _, name := range targets {
+		_, err := client.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			err = fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, triggerResult(name, err))
+	}
+	return results, nil
+}
+
+// triggerTargets picks the Applications to act on: names, checked against
+// apps, or all of them, sorted.
+func triggerTargets(apps []Application, names []string) ([]string, error) {
+	if len(names) > 0 {
+		if err := checkApplicationNames(apps, names); err != nil {
+			return nil, err
+		}
+		return names, nil
+	}
+	out := make([]string, 0, len(apps))
+	for _, a := range apps {
+		out = append(out, a.Name)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func triggerResult(name string, err error) TriggerResult {
+	if err != nil {
+		return TriggerResult{App: name, Error: err.Error()}
+	}
+	return TriggerResult{App: name}
+}
@@ -0,0 +1,84 @@
+package argocd
+
+import (
+	"context"
+	goruntime "runtime"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSyncApplications_PatchesAndSkipsRunning(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("native cluster ops are refused on Windows (must run inside WSL)")
+	}
+	busy := appObj("busy", ArgoCDHealthProgressing, ArgoCDSyncOutOfSync)
+	_ = unstructured.SetNestedField(busy.Object, "Running", "status", "operationState", "phase")
+	m := fakeManager(appObj("api", ArgoCDHealthHealthy, ArgoCDSyncOutOfSync), busy, appObj("ui", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+	ctx := context.Background()
+
+	results, err := m.SyncApplications(ctx, nil, true, true)
+	if err != nil {
+		t.Fatalf("SyncApplications: %v", err)
+	}
+	if len(results) != 3 || results[0].App != "api" || results[1].App != "busy" || results[2].App != "ui" {
+		t.Fatalf("results = %+v, want api, busy, ui in order", results)
+	}
+	if !results[0].Triggered() || results[1].Triggered() || !results[2].Triggered() {
+		t.Fatalf("results = %+v, want busy skipped and the others triggered", results)
+	}
+
+	got, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(ctx, "api", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prune, _, _ := unstructured.NestedBool(got.Object, "operation", "sync", "prune")
+	force, _, _ := unstructured.NestedBool(got.Object, "operation", "sync", "syncStrategy", "apply", "force")
+	if !prune || !force {
+		t.Errorf("sync operation prune=%v force=%v, want both true: %v", prune, force, got.Object["operation"])
+	}
+	got, _ = m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(ctx, "busy", metav1.GetOptions{})
+	if _, ok := got.Object["operation"]; ok {
+		t.Error("an application with a running sync must not be patched")
+	}
+}
+
+func TestSyncApplications_UnknownName(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("native cluster ops are refused on Windows (must run inside WSL)")
+	}
+	m := fakeManager(appObj("api", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+	if _, err := m.SyncApplications(context.Background(), []string{"api", "nope"}, false, false); err == nil {
+		t.Fatal("expected an error for an unknown application")
+	}
+}
+
+func TestRefreshApplications_SetsAnnotation(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("native cluster ops are refused on Windows (must run inside WSL)")
+	}
+	m := fakeManager(appObj("api", ArgoCDHealthHealthy, ArgoCDSyncSynced), appObj("ui", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		hard bool
+		want string
+	}{{false, "normal"}, {true, "hard"}} {
+		results, err := m.RefreshApplications(ctx, []string{"ui"}, tc.hard)
+		if err != nil {
+			t.Fatalf("RefreshApplications: %v", err)
+		}
+		if len(results) != 1 || !results[0].Triggered() {
+			t.Fatalf("results = %+v, want ui refreshed", results)
+		}
+		got, _ := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(ctx, "ui", metav1.GetOptions{})
+		if v := got.GetAnnotations()[refreshAnnotationKey]; v != tc.want {
+			t.Errorf("hard=%v: refresh annotation = %q, want %q", tc.hard, v, tc.want)
+		}
+	}
+	got, _ := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).Get(ctx, "api", metav1.GetOptions{})
+	if _, ok := got.GetAnnotations()[refreshAnnotationKey]; ok {
+		t.Error("only the named application may be refreshed")
+	}
+}
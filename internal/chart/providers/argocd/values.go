@@ -6,6 +6,8 @@ import (
 	"os"
 	"sort"
 
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
+	"golang.org/x/crypto/bcrypt"
 	"sigs.k8s.io/yaml"
 )
 
@@ -80,6 +82,55 @@ func MergedArgoCDValues(userValues map[string]interface{}) (string, []string, er
 	return string(out), keys, nil
 }
 
+// SizedArgoCDValues is MergedArgoCDValues with a host-sizing overlay between
+// the two layers: baseline, then overlay, then the user's `argocd:` overrides,
+// so an explicit override still wins over the automatic sizing. A nil overlay
+// is exactly MergedArgoCDValues.
+func SizedArgoCDValues(overlay, userValues map[string]interface{}) (string, []string, error) {
+	if len(overlay) == 0 {
+		return MergedArgoCDValues(userValues)
+	}
+	// Validates the user's subtree and collects its keys.
+	_, keys, err := MergedArgoCDValues(userValues)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var base map[string]interface{}
+	if err := yaml.Unmarshal([]byte(argoCDValues), &base); err != nil {
+		return "", nil, fmt.Errorf("parsing embedded ArgoCD values: %w", err)
+	}
+	deepMerge(base, overlay)
+	if sub, ok := userValues[UserArgoCDKey].(map[string]interface{}); ok {
+		deepMerge(base, sub)
+	}
+
+	out, err := yaml.Marshal(base)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshaling sized ArgoCD values: %w", err)
+	}
+	return string(out), keys, nil
+}
+
+// WithAdminPassword adds the admin password to an ArgoCD values overlay (nil
+// is an empty one). The chart takes its bcrypt hash; ArgoCD then generates no
+// password and creates no argocd-initial-admin-secret.
+func WithAdminPassword(overlay map[string]interface{}, password string) (map[string]interface{}, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hashing the ArgoCD admin password: %w", err)
+	}
+	if overlay == nil {
+		overlay = map[string]interface{}{}
+	}
+	deepMerge(overlay, map[string]interface{}{
+		"configs": map[string]interface{}{
+			"secret": map[string]interface{}{"argocdServerAdminPassword": string(hash)},
+		},
+	})
+	return overlay, nil
+}
+
 // ValidateUserValuesFile is the pre-flight check for the user's values file:
 // a missing file is fine (baseline install), but a file that exists must be
 // readable, parse as YAML, and its `argocd:` key — when present — must be a
@@ -120,3 +171,18 @@ func deepMerge(dst, src map[string]interface{}) {
 		dst[k] = sv
 	}
 }
+
+// PrePullChart is the pinned ArgoCD chart with its baseline values, for
+// cluster creation to pre-pull its images before ArgoCD is installed. The
+// user's `argocd:` overrides are not applied: an overridden image is simply
+// pulled by the cluster.
+func PrePullChart() prepull.Chart {
+	return prepull.Chart{
+		Release:   ArgoCDReleaseName,
+		Name:      "argo-cd",
+		Repo:      ArgoHelmRepoURL,
+		Version:   ArgoCDChartVersion,
+		Namespace: ArgoCDNamespace,
+		Values:    argoCDValues,
+	}
+}
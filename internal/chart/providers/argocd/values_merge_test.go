@@ -186,3 +186,42 @@ func TestMergedArgoCDValues_MalformedOverrideErrors(t *testing.T) {
 		})
 	}
 }
+
+// TestSizedArgoCDValues_UserOverrideWinsOverSizing: the sizing overlay lands
+// on the baseline, and the user's own `argocd:` keys still win over it.
+func TestSizedArgoCDValues_UserOverrideWinsOverSizing(t *testing.T) {
+	overlay := map[string]interface{}{
+		"repoServer": map[string]interface{}{"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"memory": "1Gi"},
+		}},
+		"configs": map[string]interface{}{"params": map[string]interface{}{"reposerver.parallelism.limit": "1"}},
+	}
+	uv := map[string]interface{}{UserArgoCDKey: map[string]interface{}{
+		"configs": map[string]interface{}{"params": map[string]interface{}{"reposerver.parallelism.limit": "4"}},
+	}}
+
+	out, keys, err := SizedArgoCDValues(overlay, uv)
+	if err != nil {
+		t.Fatalf("SizedArgoCDValues: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "configs" {
+		t.Fatalf("keys = %v, want [configs]", keys)
+	}
+	var got map[string]interface{}
+	if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	params := got["configs"].(map[string]interface{})["params"].(map[string]interface{})
+	if params["reposerver.parallelism.limit"] != "4" {
+		t.Errorf("user override lost: parallelism = %v", params["reposerver.parallelism.limit"])
+	}
+	limits := got["repoServer"].(map[string]interface{})["resources"].(map[string]interface{})["limits"].(map[string]interface{})
+	if limits["memory"] != "1Gi" {
+		t.Errorf("sizing overlay lost: repoServer memory limit = %v", limits["memory"])
+	}
+
+	baseline, _, _ := SizedArgoCDValues(nil, nil)
+	if baseline != GetArgoCDValues() {
+		t.Error("nil overlay without overrides must return the baseline verbatim")
+	}
+}
@@ -3,22 +3,25 @@ package argocd
 import (
 	"context"
 	"fmt"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
-	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
-	uispinner "github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
-	"github.com/pterm/pterm"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// WaitForApplications waits for all ArgoCD applications to be Healthy and Synced
-func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartInstallConfig) error {
+// WaitForApplications waits for all ArgoCD applications to be Healthy and
+// Synced, and records how each one got there in the sync history.
+func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartInstallConfig) (err error) {
+	out := frontend.Current()
 	// Skip waiting in dry-run mode for testing
 	if config.DryRun {
 		return nil
@@ -55,46 +58,57 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 		return fmt.Errorf("ArgoCD not ready: %w", err)
 	}
 
+	// Repo-server recovery follows the operator's policy (--recovery-policy):
+	// restart (default), patch resources on OOM, or only report.
+	recovery := newRepoServerRecovery(config.RepoServerRecovery)
+
 	// Initial repo-server health check - catch issues early
 	initialIssue := m.checkRepoServerHealth(localCtx, true)
 	if initialIssue != nil {
 		// RepoServerIssue.Message explains what is wrong (restart count, OOMKilled,
 		// CrashLoopBackOff). Every caller used to discard it, so the CLI knew the
 		// repo-server was crash-looping and said nothing.
-		pterm.Warning.Printfln("ArgoCD repo-server: %s", initialIssue.Message)
+		out.Warn("ArgoCD repo-server: %s", initialIssue.Message)
+		oomKilled := recovery.policy.Action == models.RecoveryPatch && m.repoServerOOMKilled(localCtx)
 		// If repo-server has already restarted, proactively restart it to clear any stuck state
 		// This helps CI environments where the pod may have OOM'd during initial setup
-		if initialIssue.Type == "resource" && initialIssue.Recoverable {
+		if !recovery.enabled() {
+			out.Info("Automatic repo-server recovery is off (recovery policy); not restarting it.")
+		} else if initialIssue.Type == "resource" && (initialIssue.Recoverable || recovery.shouldPatch(oomKilled)) {
 			if age, ok := m.repoServerAge(localCtx); ok && age < repoServerColdStartGrace {
 				// Cold-start grace: a freshly started repo-server produces exactly
 				// these symptoms while it warms up; restarting it only prolongs that.
-				pterm.Info.Printfln("ArgoCD repo-server is only %s old; giving it %s to settle before considering restarts.",
+				out.Info("ArgoCD repo-server is only %s old; giving it %s to settle before considering restarts.",
 					age.Round(time.Second), repoServerColdStartGrace)
 			} else {
-				pterm.Info.Println("Restarting the ArgoCD repo-server to clear the stuck state...")
-				m.triggerRepoServerRecovery(localCtx, "")
+				if recovery.shouldPatch(oomKilled) {
+					out.Info("ArgoCD repo-server was OOM-killed; raising its memory limit to %s and parallelism to %d...",
+						recovery.policy.MemoryLimit, recovery.policy.Parallelism)
+				} else {
+					out.Info("Restarting the ArgoCD repo-server to clear the stuck state...")
+				}
+				m.recoverRepoServer(localCtx, recovery, "")
 			}
 		} else if !initialIssue.Recoverable {
-			pterm.Warning.Println("This is not automatically recoverable — the installation may fail. " +
+			out.Warn("This is not automatically recoverable — the installation may fail. " +
 				"Check resources with: kubectl describe pods -n argocd -l app.kubernetes.io/component=repo-server")
 		}
 	}
 	// Show initial verbose info if enabled
 	if config.Verbose {
-		pterm.Info.Println("Starting ArgoCD application synchronization...")
-		pterm.Debug.Println("  - Waiting for applications to be created by app-of-apps")
-		pterm.Debug.Println("  - Each application must reach Healthy + Synced status")
-		pterm.Debug.Println("  - Progress updates every 10 seconds in verbose mode")
+		out.Info("Starting ArgoCD application synchronization...")
+		out.Debug("  - Waiting for applications to be created by app-of-apps")
+		out.Debug("  - Each application must reach Healthy + Synced status")
+		out.Debug("  - Progress updates every 10 seconds in verbose mode")
 	}
 
 	// Start pterm spinner only if not in silent/non-interactive mode
-	var spinner *uispinner.Spinner
+	var spinner frontend.Spinner
 	if !config.Silent {
-		spinner = uispinner.New().WithTimer()
-		spinner.Start("Installing ArgoCD applications...")
+		spinner = out.Spinner("Installing ArgoCD applications...", frontend.WithTimer())
 	} else {
 		// In non-interactive mode, just show a simple info message
-		pterm.Info.Println("Installing ArgoCD applications...")
+		out.Info("Installing ArgoCD applications...")
 	}
 
 	var spinnerMutex sync.Mutex
@@ -119,54 +133,77 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 	// Ensure spinner is stopped when function exits
 	defer stopSpinner()
 
-	// Bootstrap wait (30 seconds) with periodic cluster health checks
-	bootstrapEnd := time.Now().Add(30 * time.Second)
-	bootstrapHealthCheckInterval := 5 * time.Second
-	lastBootstrapHealthCheck := time.Now()
+	// Warning events (FailedScheduling, FailedMount, ImagePullBackOff ...) are
+	// printed as they happen, once per object and problem (see events.go).
+	warnings := m.watchWarningEvents(localCtx)
+	liveWarnings := 0
+	showWarning := func(ev warningEvent) {
+		liveWarnings++
+		switch {
+		case liveWarnings < maxLiveWarnings:
+			out.Warn("%s", ev)
+		case liveWarnings == maxLiveWarnings:
+			out.Warn("%s", ev)
+			out.Info("Further warning events are collected and listed if the install fails.")
+		}
+	}
+
+	// Bootstrap wait (bootstrapWait) with periodic cluster health checks. Each
+	// concern runs on its own timer (see schedule.go).
 	consecutiveFailures := 0
 	maxConsecutiveFailures := 5 // Increased from 3 for better WSL resilience in CI environments
 
-	// Check every 10ms for immediate response
-	ticker := time.NewTicker(10 * time.Millisecond)
-	defer ticker.Stop()
+	bootstrapTimer := time.NewTimer(bootstrapWait)
+	defer bootstrapTimer.Stop()
+	healthTimer := time.NewTimer(bootstrapHealthCheckInterval)
+	defer healthTimer.Stop()
 
-	// Bootstrap phase - check cluster health every 5 seconds
-	for time.Now().Before(bootstrapEnd) {
+bootstrap:
+	for {
 		select {
 		case <-localCtx.Done():
 			return fmt.Errorf("operation cancelled: %w", localCtx.Err())
-		case <-ticker.C:
-			// Check cluster health periodically during bootstrap
-			if time.Since(lastBootstrapHealthCheck) >= bootstrapHealthCheckInterval {
-				lastBootstrapHealthCheck = time.Now()
-				if err := m.checkClusterConnectivity(localCtx, config.Verbose); err != nil {
-					consecutiveFailures++
-					if consecutiveFailures >= maxConsecutiveFailures {
-						stopSpinner()
-						m.printClusterDiagnostics(localCtx)
-						return fmt.Errorf("cluster became unreachable during bootstrap wait: %w", err)
-					}
-				} else {
-					consecutiveFailures = 0
+		case <-bootstrapTimer.C:
+			break bootstrap
+		case ev := <-warnings.C:
+			showWarning(ev)
+		case <-healthTimer.C:
+			if err := m.checkClusterConnectivity(localCtx, config.Verbose); err != nil {
+				consecutiveFailures++
+				if consecutiveFailures >= maxConsecutiveFailures {
+					stopSpinner()
+					m.printClusterDiagnostics(localCtx)
+					return fmt.Errorf("cluster became unreachable during bootstrap wait: %w", err)
 				}
+				healthTimer.Reset(max(bootstrapHealthCheckInterval, unhealthyBackoff(consecutiveFailures)))
+			} else {
+				consecutiveFailures = 0
+				healthTimer.Reset(bootstrapHealthCheckInterval)
 			}
 		}
 	}
 
 	// Main monitoring phase
 	startTime := time.Now()
+	history := newSyncRecorder(m.clusterName, startTime)
+	defer func() { history.save(err) }()
 	timeout := m.waitTimeout
 	if timeout <= 0 {
 		timeout = 60 * time.Minute // default, sized for a fresh install
 	}
-	checkInterval := 2 * time.Second
-	lastCheck := time.Now()
-	clusterHealthCheckInterval := 10 * time.Second
-	clusterHealthCheckIntervalFast := 2 * time.Second // Faster checks when errors occur
-	lastClusterHealthCheck := time.Now()
-	resourceCheckInterval := 5 * time.Minute // Check system resources every 5 minutes
-	lastResourceCheck := time.Now()
 	consecutiveFailures = 0 // Reset for main loop
+	var lastAppQueryOK time.Time
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	appTimer := time.NewTimer(appCheckInterval)
+	defer appTimer.Stop()
+	healthTimer.Reset(healthCheckInterval)
+	resourceTimer := time.NewTimer(resourceCheckInterval)
+	defer resourceTimer.Stop()
+	diskTimer := time.NewTimer(diskCheckInterval)
+	defer diskTimer.Stop()
+	disk := newDiskWatch(m.clusterName)
 
 	// Get expected applications count
 	totalAppsExpected := m.getTotalExpectedApplications(localCtx, config)
@@ -179,7 +216,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 	consecutiveAllReady := 0
 	stabilizationChecks := m.StabilizationChecks
 	if stabilizationChecks <= 0 {
-		stabilizationChecks = 15 // default: 15 * 2s = 30s
+		stabilizationChecks = 15 // default: 15 * appCheckInterval = 30s
 	}
 
 	// Track applications that have ever been ready (healthy + synced) during this session
@@ -197,10 +234,9 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 	fatalManifest := newFatalManifestTracker()
 
 	// Repo-server issue tracking for recovery logic
-	repoServerRecoveryAttempts := 0
-	maxRepoServerRecoveryAttempts := 3 // Increased from 2 for CI resilience
 	lastRepoServerDiagnostic := time.Time{}
-	repoServerDiagnosticInterval := 2 * time.Minute  // Reduced from 3 min for faster CI recovery
+	repoServerDiagnosticInterval := recovery.policy.IntervalDuration()
+	recoveryOffNoted := false
 	appsWithRepoServerIssues := make(map[string]int) // Track consecutive failures per app
 	lastRepoServerResourceCheck := time.Now()
 	repoServerResourceCheckInterval := 30 * time.Second // Reduced from 1 min for faster issue detection
@@ -208,7 +244,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 
 	// Periodic-output throttles. These are time-based on purpose: the previous
 	// code gated on `int(elapsed.Seconds())%10 == 0`, but the status check runs
-	// every checkInterval (2s), so whether elapsed ever landed on an exact
+	// every appCheckInterval (2s), so whether elapsed ever landed on an exact
 	// multiple of 10 was luck. A skipped tick silently skipped that whole cycle.
 	lastProgressPrint := time.Now()
 	lastUnknownWarn := time.Time{}
@@ -220,6 +256,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 	// nothing about which of the apps was stuck, or what to run next.
 	var lastNotReadyApps []string  // decorated "name (Health: X)" labels, for the list
 	var lastNotReadyNames []string // bare names, for the kubectl example
+	var lastApps []Application     // full status, for the root-cause analysis
 	lastReadyCount, lastTotalApps := 0, 0
 	// The spinner already animates for interactive users, so the textual line is
 	// mainly a heartbeat for logs and CI; verbose users want it more often.
@@ -233,73 +270,70 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 		select {
 		case <-localCtx.Done():
 			return fmt.Errorf("operation cancelled: %w", localCtx.Err())
-		case <-ticker.C:
-			// Check timeout
-			if time.Since(startTime) > timeout {
-				spinnerMutex.Lock()
-				if !spinnerStopped && spinner != nil {
-					spinner.Fail(fmt.Sprintf("Timeout after %v", timeout))
-					spinnerStopped = true
-				}
-				spinnerMutex.Unlock()
-				return timeoutError(timeout, lastReadyCount, lastTotalApps, lastNotReadyApps, lastNotReadyNames)
-			}
-
-			// Periodic cluster health check
-			// Use faster interval (2s) when we've seen failures, normal interval (10s) otherwise
-			currentHealthCheckInterval := clusterHealthCheckInterval
-			if consecutiveFailures > 0 {
-				currentHealthCheckInterval = clusterHealthCheckIntervalFast
+		case <-deadline.C:
+			spinnerMutex.Lock()
+			if !spinnerStopped && spinner != nil {
+				spinner.Fail(fmt.Sprintf("Timeout after %v", timeout))
+				spinnerStopped = true
+			}
+			spinnerMutex.Unlock()
+			printWarningSummary(warnings.summary())
+			printRootCauses(m.analyzeRootCauses(localCtx, lastApps))
+			return timeoutError(timeout, lastReadyCount, lastTotalApps, lastNotReadyApps, lastNotReadyNames)
+
+		case <-healthTimer.C:
+			// A recent successful application query already proved the API
+			// server reachable; don't spawn a second probe to learn the same.
+			if !healthProbeDue(lastAppQueryOK, healthCheckInterval) {
+				healthTimer.Reset(healthCheckInterval)
+				continue
 			}
-			if time.Since(lastClusterHealthCheck) >= currentHealthCheckInterval {
-				lastClusterHealthCheck = time.Now()
-				if err := m.checkClusterConnectivity(localCtx, false); err != nil {
-					consecutiveFailures++
-
-					// On Windows, try WSL recovery before giving up
-					if runtime.GOOS == "windows" && consecutiveFailures >= maxConsecutiveFailures-1 {
-						if wslErr := executor.TryRecoverWSL(); wslErr == nil {
-							// Give WSL a moment to stabilize
-							time.Sleep(3 * time.Second)
-							// Retry the connectivity check
-							if retryErr := m.checkClusterConnectivity(localCtx, false); retryErr == nil {
-								consecutiveFailures = 0
-								continue
-							}
-						}
-					}
+			if err := m.checkClusterConnectivity(localCtx, false); err != nil {
+				consecutiveFailures++
 
-					if consecutiveFailures >= maxConsecutiveFailures {
-						stopSpinner()
-						m.printClusterDiagnostics(localCtx)
-						return fmt.Errorf("cluster became unreachable while waiting for applications: %w", err)
-					}
-
-					// Add exponential backoff delay between failures to avoid hammering WSL
-					backoffDelay := time.Duration(consecutiveFailures) * 2 * time.Second
-					if backoffDelay > 10*time.Second {
-						backoffDelay = 10 * time.Second
-					}
-					time.Sleep(backoffDelay)
-				} else {
+				// On WSL-backed Windows, try WSL recovery before giving up
+				recovered, rerr := m.wslRecovered(localCtx, consecutiveFailures, maxConsecutiveFailures)
+				if rerr != nil {
+					return fmt.Errorf("operation cancelled: %w", rerr)
+				}
+				if recovered {
 					consecutiveFailures = 0
+					healthTimer.Reset(healthCheckInterval)
+					continue
 				}
-			}
 
-			// Periodic resource check (every 5 minutes) - helps diagnose resource exhaustion
-			if time.Since(lastResourceCheck) >= resourceCheckInterval {
-				lastResourceCheck = time.Now()
-				m.logResourceStatus(localCtx, config.Verbose)
+				if consecutiveFailures >= maxConsecutiveFailures {
+					stopSpinner()
+					m.printClusterDiagnostics(localCtx)
+					return fmt.Errorf("cluster became unreachable while waiting for applications: %w", err)
+				}
 
-				// Also check repo-server health proactively
-				m.checkRepoServerHealth(localCtx, false)
+				// Back off both probes: querying a struggling cluster (a
+				// process spawn under WSL) more often only adds to its load.
+				healthTimer.Reset(unhealthyBackoff(consecutiveFailures))
+				appTimer.Reset(unhealthyBackoff(consecutiveFailures))
+			} else {
+				consecutiveFailures = 0
+				healthTimer.Reset(healthCheckInterval)
 			}
 
-			// Check applications every 2 seconds
-			if time.Since(lastCheck) < checkInterval {
-				continue
-			}
-			lastCheck = time.Now()
+		case ev := <-warnings.C:
+			showWarning(ev)
+
+		case <-diskTimer.C:
+			diskTimer.Reset(diskCheckInterval)
+			m.checkDiskPressure(localCtx, disk, out)
+
+		case <-resourceTimer.C:
+			// Periodic resource check - helps diagnose resource exhaustion
+			resourceTimer.Reset(resourceCheckInterval)
+			m.logResourceStatus(localCtx, config.Verbose)
+
+			// Also check repo-server health proactively
+			m.checkRepoServerHealth(localCtx, false)
+
+		case <-appTimer.C:
+			appTimer.Reset(appCheckInterval)
 
 			// Parse applications
 			apps, err := m.parseApplications(localCtx, config.Verbose)
@@ -308,29 +342,20 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 					return fmt.Errorf("operation cancelled: %w", localCtx.Err())
 				}
 
-				// Check if this is a cluster connectivity error (including WSL errors)
-				errStr := err.Error()
-				isConnectivityError := strings.Contains(errStr, "connection refused") ||
-					strings.Contains(errStr, "cluster unreachable") ||
-					strings.Contains(errStr, "was refused") ||
-					strings.Contains(errStr, "Unable to connect") ||
-					strings.Contains(errStr, "WSL error")
-
-				if isConnectivityError {
+				if isConnectivityError(err) {
 					consecutiveFailures++
-					pterm.Warning.Printf("Application query failed - cluster may be unreachable (%d/%d): %v\n",
+					out.Warn("Application query failed - cluster may be unreachable (%d/%d): %v",
 						consecutiveFailures, maxConsecutiveFailures, err)
 
-					// On Windows, try WSL recovery before giving up
-					if runtime.GOOS == "windows" && consecutiveFailures >= maxConsecutiveFailures-1 {
-						pterm.Info.Println("Attempting WSL recovery before giving up...")
-						if wslErr := executor.TryRecoverWSL(); wslErr != nil {
-							pterm.Warning.Printf("WSL recovery failed: %v\n", wslErr)
-						} else {
-							pterm.Success.Println("WSL recovery successful")
-							// Give WSL a moment to stabilize
-							time.Sleep(3 * time.Second)
-						}
+					// On WSL-backed Windows, try WSL recovery before giving up
+					recovered, rerr := m.wslRecovered(localCtx, consecutiveFailures, maxConsecutiveFailures)
+					if rerr != nil {
+						return fmt.Errorf("operation cancelled: %w", rerr)
+					}
+					if recovered {
+						out.Success("WSL recovery successful")
+						consecutiveFailures = 0
+						continue
 					}
 
 					if consecutiveFailures >= maxConsecutiveFailures {
@@ -339,22 +364,21 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 						return fmt.Errorf("cluster became unreachable while waiting for applications: %w", err)
 					}
 
-					// Add backoff delay between failures
-					backoffDelay := time.Duration(consecutiveFailures) * 2 * time.Second
-					if backoffDelay > 10*time.Second {
-						backoffDelay = 10 * time.Second
-					}
-					time.Sleep(backoffDelay)
+					// Back off instead of sleeping: the loop stays responsive
+					// to cancellation and the deadline while it waits.
+					appTimer.Reset(unhealthyBackoff(consecutiveFailures))
 				}
 
-				// Retry on other errors (with normal interval via lastCheck)
+				// Retry on other errors at the normal interval
 				continue
 			}
 
 			// Reset consecutive failures on successful query
+			lastAppQueryOK = time.Now()
 			if consecutiveFailures > 0 {
-				pterm.Success.Println("Application queries restored")
+				out.Success("Application queries restored")
 				consecutiveFailures = 0
+				healthTimer.Reset(healthCheckInterval)
 			}
 
 			totalApps := len(apps)
@@ -362,7 +386,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 				maxAppsSeenTotal = totalApps
 				// Show initial application count when first detected (verbose mode)
 				if config.Verbose && totalApps > 0 {
-					pterm.Info.Printf("Detected %d ArgoCD applications to synchronize\n", totalApps)
+					out.Info("Detected %d ArgoCD applications to synchronize", totalApps)
 				}
 			}
 
@@ -376,7 +400,15 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 			healthyApps := assess.healthyNames
 			notReadyApps := assess.notReady
 			lastNotReadyApps, lastReadyCount, lastTotalApps = notReadyApps, currentlyReady, totalApps
+			installstatus.ReportApps(currentlyReady, totalApps)
+			metrics.ReportApps(currentlyReady, totalApps)
 			lastNotReadyNames = assess.notReadyNames
+			lastApps = apps
+			for _, name := range assess.newlyReady {
+				timeline.Mark("app " + name + " synced")
+			}
+			history.observe(apps, time.Now())
+			history.ready(assess.newlyReady, time.Now())
 
 			// Fail fast on deterministic manifest errors (see fatalmanifest.go):
 			// once an app has shown the same "content missing at this revision"
@@ -418,18 +450,19 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 				if config.SyncStragglersOnStall {
 					if !stragglerSyncTriggered {
 						stragglerSyncTriggered = true
-						pterm.Warning.Printf("No progress for %s; triggering sync of %d OutOfSync application(s): %v\n",
+						out.Warn("No progress for %s; triggering sync of %d OutOfSync application(s): %v",
 							stallAfter.Round(time.Second), len(stragglers), stragglers)
-						patched, failedCount, syncErr := m.syncApplicationsByName(localCtx, stragglers, false)
+						patched, failedCount, syncErr := m.syncApplicationsByName(localCtx, stragglers, false, false)
+						history.nudged(stragglers, nudgeSync, time.Now())
 						if failedCount > 0 {
-							pterm.Warning.Printf("Straggler sync: %d triggered, %d failed (first error: %v)\n", patched, failedCount, syncErr)
+							out.Warn("Straggler sync: %d triggered, %d failed (first error: %v)", patched, failedCount, syncErr)
 						}
 					}
 				} else if !stallHintShown {
 					stallHintShown = true
-					pterm.Warning.Printf("No progress for %s; %d application(s) are OutOfSync and may have auto-sync disabled: %v\n",
+					out.Warn("No progress for %s; %d application(s) are OutOfSync and may have auto-sync disabled: %v",
 						stallAfter.Round(time.Second), len(stragglers), stragglers)
-					pterm.Info.Println("They will not sync on their own — run `openframe app upgrade --sync` (or sync them in ArgoCD) to roll them out.")
+					out.Info("They will not sync on their own — run `openframe app upgrade --sync` (or sync them in ArgoCD) to roll them out.")
 				}
 			}
 
@@ -464,7 +497,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 							// Print each distinct diagnosis once: the check runs every
 							// 30s and would otherwise repeat the same line forever.
 							lastRepoServerMessage = issue.Message
-							pterm.Warning.Printfln("ArgoCD repo-server: %s", issue.Message)
+							out.Warn("ArgoCD repo-server: %s", issue.Message)
 						}
 					}
 
@@ -482,35 +515,53 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 							// rendering for every app, restarting the carousel. This also
 							// spaces successive recovery attempts at least the grace apart.
 							if age, ok := m.repoServerAge(localCtx); ok && age < repoServerColdStartGrace {
-								pterm.Info.Printfln("ArgoCD repo-server is only %s old; waiting for it to settle (%s grace) before considering a restart.",
+								out.Info("ArgoCD repo-server is only %s old; waiting for it to settle (%s grace) before considering a restart.",
 									age.Round(time.Second), repoServerColdStartGrace)
 								break
 							}
 
-							if repoServerRecoveryAttempts < maxRepoServerRecoveryAttempts {
-								repoServerRecoveryAttempts++
+							if !recovery.enabled() {
+								if !recoveryOffNoted {
+									recoveryOffNoted = true
+									out.Warn("ArgoCD repo-server looks stuck (application %q cannot fetch its manifests); automatic recovery is off (recovery policy).",
+										app.Name)
+								}
+							} else if !recovery.exhausted() {
 								// Restarting the repo-server takes the apps through a
 								// visible wobble; say why, or it reads as a new failure.
-								pterm.Warning.Printfln("ArgoCD repo-server looks stuck (application %q cannot fetch its manifests); restarting it (attempt %d/%d)",
-									app.Name, repoServerRecoveryAttempts, maxRepoServerRecoveryAttempts)
-								if m.triggerRepoServerRecovery(localCtx, app.Name) {
-									pterm.Info.Println("ArgoCD repo-server restarted; applications will re-sync shortly.")
+								if recovery.shouldPatch(m.repoServerOOMKilled(localCtx)) {
+									out.Warn("ArgoCD repo-server was OOM-killed (application %q cannot fetch its manifests); raising its memory limit to %s and parallelism to %d",
+										app.Name, recovery.policy.MemoryLimit, recovery.policy.Parallelism)
+								} else {
+									out.Warn("ArgoCD repo-server looks stuck (application %q cannot fetch its manifests); restarting it (attempt %d/%d)",
+										app.Name, recovery.restarts+1, recovery.policy.MaxRestarts)
+								}
+								if m.recoverRepoServer(localCtx, recovery, app.Name) {
+									out.Info("ArgoCD repo-server restarted; applications will re-sync shortly.")
+									history.nudged([]string{app.Name}, nudgeHardRefresh, time.Now())
 									delete(appsWithRepoServerIssues, app.Name)
 									// The restarted repo-server has a cold manifest cache, so
 									// every app stuck in Unknown (not just the trigger) needs a
 									// HARD refresh to regenerate — otherwise they ride the wait
 									// out to its timeout. triggerRepoServerRecovery already
-									// hard-refreshed app.Name; cover the rest.
-									if refreshed := m.hardRefreshApplications(localCtx, appNames(unknownApps)); refreshed > 0 {
-										pterm.Info.Printfln("Hard-refreshed %d application(s) stuck in Unknown.", refreshed)
+									// hard-refreshed app.Name, counted above; cover the rest.
+									var rest []string
+									for _, name := range appNames(unknownApps) {
+										if name != app.Name {
+											rest = append(rest, name)
+										}
+									}
+									if refreshed := m.hardRefreshApplications(localCtx, rest); len(refreshed) > 0 {
+										history.nudged(refreshed, nudgeHardRefresh, time.Now())
+										out.Info("Hard-refreshed %d application(s) stuck in Unknown.", len(refreshed))
 									}
 								} else {
-									pterm.Warning.Println("Could not restart the ArgoCD repo-server; continuing to wait.")
+									out.Warn("Could not restart the ArgoCD repo-server; continuing to wait.")
 								}
-							} else if repoServerRecoveryAttempts == maxRepoServerRecoveryAttempts {
-								repoServerRecoveryAttempts++ // prevent repeated attempts
-								pterm.Warning.Printfln("ArgoCD repo-server did not recover after %d restarts; continuing to wait for the timeout.",
-									maxRepoServerRecoveryAttempts)
+							} else if recovery.restarts == recovery.policy.MaxRestarts {
+								recovery.restarts++ // prevent repeated attempts
+								out.Warn("ArgoCD repo-server did not recover after %d restarts; continuing to wait for the timeout.",
+									recovery.policy.MaxRestarts)
 							}
 							break // Only recover one app at a time
 						}
@@ -519,20 +570,21 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 
 				// Applications stuck in Unknown for 5 minutes usually mean the ArgoCD
 				// controller is unhealthy or git is unreachable. Warn at any verbosity
-				// (throttled); the per-application dump stays behind --verbose.
+				// (throttled); the per-application trees stay behind --verbose.
 				if len(unknownApps) > 0 && elapsed > 5*time.Minute && time.Since(lastUnknownWarn) >= 5*time.Minute {
 					lastUnknownWarn = time.Now()
-					pterm.Warning.Printfln("  %d application(s) have 'Unknown' status after %s. Possible causes: controller pod not ready, git repository unreachable, or resource constraints.",
+					out.Warn("  %d application(s) have 'Unknown' status after %s. Possible causes: controller pod not ready, git repository unreachable, or resource constraints.",
 						len(unknownApps), elapsed.Round(time.Second))
 					if config.Verbose {
-						describeUnknownApps(unknownApps)
+						m.printAppTrees(localCtx, unknownApps)
 					} else {
-						pterm.Info.Println("  Re-run with --verbose for per-application detail.")
+						out.Info("  Re-run with --verbose for per-application detail.")
 					}
 				}
 
 				// A concise summary of stuck applications, every 5 minutes after the
-				// 7-minute mark (in-memory status; no kubectl resource dump).
+				// 7-minute mark (in-memory status; no kubectl resource dump),
+				// followed by the classified root causes behind them.
 				if elapsed > 7*time.Minute && time.Since(lastStuckSummary) >= 5*time.Minute {
 					lastStuckSummary = time.Now()
 					for _, app := range apps {
@@ -541,9 +593,10 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 							if app.Condition != "" {
 								line += " condition=" + app.Condition
 							}
-							pterm.Warning.Println(line)
+							out.Warn("%s", line)
 						}
 					}
+					printRootCauses(m.analyzeRootCauses(localCtx, apps))
 				}
 			}
 
@@ -552,19 +605,19 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 			// suppressed entirely and the previous code printed nothing at all.
 			if totalApps > 0 && time.Since(lastProgressPrint) >= progressPrintInterval {
 				lastProgressPrint = time.Now()
-				pterm.Info.Printf("ArgoCD sync progress: %d/%d applications ready (%s elapsed)\n",
+				out.Info("ArgoCD sync progress: %d/%d applications ready (%s elapsed)",
 					currentlyReady, totalApps, elapsed.Round(time.Second))
 
 				if len(notReadyApps) > 0 {
 					if len(notReadyApps) <= 8 {
-						pterm.Info.Printf("  Still waiting for: %v\n", notReadyApps)
+						out.Info("  Still waiting for: %v", notReadyApps)
 					} else {
-						pterm.Info.Printf("  Still waiting for %d applications (showing first 5): %v...\n",
+						out.Info("  Still waiting for %d applications (showing first 5): %v...",
 							len(notReadyApps), notReadyApps[:5])
 					}
 				}
 				if config.Verbose && len(healthyApps) > 0 && len(healthyApps) <= 5 {
-					pterm.Debug.Printf("  Recently completed: %v\n", healthyApps)
+					out.Debug("  Recently completed: %v", healthyApps)
 				}
 			}
 
@@ -580,7 +633,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 			// mark of the app count (see isDeploymentComplete).
 			allReady := isDeploymentComplete(totalApps, currentlyReady, maxAppsSeenTotal)
 			if !allReady && totalApps > 0 && totalApps < maxAppsSeenTotal && config.Verbose {
-				pterm.Warning.Printf("Application count dropped: %d visible vs %d previously seen — waiting for all apps to reappear\n", totalApps, maxAppsSeenTotal)
+				out.Warn("Application count dropped: %d visible vs %d previously seen — waiting for all apps to reappear", totalApps, maxAppsSeenTotal)
 			}
 
 			// Update ready count for display purposes (still use everReady for progress tracking)
@@ -593,7 +646,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 			if allReady {
 				consecutiveAllReady++
 				if config.Verbose {
-					pterm.Debug.Printf("All apps ready (%d/%d stabilization checks)\n", consecutiveAllReady, stabilizationChecks)
+					out.Debug("All apps ready (%d/%d stabilization checks)", consecutiveAllReady, stabilizationChecks)
 				}
 				if consecutiveAllReady >= stabilizationChecks {
 					// Everything is Healthy+Synced — but "ready" is not "correct".
@@ -623,12 +676,15 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 						return refMismatchError(config.AppOfApps.GitHubBranch, mm)
 					}
 
-					pterm.Success.Println("All ArgoCD applications installed")
+					out.Success("All ArgoCD applications installed")
+					// Problems the applications recovered from are still worth
+					// knowing: a pull that needed retries will be slow again.
+					printWarningSummary(warnings.summary())
 					return nil
 				}
 			} else {
 				if consecutiveAllReady > 0 && config.Verbose {
-					pterm.Debug.Printf("Stabilization reset: was %d/%d, app became not-ready\n", consecutiveAllReady, stabilizationChecks)
+					out.Debug("Stabilization reset: was %d/%d, app became not-ready", consecutiveAllReady, stabilizationChecks)
 				}
 				consecutiveAllReady = 0
 			}
@@ -639,6 +695,7 @@ func (m *Manager) WaitForApplications(ctx context.Context, config config.ChartIn
 // waitForArgoCDReady waits for ArgoCD CRD and pods to be ready using native Go clients
 // This reduces reliance on external kubectl binary
 func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs bool) error {
+	out := frontend.Current()
 	// On Windows the cluster lives in WSL2 and must be reached from inside WSL.
 	if err := platform.WSLClusterHint("wait for ArgoCD to be ready"); err != nil {
 		return err
@@ -655,12 +712,12 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 	// Skip CRD wait if CRDs installation was skipped (e.g., in non-interactive/CI mode)
 	if skipCRDs {
 		if verbose {
-			pterm.Info.Println("Skipping ArgoCD CRD wait (CRDs managed by Helm chart)")
+			out.Info("Skipping ArgoCD CRD wait (CRDs managed by Helm chart)")
 		}
 	} else {
 		// Wait for ArgoCD CRD to be available using native apiextensions client
 		if verbose {
-			pterm.Info.Println("Waiting for ArgoCD CRD applications.argoproj.io...")
+			out.Info("Waiting for ArgoCD CRD applications.argoproj.io...")
 		}
 
 		for i := 0; i < maxRetries; i++ {
@@ -674,15 +731,16 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 			_, err := m.apiextClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, "applications.argoproj.io", metav1.GetOptions{})
 			if err == nil {
 				if verbose {
-					pterm.Success.Println("ArgoCD CRD applications.argoproj.io is ready")
+					out.Success("ArgoCD CRD applications.argoproj.io is ready")
 				}
+				timeline.Mark("ArgoCD CRDs available")
 				break
 			}
 
 			if !k8serrors.IsNotFound(err) {
 				// Non-404 error - might be connectivity issue
 				if verbose {
-					pterm.Warning.Printf("Cluster connectivity issue detected: %v (attempt %d/%d)\n", err, i+1, maxRetries)
+					out.Warn("Cluster connectivity issue detected: %v (attempt %d/%d)", err, i+1, maxRetries)
 				}
 			}
 
@@ -699,16 +757,18 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 			}
 
 			if verbose && i%5 == 0 {
-				pterm.Info.Println("Waiting for ArgoCD CRD applications.argoproj.io...")
+				out.Info("Waiting for ArgoCD CRD applications.argoproj.io...")
 			}
 
-			time.Sleep(retryInterval)
+			if err := sleepContext(ctx, retryInterval); err != nil {
+				return fmt.Errorf("operation cancelled: %w", err)
+			}
 		}
 	}
 
 	// Wait for ArgoCD pods to be ready using native Kubernetes client
 	if verbose {
-		pterm.Info.Println("Waiting for ArgoCD pods to be ready...")
+		out.Info("Waiting for ArgoCD pods to be ready...")
 	}
 
 	podExistenceTimeout := 120 * time.Second
@@ -730,21 +790,23 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 
 		if err == nil && len(podList.Items) > 0 {
 			if verbose {
-				pterm.Info.Printf("Found %d ArgoCD pod(s), waiting for them to be ready...\n", len(podList.Items))
+				out.Info("Found %d ArgoCD pod(s), waiting for them to be ready...", len(podList.Items))
 			}
 			podsExist = true
 			break
 		}
 
 		if verbose && int(time.Since(podExistenceStart).Seconds())%15 == 0 {
-			pterm.Info.Println("Waiting for ArgoCD pods to be created...")
+			out.Info("Waiting for ArgoCD pods to be created...")
 		}
 
-		time.Sleep(podExistenceInterval)
+		if err := sleepContext(ctx, podExistenceInterval); err != nil {
+			return fmt.Errorf("operation cancelled: %w", err)
+		}
 	}
 
 	if !podsExist {
-		pterm.Warning.Println("No ArgoCD pods found after waiting. Collecting diagnostics...")
+		out.Warn("No ArgoCD pods found after waiting. Collecting diagnostics...")
 		m.printArgoCDPodDiagnostics(ctx)
 		return fmt.Errorf("timeout waiting for ArgoCD pods to be created (no pods found with label app.kubernetes.io/part-of=argocd)")
 	}
@@ -766,9 +828,11 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 
 		if err != nil {
 			if verbose {
-				pterm.Warning.Printf("Failed to list pods: %v\n", err)
+				out.Warn("Failed to list pods: %v", err)
+			}
+			if err := sleepContext(ctx, retryInterval); err != nil {
+				return fmt.Errorf("operation cancelled: %w", err)
 			}
-			time.Sleep(retryInterval)
 			continue
 		}
 
@@ -782,61 +846,41 @@ func (m *Manager) waitForArgoCDReady(ctx context.Context, verbose bool, skipCRDs
 
 		if allReady && len(podList.Items) > 0 {
 			if verbose {
-				pterm.Success.Println("ArgoCD pods are ready")
+				out.Success("ArgoCD pods are ready")
 			}
+			timeline.Mark("ArgoCD ready")
 			return nil
 		}
 
-		time.Sleep(retryInterval)
+		if err := sleepContext(ctx, retryInterval); err != nil {
+			return fmt.Errorf("operation cancelled: %w", err)
+		}
 	}
 
 	m.printArgoCDPodDiagnostics(ctx)
 	return fmt.Errorf("timeout waiting for ArgoCD pods to be ready")
 }
 
-// describeUnknownApps prints the per-application detail for applications stuck
-// in Unknown: source, condition, operation state, health message, and last
-// reconciliation. It is the --verbose expansion of the one-line warning the
-// wait loop emits; the condition line is usually the one that explains it.
-func describeUnknownApps(unknownApps []Application) {
-	for _, app := range unknownApps {
-		pterm.Warning.Printf("\n  --- %s (Health: %s, Sync: %s) ---\n", app.Name, app.Health, app.Sync)
-
-		if app.RepoURL != "" {
-			pterm.Info.Printf("    Source: %s", app.RepoURL)
-			if app.Path != "" {
-				pterm.DefaultBasicText.Printf(" path=%s", app.Path)
-			}
-			if app.TargetRevision != "" {
-				pterm.DefaultBasicText.Printf(" revision=%s", app.TargetRevision)
-			}
-			pterm.DefaultBasicText.Println()
-		}
-
+// printAppTrees prints, for applications stuck in Unknown, the condition
+// that usually explains it and the application's resource tree, so the
+// resource holding it up can be followed down rather than read out of a dump.
+// It is the --verbose expansion of the one-line warning the wait loop emits.
+func (m *Manager) printAppTrees(ctx context.Context, apps []Application) {
+	out := frontend.Current()
+	for _, app := range apps {
+		out.Warn("  %s (Health: %s, Sync: %s)", app.Name, app.Health, app.Sync)
 		if app.Condition != "" {
-			condType := app.ConditionType
-			if condType == "" {
-				condType = "Error"
-			}
-			pterm.Warning.Printf("    %s: %s\n", condType, app.Condition)
+			out.Warn("    %s", app.Condition)
 		}
-
-		if app.OperationPhase != "" {
-			pterm.Info.Printf("    Operation: %s", app.OperationPhase)
-			if app.OperationMessage != "" {
-				pterm.DefaultBasicText.Printf(" - %s", app.OperationMessage)
-			}
-			pterm.DefaultBasicText.Println()
-		}
-
-		if app.HealthMessage != "" {
-			pterm.Info.Printf("    Health details: %s\n", app.HealthMessage)
+		roots, err := m.ResourceTree(ctx, app.Name)
+		switch {
+		case err != nil:
+			out.Info("    Resource tree unavailable: %v", err)
+		case len(roots) == 0:
+			out.Info("    No resources yet (ArgoCD has not rendered this application)")
 		}
-
-		if app.ReconciledAt != "" {
-			pterm.Info.Printf("    Last reconciled: %s\n", app.ReconciledAt)
-		} else {
-			pterm.Warning.Println("    Not yet reconciled (ArgoCD hasn't processed this app)")
+		for _, line := range TreeLines(roots) {
+			out.Info("    %s", line)
 		}
 	}
 }
@@ -854,10 +898,12 @@ const maxAppsInTimeoutError = 10
 // shows why.
 //
 // notReadyLabels are decorated "name (Health: X)" strings for the human list;
-// notReadyNames are the BARE application names for the kubectl example. They
+// notReadyNames are the BARE application names for the command example. They
 // must be kept separate: feeding a decorated label into `kubectl describe
 // application` produced `kubectl describe application argocd-apps (Health:
-// Progressing) -n argocd`, which is not a runnable command.
+// Progressing) -n argocd`, which is not a runnable command. The example is
+// `openframe app tree`, whose resource tree leads to the unhealthy resource
+// where describe only showed the application's own status.
 func timeoutError(timeout time.Duration, ready, total int, notReadyLabels, notReadyNames []string) error {
 	var b strings.Builder
 	fmt.Fprintf(&b, "timeout after %s waiting for ArgoCD applications", timeout)
@@ -877,7 +923,7 @@ func timeoutError(timeout time.Duration, ready, total int, notReadyLabels, notRe
 
 	b.WriteString("\nInspect them with: kubectl get applications -n argocd")
 	if len(notReadyNames) > 0 {
-		fmt.Fprintf(&b, "\nDetails for one: kubectl describe application %s -n argocd", notReadyNames[0])
+		fmt.Fprintf(&b, "\nDetails for one: openframe app tree %s", notReadyNames[0])
 	}
 	return fmt.Errorf("%s", b.String())
 }
@@ -78,6 +78,7 @@ func calleeName(call *ast.CallExpr) string {
 func TestRecoveryAndProgressAreNotGatedOnVerbose(t *testing.T) {
 	watch := map[string]bool{
 		"triggerRepoServerRecovery": true, // corrective action
+		"recoverRepoServer":         true, // corrective action (policy-driven)
 		"checkRepoServerHealth":     true, // feeds the corrective action
 		"UpdateText":                true, // spinner progress
 	}
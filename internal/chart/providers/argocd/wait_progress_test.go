@@ -22,7 +22,7 @@ func TestTimeoutError_NamesTheStuckApplications(t *testing.T) {
 		"openframe-api (Health: Progressing)", // decorated label in the list
 		"openframe-ui (Health: Degraded)",
 		"kubectl get applications -n argocd", // what to run next
-		"kubectl describe application openframe-api -n argocd",
+		"openframe app tree openframe-api",
 	} {
 		if !strings.Contains(msg, want) {
 			t.Errorf("timeout error must contain %q; got:\n%s", want, msg)
@@ -34,16 +34,17 @@ func TestTimeoutError_NamesTheStuckApplications(t *testing.T) {
 // bug: notReady labels carry a " (Health: ...)" suffix for display, and the
 // kubectl-describe hint used one verbatim, producing the un-runnable
 // "kubectl describe application argocd-apps (Health: Progressing) -n argocd".
+// The hint is `openframe app tree` now; the name must still be bare.
 func TestTimeoutError_KubectlHintUsesBareName(t *testing.T) {
 	msg := timeoutError(time.Minute, 14, 17,
 		[]string{"argocd-apps (Health: Progressing)"},
 		[]string{"argocd-apps"}).Error()
 
-	if !strings.Contains(msg, "kubectl describe application argocd-apps -n argocd") {
-		t.Errorf("the kubectl hint must use the bare app name; got:\n%s", msg)
+	if !strings.Contains(msg, "openframe app tree argocd-apps\n") && !strings.HasSuffix(msg, "openframe app tree argocd-apps") {
+		t.Errorf("the details hint must use the bare app name; got:\n%s", msg)
 	}
-	if strings.Contains(msg, "describe application argocd-apps (Health") {
-		t.Errorf("the kubectl command must not contain the decorated label; got:\n%s", msg)
+	if strings.Contains(msg, "tree argocd-apps (Health") {
+		t.Errorf("the details command must not contain the decorated label; got:\n%s", msg)
 	}
 }
 
@@ -76,7 +77,7 @@ func TestTimeoutError_NoAppsIsStillLegible(t *testing.T) {
 	if strings.Contains(msg, "still not ready:") {
 		t.Errorf("an empty list must be omitted, not printed empty; got:\n%s", msg)
 	}
-	if strings.Contains(msg, "describe application") {
+	if strings.Contains(msg, "app tree") {
 		t.Errorf("there is no application to describe; got:\n%s", msg)
 	}
 	if !strings.Contains(msg, "timeout after 1m0s") {
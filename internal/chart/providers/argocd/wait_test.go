@@ -1,14 +1,22 @@
 package argocd
 
 import (
+	"bytes"
 	"context"
+	goruntime "runtime"
 	"testing"
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
+	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestWaitForApplications_DryRun(t *testing.T) {
@@ -65,6 +73,61 @@ func TestWaitForApplications_ContextCancellation(t *testing.T) {
 	}
 }
 
+// quickWait drops WaitForApplications' bootstrap wait and polls fast, and
+// keeps the sync history it records out of the home directory.
+func quickWait(t *testing.T) {
+	t.Helper()
+	origBootstrap, origInterval, origBackoff := bootstrapWait, appCheckInterval, maxUnhealthyBackoff
+	bootstrapWait, appCheckInterval, maxUnhealthyBackoff = 0, 10*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		bootstrapWait, appCheckInterval, maxUnhealthyBackoff = origBootstrap, origInterval, origBackoff
+	})
+	t.Setenv("HOME", t.TempDir())
+}
+
+// readyArgoCD is a manager on fake clients whose ArgoCD pods are ready and
+// whose applications are the given ones.
+func readyArgoCD(apps ...*unstructured.Unstructured) *Manager {
+	m := fakeManager(apps...)
+	m.kubeClient = k8sfake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "argocd-server", Namespace: ArgoCDNamespace,
+			Labels: map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	})
+	m.StabilizationChecks = 1
+	return m
+}
+
+// TestWaitForApplications_WSLRecovery drives the wait through an outage of
+// the application queries on a WSL-backed host: one failure short of giving
+// up the WSL recovery runs, and the wait goes on if the cluster answers again.
+func TestWaitForApplications_WSLRecovery(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("TryRecoverWSL restarts the real distribution on Windows")
+	}
+	onWSLHost(t)
+	quickWait(t)
+	cfg := config.ChartInstallConfig{SkipCRDs: true, Silent: true}
+
+	t.Run("outage clears", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "dns-timeout:kube-api:4")
+		m := readyArgoCD(appObj("core-api", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+		assert.NoError(t, m.WaitForApplications(context.Background(), cfg))
+	})
+
+	t.Run("cluster stays down", func(t *testing.T) {
+		t.Setenv(executor.FaultsEnv, "dns-timeout:kube-api")
+		m := readyArgoCD(appObj("core-api", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+		err := m.WaitForApplications(context.Background(), cfg)
+		assert.ErrorContains(t, err, "cluster became unreachable while waiting for applications")
+	})
+}
+
 func TestWaitForApplications_AllAppsHealthy(t *testing.T) {
 	t.Skip("Skipping test that requires 30-second sleep")
 
@@ -113,3 +176,24 @@ func TestWaitForApplications_ParseError(t *testing.T) {
 	_ = manager
 	_ = config
 }
+
+func TestPrintAppTrees_ShowsConditionAndTree(t *testing.T) {
+	var out bytes.Buffer
+	defer frontend.Set(frontend.NewPlain(&out))()
+	pterm.DisableColor()
+	defer pterm.EnableColor()
+
+	m := fakeManager(appWithResources("openframe-api", "openframe"))
+	m.kubeClient = k8sfake.NewSimpleClientset()
+	m.printAppTrees(context.Background(), []Application{
+		{Name: "openframe-api", Health: "Unknown", Sync: "Unknown", Condition: "rpc error: repository not found"},
+		{Name: "ghost", Health: "Unknown", Sync: "Unknown"},
+	})
+
+	assert.Equal(t, "WARNING:   openframe-api (Health: Unknown, Sync: Unknown)\n"+
+		"WARNING:     rpc error: repository not found\n"+
+		"INFO:     ├── Deployment openframe/api  Synced  Degraded\n"+
+		"INFO:     └── Service openframe/api  Synced  Healthy\n"+
+		"WARNING:   ghost (Health: Unknown, Sync: Unknown)\n"+
+		"INFO:     Resource tree unavailable: no ArgoCD application named ghost\n", out.String())
+}
@@ -0,0 +1,62 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Workload is a pod-owning resource (Deployment, StatefulSet or DaemonSet)
+// that an Application manages.
+type Workload struct {
+	App       string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// workloadKinds are the apps/v1 kinds whose pods carry an application's logs.
+var workloadKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true}
+
+// ApplicationWorkloads lists the workloads every Application manages, as
+// recorded in its status.resources, sorted by application then name. A
+// resource without a namespace is placed in the app's destination namespace.
+func (m *Manager) ApplicationWorkloads(ctx context.Context) ([]Workload, error) {
+	if err := m.initKubernetesClients(); err != nil {
+		return nil, err
+	}
+	if m.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not available")
+	}
+	list, err := m.dynamicClient.Resource(applicationGVR).Namespace(ArgoCDNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing applications: %w", err)
+	}
+
+	var out []Workload
+	for i := range list.Items {
+		app, err := argoAppFromObject(list.Items[i].Object)
+		if err != nil {
+			continue
+		}
+		for _, r := range app.Status.Resources {
+			if r.Group != "apps" || !workloadKinds[r.Kind] {
+				continue
+			}
+			ns := r.Namespace
+			if ns == "" {
+				ns = app.Spec.Destination.Namespace
+			}
+			out = append(out, Workload{App: list.Items[i].GetName(), Kind: r.Kind, Namespace: ns, Name: r.Name})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].App != out[j].App {
+			return out[i].App < out[j].App
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
@@ -0,0 +1,39 @@
+package argocd
+
+import (
+	"context"
+	goruntime "runtime"
+	"testing"
+)
+
+func TestApplicationWorkloads_ReadsStatusResources(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("native cluster ops are refused on Windows (must run inside WSL)")
+	}
+	api := appObj("openframe-api", ArgoCDHealthHealthy, ArgoCDSyncSynced)
+	api.Object["spec"] = map[string]interface{}{"destination": map[string]interface{}{"namespace": "openframe"}}
+	api.Object["status"].(map[string]interface{})["resources"] = []interface{}{
+		map[string]interface{}{"group": "apps", "kind": "Deployment", "namespace": "openframe", "name": "openframe-api"},
+		map[string]interface{}{"group": "apps", "kind": "StatefulSet", "name": "openframe-api-cache"},
+		map[string]interface{}{"kind": "Service", "namespace": "openframe", "name": "openframe-api"},
+		map[string]interface{}{"group": "argoproj.io", "kind": "Application", "name": "child"},
+	}
+	m := fakeManager(api, appObj("empty", ArgoCDHealthHealthy, ArgoCDSyncSynced))
+
+	got, err := m.ApplicationWorkloads(context.Background())
+	if err != nil {
+		t.Fatalf("ApplicationWorkloads: %v", err)
+	}
+	want := []Workload{
+		{App: "openframe-api", Kind: "Deployment", Namespace: "openframe", Name: "openframe-api"},
+		{App: "openframe-api", Kind: "StatefulSet", Namespace: "openframe", Name: "openframe-api-cache"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("workloads = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("workload %d = %+v, want %+v (a namespace-less resource takes the destination)", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,378 @@
+// Package flux is the Flux implementation of gitops.Engine: it installs the
+// Flux controllers into flux-system, points a GitRepository and a root
+// Kustomization at the platform repository, publishes the helm values next to
+// the HelmReleases that read them, and waits until every Kustomization and
+// HelmRelease reports Ready.
+package flux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/gitops"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// Version is the pinned Flux release whose install manifest is applied.
+	Version = "v2.4.0"
+	// Namespace is where the controllers and the platform source live.
+	Namespace = "flux-system"
+	// SourceName names the GitRepository and the root Kustomization.
+	SourceName = "openframe"
+	// ValuesConfigMap holds openframe-helm-values.yaml under ValuesKey, so
+	// HelmReleases in the repository can use it via valuesFrom. Flux resolves
+	// valuesFrom in the HelmRelease's own namespace, so a copy is kept in each
+	// namespace whose HelmReleases reference it.
+	ValuesConfigMap = "openframe-values"
+	ValuesKey       = "values.yaml"
+	// DefaultPath is the repository directory the root Kustomization applies.
+	DefaultPath = "./manifests/flux"
+
+	controllerTimeout = 5 * time.Minute
+	readyTimeout      = 30 * time.Minute
+	pollInterval      = 5 * time.Second
+)
+
+var (
+	kustomizationGVR = schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+	helmReleaseGVR   = schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}
+)
+
+// InstallManifestURL is the pinned Flux install manifest.
+func InstallManifestURL() string {
+	return "https://github.com/fluxcd/flux2/releases/download/" + Version + "/install.yaml"
+}
+
+// Engine implements gitops.Engine with Flux.
+type Engine struct {
+	manifests *manifest.Engine
+	client    dynamic.Interface
+	path      string
+}
+
+var _ gitops.Engine = (*Engine)(nil)
+
+// NewEngine returns a Flux engine for the cluster behind cfg. path is the
+// repository directory the root Kustomization applies (DefaultPath if empty).
+func NewEngine(cfg *rest.Config, path string) (*Engine, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("flux needs a resolved cluster connection")
+	}
+	m, err := manifest.NewEngine(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+	if path == "" {
+		path = DefaultPath
+	}
+	return &Engine{manifests: m, client: client, path: path}, nil
+}
+
+// Name implements gitops.Engine.
+func (e *Engine) Name() string { return gitops.EngineFlux }
+
+// InstallController applies the pinned Flux install manifest and waits for
+// its controllers.
+func (e *Engine) InstallController(ctx context.Context, cfg config.ChartInstallConfig) error {
+	sp := startSpinner(cfg, fmt.Sprintf("Installing Flux %s...", Version))
+	objs, err := manifest.Read(ctx, []string{InstallManifestURL()}, nil)
+	if err == nil {
+		_, err = e.manifests.Apply(ctx, objs, manifest.Options{Wait: true, Timeout: controllerTimeout, DryRun: cfg.DryRun})
+	}
+	if err != nil {
+		sp.Fail("Flux installation failed")
+		return fmt.Errorf("installing Flux %s: %w", Version, err)
+	}
+	sp.Success("Flux installed")
+	return nil
+}
+
+// DeployPlatform applies the GitRepository and the root Kustomization for
+// cfg.AppOfApps.
+func (e *Engine) DeployPlatform(ctx context.Context, cfg config.ChartInstallConfig) error {
+	if cfg.AppOfApps == nil || cfg.AppOfApps.GitHubRepo == "" {
+		return fmt.Errorf("flux needs the platform repository")
+	}
+	objs := PlatformObjects(cfg.AppOfApps.GitHubRepo, cfg.AppOfApps.GitHubBranch, e.path)
+	if _, err := e.manifests.Apply(ctx, objs, manifest.Options{DryRun: cfg.DryRun}); err != nil {
+		return fmt.Errorf("pointing Flux at %s: %w", cfg.AppOfApps.GitHubRepo, err)
+	}
+	pterm.Success.Printf("Flux is reconciling %s (ref %s, path %s)\n", cfg.AppOfApps.GitHubRepo, cfg.AppOfApps.GitHubBranch, e.path)
+	return nil
+}
+
+// WaitForApplications waits until every Kustomization and HelmRelease in the
+// cluster is Ready, publishing the values ConfigMap to the namespaces of the
+// HelmReleases as the root Kustomization creates them. On timeout the ones
+// that are not ready are listed with Flux's own message.
+func (e *Engine) WaitForApplications(ctx context.Context, cfg config.ChartInstallConfig) error {
+	if cfg.DryRun {
+		return nil
+	}
+	values, err := readValues(cfg)
+	if err != nil {
+		return err
+	}
+	published := map[string]bool{}
+	sp := startSpinner(cfg, "Waiting for Flux Kustomizations and HelmReleases...")
+	var last []Resource
+	err = wait.PollUntilContextTimeout(ctx, pollInterval, readyTimeout, true, func(ctx context.Context) (bool, error) {
+		// A HelmRelease whose ConfigMap is missing is retried by Flux, so a
+		// failed publish is picked up on the next poll.
+		_ = e.publishValues(ctx, values, published)
+		res, err := e.Resources(ctx)
+		if err != nil {
+			return false, nil
+		}
+		last = res
+		notReady := NotReady(res)
+		// The root Kustomization creates the rest: an empty list means it has
+		// not reconciled yet.
+		if len(res) == 0 {
+			return false, nil
+		}
+		installstatus.ReportApps(len(res)-len(notReady), len(res))
+		metrics.ReportApps(len(res)-len(notReady), len(res))
+		sp.UpdateText(fmt.Sprintf("Waiting for Flux: %d/%d ready...", len(res)-len(notReady), len(res)))
+		return len(notReady) == 0, nil
+	})
+	if err != nil {
+		sp.Fail("Flux resources not ready")
+		return fmt.Errorf("flux resources not ready after %s: %s", readyTimeout, describe(NotReady(last)))
+	}
+	sp.Success(fmt.Sprintf("All %d Flux resources ready", len(last)))
+	return nil
+}
+
+// readValues returns the helm values file of cfg, or nil without one.
+func readValues(cfg config.ChartInstallConfig) ([]byte, error) {
+	if cfg.AppOfApps == nil || cfg.AppOfApps.ValuesFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(cfg.AppOfApps.ValuesFile) //nolint:gosec // G304: values file resolved by the install config
+	if err != nil {
+		return nil, fmt.Errorf("reading values file: %w", err)
+	}
+	return data, nil
+}
+
+// publishValues applies the values ConfigMap to every namespace whose
+// HelmReleases reference it and that is not in published yet.
+func (e *Engine) publishValues(ctx context.Context, values []byte, published map[string]bool) error {
+	list, err := e.client.Resource(helmReleaseGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing helmreleases: %w", err)
+	}
+	var objs []*unstructured.Unstructured
+	for _, ns := range ValuesNamespaces(list.Items) {
+		if !published[ns] {
+			objs = append(objs, ValuesObject(ns, values))
+		}
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	if _, err := e.manifests.Apply(ctx, objs, manifest.Options{}); err != nil {
+		return fmt.Errorf("publishing %s: %w", ValuesConfigMap, err)
+	}
+	for _, obj := range objs {
+		published[obj.GetNamespace()] = true
+	}
+	return nil
+}
+
+// ValuesNamespaces returns, sorted, the namespaces of the HelmReleases whose
+// valuesFrom reads ValuesConfigMap.
+func ValuesNamespaces(releases []unstructured.Unstructured) []string {
+	seen := map[string]bool{}
+	var out []string
+	for i := range releases {
+		refs, _, _ := unstructured.NestedSlice(releases[i].Object, "spec", "valuesFrom")
+		for _, r := range refs {
+			m, ok := r.(map[string]any)
+			if !ok || m["kind"] != "ConfigMap" || m["name"] != ValuesConfigMap {
+				continue
+			}
+			if ns := releases[i].GetNamespace(); !seen[ns] {
+				seen[ns] = true
+				out = append(out, ns)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ValuesObject is the ConfigMap carrying the helm values in namespace.
+func ValuesObject(namespace string, values []byte) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": ValuesConfigMap, "namespace": namespace},
+		"data":       map[string]any{ValuesKey: string(values)},
+	}}
+}
+
+// Resource is a Kustomization or HelmRelease and its readiness.
+type Resource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Ready     bool
+	Message   string
+}
+
+// Resources lists every Kustomization and HelmRelease in the cluster.
+func (e *Engine) Resources(ctx context.Context) ([]Resource, error) {
+	var out []Resource
+	for _, gvr := range []schema.GroupVersionResource{kustomizationGVR, helmReleaseGVR} {
+		list, err := e.client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", gvr.Resource, err)
+		}
+		for i := range list.Items {
+			out = append(out, resourceFrom(&list.Items[i]))
+		}
+	}
+	return out, nil
+}
+
+func resourceFrom(obj *unstructured.Unstructured) Resource {
+	r := Resource{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	conds, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if !ok || m["type"] != "Ready" {
+			continue
+		}
+		r.Ready = m["status"] == "True"
+		r.Message, _ = m["message"].(string)
+	}
+	// A spec change not yet reconciled is not ready, whatever the condition says.
+	if gen, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); gen < obj.GetGeneration() {
+		r.Ready = false
+	}
+	return r
+}
+
+// NotReady returns the resources that are not Ready.
+func NotReady(res []Resource) []Resource {
+	var out []Resource
+	for _, r := range res {
+		if !r.Ready {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func describe(res []Resource) string {
+	if len(res) == 0 {
+		return "nothing was reconciled from the repository"
+	}
+	parts := make([]string, 0, len(res))
+	for _, r := range res {
+		p := r.Kind + " " + r.Namespace + "/" + r.Name
+		if r.Message != "" {
+			p += " (" + r.Message + ")"
+		}
+		parts = append(parts, p)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
+
+// PlatformObjects builds the Flux objects that deploy the platform: a
+// GitRepository for repo at ref (branch, or tag when it looks like vX.Y.Z) and
+// the root Kustomization applying path from that source.
+func PlatformObjects(repo, ref, path string) []*unstructured.Unstructured {
+	refField := map[string]any{"branch": ref}
+	if isTag(ref) {
+		refField = map[string]any{"tag": ref}
+	}
+	source := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "GitRepository",
+		"metadata":   map[string]any{"name": SourceName, "namespace": Namespace},
+		"spec": map[string]any{
+			"interval": "1m",
+			"url":      repo,
+			"ref":      refField,
+		},
+	}}
+	root := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": SourceName, "namespace": Namespace},
+		"spec": map[string]any{
+			"interval":  "5m",
+			"path":      path,
+			"prune":     true,
+			"wait":      true,
+			"timeout":   "30m",
+			"sourceRef": map[string]any{"kind": "GitRepository", "name": SourceName},
+		},
+	}}
+	return []*unstructured.Unstructured{source, root}
+}
+
+// isTag reports whether ref is a release tag (v1.2.3) rather than a branch.
+func isTag(ref string) bool {
+	if len(ref) < 2 || ref[0] != 'v' {
+		return false
+	}
+	return ref[1] >= '0' && ref[1] <= '9' && strings.Contains(ref, ".")
+}
+
+// progress is a spinner in interactive installs and plain lines otherwise.
+type progress struct{ sp *spinner.Spinner }
+
+func startSpinner(cfg config.ChartInstallConfig, text string) progress {
+	if cfg.Silent || cfg.NonInteractive {
+		pterm.Info.Println(text)
+		return progress{}
+	}
+	return progress{sp: spinner.Start(text)}
+}
+
+func (p progress) UpdateText(text string) {
+	if p.sp != nil {
+		p.sp.UpdateText(text)
+	}
+}
+
+func (p progress) Success(text string) {
+	if p.sp != nil {
+		p.sp.Success(text)
+		return
+	}
+	pterm.Success.Println(text)
+}
+
+func (p progress) Fail(text string) {
+	if p.sp != nil {
+		p.sp.Fail(text)
+		return
+	}
+	pterm.Error.Println(text)
+}
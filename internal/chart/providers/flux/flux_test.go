@@ -0,0 +1,104 @@
+package flux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/gitops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestPlatformObjects(t *testing.T) {
+	objs := PlatformObjects("https://github.com/flamingo-stack/openframe-oss-tenant", "main", DefaultPath)
+	require.Len(t, objs, 2)
+
+	source, root := objs[0], objs[1]
+	assert.Equal(t, "GitRepository", source.GetKind())
+	branch, _, _ := unstructured.NestedString(source.Object, "spec", "ref", "branch")
+	assert.Equal(t, "main", branch)
+
+	assert.Equal(t, "Kustomization", root.GetKind())
+	path, _, _ := unstructured.NestedString(root.Object, "spec", "path")
+	assert.Equal(t, DefaultPath, path)
+	ref, _, _ := unstructured.NestedString(root.Object, "spec", "sourceRef", "name")
+	assert.Equal(t, source.GetName(), ref)
+
+	tagged := PlatformObjects("https://example.com/repo", "v1.2.3", "./x")
+	tag, _, _ := unstructured.NestedString(tagged[0].Object, "spec", "ref", "tag")
+	assert.Equal(t, "v1.2.3", tag, "release tags are pinned as tags")
+}
+
+func helmRelease(namespace, name string, valuesFrom ...any) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec":       map[string]any{"valuesFrom": valuesFrom},
+	}}
+}
+
+func TestValuesNamespaces(t *testing.T) {
+	ref := map[string]any{"kind": "ConfigMap", "name": ValuesConfigMap, "valuesKey": ValuesKey}
+	releases := []unstructured.Unstructured{
+		helmRelease("platform", "api", ref),
+		helmRelease("platform", "gateway", ref),
+		helmRelease("datasources", "mongodb", map[string]any{"kind": "Secret", "name": ValuesConfigMap}, ref),
+		helmRelease("monitoring", "loki", map[string]any{"kind": "ConfigMap", "name": "loki-values"}),
+		helmRelease("flux-system", "plain"),
+	}
+	assert.Equal(t, []string{"datasources", "platform"}, ValuesNamespaces(releases))
+
+	cm := ValuesObject("platform", []byte("global: {}\n"))
+	assert.Equal(t, "platform", cm.GetNamespace(), "the ConfigMap sits next to the HelmReleases reading it")
+	values, _, _ := unstructured.NestedString(cm.Object, "data", ValuesKey)
+	assert.Equal(t, "global: {}\n", values)
+}
+
+func fluxObject(apiVersion, kind, name string, generation, observed int64, ready, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": Namespace, "generation": generation},
+		"status": map[string]any{
+			"observedGeneration": observed,
+			"conditions":         []any{map[string]any{"type": "Ready", "status": ready, "message": message}},
+		},
+	}}
+	return u
+}
+
+func TestResources_Readiness(t *testing.T) {
+	listKinds := map[schema.GroupVersionResource]string{
+		kustomizationGVR: "KustomizationList",
+		helmReleaseGVR:   "HelmReleaseList",
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		fluxObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "openframe", 1, 1, "True", ""),
+		fluxObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "stale", 2, 1, "True", ""),
+		fluxObject("helm.toolkit.fluxcd.io/v2", "HelmRelease", "api", 1, 1, "False", "install retries exhausted"),
+	)
+	e := &Engine{client: client}
+
+	res, err := e.Resources(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	notReady := NotReady(res)
+	require.Len(t, notReady, 2)
+	assert.Equal(t, "HelmRelease flux-system/api (install retries exhausted); Kustomization flux-system/stale", describe(notReady))
+}
+
+func TestParseEngine(t *testing.T) {
+	for in, want := range map[string]string{"": gitops.EngineArgoCD, "ArgoCD": gitops.EngineArgoCD, " flux ": gitops.EngineFlux} {
+		got, err := gitops.ParseEngine(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := gitops.ParseEngine("jenkins-x")
+	assert.ErrorContains(t, err, "unknown GitOps engine")
+}
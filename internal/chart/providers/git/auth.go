@@ -1,9 +1,11 @@
 package git
 
 import (
+	"context"
 	"net/url"
 	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 )
@@ -48,6 +50,21 @@ func extractGitAuth(rawURL string) gitAuth {
 	return gitAuth{cleanURL: u.String(), username: username, token: token}
 }
 
+// storedToken returns the keychain token for an https repository's host, if
+// one was saved with `openframe credentials set git/<host>`; a variable so
+// tests can stub it.
+var storedToken = credentials.GitToken
+
+// gitAuthFor is extractGitAuth falling back to the stored token when the URL
+// carries none.
+func gitAuthFor(ctx context.Context, rawURL string) gitAuth {
+	auth := extractGitAuth(rawURL)
+	if auth.token == "" {
+		auth.token = storedToken(ctx, auth.cleanURL)
+	}
+	return auth
+}
+
 // buildAuth returns the in-memory HTTP auth method for a private repository, or
 // nil for a public one. The token lives only in memory — never in the URL,
 // argv, or a credentials file. GitHub PAT auth expects the token as the
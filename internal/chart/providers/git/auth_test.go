@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"testing"
 
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
@@ -29,6 +30,22 @@ func TestExtractGitAuth(t *testing.T) {
 	assert.Empty(t, pub.token)
 }
 
+func TestGitAuthForStoredToken(t *testing.T) {
+	saved := storedToken
+	t.Cleanup(func() { storedToken = saved })
+	storedToken = func(_ context.Context, repoURL string) string {
+		if repoURL == "https://github.com/org/repo" {
+			return "stored"
+		}
+		return ""
+	}
+
+	assert.Equal(t, "stored", gitAuthFor(context.Background(), "https://github.com/org/repo").token)
+	assert.Equal(t, fakeToken, gitAuthFor(context.Background(), "https://"+fakeToken+"@github.com/org/repo").token,
+		"a token in the URL wins over the keychain")
+	assert.Empty(t, gitAuthFor(context.Background(), "https://gitlab.com/org/repo").token)
+}
+
 // TestBuildAuth is the I1 guard: a private-repo token is handed to go-git only
 // as an in-memory HTTP basic-auth method (never a URL, argv, or on-disk file),
 // and a public repo gets no auth at all.
@@ -4,4 +4,6 @@ package git
 type CloneResult struct {
 	TempDir   string
 	ChartPath string
+	// Commit is the hash of the commit checked out.
+	Commit string
 }
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// ErrRemoteAuth reports a remote that answered but wants credentials it was
+// not given (or refused the ones it was). The host is reachable; whether the
+// credentials ArgoCD holds are enough is decided elsewhere.
+var ErrRemoteAuth = errors.New("the repository requires credentials")
+
+// authMarkers are what git prints when a remote answered but refused access.
+var authMarkers = []string{
+	"authentication failed",
+	"authentication required",
+	"could not read username",
+	"could not read password",
+	"terminal prompts disabled",
+	"permission denied (publickey",
+	"unable to authenticate",
+	"ssh_auth_sock",
+	"the requested url returned error: 401",
+	"the requested url returned error: 403",
+}
+
+// ListRemote contacts repoURL the way `git ls-remote` does — no clone, and no
+// git binary — and reports whether it answered. Credentials embedded in the
+// URL, or stored in the keychain for its host, are used in memory only, as for
+// CloneChartRepository. A remote that
+// answered but refused access returns ErrRemoteAuth.
+func (r *Repository) ListRemote(ctx context.Context, repoURL string) error {
+	auth := gitAuthFor(ctx, repoURL)
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{auth.cleanURL}})
+	_, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth.buildAuth()})
+	switch {
+	case err == nil, errors.Is(err, transport.ErrEmptyRemoteRepository):
+		return nil
+	case errors.Is(err, transport.ErrAuthenticationRequired), errors.Is(err, transport.ErrAuthorizationFailed):
+		return ErrRemoteAuth
+	}
+	return LsRemoteError(maskToken(err.Error(), auth.token))
+}
+
+// ResolveRef returns the commit ref (a branch or tag, as for
+// CloneChartRepository) points to in repoURL, contacting it like ListRemote.
+// An annotated tag resolves to the commit it tags.
+func (r *Repository) ResolveRef(ctx context.Context, repoURL, ref string) (string, error) {
+	auth := gitAuthFor(ctx, repoURL)
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{auth.cleanURL}})
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth.buildAuth(), PeelingOption: gogit.AppendPeeled})
+	if err != nil {
+		return "", LsRemoteError(maskToken(err.Error(), auth.token))
+	}
+	hashes := make(map[plumbing.ReferenceName]string, len(refs))
+	for _, rf := range refs {
+		hashes[rf.Name()] = rf.Hash().String()
+	}
+	for _, name := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.ReferenceName("refs/tags/" + ref + "^{}"),
+		plumbing.NewTagReferenceName(ref),
+	} {
+		if hash, ok := hashes[name]; ok {
+			return hash, nil
+		}
+	}
+	return "", fmt.Errorf("ref '%s' does not exist in %s", ref, auth.cleanURL)
+}
+
+// LsRemoteError turns the output of a failed `git ls-remote` into an error:
+// ErrRemoteAuth when the remote answered but refused access, otherwise the
+// output's last non-empty line, which is where git puts the cause.
+func LsRemoteError(output string) error {
+	lower := strings.ToLower(output)
+	for _, marker := range authMarkers {
+		if strings.Contains(lower, marker) {
+			return ErrRemoteAuth
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if last == "" {
+		last = "git ls-remote failed"
+	}
+	return errors.New(strings.TrimPrefix(last, "fatal: "))
+}
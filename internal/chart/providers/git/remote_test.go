@@ -0,0 +1,27 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRemote(t *testing.T) {
+	repoURL, _ := makeLocalRepo(t, "charts/app")
+	repo := NewRepository()
+
+	require.NoError(t, repo.ListRemote(context.Background(), repoURL))
+	assert.Error(t, repo.ListRemote(context.Background(), filepath.Join(t.TempDir(), "missing")))
+}
+
+func TestLsRemoteError(t *testing.T) {
+	assert.ErrorIs(t, LsRemoteError("fatal: could not read Username for 'https://github.com': terminal prompts disabled\n"), ErrRemoteAuth)
+	assert.ErrorIs(t, LsRemoteError("git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.\n"), ErrRemoteAuth)
+
+	err := LsRemoteError("warning: redirecting\nfatal: unable to access 'https://git.corp/x/': Could not resolve host: git.corp\n")
+	assert.EqualError(t, err, "unable to access 'https://git.corp/x/': Could not resolve host: git.corp")
+	assert.EqualError(t, LsRemoteError(""), "git ls-remote failed")
+}
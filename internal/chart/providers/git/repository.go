@@ -10,6 +10,7 @@ import (
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/pterm/pterm"
 )
@@ -27,12 +28,16 @@ func NewRepository() *Repository {
 // CloneChartRepository clones a GitHub repository to a temporary directory with
 // a shallow, single-ref checkout. GitHubBranch carries a general git ref: it is
 // tried first as a branch and, if no such branch exists, as a tag — so a release
-// tag (e.g. "v1.2.3") works as well as a branch name.
+// tag (e.g. "v1.2.3") works as well as a branch name. A full commit hash checks
+// out that commit, which is how an install manifest replays what it recorded.
 func (r *Repository) CloneChartRepository(ctx context.Context, config *models.AppOfAppsConfig) (*CloneResult, error) {
 	// Separate any embedded credential from the URL so the token is passed only
 	// via the in-memory auth method (audit I1) — never in the URL, argv, or a
 	// credentials file on disk.
-	auth := extractGitAuth(config.GitHubRepo)
+	auth := gitAuthFor(ctx, config.GitHubRepo)
+	if plumbing.IsHash(config.GitHubBranch) {
+		return r.cloneCommit(ctx, config, auth)
+	}
 
 	// Try the ref as a branch first, then as a tag. A branch that is present
 	// succeeds on the first attempt; a tag falls through the branch-not-found
@@ -47,7 +52,7 @@ func (r *Repository) CloneChartRepository(ctx context.Context, config *models.Ap
 			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
 		}
 
-		_, err = gogit.PlainCloneContext(ctx, tempDir, false, &gogit.CloneOptions{
+		repo, err := gogit.PlainCloneContext(ctx, tempDir, false, &gogit.CloneOptions{
 			URL:           auth.cleanURL,
 			Auth:          auth.buildAuth(),
 			ReferenceName: refName,
@@ -56,7 +61,12 @@ func (r *Repository) CloneChartRepository(ctx context.Context, config *models.Ap
 			Tags:          gogit.NoTags,
 		})
 		if err == nil {
-			return r.chartResult(tempDir, config.ChartPath)
+			head, err := repo.Head()
+			if err != nil {
+				r.Cleanup(tempDir)
+				return nil, fmt.Errorf("reading the cloned commit: %w", err)
+			}
+			return r.chartResult(tempDir, config.ChartPath, head.Hash())
 		}
 
 		r.Cleanup(tempDir)
@@ -75,9 +85,57 @@ func (r *Repository) CloneChartRepository(ctx context.Context, config *models.Ap
 	return nil, fmt.Errorf("failed to clone repository: %s", maskToken(lastErr.Error(), auth.token))
 }
 
+// cloneCommit checks out the commit config.GitHubBranch names. It fetches just
+// that commit where the server allows it (GitHub does) and every branch
+// otherwise, then looks for the commit among them.
+func (r *Repository) cloneCommit(ctx context.Context, config *models.AppOfAppsConfig, auth gitAuth) (*CloneResult, error) {
+	hash := plumbing.NewHash(config.GitHubBranch)
+	tempDir, err := os.MkdirTemp("", "openframe-chart-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	fail := func(err error) (*CloneResult, error) {
+		r.Cleanup(tempDir)
+		return nil, err
+	}
+
+	repo, err := gogit.PlainInit(tempDir, false)
+	if err != nil {
+		return fail(err)
+	}
+	remote, err := repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{auth.cleanURL}})
+	if err != nil {
+		return fail(err)
+	}
+	err = remote.FetchContext(ctx, &gogit.FetchOptions{
+		Auth:     auth.buildAuth(),
+		RefSpecs: []gitconfig.RefSpec{gitconfig.RefSpec(hash.String() + ":refs/heads/pinned")},
+		Depth:    1,
+		Tags:     gogit.NoTags,
+	})
+	if errors.Is(err, gogit.ErrExactSHA1NotSupported) {
+		err = remote.FetchContext(ctx, &gogit.FetchOptions{
+			Auth:     auth.buildAuth(),
+			RefSpecs: []gitconfig.RefSpec{"+refs/heads/*:refs/remotes/origin/*"},
+			Tags:     gogit.NoTags,
+		})
+	}
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fail(fmt.Errorf("failed to fetch commit %s: %s", hash, maskToken(err.Error(), auth.token)))
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fail(err)
+	}
+	if err := wt.Checkout(&gogit.CheckoutOptions{Hash: hash}); err != nil {
+		return fail(fmt.Errorf("commit %s is not in %s: %w", hash, auth.cleanURL, err))
+	}
+	return r.chartResult(tempDir, config.ChartPath, hash)
+}
+
 // chartResult validates that chartPath exists inside the freshly cloned tempDir
 // and returns the CloneResult, cleaning up on failure.
-func (r *Repository) chartResult(tempDir, chartSubPath string) (*CloneResult, error) {
+func (r *Repository) chartResult(tempDir, chartSubPath string, commit plumbing.Hash) (*CloneResult, error) {
 	chartPath := filepath.Join(tempDir, chartSubPath)
 	if _, err := os.Stat(chartPath); os.IsNotExist(err) {
 		r.Cleanup(tempDir)
@@ -86,6 +144,7 @@ func (r *Repository) chartResult(tempDir, chartSubPath string) (*CloneResult, er
 	return &CloneResult{
 		TempDir:   tempDir,
 		ChartPath: chartPath,
+		Commit:    commit.String(),
 	}, nil
 }
 
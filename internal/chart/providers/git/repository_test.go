@@ -91,6 +91,46 @@ func TestCloneChartRepository_Tag(t *testing.T) {
 	}
 }
 
+// TestCloneChartRepository_Commit proves a commit hash checks out that commit,
+// even once the branch has moved past it.
+func TestCloneChartRepository_Commit(t *testing.T) {
+	url, branch := makeLocalRepo(t, "manifests/app-of-apps")
+	repo, err := gogit.PlainOpen(url)
+	require.NoError(t, err)
+	first, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(url, "manifests/app-of-apps", "Chart.yaml"), []byte("name: moved\n"), 0o600))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Commit("move", &gogit.CommitOptions{
+		All:    true,
+		Author: &object.Signature{Name: "t", Email: "t@example.com", When: time.Unix(1700000100, 0)},
+	})
+	require.NoError(t, err)
+
+	r := NewRepository()
+	res, err := r.CloneChartRepository(context.Background(), &models.AppOfAppsConfig{
+		GitHubRepo:   url,
+		GitHubBranch: first.Hash().String(),
+		ChartPath:    "manifests/app-of-apps",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Cleanup(res.TempDir) })
+	require.Equal(t, first.Hash().String(), res.Commit)
+	chart, err := os.ReadFile(filepath.Join(res.ChartPath, "Chart.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "name: test\n", string(chart))
+
+	res, err = r.CloneChartRepository(context.Background(), &models.AppOfAppsConfig{
+		GitHubRepo:   url,
+		GitHubBranch: branch,
+		ChartPath:    "manifests/app-of-apps",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Cleanup(res.TempDir) })
+	require.NotEqual(t, first.Hash().String(), res.Commit, "a branch clone reports the commit the branch points to now")
+}
+
 func TestCloneChartRepository_BranchNotFound(t *testing.T) {
 	url, _ := makeLocalRepo(t, "manifests/app-of-apps")
 	repo := NewRepository()
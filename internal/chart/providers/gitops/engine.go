@@ -0,0 +1,50 @@
+// Package gitops defines the pluggable GitOps engine behind `app install`:
+// the controller that pulls the platform from its Git repository and reports
+// when everything it deployed is ready. ArgoCD is the built-in default; other
+// engines (Flux) implement Engine.
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+)
+
+// Engine names accepted by --gitops-engine.
+const (
+	EngineArgoCD = "argocd"
+	EngineFlux   = "flux"
+)
+
+// Engines lists every selectable engine, default first.
+var Engines = []string{EngineArgoCD, EngineFlux}
+
+// Engine installs a GitOps controller, points it at the platform repository,
+// and waits until what it deploys is ready. Each step is idempotent: a retried
+// or repeated install re-applies the same state.
+type Engine interface {
+	// Name is the engine's --gitops-engine value.
+	Name() string
+	// InstallController installs (or upgrades) the controllers and waits for them.
+	InstallController(ctx context.Context, cfg config.ChartInstallConfig) error
+	// DeployPlatform points the controllers at cfg.AppOfApps' repository and ref.
+	DeployPlatform(ctx context.Context, cfg config.ChartInstallConfig) error
+	// WaitForApplications blocks until everything the engine deploys is ready.
+	WaitForApplications(ctx context.Context, cfg config.ChartInstallConfig) error
+}
+
+// ParseEngine normalizes an engine name; empty means ArgoCD.
+func ParseEngine(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return EngineArgoCD, nil
+	}
+	for _, e := range Engines {
+		if name == e {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown GitOps engine %q (want one of: %s)", name, strings.Join(Engines, ", "))
+}
@@ -11,8 +11,9 @@ import (
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
-	"github.com/pterm/pterm"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,7 @@ import (
 // ArgoCD v3.x (Helm chart 10.x) deploys the application-controller as a StatefulSet,
 // while server and repo-server remain as Deployments.
 func (h *HelmManager) waitForArgoCDDeployments(ctx context.Context, verbose bool) error {
+	out := frontend.Current()
 	if h.kubeClient == nil {
 		return fmt.Errorf("kubernetes core client not initialized")
 	}
@@ -55,7 +57,7 @@ func (h *HelmManager) waitForArgoCDDeployments(ctx context.Context, verbose bool
 	timeout := 90 * time.Second      // 90 seconds for slow CI/Windows environments
 	retryInterval := 1 * time.Second // Fast polling interval (native API is ~ms per call)
 
-	pterm.Info.Println("Waiting for ArgoCD workloads via NATIVE API...")
+	out.Info("Waiting for ArgoCD workloads via NATIVE API...")
 
 	// Use wait.PollUntilContextTimeout for resilient polling
 	return wait.PollUntilContextTimeout(ctx, retryInterval, timeout, false, func(ctx context.Context) (bool, error) {
@@ -70,7 +72,7 @@ func (h *HelmManager) waitForArgoCDDeployments(ctx context.Context, verbose bool
 				missingWorkloads = append(missingWorkloads, "deployment/"+name)
 			} else if err != nil {
 				// If it's a transient API error (not 'Not Found'), log and retry
-				pterm.Warning.Printf("Transient API error checking deployment %s: %v\n", name, err)
+				out.Warn("Transient API error checking deployment %s: %v", name, err)
 				return false, nil
 			}
 		}
@@ -83,18 +85,18 @@ func (h *HelmManager) waitForArgoCDDeployments(ctx context.Context, verbose bool
 				missingWorkloads = append(missingWorkloads, "statefulset/"+name)
 			} else if err != nil {
 				// If it's a transient API error (not 'Not Found'), log and retry
-				pterm.Warning.Printf("Transient API error checking statefulset %s: %v\n", name, err)
+				out.Warn("Transient API error checking statefulset %s: %v", name, err)
 				return false, nil
 			}
 		}
 
 		if len(missingWorkloads) == 0 {
-			pterm.Success.Println("All ArgoCD workloads found.")
+			out.Success("All ArgoCD workloads found.")
 			return true, nil // Success: All workloads exist.
 		}
 
 		if verbose {
-			pterm.Debug.Printf("Still missing workloads: %v\n", missingWorkloads)
+			out.Debug("Still missing workloads: %v", missingWorkloads)
 		}
 
 		return false, nil // Keep polling
@@ -105,6 +107,7 @@ func (h *HelmManager) waitForArgoCDDeployments(ctx context.Context, verbose bool
 // This addresses the race condition where Helm's --create-namespace may not complete before the command returns.
 // Uses the native Go client (client-go); on Windows the cluster lives in WSL and must be reached from inside WSL.
 func (h *HelmManager) ensureArgoCDNamespace(ctx context.Context, clusterName string, verbose bool) error {
+	out := frontend.Current()
 	namespace := argocd.ArgoCDNamespace
 
 	if err := platform.WSLClusterHint("create the argocd namespace"); err != nil {
@@ -115,14 +118,14 @@ func (h *HelmManager) ensureArgoCDNamespace(ctx context.Context, clusterName str
 	}
 
 	if verbose {
-		pterm.Info.Println("Ensuring argocd namespace exists via native Go client...")
+		out.Info("Ensuring argocd namespace exists via native Go client...")
 	}
 
 	// Check if namespace already exists
 	_, err := h.kubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 	if err == nil {
 		if verbose {
-			pterm.Debug.Println("Namespace argocd already exists")
+			out.Debug("Namespace argocd already exists")
 		}
 		return nil
 	}
@@ -144,7 +147,7 @@ func (h *HelmManager) ensureArgoCDNamespace(ctx context.Context, clusterName str
 	}
 
 	if verbose {
-		pterm.Info.Println("Created argocd namespace, waiting for it to become Active...")
+		out.Info("Created argocd namespace, waiting for it to become Active...")
 	}
 
 	// Wait for namespace to become Active
@@ -155,7 +158,7 @@ func (h *HelmManager) ensureArgoCDNamespace(ctx context.Context, clusterName str
 		}
 		if ns.Status.Phase == corev1.NamespaceActive {
 			if verbose {
-				pterm.Success.Println("Namespace argocd is Active")
+				out.Success("Namespace argocd is Active")
 			}
 			return true, nil
 		}
@@ -167,6 +170,7 @@ func (h *HelmManager) ensureArgoCDNamespace(ctx context.Context, clusterName str
 // This prevents flooding a dead port with requests on Windows/WSL2 where the port
 // might not be immediately available after k3d reports success
 func (h *HelmManager) waitForAPIPort(ctx context.Context, timeout time.Duration) error {
+	out := frontend.Current()
 	if h.kubeConfig == nil {
 		return nil // Skip if no kubeConfig available
 	}
@@ -178,13 +182,13 @@ func (h *HelmManager) waitForAPIPort(ctx context.Context, timeout time.Duration)
 	}
 
 	dialer := net.Dialer{Timeout: 2 * time.Second}
-	pterm.Info.Printf("Waiting for API port %s to open...\n", apiAddress)
+	out.Info("Waiting for API port %s to open...", apiAddress)
 
 	return wait.PollUntilContextTimeout(ctx, 1*time.Second, timeout, false, func(ctx context.Context) (bool, error) {
 		conn, err := dialer.DialContext(ctx, "tcp", apiAddress)
 		if err == nil {
 			_ = conn.Close()
-			pterm.Success.Printf("API port %s is open\n", apiAddress)
+			out.Success("API port %s is open", apiAddress)
 			return true, nil // Port is open!
 		}
 		return false, nil // Keep polling
@@ -194,8 +198,9 @@ func (h *HelmManager) waitForAPIPort(ctx context.Context, timeout time.Duration)
 // verifyHelmRelease checks if a Helm release was actually created by running helm list
 // This helps diagnose issues where Helm reports success but doesn't create resources
 func (h *HelmManager) verifyHelmRelease(ctx context.Context, releaseName, namespace, clusterName string, verbose bool) error {
+	out := frontend.Current()
 	if verbose {
-		pterm.Info.Printf("Verifying Helm release '%s' in namespace '%s'...\n", releaseName, namespace)
+		out.Info("Verifying Helm release '%s' in namespace '%s'...", releaseName, namespace)
 	}
 
 	// Build helm list args
@@ -203,14 +208,16 @@ func (h *HelmManager) verifyHelmRelease(ctx context.Context, releaseName, namesp
 
 	// Add explicit kube-context if cluster name is provided
 	if clusterName != "" {
-		contextName := k8s.ResolveContextForCluster(k8s.DefaultKubeconfigPath(), clusterName)
-		args = append(args, "--kube-context", contextName)
+		kubeconfig := k8s.KubeconfigForCluster(clusterName)
+		args = append(args, "--kube-context", k8s.ResolveContextForCluster(kubeconfig, clusterName))
+		args = append(args, k8s.KubeconfigArgs(kubeconfig)...)
 	}
 
 	result, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
 		Command: "helm",
 		Args:    args,
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to run helm list: %w", err)
@@ -235,19 +242,21 @@ func (h *HelmManager) verifyHelmRelease(ctx context.Context, releaseName, namesp
 	// intentionally discarded, not printed even under --verbose (V6).
 	statusArgs := []string{"status", releaseName, "-n", namespace}
 	if clusterName != "" {
-		contextName := k8s.ResolveContextForCluster(k8s.DefaultKubeconfigPath(), clusterName)
-		statusArgs = append(statusArgs, "--kube-context", contextName)
+		kubeconfig := k8s.KubeconfigForCluster(clusterName)
+		statusArgs = append(statusArgs, "--kube-context", k8s.ResolveContextForCluster(kubeconfig, clusterName))
+		statusArgs = append(statusArgs, k8s.KubeconfigArgs(kubeconfig)...)
 	}
 
 	if _, err = h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
 		Command: "helm",
 		Args:    statusArgs,
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	}); err != nil {
 		return fmt.Errorf("helm release exists but status check failed: %w", err)
 	}
 
-	pterm.Success.Printf("Helm release '%s' verified successfully\n", releaseName)
+	out.Success("Helm release '%s' verified successfully", releaseName)
 	return nil
 }
 
@@ -255,26 +264,27 @@ func (h *HelmManager) verifyHelmRelease(ctx context.Context, releaseName, namesp
 // deployments and pods via the native client when installation fails. The
 // verbose kubectl event/log/describe dumps were dropped for a compact summary.
 func (h *HelmManager) showArgoCDDiagnostics(ctx context.Context, _ string) {
-	pterm.Warning.Println("=== ArgoCD Installation Diagnostics ===")
+	out := frontend.Current()
+	out.Warn("=== ArgoCD Installation Diagnostics ===")
 	if h.kubeClient == nil {
-		pterm.Warning.Println("Native Kubernetes client unavailable; skipping diagnostics.")
+		out.Warn("Native Kubernetes client unavailable; skipping diagnostics.")
 		return
 	}
 
 	if deps, err := h.kubeClient.AppsV1().Deployments(argocd.ArgoCDNamespace).List(ctx, metav1.ListOptions{}); err == nil {
-		pterm.Info.Println("Deployments:")
+		out.Info("Deployments:")
 		for i := range deps.Items {
 			d := deps.Items[i]
-			pterm.Info.Printf("  %s: %d/%d ready\n", d.Name, d.Status.ReadyReplicas, d.Status.Replicas)
+			out.Info("  %s: %d/%d ready", d.Name, d.Status.ReadyReplicas, d.Status.Replicas)
 		}
 	}
 
 	pods, err := h.kubeClient.CoreV1().Pods(argocd.ArgoCDNamespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
-		pterm.Warning.Printf("Could not list ArgoCD pods: %v\n", err)
+		out.Warn("Could not list ArgoCD pods: %v", err)
 		return
 	}
-	pterm.Info.Println("Pods:")
+	out.Info("Pods:")
 	for i := range pods.Items {
 		p := pods.Items[i]
 		ready, total := 0, 0
@@ -286,20 +296,21 @@ func (h *HelmManager) showArgoCDDiagnostics(ctx context.Context, _ string) {
 			}
 			restarts += cs.RestartCount
 		}
-		pterm.Info.Printf("  %s: %s, %d/%d ready, %d restart(s)\n", p.Name, p.Status.Phase, ready, total, restarts)
+		out.Info("  %s: %s, %d/%d ready, %d restart(s)", p.Name, p.Status.Phase, ready, total, restarts)
 		for _, cs := range p.Status.ContainerStatuses {
 			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
-				pterm.Warning.Printf("    %s waiting: %s\n", cs.Name, cs.State.Waiting.Reason)
+				out.Warn("    %s waiting: %s", cs.Name, cs.State.Waiting.Reason)
 			}
 		}
 	}
-	pterm.Warning.Println("=== End of Diagnostics ===")
+	out.Warn("=== End of Diagnostics ===")
 }
 
 // verifyClusterConnectivity verifies the cluster is reachable before app-of-apps
 // installation, via the native client (retried, since the API may need a moment
 // after an idle period).
 func (h *HelmManager) verifyClusterConnectivity(ctx context.Context, config config.ChartInstallConfig) error {
+	out := frontend.Current()
 	if err := platform.WSLClusterHint("verify cluster connectivity"); err != nil {
 		return err
 	}
@@ -307,17 +318,17 @@ func (h *HelmManager) verifyClusterConnectivity(ctx context.Context, config conf
 		return fmt.Errorf("kubernetes client unavailable: cannot reach the cluster")
 	}
 
-	pterm.Info.Println("Verifying cluster connectivity before app-of-apps installation...")
+	out.Info("Verifying cluster connectivity before app-of-apps installation...")
 	var lastErr error
 	for i := 0; i < 5; i++ {
 		_, err := h.kubeClient.CoreV1().Namespaces().Get(ctx, argocd.ArgoCDNamespace, metav1.GetOptions{})
 		if err == nil || k8serrors.IsNotFound(err) {
-			pterm.Success.Println("Cluster is reachable")
+			out.Success("Cluster is reachable")
 			return nil
 		}
 		lastErr = err
 		if config.Verbose {
-			pterm.Warning.Printf("Cluster connectivity check attempt %d/5 failed: %v\n", i+1, err)
+			out.Warn("Cluster connectivity check attempt %d/5 failed: %v", i+1, err)
 		}
 		select {
 		case <-ctx.Done():
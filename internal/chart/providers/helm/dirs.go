@@ -0,0 +1,125 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
+)
+
+// Environment variables choosing where helm keeps its cache, configuration
+// (the repository list) and data (plugins), over helm.cacheHome, configHome
+// and dataHome in the user config. The WSL launcher shares them into WSL.
+const (
+	CacheHomeEnv  = "OPENFRAME_HELM_CACHE_HOME"
+	ConfigHomeEnv = "OPENFRAME_HELM_CONFIG_HOME"
+	DataHomeEnv   = "OPENFRAME_HELM_DATA_HOME"
+)
+
+// helmDir is one of helm's directories: the variable helm reads it from, the
+// OpenFrame override, the user config key and its subdirectory of helmHome.
+type helmDir struct {
+	helmVar, env, key, sub string
+}
+
+var helmDirs = []helmDir{
+	{"HELM_CACHE_HOME", CacheHomeEnv, "cacheHome", "cache"},
+	{"HELM_CONFIG_HOME", ConfigHomeEnv, "configHome", "config"},
+	{"HELM_DATA_HOME", DataHomeEnv, "dataHome", "data"},
+}
+
+// configuredHelmDirs reads the directories set in the environment or in the
+// user config, keyed by helm's variable. A missing or unreadable config sets
+// none.
+func configuredHelmDirs() map[string]string {
+	var cfg struct {
+		Helm map[string]string `json:"helm"`
+	}
+	_ = sharedconfig.LoadUserConfig(&cfg)
+	dirs := map[string]string{}
+	for _, d := range helmDirs {
+		v := strings.TrimSpace(os.Getenv(d.env))
+		if v == "" {
+			v = strings.TrimSpace(cfg.Helm[d.key])
+		}
+		if v != "" {
+			dirs[d.helmVar] = expandHome(v)
+		}
+	}
+	return dirs
+}
+
+// helmDirsUnder lays helm's directories out under base, keeping those set in
+// configured.
+func helmDirsUnder(base string, configured map[string]string) map[string]string {
+	dirs := make(map[string]string, len(helmDirs))
+	for _, d := range helmDirs {
+		dirs[d.helmVar] = filepath.Join(base, d.sub)
+		if v, ok := configured[d.helmVar]; ok {
+			dirs[d.helmVar] = v
+		}
+	}
+	return dirs
+}
+
+// wslHelmEnv is getHelmEnv for helm running in WSL on Windows: the same
+// directories as on Windows, as WSL paths, and WSLENV naming helm's variables
+// so that they cross into WSL at all.
+func wslHelmEnv(configured map[string]string) map[string]string {
+	base := helmTempHome()
+	if home, err := helmHome(); err == nil && makeHelmDirs(helmDirsUnder(home, nil)) == nil {
+		base = home
+	}
+	env := helmDirsUnder(base, configured)
+	names := make([]string, 0, len(env))
+	for name, dir := range env {
+		if converted, err := wslpath.Manual(dir); err == nil {
+			env[name] = converted
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	env["WSLENV"] = withWSLENV(os.Getenv("WSLENV"), names)
+	return env
+}
+
+// withWSLENV adds names to a WSLENV value, keeping the entries it has.
+func withWSLENV(existing string, names []string) string {
+	parts := strings.FieldsFunc(existing, func(r rune) bool { return r == ':' })
+	for _, name := range names {
+		if !slices.ContainsFunc(parts, func(p string) bool {
+			n, _, _ := strings.Cut(p, "/")
+			return n == name
+		}) {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// helmTempHome is the fallback when the home directory is not writable. It is
+// per user, so two users of one machine do not share (or fight over) it.
+func helmTempHome() string {
+	name := "openframe-helm"
+	if u := os.Getuid(); u >= 0 {
+		name = fmt.Sprintf("%s-%d", name, u)
+	}
+	return filepath.Join(os.TempDir(), name)
+}
+
+// expandHome expands a leading ~ in path.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,68 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHelmEnv_DefaultsUnderHelmHome(t *testing.T) {
+	isolateHelmHome(t, "")
+	home, _ := helmHome()
+
+	env := (&HelmManager{}).getHelmEnv()
+	assert.Equal(t, map[string]string{
+		"HELM_CACHE_HOME":  filepath.Join(home, "cache"),
+		"HELM_CONFIG_HOME": filepath.Join(home, "config"),
+		"HELM_DATA_HOME":   filepath.Join(home, "data"),
+	}, env)
+	assert.DirExists(t, env["HELM_DATA_HOME"])
+}
+
+func TestGetHelmEnv_EnvOverridesConfig(t *testing.T) {
+	dir := t.TempDir()
+	isolateHelmHome(t, `{"helm": {"cacheHome": "`+filepath.ToSlash(filepath.Join(dir, "from-config"))+`", "dataHome": "  "}}`)
+	home, _ := helmHome()
+	t.Setenv(ConfigHomeEnv, filepath.Join(dir, "from-env"))
+
+	env := (&HelmManager{}).getHelmEnv()
+	assert.Equal(t, filepath.Join(dir, "from-config"), env["HELM_CACHE_HOME"])
+	assert.Equal(t, filepath.Join(dir, "from-env"), env["HELM_CONFIG_HOME"])
+	assert.Equal(t, filepath.Join(home, "data"), env["HELM_DATA_HOME"], "a blank entry keeps the default")
+	assert.DirExists(t, filepath.Join(dir, "from-env"))
+}
+
+func TestGetHelmEnv_WSL(t *testing.T) {
+	isolateHelmHome(t, `{"helm": {"cacheHome": "D:\\helm\\cache"}}`)
+	home, _ := helmHome()
+	orig := helmInWSL
+	helmInWSL = func() bool { return true }
+	t.Cleanup(func() { helmInWSL = orig })
+	t.Setenv("WSLENV", "GITHUB_TOKEN:HELM_DATA_HOME/p")
+
+	env := (&HelmManager{}).getHelmEnv()
+	assert.Equal(t, map[string]string{
+		"HELM_CACHE_HOME":  "/mnt/d/helm/cache",
+		"HELM_CONFIG_HOME": filepath.Join(home, "config"),
+		"HELM_DATA_HOME":   filepath.Join(home, "data"),
+		"WSLENV":           "GITHUB_TOKEN:HELM_DATA_HOME/p:HELM_CACHE_HOME:HELM_CONFIG_HOME",
+	}, env, "the configured directory is translated and the variables cross into WSL")
+	assert.DirExists(t, env["HELM_CONFIG_HOME"], "the default directories persist under helmHome")
+}
+
+func TestConfiguredHelmDirs_ExpandsHome(t *testing.T) {
+	isolateHelmHome(t, "")
+	t.Setenv(DataHomeEnv, "~/helm-data")
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"HELM_DATA_HOME": filepath.Join(home, "helm-data")}, configuredHelmDirs())
+}
+
+func TestHelmTempHome_IsPerUser(t *testing.T) {
+	assert.NotEqual(t, "/tmp/helm", helmTempHome())
+	assert.Equal(t, os.TempDir(), filepath.Dir(helmTempHome()))
+}
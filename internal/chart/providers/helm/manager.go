@@ -3,25 +3,25 @@ package helm
 import (
 	"context"
 	"encoding/json"
-	stderrors "errors"
 	"fmt"
 	"os"
-	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
 	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
 	uispinner "github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
-	"github.com/pterm/pterm"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
@@ -42,11 +42,12 @@ type HelmManager struct {
 // NewHelmManager creates a new Helm manager with the given rest.Config
 // The config is used to create the Kubernetes client for native API operations
 func NewHelmManager(exec executor.CommandExecutor, config *rest.Config, verbose bool) (*HelmManager, error) {
+	out := frontend.Current()
 	if config == nil {
 		// Return a minimal HelmManager that can still execute helm commands
 		// but will use kubectl fallback for deployment verification
 		if verbose {
-			pterm.Warning.Println("Creating HelmManager without rest.Config - native Go client will be unavailable")
+			out.Warn("Creating HelmManager without rest.Config - native Go client will be unavailable")
 		}
 		return &HelmManager{
 			executor: exec,
@@ -54,21 +55,14 @@ func NewHelmManager(exec executor.CommandExecutor, config *rest.Config, verbose
 		}, nil
 	}
 
-	// CRITICAL FIX: Bypass TLS Verification for local k3d clusters
-	// Uses Insecure=true with CA data cleared, preserving client cert authentication.
-	// Applied here as defense-in-depth in case the caller's config doesn't have it set.
-	config = sharedconfig.ApplyInsecureTLSConfig(config)
-
-	if verbose {
-		pterm.Debug.Println("TLS verification bypassed for local k3d cluster (Insecure=true, auth preserved)")
-	}
+	config = sharedconfig.ApplyLocalTLSConfig(config)
 
 	coreClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		// Native client unavailable — cluster operations will fail with a clear
 		// error (there is no kubectl fallback anymore).
 		if verbose {
-			pterm.Warning.Printf("Failed to create Kubernetes core client: %v\n", err)
+			out.Warn("Failed to create Kubernetes core client: %v", err)
 		}
 		return &HelmManager{
 			executor:   exec,
@@ -80,7 +74,7 @@ func NewHelmManager(exec executor.CommandExecutor, config *rest.Config, verbose
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		if verbose {
-			pterm.Warning.Printf("Failed to create Kubernetes dynamic client: %v\n", err)
+			out.Warn("Failed to create Kubernetes dynamic client: %v", err)
 		}
 		// Still return with coreClient available
 		return &HelmManager{
@@ -92,7 +86,7 @@ func NewHelmManager(exec executor.CommandExecutor, config *rest.Config, verbose
 	}
 
 	if verbose {
-		pterm.Debug.Println("HelmManager initialized with native Go Kubernetes clients")
+		out.Debug("HelmManager initialized with native Go Kubernetes clients")
 	}
 
 	return &HelmManager{
@@ -104,28 +98,38 @@ func NewHelmManager(exec executor.CommandExecutor, config *rest.Config, verbose
 	}, nil
 }
 
-// getHelmEnv returns environment variables for Helm to use writable directories
-// This is especially important in CI environments where home directory may not have write permissions
+// getHelmEnv returns environment variables for Helm to use writable directories.
+// They live under ~/.openframe/helm, so the repository list and index cache
+// survive between runs (see ensureArgoRepo), unless OPENFRAME_HELM_*_HOME or
+// the user config puts them elsewhere. A per-user temporary directory is the
+// fallback — CI home directories may not be writable.
 func (h *HelmManager) getHelmEnv() map[string]string {
-	// Define the directories - these are WSL/Linux paths
-	// On Windows, helm runs inside WSL via the helm-wrapper.sh script which sets these
-	helmDirs := map[string]string{
-		"HELM_CACHE_HOME":  "/tmp/helm/cache",
-		"HELM_CONFIG_HOME": "/tmp/helm/config",
-		"HELM_DATA_HOME":   "/tmp/helm/data",
-	}
-
-	// Only create directories on non-Windows platforms
-	// On Windows, the directories are created inside WSL by the wrapper script
-	if runtime.GOOS != "windows" {
-		for _, dir := range helmDirs {
-			if err := os.MkdirAll(dir, 0750); err != nil {
-				pterm.Debug.Printf("failed to pre-create helm dir %s: %v\n", dir, err)
-			}
+	out := frontend.Current()
+	configured := configuredHelmDirs()
+
+	if helmInWSL() {
+		return wslHelmEnv(configured)
+	}
+	if home, err := helmHome(); err == nil {
+		homeDirs := helmDirsUnder(home, configured)
+		if makeHelmDirs(homeDirs) == nil {
+			return homeDirs
 		}
 	}
+	tmpDirs := helmDirsUnder(helmTempHome(), configured)
+	if err := makeHelmDirs(tmpDirs); err != nil {
+		out.Warn("Could not create the helm directories: %v", err)
+	}
+	return tmpDirs
+}
 
-	return helmDirs
+func makeHelmDirs(dirs map[string]string) error {
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // IsHelmInstalled checks if Helm is available
@@ -134,6 +138,7 @@ func (h *HelmManager) IsHelmInstalled(ctx context.Context) error {
 		Command: "helm",
 		Args:    []string{"version", "--short"},
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	})
 	if err != nil {
 		return errors.ErrHelmNotAvailable
@@ -152,6 +157,7 @@ func (h *HelmManager) IsChartInstalled(ctx context.Context, releaseName, namespa
 		Command: "helm",
 		Args:    args,
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	})
 	if err != nil {
 		return false, err
@@ -180,11 +186,13 @@ func (h *HelmManager) UninstallRelease(ctx context.Context, releaseName, namespa
 	args := []string{"uninstall", releaseName, "-n", namespace, "--ignore-not-found"}
 	if kubeContext != "" {
 		args = append(args, "--kube-context", kubeContext)
+		args = append(args, k8s.KubeconfigArgs(k8s.KubeconfigForContext(kubeContext))...)
 	}
 	_, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
 		Command: "helm",
 		Args:    args,
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
 	})
 	if err != nil {
 		// Name the target: "helm uninstall argo-cd: exit status 1" gave no way
@@ -207,11 +215,34 @@ func helmKubeContext(cfg config.ChartInstallConfig) string {
 		return cfg.KubeContext
 	}
 	if cfg.ClusterName != "" {
-		return k8s.ResolveContextForCluster(k8s.DefaultKubeconfigPath(), cfg.ClusterName)
+		return k8s.ResolveContextForCluster(k8s.KubeconfigForCluster(cfg.ClusterName), cfg.ClusterName)
 	}
 	return ""
 }
 
+// helmKubeconfigArgs points helm at the isolated kubeconfig holding the
+// target's context; nil when the default kubeconfig has it.
+func helmKubeconfigArgs(cfg config.ChartInstallConfig) []string {
+	if cfg.KubeContext != "" {
+		return k8s.KubeconfigArgs(k8s.KubeconfigForContext(cfg.KubeContext))
+	}
+	return k8s.KubeconfigArgs(k8s.KubeconfigForCluster(cfg.ClusterName))
+}
+
+// argoCDWait is how long `helm upgrade --wait` waits for ArgoCD to come up.
+const argoCDWait = "7m"
+
+// waitTimeout bounds a helm call that waits with --timeout wait: the
+// long-running timeout, or a minute past helm's own when that is longer, so
+// helm reports what it was waiting for rather than being killed.
+func waitTimeout(wait string) time.Duration {
+	limit := sharedconfig.Timeout(sharedconfig.LongRunning)
+	if d, err := time.ParseDuration(wait); err == nil && d+time.Minute > limit {
+		return d + time.Minute
+	}
+	return limit
+}
+
 // argoCDInstallArgs builds the `helm upgrade --install argo-cd` argument list.
 // Pure and testable — the CRDs are installed by the chart itself
 // (crds.install=true), so no crds flag is passed.
@@ -222,11 +253,12 @@ func argoCDInstallArgs(cfg config.ChartInstallConfig, valuesFilePath string) []s
 		"--namespace", argocd.ArgoCDNamespace,
 		"--create-namespace",
 		"--wait",
-		"--timeout", "7m",
+		"--timeout", argoCDWait,
 		"-f", valuesFilePath,
 	}
 	if kubeContext := helmKubeContext(cfg); kubeContext != "" {
 		args = append(args, "--kube-context", kubeContext)
+		args = append(args, helmKubeconfigArgs(cfg)...)
 	}
 	if cfg.DryRun {
 		// Explicit client-side dry-run: the bare --dry-run form is deprecated in
@@ -237,15 +269,21 @@ func argoCDInstallArgs(cfg config.ChartInstallConfig, valuesFilePath string) []s
 	return args
 }
 
+// storedAdminPassword returns the ArgoCD admin password saved with
+// `openframe credentials set argocd/admin`, or ""; a variable so tests can
+// stub it.
+var storedAdminPassword = credentials.ArgoCDAdminPassword
+
 // installArgoCDHelm runs `helm upgrade --install argo-cd ... -f -`, feeding the
 // embedded ArgoCD values via stdin so nothing is written to the user's
 // filesystem (and there is no path to convert for WSL). Split out from
 // InstallArgoCDWithProgress so the stdin / no-temp-file contract is unit-testable
 // without the post-install verification and deployment waits.
 func (h *HelmManager) installArgoCDHelm(ctx context.Context, cfg config.ChartInstallConfig) (*executor.CommandResult, error) {
+	out := frontend.Current()
 	args := argoCDInstallArgs(cfg, "-")
 	if cfg.Verbose {
-		pterm.Debug.Printf("Executing: helm %s\n", strings.Join(args, " "))
+		out.Debug("Executing: helm %s", strings.Join(args, " "))
 	}
 
 	// The ArgoCD chart's values are the embedded baseline, optionally overridden
@@ -253,20 +291,30 @@ func (h *HelmManager) installArgoCDHelm(ctx context.Context, cfg config.ChartIns
 	// subtree is merged (never the whole file — the rest targets the app-of-apps
 	// chart and carries the registry password). Overrides are announced because a
 	// bad one can break the install.
+	// A host-sizing overlay (--size, or detected) sits between the two, with
+	// the admin password stored as credentials.ArgoCDAdminName.
 	values := argocd.GetArgoCDValues()
 	uv, path, err := userValues(cfg)
 	if err != nil {
 		return nil, err
 	}
-	if uv != nil {
-		merged, overridden, err := argocd.MergedArgoCDValues(uv)
+	overlay := sizing.ArgoCDOverlay(cfg.Size)
+	if password := storedAdminPassword(ctx); password != "" {
+		if overlay, err = argocd.WithAdminPassword(overlay, password); err != nil {
+			return nil, err
+		}
+	}
+	if uv != nil || overlay != nil {
+		merged, overridden, err := argocd.SizedArgoCDValues(overlay, uv)
 		if err != nil {
 			return nil, fmt.Errorf("merging ArgoCD overrides from %s: %w", path, err)
 		}
 		if len(overridden) > 0 {
-			pterm.Warning.Printfln("Using ArgoCD overrides from %s (keys: %s) on top of the built-in baseline "+
+			out.Warn("Using ArgoCD overrides from %s (keys: %s) on top of the built-in baseline "+
 				"(differs from the bundled argocd-values.yaml); a bad override can break the ArgoCD install.",
 				path, strings.Join(overridden, ", "))
+		}
+		if len(overridden) > 0 || overlay != nil {
 			values = merged
 		}
 	}
@@ -276,9 +324,55 @@ func (h *HelmManager) installArgoCDHelm(ctx context.Context, cfg config.ChartIns
 		Args:    args,
 		Env:     h.getHelmEnv(),
 		Stdin:   []byte(values),
+		Timeout: waitTimeout(argoCDWait),
 	})
 }
 
+// outputRelay returns the OnOutput callback for a blocking helm call: under
+// --verbose each line is echoed live, and sp (when animated) shows the latest.
+// It returns nil when neither is listening.
+func (h *HelmManager) outputRelay(sp frontend.Spinner) func(line string) {
+	out := frontend.Current()
+	if !h.verbose && sp == nil {
+		return nil
+	}
+	return func(line string) {
+		if h.verbose {
+			out.Debug("%s", line)
+		}
+		if sp != nil {
+			sp.SetDetail(line)
+		}
+	}
+}
+
+// writeSizingOverlay writes the app-of-apps sizing overlay for size to a temp
+// file and returns its path, or "" when size needs none. The caller removes it.
+func writeSizingOverlay(size string) (string, error) {
+	overlay := sizing.AppOfAppsOverlay(size)
+	if overlay == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(overlay)
+	if err != nil {
+		return "", fmt.Errorf("marshaling sizing overlay: %w", err)
+	}
+	f, err := os.CreateTemp("", "openframe-size-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("creating sizing overlay: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("writing sizing overlay: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("writing sizing overlay: %w", err)
+	}
+	return f.Name(), nil
+}
+
 // userValues reads and parses the user's openframe-helm-values.yaml, returning
 // the parsed map and the path it read. It resolves the path from the config
 // (explicit --values wins) or the default cwd location. A MISSING file is
@@ -309,41 +403,21 @@ func userValues(cfg config.ChartInstallConfig) (map[string]interface{}, string,
 
 // InstallArgoCDWithProgress installs ArgoCD using Helm with progress indicators
 func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config config.ChartInstallConfig) error {
+	out := frontend.Current()
 	// Show progress for each step only if not in silent/non-interactive mode
-	var spinner *uispinner.Spinner
+	var spinner frontend.Spinner
 	if !config.Silent && !config.NonInteractive {
-		spinner = uispinner.Start("Installing ArgoCD...")
+		spinner = out.Spinner("Installing ArgoCD...")
 	} else {
-		pterm.Info.Println("Installing ArgoCD...")
+		out.Info("Installing ArgoCD...")
 	}
 
-	// Add ArgoCD repository silently
-	_, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
-		Command: "helm",
-		Args:    []string{"repo", "add", "argo", argocd.ArgoHelmRepoURL},
-		Env:     h.getHelmEnv(),
-	})
-	if err != nil {
-		// Ignore if already exists
-		if !strings.Contains(err.Error(), "already exists") {
-			if spinner != nil {
-				spinner.Stop()
-			}
-			return fmt.Errorf("failed to add ArgoCD repository: %w", err)
-		}
-	}
-
-	// Update repositories silently
-	_, err = h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
-		Command: "helm",
-		Args:    []string{"repo", "update"},
-		Env:     h.getHelmEnv(),
-	})
-	if err != nil {
+	// Add or refresh the ArgoCD repository only when the cached index will not do
+	if err := h.ensureArgoRepo(ctx, config.SkipRepoUpdate); err != nil {
 		if spinner != nil {
 			spinner.Stop()
 		}
-		return fmt.Errorf("failed to update Helm repositories: %w", err)
+		return err
 	}
 
 	// First, verify the cluster is reachable via the native client (client-go),
@@ -378,7 +452,7 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 		lastErr = err
 		if i < maxRetries-1 {
 			if config.Verbose {
-				pterm.Info.Printf("Waiting for cluster to be ready... (attempt %d/%d)\n", i+1, maxRetries)
+				out.Info("Waiting for cluster to be ready... (attempt %d/%d)", i+1, maxRetries)
 			}
 			select {
 			case <-ctx.Done():
@@ -404,9 +478,9 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 
 	// Installation details are now silent - just show in verbose mode
 	if config.Verbose {
-		pterm.Info.Printf("   Version: %s\n", argocd.ArgoCDChartVersion)
-		pterm.Info.Printf("   Namespace: argocd\n")
-		pterm.Info.Println("   Values: piped via stdin (-f -)")
+		out.Info("   Version: %s", argocd.ArgoCDChartVersion)
+		out.Info("   Namespace: argocd")
+		out.Info("   Values: piped via stdin (-f -)")
 	}
 
 	// Explicitly create and verify the argocd namespace exists BEFORE Helm install
@@ -422,7 +496,7 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 	}
 
 	if config.DryRun && config.Verbose {
-		pterm.Info.Println("Running in dry-run mode...")
+		out.Info("Running in dry-run mode...")
 	}
 
 	// installArgoCDHelm blocks on `helm upgrade --wait --timeout 7m`, which
@@ -480,8 +554,10 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 	// not printed. Stderr, when present, carries deprecation/ownership warnings
 	// worth seeing; it arrives already redacted by the executor.
 	if config.Verbose && result != nil && result.Stderr != "" {
-		pterm.Info.Println("Helm stderr:")
-		pterm.Println(result.Stderr)
+		out.Info("Helm stderr:")
+		for _, l := range strings.Split(strings.TrimRight(result.Stderr, "\n"), "\n") {
+			out.Info("  %s", l)
+		}
 	}
 
 	// Dry-run creates nothing: helm ran with --dry-run=client and the executor
@@ -493,7 +569,7 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 		if spinner != nil {
 			spinner.Stop()
 		}
-		pterm.Info.Println("Skipping release verification and deployment waits (dry-run)")
+		out.Info("Skipping release verification and deployment waits (dry-run)")
 		return nil
 	}
 
@@ -526,8 +602,8 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 		if ctx.Err() == context.Canceled {
 			return ctx.Err()
 		}
-		pterm.Warning.Println("Helm install reported success but ArgoCD deployments were not found")
-		pterm.Info.Println("This may indicate a Helm caching issue or cluster connectivity problem")
+		out.Warn("Helm install reported success but ArgoCD deployments were not found")
+		out.Info("This may indicate a Helm caching issue or cluster connectivity problem")
 		return fmt.Errorf("ArgoCD Helm install completed but deployments were not created: %w", err)
 	}
 
@@ -540,6 +616,7 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 
 // InstallAppOfAppsFromLocal installs the app-of-apps chart from a local path
 func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config config.ChartInstallConfig, certFile, keyFile string) error {
+	out := frontend.Current()
 	// Validate configuration
 	if config.AppOfApps == nil {
 		return fmt.Errorf("app-of-apps configuration is required")
@@ -552,7 +629,7 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 
 	// On Windows, validate WSL Ubuntu is accessible before proceeding
 	// This provides early, clear error messages instead of cryptic failures later
-	if runtime.GOOS == "windows" {
+	if platform.UsesWSL() {
 		if !executor.IsWSLAvailable() {
 			return fmt.Errorf("WSL is not available on this system. Helm requires WSL2 with Ubuntu to run on Windows.\n" +
 				"Please install WSL2: wsl --install")
@@ -566,7 +643,7 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 				"Check status with: wsl --list --verbose")
 		}
 		if h.verbose {
-			pterm.Debug.Println("WSL Ubuntu is accessible, proceeding with helm installation")
+			out.Debug("WSL Ubuntu is accessible, proceeding with helm installation")
 		}
 	}
 
@@ -582,12 +659,13 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 	certFilePath := certFile
 	keyFilePath := keyFile
 
-	if runtime.GOOS == "windows" {
+	if platform.UsesWSL() {
 		var err error
+		paths := wslpath.NewConverter(h.executor, h.verbose)
 
 		// Convert chart path
 		if chartPath != "" {
-			chartPath, err = h.convertWindowsPathToWSL(appConfig.ChartPath)
+			chartPath, err = paths.ToWSL(ctx, appConfig.ChartPath)
 			if err != nil {
 				return fmt.Errorf("failed to convert chart path for WSL: %w", err)
 			}
@@ -595,7 +673,7 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 
 		// Convert values file path
 		if valuesFilePath != "" {
-			valuesFilePath, err = h.convertWindowsPathToWSL(appConfig.ValuesFile)
+			valuesFilePath, err = paths.ToWSL(ctx, appConfig.ValuesFile)
 			if err != nil {
 				return fmt.Errorf("failed to convert values file path for WSL: %w", err)
 			}
@@ -603,14 +681,14 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 
 		// Convert certificate file paths
 		if certFile != "" {
-			certFilePath, err = h.convertWindowsPathToWSL(certFile)
+			certFilePath, err = paths.ToWSL(ctx, certFile)
 			if err != nil {
 				return fmt.Errorf("failed to convert cert file path for WSL: %w", err)
 			}
 		}
 
 		if keyFile != "" {
-			keyFilePath, err = h.convertWindowsPathToWSL(keyFile)
+			keyFilePath, err = paths.ToWSL(ctx, keyFile)
 			if err != nil {
 				return fmt.Errorf("failed to convert key file path for WSL: %w", err)
 			}
@@ -626,6 +704,21 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 		"-f", valuesFilePath,
 	}
 
+	// The sizing overlay goes after the values file so it wins over the
+	// chart defaults; the user's own values file is never modified.
+	overlayFile, err := writeSizingOverlay(config.Size)
+	if err != nil {
+		return err
+	}
+	if overlayFile != "" {
+		defer func() { _ = os.Remove(overlayFile) }()
+		overlayPath, err := h.helmPath(ctx, overlayFile)
+		if err != nil {
+			return fmt.Errorf("failed to convert sizing overlay path for WSL: %w", err)
+		}
+		args = append(args, "-f", overlayPath)
+	}
+
 	// Only add certificate files if they exist and are not empty paths
 	if certFile != "" && keyFile != "" {
 		// Check if files actually exist before adding them (use original Windows paths for os.Stat)
@@ -645,6 +738,7 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 	// --context wins over the cluster-derived one — F4 one-target rule)
 	if kubeContext := helmKubeContext(config); kubeContext != "" {
 		args = append(args, "--kube-context", kubeContext)
+		args = append(args, helmKubeconfigArgs(config)...)
 	}
 
 	if config.DryRun {
@@ -656,11 +750,11 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 	// default) and produces no output while it blocks. Without an indicator the
 	// CLI looks hung for the longest phase of an install — mirror the spinner
 	// InstallArgoCDWithProgress uses.
-	var spinner *uispinner.Spinner
+	var spinner frontend.Spinner
 	if !config.Silent && !config.NonInteractive {
-		spinner = uispinner.Start("Installing the OpenFrame app-of-apps chart...")
+		spinner = out.Spinner("Installing the OpenFrame app-of-apps chart...")
 	} else {
-		pterm.Info.Println("Installing the OpenFrame app-of-apps chart...")
+		out.Info("Installing the OpenFrame app-of-apps chart...")
 	}
 
 	// Execute helm command with local chart path. Like the ArgoCD install this
@@ -673,9 +767,11 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 			defer hb.Stop()
 		}
 		return h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
-			Command: "helm",
-			Args:    args,
-			Env:     h.getHelmEnv(),
+			Command:  "helm",
+			Args:     args,
+			Env:      h.getHelmEnv(),
+			Timeout:  waitTimeout(appConfig.Timeout),
+			OnOutput: h.outputRelay(spinner),
 		})
 	}()
 
@@ -728,6 +824,7 @@ func (h *HelmManager) GetChartStatus(ctx context.Context, releaseName, namespace
 		Command: "helm",
 		Args:    args,
 		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	})
 	if err != nil {
 		return models.ChartInfo{}, fmt.Errorf("failed to get status of release %s in namespace %s: %w", releaseName, namespace, err)
@@ -746,99 +843,3 @@ func (h *HelmManager) GetChartStatus(ctx context.Context, releaseName, namespace
 		AppVersion: meta.AppVersion,
 	}, nil
 }
-
-// convertWindowsPathToWSL converts a Windows path to a WSL path format
-// Example: C:\Users\foo\file.txt -> /mnt/c/Users/foo/file.txt
-// This is necessary when passing file paths from Windows to commands running in WSL2
-//
-// IMPORTANT: Uses `wsl wslpath` command for reliable conversion that handles:
-// - Windows 8.3 short filenames (e.g., RUNNER~1 -> runneradmin)
-// - Proper path escaping and special characters
-// Falls back to manual conversion if wslpath is not available.
-func (h *HelmManager) convertWindowsPathToWSL(windowsPath string) (string, error) {
-	if windowsPath == "" {
-		return "", fmt.Errorf("empty path provided")
-	}
-
-	// First, convert relative paths to absolute paths
-	// This is necessary because wslpath requires absolute paths and
-	// WSL won't be able to find files using Windows relative paths
-	absPath, err := filepath.Abs(windowsPath)
-	if err != nil {
-		// If we can't get absolute path, try with original
-		absPath = windowsPath
-	}
-
-	// Expand Windows 8.3 short filenames to long path names
-	// For example: C:\Users\RUNNER~1\... -> C:\Users\runneradmin\...
-	// This is critical because WSL doesn't understand Windows short filenames
-	expandedPath, err := expandShortPath(absPath)
-	if err == nil && expandedPath != "" {
-		absPath = expandedPath
-		if h.verbose {
-			pterm.Debug.Printf("Expanded short path: %s -> %s\n", windowsPath, absPath)
-		}
-	}
-
-	// Try using WSL's wslpath command for reliable conversion
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Convert backslashes to forward slashes before passing to wslpath
-	// This prevents backslashes from being interpreted as escape characters
-	// when WSL passes arguments to the Linux command
-	// wslpath accepts both forward and backward slashes
-	windowsPathForWSL := strings.ReplaceAll(absPath, "\\", "/")
-
-	// Must specify -d Ubuntu to use the correct distribution
-	// Without this, wsl may try to use a non-existent default distribution
-	result, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
-		Command: "wsl",
-		Args:    []string{"-d", "Ubuntu", "wslpath", "-u", windowsPathForWSL},
-	})
-
-	if err == nil && result != nil && result.ExitCode == 0 {
-		wslPath := strings.TrimSpace(result.Stdout)
-		if wslPath != "" {
-			if h.verbose {
-				pterm.Debug.Printf("Converted path via wslpath: %s -> %s\n", windowsPath, wslPath)
-			}
-			return wslPath, nil
-		}
-	}
-
-	// Log WSL errors for debugging
-	if err != nil {
-		// Check if this is a WSL-specific error
-		var wslErr *executor.WSLError
-		if stderrors.As(err, &wslErr) {
-			if h.verbose {
-				pterm.Warning.Printf("WSL error during path conversion: %s\n", wslErr.Error())
-				pterm.Info.Printf("Falling back to manual path conversion\n")
-			}
-		} else if h.verbose {
-			pterm.Debug.Printf("wslpath command failed: %v\n", err)
-		}
-	} else if result != nil && result.ExitCode != 0 {
-		if h.verbose {
-			pterm.Debug.Printf("wslpath returned exit code %d, stderr: %s\n", result.ExitCode, result.Stderr)
-		}
-	}
-
-	// Fallback to manual conversion if wslpath is not available
-	if h.verbose {
-		pterm.Debug.Printf("Using manual path conversion for: %s\n", absPath)
-	}
-
-	// Replace backslashes with forward slashes (use absPath which is already absolute)
-	path := strings.ReplaceAll(absPath, "\\", "/")
-
-	// Convert drive letter (e.g., C: -> /mnt/c)
-	if len(path) >= 2 && path[1] == ':' {
-		driveLetter := strings.ToLower(string(path[0]))
-		// Remove the drive letter and colon, then prepend /mnt/<drive>
-		path = "/mnt/" + driveLetter + path[2:]
-	}
-
-	return path, nil
-}
@@ -3,8 +3,10 @@ package helm
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 )
 
 func TestArgoCDInstallArgs(t *testing.T) {
@@ -56,3 +58,16 @@ func TestArgoCDInstallArgs_ClusterContextAndDryRun(t *testing.T) {
 		}
 	}
 }
+
+func TestWaitTimeout(t *testing.T) {
+	long := sharedconfig.Timeout(sharedconfig.LongRunning)
+	if got := waitTimeout(argoCDWait); got != long {
+		t.Errorf("waitTimeout(%s) = %s, want the long-running %s", argoCDWait, got, long)
+	}
+	if got := waitTimeout("60m"); got != 61*time.Minute {
+		t.Errorf("waitTimeout(60m) = %s, want a minute past helm's own", got)
+	}
+	if got := waitTimeout("soon"); got != long {
+		t.Errorf("waitTimeout(soon) = %s, want the long-running %s", got, long)
+	}
+}
@@ -0,0 +1,90 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
+)
+
+// helmPath returns p as the helm process sees it: unchanged, or converted to
+// a WSL path where helm runs inside WSL.
+func (h *HelmManager) helmPath(ctx context.Context, p string) (string, error) {
+	if p == "" || !platform.UsesWSL() {
+		return p, nil
+	}
+	return wslpath.NewConverter(h.executor, h.verbose).ToWSL(ctx, p)
+}
+
+// renderArgs builds the shared tail of `helm lint` / `helm template` for a
+// local chart: the chart path and values file, WSL-converted where needed.
+func (h *HelmManager) renderArgs(ctx context.Context, chartPath, valuesFile string) ([]string, error) {
+	chart, err := h.helmPath(ctx, chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert chart path for WSL: %w", err)
+	}
+	args := []string{chart}
+	if valuesFile != "" {
+		values, err := h.helmPath(ctx, valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert values file path for WSL: %w", err)
+		}
+		args = append(args, "-f", values)
+	}
+	return args, nil
+}
+
+// LintAppOfApps runs `helm lint` on a local app-of-apps chart with the given
+// values. The combined lint output is returned even when lint fails, since it
+// carries the [ERROR] lines worth reporting.
+func (h *HelmManager) LintAppOfApps(ctx context.Context, chartPath, valuesFile string) (string, error) {
+	args, err := h.renderArgs(ctx, chartPath, valuesFile)
+	if err != nil {
+		return "", err
+	}
+	result, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "helm",
+		Args:    append([]string{"lint"}, args...),
+		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	out := ""
+	if result != nil {
+		out = strings.TrimSpace(result.Stdout + "\n" + result.Stderr)
+	}
+	if err != nil {
+		return out, fmt.Errorf("helm lint failed: %w", err)
+	}
+	return out, nil
+}
+
+// TemplateAppOfApps renders a local app-of-apps chart with the given values,
+// exactly as `upgrade --install` would install it into namespace, without
+// contacting a cluster. It returns the rendered multi-document YAML.
+func (h *HelmManager) TemplateAppOfApps(ctx context.Context, chartPath, valuesFile, namespace string) (string, error) {
+	args, err := h.renderArgs(ctx, chartPath, valuesFile)
+	if err != nil {
+		return "", err
+	}
+	args = append([]string{"template", "app-of-apps"}, args...)
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	result, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "helm",
+		Args:    args,
+		Env:     h.getHelmEnv(),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return "", fmt.Errorf("helm template failed: %w\nHelm output: %s", err, strings.TrimSpace(result.Stderr))
+		}
+		return "", fmt.Errorf("helm template failed: %w", err)
+	}
+	return result.Stdout, nil
+}
@@ -0,0 +1,215 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/frontend"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
+)
+
+// argoRepoName is the local name of the ArgoCD chart repository.
+const argoRepoName = "argo"
+
+// defaultRepoCacheTTL is how long a downloaded repository index is trusted
+// before `helm repo update` refreshes it. The ArgoCD chart is pinned, so a
+// stale index only matters once the pin moves past it — which the check for
+// the pinned chart catches regardless of age.
+const defaultRepoCacheTTL = 24 * time.Hour
+
+// helmHome is where helm keeps its repository list and index cache between
+// runs; a variable so tests can point it elsewhere.
+var helmHome = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "helm"), nil
+}
+
+// repoCacheTTL reads helm.repoCacheTTL ("6h", "0s" = always update) from the
+// user config. A missing file or key, or an invalid value, is the default.
+func repoCacheTTL() time.Duration {
+	var cfg struct {
+		Helm struct {
+			RepoCacheTTL string `json:"repoCacheTTL"`
+		} `json:"helm"`
+	}
+	if sharedconfig.LoadUserConfig(&cfg) != nil || cfg.Helm.RepoCacheTTL == "" {
+		return defaultRepoCacheTTL
+	}
+	ttl, err := time.ParseDuration(cfg.Helm.RepoCacheTTL)
+	if err != nil || ttl < 0 {
+		frontend.Current().Warn("helm.repoCacheTTL in the CLI config must be a duration such as 6h, got %q; using %s", cfg.Helm.RepoCacheTTL, defaultRepoCacheTTL)
+		return defaultRepoCacheTTL
+	}
+	return ttl
+}
+
+// repoCache is what is known about a cached repository index.
+type repoCache int
+
+const (
+	// repoCacheMissing: no index, the repository must be added.
+	repoCacheMissing repoCache = iota
+	// repoCacheStale: an index that is older than the TTL or lacks the pinned
+	// chart.
+	repoCacheStale
+	// repoCacheFresh: an index within the TTL that lists the pinned chart.
+	repoCacheFresh
+)
+
+// checkRepoCache classifies the index at path for chart at version.
+func checkRepoCache(path, chart, version string, ttl time.Duration, now time.Time) (repoCache, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return repoCacheMissing, false
+	}
+	return classifyRepoCache(now.Sub(info.ModTime()), indexHasChart(path, chart, version), ttl)
+}
+
+// classifyRepoCache classifies an index of the given age that does or does
+// not list the pinned chart.
+func classifyRepoCache(age time.Duration, hasPinned bool, ttl time.Duration) (repoCache, bool) {
+	if hasPinned && age < ttl {
+		return repoCacheFresh, true
+	}
+	return repoCacheStale, hasPinned
+}
+
+// checkWSLRepoCache is checkRepoCache for helm running in WSL, whose cache is
+// only readable there: the age and the pinned chart are looked up by
+// scripts.RepoIndexWSL. Not reaching WSL counts as no index.
+func (h *HelmManager) checkWSLRepoCache(ctx context.Context, path, chart, version string, ttl time.Duration) (repoCache, bool) {
+	script := scripts.MustRender(scripts.RepoIndexWSL, nil)
+	result, err := h.executor.ExecuteWithOptions(ctx, executor.WSLShellScript(wslpath.DistroArgs(), script, path, chart+"-"+version+".tgz"))
+	if err != nil || result == nil {
+		return repoCacheMissing, false
+	}
+	fields := strings.Fields(result.Stdout)
+	if len(fields) == 0 {
+		return repoCacheMissing, false
+	}
+	seconds, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return repoCacheMissing, false
+	}
+	return classifyRepoCache(time.Duration(seconds)*time.Second, len(fields) > 1 && fields[1] == "pinned", ttl)
+}
+
+// indexHasChart reports whether the index lists chart at version. Chart
+// archives are named <chart>-<version>.tgz, which avoids parsing an index
+// that runs to megabytes.
+func indexHasChart(path, chart, version string) bool {
+	data, err := os.ReadFile(path) // #nosec G304 -- helm's own cache under helmHome
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(data, []byte(chart+"-"+version+".tgz"))
+}
+
+// helmInWSL reports whether helm runs in WSL, where its cache is read through
+// wsl.exe; a variable so tests can take that path off Windows.
+var helmInWSL = platform.UsesWSL
+
+// argoRepoCache classifies the cached index of the ArgoCD repository under
+// env's cache home, wherever helm runs.
+func (h *HelmManager) argoRepoCache(ctx context.Context, env map[string]string) (repoCache, bool) {
+	const chart = "argo-cd"
+	ttl := repoCacheTTL()
+	if helmInWSL() {
+		index := path.Join(env["HELM_CACHE_HOME"], "repository", argoRepoName+"-index.yaml")
+		return h.checkWSLRepoCache(ctx, index, chart, argocd.ArgoCDChartVersion, ttl)
+	}
+	index := filepath.Join(env["HELM_CACHE_HOME"], "repository", argoRepoName+"-index.yaml")
+	return checkRepoCache(index, chart, argocd.ArgoCDChartVersion, ttl, time.Now())
+}
+
+// ensureArgoRepo makes the ArgoCD chart repository available to helm,
+// touching the network only when it must: a repository never added is added
+// (which downloads its index), a cached index within the TTL that lists the
+// pinned chart is used as is, and anything else is refreshed. A refresh that
+// fails — typically offline — falls back to a cached index that still lists
+// the pinned chart. skipUpdate (--skip-repo-update) never refreshes.
+func (h *HelmManager) ensureArgoRepo(ctx context.Context, skipUpdate bool) error {
+	env := h.getHelmEnv()
+	state, hasPinned := h.argoRepoCache(ctx, env)
+
+	// helm records a repository's CA file when it is added, and `helm repo
+	// update` takes none: with --ca-bundle a refresh re-adds the repository.
+	caArgs := h.caFileArgs(ctx)
+	if len(caArgs) > 0 {
+		caArgs = append(caArgs, "--force-update")
+		if state == repoCacheStale && !skipUpdate {
+			state = repoCacheMissing
+		}
+	}
+
+	if state == repoCacheMissing {
+		_, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "helm",
+			Args:    append([]string{"repo", "add", argoRepoName, argocd.ArgoHelmRepoURL}, caArgs...),
+			Env:     env,
+			Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+		})
+		if err == nil {
+			return nil
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to add ArgoCD repository: %w", err)
+		}
+		// Known to helm but its index is gone: there is nothing to skip to.
+		skipUpdate = false
+	}
+	if state == repoCacheFresh {
+		frontend.Current().Debug("Using the cached ArgoCD chart repository index")
+		return nil
+	}
+	if skipUpdate {
+		frontend.Current().Debug("Skipping the Helm repository update (--skip-repo-update)")
+		return nil
+	}
+
+	_, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "helm",
+		Args:    []string{"repo", "update", argoRepoName},
+		Env:     env,
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+	})
+	if err != nil {
+		if hasPinned && !errors.Is(err, context.Canceled) {
+			frontend.Current().Warn("Could not update the Helm repository index, using the cached one: %v", err)
+			return nil
+		}
+		return fmt.Errorf("failed to update Helm repositories: %w", err)
+	}
+	return nil
+}
+
+// caFileArgs passes --ca-bundle to helm as --ca-file, as a WSL path when helm
+// runs in WSL; nil without a bundle.
+func (h *HelmManager) caFileArgs(ctx context.Context) []string {
+	path := sharedconfig.CABundle()
+	if path == "" {
+		return nil
+	}
+	if platform.UsesWSL() {
+		if converted, err := wslpath.NewConverter(h.executor, h.verbose).ToWSL(ctx, path); err == nil {
+			path = converted
+		}
+	}
+	return []string{"--ca-file", path}
+}
@@ -0,0 +1,133 @@
+package helm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pinnedIndex = "entries:\n  argo-cd:\n    - urls:\n        - https://github.com/argoproj/argo-helm/releases/download/argo-cd-" +
+	argocd.ArgoCDChartVersion + "/argo-cd-" + argocd.ArgoCDChartVersion + ".tgz\n"
+
+// isolateHelmHome points the helm home and user config at a temp dir and
+// returns where the ArgoCD index is cached.
+func isolateHelmHome(t *testing.T, config string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("helm runs in WSL on Windows; its cache is not read from here")
+	}
+	dir := t.TempDir()
+	origHome, origConfig := helmHome, sharedconfig.UserConfigFile
+	helmHome = func() (string, error) { return filepath.Join(dir, "helm"), nil }
+	sharedconfig.UserConfigFile = func() (string, error) { return filepath.Join(dir, "config.json"), nil }
+	t.Cleanup(func() { helmHome, sharedconfig.UserConfigFile = origHome, origConfig })
+	if config != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0o600))
+	}
+	return filepath.Join(dir, "helm", "cache", "repository", "argo-index.yaml")
+}
+
+func writeIndex(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	stamp := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, stamp, stamp))
+}
+
+func TestRepoCacheTTL(t *testing.T) {
+	isolateHelmHome(t, "")
+	assert.Equal(t, defaultRepoCacheTTL, repoCacheTTL(), "no config")
+
+	isolateHelmHome(t, `{"helm": {"repoCacheTTL": "90m"}}`)
+	assert.Equal(t, 90*time.Minute, repoCacheTTL())
+
+	isolateHelmHome(t, `{"helm": {"repoCacheTTL": "soon"}}`)
+	assert.Equal(t, defaultRepoCacheTTL, repoCacheTTL(), "an invalid TTL is ignored")
+}
+
+func TestEnsureArgoRepo(t *testing.T) {
+	tests := []struct {
+		name       string
+		index      string
+		age        time.Duration
+		skipUpdate bool
+		updateFail bool
+		want       []string
+		wantErr    bool
+	}{
+		{name: "no cache: added", want: []string{"helm repo add argo " + argocd.ArgoHelmRepoURL}},
+		{name: "fresh cache: no network", index: pinnedIndex, age: time.Hour},
+		{name: "expired cache: updated", index: pinnedIndex, age: 48 * time.Hour, want: []string{"helm repo update argo"}},
+		{name: "pinned chart missing: updated", index: "entries: {}\n", age: time.Hour, want: []string{"helm repo update argo"}},
+		{name: "--skip-repo-update: expired cache used", index: pinnedIndex, age: 48 * time.Hour, skipUpdate: true},
+		{name: "offline: expired cache used", index: pinnedIndex, age: 48 * time.Hour, updateFail: true, want: []string{"helm repo update argo"}},
+		{name: "offline without the pinned chart: error", index: "entries: {}\n", age: time.Hour, updateFail: true, want: []string{"helm repo update argo"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := isolateHelmHome(t, "")
+			if tt.index != "" {
+				writeIndex(t, path, tt.index, tt.age)
+			}
+			mock := executor.NewMockCommandExecutor()
+			if tt.updateFail {
+				mock.SetResponse("helm repo update", &executor.CommandResult{ExitCode: 1, Stderr: "no such host"})
+			}
+			m := &HelmManager{executor: mock}
+
+			err := m.ensureArgoRepo(context.Background(), tt.skipUpdate)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.want, mock.GetExecutedCommands())
+		})
+	}
+}
+
+// TestEnsureArgoRepo_WSL checks the index helm caches in WSL is refreshed on
+// the same schedule, read through wsl.exe since it is not readable from here.
+func TestEnsureArgoRepo_WSL(t *testing.T) {
+	tests := []struct {
+		name  string
+		index string // what scripts.RepoIndexWSL prints
+		want  []string
+	}{
+		{name: "no cache: added", want: []string{"helm repo add argo " + argocd.ArgoHelmRepoURL}},
+		{name: "fresh cache: no network", index: "3600\npinned\n"},
+		{name: "expired cache: updated", index: "172800\npinned\n", want: []string{"helm repo update argo"}},
+		{name: "pinned chart missing: updated", index: "3600\n", want: []string{"helm repo update argo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateHelmHome(t, "")
+			orig := helmInWSL
+			helmInWSL = func() bool { return true }
+			t.Cleanup(func() { helmInWSL = orig })
+			mock := executor.NewMockCommandExecutor()
+			mock.SetResponse("wsl", &executor.CommandResult{Stdout: tt.index})
+			m := &HelmManager{executor: mock}
+
+			require.NoError(t, m.ensureArgoRepo(context.Background(), false))
+			var helm []string
+			for _, cmd := range mock.GetExecutedCommands() {
+				if strings.HasPrefix(cmd, "helm ") {
+					helm = append(helm, cmd)
+				}
+			}
+			assert.ElementsMatch(t, tt.want, helm)
+		})
+	}
+}
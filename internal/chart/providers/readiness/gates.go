@@ -0,0 +1,344 @@
+// Package readiness checks the extra readiness gates an install waits for
+// after every ArgoCD application is Healthy and Synced: a Job that must
+// complete, a URL that must answer, a custom resource that must reach a
+// phase. Healthy applications only mean the workloads are up; gates are how an
+// operator says what "OpenFrame is usable" means for their deployment.
+//
+// Gates are read from openframe-readiness-gates.yaml next to the helm values
+// (or --readiness-gates):
+//
+//	timeout: 10m
+//	gates:
+//	  - name: database migrations
+//	    job: {namespace: openframe, name: db-migrate}
+//	  - name: API
+//	    http: {url: https://localhost/api/health}
+//	  - name: tenant
+//	    resource:
+//	      apiVersion: openframe.io/v1
+//	      kind: Tenant
+//	      namespace: openframe
+//	      name: default
+//	      field: status.phase
+//	      value: Ready
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// DefaultFile is the gates file read from the working directory when
+// --readiness-gates is not given.
+const DefaultFile = "openframe-readiness-gates.yaml"
+
+const (
+	defaultTimeout = 10 * time.Minute
+	pollInterval   = 5 * time.Second
+	httpTimeout    = 10 * time.Second
+)
+
+var jobGVR = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+// Config is a parsed gates file.
+type Config struct {
+	// Timeout bounds the wait for all gates together (default 10m).
+	Timeout time.Duration `yaml:"timeout"`
+	Gates   []Gate        `yaml:"gates"`
+}
+
+// Gate is one readiness condition. Exactly one of Job, HTTP and Resource is set.
+type Gate struct {
+	Name     string        `yaml:"name"`
+	Job      *JobGate      `yaml:"job,omitempty"`
+	HTTP     *HTTPGate     `yaml:"http,omitempty"`
+	Resource *ResourceGate `yaml:"resource,omitempty"`
+}
+
+// JobGate passes once the Job has a succeeded pod. A Job that reports Failed
+// fails the wait immediately: it will not complete by waiting longer.
+type JobGate struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+}
+
+// HTTPGate passes once a GET of URL returns Status (default 200).
+type HTTPGate struct {
+	URL    string `yaml:"url"`
+	Status int    `yaml:"status,omitempty"`
+}
+
+// ResourceGate passes once the object's Field (a dotted path such as
+// status.phase) equals Value.
+type ResourceGate struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Namespace  string `yaml:"namespace,omitempty"`
+	Name       string `yaml:"name"`
+	Field      string `yaml:"field"`
+	Value      string `yaml:"value"`
+}
+
+// Load reads and validates the gates file at path. A missing file is no gates
+// (nil, nil); a file that exists but does not parse is an error.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: gates file chosen by the operator
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading readiness gates %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("readiness gates %s is not valid YAML: %w", path, err)
+	}
+	for i, g := range cfg.Gates {
+		if err := g.validate(); err != nil {
+			return nil, fmt.Errorf("readiness gates %s: gate %d: %w", path, i+1, err)
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &cfg, nil
+}
+
+func (g Gate) validate() error {
+	set := 0
+	if g.Job != nil {
+		set++
+		if g.Job.Namespace == "" || g.Job.Name == "" {
+			return fmt.Errorf("job needs a namespace and a name")
+		}
+	}
+	if g.HTTP != nil {
+		set++
+		if !strings.HasPrefix(g.HTTP.URL, "http://") && !strings.HasPrefix(g.HTTP.URL, "https://") {
+			return fmt.Errorf("http needs an http(s) url, got %q", g.HTTP.URL)
+		}
+	}
+	if g.Resource != nil {
+		set++
+		r := g.Resource
+		if r.APIVersion == "" || r.Kind == "" || r.Name == "" || r.Field == "" {
+			return fmt.Errorf("resource needs apiVersion, kind, name and field")
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("set exactly one of job, http and resource")
+	}
+	return nil
+}
+
+// Label is the gate's name, or a description of what it checks.
+func (g Gate) Label() string {
+	switch {
+	case g.Name != "":
+		return g.Name
+	case g.Job != nil:
+		return "job " + g.Job.Namespace + "/" + g.Job.Name
+	case g.HTTP != nil:
+		return "GET " + g.HTTP.URL
+	default:
+		return g.Resource.Kind + " " + g.Resource.Name
+	}
+}
+
+// errFatal marks a gate that cannot pass by waiting longer.
+var errFatal = errors.New("will not become ready")
+
+// Checker evaluates gates against a cluster.
+type Checker struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+	http   *http.Client
+}
+
+// NewChecker returns a Checker for the cluster behind cfg.
+func NewChecker(cfg *rest.Config) (*Checker, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("readiness gates need a resolved cluster connection")
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating discovery client: %w", err)
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+	return NewCheckerWith(client, mapper, &http.Client{Timeout: httpTimeout}), nil
+}
+
+// NewCheckerWith builds a Checker from existing clients.
+func NewCheckerWith(client dynamic.Interface, mapper meta.RESTMapper, httpClient *http.Client) *Checker {
+	return &Checker{client: client, mapper: mapper, http: httpClient}
+}
+
+// Check evaluates one gate once. It returns whether the gate passed and, when
+// not, why. A non-nil error means the gate failed for good.
+func (c *Checker) Check(ctx context.Context, g Gate) (bool, string, error) {
+	switch {
+	case g.Job != nil:
+		return c.checkJob(ctx, g.Job)
+	case g.HTTP != nil:
+		return c.checkHTTP(ctx, g.HTTP)
+	case g.Resource != nil:
+		return c.checkResource(ctx, g.Resource)
+	}
+	return false, "", fmt.Errorf("gate %q checks nothing: %w", g.Label(), errFatal)
+}
+
+func (c *Checker) checkJob(ctx context.Context, j *JobGate) (bool, string, error) {
+	job, err := c.client.Resource(jobGVR).Namespace(j.Namespace).Get(ctx, j.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	if succeeded, _, _ := unstructured.NestedInt64(job.Object, "status", "succeeded"); succeeded > 0 {
+		return true, "", nil
+	}
+	conds, _, _ := unstructured.NestedSlice(job.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if ok && m["type"] == "Failed" && m["status"] == "True" {
+			msg, _ := m["message"].(string)
+			return false, msg, fmt.Errorf("job %s/%s failed (%s): %w", j.Namespace, j.Name, msg, errFatal)
+		}
+	}
+	active, _, _ := unstructured.NestedInt64(job.Object, "status", "active")
+	return false, fmt.Sprintf("%d pod(s) running", active), nil
+}
+
+func (c *Checker) checkHTTP(ctx context.Context, h *HTTPGate) (bool, string, error) {
+	want := h.Status
+	if want == 0 {
+		want = http.StatusOK
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return false, "", fmt.Errorf("%s: %v: %w", h.URL, err, errFatal)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != want {
+		return false, fmt.Sprintf("HTTP %d, want %d", resp.StatusCode, want), nil
+	}
+	return true, "", nil
+}
+
+func (c *Checker) checkResource(ctx context.Context, r *ResourceGate) (bool, string, error) {
+	gv, err := schema.ParseGroupVersion(r.APIVersion)
+	if err != nil {
+		return false, "", fmt.Errorf("apiVersion %q: %v: %w", r.APIVersion, err, errFatal)
+	}
+	// The CRD may arrive late in the sync; an unknown kind is "not yet".
+	m, err := c.mapper.RESTMapping(gv.WithKind(r.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		if rm, ok := c.mapper.(meta.ResettableRESTMapper); ok {
+			rm.Reset()
+		}
+		return false, err.Error(), nil
+	}
+	res := c.client.Resource(m.Resource)
+	var obj *unstructured.Unstructured
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		obj, err = res.Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{})
+	} else {
+		obj, err = res.Get(ctx, r.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	got, found, _ := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(r.Field, ".")...)
+	if !found {
+		return false, r.Field + " not set", nil
+	}
+	if v := fmt.Sprint(got); v != r.Value {
+		return false, fmt.Sprintf("%s is %q, want %q", r.Field, v, r.Value), nil
+	}
+	return true, "", nil
+}
+
+// Status is a gate's last observed state.
+type Status struct {
+	Gate   string
+	Ready  bool
+	Detail string
+}
+
+// Wait polls every gate until all pass, one fails for good, or cfg.Timeout
+// elapses. progress, when set, is called after each round.
+func (c *Checker) Wait(ctx context.Context, cfg *Config, progress func([]Status)) error {
+	if cfg == nil || len(cfg.Gates) == 0 {
+		return nil
+	}
+	statuses := make([]Status, len(cfg.Gates))
+	for i, g := range cfg.Gates {
+		statuses[i] = Status{Gate: g.Label(), Detail: "not checked yet"}
+	}
+	var fatal error
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, cfg.Timeout, true, func(ctx context.Context) (bool, error) {
+		all := true
+		for i, g := range cfg.Gates {
+			if statuses[i].Ready {
+				continue
+			}
+			ok, detail, err := c.Check(ctx, g)
+			if err != nil {
+				fatal = fmt.Errorf("readiness gate %q: %w", g.Label(), err)
+				return false, fatal
+			}
+			statuses[i] = Status{Gate: g.Label(), Ready: ok, Detail: detail}
+			all = all && ok
+		}
+		if progress != nil {
+			progress(statuses)
+		}
+		return all, nil
+	})
+	if fatal != nil {
+		return fatal
+	}
+	if err != nil {
+		return fmt.Errorf("readiness gates not passed after %s: %s", cfg.Timeout, Pending(statuses))
+	}
+	return nil
+}
+
+// Pending describes the gates that have not passed.
+func Pending(statuses []Status) string {
+	var parts []string
+	for _, s := range statuses {
+		if s.Ready {
+			continue
+		}
+		p := s.Gate
+		if s.Detail != "" {
+			p += " (" + s.Detail + ")"
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, "; ")
+}
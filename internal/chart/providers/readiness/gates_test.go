@@ -0,0 +1,156 @@
+package readiness
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func writeGates(t *testing.T, body string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), DefaultFile)
+	require.NoError(t, os.WriteFile(p, []byte(body), 0o600))
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, cfg, "a missing file is no gates")
+
+	cfg, err = Load(writeGates(t, `
+timeout: 2m
+gates:
+  - name: migrations
+    job: {namespace: openframe, name: db-migrate}
+  - http: {url: "https://localhost/api/health"}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, cfg.Timeout)
+	require.Len(t, cfg.Gates, 2)
+	assert.Equal(t, "migrations", cfg.Gates[0].Label())
+	assert.Equal(t, "GET https://localhost/api/health", cfg.Gates[1].Label())
+
+	cfg, err = Load(writeGates(t, "gates:\n  - job: {namespace: a, name: b}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, defaultTimeout, cfg.Timeout)
+
+	_, err = Load(writeGates(t, "gates:\n  - job: {namespace: a, name: b}\n    http: {url: http://x}\n"))
+	assert.ErrorContains(t, err, "exactly one")
+	_, err = Load(writeGates(t, "gates:\n  - http: {url: localhost}\n"))
+	assert.ErrorContains(t, err, "http(s) url")
+}
+
+func job(name string, status map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]any{"name": name, "namespace": "openframe"},
+		"status":     status,
+	}}
+}
+
+func newTestChecker(objs ...runtime.Object) *Checker {
+	tenantGVK := schema.GroupVersionKind{Group: "openframe.io", Version: "v1", Kind: "Tenant"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(tenantGVK, meta.RESTScopeNamespace)
+	listKinds := map[schema.GroupVersionResource]string{
+		jobGVR: "JobList",
+		{Group: "openframe.io", Version: "v1", Resource: "tenants"}: "TenantList",
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objs...)
+	return NewCheckerWith(client, mapper, http.DefaultClient)
+}
+
+func TestCheck_Job(t *testing.T) {
+	c := newTestChecker(
+		job("done", map[string]any{"succeeded": int64(1)}),
+		job("running", map[string]any{"active": int64(1)}),
+		job("failed", map[string]any{"conditions": []any{
+			map[string]any{"type": "Failed", "status": "True", "message": "BackoffLimitExceeded"},
+		}}),
+	)
+	ctx := context.Background()
+
+	ok, _, err := c.Check(ctx, Gate{Job: &JobGate{Namespace: "openframe", Name: "done"}})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, detail, err := c.Check(ctx, Gate{Job: &JobGate{Namespace: "openframe", Name: "running"}})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "1 pod(s) running", detail)
+
+	_, _, err = c.Check(ctx, Gate{Job: &JobGate{Namespace: "openframe", Name: "failed"}})
+	assert.ErrorIs(t, err, errFatal, "a failed Job ends the wait")
+
+	ok, _, err = c.Check(ctx, Gate{Job: &JobGate{Namespace: "openframe", Name: "absent"}})
+	require.NoError(t, err, "a Job not created yet is only pending")
+	assert.False(t, ok)
+}
+
+func TestCheck_HTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ready" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c := newTestChecker()
+
+	ok, _, err := c.Check(context.Background(), Gate{HTTP: &HTTPGate{URL: srv.URL + "/ready"}})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, detail, err := c.Check(context.Background(), Gate{HTTP: &HTTPGate{URL: srv.URL + "/starting"}})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "HTTP 503, want 200", detail)
+}
+
+func TestCheck_Resource(t *testing.T) {
+	tenant := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "openframe.io/v1",
+		"kind":       "Tenant",
+		"metadata":   map[string]any{"name": "default", "namespace": "openframe"},
+		"status":     map[string]any{"phase": "Provisioning"},
+	}}
+	c := newTestChecker(tenant)
+	gate := Gate{Resource: &ResourceGate{
+		APIVersion: "openframe.io/v1", Kind: "Tenant", Namespace: "openframe", Name: "default",
+		Field: "status.phase", Value: "Ready",
+	}}
+
+	ok, detail, err := c.Check(context.Background(), gate)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, `status.phase is "Provisioning", want "Ready"`, detail)
+
+	gate.Resource.Value = "Provisioning"
+	ok, _, err = c.Check(context.Background(), gate)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestWait_ReportsPendingGates(t *testing.T) {
+	c := newTestChecker(job("running", map[string]any{"active": int64(2)}))
+	cfg := &Config{Timeout: 10 * time.Millisecond, Gates: []Gate{
+		{Name: "migrations", Job: &JobGate{Namespace: "openframe", Name: "running"}},
+	}}
+	err := c.Wait(context.Background(), cfg, nil)
+	assert.ErrorContains(t, err, "migrations (2 pod(s) running)")
+}
@@ -0,0 +1,153 @@
+// Package registry distributes private container-registry pull credentials
+// into cluster namespaces, so workloads pulling from authenticated mirrors
+// (air-gapped or enterprise registries) start without manual secret wiring.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Injector creates the dockerconfigjson pull secret in namespaces and attaches
+// it to their service accounts via the native client.
+type Injector struct {
+	client kubernetes.Interface
+	auth   *models.RegistryAuthConfig
+}
+
+// NewInjector returns an injector for the given credentials.
+func NewInjector(client kubernetes.Interface, auth *models.RegistryAuthConfig) *Injector {
+	return &Injector{client: client, auth: auth}
+}
+
+// Inject makes the pull secret available in every namespace: the namespace is
+// created if missing, the secret is server-side applied, and every service
+// account in it (including "default", created here if the controller has not
+// yet) gets the secret in its imagePullSecrets. Duplicate and empty names are
+// ignored. It returns the namespaces it touched.
+func (i *Injector) Inject(ctx context.Context, namespaces []string) ([]string, error) {
+	if i.auth.IsEmpty() {
+		return nil, nil
+	}
+	payload, err := i.auth.DockerConfigJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encoding registry credentials: %w", err)
+	}
+
+	done := make([]string, 0, len(namespaces))
+	for _, ns := range uniqueNamespaces(namespaces) {
+		if err := i.injectNamespace(ctx, ns, payload); err != nil {
+			return done, fmt.Errorf("namespace %s: %w", ns, err)
+		}
+		done = append(done, ns)
+	}
+	return done, nil
+}
+
+func (i *Injector) injectNamespace(ctx context.Context, ns string, payload []byte) error {
+	if err := i.ensureNamespace(ctx, ns); err != nil {
+		return err
+	}
+	if err := i.applySecret(ctx, ns, payload); err != nil {
+		return err
+	}
+	if err := i.ensureDefaultServiceAccount(ctx, ns); err != nil {
+		return err
+	}
+
+	sas, err := i.client.CoreV1().ServiceAccounts(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing service accounts: %w", err)
+	}
+	for idx := range sas.Items {
+		if err := i.attachToServiceAccount(ctx, &sas.Items[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Injector) ensureNamespace(ctx context.Context, ns string) error {
+	_, err := i.client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ns},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating namespace: %w", err)
+	}
+	return nil
+}
+
+func (i *Injector) applySecret(ctx context.Context, ns string, payload []byte) error {
+	want := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      models.RegistryPullSecretName,
+			Namespace: ns,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "openframe-cli"},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: payload},
+	}
+	if _, err := k8s.ApplySecret(ctx, i.client, want); err != nil {
+		return fmt.Errorf("applying pull secret: %w", err)
+	}
+	return nil
+}
+
+// ensureDefaultServiceAccount creates "default" when the namespace is brand new
+// and the service-account controller has not caught up yet, so pods created
+// right after (e.g. by an ArgoCD sync) already see the pull secret.
+func (i *Injector) ensureDefaultServiceAccount(ctx context.Context, ns string) error {
+	_, err := i.client.CoreV1().ServiceAccounts(ns).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: ns},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating default service account: %w", err)
+	}
+	return nil
+}
+
+// attachToServiceAccount adds the pull secret to sa's imagePullSecrets with a
+// merge patch, leaving any secrets already listed in place.
+func (i *Injector) attachToServiceAccount(ctx context.Context, sa *corev1.ServiceAccount) error {
+	refs := sa.ImagePullSecrets
+	for _, ref := range refs {
+		if ref.Name == models.RegistryPullSecretName {
+			return nil
+		}
+	}
+	refs = append(append([]corev1.LocalObjectReference{}, refs...), corev1.LocalObjectReference{Name: models.RegistryPullSecretName})
+	patch, err := json.Marshal(map[string]any{"imagePullSecrets": refs})
+	if err != nil {
+		return err
+	}
+	if _, err := i.client.CoreV1().ServiceAccounts(sa.Namespace).Patch(ctx, sa.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("patching service account %s: %w", sa.Name, err)
+	}
+	return nil
+}
+
+// uniqueNamespaces drops empty and duplicate names and sorts the rest, so the
+// injection order (and its output) is deterministic.
+func uniqueNamespaces(namespaces []string) []string {
+	seen := make(map[string]bool, len(namespaces))
+	out := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		out = append(out, ns)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testAuth() *models.RegistryAuthConfig {
+	return &models.RegistryAuthConfig{Registries: []models.RegistryCredential{
+		{Host: "registry.example.com", Username: "robot", Password: "pw"},
+	}}
+}
+
+func TestInjector_CreatesSecretAndPatchesServiceAccounts(t *testing.T) {
+	existing := &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "app", Namespace: "platform"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "keep-me"}},
+	}
+	client := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}}, existing)
+	ctx := context.Background()
+
+	done, err := NewInjector(client, testAuth()).Inject(ctx, []string{"platform", "fresh", "platform", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fresh", "platform"}, done)
+
+	for _, ns := range done {
+		secret, err := client.CoreV1().Secrets(ns).Get(ctx, models.RegistryPullSecretName, metav1.GetOptions{})
+		require.NoErrorf(t, err, "pull secret missing in %s", ns)
+		assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+		assert.Contains(t, string(secret.Data[corev1.DockerConfigJsonKey]), "registry.example.com")
+
+		def, err := client.CoreV1().ServiceAccounts(ns).Get(ctx, "default", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, def.ImagePullSecrets, corev1.LocalObjectReference{Name: models.RegistryPullSecretName})
+	}
+
+	app, err := client.CoreV1().ServiceAccounts("platform").Get(ctx, "app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "keep-me"}, {Name: models.RegistryPullSecretName}},
+		app.ImagePullSecrets, "existing pull secrets must be kept")
+}
+
+func TestInjector_IsIdempotent(t *testing.T) {
+	client := fake.NewClientset()
+	inj := NewInjector(client, testAuth())
+	ctx := context.Background()
+
+	_, err := inj.Inject(ctx, []string{"platform"})
+	require.NoError(t, err)
+	_, err = inj.Inject(ctx, []string{"platform"})
+	require.NoError(t, err)
+
+	sa, err := client.CoreV1().ServiceAccounts("platform").Get(ctx, "default", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, sa.ImagePullSecrets, 1, "re-running must not duplicate the reference")
+}
+
+func TestInjector_NoCredentialsIsNoOp(t *testing.T) {
+	client := fake.NewClientset()
+	done, err := NewInjector(client, nil).Inject(context.Background(), []string{"platform"})
+	require.NoError(t, err)
+	assert.Empty(t, done)
+
+	list, _ := client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	assert.Empty(t, list.Items, "nothing may be created without credentials")
+}
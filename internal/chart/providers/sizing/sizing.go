@@ -0,0 +1,199 @@
+// Package sizing scales the ArgoCD and app-of-apps values to the machine the
+// cluster runs on. A laptop with 8GB for WSL cannot hold the default replica
+// counts and requests: pods sit Pending, the repo-server is OOM-killed, and the
+// install times out without saying why. The host is classified as small,
+// medium or large (--size overrides the detection) and each class maps to a
+// values overlay; large is the unmodified baseline.
+package sizing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/dockerdesktop"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslconfig"
+)
+
+// Sizes, smallest first. Auto means detect from the host.
+const (
+	Auto   = "auto"
+	Small  = "small"
+	Medium = "medium"
+	Large  = "large"
+)
+
+// Sizes lists the accepted --size values.
+var Sizes = []string{Auto, Small, Medium, Large}
+
+// Thresholds: a host below the small limits is small, below the medium
+// limits medium.
+const (
+	smallMaxMemoryMB  = 12 * 1024
+	smallMaxCPUs      = 4
+	mediumMaxMemoryMB = 24 * 1024
+	mediumMaxCPUs     = 8
+)
+
+// ParseSize validates a --size value; empty is Auto.
+func ParseSize(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Auto, nil
+	}
+	for _, v := range Sizes {
+		if s == v {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown size %q: want %s", s, strings.Join(Sizes, "|"))
+}
+
+// Host is the capacity available to the cluster.
+type Host struct {
+	MemoryMB int
+	CPUs     int
+	// Source says where the numbers come from, e.g. ".wslconfig".
+	Source string
+}
+
+// DetectHost reads the host's memory and CPUs. Under Windows the cluster runs
+// in the WSL2 VM, so the .wslconfig limits (or WSL's defaults: half the
+// memory, every CPU) apply instead of the machine's; on macOS it runs in
+// Docker Desktop's VM, whose limits `docker info` reports.
+func DetectHost() Host {
+	mem, cpus := wslconfig.Host()
+	h := Host{MemoryMB: mem, CPUs: cpus, Source: "host"}
+	if platform.UsesWSL() {
+		path, _ := wslconfig.Path()
+		h = wslLimits(h, path)
+	}
+	if vm, ok := dockerdesktop.Detect(context.Background()); ok {
+		h = Host{MemoryMB: vm.MemoryMB, CPUs: vm.CPUs, Source: "Docker Desktop"}
+	}
+	return h
+}
+
+// Requirements is the least a host of size must give the cluster: below it,
+// pods of that size are OOM-killed or stay Pending. Large is a full install,
+// as the WSL limits are raised for.
+func Requirements(size string) Host {
+	switch size {
+	case Small:
+		return Host{MemoryMB: 6 * 1024, CPUs: 2}
+	case Medium:
+		return Host{MemoryMB: smallMaxMemoryMB, CPUs: smallMaxCPUs}
+	}
+	return Host{MemoryMB: wslconfig.ClusterRequirements.MemoryMB, CPUs: wslconfig.ClusterRequirements.Processors}
+}
+
+// Shortfall says how h falls short of the Requirements of size, e.g.
+// "3.8 GB memory (needs 12.0 GB)"; "" when it does not, or is unknown.
+func Shortfall(h Host, size string) string {
+	need := Requirements(size)
+	var short []string
+	if h.MemoryMB > 0 && h.MemoryMB < need.MemoryMB {
+		short = append(short, fmt.Sprintf("%.1f GB memory (needs %.1f GB)", float64(h.MemoryMB)/1024, float64(need.MemoryMB)/1024))
+	}
+	if h.CPUs > 0 && h.CPUs < need.CPUs {
+		short = append(short, fmt.Sprintf("%d CPUs (needs %d)", h.CPUs, need.CPUs))
+	}
+	return strings.Join(short, " and ")
+}
+
+// wslLimits applies the WSL2 VM's limits in the .wslconfig at path to the
+// Windows host's capacity.
+func wslLimits(h Host, path string) Host {
+	var set wslconfig.Limits
+	if path != "" {
+		if f, err := wslconfig.Load(path); err == nil {
+			set = f.Limits()
+		}
+	}
+	eff := set.Effective(h.MemoryMB, h.CPUs)
+	source := "WSL defaults"
+	if set.MemoryMB > 0 || set.Processors > 0 {
+		source = ".wslconfig"
+	}
+	return Host{MemoryMB: eff.MemoryMB, CPUs: eff.Processors, Source: source}
+}
+
+// Classify maps a host to a size. Unknown memory counts as large: sizing down
+// on a detection failure would shrink a perfectly good machine.
+func Classify(h Host) string {
+	switch {
+	case h.MemoryMB <= 0:
+		return Large
+	case h.MemoryMB < smallMaxMemoryMB || h.CPUs < smallMaxCPUs:
+		return Small
+	case h.MemoryMB < mediumMaxMemoryMB || h.CPUs < mediumMaxCPUs:
+		return Medium
+	}
+	return Large
+}
+
+// Resolve turns a --size value into the size to apply, detecting the host for
+// Auto. host is zero unless it was measured.
+func Resolve(size string) (string, Host) {
+	if size != Auto && size != "" {
+		return size, Host{}
+	}
+	host := DetectHost()
+	return Classify(host), host
+}
+
+// ArgoCDOverlay is deep-merged over the ArgoCD baseline values for size; nil
+// for Large.
+func ArgoCDOverlay(size string) map[string]interface{} {
+	switch size {
+	case Small:
+		return map[string]interface{}{
+			"configs": map[string]interface{}{
+				"params": map[string]interface{}{"reposerver.parallelism.limit": "1"},
+			},
+			"controller": map[string]interface{}{
+				"extraArgs": []interface{}{"--status-processors=5", "--operation-processors=3"},
+				"resources": resources("100m", "400Mi", "500m", "1Gi"),
+			},
+			"server":        map[string]interface{}{"resources": resources("50m", "128Mi", "300m", "384Mi")},
+			"repoServer":    map[string]interface{}{"resources": resources("100m", "256Mi", "1000m", "1Gi")},
+			"redis":         map[string]interface{}{"resources": resources("25m", "32Mi", "100m", "128Mi")},
+			"notifications": map[string]interface{}{"enabled": false},
+		}
+	case Medium:
+		return map[string]interface{}{
+			"configs": map[string]interface{}{
+				"params": map[string]interface{}{"reposerver.parallelism.limit": "2"},
+			},
+			"controller": map[string]interface{}{
+				"extraArgs": []interface{}{"--status-processors=10", "--operation-processors=5"},
+			},
+		}
+	}
+	return nil
+}
+
+// AppOfAppsOverlay is passed to the app-of-apps chart after the values file
+// for size; nil when size needs none. The app-of-apps renders one Application
+// per enabled platform.apps entry, so a small host leaves out dev-tools, the
+// developer tooling the platform runs without.
+func AppOfAppsOverlay(size string) map[string]interface{} {
+	if size != Small {
+		return nil
+	}
+	return map[string]interface{}{
+		"platform": map[string]interface{}{
+			"apps": map[string]interface{}{
+				"dev-tools": map[string]interface{}{"enabled": false},
+			},
+		},
+	}
+}
+
+func resources(cpuReq, memReq, cpuLim, memLim string) map[string]interface{} {
+	return map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": cpuReq, "memory": memReq},
+		"limits":   map[string]interface{}{"cpu": cpuLim, "memory": memLim},
+	}
+}
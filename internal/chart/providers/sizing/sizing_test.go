@@ -0,0 +1,78 @@
+package sizing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]string{"": Auto, "auto": Auto, "Small": Small, " large ": Large} {
+		got, err := ParseSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseSize("tiny")
+	assert.Error(t, err)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		host Host
+		want string
+	}{
+		{Host{MemoryMB: 8 * 1024, CPUs: 8}, Small},
+		{Host{MemoryMB: 32 * 1024, CPUs: 2}, Small},
+		{Host{MemoryMB: 16 * 1024, CPUs: 8}, Medium},
+		{Host{MemoryMB: 32 * 1024, CPUs: 6}, Medium},
+		{Host{MemoryMB: 32 * 1024, CPUs: 12}, Large},
+		{Host{MemoryMB: 0, CPUs: 1}, Large}, // detection failed
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Classify(tt.host), "%+v", tt.host)
+	}
+}
+
+func TestWSLLimits(t *testing.T) {
+	host := Host{MemoryMB: 32 * 1024, CPUs: 16, Source: "host"}
+
+	// No .wslconfig: WSL gives the VM half the memory and every CPU.
+	got := wslLimits(host, filepath.Join(t.TempDir(), "missing"))
+	assert.Equal(t, Host{MemoryMB: 16 * 1024, CPUs: 16, Source: "WSL defaults"}, got)
+
+	path := filepath.Join(t.TempDir(), ".wslconfig")
+	require.NoError(t, os.WriteFile(path, []byte("[wsl2]\nmemory=8GB\n"), 0o600))
+	got = wslLimits(host, path)
+	assert.Equal(t, Host{MemoryMB: 8 * 1024, CPUs: 16, Source: ".wslconfig"}, got)
+	assert.Equal(t, Small, Classify(got))
+}
+
+func TestOverlays(t *testing.T) {
+	assert.Nil(t, ArgoCDOverlay(Large))
+	assert.Nil(t, AppOfAppsOverlay(Large))
+	assert.Nil(t, ArgoCDOverlay(""))
+
+	small := ArgoCDOverlay(Small)
+	params := small["configs"].(map[string]interface{})["params"].(map[string]interface{})
+	assert.Equal(t, "1", params["reposerver.parallelism.limit"])
+	assert.Contains(t, small, "repoServer")
+
+	assert.Nil(t, AppOfAppsOverlay(Medium))
+	assert.Equal(t, map[string]interface{}{"platform": map[string]interface{}{
+		"apps": map[string]interface{}{"dev-tools": map[string]interface{}{"enabled": false}},
+	}}, AppOfAppsOverlay(Small))
+}
+
+func TestShortfall(t *testing.T) {
+	// Docker Desktop's old 2 GB default fits no size.
+	assert.Equal(t, "2.0 GB memory (needs 6.0 GB)", Shortfall(Host{MemoryMB: 2 * 1024, CPUs: 4}, Small))
+	assert.Equal(t, "8.0 GB memory (needs 12.0 GB) and 2 CPUs (needs 4)", Shortfall(Host{MemoryMB: 8 * 1024, CPUs: 2}, Medium))
+	assert.Empty(t, Shortfall(Host{MemoryMB: 16 * 1024, CPUs: 8}, Large))
+	assert.Empty(t, Shortfall(Host{}, Large), "unknown resources are not short")
+
+	assert.Less(t, Requirements(Small).MemoryMB, Requirements(Medium).MemoryMB)
+	assert.Less(t, Requirements(Medium).MemoryMB, Requirements(Large).MemoryMB)
+}
@@ -0,0 +1,301 @@
+// Package smoke runs the post-install smoke test: after every application is
+// Healthy and Synced it checks that the platform is actually reachable — the
+// ArgoCD API answers, the OpenFrame gateway reports healthy through the
+// ingress, and the ingress serves the certificate the CLI generated. It
+// catches "everything synced but nothing reachable" (ingress controller not
+// bound to the host port, a stale certificate, a gateway crash-looping behind
+// a Healthy Deployment).
+package smoke
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GatewayHealthPath is the OpenFrame gateway's health endpoint behind the
+// ingress.
+const GatewayHealthPath = "/health"
+
+const (
+	argoCDNamespace = "argocd"
+	argoCDService   = "argocd-server"
+	probeTimeout    = 10 * time.Second
+)
+
+// Outcome of one check.
+const (
+	Pass = "pass"
+	Fail = "fail"
+	Skip = "skip"
+)
+
+// Result is one smoke check.
+type Result struct {
+	Check    string        `json:"check"`
+	Target   string        `json:"target"`
+	Outcome  string        `json:"outcome"`
+	Detail   string        `json:"detail,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of Run.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// OK reports whether no check failed (skipped checks do not count).
+func (r Report) OK() bool {
+	for _, res := range r.Results {
+		if res.Outcome == Fail {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the failed checks.
+func (r Report) Failed() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if res.Outcome == Fail {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Target is where the platform is served.
+type Target struct {
+	// BaseURL is the ingress origin (https://localhost, or the ngrok domain);
+	// empty when the values configure no ingress the CLI knows about.
+	BaseURL string
+	// PinnedCert, for the localhost ingress, is the certificate the CLI
+	// generated; the ingress must serve exactly this one. nil verifies the
+	// chain against the system roots instead (public ngrok domain).
+	PinnedCert *x509.Certificate
+}
+
+// TargetFromValues derives the Target from the helm values file: a localhost
+// ingress is https://localhost pinned to certFile, an ngrok ingress is its
+// public domain.
+func TargetFromValues(valuesFile, certFile string) (Target, error) {
+	data, err := os.ReadFile(valuesFile) //nolint:gosec // G304: values file resolved by the install config
+	if err != nil {
+		return Target{}, fmt.Errorf("reading values file: %w", err)
+	}
+	var values struct {
+		Deployment struct {
+			Ingress struct {
+				Localhost struct {
+					Enabled bool `yaml:"enabled"`
+				} `yaml:"localhost"`
+				Ngrok struct {
+					Enabled bool   `yaml:"enabled"`
+					URL     string `yaml:"url"`
+				} `yaml:"ngrok"`
+			} `yaml:"ingress"`
+		} `yaml:"deployment"`
+	}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return Target{}, fmt.Errorf("values file %s is not valid YAML: %w", valuesFile, err)
+	}
+	ingress := values.Deployment.Ingress
+	switch {
+	case ingress.Ngrok.Enabled && ingress.Ngrok.URL != "":
+		host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(ingress.Ngrok.URL, "https://"), "http://"), "/")
+		return Target{BaseURL: "https://" + host}, nil
+	case ingress.Localhost.Enabled:
+		cert, err := loadCert(certFile)
+		if err != nil {
+			return Target{BaseURL: "https://localhost"}, err
+		}
+		return Target{BaseURL: "https://localhost", PinnedCert: cert}, nil
+	}
+	return Target{}, nil
+}
+
+func loadCert(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: CLI-generated certificate path
+	if err != nil {
+		return nil, fmt.Errorf("reading generated certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s holds no PEM certificate", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// Runner runs the smoke checks.
+type Runner struct {
+	target Target
+	// argoCD probes the ArgoCD API; nil skips the check (another GitOps
+	// engine, or no cluster connection).
+	argoCD func(ctx context.Context) (string, error)
+}
+
+// NewRunner returns a Runner for target. client, when set, probes the ArgoCD
+// API through the API server's service proxy — no port-forward or ingress
+// needed.
+func NewRunner(client kubernetes.Interface, target Target) *Runner {
+	r := &Runner{target: target}
+	if client != nil {
+		r.argoCD = func(ctx context.Context) (string, error) {
+			body, err := client.CoreV1().Services(argoCDNamespace).
+				ProxyGet("https", argoCDService, "443", "api/version", nil).DoRaw(ctx)
+			if err != nil {
+				return "", err
+			}
+			return string(body), nil
+		}
+	}
+	return r
+}
+
+// Run runs every check and returns the report. Checks never abort the run.
+func (r *Runner) Run(ctx context.Context) Report {
+	return Report{Results: []Result{
+		r.timed(ctx, r.checkArgoCD),
+		r.timed(ctx, r.checkIngressTLS),
+		r.timed(ctx, r.checkGateway),
+	}}
+}
+
+func (r *Runner) timed(ctx context.Context, check func(context.Context) Result) Result {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	start := time.Now()
+	res := check(ctx)
+	res.Duration = time.Since(start).Round(time.Millisecond)
+	return res
+}
+
+func (r *Runner) checkArgoCD(ctx context.Context) Result {
+	res := Result{Check: "ArgoCD API", Target: "svc/" + argoCDService + " -n " + argoCDNamespace}
+	if r.argoCD == nil {
+		res.Outcome, res.Detail = Skip, "ArgoCD not in use"
+		return res
+	}
+	body, err := r.argoCD(ctx)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	res.Outcome = Pass
+	if v := argoCDVersion(body); v != "" {
+		res.Detail = "version " + v
+	}
+	return res
+}
+
+// argoCDVersion pulls "Version" out of /api/version without a full decode.
+func argoCDVersion(body string) string {
+	_, rest, ok := strings.Cut(body, `"Version":"`)
+	if !ok {
+		return ""
+	}
+	v, _, _ := strings.Cut(rest, `"`)
+	return v
+}
+
+func (r *Runner) checkIngressTLS(ctx context.Context) Result {
+	res := Result{Check: "Ingress TLS", Target: r.target.BaseURL}
+	if r.target.BaseURL == "" {
+		res.Outcome, res.Detail = Skip, "no ingress configured"
+		return res
+	}
+	u, err := url.Parse(r.target.BaseURL)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "443")
+	}
+	dialer := &tls.Dialer{Config: r.tlsConfig(u.Hostname())}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	defer func() { _ = conn.Close() }()
+	leaf := conn.(*tls.Conn).ConnectionState().PeerCertificates[0]
+	res.Outcome = Pass
+	res.Detail = "serves " + leaf.Subject.CommonName + ", expires " + leaf.NotAfter.Format("2006-01-02")
+	if r.target.PinnedCert != nil {
+		res.Detail = "serves the generated certificate, expires " + leaf.NotAfter.Format("2006-01-02")
+	}
+	return res
+}
+
+func (r *Runner) checkGateway(ctx context.Context) Result {
+	res := Result{Check: "Gateway health", Target: r.target.BaseURL + GatewayHealthPath}
+	if r.target.BaseURL == "" {
+		res.Outcome, res.Detail = Skip, "no ingress configured"
+		return res
+	}
+	u, err := url.Parse(r.target.BaseURL)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: r.tlsConfig(u.Hostname())}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, res.Target, nil)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		res.Outcome, res.Detail = Fail, err.Error()
+		return res
+	}
+	_ = resp.Body.Close()
+	res.Detail = "HTTP " + resp.Status
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		res.Outcome = Pass
+	} else {
+		res.Outcome = Fail
+	}
+	return res
+}
+
+// errNotPinned is returned when the ingress serves another certificate than
+// the one the CLI generated.
+var errNotPinned = errors.New("ingress does not serve the generated certificate")
+
+// tlsConfig verifies the normal chain, or — with a pinned certificate — that
+// the server presents exactly that certificate. Pinning is stricter than
+// chain verification and does not depend on the mkcert CA being in this
+// process's trust store (it is not, under WSL).
+func (r *Runner) tlsConfig(serverName string) *tls.Config {
+	cfg := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	pinned := r.target.PinnedCert
+	if pinned == nil {
+		return cfg
+	}
+	want := sha256.Sum256(pinned.Raw)
+	cfg.InsecureSkipVerify = true //nolint:gosec // G402: replaced by the certificate pin below
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 || sha256.Sum256(cs.PeerCertificates[0].Raw) != want {
+			return errNotPinned
+		}
+		return nil
+	}
+	return cfg
+}
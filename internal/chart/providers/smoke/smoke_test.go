@@ -0,0 +1,118 @@
+package smoke
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func healthServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == GatewayHealthPath {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// otherCert is a certificate no test server serves. (Every httptest TLS
+// server shares one built-in certificate, so a second server would not do.)
+func otherCert(t *testing.T) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "other"}, NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func outcomes(r Report) map[string]string {
+	out := map[string]string{}
+	for _, res := range r.Results {
+		out[res.Check] = res.Outcome
+	}
+	return out
+}
+
+func TestRun_AllPass(t *testing.T) {
+	srv := healthServer(t, http.StatusOK)
+	r := NewRunner(nil, Target{BaseURL: srv.URL, PinnedCert: srv.Certificate()})
+	r.argoCD = func(context.Context) (string, error) { return `{"Version":"v2.13.1+af54ef8"}`, nil }
+
+	report := r.Run(context.Background())
+
+	assert.True(t, report.OK())
+	assert.Equal(t, map[string]string{"ArgoCD API": Pass, "Ingress TLS": Pass, "Gateway health": Pass}, outcomes(report))
+	assert.Equal(t, "version v2.13.1+af54ef8", report.Results[0].Detail)
+}
+
+func TestRun_WrongCertificateAndUnhealthyGateway(t *testing.T) {
+	srv := healthServer(t, http.StatusServiceUnavailable)
+	r := NewRunner(nil, Target{BaseURL: srv.URL, PinnedCert: otherCert(t)})
+	r.argoCD = func(context.Context) (string, error) { return "", errors.New("service unavailable") }
+
+	report := r.Run(context.Background())
+
+	assert.False(t, report.OK())
+	assert.Len(t, report.Failed(), 3)
+	assert.Contains(t, report.Results[1].Detail, errNotPinned.Error())
+
+	r.target.PinnedCert = srv.Certificate()
+	report = r.Run(context.Background())
+	assert.Equal(t, "HTTP 503 Service Unavailable", report.Results[2].Detail)
+}
+
+func TestRun_NoIngressSkips(t *testing.T) {
+	report := NewRunner(nil, Target{}).Run(context.Background())
+	assert.True(t, report.OK())
+	assert.Equal(t, map[string]string{"ArgoCD API": Skip, "Ingress TLS": Skip, "Gateway health": Skip}, outcomes(report))
+}
+
+func TestTargetFromValues(t *testing.T) {
+	dir := t.TempDir()
+	srv := healthServer(t, http.StatusOK)
+	certFile := filepath.Join(dir, "localhost.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+
+	write := func(body string) string {
+		p := filepath.Join(dir, "values.yaml")
+		require.NoError(t, os.WriteFile(p, []byte(body), 0o600))
+		return p
+	}
+
+	target, err := TargetFromValues(write("deployment:\n  ingress:\n    localhost:\n      enabled: true\n"), certFile)
+	require.NoError(t, err)
+	assert.Equal(t, "https://localhost", target.BaseURL)
+	require.NotNil(t, target.PinnedCert)
+	assert.Equal(t, srv.Certificate().Raw, target.PinnedCert.Raw)
+
+	target, err = TargetFromValues(write("deployment:\n  ingress:\n    ngrok:\n      enabled: true\n      url: https://acme.ngrok.app/\n"), certFile)
+	require.NoError(t, err)
+	assert.Equal(t, Target{BaseURL: "https://acme.ngrok.app"}, target)
+
+	target, err = TargetFromValues(write("global: {}\n"), certFile)
+	require.NoError(t, err)
+	assert.Empty(t, target.BaseURL)
+}
@@ -0,0 +1,131 @@
+// Package valueschema checks a helm values file against a JSON schema before
+// anything is installed, reporting unknown keys and type mismatches with the
+// line they are on. A typo such as `ingres:` is otherwise silently ignored by
+// the chart and only shows up as a failed application wait tens of minutes
+// later.
+//
+// It understands the subset of JSON Schema that values schemas are written
+// in — type, properties, additionalProperties, items and enum — and does not
+// look into a subschema using anything else ($ref, allOf, anyOf, ...), so an
+// unfamiliar schema can only make it check less, never reject a valid file.
+package valueschema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ChartSchemaFile is where a chart ships its values schema.
+const ChartSchemaFile = "values.schema.json"
+
+//go:embed openframe-values.schema.json
+var bundledSchema []byte
+
+// Schema is a JSON schema, or the part of one this package checks.
+type Schema struct {
+	// Source names the schema in findings: "bundled" or the file it was read
+	// from.
+	Source string `json:"-"`
+
+	Type                 types              `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+
+	// opaque is set when the schema uses a keyword this package does not
+	// evaluate; nothing below it is checked.
+	opaque bool
+}
+
+// unsupported are the keywords whose meaning depends on evaluating schemas
+// this package does not: a subschema carrying one is left unchecked.
+var unsupported = []string{"$ref", "allOf", "anyOf", "oneOf", "not", "if", "patternProperties", "dependentSchemas"}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	for _, k := range unsupported {
+		if _, ok := keys[k]; ok {
+			s.opaque = true
+		}
+	}
+	return nil
+}
+
+// types is a schema's "type": one name or a list of them.
+type types []string
+
+func (t *types) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = types{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = many
+	return nil
+}
+
+// additional is "additionalProperties": false forbids unknown keys, a schema
+// checks them, true (or its absence) allows anything.
+type additional struct {
+	forbidden bool
+	schema    *Schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.forbidden = !allowed
+		return nil
+	}
+	a.schema = &Schema{}
+	return json.Unmarshal(data, a.schema)
+}
+
+// Parse reads a JSON schema; source names it in findings.
+func Parse(data []byte, source string) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing values schema %s: %w", source, err)
+	}
+	s.Source = source
+	return s, nil
+}
+
+// Load reads the schema at path, or returns nil when there is none.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- a schema inside the cloned chart
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, path)
+}
+
+// Bundled is the CLI's own schema of openframe-helm-values.yaml, for checking
+// the file before the chart (and any schema it ships) has been fetched. It
+// closes only the sections the CLI itself reads — deployment, repository,
+// registry — so a key a newer chart added elsewhere is never refused.
+func Bundled() *Schema {
+	s, err := Parse(bundledSchema, "bundled")
+	if err != nil {
+		panic(err) // the embedded schema is covered by tests
+	}
+	return s
+}
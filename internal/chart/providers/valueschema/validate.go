@@ -0,0 +1,228 @@
+package valueschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxReported bounds the findings an Error prints; a file written against the
+// wrong chart version would otherwise bury the first (usually the real) one.
+const maxReported = 20
+
+// Finding is one place where the values do not match the schema.
+type Finding struct {
+	Line    int    `json:"line"`
+	Path    string `json:"path"` // dotted key path, e.g. deployment.ingress
+	Problem string `json:"problem"`
+}
+
+// Error lists the findings for a values file.
+type Error struct {
+	File     string
+	Schema   string
+	Findings []Finding
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s does not match the values schema (%s):", e.File, e.Schema)
+	for i, f := range e.Findings {
+		if i == maxReported {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(e.Findings)-maxReported)
+			break
+		}
+		fmt.Fprintf(&b, "\n  %s:%d: %s: %s", e.File, f.Line, f.Path, f.Problem)
+	}
+	return b.String()
+}
+
+// ValidateFile checks the values file at path against s. A missing file has
+// nothing to check; findings are returned as an *Error.
+func ValidateFile(path string, s *Schema) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- values path resolved from config/CLI, read as the invoking user
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading values file %s: %w", path, err)
+	}
+	findings, err := Validate(data, s)
+	if err != nil {
+		return fmt.Errorf("values file %s: %w", path, err)
+	}
+	if len(findings) > 0 {
+		return &Error{File: path, Schema: s.Source, Findings: findings}
+	}
+	return nil
+}
+
+// Validate checks YAML values against s, in document order.
+func Validate(data []byte, s *Schema) ([]Finding, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("not valid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	var findings []Finding
+	check(doc.Content[0], s, "", &findings)
+	return findings, nil
+}
+
+func check(n *yaml.Node, s *Schema, path string, findings *[]Finding) {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if s == nil || s.opaque || n.Tag == "!!null" {
+		// helm reads null as "delete this key", valid whatever the type.
+		return
+	}
+	report := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "(top level)"
+		}
+		*findings = append(*findings, Finding{Line: n.Line, Path: p, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	got := nodeType(n)
+	if len(s.Type) > 0 && !typeAllowed(s.Type, got) {
+		if n.Kind == yaml.ScalarNode {
+			report("expected %s, got %s %s", strings.Join(s.Type, " or "), got, strconv.Quote(n.Value))
+		} else {
+			report("expected %s, got %s", strings.Join(s.Type, " or "), got)
+		}
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(n, s.Enum) {
+		report("must be one of %s, got %s", enumList(s.Enum), strconv.Quote(n.Value))
+		return
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			child := joinPath(path, key.Value)
+			if prop, ok := s.Properties[key.Value]; ok {
+				check(value, prop, child, findings)
+				continue
+			}
+			switch a := s.AdditionalProperties; {
+			case a == nil:
+			case a.schema != nil:
+				check(value, a.schema, child, findings)
+			case a.forbidden:
+				problem := fmt.Sprintf("unknown key %q", key.Value)
+				if hint := suggest(key.Value, s.Properties); hint != "" {
+					problem += fmt.Sprintf(" (did you mean %q?)", hint)
+				}
+				*findings = append(*findings, Finding{Line: key.Line, Path: child, Problem: problem})
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			check(item, s.Items, fmt.Sprintf("%s[%d]", path, i), findings)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// nodeType is the JSON type a YAML node decodes to.
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	}
+	return "string"
+}
+
+func typeAllowed(allowed types, got string) bool {
+	return slices.Contains(allowed, got) || (got == "integer" && slices.Contains(allowed, "number"))
+}
+
+// inEnum compares n with the enum values as JSON, so 1 matches 1.0 and "a"
+// matches "a".
+func inEnum(n *yaml.Node, enum []any) bool {
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return false
+	}
+	got, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	for _, e := range enum {
+		if want, err := json.Marshal(e); err == nil && string(want) == string(got) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []any) string {
+	parts := make([]string, len(enum))
+	for i, e := range enum {
+		b, _ := json.Marshal(e)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// suggest returns the known key closest to key — differing in case or by at
+// most two edits — or "".
+func suggest(key string, known map[string]*Schema) string {
+	best, bestDist := "", 3
+	for k := range known {
+		d := distance(strings.ToLower(key), strings.ToLower(k))
+		if d < bestDist || (d == bestDist && best != "" && k < best) {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
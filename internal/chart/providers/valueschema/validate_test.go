@@ -0,0 +1,120 @@
+package valueschema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundled_AcceptsTheExampleValues(t *testing.T) {
+	data, err := os.ReadFile("../../../../openframe-helm-values.example.yaml")
+	require.NoError(t, err)
+	findings, err := Validate(data, Bundled())
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestBundled_ReportsTyposAndTypesWithLines(t *testing.T) {
+	values := `deployment:
+  ingres:
+    localhost:
+      enabled: true
+repository:
+  branch: main
+  URl: https://example.com
+platform:
+  apps:
+    ingress-nginx:
+      enabled: "yes"
+deployment2: {}
+`
+	findings, err := Validate([]byte(values), Bundled())
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Line: 2, Path: "deployment.ingres", Problem: `unknown key "ingres" (did you mean "ingress"?)`},
+		{Line: 7, Path: "repository.URl", Problem: `unknown key "URl" (did you mean "URL"?)`},
+		{Line: 11, Path: "platform.apps.ingress-nginx.enabled", Problem: `expected boolean, got string "yes"`},
+	}, findings, "top-level keys stay open in the bundled schema")
+}
+
+func TestValidate_Enum(t *testing.T) {
+	s, err := Parse([]byte(`{"type": "object", "properties": {"size": {"type": "string", "enum": ["small", "medium", "large"]}}}`), "test")
+	require.NoError(t, err)
+	findings, err := Validate([]byte("size: huge\n"), s)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, `must be one of "small", "medium", "large", got "huge"`, findings[0].Problem)
+}
+
+func TestValidate_NullAnchorsAndArrays(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"ports": {"type": "array", "items": {"type": "integer"}},
+			"ratio": {"type": "number"},
+			"base": {"type": "object", "properties": {"on": {"type": "boolean"}}},
+			"copy": {"type": "object", "properties": {"on": {"type": "boolean"}}},
+			"gone": {"type": "string"}
+		}
+	}`), "test")
+	require.NoError(t, err)
+
+	findings, err := Validate([]byte("ports: [80, http]\nratio: 2\nbase: &b {on: true}\ncopy: *b\ngone: null\n"), s)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{{Line: 1, Path: "ports[1]", Problem: `expected integer, got string "http"`}}, findings)
+}
+
+func TestValidate_UnsupportedKeywordsAreNotChecked(t *testing.T) {
+	s, err := Parse([]byte(`{"type": "object", "properties": {"a": {"$ref": "#/definitions/x", "type": "string"}}, "additionalProperties": {"anyOf": [{"type": "string"}]}}`), "test")
+	require.NoError(t, err)
+	findings, err := Validate([]byte("a: 1\nb: true\n"), s)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestValidate_InvalidYAML(t *testing.T) {
+	_, err := Validate([]byte("a: [1\n"), Bundled())
+	assert.Error(t, err)
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ValidateFile(filepath.Join(dir, "missing.yaml"), Bundled()), "a missing file has nothing to check")
+
+	path := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("deployment:\n  ingres: {}\n"), 0o600))
+	err := ValidateFile(path, Bundled())
+	var verr *Error
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, "bundled", verr.Schema)
+	assert.Contains(t, err.Error(), path+":2: deployment.ingres: unknown key")
+}
+
+func TestError_CapsFindings(t *testing.T) {
+	e := &Error{File: "v.yaml", Schema: "bundled", Findings: make([]Finding, maxReported+5)}
+	assert.Equal(t, maxReported+2, strings.Count(e.Error(), "\n")+1)
+	assert.Contains(t, e.Error(), "... and 5 more")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Load(filepath.Join(dir, ChartSchemaFile))
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	path := filepath.Join(dir, ChartSchemaFile)
+	require.NoError(t, os.WriteFile(path, []byte(`{"type": "object"}`), 0o600))
+	s, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, s.Source)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"type": 3}`), 0o600))
+	_, err = Load(path)
+	assert.Error(t, err)
+}
@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/valueschema"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/errors"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
@@ -21,6 +23,9 @@ type AppOfApps struct {
 	helmManager  *helm.HelmManager
 	gitRepo      *git.Repository
 	pathResolver *config.PathResolver
+	// repoCheck checks the applications' repositories answer before the
+	// chart is installed; nil skips the check.
+	repoCheck *repoCheck
 }
 
 // NewAppOfApps creates a new app-of-apps service
@@ -74,18 +79,45 @@ func (a *AppOfApps) Install(ctx context.Context, config config.ChartInstallConfi
 	if cloneSpinner != nil {
 		cloneSpinner.Success("Chart repository cloned")
 	}
+	appConfig.Commit = cloneResult.Commit
 
 	// Ensure cleanup happens after installation completes (success or failure)
 	defer func() {
 		a.gitRepo.Cleanup(cloneResult.TempDir)
 	}()
 
+	// A chart that ships a values schema is the authority on its values:
+	// check the user's file against it now, before ArgoCD spends the
+	// application wait on values the chart ignores.
+	if err := a.validateChartSchema(cloneResult.ChartPath); err != nil {
+		return err
+	}
+
 	// Get file paths
 	valuesFile := a.pathResolver.GetHelmValuesFile()
 	if appConfig.ValuesFile != "" {
 		valuesFile = appConfig.ValuesFile
 	}
 
+	// An unreachable repository otherwise shows up only as an application
+	// wait that never finishes, up to an hour later.
+	if a.repoCheck != nil && !config.DryRun && !config.SkipRepoCheck {
+		sources := repoSourcesFromFiles(filepath.Join(cloneResult.ChartPath, "values.yaml"), valuesFile, appConfig.GitHubRepo)
+		var checkSpinner *spinner.Spinner
+		if !config.Silent && !config.NonInteractive {
+			checkSpinner = spinner.Start(fmt.Sprintf("Checking %d application repositories are reachable...", len(sources)))
+		}
+		if err := a.repoCheck.run(ctx, sources); err != nil {
+			if checkSpinner != nil {
+				checkSpinner.Fail("Application repositories are unreachable")
+			}
+			return errors.NewChartError("preflight", "repository reachability", err).WithCluster(config.ClusterName)
+		}
+		if checkSpinner != nil {
+			checkSpinner.Success("Application repositories are reachable")
+		}
+	}
+
 	certFile, keyFile := a.pathResolver.GetCertificateFiles()
 
 	// Create a modified config with the local chart path
@@ -111,6 +143,22 @@ func (a *AppOfApps) Install(ctx context.Context, config config.ChartInstallConfi
 	return nil
 }
 
+// validateChartSchema checks the user's values file against the chart's
+// values.schema.json. A schema that cannot be read is reported and skipped:
+// helm validates against it again at install.
+func (a *AppOfApps) validateChartSchema(chartPath string) error {
+	schema, err := valueschema.Load(filepath.Join(chartPath, valueschema.ChartSchemaFile))
+	if err != nil {
+		pterm.Warning.Printf("Skipping the chart's values schema check: %v\n", err)
+		return nil
+	}
+	if schema == nil {
+		return nil
+	}
+	schema.Source = "the chart's " + valueschema.ChartSchemaFile
+	return validateValuesSchema(a.pathResolver.GetHelmValuesFile(), schema)
+}
+
 // IsInstalled checks if app-of-apps is installed
 func (a *AppOfApps) IsInstalled(ctx context.Context, namespace string) (bool, error) {
 	return a.helmManager.IsChartInstalled(ctx, "app-of-apps", namespace)
@@ -8,10 +8,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/prerequisites"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/flux"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/gitops"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/smoke"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/valueschema"
 	chartUI "github.com/flamingo-stack/openframe-cli/internal/chart/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/ui/configuration"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/ui/templates"
@@ -21,8 +27,11 @@ import (
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/files"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
 	sharedUI "github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/pterm/pterm"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
@@ -114,11 +123,14 @@ func (cs *ChartService) initializeHelmManager(kubeConfig *rest.Config, verbose b
 	return nil
 }
 
-func (cs *ChartService) InstallWithContext(ctx context.Context, req types.InstallationRequest) error {
+// InstallWithContext runs the installation workflow. The result covers what
+// ran, also when the install failed part way; it is nil only when nothing
+// started.
+func (cs *ChartService) InstallWithContext(ctx context.Context, req types.InstallationRequest) (*models.InstallResult, error) {
 	// Check if context is already cancelled
 	select {
 	case <-ctx.Done():
-		return fmt.Errorf("chart installation cancelled: %w", ctx.Err())
+		return nil, fmt.Errorf("chart installation cancelled: %w", ctx.Err())
 	default:
 	}
 
@@ -130,17 +142,20 @@ func (cs *ChartService) InstallWithContext(ctx context.Context, req types.Instal
 		chartService:   cs,
 		clusterService: cs.clusterService,
 		fileCleanup:    fileCleanup,
+		result:         &models.InstallResult{DryRun: req.DryRun, Started: time.Now()},
 	}
 
 	// Execute workflow with context
-	return workflow.ExecuteWithContext(ctx, req)
+	err := workflow.ExecuteWithContext(ctx, req)
+	workflow.result.Finished = time.Now()
+	return workflow.result, err
 }
 
 // InstallWithContextDeferred performs installation with deferred HelmManager
 // initialization — used when KubeConfig is not available upfront (standalone
 // chart install). Same workflow as InstallWithContext: the nil HelmManager on a
 // service built by NewChartServiceDeferred triggers the in-workflow resolution.
-func (cs *ChartService) InstallWithContextDeferred(ctx context.Context, req types.InstallationRequest) error {
+func (cs *ChartService) InstallWithContextDeferred(ctx context.Context, req types.InstallationRequest) (*models.InstallResult, error) {
 	return cs.InstallWithContext(ctx, req)
 }
 
@@ -149,6 +164,8 @@ type InstallationWorkflow struct {
 	chartService   *ChartService
 	clusterService types.ClusterAccess
 	fileCleanup    *files.FileCleanup
+	// result is filled in as the workflow runs; nil records nothing.
+	result *models.InstallResult
 }
 
 func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req types.InstallationRequest) error {
@@ -180,7 +197,11 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		// missing-file warning two lines later (verification finding N1).
 		pterm.Info.Println("Running in non-interactive mode")
 		var err error
-		chartConfig, err = w.loadExistingConfiguration(req.RequireExistingValues)
+		if req.ValuesFile != "" {
+			chartConfig, err = w.loadValuesFile(req.ValuesFile)
+		} else {
+			chartConfig, err = w.loadExistingConfiguration(req.RequireExistingValues)
+		}
 		if err != nil {
 			return fmt.Errorf("non-interactive configuration failed: %w", err)
 		}
@@ -207,15 +228,29 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		}
 	}
 
+	// Environment values overlays (bootstrap --env) go on top of whichever
+	// values the mode above produced.
+	if err := applyValuesOverlays(chartConfig, req.ValuesOverlays); err != nil {
+		return err
+	}
+
 	// Step 1.5: Pre-flight the values that will feed the ArgoCD install. The
 	// values are fully parsed by now, so a malformed `argocd:` override fails
 	// here — before cluster selection and any cluster work — instead of
 	// surfacing mid-install behind a misleading ArgoCD pod-diagnostics dump
 	// (0.4.9 verification observation). The install-time check remains as
-	// defense in depth.
-	if path := chartConfig.TempHelmValuesPath; path != "" {
-		if err := argocd.ValidateUserValuesFile(path); err != nil {
-			return fmt.Errorf("helm values pre-flight failed: %w", err)
+	// defense in depth. A run of --only certificates or verify installs no
+	// chart, so it does not check the values.
+	if req.Phases.InstallsCharts() {
+		if path := chartConfig.TempHelmValuesPath; path != "" {
+			if err := argocd.ValidateUserValuesFile(path); err != nil {
+				return fmt.Errorf("helm values pre-flight failed: %w", err)
+			}
+		}
+		// The schema check reads the user's own file, so its line numbers are
+		// the ones they see in their editor.
+		if err := validateValuesSchema(config.NewPathResolver().GetHelmValuesFile(), valueschema.Bundled()); err != nil {
+			return err
 		}
 	}
 
@@ -277,22 +312,49 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		}
 	}
 
-	// Step 4: Regenerate certificates (skipped in non-interactive and dry-run modes)
-	if !req.NonInteractive && !req.DryRun {
-		// Non-fatal: failures are logged inside the method, continue regardless.
-		_ = w.regenerateCertificates()
+	// Step 4: Regenerate certificates (skipped in non-interactive and dry-run
+	// modes, unless --only names them)
+	if req.Phases != nil {
+		pterm.Info.Printf("Running install phases: %s (--only/--skip)\n", req.Phases)
+	}
+	if !req.Phases.Runs(models.PhaseCertificates) {
+		pterm.Info.Println("Skipping certificate regeneration (--only/--skip)")
 	} else if req.DryRun {
 		pterm.Info.Println("Skipping certificate regeneration (dry-run)")
+	} else if !req.NonInteractive || req.Phases.Requested(models.PhaseCertificates) {
+		// Non-fatal: failures are logged inside the method, continue regardless.
+		_ = w.regenerateCertificates()
 	} else {
 		pterm.Warning.Println("Skipping certificate regeneration (non-interactive mode)")
 	}
 
+	// Step 4.5: Defer to an ingress-nginx the cluster was created with
+	if w.chartService.kubeConfig != nil && req.Phases.Runs(models.PhaseAppOfApps) {
+		if c, cerr := kubernetes.NewForConfig(w.chartService.kubeConfig); cerr == nil {
+			if err := useClusterIngress(ctx, c, chartConfig); err != nil {
+				return fmt.Errorf("disabling the platform's ingress-nginx: %w", err)
+			}
+		}
+	}
+
 	// Step 5: Build configuration
 	config, err := w.buildConfiguration(req, clusterName, chartConfig)
 	if err != nil {
 		chartErr := errors.WrapAsChartError("configuration", "build", err).WithCluster(clusterName)
 		return sharedErrors.HandleGlobalError(chartErr, req.Verbose)
 	}
+	if w.result != nil {
+		if path := chartConfig.TempHelmValuesPath; path != "" {
+			if values, err := os.ReadFile(path); err == nil { // #nosec G304 -- the install's own temp file
+				w.result.Values = values
+			}
+		}
+		w.result.Cluster, w.result.KubeContext = config.ClusterName, config.KubeContext
+		w.result.Engine = gitops.EngineArgoCD
+		if config.GitOpsEngine != "" {
+			w.result.Engine = config.GitOpsEngine
+		}
+	}
 
 	// Step 6: Execute installation with retry support
 	err = w.performInstallationWithRetry(ctx, config)
@@ -314,7 +376,11 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 	}
 
 	// Step 8: ArgoCD sync is already handled by installer.InstallCharts
-	// The installer waits for all ArgoCD applications after installing app-of-apps
+	// The installer waits for all ArgoCD applications after installing app-of-apps.
+	// Then smoke-test the endpoints while the values file is still in place.
+	if !req.DryRun && !req.SkipVerify && req.Phases.Runs(models.PhaseVerify) && config.HasAppOfApps() {
+		w.verifyEndpoints(ctx, config)
+	}
 
 	// Step 9: Installation successful - clean up temporary files
 	if cleanupErr := w.fileCleanup.RestoreFilesOnSuccess(req.Verbose); cleanupErr != nil {
@@ -330,6 +396,44 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 	return nil
 }
 
+// verifyEndpoints runs the post-install smoke test and prints its table. A
+// failing check is reported, not fatal: the platform is installed, and the
+// table says what to look at.
+func (w *InstallationWorkflow) verifyEndpoints(ctx context.Context, config config.ChartInstallConfig) {
+	certFile, _ := w.chartService.configService.GetPathResolver().GetCertificateFiles()
+	target, err := smoke.TargetFromValues(config.AppOfApps.ValuesFile, certFile)
+	if err != nil {
+		pterm.Warning.Printf("Smoke test: %v\n", err)
+		w.result.Warn("smoke test: %v", err)
+	}
+
+	var client kubernetes.Interface
+	if w.chartService.kubeConfig != nil && config.GitOpsEngine != gitops.EngineFlux {
+		if c, cerr := kubernetes.NewForConfig(w.chartService.kubeConfig); cerr == nil {
+			client = c
+		}
+	}
+	report := smoke.NewRunner(client, target).Run(ctx)
+	timeline.Mark("smoke test finished")
+
+	table := pterm.TableData{{"CHECK", "TARGET", "RESULT", "DETAIL"}}
+	for _, r := range report.Results {
+		table = append(table, []string{r.Check, r.Target, strings.ToUpper(r.Outcome), r.Detail})
+		if w.result != nil {
+			w.result.Endpoints = append(w.result.Endpoints, models.EndpointResult{Check: r.Check, Target: r.Target, Outcome: r.Outcome, Detail: r.Detail})
+		}
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+	if report.OK() {
+		pterm.Success.Println("Smoke test passed: the platform is reachable.")
+		return
+	}
+	pterm.Warning.Printf("Smoke test: %d check(s) failed — everything synced, but not everything is reachable.\n", len(report.Failed()))
+	for _, r := range report.Failed() {
+		w.result.Warn("smoke test %s on %s failed: %s", r.Check, r.Target, r.Detail)
+	}
+}
+
 // selectCluster handles cluster selection
 func (w *InstallationWorkflow) selectCluster(args []string, nonInteractive, verbose bool) (string, error) {
 	clusterSelector := NewClusterSelector(w.clusterService, w.chartService.operationsUI)
@@ -441,6 +545,27 @@ func (w *InstallationWorkflow) loadExistingConfiguration(requireValuesFile bool)
 	return result, nil
 }
 
+// loadValuesFile builds the chart configuration from the values file at path,
+// as loadExistingConfiguration does from openframe-helm-values.yaml.
+func (w *InstallationWorkflow) loadValuesFile(path string) (*types.ChartConfiguration, error) {
+	modifier := templates.NewHelmValuesModifier()
+	values, err := modifier.LoadExistingValues(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	pterm.Info.Printf("Using %s\n", path)
+	tempFilePath, err := modifier.CreateTemporaryValuesFile(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary values file: %w", err)
+	}
+	return &types.ChartConfiguration{
+		BaseHelmValuesPath: path,
+		TempHelmValuesPath: tempFilePath,
+		ExistingValues:     values,
+		ModifiedSections:   []string{},
+	}, nil
+}
+
 // buildConfiguration constructs the installation configuration
 func (w *InstallationWorkflow) buildConfiguration(req types.InstallationRequest, clusterName string, chartConfig *types.ChartConfiguration) (config.ChartInstallConfig, error) {
 	configBuilder := config.NewBuilder(w.chartService.operationsUI)
@@ -483,9 +608,56 @@ func (w *InstallationWorkflow) buildConfiguration(req types.InstallationRequest,
 	// layer overrides the ClusterName-derived context in every helm call.
 	cfg.KubeContext = req.KubeContext
 	cfg.SyncStragglersOnStall = req.SyncStragglersOnStall
+	cfg.RegistryAuth = req.RegistryAuth
+	cfg.RepoServerRecovery = req.RepoServerRecovery
+	cfg.GitOpsEngine = req.GitOpsEngine
+	cfg.FluxPath = req.FluxPath
+	cfg.ReadinessGates = req.ReadinessGates
+	cfg.Size = resolveSize(req)
+	cfg.Resume = req.Resume
+	cfg.PauseSyncOnCancel = req.PauseSyncOnCancel
+	cfg.SkipRepoUpdate = req.SkipRepoUpdate
+	cfg.SkipRepoCheck = req.SkipRepoCheck
+	cfg.Phases = req.Phases
 	return cfg, nil
 }
 
+// resolveSize turns --size into the size the values are scaled to. Auto only
+// measures this machine when the cluster runs on it: an explicit --context may
+// be a remote cluster whose nodes have nothing to do with the local host.
+func resolveSize(req types.InstallationRequest) string {
+	if req.Size != sizing.Auto && req.Size != "" {
+		pterm.Info.Printf("Sizing platform resources for a %s host (--size)\n", req.Size)
+		if req.KubeContext == "" {
+			warnDockerDesktopShortfall(sizing.DetectHost(), req.Size)
+		}
+		return req.Size
+	}
+	if req.KubeContext != "" {
+		return ""
+	}
+	size, host := sizing.Resolve(sizing.Auto)
+	if size != sizing.Large {
+		pterm.Info.Printf("Host has %.1f GB memory and %d CPUs (%s): sizing platform resources for a %s host (override with --size)\n",
+			float64(host.MemoryMB)/1024, host.CPUs, host.Source, size)
+	}
+	warnDockerDesktopShortfall(host, size)
+	return size
+}
+
+// warnDockerDesktopShortfall warns when Docker Desktop's VM, where the
+// cluster runs on macOS, has less than the platform needs at size. Even the
+// small size cannot fit a VM left at a 2 GB default.
+func warnDockerDesktopShortfall(host sizing.Host, size string) {
+	if host.Source != "Docker Desktop" {
+		return
+	}
+	if short := sizing.Shortfall(host, size); short != "" {
+		pterm.Warning.Printf("Docker Desktop gives its VM %s for a %s install; pods may be OOM-killed or stay Pending. "+
+			"Raise it in Docker Desktop: Settings > Resources > Advanced (then Apply & restart).\n", short, size)
+	}
+}
+
 // performInstallation executes the actual installation
 func (w *InstallationWorkflow) performInstallation(ctx context.Context, config config.ChartInstallConfig) error {
 	// Create installer directly without factory. The ArgoCD wait manager gets
@@ -498,10 +670,46 @@ func (w *InstallationWorkflow) performInstallation(ctx context.Context, config c
 		return fmt.Errorf("failed to create ArgoCD service for the install target: %w", err)
 	}
 	appOfAppsService := NewAppOfApps(w.chartService.helmManager, w.chartService.gitRepository, pathResolver)
+	// Flux has no repo-server to ask, so only this machine's view is checked.
+	var cluster clusterLsRemote
+	if config.GitOpsEngine != gitops.EngineFlux {
+		cluster = argoCDService.argoCDManager
+	}
+	appOfAppsService.repoCheck = newRepoCheck(w.chartService.gitRepository, cluster, config.Verbose)
+
+	registryAuth, err := NewRegistryAuthStep(w.chartService.kubeConfig, config.RegistryAuth, argoCDService.argoCDManager)
+	if err != nil {
+		return fmt.Errorf("failed to prepare registry credentials: %w", err)
+	}
+
+	readinessGates, err := NewReadinessGateStep(w.chartService.kubeConfig, config.ReadinessGates)
+	if err != nil {
+		return fmt.Errorf("failed to prepare readiness gates: %w", err)
+	}
 
 	installer := &Installer{
 		argoCDService:    argoCDService,
 		appOfAppsService: appOfAppsService,
+		registryAuth:     registryAuth,
+		readinessGates:   readinessGates,
+		syncPauser:       argoCDService.argoCDManager,
+		result:           w.result,
+		apps:             argoCDService.argoCDManager,
+	}
+	// A retry starts over, so the result only keeps the attempt that counts.
+	w.result.ResetAttempt()
+	// Completed steps are recorded per target so a re-run after a failure
+	// resumes instead of starting over.
+	if progress, err := LoadInstallProgress(installTarget(config)); err == nil {
+		installer.progress = progress
+	}
+	// A non-default GitOps engine replaces the ArgoCD + app-of-apps steps.
+	if config.GitOpsEngine == gitops.EngineFlux {
+		engine, err := flux.NewEngine(w.chartService.kubeConfig, config.FluxPath)
+		if err != nil {
+			return fmt.Errorf("failed to create the Flux engine for the install target: %w", err)
+		}
+		installer.engine = engine
 	}
 
 	err = installer.InstallChartsWithContext(ctx, config)
@@ -526,6 +734,7 @@ func (w *InstallationWorkflow) performInstallationWithRetry(parentCtx context.Co
 	ctx, cancel := context.WithTimeout(parentCtx, 60*time.Minute)
 	defer cancel()
 
+	attempt := 0
 	return retryExecutor.Execute(ctx, func() error {
 		// Check if cancelled before attempting installation
 		select {
@@ -533,6 +742,9 @@ func (w *InstallationWorkflow) performInstallationWithRetry(parentCtx context.Co
 			return ctx.Err()
 		default:
 		}
+		if attempt++; attempt > 1 {
+			metrics.Retry("chart-install")
+		}
 		return w.performInstallation(ctx, config)
 	})
 }
@@ -540,10 +752,18 @@ func (w *InstallationWorkflow) performInstallationWithRetry(parentCtx context.Co
 // InstallChartsWithConfigContext installs charts with the given configuration and context support
 // If KubeConfig is nil, it will be obtained after cluster selection (for standalone chart install)
 func InstallChartsWithConfigContext(ctx context.Context, req types.InstallationRequest) error {
+	_, err := InstallChartsWithResult(ctx, req)
+	return err
+}
+
+// InstallChartsWithResult is InstallChartsWithConfigContext returning what
+// the install did. The result is nil when the install never started
+// (cancelled, missing prerequisites).
+func InstallChartsWithResult(ctx context.Context, req types.InstallationRequest) (*models.InstallResult, error) {
 	// Check if context is already cancelled
 	select {
 	case <-ctx.Done():
-		return fmt.Errorf("chart installation cancelled: %w", ctx.Err())
+		return nil, fmt.Errorf("chart installation cancelled: %w", ctx.Err())
 	default:
 	}
 
@@ -552,13 +772,13 @@ func InstallChartsWithConfigContext(ctx context.Context, req types.InstallationR
 	// prerequisite gate now — the app command group no longer runs a second one).
 	installer := prerequisites.NewInstaller()
 	if err := installer.CheckAndInstallNonInteractive(req.NonInteractive || sharedUI.IsNonInteractive()); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check context again after prerequisites
 	select {
 	case <-ctx.Done():
-		return fmt.Errorf("chart installation cancelled: %w", ctx.Err())
+		return nil, fmt.Errorf("chart installation cancelled: %w", ctx.Err())
 	default:
 	}
 
@@ -568,7 +788,7 @@ func InstallChartsWithConfigContext(ctx context.Context, req types.InstallationR
 		// Create a chart service with the KubeConfig and perform the installation with context
 		chartService, err := NewChartService(req.ClusterAccess, req.KubeConfig, req.DryRun, req.Verbose)
 		if err != nil {
-			return fmt.Errorf("failed to create chart service: %w", err)
+			return nil, fmt.Errorf("failed to create chart service: %w", err)
 		}
 		return chartService.InstallWithContext(ctx, req)
 	}
@@ -577,11 +797,11 @@ func InstallChartsWithConfigContext(ctx context.Context, req types.InstallationR
 	// cluster and resolves its rest.Config, so it needs cluster access injected
 	// by the caller (req 18/19 keeps this out of internal/cluster).
 	if req.ClusterAccess == nil {
-		return fmt.Errorf("cluster access is required to install without an explicit kubeconfig")
+		return nil, fmt.Errorf("cluster access is required to install without an explicit kubeconfig")
 	}
 	chartService, err := NewChartServiceDeferred(req.ClusterAccess, req.DryRun, req.Verbose)
 	if err != nil {
-		return fmt.Errorf("failed to create chart service: %w", err)
+		return nil, fmt.Errorf("failed to create chart service: %w", err)
 	}
 	return chartService.InstallWithContextDeferred(ctx, req)
 }
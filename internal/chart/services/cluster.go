@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	chartUI "github.com/flamingo-stack/openframe-cli/internal/chart/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	clusterDomain "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/pterm/pterm"
 )
 
@@ -61,5 +63,18 @@ func (c *ClusterSelector) SelectCluster(args []string, nonInteractive, verbose b
 		return "", fmt.Errorf("--non-interactive requires a cluster name argument; available clusters: %s", strings.Join(names, ", "))
 	}
 
-	return c.operationsUI.SelectClusterForInstall(clusters, args)
+	name, err := c.operationsUI.SelectClusterForInstall(clusters, args)
+	if err != nil {
+		return name, err
+	}
+	// The install goes ahead on an older cluster, but the applications that
+	// need newer APIs will fail to sync; say why before they do.
+	for _, cl := range clusters {
+		if cl.Name == name {
+			if warning := clusterDomain.KubernetesVersionWarning(cl.K8sVersion, argocd.MinKubernetesVersion()); warning != "" {
+				pterm.Warning.Printf("Cluster %s: %s\n", name, warning)
+			}
+		}
+	}
+	return name, nil
 }
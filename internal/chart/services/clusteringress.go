@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	clusterDomain "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// platformIngressApp is the platform.apps entry that deploys the platform's
+// own ingress-nginx.
+const platformIngressApp = "ingress-nginx"
+
+// clusterIngressTimeout bounds the lookup; an unreachable cluster fails the
+// install soon after anyway.
+const clusterIngressTimeout = 10 * time.Second
+
+// clusterIngressNamespace returns the namespace of the controller cluster
+// create --ingress nginx installed, or "" when the cluster has none.
+func clusterIngressNamespace(ctx context.Context, client kubernetes.Interface) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, clusterIngressTimeout)
+	defer cancel()
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: clusterDomain.ClusterIngressLabel + "=true",
+		Limit:         1,
+	})
+	if err != nil || len(deployments.Items) == 0 {
+		return "", err
+	}
+	return deployments.Items[0].Namespace, nil
+}
+
+// useClusterIngress disables the platform's ingress-nginx when the cluster
+// already runs the one cluster create --ingress nginx installed: both would
+// claim ports 80 and 443 and the nginx ingress class, and the platform's
+// Ingresses are served just as well by the existing controller. The
+// temporary values file is rewritten; the user's own is not touched.
+func useClusterIngress(ctx context.Context, client kubernetes.Interface, chartConfig *types.ChartConfiguration) error {
+	if client == nil || chartConfig == nil || chartConfig.TempHelmValuesPath == "" {
+		return nil
+	}
+	namespace, err := clusterIngressNamespace(ctx, client)
+	if err != nil && ctx.Err() == nil {
+		pterm.Warning.Printf("Could not check the cluster for an existing ingress controller: %v\n", err)
+	}
+	if namespace == "" {
+		return nil
+	}
+	modifier := templates.NewHelmValuesModifier()
+	values := chartConfig.ExistingValues
+	if values == nil {
+		if values, err = modifier.LoadExistingValues(chartConfig.TempHelmValuesPath); err != nil {
+			return err
+		}
+	}
+	modifier.DisablePlatformApp(values, platformIngressApp)
+	if err := modifier.WriteValues(values, chartConfig.TempHelmValuesPath); err != nil {
+		return err
+	}
+	chartConfig.ExistingValues = values
+	pterm.Info.Printf("Using the cluster's ingress-nginx in namespace %s instead of the platform's own\n", namespace)
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	clusterDomain "github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUseClusterIngress(t *testing.T) {
+	valuesFor := func(t *testing.T) *types.ChartConfiguration {
+		path := filepath.Join(t.TempDir(), "values.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("platform:\n  apps:\n    ingress-nginx:\n      enabled: true\n    dev-tools:\n      enabled: true\n"), 0o600))
+		return &types.ChartConfiguration{TempHelmValuesPath: path}
+	}
+
+	t.Run("platform controller kept on a cluster without one", func(t *testing.T) {
+		cfg := valuesFor(t)
+		require.NoError(t, useClusterIngress(context.Background(), fake.NewSimpleClientset(), cfg))
+		data, err := os.ReadFile(cfg.TempHelmValuesPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "enabled: true\n    dev-tools")
+		assert.NotContains(t, string(data), "enabled: false")
+	})
+
+	t.Run("platform controller disabled next to the cluster's", func(t *testing.T) {
+		cfg := valuesFor(t)
+		client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress-nginx-controller",
+			Namespace: "ingress-nginx",
+			Labels:    map[string]string{clusterDomain.ClusterIngressLabel: "true"},
+		}})
+		require.NoError(t, useClusterIngress(context.Background(), client, cfg))
+
+		apps := cfg.ExistingValues["platform"].(map[string]interface{})["apps"].(map[string]interface{})
+		assert.Equal(t, false, apps["ingress-nginx"].(map[string]interface{})["enabled"])
+		assert.Equal(t, true, apps["dev-tools"].(map[string]interface{})["enabled"])
+		data, err := os.ReadFile(cfg.TempHelmValuesPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "enabled: false", "the file the install reads is rewritten")
+	})
+}
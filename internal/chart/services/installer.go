@@ -3,28 +3,165 @@ package services
 import (
 	"context"
 	stderrors "errors"
+	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/gitops"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/credentials"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
+	"github.com/pterm/pterm"
 )
 
 // Installer orchestrates the chart installation process
 type Installer struct {
 	argoCDService    types.ArgoCDService
 	appOfAppsService types.AppOfAppsService
+	// registryAuth, when set, injects private-registry pull credentials
+	// around the ArgoCD and app-of-apps installs. nil skips it.
+	registryAuth RegistryAuthStep
+	// engine, when set, deploys the platform instead of ArgoCD + app-of-apps
+	// (--gitops-engine flux). nil is the built-in ArgoCD path.
+	engine gitops.Engine
+	// readinessGates, when set, is waited for after every application is
+	// ready. nil means applications ready = install complete.
+	readinessGates ReadinessGateStep
+	// progress, when set, records completed steps and lets a re-run skip the
+	// ones still in place. nil always runs every step.
+	progress *InstallProgress
+	// syncPauser, when set, lets a cancelled application wait pause auto-sync
+	// on the app-of-apps (--pause-sync-on-cancel). nil leaves it running.
+	syncPauser SyncPauser
+	// result, when set, records what the install did (see InstallResult);
+	// apps lists the applications for it once they are ready. Either may be
+	// nil.
+	result *models.InstallResult
+	apps   ApplicationLister
 }
 
 // InstallChartsWithContext handles the complete chart installation process with context support
 func (i *Installer) InstallChartsWithContext(ctx context.Context, config config.ChartInstallConfig) error {
+	if err := i.prepareResume(ctx, config); err != nil {
+		return errors.NewChartError("resuming", "interrupted install", err).WithCluster(config.ClusterName)
+	}
+	if i.engine != nil {
+		return i.installWithEngine(ctx, config)
+	}
+	phases := config.Phases
+	if err := i.injectRegistryAuth(ctx, config, RegistryAuthStep.BeforeArgoCD); err != nil {
+		return err
+	}
+
 	// Install ArgoCD first
-	if err := i.argoCDService.Install(ctx, config); err != nil {
-		return errors.WrapAsChartError("installation", "ArgoCD", err).WithCluster(config.ClusterName)
+	if selected(phases, models.PhaseArgoCD, "ArgoCD install") {
+		if err := i.installArgoCD(ctx, config); err != nil {
+			return err
+		}
 	}
 
 	// Install app-of-apps from GitHub repository if configured
 	if config.HasAppOfApps() {
+		if selected(phases, models.PhaseAppOfApps, "app-of-apps install") {
+			if err := i.installAppOfApps(ctx, config); err != nil {
+				return err
+			}
+		}
+		if err := i.injectRegistryAuth(ctx, config, RegistryAuthStep.AfterAppOfApps); err != nil {
+			return err
+		}
+
+		if selected(phases, models.PhaseSync, "application wait") {
+			// Wait for all ArgoCD applications to be ready after app-of-apps installation
+			// Note: This is NOT a recoverable error - ArgoCD and app-of-apps are already installed,
+			// so retrying would reinstall them unnecessarily. WaitForApplications has its own internal retry logic.
+			telemetry.EnterPhase(telemetry.PhaseArgoCDSync)
+			started := time.Now()
+			if err := i.argoCDService.WaitForApplications(ctx, config); err != nil {
+				if ctx.Err() != nil {
+					i.interrupt(ctx, config)
+				}
+				// Create a new non-recoverable error (don't use WrapAsChartError which preserves existing ChartError's Recoverable flag)
+				return errors.NewChartError("waiting", "ArgoCD applications", err).WithCluster(config.ClusterName)
+			}
+			i.result.AddPhase(telemetry.PhaseArgoCDSync, started, false)
+			timeline.Mark("all applications ready")
+			i.reportApplications(ctx, config)
+
+			if err := i.waitForReadinessGates(ctx, config); err != nil {
+				return err
+			}
+		}
+	}
+
+	i.finishInterrupted(config)
+	return nil
+}
+
+// selected reports whether phase is part of the --only/--skip selection,
+// saying what is left out when it is not.
+func selected(phases *models.PhaseSelection, phase, what string) bool {
+	if phases.Runs(phase) {
+		return true
+	}
+	pterm.Info.Printf("Skipping the %s (%s not selected by --only/--skip)\n", what, phase)
+	return false
+}
+
+// injectRegistryAuth runs one of the registry credential steps, unless there
+// are no credentials, this is a dry run or the phase is not selected.
+func (i *Installer) injectRegistryAuth(ctx context.Context, config config.ChartInstallConfig, step func(RegistryAuthStep, context.Context) error) error {
+	if i.registryAuth == nil || config.DryRun || !config.Phases.Runs(models.PhaseRegistryAuth) {
+		return nil
+	}
+	telemetry.EnterPhase(telemetry.PhaseRegistryAuth)
+	started := time.Now()
+	if err := step(i.registryAuth, ctx); err != nil {
+		return errors.WrapAsChartError("installation", "registry credentials", err).WithCluster(config.ClusterName)
+	}
+	i.result.AddPhase(telemetry.PhaseRegistryAuth, started, false)
+	return nil
+}
+
+// installArgoCD installs the ArgoCD chart, unless a previous run left it in
+// place.
+func (i *Installer) installArgoCD(ctx context.Context, config config.ChartInstallConfig) error {
+	telemetry.EnterPhase(telemetry.PhaseArgoCD)
+	started := time.Now()
+	if i.satisfied(ctx, config, stepArgoCD) {
+		pterm.Info.Printf("ArgoCD %s already installed by a previous run, skipping (--force reinstalls)\n", argocd.ArgoCDChartVersion)
+		i.result.AddPhase(telemetry.PhaseArgoCD, started, true)
+	} else {
+		if err := i.argoCDService.Install(ctx, config); err != nil {
+			return errors.WrapAsChartError("installation", "ArgoCD", err).WithCluster(config.ClusterName)
+		}
+		i.complete(config, stepArgoCD)
+		i.result.AddPhase(telemetry.PhaseArgoCD, started, false)
+	}
+	switch {
+	case config.DryRun:
+	case credentials.Stored(credentials.ArgoCDAdminName):
+		i.result.AddCredential(models.ArgoCDStoredAdminCredential)
+	default:
+		i.result.AddCredential(models.ArgoCDAdminCredential)
+	}
+	timeline.Mark("ArgoCD installed")
+	return nil
+}
+
+// installAppOfApps installs the app-of-apps chart, unless a previous run
+// left it in place.
+func (i *Installer) installAppOfApps(ctx context.Context, config config.ChartInstallConfig) error {
+	telemetry.EnterPhase(telemetry.PhaseAppOfApps)
+	started := time.Now()
+	if i.satisfied(ctx, config, stepAppOfApps) {
+		pterm.Info.Printf("app-of-apps for ref '%s' already installed by a previous run, skipping (--force reinstalls)\n", config.AppOfApps.GitHubBranch)
+		i.result.AddPhase(telemetry.PhaseAppOfApps, started, true)
+	} else {
 		if err := i.appOfAppsService.Install(ctx, config); err != nil {
 			// Check if this is a branch not found error
 			var bnfErr *sharedErrors.BranchNotFoundError
@@ -33,15 +170,118 @@ func (i *Installer) InstallChartsWithContext(ctx context.Context, config config.
 			}
 			return errors.WrapAsChartError("installation", "app-of-apps", err).WithCluster(config.ClusterName)
 		}
+		i.complete(config, stepAppOfApps)
+		i.result.AddPhase(telemetry.PhaseAppOfApps, started, false)
+		if i.result != nil {
+			i.result.Commit = config.AppOfApps.Commit
+		}
+	}
+	timeline.Mark("app-of-apps installed")
+	return nil
+}
+
+// waitForReadinessGates waits for the operator-defined gates. Like the
+// application wait it is not recoverable: everything is installed already.
+func (i *Installer) waitForReadinessGates(ctx context.Context, config config.ChartInstallConfig) error {
+	if i.readinessGates == nil || config.DryRun {
+		return nil
+	}
+	if err := i.readinessGates.Wait(ctx, config); err != nil {
+		return errors.NewChartError("waiting", "readiness gates", err).WithCluster(config.ClusterName)
+	}
+	timeline.Mark("readiness gates passed")
+	return nil
+}
+
+// satisfied reports whether step can be skipped: a previous run recorded it
+// with the same inputs AND its release is still deployed in the cluster (an
+// uninstall or a failed helm upgrade since then re-runs it). --force and
+// dry runs never skip.
+func (i *Installer) satisfied(ctx context.Context, config config.ChartInstallConfig, step string) bool {
+	if i.progress == nil || config.Force || config.DryRun {
+		return false
+	}
+	if !i.progress.Done(step, stepFingerprint(step, config)) {
+		return false
+	}
+	var (
+		info models.ChartInfo
+		err  error
+	)
+	switch step {
+	case stepArgoCD:
+		info, err = i.argoCDService.GetStatus(ctx)
+		if err == nil && info.Version != argocd.ArgoCDChartVersion {
+			return false
+		}
+	case stepAppOfApps:
+		info, err = i.appOfAppsService.GetStatus(ctx, config.AppOfApps.Namespace)
+	default:
+		return false
+	}
+	return err == nil && info.Status == "deployed"
+}
+
+// complete records step as done, unless this is a dry run.
+func (i *Installer) complete(config config.ChartInstallConfig, step string) {
+	if i.progress != nil && !config.DryRun {
+		i.progress.Complete(step, stepFingerprint(step, config))
+	}
+}
+
+// installWithEngine runs the same three steps through a pluggable GitOps
+// engine: controllers, platform source, readiness wait. Registry credentials
+// are injected up front only — the per-application namespaces are an ArgoCD
+// notion. Flux is the only such engine, so its phase names are recorded.
+func (i *Installer) installWithEngine(ctx context.Context, config config.ChartInstallConfig) error {
+	name := i.engine.Name()
+	phases := config.Phases
+	if err := i.injectRegistryAuth(ctx, config, RegistryAuthStep.BeforeArgoCD); err != nil {
+		return err
+	}
 
-		// Wait for all ArgoCD applications to be ready after app-of-apps installation
-		// Note: This is NOT a recoverable error - ArgoCD and app-of-apps are already installed,
-		// so retrying would reinstall them unnecessarily. WaitForApplications has its own internal retry logic.
-		if err := i.argoCDService.WaitForApplications(ctx, config); err != nil {
-			// Create a new non-recoverable error (don't use WrapAsChartError which preserves existing ChartError's Recoverable flag)
-			return errors.NewChartError("waiting", "ArgoCD applications", err).WithCluster(config.ClusterName)
+	if selected(phases, models.PhaseArgoCD, name+" install") {
+		telemetry.EnterPhase(telemetry.PhaseFlux)
+		started := time.Now()
+		if err := i.engine.InstallController(ctx, config); err != nil {
+			return errors.WrapAsChartError("installation", name, err).WithCluster(config.ClusterName)
 		}
+		i.result.AddPhase(telemetry.PhaseFlux, started, false)
+		timeline.Mark(name + " installed")
 	}
 
+	if !config.HasAppOfApps() {
+		i.finishInterrupted(config)
+		return nil
+	}
+	if selected(phases, models.PhaseAppOfApps, name+" platform source") {
+		telemetry.EnterPhase(telemetry.PhaseFluxSource)
+		started := time.Now()
+		if err := i.engine.DeployPlatform(ctx, config); err != nil {
+			return errors.WrapAsChartError("installation", name+" platform source", err).WithCluster(config.ClusterName)
+		}
+		i.result.AddPhase(telemetry.PhaseFluxSource, started, false)
+		timeline.Mark(name + " platform source applied")
+	}
+
+	if !selected(phases, models.PhaseSync, name+" resource wait") {
+		i.finishInterrupted(config)
+		return nil
+	}
+	// Like the ArgoCD wait: not recoverable, the controllers and source are in.
+	telemetry.EnterPhase(telemetry.PhaseFluxSync)
+	started := time.Now()
+	if err := i.engine.WaitForApplications(ctx, config); err != nil {
+		if ctx.Err() != nil {
+			i.interrupt(ctx, config)
+		}
+		return errors.NewChartError("waiting", name+" resources", err).WithCluster(config.ClusterName)
+	}
+	i.result.AddPhase(telemetry.PhaseFluxSync, started, false)
+	timeline.Mark("all applications ready")
+	if err := i.waitForReadinessGates(ctx, config); err != nil {
+		return err
+	}
+	i.finishInterrupted(config)
 	return nil
 }
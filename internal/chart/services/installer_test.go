@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/errors"
 	sharedErrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
@@ -350,3 +351,115 @@ func TestInstaller_InstallCharts_ErrorTypes(t *testing.T) {
 		})
 	}
 }
+
+// fakeGates is a ReadinessGateStep returning err.
+type fakeGates struct {
+	err    error
+	called bool
+}
+
+func (f *fakeGates) Wait(context.Context, config.ChartInstallConfig) error {
+	f.called = true
+	return f.err
+}
+
+func TestInstaller_ReadinessGatesAfterApplications(t *testing.T) {
+	cfg := config.ChartInstallConfig{
+		ClusterName: "test-cluster",
+		AppOfApps:   &models.AppOfAppsConfig{GitHubRepo: "owner/repo"},
+	}
+	mockArgoCD := new(MockArgoCDService)
+	mockAppOfApps := new(MockAppOfAppsService)
+	mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockAppOfApps.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).Return(nil)
+	gates := &fakeGates{err: assert.AnError}
+
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, readinessGates: gates}
+	err := installer.InstallChartsWithContext(context.Background(), cfg)
+
+	assert.True(t, gates.called)
+	assert.ErrorContains(t, err, "waiting failed for readiness gates")
+	var chartErr *errors.ChartError
+	if assert.True(t, stderrors.As(err, &chartErr)) {
+		assert.False(t, chartErr.IsRecoverable(), "everything is installed; retrying would reinstall")
+	}
+}
+
+type fakeApps struct {
+	apps []argocd.Application
+	err  error
+}
+
+func (f *fakeApps) ListApplications(context.Context, bool) ([]argocd.Application, error) {
+	return f.apps, f.err
+}
+
+func TestInstaller_RecordsResult(t *testing.T) {
+	cfg := config.ChartInstallConfig{
+		ClusterName: "test-cluster",
+		AppOfApps:   &models.AppOfAppsConfig{GitHubRepo: "owner/repo"},
+	}
+	mockArgoCD := new(MockArgoCDService)
+	mockAppOfApps := new(MockAppOfAppsService)
+	mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockAppOfApps.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).Return(nil)
+	result := &models.InstallResult{}
+	apps := &fakeApps{apps: []argocd.Application{{Name: "api", Sync: "Synced", Health: "Healthy"}}}
+
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, result: result, apps: apps}
+	assert.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+
+	var phases []string
+	for _, p := range result.Phases {
+		phases = append(phases, p.Name)
+		assert.False(t, p.Skipped)
+	}
+	assert.Equal(t, []string{"argocd-install", "app-of-apps", "argocd-sync"}, phases)
+	assert.Equal(t, []models.ApplicationResult{{Name: "api", Sync: "Synced", Health: "Healthy"}}, result.Applications)
+	assert.Equal(t, []models.CredentialRef{models.ArgoCDAdminCredential}, result.Credentials)
+	assert.Empty(t, result.Warnings)
+
+	// A listing that fails is a warning, not a failed install.
+	result.ResetAttempt()
+	apps.err = assert.AnError
+	assert.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+	assert.Empty(t, result.Applications)
+	assert.Len(t, result.Warnings, 1)
+}
+
+func TestInstaller_PhaseSelection(t *testing.T) {
+	cfg := config.ChartInstallConfig{
+		ClusterName: "test-cluster",
+		AppOfApps:   &models.AppOfAppsConfig{GitHubRepo: "owner/repo"},
+	}
+	mockArgoCD := new(MockArgoCDService)
+	mockAppOfApps := new(MockAppOfAppsService)
+	mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockAppOfApps.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).Return(nil)
+	result := &models.InstallResult{}
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, result: result}
+
+	var err error
+	cfg.Phases, err = models.ParsePhaseSelection([]string{"argocd"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+	mockArgoCD.AssertCalled(t, "Install", mock.Anything, mock.Anything)
+	mockAppOfApps.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+	mockArgoCD.AssertNotCalled(t, "WaitForApplications", mock.Anything, mock.Anything)
+
+	// Skipping app-of-apps still waits for the applications already there.
+	result.ResetAttempt()
+	cfg.Phases, err = models.ParsePhaseSelection(nil, []string{"app-of-apps"})
+	assert.NoError(t, err)
+	assert.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+	mockAppOfApps.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+	mockArgoCD.AssertCalled(t, "WaitForApplications", mock.Anything, mock.Anything)
+	var phases []string
+	for _, p := range result.Phases {
+		phases = append(phases, p.Name)
+	}
+	assert.Equal(t, []string{"argocd-install", "argocd-sync"}, phases)
+}
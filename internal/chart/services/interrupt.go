@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
+	"github.com/pterm/pterm"
+)
+
+// interruptTimeout bounds the teardown after Ctrl+C: the user is waiting for
+// the prompt back, and a cluster that does not answer in time is left as is.
+const interruptTimeout = 15 * time.Second
+
+// SyncPauser pauses and restores auto-sync on the root app-of-apps. It is
+// implemented by *argocd.Manager.
+type SyncPauser interface {
+	PauseAutoSync(ctx context.Context) (string, error)
+	ResumeAutoSync(ctx context.Context, policy string) error
+}
+
+// interrupt handles an install cancelled during the application wait: with
+// --pause-sync-on-cancel it pauses auto-sync on the app-of-apps so ArgoCD
+// stops rolling out children nobody is watching, then records the install as
+// interrupted and prints the command that resumes it. The cancelled ctx is
+// only used for its values.
+func (i *Installer) interrupt(ctx context.Context, config config.ChartInstallConfig) {
+	if config.DryRun {
+		return
+	}
+	paused := ""
+	if config.PauseSyncOnCancel && i.syncPauser != nil && i.engine == nil {
+		teardownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), interruptTimeout)
+		defer cancel()
+		policy, err := i.syncPauser.PauseAutoSync(teardownCtx)
+		switch {
+		case err != nil:
+			pterm.Warning.Printf("Could not pause auto-sync on %s: %v\n", argocd.AppOfAppsName, err)
+		case policy != "":
+			paused = policy
+			pterm.Info.Printf("Paused auto-sync on %s; resuming the install turns it back on\n", argocd.AppOfAppsName)
+		}
+	}
+	if i.progress != nil {
+		i.progress.MarkInterrupted(telemetry.CurrentPhase(), paused)
+	}
+	pterm.Warning.Println("Install interrupted before all applications were ready. Resume it with:")
+	pterm.Printf("  %s\n", resumeCommand(config))
+}
+
+// prepareResume runs before the install steps. An install interrupted with
+// auto-sync paused gets it back first, whether or not --resume was given:
+// otherwise the application wait could never finish. --resume itself insists
+// there is an interrupted install to resume.
+func (i *Installer) prepareResume(ctx context.Context, config config.ChartInstallConfig) error {
+	if config.DryRun {
+		return nil
+	}
+	var rec *Interruption
+	if i.progress != nil {
+		rec = i.progress.Interrupted
+	}
+	if config.Resume {
+		if rec == nil {
+			return fmt.Errorf("nothing to resume: no interrupted install recorded for '%s'", installTarget(config))
+		}
+		pterm.Info.Printf("Resuming the install interrupted during %s at %s\n", rec.Phase, rec.At.Local().Format(time.DateTime))
+	}
+	if rec == nil || rec.PausedSync == "" || i.syncPauser == nil {
+		return nil
+	}
+	if err := i.syncPauser.ResumeAutoSync(ctx, rec.PausedSync); err != nil {
+		return fmt.Errorf("restoring auto-sync paused by the interrupted install: %w", err)
+	}
+	pterm.Info.Printf("Restored auto-sync on %s\n", argocd.AppOfAppsName)
+	rec.PausedSync = ""
+	i.progress.save()
+	return nil
+}
+
+// finishInterrupted drops the interruption record once the install completed.
+func (i *Installer) finishInterrupted(config config.ChartInstallConfig) {
+	if i.progress != nil && !config.DryRun {
+		i.progress.ClearInterrupted()
+	}
+}
+
+// resumeCommand is the command line that picks an interrupted install up
+// where it stopped: same target, ref, repository and prompt mode.
+func resumeCommand(config config.ChartInstallConfig) string {
+	args := []string{"openframe", "app", "install"}
+	if config.KubeContext != "" {
+		args = append(args, "--context", config.KubeContext)
+	} else if config.ClusterName != "" {
+		args = append(args, config.ClusterName)
+	}
+	if config.AppOfApps != nil {
+		if repo := config.AppOfApps.GitHubRepo; repo != "" && repo != models.RepoOSSTenant {
+			args = append(args, "--github-repo", repo)
+		}
+		if ref := config.AppOfApps.GitHubBranch; ref != "" && ref != models.DefaultGitBranch {
+			args = append(args, "--ref", ref)
+		}
+	}
+	if config.NonInteractive {
+		args = append(args, "--non-interactive")
+	}
+	return strings.Join(append(args, "--resume"), " ")
+}
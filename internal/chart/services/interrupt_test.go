@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePauser records what the installer paused and restored.
+type fakePauser struct {
+	policy   string
+	paused   bool
+	restored string
+}
+
+func (f *fakePauser) PauseAutoSync(ctx context.Context) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	f.paused = true
+	return f.policy, nil
+}
+
+func (f *fakePauser) ResumeAutoSync(_ context.Context, policy string) error {
+	f.restored = policy
+	return nil
+}
+
+func TestInstaller_CancelledWaitIsRecordedAndResumed(t *testing.T) {
+	cfg := resumeConfig(t)
+	cfg.PauseSyncOnCancel = true
+	pauser := &fakePauser{policy: `{"selfHeal":true}`}
+	deployed := models.ChartInfo{Status: "deployed"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockArgoCD := new(MockArgoCDService)
+	mockAppOfApps := new(MockAppOfAppsService)
+	mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockAppOfApps.On("Install", mock.Anything, mock.Anything).Return(nil)
+	mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).Return(context.Canceled)
+
+	progress, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, progress: progress, syncPauser: pauser}
+	require.Error(t, installer.InstallChartsWithContext(ctx, cfg))
+	assert.True(t, pauser.paused, "the teardown runs after the install context is cancelled")
+
+	saved, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	require.NotNil(t, saved.Interrupted)
+	assert.Equal(t, `{"selfHeal":true}`, saved.Interrupted.PausedSync)
+
+	// --resume: auto-sync comes back before the wait, the record goes after it.
+	cfg.Resume = true
+	mockArgoCD = new(MockArgoCDService)
+	mockArgoCD.On("GetStatus", mock.Anything).Return(models.ChartInfo{Status: "deployed", Version: argocd.ArgoCDChartVersion}, nil)
+	mockAppOfApps = new(MockAppOfAppsService)
+	mockAppOfApps.On("GetStatus", mock.Anything, mock.Anything).Return(deployed, nil).Maybe()
+	mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).Return(nil)
+	installer = &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, progress: saved, syncPauser: pauser}
+	require.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+	assert.Equal(t, `{"selfHeal":true}`, pauser.restored)
+	mockArgoCD.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+	mockAppOfApps.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+
+	saved, err = LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	assert.Nil(t, saved.Interrupted, "a finished install is no longer interrupted")
+}
+
+func TestInstaller_RunWithoutSyncFinishesInterruption(t *testing.T) {
+	cfg := resumeConfig(t)
+	progress, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	progress.MarkInterrupted("argocd-sync", "")
+	cfg.Phases, err = models.ParsePhaseSelection([]string{"argocd"}, nil)
+	require.NoError(t, err)
+
+	mockArgoCD := new(MockArgoCDService)
+	mockArgoCD.On("GetStatus", mock.Anything).Return(models.ChartInfo{}, nil).Maybe()
+	mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: new(MockAppOfAppsService), progress: progress}
+	require.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+	mockArgoCD.AssertNotCalled(t, "WaitForApplications", mock.Anything, mock.Anything)
+
+	saved, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	assert.Nil(t, saved.Interrupted, "an install that ran its selected phases is no longer interrupted")
+}
+
+func TestInstaller_ResumeNeedsAnInterruptedInstall(t *testing.T) {
+	cfg := resumeConfig(t)
+	cfg.Resume = true
+	progress, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+
+	mockArgoCD := new(MockArgoCDService)
+	installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: new(MockAppOfAppsService), progress: progress}
+	err = installer.InstallChartsWithContext(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to resume")
+	mockArgoCD.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+}
+
+func TestResumeCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ChartInstallConfig
+		want string
+	}{
+		{
+			name: "named cluster, default ref",
+			cfg:  config.ChartInstallConfig{ClusterName: "dev", AppOfApps: &models.AppOfAppsConfig{GitHubRepo: models.RepoOSSTenant, GitHubBranch: models.DefaultGitBranch}},
+			want: "openframe app install dev --resume",
+		},
+		{
+			name: "context, pinned ref, no prompts",
+			cfg:  config.ChartInstallConfig{ClusterName: "dev", KubeContext: "prod", NonInteractive: true, AppOfApps: &models.AppOfAppsConfig{GitHubRepo: models.RepoOSSTenant, GitHubBranch: "v1.2.3"}},
+			want: "openframe app install --context prod --ref v1.2.3 --non-interactive --resume",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resumeCommand(tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/valueschema"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+)
+
+// applyValuesOverlays lays the overlay files, in order, over the values the
+// install will use, the way helm merges repeated -f files: maps merge,
+// anything else replaces. The temporary values file is rewritten in place, so
+// every later step — pre-flight, the app-of-apps install, the repository
+// check — sees the merged values. The user's own values file is not touched.
+// Each overlay is checked against the bundled values schema before it is
+// merged, so a finding names the overlay file and its own line numbers.
+func applyValuesOverlays(chartConfig *types.ChartConfiguration, overlays []string) error {
+	if len(overlays) == 0 || chartConfig == nil || chartConfig.TempHelmValuesPath == "" {
+		return nil
+	}
+	modifier := templates.NewHelmValuesModifier()
+	values, err := modifier.LoadExistingValues(chartConfig.TempHelmValuesPath)
+	if err != nil {
+		return err
+	}
+	for _, path := range overlays {
+		if err := validateValuesSchema(path, valueschema.Bundled()); err != nil {
+			return err
+		}
+		overlay, err := modifier.LoadExistingValues(path)
+		if err != nil {
+			return fmt.Errorf("values overlay %s: %w", path, err)
+		}
+		overlayValues(values, overlay)
+	}
+	if err := modifier.WriteValues(values, chartConfig.TempHelmValuesPath); err != nil {
+		return err
+	}
+	chartConfig.ExistingValues = values
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyValuesOverlays(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	temp := write("helm-values-tmp.yaml", "repository:\n  branch: main\nregistry:\n  docker:\n    username: me\n")
+	demo := write("demo.yaml", "registry:\n  docker:\n    password: s3cret\ningress: ngrok\n")
+	small := write("small.yaml", "ingress: localhost\n")
+	chartConfig := &types.ChartConfiguration{TempHelmValuesPath: temp}
+
+	require.NoError(t, applyValuesOverlays(chartConfig, []string{demo, small}))
+
+	data, err := os.ReadFile(temp)
+	require.NoError(t, err)
+	var got map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, map[string]interface{}{
+		"repository": map[string]interface{}{"branch": "main"},
+		"registry":   map[string]interface{}{"docker": map[string]interface{}{"username": "me", "password": "s3cret"}},
+		"ingress":    "localhost",
+	}, got, "maps merge and later files win, like helm -f")
+	assert.Equal(t, got, chartConfig.ExistingValues)
+
+	err = applyValuesOverlays(chartConfig, []string{filepath.Join(dir, "missing.yaml")})
+	assert.ErrorContains(t, err, "values overlay")
+
+	typo := write("typo.yaml", "registry:\n  docker:\n    pasword: s3cret\n")
+	err = applyValuesOverlays(chartConfig, []string{typo})
+	assert.ErrorContains(t, err, typo+":3: registry.docker.pasword", "checked against the schema before it is merged")
+	after, rerr := os.ReadFile(temp)
+	require.NoError(t, rerr)
+	assert.Equal(t, data, after, "a rejected overlay is not merged")
+}
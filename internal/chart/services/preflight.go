@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/valueschema"
 	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
 )
 
@@ -12,11 +13,21 @@ import (
 // a malformed `argocd:` override costs a full k3d cluster create (minutes)
 // before the chart install rejects the same file (0.4.9 verification
 // observation). The install workflow re-validates the temp values file it
-// actually feeds to helm; this is the earliest, cheapest gate.
+// actually feeds to helm; this is the earliest, cheapest gate. It also checks
+// the file against the bundled values schema, so a typo such as `ingres:`
+// fails here rather than as an application wait that never finishes.
 func ValidateHelmValuesFile() error {
 	path := config.NewPathResolver().GetHelmValuesFile()
 	if err := argocd.ValidateUserValuesFile(path); err != nil {
 		return fmt.Errorf("helm values pre-flight failed: %w", err)
 	}
+	return validateValuesSchema(path, valueschema.Bundled())
+}
+
+// validateValuesSchema checks the user's values file at path against schema.
+func validateValuesSchema(path string, schema *valueschema.Schema) error {
+	if err := valueschema.ValidateFile(path, schema); err != nil {
+		return fmt.Errorf("helm values pre-flight failed: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/readiness"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/rest"
+)
+
+// ReadinessGateStep waits for the operator-defined readiness gates once every
+// application is ready.
+type ReadinessGateStep interface {
+	Wait(ctx context.Context, cfg config.ChartInstallConfig) error
+}
+
+// gateWaiter checks a set of gates; *readiness.Checker in a real install.
+type gateWaiter interface {
+	Wait(ctx context.Context, cfg *readiness.Config, progress func([]readiness.Status)) error
+}
+
+// readinessGateWait is the ReadinessGateStep used by a real install.
+type readinessGateWait struct {
+	checker gateWaiter
+	gates   *readiness.Config
+}
+
+// NewReadinessGateStep loads the gates file at path and returns the step that
+// waits for them on the cluster behind kubeConfig, or nil when there are no
+// gates.
+func NewReadinessGateStep(kubeConfig *rest.Config, path string) (ReadinessGateStep, error) {
+	if path == "" {
+		return nil, nil
+	}
+	gates, err := readiness.Load(path)
+	if err != nil || gates == nil || len(gates.Gates) == 0 {
+		return nil, err
+	}
+	checker, err := readiness.NewChecker(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &readinessGateWait{checker: checker, gates: gates}, nil
+}
+
+// Wait implements ReadinessGateStep.
+func (r *readinessGateWait) Wait(ctx context.Context, cfg config.ChartInstallConfig) error {
+	total := len(r.gates.Gates)
+	var sp *spinner.Spinner
+	if !cfg.Silent && !cfg.NonInteractive {
+		sp = spinner.Start(fmt.Sprintf("Waiting for %d readiness gate(s)...", total))
+	} else {
+		pterm.Info.Printf("Waiting for %d readiness gate(s)...\n", total)
+	}
+	err := r.checker.Wait(ctx, r.gates, func(statuses []readiness.Status) {
+		if sp == nil {
+			return
+		}
+		ready := 0
+		for _, s := range statuses {
+			if s.Ready {
+				ready++
+			}
+		}
+		sp.UpdateText(fmt.Sprintf("Waiting for readiness gates: %d/%d passed...", ready, total))
+	})
+	if err != nil {
+		if sp != nil {
+			sp.Fail("Readiness gates did not pass")
+		}
+		return err
+	}
+	if sp != nil {
+		sp.Success(fmt.Sprintf("All %d readiness gate(s) passed", total))
+	} else {
+		pterm.Success.Printf("All %d readiness gate(s) passed\n", total)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/registry"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// RegistryAuthStep injects private-registry pull credentials at the two points
+// of an install where new namespaces appear: around the ArgoCD install (the
+// argocd namespace plus any namespaces the user listed) and after the
+// app-of-apps is applied (the namespaces its applications deploy to).
+type RegistryAuthStep interface {
+	BeforeArgoCD(ctx context.Context) error
+	AfterAppOfApps(ctx context.Context) error
+}
+
+// namespaceLister lists the namespaces ArgoCD applications deploy into.
+type namespaceLister interface {
+	DestinationNamespaces(ctx context.Context) ([]string, error)
+}
+
+// appNamespaceWait bounds how long AfterAppOfApps waits for the app-of-apps to
+// create its child applications.
+const appNamespaceWait = 2 * time.Minute
+
+// registryAuthInjection is the RegistryAuthStep used by a real install.
+type registryAuthInjection struct {
+	injector   *registry.Injector
+	apps       namespaceLister
+	namespaces []string
+
+	// pollInterval and wait override the application-namespace polling
+	// cadence and bound. Zero means the defaults; tests set tiny values.
+	pollInterval time.Duration
+	wait         time.Duration
+}
+
+// NewRegistryAuthStep returns the injection step for auth on the cluster behind
+// kubeConfig, or nil when there are no credentials to inject.
+func NewRegistryAuthStep(kubeConfig *rest.Config, auth *models.RegistryAuthConfig, apps *argocd.Manager) (RegistryAuthStep, error) {
+	if auth.IsEmpty() {
+		return nil, nil
+	}
+	if kubeConfig == nil {
+		return nil, fmt.Errorf("registry credentials need a resolved cluster connection")
+	}
+	client, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	return &registryAuthInjection{
+		injector:   registry.NewInjector(client, auth),
+		apps:       apps,
+		namespaces: append([]string{argocd.ArgoCDNamespace}, auth.Namespaces...),
+	}, nil
+}
+
+// BeforeArgoCD pre-creates the argocd namespace and the user-listed namespaces
+// with the pull secret, so the very first pods can already pull.
+func (r *registryAuthInjection) BeforeArgoCD(ctx context.Context) error {
+	done, err := r.injector.Inject(ctx, r.namespaces)
+	if err != nil {
+		return fmt.Errorf("injecting registry credentials: %w", err)
+	}
+	pterm.Info.Printf("Registry pull secret %q added to %d namespace(s)\n", models.RegistryPullSecretName, len(done))
+	return nil
+}
+
+// AfterAppOfApps covers the application namespaces (and re-attaches the secret
+// to service accounts created since, e.g. by the ArgoCD chart). ArgoCD creates
+// the child applications asynchronously once the app-of-apps syncs, so it polls
+// until their namespace set stops growing (bounded by appNamespaceWait). A
+// failure to list applications is only a warning: the explicitly requested
+// namespaces are already covered.
+func (r *registryAuthInjection) AfterAppOfApps(ctx context.Context) error {
+	namespaces := r.settledDestinationNamespaces(ctx)
+	done, err := r.injector.Inject(ctx, append(append([]string{}, r.namespaces...), namespaces...))
+	if err != nil {
+		return fmt.Errorf("injecting registry credentials: %w", err)
+	}
+	pterm.Debug.Printf("Registry pull secret present in namespaces: %v\n", done)
+	return nil
+}
+
+// settledDestinationNamespaces returns the application destination namespaces
+// once two consecutive polls agree (or the wait runs out).
+func (r *registryAuthInjection) settledDestinationNamespaces(ctx context.Context) []string {
+	interval, limit := r.pollInterval, r.wait
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+	if limit == 0 {
+		limit = appNamespaceWait
+	}
+	deadline := time.Now().Add(limit)
+	var last []string
+	for {
+		namespaces, err := r.apps.DestinationNamespaces(ctx)
+		if err != nil {
+			pterm.Warning.Printf("Could not list application namespaces for registry credentials: %v\n", err)
+			return last
+		}
+		if len(namespaces) > 0 && len(namespaces) == len(last) {
+			return namespaces
+		}
+		last = namespaces
+		if time.Now().After(deadline) {
+			return last
+		}
+		select {
+		case <-ctx.Done():
+			return last
+		case <-time.After(interval):
+		}
+	}
+}
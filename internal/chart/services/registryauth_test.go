@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/registry"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// recordingRegistryStep records when each injection point runs relative to the
+// ArgoCD and app-of-apps installs.
+type recordingRegistryStep struct{ calls *[]string }
+
+func (r recordingRegistryStep) BeforeArgoCD(context.Context) error {
+	*r.calls = append(*r.calls, "registry-before")
+	return nil
+}
+
+func (r recordingRegistryStep) AfterAppOfApps(context.Context) error {
+	*r.calls = append(*r.calls, "registry-after")
+	return nil
+}
+
+func TestInstaller_RegistryAuthRunsAroundInstalls(t *testing.T) {
+	var calls []string
+	argoCD := new(MockArgoCDService)
+	appOfApps := new(MockAppOfAppsService)
+	argoCD.On("Install", mock.Anything, mock.Anything).Run(func(mock.Arguments) { calls = append(calls, "argocd") }).Return(nil)
+	appOfApps.On("Install", mock.Anything, mock.Anything).Run(func(mock.Arguments) { calls = append(calls, "app-of-apps") }).Return(nil)
+	argoCD.On("WaitForApplications", mock.Anything, mock.Anything).Run(func(mock.Arguments) { calls = append(calls, "wait") }).Return(nil)
+
+	installer := &Installer{argoCDService: argoCD, appOfAppsService: appOfApps, registryAuth: recordingRegistryStep{&calls}}
+	err := installer.InstallChartsWithContext(context.Background(), config.ChartInstallConfig{
+		AppOfApps: &models.AppOfAppsConfig{GitHubRepo: "owner/repo"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry-before", "argocd", "app-of-apps", "registry-after", "wait"}, calls)
+}
+
+func TestInstaller_RegistryAuthSkippedOnDryRun(t *testing.T) {
+	var calls []string
+	argoCD := new(MockArgoCDService)
+	argoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+
+	installer := &Installer{argoCDService: argoCD, appOfAppsService: new(MockAppOfAppsService), registryAuth: recordingRegistryStep{&calls}}
+	require.NoError(t, installer.InstallChartsWithContext(context.Background(), config.ChartInstallConfig{DryRun: true}))
+	assert.Empty(t, calls, "a dry run must not write pull secrets")
+}
+
+// fakeNamespaceLister returns successive results from a script, repeating the
+// last one.
+type fakeNamespaceLister struct {
+	results [][]string
+	n       int
+}
+
+func (f *fakeNamespaceLister) DestinationNamespaces(context.Context) ([]string, error) {
+	r := f.results[min(f.n, len(f.results)-1)]
+	f.n++
+	return r, nil
+}
+
+func TestRegistryAuthInjection_AfterAppOfAppsWaitsForNamespacesToSettle(t *testing.T) {
+	client := fake.NewClientset()
+	auth := &models.RegistryAuthConfig{Registries: []models.RegistryCredential{{Host: "ghcr.io", Username: "u", Password: "p"}}}
+	step := &registryAuthInjection{
+		injector:     registry.NewInjector(client, auth),
+		apps:         &fakeNamespaceLister{results: [][]string{nil, {"platform"}, {"platform", "monitoring"}}},
+		namespaces:   []string{"argocd"},
+		pollInterval: time.Millisecond,
+		wait:         time.Second,
+	}
+
+	require.NoError(t, step.AfterAppOfApps(context.Background()))
+	for _, ns := range []string{"argocd", "platform", "monitoring"} {
+		_, err := client.CoreV1().Secrets(ns).Get(context.Background(), models.RegistryPullSecretName, metav1.GetOptions{})
+		assert.NoErrorf(t, err, "pull secret missing in %s", ns)
+	}
+}
+
+func TestNewRegistryAuthStep_NilWithoutCredentials(t *testing.T) {
+	step, err := NewRegistryAuthStep(nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, step)
+}
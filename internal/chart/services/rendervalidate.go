@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/valueschema"
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderFinding is one problem found while validating the rendered chart.
+type RenderFinding struct {
+	Object  string `json:"object"` // "Kind/name", or "helm lint"
+	Problem string `json:"problem"`
+}
+
+// RenderReport is the outcome of ValidateAppOfAppsRender.
+type RenderReport struct {
+	Ref          string          `json:"ref"`
+	ValuesFile   string          `json:"valuesFile"`
+	Kubernetes   string          `json:"kubernetes"` // "1.MINOR" the objects were checked for
+	Objects      int             `json:"objects"`
+	Applications int             `json:"applications"`
+	Lint         string          `json:"lint,omitempty"`
+	Findings     []RenderFinding `json:"findings,omitempty"`
+}
+
+// OK reports whether nothing was found.
+func (r *RenderReport) OK() bool { return len(r.Findings) == 0 }
+
+// ValidateAppOfAppsRender clones the app-of-apps chart at cfg's ref and checks
+// what `app install` would deploy, without touching a cluster: the values file
+// against the chart's values schema (the bundled one when it ships none),
+// `helm lint`, `helm template` with the values file, then every rendered
+// object against the built-in Kubernetes types of Kubernetes 1.kubeMinor and
+// every ArgoCD Application for the fields ArgoCD needs. Problems are findings
+// in the report; an error means the check itself could not run (clone failed,
+// chart does not render).
+func ValidateAppOfAppsRender(ctx context.Context, helmManager *helm.HelmManager, gitRepo *git.Repository, cfg *models.AppOfAppsConfig, kubeMinor int) (*RenderReport, error) {
+	report := &RenderReport{Ref: cfg.GitHubBranch, ValuesFile: cfg.ValuesFile, Kubernetes: fmt.Sprintf("1.%d", kubeMinor)}
+
+	clone, err := gitRepo.CloneChartRepository(ctx, cfg)
+	if err != nil {
+		return report, err
+	}
+	defer gitRepo.Cleanup(clone.TempDir)
+
+	report.Findings = append(report.Findings, schemaFindings(clone.ChartPath, cfg.ValuesFile)...)
+
+	lint, lintErr := helmManager.LintAppOfApps(ctx, clone.ChartPath, cfg.ValuesFile)
+	report.Lint = lint
+	if lintErr != nil {
+		report.Findings = append(report.Findings, lintFindings(lint, lintErr)...)
+	}
+
+	rendered, err := helmManager.TemplateAppOfApps(ctx, clone.ChartPath, cfg.ValuesFile, cfg.Namespace)
+	if err != nil {
+		return report, err
+	}
+	objs, err := manifest.Decode([]byte(rendered))
+	if err != nil {
+		return report, fmt.Errorf("parsing rendered chart: %w", err)
+	}
+	report.Objects = len(objs)
+	for _, obj := range objs {
+		if isArgoApplication(obj) {
+			report.Applications++
+		}
+		report.Findings = append(report.Findings, checkRenderedObject(obj, kubeMinor)...)
+	}
+	return report, nil
+}
+
+// lintFindings turns helm lint's [ERROR] lines into findings; when there are
+// none (helm itself failed), the error is the finding.
+func lintFindings(out string, err error) []RenderFinding {
+	var findings []RenderFinding
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if _, msg, ok := strings.Cut(line, "[ERROR]"); ok {
+			findings = append(findings, RenderFinding{Object: "helm lint", Problem: strings.TrimSpace(msg)})
+		}
+	}
+	if len(findings) == 0 {
+		findings = append(findings, RenderFinding{Object: "helm lint", Problem: err.Error()})
+	}
+	return findings
+}
+
+// schemaFindings checks valuesFile against the chart's values schema, or the
+// bundled one when the chart has none.
+func schemaFindings(chartPath, valuesFile string) []RenderFinding {
+	schema, err := valueschema.Load(filepath.Join(chartPath, valueschema.ChartSchemaFile))
+	if err != nil {
+		return []RenderFinding{{Object: valueschema.ChartSchemaFile, Problem: err.Error()}}
+	}
+	if schema == nil {
+		schema = valueschema.Bundled()
+	}
+	err = valueschema.ValidateFile(valuesFile, schema)
+	var verr *valueschema.Error
+	if stderrors.As(err, &verr) {
+		findings := make([]RenderFinding, len(verr.Findings))
+		for i, f := range verr.Findings {
+			findings[i] = RenderFinding{Object: fmt.Sprintf("%s:%d", valuesFile, f.Line), Problem: f.Path + ": " + f.Problem}
+		}
+		return findings
+	}
+	if err != nil {
+		return []RenderFinding{{Object: valuesFile, Problem: err.Error()}}
+	}
+	return nil
+}
+
+func isArgoApplication(obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == "Application" && obj.GroupVersionKind().Group == "argoproj.io"
+}
+
+func checkRenderedObject(obj *unstructured.Unstructured, kubeMinor int) []RenderFinding {
+	name := obj.GetKind() + "/" + obj.GetName()
+	var findings []RenderFinding
+	if err := manifest.Validate(obj, kubeMinor); err != nil {
+		findings = append(findings, RenderFinding{Object: name, Problem: err.Error()})
+	}
+	if isArgoApplication(obj) {
+		for _, p := range applicationProblems(obj) {
+			findings = append(findings, RenderFinding{Object: name, Problem: p})
+		}
+	}
+	return findings
+}
+
+// applicationProblems checks the Application fields ArgoCD rejects or cannot
+// sync without. A missing field here otherwise shows up as an app stuck
+// Unknown long after the install "succeeded".
+func applicationProblems(obj *unstructured.Unstructured) []string {
+	var problems []string
+	if project, _, _ := unstructured.NestedString(obj.Object, "spec", "project"); project == "" {
+		problems = append(problems, "spec.project is empty")
+	}
+	server, _, _ := unstructured.NestedString(obj.Object, "spec", "destination", "server")
+	destName, _, _ := unstructured.NestedString(obj.Object, "spec", "destination", "name")
+	if server == "" && destName == "" {
+		problems = append(problems, "spec.destination needs a server or a name")
+	}
+
+	var sources []map[string]any
+	if src, ok, _ := unstructured.NestedMap(obj.Object, "spec", "source"); ok {
+		sources = append(sources, src)
+	}
+	if list, ok, _ := unstructured.NestedSlice(obj.Object, "spec", "sources"); ok {
+		for _, s := range list {
+			if m, ok := s.(map[string]any); ok {
+				sources = append(sources, m)
+			}
+		}
+	}
+	if len(sources) == 0 {
+		problems = append(problems, "spec.source or spec.sources is required")
+	}
+	for i, src := range sources {
+		repo, _ := src["repoURL"].(string)
+		if repo == "" {
+			problems = append(problems, fmt.Sprintf("source %d: repoURL is empty", i+1))
+		}
+		// Ref-only sources in a multi-source app just expose values files.
+		if _, isRef := src["ref"]; isRef {
+			continue
+		}
+		path, _ := src["path"].(string)
+		chart, _ := src["chart"].(string)
+		if path == "" && chart == "" {
+			problems = append(problems, fmt.Sprintf("source %d: needs a path or a chart", i+1))
+		}
+	}
+	return problems
+}
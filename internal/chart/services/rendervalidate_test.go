@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderedChart = `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: openframe-api
+spec:
+  project: default
+  destination: {server: "https://kubernetes.default.svc", namespace: openframe}
+  sources:
+  - repoURL: https://github.com/flamingo-stack/openframe-oss-tenant
+    path: manifests/apps/api
+  - repoURL: https://github.com/flamingo-stack/openframe-oss-tenant
+    ref: values
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: broken
+spec:
+  destination: {namespace: openframe}
+  source: {repoURL: ""}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data: {mode: oss}
+bogusField: true
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: legacy
+`
+
+func TestCheckRenderedObject(t *testing.T) {
+	objs, err := manifest.Decode([]byte(renderedChart))
+	require.NoError(t, err)
+	require.Len(t, objs, 4)
+
+	assert.Empty(t, checkRenderedObject(objs[0], 31), "a valid multi-source application")
+
+	problems := map[string]bool{}
+	for _, f := range checkRenderedObject(objs[1], 31) {
+		assert.Equal(t, "Application/broken", f.Object)
+		problems[f.Problem] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"spec.project is empty":                     true,
+		"spec.destination needs a server or a name": true,
+		"source 1: repoURL is empty":                true,
+		"source 1: needs a path or a chart":         true,
+	}, problems)
+
+	cm := checkRenderedObject(objs[2], 31)
+	require.Len(t, cm, 1)
+	assert.Contains(t, cm[0].Problem, "bogusField")
+
+	ing := checkRenderedObject(objs[3], 31)
+	require.Len(t, ing, 1)
+	assert.Contains(t, ing[0].Problem, "not a known Kubernetes API")
+}
+
+func TestLintFindings(t *testing.T) {
+	out := "==> Linting /tmp/chart\n[INFO] Chart.yaml: icon is recommended\n[ERROR] templates/apps.yaml: unable to parse YAML\n\nError: 1 chart(s) linted, 1 chart(s) failed"
+	assert.Equal(t, []RenderFinding{{Object: "helm lint", Problem: "templates/apps.yaml: unable to parse YAML"}},
+		lintFindings(out, errors.New("exit status 1")))
+
+	assert.Equal(t, []RenderFinding{{Object: "helm lint", Problem: "helm not found"}},
+		lintFindings("", errors.New("helm not found")))
+}
+
+func TestSchemaFindings(t *testing.T) {
+	dir := t.TempDir()
+	values := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("platform:\n  apps:\n    dev-tools:\n      enabled: \"yes\"\n"), 0o600))
+
+	chart := t.TempDir()
+	assert.Equal(t, []RenderFinding{{Object: values + ":4", Problem: `platform.apps.dev-tools.enabled: expected boolean, got string "yes"`}},
+		schemaFindings(chart, values), "the bundled schema when the chart ships none")
+
+	require.NoError(t, os.WriteFile(filepath.Join(chart, "values.schema.json"), []byte(`{"type": "object"}`), 0o600))
+	assert.Empty(t, schemaFindings(chart, values), "the chart's own schema wins")
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/git"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+// Time box for the repository reachability preflight. An unreachable
+// repository used to surface as an application wait that never finished; the
+// whole check is bounded so a slow network costs well under a minute.
+// Overridden in tests.
+var (
+	repoCheckTimeout = 45 * time.Second
+	repoProbeTimeout = 15 * time.Second
+)
+
+const repoCheckConcurrency = 4
+
+// repoSource is one repository the applications are deployed from.
+type repoSource struct {
+	URL  string
+	Helm bool // a Helm chart repository rather than a git repository
+}
+
+// repoProbe reports whether one repository answered. git.ErrRemoteAuth means
+// it did, but wants credentials.
+type repoProbe func(ctx context.Context, src repoSource) error
+
+// repoCheck verifies the repositories answer from this machine and from
+// inside the cluster. Either probe may be nil to skip that side.
+type repoCheck struct {
+	host    repoProbe
+	cluster repoProbe
+	verbose bool
+}
+
+// clusterLsRemote runs git ls-remote inside the cluster; the ArgoCD manager
+// implements it.
+type clusterLsRemote interface {
+	LsRemoteFromCluster(ctx context.Context, repoURL string) error
+}
+
+// newRepoCheck checks git sources with gitRepo from here and through cluster
+// from inside the cluster, and Helm sources with a HEAD of their index.
+func newRepoCheck(gitRepo *git.Repository, cluster clusterLsRemote, verbose bool) *repoCheck {
+	client := &http.Client{Timeout: repoProbeTimeout}
+	c := &repoCheck{
+		verbose: verbose,
+		host: func(ctx context.Context, src repoSource) error {
+			if src.Helm {
+				return headHelmIndex(ctx, client, src.URL)
+			}
+			return gitRepo.ListRemote(ctx, src.URL)
+		},
+	}
+	if cluster != nil {
+		c.cluster = func(ctx context.Context, src repoSource) error {
+			if src.Helm {
+				return nil // ArgoCD fetches charts itself; git is what needs the pod
+			}
+			return cluster.LsRemoteFromCluster(ctx, src.URL)
+		}
+	}
+	return c
+}
+
+// run probes every source and fails listing each one that did not answer,
+// and from where. A repository that answered but wants credentials passes:
+// it is reachable, and whether ArgoCD holds the credentials is not something
+// a probe can tell. One that was not checked in time only warns: a slow
+// network says nothing about whether ArgoCD will get there.
+func (c *repoCheck) run(parent context.Context, sources []repoSource) error {
+	ctx, cancel := context.WithTimeout(parent, repoCheckTimeout)
+	defer cancel()
+
+	type probe struct {
+		src   repoSource
+		where string
+		fn    repoProbe
+	}
+	var probes []probe
+	for _, src := range sources {
+		if c.host != nil {
+			probes = append(probes, probe{src, "from this machine", c.host})
+		}
+		if c.cluster != nil {
+			probes = append(probes, probe{src, "from inside the cluster", c.cluster})
+		}
+	}
+
+	failures := make([]string, len(probes))
+	unchecked := make([]string, len(probes))
+	sem := make(chan struct{}, repoCheckConcurrency)
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				unchecked[i] = fmt.Sprintf("%s (%s): not checked in time", p.src.URL, p.where)
+				return
+			}
+			defer func() { <-sem }()
+			probeCtx, cancel := context.WithTimeout(ctx, repoProbeTimeout)
+			defer cancel()
+			err := p.fn(probeCtx, p.src)
+			switch {
+			case err == nil:
+			case errors.Is(err, git.ErrRemoteAuth):
+				if c.verbose {
+					pterm.Info.Printf("%s answered %s but requires credentials\n", p.src.URL, p.where)
+				}
+			case probeCtx.Err() != nil && ctx.Err() == nil:
+				unchecked[i] = fmt.Sprintf("%s (%s): no answer within %s", p.src.URL, p.where, repoProbeTimeout)
+			case ctx.Err() != nil:
+				unchecked[i] = fmt.Sprintf("%s (%s): not checked in time", p.src.URL, p.where)
+			default:
+				failures[i] = fmt.Sprintf("%s (%s): %v", p.src.URL, p.where, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := parent.Err(); err != nil {
+		return fmt.Errorf("operation cancelled: %w", err)
+	}
+
+	for _, u := range unchecked {
+		if u != "" {
+			pterm.Warning.Printf("Repository check: %s\n", u)
+		}
+	}
+	var lines []string
+	for _, f := range failures {
+		if f != "" {
+			lines = append(lines, "  "+f)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the applications' repositories are unreachable, so ArgoCD could not sync them:\n%s\n"+
+		"Check DNS, proxy and firewall settings for these hosts (a proxy this machine uses is not automatically used by the cluster), or pass --skip-repo-check to install anyway",
+		strings.Join(lines, "\n"))
+}
+
+// headHelmIndex checks a Helm repository by asking for its index.yaml. OCI
+// registries have no index and are skipped.
+func headHelmIndex(ctx context.Context, client *http.Client, repoURL string) error {
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/index.yaml"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return git.ErrRemoteAuth
+	case resp.StatusCode == http.StatusMethodNotAllowed, resp.StatusCode/100 == 2:
+		return nil // some servers refuse HEAD; they still answered
+	}
+	return fmt.Errorf("%s answered %s", u.Redacted(), resp.Status)
+}
+
+// repoSourcesFromFiles collects the repositories in the chart's values with
+// the user's values file laid over them, as helm would merge them, plus the
+// git repositories in extra. A file that is missing or does not parse
+// contributes nothing: helm reports it.
+func repoSourcesFromFiles(chartValues, userValues string, extra ...string) []repoSource {
+	values := map[string]interface{}{}
+	for _, path := range []string{chartValues, userValues} {
+		data, err := os.ReadFile(path) // #nosec G304 -- the chart's and the user's values files
+		if err != nil {
+			continue
+		}
+		var m map[string]interface{}
+		if yaml.Unmarshal(data, &m) == nil {
+			overlayValues(values, m)
+		}
+	}
+	return repoSources(values, extra...)
+}
+
+// repoSources collects every repoURL in values (and the top-level
+// repository.url) plus the git repositories in extra, without credentials and
+// without duplicates. A repoURL next to a chart key is a Helm repository.
+// Templated values are skipped.
+func repoSources(values map[string]interface{}, extra ...string) []repoSource {
+	found := map[string]repoSource{}
+	add := func(raw string, helm bool) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.Contains(raw, "{{") || !strings.Contains(raw, "://") && !strings.Contains(raw, "@") {
+			return
+		}
+		clean := stripCredentials(raw)
+		if _, seen := found[clean]; !seen {
+			found[clean] = repoSource{URL: clean, Helm: helm}
+		}
+	}
+	if repo, ok := values["repository"].(map[string]interface{}); ok {
+		if u, ok := repo["url"].(string); ok {
+			add(u, false)
+		}
+	}
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if u, ok := v["repoURL"].(string); ok {
+				_, helm := v["chart"]
+				add(u, helm)
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(values)
+	for _, u := range extra {
+		add(u, false)
+	}
+
+	sources := make([]repoSource, 0, len(found))
+	for _, src := range found {
+		sources = append(sources, src)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].URL < sources[j].URL })
+	return sources
+}
+
+// overlayValues lays src over dst the way helm merges values files: maps
+// merge, anything else replaces.
+func overlayValues(dst, src map[string]interface{}) {
+	for k, sv := range src {
+		if dm, ok := dst[k].(map[string]interface{}); ok {
+			if sm, ok := sv.(map[string]interface{}); ok {
+				overlayValues(dm, sm)
+				continue
+			}
+		}
+		dst[k] = sv
+	}
+}
+
+// stripCredentials removes userinfo from an http(s) URL, so a token in the
+// values never reaches the cluster or the output.
+func stripCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+)
+
+// ApplicationLister lists the ArgoCD applications in the cluster. It is
+// implemented by *argocd.Manager.
+type ApplicationLister interface {
+	ListApplications(ctx context.Context, verbose bool) ([]argocd.Application, error)
+}
+
+// reportApplications records the applications the install left behind. A
+// listing that fails only costs the result its application list.
+func (i *Installer) reportApplications(ctx context.Context, config config.ChartInstallConfig) {
+	if i.result == nil || i.apps == nil || config.DryRun {
+		return
+	}
+	apps, err := i.apps.ListApplications(ctx, false)
+	if err != nil {
+		i.result.Warn("could not list the installed applications: %v", err)
+		return
+	}
+	for _, app := range apps {
+		i.result.Applications = append(i.result.Applications, models.ApplicationResult{Name: app.Name, Sync: app.Sync, Health: app.Health})
+	}
+}
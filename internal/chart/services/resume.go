@@ -0,0 +1,181 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+)
+
+// Install steps whose completion is recorded.
+const (
+	stepArgoCD    = "argocd"
+	stepAppOfApps = "app-of-apps"
+)
+
+// installStateDir holds one progress file per install target. A variable so
+// tests can point it at a temp dir.
+var installStateDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "install"), nil
+}
+
+// StepRecord is one completed install step and the inputs it ran with.
+type StepRecord struct {
+	Fingerprint string    `json:"fingerprint"`
+	Completed   time.Time `json:"completed"`
+}
+
+// InstallProgress records which install steps completed for one target, so a
+// re-run after a failure (ArgoCD up, app-of-apps failed) skips what is already
+// in place instead of redoing everything. A record alone never skips a step:
+// the installer also checks the cluster (see Installer.satisfied).
+type InstallProgress struct {
+	Target string                `json:"target"`
+	Steps  map[string]StepRecord `json:"steps"`
+	// Interrupted is set while an install cancelled during the application
+	// wait has not been resumed (see Installer.interrupt).
+	Interrupted *Interruption `json:"interrupted,omitempty"`
+
+	path string
+}
+
+// Interruption records an install cancelled before its applications were
+// ready.
+type Interruption struct {
+	At    time.Time `json:"at"`
+	Phase string    `json:"phase"`
+	// PausedSync is the app-of-apps auto-sync policy (JSON) that was paused
+	// on cancel and must be restored; "" when auto-sync was left running.
+	PausedSync string `json:"pausedSync,omitempty"`
+}
+
+// LoadInstallProgress returns the recorded progress for target. A missing or
+// unreadable file is an empty record — the worst case is redoing a step.
+func LoadInstallProgress(target string) (*InstallProgress, error) {
+	dir, err := installStateDir()
+	if err != nil {
+		return nil, err
+	}
+	p := &InstallProgress{
+		Target: target,
+		Steps:  map[string]StepRecord{},
+		path:   filepath.Join(dir, progressFileName(target)),
+	}
+	b, err := os.ReadFile(p.path) //nolint:gosec // G304: fixed CLI-owned path
+	if err != nil {
+		return p, nil
+	}
+	var saved InstallProgress
+	if json.Unmarshal(b, &saved) == nil && saved.Target == target && saved.Steps != nil {
+		p.Steps = saved.Steps
+		p.Interrupted = saved.Interrupted
+	}
+	return p, nil
+}
+
+// Done reports whether step completed with the same inputs.
+func (p *InstallProgress) Done(step, fingerprint string) bool {
+	rec, ok := p.Steps[step]
+	return ok && rec.Fingerprint == fingerprint
+}
+
+// Complete records step as completed with fingerprint. Saving is best-effort:
+// a lost record only costs the step being redone.
+func (p *InstallProgress) Complete(step, fingerprint string) {
+	p.Steps[step] = StepRecord{Fingerprint: fingerprint, Completed: time.Now().UTC()}
+	p.save()
+}
+
+// MarkInterrupted records that the install was cancelled in phase, with the
+// auto-sync policy paused on the way out ("" = none).
+func (p *InstallProgress) MarkInterrupted(phase, pausedSync string) {
+	p.Interrupted = &Interruption{At: time.Now().UTC(), Phase: phase, PausedSync: pausedSync}
+	p.save()
+}
+
+// ClearInterrupted drops the interruption record once the install finished.
+func (p *InstallProgress) ClearInterrupted() {
+	if p.Interrupted == nil {
+		return
+	}
+	p.Interrupted = nil
+	p.save()
+}
+
+func (p *InstallProgress) save() {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o750); err != nil {
+		return
+	}
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(p.path, b, 0o600)
+}
+
+// installTarget names the cluster an install targets: the explicit
+// kube-context when one was resolved, otherwise the cluster name.
+func installTarget(cfg config.ChartInstallConfig) string {
+	if cfg.KubeContext != "" {
+		return cfg.KubeContext
+	}
+	return cfg.ClusterName
+}
+
+// progressFileName turns a target into a safe file name.
+func progressFileName(target string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		}
+		return '_'
+	}, target)
+	if name == "" {
+		name = "default"
+	}
+	return name + ".json"
+}
+
+// stepFingerprint hashes the inputs a step's outcome depends on. A step whose
+// inputs changed since it was recorded (new ref, edited values file, newer
+// pinned ArgoCD chart) runs again.
+func stepFingerprint(step string, cfg config.ChartInstallConfig) string {
+	h := sha256.New()
+	h.Write([]byte(step + "\n"))
+	switch step {
+	case stepArgoCD:
+		h.Write([]byte(argocd.ArgoCDChartVersion + "\n"))
+		if cfg.SkipCRDs {
+			h.Write([]byte("skip-crds\n"))
+		}
+	case stepAppOfApps:
+		if cfg.AppOfApps != nil {
+			h.Write([]byte(cfg.AppOfApps.GitHubRepo + "\n" + cfg.AppOfApps.GitHubBranch + "\n" + cfg.AppOfApps.Namespace + "\n"))
+		}
+	}
+	// Both releases are scaled by the host size; large is the unscaled
+	// baseline, so it leaves the fingerprint as it was before sizing existed.
+	if cfg.Size != "" && cfg.Size != sizing.Large {
+		h.Write([]byte("size=" + cfg.Size + "\n"))
+	}
+	// Both releases are rendered from the helm values (ArgoCD reads its
+	// overrides from the same file).
+	if cfg.AppOfApps != nil && cfg.AppOfApps.ValuesFile != "" {
+		if b, err := os.ReadFile(cfg.AppOfApps.ValuesFile); err == nil { //nolint:gosec // G304: values file resolved by the install config
+			h.Write(b)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
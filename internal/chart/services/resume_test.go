@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func resumeConfig(t *testing.T) config.ChartInstallConfig {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	values := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("repository:\n  branch: main\n"), 0o600))
+	return config.ChartInstallConfig{
+		ClusterName: "dev",
+		KubeContext: "k3d-dev",
+		AppOfApps: &models.AppOfAppsConfig{
+			GitHubRepo:   "owner/repo",
+			GitHubBranch: "main",
+			Namespace:    "argocd",
+			ValuesFile:   values,
+		},
+	}
+}
+
+func TestInstallProgress_RoundTrip(t *testing.T) {
+	cfg := resumeConfig(t)
+	p, err := LoadInstallProgress(installTarget(cfg))
+	require.NoError(t, err)
+	fp := stepFingerprint(stepArgoCD, cfg)
+	assert.False(t, p.Done(stepArgoCD, fp))
+
+	p.Complete(stepArgoCD, fp)
+
+	again, err := LoadInstallProgress("k3d-dev")
+	require.NoError(t, err)
+	assert.True(t, again.Done(stepArgoCD, fp))
+	assert.False(t, again.Done(stepAppOfApps, stepFingerprint(stepAppOfApps, cfg)))
+
+	other, err := LoadInstallProgress("k3d-other")
+	require.NoError(t, err)
+	assert.False(t, other.Done(stepArgoCD, fp), "progress is per target")
+}
+
+func TestStepFingerprint_ChangesWithInputs(t *testing.T) {
+	cfg := resumeConfig(t)
+	before := stepFingerprint(stepAppOfApps, cfg)
+
+	ref := *cfg.AppOfApps
+	ref.GitHubBranch = "v1.2.3"
+	moved := cfg
+	moved.AppOfApps = &ref
+	assert.NotEqual(t, before, stepFingerprint(stepAppOfApps, moved), "a new ref re-runs app-of-apps")
+
+	require.NoError(t, os.WriteFile(cfg.AppOfApps.ValuesFile, []byte("repository:\n  branch: dev\n"), 0o600))
+	assert.NotEqual(t, before, stepFingerprint(stepAppOfApps, cfg), "edited values re-run app-of-apps")
+}
+
+func TestInstaller_ResumesCompletedSteps(t *testing.T) {
+	deployedArgo := models.ChartInfo{Status: "deployed", Version: argocd.ArgoCDChartVersion}
+
+	tests := []struct {
+		name      string
+		force     bool
+		argoInfo  models.ChartInfo
+		reinstall bool
+	}{
+		{name: "recorded and deployed: skipped", argoInfo: deployedArgo},
+		{name: "--force reinstalls", force: true, argoInfo: deployedArgo, reinstall: true},
+		{name: "release failed since: reinstalled", argoInfo: models.ChartInfo{Status: "failed", Version: argocd.ArgoCDChartVersion}, reinstall: true},
+		{name: "older ArgoCD chart: reinstalled", argoInfo: models.ChartInfo{Status: "deployed", Version: "0.0.1"}, reinstall: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := resumeConfig(t)
+			cfg.Force = tt.force
+			progress, err := LoadInstallProgress(installTarget(cfg))
+			require.NoError(t, err)
+			progress.Complete(stepArgoCD, stepFingerprint(stepArgoCD, cfg))
+
+			mockArgoCD := new(MockArgoCDService)
+			mockAppOfApps := new(MockAppOfAppsService)
+			mockArgoCD.On("GetStatus", mock.Anything).Return(tt.argoInfo, nil).Maybe()
+			if tt.reinstall {
+				mockArgoCD.On("Install", mock.Anything, mock.Anything).Return(nil)
+			}
+			// app-of-apps was never recorded, so it runs either way.
+			mockAppOfApps.On("Install", mock.Anything, mock.Anything).Return(nil)
+			mockArgoCD.On("WaitForApplications", mock.Anything, mock.Anything).Return(nil)
+
+			installer := &Installer{argoCDService: mockArgoCD, appOfAppsService: mockAppOfApps, progress: progress}
+			require.NoError(t, installer.InstallChartsWithContext(context.Background(), cfg))
+
+			mockArgoCD.AssertExpectations(t)
+			mockAppOfApps.AssertExpectations(t)
+			if !tt.reinstall {
+				mockArgoCD.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+			}
+
+			saved, err := LoadInstallProgress(installTarget(cfg))
+			require.NoError(t, err)
+			assert.True(t, saved.Done(stepAppOfApps, stepFingerprint(stepAppOfApps, cfg)), "completed steps are recorded")
+		})
+	}
+}
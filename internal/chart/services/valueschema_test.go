@@ -0,0 +1,46 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateHelmValuesFile_CatchesTypoBeforeInstall: a misspelled section
+// the chart would silently ignore fails the pre-flight, naming the line.
+func TestValidateHelmValuesFile_CatchesTypoBeforeInstall(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(config.DefaultHelmValuesFile, []byte("deployment:\n  ingres:\n    localhost:\n      enabled: true\n"), 0o600))
+
+	err := ValidateHelmValuesFile()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), config.DefaultHelmValuesFile+":2: deployment.ingres: unknown key \"ingres\" (did you mean \"ingress\"?)")
+}
+
+func TestValidateHelmValuesFile_NoFileIsFine(t *testing.T) {
+	t.Chdir(t.TempDir())
+	assert.NoError(t, ValidateHelmValuesFile())
+}
+
+func TestAppOfApps_ValidateChartSchema(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(config.DefaultHelmValuesFile, []byte("tenant:\n  replicas: two\n"), 0o600))
+	a := NewAppOfApps(nil, nil, config.NewPathResolver())
+
+	chart := t.TempDir()
+	assert.NoError(t, a.validateChartSchema(chart), "a chart without a schema is not checked")
+
+	require.NoError(t, os.WriteFile(filepath.Join(chart, "values.schema.json"),
+		[]byte(`{"properties": {"tenant": {"properties": {"replicas": {"type": "integer"}}}}}`), 0o600))
+	err := a.validateChartSchema(chart)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the chart's values.schema.json")
+	assert.Contains(t, err.Error(), ":2: tenant.replicas: expected integer, got string \"two\"")
+
+	require.NoError(t, os.WriteFile(filepath.Join(chart, "values.schema.json"), []byte(`{`), 0o600))
+	assert.NoError(t, a.validateChartSchema(chart), "an unreadable schema is left to helm")
+}
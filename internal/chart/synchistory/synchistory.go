@@ -0,0 +1,175 @@
+// Package synchistory keeps, for the last few application waits of install and
+// upgrade runs, how each ArgoCD application fared: how many sync operations it
+// took, how many of them failed, how often the CLI had to step in, and how long
+// it took to become Healthy and Synced. `openframe app history` aggregates the
+// runs, so an application that is flaky across installs stands out from one
+// that was slow once.
+package synchistory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/files"
+)
+
+// maxRuns is how many runs the state file keeps, newest last.
+const maxRuns = 20
+
+// App is how one application fared during one wait.
+type App struct {
+	Name     string `json:"name"`
+	Attempts int    `json:"attempts"` // sync operations ArgoCD started
+	Failures int    `json:"failures"` // of those, the ones that ended Failed or Error
+	Retries  int    `json:"retries"`  // ArgoCD's own retries within them
+	Nudges   int    `json:"nudges"`   // syncs and hard refreshes the CLI triggered
+	Ready    bool   `json:"ready"`
+	// Duration runs from when the wait first saw the application until it was
+	// Healthy and Synced, or until the wait ended.
+	Duration time.Duration `json:"duration"`
+}
+
+// Troubled reports whether the application needed more than one clean sync.
+func (a App) Troubled() bool {
+	return !a.Ready || a.Failures > 0 || a.Retries > 0 || a.Nudges > 0
+}
+
+// Run is one wait for the applications of a cluster.
+type Run struct {
+	Cluster  string    `json:"cluster"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Success  bool      `json:"success"`
+	CI       bool      `json:"ci"`
+	Apps     []App     `json:"apps"`
+}
+
+// historyFile is where the runs are kept; a variable so tests can redirect it.
+var historyFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "sync-history.json"), nil
+}
+
+// Load returns the recorded runs, oldest first.
+func Load() ([]Run, error) {
+	p, err := historyFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", p, err)
+	}
+	return runs, nil
+}
+
+// Record appends run to the history, dropping the oldest runs past maxRuns.
+// A run that saw no application is not recorded. The file is locked while it
+// is updated, so runs of concurrent installs are all kept.
+func Record(run Run) error {
+	if len(run.Apps) == 0 {
+		return nil
+	}
+	p, err := historyFile()
+	if err != nil {
+		return err
+	}
+	unlock, err := files.Lock(p)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	runs, err := Load()
+	if err != nil {
+		return err
+	}
+	runs = append(runs, run)
+	if len(runs) > maxRuns {
+		runs = runs[len(runs)-maxRuns:]
+	}
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return err
+	}
+	return files.WriteAtomic(p, data)
+}
+
+// Stats is how one application fared across the recorded runs.
+type Stats struct {
+	Name     string `json:"name"`
+	Runs     int    `json:"runs"`
+	Troubled int    `json:"troubledRuns"` // runs in which App.Troubled
+	NotReady int    `json:"notReady"`     // runs that ended before it was ready
+	Attempts int    `json:"attempts"`
+	Failures int    `json:"failures"`
+	Retries  int    `json:"retries"`
+	Nudges   int    `json:"nudges"`
+	// MeanDuration and Slowest cover the runs in which it became ready.
+	MeanDuration time.Duration `json:"meanDuration"`
+	Slowest      time.Duration `json:"slowest"`
+}
+
+// Aggregate sums runs per application, flakiest first: by the number of
+// troubled runs, then failures, then name.
+func Aggregate(runs []Run) []Stats {
+	byName := map[string]*Stats{}
+	readyTotal := map[string]time.Duration{}
+	readyRuns := map[string]int{}
+	for _, run := range runs {
+		for _, a := range run.Apps {
+			s := byName[a.Name]
+			if s == nil {
+				s = &Stats{Name: a.Name}
+				byName[a.Name] = s
+			}
+			s.Runs++
+			s.Attempts += a.Attempts
+			s.Failures += a.Failures
+			s.Retries += a.Retries
+			s.Nudges += a.Nudges
+			if a.Troubled() {
+				s.Troubled++
+			}
+			if !a.Ready {
+				s.NotReady++
+				continue
+			}
+			readyTotal[a.Name] += a.Duration
+			readyRuns[a.Name]++
+			s.Slowest = max(s.Slowest, a.Duration)
+		}
+	}
+	stats := make([]Stats, 0, len(byName))
+	for name, s := range byName {
+		if n := readyRuns[name]; n > 0 {
+			s.MeanDuration = readyTotal[name] / time.Duration(n)
+		}
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Troubled != b.Troubled {
+			return a.Troubled > b.Troubled
+		}
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		return a.Name < b.Name
+	})
+	return stats
+}
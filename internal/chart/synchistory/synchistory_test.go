@@ -0,0 +1,84 @@
+package synchistory
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useHistoryFile(t *testing.T) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "state", "sync-history.json")
+	orig := historyFile
+	historyFile = func() (string, error) { return p, nil }
+	t.Cleanup(func() { historyFile = orig })
+	return p
+}
+
+func TestRecord_KeepsLastRuns(t *testing.T) {
+	useHistoryFile(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range maxRuns + 3 {
+		require.NoError(t, Record(Run{Cluster: "dev", Started: start.Add(time.Duration(i) * time.Hour), Apps: []App{{Name: "api", Ready: true}}}))
+	}
+	require.NoError(t, Record(Run{Cluster: "dev"}), "a run without applications is skipped")
+
+	runs, err := Load()
+	require.NoError(t, err)
+	require.Len(t, runs, maxRuns)
+	assert.Equal(t, start.Add(3*time.Hour), runs[0].Started.UTC(), "the oldest runs are dropped")
+}
+
+func TestRecord_Concurrent(t *testing.T) {
+	useHistoryFile(t)
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, Record(Run{Cluster: fmt.Sprintf("dev-%d", i), Apps: []App{{Name: "api"}}}))
+		}()
+	}
+	wg.Wait()
+
+	runs, err := Load()
+	require.NoError(t, err)
+	assert.Len(t, runs, 5, "no run is lost to another process's write")
+}
+
+func TestLoad_NoHistory(t *testing.T) {
+	useHistoryFile(t)
+	runs, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestAggregate_FlakiestFirst(t *testing.T) {
+	runs := []Run{
+		{Apps: []App{
+			{Name: "api", Attempts: 1, Ready: true, Duration: time.Minute},
+			{Name: "mongodb", Attempts: 3, Failures: 2, Retries: 2, Ready: true, Duration: 5 * time.Minute},
+			{Name: "kafka", Attempts: 1, Ready: true, Duration: 2 * time.Minute},
+		}},
+		{Apps: []App{
+			{Name: "api", Attempts: 1, Ready: true, Duration: 3 * time.Minute},
+			{Name: "mongodb", Attempts: 1, Nudges: 1, Ready: false, Duration: 10 * time.Minute},
+			{Name: "kafka", Attempts: 2, Failures: 1, Ready: true, Duration: 4 * time.Minute},
+		}},
+	}
+
+	stats := Aggregate(runs)
+	require.Len(t, stats, 3)
+	assert.Equal(t, []string{"mongodb", "kafka", "api"}, []string{stats[0].Name, stats[1].Name, stats[2].Name})
+
+	mongo := stats[0]
+	assert.Equal(t, Stats{Name: "mongodb", Runs: 2, Troubled: 2, NotReady: 1, Attempts: 4, Failures: 2, Retries: 2, Nudges: 1,
+		MeanDuration: 5 * time.Minute, Slowest: 5 * time.Minute}, mongo, "the run it never became ready in is left out of its durations")
+	assert.Equal(t, 2*time.Minute, stats[2].MeanDuration)
+	assert.Zero(t, stats[2].Troubled)
+}
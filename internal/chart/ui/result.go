@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/pterm/pterm"
+)
+
+// ShowInstallResult prints the summary of a finished install: how long each
+// phase took, the state the applications were left in, where to find the
+// credentials and the warnings raised on the way. A nil result prints nothing.
+func (d *DisplayService) ShowInstallResult(result *models.InstallResult) {
+	if result == nil {
+		return
+	}
+	pterm.DefaultBasicText.Println(strings.Join(installResultLines(result), "\n"))
+	for _, w := range result.Warnings {
+		pterm.Warning.Println(w)
+	}
+}
+
+// installResultLines renders everything in result but the warnings.
+func installResultLines(result *models.InstallResult) []string {
+	lines := []string{fmt.Sprintf("Install summary (%s):", result.Duration().Round(time.Second))}
+	for _, p := range result.Phases {
+		if p.Skipped {
+			lines = append(lines, fmt.Sprintf("  %-16s skipped (left by a previous run)", p.Name))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %-16s %s", p.Name, p.Duration.Round(time.Second)))
+	}
+	if len(result.Applications) > 0 {
+		lines = append(lines, fmt.Sprintf("  Applications: %d (%s)", len(result.Applications), countBy(result.Applications, func(a models.ApplicationResult) string { return a.Health })))
+	}
+	if len(result.Endpoints) > 0 {
+		lines = append(lines, fmt.Sprintf("  Endpoints: %s", countBy(result.Endpoints, func(e models.EndpointResult) string { return e.Outcome })))
+	}
+	for _, c := range result.Credentials {
+		if c.Keychain != "" {
+			lines = append(lines, fmt.Sprintf("  %s: openframe credentials get %s", c.Description, c.Keychain))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s: kubectl -n %s get secret %s -o jsonpath='{.data.%s}' | base64 -d", c.Description, c.Namespace, c.Secret, c.Key))
+	}
+	return lines
+}
+
+// countBy summarises items as "3 Healthy, 1 Progressing", most frequent first.
+func countBy[T any](items []T, key func(T) string) string {
+	counts := map[string]int{}
+	for _, item := range items {
+		k := key(item)
+		if k == "" {
+			k = "Unknown"
+		}
+		counts[k]++
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[k], k))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallResultLines(t *testing.T) {
+	started := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	result := &models.InstallResult{
+		Started:  started,
+		Finished: started.Add(95 * time.Second),
+		Phases: []models.PhaseResult{
+			{Name: "argocd-install", Skipped: true},
+			{Name: "argocd-sync", Duration: 80*time.Second + 400*time.Millisecond},
+		},
+		Applications: []models.ApplicationResult{
+			{Name: "api", Health: "Healthy"}, {Name: "ui", Health: "Healthy"}, {Name: "kafka", Health: "Progressing"},
+		},
+		Endpoints:   []models.EndpointResult{{Check: "ui", Outcome: "pass"}, {Check: "api", Outcome: "fail"}},
+		Credentials: []models.CredentialRef{models.ArgoCDAdminCredential},
+	}
+
+	assert.Equal(t, []string{
+		"Install summary (1m35s):",
+		"  argocd-install   skipped (left by a previous run)",
+		"  argocd-sync      1m20s",
+		"  Applications: 3 (2 Healthy, 1 Progressing)",
+		"  Endpoints: 1 fail, 1 pass",
+		"  ArgoCD admin password (user admin): kubectl -n argocd get secret argocd-initial-admin-secret -o jsonpath='{.data.password}' | base64 -d",
+	}, installResultLines(result))
+}
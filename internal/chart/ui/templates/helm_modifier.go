@@ -164,6 +164,21 @@ func (h *HelmValuesModifier) setRepositoryBranch(values map[string]interface{},
 	repository["branch"] = branch
 }
 
+// DisablePlatformApp turns off the platform.apps entry name, creating the maps
+// as needed.
+func (h *HelmValuesModifier) DisablePlatformApp(values map[string]interface{}, name string) {
+	node := values
+	for _, key := range []string{"platform", "apps", name} {
+		next, ok := node[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			node[key] = next
+		}
+		node = next
+	}
+	node["enabled"] = false
+}
+
 // WriteValues writes updated values back to the Helm values file
 func (h *HelmValuesModifier) WriteValues(values map[string]interface{}, helmValuesPath string) error {
 	// Marshal back to YAML
@@ -23,6 +23,38 @@ type ChartInstallConfig struct {
 	// (ref-change) path: children with autoSync disabled never roll a new ref
 	// out by themselves, so waiting for them is provably futile (finding N3).
 	SyncStragglersOnStall bool
+	// RegistryAuth holds private-registry pull credentials to inject into the
+	// install's namespaces (--registry-auth / --registry-auth-file). nil or
+	// empty skips the injection.
+	RegistryAuth *models.RegistryAuthConfig
+	// RepoServerRecovery is the repo-server recovery policy for the
+	// application wait (--recovery-policy); nil is the built-in policy.
+	RepoServerRecovery *models.RepoServerRecoveryPolicy
+	// GitOpsEngine selects the engine that deploys the platform ("argocd",
+	// the default when empty, or "flux"). FluxPath is the repository
+	// directory the Flux root Kustomization applies ("" = the engine default).
+	GitOpsEngine string
+	FluxPath     string
+	// ReadinessGates is the gates file waited for after the applications are
+	// ready ("" or a missing default file = none).
+	ReadinessGates string
+	// Size is the resolved host size (sizing.Small/Medium/Large) the ArgoCD
+	// and app-of-apps values are scaled to; "" or large leaves them as-is.
+	Size string
+	// Resume requires an interrupted install to pick up (--resume);
+	// PauseSyncOnCancel pauses app-of-apps auto-sync when the application
+	// wait is cancelled (--pause-sync-on-cancel).
+	Resume            bool
+	PauseSyncOnCancel bool
+	// SkipRepoUpdate uses the cached Helm repository index as is, however old
+	// (--skip-repo-update).
+	SkipRepoUpdate bool
+	// SkipRepoCheck skips the preflight that checks the applications'
+	// repositories are reachable (--skip-repo-check).
+	SkipRepoCheck bool
+	// Phases is the part of the pipeline to run (--only / --skip); nil runs
+	// every phase.
+	Phases *models.PhaseSelection
 	// App-of-apps specific configuration
 	AppOfApps *models.AppOfAppsConfig
 }
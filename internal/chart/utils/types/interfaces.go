@@ -79,6 +79,43 @@ type InstallationRequest struct {
 	// ArgoCD wait all watch the SAME cluster (audit F4: three different targets
 	// could be used within a single install).
 	KubeContext string
+	// RegistryAuth carries private-registry pull credentials to inject as
+	// imagePullSecrets during the install (nil = none).
+	RegistryAuth *models.RegistryAuthConfig
+	// RepoServerRecovery is the --recovery-policy (nil = built-in policy).
+	RepoServerRecovery *models.RepoServerRecoveryPolicy
+	// GitOpsEngine and FluxPath select the engine that deploys the platform
+	// (--gitops-engine, --flux-path); empty means ArgoCD.
+	GitOpsEngine string
+	FluxPath     string
+	// ReadinessGates is the --readiness-gates file.
+	ReadinessGates string
+	// SkipVerify skips the post-install endpoint smoke test (--skip-verify).
+	SkipVerify bool
+	// Size is the --size value: sizing.Auto detects the host, or an explicit
+	// small|medium|large.
+	Size string
+	// Resume picks up an install interrupted during the application wait
+	// (--resume); PauseSyncOnCancel pauses app-of-apps auto-sync when that
+	// wait is cancelled (--pause-sync-on-cancel).
+	Resume            bool
+	PauseSyncOnCancel bool
+	// SkipRepoUpdate never refreshes the cached Helm repository index
+	// (--skip-repo-update).
+	SkipRepoUpdate bool
+	// SkipRepoCheck skips the repository reachability preflight
+	// (--skip-repo-check).
+	SkipRepoCheck bool
+	// Phases selects the install phases to run (--only / --skip); nil runs
+	// them all.
+	Phases *models.PhaseSelection
+	// ValuesOverlays are YAML files laid over the values, in order — the
+	// values of a named environment (bootstrap --env).
+	ValuesOverlays []string
+	// ValuesFile, when set, is used as-is in place of
+	// openframe-helm-values.yaml and the wizard: the effective values of an
+	// install manifest being replayed. Requires NonInteractive.
+	ValuesFile string
 	// ClusterAccess resolves clusters and their rest.Config for the install
 	// target. Injected by the composition root so the app subsystem never imports
 	// cluster-creation code (req 18/19). Required for interactive/named-cluster
@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+)
+
+// newAddonInstaller builds the installer --addons uses; a variable so tests
+// can give it a fake cluster.
+var newAddonInstaller = func(ex executor.CommandExecutor, cluster string) *addon.Installer {
+	return addon.NewInstaller(ex, cluster, nil)
+}
+
+// installAddons installs the named add-ons into the new cluster, in order.
+// They are conveniences next to a cluster that is already usable, so one that
+// fails is reported and the rest still installed.
+func (s *ClusterService) installAddons(ctx context.Context, name string, names []string) {
+	installer := newAddonInstaller(s.executor, name)
+	for _, n := range names {
+		a, err := addon.Load(n)
+		if err == nil {
+			pterm.Info.Printf("Installing the %s add-on...\n", n)
+			err = installer.Install(ctx, a)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			pterm.Warning.Printf("Skipping the %s add-on: %v\n", n, err)
+			continue
+		}
+		pterm.Success.Printf("%s installed\n", n)
+	}
+}
+
+// runAddonPreRemoveHooks runs the pre-remove hooks of the add-ons installed
+// on the cluster before it is deleted, e.g. to export their data. A hook that
+// fails is reported and does not stop the deletion.
+func (s *ClusterService) runAddonPreRemoveHooks(ctx context.Context, name string) {
+	names, err := addon.InstalledOn(name)
+	if err != nil || len(names) == 0 {
+		return
+	}
+	installer := newAddonInstaller(s.executor, name)
+	for _, n := range names {
+		a, err := addon.Load(n)
+		if err != nil {
+			pterm.Warning.Printf("Not running the %s add-on's hooks: %v\n", n, err)
+			continue
+		}
+		if err := installer.RunHooks(ctx, a, a.Hooks.PreRemove); err != nil {
+			pterm.Warning.Println(err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Package clusterstate keeps what the CLI knows about the clusters it manages
+// beyond what the cluster backend reports, such as the labels a cluster was
+// created with or that create adopted it rather than creating it.
+package clusterstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/files"
+)
+
+// stateFile holds a Record per cluster, by name; a variable so tests can
+// redirect it.
+var stateFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "clusters.json"), nil
+}
+
+// Record is what the CLI remembers about one cluster.
+type Record struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	// AdoptedAt is when `cluster create --adopt` took over the cluster
+	// instead of creating it; zero for a cluster this CLI created.
+	AdoptedAt time.Time `json:"adopted_at,omitzero"`
+	// Prepulled are the images imported into the nodes at creation, which an
+	// install starts from; pruning for disk space leaves them alone.
+	Prepulled []string `json:"prepulled,omitempty"`
+}
+
+// Adopted reports whether the cluster was adopted rather than created.
+func (r Record) Adopted() bool {
+	return !r.AdoptedAt.IsZero()
+}
+
+// All returns every cluster's record, by cluster name.
+func All() (map[string]Record, error) {
+	p, err := stateFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Record{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	records := map[string]Record{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", p, err)
+	}
+	return records, nil
+}
+
+// Get returns the record of cluster; the zero Record when there is none.
+func Get(cluster string) (Record, error) {
+	records, err := All()
+	if err != nil {
+		return Record{}, err
+	}
+	return records[cluster], nil
+}
+
+// SetLabels records the labels of cluster, replacing any it had.
+func SetLabels(cluster string, labels map[string]string) error {
+	return update(func(records map[string]Record) bool {
+		r := records[cluster]
+		r.Labels = labels
+		set(records, cluster, r)
+		return true
+	})
+}
+
+// MarkAdopted records that cluster was adopted now. A cluster adopted again
+// keeps the time it was first adopted.
+func MarkAdopted(cluster string) error {
+	return update(func(records map[string]Record) bool {
+		r := records[cluster]
+		if r.Adopted() {
+			return false
+		}
+		r.AdoptedAt = time.Now().UTC()
+		records[cluster] = r
+		return true
+	})
+}
+
+// SetPrepulled records the images imported into cluster's nodes, replacing
+// any recorded before.
+func SetPrepulled(cluster string, images []string) error {
+	return update(func(records map[string]Record) bool {
+		r := records[cluster]
+		r.Prepulled = images
+		set(records, cluster, r)
+		return true
+	})
+}
+
+// Forget drops the record of cluster, once it is deleted.
+func Forget(cluster string) error {
+	return update(func(records map[string]Record) bool {
+		if _, ok := records[cluster]; !ok {
+			return false
+		}
+		delete(records, cluster)
+		return true
+	})
+}
+
+// Rename moves the record of cluster to its new name.
+func Rename(cluster, newName string) error {
+	return update(func(records map[string]Record) bool {
+		r, ok := records[cluster]
+		if !ok {
+			return false
+		}
+		delete(records, cluster)
+		records[newName] = r
+		return true
+	})
+}
+
+// set stores r as cluster's record, dropping the record once nothing is left
+// in it.
+func set(records map[string]Record, cluster string, r Record) {
+	if r.empty() {
+		delete(records, cluster)
+		return
+	}
+	records[cluster] = r
+}
+
+func (r Record) empty() bool {
+	return len(r.Labels) == 0 && !r.Adopted() && len(r.Prepulled) == 0
+}
+
+// update applies change to the records under the state file's lock, and
+// writes them back when change reports that it changed them. The lock keeps
+// concurrent commands from losing each other's updates.
+func update(change func(map[string]Record) bool) error {
+	p, err := stateFile()
+	if err != nil {
+		return err
+	}
+	unlock, err := files.Lock(p)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	records, err := All()
+	if err != nil {
+		return err
+	}
+	if !change(records) {
+		return nil
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return files.WriteAtomic(p, data)
+}
@@ -0,0 +1,93 @@
+package clusterstate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTempState(t *testing.T) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "state", "clusters.json")
+	orig := stateFile
+	stateFile = func() (string, error) { return p, nil }
+	t.Cleanup(func() { stateFile = orig })
+	return p
+}
+
+func TestLabels_Lifecycle(t *testing.T) {
+	useTempState(t)
+
+	r, err := Get("dev")
+	require.NoError(t, err)
+	assert.Empty(t, r.Labels, "no state file yet")
+
+	require.NoError(t, SetLabels("dev", map[string]string{"team": "payments"}))
+	require.NoError(t, SetLabels("qa", map[string]string{"team": "search"}))
+	r, err = Get("dev")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "payments"}, r.Labels)
+
+	require.NoError(t, Rename("dev", "dev2"))
+	all, err := All()
+	require.NoError(t, err)
+	assert.NotContains(t, all, "dev")
+	assert.Equal(t, "payments", all["dev2"].Labels["team"])
+
+	require.NoError(t, Forget("dev2"))
+	require.NoError(t, SetLabels("qa", nil))
+	all, err = All()
+	require.NoError(t, err)
+	assert.Empty(t, all, "a cluster without labels has no record")
+}
+
+func TestForgetAndRename_UnknownCluster(t *testing.T) {
+	p := useTempState(t)
+	require.NoError(t, Forget("nope"))
+	require.NoError(t, Rename("nope", "other"))
+	assert.NoFileExists(t, p, "nothing to record, nothing written")
+}
+
+func TestMarkAdopted(t *testing.T) {
+	useTempState(t)
+
+	require.NoError(t, SetLabels("dev", map[string]string{"team": "payments"}))
+	require.NoError(t, MarkAdopted("dev"))
+	r, err := Get("dev")
+	require.NoError(t, err)
+	require.True(t, r.Adopted())
+	assert.Equal(t, "payments", r.Labels["team"], "adoption keeps the labels")
+	first := r.AdoptedAt
+
+	require.NoError(t, MarkAdopted("dev"))
+	require.NoError(t, SetLabels("dev", nil))
+	r, err = Get("dev")
+	require.NoError(t, err)
+	assert.Equal(t, first, r.AdoptedAt, "adopting again keeps the first time")
+
+	require.NoError(t, Forget("dev"))
+	r, err = Get("dev")
+	require.NoError(t, err)
+	assert.False(t, r.Adopted())
+}
+
+func TestUpdate_Concurrent(t *testing.T) {
+	useTempState(t)
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, SetLabels(fmt.Sprintf("dev-%d", i), map[string]string{"team": "payments"}))
+		}()
+	}
+	wg.Wait()
+
+	records, err := All()
+	require.NoError(t, err)
+	assert.Len(t, records, 5, "no command loses another's update")
+}
@@ -0,0 +1,342 @@
+// Package diskguard watches the disk a k3d cluster's nodes write to and frees
+// it before the kubelet starts evicting pods. A full install pulls several GB
+// of images into the nodes' containerd store, which lives on the Docker host's
+// disk — the WSL2 or Docker Desktop virtual disk on most laptops — and once
+// its free space drops under the kubelet's eviction threshold, pods are
+// evicted with DiskPressure and the install stalls with no hint why.
+//
+// Free space is read with df inside a node, so it is the space the kubelet
+// sees. Images no container uses are removed with `crictl rmi --prune` in the
+// nodes, and dangling images on the Docker host with `docker image prune`.
+package diskguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+const (
+	// LowFreePercent is the free space under which images are pruned. The
+	// kubelet garbage-collects images itself only at 85% use and k3s evicts at
+	// 95%, which leaves a busy install little room between the two.
+	LowFreePercent = 15
+
+	// window is how far back the fill rate is measured.
+	window = 10 * time.Minute
+
+	// soonFull is the projected time to full under which a warning is printed
+	// even while free space is above LowFreePercent.
+	soonFull = 15 * time.Minute
+
+	// pruneCooldown spaces automatic prunes: a prune that freed nothing will
+	// not free anything a minute later either.
+	pruneCooldown = 10 * time.Minute
+)
+
+// containerdDir is where k3s keeps images and container filesystems inside a
+// node.
+const containerdDir = "/var/lib/rancher/k3s/agent/containerd"
+
+// Usage is the size and free space of a filesystem, in bytes.
+type Usage struct {
+	Total uint64
+	Free  uint64
+}
+
+// FreePercent is the free share of the filesystem, 0–100.
+func (u Usage) FreePercent() float64 {
+	if u.Total == 0 {
+		return 100
+	}
+	return float64(u.Free) * 100 / float64(u.Total)
+}
+
+// Low reports whether free space is under LowFreePercent.
+func (u Usage) Low() bool { return u.Total > 0 && u.FreePercent() < LowFreePercent }
+
+func (u Usage) String() string {
+	return fmt.Sprintf("%s free of %s (%.0f%%)", Size(u.Free), Size(u.Total), u.FreePercent())
+}
+
+// Size formats a byte count with a binary unit.
+func Size(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// Nodes returns the running server and agent containers of a k3d cluster;
+// none for a cluster k3d did not create.
+func Nodes(ctx context.Context, ex executor.CommandExecutor, cluster string) ([]string, error) {
+	result, err := ex.Execute(ctx, "docker", "ps",
+		"--filter", "label=k3d.cluster="+cluster,
+		"--filter", "status=running",
+		"--format", `{{.Names}}	{{.Label "k3d.role"}}`)
+	if err != nil {
+		return nil, fmt.Errorf("listing the nodes of %s: %w", cluster, err)
+	}
+	var nodes []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		name, role, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name != "" && (role == "server" || role == "agent") {
+			nodes = append(nodes, name)
+		}
+	}
+	return nodes, nil
+}
+
+// Measure returns the fullest node's usage. Nodes usually share the Docker
+// host's disk, so this is that disk seen through one of them.
+func Measure(ctx context.Context, ex executor.CommandExecutor, nodes []string) (Usage, error) {
+	var least Usage
+	var lastErr error
+	found := false
+	for _, node := range nodes {
+		result, err := ex.Execute(ctx, "docker", "exec", node, "df", "-Pk", containerdDir)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", node, err)
+			continue
+		}
+		u, err := parseDF(result.Stdout)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", node, err)
+			continue
+		}
+		if !found || u.Free < least.Free {
+			least, found = u, true
+		}
+	}
+	if !found {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no running nodes")
+		}
+		return Usage{}, lastErr
+	}
+	return least, nil
+}
+
+// parseDF reads POSIX `df -Pk` output: a header, then
+// "filesystem 1024-blocks used available capacity mountpoint".
+func parseDF(out string) (Usage, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return Usage{}, fmt.Errorf("unexpected df output %q", strings.TrimSpace(out))
+	}
+	f := strings.Fields(lines[len(lines)-1])
+	if len(f) < 4 {
+		return Usage{}, fmt.Errorf("unexpected df output %q", lines[len(lines)-1])
+	}
+	total, err1 := strconv.ParseUint(f[1], 10, 64)
+	free, err2 := strconv.ParseUint(f[3], 10, 64)
+	if err1 != nil || err2 != nil {
+		return Usage{}, fmt.Errorf("unexpected df output %q", lines[len(lines)-1])
+	}
+	return Usage{Total: total * 1024, Free: free * 1024}, nil
+}
+
+// PruneNodes removes the images no container uses from every node, except
+// those in keep — the images pre-pulled for an install whose pods have not
+// started yet. A node that fails does not stop the others; the failures are
+// reported together.
+func PruneNodes(ctx context.Context, ex executor.CommandExecutor, nodes, keep []string) error {
+	var failed []string
+	for _, node := range nodes {
+		if err := pruneNode(ctx, ex, node, keep); err != nil {
+			failed = append(failed, node)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("image prune failed on node(s): %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// pruneNode is PruneNodes for one node. Without images to keep, crictl's own
+// prune does; otherwise the unused images are listed and removed by ID.
+func pruneNode(ctx context.Context, ex executor.CommandExecutor, node string, keep []string) error {
+	if len(keep) == 0 {
+		_, err := ex.Execute(ctx, "docker", "exec", node, "crictl", "rmi", "--prune")
+		return err
+	}
+	result, err := ex.Execute(ctx, "docker", "exec", node, "crictl", "images", "-o", "json")
+	if err != nil {
+		return err
+	}
+	var images struct {
+		Images []struct {
+			ID       string   `json:"id"`
+			RepoTags []string `json:"repoTags"`
+		} `json:"images"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &images); err != nil {
+		return fmt.Errorf("parsing crictl images: %w", err)
+	}
+	result, err = ex.Execute(ctx, "docker", "exec", node, "crictl", "ps", "-a", "-o", "json")
+	if err != nil {
+		return err
+	}
+	var containers struct {
+		Containers []struct {
+			ImageRef string `json:"imageRef"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &containers); err != nil {
+		return fmt.Errorf("parsing crictl ps: %w", err)
+	}
+
+	skip := map[string]bool{}
+	for _, c := range containers.Containers {
+		skip[c.ImageRef] = true
+	}
+	for _, ref := range keep {
+		skip[normalizeRef(ref)] = true
+	}
+	var remove []string
+	for _, image := range images.Images {
+		if unused(image.ID, image.RepoTags, skip) {
+			remove = append(remove, image.ID)
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+	_, err = ex.Execute(ctx, "docker", append([]string{"exec", node, "crictl", "rmi"}, remove...)...)
+	return err
+}
+
+// unused reports whether an image is neither run by a container nor kept:
+// skip holds the image IDs in use and the normalized references to keep.
+func unused(id string, tags []string, skip map[string]bool) bool {
+	if skip[id] {
+		return false
+	}
+	for _, tag := range tags {
+		if skip[normalizeRef(tag)] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRef spells an image reference the way containerd lists it, so
+// "nginx:1.27" matches "docker.io/library/nginx:1.27".
+func normalizeRef(ref string) string {
+	name, rest := ref, ""
+	if i := strings.Index(ref, "@"); i >= 0 {
+		name, rest = ref[:i], ref[i:]
+	} else if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name, rest = ref[:i], ref[i:]
+	} else {
+		rest = ":latest"
+	}
+	domain, _, found := strings.Cut(name, "/")
+	switch {
+	case !found:
+		name = "docker.io/library/" + name
+	case !strings.ContainsAny(domain, ".:") && domain != "localhost":
+		name = "docker.io/" + name
+	}
+	return name + rest
+}
+
+// PruneHost removes dangling images from the Docker host, or with all every
+// image no container uses, and returns what Docker says it reclaimed.
+func PruneHost(ctx context.Context, ex executor.CommandExecutor, all bool) (string, error) {
+	args := []string{"image", "prune", "--force"}
+	if all {
+		args = append(args, "--all")
+	}
+	result, err := ex.Execute(ctx, "docker", args...)
+	if err != nil {
+		return "", fmt.Errorf("docker image prune: %w", err)
+	}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "Total reclaimed space:"); ok {
+			return strings.TrimSpace(v), nil
+		}
+	}
+	return "", nil
+}
+
+type sample struct {
+	at   time.Time
+	free uint64
+}
+
+// Monitor follows one filesystem's free space over time.
+type Monitor struct {
+	samples   []sample
+	lastPrune time.Time
+	lastWarn  time.Time
+}
+
+// Reading is one observation with what it implies.
+type Reading struct {
+	Usage
+	// TimeToFull is when the disk fills at the rate seen over the last few
+	// minutes; zero when it is not filling.
+	TimeToFull time.Duration
+}
+
+// Warn reports whether the reading is worth telling the user about.
+func (r Reading) Warn() bool {
+	return r.Low() || (r.TimeToFull > 0 && r.TimeToFull < soonFull)
+}
+
+func (r Reading) String() string {
+	s := r.Usage.String()
+	if r.TimeToFull > 0 {
+		s += fmt.Sprintf(", full in about %s at the current rate", r.TimeToFull.Round(time.Minute))
+	}
+	return s
+}
+
+// Observe records u as measured at now and projects when the disk fills.
+func (m *Monitor) Observe(now time.Time, u Usage) Reading {
+	m.samples = append(m.samples, sample{at: now, free: u.Free})
+	for len(m.samples) > 2 && now.Sub(m.samples[1].at) >= window {
+		m.samples = m.samples[1:]
+	}
+	r := Reading{Usage: u}
+	first := m.samples[0]
+	if elapsed := now.Sub(first.at); elapsed > 0 && first.free > u.Free {
+		rate := float64(first.free-u.Free) / elapsed.Seconds() // bytes per second
+		r.TimeToFull = time.Duration(float64(u.Free) / rate * float64(time.Second))
+	}
+	return r
+}
+
+// PruneDue reports whether r calls for a prune, at most once per cooldown,
+// and records it as done. Only a disk under LowFreePercent is pruned: the time
+// to full is an estimate from a few minutes of an install that pulls in
+// bursts, and alone it does not justify removing images.
+func (m *Monitor) PruneDue(now time.Time, r Reading) bool {
+	if !r.Low() || (!m.lastPrune.IsZero() && now.Sub(m.lastPrune) < pruneCooldown) {
+		return false
+	}
+	m.lastPrune, m.lastWarn = now, now
+	return true
+}
+
+// WarnDue reports whether r is worth a warning, at most once per cooldown,
+// and records it as given. A prune warns on its own, so it counts.
+func (m *Monitor) WarnDue(now time.Time, r Reading) bool {
+	if !r.Warn() || (!m.lastWarn.IsZero() && now.Sub(m.lastWarn) < pruneCooldown) {
+		return false
+	}
+	m.lastWarn = now
+	return true
+}
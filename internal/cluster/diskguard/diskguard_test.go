@@ -0,0 +1,116 @@
+package diskguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gib = 1 << 30
+
+func TestNodes(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("docker ps", &executor.CommandResult{Stdout: "k3d-dev-server-0\tserver\nk3d-dev-agent-0\tagent\nk3d-dev-serverlb\tloadbalancer\nk3d-dev-tools\tnoRole\n"})
+
+	nodes, err := Nodes(context.Background(), ex, "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"k3d-dev-server-0", "k3d-dev-agent-0"}, nodes)
+}
+
+func TestMeasure(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("docker exec k3d-dev-server-0 df", &executor.CommandResult{Stdout: "Filesystem     1024-blocks      Used Available Capacity Mounted on\noverlay          263174212 210539368  52634844      80% /\n"})
+	ex.SetResponse("docker exec k3d-dev-agent-0 df", &executor.CommandResult{Stdout: "Filesystem     1024-blocks      Used Available Capacity Mounted on\noverlay          263174212 236856790  26317422      90% /\n"})
+
+	u, err := Measure(context.Background(), ex, []string{"k3d-dev-server-0", "k3d-dev-agent-0"})
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Total: 263174212 * 1024, Free: 26317422 * 1024}, u, "the fullest node counts")
+	assert.True(t, u.Low())
+	assert.Equal(t, "25.1 GiB free of 251.0 GiB (10%)", u.String())
+
+	_, err = Measure(context.Background(), executor.NewMockCommandExecutor(), []string{"k3d-dev-server-0"})
+	assert.ErrorContains(t, err, "unexpected df output")
+}
+
+func TestPruneHost(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("docker image prune", &executor.CommandResult{Stdout: "Deleted Images:\ndeleted: sha256:0123\n\nTotal reclaimed space: 1.2GB\n"})
+
+	reclaimed, err := PruneHost(context.Background(), ex, true)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2GB", reclaimed)
+	assert.Equal(t, []string{"docker image prune --force --all"}, ex.GetExecutedCommands())
+}
+
+func TestPruneNodes_KeepsPrepulled(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("crictl images", &executor.CommandResult{Stdout: `{"images": [
+		{"id": "sha256:aaa", "repoTags": ["quay.io/argoproj/argocd:v3.0.0"]},
+		{"id": "sha256:bbb", "repoTags": ["docker.io/library/redis:7.2"]},
+		{"id": "sha256:ccc", "repoTags": ["docker.io/rancher/mirrored-pause:3.6"]},
+		{"id": "sha256:ddd", "repoTags": ["ghcr.io/acme/old:1.0"]}
+	]}`})
+	ex.SetResponse("crictl ps", &executor.CommandResult{Stdout: `{"containers": [{"imageRef": "sha256:ccc"}]}`})
+
+	err := PruneNodes(context.Background(), ex, []string{"k3d-dev-server-0"}, []string{"quay.io/argoproj/argocd:v3.0.0", "redis:7.2"})
+	require.NoError(t, err)
+	assert.Equal(t, "docker exec k3d-dev-server-0 crictl rmi sha256:ddd", ex.GetLastCommand(),
+		"only the image neither in use nor pre-pulled is removed")
+
+	ex = executor.NewMockCommandExecutor()
+	require.NoError(t, PruneNodes(context.Background(), ex, []string{"k3d-dev-server-0"}, nil))
+	assert.Equal(t, []string{"docker exec k3d-dev-server-0 crictl rmi --prune"}, ex.GetExecutedCommands())
+}
+
+func TestNormalizeRef(t *testing.T) {
+	for ref, want := range map[string]string{
+		"nginx":                        "docker.io/library/nginx:latest",
+		"redis:7.2":                    "docker.io/library/redis:7.2",
+		"bitnami/redis:7.2":            "docker.io/bitnami/redis:7.2",
+		"quay.io/argoproj/argocd:v3":   "quay.io/argoproj/argocd:v3",
+		"localhost:5000/app":           "localhost:5000/app:latest",
+		"ghcr.io/acme/app@sha256:0123": "ghcr.io/acme/app@sha256:0123",
+	} {
+		assert.Equal(t, want, normalizeRef(ref), ref)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	var m Monitor
+	start := time.Now()
+	total := uint64(100 * gib)
+
+	r := m.Observe(start, Usage{Total: total, Free: 40 * gib})
+	assert.Zero(t, r.TimeToFull, "one sample gives no rate")
+	assert.False(t, r.Warn())
+
+	// 1 GiB a minute with 30 GiB left.
+	r = m.Observe(start.Add(10*time.Minute), Usage{Total: total, Free: 30 * gib})
+	assert.Equal(t, 30*time.Minute, r.TimeToFull)
+	assert.False(t, r.Warn())
+	assert.False(t, m.PruneDue(start.Add(10*time.Minute), r))
+
+	// Filling fast but far from low: a warning, no prune.
+	fast := Reading{Usage: Usage{Total: total, Free: 30 * gib}, TimeToFull: 10 * time.Minute}
+	var w Monitor
+	assert.False(t, w.PruneDue(start, fast), "the time to full alone does not prune")
+	assert.True(t, w.WarnDue(start, fast))
+	assert.False(t, w.WarnDue(start.Add(time.Minute), fast), "warnings are spaced by the cooldown")
+
+	// Past the window only recent samples count: 2 GiB a minute with 20 left.
+	r = m.Observe(start.Add(15*time.Minute), Usage{Total: total, Free: 20 * gib})
+	r = m.Observe(start.Add(20*time.Minute), Usage{Total: total, Free: 10 * gib})
+	assert.Equal(t, 5*time.Minute, r.TimeToFull)
+	assert.True(t, r.Warn())
+	assert.True(t, m.PruneDue(start.Add(20*time.Minute), r))
+	assert.False(t, m.PruneDue(start.Add(25*time.Minute), r), "prunes are spaced by the cooldown")
+	assert.True(t, m.PruneDue(start.Add(31*time.Minute), r))
+
+	r = m.Observe(start.Add(32*time.Minute), Usage{Total: total, Free: 12 * gib})
+	assert.Zero(t, r.TimeToFull, "a disk that is not filling has no time to full")
+	assert.True(t, r.Low())
+}
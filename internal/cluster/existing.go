@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/rest"
+)
+
+// showExistingCluster describes the cluster create found under the name it
+// was asked for.
+func (s *ClusterService) showExistingCluster(existingInfo models.ClusterInfo) {
+	pterm.Warning.Printf("Cluster '%s' already exists!\n", pterm.Cyan(existingInfo.Name))
+	pterm.DefaultBasicText.Println()
+
+	boxContent := fmt.Sprintf(
+		"NAME:     %s\n"+
+			"TYPE:     %s\n"+
+			"STATUS:   %s\n"+
+			"NODES:    %d\n"+
+			"NETWORK:  k3d-%s",
+		pterm.Bold.Sprint(existingInfo.Name),
+		strings.ToUpper(string(existingInfo.Type)),
+		pterm.Green("Running"),
+		existingInfo.NodeCount,
+		existingInfo.Name,
+	)
+
+	pterm.DefaultBox.
+		WithTitle(" ⚠️  Cluster Already Running  ⚠️ ").
+		WithTitleTopCenter().
+		Println(boxContent)
+}
+
+// adoptCluster reuses the existing cluster config names for create --adopt:
+// only once its API answers with a Ready node, so a stopped or broken cluster
+// is not handed on as if it were usable. The adoption, and any --label, is
+// recorded in the CLI's state.
+func (s *ClusterService) adoptCluster(ctx context.Context, config models.ClusterConfig) (*rest.Config, error) {
+	restConfig, err := s.manager.GetRestConfig(ctx, config.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot adopt cluster '%s': it is not reachable (start it, or use --recreate): %w", config.Name, err)
+	}
+	accessor, err := k8s.NewAccessorForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot adopt cluster '%s': %w", config.Name, err)
+	}
+	health, err := accessor.CheckHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot adopt cluster '%s': %w", config.Name, err)
+	}
+	if !health.Ready() {
+		return nil, fmt.Errorf("cannot adopt cluster '%s': none of its %d nodes is Ready", config.Name, health.NodesTotal)
+	}
+
+	if err := clusterstate.MarkAdopted(config.Name); err != nil {
+		pterm.Warning.Printf("Could not record the adoption of %s: %v\n", config.Name, err)
+	}
+	recordLabels(config.Name, config.Labels)
+	pterm.Success.Printf("Adopted existing cluster '%s' (%d/%d nodes Ready)\n", config.Name, health.NodesReady, health.NodesTotal)
+	return restConfig, nil
+}
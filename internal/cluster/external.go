@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/rest"
+)
+
+// lookupExternalCluster and loadExternalClusters are overridden in tests.
+var (
+	lookupExternalCluster = k8s.LookupExternalCluster
+	loadExternalClusters  = k8s.LoadExternalClusters
+)
+
+// externalStatus is what `cluster list` shows for an attached cluster: the
+// CLI does not poll clusters it does not manage.
+const externalStatus = "attached"
+
+// externalClusterInfos lists the attached clusters. An unreadable registry
+// lists none rather than failing the k3d listing beside it.
+func externalClusterInfos() []models.ClusterInfo {
+	clusters, err := loadExternalClusters()
+	if err != nil {
+		return nil
+	}
+	out := make([]models.ClusterInfo, 0, len(clusters))
+	for _, c := range clusters {
+		out = append(out, externalClusterInfo(c))
+	}
+	return out
+}
+
+func externalClusterInfo(c k8s.ExternalCluster) models.ClusterInfo {
+	return models.ClusterInfo{
+		Name:      c.Name,
+		Type:      models.ClusterTypeExternal,
+		Status:    externalStatus,
+		CreatedAt: c.AttachedAt,
+	}
+}
+
+func externalRestConfig(c k8s.ExternalCluster) (*rest.Config, error) {
+	return k8s.RestConfigForContext(k8s.KubeconfigForCluster(c.Name), c.Context)
+}
+
+// displayExternalClusterStatus shows where an attached cluster points and
+// whether it answers; k3d details (network, node containers) do not apply.
+func (s *ClusterService) displayExternalClusterStatus(ctx context.Context, c k8s.ExternalCluster) {
+	kubeconfig := c.Kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = k8s.DefaultKubeconfigPath() + " (default)"
+	}
+
+	health := "unreachable"
+	if cfg, err := externalRestConfig(c); err == nil {
+		if accessor, aerr := k8s.NewAccessorForConfig(cfg); aerr == nil {
+			if h, herr := accessor.CheckHealth(ctx); herr == nil && h.Reachable {
+				health = fmt.Sprintf("reachable, %d/%d nodes ready, Kubernetes %s", h.NodesReady, h.NodesTotal, h.ServerVersion)
+			}
+		}
+	}
+
+	pterm.DefaultBasicText.Println()
+	pterm.DefaultBox.
+		WithTitle(" External Cluster ").
+		WithTitleTopCenter().
+		Println(fmt.Sprintf(
+			"NAME:        %s\n"+
+				"TYPE:        EXTERNAL\n"+
+				"CONTEXT:     %s\n"+
+				"KUBECONFIG:  %s\n"+
+				"API:         %s",
+			pterm.Bold.Sprint(c.Name), c.Context, kubeconfig, health))
+	pterm.Info.Println("Lifecycle commands (create, delete, cleanup) are disabled for external clusters")
+}
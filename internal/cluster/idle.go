@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/idle"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/providers/k3d"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
+	"github.com/pterm/pterm"
+)
+
+var _ idle.Backend = (*k3d.K3dManager)(nil)
+
+// WatchIdle blocks until cluster name has had no host traffic for idleAfter,
+// then stops it. The next CLI command that needs the cluster resumes it (see
+// ResumeIdleCluster).
+func (s *ClusterService) WatchIdle(ctx context.Context, name string, idleAfter time.Duration) error {
+	backend, ok := s.manager.(idle.Backend)
+	if !ok {
+		return fmt.Errorf("pause-on-idle is not supported for cluster %s", name)
+	}
+
+	pterm.Info.Printf("Watching cluster '%s'; it will be paused after %s without activity\n", name, idleAfter)
+	if err := idle.NewWatcher(backend, name, idleAfter).Run(ctx); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Cluster '%s' paused after %s idle; the next openframe command against it resumes it\n", name, idleAfter)
+	return nil
+}
+
+// ResumeIdleCluster starts cluster name if WatchIdle paused it; any other
+// paused cluster stays paused. It is cheap when nothing is paused (a directory
+// read, no k3d call), so commands that need the cluster running call it up
+// front. quiet suppresses the progress line for machine output. In read-only
+// mode the cluster is left paused.
+func ResumeIdleCluster(ctx context.Context, name string, quiet bool) error {
+	paused, err := idle.PausedClusters()
+	if err != nil || !slices.Contains(paused, name) {
+		return err
+	}
+	if readonly.Enabled() {
+		if !quiet {
+			pterm.Info.Printf("Leaving idle-paused cluster '%s' paused (read-only mode)\n", name)
+		}
+		return nil
+	}
+
+	if !quiet {
+		pterm.Info.Printf("Resuming idle-paused cluster '%s'\n", name)
+	}
+	manager := k3d.NewK3dManager(executor.NewRealCommandExecutor(false, false), false)
+	_, err = idle.ResumeCluster(ctx, manager, name)
+	return err
+}
+
+// ResumeIdleContext resumes the cluster behind kube-context contextName ("" is
+// the current context, and an attached cluster's name is accepted) the way
+// ResumeIdleCluster does.
+func ResumeIdleContext(ctx context.Context, contextName string, quiet bool) error {
+	name := k8s.ResolveContextName(contextName)
+	if name == "" {
+		if _, current, err := k8s.LoadAllContexts(); err == nil {
+			name = current
+		}
+	}
+	return ResumeIdleCluster(ctx, strings.TrimPrefix(name, "k3d-"), quiet)
+}
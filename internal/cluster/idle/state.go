@@ -0,0 +1,88 @@
+// Package idle pauses local clusters that nobody uses and resumes them on the
+// next CLI command that needs them.
+//
+// Activity is measured from outside the cluster: every kubectl/API request and
+// every ingress request from the host passes through the cluster's load
+// balancer container, while in-cluster controllers talk to the API server
+// directly. A load balancer whose traffic counters stop moving therefore means
+// nobody on the host is using the cluster.
+package idle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// stateDir is where a marker file per paused cluster is kept. A variable so
+// tests can point it at a temp dir.
+var stateDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "idle-paused"), nil
+}
+
+// MarkPaused records that the watcher stopped the cluster, so the next CLI
+// command resumes it. Clusters stopped by other means are never auto-started.
+func MarkPaused(name string) error {
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating idle state directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, name), nil, 0o600)
+}
+
+// ClearPaused forgets the paused marker for name. A missing marker is fine.
+func ClearPaused(name string) error {
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RenamePaused moves the paused marker of a cluster given a new name, if it
+// has one.
+func RenamePaused(name, newName string) error {
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(filepath.Join(dir, name), filepath.Join(dir, newName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// PausedClusters returns the clusters the watcher paused, sorted by name.
+func PausedClusters() ([]string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
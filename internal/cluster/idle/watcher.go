@@ -0,0 +1,119 @@
+package idle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+)
+
+// DefaultPollInterval is how often the watcher samples traffic counters.
+const DefaultPollInterval = time.Minute
+
+// Backend is what the watcher needs from a cluster provider.
+type Backend interface {
+	// TrafficCounter returns a counter that grows with every request from the
+	// host into the cluster (API and ingress).
+	TrafficCounter(ctx context.Context, name string) (uint64, error)
+	StopCluster(ctx context.Context, name string, clusterType models.ClusterType) error
+	StartCluster(ctx context.Context, name string, clusterType models.ClusterType) error
+}
+
+// Watcher stops a cluster after IdleAfter without host traffic.
+type Watcher struct {
+	Backend      Backend
+	Cluster      string
+	IdleAfter    time.Duration
+	PollInterval time.Duration
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewWatcher returns a watcher for the named k3d cluster.
+func NewWatcher(backend Backend, cluster string, idleAfter time.Duration) *Watcher {
+	return &Watcher{
+		Backend:      backend,
+		Cluster:      cluster,
+		IdleAfter:    idleAfter,
+		PollInterval: DefaultPollInterval,
+		now:          time.Now,
+		sleep:        sleepContext,
+	}
+}
+
+// Run samples the cluster's traffic until it has been idle for IdleAfter, then
+// stops the cluster, records it as paused and returns nil. It returns early
+// with ctx's error when cancelled, and with an error when the cluster can no
+// longer be sampled (deleted or stopped by someone else).
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.IdleAfter <= 0 {
+		return fmt.Errorf("idle timeout must be positive, got %s", w.IdleAfter)
+	}
+	last, err := w.Backend.TrafficCounter(ctx, w.Cluster)
+	if err != nil {
+		return fmt.Errorf("cannot watch cluster %s: %w", w.Cluster, err)
+	}
+	lastActive := w.now()
+
+	for {
+		if err := w.sleep(ctx, w.PollInterval); err != nil {
+			return err
+		}
+		current, err := w.Backend.TrafficCounter(ctx, w.Cluster)
+		if err != nil {
+			return fmt.Errorf("cluster %s is no longer reachable: %w", w.Cluster, err)
+		}
+		if current != last {
+			last, lastActive = current, w.now()
+			continue
+		}
+		if w.now().Sub(lastActive) >= w.IdleAfter {
+			return w.pause(ctx)
+		}
+	}
+}
+
+func (w *Watcher) pause(ctx context.Context) error {
+	if err := w.Backend.StopCluster(ctx, w.Cluster, models.ClusterTypeK3d); err != nil {
+		return err
+	}
+	return MarkPaused(w.Cluster)
+}
+
+// ResumeCluster starts cluster name if the watcher paused it, leaving any
+// other paused cluster paused, and clears its marker. resumed is false when
+// name was not paused; a cluster that fails to start keeps its marker so the
+// next command tries again.
+func ResumeCluster(ctx context.Context, backend Backend, name string) (resumed bool, err error) {
+	paused, err := PausedClusters()
+	if err != nil || !slices.Contains(paused, name) {
+		return false, err
+	}
+	if err := resume(ctx, backend, name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// resume starts one paused cluster and clears its marker.
+func resume(ctx context.Context, backend Backend, name string) error {
+	if err := backend.StartCluster(ctx, name, models.ClusterTypeK3d); err != nil {
+		return fmt.Errorf("resuming idle cluster %s: %w", name, err)
+	}
+	return ClearPaused(name)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
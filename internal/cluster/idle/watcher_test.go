@@ -0,0 +1,116 @@
+package idle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend replays a scripted sequence of traffic counters.
+type fakeBackend struct {
+	counters []uint64
+	calls    int
+	stopped  []string
+	started  []string
+	startErr error
+}
+
+func (f *fakeBackend) TrafficCounter(context.Context, string) (uint64, error) {
+	if f.calls >= len(f.counters) {
+		return 0, errors.New("container gone")
+	}
+	c := f.counters[f.calls]
+	f.calls++
+	return c, nil
+}
+
+func (f *fakeBackend) StopCluster(_ context.Context, name string, _ models.ClusterType) error {
+	f.stopped = append(f.stopped, name)
+	return nil
+}
+
+func (f *fakeBackend) StartCluster(_ context.Context, name string, _ models.ClusterType) error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.started = append(f.started, name)
+	return nil
+}
+
+func useTempState(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	orig := stateDir
+	stateDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { stateDir = orig })
+}
+
+// newTestWatcher returns a watcher on a fake clock that advances by the poll
+// interval on every sleep.
+func newTestWatcher(b Backend, idleAfter time.Duration) *Watcher {
+	w := NewWatcher(b, "dev", idleAfter)
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return clock }
+	w.sleep = func(_ context.Context, d time.Duration) error {
+		clock = clock.Add(d)
+		return nil
+	}
+	return w
+}
+
+func TestWatcher_PausesAfterIdlePeriod(t *testing.T) {
+	useTempState(t)
+	// Traffic for two minutes, then flat for three.
+	b := &fakeBackend{counters: []uint64{10, 20, 30, 30, 30, 30}}
+
+	require.NoError(t, newTestWatcher(b, 3*time.Minute).Run(context.Background()))
+	assert.Equal(t, []string{"dev"}, b.stopped)
+	assert.Equal(t, 6, b.calls, "activity must reset the idle timer")
+
+	paused, err := PausedClusters()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev"}, paused)
+}
+
+func TestWatcher_StopsWatchingWhenClusterDisappears(t *testing.T) {
+	useTempState(t)
+	b := &fakeBackend{counters: []uint64{10, 20}}
+
+	err := newTestWatcher(b, time.Hour).Run(context.Background())
+	assert.ErrorContains(t, err, "no longer reachable")
+	assert.Empty(t, b.stopped)
+}
+
+func TestResumeCluster_LeavesOthersPaused(t *testing.T) {
+	useTempState(t)
+	require.NoError(t, MarkPaused("dev"))
+	require.NoError(t, MarkPaused("staging"))
+	b := &fakeBackend{}
+
+	resumed, err := ResumeCluster(context.Background(), b, "dev")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	assert.Equal(t, []string{"dev"}, b.started)
+	paused, _ := PausedClusters()
+	assert.Equal(t, []string{"staging"}, paused, "the marker is cleared once the cluster is back")
+
+	resumed, err = ResumeCluster(context.Background(), b, "prod")
+	require.NoError(t, err)
+	assert.False(t, resumed, "a cluster the watcher did not pause is not started")
+	assert.Equal(t, []string{"dev"}, b.started)
+}
+
+func TestResumeCluster_KeepsMarkerOnFailure(t *testing.T) {
+	useTempState(t)
+	require.NoError(t, MarkPaused("dev"))
+
+	_, err := ResumeCluster(context.Background(), &fakeBackend{startErr: errors.New("docker down")}, "dev")
+	require.Error(t, err)
+	paused, _ := PausedClusters()
+	assert.Equal(t, []string{"dev"}, paused)
+}
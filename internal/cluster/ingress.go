@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+)
+
+// ingressNginxValues publish the controller as a LoadBalancer on ports 80 and
+// 443. k3s's service load balancer binds them on every node, which is where
+// the k3d load balancer forwards the host's HTTP and HTTPS ports. The label
+// lets app install use this controller instead of the platform's own, which
+// would want the same ports and the same nginx class.
+const ingressNginxValues = `controller:
+  labels:
+    ` + models.ClusterIngressLabel + `: "true"
+  ingressClassResource:
+    default: true
+  service:
+    type: LoadBalancer
+    ports:
+      http: 80
+      https: 443
+`
+
+// ingressNginxChart is the pinned ingress-nginx chart --ingress nginx
+// installs; its images are pre-pulled with the rest.
+var ingressNginxChart = prepull.Chart{
+	Release:   "ingress-nginx",
+	Name:      "ingress-nginx",
+	Repo:      "https://kubernetes.github.io/ingress-nginx",
+	Version:   "4.13.0",
+	Namespace: "ingress-nginx",
+	Values:    ingressNginxValues,
+}
+
+// installIngressNginx installs ingressNginxChart into the new cluster and
+// waits for the controller, so the app-of-apps finds an ingress class.
+func (s *ClusterService) installIngressNginx(ctx context.Context, name string) error {
+	return s.installChart(ctx, name, ingressNginxChart)
+}
+
+// installChart installs chart into the new cluster with helm and waits for
+// its workloads to be ready.
+func (s *ClusterService) installChart(ctx context.Context, name string, chart prepull.Chart) error {
+	var sp *spinner.Spinner
+	if !s.suppressUI {
+		sp = spinner.New()
+		sp.Start(fmt.Sprintf("Installing %s...", chart.Release))
+	} else {
+		pterm.Info.Printf("Installing %s...\n", chart.Release)
+	}
+	_, err := s.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "helm",
+		Args: []string{
+			"upgrade", "--install", chart.Release, chart.Name,
+			"--repo", chart.Repo,
+			"--version", chart.Version,
+			"--namespace", chart.Namespace, "--create-namespace",
+			"--kube-context", "k3d-" + name,
+			"--wait", "--timeout", "5m",
+			"-f", "-",
+		},
+		Stdin:   []byte(chart.Values),
+		Timeout: sharedconfig.Timeout(sharedconfig.LongRunning),
+	})
+	if err != nil {
+		if sp != nil {
+			sp.Fail(fmt.Sprintf("Failed to install %s", chart.Release))
+		}
+		return fmt.Errorf("installing %s into cluster %s: %w", chart.Release, name, err)
+	}
+	if sp != nil {
+		sp.Success(fmt.Sprintf("%s installed", chart.Release))
+	}
+	return nil
+}
+
+// prePullChartsFor adds the charts config installs at creation to the
+// injected ones.
+func (s *ClusterService) prePullChartsFor(config models.ClusterConfig) []prepull.Chart {
+	if config.Ingress != models.IngressNginx && len(config.Addons) == 0 {
+		return s.prePullCharts
+	}
+	charts := append([]prepull.Chart(nil), s.prePullCharts...)
+	if config.Ingress == models.IngressNginx {
+		charts = append(charts, ingressNginxChart)
+	}
+	for _, n := range config.Addons {
+		if a, err := addon.Load(n); err == nil {
+			if chart, ok := a.PrePullChart(); ok {
+				charts = append(charts, chart)
+			}
+		}
+	}
+	return charts
+}
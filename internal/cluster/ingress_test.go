@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInstallIngressNginx(t *testing.T) {
+	exec := executor.NewMockCommandExecutor()
+	service := NewClusterServiceSuppressed(exec)
+
+	require.NoError(t, service.installIngressNginx(context.Background(), "dev"))
+	cmd := exec.Commands()[0]
+	assert.Equal(t, "helm upgrade --install ingress-nginx ingress-nginx --repo https://kubernetes.github.io/ingress-nginx --version 4.13.0 --namespace ingress-nginx --create-namespace --kube-context k3d-dev --wait --timeout 5m -f -", cmd.String())
+	assert.Contains(t, string(cmd.Stdin), "type: LoadBalancer")
+
+	exec.SetResponse("helm upgrade", &executor.CommandResult{ExitCode: 1})
+	assert.ErrorContains(t, service.installIngressNginx(context.Background(), "dev"), "installing ingress-nginx into cluster dev")
+}
+
+func TestPrePullChartsFor_AddsIngressNginx(t *testing.T) {
+	argo := prepull.Chart{Release: "argo-cd"}
+	service := NewClusterServiceSuppressed(executor.NewMockCommandExecutor()).WithPrePullCharts(argo)
+
+	assert.Equal(t, []prepull.Chart{argo}, service.prePullChartsFor(models.ClusterConfig{Ingress: models.IngressTraefik}))
+	assert.Equal(t, []prepull.Chart{argo, ingressNginxChart}, service.prePullChartsFor(models.ClusterConfig{Ingress: models.IngressNginx}))
+	assert.Equal(t, []prepull.Chart{argo}, service.prePullCharts, "the injected charts are not changed")
+}
+
+func TestInstallAddons_ContinuesPastAFailure(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mailhog := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mailhog-0", Namespace: addon.DefaultNamespace, Labels: map[string]string{"app.kubernetes.io/name": "mailhog"}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	original := newAddonInstaller
+	newAddonInstaller = func(ex executor.CommandExecutor, cluster string) *addon.Installer {
+		return addon.NewInstaller(ex, cluster, nil).WithClient(fake.NewClientset(mailhog))
+	}
+	t.Cleanup(func() { newAddonInstaller = original })
+
+	exec := executor.NewMockCommandExecutor()
+	exec.SetResponse("--install minio", &executor.CommandResult{ExitCode: 1})
+	service := NewClusterServiceSuppressed(exec)
+
+	service.installAddons(context.Background(), "dev", []string{"minio", "nope", "mailhog"})
+	require.Len(t, exec.Commands(), 2)
+	assert.Equal(t, "helm upgrade --install mailhog mailhog --repo https://codecentric.github.io/helm-charts --version 5.8.0 --namespace openframe-addons --create-namespace --kube-context k3d-dev --wait --timeout 5m -f -", exec.Commands()[1].String())
+	installed, err := addon.InstalledOn("dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mailhog"}, installed)
+}
+
+func TestPrePullChartsFor_AddsAddons(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	service := NewClusterServiceSuppressed(executor.NewMockCommandExecutor())
+
+	got := service.prePullChartsFor(models.ClusterConfig{Addons: []string{"localstack"}})
+	require.Len(t, got, 1)
+	assert.Equal(t, "localstack", got[0].Release)
+	assert.Equal(t, addon.DefaultNamespace, got[0].Namespace)
+}
@@ -0,0 +1,43 @@
+package cluster
+
+import (
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/pterm/pterm"
+)
+
+// recordLabels keeps the labels of a new cluster in the CLI's state, so they
+// survive what the node containers do not, such as a rename.
+func recordLabels(name string, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	if err := clusterstate.SetLabels(name, labels); err != nil {
+		pterm.Warning.Printf("Could not record the labels of %s: %v\n", name, err)
+	}
+}
+
+// withRecordedLabels adds the labels the CLI recorded for each cluster to
+// those its backend reports; the backend's win. Unreadable state leaves the
+// clusters as they are.
+func withRecordedLabels(clusters []models.ClusterInfo) []models.ClusterInfo {
+	records, err := clusterstate.All()
+	if err != nil || len(records) == 0 {
+		return clusters
+	}
+	for i := range clusters {
+		recorded := records[clusters[i].Name].Labels
+		if len(recorded) == 0 {
+			continue
+		}
+		merged := make(map[string]string, len(recorded)+len(clusters[i].Labels))
+		for k, v := range recorded {
+			merged[k] = v
+		}
+		for k, v := range clusters[i].Labels {
+			merged[k] = v
+		}
+		clusters[i].Labels = merged
+	}
+	return clusters
+}
@@ -9,6 +9,13 @@ const (
 	ClusterTypeK3d ClusterType = "k3d"
 	ClusterTypeGKE ClusterType = "gke"
 	ClusterTypeEKS ClusterType = "eks"
+	// Clusters the CLI does not create but recognises by name, so lifecycle
+	// commands can say which backend a cluster belongs to.
+	ClusterTypeKind     ClusterType = "kind"
+	ClusterTypeMinikube ClusterType = "minikube"
+	// ClusterTypeExternal is a cluster reached through an existing kubeconfig
+	// context (see `cluster attach`); its lifecycle is not the CLI's to manage.
+	ClusterTypeExternal ClusterType = "external"
 )
 
 // ClusterConfig holds cluster configuration
@@ -17,6 +24,44 @@ type ClusterConfig struct {
 	Type       ClusterType `json:"type"`
 	NodeCount  int         `json:"node_count"`
 	K8sVersion string      `json:"k8s_version"`
+	// RegistryMirrors configures containerd on every node to pull through
+	// the given mirrors (k3d registries.yaml).
+	RegistryMirrors []RegistryMirror `json:"registry_mirrors,omitempty"`
+	// NodeLabels and NodeTaints are applied by k3s to the nodes their node
+	// filters select, e.g. to dedicate one agent to databases.
+	NodeLabels []NodeLabel `json:"node_labels,omitempty"`
+	NodeTaints []NodeTaint `json:"node_taints,omitempty"`
+	// Labels are the cluster's own labels (--label), kept on its node
+	// containers and in the CLI's state so `cluster list --selector` finds it.
+	Labels map[string]string `json:"labels,omitempty"`
+	// K3sArgs are extra k3s arguments from k3s.extraArgs in the user config
+	// and --k3s-arg, in that order; they override the CLI's defaults.
+	K3sArgs []K3sArg `json:"k3s_args,omitempty"`
+	// Ingress is the ingress controller the cluster starts with; empty is
+	// IngressNone.
+	Ingress Ingress `json:"ingress,omitempty"`
+	// GPUs requests NVIDIA GPU passthrough for the node containers ("all" or
+	// a device count); empty disables it.
+	GPUs string `json:"gpus,omitempty"`
+	// PersistSysctl also writes the raised inotify limits to
+	// /etc/sysctl.d so they survive a reboot.
+	PersistSysctl bool `json:"persist_sysctl,omitempty"`
+	// WaitFor is how many nodes must be Ready before create returns; empty
+	// means all of them.
+	WaitFor WaitFor `json:"wait_for,omitempty"`
+	// SkipImagePrePull turns off pulling the stack's images on the host and
+	// importing them into the new nodes.
+	SkipImagePrePull bool `json:"skip_image_pre_pull,omitempty"`
+	// ImageCache keeps each node's containerd images outside the node, so a
+	// recreated cluster does not download them again: ImageCacheVolume for
+	// Docker volumes, or a host directory. Empty disables it.
+	ImageCache string `json:"image_cache,omitempty"`
+	// Addons names the add-ons installed once the nodes are up; see
+	// internal/addon.
+	Addons []string `json:"addons,omitempty"`
+	// Existing is what to do when a cluster with this name already exists;
+	// empty reuses it.
+	Existing ExistingCluster `json:"-"`
 }
 
 // ClusterInfo represents information about a cluster
@@ -33,13 +78,29 @@ type ClusterInfo struct {
 	K8sVersion   string     `json:"k8s_version,omitempty"`
 	CreatedAt    time.Time  `json:"created_at,omitempty"`
 	Nodes        []NodeInfo `json:"nodes,omitempty"`
+	// Ports are the host ports a k3d cluster publishes; clusters created side
+	// by side each get their own.
+	Ports *ClusterPorts `json:"ports,omitempty"`
+	// Labels are the labels the cluster was created with.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ClusterPorts are the host ports of a cluster's API server and ingress.
+type ClusterPorts struct {
+	API   int `json:"api"`
+	HTTP  int `json:"http"`
+	HTTPS int `json:"https"`
 }
 
 // NodeInfo represents information about a node in the cluster
 type NodeInfo struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
-	Role   string `json:"role"`
+	Name string `json:"name"`
+	// Status is the state of the node's container, e.g. "running" or "exited".
+	Status    string    `json:"status"`
+	Role      string    `json:"role"`
+	Image     string    `json:"image,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
 }
 
 // ProviderOptions contains provider-specific options
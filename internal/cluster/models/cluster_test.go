@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -175,6 +176,12 @@ func TestNodeInfo(t *testing.T) {
 			assert.Equal(t, role, node.Role)
 		}
 	})
+
+	t.Run("omits an unknown creation time from JSON", func(t *testing.T) {
+		data, err := json.Marshal(NodeInfo{Name: "test-node", Status: "running", Role: "server"})
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "created_at")
+	})
 }
 
 func TestProviderOptions(t *testing.T) {
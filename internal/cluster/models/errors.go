@@ -23,6 +23,26 @@ func (e ErrProviderNotFound) Error() string {
 	return fmt.Sprintf("no provider available for cluster type '%s'", e.ClusterType)
 }
 
+// ErrExternalCluster indicates a lifecycle operation on an external cluster,
+// which the CLI reaches but does not manage
+type ErrExternalCluster struct {
+	Name      string
+	Operation string
+}
+
+func (e ErrExternalCluster) Error() string {
+	return fmt.Sprintf("cluster '%s' is external (an existing kube-context, not created by openframe): openframe cannot %s it", e.Name, e.Operation)
+}
+
+// ErrClusterExists indicates create was asked for a name a cluster already has
+type ErrClusterExists struct {
+	Name string
+}
+
+func (e ErrClusterExists) Error() string {
+	return fmt.Sprintf("cluster '%s' already exists: reuse it with --adopt, delete and create it again with --recreate, or pick another name", e.Name)
+}
+
 // ErrInvalidClusterConfig indicates the cluster configuration is invalid
 type ErrInvalidClusterConfig struct {
 	Field  string
@@ -59,6 +79,16 @@ func NewProviderNotFoundError(clusterType ClusterType) error {
 	return ErrProviderNotFound{ClusterType: clusterType}
 }
 
+// NewExternalClusterError creates a new external cluster error
+func NewExternalClusterError(name, operation string) error {
+	return ErrExternalCluster{Name: name, Operation: operation}
+}
+
+// NewClusterExistsError creates a new cluster exists error
+func NewClusterExistsError(name string) error {
+	return ErrClusterExists{Name: name}
+}
+
 // NewInvalidConfigError creates a new invalid config error
 func NewInvalidConfigError(field string, value interface{}, reason string) error {
 	return ErrInvalidClusterConfig{Field: field, Value: value, Reason: reason}
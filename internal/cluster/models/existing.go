@@ -0,0 +1,35 @@
+package models
+
+import "fmt"
+
+// ExistingCluster is what cluster create does when a cluster with the same
+// name already exists (--adopt, --recreate).
+type ExistingCluster string
+
+const (
+	// ExistingReuse returns the existing cluster as it is. Bootstrap relies
+	// on it to run again over the cluster it created.
+	ExistingReuse ExistingCluster = ""
+	// ExistingFail stops with ErrClusterExists. The default of cluster create.
+	ExistingFail ExistingCluster = "fail"
+	// ExistingAdopt reuses the cluster once its API answers, and records
+	// it as adopted.
+	ExistingAdopt ExistingCluster = "adopt"
+	// ExistingRecreate deletes the cluster and creates it again.
+	ExistingRecreate ExistingCluster = "recreate"
+)
+
+// ParseExisting turns --adopt and --recreate into an ExistingCluster;
+// neither is ExistingFail.
+func ParseExisting(adopt, recreate bool) (ExistingCluster, error) {
+	switch {
+	case adopt && recreate:
+		return "", fmt.Errorf("--adopt and --recreate cannot be used together")
+	case adopt:
+		return ExistingAdopt, nil
+	case recreate:
+		return ExistingRecreate, nil
+	default:
+		return ExistingFail, nil
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExisting(t *testing.T) {
+	cases := []struct {
+		adopt, recreate bool
+		want            ExistingCluster
+	}{
+		{false, false, ExistingFail},
+		{true, false, ExistingAdopt},
+		{false, true, ExistingRecreate},
+	}
+	for _, c := range cases {
+		got, err := ParseExisting(c.adopt, c.recreate)
+		require.NoError(t, err)
+		assert.Equal(t, c.want, got)
+	}
+
+	_, err := ParseExisting(true, true)
+	assert.ErrorContains(t, err, "cannot be used together")
+}
+
+func TestErrClusterExists(t *testing.T) {
+	err := NewClusterExistsError("dev")
+	assert.ErrorContains(t, err, "cluster 'dev' already exists")
+	assert.ErrorContains(t, err, "--adopt")
+	assert.ErrorContains(t, err, "--recreate")
+
+	var exists ErrClusterExists
+	require.True(t, errors.As(NewClusterOperationError("create", "dev", err), &exists))
+	assert.Equal(t, "dev", exists.Name)
+}
@@ -19,12 +19,40 @@ type CreateFlags struct {
 	NodeCount   int
 	K8sVersion  string
 	SkipWizard  bool
+	// RegistryMirrors holds raw "source=endpoint" values from --registry-mirror.
+	RegistryMirrors []string
+	// NodeLabels and NodeTaints hold raw --node-label/--node-taint values.
+	NodeLabels []string
+	NodeTaints []string
+	// Labels holds raw --label values.
+	Labels []string
+	// K3sArgs holds raw --k3s-arg values.
+	K3sArgs []string
+	// Ingress holds the raw --ingress value.
+	Ingress string
+	// GPUs is the raw --gpus value ("all" or a device count).
+	GPUs string
+	// PersistSysctl is --persist-sysctl.
+	PersistSysctl bool
+	// WaitFor is the raw --wait-for value.
+	WaitFor string
+	// NoPrePull is --no-prepull.
+	NoPrePull bool
+	// ImageCache is the raw --image-cache value.
+	ImageCache string
+	// Addons holds the raw --addons values.
+	Addons []string
+	// Adopt and Recreate are --adopt and --recreate.
+	Adopt    bool
+	Recreate bool
 }
 
 // ListFlags contains flags specific to list command
 type ListFlags struct {
 	GlobalFlags
 	Quiet bool
+	// Selector is the raw --selector value.
+	Selector string
 }
 
 // StatusFlags contains flags specific to status command
@@ -58,13 +86,29 @@ func AddGlobalFlags(cmd *cobra.Command, global *GlobalFlags) {
 func AddCreateFlags(cmd *cobra.Command, flags *CreateFlags) {
 	cmd.Flags().StringVarP(&flags.ClusterType, "type", "t", "", "Cluster type (k3d, gke)")
 	cmd.Flags().IntVarP(&flags.NodeCount, "nodes", "n", 3, "Number of nodes (default 3)")
-	cmd.Flags().StringVar(&flags.K8sVersion, "version", "", "Kubernetes version")
+	cmd.Flags().StringVar(&flags.K8sVersion, "version", "", "Kubernetes version: a rancher/k3s tag (v1.31.5-k3s1) or shorthand (1.31, 1.31.5) resolved to the newest release")
 	cmd.Flags().BoolVar(&flags.SkipWizard, "skip-wizard", false, "Skip interactive wizard")
+	cmd.Flags().StringArrayVar(&flags.RegistryMirrors, "registry-mirror", nil, "Pull images for a registry through a mirror, as source=endpoint (repeatable, e.g. docker.io=https://mirror.example.com)")
+	cmd.Flags().StringArrayVar(&flags.NodeLabels, "node-label", nil, "Label nodes as key=value[@nodefilter] (repeatable, e.g. workload=db@agent:0)")
+	cmd.Flags().StringArrayVar(&flags.Labels, "label", nil, "Label the cluster as key=value (repeatable, e.g. team=payments); see cluster list --selector")
+	cmd.Flags().StringArrayVar(&flags.NodeTaints, "node-taint", nil, "Taint nodes as key[=value]:Effect[@nodefilter] (repeatable, e.g. dedicated=db:NoSchedule@agent:0)")
+	cmd.Flags().StringVar(&flags.Ingress, "ingress", string(IngressNone), "Ingress controller to start with: traefik (k3s bundled), nginx (ingress-nginx via helm) or none")
+	cmd.Flags().StringArrayVar(&flags.K3sArgs, "k3s-arg", nil, "Pass ARG[@nodefilter] to k3s, servers by default (repeatable, e.g. --k3s-arg=--kube-proxy-arg=proxy-mode=ipvs); overrides the default for the same flag, --disable values add up")
+	cmd.Flags().StringVar(&flags.GPUs, "gpus", "", "Pass NVIDIA GPUs through to the cluster nodes (all or a device count; needs the NVIDIA Container Toolkit on the Docker host)")
+	cmd.Flags().BoolVar(&flags.PersistSysctl, "persist-sysctl", false, "Also persist the raised inotify limits in /etc/sysctl.d/99-openframe.conf so they survive reboots")
+	cmd.Flags().StringVar(&flags.WaitFor, "wait-for", string(WaitForAll), "Nodes that must be Ready before create returns: all, quorum (a majority) or one")
+	cmd.Flags().StringVar(&flags.ImageCache, "image-cache", "", "Keep node images across cluster recreations: \"volume\" for Docker volumes, or a host directory")
+	cmd.Flags().Lookup("image-cache").NoOptDefVal = ImageCacheVolume
+	cmd.Flags().StringSliceVar(&flags.Addons, "addons", nil, "Install add-ons with the cluster, by name, definition file or URL, comma-separated; built in: minio (S3), localstack (AWS APIs), mailhog (SMTP)")
+	cmd.Flags().BoolVar(&flags.NoPrePull, "no-prepull", false, "Skip pre-pulling the images of the ArgoCD, ingress and add-on charts and the prePull.images list on the host; the nodes pull them themselves")
+	cmd.Flags().BoolVar(&flags.Adopt, "adopt", false, "If a cluster with this name already exists, check that it is reachable and reuse it instead of failing")
+	cmd.Flags().BoolVar(&flags.Recreate, "recreate", false, "If a cluster with this name already exists, delete it and create it again")
 }
 
 // AddListFlags adds list-specific flags to a command
 func AddListFlags(cmd *cobra.Command, flags *ListFlags) {
 	cmd.Flags().BoolVarP(&flags.Quiet, "quiet", "q", false, "Only show cluster names")
+	cmd.Flags().StringVarP(&flags.Selector, "selector", "l", "", "Only show clusters whose labels match, e.g. team=payments or 'env in (dev,qa)'")
 }
 
 // AddStatusFlags adds status-specific flags to a command
@@ -137,12 +181,44 @@ func ValidateCreateFlags(flags *CreateFlags) error {
 		return fmt.Errorf("node count must be at least 1: %d", flags.NodeCount)
 	}
 
+	if _, err := ParseRegistryMirrors(flags.RegistryMirrors); err != nil {
+		return err
+	}
+	if _, err := ParseNodeLabels(flags.NodeLabels); err != nil {
+		return err
+	}
+	if _, err := ParseNodeTaints(flags.NodeTaints); err != nil {
+		return err
+	}
+	if _, err := ParseClusterLabels(flags.Labels); err != nil {
+		return err
+	}
+	if _, err := ParseK3sArgs(flags.K3sArgs); err != nil {
+		return err
+	}
+	if _, err := ParseIngress(flags.Ingress); err != nil {
+		return err
+	}
+	if err := ValidateGPURequest(flags.GPUs); err != nil {
+		return err
+	}
+	if _, err := ParseExisting(flags.Adopt, flags.Recreate); err != nil {
+		return err
+	}
+	if _, err := ParseWaitFor(flags.WaitFor); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // ValidateListFlags validates list flag combinations
 func ValidateListFlags(flags *ListFlags) error {
-	return ValidateGlobalFlags(&flags.GlobalFlags)
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	_, err := ParseClusterSelector(flags.Selector)
+	return err
 }
 
 // ValidateStatusFlags validates status flag combinations
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ValidateGPURequest checks a --gpus value: empty (no GPUs), "all", or a
+// positive number of devices, matching docker's --gpus syntax.
+func ValidateGPURequest(gpus string) error {
+	if gpus == "" || gpus == "all" {
+		return nil
+	}
+	if n, err := strconv.Atoi(gpus); err != nil || n < 1 {
+		return fmt.Errorf("invalid --gpus value %q: use all or a positive device count", gpus)
+	}
+	return nil
+}
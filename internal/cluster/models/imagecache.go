@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageCacheVolume is the --image-cache value that keeps each node's image
+// cache in a Docker volume.
+const ImageCacheVolume = "volume"
+
+// ParseImageCache checks an --image-cache value: empty (no cache),
+// ImageCacheVolume, or a host directory, returned absolute with a leading ~/
+// expanded (the shell leaves --image-cache=~/dir alone).
+func ParseImageCache(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == ImageCacheVolume {
+		return s, nil
+	}
+	if !strings.ContainsAny(s, `/\`) {
+		return "", fmt.Errorf("invalid --image-cache value %q: use %q or a host directory such as ~/.openframe/image-cache", s, ImageCacheVolume)
+	}
+	if rest, ok := strings.CutPrefix(s, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("invalid --image-cache directory %q: %w", s, err)
+		}
+		s = filepath.Join(home, rest)
+	}
+	abs, err := filepath.Abs(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid --image-cache directory %q: %w", s, err)
+	}
+	return abs, nil
+}
+
+// ImageCache is the Docker volume holding one node's containerd image cache,
+// kept across deletes so a recreated cluster starts with its images.
+type ImageCache struct {
+	Volume  string
+	Cluster string // the cluster it was created for
+	Live    bool   // the cluster still exists
+}
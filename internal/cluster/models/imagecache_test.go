@@ -0,0 +1,25 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageCache(t *testing.T) {
+	for _, v := range []string{"", "volume"} {
+		got, err := ParseImageCache(v)
+		require.NoError(t, err)
+		assert.Equal(t, v, got)
+	}
+	_, err := ParseImageCache("volumes")
+	assert.Error(t, err, "a typo is not taken for a relative directory")
+
+	home, _ := os.UserHomeDir()
+	got, err := ParseImageCache("~/.openframe/image-cache")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".openframe", "image-cache"), got)
+}
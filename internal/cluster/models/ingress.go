@@ -0,0 +1,31 @@
+package models
+
+import "fmt"
+
+// Ingress is the ingress controller a new cluster starts with.
+type Ingress string
+
+const (
+	// IngressNone leaves ingress to the stack: k3s's traefik is disabled and
+	// nothing else is installed.
+	IngressNone Ingress = "none"
+	// IngressTraefik keeps the traefik that k3s bundles.
+	IngressTraefik Ingress = "traefik"
+	// IngressNginx installs ingress-nginx with helm once the nodes are up.
+	IngressNginx Ingress = "nginx"
+)
+
+// ClusterIngressLabel marks the ingress-nginx controller IngressNginx
+// installs, so the platform install can find it and not start a second one.
+const ClusterIngressLabel = "openframe.io/cluster-ingress"
+
+// ParseIngress checks an --ingress value; empty means IngressNone.
+func ParseIngress(s string) (Ingress, error) {
+	switch Ingress(s) {
+	case "", IngressNone:
+		return IngressNone, nil
+	case IngressTraefik, IngressNginx:
+		return Ingress(s), nil
+	}
+	return "", fmt.Errorf("invalid --ingress value %q: use traefik, nginx or none", s)
+}
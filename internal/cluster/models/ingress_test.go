@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIngress(t *testing.T) {
+	for in, want := range map[string]Ingress{"": IngressNone, "none": IngressNone, "traefik": IngressTraefik, "nginx": IngressNginx} {
+		got, err := ParseIngress(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseIngress("istio")
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+)
+
+// defaultK3sArgFilter is where a k3s argument goes without a node filter:
+// most k3s flags (--disable, --kube-proxy-arg, --kube-apiserver-arg) only
+// exist on servers, and an agent refuses to start on one it does not know.
+const defaultK3sArgFilter = "server:*"
+
+// K3sArg is an argument passed to k3s on the nodes selected by NodeFilters.
+// It replaces the CLI's default argument with the same key (see Key); an Arg
+// ending in "=" only removes that default. --disable values add up instead.
+type K3sArg struct {
+	Arg         string   `json:"arg"`
+	NodeFilters []string `json:"nodeFilters,omitempty"`
+}
+
+// Key identifies the setting an argument changes: the flag name, and for the
+// pass-through flags (--kubelet-arg, --kube-proxy-arg, ...) the component
+// flag as well, so "--kubelet-arg=eviction-hard=..." overrides only its own
+// default. Each --disable value is a setting of its own, so a user's
+// "--disable=servicelb" is merged with the traefik one --ingress adds.
+func (a K3sArg) Key() string {
+	name, value, _ := strings.Cut(a.Arg, "=")
+	if strings.HasSuffix(name, "-arg") {
+		inner, _, _ := strings.Cut(value, "=")
+		return name + "=" + inner
+	}
+	if name == "--disable" {
+		return a.Arg
+	}
+	return name
+}
+
+// Removes reports whether the argument only drops the default it overrides,
+// e.g. "--kubelet-arg=eviction-hard=" to keep kubelet's hard eviction.
+func (a K3sArg) Removes() bool {
+	return strings.HasSuffix(a.Arg, "=")
+}
+
+// ParseK3sArg parses "ARG[@filter[;filter...]]", e.g.
+// "--kube-proxy-arg=proxy-mode=ipvs@server:*". Without a filter the argument
+// goes to the servers.
+func ParseK3sArg(spec string) (K3sArg, error) {
+	spec = strings.TrimSpace(spec)
+	body, filters, err := splitNodeFilters(spec)
+	if err != nil {
+		return K3sArg{}, err
+	}
+	if !strings.Contains(spec, "@") {
+		filters = []string{defaultK3sArgFilter}
+	}
+	if err := validateK3sArg(body); err != nil {
+		return K3sArg{}, fmt.Errorf("invalid k3s arg %q: %w", spec, err)
+	}
+	return K3sArg{Arg: body, NodeFilters: filters}, nil
+}
+
+// ParseK3sArgs parses every --k3s-arg value, keeping order.
+func ParseK3sArgs(specs []string) ([]K3sArg, error) {
+	args := make([]K3sArg, 0, len(specs))
+	for _, spec := range specs {
+		arg, err := ParseK3sArg(spec)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// ConfiguredK3sArgs reads k3s.extraArgs from ~/.openframe/config.json:
+//
+//	{ "k3s": { "extraArgs": [ { "arg": "--disable=servicelb", "nodeFilters": ["server:*"] } ] } }
+//
+// A missing file or key means none; a missing nodeFilters means the servers.
+func ConfiguredK3sArgs() ([]K3sArg, error) {
+	var cfg struct {
+		K3s struct {
+			ExtraArgs []K3sArg `json:"extraArgs"`
+		} `json:"k3s"`
+	}
+	if err := sharedconfig.LoadUserConfig(&cfg); err != nil {
+		return nil, err
+	}
+	args := cfg.K3s.ExtraArgs
+	for i, arg := range args {
+		if err := validateK3sArg(arg.Arg); err != nil {
+			return nil, fmt.Errorf("invalid k3s.extraArgs entry %q in the CLI config: %w", arg.Arg, err)
+		}
+		if len(arg.NodeFilters) == 0 {
+			args[i].NodeFilters = []string{defaultK3sArgFilter}
+		}
+		for _, f := range arg.NodeFilters {
+			if !nodeFilterPattern.MatchString(f) {
+				return nil, fmt.Errorf("invalid node filter %q for k3s.extraArgs entry %q in the CLI config", f, arg.Arg)
+			}
+		}
+	}
+	return args, nil
+}
+
+func validateK3sArg(arg string) error {
+	if !strings.HasPrefix(arg, "--") || len(arg) == 2 {
+		return errors.New("expected a k3s flag such as --disable=traefik")
+	}
+	if strings.ContainsAny(arg, " \t\n") {
+		return errors.New("one flag per value; repeat the option for more")
+	}
+	if arg == "--disable=" {
+		return errors.New("--disable values add up and cannot be cleared; use --ingress traefik to keep traefik")
+	}
+	return nil
+}
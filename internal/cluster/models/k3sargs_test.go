@@ -0,0 +1,63 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolateConfig points the user config at a temp file holding content ("" = none).
+func isolateConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	orig := sharedconfig.UserConfigFile
+	sharedconfig.UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { sharedconfig.UserConfigFile = orig })
+}
+
+func TestParseK3sArg(t *testing.T) {
+	arg, err := ParseK3sArg("--kubelet-arg=feature-gates=InPlacePodVerticalScaling=true@all")
+	require.NoError(t, err)
+	assert.Equal(t, K3sArg{Arg: "--kubelet-arg=feature-gates=InPlacePodVerticalScaling=true", NodeFilters: []string{"all"}}, arg)
+	assert.Equal(t, "--kubelet-arg=feature-gates", arg.Key())
+
+	arg, err = ParseK3sArg("--kubelet-arg=eviction-hard=")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"server:*"}, arg.NodeFilters, "no filter means the servers")
+	assert.True(t, arg.Removes())
+
+	arg, err = ParseK3sArg("--disable=servicelb")
+	require.NoError(t, err)
+	assert.Equal(t, "--disable=servicelb", arg.Key(), "--disable values add up")
+
+	for _, spec := range []string{"disable=traefik", "--", "--disable=", "--disable=traefik --flannel-backend=none", "--disable=traefik@worker:0"} {
+		_, err := ParseK3sArg(spec)
+		assert.Errorf(t, err, "k3s arg %q should be rejected", spec)
+	}
+}
+
+func TestConfiguredK3sArgs(t *testing.T) {
+	isolateConfig(t, "")
+	args, err := ConfiguredK3sArgs()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	isolateConfig(t, `{"k3s": {"extraArgs": [{"arg": "--kube-proxy-arg=proxy-mode=ipvs"}, {"arg": "--kubelet-arg=max-pods=200", "nodeFilters": ["agent:*"]}]}}`)
+	args, err = ConfiguredK3sArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []K3sArg{
+		{Arg: "--kube-proxy-arg=proxy-mode=ipvs", NodeFilters: []string{"server:*"}},
+		{Arg: "--kubelet-arg=max-pods=200", NodeFilters: []string{"agent:*"}},
+	}, args)
+
+	isolateConfig(t, `{"k3s": {"extraArgs": [{"arg": "--disable=traefik", "nodeFilters": ["workers"]}]}}`)
+	_, err = ConfiguredK3sArgs()
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseClusterLabels parses every --label value, "key=value" with a
+// Kubernetes label key and value, e.g. "team=payments". A key given twice
+// keeps its last value.
+func ParseClusterLabels(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		key, value, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label %q: expected key=value", spec)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label %q: key %s", spec, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label %q: value %s", spec, strings.Join(errs, "; "))
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// ParseClusterSelector parses a --selector value with the syntax of kubectl's
+// label selectors: "team=payments", "env!=prod", "tier in (a,b)", "!legacy".
+// An empty selector matches every cluster.
+func ParseClusterSelector(selector string) (labels.Selector, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --selector %q: %w", selector, err)
+	}
+	return parsed, nil
+}
+
+// FilterClusters returns the clusters whose labels match selector, in order.
+func FilterClusters(clusters []ClusterInfo, selector labels.Selector) []ClusterInfo {
+	if selector == nil || selector.Empty() {
+		return clusters
+	}
+	matched := make([]ClusterInfo, 0, len(clusters))
+	for _, c := range clusters {
+		if selector.Matches(labels.Set(c.Labels)) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClusterLabels(t *testing.T) {
+	parsed, err := ParseClusterLabels([]string{"team=payments", "example.com/env=dev", "team=search", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "search", "example.com/env": "dev", "empty": ""}, parsed, "the last value of a key wins")
+
+	parsed, err = ParseClusterLabels(nil)
+	require.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	for _, bad := range []string{"team", "=payments", "team=pay ments", "bad key=x", "-team=x"} {
+		_, err := ParseClusterLabels([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFilterClusters(t *testing.T) {
+	clusters := []ClusterInfo{
+		{Name: "pay", Labels: map[string]string{"team": "payments", "env": "dev"}},
+		{Name: "search", Labels: map[string]string{"team": "search"}},
+		{Name: "plain"},
+	}
+	names := func(cs []ClusterInfo) []string {
+		out := []string{}
+		for _, c := range cs {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+	for selector, want := range map[string][]string{
+		"":                          {"pay", "search", "plain"},
+		"team=payments":             {"pay"},
+		"team!=payments":            {"search", "plain"},
+		"team in (payments,search)": {"pay", "search"},
+		"!team":                     {"plain"},
+		"team=payments,env=prod":    {},
+	} {
+		sel, err := ParseClusterSelector(selector)
+		require.NoError(t, err, selector)
+		assert.Equal(t, want, names(FilterClusters(clusters, sel)), selector)
+	}
+
+	_, err := ParseClusterSelector("team in payments")
+	assert.ErrorContains(t, err, "invalid --selector")
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RegistryMirror redirects image pulls for one upstream registry (Source, e.g.
+// "docker.io", "ghcr.io") to a mirror endpoint. Several mirrors for the same
+// source are tried in order, the upstream last.
+type RegistryMirror struct {
+	Source   string `json:"source"`
+	Endpoint string `json:"endpoint"`
+}
+
+// ParseRegistryMirror parses a "source=endpoint" flag value. The endpoint
+// defaults to https:// when it has no scheme.
+func ParseRegistryMirror(spec string) (RegistryMirror, error) {
+	source, endpoint, ok := strings.Cut(strings.TrimSpace(spec), "=")
+	if !ok {
+		return RegistryMirror{}, fmt.Errorf("invalid registry mirror %q: expected source=endpoint (e.g. docker.io=https://mirror.example.com)", spec)
+	}
+	source, endpoint = strings.TrimSpace(source), strings.TrimSpace(endpoint)
+
+	if source == "" || strings.Contains(source, "://") || strings.ContainsAny(source, "/ \t") {
+		return RegistryMirror{}, fmt.Errorf("invalid registry mirror %q: source must be a registry host such as docker.io or quay.io", spec)
+	}
+	if endpoint != "" && !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return RegistryMirror{}, fmt.Errorf("invalid registry mirror %q: endpoint must be an http(s) URL", spec)
+	}
+	return RegistryMirror{Source: source, Endpoint: strings.TrimSuffix(endpoint, "/")}, nil
+}
+
+// ParseRegistryMirrors parses every "source=endpoint" value, keeping order.
+func ParseRegistryMirrors(specs []string) ([]RegistryMirror, error) {
+	mirrors := make([]RegistryMirror, 0, len(specs))
+	for _, spec := range specs {
+		mirror, err := ParseRegistryMirror(spec)
+		if err != nil {
+			return nil, err
+		}
+		mirrors = append(mirrors, mirror)
+	}
+	return mirrors, nil
+}
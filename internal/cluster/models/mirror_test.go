@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegistryMirror(t *testing.T) {
+	tests := []struct {
+		spec string
+		want RegistryMirror
+	}{
+		{"docker.io=https://mirror.example.com", RegistryMirror{Source: "docker.io", Endpoint: "https://mirror.example.com"}},
+		{"quay.io=mirror.internal:5000/", RegistryMirror{Source: "quay.io", Endpoint: "https://mirror.internal:5000"}},
+		{" ghcr.io = http://10.0.0.5:5000 ", RegistryMirror{Source: "ghcr.io", Endpoint: "http://10.0.0.5:5000"}},
+		{"*=https://catch-all.example.com", RegistryMirror{Source: "*", Endpoint: "https://catch-all.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseRegistryMirror(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRegistryMirror_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"docker.io",
+		"=https://mirror.example.com",
+		"https://docker.io=https://mirror.example.com",
+		"docker.io=",
+		"docker.io=ftp://mirror.example.com",
+	} {
+		_, err := ParseRegistryMirror(spec)
+		assert.Errorf(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+func TestValidateCreateFlags_RegistryMirror(t *testing.T) {
+	flags := &CreateFlags{NodeCount: 1, RegistryMirrors: []string{"docker.io"}}
+	assert.Error(t, ValidateCreateFlags(flags))
+
+	flags.RegistryMirrors = []string{"docker.io=mirror.example.com"}
+	assert.NoError(t, ValidateCreateFlags(flags))
+}
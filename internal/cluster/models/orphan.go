@@ -0,0 +1,19 @@
+package models
+
+// OrphanKind is the type of resource a deleted cluster left behind.
+type OrphanKind string
+
+const (
+	OrphanContainer OrphanKind = "container"
+	OrphanNetwork   OrphanKind = "network"
+	OrphanVolume    OrphanKind = "volume"
+	OrphanFile      OrphanKind = "file"
+)
+
+// Orphan is a Docker resource or local file of a cluster that no longer
+// exists, as left by a failed create or a forced delete.
+type Orphan struct {
+	Kind    OrphanKind
+	Name    string // container, network or volume name; path of a file
+	Cluster string // the cluster it belonged to, "" when unknown
+}
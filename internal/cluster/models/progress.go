@@ -0,0 +1,70 @@
+package models
+
+import "context"
+
+// CreatePhase is an observable step of cluster creation. Phases only move
+// forward; a provider may skip one (images already pulled) but never returns
+// to an earlier one.
+type CreatePhase int
+
+const (
+	PhasePullImages CreatePhase = iota + 1
+	PhaseCreateNodes
+	PhaseLoadBalancer
+	PhaseKubeconfig
+	PhaseWaitNodes
+)
+
+// CreatePhaseCount is the number of creation phases, for "[2/5]" displays.
+const CreatePhaseCount = int(PhaseWaitNodes)
+
+// String is the phase in progress, e.g. "Creating nodes".
+func (p CreatePhase) String() string {
+	switch p {
+	case PhasePullImages:
+		return "Pulling images"
+	case PhaseCreateNodes:
+		return "Creating nodes"
+	case PhaseLoadBalancer:
+		return "Starting load balancer"
+	case PhaseKubeconfig:
+		return "Writing kubeconfig"
+	case PhaseWaitNodes:
+		return "Waiting for nodes to be Ready"
+	default:
+		return "Creating cluster"
+	}
+}
+
+// Done is the phase once finished, e.g. "Nodes created".
+func (p CreatePhase) Done() string {
+	switch p {
+	case PhasePullImages:
+		return "Images pulled"
+	case PhaseCreateNodes:
+		return "Nodes created"
+	case PhaseLoadBalancer:
+		return "Load balancer started"
+	case PhaseKubeconfig:
+		return "Kubeconfig written"
+	case PhaseWaitNodes:
+		return "Nodes Ready"
+	default:
+		return "Cluster created"
+	}
+}
+
+type phaseKey struct{}
+
+// WithCreatePhases returns a context carrying fn as the sink for creation
+// phases. Like executor.WithProgress, the layer owning the display sets it and
+// providers report through CreatePhasesFrom.
+func WithCreatePhases(ctx context.Context, fn func(CreatePhase)) context.Context {
+	return context.WithValue(ctx, phaseKey{}, fn)
+}
+
+// CreatePhasesFrom returns the phase sink carried by ctx, or nil.
+func CreatePhasesFrom(ctx context.Context) func(CreatePhase) {
+	fn, _ := ctx.Value(phaseKey{}).(func(CreatePhase))
+	return fn
+}
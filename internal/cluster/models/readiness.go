@@ -0,0 +1,44 @@
+package models
+
+import "fmt"
+
+// WaitFor is how many nodes must be Ready before cluster create returns
+// (--wait-for).
+type WaitFor string
+
+const (
+	// WaitForAll waits for every node, so nothing is scheduled onto an agent
+	// that never came up. The default.
+	WaitForAll WaitFor = "all"
+	// WaitForQuorum waits for a majority of the nodes.
+	WaitForQuorum WaitFor = "quorum"
+	// WaitForOne returns as soon as the API answers with one Ready node.
+	WaitForOne WaitFor = "one"
+)
+
+// ParseWaitFor checks a --wait-for value; empty is WaitForAll.
+func ParseWaitFor(s string) (WaitFor, error) {
+	switch w := WaitFor(s); w {
+	case "":
+		return WaitForAll, nil
+	case WaitForAll, WaitForQuorum, WaitForOne:
+		return w, nil
+	default:
+		return "", fmt.Errorf("invalid --wait-for value %q: use all, quorum or one", s)
+	}
+}
+
+// RequiredReady is how many of total nodes must be Ready; never less than one.
+func (w WaitFor) RequiredReady(total int) int {
+	if total < 1 {
+		return 1
+	}
+	switch w {
+	case WaitForOne:
+		return 1
+	case WaitForQuorum:
+		return total/2 + 1
+	default:
+		return total
+	}
+}
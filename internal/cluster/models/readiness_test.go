@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWaitFor(t *testing.T) {
+	w, err := ParseWaitFor("")
+	require.NoError(t, err)
+	assert.Equal(t, WaitForAll, w, "empty defaults to all")
+
+	for _, s := range []string{"all", "quorum", "one"} {
+		w, err := ParseWaitFor(s)
+		require.NoError(t, err)
+		assert.Equal(t, WaitFor(s), w)
+	}
+
+	_, err = ParseWaitFor("most")
+	assert.ErrorContains(t, err, "use all, quorum or one")
+}
+
+func TestWaitFor_RequiredReady(t *testing.T) {
+	cases := []struct {
+		w     WaitFor
+		total int
+		want  int
+	}{
+		{WaitForAll, 3, 3},
+		{"", 3, 3},
+		{WaitForQuorum, 3, 2},
+		{WaitForQuorum, 4, 3},
+		{WaitForQuorum, 1, 1},
+		{WaitForOne, 5, 1},
+		{WaitForAll, 0, 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, c.w.RequiredReady(c.total), "%q of %d", c.w, c.total)
+	}
+}
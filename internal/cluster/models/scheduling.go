@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultNodeFilter applies a label or taint to every node when no k3d node
+// filter is given.
+const defaultNodeFilter = "all"
+
+// nodeFilterPattern accepts k3d node filters such as "all", "server:0",
+// "agent:*" and "agent:1-2".
+var nodeFilterPattern = regexp.MustCompile(`^(all|servers?|agents?|loadbalancer)(:(\*|\d+(-\d+)?))?$`)
+
+// NodeLabel is a Kubernetes node label applied by k3s at registration to the
+// nodes selected by NodeFilters (k3d node filter syntax).
+type NodeLabel struct {
+	Key         string   `json:"key"`
+	Value       string   `json:"value"`
+	NodeFilters []string `json:"node_filters,omitempty"`
+}
+
+// NodeTaint is a Kubernetes node taint applied by k3s at registration to the
+// nodes selected by NodeFilters.
+type NodeTaint struct {
+	Key         string   `json:"key"`
+	Value       string   `json:"value,omitempty"`
+	Effect      string   `json:"effect"`
+	NodeFilters []string `json:"node_filters,omitempty"`
+}
+
+// String renders the taint the way k3s --node-taint expects it.
+func (t NodeTaint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
+
+// ParseNodeLabel parses "key=value[@filter[;filter...]]", e.g.
+// "workload=db@agent:0". Without a filter the label applies to all nodes.
+func ParseNodeLabel(spec string) (NodeLabel, error) {
+	body, filters, err := splitNodeFilters(spec)
+	if err != nil {
+		return NodeLabel{}, err
+	}
+	key, value, ok := strings.Cut(body, "=")
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		return NodeLabel{}, fmt.Errorf("invalid node label %q: expected key=value[@nodefilter]", spec)
+	}
+	return NodeLabel{Key: key, Value: value, NodeFilters: filters}, nil
+}
+
+// ParseNodeTaint parses "key[=value]:Effect[@filter[;filter...]]", e.g.
+// "dedicated=db:NoSchedule@agent:0".
+func ParseNodeTaint(spec string) (NodeTaint, error) {
+	body, filters, err := splitNodeFilters(spec)
+	if err != nil {
+		return NodeTaint{}, err
+	}
+	idx := strings.LastIndex(body, ":")
+	if idx <= 0 {
+		return NodeTaint{}, fmt.Errorf("invalid node taint %q: expected key[=value]:Effect[@nodefilter]", spec)
+	}
+	effect := body[idx+1:]
+	switch effect {
+	case "NoSchedule", "PreferNoSchedule", "NoExecute":
+	default:
+		return NodeTaint{}, fmt.Errorf("invalid node taint %q: effect must be NoSchedule, PreferNoSchedule or NoExecute", spec)
+	}
+	key, value, _ := strings.Cut(body[:idx], "=")
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return NodeTaint{}, fmt.Errorf("invalid node taint %q: key cannot be empty", spec)
+	}
+	return NodeTaint{Key: key, Value: value, Effect: effect, NodeFilters: filters}, nil
+}
+
+// ParseNodeLabels parses every --node-label value, keeping order.
+func ParseNodeLabels(specs []string) ([]NodeLabel, error) {
+	labels := make([]NodeLabel, 0, len(specs))
+	for _, spec := range specs {
+		label, err := ParseNodeLabel(spec)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// ParseNodeTaints parses every --node-taint value, keeping order.
+func ParseNodeTaints(specs []string) ([]NodeTaint, error) {
+	taints := make([]NodeTaint, 0, len(specs))
+	for _, spec := range specs {
+		taint, err := ParseNodeTaint(spec)
+		if err != nil {
+			return nil, err
+		}
+		taints = append(taints, taint)
+	}
+	return taints, nil
+}
+
+// splitNodeFilters separates the "@filter;filter" suffix of spec and checks
+// each filter, defaulting to all nodes.
+func splitNodeFilters(spec string) (string, []string, error) {
+	body, suffix, hasFilter := strings.Cut(strings.TrimSpace(spec), "@")
+	if !hasFilter {
+		return body, []string{defaultNodeFilter}, nil
+	}
+	var filters []string
+	for _, f := range strings.Split(suffix, ";") {
+		f = strings.TrimSpace(f)
+		if !nodeFilterPattern.MatchString(f) {
+			return "", nil, fmt.Errorf("invalid node filter %q in %q: use all, server:N, agent:N or agent:*", f, spec)
+		}
+		filters = append(filters, f)
+	}
+	return body, filters, nil
+}
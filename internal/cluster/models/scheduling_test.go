@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeLabel(t *testing.T) {
+	label, err := ParseNodeLabel("workload=db@agent:0;agent:1")
+	require.NoError(t, err)
+	assert.Equal(t, NodeLabel{Key: "workload", Value: "db", NodeFilters: []string{"agent:0", "agent:1"}}, label)
+
+	label, err = ParseNodeLabel("tier=edge")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all"}, label.NodeFilters, "no filter means every node")
+}
+
+func TestParseNodeTaint(t *testing.T) {
+	taint, err := ParseNodeTaint("dedicated=db:NoSchedule@agent:0")
+	require.NoError(t, err)
+	assert.Equal(t, NodeTaint{Key: "dedicated", Value: "db", Effect: "NoSchedule", NodeFilters: []string{"agent:0"}}, taint)
+	assert.Equal(t, "dedicated=db:NoSchedule", taint.String())
+
+	taint, err = ParseNodeTaint("gpu:PreferNoSchedule")
+	require.NoError(t, err)
+	assert.Equal(t, "gpu:PreferNoSchedule", taint.String())
+}
+
+func TestParseNodeLabelAndTaint_Invalid(t *testing.T) {
+	for _, spec := range []string{"novalue", "=db", "workload=db@worker:0", "workload=db@"} {
+		_, err := ParseNodeLabel(spec)
+		assert.Errorf(t, err, "label %q should be rejected", spec)
+	}
+	for _, spec := range []string{"dedicated=db", "dedicated=db:Sometimes", ":NoSchedule", "a=b:NoSchedule@agent:x"} {
+		_, err := ParseNodeTaint(spec)
+		assert.Errorf(t, err, "taint %q should be rejected", spec)
+	}
+}
+
+func TestValidateGPURequest(t *testing.T) {
+	for _, ok := range []string{"", "all", "1", "2"} {
+		assert.NoErrorf(t, ValidateGPURequest(ok), "%q should be accepted", ok)
+	}
+	for _, bad := range []string{"0", "-1", "some", "nvidia"} {
+		assert.Errorf(t, ValidateGPURequest(bad), "%q should be rejected", bad)
+	}
+}
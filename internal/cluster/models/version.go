@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// kubeVersionFloor matches the lower bound of a chart's kubeVersion
+// constraint: 1.30 in ">=1.30.0-0".
+var kubeVersionFloor = regexp.MustCompile(`>=\s*v?(\d+)\.(\d+)`)
+
+// k3sImageTag matches the Kubernetes version at the start of a rancher/k3s
+// tag: v1.31.5 in v1.31.5-k3s1.
+var k3sImageTag = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+
+// KubernetesVersionFromImage returns the Kubernetes version a k3s node image
+// runs, e.g. v1.31.5 for rancher/k3s:v1.31.5-k3s1 (pinned to a digest or not).
+// It is "" when the tag does not tell, as with latest.
+func KubernetesVersionFromImage(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	m := k3sImageTag.FindStringSubmatch(image[i+1:])
+	if m == nil {
+		return ""
+	}
+	return fmt.Sprintf("v%s.%s.%s", m[1], m[2], m[3])
+}
+
+// MinKubernetesVersion returns the oldest Kubernetes a chart's kubeVersion
+// constraint admits, as major.minor: v1.30 for ">=1.30.0-0". It is "" when
+// the constraint sets no lower bound.
+func MinKubernetesVersion(kubeVersion string) string {
+	m := kubeVersionFloor.FindStringSubmatch(kubeVersion)
+	if m == nil {
+		return ""
+	}
+	return fmt.Sprintf("v%s.%s", m[1], m[2])
+}
+
+// KubernetesVersionWarning says why version is older than min, the oldest
+// Kubernetes the chart stack supports; "" when it is supported or either is
+// unknown.
+func KubernetesVersionWarning(version, min string) string {
+	if version == "" || min == "" || !versionBelow(version, min) {
+		return ""
+	}
+	return fmt.Sprintf("Kubernetes %s is older than %s, the oldest the OpenFrame charts support; "+
+		"recreate the cluster with a newer --version", version, min)
+}
+
+// CheckKubernetesVersion rejects a cluster create asking for a Kubernetes
+// version older than min. latest and versions it cannot parse pass.
+func CheckKubernetesVersion(version, min string) error {
+	if version == "" || min == "" || !versionBelow(version, min) {
+		return nil
+	}
+	return fmt.Errorf("--version %s is older than Kubernetes %s, the oldest the OpenFrame charts support", version, min)
+}
+
+// versionBelow reports whether Kubernetes version v is older than min,
+// comparing major and minor; an unparsable v is not.
+func versionBelow(v, min string) bool {
+	vMajor, vMinor, ok := majorMinor(v)
+	if !ok {
+		return false
+	}
+	mMajor, mMinor, _ := majorMinor(min)
+	if vMajor != mMajor {
+		return vMajor < mMajor
+	}
+	return vMinor < mMinor
+}
+
+func majorMinor(v string) (major, minor int, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(parts[1])
+	return major, minor, err1 == nil && err2 == nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKubernetesVersionFromImage(t *testing.T) {
+	for image, want := range map[string]string{
+		"rancher/k3s:v1.31.5-k3s1":                     "v1.31.5",
+		"rancher/k3s:v1.29.10-k3s2@sha256:abcdef":      "v1.29.10",
+		"registry.local:5000/rancher/k3s:v1.30.1-k3s1": "v1.30.1",
+		"rancher/k3s:latest":                           "",
+		"rancher/k3s":                                  "",
+		"registry.local:5000/rancher/k3s":              "",
+		"":                                             "",
+	} {
+		assert.Equal(t, want, KubernetesVersionFromImage(image), image)
+	}
+}
+
+func TestMinKubernetesVersion(t *testing.T) {
+	for constraint, want := range map[string]string{
+		">=1.30.0-0":         "v1.30",
+		">= 1.25.0-0, <1.34": "v1.25",
+		">=v1.28":            "v1.28",
+		"<1.34.0":            "",
+		"":                   "",
+	} {
+		assert.Equal(t, want, MinKubernetesVersion(constraint), constraint)
+	}
+}
+
+func TestKubernetesVersionWarning(t *testing.T) {
+	const min = "v1.30"
+	assert.Empty(t, KubernetesVersionWarning("", min), "an unknown version is not warned about")
+	assert.Empty(t, KubernetesVersionWarning("v1.29.10", ""), "nor is any version without a minimum")
+	assert.Empty(t, KubernetesVersionWarning("v1.30.0", min))
+	assert.Empty(t, KubernetesVersionWarning("v1.33.2", min))
+	assert.Empty(t, KubernetesVersionWarning("v2.0.0", min))
+	assert.Empty(t, KubernetesVersionWarning("not-a-version", min))
+
+	warning := KubernetesVersionWarning("v1.29.10", min)
+	assert.True(t, strings.Contains(warning, "v1.29.10") && strings.Contains(warning, min), warning)
+}
+
+func TestCheckKubernetesVersion(t *testing.T) {
+	const min = "v1.30"
+	for _, version := range []string{"", "latest", "1.30", "v1.31.5-k3s1", "v1.33"} {
+		assert.NoError(t, CheckKubernetesVersion(version, min), version)
+	}
+	for _, version := range []string{"1.28", "v1.29.10-k3s1"} {
+		assert.ErrorContains(t, CheckKubernetesVersion(version, min), "older than Kubernetes v1.30", version)
+	}
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+)
+
+// importPrePulled waits for the host pulls started alongside cluster creation,
+// showing their progress, and imports what was pulled into the new cluster.
+// Nothing here fails the create: an image left out is pulled by the cluster.
+func (s *ClusterService) importPrePulled(ctx context.Context, name string, pull *prepull.Pull) {
+	var sp *spinner.Spinner
+	if !s.suppressUI {
+		sp = spinner.New()
+		sp.Start("Pre-pulling stack images...")
+	}
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+wait:
+	for {
+		select {
+		case <-pull.Done():
+			break wait
+		case <-ctx.Done():
+			pull.Cancel()
+			break wait
+		case <-ticker.C:
+			if done, total := pull.Progress(); sp != nil && total > 0 {
+				sp.SetDetail(fmt.Sprintf("%d/%d", done, total))
+			}
+		}
+	}
+	result := pull.Wait()
+
+	if sp != nil {
+		sp.UpdateText(fmt.Sprintf("Importing %d images into cluster '%s'...", len(result.Pulled), name))
+		sp.SetDetail("")
+	}
+	importErr := prepull.Import(ctx, s.executor, name, result.Pulled)
+	if importErr == nil {
+		if err := clusterstate.SetPrepulled(name, result.Pulled); err != nil {
+			pterm.Debug.Printf("Could not record the pre-pulled images: %v\n", err)
+		}
+	}
+
+	msg := fmt.Sprintf("Pre-pulled %d images", len(result.Pulled))
+	switch {
+	case importErr != nil:
+		msg = fmt.Sprintf("Could not import pre-pulled images; the cluster will pull them itself: %v", importErr)
+	case len(result.Failed) > 0:
+		msg = fmt.Sprintf("%s (%d failed; the cluster will pull them itself)", msg, len(result.Failed))
+	}
+	switch {
+	case sp == nil && (importErr != nil || len(result.Failed) > 0):
+		pterm.Warning.Println(msg)
+	case sp == nil:
+		pterm.Info.Println(msg)
+	case importErr != nil || len(result.Failed) > 0:
+		sp.Warning(msg)
+	default:
+		sp.Success(msg)
+	}
+	if result.ManifestErr != nil {
+		pterm.Debug.Printf("Pre-pull manifest incomplete: %v\n", result.ManifestErr)
+	}
+	for image, err := range result.Failed {
+		pterm.Debug.Printf("Pre-pull of %s failed: %v\n", image, err)
+	}
+}
@@ -0,0 +1,254 @@
+// Package prepull fetches the images a fresh install needs before the cluster
+// asks for them. The images are pulled on the Docker host in parallel while
+// k3d creates the nodes, then imported into the nodes with `k3d image import`.
+// ArgoCD then starts from local images instead of pulling them one by one
+// through the nodes' DNS, which is slow on a first sync and the usual reason a
+// pull fails.
+//
+// The manifest is derived from the charts rather than kept by hand: it is
+// every container image the given charts render with their values (the pinned
+// ArgoCD chart, injected by the command layer since internal/cluster must not
+// import internal/chart), plus the extra images listed in
+// ~/.openframe/config.json:
+//
+//	{ "prePull": { "images": ["ghcr.io/flamingo-stack/openframe-api:1.4.0"] } }
+//
+// Pre-pulling is best effort. An image that fails to pull or import is left
+// for the cluster to pull itself, as it would without this step.
+package prepull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/manifest"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// concurrency is how many images are pulled at once. Docker already pulls the
+// layers of one image in parallel, so a handful of images saturates a typical
+// link without starving k3d's own pulls.
+const concurrency = 4
+
+// Chart is a chart whose images are pre-pulled, rendered as it is installed.
+type Chart struct {
+	Release   string
+	Name      string // chart name in Repo
+	Repo      string // chart repository URL
+	Version   string
+	Namespace string
+	Values    string // values YAML, piped to helm
+}
+
+// Images returns the pre-pull manifest: the images of every chart rendered
+// with its values, then the configured extras, deduplicated and sorted.
+// Rendering needs helm and the chart repository; a chart that fails to render
+// contributes nothing and its error is returned alongside the rest.
+func Images(ctx context.Context, exec executor.CommandExecutor, charts []Chart) ([]string, error) {
+	images, errs := configuredImages()
+	for _, chart := range charts {
+		args := []string{
+			"template", chart.Release, chart.Name,
+			"--repo", chart.Repo,
+			"--version", chart.Version,
+			"--namespace", chart.Namespace,
+			"-f", "-",
+		}
+		if ca := sharedconfig.CABundle(); ca != "" {
+			args = append(args, "--ca-file", ca)
+		}
+		rendered, err := exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "helm",
+			Args:    args,
+			Stdin:   []byte(chart.Values),
+			Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+		})
+		var found []string
+		if err == nil {
+			found, err = ImagesFromManifest([]byte(rendered.Stdout))
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("rendering chart %s %s: %w", chart.Name, chart.Version, err))
+			continue
+		}
+		images = append(images, found...)
+	}
+	return dedupe(images), errs
+}
+
+// ImagesFromManifest returns the container images referenced by the objects in
+// a rendered multi-document manifest: the containers and init containers of
+// pods and of every pod template (Deployments, StatefulSets, Jobs, CronJobs).
+func ImagesFromManifest(data []byte) ([]string, error) {
+	objs, err := manifest.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	for _, obj := range objs {
+		images = collectImages(obj.Object, images)
+	}
+	return dedupe(images), nil
+}
+
+// collectImages walks v and appends the image of every entry of a containers
+// or initContainers list, wherever the pod spec is nested.
+func collectImages(v any, images []string) []string {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if key == "containers" || key == "initContainers" {
+				if list, ok := child.([]any); ok {
+					for _, c := range list {
+						if c, ok := c.(map[string]any); ok {
+							if image, ok := c["image"].(string); ok && image != "" {
+								images = append(images, image)
+							}
+						}
+					}
+					continue
+				}
+			}
+			images = collectImages(child, images)
+		}
+	case []any:
+		for _, child := range v {
+			images = collectImages(child, images)
+		}
+	}
+	return images
+}
+
+// configuredImages reads prePull.images from the user config. A missing file
+// or key means no extras.
+func configuredImages() ([]string, error) {
+	var cfg struct {
+		PrePull struct {
+			Images []string `json:"images"`
+		} `json:"prePull"`
+	}
+	err := sharedconfig.LoadUserConfig(&cfg)
+	return cfg.PrePull.Images, err
+}
+
+func dedupe(images []string) []string {
+	seen := make(map[string]bool, len(images))
+	out := images[:0:0]
+	for _, image := range images {
+		if image != "" && !seen[image] {
+			seen[image] = true
+			out = append(out, image)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Result is the outcome of the host pulls.
+type Result struct {
+	// Pulled are the images now present on the Docker host.
+	Pulled []string
+	// Failed maps each image that could not be pulled to why.
+	Failed map[string]error
+	// ManifestErr is why the manifest is incomplete, if it is.
+	ManifestErr error
+}
+
+// Pull is a pre-pull running in the background; see Start.
+type Pull struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	total     int
+	completed int
+	result    Result
+}
+
+// Start builds the manifest and pulls it on the Docker host in the background,
+// concurrency images at a time. Cancel ctx or call Cancel to stop early.
+func Start(ctx context.Context, exec executor.CommandExecutor, charts []Chart) *Pull {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &Pull{cancel: cancel, done: make(chan struct{}), result: Result{Failed: map[string]error{}}}
+	go func() {
+		defer close(p.done)
+		images, err := Images(ctx, exec, charts)
+		p.mu.Lock()
+		p.total = len(images)
+		p.result.ManifestErr = err
+		p.mu.Unlock()
+
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for _, image := range images {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				_, err := exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+					Command: "docker",
+					Args:    []string{"pull", "--quiet", image},
+					Timeout: sharedconfig.Timeout(sharedconfig.LongRunning),
+				})
+				p.mu.Lock()
+				defer p.mu.Unlock()
+				p.completed++
+				if err != nil {
+					p.result.Failed[image] = err
+					return
+				}
+				p.result.Pulled = append(p.result.Pulled, image)
+			}()
+		}
+		wg.Wait()
+		sort.Strings(p.result.Pulled)
+	}()
+	return p
+}
+
+// Progress reports how many images have finished pulling, successfully or not,
+// out of how many. total is 0 until the manifest is known.
+func (p *Pull) Progress() (completed, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.completed, p.total
+}
+
+// Done is closed when every pull has finished.
+func (p *Pull) Done() <-chan struct{} { return p.done }
+
+// Wait blocks until every pull has finished and returns the outcome.
+func (p *Pull) Wait() Result {
+	<-p.done
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.result
+}
+
+// Cancel stops the pulls still running and waits for them to exit.
+func (p *Pull) Cancel() {
+	p.cancel()
+	<-p.done
+}
+
+// Import copies images from the Docker host into every node of cluster, so
+// containerd finds them locally.
+func Import(ctx context.Context, exec executor.CommandExecutor, cluster string, images []string) error {
+	if len(images) == 0 {
+		return nil
+	}
+	args := append([]string{"image", "import", "--cluster", cluster}, images...)
+	if _, err := exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "k3d",
+		Args:    args,
+		Timeout: sharedconfig.Timeout(sharedconfig.LongRunning),
+	}); err != nil {
+		return fmt.Errorf("importing images into cluster %s: %w", cluster, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package prepull
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rendered = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: argocd-server
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: copyutil
+          image: quay.io/argoproj/argocd:v3.1.0
+      containers:
+        - name: server
+          image: quay.io/argoproj/argocd:v3.1.0
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: argocd-redis
+spec:
+  template:
+    spec:
+      containers:
+        - name: redis
+          image: public.ecr.aws/docker/library/redis:7.2.8-alpine
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: cleanup
+              image: alpine:3.20
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: argocd-cm
+data:
+  image: not-a-container
+`
+
+var argoCD = []Chart{{Release: "argo-cd", Name: "argo-cd", Repo: "https://argoproj.github.io/argo-helm", Version: "10.1.4", Namespace: "argocd", Values: "fullnameOverride: argocd\n"}}
+
+// isolate points the user config at a temp file holding content ("" = none).
+func isolate(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	orig := sharedconfig.UserConfigFile
+	sharedconfig.UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { sharedconfig.UserConfigFile = orig })
+}
+
+func TestImagesFromManifest(t *testing.T) {
+	images, err := ImagesFromManifest([]byte(rendered))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alpine:3.20",
+		"public.ecr.aws/docker/library/redis:7.2.8-alpine",
+		"quay.io/argoproj/argocd:v3.1.0",
+	}, images)
+}
+
+func TestImages_RendersChartAndAddsExtras(t *testing.T) {
+	isolate(t, `{"prePull": {"images": ["ghcr.io/flamingo-stack/openframe-api:1.4.0", "alpine:3.20"]}}`)
+	exec := executor.NewMockCommandExecutor()
+	exec.SetResponse("helm template", &executor.CommandResult{Stdout: rendered})
+
+	images, err := Images(context.Background(), exec, argoCD)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alpine:3.20",
+		"ghcr.io/flamingo-stack/openframe-api:1.4.0",
+		"public.ecr.aws/docker/library/redis:7.2.8-alpine",
+		"quay.io/argoproj/argocd:v3.1.0",
+	}, images)
+
+	cmd := exec.Commands()[0]
+	assert.Equal(t, "helm template argo-cd argo-cd --repo https://argoproj.github.io/argo-helm --version 10.1.4 --namespace argocd -f -", cmd.String())
+	assert.Equal(t, "fullnameOverride: argocd\n", string(cmd.Stdin), "values are piped, not written to disk")
+}
+
+func TestImages_RenderFailureKeepsExtras(t *testing.T) {
+	isolate(t, `{"prePull": {"images": ["alpine:3.20"]}}`)
+	exec := executor.NewMockCommandExecutor()
+	exec.SetResponse("helm template", &executor.CommandResult{ExitCode: 1})
+
+	images, err := Images(context.Background(), exec, argoCD)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"alpine:3.20"}, images)
+}
+
+func TestStart_PullsInParallelAndRecordsFailures(t *testing.T) {
+	isolate(t, "")
+	exec := executor.NewMockCommandExecutor()
+	exec.SetResponse("helm template", &executor.CommandResult{Stdout: rendered})
+	exec.SetResponse("docker pull --quiet alpine", &executor.CommandResult{ExitCode: 1})
+
+	pull := Start(context.Background(), exec, argoCD)
+	result := pull.Wait()
+	require.NoError(t, result.ManifestErr)
+	assert.Equal(t, []string{
+		"public.ecr.aws/docker/library/redis:7.2.8-alpine",
+		"quay.io/argoproj/argocd:v3.1.0",
+	}, result.Pulled)
+	assert.Contains(t, result.Failed, "alpine:3.20")
+
+	done, total := pull.Progress()
+	assert.Equal(t, 3, done)
+	assert.Equal(t, 3, total)
+}
+
+func TestImport(t *testing.T) {
+	exec := executor.NewMockCommandExecutor()
+	require.NoError(t, Import(context.Background(), exec, "dev", []string{"alpine:3.20", "redis:7"}))
+	assert.Equal(t, []string{"k3d image import --cluster dev alpine:3.20 redis:7"}, exec.GetExecutedCommands())
+
+	exec.Reset()
+	require.NoError(t, Import(context.Background(), exec, "dev", nil))
+	assert.Empty(t, exec.GetExecutedCommands(), "nothing pulled, nothing to import")
+}
@@ -1,9 +1,13 @@
 package prerequisites
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/docker"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/helm"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/k3d"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/readonly"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 )
 
@@ -29,7 +33,7 @@ func NewPrerequisiteChecker() *PrerequisiteChecker {
 					if !docker.NewDockerInstaller().IsInstalled() {
 						return docker.NewDockerInstaller().GetInstallHelp()
 					}
-					return "Docker is installed but not running. Please start Docker Desktop or the Docker daemon."
+					return "Docker is " + docker.UnavailableDetail() + "."
 				},
 			},
 			{
@@ -63,6 +67,13 @@ func (pc *PrerequisiteChecker) CheckAll() (bool, []string) {
 }
 
 func CheckPrerequisites() error {
+	// Read-only mode installs and starts nothing; it only says what is missing.
+	if readonly.Enabled() {
+		if ok, missing := NewPrerequisiteChecker().CheckAll(); !ok {
+			return fmt.Errorf("%w: missing prerequisites are not installed: %s", readonly.ErrReadOnly, strings.Join(missing, ", "))
+		}
+		return nil
+	}
 	// A CI environment or a non-terminal stdin must not hit an interactive prompt.
 	return NewInstaller().CheckAndInstallNonInteractive(ui.IsNonInteractive())
 }
@@ -0,0 +1,71 @@
+package prerequisites
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/clockskew"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+)
+
+// checkClockSkew runs FixClock before a cluster is created. The cluster's
+// nodes share the WSL clock, so a clock left behind by a laptop's sleep makes
+// every certificate look not yet valid.
+func checkClockSkew(ctx context.Context, nonInteractive bool) error {
+	return FixClock(ctx, executor.NewRealCommandExecutor(false, false), nonInteractive)
+}
+
+// ReportClock compares the WSL clock with NTP (or the Windows clock) and
+// warns when it has drifted. It reports whether it has; outside WSL there is
+// nothing to check.
+func ReportClock(ctx context.Context, ex executor.CommandExecutor) bool {
+	skew, ok, err := clockskew.Check(ctx, ex)
+	switch {
+	case err != nil:
+		fmt.Println()
+		pterm.Warning.Printfln("Could not check the WSL clock: %v", err)
+		return false
+	case !ok:
+		return false
+	case !skew.Drifted():
+		fmt.Println()
+		pterm.Success.Printfln("WSL clock in sync (%s from %s)", skew.Offset.Abs().Round(time.Millisecond), skew.Reference)
+		return false
+	}
+	fmt.Println()
+	pterm.Warning.Printfln("%s; TLS handshakes and certificate checks in the cluster fail until it is reset:", skew)
+	pterm.DefaultBasicText.Printfln("  %s", clockskew.FixHint())
+	return true
+}
+
+// FixClock reports the WSL clock and, when it has drifted, resets it with
+// `hwclock -s`, after asking on an interactive run. Nothing of the user's is
+// changed — the clock is only put back in step with the host — so
+// non-interactive runs correct it too. It fails only on a broken prompt: a
+// clock that cannot be read or reset is a warning with the command to run by
+// hand.
+func FixClock(ctx context.Context, ex executor.CommandExecutor, nonInteractive bool) error {
+	if !ReportClock(ctx, ex) {
+		return nil
+	}
+	if !nonInteractive {
+		confirmed, err := ui.ConfirmActionInteractive("Reset the WSL clock from the host now?", true)
+		if err := errors.WrapConfirmationError(err, "failed to get clock confirmation"); err != nil {
+			return err
+		}
+		if !confirmed {
+			pterm.Info.Println("Leaving the WSL clock unchanged.")
+			return nil
+		}
+	}
+	if err := clockskew.Fix(ctx, ex); err != nil {
+		pterm.Warning.Printfln("Could not reset the WSL clock: %v", err)
+		return nil
+	}
+	pterm.Success.Println("WSL clock reset from the host")
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/dockerhost"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
 	"github.com/pterm/pterm"
 )
 
@@ -22,25 +24,51 @@ func commandExists(cmd string) bool {
 // isDockerInstalled reports whether the docker CLI is present.
 //
 // No Windows branch: on Windows the root command forwards the whole CLI into
-// WSL before any command runs, so this code only executes as a Linux process
-// (see wsllauncher). The old branch probed WSL from the outside and hardcoded
-// the "Ubuntu" distro, contradicting the distro-agnostic launcher.
+// WSL before any command runs, so this code executes as a Linux process (see
+// wsllauncher) — or in Docker Desktop native mode, where docker.exe is on the
+// Windows PATH and the plain lookup is exactly right.
 func isDockerInstalled() bool {
 	return commandExists("docker")
 }
 
-// IsDockerRunning reports whether the docker daemon answers. See
+// IsDockerRunning reports whether the docker daemon answers the user; see
+// CheckDaemon to tell a stopped daemon from one that refuses the user, and
 // isDockerInstalled for why there is no Windows branch.
 func IsDockerRunning() bool {
-	if !commandExists("docker") {
-		return false
-	}
-	// Check if Docker daemon is accessible by running docker ps with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "docker", "ps")
-	err := cmd.Run()
-	return err == nil
+	return CheckDaemon() == DaemonRunning
+}
+
+// isRootlessDocker reports whether the user runs rootless Docker: the resolved
+// endpoint is a per-user socket, or the rootless daemon is installed.
+func isRootlessDocker() bool {
+	if ep, err := dockerhost.Resolve(); err == nil && ep.Rootless() {
+		return true
+	}
+	return commandExists("dockerd-rootless.sh")
+}
+
+// NotRunningDetail explains a docker CLI whose daemon does not answer, naming
+// the endpoint it tried so a stale docker context or a stopped rootless daemon
+// is visible instead of a generic "not running".
+func NotRunningDetail() string {
+	ep, err := dockerhost.Resolve()
+	switch {
+	case err != nil:
+		return "installed but its daemon is unreachable: " + err.Error()
+	case ep.Rootless():
+		return fmt.Sprintf("installed but the rootless daemon at %s is not running — start it with: systemctl --user start docker", ep)
+	}
+	return fmt.Sprintf("installed but not running at %s — start Docker Desktop or the Docker daemon", ep)
+}
+
+// UnavailableDetail explains an installed docker CLI that cannot use its
+// daemon: a stopped daemon (NotRunningDetail) or one refusing the user
+// (PermissionDeniedDetail).
+func UnavailableDetail() string {
+	if CheckDaemon() == DaemonPermissionDenied {
+		return PermissionDeniedDetail()
+	}
+	return NotRunningDetail()
 }
 
 func dockerInstallHelp() string {
@@ -116,19 +144,14 @@ func (d *DockerInstaller) installLinux() error {
 // installAlpine installs Docker on Alpine Linux following
 // https://wiki.alpinelinux.org/wiki/Docker — apk add docker, then enable and
 // start the OpenRC service. Alpine's default user is often root (and may not
-// ship sudo, e.g. in WSL/containers), so sudo is only prefixed when needed.
+// ship sudo, e.g. in WSL/containers); runAsRoot only adds sudo when needed.
 // Enabling/starting the service is best-effort: under WSL or containers OpenRC
 // may not be the init system, but `apk add docker` already provides the engine,
 // which can be started directly (see StartDocker).
 func (d *DockerInstaller) installAlpine() error {
 	pterm.Info.Println("Installing Docker on Alpine Linux...")
 
-	run := func(args ...string) error {
-		if os.Geteuid() != 0 && commandExists("sudo") {
-			args = append([]string{"sudo"}, args...)
-		}
-		return d.runCommand(args[0], args[1:]...)
-	}
+	run := d.runAsRoot
 
 	if err := run("apk", "add", "--no-cache", "docker"); err != nil {
 		return fmt.Errorf("failed to install Docker with apk: %w", err)
@@ -156,38 +179,38 @@ func (d *DockerInstaller) installUbuntu() error {
 	pterm.Info.Println("Installing Docker on Ubuntu/Debian...")
 
 	commands := [][]string{
-		{"sudo", "apt", "update"},
-		{"sudo", "apt", "install", "-y", "apt-transport-https", "ca-certificates", "curl", "gnupg", "lsb-release"},
+		{"apt", "update"},
+		{"apt", "install", "-y", "apt-transport-https", "ca-certificates", "curl", "gnupg", "lsb-release"},
 	}
 
 	for _, cmdArgs := range commands {
-		if err := d.runCommand(cmdArgs[0], cmdArgs[1:]...); err != nil {
+		if err := d.runAsRoot(cmdArgs...); err != nil {
 			return fmt.Errorf("failed to run %s: %w", cmdArgs[0], err)
 		}
 	}
 
 	// Add Docker's official GPG key
-	gpgCmd := "curl -fsSL https://download.docker.com/linux/ubuntu/gpg | sudo gpg --dearmor -o /usr/share/keyrings/docker-archive-keyring.gpg"
-	if err := d.runShellCommand(gpgCmd); err != nil {
+	gpgCmd := "curl -fsSL https://download.docker.com/linux/ubuntu/gpg | gpg --dearmor -o /usr/share/keyrings/docker-archive-keyring.gpg"
+	if err := d.runShellAsRoot(gpgCmd); err != nil {
 		return fmt.Errorf("failed to add Docker GPG key: %w", err)
 	}
 
 	// Add Docker repository
-	repoCmd := `echo "deb [arch=amd64 signed-by=/usr/share/keyrings/docker-archive-keyring.gpg] https://download.docker.com/linux/ubuntu $(lsb_release -cs) stable" | sudo tee /etc/apt/sources.list.d/docker.list > /dev/null`
-	if err := d.runShellCommand(repoCmd); err != nil {
+	repoCmd := `echo "deb [arch=amd64 signed-by=/usr/share/keyrings/docker-archive-keyring.gpg] https://download.docker.com/linux/ubuntu $(lsb_release -cs) stable" | tee /etc/apt/sources.list.d/docker.list > /dev/null`
+	if err := d.runShellAsRoot(repoCmd); err != nil {
 		return fmt.Errorf("failed to add Docker repository: %w", err)
 	}
 
 	// Install Docker
 	installCommands := [][]string{
-		{"sudo", "apt", "update"},
-		{"sudo", "apt", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
-		{"sudo", "systemctl", "enable", "docker"},
-		{"sudo", "systemctl", "start", "docker"},
+		{"apt", "update"},
+		{"apt", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
+		{"systemctl", "enable", "docker"},
+		{"systemctl", "start", "docker"},
 	}
 
 	for _, cmdArgs := range installCommands {
-		if err := d.runCommand(cmdArgs[0], cmdArgs[1:]...); err != nil {
+		if err := d.runAsRoot(cmdArgs...); err != nil {
 			return fmt.Errorf("failed to run %s: %w", cmdArgs[0], err)
 		}
 	}
@@ -195,7 +218,7 @@ func (d *DockerInstaller) installUbuntu() error {
 	// Add user to docker group
 	user := os.Getenv("USER")
 	if user != "" {
-		if err := d.runCommand("sudo", "usermod", "-aG", "docker", user); err != nil {
+		if err := d.runAsRoot("usermod", "-aG", "docker", user); err != nil {
 			pterm.Warning.Printfln("Could not add user to docker group: %v", err)
 		} else {
 			pterm.Info.Println("You may need to log out and back in for Docker group permissions to take effect")
@@ -209,15 +232,15 @@ func (d *DockerInstaller) installRedHat() error {
 	pterm.Info.Println("Installing Docker on CentOS/RHEL...")
 
 	commands := [][]string{
-		{"sudo", "yum", "install", "-y", "yum-utils"},
-		{"sudo", "yum-config-manager", "--add-repo", "https://download.docker.com/linux/centos/docker-ce.repo"},
-		{"sudo", "yum", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
-		{"sudo", "systemctl", "enable", "docker"},
-		{"sudo", "systemctl", "start", "docker"},
+		{"yum", "install", "-y", "yum-utils"},
+		{"yum-config-manager", "--add-repo", "https://download.docker.com/linux/centos/docker-ce.repo"},
+		{"yum", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
+		{"systemctl", "enable", "docker"},
+		{"systemctl", "start", "docker"},
 	}
 
 	for _, cmdArgs := range commands {
-		if err := d.runCommand(cmdArgs[0], cmdArgs[1:]...); err != nil {
+		if err := d.runAsRoot(cmdArgs...); err != nil {
 			return fmt.Errorf("failed to run %s: %w", cmdArgs[0], err)
 		}
 	}
@@ -225,7 +248,7 @@ func (d *DockerInstaller) installRedHat() error {
 	// Add user to docker group
 	user := os.Getenv("USER")
 	if user != "" {
-		if err := d.runCommand("sudo", "usermod", "-aG", "docker", user); err != nil {
+		if err := d.runAsRoot("usermod", "-aG", "docker", user); err != nil {
 			pterm.Warning.Printfln("Could not add user to docker group: %v", err)
 		} else {
 			pterm.Info.Println("You may need to log out and back in for Docker group permissions to take effect")
@@ -239,15 +262,15 @@ func (d *DockerInstaller) installFedora() error {
 	pterm.Info.Println("Installing Docker on Fedora...")
 
 	commands := [][]string{
-		{"sudo", "dnf", "install", "-y", "dnf-plugins-core"},
-		{"sudo", "dnf", "config-manager", "--add-repo", "https://download.docker.com/linux/fedora/docker-ce.repo"},
-		{"sudo", "dnf", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
-		{"sudo", "systemctl", "enable", "docker"},
-		{"sudo", "systemctl", "start", "docker"},
+		{"dnf", "install", "-y", "dnf-plugins-core"},
+		{"dnf", "config-manager", "--add-repo", "https://download.docker.com/linux/fedora/docker-ce.repo"},
+		{"dnf", "install", "-y", "docker-ce", "docker-ce-cli", "containerd.io"},
+		{"systemctl", "enable", "docker"},
+		{"systemctl", "start", "docker"},
 	}
 
 	for _, cmdArgs := range commands {
-		if err := d.runCommand(cmdArgs[0], cmdArgs[1:]...); err != nil {
+		if err := d.runAsRoot(cmdArgs...); err != nil {
 			return fmt.Errorf("failed to run %s: %w", cmdArgs[0], err)
 		}
 	}
@@ -255,7 +278,7 @@ func (d *DockerInstaller) installFedora() error {
 	// Add user to docker group
 	user := os.Getenv("USER")
 	if user != "" {
-		if err := d.runCommand("sudo", "usermod", "-aG", "docker", user); err != nil {
+		if err := d.runAsRoot("usermod", "-aG", "docker", user); err != nil {
 			pterm.Warning.Printfln("Could not add user to docker group: %v", err)
 		} else {
 			pterm.Info.Println("You may need to log out and back in for Docker group permissions to take effect")
@@ -269,13 +292,13 @@ func (d *DockerInstaller) installArch() error {
 	pterm.Info.Println("Installing Docker on Arch Linux...")
 
 	commands := [][]string{
-		{"sudo", "pacman", "-S", "--noconfirm", "docker"},
-		{"sudo", "systemctl", "enable", "docker"},
-		{"sudo", "systemctl", "start", "docker"},
+		{"pacman", "-S", "--noconfirm", "docker"},
+		{"systemctl", "enable", "docker"},
+		{"systemctl", "start", "docker"},
 	}
 
 	for _, cmdArgs := range commands {
-		if err := d.runCommand(cmdArgs[0], cmdArgs[1:]...); err != nil {
+		if err := d.runAsRoot(cmdArgs...); err != nil {
 			return fmt.Errorf("failed to run %s: %w", cmdArgs[0], err)
 		}
 	}
@@ -283,7 +306,7 @@ func (d *DockerInstaller) installArch() error {
 	// Add user to docker group
 	user := os.Getenv("USER")
 	if user != "" {
-		if err := d.runCommand("sudo", "usermod", "-aG", "docker", user); err != nil {
+		if err := d.runAsRoot("usermod", "-aG", "docker", user); err != nil {
 			pterm.Warning.Printfln("Could not add user to docker group: %v", err)
 		} else {
 			pterm.Info.Println("You may need to log out and back in for Docker group permissions to take effect")
@@ -299,10 +322,21 @@ func (d *DockerInstaller) runCommand(name string, args ...string) error {
 	return cmd.Run()
 }
 
-func (d *DockerInstaller) runShellCommand(command string) error {
-	cmd := exec.Command("bash", "-c", command) // #nosec G204 -- shell string built from constant/program-derived values, not untrusted input
-	// Completely silence output during installation
-	return cmd.Run()
+// runAsRoot runs an installation step with root privileges (see package
+// privilege): directly as root, else through sudo, else a clear error naming
+// the command to run by hand.
+func (d *DockerInstaller) runAsRoot(args ...string) error {
+	argv, err := privilege.Default().Command(context.Background(), args[0], args[1:]...)
+	if err != nil {
+		return err
+	}
+	return d.runCommand(argv[0], argv[1:]...)
+}
+
+// runShellAsRoot runs a shell pipeline as root as a whole, so every stage of it
+// (e.g. `curl ... | gpg --dearmor -o /usr/share/...`) has the privileges.
+func (d *DockerInstaller) runShellAsRoot(command string) error {
+	return d.runAsRoot("bash", "-c", command)
 }
 
 // StartDocker attempts to start Docker based on the operating system
@@ -313,8 +347,11 @@ func StartDocker() error {
 	case "linux":
 		return startDockerLinux()
 	case "windows":
-		// Unreachable in the supported flow (the CLI runs inside WSL as linux);
-		// the previous WSL-from-Windows starter hardcoded the Ubuntu distro.
+		// Only Docker Desktop native mode runs here; in WSL mode the CLI runs
+		// inside WSL as linux.
+		if platform.NativeWindows() {
+			return startDockerDesktopWindows()
+		}
 		return fmt.Errorf("starting Docker from the native Windows launcher is not supported — run openframe inside WSL")
 	default:
 		return fmt.Errorf("starting Docker is not supported on %s", runtime.GOOS)
@@ -334,15 +371,40 @@ func startDockerMacOS() error {
 	return nil
 }
 
+// startDockerDesktopWindows launches Docker Desktop from its default install
+// location (Docker Desktop native mode only). ProgramFiles honors non-C:
+// system drives.
+func startDockerDesktopWindows() error {
+	programFiles := os.Getenv("ProgramFiles")
+	if programFiles == "" {
+		programFiles = `C:\Program Files`
+	}
+	exe := programFiles + `\Docker\Docker\Docker Desktop.exe`
+	if err := exec.Command(exe).Start(); err != nil { // #nosec G204 -- fixed install path
+		return fmt.Errorf("failed to start Docker Desktop: %w", err)
+	}
+	return nil
+}
+
 func startDockerLinux() error {
+	ctx := context.Background()
+	sudo := privilege.Default()
+
+	// Rootless Docker runs as a systemd *user* service and must never be
+	// started with sudo (that would start the rootful daemon instead).
+	if isRootlessDocker() {
+		if err := exec.Command("systemctl", "--user", "start", "docker").Run(); err != nil {
+			return fmt.Errorf("failed to start rootless Docker (systemctl --user start docker): %w", err)
+		}
+		return nil
+	}
+
 	// Try to start Docker daemon on Linux
 	// First check if systemctl exists (systemd)
 	if commandExists("systemctl") {
-		cmd := exec.Command("sudo", "systemctl", "start", "docker")
-		if err := cmd.Run(); err != nil {
+		if err := sudo.Run(ctx, "systemctl", "start", "docker"); err != nil {
 			// Try without sudo in case user has permissions
-			cmd = exec.Command("systemctl", "start", "docker")
-			if err := cmd.Run(); err != nil {
+			if exec.Command("systemctl", "start", "docker").Run() != nil {
 				return fmt.Errorf("failed to start Docker daemon with systemctl: %w", err)
 			}
 		}
@@ -354,7 +416,7 @@ func startDockerLinux() error {
 		if exec.Command("rc-service", "docker", "start").Run() == nil {
 			return nil
 		}
-		if err := exec.Command("sudo", "rc-service", "docker", "start").Run(); err != nil {
+		if err := sudo.Run(ctx, "rc-service", "docker", "start"); err != nil {
 			return fmt.Errorf("failed to start Docker daemon with rc-service: %w", err)
 		}
 		return nil
@@ -362,8 +424,7 @@ func startDockerLinux() error {
 
 	// Try service command (older systems)
 	if commandExists("service") {
-		cmd := exec.Command("sudo", "service", "docker", "start")
-		if err := cmd.Run(); err != nil {
+		if err := sudo.Run(ctx, "service", "docker", "start"); err != nil {
 			return fmt.Errorf("failed to start Docker daemon with service: %w", err)
 		}
 		return nil
@@ -0,0 +1,186 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// DaemonState is what the docker CLI says about its daemon.
+type DaemonState int
+
+const (
+	// DaemonRunning answers.
+	DaemonRunning DaemonState = iota
+	// DaemonNotRunning does not answer, or the docker CLI is missing.
+	DaemonNotRunning
+	// DaemonPermissionDenied answers, but the user may not open its socket:
+	// on Linux, a user outside the docker group.
+	DaemonPermissionDenied
+)
+
+// dockerGroup owns the daemon's socket on a rootful Linux install.
+const dockerGroup = "docker"
+
+// dockerPS runs `docker ps` and returns what it printed; a variable so tests
+// can fake the daemon.
+var dockerPS = func(ctx context.Context) (string, error) {
+	if !commandExists("docker") {
+		return "", exec.ErrNotFound
+	}
+	out, err := exec.CommandContext(ctx, "docker", "ps").CombinedOutput()
+	return string(out), err
+}
+
+// CheckDaemon tells whether the docker daemon answers, and when it does not,
+// whether that is because the user may not talk to it.
+func CheckDaemon() DaemonState {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := dockerPS(ctx)
+	if err == nil {
+		return DaemonRunning
+	}
+	return classifyDaemonError(out)
+}
+
+// classifyDaemonError reads a failed `docker ps`: the socket refusing the user
+// reads "permission denied while trying to connect to the Docker daemon
+// socket at unix:///var/run/docker.sock".
+func classifyDaemonError(output string) DaemonState {
+	msg := strings.ToLower(output)
+	if strings.Contains(msg, "permission denied") &&
+		(strings.Contains(msg, "docker.sock") || strings.Contains(msg, "docker daemon socket") || strings.Contains(msg, "dial unix")) {
+		return DaemonPermissionDenied
+	}
+	return DaemonNotRunning
+}
+
+// GroupMembership is where the user stands with the docker group.
+type GroupMembership struct {
+	// Listed: the group database lists the user, e.g. after `usermod -aG docker`.
+	Listed bool
+	// Active: this process runs with the group, which a login session only
+	// picks up when it starts.
+	Active bool
+}
+
+// Pending reports a membership granted after this session started: a new
+// login, `newgrp docker` or `sg docker` makes it count.
+func (m GroupMembership) Pending() bool {
+	return m.Listed && !m.Active
+}
+
+// dockerGroupMembership reads the user's docker group membership; a variable
+// so tests can fake it. Without a docker group the user is in neither.
+var dockerGroupMembership = func() GroupMembership {
+	g, err := user.LookupGroup(dockerGroup)
+	if err != nil {
+		return GroupMembership{}
+	}
+	gid, err := strconv.Atoi(g.Gid)
+	if err != nil {
+		return GroupMembership{}
+	}
+	var m GroupMembership
+	if os.Getegid() == gid {
+		m.Active = true
+	}
+	if groups, err := os.Getgroups(); err == nil {
+		for _, id := range groups {
+			if id == gid {
+				m.Active = true
+			}
+		}
+	}
+	if u, err := user.Current(); err == nil {
+		if ids, err := u.GroupIds(); err == nil {
+			for _, id := range ids {
+				if id == g.Gid {
+					m.Listed = true
+				}
+			}
+		}
+	}
+	m.Listed = m.Listed || m.Active
+	return m
+}
+
+// DockerGroupMembership reports where the user stands with the docker group.
+func DockerGroupMembership() GroupMembership {
+	return dockerGroupMembership()
+}
+
+// PermissionDeniedDetail explains a daemon that refuses the user and the
+// commands that fix it.
+func PermissionDeniedDetail() string {
+	if dockerGroupMembership().Pending() {
+		return "running but this session cannot use it: your user was added to the docker group after you logged in — " +
+			"run `newgrp docker` (or log out and back in), then retry"
+	}
+	return "running but your user may not use it: add yourself to the docker group with " +
+		"`sudo usermod -aG docker $USER`, then run `newgrp docker` (or log out and back in) and retry"
+}
+
+// AddUserToDockerGroup adds the current user to the docker group. Like the
+// install, it needs root; the membership counts from the next login (or
+// under `sg docker`).
+func AddUserToDockerGroup() error {
+	u, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("looking up the current user: %w", err)
+	}
+	d := NewDockerInstaller()
+	if commandExists("usermod") {
+		err = d.runAsRoot("usermod", "-aG", dockerGroup, u.Username)
+	} else {
+		// Alpine's busybox has no usermod.
+		err = d.runAsRoot("addgroup", u.Username, dockerGroup)
+	}
+	if err != nil {
+		return fmt.Errorf("adding %s to the docker group: %w", u.Username, err)
+	}
+	return nil
+}
+
+// WorksUnderGroup reports whether docker answers under `sg docker`, i.e.
+// whether a session with the docker group could use it. The probe runs
+// through ex, so the sandbox and --audit see it.
+func WorksUnderGroup(ctx context.Context, ex executor.CommandExecutor) bool {
+	if !commandExists("sg") {
+		return false
+	}
+	_, err := ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "sg",
+		Args:    []string{dockerGroup, "-c", "docker ps"},
+		Timeout: 10 * time.Second,
+	})
+	return err == nil
+}
+
+// SgCommand is the running command line under `sg docker`, for the user to
+// re-run it with the docker group without a new login.
+func SgCommand() string {
+	argv := append([]string{"openframe"}, os.Args[1:]...)
+	return "sg " + dockerGroup + " -c " + shellJoin([]string{shellJoin(argv)})
+}
+
+// shellJoin quotes args for sh -c, in single quotes where they need any.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && strings.Trim(a, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@") == "" {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
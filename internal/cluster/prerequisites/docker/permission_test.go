@@ -0,0 +1,87 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func fakeDockerPS(t *testing.T, out string, err error) {
+	t.Helper()
+	orig := dockerPS
+	dockerPS = func(context.Context) (string, error) { return out, err }
+	t.Cleanup(func() { dockerPS = orig })
+}
+
+func fakeMembership(t *testing.T, m GroupMembership) {
+	t.Helper()
+	orig := dockerGroupMembership
+	dockerGroupMembership = func() GroupMembership { return m }
+	t.Cleanup(func() { dockerGroupMembership = orig })
+}
+
+func TestCheckDaemon(t *testing.T) {
+	cases := []struct {
+		name string
+		out  string
+		err  error
+		want DaemonState
+	}{
+		{"answers", "CONTAINER ID   IMAGE", nil, DaemonRunning},
+		{"socket refuses the user", "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock: " +
+			`Get "http://%2Fvar%2Frun%2Fdocker.sock/v1.24/containers/json": dial unix /var/run/docker.sock: connect: permission denied`, errors.New("exit status 1"), DaemonPermissionDenied},
+		{"daemon stopped", "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?", errors.New("exit status 1"), DaemonNotRunning},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeDockerPS(t, tc.out, tc.err)
+			if got := CheckDaemon(); got != tc.want {
+				t.Errorf("CheckDaemon() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClassifyDaemonError(t *testing.T) {
+	if got := classifyDaemonError("open /root/.docker/config.json: permission denied"); got != DaemonNotRunning {
+		t.Errorf("a permission error away from the socket is not the socket's: got %v", got)
+	}
+	if got := classifyDaemonError("dial unix /run/user/1000/docker.sock: connect: permission denied"); got != DaemonPermissionDenied {
+		t.Errorf("rootless socket refusing the user: got %v", got)
+	}
+}
+
+func TestPermissionDeniedDetail(t *testing.T) {
+	fakeMembership(t, GroupMembership{Listed: true})
+	if d := PermissionDeniedDetail(); !strings.Contains(d, "newgrp docker") || strings.Contains(d, "usermod") {
+		t.Errorf("pending membership only needs a new session: %q", d)
+	}
+	fakeMembership(t, GroupMembership{})
+	if d := PermissionDeniedDetail(); !strings.Contains(d, "usermod -aG docker") {
+		t.Errorf("a user outside the group must be told to join it: %q", d)
+	}
+}
+
+func TestGroupMembership_Pending(t *testing.T) {
+	for _, tc := range []struct {
+		m    GroupMembership
+		want bool
+	}{
+		{GroupMembership{Listed: true}, true},
+		{GroupMembership{Listed: true, Active: true}, false},
+		{GroupMembership{}, false},
+	} {
+		if got := tc.m.Pending(); got != tc.want {
+			t.Errorf("%+v.Pending() = %v, want %v", tc.m, got, tc.want)
+		}
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	got := shellJoin([]string{"/usr/local/bin/openframe", "cluster", "create", "it's mine", "--name=dev"})
+	want := `/usr/local/bin/openframe cluster create 'it'\''s mine' --name=dev`
+	if got != want {
+		t.Errorf("shellJoin = %s, want %s", got, want)
+	}
+}
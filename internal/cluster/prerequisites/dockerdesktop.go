@@ -0,0 +1,88 @@
+package prerequisites
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/docker"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/dockerdesktop"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslconfig"
+	"github.com/pterm/pterm"
+)
+
+// checkDockerDesktopLimits compares the resources of Docker Desktop's VM on
+// macOS with what the platform needs at the size this Mac would get, and,
+// with the user's consent, raises them in Docker Desktop's settings file and
+// restarts it. Like checkWSLLimits it never fails the prerequisites, and
+// non-interactive runs only report.
+func checkDockerDesktopLimits(ctx context.Context, nonInteractive bool) error {
+	have, ok := dockerdesktop.Detect(ctx)
+	if !ok {
+		return nil
+	}
+	hostMemory, hostCPUs := wslconfig.Host()
+	size := sizing.Classify(sizing.Host{MemoryMB: hostMemory, CPUs: hostCPUs})
+	need := sizing.Requirements(size)
+	want, changes := dockerdesktop.Plan(have, dockerdesktop.Resources{MemoryMB: need.MemoryMB, CPUs: need.CPUs}, hostMemory, hostCPUs)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pterm.Warning.Printfln("Docker Desktop gives its VM less than a %s install needs; pods may be OOM-killed during the install.", size)
+	table := pterm.TableData{{"SETTING", "CURRENT", "PROPOSED"}}
+	for _, c := range changes {
+		table = append(table, []string{c.Setting, c.From, c.To})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+
+	settings, err := dockerdesktop.LoadSettings()
+	if err != nil {
+		pterm.Info.Println("Raise them in Docker Desktop: Settings > Resources > Advanced, then Apply & restart.")
+		return nil
+	}
+	if nonInteractive {
+		pterm.Info.Printfln("Raise them in Docker Desktop (Settings > Resources > Advanced), or quit it and set them in %s.", settings.Path)
+		return nil
+	}
+	confirmed, err := ui.ConfirmActionInteractive("Quit Docker Desktop, raise these limits in its settings and start it again? Running containers and clusters will stop.", true)
+	if err := errors.WrapConfirmationError(err, "failed to get Docker Desktop settings confirmation"); err != nil {
+		return err
+	}
+	if !confirmed {
+		pterm.Info.Println("Leaving Docker Desktop's settings unchanged.")
+		return nil
+	}
+
+	// Docker Desktop writes its settings back when it quits, so the file is
+	// only edited once it has.
+	sp := spinner.New()
+	sp.Start("Quitting Docker Desktop...")
+	if err := dockerdesktop.Quit(ctx); err != nil {
+		sp.Fail("Docker Desktop did not quit")
+		pterm.Warning.Printfln("%v. Raise the limits in Docker Desktop: Settings > Resources > Advanced.", err)
+		return nil
+	}
+	sp.Stop()
+	settings.Set(want)
+	if err := settings.Save(); err != nil {
+		pterm.Warning.Printfln("Could not update Docker Desktop's settings: %v", err)
+	} else {
+		pterm.Success.Printfln("Updated %s (previous version saved as %s.bak)", settings.Path, settings.Path)
+	}
+
+	if err := docker.StartDocker(); err != nil {
+		return fmt.Errorf("failed to start Docker Desktop again: %w", err)
+	}
+	sp = spinner.New()
+	sp.Start("Waiting for Docker to start...")
+	if err := docker.WaitForDocker(); err != nil {
+		sp.Fail("Docker failed to start")
+		return fmt.Errorf("timed out waiting for Docker Desktop to start: %w", err)
+	}
+	sp.Success("Docker Desktop restarted with the new limits")
+	return nil
+}
@@ -1,6 +1,7 @@
 package prerequisites
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/helm"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites/k3d"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
 	"github.com/pterm/pterm"
@@ -96,22 +98,36 @@ func (i *Installer) installTool(tool string) error {
 
 // CheckAndInstallNonInteractive checks and installs prerequisites with optional non-interactive mode
 func (i *Installer) CheckAndInstallNonInteractive(nonInteractive bool) error {
+	// PHASE 0: WSL VM limits and clock. Restarting WSL stops Docker inside
+	// it, so this runs before the Docker checks below see (and restart) it.
+	if err := checkWSLLimits(context.Background(), nonInteractive); err != nil {
+		return err
+	}
+	if err := checkClockSkew(context.Background(), nonInteractive); err != nil {
+		return err
+	}
+
 	// PHASE 1: Check what's actually missing vs what's not running
 	allPresent, missing := i.checker.CheckAll()
 	if allPresent {
-		return nil
+		return checkDockerDesktopLimits(context.Background(), nonInteractive)
 	}
 
 	// Separate into truly missing tools vs Docker not running
 	var missingTools []string
-	var dockerNotRunning bool
+	var dockerNotRunning, dockerPermissionDenied bool
 
 	for _, tool := range missing {
 		switch strings.ToLower(tool) {
 		case "docker":
 			if docker.NewDockerInstaller().IsInstalled() {
-				// Docker is installed but not running - handle later
-				dockerNotRunning = true
+				// Docker is installed but not running, or refuses the user -
+				// handle later
+				if docker.CheckDaemon() == docker.DaemonPermissionDenied {
+					dockerPermissionDenied = true
+				} else {
+					dockerNotRunning = true
+				}
 			} else {
 				// Docker is not installed - needs installation
 				missingTools = append(missingTools, "Docker")
@@ -153,8 +169,15 @@ func (i *Installer) CheckAndInstallNonInteractive(nonInteractive bool) error {
 			// macOS takes tens of seconds to start, and on Linux the daemon may
 			// not be running at all. Route it through the start/wait phase below
 			// instead of letting the very next `k3d cluster create` fail.
-			if containsTool(missingTools, "Docker") && !docker.IsDockerRunning() {
-				dockerNotRunning = true
+			// On Linux the install adds the user to the docker group, which this
+			// session does not have yet.
+			if containsTool(missingTools, "Docker") {
+				switch docker.CheckDaemon() {
+				case docker.DaemonPermissionDenied:
+					dockerPermissionDenied = true
+				case docker.DaemonNotRunning:
+					dockerNotRunning = true
+				}
 			}
 		} else {
 			i.showManualInstructions()
@@ -162,7 +185,13 @@ func (i *Installer) CheckAndInstallNonInteractive(nonInteractive bool) error {
 		}
 	}
 
-	// PHASE 3: Now check if Docker needs to be started (after all tools are installed)
+	// PHASE 3: A daemon that refuses the user needs group membership, not a
+	// start.
+	if dockerPermissionDenied {
+		return i.handleDockerPermission(context.Background(), nonInteractive)
+	}
+
+	// PHASE 4: Now check if Docker needs to be started (after all tools are installed)
 	if dockerNotRunning {
 		if nonInteractive {
 			// In non-interactive mode, try to start Docker automatically
@@ -212,7 +241,8 @@ func (i *Installer) CheckAndInstallNonInteractive(nonInteractive bool) error {
 		}
 	}
 
-	return nil
+	// PHASE 5: Docker Desktop's VM limits, once Docker answers.
+	return checkDockerDesktopLimits(context.Background(), nonInteractive)
 }
 
 func (i *Installer) showManualInstructions() {
@@ -239,6 +269,60 @@ func (i *Installer) showManualInstructions() {
 	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
 }
 
+// The docker group steps of handleDockerPermission. Overridden in tests.
+var (
+	dockerGroupListed     = func() bool { return docker.DockerGroupMembership().Listed }
+	confirmDockerGroup    = ui.ConfirmActionInteractive
+	addUserToDockerGroup  = docker.AddUserToDockerGroup
+	dockerWorksUnderGroup = func(ctx context.Context) bool {
+		return docker.WorksUnderGroup(ctx, executor.NewRealCommandExecutor(false, false))
+	}
+	dockerPermissionIsLinux = runtime.GOOS == "linux"
+)
+
+// handleDockerPermission deals with a running daemon that refuses the user.
+// On Linux an interactive run offers to add the user to the docker group;
+// membership is root-equivalent, so a non-interactive one only reports, like
+// the firewall and Docker Desktop checks. The membership only counts in a new
+// session, so it always ends by saying how to start one and retry.
+func (i *Installer) handleDockerPermission(ctx context.Context, nonInteractive bool) error {
+	pterm.Warning.Println("Docker is running, but your user may not use it (permission denied on the Docker socket).")
+	if dockerPermissionIsLinux {
+		if !dockerGroupListed() && !nonInteractive {
+			confirmed, err := confirmDockerGroup("Would you like me to add your user to the docker group? It gives your user root-equivalent access to this machine.", true)
+			if err := errors.WrapConfirmationError(err, "failed to get docker group confirmation"); err != nil {
+				return err
+			}
+			if confirmed {
+				if err := addUserToDockerGroup(); err != nil {
+					i.showDockerPermissionInstructions()
+					return err
+				}
+				pterm.Success.Println("Added your user to the docker group")
+			}
+		}
+		if dockerGroupListed() && dockerWorksUnderGroup(ctx) {
+			pterm.Info.Printf("Docker answers with the docker group: run %s in this shell, or re-run this command as\n  %s\n",
+				pterm.Cyan("newgrp docker"), pterm.Cyan(docker.SgCommand()))
+			return fmt.Errorf("the Docker daemon refuses your user: %s", docker.PermissionDeniedDetail())
+		}
+	}
+	i.showDockerPermissionInstructions()
+	return fmt.Errorf("the Docker daemon refuses your user: %s", docker.PermissionDeniedDetail())
+}
+
+func (i *Installer) showDockerPermissionInstructions() {
+	fmt.Println()
+	pterm.Info.Println("Give your user access to Docker, then try again:")
+	if !docker.DockerGroupMembership().Listed {
+		pterm.Printf("• Join the docker group:\n")
+		pterm.Printf("  %s\n", pterm.Cyan("sudo usermod -aG docker $USER"))
+	}
+	pterm.Printf("• Start a shell with the group (or log out and back in):\n")
+	pterm.Printf("  %s\n", pterm.Cyan("newgrp docker"))
+	pterm.Printf("• Check that Docker answers: %s\n", pterm.Cyan("docker ps"))
+}
+
 func (i *Installer) showDockerStartInstructions() {
 	fmt.Println()
 	pterm.Info.Println("Please start Docker manually and try again:")
@@ -1,6 +1,8 @@
 package prerequisites
 
 import (
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -77,3 +79,69 @@ func TestContainsTool(t *testing.T) {
 		t.Error("containsTool must not match absent tools")
 	}
 }
+
+// fakeDockerGroup stubs the docker group steps of handleDockerPermission; it
+// returns the calls made to add the user.
+func fakeDockerGroup(t *testing.T, listed, confirm, worksUnderGroup bool) *int {
+	t.Helper()
+	added := new(int)
+	origListed, origConfirm, origAdd, origWorks, origLinux := dockerGroupListed, confirmDockerGroup, addUserToDockerGroup, dockerWorksUnderGroup, dockerPermissionIsLinux
+	t.Cleanup(func() {
+		dockerGroupListed, confirmDockerGroup, addUserToDockerGroup, dockerWorksUnderGroup, dockerPermissionIsLinux = origListed, origConfirm, origAdd, origWorks, origLinux
+	})
+	dockerPermissionIsLinux = true
+	dockerGroupListed = func() bool { return listed }
+	confirmDockerGroup = func(string, bool) (bool, error) { return confirm, nil }
+	addUserToDockerGroup = func() error {
+		*added++
+		listed = true
+		return nil
+	}
+	dockerWorksUnderGroup = func(context.Context) bool { return worksUnderGroup }
+	return added
+}
+
+func TestHandleDockerPermission(t *testing.T) {
+	installer := NewInstaller()
+
+	t.Run("non-interactive only reports", func(t *testing.T) {
+		added := fakeDockerGroup(t, false, true, true)
+		err := installer.handleDockerPermission(context.Background(), true)
+		if err == nil || !strings.Contains(err.Error(), "refuses your user") {
+			t.Fatalf("want the permission error, got %v", err)
+		}
+		if *added != 0 {
+			t.Error("a non-interactive run must not add the user to the docker group")
+		}
+	})
+
+	t.Run("interactive adds the user once confirmed", func(t *testing.T) {
+		added := fakeDockerGroup(t, false, true, true)
+		if err := installer.handleDockerPermission(context.Background(), false); err == nil {
+			t.Fatal("the session still lacks the group, so the check must fail")
+		}
+		if *added != 1 {
+			t.Errorf("want the user added once, got %d", *added)
+		}
+	})
+
+	t.Run("interactive declined", func(t *testing.T) {
+		added := fakeDockerGroup(t, false, false, true)
+		if err := installer.handleDockerPermission(context.Background(), false); err == nil {
+			t.Fatal("want the permission error")
+		}
+		if *added != 0 {
+			t.Error("a declined prompt must not add the user")
+		}
+	})
+
+	t.Run("already listed", func(t *testing.T) {
+		added := fakeDockerGroup(t, true, true, false)
+		if err := installer.handleDockerPermission(context.Background(), false); err == nil {
+			t.Fatal("want the permission error")
+		}
+		if *added != 0 {
+			t.Error("a listed user is not added again")
+		}
+	})
+}
@@ -21,8 +21,8 @@ func commandExists(cmd string) bool {
 }
 
 func isK3dInstalled() bool {
-	// On Windows, check k3d in WSL2
-	if runtime.GOOS == "windows" {
+	// On Windows (unless in Docker Desktop native mode), check k3d in WSL2
+	if platform.UsesWSL() {
 		return wsllauncher.CommandAvailable("k3d")
 	}
 
@@ -0,0 +1,79 @@
+package prerequisites
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/errors"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslconfig"
+	"github.com/pterm/pterm"
+)
+
+// checkWSLLimits compares the WSL2 VM's limits with what the cluster needs
+// and, with the user's consent, raises them in .wslconfig and restarts WSL.
+// It never fails the prerequisites: a VM that is too small is a warning, since
+// the install can still be sized down (--size small). Non-interactive runs
+// only report — the file is the user's and is never changed unasked.
+func checkWSLLimits(ctx context.Context, nonInteractive bool) error {
+	if !platform.UsesWSL() {
+		return nil
+	}
+	path, err := wslconfig.Path()
+	if err != nil {
+		return nil
+	}
+	f, err := wslconfig.Load(path)
+	if err != nil {
+		pterm.Warning.Printfln("Could not check the WSL limits: %v", err)
+		return nil
+	}
+	hostMemory, hostCPUs := wslconfig.Host()
+	changes := wslconfig.Plan(f.Limits(), hostMemory, hostCPUs, wslconfig.ClusterRequirements)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pterm.Warning.Printfln("The WSL2 VM limits in %s are below what the cluster needs; pods may be OOM-killed during the install.", path)
+	table := pterm.TableData{{"SETTING", "CURRENT", "PROPOSED"}}
+	for _, c := range changes {
+		table = append(table, []string{"[" + wslconfig.Section + "] " + c.Key, c.From, c.To})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(table).Render()
+
+	if nonInteractive {
+		pterm.Info.Printfln("Set these in %s and run 'wsl --shutdown' to apply them.", path)
+		return nil
+	}
+	confirmed, err := ui.ConfirmActionInteractive(fmt.Sprintf("Update %s with these limits?", path), true)
+	if err := errors.WrapConfirmationError(err, "failed to get .wslconfig confirmation"); err != nil {
+		return err
+	}
+	if !confirmed {
+		pterm.Info.Println("Leaving .wslconfig unchanged.")
+		return nil
+	}
+	f.Apply(changes)
+	if err := f.Save(); err != nil {
+		return err
+	}
+	pterm.Success.Printfln("Updated %s (previous version saved as %s.bak)", path, path)
+
+	// The limits only apply once the VM restarts, which stops every distro —
+	// including Docker and any running cluster — so it is asked separately.
+	restart, err := ui.ConfirmActionInteractive("Restart WSL now ('wsl --shutdown') so the new limits apply? Running WSL distros and clusters will stop.", true)
+	if err := errors.WrapConfirmationError(err, "failed to get WSL restart confirmation"); err != nil {
+		return err
+	}
+	if !restart {
+		pterm.Info.Println("Run 'wsl --shutdown' before creating the cluster; the new limits apply after WSL restarts.")
+		return nil
+	}
+	if err := wslconfig.Shutdown(ctx); err != nil {
+		pterm.Warning.Printfln("Could not restart WSL: %v. Run 'wsl --shutdown' manually.", err)
+		return nil
+	}
+	pterm.Success.Println("WSL restarted with the new limits")
+	return nil
+}
@@ -0,0 +1,64 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+)
+
+// createProgress renders cluster creation phase by phase, the way the chart
+// installer reports its steps: the phase in progress on the spinner (with the
+// provider's latest line as detail), each finished phase as a success line.
+// Without a spinner (non-interactive) each phase is one info line.
+type createProgress struct {
+	sp      *spinner.Spinner
+	current models.CreatePhase
+}
+
+// enter moves to phase. Repeated and earlier phases are ignored: k3d logs
+// several lines per phase and the provider reports some phases itself.
+func (p *createProgress) enter(phase models.CreatePhase) {
+	if phase <= p.current {
+		return
+	}
+	if p.current != 0 && p.sp != nil {
+		p.sp.Success(p.current.Done())
+	}
+	p.current = phase
+
+	label := fmt.Sprintf("[%d/%d] %s...", phase, models.CreatePhaseCount, phase)
+	if p.sp == nil {
+		pterm.Info.Println(label)
+		return
+	}
+	p.sp.SetDetail("")
+	p.sp.Start(label)
+}
+
+// succeed closes the phase in progress and prints msg.
+func (p *createProgress) succeed(msg string) {
+	if p.sp == nil {
+		pterm.Success.Println(msg)
+		return
+	}
+	if p.current == 0 {
+		p.sp.Success(msg)
+		return
+	}
+	p.sp.Success(p.current.Done())
+	pterm.Success.Println(msg)
+}
+
+// fail stops the spinner with msg, naming the phase that failed.
+func (p *createProgress) fail(msg string) {
+	if p.sp == nil {
+		return
+	}
+	if p.current != 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.ToLower(p.current.String()))
+	}
+	p.sp.Fail(msg)
+}
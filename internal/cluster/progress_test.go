@@ -0,0 +1,41 @@
+package cluster
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProgress_FinishedPhasesPrintOnce(t *testing.T) {
+	var buf bytes.Buffer
+	p := &createProgress{sp: spinner.NewWithWriter(&buf)}
+	p.sp.Start("Creating k3d cluster 'dev'...")
+
+	p.enter(models.PhasePullImages)
+	p.enter(models.PhaseCreateNodes)
+	p.enter(models.PhaseCreateNodes)
+	p.enter(models.PhasePullImages)
+	p.enter(models.PhaseWaitNodes)
+	p.succeed("Cluster 'dev' created successfully")
+
+	out := buf.String()
+	assert.Equal(t, 1, strings.Count(out, "Images pulled"))
+	assert.Equal(t, 1, strings.Count(out, "Nodes created"))
+	assert.Contains(t, out, "Nodes Ready")
+	assert.NotContains(t, out, "Kubeconfig written", "a skipped phase is not reported as done")
+}
+
+func TestCreateProgress_FailNamesPhase(t *testing.T) {
+	var buf bytes.Buffer
+	p := &createProgress{sp: spinner.NewWithWriter(&buf)}
+	p.sp.Start("Creating k3d cluster 'dev'...")
+
+	p.enter(models.PhaseCreateNodes)
+	p.fail("Failed to create cluster 'dev'")
+
+	assert.Contains(t, buf.String(), "Failed to create cluster 'dev' (creating nodes)")
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// Detector recognises the clusters of one backend. It returns the backend's
+// ClusterType when name is one of its clusters and an error otherwise — a
+// missing CLI is simply "not mine".
+type Detector interface {
+	DetectClusterType(ctx context.Context, name string) (models.ClusterType, error)
+}
+
+// Registry asks every registered Detector about a cluster name at once and
+// reports which backend owns it, so commands such as delete and start need not
+// assume k3d.
+type Registry struct {
+	detectors []Detector
+}
+
+// NewRegistry returns a registry over detectors. Registration order is the
+// precedence when several match: a k3d cluster also appears as a kubeconfig
+// context, so the external detector belongs last.
+func NewRegistry(detectors ...Detector) *Registry {
+	return &Registry{detectors: detectors}
+}
+
+// DefaultRegistry returns the registry the CLI uses: k3d (via the given
+// provider), kind, minikube, then external clusters and kubeconfig contexts.
+func DefaultRegistry(k3dProvider Detector, exec executor.CommandExecutor) *Registry {
+	return NewRegistry(
+		k3dProvider,
+		NewKindDetector(exec),
+		NewMinikubeDetector(exec),
+		ExternalDetector{},
+	)
+}
+
+// Register adds d with the lowest precedence.
+func (r *Registry) Register(d Detector) {
+	r.detectors = append(r.detectors, d)
+}
+
+// DetectClusterType runs every detector in parallel and returns the type from
+// the highest-precedence match, or ErrClusterNotFound when none matches.
+func (r *Registry) DetectClusterType(ctx context.Context, name string) (models.ClusterType, error) {
+	if name == "" {
+		return "", models.NewInvalidConfigError("name", name, "cluster name cannot be empty")
+	}
+
+	found := make([]models.ClusterType, len(r.detectors))
+	var wg sync.WaitGroup
+	for i, d := range r.detectors {
+		wg.Add(1)
+		go func(i int, d Detector) {
+			defer wg.Done()
+			if t, err := d.DetectClusterType(ctx, name); err == nil {
+				found[i] = t
+			}
+		}(i, d)
+	}
+	wg.Wait()
+
+	for _, t := range found {
+		if t != "" {
+			return t, nil
+		}
+	}
+	return "", models.NewClusterNotFoundError(name)
+}
+
+// KindDetector recognises clusters listed by `kind get clusters`.
+type KindDetector struct {
+	exec executor.CommandExecutor
+}
+
+// NewKindDetector returns a KindDetector running kind through exec.
+func NewKindDetector(exec executor.CommandExecutor) *KindDetector {
+	return &KindDetector{exec: exec}
+}
+
+// DetectClusterType implements Detector.
+func (d *KindDetector) DetectClusterType(ctx context.Context, name string) (models.ClusterType, error) {
+	result, err := d.exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "kind",
+		Args:    []string{"get", "clusters"},
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		return "", models.NewClusterNotFoundError(name)
+	}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if strings.TrimSpace(line) == name {
+			return models.ClusterTypeKind, nil
+		}
+	}
+	return "", models.NewClusterNotFoundError(name)
+}
+
+// MinikubeDetector recognises minikube profiles.
+type MinikubeDetector struct {
+	exec executor.CommandExecutor
+}
+
+// NewMinikubeDetector returns a MinikubeDetector running minikube through exec.
+func NewMinikubeDetector(exec executor.CommandExecutor) *MinikubeDetector {
+	return &MinikubeDetector{exec: exec}
+}
+
+// DetectClusterType implements Detector.
+func (d *MinikubeDetector) DetectClusterType(ctx context.Context, name string) (models.ClusterType, error) {
+	result, err := d.exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "minikube",
+		Args:    []string{"profile", "list", "--output", "json"},
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		return "", models.NewClusterNotFoundError(name)
+	}
+	var profiles struct {
+		Valid   []struct{ Name string } `json:"valid"`
+		Invalid []struct{ Name string } `json:"invalid"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &profiles); err != nil {
+		return "", models.NewClusterNotFoundError(name)
+	}
+	for _, p := range append(profiles.Valid, profiles.Invalid...) {
+		if p.Name == name {
+			return models.ClusterTypeMinikube, nil
+		}
+	}
+	return "", models.NewClusterNotFoundError(name)
+}
+
+// ExternalDetector recognises an attached external cluster, or a cluster
+// known only as a kubeconfig context of the same name (the default kubeconfig,
+// the isolated ones and the attached ones).
+type ExternalDetector struct{}
+
+// Overridden in tests.
+var (
+	lookupExternal = k8s.LookupExternalCluster
+	loadContexts   = k8s.LoadAllContexts
+)
+
+// DetectClusterType implements Detector.
+func (ExternalDetector) DetectClusterType(_ context.Context, name string) (models.ClusterType, error) {
+	if _, ok := lookupExternal(name); ok {
+		return models.ClusterTypeExternal, nil
+	}
+	contexts, _, err := loadContexts()
+	if err != nil {
+		return "", models.NewClusterNotFoundError(name)
+	}
+	for _, c := range contexts {
+		if c.Name == name {
+			return models.ClusterTypeExternal, nil
+		}
+	}
+	return "", models.NewClusterNotFoundError(name)
+}
+
+// A Registry is itself a Detector, and every Provider is one.
+var (
+	_ Detector = (*Registry)(nil)
+	_ Detector = Provider(nil)
+)
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedDetector models.ClusterType
+
+func (f fixedDetector) DetectClusterType(_ context.Context, name string) (models.ClusterType, error) {
+	if f == "" {
+		return "", models.NewClusterNotFoundError(name)
+	}
+	return models.ClusterType(f), nil
+}
+
+func withContexts(t *testing.T, names ...string) {
+	origLoad, origLookup := loadContexts, lookupExternal
+	t.Cleanup(func() { loadContexts, lookupExternal = origLoad, origLookup })
+	lookupExternal = func(name string) (k8s.ExternalCluster, bool) {
+		return k8s.ExternalCluster{Name: name, Context: "gke_shared"}, name == "shared"
+	}
+	loadContexts = func() ([]k8s.ContextInfo, string, error) {
+		var out []k8s.ContextInfo
+		for _, n := range names {
+			out = append(out, k8s.ContextInfo{Name: n})
+		}
+		return out, "", nil
+	}
+}
+
+func TestRegistry_PrecedenceAndNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewRegistry(fixedDetector(""), fixedDetector(models.ClusterTypeKind), fixedDetector(models.ClusterTypeExternal))
+	got, err := r.DetectClusterType(ctx, "dev")
+	require.NoError(t, err)
+	assert.Equal(t, models.ClusterTypeKind, got, "the earliest registered match wins")
+
+	_, err = NewRegistry(fixedDetector("")).DetectClusterType(ctx, "dev")
+	assert.True(t, errors.As(err, new(models.ErrClusterNotFound)))
+
+	_, err = NewRegistry().DetectClusterType(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	ctx := context.Background()
+	withContexts(t, "k3d-dev", "prod-eks")
+
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("kind get clusters", &executor.CommandResult{Stdout: "kind-a\nkind-b\n"})
+	ex.SetResponse("minikube profile list", &executor.CommandResult{Stdout: `{"invalid":[],"valid":[{"Name":"minikube"}]}`})
+	r := DefaultRegistry(fixedDetector(""), ex)
+
+	for name, want := range map[string]models.ClusterType{
+		"kind-b":   models.ClusterTypeKind,
+		"minikube": models.ClusterTypeMinikube,
+		"prod-eks": models.ClusterTypeExternal,
+		"shared":   models.ClusterTypeExternal,
+	} {
+		got, err := r.DetectClusterType(ctx, name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := r.DetectClusterType(ctx, "nowhere")
+	assert.Error(t, err)
+}
+
+func TestDetectors_MissingCLIIsNotAMatch(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetShouldFail(true, "executable file not found in $PATH")
+
+	_, err := NewKindDetector(ex).DetectClusterType(context.Background(), "dev")
+	assert.Error(t, err)
+	_, err = NewMinikubeDetector(ex).DetectClusterType(context.Background(), "dev")
+	assert.Error(t, err)
+}
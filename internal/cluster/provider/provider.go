@@ -24,6 +24,8 @@ type Provider interface {
 	DeleteCluster(ctx context.Context, name string, clusterType models.ClusterType, force bool) error
 	// StartCluster starts a stopped cluster.
 	StartCluster(ctx context.Context, name string, clusterType models.ClusterType) error
+	// StopCluster stops a running cluster without deleting it.
+	StopCluster(ctx context.Context, name string, clusterType models.ClusterType) error
 	// ListClusters returns the clusters managed by this provider.
 	ListClusters(ctx context.Context) ([]models.ClusterInfo, error)
 	// ListAllClusters returns all clusters visible to this provider.
@@ -36,6 +38,8 @@ type Provider interface {
 	GetRestConfig(ctx context.Context, name string) (*rest.Config, error)
 	// GetKubeconfig returns the kubeconfig for a cluster.
 	GetKubeconfig(ctx context.Context, name string, clusterType models.ClusterType) (string, error)
+	// RenameCluster gives a cluster a new name, keeping its data.
+	RenameCluster(ctx context.Context, oldName, newName string) error
 }
 
 // Compile-time assertion that the k3d manager satisfies Provider.
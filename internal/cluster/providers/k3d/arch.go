@@ -0,0 +1,54 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// hostArch is the architecture node containers run on. Docker Desktop on
+// macOS and Docker inside WSL share the host CPU, so it is the CLI's own.
+// A variable so tests can pretend to be on Apple Silicon.
+var hostArch = func() string { return runtime.GOARCH }
+
+// k3sNodeImage returns the node image for a resolved version: the rancher/k3s
+// tag pinned to the digest of this host's platform image, so every node runs
+// the same native build. A tag without an image for this host keeps the plain
+// tag (Docker falls back to emulation) and yields a warning; without Docker
+// Hub the plain tag is used as before.
+func k3sNodeImage(ctx context.Context, version string) (image, warning string) {
+	tag := strings.TrimPrefix(defaultK3sImage, "rancher/k3s:")
+	if version != "" {
+		tag = version
+	}
+	image = "rancher/k3s:" + tag
+
+	info, err := lookupK3sTag(ctx, tag)
+	if err != nil || !info.Found || len(info.Images) == 0 {
+		return image, ""
+	}
+	arch := hostArch()
+	var archs []string
+	for _, img := range info.Images {
+		if img.Architecture == arch {
+			return image + "@" + img.Digest, ""
+		}
+		archs = append(archs, img.Architecture+variantSuffix(img.Variant))
+	}
+	warning = fmt.Sprintf("rancher/k3s:%s has no linux/%s image (only %s); Docker will emulate it, which is slow and often crashes k3s",
+		tag, arch, strings.Join(archs, ", "))
+	if arch == "arm64" {
+		// Every recent k3s release is multi-arch; the default one certainly is.
+		def := strings.TrimPrefix(defaultK3sImage, "rancher/k3s:v")
+		warning += fmt.Sprintf(". On Apple Silicon use a recent release, e.g. --version %s", def[:strings.LastIndex(def, ".")])
+	}
+	return image, warning
+}
+
+func variantSuffix(variant string) string {
+	if variant == "" {
+		return ""
+	}
+	return "/" + variant
+}
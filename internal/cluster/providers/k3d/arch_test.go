@@ -0,0 +1,57 @@
+package k3d
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withTagInfo(t *testing.T, arch string, info k3sTagInfo, err error) *string {
+	t.Helper()
+	var asked string
+	origLookup, origArch := lookupK3sTag, hostArch
+	t.Cleanup(func() { lookupK3sTag, hostArch = origLookup, origArch })
+	hostArch = func() string { return arch }
+	lookupK3sTag = func(_ context.Context, tag string) (k3sTagInfo, error) {
+		asked = tag
+		return info, err
+	}
+	return &asked
+}
+
+var multiArch = k3sTagInfo{Found: true, Images: []k3sImage{
+	{OS: "linux", Architecture: "amd64", Digest: "sha256:aaa"},
+	{OS: "linux", Architecture: "arm64", Digest: "sha256:bbb"},
+	{OS: "linux", Architecture: "arm", Variant: "v7", Digest: "sha256:ccc"},
+}}
+
+func TestK3sNodeImage_PinsHostDigest(t *testing.T) {
+	asked := withTagInfo(t, "arm64", multiArch, nil)
+
+	image, warning := k3sNodeImage(context.Background(), "v1.32.2-k3s1")
+	assert.Equal(t, "rancher/k3s:v1.32.2-k3s1@sha256:bbb", image)
+	assert.Empty(t, warning)
+	assert.Equal(t, "v1.32.2-k3s1", *asked)
+
+	image, _ = k3sNodeImage(context.Background(), "")
+	assert.Equal(t, defaultK3sImage+"@sha256:bbb", image, "no version means the default tag")
+}
+
+func TestK3sNodeImage_WarnsOnMissingArch(t *testing.T) {
+	withTagInfo(t, "arm64", k3sTagInfo{Found: true, Images: []k3sImage{{OS: "linux", Architecture: "amd64", Digest: "sha256:aaa"}}}, nil)
+
+	image, warning := k3sNodeImage(context.Background(), "v1.20.0-k3s1")
+	assert.Equal(t, "rancher/k3s:v1.20.0-k3s1", image, "left to Docker, unpinned")
+	assert.Contains(t, warning, "no linux/arm64 image (only amd64)")
+	assert.Contains(t, warning, "Apple Silicon")
+}
+
+func TestK3sNodeImage_OfflineKeepsTag(t *testing.T) {
+	withTagInfo(t, "amd64", k3sTagInfo{}, errors.New("no such host"))
+
+	image, warning := k3sNodeImage(context.Background(), "v1.31.5-k3s1")
+	assert.Equal(t, "rancher/k3s:v1.31.5-k3s1", image)
+	assert.Empty(t, warning)
+}
@@ -0,0 +1,17 @@
+package k3d
+
+import "fmt"
+
+// nodeCABundle is where --ca-bundle is mounted in each node. k3s and its
+// containerd read every file under /etc/ssl/certs next to the system roots,
+// so image pulls through a TLS-intercepting proxy trust its CA.
+const nodeCABundle = "/etc/ssl/certs/openframe-ca-bundle.pem"
+
+// caBundleVolume renders the k3d `volumes:` entry mounting bundle read-only in
+// every node, or "" without a bundle.
+func caBundleVolume(bundle string) string {
+	if bundle == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n  - volume: %q\n    nodeFilters:\n      - server:*\n      - agent:*", bundle+":"+nodeCABundle+":ro")
+}
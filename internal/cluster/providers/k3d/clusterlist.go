@@ -0,0 +1,243 @@
+package k3d
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// k3d has changed the JSON of its clusters across releases. v5 keeps a node's
+// container labels under "runtimeLabels" and its published ports under
+// "portMappings" (Docker's port map). v4 kept them under "labels" and "ports",
+// which was a list of "[ip:]host:container[/proto]" specs in v4.0 and Docker's
+// port map later. Both are read; a node carrying neither comes from an output
+// this CLI does not know, and the cluster is read again with `k3d cluster get`.
+const (
+	k3dSchemaUnknown = 0
+	k3dSchemaV4      = 4
+	k3dSchemaV5      = 5
+)
+
+// rawK3dCluster is a cluster as any k3d v4 or v5 prints it.
+type rawK3dCluster struct {
+	Name           string       `json:"name"`
+	ServersCount   int          `json:"serversCount"`
+	ServersRunning int          `json:"serversRunning"`
+	AgentsCount    int          `json:"agentsCount"`
+	AgentsRunning  int          `json:"agentsRunning"`
+	Image          string       `json:"image"`
+	Nodes          []rawK3dNode `json:"nodes"`
+}
+
+type rawK3dNode struct {
+	Name    string       `json:"name"`
+	Role    string       `json:"role"`
+	Image   string       `json:"image"`
+	Created string       `json:"created"`
+	State   k3dNodeState `json:"State"`
+	IP      k3dNodeIP    `json:"IP"`
+	// v5
+	RuntimeLabels map[string]string        `json:"runtimeLabels"`
+	PortMappings  map[string][]PortMapping `json:"portMappings"`
+	// v4
+	Labels map[string]string `json:"labels"`
+	Ports  json.RawMessage   `json:"ports"`
+}
+
+// schema tells which k3d release printed the node.
+func (n rawK3dNode) schema() int {
+	switch {
+	case n.RuntimeLabels != nil || n.PortMappings != nil:
+		return k3dSchemaV5
+	case n.Labels != nil || len(n.Ports) > 0:
+		return k3dSchemaV4
+	default:
+		return k3dSchemaUnknown
+	}
+}
+
+// listK3dClusters returns the clusters k3d knows about. A cluster listed
+// without its nodes' labels and ports is read again with `k3d cluster get`
+// for them; when that tells no more, the list is what there is.
+func (m *K3dManager) listK3dClusters(ctx context.Context) ([]k3dClusterInfo, error) {
+	raw, err := m.k3dClusterJSON(ctx, "list")
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]k3dClusterInfo, 0, len(raw))
+	for _, rc := range raw {
+		if clusterSchema(rc) == k3dSchemaUnknown {
+			if detailed, err := m.getK3dCluster(ctx, rc.Name); err != nil {
+				if m.verbose {
+					fmt.Printf("Warning: could not read the nodes of cluster %s: %v\n", rc.Name, err)
+				}
+			} else if detailed != nil && clusterSchema(*detailed) != k3dSchemaUnknown {
+				rc.Nodes = detailed.Nodes
+				if rc.Image == "" {
+					rc.Image = detailed.Image
+				}
+			}
+		}
+		c, err := rc.info()
+		if err != nil {
+			return nil, fmt.Errorf("parsing cluster %s from k3d: %w", rc.Name, err)
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
+
+// getK3dCluster reads one cluster with `k3d cluster get`; nil when k3d prints
+// nothing for it.
+func (m *K3dManager) getK3dCluster(ctx context.Context, name string) (*rawK3dCluster, error) {
+	raw, err := m.k3dClusterJSON(ctx, "get", name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range raw {
+		if raw[i].Name == name {
+			return &raw[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// k3dClusterJSON runs `k3d cluster <args> --output json` and decodes it.
+func (m *K3dManager) k3dClusterJSON(ctx context.Context, args ...string) ([]rawK3dCluster, error) {
+	command := "k3d cluster " + strings.Join(args, " ")
+	result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "k3d",
+		Args:    append(append([]string{"cluster"}, args...), "--output", "json"),
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("running %s: %w", command, err)
+	}
+	clusters, err := decodeK3dClusters([]byte(result.Stdout))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the output of %s: %w", command, err)
+	}
+	return clusters, nil
+}
+
+// decodeK3dClusters decodes a JSON list of clusters, or a single cluster as
+// some releases print for `cluster get`. Log lines k3d wrote to stdout before
+// the JSON are skipped; no output at all, or null, is no clusters.
+func decodeK3dClusters(out []byte) ([]rawK3dCluster, error) {
+	out = bytes.TrimSpace(out)
+	for len(out) > 0 && out[0] != '[' && out[0] != '{' && string(out) != "null" {
+		nl := bytes.IndexByte(out, '\n')
+		if nl < 0 {
+			return nil, fmt.Errorf("no JSON in %q", truncate(string(out), 80))
+		}
+		out = bytes.TrimSpace(out[nl+1:])
+	}
+	if len(out) == 0 || string(out) == "null" {
+		return nil, nil
+	}
+	var clusters []rawK3dCluster
+	if out[0] == '{' {
+		var one rawK3dCluster
+		if err := json.Unmarshal(out, &one); err != nil {
+			return nil, err
+		}
+		clusters = []rawK3dCluster{one}
+	} else if err := json.Unmarshal(out, &clusters); err != nil {
+		return nil, err
+	}
+	if len(clusters) > 0 && clusters[0].Name == "" {
+		return nil, fmt.Errorf("not a list of k3d clusters: %s", truncate(string(out), 80))
+	}
+	return clusters, nil
+}
+
+// clusterSchema is the schema of the cluster's nodes; unknown when it has
+// none or none of them carries labels or ports.
+func clusterSchema(c rawK3dCluster) int {
+	for _, n := range c.Nodes {
+		if s := n.schema(); s != k3dSchemaUnknown {
+			return s
+		}
+	}
+	return k3dSchemaUnknown
+}
+
+// info converts the cluster to the v5 shape the rest of the package reads.
+func (c rawK3dCluster) info() (k3dClusterInfo, error) {
+	info := k3dClusterInfo{
+		Name:           c.Name,
+		ServersCount:   c.ServersCount,
+		ServersRunning: c.ServersRunning,
+		AgentsCount:    c.AgentsCount,
+		AgentsRunning:  c.AgentsRunning,
+		Image:          c.Image,
+	}
+	for _, n := range c.Nodes {
+		node := k3dNode{
+			Name: n.Name, Role: n.Role, Image: n.Image, State: n.State, IP: n.IP,
+			RuntimeLabels: n.RuntimeLabels, PortMappings: n.PortMappings,
+		}
+		// Only the display uses it; a format k3d may change is not worth failing for.
+		node.Created, _ = time.Parse(time.RFC3339Nano, n.Created)
+		if n.schema() == k3dSchemaV4 {
+			node.RuntimeLabels = n.Labels
+			ports, err := legacyPorts(n.Ports)
+			if err != nil {
+				return k3dClusterInfo{}, fmt.Errorf("node %s: %w", n.Name, err)
+			}
+			node.PortMappings = ports
+		}
+		info.Nodes = append(info.Nodes, node)
+	}
+	return info, nil
+}
+
+// legacyPorts reads a v4 node's "ports": Docker's port map, or a list of
+// "[ip:]host:container[/proto]" specs.
+func legacyPorts(raw json.RawMessage) (map[string][]PortMapping, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if raw[0] == '{' {
+		var ports map[string][]PortMapping
+		if err := json.Unmarshal(raw, &ports); err != nil {
+			return nil, fmt.Errorf("reading its ports: %w", err)
+		}
+		return ports, nil
+	}
+	var specs []string
+	if err := json.Unmarshal(raw, &specs); err != nil {
+		return nil, fmt.Errorf("reading its ports: %w", err)
+	}
+	ports := map[string][]PortMapping{}
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("unrecognised port %q", spec)
+		}
+		container := parts[len(parts)-1]
+		if !strings.Contains(container, "/") {
+			container += "/tcp"
+		}
+		mapping := PortMapping{HostPort: parts[len(parts)-2]}
+		if len(parts) == 3 {
+			mapping.HostIP = parts[0]
+		}
+		ports[container] = append(ports[container], mapping)
+	}
+	return ports, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
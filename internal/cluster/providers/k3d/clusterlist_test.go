@@ -0,0 +1,125 @@
+package k3d
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listWith(t *testing.T, responses map[string]string) []k3dClusterInfo {
+	t.Helper()
+	mock := executor.NewMockCommandExecutor()
+	for cmd, out := range responses {
+		mock.SetResponse(cmd, &executor.CommandResult{Stdout: out})
+	}
+	clusters, err := NewK3dManager(mock, false).listK3dClusters(context.Background())
+	require.NoError(t, err)
+	return clusters
+}
+
+func TestListK3dClusters_V4Schema(t *testing.T) {
+	t.Run("port specs", func(t *testing.T) {
+		clusters := listWith(t, map[string]string{"k3d cluster list": `[{"name": "old", "serversCount": 1, "nodes": [
+			{"name": "k3d-old-server-0", "role": "server", "labels": {"k3d.server.api.port": "6551"}},
+			{"name": "k3d-old-serverlb", "role": "loadbalancer", "ports": ["0.0.0.0:8080:80/tcp", "8443:443"]}
+		]}]`})
+		require.Len(t, clusters, 1)
+		assert.Equal(t, &models.ClusterPorts{API: 6551, HTTP: 8080, HTTPS: 8443}, clusterPorts(clusters[0]))
+		assert.Equal(t, "0.0.0.0", clusters[0].Nodes[1].PortMappings["80/tcp"][0].HostIP)
+	})
+	t.Run("port map", func(t *testing.T) {
+		clusters := listWith(t, map[string]string{"k3d cluster list": `[{"name": "old", "nodes": [
+			{"name": "k3d-old-serverlb", "role": "loadbalancer", "labels": {},
+			 "ports": {"80/tcp": [{"HostIp": "", "HostPort": "8080"}]}}
+		]}]`})
+		require.Len(t, clusters, 1)
+		assert.Equal(t, []int{8080}, usedPorts(clusters[0]))
+	})
+	t.Run("unrecognised port", func(t *testing.T) {
+		mock := executor.NewMockCommandExecutor()
+		mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: `[{"name": "old", "nodes": [
+			{"name": "lb", "role": "loadbalancer", "ports": ["80"]}]}]`})
+		_, err := NewK3dManager(mock, false).listK3dClusters(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cluster old")
+		assert.Contains(t, err.Error(), `unrecognised port "80"`)
+	})
+}
+
+func TestListK3dClusters_Tolerance(t *testing.T) {
+	t.Run("log lines before the JSON", func(t *testing.T) {
+		clusters := listWith(t, map[string]string{"k3d cluster list": "WARN[0000] a newer k3d is available\n" + clusterListJSON})
+		assert.Len(t, clusters, 1)
+	})
+	t.Run("no output is no clusters", func(t *testing.T) {
+		assert.Empty(t, listWith(t, map[string]string{"k3d cluster list": "\n"}))
+		assert.Empty(t, listWith(t, map[string]string{"k3d cluster list": "null"}))
+	})
+	t.Run("JSON that is not clusters", func(t *testing.T) {
+		mock := executor.NewMockCommandExecutor()
+		mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: `[{"kind": "Node"}]`})
+		_, err := NewK3dManager(mock, false).listK3dClusters(context.Background())
+		require.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "parsing the output of k3d cluster list"), err.Error())
+	})
+}
+
+func TestListK3dClusters_GetFallback(t *testing.T) {
+	t.Run("nodes and image from cluster get", func(t *testing.T) {
+		clusters := listWith(t, map[string]string{
+			"k3d cluster list": `[{"name": "dev", "serversCount": 1, "serversRunning": 1}]`,
+			"k3d cluster get dev": `{"name": "dev", "image": "rancher/k3s:v1.31.4-k3s1", "nodes": [
+				{"name": "k3d-dev-server-0", "role": "server", "image": "rancher/k3s:v1.31.4-k3s1",
+				 "runtimeLabels": {"k3d.server.api.port": "6550"}}]}`,
+		})
+		require.Len(t, clusters, 1)
+		assert.Equal(t, 1, clusters[0].ServersRunning, "the list's counts are kept")
+		assert.Equal(t, "rancher/k3s:v1.31.4-k3s1", clusters[0].Image)
+		require.Len(t, clusters[0].Nodes, 1)
+		assert.Equal(t, "rancher/k3s:v1.31.4-k3s1", clusters[0].Nodes[0].Image)
+		assert.Equal(t, []int{6550}, usedPorts(clusters[0]))
+	})
+	t.Run("cluster get failing keeps the list", func(t *testing.T) {
+		clusters := listWith(t, map[string]string{
+			"k3d cluster list":    `[{"name": "dev", "serversCount": 1}]`,
+			"k3d cluster get dev": "FATA[0000] boom",
+		})
+		require.Len(t, clusters, 1)
+		assert.Equal(t, "dev", clusters[0].Name)
+		assert.Empty(t, clusters[0].Nodes)
+	})
+}
+
+func TestListClusters_Nodes(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: `[{"name": "dev", "serversCount": 1, "serversRunning": 1, "agentsCount": 1, "nodes": [
+		{"name": "k3d-dev-serverlb", "role": "loadbalancer", "image": "ghcr.io/k3d-io/k3d-proxy:5.9.0", "runtimeLabels": {},
+		 "State": {"Running": true, "Status": "running"}, "IP": {"IP": "172.18.0.4", "Static": false}},
+		{"name": "k3d-dev-agent-0", "role": "agent", "image": "rancher/k3s:v1.31.4-k3s1", "runtimeLabels": {},
+		 "State": {"Running": false, "Status": "exited"}},
+		{"name": "k3d-dev-server-0", "role": "server", "image": "rancher/k3s:v1.31.4-k3s1", "created": "2024-05-01T10:00:00.123456789Z",
+		 "runtimeLabels": {"k3d.server.api.port": "6550"}, "State": {"Running": true, "Status": "running"}, "IP": {"IP": "172.18.0.3"}}
+	]}]`})
+	clusters, err := NewK3dManager(mock, false).ListClusters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+
+	assert.Equal(t, "v1.31.4", clusters[0].K8sVersion, "from the servers' k3s image")
+	nodes := clusters[0].Nodes
+	require.Len(t, nodes, 3)
+	assert.Equal(t, []string{"k3d-dev-server-0", "k3d-dev-agent-0", "k3d-dev-serverlb"},
+		[]string{nodes[0].Name, nodes[1].Name, nodes[2].Name}, "servers, agents, then the load balancer")
+	assert.Equal(t, models.NodeInfo{
+		Name: "k3d-dev-server-0", Status: "running", Role: "server", Image: "rancher/k3s:v1.31.4-k3s1",
+		IP: "172.18.0.3", CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC),
+	}, nodes[0])
+	assert.Equal(t, "exited", nodes[1].Status)
+	assert.Empty(t, nodes[1].IP)
+	assert.Equal(t, clusters[0].CreatedAt, nodes[0].CreatedAt, "the cluster is as old as its first server")
+}
@@ -0,0 +1,44 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/firewall"
+)
+
+// warnFirewall warns when a host firewall stops other machines from reaching
+// the ingress ports the cluster is about to publish: the create succeeds
+// either way, and the ingress then only answers on this machine. Like the
+// inotify step it never prompts, and the API port is left out, since it is
+// bound to loopback.
+func (m *K3dManager) warnFirewall(ctx context.Context, ports PortConfig) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	findings, err := firewall.Detect(ctx, firewall.ExecRunner(m.executor), []int{ports.HTTP, ports.HTTPS})
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+	for _, f := range findings {
+		if f.Unreadable {
+			fmt.Printf("Warning: %s is active and its rules need root to read; it may stop other machines reaching ports %s\n", f.Firewall, joinPorts(f.Ports))
+		} else {
+			fmt.Printf("Warning: %s stops other machines reaching ports %s; this machine still reaches them on localhost\n", f.Firewall, joinPorts(f.Ports))
+		}
+		for _, argv := range f.Commands() {
+			fmt.Printf("  sudo %s\n", strings.Join(argv, " "))
+		}
+	}
+}
+
+func joinPorts(ports []int) string {
+	s := make([]string, len(ports))
+	for i, p := range ports {
+		s[i] = fmt.Sprint(p)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,53 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// gpuRuntimeArgs makes nvidia the default containerd runtime on every node,
+// so GPU workloads need no runtimeClassName. k3s only registers the nvidia
+// runtime when the node image ships nvidia-container-runtime.
+func gpuRuntimeArgs(gpus string) string {
+	if gpus == "" {
+		return ""
+	}
+	return "\n      - arg: --default-runtime=nvidia\n        nodeFilters:\n          - all"
+}
+
+// runtimeConfig renders options.runtime: gpuRequest, the config-file form of
+// `k3d cluster create --gpus`, which adds a docker device request for the
+// node containers, and the cluster's labels (see runtimeLabelsConfig).
+func runtimeConfig(gpus string, labels map[string]string) string {
+	var body string
+	if gpus != "" {
+		body = fmt.Sprintf("\n    gpuRequest: %q", gpus)
+	}
+	body += runtimeLabelsConfig(labels)
+	if body == "" {
+		return ""
+	}
+	return "\n  runtime:" + body
+}
+
+// checkNvidiaRuntime fails early when Docker has no nvidia runtime: without
+// the NVIDIA Container Toolkit the device request makes every node container
+// fail to start, deep inside `k3d cluster create`.
+func (m *K3dManager) checkNvidiaRuntime(ctx context.Context) error {
+	result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "docker",
+		Args:    []string{"info", "--format", "{{json .Runtimes}}"},
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query Docker runtimes for GPU support: %w", err)
+	}
+	if !strings.Contains(result.Stdout, `"nvidia"`) {
+		return fmt.Errorf("--gpus needs the NVIDIA Container Toolkit: Docker reports no nvidia runtime (install nvidia-container-toolkit and run `nvidia-ctk runtime configure --runtime=docker`)")
+	}
+	return nil
+}
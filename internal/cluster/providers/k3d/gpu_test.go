@@ -0,0 +1,54 @@
+package k3d
+
+import (
+	"context"
+	"testing"
+
+	execPkg "github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGPURuntimeConfig(t *testing.T) {
+	assert.Empty(t, runtimeConfig("", nil))
+	assert.Empty(t, gpuRuntimeArgs(""))
+
+	var parsed struct {
+		Options struct {
+			Runtime struct {
+				GPURequest string `json:"gpuRequest"`
+			} `json:"runtime"`
+		} `json:"options"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte("options:"+runtimeConfig("all", nil)), &parsed))
+	assert.Equal(t, "all", parsed.Options.Runtime.GPURequest)
+	assert.Contains(t, gpuRuntimeArgs("all"), "--default-runtime=nvidia")
+}
+
+func TestCheckNvidiaRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		runtimes string
+		wantErr  bool
+	}{
+		{"nvidia runtime present", `{"io.containerd.runc.v2":{},"nvidia":{"path":"nvidia-container-runtime"},"runc":{}}`, false},
+		{"no nvidia runtime", `{"io.containerd.runc.v2":{},"runc":{}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &MockExecutor{}
+			exec.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(o execPkg.ExecuteOptions) bool {
+				return o.Command == "docker" && o.Args[0] == "info"
+			})).Return(&execPkg.CommandResult{Stdout: tt.runtimes}, nil)
+
+			err := NewK3dManager(exec, false).checkNvidiaRuntime(context.Background())
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "NVIDIA Container Toolkit")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
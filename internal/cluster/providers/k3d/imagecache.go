@@ -0,0 +1,131 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// containerdDir is where k3s keeps containerd's content store and snapshots
+// in a node. Mounting it from outside the node container is what lets the
+// images outlive the cluster.
+const containerdDir = "/var/lib/rancher/k3s/agent/containerd"
+
+// imageCacheLabel marks the cache volumes with the cluster they belong to.
+// It is not k3d's app=k3d label, so `cluster prune` leaves them alone.
+const imageCacheLabel = "openframe.image-cache"
+
+// imageCacheNodes names each node of a cluster with servers and agents as a
+// k3d node filter ("server:0", "agent:1", ...). Each node gets its own cache:
+// containerd does not share its store between processes.
+func imageCacheNodes(servers, agents int) []string {
+	nodes := make([]string, 0, servers+agents)
+	for i := 0; i < servers; i++ {
+		nodes = append(nodes, fmt.Sprintf("server:%d", i))
+	}
+	for i := 0; i < agents; i++ {
+		nodes = append(nodes, fmt.Sprintf("agent:%d", i))
+	}
+	return nodes
+}
+
+// imageCacheSource is what is mounted for node: a volume named after the
+// cluster and node, or a per-cluster, per-node directory under the host
+// directory cache.
+func imageCacheSource(cluster, node, cache string) string {
+	key := strings.ReplaceAll(node, ":", "-")
+	if cache == models.ImageCacheVolume {
+		return "openframe-image-cache-" + cluster + "-" + key
+	}
+	return filepath.Join(cache, cluster, key)
+}
+
+// imageCacheConfig renders the k3d `volumes:` block mounting each node's
+// cache over its containerd directory. It returns "" without a cache.
+func imageCacheConfig(cluster string, servers, agents int, cache string) string {
+	if cache == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nvolumes:")
+	for _, node := range imageCacheNodes(servers, agents) {
+		fmt.Fprintf(&b, "\n  - volume: %q\n    nodeFilters:\n      - %s", imageCacheSource(cluster, node, cache)+":"+containerdDir, node)
+	}
+	return b.String()
+}
+
+// prepareImageCache creates what imageCacheConfig mounts: k3d only mounts
+// named volumes that exist, and a directory created by Docker would be owned
+// by root.
+func (m *K3dManager) prepareImageCache(ctx context.Context, cluster string, servers, agents int, cache string) error {
+	for _, node := range imageCacheNodes(servers, agents) {
+		source := imageCacheSource(cluster, node, cache)
+		if cache != models.ImageCacheVolume {
+			if err := os.MkdirAll(source, 0o750); err != nil {
+				return fmt.Errorf("creating image cache directory: %w", err)
+			}
+			continue
+		}
+		// Creating a volume that exists is a no-op, which is what makes the
+		// cache survive a recreate.
+		if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "docker",
+			Args:    []string{"volume", "create", "--label", imageCacheLabel + "=" + cluster, source},
+			Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+		}); err != nil {
+			return fmt.Errorf("creating image cache volume %s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+// ListImageCaches lists the image cache volumes and whether their cluster
+// still exists. Host directory caches are not tracked.
+func (m *K3dManager) ListImageCaches(ctx context.Context) ([]models.ImageCache, error) {
+	clusters, err := m.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]bool{}
+	for _, c := range clusters {
+		live[c.Name] = true
+	}
+	volumes, err := m.dockerList(ctx, "volume", "ls", "--filter", "label="+imageCacheLabel, "--format", `{{.Name}}\t{{.Label "`+imageCacheLabel+`"}}`)
+	if err != nil {
+		return nil, err
+	}
+	caches := make([]models.ImageCache, 0, len(volumes))
+	for _, v := range volumes {
+		caches = append(caches, models.ImageCache{Volume: v[0], Cluster: v[1], Live: live[v[1]]})
+	}
+	return caches, nil
+}
+
+// RemoveImageCaches deletes the given cache volumes and returns how many went.
+// Docker refuses to remove one still mounted by a node, so the cache of a
+// live cluster survives; the error names those that could not be removed.
+func (m *K3dManager) RemoveImageCaches(ctx context.Context, caches []models.ImageCache) (int, error) {
+	removed := 0
+	var failed []string
+	for _, c := range caches {
+		if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "docker",
+			Args:    []string{"volume", "rm", c.Volume},
+			Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+		}); err != nil {
+			failed = append(failed, c.Volume)
+			continue
+		}
+		removed++
+	}
+	if len(failed) > 0 {
+		return removed, fmt.Errorf("could not remove: %s", strings.Join(failed, ", "))
+	}
+	return removed, nil
+}
@@ -0,0 +1,65 @@
+package k3d
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageCacheConfig(t *testing.T) {
+	assert.Empty(t, imageCacheConfig("dev", 1, 1, ""))
+
+	assert.Equal(t, `
+volumes:
+  - volume: "openframe-image-cache-dev-server-0:/var/lib/rancher/k3s/agent/containerd"
+    nodeFilters:
+      - server:0
+  - volume: "openframe-image-cache-dev-agent-0:/var/lib/rancher/k3s/agent/containerd"
+    nodeFilters:
+      - agent:0`, imageCacheConfig("dev", 1, 1, models.ImageCacheVolume))
+
+	assert.Contains(t, imageCacheConfig("dev", 1, 0, "/cache"), `"/cache/dev/server-0:/var/lib/rancher/k3s/agent/containerd"`)
+}
+
+func TestPrepareImageCache(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	require.NoError(t, NewK3dManager(mock, false).prepareImageCache(context.Background(), "dev", 1, 2, models.ImageCacheVolume))
+	assert.Equal(t, []string{
+		"docker volume create --label openframe.image-cache=dev openframe-image-cache-dev-server-0",
+		"docker volume create --label openframe.image-cache=dev openframe-image-cache-dev-agent-0",
+		"docker volume create --label openframe.image-cache=dev openframe-image-cache-dev-agent-1",
+	}, mock.GetExecutedCommands())
+
+	dir := t.TempDir()
+	mock.Reset()
+	require.NoError(t, NewK3dManager(mock, false).prepareImageCache(context.Background(), "dev", 1, 1, dir))
+	assert.Empty(t, mock.GetExecutedCommands(), "a host directory needs no volumes")
+	assert.DirExists(t, filepath.Join(dir, "dev", "agent-0"))
+}
+
+func TestListImageCaches(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("cluster list", &executor.CommandResult{Stdout: `[{"name":"dev","serversCount":1,"serversRunning":1}]`})
+	mock.SetResponse("volume ls", &executor.CommandResult{Stdout: "openframe-image-cache-dev-server-0\tdev\nopenframe-image-cache-gone-server-0\tgone\n"})
+
+	got, err := NewK3dManager(mock, false).ListImageCaches(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImageCache{
+		{Volume: "openframe-image-cache-dev-server-0", Cluster: "dev", Live: true},
+		{Volume: "openframe-image-cache-gone-server-0", Cluster: "gone"},
+	}, got)
+}
+
+func TestCABundleVolume(t *testing.T) {
+	assert.Empty(t, caBundleVolume(""))
+	assert.Equal(t, `
+  - volume: "/etc/proxy-ca.pem:/etc/ssl/certs/openframe-ca-bundle.pem:ro"
+    nodeFilters:
+      - server:*
+      - agent:*`, caBundleVolume("/etc/proxy-ca.pem"))
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +21,7 @@ func TestInotify_DarwinIsSkippedEntirely(t *testing.T) {
 	mock := executor.NewMockCommandExecutor()
 	m := NewK3dManager(mock, false)
 
-	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "darwin"))
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "darwin", false))
 	assert.Zero(t, mock.GetCommandCount(), "macOS has no inotify sysctls; nothing may run (the old code ran `sudo sysctl` and prompted for a password)")
 }
 
@@ -28,7 +30,7 @@ func TestInotify_SufficientLimitsSkipSudo(t *testing.T) {
 	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "999999\n", Duration: time.Millisecond})
 	m := NewK3dManager(mock, false)
 
-	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux"))
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux", false))
 	for _, rc := range mock.Commands() {
 		assert.NotEqualf(t, "sudo", rc.Name, "no privilege escalation when limits already suffice: %v", rc)
 	}
@@ -38,8 +40,9 @@ func TestInotify_LowLimitsEscalateWithSudoN(t *testing.T) {
 	mock := executor.NewMockCommandExecutor()
 	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "8192\n", Duration: time.Millisecond})
 	m := NewK3dManager(mock, false)
+	m.privilege = privilege.Assume(false, "") // non-root with passwordless sudo
 
-	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux"))
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux", false))
 
 	var sawSudo bool
 	for _, rc := range mock.Commands() {
@@ -58,19 +61,66 @@ func TestInotify_SudoFailureIsActionableNotAPrompt(t *testing.T) {
 	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "8192\n", Duration: time.Millisecond})
 	mock.SetResponse("sudo -n sysctl", &executor.CommandResult{ExitCode: 1, Stderr: "sudo: a password is required", Duration: time.Millisecond})
 	m := NewK3dManager(mock, false)
+	m.privilege = privilege.Assume(false, "")
 
-	err := m.increaseInotifyLimitsFor(context.Background(), "linux")
+	err := m.increaseInotifyLimitsFor(context.Background(), "linux", false)
 	require.Error(t, err, "missing passwordless sudo surfaces as an error (downgraded to a warning by the caller)")
 	assert.Contains(t, err.Error(), "sudo sysctl -w", "error must carry the manual command since we refused to prompt")
 }
 
+func TestInotify_NoSudoReportsManualCommand(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "8192\n", Duration: time.Millisecond})
+	m := NewK3dManager(mock, false)
+	m.privilege = privilege.Assume(false, "--no-sudo (or OPENFRAME_NO_SUDO) is set")
+
+	err := m.increaseInotifyLimitsFor(context.Background(), "linux", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sudo sysctl -w fs.inotify.max_user_watches=524288")
+	for _, rc := range mock.Commands() {
+		assert.NotEqualf(t, "sudo", rc.Name, "--no-sudo must never run sudo: %v", rc)
+	}
+}
+
+func TestInotify_RootSkipsSudo(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "8192\n", Duration: time.Millisecond})
+	m := NewK3dManager(mock, false)
+	m.privilege = privilege.Assume(true, "")
+
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux", false))
+	assert.True(t, mock.WasCommandExecuted("sysctl -w"))
+	for _, rc := range mock.Commands() {
+		assert.NotEqualf(t, "sudo", rc.Name, "root needs no sudo: %v", rc)
+	}
+}
+
 func TestInotify_WindowsWSLUsesSudoN(t *testing.T) {
 	mock := executor.NewMockCommandExecutor()
 	m := NewK3dManager(mock, false)
 
-	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "windows"))
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "windows", false))
 	cmds := mock.Commands()
 	require.Len(t, cmds, 1)
 	assert.Equal(t, "wsl", cmds[0].Name)
-	assert.Truef(t, strings.Contains(cmds[0].String(), "sudo -n sysctl"), "WSL branch must also be prompt-free: %s", cmds[0])
+	assert.Truef(t, strings.Contains(string(cmds[0].Stdin), "sudo -n sysctl"), "WSL branch must also be prompt-free: %s", cmds[0].Stdin)
+}
+
+func TestInotify_PersistWritesSysctlD(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("sysctl -n", &executor.CommandResult{ExitCode: 0, Stdout: "999999\n", Duration: time.Millisecond})
+	m := NewK3dManager(mock, false)
+	m.privilege = privilege.Assume(true, "")
+
+	require.NoError(t, m.increaseInotifyLimitsFor(context.Background(), "linux", true))
+	var tee *executor.RecordedCommand
+	for _, rc := range mock.Commands() {
+		if rc.Name == "tee" {
+			tee = &rc
+		}
+	}
+	require.NotNil(t, tee, "persist must write the sysctl.d file even when the running limits already suffice")
+	assert.Equal(t, []string{sysctl.PersistFile}, tee.Args)
+	assert.Contains(t, string(tee.Stdin), "fs.inotify.max_user_watches=524288\n")
+	assert.Contains(t, string(tee.Stdin), "fs.inotify.max_user_instances=512\n")
 }
@@ -0,0 +1,60 @@
+package k3d
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+)
+
+// defaultK3sArgs are passed to k3s unless the user overrides them: kubelet
+// eviction is off so a laptop low on disk or memory does not evict the stack
+// mid-install. Whether traefik runs is up to --ingress (ingressK3sArgs).
+var defaultK3sArgs = []models.K3sArg{
+	{Arg: "--kubelet-arg=eviction-hard=", NodeFilters: []string{"all"}},
+	{Arg: "--kubelet-arg=eviction-soft=", NodeFilters: []string{"all"}},
+}
+
+// k3sExtraArgs merges the user's arguments into the defaults: a user argument
+// replaces every default with the same key, and one ending in "=" only
+// removes it. The user's arguments are otherwise kept as given, repeats
+// included (k3s accepts --disable more than once, and merges the values).
+func k3sExtraArgs(user []models.K3sArg) []models.K3sArg {
+	overridden := map[string]bool{}
+	for _, arg := range user {
+		overridden[arg.Key()] = true
+	}
+	var args []models.K3sArg
+	for _, arg := range defaultK3sArgs {
+		if !overridden[arg.Key()] {
+			args = append(args, arg)
+		}
+	}
+	for _, arg := range user {
+		if !arg.Removes() {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// ingressK3sArgs are the arguments an --ingress choice adds: every choice but
+// traefik disables the traefik k3s bundles, since the chosen ingress (or the
+// stack's own) claims the same ports. A user's --disable values are added to
+// this one, so they cannot bring traefik back beside it.
+func ingressK3sArgs(ingress models.Ingress) []models.K3sArg {
+	if ingress == models.IngressTraefik {
+		return nil
+	}
+	return []models.K3sArg{{Arg: "--disable=traefik", NodeFilters: []string{"server:*"}}}
+}
+
+// k3sArgsConfig renders args as options.k3s.extraArgs entries.
+func k3sArgsConfig(args []models.K3sArg) string {
+	var b strings.Builder
+	for _, arg := range args {
+		fmt.Fprintf(&b, "\n      - arg: %q\n        nodeFilters:", arg.Arg)
+		writeNodeFilters(&b, arg.NodeFilters)
+	}
+	return b.String()
+}
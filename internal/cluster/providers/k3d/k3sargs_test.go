@@ -0,0 +1,57 @@
+package k3d
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestK3sExtraArgs_DefaultsWithoutUserArgs(t *testing.T) {
+	assert.Equal(t, defaultK3sArgs, k3sExtraArgs(nil))
+}
+
+func TestK3sExtraArgs_UserArgsOverrideDefaults(t *testing.T) {
+	args := k3sExtraArgs([]models.K3sArg{
+		{Arg: "--kubelet-arg=eviction-hard=memory.available<100Mi", NodeFilters: []string{"all"}},
+		{Arg: "--kube-proxy-arg=proxy-mode=ipvs", NodeFilters: []string{"server:*"}},
+	})
+	assert.Equal(t, []models.K3sArg{
+		{Arg: "--kubelet-arg=eviction-soft=", NodeFilters: []string{"all"}},
+		{Arg: "--kubelet-arg=eviction-hard=memory.available<100Mi", NodeFilters: []string{"all"}},
+		{Arg: "--kube-proxy-arg=proxy-mode=ipvs", NodeFilters: []string{"server:*"}},
+	}, args, "only eviction-hard is replaced")
+}
+
+func TestK3sArgsConfig_RendersIntoK3sOptions(t *testing.T) {
+	doc := "options:\n  k3s:\n    extraArgs:" + k3sArgsConfig(k3sExtraArgs([]models.K3sArg{
+		{Arg: "--disable=servicelb", NodeFilters: []string{"server:0"}},
+	}))
+
+	var parsed k3sOptionsFixture
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &parsed))
+
+	args := parsed.Options.K3s.ExtraArgs
+	require.Len(t, args, 3)
+	assert.Equal(t, "--kubelet-arg=eviction-hard=", args[0].Arg)
+	assert.Equal(t, "--disable=servicelb", args[2].Arg)
+	assert.Equal(t, []string{"server:0"}, args[2].NodeFilters)
+}
+
+func TestIngressK3sArgs_TraefikKeepsTraefik(t *testing.T) {
+	assert.Equal(t, defaultK3sArgs, k3sExtraArgs(ingressK3sArgs(models.IngressTraefik)), "only the eviction defaults")
+
+	for _, ingress := range []models.Ingress{models.IngressNginx, models.IngressNone} {
+		args := k3sExtraArgs(ingressK3sArgs(ingress))
+		assert.Contains(t, args, models.K3sArg{Arg: "--disable=traefik", NodeFilters: []string{"server:*"}}, "%s runs beside a disabled traefik", ingress)
+	}
+}
+
+func TestIngressK3sArgs_UserDisablesAreMerged(t *testing.T) {
+	user := []models.K3sArg{{Arg: "--disable=servicelb", NodeFilters: []string{"server:*"}}}
+	args := k3sExtraArgs(append(ingressK3sArgs(models.IngressNginx), user...))
+	assert.Contains(t, args, models.K3sArg{Arg: "--disable=traefik", NodeFilters: []string{"server:*"}}, "traefik stays off beside nginx")
+	assert.Contains(t, args, user[0])
+}
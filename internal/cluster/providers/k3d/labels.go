@@ -0,0 +1,53 @@
+package k3d
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// clusterLabelPrefix namespaces a cluster's --label labels among the
+// container labels of its nodes, which k3d and Docker fill with their own.
+const clusterLabelPrefix = "openframe.label/"
+
+// runtimeLabelsConfig renders the options.runtime.labels list (the
+// config-file form of `k3d --runtime-label key=value@all`) carrying the
+// cluster's labels on every node container, or "" without labels.
+func runtimeLabelsConfig(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString("\n    labels:")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n      - label: %q\n        nodeFilters:", clusterLabelPrefix+k+"="+labels[k])
+		writeNodeFilters(&b, nil)
+	}
+	return b.String()
+}
+
+// clusterLabels reads the cluster's labels back from its first server's
+// container labels; nil when it has none.
+func clusterLabels(c k3dClusterInfo) map[string]string {
+	for _, node := range c.Nodes {
+		if node.Role != "server" {
+			continue
+		}
+		var labels map[string]string
+		for k, v := range node.RuntimeLabels {
+			if key, ok := strings.CutPrefix(k, clusterLabelPrefix); ok {
+				if labels == nil {
+					labels = map[string]string{}
+				}
+				labels[key] = v
+			}
+		}
+		return labels
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package k3d
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRuntimeConfig_Labels(t *testing.T) {
+	var parsed struct {
+		Options struct {
+			Runtime struct {
+				GPURequest string `json:"gpuRequest"`
+				Labels     []struct {
+					Label       string   `json:"label"`
+					NodeFilters []string `json:"nodeFilters"`
+				} `json:"labels"`
+			} `json:"runtime"`
+		} `json:"options"`
+	}
+	doc := "options:" + runtimeConfig("all", map[string]string{"team": "payments", "example.com/env": "dev"})
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &parsed))
+
+	assert.Equal(t, "all", parsed.Options.Runtime.GPURequest, "one runtime section for GPUs and labels")
+	labels := parsed.Options.Runtime.Labels
+	require.Len(t, labels, 2)
+	assert.Equal(t, "openframe.label/example.com/env=dev", labels[0].Label, "sorted by key")
+	assert.Equal(t, "openframe.label/team=payments", labels[1].Label)
+	assert.Equal(t, []string{"all"}, labels[1].NodeFilters)
+}
+
+func TestListClusters_Labels(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: `[
+		{"name": "pay", "serversCount": 1, "nodes": [{"name": "k3d-pay-server-0", "role": "server",
+		 "runtimeLabels": {"k3d.cluster": "pay", "openframe.label/team": "payments"}}]},
+		{"name": "plain", "serversCount": 1, "nodes": [{"name": "k3d-plain-server-0", "role": "server",
+		 "runtimeLabels": {"k3d.cluster": "plain"}}]}
+	]`})
+	clusters, err := NewK3dManager(mock, false).ListClusters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, map[string]string{"team": "payments"}, clusters[0].Labels, "k3d's own labels are not the cluster's")
+	assert.Nil(t, clusters[1].Labels)
+}
@@ -2,16 +2,23 @@ package k3d
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
 	"k8s.io/client-go/rest"
 )
 
@@ -22,6 +29,13 @@ const (
 	timestampSuffixLen = 6
 )
 
+// DefaultKubernetesVersion is the Kubernetes version of clusters created
+// without --version, e.g. v1.31.5.
+func DefaultKubernetesVersion() string {
+	version, _, _ := strings.Cut(strings.TrimPrefix(defaultK3sImage, "rancher/k3s:"), "-")
+	return version
+}
+
 // ClusterManager interface for managing clusters
 type ClusterManager interface {
 	DetectClusterType(ctx context.Context, name string) (models.ClusterType, error)
@@ -31,23 +45,31 @@ type ClusterManager interface {
 
 // K3dManager manages K3D cluster operations
 type K3dManager struct {
-	executor executor.CommandExecutor
-	verbose  bool
-	timeout  string
+	executor  executor.CommandExecutor
+	privilege *privilege.Escalator
+	verbose   bool
+	timeout   string
 }
 
 // NewK3dManager creates a new K3D cluster manager with default timeout
 func NewK3dManager(exec executor.CommandExecutor, verbose bool) *K3dManager {
 	return &K3dManager{
-		executor: exec,
-		verbose:  verbose,
-		timeout:  defaultTimeout,
+		executor:  exec,
+		privilege: privilege.Default(),
+		verbose:   verbose,
+		timeout:   defaultTimeout,
 	}
 }
 
 // CreateCluster creates a new K3D cluster using config file approach
 // Returns the *rest.Config for the created cluster that can be used to interact with it
 func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterConfig) (*rest.Config, error) {
+	return m.createCluster(ctx, config, nil)
+}
+
+// createCluster creates the cluster config describes; rc, when set, is the
+// cluster it replaces (see RenameCluster).
+func (m *K3dManager) createCluster(ctx context.Context, config models.ClusterConfig, rc *recreation) (*rest.Config, error) {
 	if err := m.validateClusterConfig(config); err != nil {
 		return nil, err
 	}
@@ -56,17 +78,66 @@ func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterCon
 		return nil, models.NewProviderNotFoundError(config.Type)
 	}
 
+	// Resolve --version to a real rancher/k3s tag before anything is changed
+	// on the host: a typo used to surface as an image pull failure minutes
+	// into `k3d cluster create`. A recreated cluster keeps its image.
+	if rc == nil {
+		version, err := ResolveK3sVersion(ctx, config.K8sVersion)
+		if err != nil {
+			return nil, models.NewInvalidConfigError("version", config.K8sVersion, err.Error())
+		}
+		if version != config.K8sVersion && m.verbose {
+			fmt.Printf("Using k3s %s for Kubernetes version %s\n", version, config.K8sVersion)
+		}
+		config.K8sVersion = version
+	}
+
 	// Increase inotify limits for applications like MeshCentral that use many file watchers
 	// This must be done before cluster creation as it affects the Docker/WSL host
-	if err := m.increaseInotifyLimits(ctx); err != nil {
+	if err := m.increaseInotifyLimits(ctx, config.PersistSysctl); err != nil {
 		if m.verbose {
 			fmt.Printf("Warning: Could not increase inotify limits: %v\n", err)
 		}
 		// Don't fail - cluster might still work if limits are already sufficient
 	}
 
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
-	configFile, err := m.createK3dConfigFile(config)
+	if config.GPUs != "" {
+		if err := m.checkNvidiaRuntime(ctx); err != nil {
+			return nil, models.NewClusterOperationError("create", config.Name, err)
+		}
+	}
+
+	if config.ImageCache != "" {
+		if err := m.prepareImageCache(ctx, config.Name, 1, max(config.NodeCount-1, 0), config.ImageCache); err != nil {
+			return nil, models.NewClusterOperationError("create", config.Name, err)
+		}
+	}
+
+	var image, replaces string
+	if rc != nil {
+		image, replaces = rc.image, rc.from
+	} else {
+		var warning string
+		image, warning = k3sNodeImage(ctx, config.K8sVersion)
+		if warning != "" {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+	// Reserve the cluster's port slot: 6550/80/443 for the first cluster,
+	// the next free slot for every one created beside it.
+	ports, err := m.allocatePorts(ctx, config.Name, replaces)
+	if err != nil {
+		return nil, models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to find available ports: %w", err))
+	}
+	created := false
+	defer func() {
+		if !created {
+			m.releasePortsOf(config.Name)
+		}
+	}()
+	m.warnFirewall(ctx, ports)
+
+	configFile, err := m.createK3dConfigFile(config, image, ports, rc)
 	if err != nil {
 		return nil, models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to create config file: %w", err))
 	}
@@ -78,61 +149,63 @@ func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterCon
 		}
 	}
 
-	// Prepare kubeconfig directory before k3d operations (Windows/WSL and Linux CI)
-	if err := m.prepareKubeconfigDirectory(ctx); err != nil {
-		if m.verbose {
-			fmt.Printf("Warning: Could not prepare kubeconfig directory: %v\n", err)
-		}
-		// Don't fail - k3d will create it, but log the warning
-	}
+	// An isolated cluster never touches the default kubeconfig, so none of
+	// its preparation or permission fixes apply.
+	isolated := k8s.IsolationEnabled()
 
-	// Clean up any stale lock files that might prevent k3d from updating kubeconfig
-	if err := m.cleanupStaleLockFiles(ctx); err != nil {
-		if m.verbose {
-			fmt.Printf("Warning: Could not cleanup stale lock files: %v\n", err)
-		}
-		// Don't fail - this is not critical
+	if !isolated {
+		m.prepareDefaultKubeconfig(ctx)
 	}
 
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 	args := []string{
 		"cluster", "create",
 		"--config", configFile,
 		"--timeout", m.timeout,
-		"--kubeconfig-update-default", // Update default kubeconfig with new cluster context
-		"--kubeconfig-switch-context", // Automatically switch to new cluster context
+	}
+	if isolated {
+		args = append(args, "--kubeconfig-update-default=false", "--kubeconfig-switch-context=false")
+	} else {
+		args = append(args,
+			"--kubeconfig-update-default", // Update default kubeconfig with new cluster context
+			"--kubeconfig-switch-context", // Automatically switch to new cluster context
+		)
 	}
 	if m.verbose {
 		args = append(args, "--verbose")
 	}
 
-	if _, err := m.executor.Execute(ctx, "k3d", args...); err != nil {
+	if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command:  "k3d",
+		Args:     args,
+		Timeout:  sharedconfig.Timeout(sharedconfig.LongRunning),
+		OnOutput: m.outputRelay(ctx),
+	}); err != nil {
+		if clusterAlreadyExists(err) {
+			// Created by someone else since the service looked for it.
+			return nil, models.NewClusterOperationError("create", config.Name, models.NewClusterExistsError(config.Name))
+		}
 		return nil, models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to create cluster %s: %w", config.Name, err))
 	}
+	created = true
 
-	// Fix kubeconfig permissions if k3d ran with sudo (Windows/WSL and Linux CI)
-	// This is necessary because k3d creates ~/.kube/config with root ownership when run with sudo
-	if err := m.fixKubeconfigPermissions(ctx); err != nil {
-		if m.verbose {
-			fmt.Printf("Warning: Could not fix kubeconfig permissions: %v\n", err)
+	// k3d only announces the kubeconfig when it merges into the default one.
+	reportCreatePhase(ctx, models.PhaseKubeconfig)
+	if isolated {
+		if err := m.writeIsolatedKubeconfig(ctx, config.Name); err != nil {
+			return nil, models.NewClusterOperationError("create", config.Name, err)
 		}
-		// Don't fail - this is not critical, just log the warning
+	} else {
+		m.repairDefaultKubeconfig(ctx)
 	}
 
-	// Clean up any lock files after fixing permissions to ensure kubectl can access the config
-	// This is critical because lock files may have been created with root ownership
-	if err := m.cleanupStaleLockFiles(ctx); err != nil {
-		if m.verbose {
-			fmt.Printf("Warning: Could not cleanup lock files after permission fix: %v\n", err)
-		}
-		// Don't fail - this is not critical
-	}
-
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 	// Verify the cluster is reachable and get the rest.Config via the native
 	// client (client-go). This is the sole verification — the previous best-effort
 	// kubectl double-check was removed with the kubectl migration.
-	restConfig, err := m.verifyClusterReachable(ctx, config.Name)
+	if err := m.switchContext(config.Name); err != nil {
+		return nil, models.NewClusterOperationError("create", config.Name, err)
+	}
+	reportCreatePhase(ctx, models.PhaseWaitNodes)
+	restConfig, err := m.verifyClusterReachable(ctx, config.Name, config.WaitFor.RequiredReady(config.NodeCount))
 	if err != nil {
 		return nil, models.NewClusterOperationError("create", config.Name, fmt.Errorf("cluster created but not reachable: %w", err))
 	}
@@ -141,9 +214,10 @@ func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterCon
 }
 
 // GetRestConfig returns the rest.Config for an existing cluster
-// This is used to get the config for a cluster that was already created
+// This is used to get the config for a cluster that was already created.
+// It only reads the kubeconfig, and gives up when ctx is done.
 func (m *K3dManager) GetRestConfig(ctx context.Context, clusterName string) (*rest.Config, error) {
-	return m.verifyClusterReachable(ctx, clusterName)
+	return m.verifyClusterReachable(ctx, clusterName, 1)
 }
 
 // DeleteCluster removes a K3D cluster
@@ -166,18 +240,17 @@ func (m *K3dManager) DeleteCluster(ctx context.Context, name string, clusterType
 		args = append(args, "--verbose")
 	}
 
-	// Use a 2-minute timeout to prevent hanging on WSL networking issues
+	// Bounded so WSL networking issues cannot hang the delete
 	options := executor.ExecuteOptions{
 		Command: "k3d",
 		Args:    args,
-		Timeout: 2 * time.Minute,
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
 	}
 
 	_, err := m.executor.ExecuteWithOptions(ctx, options)
 	if err != nil {
 		// When force is set, fall back to direct Docker cleanup.
 		// This handles networking issues that can cause k3d to hang or fail.
-		// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 		if force {
 			if m.verbose {
 				fmt.Printf("k3d delete failed, attempting direct Docker cleanup for cluster %s: %v\n", name, err)
@@ -190,18 +263,95 @@ func (m *K3dManager) DeleteCluster(ctx context.Context, name string, clusterType
 			if m.verbose {
 				fmt.Printf("✓ Cluster %s removed via direct Docker cleanup\n", name)
 			}
+			m.removeIsolatedKubeconfig(name)
+			m.releasePortsOf(name)
 			return nil
 		}
 		return models.NewClusterOperationError("delete", name, fmt.Errorf("failed to delete cluster %s: %w", name, err))
 	}
 
+	m.removeIsolatedKubeconfig(name)
+	m.releasePortsOf(name)
 	return nil
 }
 
+// releasePortsOf frees the cluster's port slot. A failure is only logged: a
+// later create drops the record once its reservation expires.
+func (m *K3dManager) releasePortsOf(name string) {
+	if err := releasePorts(name); err != nil && m.verbose {
+		fmt.Printf("Warning: could not release the ports of cluster %s: %v\n", name, err)
+	}
+}
+
+// prepareDefaultKubeconfig gets ~/.kube ready for k3d to merge the new
+// cluster into. Failures are only logged: k3d creates what is missing.
+func (m *K3dManager) prepareDefaultKubeconfig(ctx context.Context) {
+	// Prepare kubeconfig directory before k3d operations (Windows/WSL and Linux CI)
+	if err := m.prepareKubeconfigDirectory(ctx); err != nil {
+		if m.verbose {
+			fmt.Printf("Warning: Could not prepare kubeconfig directory: %v\n", err)
+		}
+		// Don't fail - k3d will create it, but log the warning
+	}
+
+	// Clean up any stale lock files that might prevent k3d from updating kubeconfig
+	if err := m.cleanupStaleLockFiles(ctx); err != nil {
+		if m.verbose {
+			fmt.Printf("Warning: Could not cleanup stale lock files: %v\n", err)
+		}
+		// Don't fail - this is not critical
+	}
+}
+
+// repairDefaultKubeconfig undoes what a privileged k3d leaves behind in
+// ~/.kube. Failures are only logged.
+func (m *K3dManager) repairDefaultKubeconfig(ctx context.Context) {
+	// Fix kubeconfig permissions if k3d ran with sudo (Windows/WSL and Linux CI)
+	// This is necessary because k3d creates ~/.kube/config with root ownership when run with sudo
+	if err := m.fixKubeconfigPermissions(ctx); err != nil {
+		if m.verbose {
+			fmt.Printf("Warning: Could not fix kubeconfig permissions: %v\n", err)
+		}
+		// Don't fail - this is not critical, just log the warning
+	}
+
+	// Clean up any lock files after fixing permissions to ensure kubectl can access the config
+	// This is critical because lock files may have been created with root ownership
+	if err := m.cleanupStaleLockFiles(ctx); err != nil {
+		if m.verbose {
+			fmt.Printf("Warning: Could not cleanup lock files after permission fix: %v\n", err)
+		}
+		// Don't fail - this is not critical
+	}
+}
+
+// writeIsolatedKubeconfig saves the kubeconfig k3d generated for name to the
+// cluster's own file under ~/.openframe/kubeconfigs.
+func (m *K3dManager) writeIsolatedKubeconfig(ctx context.Context, name string) error {
+	data, err := m.GetKubeconfig(ctx, name, models.ClusterTypeK3d)
+	if err != nil {
+		return err
+	}
+	path, err := k8s.WriteIsolatedKubeconfig(name, []byte(data))
+	if err != nil {
+		return err
+	}
+	if m.verbose {
+		fmt.Printf("✓ Kubeconfig for %s written to %s\n", name, path)
+	}
+	return nil
+}
+
+// removeIsolatedKubeconfig drops name's isolated kubeconfig, if any, once the
+// cluster is gone.
+func (m *K3dManager) removeIsolatedKubeconfig(name string) {
+	if err := k8s.RemoveIsolatedKubeconfig(name); err != nil && m.verbose {
+		fmt.Printf("Warning: Could not remove isolated kubeconfig for %s: %v\n", name, err)
+	}
+}
+
 // forceCleanupDockerContainers removes all Docker containers associated with a k3d cluster
 // This is a fallback mechanism when k3d cluster delete fails.
-//
-// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 func (m *K3dManager) forceCleanupDockerContainers(ctx context.Context, clusterName string) error {
 	// Defense in depth: the cluster name is interpolated into Docker arguments
 	// here. Callers validate, but re-check so a future caller cannot introduce
@@ -254,34 +404,51 @@ func (m *K3dManager) StartCluster(ctx context.Context, name string, clusterType
 		args = append(args, "--verbose")
 	}
 
-	if _, err := m.executor.Execute(ctx, "k3d", args...); err != nil {
+	if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "k3d",
+		Args:    args,
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+	}); err != nil {
 		return models.NewClusterOperationError("start", name, fmt.Errorf("failed to start cluster %s: %w", name, err))
 	}
 
 	return nil
 }
 
-// ListClusters returns all K3D clusters
-func (m *K3dManager) ListClusters(ctx context.Context) ([]models.ClusterInfo, error) {
-	args := []string{"cluster", "list", "--output", "json"}
+// StopCluster stops a K3D cluster, keeping its containers and volumes so that
+// StartCluster brings it back with all state.
+func (m *K3dManager) StopCluster(ctx context.Context, name string, clusterType models.ClusterType) error {
+	if name == "" {
+		return models.NewInvalidConfigError("name", name, "cluster name cannot be empty")
+	}
 
-	// Use a 30-second timeout to prevent hanging on WSL networking issues
-	options := executor.ExecuteOptions{
+	if clusterType != models.ClusterTypeK3d {
+		return models.NewProviderNotFoundError(clusterType)
+	}
+
+	args := []string{"cluster", "stop", name}
+	if m.verbose {
+		args = append(args, "--verbose")
+	}
+
+	if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
 		Command: "k3d",
 		Args:    args,
-		Timeout: 30 * time.Second,
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+	}); err != nil {
+		return models.NewClusterOperationError("stop", name, fmt.Errorf("failed to stop cluster %s: %w", name, err))
 	}
 
-	result, err := m.executor.ExecuteWithOptions(ctx, options)
+	return nil
+}
+
+// ListClusters returns all K3D clusters
+func (m *K3dManager) ListClusters(ctx context.Context) ([]models.ClusterInfo, error) {
+	k3dClusters, err := m.listK3dClusters(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list clusters: %w", err)
 	}
 
-	var k3dClusters []k3dClusterInfo
-	if err := json.Unmarshal([]byte(result.Stdout), &k3dClusters); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster list JSON: %w", err)
-	}
-
 	var clusters []models.ClusterInfo
 	for _, k3dCluster := range k3dClusters {
 		// Find the earliest server node creation time as cluster creation time
@@ -301,14 +468,63 @@ func (m *K3dManager) ListClusters(ctx context.Context) ([]models.ClusterInfo, er
 			ReadyServers: k3dCluster.ServersRunning,
 			TotalServers: k3dCluster.ServersCount,
 			NodeCount:    k3dCluster.AgentsCount + k3dCluster.ServersCount,
+			K8sVersion:   models.KubernetesVersionFromImage(clusterImage(k3dCluster)),
 			CreatedAt:    createdAt,
-			Nodes:        []models.NodeInfo{},
+			Nodes:        nodeInfos(k3dCluster),
+			Ports:        clusterPorts(k3dCluster),
+			Labels:       clusterLabels(k3dCluster),
 		})
 	}
 
 	return clusters, nil
 }
 
+// clusterImage is the k3s image the cluster runs: its servers', else the one
+// k3d reports for the cluster.
+func clusterImage(c k3dClusterInfo) string {
+	for _, node := range c.Nodes {
+		if node.Role == "server" && node.Image != "" {
+			return node.Image
+		}
+	}
+	return c.Image
+}
+
+// nodeRoles orders a cluster's nodes: servers, agents, then k3d's helpers.
+var nodeRoles = map[string]int{"server": 0, "agent": 1, "loadbalancer": 2}
+
+// nodeInfos returns the cluster's nodes as k3d reports them, servers first.
+func nodeInfos(c k3dClusterInfo) []models.NodeInfo {
+	nodes := make([]models.NodeInfo, 0, len(c.Nodes))
+	for _, n := range c.Nodes {
+		status := n.State.Status
+		if status == "" && n.State.Running {
+			status = "running"
+		}
+		nodes = append(nodes, models.NodeInfo{
+			Name:      n.Name,
+			Status:    status,
+			Role:      n.Role,
+			Image:     n.Image,
+			IP:        n.IP.IP,
+			CreatedAt: n.Created,
+		})
+	}
+	rank := func(role string) int {
+		if r, ok := nodeRoles[role]; ok {
+			return r
+		}
+		return len(nodeRoles)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if ri, rj := rank(nodes[i].Role), rank(nodes[j].Role); ri != rj {
+			return ri < rj
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
 // ListAllClusters is an alias for ListClusters for backward compatibility
 func (m *K3dManager) ListAllClusters(ctx context.Context) ([]models.ClusterInfo, error) {
 	return m.ListClusters(ctx)
@@ -342,11 +558,11 @@ func (m *K3dManager) DetectClusterType(ctx context.Context, name string) (models
 
 	args := []string{"cluster", "get", name}
 
-	// Use a 30-second timeout to prevent hanging on WSL networking issues
+	// Bounded so WSL networking issues cannot hang the lookup
 	options := executor.ExecuteOptions{
 		Command: "k3d",
 		Args:    args,
-		Timeout: 30 * time.Second,
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
 	}
 
 	if _, err := m.executor.ExecuteWithOptions(ctx, options); err != nil {
@@ -363,7 +579,11 @@ func (m *K3dManager) GetKubeconfig(ctx context.Context, name string, clusterType
 	}
 
 	args := []string{"kubeconfig", "get", name}
-	result, err := m.executor.Execute(ctx, "k3d", args...)
+	result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "k3d",
+		Args:    args,
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get kubeconfig for cluster %s: %w", name, err)
 	}
@@ -385,15 +605,8 @@ func (m *K3dManager) validateClusterConfig(config models.ClusterConfig) error {
 	return nil
 }
 
-// createK3dConfigFile creates a k3d config file
-func (m *K3dManager) createK3dConfigFile(config models.ClusterConfig) (string, error) {
-	image := defaultK3sImage
-	if runtime.GOARCH == "arm64" {
-		image = defaultK3sImage
-	}
-	if config.K8sVersion != "" {
-		image = "rancher/k3s:" + config.K8sVersion
-	}
+// createK3dConfigFile creates a k3d config file running image on every node
+func (m *K3dManager) createK3dConfigFile(config models.ClusterConfig, image string, ports PortConfig, rc *recreation) (string, error) {
 
 	servers := 1
 	agents := config.NodeCount - 1
@@ -409,17 +622,12 @@ servers: %d
 agents: %d
 image: %s`, config.Name, servers, agents, image)
 
-	// Find available ports, preferring standard ports (80, 443) with fallback to high ports
-	ports, err := m.findAvailablePorts()
-	if err != nil {
-		return "", fmt.Errorf("failed to find available ports: %w", err)
-	}
 	apiPort := strconv.Itoa(ports.API)
 	httpPort := strconv.Itoa(ports.HTTP)
 	httpsPort := strconv.Itoa(ports.HTTPS)
 
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher),
-	// so the API always binds to the loopback address.
+	// The API binds to the loopback address in WSL and in Docker Desktop
+	// native mode alike: the CLI reaches it from the same host either way.
 	hostIP := "127.0.0.1"
 
 	configContent += fmt.Sprintf(`
@@ -429,23 +637,36 @@ kubeAPI:
   hostPort: "%s"
 options:
   k3s:
-    extraArgs:
-      - arg: --disable=traefik
-        nodeFilters:
-          - server:*
-      - arg: --kubelet-arg=eviction-hard=
-        nodeFilters:
-          - all
-      - arg: --kubelet-arg=eviction-soft=
-        nodeFilters:
-          - all
+    extraArgs:%s%s%s
 ports:
   - port: %s:80
     nodeFilters:
       - loadbalancer
   - port: %s:443
     nodeFilters:
-      - loadbalancer`, hostIP, hostIP, apiPort, httpPort, httpsPort)
+      - loadbalancer`, hostIP, hostIP, apiPort,
+		k3sArgsConfig(k3sExtraArgs(append(ingressK3sArgs(config.Ingress), config.K3sArgs...)))+nodeTaintArgs(config.NodeTaints)+gpuRuntimeArgs(config.GPUs),
+		nodeLabelsConfig(config.NodeLabels),
+		runtimeConfig(config.GPUs, config.Labels),
+		httpPort, httpsPort)
+
+	// Registry mirrors apply on every platform: k3d materializes them as
+	// registries.yaml inside each node container.
+	configContent += registriesConfig(config.RegistryMirrors)
+	volumes := imageCacheConfig(config.Name, servers, agents, config.ImageCache)
+	extra := caBundleVolume(sharedconfig.CABundle())
+	if rc != nil {
+		token, data := rc.config()
+		configContent += token
+		extra += data
+	}
+	if extra != "" {
+		if volumes == "" {
+			volumes = "\nvolumes:"
+		}
+		volumes += extra
+	}
+	configContent += volumes
 
 	tmpFile, err := os.CreateTemp("", "k3d-config-*.yaml")
 	if err != nil {
@@ -479,20 +700,37 @@ func CreateClusterManagerWithExecutor(exec executor.CommandExecutor) *K3dManager
 // - fs.inotify.max_user_watches: max number of file watches per user (default: 8192)
 // - fs.inotify.max_user_instances: max number of inotify instances per user (default: 128)
 //
+// sysctl -w only lasts until the next reboot (or WSL restart); with persist
+// the values are also written to sysctl.PersistFile.
+//
 // Best-effort by design, and it must NEVER prompt: sudo runs with -n
 // (non-interactive) so a box without passwordless sudo gets a skip + hint, not
 // a hidden password prompt on /dev/tty that stalls `bootstrap --non-interactive`
 // mid-spinner.
-func (m *K3dManager) increaseInotifyLimits(ctx context.Context) error {
-	return m.increaseInotifyLimitsFor(ctx, runtime.GOOS)
+func (m *K3dManager) increaseInotifyLimits(ctx context.Context, persist bool) error {
+	if platform.NativeWindows() {
+		return m.increaseInotifyLimitsDockerDesktop(ctx)
+	}
+	return m.increaseInotifyLimitsFor(ctx, runtime.GOOS, persist)
+}
+
+// increaseInotifyLimitsDockerDesktop raises the limits in Docker Desktop's own
+// VM (the docker-desktop WSL distro, where the node containers' kernel runs).
+// Commands there already run as root, so no sudo is involved. That VM is
+// managed by Docker Desktop and rebuilt on update, so nothing is persisted.
+func (m *K3dManager) increaseInotifyLimitsDockerDesktop(ctx context.Context) error {
+	args := append([]string{"-d", "docker-desktop", "sysctl", "-w"}, sysctl.Assignments(sysctl.Inotify)...)
+	_, err := m.executor.Execute(ctx, "wsl", args...)
+	if err != nil {
+		return fmt.Errorf("failed to set inotify limits in the Docker Desktop VM: %w", err)
+	}
+	return nil
 }
 
 // increaseInotifyLimitsFor is the goos-parameterized implementation (testable
 // off-Linux).
-func (m *K3dManager) increaseInotifyLimitsFor(ctx context.Context, goos string) error {
-	// Desired limits - these are common recommended values for development environments
-	const maxUserWatches = 524288
-	const maxUserInstances = 512
+func (m *K3dManager) increaseInotifyLimitsFor(ctx context.Context, goos string, persist bool) error {
+	assignments := sysctl.Assignments(sysctl.Inotify)
 
 	switch goos {
 	case "darwin":
@@ -502,65 +740,106 @@ func (m *K3dManager) increaseInotifyLimitsFor(ctx context.Context, goos string)
 	case "windows":
 		// On Windows, the limits need to be set inside WSL2 where Docker runs.
 		// Reached only with WSL forwarding disabled; keep it prompt-free too.
-		sysctlCmd := fmt.Sprintf(
-			"sudo -n sysctl -w fs.inotify.max_user_watches=%d fs.inotify.max_user_instances=%d 2>/dev/null || true",
-			maxUserWatches, maxUserInstances,
-		)
+		data := scripts.InotifyData{Assignments: assignments}
+		if persist {
+			data.PersistFile, data.PersistContent = sysctl.PersistFile, sysctl.PersistContent(sysctl.Inotify)
+		}
+		sysctlCmd := scripts.MustRender(scripts.InotifyWSL, data)
 
-		_, err := m.executor.Execute(ctx, "wsl", "-d", "Ubuntu", "bash", "-c", sysctlCmd)
+		_, err := m.executor.ExecuteWithOptions(ctx, executor.WSLShellScript(wslpath.DistroArgs(), sysctlCmd))
 		if err != nil {
 			return fmt.Errorf("failed to set inotify limits in WSL: %w", err)
 		}
 
 		if m.verbose {
-			fmt.Printf("✓ Increased inotify limits in WSL (max_user_watches=%d, max_user_instances=%d)\n",
-				maxUserWatches, maxUserInstances)
+			fmt.Printf("✓ Increased inotify limits in WSL (%s)\n", strings.Join(assignments, ", "))
 		}
 	default: // linux
 		// Skip the privileged write when the current limits already suffice.
-		if m.inotifyLimitsSufficient(ctx, maxUserWatches, maxUserInstances) {
+		if m.inotifyLimitsSufficient(ctx) {
 			if m.verbose {
 				fmt.Println("✓ inotify limits already sufficient")
 			}
-			return nil
-		}
+		} else {
+			// The escalator runs `sudo -n` (never a hidden prompt), or reports
+			// why root is out of reach (--no-sudo, non-interactive, no sudo).
+			argv, err := m.privilege.Command(ctx, "sysctl", append([]string{"-w"}, assignments...)...)
+			if err != nil {
+				return fmt.Errorf("could not raise inotify limits: %w", err)
+			}
+			if _, err := m.executor.Execute(ctx, argv[0], argv[1:]...); err != nil {
+				// Best-effort: the caller downgrades this to a warning. Give the
+				// manual command since we deliberately refused to prompt for sudo.
+				return fmt.Errorf("could not raise inotify limits without prompting for sudo; run manually: sudo sysctl -w %s: %w",
+					strings.Join(assignments, " "), err)
+			}
+			// sysctl -w can succeed without effect (e.g. a read-only /proc/sys
+			// in a container), so read the values back.
+			m.verifyInotifyLimits(ctx)
 
-		// sudo -n: fail instead of prompting when passwordless sudo is missing.
-		_, err := m.executor.Execute(ctx, "sudo", "-n", "sysctl", "-w",
-			fmt.Sprintf("fs.inotify.max_user_watches=%d", maxUserWatches),
-			fmt.Sprintf("fs.inotify.max_user_instances=%d", maxUserInstances),
-		)
-		if err != nil {
-			// Best-effort: the caller downgrades this to a warning. Give the
-			// manual command since we deliberately refused to prompt for sudo.
-			return fmt.Errorf("could not raise inotify limits without prompting for sudo; run manually: sudo sysctl -w fs.inotify.max_user_watches=%d fs.inotify.max_user_instances=%d: %w",
-				maxUserWatches, maxUserInstances, err)
+			if m.verbose {
+				fmt.Printf("✓ Increased inotify limits (%s)\n", strings.Join(assignments, ", "))
+			}
 		}
-
-		if m.verbose {
-			fmt.Printf("✓ Increased inotify limits (max_user_watches=%d, max_user_instances=%d)\n",
-				maxUserWatches, maxUserInstances)
+		if persist {
+			return m.persistInotifyLimits(ctx)
 		}
 	}
 
 	return nil
 }
 
-// inotifyLimitsSufficient reports whether both current inotify limits already
-// meet the wanted values (reading them needs no privileges).
-func (m *K3dManager) inotifyLimitsSufficient(ctx context.Context, wantWatches, wantInstances int) bool {
-	for key, want := range map[string]int{
-		"fs.inotify.max_user_watches":   wantWatches,
-		"fs.inotify.max_user_instances": wantInstances,
-	} {
-		result, err := m.executor.Execute(ctx, "sysctl", "-n", key)
+// verifyInotifyLimits warns about every limit still below the wanted value.
+func (m *K3dManager) verifyInotifyLimits(ctx context.Context) {
+	for _, st := range sysctl.Low(sysctl.Report(ctx, sysctl.ExecReader(m.executor), sysctl.PersistFile, sysctl.Inotify)) {
+		if st.Current < 0 {
+			fmt.Printf("Warning: Could not read %s back after setting it\n", st.Key)
+			continue
+		}
+		fmt.Printf("Warning: %s is still %d after setting it to %d; the kernel did not accept the new value\n", st.Key, st.Current, st.Want)
+	}
+}
+
+// persistInotifyLimits writes the limits to sysctl.PersistFile so they survive
+// a reboot. A file that already has them is left alone.
+func (m *K3dManager) persistInotifyLimits(ctx context.Context) error {
+	durable := true
+	for _, st := range sysctl.Report(ctx, sysctl.ExecReader(m.executor), sysctl.PersistFile, sysctl.Inotify) {
+		durable = durable && st.Durable()
+	}
+	if !durable {
+		argv, err := m.privilege.Command(ctx, "tee", sysctl.PersistFile)
 		if err != nil {
-			return false
+			return fmt.Errorf("could not persist inotify limits: %w", err)
 		}
-		current, err := strconv.Atoi(strings.TrimSpace(result.Stdout))
-		if err != nil || current < want {
-			return false
+		if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: argv[0],
+			Args:    argv[1:],
+			Stdin:   []byte(sysctl.PersistContent(sysctl.Inotify)),
+		}); err != nil {
+			return fmt.Errorf("could not persist inotify limits; add %s to %s yourself: %w",
+				strings.Join(sysctl.Assignments(sysctl.Inotify), " and "), sysctl.PersistFile, err)
 		}
+		if m.verbose {
+			fmt.Printf("✓ Persisted inotify limits in %s\n", sysctl.PersistFile)
+		}
+	}
+	// WSL distros only run systemd-sysctl when systemd is enabled.
+	if sysctl.InsideWSL() && !sysctl.BootLoadsPersisted() {
+		fmt.Printf("Warning: %s is only loaded at boot with systemd; enable it in /etc/wsl.conf ([boot] systemd=true) or the limits reset when WSL restarts\n", sysctl.PersistFile)
 	}
-	return true
+	return nil
+}
+
+// inotifyLimitsSufficient reports whether every current inotify limit already
+// meets the wanted value (reading them needs no privileges).
+func (m *K3dManager) inotifyLimitsSufficient(ctx context.Context) bool {
+	return len(sysctl.Low(sysctl.Report(ctx, sysctl.ExecReader(m.executor), sysctl.PersistFile, sysctl.Inotify))) == 0
+}
+
+// clusterAlreadyExists reports a `k3d cluster create` refused because the
+// name is taken: "Failed to create cluster 'x' because a cluster with that
+// name already exists".
+func clusterAlreadyExists(err error) bool {
+	return strings.Contains(err.Error(), "a cluster with that name already exists")
 }
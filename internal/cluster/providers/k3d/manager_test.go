@@ -5,14 +5,18 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
 	"testing"
 
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 	execPkg "github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
 )
 
 // MockExecutor is a mock implementation of CommandExecutor for testing
@@ -83,6 +87,28 @@ func (m *MockExecutor) ExecuteWithOptions(ctx context.Context, options execPkg.E
 	return arguments.Get(0).(*execPkg.CommandResult), arguments.Error(1)
 }
 
+// isK3dCommand matches ExecuteWithOptions calls that run k3d.
+func isK3dCommand(options execPkg.ExecuteOptions) bool {
+	return options.Command == "k3d"
+}
+
+// isK3dClusterList matches `k3d cluster list`, which the port allocation reads.
+func isK3dClusterList(options execPkg.ExecuteOptions) bool {
+	return options.Command == "k3d" && len(options.Args) > 1 && options.Args[1] == "list"
+}
+
+// isShellScript matches the kubeconfig housekeeping scripts run with bash -s.
+func isShellScript(options execPkg.ExecuteOptions) bool {
+	return options.Command == "bash"
+}
+
+// isK3dArgs matches ExecuteWithOptions calls that run k3d with exactly args.
+func isK3dArgs(args ...string) func(execPkg.ExecuteOptions) bool {
+	return func(options execPkg.ExecuteOptions) bool {
+		return options.Command == "k3d" && reflect.DeepEqual(options.Args, args)
+	}
+}
+
 func TestNewK3dManager(t *testing.T) {
 	executor := &MockExecutor{}
 
@@ -139,14 +165,20 @@ func TestK3dManager_CreateCluster(t *testing.T) {
 			setupMock: func(m *MockExecutor) {
 				// Mock bash or wsl for kubeconfig directory prep and cleanup
 				// Using Maybe() to allow flexible number of calls as implementation may vary
-				m.On("Execute", mock.Anything, "bash", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isShellScript)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				// The Linux inotify pre-check reads current limits (sysctl -n) and only
 				// escalates via `sudo -n` when they are low; report them sufficient so no
 				// escalation happens. .Maybe(): on darwin the whole step is skipped.
 				m.On("Execute", mock.Anything, "sysctl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "999999"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "sudo", mock.Anything).Return(&execPkg.CommandResult{Stdout: ""}, nil).Maybe()
+				// The firewall check finds no firewall it can read.
+				m.On("Execute", mock.Anything, "firewall-cmd", mock.Anything).Return(nil, errors.New("not found")).Maybe()
+				m.On("Execute", mock.Anything, "iptables", mock.Anything).Return(nil, errors.New("permission denied")).Maybe()
 				m.On("Execute", mock.Anything, "wsl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				// `k3d cluster create` goes through ExecuteWithOptions so its output can stream.
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 			},
 		},
 		{
@@ -161,14 +193,20 @@ func TestK3dManager_CreateCluster(t *testing.T) {
 			setupMock: func(m *MockExecutor) {
 				// Mock bash or wsl for kubeconfig directory prep and cleanup
 				// Using Maybe() to allow flexible number of calls as implementation may vary
-				m.On("Execute", mock.Anything, "bash", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isShellScript)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				// The Linux inotify pre-check reads current limits (sysctl -n) and only
 				// escalates via `sudo -n` when they are low; report them sufficient so no
 				// escalation happens. .Maybe(): on darwin the whole step is skipped.
 				m.On("Execute", mock.Anything, "sysctl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "999999"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "sudo", mock.Anything).Return(&execPkg.CommandResult{Stdout: ""}, nil).Maybe()
+				// The firewall check finds no firewall it can read.
+				m.On("Execute", mock.Anything, "firewall-cmd", mock.Anything).Return(nil, errors.New("not found")).Maybe()
+				m.On("Execute", mock.Anything, "iptables", mock.Anything).Return(nil, errors.New("permission denied")).Maybe()
 				m.On("Execute", mock.Anything, "wsl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				// `k3d cluster create` goes through ExecuteWithOptions so its output can stream.
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 			},
 		},
 		{
@@ -207,21 +245,49 @@ func TestK3dManager_CreateCluster(t *testing.T) {
 			},
 			setupMock: func(m *MockExecutor) {
 				// Mock bash or wsl for kubeconfig directory prep and cleanup
-				m.On("Execute", mock.Anything, "bash", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isShellScript)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				// The Linux inotify pre-check reads current limits (sysctl -n) and only
 				// escalates via `sudo -n` when they are low; report them sufficient so no
 				// escalation happens. .Maybe(): on darwin the whole step is skipped.
 				m.On("Execute", mock.Anything, "sysctl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "999999"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "sudo", mock.Anything).Return(&execPkg.CommandResult{Stdout: ""}, nil).Maybe()
+				// The firewall check finds no firewall it can read.
+				m.On("Execute", mock.Anything, "firewall-cmd", mock.Anything).Return(nil, errors.New("not found")).Maybe()
+				m.On("Execute", mock.Anything, "iptables", mock.Anything).Return(nil, errors.New("permission denied")).Maybe()
 				m.On("Execute", mock.Anything, "wsl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(nil, errors.New("k3d error")).Maybe()
+				// `k3d cluster create` goes through ExecuteWithOptions so its output can stream.
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(nil, errors.New("k3d error")).Maybe()
 			},
 			expectedError: "failed to create cluster test-cluster",
 		},
+		{
+			name: "cluster already exists",
+			config: models.ClusterConfig{
+				Name:      "test-cluster",
+				Type:      models.ClusterTypeK3d,
+				NodeCount: 3,
+			},
+			setupMock: func(m *MockExecutor) {
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isShellScript)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("Execute", mock.Anything, "sysctl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "999999"}, nil).Maybe()
+				m.On("Execute", mock.Anything, "sudo", mock.Anything).Return(&execPkg.CommandResult{Stdout: ""}, nil).Maybe()
+				m.On("Execute", mock.Anything, "firewall-cmd", mock.Anything).Return(nil, errors.New("not found")).Maybe()
+				m.On("Execute", mock.Anything, "iptables", mock.Anything).Return(nil, errors.New("permission denied")).Maybe()
+				m.On("Execute", mock.Anything, "wsl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(nil,
+					execPkg.NewCommandError("k3d cluster create", 1, "FATA[0000] Failed to create cluster 'test-cluster' because a cluster with that name already exists")).Maybe()
+			},
+			expectedError: "cluster 'test-cluster' already exists",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			usePortsFile(t)
 			// Setup kubeconfig if needed for tests that verify cluster reachability
 			if tt.setupKubeconfig {
 				cleanup := setupTestKubeconfig(t, tt.config.Name)
@@ -254,20 +320,27 @@ func TestK3dManager_CreateCluster(t *testing.T) {
 }
 
 func TestK3dManager_CreateCluster_VerboseMode(t *testing.T) {
+	usePortsFile(t)
 	// Setup kubeconfig for the test
 	cleanup := setupTestKubeconfig(t, "test-cluster")
 	defer cleanup()
 
 	executor := &MockExecutor{}
 	// Mock bash or wsl for kubeconfig directory prep and cleanup
-	executor.On("Execute", mock.Anything, "bash", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+	executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isShellScript)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 	// The Linux inotify pre-check reads current limits (sysctl -n) and only
 	// escalates via `sudo -n` when they are low; report them sufficient so no
 	// escalation happens. .Maybe(): on darwin the whole step is skipped.
 	executor.On("Execute", mock.Anything, "sysctl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "999999"}, nil).Maybe()
 	executor.On("Execute", mock.Anything, "sudo", mock.Anything).Return(&execPkg.CommandResult{Stdout: ""}, nil).Maybe()
+	// The firewall check finds no firewall it can read.
+	executor.On("Execute", mock.Anything, "firewall-cmd", mock.Anything).Return(nil, errors.New("not found")).Maybe()
+	executor.On("Execute", mock.Anything, "iptables", mock.Anything).Return(nil, errors.New("permission denied")).Maybe()
 	executor.On("Execute", mock.Anything, "wsl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 	executor.On("Execute", mock.Anything, "k3d", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
+	// `k3d cluster create` goes through ExecuteWithOptions so its output can stream.
+	executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+	executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(&execPkg.CommandResult{Stdout: "success"}, nil).Maybe()
 
 	manager := NewK3dManager(executor, true) // verbose mode
 	config := models.ClusterConfig{
@@ -370,7 +443,10 @@ func TestK3dManager_StartCluster(t *testing.T) {
 			clusterName: "test-cluster",
 			clusterType: models.ClusterTypeK3d,
 			setupMock: func(m *MockExecutor) {
-				m.On("Execute", mock.Anything, "k3d", []string{"cluster", "start", "test-cluster"}).Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(opts execPkg.ExecuteOptions) bool {
+					return opts.Command == "k3d" && reflect.DeepEqual(opts.Args, []string{"cluster", "start", "test-cluster"}) &&
+						opts.Timeout == sharedconfig.DefaultTimeoutPolicy.Mutation
+				})).Return(&execPkg.CommandResult{Stdout: "success"}, nil)
 			},
 		},
 		{
@@ -390,7 +466,8 @@ func TestK3dManager_StartCluster(t *testing.T) {
 			clusterName: "test-cluster",
 			clusterType: models.ClusterTypeK3d,
 			setupMock: func(m *MockExecutor) {
-				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(nil, errors.New("k3d error"))
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+				m.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(nil, errors.New("k3d error"))
 			},
 			expectedError: "failed to start cluster test-cluster",
 		},
@@ -443,12 +520,22 @@ func TestK3dManager_ListClusters(t *testing.T) {
 		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(opts execPkg.ExecuteOptions) bool {
 			return opts.Command == "k3d" && len(opts.Args) >= 2 && opts.Args[0] == "cluster" && opts.Args[1] == "list"
 		})).Return(&execPkg.CommandResult{Stdout: jsonOutput}, nil)
+		// The list carries no nodes, so each cluster is read again for them.
+		for _, name := range []string{"cluster1", "cluster2"} {
+			executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(opts execPkg.ExecuteOptions) bool {
+				return opts.Command == "k3d" && len(opts.Args) >= 3 && opts.Args[1] == "get" && opts.Args[2] == name
+			})).Return(&execPkg.CommandResult{Stdout: `[{"name": "` + name + `", "serversCount": 1, "nodes": [
+				{"name": "k3d-` + name + `-server-0", "role": "server", "runtimeLabels": {"k3d.server.api.port": "6550"}}]}]`}, nil)
+		}
 
 		manager := NewK3dManager(executor, false)
 		clusters, err := manager.ListClusters(context.Background())
 
 		assert.NoError(t, err)
 		assert.Len(t, clusters, 2)
+		if assert.NotNil(t, clusters[0].Ports) {
+			assert.Equal(t, 6550, clusters[0].Ports.API, "ports come from k3d cluster get")
+		}
 
 		assert.Equal(t, "cluster1", clusters[0].Name)
 		assert.Equal(t, models.ClusterTypeK3d, clusters[0].Type)
@@ -489,7 +576,7 @@ func TestK3dManager_ListClusters(t *testing.T) {
 		clusters, err := manager.ListClusters(context.Background())
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to parse cluster list JSON")
+		assert.Contains(t, err.Error(), "parsing the output of k3d cluster list")
 		assert.Nil(t, clusters)
 
 		executor.AssertExpectations(t)
@@ -530,6 +617,10 @@ func TestK3dManager_GetClusterStatus(t *testing.T) {
 		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(opts execPkg.ExecuteOptions) bool {
 			return opts.Command == "k3d" && len(opts.Args) >= 2 && opts.Args[0] == "cluster" && opts.Args[1] == "list"
 		})).Return(&execPkg.CommandResult{Stdout: jsonOutput}, nil)
+		// Listed without nodes, the cluster is read again; no nodes there either.
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(func(opts execPkg.ExecuteOptions) bool {
+			return opts.Command == "k3d" && len(opts.Args) >= 2 && opts.Args[1] == "get"
+		})).Return(&execPkg.CommandResult{Stdout: "[]"}, nil)
 
 		manager := NewK3dManager(executor, false)
 		clusterInfo, err := manager.GetClusterStatus(context.Background(), "test-cluster")
@@ -618,7 +709,7 @@ func TestK3dManager_GetKubeconfig(t *testing.T) {
 	t.Run("successful kubeconfig retrieval", func(t *testing.T) {
 		executor := &MockExecutor{}
 		kubeconfigContent := "apiVersion: v1\nkind: Config\n..."
-		executor.On("Execute", mock.Anything, "k3d", []string{"kubeconfig", "get", "test-cluster"}).Return(&execPkg.CommandResult{Stdout: kubeconfigContent}, nil)
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dArgs("kubeconfig", "get", "test-cluster"))).Return(&execPkg.CommandResult{Stdout: kubeconfigContent}, nil)
 
 		manager := NewK3dManager(executor, false)
 		kubeconfig, err := manager.GetKubeconfig(context.Background(), "test-cluster", models.ClusterTypeK3d)
@@ -642,7 +733,8 @@ func TestK3dManager_GetKubeconfig(t *testing.T) {
 
 	t.Run("k3d command fails", func(t *testing.T) {
 		executor := &MockExecutor{}
-		executor.On("Execute", mock.Anything, "k3d", mock.Anything).Return(nil, errors.New("k3d error"))
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dClusterList)).Return(&execPkg.CommandResult{Stdout: "[]"}, nil).Maybe()
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dCommand)).Return(nil, errors.New("k3d error"))
 
 		manager := NewK3dManager(executor, false)
 		kubeconfig, err := manager.GetKubeconfig(context.Background(), "test-cluster", models.ClusterTypeK3d)
@@ -655,6 +747,67 @@ func TestK3dManager_GetKubeconfig(t *testing.T) {
 	})
 }
 
+func TestK3dManager_fetchClusterCA(t *testing.T) {
+	const kubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- name: k3d-dev
+  context: {cluster: k3d-dev, user: admin@k3d-dev}
+clusters:
+- name: k3d-dev
+  cluster: {server: "https://0.0.0.0:6550", certificate-authority-data: Y2EtZGF0YQ==}
+users:
+- name: admin@k3d-dev
+`
+	t.Run("copies the CA k3d reports", func(t *testing.T) {
+		executor := &MockExecutor{}
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dArgs("kubeconfig", "get", "dev"))).Return(&execPkg.CommandResult{Stdout: kubeconfig}, nil)
+
+		cfg := &rest.Config{Host: "https://0.0.0.0:6550"}
+		require.NoError(t, NewK3dManager(executor, false).fetchClusterCA(context.Background(), "dev", cfg))
+		assert.Equal(t, []byte("ca-data"), cfg.CAData)
+	})
+
+	t.Run("no context for the cluster", func(t *testing.T) {
+		executor := &MockExecutor{}
+		executor.On("ExecuteWithOptions", mock.Anything, mock.MatchedBy(isK3dArgs("kubeconfig", "get", "other"))).Return(&execPkg.CommandResult{Stdout: kubeconfig}, nil)
+
+		cfg := &rest.Config{}
+		err := NewK3dManager(executor, false).fetchClusterCA(context.Background(), "other", cfg)
+		assert.ErrorContains(t, err, "no context k3d-other")
+		assert.Empty(t, cfg.CAData)
+	})
+}
+
+func TestK3dManager_GetRestConfigOnlyReads(t *testing.T) {
+	const kubeconfig = `apiVersion: v1
+kind: Config
+current-context: other
+contexts:
+- name: k3d-dev
+  context: {cluster: k3d-dev, user: admin@k3d-dev}
+- name: other
+  context: {cluster: k3d-dev, user: admin@k3d-dev}
+clusters:
+- name: k3d-dev
+  cluster: {server: "https://127.0.0.1:1", certificate-authority-data: Y2EtZGF0YQ==}
+users:
+- name: admin@k3d-dev
+`
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+	t.Setenv("KUBECONFIG", path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewK3dManager(&MockExecutor{}, false).GetRestConfig(ctx, "dev")
+	require.ErrorIs(t, err, context.Canceled, "a done ctx ends the wait for the API server")
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfig, string(after), "the current context is left alone")
+}
+
 func TestK3dManager_validateClusterConfig(t *testing.T) {
 	manager := &K3dManager{}
 
@@ -909,3 +1062,7 @@ func TestIsTemporaryError(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultKubernetesVersion(t *testing.T) {
+	assert.Equal(t, "v1.31.5", DefaultKubernetesVersion())
+}
@@ -3,114 +3,254 @@ package k3d
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/files"
 )
 
 // PortConfig holds the allocated ports for a k3d cluster
-type PortConfig struct {
-	API   int
-	HTTP  int
-	HTTPS int
-}
+type PortConfig = models.ClusterPorts
 
-// findAvailablePorts finds available TCP ports for API, HTTP, and HTTPS
-// It prefers standard ports (6550, 80, 443) and falls back to high ports (6551, 8080, 8443) if needed
-func (m *K3dManager) findAvailablePorts() (PortConfig, error) {
-	// Get ports used by existing k3d clusters
-	usedPorts := m.getUsedPortsByExistingClusters()
-
-	config := PortConfig{}
+// Every cluster gets a port slot: slot 0 is the standard 6550/80/443, slot n
+// is 6550+n/8079+n/8442+n (so slot 1 is the familiar 6551/8080/8443). The slot
+// is recorded in ~/.openframe/state/ports.json under a lock, which is what
+// lets two `cluster create`s run at once: neither cluster exists in k3d yet
+// when the other one looks for free ports, but its reservation does.
+const (
+	maxPortSlots = 100
+	// portReservationTTL is how long a reservation holds its slot before its
+	// cluster exists: longer than any create, short enough that a create
+	// killed midway does not hold the slot for good.
+	portReservationTTL = 15 * time.Minute
+)
 
-	// Find API port (6550 preferred, 6551 fallback)
-	config.API = m.findPort([]int{6550, 6551}, 6552, usedPorts)
-	if config.API == 0 {
-		return config, fmt.Errorf("could not find available API port")
+// portsFile records each cluster's port slot; a variable so tests can
+// redirect it.
+var portsFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(home, ".openframe", "state", "ports.json"), nil
+}
 
-	// Find HTTP port (80 preferred, 8080 fallback)
-	config.HTTP = m.findPort([]int{80, 8080}, 8081, usedPorts)
-	if config.HTTP == 0 {
-		return config, fmt.Errorf("could not find available HTTP port")
-	}
+// portRecord is a cluster's entry in the ports file.
+type portRecord struct {
+	Slot       int        `json:"slot"`
+	Ports      PortConfig `json:"ports"`
+	ReservedAt time.Time  `json:"reservedAt"`
+}
 
-	// Find HTTPS port (443 preferred, 8443 fallback)
-	config.HTTPS = m.findPort([]int{443, 8443}, 8444, usedPorts)
-	if config.HTTPS == 0 {
-		return config, fmt.Errorf("could not find available HTTPS port")
+// slotPorts returns the ports of a slot.
+func slotPorts(slot int) PortConfig {
+	if slot == 0 {
+		return PortConfig{API: 6550, HTTP: 80, HTTPS: 443}
 	}
-
-	return config, nil
+	return PortConfig{API: 6550 + slot, HTTP: 8079 + slot, HTTPS: 8442 + slot}
 }
 
-// findPort tries preferred ports first, then searches from searchStart
-func (m *K3dManager) findPort(preferred []int, searchStart int, usedPorts map[int]bool) int {
-	// Try preferred ports first
-	for _, port := range preferred {
-		if !usedPorts[port] && m.isPortAvailable(port) {
-			return port
+// allocatePorts reserves a port slot for the cluster about to be created:
+// the one it had before if that is still free, otherwise the lowest slot no
+// other cluster holds whose ports are all free. replaces, when set, is a
+// stopped cluster the new one takes over from, with its slot.
+func (m *K3dManager) allocatePorts(ctx context.Context, name, replaces string) (PortConfig, error) {
+	var ports PortConfig
+	err := updatePortRecords(func(records map[string]portRecord) error {
+		// Without the existing clusters' ports a stopped cluster's ports look
+		// free to the dial check, and would be handed out again.
+		clusters, err := m.listK3dClusters(ctx)
+		if err != nil {
+			return fmt.Errorf("reading the ports of the existing clusters: %w", err)
+		}
+		exists := map[string]bool{}
+		used := map[int]bool{}
+		for _, c := range clusters {
+			exists[c.Name] = true
+			if c.Name == replaces {
+				continue
+			}
+			for _, p := range usedPorts(c) {
+				used[p] = true
+			}
+		}
+
+		// Forget the reservations of creates that never finished; a record
+		// whose cluster exists is kept for as long as the cluster.
+		held := map[int]bool{}
+		for n, r := range records {
+			if n == name || n == replaces {
+				continue
+			}
+			if !exists[n] && time.Since(r.ReservedAt) > portReservationTTL {
+				delete(records, n)
+				continue
+			}
+			held[r.Slot] = true
 		}
-	}
 
-	// Search for an available port
-	for port := searchStart; port < searchStart+1000; port++ {
-		if !usedPorts[port] && m.isPortAvailable(port) {
-			return port
+		free := func(slot int) bool {
+			if held[slot] {
+				return false
+			}
+			p := slotPorts(slot)
+			for _, port := range []int{p.API, p.HTTP, p.HTTPS} {
+				if used[port] || !isPortAvailable(port) {
+					return false
+				}
+			}
+			return true
 		}
-	}
+		slot := -1
+		if r, ok := records[replaces]; ok && free(r.Slot) {
+			slot = r.Slot
+		} else if r, ok := records[name]; ok && free(r.Slot) {
+			slot = r.Slot
+		}
+		for s := 0; slot < 0 && s < maxPortSlots; s++ {
+			if free(s) {
+				slot = s
+			}
+		}
+		if slot < 0 {
+			return fmt.Errorf("all %d port slots are taken; delete a cluster or free ports 6550-%d", maxPortSlots, 6550+maxPortSlots-1)
+		}
+		ports = slotPorts(slot)
+		records[name] = portRecord{Slot: slot, Ports: ports, ReservedAt: time.Now().UTC()}
+		return nil
+	})
+	return ports, err
+}
 
-	return 0
+// releasePorts gives up the cluster's port slot, once it is deleted or its
+// create failed.
+func releasePorts(name string) error {
+	p, err := portsFile()
+	if err != nil {
+		return err
+	}
+	records, err := readPortRecords(p)
+	if _, ok := records[name]; err != nil || !ok {
+		return err
+	}
+	return updatePortRecords(func(records map[string]portRecord) error {
+		delete(records, name)
+		return nil
+	})
 }
 
-// getUsedPortsByExistingClusters returns a map of ports used by existing k3d clusters
-func (m *K3dManager) getUsedPortsByExistingClusters() map[int]bool {
-	usedPorts := make(map[int]bool)
+func readPortRecords(p string) (map[string]portRecord, error) {
+	records := map[string]portRecord{}
+	data, err := os.ReadFile(p) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", p, err)
+		}
+	}
+	return records, nil
+}
 
-	ctx := context.Background()
-	result, err := m.executor.Execute(ctx, "k3d", "cluster", "list", "--output", "json")
+// updatePortRecords applies fn to the ports file under its lock, and writes
+// the result back if fn succeeds.
+func updatePortRecords(fn func(map[string]portRecord) error) error {
+	p, err := portsFile()
 	if err != nil {
-		return usedPorts // Return empty map on error, will rely on port availability check
+		return err
 	}
+	unlock, err := files.Lock(p)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	var k3dClusters []k3dClusterInfo
-	if err := json.Unmarshal([]byte(result.Stdout), &k3dClusters); err != nil {
-		return usedPorts // Return empty map on error
+	records, err := readPortRecords(p)
+	if err != nil {
+		return err
+	}
+	if err := fn(records); err != nil {
+		return err
 	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return files.WriteAtomic(p, append(data, '\n'))
+}
 
-	// Extract ports from all existing clusters
-	for _, cluster := range k3dClusters {
-		for _, node := range cluster.Nodes {
-			if node.Role == "server" || node.Role == "loadbalancer" {
-				// Parse runtime labels to get port bindings
-				if apiPort, exists := node.RuntimeLabels["k3d.server.api.port"]; exists {
-					if port, err := strconv.Atoi(apiPort); err == nil {
-						usedPorts[port] = true
-					}
-				}
+// usedPorts returns the host ports a cluster's server and load balancer
+// nodes publish.
+func usedPorts(cluster k3dClusterInfo) []int {
+	var ports []int
+	for _, node := range cluster.Nodes {
+		if node.Role != "server" && node.Role != "loadbalancer" {
+			continue
+		}
+		// Parse runtime labels to get port bindings
+		if apiPort, exists := node.RuntimeLabels["k3d.server.api.port"]; exists {
+			if port, err := strconv.Atoi(apiPort); err == nil {
+				ports = append(ports, port)
+			}
+		}
 
-				// Parse port mappings from the load balancer
-				for _, mappings := range node.PortMappings {
-					for _, mapping := range mappings {
-						if mapping.HostPort != "" {
-							if port, err := strconv.Atoi(mapping.HostPort); err == nil {
-								usedPorts[port] = true
-							}
-						}
+		// Parse port mappings from the load balancer
+		for _, mappings := range node.PortMappings {
+			for _, mapping := range mappings {
+				if mapping.HostPort != "" {
+					if port, err := strconv.Atoi(mapping.HostPort); err == nil {
+						ports = append(ports, port)
 					}
 				}
 			}
 		}
 	}
+	return ports
+}
 
-	return usedPorts
+// clusterPorts returns the API, HTTP and HTTPS ports a cluster publishes, or
+// nil when k3d reports none.
+func clusterPorts(cluster k3dClusterInfo) *models.ClusterPorts {
+	var ports models.ClusterPorts
+	for _, node := range cluster.Nodes {
+		if apiPort, ok := node.RuntimeLabels["k3d.server.api.port"]; ok && node.Role == "server" && ports.API == 0 {
+			ports.API, _ = strconv.Atoi(apiPort)
+		}
+		if node.Role != "loadbalancer" {
+			continue
+		}
+		for _, mapping := range node.PortMappings["80/tcp"] {
+			if p, err := strconv.Atoi(mapping.HostPort); err == nil {
+				ports.HTTP = p
+			}
+		}
+		for _, mapping := range node.PortMappings["443/tcp"] {
+			if p, err := strconv.Atoi(mapping.HostPort); err == nil {
+				ports.HTTPS = p
+			}
+		}
+	}
+	if ports == (models.ClusterPorts{}) {
+		return nil
+	}
+	return &ports
 }
 
 // isPortAvailable checks if a TCP port is available by attempting to connect to it.
 // If connection is refused, the port is available. This approach works regardless of
 // user privileges (unlike bind-based checks which fail for ports < 1024 without root).
-func (m *K3dManager) isPortAvailable(port int) bool {
+// A variable so tests do not depend on what listens on this machine.
+var isPortAvailable = func(port int) bool {
 	address := fmt.Sprintf("127.0.0.1:%d", port)
 	conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
 	if err != nil {
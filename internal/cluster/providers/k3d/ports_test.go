@@ -0,0 +1,135 @@
+package k3d
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usePortsFile points the port records at a temp dir and makes every port
+// but those in busy free on this machine.
+func usePortsFile(t *testing.T, busy ...int) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "state", "ports.json")
+	origFile, origAvailable := portsFile, isPortAvailable
+	portsFile = func() (string, error) { return p, nil }
+	isPortAvailable = func(port int) bool {
+		for _, b := range busy {
+			if b == port {
+				return false
+			}
+		}
+		return true
+	}
+	t.Cleanup(func() { portsFile, isPortAvailable = origFile, origAvailable })
+	return p
+}
+
+func noClusters() *K3dManager {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: "[]"})
+	return NewK3dManager(mock, false)
+}
+
+func TestSlotPorts(t *testing.T) {
+	assert.Equal(t, PortConfig{API: 6550, HTTP: 80, HTTPS: 443}, slotPorts(0))
+	assert.Equal(t, PortConfig{API: 6551, HTTP: 8080, HTTPS: 8443}, slotPorts(1))
+	assert.Equal(t, PortConfig{API: 6553, HTTP: 8082, HTTPS: 8445}, slotPorts(3))
+}
+
+// TestAllocatePorts_SkipsUsedPorts guards the property that matters for
+// correctness: a cluster never gets a port another cluster publishes or
+// something else on the machine listens on.
+func TestAllocatePorts_SkipsUsedPorts(t *testing.T) {
+	usePortsFile(t, 8080)
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: clusterListJSON})
+
+	ports, err := NewK3dManager(mock, false).allocatePorts(context.Background(), "dev", "")
+	require.NoError(t, err)
+	assert.Equal(t, slotPorts(2), ports, "slot 0 is c1's and port 8080 of slot 1 is busy")
+}
+
+func TestAllocatePorts_FailsWithoutTheClusterList(t *testing.T) {
+	p := usePortsFile(t)
+	mock := executor.NewMockCommandExecutor()
+	mock.SetShouldFail(true, "Cannot connect to the Docker daemon")
+
+	_, err := NewK3dManager(mock, false).allocatePorts(context.Background(), "dev", "")
+	require.ErrorContains(t, err, "reading the ports of the existing clusters")
+	_, err = os.Stat(p)
+	assert.True(t, os.IsNotExist(err), "nothing is reserved")
+}
+
+func TestAllocatePorts_ConcurrentCreatesGetDistinctSlots(t *testing.T) {
+	usePortsFile(t)
+	m := noClusters()
+
+	names := []string{"a", "b", "c", "d"}
+	got := make([]PortConfig, len(names))
+	var wg sync.WaitGroup
+	for i, n := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ports, err := m.allocatePorts(context.Background(), n, "")
+			assert.NoError(t, err)
+			got[i] = ports
+		}()
+	}
+	wg.Wait()
+
+	seen := map[int]bool{}
+	for _, p := range got {
+		assert.False(t, seen[p.API], "API port %d handed out twice", p.API)
+		seen[p.API] = true
+	}
+}
+
+func TestAllocatePorts_KeepsSlotAndReleases(t *testing.T) {
+	usePortsFile(t)
+	m := noClusters()
+	ctx := context.Background()
+
+	a, err := m.allocatePorts(ctx, "a", "")
+	require.NoError(t, err)
+	b, err := m.allocatePorts(ctx, "b", "")
+	require.NoError(t, err)
+	assert.Equal(t, slotPorts(1), b)
+
+	again, err := m.allocatePorts(ctx, "b", "")
+	require.NoError(t, err)
+	assert.Equal(t, b, again, "a cluster created again keeps its slot")
+
+	require.NoError(t, releasePorts("a"))
+	c, err := m.allocatePorts(ctx, "c", "")
+	require.NoError(t, err)
+	assert.Equal(t, a, c, "a released slot is reused")
+}
+
+func TestAllocatePorts_ExpiredReservationIsDropped(t *testing.T) {
+	usePortsFile(t)
+	m := noClusters()
+	require.NoError(t, updatePortRecords(func(records map[string]portRecord) error {
+		records["killed"] = portRecord{Slot: 0, Ports: slotPorts(0), ReservedAt: time.Now().Add(-time.Hour)}
+		return nil
+	}))
+
+	ports, err := m.allocatePorts(context.Background(), "dev", "")
+	require.NoError(t, err)
+	assert.Equal(t, slotPorts(0), ports, "the create that reserved slot 0 never finished")
+}
+
+func TestReleasePorts_WithoutRecordWritesNothing(t *testing.T) {
+	p := usePortsFile(t)
+	require.NoError(t, releasePorts("dev"))
+	_, err := os.Stat(p)
+	assert.True(t, os.IsNotExist(err))
+}
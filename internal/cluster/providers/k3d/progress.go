@@ -0,0 +1,72 @@
+package k3d
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+)
+
+// logrusPrefix matches the level/elapsed prefix k3d puts on every log line
+// ("INFO[0003] Creating node ..."), which is noise in a one-line progress view.
+var logrusPrefix = regexp.MustCompile(`^[A-Z]{4}\[\d+\]\s*`)
+
+// createPhaseLines maps the k3d log lines that open a creation phase to it.
+// Image pulls also happen inside node creation; those stay in the phase
+// already shown and surface only as the spinner detail.
+var createPhaseLines = []struct {
+	re    *regexp.Regexp
+	phase models.CreatePhase
+}{
+	{regexp.MustCompile(`^(Prep: Network|Created network|Created image volume|Pulling image)`), models.PhasePullImages},
+	{regexp.MustCompile(`^(Creating node|Creating LoadBalancer|Starting cluster|Starting servers|Starting agents)`), models.PhaseCreateNodes},
+	{regexp.MustCompile(`^(Starting helpers|Starting node '.*-serverlb')`), models.PhaseLoadBalancer},
+	{regexp.MustCompile(`(?i)^(Cluster '.*' created successfully|Updating default kubeconfig|Successfully merged kubeconfig)`), models.PhaseKubeconfig},
+}
+
+// createPhaseOf returns the creation phase a k3d log line (prefix already
+// stripped) opens, if any.
+func createPhaseOf(line string) (models.CreatePhase, bool) {
+	for _, l := range createPhaseLines {
+		if l.re.MatchString(line) {
+			return l.phase, true
+		}
+	}
+	return 0, false
+}
+
+// outputRelay returns the ExecuteOptions.OnOutput callback for a long-running
+// k3d command, or nil when nobody is listening. Under --verbose every line is
+// echoed as it arrives; a progress sink on ctx (the caller's spinner) gets the
+// latest line without its log prefix, and a phase sink the creation phases
+// the lines announce.
+func (m *K3dManager) outputRelay(ctx context.Context) func(line string) {
+	progress := executor.ProgressFrom(ctx)
+	phases := models.CreatePhasesFrom(ctx)
+	if !m.verbose && progress == nil && phases == nil {
+		return nil
+	}
+	return func(line string) {
+		if m.verbose {
+			pterm.Debug.Println(line)
+		}
+		line = logrusPrefix.ReplaceAllString(line, "")
+		if phases != nil {
+			if phase, ok := createPhaseOf(line); ok {
+				phases(phase)
+			}
+		}
+		if progress != nil {
+			progress(line)
+		}
+	}
+}
+
+// reportCreatePhase tells the phase sink on ctx, if any, that phase began.
+func reportCreatePhase(ctx context.Context, phase models.CreatePhase) {
+	if phases := models.CreatePhasesFrom(ctx); phases != nil {
+		phases(phase)
+	}
+}
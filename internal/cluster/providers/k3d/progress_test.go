@@ -0,0 +1,60 @@
+package k3d
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputRelay_NilWithoutListener(t *testing.T) {
+	m := NewK3dManager(executor.NewMockCommandExecutor(), false)
+	assert.Nil(t, m.outputRelay(context.Background()), "no spinner and no --verbose: nothing to stream to")
+}
+
+func TestOutputRelay_StripsLogPrefixForProgress(t *testing.T) {
+	var got []string
+	ctx := executor.WithProgress(context.Background(), func(line string) { got = append(got, line) })
+
+	relay := NewK3dManager(executor.NewMockCommandExecutor(), false).outputRelay(ctx)
+	relay("INFO[0003] Creating node 'k3d-dev-server-0'")
+	relay("plain line")
+
+	assert.Equal(t, []string{"Creating node 'k3d-dev-server-0'", "plain line"}, got)
+}
+
+func TestCreatePhaseOf(t *testing.T) {
+	cases := map[string]models.CreatePhase{
+		"Prep: Network": models.PhasePullImages,
+		"Pulling image 'docker.io/rancher/k3s:v1.31.5-k3s1'":             models.PhasePullImages,
+		"Creating node 'k3d-dev-server-0'":                               models.PhaseCreateNodes,
+		"Creating LoadBalancer 'k3d-dev-serverlb'":                       models.PhaseCreateNodes,
+		"Starting helpers...":                                            models.PhaseLoadBalancer,
+		"Starting node 'k3d-dev-serverlb'":                               models.PhaseLoadBalancer,
+		"Cluster 'dev' created successfully!":                            models.PhaseKubeconfig,
+		"Updating default kubeconfig with a new context for cluster dev": models.PhaseKubeconfig,
+	}
+	for line, want := range cases {
+		got, ok := createPhaseOf(line)
+		assert.True(t, ok, line)
+		assert.Equal(t, want, got, line)
+	}
+
+	_, ok := createPhaseOf("Starting node 'k3d-dev-server-0'")
+	assert.False(t, ok, "a server starting is not a phase boundary")
+}
+
+func TestOutputRelay_ReportsPhases(t *testing.T) {
+	var got []models.CreatePhase
+	ctx := models.WithCreatePhases(context.Background(), func(p models.CreatePhase) { got = append(got, p) })
+
+	relay := NewK3dManager(executor.NewMockCommandExecutor(), false).outputRelay(ctx)
+	assert.NotNil(t, relay, "a phase sink alone is a listener")
+	relay("INFO[0000] Prep: Network")
+	relay("INFO[0001] Created network 'k3d-dev'")
+	relay("INFO[0002] Creating node 'k3d-dev-server-0'")
+
+	assert.Equal(t, []models.CreatePhase{models.PhasePullImages, models.PhasePullImages, models.PhaseCreateNodes}, got)
+}
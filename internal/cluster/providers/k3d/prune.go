@@ -0,0 +1,137 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// configFileGrace is how old a k3d-config-*.yaml must be before prune takes
+// it for a leftover rather than the config of a create still running.
+const configFileGrace = time.Hour
+
+// tempDir is where createK3dConfigFile writes; a variable so tests can
+// redirect it.
+var tempDir = os.TempDir
+
+// FindOrphans lists the k3d containers, networks and volumes, the temp config
+// files and the isolated kubeconfigs whose cluster no longer exists. It fails
+// rather than guess when the live clusters cannot be listed. Registries not
+// labeled with a cluster are never reported: they may serve several.
+func (m *K3dManager) FindOrphans(ctx context.Context) ([]models.Orphan, error) {
+	clusters, err := m.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]bool{}
+	for _, c := range clusters {
+		live[c.Name] = true
+	}
+	dead := func(cluster string) bool { return cluster != "" && !live[cluster] }
+
+	var out []models.Orphan
+	containers, err := m.dockerList(ctx, "ps", "-a", "--filter", "label=app=k3d", "--format", `{{.Names}}\t{{.Label "k3d.cluster"}}`)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range containers {
+		if dead(c[1]) {
+			out = append(out, models.Orphan{Kind: models.OrphanContainer, Name: c[0], Cluster: c[1]})
+		}
+	}
+	// k3d names a cluster's network k3d-<cluster>; the name, not a label, is
+	// what every k3d version has in common.
+	networks, err := m.dockerList(ctx, "network", "ls", "--filter", "name=k3d-", "--format", "{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if cluster, ok := strings.CutPrefix(n[0], "k3d-"); ok && dead(cluster) {
+			out = append(out, models.Orphan{Kind: models.OrphanNetwork, Name: n[0], Cluster: cluster})
+		}
+	}
+	volumes, err := m.dockerList(ctx, "volume", "ls", "--filter", "label=app=k3d", "--format", `{{.Name}}\t{{.Label "k3d.cluster"}}`)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		if dead(v[1]) {
+			out = append(out, models.Orphan{Kind: models.OrphanVolume, Name: v[0], Cluster: v[1]})
+		}
+	}
+
+	configs, _ := filepath.Glob(filepath.Join(tempDir(), "k3d-config-*.yaml"))
+	for _, path := range configs {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > configFileGrace {
+			out = append(out, models.Orphan{Kind: models.OrphanFile, Name: path})
+		}
+	}
+	for _, cluster := range k8s.IsolatedClusters() {
+		if _, external := k8s.LookupExternalCluster(cluster); !external && dead(cluster) {
+			if path, err := k8s.IsolatedKubeconfigPath(cluster); err == nil {
+				out = append(out, models.Orphan{Kind: models.OrphanFile, Name: path, Cluster: cluster})
+			}
+		}
+	}
+	return out, nil
+}
+
+// dockerList runs a docker listing and splits each output line on tabs.
+func (m *K3dManager) dockerList(ctx context.Context, args ...string) ([][]string, error) {
+	result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: "docker", Args: args, Timeout: sharedconfig.Timeout(sharedconfig.Query)})
+	if err != nil {
+		return nil, fmt.Errorf("docker %s %s: %w", args[0], args[1], err)
+	}
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(result.Stdout), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			rows = append(rows, append(strings.Split(line, "\t"), "")) // pad: a missing label
+		}
+	}
+	return rows, nil
+}
+
+// RemoveOrphans deletes orphans — containers first, since Docker refuses to
+// remove a network or volume still in use — and returns how many went. The
+// error names those that could not be removed.
+func (m *K3dManager) RemoveOrphans(ctx context.Context, orphans []models.Orphan) (int, error) {
+	order := map[models.OrphanKind]int{models.OrphanContainer: 0, models.OrphanNetwork: 1, models.OrphanVolume: 2, models.OrphanFile: 3}
+	sorted := append([]models.Orphan(nil), orphans...)
+	sort.SliceStable(sorted, func(i, j int) bool { return order[sorted[i].Kind] < order[sorted[j].Kind] })
+
+	removed := 0
+	var failed []string
+	for _, o := range sorted {
+		var err error
+		switch o.Kind {
+		case models.OrphanContainer:
+			_, err = m.executor.Execute(ctx, "docker", "rm", "-f", o.Name)
+		case models.OrphanNetwork:
+			_, err = m.executor.Execute(ctx, "docker", "network", "rm", o.Name)
+		case models.OrphanVolume:
+			_, err = m.executor.Execute(ctx, "docker", "volume", "rm", o.Name)
+		case models.OrphanFile:
+			if err = os.Remove(o.Name); os.IsNotExist(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s %s", o.Kind, o.Name))
+			continue
+		}
+		removed++
+	}
+	if len(failed) > 0 {
+		return removed, fmt.Errorf("could not remove: %s", strings.Join(failed, ", "))
+	}
+	return removed, nil
+}
@@ -0,0 +1,84 @@
+package k3d
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrphans_OnlyResourcesOfDeletedClusters(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBECONFIG", "")
+	tmp := t.TempDir()
+	orig := tempDir
+	tempDir = func() string { return tmp }
+	defer func() { tempDir = orig }()
+
+	stale := filepath.Join(tmp, "k3d-config-111.yaml")
+	fresh := filepath.Join(tmp, "k3d-config-222.yaml")
+	require.NoError(t, os.WriteFile(stale, nil, 0o600))
+	require.NoError(t, os.WriteFile(fresh, nil, 0o600))
+	old := time.Now().Add(-2 * configFileGrace)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	kubeconfigs := filepath.Join(os.Getenv("HOME"), ".openframe", "kubeconfigs")
+	require.NoError(t, os.MkdirAll(kubeconfigs, 0o750))
+	for _, c := range []string{"dev", "gone"} {
+		require.NoError(t, os.WriteFile(filepath.Join(kubeconfigs, c+".yaml"), nil, 0o600))
+	}
+
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("cluster list", &executor.CommandResult{Stdout: `[{"name":"dev","serversCount":1,"serversRunning":1}]`})
+	mock.SetResponse("docker ps", &executor.CommandResult{Stdout: "k3d-dev-server-0\tdev\nk3d-gone-server-0\tgone\nk3d-shared-registry\t\n"})
+	mock.SetResponse("network ls", &executor.CommandResult{Stdout: "k3d-dev\nk3d-gone\n"})
+	mock.SetResponse("volume ls", &executor.CommandResult{Stdout: "k3d-dev-images\tdev\nk3d-gone-images\tgone\n"})
+
+	got, err := NewK3dManager(mock, false).FindOrphans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Orphan{
+		{Kind: models.OrphanContainer, Name: "k3d-gone-server-0", Cluster: "gone"},
+		{Kind: models.OrphanNetwork, Name: "k3d-gone", Cluster: "gone"},
+		{Kind: models.OrphanVolume, Name: "k3d-gone-images", Cluster: "gone"},
+		{Kind: models.OrphanFile, Name: stale},
+		{Kind: models.OrphanFile, Name: filepath.Join(kubeconfigs, "gone.yaml"), Cluster: "gone"},
+	}, got)
+}
+
+func TestFindOrphans_FailsWhenClustersCannotBeListed(t *testing.T) {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("cluster list", &executor.CommandResult{ExitCode: 1})
+
+	_, err := NewK3dManager(mock, false).FindOrphans(context.Background())
+	assert.Error(t, err)
+	for _, c := range mock.GetExecutedCommands() {
+		assert.NotContains(t, c, "docker", "nothing is listed, let alone removed, without the live clusters")
+	}
+}
+
+func TestRemoveOrphans_ContainersBeforeNetworks(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "k3d-config-1.yaml")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	mock := executor.NewMockCommandExecutor()
+
+	n, err := NewK3dManager(mock, false).RemoveOrphans(context.Background(), []models.Orphan{
+		{Kind: models.OrphanFile, Name: file},
+		{Kind: models.OrphanNetwork, Name: "k3d-gone"},
+		{Kind: models.OrphanVolume, Name: "k3d-gone-images"},
+		{Kind: models.OrphanContainer, Name: "k3d-gone-server-0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []string{
+		"docker rm -f k3d-gone-server-0",
+		"docker network rm k3d-gone",
+		"docker volume rm k3d-gone-images",
+	}, mock.GetExecutedCommands())
+	assert.NoFileExists(t, file)
+}
@@ -0,0 +1,37 @@
+package k3d
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+)
+
+// registriesConfig renders the k3d `registries:` block that k3d writes to
+// /etc/rancher/k3s/registries.yaml on every node, pointing containerd at the
+// given mirrors. Mirrors for the same source keep their flag order (containerd
+// tries them in turn before the upstream). It returns "" when there are none.
+func registriesConfig(mirrors []models.RegistryMirror) string {
+	if len(mirrors) == 0 {
+		return ""
+	}
+
+	var sources []string
+	endpoints := make(map[string][]string)
+	for _, mirror := range mirrors {
+		if _, seen := endpoints[mirror.Source]; !seen {
+			sources = append(sources, mirror.Source)
+		}
+		endpoints[mirror.Source] = append(endpoints[mirror.Source], mirror.Endpoint)
+	}
+
+	var b strings.Builder
+	b.WriteString("\nregistries:\n  config: |\n    mirrors:")
+	for _, source := range sources {
+		fmt.Fprintf(&b, "\n      %q:\n        endpoint:", source)
+		for _, endpoint := range endpoints[source] {
+			fmt.Fprintf(&b, "\n          - %q", endpoint)
+		}
+	}
+	return b.String()
+}
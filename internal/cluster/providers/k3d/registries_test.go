@@ -0,0 +1,39 @@
+package k3d
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRegistriesConfig_Empty(t *testing.T) {
+	assert.Empty(t, registriesConfig(nil))
+}
+
+func TestRegistriesConfig_GroupsEndpointsBySource(t *testing.T) {
+	block := registriesConfig([]models.RegistryMirror{
+		{Source: "docker.io", Endpoint: "https://mirror-a.example.com"},
+		{Source: "ghcr.io", Endpoint: "http://10.0.0.5:5000"},
+		{Source: "docker.io", Endpoint: "https://mirror-b.example.com"},
+	})
+
+	var outer struct {
+		Registries struct {
+			Config string `json:"config"`
+		} `json:"registries"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(block), &outer))
+
+	var inner struct {
+		Mirrors map[string]struct {
+			Endpoint []string `json:"endpoint"`
+		} `json:"mirrors"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(outer.Registries.Config), &inner), "the embedded registries.yaml must parse")
+
+	assert.Equal(t, []string{"https://mirror-a.example.com", "https://mirror-b.example.com"}, inner.Mirrors["docker.io"].Endpoint)
+	assert.Equal(t, []string{"http://10.0.0.5:5000"}, inner.Mirrors["ghcr.io"].Endpoint)
+}
@@ -0,0 +1,219 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// k3sDataDir is where k3s keeps a node's state: the datastore and
+// certificates on a server, containerd and local-path volumes on every node.
+// The rancher/k3s image declares it a volume, so it outlives the container.
+const k3sDataDir = "/var/lib/rancher/k3s"
+
+// recreation is what createCluster carries over from the cluster RenameCluster
+// replaces.
+type recreation struct {
+	from  string // the cluster being renamed
+	image string
+	token string
+	nodes []recreatedNode
+}
+
+// recreatedNode is one node of the renamed cluster and where it comes back.
+type recreatedNode struct {
+	filter string // the k3d node filter of the new node: server:0, agent:1
+	name   string // the k3s node name, kept so the datastore still matches
+	volume string // the Docker volume holding its k3sDataDir
+}
+
+// RenameCluster gives a k3d cluster a new name. k3d cannot rename a cluster —
+// the name is in every node container's labels, hostname and network — so the
+// cluster is recreated under the new name on its own data: each new node
+// mounts the volume holding the old node's k3s state, and keeps the old node's
+// k3s node name and the cluster token, so the datastore, certificates, images
+// and local-path volumes carry over as they are.
+//
+// The old cluster is stopped, not deleted, until the new one is up: if the
+// create fails it is started again untouched. Its containers are then removed
+// without their volumes, which the new nodes now use.
+func (m *K3dManager) RenameCluster(ctx context.Context, oldName, newName string) error {
+	for _, n := range []string{oldName, newName} {
+		if err := models.ValidateClusterName(n); err != nil {
+			return models.NewInvalidConfigError("name", n, err.Error())
+		}
+	}
+	if oldName == newName {
+		return models.NewInvalidConfigError("name", newName, "the new name is the current one")
+	}
+
+	clusters, err := m.listK3dClusters(ctx)
+	if err != nil {
+		return models.NewClusterOperationError("rename", oldName, fmt.Errorf("failed to list clusters: %w", err))
+	}
+	var old *k3dClusterInfo
+	for i, c := range clusters {
+		switch c.Name {
+		case oldName:
+			old = &clusters[i]
+		case newName:
+			return models.NewClusterOperationError("rename", oldName, fmt.Errorf("cluster %s already exists", newName))
+		}
+	}
+	if old == nil {
+		return models.NewClusterOperationError("rename", oldName, fmt.Errorf("cluster %s not found", oldName))
+	}
+
+	rc, err := m.inspectForRecreation(ctx, *old)
+	if err != nil {
+		return models.NewClusterOperationError("rename", oldName, err)
+	}
+	wasRunning := old.ServersRunning > 0
+
+	// A k3s node keeps a password in /etc/rancher/node, outside its data
+	// volume, and the server refuses a node whose password does not match the
+	// one on record. The new containers have new passwords, so the records go;
+	// k3s makes new ones when the nodes join.
+	if !wasRunning {
+		if err := m.StartCluster(ctx, oldName, models.ClusterTypeK3d); err != nil {
+			return err
+		}
+	}
+	if err := m.forgetNodePasswords(ctx, *old, rc); err != nil {
+		return models.NewClusterOperationError("rename", oldName, err)
+	}
+	if err := m.StopCluster(ctx, oldName, models.ClusterTypeK3d); err != nil {
+		return err
+	}
+
+	config := models.ClusterConfig{
+		Name:      newName,
+		Type:      models.ClusterTypeK3d,
+		NodeCount: len(rc.nodes),
+	}
+	for _, n := range rc.nodes {
+		config.K3sArgs = append(config.K3sArgs, models.K3sArg{Arg: "--node-name=" + n.name, NodeFilters: []string{n.filter}})
+	}
+	if _, err := m.createCluster(ctx, config, rc); err != nil {
+		if wasRunning {
+			if serr := m.StartCluster(ctx, oldName, models.ClusterTypeK3d); serr != nil {
+				return fmt.Errorf("%w (starting %s again also failed: %v)", err, oldName, serr)
+			}
+		}
+		return err
+	}
+
+	// The old containers go without their volumes (no `docker rm -v`), which
+	// is exactly the cleanup --force falls back to.
+	if err := m.forceCleanupDockerContainers(ctx, oldName); err != nil {
+		fmt.Printf("Warning: cluster %s is renamed, but the old containers were not removed: %v\n", newName, err)
+	}
+	_, _ = m.executor.Execute(ctx, "docker", "volume", "rm", "k3d-"+oldName+"-images")
+	m.removeIsolatedKubeconfig(oldName)
+	m.releasePortsOf(oldName)
+	if !k8s.IsolationEnabled() {
+		if err := k8s.RemoveContext(k8s.DefaultKubeconfigPath(), "k3d-"+oldName); err != nil && m.verbose {
+			fmt.Printf("Warning: could not remove context k3d-%s from the kubeconfig: %v\n", oldName, err)
+		}
+	}
+	if !wasRunning {
+		return m.StopCluster(ctx, newName, models.ClusterTypeK3d)
+	}
+	return nil
+}
+
+// inspectForRecreation reads what the new cluster needs from the old one's
+// node containers: its image and token, and every node's data volume.
+func (m *K3dManager) inspectForRecreation(ctx context.Context, c k3dClusterInfo) (*recreation, error) {
+	rc := &recreation{from: c.Name}
+	servers, agents := 0, 0
+	for _, node := range sortedNodes(c) {
+		var filter string
+		switch node.Role {
+		case "server":
+			filter = "server:" + strconv.Itoa(servers)
+			servers++
+		case "agent":
+			filter = "agent:" + strconv.Itoa(agents)
+			agents++
+		default:
+			continue // the load balancer and tools nodes keep no state
+		}
+		result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command: "docker",
+			Args: []string{"inspect", "--format",
+				`{{.Config.Image}}	{{index .Config.Labels "k3d.cluster.token"}}	{{range .Mounts}}{{if eq .Destination "` + k3sDataDir + `"}}{{.Name}}{{end}}{{end}}`,
+				node.Name},
+			Timeout: sharedconfig.Timeout(sharedconfig.Query),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect node %s: %w", node.Name, err)
+		}
+		fields := strings.Split(strings.TrimSpace(result.Stdout), "\t")
+		if len(fields) != 3 || fields[2] == "" {
+			return nil, fmt.Errorf("node %s keeps its k3s data in no volume; it cannot be moved to a new cluster", node.Name)
+		}
+		if rc.image == "" {
+			rc.image, rc.token = fields[0], fields[1]
+		}
+		rc.nodes = append(rc.nodes, recreatedNode{filter: filter, name: node.Name, volume: fields[2]})
+	}
+	if servers != 1 {
+		return nil, fmt.Errorf("cluster %s has %d servers; only single-server clusters, as 'openframe cluster create' makes them, can be renamed", c.Name, servers)
+	}
+	if rc.token == "" {
+		return nil, fmt.Errorf("cluster %s has no k3d.cluster.token label; it was not created by k3d 5", c.Name)
+	}
+	return rc, nil
+}
+
+// forgetNodePasswords deletes the k3s node password secrets of the old
+// cluster's nodes, from inside its server.
+func (m *K3dManager) forgetNodePasswords(ctx context.Context, c k3dClusterInfo, rc *recreation) error {
+	args := []string{"exec", rc.nodes[0].name, "kubectl", "delete", "secret", "--namespace", "kube-system", "--ignore-not-found"}
+	for _, n := range rc.nodes {
+		args = append(args, n.name+".node-password.k3s")
+	}
+	if _, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "docker",
+		Args:    args,
+		Timeout: sharedconfig.Timeout(sharedconfig.Mutation),
+	}); err != nil {
+		return fmt.Errorf("failed to reset the node passwords of cluster %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// sortedNodes returns the cluster's nodes in k3d's index order: server-0
+// before server-1, agent-2 before agent-10.
+func sortedNodes(c k3dClusterInfo) []k3dNode {
+	nodes := append([]k3dNode(nil), c.Nodes...)
+	index := func(n k3dNode) int {
+		i, _ := strconv.Atoi(n.Name[strings.LastIndex(n.Name, "-")+1:])
+		return i
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Role != nodes[j].Role {
+			return nodes[i].Role > nodes[j].Role // server, then agent
+		}
+		return index(nodes[i]) < index(nodes[j])
+	})
+	return nodes
+}
+
+// config renders the k3d config the recreated cluster needs beyond a new
+// one's: the old token, and each node's data volume.
+func (rc *recreation) config() (token, volumes string) {
+	var b strings.Builder
+	for _, n := range rc.nodes {
+		fmt.Fprintf(&b, "\n  - volume: %q\n    nodeFilters:\n      - %s", n.volume+":"+k3sDataDir, n.filter)
+	}
+	return fmt.Sprintf("\ntoken: %q", rc.token), b.String()
+}
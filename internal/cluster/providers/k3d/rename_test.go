@@ -0,0 +1,126 @@
+//go:build !windows
+
+package k3d
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renameClusterJSON = `[
+  {"name": "dev", "serversCount": 1, "serversRunning": 1, "agentsCount": 2, "nodes": [
+    {"name": "k3d-dev-agent-1", "role": "agent"},
+    {"name": "k3d-dev-serverlb", "role": "loadbalancer"},
+    {"name": "k3d-dev-server-0", "role": "server"},
+    {"name": "k3d-dev-agent-0", "role": "agent"}
+  ]},
+  {"name": "taken", "nodes": []}
+]`
+
+// renameMock answers `k3d cluster list` and inspects each node of dev.
+func renameMock() *executor.MockCommandExecutor {
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: renameClusterJSON})
+	for _, n := range []string{"server-0", "agent-0", "agent-1"} {
+		mock.SetResponse("}} k3d-dev-"+n, &executor.CommandResult{Stdout: "rancher/k3s:v1.31.5-k3s1\tsecret-token\tvol-" + n + "\n"})
+	}
+	return mock
+}
+
+func TestInspectForRecreation(t *testing.T) {
+	m := NewK3dManager(renameMock(), false)
+	clusters, err := m.listK3dClusters(context.Background())
+	require.NoError(t, err)
+
+	rc, err := m.inspectForRecreation(context.Background(), clusters[0])
+	require.NoError(t, err)
+	assert.Equal(t, "rancher/k3s:v1.31.5-k3s1", rc.image)
+	assert.Equal(t, "secret-token", rc.token)
+	assert.Equal(t, []recreatedNode{
+		{filter: "server:0", name: "k3d-dev-server-0", volume: "vol-server-0"},
+		{filter: "agent:0", name: "k3d-dev-agent-0", volume: "vol-agent-0"},
+		{filter: "agent:1", name: "k3d-dev-agent-1", volume: "vol-agent-1"},
+	}, rc.nodes)
+
+	token, volumes := rc.config()
+	assert.Equal(t, "\ntoken: \"secret-token\"", token)
+	assert.Contains(t, volumes, "- volume: \"vol-agent-1:/var/lib/rancher/k3s\"\n    nodeFilters:\n      - agent:1")
+}
+
+func TestInspectForRecreation_NeedsDataVolumes(t *testing.T) {
+	mock := renameMock()
+	mock.SetResponse("}} k3d-dev-agent-1", &executor.CommandResult{Stdout: "rancher/k3s:v1.31.5-k3s1\tsecret-token\t\n"})
+	m := NewK3dManager(mock, false)
+	clusters, err := m.listK3dClusters(context.Background())
+	require.NoError(t, err)
+
+	_, err = m.inspectForRecreation(context.Background(), clusters[0])
+	assert.ErrorContains(t, err, "k3d-dev-agent-1 keeps its k3s data in no volume")
+}
+
+func TestCreateK3dConfigFile_Recreation(t *testing.T) {
+	rc := &recreation{from: "dev", image: "rancher/k3s:v1.31.5-k3s1", token: "secret-token", nodes: []recreatedNode{
+		{filter: "server:0", name: "k3d-dev-server-0", volume: "vol-server-0"},
+	}}
+	config := models.ClusterConfig{Name: "prod", Type: models.ClusterTypeK3d, NodeCount: 1,
+		K3sArgs: []models.K3sArg{{Arg: "--node-name=k3d-dev-server-0", NodeFilters: []string{"server:0"}}}}
+
+	path, err := NewK3dManager(executor.NewMockCommandExecutor(), false).createK3dConfigFile(config, rc.image, slotPorts(0), rc)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: the temp file just created
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "image: rancher/k3s:v1.31.5-k3s1")
+	assert.Contains(t, content, "\ntoken: \"secret-token\"")
+	assert.Contains(t, content, "\nvolumes:\n  - volume: \"vol-server-0:/var/lib/rancher/k3s\"")
+	assert.Contains(t, content, `arg: "--node-name=k3d-dev-server-0"`)
+}
+
+func TestRenameCluster_Refuses(t *testing.T) {
+	m := NewK3dManager(renameMock(), false)
+	ctx := context.Background()
+
+	assert.ErrorContains(t, m.RenameCluster(ctx, "dev", "taken"), "cluster taken already exists")
+	assert.ErrorContains(t, m.RenameCluster(ctx, "gone", "prod"), "cluster gone not found")
+	assert.ErrorContains(t, m.RenameCluster(ctx, "dev", "dev"), "the new name is the current one")
+	assert.Error(t, m.RenameCluster(ctx, "dev", "bad_name"))
+}
+
+// TestRenameCluster_FailedCreateRestartsOld guards the promise that a rename
+// loses nothing: when the new cluster cannot be created the old one is
+// started again, and its containers are never removed.
+func TestRenameCluster_FailedCreateRestartsOld(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	usePortsFile(t)
+	mock := renameMock()
+	mock.SetResponse("k3d cluster create", &executor.CommandResult{ExitCode: 1, Stderr: "boom"})
+
+	err := NewK3dManager(mock, false).RenameCluster(context.Background(), "dev", "prod")
+	require.ErrorContains(t, err, "failed to create cluster prod")
+
+	var steps []string
+	for _, c := range mock.Commands() {
+		line := c.String()
+		switch {
+		case strings.HasPrefix(line, "docker exec"), strings.HasPrefix(line, "k3d cluster stop"),
+			strings.HasPrefix(line, "k3d cluster start"), strings.HasPrefix(line, "k3d cluster create"):
+			steps = append(steps, strings.Join(strings.Fields(line)[:3], " "))
+		case strings.HasPrefix(line, "docker rm"):
+			t.Errorf("removed a container of the cluster being renamed: %s", line)
+		}
+		if strings.HasPrefix(line, "docker exec") {
+			assert.Equal(t, "docker exec k3d-dev-server-0 kubectl delete secret --namespace kube-system --ignore-not-found "+
+				"k3d-dev-server-0.node-password.k3s k3d-dev-agent-0.node-password.k3s k3d-dev-agent-1.node-password.k3s", line)
+		}
+	}
+	assert.Equal(t, []string{"docker exec k3d-dev-server-0", "k3d cluster stop", "k3d cluster create", "k3d cluster start"}, steps)
+}
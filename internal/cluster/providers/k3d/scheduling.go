@@ -0,0 +1,44 @@
+package k3d
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+)
+
+// nodeTaintArgs renders one k3s --node-taint extraArgs entry per taint, to be
+// appended to the options.k3s.extraArgs list (the config-file form of
+// `k3d --k3s-arg "--node-taint=...@filter"`).
+func nodeTaintArgs(taints []models.NodeTaint) string {
+	var b strings.Builder
+	for _, taint := range taints {
+		fmt.Fprintf(&b, "\n      - arg: %q\n        nodeFilters:", "--node-taint="+taint.String())
+		writeNodeFilters(&b, taint.NodeFilters)
+	}
+	return b.String()
+}
+
+// nodeLabelsConfig renders the options.k3s.nodeLabels list (the config-file
+// form of `k3d --k3s-node-label key=value@filter`), or "" without labels.
+func nodeLabelsConfig(labels []models.NodeLabel) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n    nodeLabels:")
+	for _, label := range labels {
+		fmt.Fprintf(&b, "\n      - label: %q\n        nodeFilters:", label.Key+"="+label.Value)
+		writeNodeFilters(&b, label.NodeFilters)
+	}
+	return b.String()
+}
+
+func writeNodeFilters(b *strings.Builder, filters []string) {
+	if len(filters) == 0 {
+		filters = []string{"all"}
+	}
+	for _, filter := range filters {
+		fmt.Fprintf(b, "\n          - %s", filter)
+	}
+}
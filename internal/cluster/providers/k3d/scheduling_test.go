@@ -0,0 +1,49 @@
+package k3d
+
+import (
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+type k3sOptionsFixture struct {
+	Options struct {
+		K3s struct {
+			ExtraArgs []struct {
+				Arg         string   `json:"arg"`
+				NodeFilters []string `json:"nodeFilters"`
+			} `json:"extraArgs"`
+			NodeLabels []struct {
+				Label       string   `json:"label"`
+				NodeFilters []string `json:"nodeFilters"`
+			} `json:"nodeLabels"`
+		} `json:"k3s"`
+	} `json:"options"`
+}
+
+func TestNodeLabelsAndTaints_RenderIntoK3sOptions(t *testing.T) {
+	doc := "options:\n  k3s:\n    extraArgs:\n      - arg: --disable=traefik\n        nodeFilters:\n          - server:*" +
+		nodeTaintArgs([]models.NodeTaint{{Key: "dedicated", Value: "db", Effect: "NoSchedule", NodeFilters: []string{"agent:0"}}}) +
+		nodeLabelsConfig([]models.NodeLabel{{Key: "workload", Value: "db", NodeFilters: []string{"agent:0", "agent:1"}}})
+
+	var parsed k3sOptionsFixture
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &parsed))
+
+	args := parsed.Options.K3s.ExtraArgs
+	require.Len(t, args, 2)
+	assert.Equal(t, "--node-taint=dedicated=db:NoSchedule", args[1].Arg)
+	assert.Equal(t, []string{"agent:0"}, args[1].NodeFilters)
+
+	labels := parsed.Options.K3s.NodeLabels
+	require.Len(t, labels, 1)
+	assert.Equal(t, "workload=db", labels[0].Label)
+	assert.Equal(t, []string{"agent:0", "agent:1"}, labels[0].NodeFilters)
+}
+
+func TestNodeLabelsAndTaints_EmptyRenderNothing(t *testing.T) {
+	assert.Empty(t, nodeTaintArgs(nil))
+	assert.Empty(t, nodeLabelsConfig(nil))
+}
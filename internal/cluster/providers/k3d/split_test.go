@@ -6,10 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 )
 
-// --- ports.go: getUsedPortsByExistingClusters ---
+// --- ports.go: usedPorts ---
 
 const clusterListJSON = `[
   {
@@ -25,13 +26,18 @@ const clusterListJSON = `[
   }
 ]`
 
-func TestGetUsedPortsByExistingClusters(t *testing.T) {
+func TestUsedPorts(t *testing.T) {
 	mock := executor.NewMockCommandExecutor()
 	mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: clusterListJSON})
-	m := NewK3dManager(mock, false)
-
-	used := m.getUsedPortsByExistingClusters()
+	clusters, err := NewK3dManager(mock, false).listK3dClusters(context.Background())
+	if err != nil || len(clusters) != 1 {
+		t.Fatalf("want one cluster, got %v (%v)", clusters, err)
+	}
 
+	used := map[int]bool{}
+	for _, p := range usedPorts(clusters[0]) {
+		used[p] = true
+	}
 	for _, want := range []int{6550, 80, 443} {
 		if !used[want] {
 			t.Errorf("port %d should be marked used, got %v", want, used)
@@ -41,24 +47,28 @@ func TestGetUsedPortsByExistingClusters(t *testing.T) {
 	if used[9999] {
 		t.Errorf("agent-node port must be ignored, got %v", used)
 	}
+
+	if got := clusterPorts(clusters[0]); got == nil || *got != (models.ClusterPorts{API: 6550, HTTP: 80, HTTPS: 443}) {
+		t.Errorf("clusterPorts = %v, want 6550/80/443", got)
+	}
 }
 
-func TestGetUsedPortsByExistingClusters_ErrorsYieldEmpty(t *testing.T) {
-	// Documented (if debatable) behavior: on k3d failure or malformed JSON the
-	// function falls back to an EMPTY used-set and relies on the later
-	// isPortAvailable dial check. If this ever changes, update findPort too.
+func TestListK3dClusters_Errors(t *testing.T) {
+	// On k3d failure or malformed JSON allocatePorts warns and goes on without
+	// the clusters' ports, relying on the isPortAvailable dial check and the
+	// recorded slots, so both must come back as errors rather than no clusters.
 	t.Run("executor error", func(t *testing.T) {
 		mock := executor.NewMockCommandExecutor()
 		mock.SetShouldFail(true, "k3d unavailable")
-		if used := NewK3dManager(mock, false).getUsedPortsByExistingClusters(); len(used) != 0 {
-			t.Fatalf("want empty map on executor error, got %v", used)
+		if _, err := NewK3dManager(mock, false).listK3dClusters(context.Background()); err == nil {
+			t.Fatal("want an error when k3d fails")
 		}
 	})
 	t.Run("malformed JSON", func(t *testing.T) {
 		mock := executor.NewMockCommandExecutor()
 		mock.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: "FATAL: not json"})
-		if used := NewK3dManager(mock, false).getUsedPortsByExistingClusters(); len(used) != 0 {
-			t.Fatalf("want empty map on malformed JSON, got %v", used)
+		if _, err := NewK3dManager(mock, false).listK3dClusters(context.Background()); err == nil {
+			t.Fatal("want an error on malformed JSON")
 		}
 	})
 }
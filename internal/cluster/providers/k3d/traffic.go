@@ -0,0 +1,60 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// TrafficCounter returns the bytes received plus sent by the cluster's load
+// balancer container. Host kubectl/API and ingress traffic all passes through
+// it, so an unchanged value between samples means the cluster sat idle.
+func (m *K3dManager) TrafficCounter(ctx context.Context, name string) (uint64, error) {
+	if err := models.ValidateClusterName(name); err != nil {
+		return 0, models.NewInvalidConfigError("name", name, err.Error())
+	}
+
+	result, err := m.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "docker",
+		Args:    []string{"exec", fmt.Sprintf("k3d-%s-serverlb", name), "cat", "/proc/net/dev"},
+		Timeout: sharedconfig.Timeout(sharedconfig.Query),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read load balancer traffic for cluster %s: %w", name, err)
+	}
+	return parseNetDevTotal(result.Stdout)
+}
+
+// parseNetDevTotal sums receive and transmit bytes over every non-loopback
+// interface in /proc/net/dev output.
+func parseNetDevTotal(out string) (uint64, error) {
+	var total uint64
+	found := false
+	for _, line := range strings.Split(out, "\n") {
+		iface, stats, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(iface) == "lo" {
+			continue
+		}
+		fields := strings.Fields(stats)
+		// Receive bytes is field 0, transmit bytes field 8.
+		if len(fields) < 9 {
+			continue
+		}
+		rx, rxErr := strconv.ParseUint(fields[0], 10, 64)
+		tx, txErr := strconv.ParseUint(fields[8], 10, 64)
+		if rxErr != nil || txErr != nil {
+			continue
+		}
+		total += rx + tx
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no network interfaces in /proc/net/dev output")
+	}
+	return total, nil
+}
@@ -0,0 +1,24 @@
+package k3d
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetDevTotal(t *testing.T) {
+	out := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:  999999      10    0    0    0     0          0         0   999999      10    0    0    0     0       0          0
+  eth0:    1500      12    0    0    0     0          0         0      500       7    0    0    0     0       0          0
+`
+	total, err := parseNetDevTotal(out)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2000), total, "loopback traffic must not count as activity")
+}
+
+func TestParseNetDevTotal_NoInterfaces(t *testing.T) {
+	_, err := parseNetDevTotal("Error: No such container: k3d-dev-serverlb")
+	assert.Error(t, err)
+}
@@ -4,53 +4,71 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
 	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// verifyClusterReachable checks if the cluster is reachable using native Go client
-// This reduces reliance on external kubectl binary for context management
-// Returns the *rest.Config that can be used to interact with the cluster
-func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName string) (*rest.Config, error) {
+// switchContext makes the new cluster's context the current one in its
+// kubeconfig, as k3d does for the default kubeconfig. Only a create does
+// this: reading a cluster's config leaves the current context alone.
+func (m *K3dManager) switchContext(clusterName string) error {
 	contextName := fmt.Sprintf("k3d-%s", clusterName)
 
-	var restConfig *rest.Config
+	// The file-based kubeconfig is used in WSL and in Docker Desktop native
+	// mode alike: the cluster's isolated one when it has one, the default
+	// otherwise.
+	kubeconfigPath := k8s.KubeconfigForCluster(clusterName)
 
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher),
-	// so the file-based kubeconfig is always used.
-	kubeconfigPath := m.getKubeconfigPath()
-
-	// Load the Kubeconfig file
 	config, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load kubeconfig file from %s: %w", kubeconfigPath, err)
+		return fmt.Errorf("failed to load kubeconfig file from %s: %w", kubeconfigPath, err)
 	}
-
-	// Check if the context exists
 	if _, exists := config.Contexts[contextName]; !exists {
-		return nil, fmt.Errorf("kubectl context %s not found in kubeconfig", contextName)
+		return fmt.Errorf("kubectl context %s not found in kubeconfig", contextName)
+	}
+	if config.CurrentContext == contextName {
+		return nil
 	}
-
-	// Switch the current context
 	config.CurrentContext = contextName
 	if err := clientcmd.WriteToFile(*config, kubeconfigPath); err != nil {
-		return nil, fmt.Errorf("failed to switch and write kubectl context: %w", err)
+		return fmt.Errorf("failed to switch and write kubectl context: %w", err)
 	}
 
 	if m.verbose {
 		fmt.Printf("✓ Switched kubectl context to %s\n", contextName)
 	}
+	return nil
+}
 
-	// Build rest.Config from the loaded Kubeconfig
-	restConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+// verifyClusterReachable checks if the cluster is reachable using native Go client
+// This reduces reliance on external kubectl binary for context management
+// It waits until at least required nodes are Ready (see --wait-for).
+// It only reads the kubeconfig.
+// Returns the *rest.Config that can be used to interact with the cluster
+func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName string, required int) (*rest.Config, error) {
+	contextName := fmt.Sprintf("k3d-%s", clusterName)
+	kubeconfigPath := k8s.KubeconfigForCluster(clusterName)
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig file from %s: %w", kubeconfigPath, err)
+	}
+	if _, exists := config.Contexts[contextName]; !exists {
+		return nil, fmt.Errorf("kubectl context %s not found in kubeconfig", contextName)
+	}
+
+	// Build rest.Config for the cluster's context, whatever the current one is
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
 		&clientcmd.ConfigOverrides{CurrentContext: contextName},
 	).ClientConfig()
@@ -58,15 +76,22 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 		return nil, fmt.Errorf("failed to build REST config: %w", err)
 	}
 
-	// CRITICAL FIX: Bypass TLS Verification for local k3d clusters
-	// The API server's certificate is issued to the cluster name or specific hostnames,
-	// which may not match when connecting via 127.0.0.1 from Windows/WSL2.
-	// This is safe for local development clusters and solves handshake failures.
-	// Uses Insecure=true with CA data cleared, preserving client cert authentication.
-	restConfig = sharedconfig.ApplyInsecureTLSConfig(restConfig)
+	// Verify the API server against the cluster CA. A kubeconfig without one
+	// (edited by hand, or written by an older CLI that stripped it) gets it
+	// back from k3d rather than falling back to an unverified connection.
+	if !sharedconfig.HasClusterCA(restConfig) && !sharedconfig.InsecureSkipTLSVerify() {
+		if err := m.fetchClusterCA(ctx, clusterName, restConfig); err != nil {
+			return nil, fmt.Errorf("no CA to verify the API server of %s: %w (pass --insecure-skip-tls-verify to connect without verification)", clusterName, err)
+		}
+	}
+	restConfig = sharedconfig.ApplyLocalTLSConfig(restConfig)
 
 	if m.verbose {
-		fmt.Println("✓ TLS verification bypassed for local k3d cluster (Insecure=true, auth preserved)")
+		if restConfig.Insecure {
+			fmt.Println("✓ TLS verification skipped for local k3d cluster (--insecure-skip-tls-verify)")
+		} else {
+			fmt.Println("✓ API server certificate verified against the cluster CA")
+		}
 	}
 
 	// --- PHASE 2: Verify Network Connectivity and Update Endpoint ---
@@ -82,7 +107,6 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 		port = "6550"
 	}
 
-	// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 	// Wait for TCP port to be available before attempting API calls
 	// This prevents flooding a dead port with requests on Windows/WSL2
 	tcpRetries := 10
@@ -101,6 +125,10 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 
 	// Verify cluster reachability and node readiness with polling
 	maxRetries := 15 // 15 retries * 2 seconds = 30 seconds max
+	if required > 1 {
+		// Agents register after the servers; give them another minute.
+		maxRetries = 45
+	}
 	retryDelay := 2 * time.Second
 	var lastErr error
 
@@ -125,7 +153,7 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 				if m.verbose {
 					fmt.Printf("  Cluster not ready yet (attempt %d/%d): %v\n", i+1, maxRetries, err)
 				}
-				time.Sleep(retryDelay)
+				sleepCtx(ctx, retryDelay)
 				continue
 			}
 			// Fatal error - don't retry
@@ -138,7 +166,7 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 			if m.verbose {
 				fmt.Printf("  No nodes found yet (attempt %d/%d), waiting...\n", i+1, maxRetries)
 			}
-			time.Sleep(retryDelay)
+			sleepCtx(ctx, retryDelay)
 			continue
 		}
 
@@ -154,8 +182,10 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 			}
 		}
 
-		// Success condition: Nodes exist and at least one is ready
-		if readyCount > 0 {
+		// Success condition: as many nodes Ready as --wait-for asks for.
+		// Nodes that never registered are not listed, so they count against
+		// required rather than being looked for.
+		if readyCount >= required {
 			if m.verbose {
 				fmt.Printf("  Found %d ready node(s) out of %d total\n", readyCount, len(nodes.Items))
 				fmt.Println("✓ Cluster API and nodes are ready.")
@@ -163,11 +193,11 @@ func (m *K3dManager) verifyClusterReachable(ctx context.Context, clusterName str
 			return restConfig, nil
 		}
 
-		lastErr = fmt.Errorf("no nodes in Ready state (found %d nodes, 0 ready)", len(nodes.Items))
+		lastErr = fmt.Errorf("%d of %d required nodes Ready (%d registered)", readyCount, required, len(nodes.Items))
 		if m.verbose {
-			fmt.Printf("  Nodes exist but none are Ready yet (attempt %d/%d), waiting...\n", i+1, maxRetries)
+			fmt.Printf("  %d/%d required nodes Ready (attempt %d/%d), waiting...\n", readyCount, required, i+1, maxRetries)
 		}
-		time.Sleep(retryDelay)
+		sleepCtx(ctx, retryDelay)
 	}
 
 	return nil, fmt.Errorf("cluster not reachable after %d retries (last error: %w)", maxRetries, lastErr)
@@ -221,7 +251,7 @@ func (m *K3dManager) waitForTCPPort(ctx context.Context, host string, port strin
 		if m.verbose {
 			fmt.Printf("  TCP port not ready yet (attempt %d/%d): %v\n", i+1, maxRetries, err)
 		}
-		time.Sleep(retryDelay)
+		sleepCtx(ctx, retryDelay)
 	}
 
 	return fmt.Errorf("TCP port %s not available after %d retries: %w", address, maxRetries, lastErr)
@@ -242,30 +272,42 @@ func extractHostPort(urlStr string) (string, string, error) {
 	return host, port, nil
 }
 
-// getKubeconfigPath returns the kubeconfig file path (for non-Windows platforms)
-func (m *K3dManager) getKubeconfigPath() string {
-	// Check if KUBECONFIG environment variable is set
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		return kubeconfig
+// fetchClusterCA sets restConfig's CA to the one k3d reports for the
+// cluster, for kubeconfigs that lost theirs.
+func (m *K3dManager) fetchClusterCA(ctx context.Context, clusterName string, restConfig *rest.Config) error {
+	data, err := m.GetKubeconfig(ctx, clusterName, models.ClusterTypeK3d)
+	if err != nil {
+		return err
 	}
-
-	// Default to ~/.kube/config
-	homeDir, err := os.UserHomeDir()
+	cfg, err := clientcmd.Load([]byte(data))
 	if err != nil {
-		// Fallback to clientcmd recommended path
-		return clientcmd.RecommendedHomeFile
+		return fmt.Errorf("parsing the kubeconfig k3d reported: %w", err)
 	}
-
-	return filepath.Join(homeDir, ".kube", "config")
+	contextName := fmt.Sprintf("k3d-%s", clusterName)
+	kctx, ok := cfg.Contexts[contextName]
+	if !ok {
+		return fmt.Errorf("k3d reported no context %s", contextName)
+	}
+	cluster, ok := cfg.Clusters[kctx.Cluster]
+	if !ok || len(cluster.CertificateAuthorityData) == 0 {
+		return fmt.Errorf("k3d reported no CA for %s", clusterName)
+	}
+	restConfig.CAData = cluster.CertificateAuthorityData
+	if m.verbose {
+		fmt.Printf("✓ Cluster CA for %s fetched from k3d\n", clusterName)
+	}
+	return nil
 }
 
 // cleanupStaleLockFiles removes any stale kubeconfig lock files.
-//
-// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 func (m *K3dManager) cleanupStaleLockFiles(ctx context.Context) error {
-	// Linux/macOS: Remove lock files
+	// Docker Desktop native mode: there is no bash, and k3d.exe never runs
+	// under sudo, so it leaves no lock the user cannot remove.
+	if platform.NativeWindows() {
+		return nil
+	}
 	cleanupCmd := "rm -f ~/.kube/config.lock ~/.kube/config.lock.* 2>/dev/null || true"
-	_, err := m.executor.Execute(ctx, "bash", "-c", cleanupCmd)
+	_, err := m.executor.ExecuteWithOptions(ctx, executor.ShellScript(cleanupCmd))
 	if err != nil {
 		return fmt.Errorf("failed to cleanup lock files: %w", err)
 	}
@@ -276,3 +318,13 @@ func (m *K3dManager) cleanupStaleLockFiles(ctx context.Context) error {
 
 	return nil
 }
+
+// sleepCtx waits d, or until ctx is done; the retry loops then see ctx.Err.
+func sleepCtx(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,297 @@
+package k3d
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// k3sTagsURL lists rancher/k3s tags on Docker Hub, most recently pushed
+// first; k3sTagURL looks one up.
+const (
+	k3sTagsURL = "https://hub.docker.com/v2/repositories/rancher/k3s/tags?page_size=100&ordering=last_updated"
+	k3sTagURL  = "https://hub.docker.com/v2/repositories/rancher/k3s/tags/"
+)
+
+const (
+	// catalogTTL is how long the cached tag list is trusted before refetching.
+	catalogTTL = 24 * time.Hour
+	// catalogPages bounds the fetch; 1000 tags reach back several minors.
+	catalogPages = 10
+	// suggestedMinors is how many minors an unknown-version error lists.
+	suggestedMinors = 6
+)
+
+// stableK3sTag matches release tags (v1.31.5-k3s1), not release candidates or
+// per-architecture variants.
+var stableK3sTag = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)-k3s(\d+)$`)
+
+// versionShorthand matches what --version accepts besides a full tag: 1.31,
+// v1.31, 1.31.5 or v1.31.5.
+var versionShorthand = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?$`)
+
+// k3sCatalog is the cached list of stable rancher/k3s tags.
+type k3sCatalog struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Tags      []string  `json:"tags"`
+}
+
+// Overridden in tests.
+var (
+	fetchK3sTags = fetchK3sTagsFromHub
+	lookupK3sTag = lookupK3sTagOnHub
+	catalogFile  = func() (string, error) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".openframe", "state", "k3s-versions.json"), nil
+	}
+)
+
+// ResolveK3sVersion turns a requested Kubernetes version into a rancher/k3s
+// tag. Empty and "latest" pass through. A full tag must exist; shorthand
+// (1.31, 1.31.5) resolves to the newest matching release. Without a catalog
+// (offline, no cache) a full tag is trusted and shorthand is an error.
+func ResolveK3sVersion(ctx context.Context, requested string) (string, error) {
+	requested = strings.TrimSpace(requested)
+	if requested == "" || requested == "latest" {
+		return requested, nil
+	}
+	tag := requested
+	if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+
+	tags, catalogErr := k3sTags(ctx)
+	if catalogErr != nil {
+		if stableK3sTag.MatchString(tag) {
+			return tag, nil
+		}
+		return "", fmt.Errorf("cannot resolve Kubernetes version %q without the k3s tag list (%v); pass a full tag such as %s",
+			requested, catalogErr, strings.TrimPrefix(defaultK3sImage, "rancher/k3s:"))
+	}
+
+	if stableK3sTag.MatchString(tag) {
+		for _, t := range tags {
+			if t == tag {
+				return tag, nil
+			}
+		}
+		// The catalog only reaches back so far; ask about an older tag
+		// directly, trusting it when Docker Hub cannot be asked.
+		if info, err := lookupK3sTag(ctx, tag); err != nil || info.Found {
+			return tag, nil
+		}
+	} else if m := versionShorthand.FindStringSubmatch(requested); m != nil {
+		prefix := fmt.Sprintf("v%s.%s.", m[1], m[2])
+		if m[3] != "" {
+			prefix += m[3] + "-"
+		}
+		// tags are sorted newest first, so the first match is the newest.
+		for _, t := range tags {
+			if strings.HasPrefix(t, prefix) {
+				return t, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no rancher/k3s release for Kubernetes version %q; available: %s (or shorthand such as 1.31)",
+		requested, strings.Join(newestPerMinor(tags, suggestedMinors), ", "))
+}
+
+// k3sTags returns the stable tags newest first, from the cache while it is
+// fresh, else from Docker Hub. A failed refresh falls back to a stale cache.
+func k3sTags(ctx context.Context) ([]string, error) {
+	cached, cacheErr := loadCatalog()
+	if cacheErr == nil && time.Since(cached.FetchedAt) < catalogTTL && len(cached.Tags) > 0 {
+		return cached.Tags, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	tags, err := fetchK3sTags(ctx)
+	if err == nil && len(tags) == 0 {
+		err = fmt.Errorf("no stable k3s tags found")
+	}
+	if err != nil {
+		if cacheErr == nil && len(cached.Tags) > 0 {
+			return cached.Tags, nil
+		}
+		return nil, err
+	}
+	tags = sortK3sTags(tags)
+	saveCatalog(k3sCatalog{FetchedAt: time.Now().UTC(), Tags: tags})
+	return tags, nil
+}
+
+func loadCatalog() (k3sCatalog, error) {
+	var c k3sCatalog
+	path, err := catalogFile()
+	if err != nil {
+		return c, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: fixed CLI-owned path
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// saveCatalog is best effort: a read-only home only costs a refetch.
+func saveCatalog(c k3sCatalog) {
+	path, err := catalogFile()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+	if data, err := json.Marshal(c); err == nil {
+		_ = os.WriteFile(path, data, 0o600)
+	}
+}
+
+// fetchK3sTagsFromHub pages through the Docker Hub tag list and keeps the
+// stable release tags.
+func fetchK3sTagsFromHub(ctx context.Context) ([]string, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	var tags []string
+	next := k3sTagsURL
+	for page := 0; next != "" && page < catalogPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("listing rancher/k3s tags: %w", err)
+		}
+		var body struct {
+			Next    string `json:"next"`
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("listing rancher/k3s tags: HTTP %d", resp.StatusCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing rancher/k3s tags: %w", err)
+		}
+		for _, r := range body.Results {
+			if stableK3sTag.MatchString(r.Name) {
+				tags = append(tags, r.Name)
+			}
+		}
+		next = body.Next
+	}
+	return tags, nil
+}
+
+// k3sTagInfo describes one rancher/k3s tag.
+type k3sTagInfo struct {
+	Found bool
+	// Images are the tag's linux images, one per architecture.
+	Images []k3sImage
+}
+
+// k3sImage is one platform image of a multi-arch tag.
+type k3sImage struct {
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant"`
+	OS           string `json:"os"`
+	Digest       string `json:"digest"`
+}
+
+// lookupK3sTagOnHub asks Docker Hub about one rancher/k3s tag.
+func lookupK3sTagOnHub(ctx context.Context, tag string) (k3sTagInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k3sTagURL+tag, nil)
+	if err != nil {
+		return k3sTagInfo{}, err
+	}
+	resp, err := (&http.Client{Timeout: 15 * time.Second}).Do(req)
+	if err != nil {
+		return k3sTagInfo{}, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return k3sTagInfo{}, nil
+	default:
+		return k3sTagInfo{}, fmt.Errorf("looking up rancher/k3s:%s: HTTP %d", tag, resp.StatusCode)
+	}
+	var body struct {
+		Images []k3sImage `json:"images"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return k3sTagInfo{}, fmt.Errorf("looking up rancher/k3s:%s: %w", tag, err)
+	}
+	info := k3sTagInfo{Found: true}
+	for _, img := range body.Images {
+		if img.OS == "linux" && img.Digest != "" {
+			info.Images = append(info.Images, img)
+		}
+	}
+	return info, nil
+}
+
+// sortK3sTags returns the stable tags of tags, deduplicated, newest first.
+func sortK3sTags(tags []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, t := range tags {
+		if stableK3sTag.MatchString(t) && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return k3sTagLess(out[j], out[i]) })
+	return out
+}
+
+// k3sTagLess orders stable tags by major, minor, patch, then k3s revision.
+func k3sTagLess(a, b string) bool {
+	ma, mb := stableK3sTag.FindStringSubmatch(a), stableK3sTag.FindStringSubmatch(b)
+	for i := 1; i <= 4; i++ {
+		x, _ := strconv.Atoi(ma[i])
+		y, _ := strconv.Atoi(mb[i])
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+// newestPerMinor returns the newest tag of each of the n newest minors, from
+// tags sorted newest first.
+func newestPerMinor(tags []string, n int) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		m := stableK3sTag.FindStringSubmatch(t)
+		minor := m[1] + "." + m[2]
+		if seen[minor] {
+			continue
+		}
+		seen[minor] = true
+		out = append(out, t)
+		if len(out) == n {
+			break
+		}
+	}
+	return out
+}
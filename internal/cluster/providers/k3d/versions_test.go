@@ -0,0 +1,100 @@
+package k3d
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withCatalog serves tags (plus noise Docker Hub also lists) instead of
+// Docker Hub, caches in a temp dir, and answers tag lookups with exists.
+func withCatalog(t *testing.T, fetchErr error, exists bool, tags ...string) *int {
+	t.Helper()
+	fetches := 0
+	origFetch, origLookup, origFile := fetchK3sTags, lookupK3sTag, catalogFile
+	t.Cleanup(func() { fetchK3sTags, lookupK3sTag, catalogFile = origFetch, origLookup, origFile })
+
+	dir := t.TempDir()
+	catalogFile = func() (string, error) { return filepath.Join(dir, "k3s-versions.json"), nil }
+	fetchK3sTags = func(context.Context) ([]string, error) {
+		fetches++
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		return append([]string{"v1.33.0-rc1-k3s1", "v1.32.2-k3s1-amd64"}, tags...), nil
+	}
+	lookupK3sTag = func(context.Context, string) (k3sTagInfo, error) { return k3sTagInfo{Found: exists}, nil }
+	return &fetches
+}
+
+func TestResolveK3sVersion(t *testing.T) {
+	withCatalog(t, nil, false, "v1.31.4-k3s1", "v1.32.2-k3s1", "v1.31.5-k3s1", "v1.32.2-k3s2", "v1.30.9-k3s1")
+	ctx := context.Background()
+
+	for requested, want := range map[string]string{
+		"":             "",
+		"latest":       "latest",
+		"1.31":         "v1.31.5-k3s1",
+		"v1.32":        "v1.32.2-k3s2",
+		"1.31.4":       "v1.31.4-k3s1",
+		"v1.30.9-k3s1": "v1.30.9-k3s1",
+		"1.30.9-k3s1":  "v1.30.9-k3s1",
+	} {
+		got, err := ResolveK3sVersion(ctx, requested)
+		require.NoError(t, err, requested)
+		assert.Equal(t, want, got, requested)
+	}
+
+	_, err := ResolveK3sVersion(ctx, "1.99")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: v1.32.2-k3s2, v1.31.5-k3s1, v1.30.9-k3s1")
+
+	_, err = ResolveK3sVersion(ctx, "v1.31.9-k3s1")
+	assert.Error(t, err, "a full tag Docker Hub does not have is rejected")
+
+	_, err = ResolveK3sVersion(ctx, "banana")
+	assert.Error(t, err)
+}
+
+func TestResolveK3sVersion_OlderTagAskedDirectly(t *testing.T) {
+	withCatalog(t, nil, true, "v1.32.2-k3s1")
+
+	got, err := ResolveK3sVersion(context.Background(), "v1.25.0-k3s1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.25.0-k3s1", got)
+}
+
+func TestResolveK3sVersion_CachesCatalog(t *testing.T) {
+	fetches := withCatalog(t, nil, false, "v1.32.2-k3s1")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := ResolveK3sVersion(ctx, "1.32")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, *fetches, "the catalog is fetched once per TTL")
+
+	// A stale cache is refreshed, and still served when the refresh fails.
+	saveCatalog(k3sCatalog{FetchedAt: time.Now().Add(-2 * catalogTTL), Tags: []string{"v1.31.5-k3s1"}})
+	fetchK3sTags = func(context.Context) ([]string, error) { return nil, errors.New("offline") }
+	got, err := ResolveK3sVersion(ctx, "1.31")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.31.5-k3s1", got)
+}
+
+func TestResolveK3sVersion_Offline(t *testing.T) {
+	withCatalog(t, errors.New("dial tcp: no such host"), false)
+	ctx := context.Background()
+
+	got, err := ResolveK3sVersion(ctx, "v1.31.5-k3s1")
+	require.NoError(t, err, "a full tag is trusted without a catalog")
+	assert.Equal(t, "v1.31.5-k3s1", got)
+
+	_, err = ResolveK3sVersion(ctx, "1.31")
+	assert.Error(t, err, "shorthand needs the catalog")
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 )
 
-// k3dClusterInfo represents the JSON structure returned by k3d cluster list
+// k3dClusterInfo is a cluster as k3d v5 lists it; listK3dClusters also reads
+// the older v4 shape into it.
 type k3dClusterInfo struct {
 	Name           string    `json:"name"`
 	ServersCount   int       `json:"serversCount"`
@@ -21,9 +25,24 @@ type k3dClusterInfo struct {
 type k3dNode struct {
 	Name          string                   `json:"name"`
 	Role          string                   `json:"role"`
+	Image         string                   `json:"image,omitempty"`
 	Created       time.Time                `json:"created"`
 	RuntimeLabels map[string]string        `json:"runtimeLabels,omitempty"`
 	PortMappings  map[string][]PortMapping `json:"portMappings,omitempty"`
+	State         k3dNodeState             `json:"State"`
+	IP            k3dNodeIP                `json:"IP"`
+}
+
+// k3dNodeState is the state of a node's container, which k3d fills in when
+// it lists the nodes.
+type k3dNodeState struct {
+	Running bool   `json:"Running"`
+	Status  string `json:"Status"`
+}
+
+// k3dNodeIP is a node's address on the cluster's network.
+type k3dNodeIP struct {
+	IP string `json:"IP"`
 }
 
 // PortMapping represents a port mapping for k3d nodes
@@ -33,12 +52,15 @@ type PortMapping struct {
 }
 
 // prepareKubeconfigDirectory ensures ~/.kube directory exists with proper permissions.
-//
-// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 func (m *K3dManager) prepareKubeconfigDirectory(ctx context.Context) error {
+	// Docker Desktop native mode: there is no bash, and k3d.exe creates
+	// %USERPROFILE%\.kube with the right owner itself.
+	if platform.NativeWindows() {
+		return nil
+	}
 	// Linux/macOS: Create .kube directory with proper permissions
 	createCmd := "mkdir -p ~/.kube && chmod 755 ~/.kube"
-	_, err := m.executor.Execute(ctx, "bash", "-c", createCmd)
+	_, err := m.executor.ExecuteWithOptions(ctx, executor.ShellScript(createCmd))
 	if err != nil {
 		return fmt.Errorf("failed to create .kube directory: %w", err)
 	}
@@ -52,13 +74,15 @@ func (m *K3dManager) prepareKubeconfigDirectory(ctx context.Context) error {
 
 // fixKubeconfigPermissions fixes kubeconfig file permissions.
 // This is needed because k3d running with sudo creates ~/.kube/config with root ownership.
-//
-// No Windows branch: the CLI forwards into WSL and runs as linux (see wsllauncher).
 func (m *K3dManager) fixKubeconfigPermissions(ctx context.Context) error {
+	// Docker Desktop native mode: k3d.exe never runs under sudo.
+	if platform.NativeWindows() {
+		return nil
+	}
 	// Linux/macOS: Fix permissions without changing ownership (assuming we're the owner)
 	// First check if the file exists and needs fixing
 	fixCmd := "test -f ~/.kube/config && chmod 600 ~/.kube/config || true"
-	_, err := m.executor.Execute(ctx, "bash", "-c", fixCmd)
+	_, err := m.executor.ExecuteWithOptions(ctx, executor.ShellScript(fixCmd))
 	if err != nil {
 		return fmt.Errorf("failed to fix kubeconfig permissions: %w", err)
 	}
@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/providers/k3d"
+)
+
+// orphanPruner is implemented by backends that can find and remove what
+// deleted clusters left behind.
+type orphanPruner interface {
+	FindOrphans(ctx context.Context) ([]models.Orphan, error)
+	RemoveOrphans(ctx context.Context, orphans []models.Orphan) (int, error)
+}
+
+var _ orphanPruner = (*k3d.K3dManager)(nil)
+
+// FindOrphans lists the Docker resources and files of clusters that no longer
+// exist.
+func (s *ClusterService) FindOrphans(ctx context.Context) ([]models.Orphan, error) {
+	p, ok := s.manager.(orphanPruner)
+	if !ok {
+		return nil, fmt.Errorf("prune is not supported by this cluster provider")
+	}
+	return p.FindOrphans(ctx)
+}
+
+// RemoveOrphans deletes orphans found by FindOrphans and returns how many
+// were removed.
+func (s *ClusterService) RemoveOrphans(ctx context.Context, orphans []models.Orphan) (int, error) {
+	p, ok := s.manager.(orphanPruner)
+	if !ok {
+		return 0, fmt.Errorf("prune is not supported by this cluster provider")
+	}
+	return p.RemoveOrphans(ctx, orphans)
+}
+
+// imageCacheManager is implemented by backends that keep node images in
+// Docker volumes across cluster recreations.
+type imageCacheManager interface {
+	ListImageCaches(ctx context.Context) ([]models.ImageCache, error)
+	RemoveImageCaches(ctx context.Context, caches []models.ImageCache) (int, error)
+}
+
+var _ imageCacheManager = (*k3d.K3dManager)(nil)
+
+// ListImageCaches lists the image cache volumes made by --image-cache.
+func (s *ClusterService) ListImageCaches(ctx context.Context) ([]models.ImageCache, error) {
+	c, ok := s.manager.(imageCacheManager)
+	if !ok {
+		return nil, fmt.Errorf("image caches are not supported by this cluster provider")
+	}
+	return c.ListImageCaches(ctx)
+}
+
+// RemoveImageCaches deletes caches listed by ListImageCaches and returns how
+// many were removed.
+func (s *ClusterService) RemoveImageCaches(ctx context.Context, caches []models.ImageCache) (int, error) {
+	c, ok := s.manager.(imageCacheManager)
+	if !ok {
+		return 0, fmt.Errorf("image caches are not supported by this cluster provider")
+	}
+	return c.RemoveImageCaches(ctx, caches)
+}
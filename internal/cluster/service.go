@@ -3,25 +3,34 @@ package cluster
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/addon"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/clusterstate"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/idle"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/prepull"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/prerequisites"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/provider"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/providers/k3d"
 	uiCluster "github.com/flamingo-stack/openframe-cli/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
 	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	"github.com/flamingo-stack/openframe-cli/internal/platform"
 	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/timeline"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
 	"github.com/pterm/pterm"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -46,13 +55,22 @@ type ApplicationCleaner interface {
 // ClusterService provides cluster configuration and management operations
 // This handles cluster lifecycle operations and configuration management
 type ClusterService struct {
-	manager    provider.Provider
-	executor   executor.CommandExecutor
+	manager  provider.Provider
+	executor executor.CommandExecutor
+	// detector decides which backend owns a cluster name; nil falls back to
+	// the manager's own (k3d-only) detection.
+	detector   provider.Detector
 	suppressUI bool // Suppress interactive UI elements for automation
 	// appCleaner, when set, lets cleanup remove ArgoCD Applications before the
 	// Helm uninstall and strip their finalizers afterwards. Optional: nil means
 	// the Helm/namespace phases run as before (the CRs may then stay stuck).
 	appCleaner ApplicationCleaner
+	// prePullCharts are the charts whose images creation pre-pulls; see
+	// WithPrePullCharts.
+	prePullCharts []prepull.Chart
+	// minKubernetesVersion is the oldest Kubernetes the charts support; see
+	// WithMinKubernetesVersion.
+	minKubernetesVersion string
 }
 
 // WithApplicationCleaner injects the ArgoCD-backed application cleaner used by
@@ -62,6 +80,22 @@ func (s *ClusterService) WithApplicationCleaner(c ApplicationCleaner) *ClusterSe
 	return s
 }
 
+// WithPrePullCharts sets the charts whose images CreateCluster pulls on the
+// host and imports into the new nodes. Injected for the same reason as the
+// application cleaner. Returns the service for chaining.
+func (s *ClusterService) WithPrePullCharts(charts ...prepull.Chart) *ClusterService {
+	s.prePullCharts = charts
+	return s
+}
+
+// WithMinKubernetesVersion sets the oldest Kubernetes the charts support, which
+// list and status warn below; "" warns about nothing. Injected for the same
+// reason as the application cleaner. Returns the service for chaining.
+func (s *ClusterService) WithMinKubernetesVersion(version string) *ClusterService {
+	s.minKubernetesVersion = version
+	return s
+}
+
 // isTerminalEnvironment checks if we're running in a proper terminal
 func isTerminalEnvironment() bool {
 	// Check if stdout is a terminal
@@ -77,6 +111,7 @@ func NewClusterService(exec executor.CommandExecutor) *ClusterService {
 	return &ClusterService{
 		manager:    manager,
 		executor:   exec,
+		detector:   provider.DefaultRegistry(manager, exec),
 		suppressUI: false,
 	}
 }
@@ -87,6 +122,7 @@ func NewClusterServiceSuppressed(exec executor.CommandExecutor) *ClusterService
 	return &ClusterService{
 		manager:    manager,
 		executor:   exec,
+		detector:   provider.DefaultRegistry(manager, exec),
 		suppressUI: true,
 	}
 }
@@ -94,71 +130,91 @@ func NewClusterServiceSuppressed(exec executor.CommandExecutor) *ClusterService
 // CreateCluster handles cluster creation operations
 // Returns the *rest.Config for the created cluster that can be used to interact with it
 func (s *ClusterService) CreateCluster(ctx context.Context, config models.ClusterConfig) (*rest.Config, error) {
+	// An attached external cluster owns the name; a k3d cluster beside it
+	// would make every name lookup ambiguous.
+	if _, ok := lookupExternalCluster(config.Name); ok {
+		return nil, models.NewExternalClusterError(config.Name, "create")
+	}
+
 	// Check if cluster already exists
 	if existingInfo, err := s.manager.GetClusterStatus(ctx, config.Name); err == nil {
-		// Cluster already exists - show friendly message
-
-		// Show warning for existing cluster
-		pterm.Warning.Printf("Cluster '%s' already exists!\n", pterm.Cyan(config.Name))
-		pterm.DefaultBasicText.Println()
+		switch config.Existing {
+		case models.ExistingFail:
+			s.showExistingCluster(existingInfo)
+			return nil, models.NewClusterExistsError(config.Name)
+		case models.ExistingAdopt:
+			return s.adoptCluster(ctx, config)
+		case models.ExistingRecreate:
+			pterm.Info.Printf("Cluster '%s' already exists; deleting it to create it again\n", config.Name)
+			if err := s.DeleteCluster(ctx, config.Name, existingInfo.Type, true); err != nil {
+				return nil, fmt.Errorf("failed to delete cluster '%s' to recreate it: %w", config.Name, err)
+			}
+		default:
+			s.showExistingCluster(existingInfo)
 
-		boxContent := fmt.Sprintf(
-			"NAME:     %s\n"+
-				"TYPE:     %s\n"+
-				"STATUS:   %s\n"+
-				"NODES:    %d\n"+
-				"NETWORK:  k3d-%s",
-			pterm.Bold.Sprint(existingInfo.Name),
-			strings.ToUpper(string(existingInfo.Type)),
-			pterm.Green("Running"),
-			existingInfo.NodeCount,
-			existingInfo.Name,
-		)
-
-		pterm.DefaultBox.
-			WithTitle(" ⚠️  Cluster Already Running  ⚠️ ").
-			WithTitleTopCenter().
-			Println(boxContent)
-
-		// Show what user can do (suppress for automation)
-		if !s.suppressUI {
-			pterm.DefaultBasicText.Println()
-			pterm.Info.Printf("What would you like to do?\n")
-			pterm.DefaultBasicText.Printf("  • Check status: openframe cluster status %s\n", config.Name)
-			pterm.DefaultBasicText.Printf("  • Delete first: openframe cluster delete %s\n", config.Name)
-			pterm.DefaultBasicText.Printf("  • Use different name: openframe cluster create my-new-cluster\n")
-		}
+			// Show what user can do (suppress for automation)
+			if !s.suppressUI {
+				pterm.DefaultBasicText.Println()
+				pterm.Info.Printf("What would you like to do?\n")
+				pterm.DefaultBasicText.Printf("  • Check status: openframe cluster status %s\n", config.Name)
+				pterm.DefaultBasicText.Printf("  • Delete first: openframe cluster delete %s\n", config.Name)
+				pterm.DefaultBasicText.Printf("  • Use different name: openframe cluster create my-new-cluster\n")
+			}
 
-		// Return the rest.Config for the existing cluster
-		restConfig, err := s.manager.GetRestConfig(ctx, config.Name)
-		if err != nil {
-			return nil, fmt.Errorf("cluster exists but failed to get REST config: %w", err)
+			// Return the rest.Config for the existing cluster
+			restConfig, err := s.manager.GetRestConfig(ctx, config.Name)
+			if err != nil {
+				return nil, fmt.Errorf("cluster exists but failed to get REST config: %w", err)
+			}
+			return restConfig, nil // Exit gracefully without error
 		}
-		return restConfig, nil // Exit gracefully without error
 	}
 
 	// Cluster doesn't exist, proceed with creation
-	var sp *spinner.Spinner
+	telemetry.EnterPhase(telemetry.PhaseCluster)
+	var pull *prepull.Pull
+	if config.Type == models.ClusterTypeK3d && !config.SkipImagePrePull {
+		// Pull the stack's images on the host while k3d creates the nodes.
+		pull = prepull.Start(ctx, s.executor, s.prePullChartsFor(config))
+	}
+	progress := &createProgress{}
 	if !s.suppressUI {
-		sp = spinner.New()
-		sp.Start(fmt.Sprintf("Creating %s cluster '%s'...", config.Type, config.Name))
+		progress.sp = spinner.New()
+		progress.sp.Start(fmt.Sprintf("Creating %s cluster '%s'...", config.Type, config.Name))
+		// Show the provider's latest progress line (e.g. k3d's "Creating node
+		// ...") next to the spinner, so a multi-minute create does not look hung.
+		ctx = executor.WithProgress(ctx, progress.sp.SetDetail)
 	} else {
 		// In non-interactive mode, just show a simple info message
 		pterm.Info.Printf("Creating %s cluster '%s'...\n", config.Type, config.Name)
 	}
+	ctx = models.WithCreatePhases(ctx, progress.enter)
 
 	restConfig, err := s.manager.CreateCluster(ctx, config)
 	if err != nil {
-		if sp != nil {
-			sp.Fail(fmt.Sprintf("Failed to create cluster '%s'", config.Name))
+		progress.fail(fmt.Sprintf("Failed to create cluster '%s'", config.Name))
+		if pull != nil {
+			pull.Cancel()
 		}
 		return nil, err
 	}
+	timeline.Mark("cluster created")
+	recordLabels(config.Name, config.Labels)
 
-	if sp != nil {
-		sp.Success(fmt.Sprintf("Cluster '%s' created successfully", config.Name))
-	} else {
-		pterm.Success.Printf("Cluster '%s' created successfully\n", config.Name)
+	progress.succeed(fmt.Sprintf("Cluster '%s' created successfully", config.Name))
+	if pull != nil {
+		s.importPrePulled(ctx, config.Name, pull)
+		timeline.Mark("images pre-pulled")
+	}
+	if config.Type == models.ClusterTypeK3d && config.Ingress == models.IngressNginx {
+		if err := s.installIngressNginx(ctx, config.Name); err != nil {
+			return nil, err
+		}
+		timeline.Mark("ingress installed")
+	}
+	if config.Type == models.ClusterTypeK3d && len(config.Addons) > 0 {
+		s.installAddons(ctx, config.Name, config.Addons)
+		timeline.Mark("add-ons installed")
 	}
 
 	// Get and display cluster status
@@ -174,6 +230,13 @@ func (s *ClusterService) CreateCluster(ctx context.Context, config models.Cluste
 
 // DeleteCluster handles cluster deletion business logic
 func (s *ClusterService) DeleteCluster(ctx context.Context, name string, clusterType models.ClusterType, force bool) error {
+	if clusterType == models.ClusterTypeExternal {
+		return models.NewExternalClusterError(name, "delete")
+	}
+	if clusterType == models.ClusterTypeK3d {
+		s.runAddonPreRemoveHooks(ctx, name)
+	}
+
 	// Show deletion progress
 	var sp *spinner.Spinner
 	if !s.suppressUI {
@@ -195,33 +258,107 @@ func (s *ClusterService) DeleteCluster(ctx context.Context, name string, cluster
 		sp.Stop() // Stop spinner without message - UI layer will show success
 	}
 
+	// A deleted cluster must not be "resumed" by the next command.
+	_ = idle.ClearPaused(name)
+	_ = addon.Forget(name)
+	_ = clusterstate.Forget(name)
+
 	// Don't show summary here - let the UI layer handle it
 
 	return nil
 }
 
+// RenameCluster gives a cluster a new name. An attached cluster only has its
+// record renamed; a k3d cluster is recreated on its own data (see
+// k3d.K3dManager.RenameCluster). The CLI's records of the cluster — its
+// add-ons, the environments running on it, the idle marker — follow it.
+func (s *ClusterService) RenameCluster(ctx context.Context, oldName, newName string, clusterType models.ClusterType) error {
+	if err := models.ValidateClusterName(newName); err != nil {
+		return models.NewInvalidConfigError("name", newName, err.Error())
+	}
+	if clusterType == models.ClusterTypeExternal {
+		if err := k8s.RenameExternalCluster(oldName, newName); err != nil {
+			return err
+		}
+	} else {
+		var sp *spinner.Spinner
+		if !s.suppressUI {
+			sp = spinner.New()
+			sp.Start(fmt.Sprintf("Renaming %s cluster '%s' to '%s'...", clusterType, oldName, newName))
+		} else {
+			pterm.Info.Printf("Renaming %s cluster '%s' to '%s'...\n", clusterType, oldName, newName)
+		}
+		if err := s.manager.RenameCluster(ctx, oldName, newName); err != nil {
+			if sp != nil {
+				sp.Fail(fmt.Sprintf("Failed to rename cluster '%s'", oldName))
+			}
+			return err
+		}
+		if sp != nil {
+			sp.Stop()
+		}
+	}
+
+	if err := idle.RenamePaused(oldName, newName); err != nil {
+		pterm.Warning.Printf("Could not move the idle marker of %s: %v\n", oldName, err)
+	}
+	if err := addon.Rename(oldName, newName); err != nil {
+		pterm.Warning.Printf("Could not move the add-on records of %s: %v\n", oldName, err)
+	}
+	if err := clusterstate.Rename(oldName, newName); err != nil {
+		pterm.Warning.Printf("Could not move the labels of %s: %v\n", oldName, err)
+	}
+	if err := environment.RenameCluster(oldName, newName); err != nil {
+		pterm.Warning.Printf("Could not point the environments on %s at %s: %v\n", oldName, newName, err)
+	}
+	return nil
+}
+
 // ListClusters handles cluster listing business logic
 func (s *ClusterService) ListClusters() ([]models.ClusterInfo, error) {
 	ctx := context.Background()
-	return s.manager.ListAllClusters(ctx)
+	clusters, err := s.manager.ListAllClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return withRecordedLabels(append(clusters, externalClusterInfos()...)), nil
 }
 
 // GetClusterStatus handles cluster status business logic
 func (s *ClusterService) GetClusterStatus(name string) (models.ClusterInfo, error) {
+	if ext, ok := lookupExternalCluster(name); ok {
+		return externalClusterInfo(ext), nil
+	}
 	ctx := context.Background()
-	return s.manager.GetClusterStatus(ctx, name)
+	info, err := s.manager.GetClusterStatus(ctx, name)
+	if err != nil {
+		return info, err
+	}
+	return withRecordedLabels([]models.ClusterInfo{info})[0], nil
 }
 
 // GetRestConfig returns the rest.Config for an existing cluster
 func (s *ClusterService) GetRestConfig(name string) (*rest.Config, error) {
-	ctx := context.Background()
+	return s.RestConfig(context.Background(), name)
+}
+
+// RestConfig returns the rest.Config for an existing cluster, waiting for it
+// within ctx. It only reads the kubeconfig.
+func (s *ClusterService) RestConfig(ctx context.Context, name string) (*rest.Config, error) {
+	if ext, ok := lookupExternalCluster(name); ok {
+		return externalRestConfig(ext)
+	}
 	return s.manager.GetRestConfig(ctx, name)
 }
 
-// DetectClusterType handles cluster type detection business logic
+// DetectClusterType reports which backend (k3d, kind, minikube or a plain
+// kubeconfig context) owns the named cluster, asking all of them at once.
 func (s *ClusterService) DetectClusterType(name string) (models.ClusterType, error) {
 	ctx := context.Background()
-	return s.manager.DetectClusterType(ctx, name)
+	if s.detector == nil {
+		return s.manager.DetectClusterType(ctx, name)
+	}
+	return s.detector.DetectClusterType(ctx, name)
 }
 
 // CleanupCluster handles cluster cleanup business logic. The returned
@@ -231,6 +368,8 @@ func (s *ClusterService) CleanupCluster(ctx context.Context, name string, cluste
 	switch clusterType {
 	case models.ClusterTypeK3d:
 		return s.cleanupK3dCluster(ctx, name, verbose, force)
+	case models.ClusterTypeExternal:
+		return models.CleanupResult{}, models.NewExternalClusterError(name, "clean up")
 	default:
 		return models.CleanupResult{}, fmt.Errorf("cleanup not supported for cluster type: %s", clusterType)
 	}
@@ -267,7 +406,7 @@ func (s *ClusterService) cleanupK3dCluster(ctx context.Context, clusterName stri
 	// 2. Clean up Helm releases (including ArgoCD) — pinned to this cluster's
 	// kube-context. Without the pin helm operates on the kubeconfig's CURRENT
 	// context, which may be a different (even production) cluster.
-	kubeContext := k8s.ResolveContextForCluster(k8s.DefaultKubeconfigPath(), clusterName)
+	kubeContext := k8s.ResolveContextForCluster(k8s.KubeconfigForCluster(clusterName), clusterName)
 	removed, err := s.cleanupHelmReleases(ctx, kubeContext, verbose, force)
 	result.ReleasesRemoved = removed
 	if err != nil {
@@ -330,7 +469,9 @@ func (s *ClusterService) cleanupHelmReleases(ctx context.Context, kubeContext st
 		return 0, fmt.Errorf("refusing to cleanup Helm releases without an explicit kube-context")
 	}
 
-	result, err := s.executor.Execute(ctx, "helm", "list", "--all-namespaces", "--output", "json", "--kube-context", kubeContext)
+	kubeArgs := k8s.KubeconfigArgs(k8s.KubeconfigForContext(kubeContext))
+	listArgs := append([]string{"list", "--all-namespaces", "--output", "json", "--kube-context", kubeContext}, kubeArgs...)
+	result, err := s.executor.Execute(ctx, "helm", listArgs...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to list Helm releases: %w", err)
 	}
@@ -370,6 +511,7 @@ func (s *ClusterService) cleanupHelmReleases(ctx context.Context, kubeContext st
 		// finalizer-stripping phase that runs right after this one (see
 		// cleanupK3dCluster step 3), mirroring `app uninstall`.
 		args := []string{"uninstall", release.Name, "--namespace", release.Namespace, "--kube-context", kubeContext, "--no-hooks"}
+		args = append(args, kubeArgs...)
 		if force {
 			// Add even more aggressive flags when force is enabled
 			args = append(args, "--ignore-not-found")
@@ -438,7 +580,7 @@ func (s *ClusterService) cleanupKubernetesResources(ctx context.Context, cluster
 	if err != nil {
 		return 0, fmt.Errorf("failed to get cluster config for cleanup: %w", err)
 	}
-	client, err := kubernetes.NewForConfig(sharedconfig.ApplyInsecureTLSConfig(restConfig))
+	client, err := kubernetes.NewForConfig(sharedconfig.ApplyLocalTLSConfig(restConfig))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
@@ -614,8 +756,8 @@ func (s *ClusterService) isK3dWorkerNode(nodeName, clusterName string) bool {
 // records it, or "" when it cannot be determined.
 //
 // It replaces a hardcoded "https://0.0.0.0:6550" printed in three places. 6550
-// is only the *preferred* API port: findPort falls back to 6551, then 6552,
-// when the port is taken (see providers/k3d/ports.go). So on any machine with
+// is only the first cluster's API port: every other cluster gets its own port
+// slot, 6551, 6552 and on (see providers/k3d/ports.go). So on any machine with
 // a second cluster the box pointed the user at a different cluster's API
 // server. The kubeconfig records the port that was actually bound.
 func (s *ClusterService) apiServerEndpoint(ctx context.Context, name string) string {
@@ -682,6 +824,11 @@ func (s *ClusterService) showNextSteps(clusterName string) {
 func (s *ClusterService) ShowClusterStatus(name string, detailed bool, skipApps bool, verbose bool) error {
 	ctx := context.Background()
 
+	if ext, ok := lookupExternalCluster(name); ok {
+		s.displayExternalClusterStatus(ctx, ext)
+		return nil
+	}
+
 	// Get cluster status
 	status, err := s.manager.GetClusterStatus(ctx, name)
 	if err != nil {
@@ -760,11 +907,16 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 	}
 
 	endpoint := s.apiServerEndpoint(context.Background(), status.Name)
+	version := status.K8sVersion
+	if version == "" {
+		version = "Unknown"
+	}
 	boxContent := fmt.Sprintf(
 		"NAME:     %s\n"+
 			"TYPE:     %s\n"+
 			"STATUS:   %s\n"+
 			"NODES:    %d\n"+
+			"VERSION:  Kubernetes %s\n"+
 			"NETWORK:  k3d-%s\n"+
 			"%s\n"+
 			"AGE:      %s",
@@ -772,6 +924,7 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 		strings.ToUpper(string(status.Type)),
 		statusDisplay,
 		status.NodeCount,
+		version,
 		status.Name,
 		apiServerLine(endpoint),
 		ageStr,
@@ -781,6 +934,9 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 		WithTitle(" 📊 Cluster Status ").
 		WithTitleTopCenter().
 		Println(boxContent)
+	if warning := models.KubernetesVersionWarning(status.K8sVersion, s.minKubernetesVersion); warning != "" {
+		pterm.Warning.Println(warning)
+	}
 
 	// Network information
 	pterm.DefaultBasicText.Println()
@@ -789,28 +945,41 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 	if endpoint != "" {
 		pterm.DefaultBasicText.Printf("  API Server: %s\n", endpoint)
 	}
-	pterm.DefaultBasicText.Printf("  Kubeconfig: %s\n", k8s.DefaultKubeconfigPath())
+	if status.Ports != nil {
+		pterm.DefaultBasicText.Printf("  Ports:      API %d, HTTP %d, HTTPS %d\n", status.Ports.API, status.Ports.HTTP, status.Ports.HTTPS)
+	}
+	pterm.DefaultBasicText.Printf("  Kubeconfig: %s\n", k8s.KubeconfigForCluster(status.Name))
 
-	// --detailed lists the nodes the provider actually reported. It used to
-	// print fixed CPU/Memory/Storage figures ("0.2 cores (10%)", "512MB (5%)",
-	// "2.1GB (local)") that were never measured — identical for every cluster,
-	// on every machine, at every point in time. The CLI does not collect
-	// metrics, so it says so and points at the tool that does.
-	if detailed {
-		pterm.DefaultBasicText.Println()
-		pterm.Info.Printf("🖥️ Nodes:\n")
-		if len(status.Nodes) == 0 {
-			pterm.DefaultBasicText.Printf("  (none reported)\n")
-		}
-		for _, node := range status.Nodes {
-			pterm.DefaultBasicText.Printf("  %-28s %-8s %s\n", node.Name, node.Role, node.Status)
+	// The nodes the provider actually reported; --detailed adds their usage
+	// as measured by the metrics API — never made-up figures.
+	pterm.DefaultBasicText.Println()
+	pterm.Info.Printf("🖥️ Nodes:\n")
+	nodes := make([]uiCluster.NodeDisplayInfo, len(status.Nodes))
+	for i, node := range status.Nodes {
+		nodes[i] = uiCluster.NodeDisplayInfo{
+			Name:      node.Name,
+			Role:      node.Role,
+			Status:    node.Status,
+			Image:     node.Image,
+			IP:        node.IP,
+			CreatedAt: node.CreatedAt,
 		}
+	}
+	uiCluster.NewDisplayService().ShowNodeTable(nodes, os.Stdout)
 
+	if detailed {
 		pterm.DefaultBasicText.Println()
 		pterm.Info.Printf("💾 Resource Usage:\n")
-		pterm.DefaultBasicText.Printf("  Not collected by the CLI. With metrics-server installed:\n")
-		pterm.DefaultBasicText.Printf("    kubectl top nodes\n")
-		pterm.DefaultBasicText.Printf("    kubectl top pods -A\n")
+		usage, err := s.nodeUsage(context.Background(), status.Name)
+		switch {
+		case errors.Is(err, k8s.ErrMetricsUnavailable):
+			pterm.DefaultBasicText.Printf("  Not available: the cluster has no metrics-server\n")
+		case err != nil:
+			pterm.DefaultBasicText.Printf("  Not available: %v\n", err)
+		}
+		for _, u := range usage {
+			pterm.DefaultBasicText.Printf("  %-28s CPU %-8s Memory %dMi\n", u.Name, fmt.Sprintf("%dm", u.CPUMillis), u.MemBytes/(1<<20))
+		}
 	}
 
 	// Management commands
@@ -822,6 +991,19 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 	pterm.DefaultBasicText.Printf("  Get cluster info:    kubectl cluster-info\n")
 }
 
+// nodeUsage reads the nodes' current usage from the metrics API.
+func (s *ClusterService) nodeUsage(ctx context.Context, name string) ([]k8s.Usage, error) {
+	restConfig, err := s.manager.GetRestConfig(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	client, err := k8s.NewKubeClient(restConfig, s.executor, k8s.KubeconfigForCluster(name), "")
+	if err != nil {
+		return nil, err
+	}
+	return client.TopNodes(ctx)
+}
+
 // DisplayClusterList handles cluster list display logic
 func (s *ClusterService) DisplayClusterList(clusters []models.ClusterInfo, quiet bool, verbose bool) error {
 	if len(clusters) == 0 {
@@ -847,17 +1029,24 @@ func (s *ClusterService) DisplayClusterList(clusters []models.ClusterInfo, quiet
 	displayClusters := make([]uiCluster.ClusterDisplayInfo, len(clusters))
 	for i, cluster := range clusters {
 		displayClusters[i] = uiCluster.ClusterDisplayInfo{
-			Name:      cluster.Name,
-			Type:      string(cluster.Type),
-			Status:    cluster.Status,
-			NodeCount: cluster.NodeCount,
-			CreatedAt: cluster.CreatedAt,
+			Name:       cluster.Name,
+			Type:       string(cluster.Type),
+			Status:     cluster.Status,
+			NodeCount:  cluster.NodeCount,
+			K8sVersion: cluster.K8sVersion,
+			CreatedAt:  cluster.CreatedAt,
+			Labels:     labels.Set(cluster.Labels).String(),
 		}
 	}
 
 	// Use UI service to display the list
 	displayService := uiCluster.NewDisplayService()
 	displayService.ShowClusterList(displayClusters, os.Stdout)
+	for _, cluster := range clusters {
+		if warning := models.KubernetesVersionWarning(cluster.K8sVersion, s.minKubernetesVersion); warning != "" {
+			pterm.Warning.Printf("%s: %s\n", cluster.Name, warning)
+		}
+	}
 
 	// Show additional info if verbose
 	if verbose {
@@ -869,8 +1058,17 @@ func (s *ClusterService) DisplayClusterList(clusters []models.ClusterInfo, quiet
 }
 
 // CreateClusterWithPrerequisitesNonInteractive creates a cluster with non-interactive support
-// Returns the *rest.Config for the created cluster
-func CreateClusterWithPrerequisitesNonInteractive(ctx context.Context, clusterName string, verbose bool, nonInteractive bool) (*rest.Config, error) {
+// Returns the *rest.Config for the created cluster. The images of prePull are
+// pre-pulled into it (see WithPrePullCharts).
+func CreateClusterWithPrerequisitesNonInteractive(ctx context.Context, clusterName string, verbose bool, nonInteractive bool, prePull ...prepull.Chart) (*rest.Config, error) {
+	return CreateClusterFromConfigWithPrerequisites(ctx, models.ClusterConfig{Name: clusterName}, verbose, nonInteractive, prePull...)
+}
+
+// CreateClusterFromConfigWithPrerequisites is
+// CreateClusterWithPrerequisitesNonInteractive for a cluster described by
+// config, such as a named environment's profile. An empty name, type or node
+// count takes the bootstrap default.
+func CreateClusterFromConfigWithPrerequisites(ctx context.Context, config models.ClusterConfig, verbose bool, nonInteractive bool, prePull ...prepull.Chart) (*rest.Config, error) {
 	// Show logo first, then check prerequisites (consistent with individual commands)
 	ui.ShowLogo()
 
@@ -878,10 +1076,12 @@ func CreateClusterWithPrerequisitesNonInteractive(ctx context.Context, clusterNa
 	// environment detection (CI / piped stdin) so a forgotten --non-interactive
 	// in CI cannot reach an interactive confirm that hangs the job — same rule
 	// as the chart-side gate (chart_service.go).
+	telemetry.EnterPhase(telemetry.PhasePrereqs)
 	installer := prerequisites.NewInstaller()
 	if err := installer.CheckAndInstallNonInteractive(nonInteractive || ui.IsNonInteractive()); err != nil {
 		return nil, err
 	}
+	timeline.Mark("prerequisites ready")
 
 	// Create service directly without using utils to avoid circular import
 	exec := executor.NewRealCommandExecutor(false, verbose) // dryRun = false
@@ -892,17 +1092,18 @@ func CreateClusterWithPrerequisitesNonInteractive(ctx context.Context, clusterNa
 	} else {
 		service = NewClusterService(exec)
 	}
+	service.WithPrePullCharts(prePull...)
 
-	// Build cluster configuration
-	config := models.ClusterConfig{
-		Name:       clusterName,
-		Type:       models.ClusterTypeK3d,
-		K8sVersion: "",
-		NodeCount:  4,
-	}
-	if clusterName == "" {
+	// Fill in the bootstrap defaults
+	if config.Name == "" {
 		config.Name = "openframe-dev" // default name
 	}
+	if config.Type == "" {
+		config.Type = models.ClusterTypeK3d
+	}
+	if config.NodeCount == 0 {
+		config.NodeCount = 4
+	}
 
 	// Create the cluster and return the rest.Config
 	return service.CreateCluster(ctx, config)
@@ -2,9 +2,15 @@ package cluster
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/idle"
 	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/internal/environment"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
 )
 
@@ -41,6 +47,7 @@ func TestNewClusterService(t *testing.T) {
 }
 
 func TestClusterService_CreateCluster(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the port slot is recorded under ~/.openframe
 	exec := createTestExecutor()
 	service := NewClusterService(exec)
 
@@ -140,6 +147,7 @@ func TestClusterService_DisplayClusterList(t *testing.T) {
 }
 
 func TestClusterService_WithRealExecutor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the port slot is recorded under ~/.openframe
 	// Test with real executor (dry-run mode)
 	exec := executor.NewRealCommandExecutor(true, false) // dry-run mode
 	service := NewClusterService(exec)
@@ -160,3 +168,56 @@ func TestClusterService_WithRealExecutor(t *testing.T) {
 	// Dry-run might still error if k3d is not available, which is acceptable in tests
 	_ = err
 }
+
+func TestClusterService_RenameExternalCluster(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBECONFIG", "")
+	kubeconfig := filepath.Join(home, "shared.yaml")
+	if err := os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\ncontexts:\n- name: shared-ctx\n  context: {cluster: c, user: u}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8s.AttachExternalCluster(k8s.ExternalCluster{Name: "shared", Context: "shared-ctx", Kubeconfig: kubeconfig}); err != nil {
+		t.Fatal(err)
+	}
+	if err := environment.Set(environment.Environment{Name: "demo", Cluster: "shared"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := idle.MarkPaused("shared"); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewClusterService(executor.NewMockCommandExecutor())
+	if err := service.RenameCluster(context.Background(), "shared", "staging", models.ClusterTypeExternal); err != nil {
+		t.Fatalf("RenameCluster: %v", err)
+	}
+
+	if c, ok := k8s.LookupExternalCluster("staging"); !ok || c.Context != "shared-ctx" {
+		t.Errorf("want staging attached with context shared-ctx, got %+v (%v)", c, ok)
+	}
+	if env, err := environment.Get("demo"); err != nil || env.ClusterName() != "staging" {
+		t.Errorf("want environment demo on staging, got %+v (%v)", env, err)
+	}
+	if paused, _ := idle.PausedClusters(); len(paused) != 1 || paused[0] != "staging" {
+		t.Errorf("want the idle marker moved to staging, got %v", paused)
+	}
+	if err := service.RenameCluster(context.Background(), "staging", "Not_Valid", models.ClusterTypeExternal); err == nil {
+		t.Error("want an invalid new name rejected")
+	}
+}
+
+func TestClusterService_CreateCluster_ExistingFails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	service := NewClusterService(createTestExecutor())
+
+	_, err := service.CreateCluster(context.Background(), models.ClusterConfig{
+		Name:      "test-cluster",
+		Type:      models.ClusterTypeK3d,
+		NodeCount: 1,
+		Existing:  models.ExistingFail,
+	})
+	var exists models.ErrClusterExists
+	if !errors.As(err, &exists) || exists.Name != "test-cluster" {
+		t.Fatalf("CreateCluster over an existing cluster: got %v, want ErrClusterExists", err)
+	}
+}
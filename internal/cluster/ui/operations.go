@@ -337,6 +337,31 @@ func (ui *OperationsUI) ShowConfigurationSummary(config models.ClusterConfig, dr
 		pterm.DefaultBasicText.Printf("Version: %s\n", config.K8sVersion)
 	}
 
+	if config.Ingress != "" && config.Ingress != models.IngressNone {
+		pterm.DefaultBasicText.Printf("Ingress: %s\n", config.Ingress)
+	}
+	if config.GPUs != "" {
+		pterm.DefaultBasicText.Printf("   GPUs: %s\n", config.GPUs)
+	}
+	if len(config.Addons) > 0 {
+		pterm.DefaultBasicText.Printf(" Addons: %s\n", strings.Join(config.Addons, ", "))
+	}
+	if config.WaitFor != "" && config.WaitFor != models.WaitForAll {
+		pterm.DefaultBasicText.Printf("   Wait: %s node(s) Ready\n", config.WaitFor)
+	}
+	for _, mirror := range config.RegistryMirrors {
+		pterm.DefaultBasicText.Printf(" Mirror: %s -> %s\n", mirror.Source, mirror.Endpoint)
+	}
+	for _, label := range config.NodeLabels {
+		pterm.DefaultBasicText.Printf("  Label: %s=%s @ %s\n", label.Key, label.Value, strings.Join(label.NodeFilters, ";"))
+	}
+	for _, taint := range config.NodeTaints {
+		pterm.DefaultBasicText.Printf("  Taint: %s @ %s\n", taint, strings.Join(taint.NodeFilters, ";"))
+	}
+	for _, arg := range config.K3sArgs {
+		pterm.DefaultBasicText.Printf("    k3s: %s @ %s\n", arg.Arg, strings.Join(arg.NodeFilters, ";"))
+	}
+
 	pterm.DefaultBasicText.Println()
 
 	if dryRun {
@@ -15,15 +15,22 @@ type ClusterDisplayInfo struct {
 	Type      string
 	Status    string
 	NodeCount int
-	CreatedAt time.Time
-	Nodes     []NodeDisplayInfo
+	// K8sVersion is the Kubernetes version the cluster runs; "" when unknown.
+	K8sVersion string
+	CreatedAt  time.Time
+	// Labels are the cluster's labels as "key=value,..."; "" without any.
+	Labels string
+	Nodes  []NodeDisplayInfo
 }
 
 // NodeDisplayInfo represents node information for display
 type NodeDisplayInfo struct {
-	Name   string
-	Role   string
-	Status string
+	Name      string
+	Role      string
+	Status    string
+	Image     string
+	IP        string
+	CreatedAt time.Time
 }
 
 // DisplayService handles all cluster-related UI display operations
@@ -44,7 +51,7 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 
 	// Create table data
 	tableData := pterm.TableData{
-		{"NAME", "TYPE", "STATUS", "NODES", "CREATED"},
+		{"NAME", "TYPE", "STATUS", "NODES", "KUBERNETES", "CREATED", "LABELS"},
 	}
 
 	for _, clusterInfo := range clusters {
@@ -54,7 +61,9 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 			clusterInfo.Type,
 			statusColor(clusterInfo.Status),
 			fmt.Sprintf("%d", clusterInfo.NodeCount),
+			orDash(clusterInfo.K8sVersion),
 			clusterInfo.CreatedAt.Format("2006-01-02 15:04"),
+			orDash(clusterInfo.Labels),
 		})
 	}
 
@@ -65,16 +74,74 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 		for i, row := range tableData {
 			if i == 0 {
 				// Header row
-				fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %s\n", row[0], row[1], row[2], row[3], row[4])
+				fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %-11s %-16s %s\n", row[0], row[1], row[2], row[3], row[4], row[5], row[6])
 				continue
 			}
 			// Data rows - need to account for styled text by using different spacing
-			fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %s\n",
+			fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %-11s %-16s %s\n",
 				pterm.RemoveColorFromString(row[0]), // Remove color codes for alignment
 				row[1],
 				pterm.RemoveColorFromString(row[2]), // Remove color codes for alignment
 				row[3],
-				row[4])
+				row[4],
+				row[5],
+				row[6])
+		}
+	}
+}
+
+// ShowNodeTable displays a cluster's nodes: role, container state, image,
+// address and age — what to look at when pods do not land where expected.
+func (s *DisplayService) ShowNodeTable(nodes []NodeDisplayInfo, out io.Writer) {
+	if len(nodes) == 0 {
+		fmt.Fprintln(out, "  (none reported)")
+		return
+	}
+
+	tableData := pterm.TableData{
+		{"NAME", "ROLE", "STATE", "IMAGE", "IP", "AGE"},
+	}
+	for _, node := range nodes {
+		tableData = append(tableData, []string{
+			node.Name,
+			node.Role,
+			sharedUI.GetStatusColor(node.Status)(orDash(node.Status)),
+			orDash(node.Image),
+			orDash(node.IP),
+			shortAge(node.CreatedAt, time.Now()),
+		})
+	}
+
+	table := pterm.DefaultTable.WithHasHeader().WithData(tableData).WithWriter(out)
+	if err := table.Render(); err != nil {
+		for _, row := range tableData {
+			fmt.Fprintf(out, "%-28s %-13s %-8s %-32s %-15s %s\n",
+				row[0], row[1], pterm.RemoveColorFromString(row[2]), row[3], row[4], row[5])
 		}
 	}
 }
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// shortAge is the time since t the way kubectl shows it: 45s, 12m, 5h, 3d.
+func shortAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
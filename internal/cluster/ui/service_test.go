@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,11 +25,13 @@ func TestDisplayService_ShowClusterList(t *testing.T) {
 
 		clusters := []ClusterDisplayInfo{
 			{
-				Name:      "cluster1",
-				Type:      "k3d",
-				Status:    "running",
-				NodeCount: 3,
-				CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				Name:       "cluster1",
+				Type:       "k3d",
+				Status:     "running",
+				NodeCount:  3,
+				K8sVersion: "v1.31.5",
+				CreatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				Labels:     "team=payments",
 			},
 			{
 				Name:      "cluster2",
@@ -50,6 +53,10 @@ func TestDisplayService_ShowClusterList(t *testing.T) {
 		assert.Contains(t, output, "stopped")
 		assert.Contains(t, output, "3")
 		assert.Contains(t, output, "5")
+		assert.Contains(t, output, "KUBERNETES")
+		assert.Contains(t, output, "v1.31.5")
+		assert.Contains(t, output, "LABELS")
+		assert.Contains(t, output, "team=payments")
 	})
 
 	t.Run("displays no clusters message when list is empty", func(t *testing.T) {
@@ -115,6 +122,38 @@ func TestDisplayService_ShowClusterList(t *testing.T) {
 	})
 }
 
+func TestDisplayService_ShowNodeTable(t *testing.T) {
+	t.Run("renders every node with its details", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewDisplayService().ShowNodeTable([]NodeDisplayInfo{
+			{Name: "k3d-dev-server-0", Role: "server", Status: "running", Image: "rancher/k3s:v1.31.4-k3s1", IP: "172.18.0.3", CreatedAt: time.Now().Add(-3 * time.Hour)},
+			{Name: "k3d-dev-agent-0", Role: "agent", Status: "exited"},
+		}, &buf)
+
+		out := pterm.RemoveColorFromString(buf.String())
+		for _, want := range []string{"NAME", "ROLE", "STATE", "IMAGE", "IP", "AGE",
+			"k3d-dev-server-0", "server", "running", "rancher/k3s:v1.31.4-k3s1", "172.18.0.3", "3h",
+			"k3d-dev-agent-0", "exited"} {
+			assert.Contains(t, out, want)
+		}
+	})
+
+	t.Run("says so when no nodes are reported", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewDisplayService().ShowNodeTable(nil, &buf)
+		assert.Contains(t, buf.String(), "(none reported)")
+	})
+}
+
+func TestShortAge(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "-", shortAge(time.Time{}, now))
+	assert.Equal(t, "45s", shortAge(now.Add(-45*time.Second), now))
+	assert.Equal(t, "12m", shortAge(now.Add(-12*time.Minute), now))
+	assert.Equal(t, "30h", shortAge(now.Add(-30*time.Hour), now))
+	assert.Equal(t, "3d", shortAge(now.Add(-72*time.Hour), now))
+}
+
 func TestClusterDisplayInfo(t *testing.T) {
 	t.Run("creates cluster display info with all fields", func(t *testing.T) {
 		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
@@ -0,0 +1,201 @@
+// Package component maps an OpenFrame application or component name to the
+// workloads ArgoCD manages for it, and those workloads to their pods.
+package component
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Select picks the workloads of name: the ArgoCD application called name or,
+// failing that, the workloads called name (a component such as
+// "openframe-api").
+func Select(workloads []argocd.Workload, name string) ([]argocd.Workload, error) {
+	var byApp, byName []argocd.Workload
+	apps := map[string]bool{}
+	for _, w := range workloads {
+		apps[w.App] = true
+		if w.App == name {
+			byApp = append(byApp, w)
+		}
+		if w.Name == name {
+			byName = append(byName, w)
+		}
+	}
+	if len(byApp) > 0 {
+		return byApp, nil
+	}
+	if len(byName) > 0 {
+		return byName, nil
+	}
+	known := make([]string, 0, len(apps))
+	for a := range apps {
+		known = append(known, a)
+	}
+	sort.Strings(known)
+	return nil, fmt.Errorf("no application or component named %q (applications with workloads: %s)", name, strings.Join(known, ", "))
+}
+
+// Names lists the application and workload names of workloads, each once, for
+// shell completion.
+func Names(workloads []argocd.Workload) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, w := range workloads {
+		for _, n := range []string{w.App, w.Name} {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
+
+// listConcurrency bounds how many namespaces are read from the API server at
+// once: enough that a large install is not read one namespace after another,
+// few enough not to flood an API server reached through WSL.
+const listConcurrency = 4
+
+// Pods returns the pods of workloads, each once, sorted by name. Each
+// namespace's pods and workloads are listed once and matched locally, and the
+// namespaces are read concurrently, so the cost no longer grows with the
+// number of workloads.
+func Pods(ctx context.Context, cs kubernetes.Interface, workloads []argocd.Workload) ([]corev1.Pod, error) {
+	byNamespace := map[string][]argocd.Workload{}
+	var namespaces []string
+	for _, w := range workloads {
+		if _, ok := byNamespace[w.Namespace]; !ok {
+			namespaces = append(namespaces, w.Namespace)
+		}
+		byNamespace[w.Namespace] = append(byNamespace[w.Namespace], w)
+	}
+
+	results := make([][]corev1.Pod, len(namespaces))
+	errs := make([]error, len(namespaces))
+	sem := make(chan struct{}, listConcurrency)
+	var wg sync.WaitGroup
+	for i, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = namespacePods(ctx, cs, ns, byNamespace[ns])
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	var out []corev1.Pod
+	for i := range namespaces {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		for _, p := range results[i] {
+			if !seen[p.Namespace+"/"+p.Name] {
+				seen[p.Namespace+"/"+p.Name] = true
+				out = append(out, p)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// namespacePods returns the pods in ns that belong to any of workloads, all of
+// which live in ns.
+func namespacePods(ctx context.Context, cs kubernetes.Interface, ns string, workloads []argocd.Workload) ([]corev1.Pod, error) {
+	selectors, err := workloadSelectors(ctx, cs, ns, workloads)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := cs.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods in %s: %w", ns, err)
+	}
+	var out []corev1.Pod
+	for _, p := range pods.Items {
+		for _, sel := range selectors {
+			if sel.Matches(labels.Set(p.Labels)) {
+				out = append(out, p)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// workloadSelectors returns the pod selector of each of workloads, all in ns,
+// listing every workload kind they use once.
+func workloadSelectors(ctx context.Context, cs kubernetes.Interface, ns string, workloads []argocd.Workload) ([]labels.Selector, error) {
+	byKind := map[string]map[string]*metav1.LabelSelector{}
+	var out []labels.Selector
+	for _, w := range workloads {
+		known, ok := byKind[w.Kind]
+		if !ok {
+			var err error
+			if known, err = listSelectors(ctx, cs, ns, w.Kind); err != nil {
+				return nil, err
+			}
+			byKind[w.Kind] = known
+		}
+		sel, ok := known[w.Name]
+		if !ok {
+			return nil, fmt.Errorf("reading %s %s/%s: not found", w.Kind, w.Namespace, w.Name)
+		}
+		s, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return nil, fmt.Errorf("selector of %s %s/%s: %w", w.Kind, w.Namespace, w.Name, err)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// listSelectors returns the pod selector of every workload of kind in ns, by
+// name.
+func listSelectors(ctx context.Context, cs kubernetes.Interface, ns, kind string) (map[string]*metav1.LabelSelector, error) {
+	selectors := map[string]*metav1.LabelSelector{}
+	apps := cs.AppsV1()
+	var err error
+	switch kind {
+	case "Deployment":
+		var list *appsv1.DeploymentList
+		if list, err = apps.Deployments(ns).List(ctx, metav1.ListOptions{}); err == nil {
+			for _, o := range list.Items {
+				selectors[o.Name] = o.Spec.Selector
+			}
+		}
+	case "StatefulSet":
+		var list *appsv1.StatefulSetList
+		if list, err = apps.StatefulSets(ns).List(ctx, metav1.ListOptions{}); err == nil {
+			for _, o := range list.Items {
+				selectors[o.Name] = o.Spec.Selector
+			}
+		}
+	case "DaemonSet":
+		var list *appsv1.DaemonSetList
+		if list, err = apps.DaemonSets(ns).List(ctx, metav1.ListOptions{}); err == nil {
+			for _, o := range list.Items {
+				selectors[o.Name] = o.Spec.Selector
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing %ss in %s: %w", kind, ns, err)
+	}
+	return selectors, nil
+}
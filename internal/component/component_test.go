@@ -0,0 +1,103 @@
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var workloads = []argocd.Workload{
+	{App: "openframe-api", Kind: "Deployment", Namespace: "openframe", Name: "openframe-api"},
+	{App: "kafka", Kind: "StatefulSet", Namespace: "datasources", Name: "kafka"},
+	{App: "kafka", Kind: "Deployment", Namespace: "datasources", Name: "kafka-ui"},
+}
+
+func TestSelect_ByAppThenComponent(t *testing.T) {
+	got, err := Select(workloads, "kafka")
+	require.NoError(t, err)
+	assert.Len(t, got, 2, "an application name selects all of its workloads")
+
+	got, err = Select(workloads, "kafka-ui")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "kafka-ui", got[0].Name)
+
+	_, err = Select(workloads, "nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka, openframe-api")
+}
+
+func TestNames_AppsAndWorkloadsOnce(t *testing.T) {
+	assert.Equal(t, []string{"openframe-api", "kafka", "kafka-ui"}, Names(workloads))
+}
+
+func TestPods_FollowTheWorkloadSelector(t *testing.T) {
+	labeled := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "datasources", Labels: map[string]string{"app": app}}}
+	}
+	cs := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "datasources"},
+			Spec:       appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kafka"}}},
+		},
+		labeled("kafka-1", "kafka"), labeled("kafka-0", "kafka"), labeled("kafka-ui-x", "kafka-ui"),
+	)
+
+	pods, err := Pods(context.Background(), cs, workloads[1:2])
+	require.NoError(t, err)
+	require.Len(t, pods, 2)
+	assert.Equal(t, "kafka-0", pods[0].Name)
+	assert.Equal(t, "kafka-1", pods[1].Name)
+
+	_, err = Pods(context.Background(), cs, workloads[2:])
+	assert.Error(t, err, "a workload that does not exist is an error")
+}
+
+func TestPods_ListsEachNamespaceOnce(t *testing.T) {
+	pod := func(ns, name, app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": app}}}
+	}
+	deployment := func(ns, name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}},
+		}
+	}
+	cs := fake.NewSimpleClientset(
+		deployment("openframe", "openframe-api"), deployment("datasources", "kafka-ui"),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "datasources"},
+			Spec:       appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kafka"}}},
+		},
+		pod("openframe", "api-0", "openframe-api"), pod("datasources", "kafka-0", "kafka"),
+		pod("datasources", "ui-0", "kafka-ui"), pod("datasources", "other-0", "other"),
+	)
+
+	pods, err := Pods(context.Background(), cs, workloads)
+	require.NoError(t, err)
+	var names []string
+	for _, p := range pods {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"api-0", "kafka-0", "ui-0"}, names)
+
+	lists := map[string]int{}
+	for _, a := range cs.Actions() {
+		assert.Equal(t, "list", a.GetVerb(), "workloads are listed, not fetched one by one")
+		lists[a.GetNamespace()+"/"+a.GetResource().Resource]++
+	}
+	assert.Equal(t, map[string]int{
+		"openframe/deployments":    1,
+		"openframe/pods":           1,
+		"datasources/deployments":  1,
+		"datasources/statefulsets": 1,
+		"datasources/pods":         1,
+	}, lists)
+}
@@ -0,0 +1,294 @@
+// Package connections finds how to reach the datastores the OpenFrame charts
+// deploy — MongoDB, Redis, Kafka and the like — and whatever else the caller
+// names, such as the ports of the installed add-ons: their Services, the node
+// ports, load balancers and Ingress hosts that expose them, and the Secret
+// keys their pods take credentials from.
+package connections
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kind is a datastore the cheat-sheet knows: a Service is taken for it when its
+// name contains Match and it exposes Port.
+type Kind struct {
+	Name   string
+	Match  string
+	Port   int32
+	Scheme string // URL scheme of its clients, "" for plain host:port
+}
+
+// Known lists the datastores recognized, in the order they are printed.
+var Known = []Kind{
+	{Name: "MongoDB", Match: "mongo", Port: 27017, Scheme: "mongodb"},
+	{Name: "Redis", Match: "redis", Port: 6379, Scheme: "redis"},
+	{Name: "Kafka", Match: "kafka", Port: 9092},
+	{Name: "Cassandra", Match: "cassandra", Port: 9042},
+	{Name: "PostgreSQL", Match: "postgres", Port: 5432, Scheme: "postgresql"},
+	{Name: "NATS", Match: "nats", Port: 4222, Scheme: "nats"},
+	{Name: "Pinot", Match: "pinot", Port: 9000, Scheme: "http"},
+	{Name: "ZooKeeper", Match: "zookeeper", Port: 2181},
+}
+
+// Credential is one Secret key a datastore's pods read, e.g. its root
+// password. Value is only filled in when secrets were asked for.
+type Credential struct {
+	Env    string `json:"env,omitempty"` // the variable the pod reads it as
+	Secret string `json:"secret"`
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+}
+
+// Connection is how to reach one datastore Service.
+type Connection struct {
+	Kind      string       `json:"kind"`
+	Namespace string       `json:"namespace"`
+	Service   string       `json:"service"`
+	Port      int32        `json:"port"`
+	InCluster string       `json:"inCluster"`
+	NodePort  int32        `json:"nodePort,omitempty"`
+	External  []string     `json:"external,omitempty"` // load balancer addresses and Ingress URLs
+	Secrets   []Credential `json:"credentials,omitempty"`
+}
+
+// PortForward is the kubectl command that makes c reachable on localhost.
+func (c Connection) PortForward() string {
+	return fmt.Sprintf("kubectl -n %s port-forward svc/%s %d:%d", c.Namespace, c.Service, c.Port, c.Port)
+}
+
+// Discover lists the connections of the known datastores, and of the extra
+// kinds after them, in the cluster, sorted by kind and then namespace/name.
+// Headless Services and ArgoCD's own are left out. Secret values are only
+// returned when showSecrets is set.
+func Discover(ctx context.Context, cs kubernetes.Interface, showSecrets bool, extra ...Kind) ([]Connection, error) {
+	svcs, err := cs.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing services: %w", err)
+	}
+	ingresses, err := cs.NetworkingV1().Ingresses(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing ingresses: %w", err)
+	}
+
+	var out []Connection
+	kinds := append(append([]Kind(nil), Known...), extra...)
+	order := map[string]int{}
+	for i, k := range kinds {
+		if _, ok := order[k.Name]; !ok {
+			order[k.Name] = i
+		}
+	}
+	for i := range svcs.Items {
+		svc := &svcs.Items[i]
+		if svc.Namespace == argocd.ArgoCDNamespace || svc.Namespace == metav1.NamespaceSystem || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			continue
+		}
+		matches := classify(svc, kinds)
+		if len(matches) == 0 {
+			continue
+		}
+		secrets, err := credentials(ctx, cs, svc, showSecrets)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			kind, port := m.kind, m.port
+			c := Connection{
+				Kind:      kind.Name,
+				Namespace: svc.Namespace,
+				Service:   svc.Name,
+				Port:      port.Port,
+				InCluster: address(kind, fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, svc.Namespace), port.Port),
+				Secrets:   secrets,
+			}
+			if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				c.NodePort = port.NodePort
+			}
+			for _, lb := range svc.Status.LoadBalancer.Ingress {
+				host := lb.IP
+				if lb.Hostname != "" {
+					host = lb.Hostname
+				}
+				c.External = append(c.External, address(kind, host, port.Port))
+			}
+			c.External = append(c.External, IngressURLs(ingresses.Items, svc)...)
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if order[out[i].Kind] != order[out[j].Kind] {
+			return order[out[i].Kind] < order[out[j].Kind]
+		}
+		return out[i].Namespace+"/"+out[i].Service < out[j].Namespace+"/"+out[j].Service
+	})
+	return out, nil
+}
+
+// match is a kind a Service serves, on one of its ports.
+type match struct {
+	kind Kind
+	port corev1.ServicePort
+}
+
+// classify matches svc against kinds. A Service may serve several kinds on
+// different ports, as MailHog does SMTP and its web UI.
+func classify(svc *corev1.Service, kinds []Kind) []match {
+	var matches []match
+	for _, k := range kinds {
+		if !strings.Contains(svc.Name, k.Match) {
+			continue
+		}
+		for _, p := range svc.Spec.Ports {
+			if p.Port == k.Port || p.TargetPort.IntValue() == int(k.Port) {
+				matches = append(matches, match{k, p})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func address(k Kind, host string, port int32) string {
+	if k.Scheme == "" {
+		return fmt.Sprintf("%s:%d", host, port)
+	}
+	return fmt.Sprintf("%s://%s:%d", k.Scheme, host, port)
+}
+
+// IngressURLs returns the URLs of the Ingress rules that route to svc.
+func IngressURLs(ingresses []networkingv1.Ingress, svc *corev1.Service) []string {
+	var urls []string
+	for _, ing := range ingresses {
+		if ing.Namespace != svc.Namespace {
+			continue
+		}
+		tls := map[string]bool{}
+		for _, t := range ing.Spec.TLS {
+			for _, h := range t.Hosts {
+				tls[h] = true
+			}
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil || rule.Host == "" {
+				continue
+			}
+			for _, p := range rule.HTTP.Paths {
+				if p.Backend.Service == nil || p.Backend.Service.Name != svc.Name {
+					continue
+				}
+				scheme := "http"
+				if tls[rule.Host] {
+					scheme = "https"
+				}
+				urls = append(urls, scheme+"://"+rule.Host+p.Path)
+			}
+		}
+	}
+	return urls
+}
+
+// credentialWords mark the Secret keys and variables worth listing.
+var credentialWords = []string{"password", "passwd", "user", "token", "auth", "secret"}
+
+func isCredential(name string) bool {
+	name = strings.ToLower(name)
+	for _, w := range credentialWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// credentials collects the Secret keys the pods behind svc read through env
+// and envFrom, each once. Only the first pod is looked at: the replicas of a
+// datastore share their spec.
+func credentials(ctx context.Context, cs kubernetes.Interface, svc *corev1.Service, showSecrets bool) ([]Credential, error) {
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	pods, err := cs.CoreV1().Pods(svc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods of %s/%s: %w", svc.Namespace, svc.Name, err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+	pod := pods.Items[0]
+
+	secrets := map[string]*corev1.Secret{}
+	get := func(name string) (*corev1.Secret, error) {
+		if s, ok := secrets[name]; ok {
+			return s, nil
+		}
+		s, err := cs.CoreV1().Secrets(svc.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("reading secret %s/%s: %w", svc.Namespace, name, err)
+		}
+		secrets[name] = s
+		return s, nil
+	}
+
+	var out []Credential
+	seen := map[string]bool{}
+	add := func(c Credential) error {
+		if seen[c.Secret+"/"+c.Key] || !(isCredential(c.Key) || isCredential(c.Env)) {
+			return nil
+		}
+		seen[c.Secret+"/"+c.Key] = true
+		if showSecrets {
+			s, err := get(c.Secret)
+			if err != nil {
+				return err
+			}
+			c.Value = string(s.Data[c.Key])
+		}
+		out = append(out, c)
+		return nil
+	}
+	for _, ctr := range pod.Spec.Containers {
+		for _, env := range ctr.Env {
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			ref := env.ValueFrom.SecretKeyRef
+			if err := add(Credential{Env: env.Name, Secret: ref.Name, Key: ref.Key}); err != nil {
+				return nil, err
+			}
+		}
+		for _, from := range ctr.EnvFrom {
+			if from.SecretRef == nil {
+				continue
+			}
+			// The keys of an envFrom secret are only known by reading it; one
+			// that cannot be read is left out rather than failing the list.
+			s, err := get(from.SecretRef.Name)
+			if err != nil {
+				continue
+			}
+			keys := make([]string, 0, len(s.Data))
+			for k := range s.Data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if err := add(Credential{Env: from.Prefix + k, Secret: s.Name, Key: k}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,114 @@
+package connections
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func service(ns, name string, typ corev1.ServiceType, port, nodePort int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: corev1.ServiceSpec{
+			Type:     typ,
+			Selector: map[string]string{"app": name},
+			Ports:    []corev1.ServicePort{{Port: port, NodePort: nodePort, TargetPort: intstr.FromInt32(port)}},
+		},
+	}
+}
+
+func objects() []runtime.Object {
+	mongo := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mongodb-0", Namespace: "datasources", Labels: map[string]string{"app": "mongodb"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "mongodb",
+			Env: []corev1.EnvVar{
+				{Name: "MONGODB_ROOT_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "mongodb"}, Key: "mongodb-root-password"}}},
+				{Name: "TZ", Value: "UTC"},
+			},
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "mongodb-extra"}}}},
+		}}},
+	}
+	headless := service("datasources", "mongodb-headless", corev1.ServiceTypeClusterIP, 27017, 0)
+	headless.Spec.ClusterIP = corev1.ClusterIPNone
+	prefix := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-ui", Namespace: "datasources"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"kafka.localhost"}}},
+			Rules: []networkingv1.IngressRule{{Host: "kafka.localhost", IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+					Path: "/", PathType: &prefix,
+					Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "kafka"}},
+				}}},
+			}}},
+		},
+	}
+	return []runtime.Object{
+		service("datasources", "mongodb", corev1.ServiceTypeClusterIP, 27017, 0),
+		headless,
+		service("datasources", "kafka", corev1.ServiceTypeNodePort, 9092, 30092),
+		service("datasources", "redis-metrics", corev1.ServiceTypeClusterIP, 9121, 0),
+		service("argocd", "argocd-redis", corev1.ServiceTypeClusterIP, 6379, 0),
+		mongo,
+		ingress,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: "datasources"},
+			Data: map[string][]byte{"mongodb-root-password": []byte("s3cret")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mongodb-extra", Namespace: "datasources"},
+			Data: map[string][]byte{"MONGODB_USERNAME": []byte("openframe"), "MONGODB_DATABASE": []byte("openframe")}},
+	}
+}
+
+func TestDiscover_MasksSecretsByDefault(t *testing.T) {
+	got, err := Discover(context.Background(), fake.NewClientset(objects()...), false)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "headless, unknown-port and ArgoCD services are left out")
+
+	assert.Equal(t, Connection{
+		Kind: "MongoDB", Namespace: "datasources", Service: "mongodb", Port: 27017,
+		InCluster: "mongodb://mongodb.datasources.svc.cluster.local:27017",
+		Secrets: []Credential{
+			{Env: "MONGODB_ROOT_PASSWORD", Secret: "mongodb", Key: "mongodb-root-password"},
+			{Env: "MONGODB_USERNAME", Secret: "mongodb-extra", Key: "MONGODB_USERNAME"},
+		},
+	}, got[0])
+	assert.Equal(t, Connection{
+		Kind: "Kafka", Namespace: "datasources", Service: "kafka", Port: 9092,
+		InCluster: "kafka.datasources.svc.cluster.local:9092",
+		NodePort:  30092,
+		External:  []string{"https://kafka.localhost/"},
+	}, got[1])
+	assert.Equal(t, "kubectl -n datasources port-forward svc/kafka 9092:9092", got[1].PortForward())
+}
+
+func TestDiscover_ShowSecrets(t *testing.T) {
+	got, err := Discover(context.Background(), fake.NewClientset(objects()...), true)
+	require.NoError(t, err)
+	require.Len(t, got[0].Secrets, 2)
+	assert.Equal(t, "s3cret", got[0].Secrets[0].Value)
+	assert.Equal(t, "openframe", got[0].Secrets[1].Value)
+}
+
+func TestDiscover_ServiceWithSeveralKinds(t *testing.T) {
+	mailhog := service("openframe-addons", "mailhog", corev1.ServiceTypeClusterIP, 8025, 0)
+	mailhog.Spec.Ports = append(mailhog.Spec.Ports, corev1.ServicePort{Port: 1025, TargetPort: intstr.FromInt32(1025)})
+
+	got, err := Discover(context.Background(), fake.NewClientset(mailhog), false,
+		Kind{Name: "MailHog SMTP", Match: "mailhog", Port: 1025},
+		Kind{Name: "MailHog UI", Match: "mailhog", Port: 8025, Scheme: "http"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "MailHog SMTP", got[0].Kind)
+	assert.Equal(t, "mailhog.openframe-addons.svc.cluster.local:1025", got[0].InCluster)
+	assert.Equal(t, "MailHog UI", got[1].Kind)
+	assert.Equal(t, "http://mailhog.openframe-addons.svc.cluster.local:8025", got[1].InCluster)
+}
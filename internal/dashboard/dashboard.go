@@ -0,0 +1,218 @@
+// Package dashboard finds the web UIs an OpenFrame cluster serves — ArgoCD,
+// and Grafana or the OpenFrame UI when the applications that install them are
+// there — and makes one reachable from this machine: through the Ingress that
+// routes to it, or else a port-forward, with the credentials to sign in.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/connections"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// instanceLabel is the label ArgoCD tracks the resources of an application by.
+const instanceLabel = "app.kubernetes.io/instance"
+
+// nameLabel is the component label the charts put on their Services.
+const nameLabel = "app.kubernetes.io/name"
+
+// Annotation marks a Service as the one serving a dashboard: its value is the
+// dashboard's Name. It wins over the component label, for charts that label
+// their Services some other way.
+const Annotation = "openframe.io/dashboard"
+
+// Kind is a dashboard the CLI knows how to open.
+type Kind struct {
+	Name  string // on the command line: openframe dashboard <Name>
+	Title string
+	// App is what the name of the ArgoCD application installing it contains;
+	// "" for ArgoCD itself, which is always there.
+	App string
+	// Component is the app.kubernetes.io/name label of its Service, Ports its
+	// web ports in order of preference, and Scheme what they speak.
+	Component string
+	Ports     []int32
+	Scheme    string
+	// Login is where its sign-in credentials are kept; nil when it has its
+	// own accounts.
+	Login *Login
+}
+
+// Login is a user name and password kept in a Secret next to the dashboard.
+type Login struct {
+	// Secret is the Secret's name; "" is the name of the dashboard's Service,
+	// as the Grafana chart names it.
+	Secret string
+	// User is a fixed user name, else UserKey the Secret key holding it.
+	User        string
+	UserKey     string
+	PasswordKey string
+}
+
+// Known lists the dashboards, in the order they are listed.
+var Known = []Kind{
+	{
+		Name: "argocd", Title: "ArgoCD",
+		Component: "argocd-server", Ports: []int32{443, 80}, Scheme: "https",
+		Login: &Login{Secret: "argocd-initial-admin-secret", User: "admin", PasswordKey: "password"},
+	},
+	{
+		Name: "grafana", Title: "Grafana", App: "grafana",
+		Component: "grafana", Ports: []int32{80, 3000}, Scheme: "http",
+		Login: &Login{UserKey: "admin-user", PasswordKey: "admin-password"},
+	},
+	{
+		Name: "openframe", Title: "OpenFrame UI", App: "openframe-ui",
+		Component: "openframe-ui", Ports: []int32{80, 8080, 3000}, Scheme: "http",
+	},
+}
+
+// Lookup returns the known dashboard called name.
+func Lookup(name string) (Kind, error) {
+	var names []string
+	for _, k := range Known {
+		if k.Name == name {
+			return k, nil
+		}
+		names = append(names, k.Name)
+	}
+	return Kind{}, fmt.Errorf("unknown dashboard %q (want one of: %s)", name, strings.Join(names, ", "))
+}
+
+// Dashboard is a dashboard installed in the cluster.
+type Dashboard struct {
+	Kind        string `json:"kind"`
+	Title       string `json:"title"`
+	Application string `json:"application,omitempty"`
+	Namespace   string `json:"namespace"`
+	Service     string `json:"service"`
+	Port        int32  `json:"port"`
+	Scheme      string `json:"scheme"`
+	// URLs are the Ingress routes to it; without one it is reached through a
+	// port-forward.
+	URLs []string `json:"urls,omitempty"`
+}
+
+// Discover lists the known dashboards installed in the cluster: ArgoCD, and
+// the others whose application is among apps. A dashboard whose Service is
+// not found is left out.
+func Discover(ctx context.Context, cs kubernetes.Interface, apps []argocd.Application) ([]Dashboard, error) {
+	var out []Dashboard
+	for _, k := range Known {
+		d, ok, err := Find(ctx, cs, k, apps)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// Find looks for dashboard k: the Service of the ArgoCD server, or one of the
+// Services of the application installing k, tracked by ArgoCD's instance
+// label. ok is false when it is not installed.
+func Find(ctx context.Context, cs kubernetes.Interface, k Kind, apps []argocd.Application) (Dashboard, bool, error) {
+	if k.App == "" {
+		return findService(ctx, cs, k, "", argocd.ArgoCDNamespace, metav1.ListOptions{})
+	}
+	for _, app := range apps {
+		if !strings.Contains(app.Name, k.App) || app.Namespace == "" {
+			continue
+		}
+		d, ok, err := findService(ctx, cs, k, app.Name, app.Namespace, metav1.ListOptions{LabelSelector: instanceLabel + "=" + app.Name})
+		if err != nil || ok {
+			return d, ok, err
+		}
+	}
+	return Dashboard{}, false, nil
+}
+
+// findService picks the Service of k among the listed ones: the one annotated
+// as k, else the one with k's component label. It must serve one of k's ports.
+func findService(ctx context.Context, cs kubernetes.Interface, k Kind, app, namespace string, opts metav1.ListOptions) (Dashboard, bool, error) {
+	svcs, err := cs.CoreV1().Services(namespace).List(ctx, opts)
+	if err != nil {
+		return Dashboard{}, false, fmt.Errorf("listing services in %s: %w", namespace, err)
+	}
+	var (
+		best *corev1.Service
+		port int32
+	)
+	for i := range svcs.Items {
+		svc := &svcs.Items[i]
+		p := webPort(svc, k.Ports)
+		if p == 0 {
+			continue
+		}
+		if svc.Annotations[Annotation] == k.Name {
+			best, port = svc, p
+			break
+		}
+		if best == nil && svc.Labels[nameLabel] == k.Component {
+			best, port = svc, p
+		}
+	}
+	if best == nil {
+		return Dashboard{}, false, nil
+	}
+	ingresses, err := cs.NetworkingV1().Ingresses(best.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Dashboard{}, false, fmt.Errorf("listing ingresses in %s: %w", best.Namespace, err)
+	}
+	return Dashboard{
+		Kind:        k.Name,
+		Title:       k.Title,
+		Application: app,
+		Namespace:   best.Namespace,
+		Service:     best.Name,
+		Port:        port,
+		Scheme:      k.Scheme,
+		URLs:        connections.IngressURLs(ingresses.Items, best),
+	}, true, nil
+}
+
+// webPort returns the first of ports svc serves, or 0.
+func webPort(svc *corev1.Service, ports []int32) int32 {
+	for _, want := range ports {
+		for _, p := range svc.Spec.Ports {
+			if p.Port == want {
+				return want
+			}
+		}
+	}
+	return 0
+}
+
+// Credentials reads the user name and password to sign in to d with. ok is
+// false when it has none the CLI knows of.
+func Credentials(ctx context.Context, cs kubernetes.Interface, d Dashboard) (user, password string, ok bool, err error) {
+	k, err := Lookup(d.Kind)
+	if err != nil || k.Login == nil {
+		return "", "", false, err
+	}
+	name := k.Login.Secret
+	if name == "" {
+		name = d.Service
+	}
+	secret, err := cs.CoreV1().Secrets(d.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", false, fmt.Errorf("reading %s credentials from secret %s/%s: %w", d.Title, d.Namespace, name, err)
+	}
+	user = k.Login.User
+	if k.Login.UserKey != "" {
+		user = string(secret.Data[k.Login.UserKey])
+	}
+	password = string(secret.Data[k.Login.PasswordKey])
+	if user == "" || password == "" {
+		return "", "", false, fmt.Errorf("secret %s/%s has no %s credentials", d.Namespace, name, d.Title)
+	}
+	return user, password, true, nil
+}
@@ -0,0 +1,174 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func service(ns, name, app, component string, ports ...int32) *corev1.Service {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{}}}
+	if app != "" {
+		svc.Labels[instanceLabel] = app
+	}
+	if component != "" {
+		svc.Labels[nameLabel] = component
+	}
+	for _, p := range ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Port: p, TargetPort: intstr.FromInt32(p)})
+	}
+	return svc
+}
+
+func secret(ns, name string, data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}, Data: map[string][]byte{}}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func TestDiscover(t *testing.T) {
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ui", Namespace: "openframe"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"localhost"}}},
+			Rules: []networkingv1.IngressRule{{Host: "localhost", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{Path: "/", PathType: &pathType, Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{Name: "openframe-ui"}}}},
+			}}}},
+		},
+	}
+	cs := fake.NewClientset(
+		service(argocd.ArgoCDNamespace, "argocd-repo-server", "", "argocd-repo-server", 8081),
+		service(argocd.ArgoCDNamespace, "argocd-server", "", "argocd-server", 80, 443),
+		service("observability", "grafana", "grafana", "grafana", 80),
+		service("observability", "grafana-other", "", "", 80),
+		service("openframe", "openframe-api", "openframe-api", "openframe-api", 8080),
+		service("openframe", "openframe-ui", "openframe-ui", "openframe-ui", 80),
+		ingress,
+	)
+	apps := []argocd.Application{
+		{Name: "grafana", Namespace: "observability"},
+		{Name: "openframe-ui", Namespace: "openframe"},
+		{Name: "openframe-api", Namespace: "openframe"},
+	}
+
+	found, err := Discover(context.Background(), cs, apps)
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, Dashboard{Kind: "argocd", Title: "ArgoCD", Namespace: "argocd", Service: "argocd-server", Port: 443, Scheme: "https"}, found[0])
+	assert.Equal(t, "grafana", found[1].Service)
+	assert.Equal(t, "grafana", found[1].Application)
+	assert.Equal(t, "openframe-ui", found[2].Service)
+	assert.Equal(t, []string{"https://localhost/"}, found[2].URLs)
+
+	// Without the applications only ArgoCD is there.
+	found, err = Discover(context.Background(), cs, nil)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "argocd", found[0].Kind)
+}
+
+func TestFind_MatchesOnlyLabelledServices(t *testing.T) {
+	grafana, _ := Lookup("grafana")
+	apps := []argocd.Application{{Name: "grafana", Namespace: "observability"}}
+
+	// A Service of the application on a web port is not enough.
+	cs := fake.NewClientset(service("observability", "grafana-image-renderer", "grafana", "", 80))
+	_, ok, err := Find(context.Background(), cs, grafana, apps)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The annotation wins over the component label.
+	annotated := service("observability", "web", "grafana", "", 3000)
+	annotated.Annotations = map[string]string{Annotation: "grafana"}
+	cs = fake.NewClientset(service("observability", "grafana", "grafana", "grafana", 80), annotated)
+	d, ok, err := Find(context.Background(), cs, grafana, apps)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "web", d.Service)
+	assert.Equal(t, int32(3000), d.Port)
+}
+
+func TestCredentials(t *testing.T) {
+	cs := fake.NewClientset(
+		secret("argocd", "argocd-initial-admin-secret", map[string]string{"password": "s3cret"}),
+		secret("observability", "grafana", map[string]string{"admin-user": "admin", "admin-password": "prom"}),
+	)
+	ctx := context.Background()
+
+	user, password, ok, err := Credentials(ctx, cs, Dashboard{Kind: "argocd", Title: "ArgoCD", Namespace: "argocd", Service: "argocd-server"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"admin", "s3cret"}, []string{user, password})
+
+	user, password, ok, err = Credentials(ctx, cs, Dashboard{Kind: "grafana", Title: "Grafana", Namespace: "observability", Service: "grafana"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"admin", "prom"}, []string{user, password})
+
+	_, _, ok, err = Credentials(ctx, cs, Dashboard{Kind: "openframe", Namespace: "openframe", Service: "openframe-ui"})
+	assert.NoError(t, err)
+	assert.False(t, ok, "the OpenFrame UI has its own accounts")
+
+	_, _, _, err = Credentials(ctx, cs, Dashboard{Kind: "grafana", Title: "Grafana", Namespace: "monitoring", Service: "grafana"})
+	assert.ErrorContains(t, err, "secret monitoring/grafana")
+}
+
+func TestTargetPort(t *testing.T) {
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+		{Port: 443, TargetPort: intstr.FromString("server")},
+		{Port: 80, TargetPort: intstr.FromInt32(8080)},
+		{Port: 3000},
+	}}}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{Name: "server", ContainerPort: 8083}}}}}}
+
+	for port, want := range map[int32]int32{443: 8083, 80: 8080, 3000: 3000} {
+		got, err := targetPort(svc, port, pod)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := targetPort(svc, 9090, pod)
+	assert.Error(t, err)
+}
+
+func TestBrowserCommand(t *testing.T) {
+	const url = "https://localhost/"
+	found := func(string) (string, error) { return "/usr/bin/wslview", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+
+	for _, tc := range []struct {
+		goos     string
+		inWSL    bool
+		lookPath func(string) (string, error)
+		want     string
+	}{
+		{"darwin", false, missing, "open"},
+		{"linux", false, found, "xdg-open"},
+		{"linux", true, found, "wslview"},
+		{"linux", true, missing, "explorer.exe"},
+	} {
+		name, args := browserCommand(tc.goos, tc.inWSL, tc.lookPath, url)
+		assert.Equal(t, tc.want, name)
+		assert.Equal(t, []string{url}, args)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	k, err := Lookup("grafana")
+	require.NoError(t, err)
+	assert.Equal(t, "Grafana", k.Title)
+	_, err = Lookup("kibana")
+	assert.ErrorContains(t, err, "argocd, grafana, openframe")
+}
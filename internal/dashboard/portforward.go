@@ -0,0 +1,149 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/podexec"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// Forward forwards localPort (0 picks a free one) on 127.0.0.1 to d's Service
+// port, through a running pod behind it — what `kubectl port-forward svc/...`
+// does. It returns the local URL once the forward is up; done receives the
+// forward's result when it stops, which it does when ctx is done.
+func Forward(ctx context.Context, cfg *rest.Config, cs kubernetes.Interface, d Dashboard, localPort int) (url string, done <-chan error, err error) {
+	svc, err := cs.CoreV1().Services(d.Namespace).Get(ctx, d.Service, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("reading service %s/%s: %w", d.Namespace, d.Service, err)
+	}
+	pods, err := cs.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("listing pods of %s/%s: %w", d.Namespace, d.Service, err)
+	}
+	pod, err := podexec.Primary(pods.Items)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s/%s: %w", d.Namespace, d.Service, err)
+	}
+	target, err := targetPort(svc, d.Port, pod)
+	if err != nil {
+		return "", nil, err
+	}
+
+	req := cs.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return "", nil, fmt.Errorf("preparing port-forward: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+	// WebSockets first, SPDY for API servers that predate them — kubectl's order.
+	if ws, werr := portforward.NewSPDYOverWebsocketDialer(req.URL(), cfg); werr == nil {
+		dialer = portforward.NewFallbackDialer(ws, dialer, func(err error) bool {
+			return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+		})
+	}
+
+	stop, ready := make(chan struct{}), make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("%d:%d", localPort, target)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return "", nil, fmt.Errorf("preparing port-forward: %w", err)
+	}
+	result := make(chan error, 1)
+	go func() { result <- fw.ForwardPorts() }()
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+
+	select {
+	case <-ready:
+	case err := <-result:
+		return "", nil, fmt.Errorf("port-forward to %s/%s: %w", pod.Namespace, pod.Name, err)
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		return "", nil, fmt.Errorf("port-forward to %s/%s: no local port", pod.Namespace, pod.Name)
+	}
+	return fmt.Sprintf("%s://localhost:%d", d.Scheme, ports[0].Local), result, nil
+}
+
+// targetPort resolves the container port behind the Service port: a number,
+// or a name looked up among the pod's container ports.
+func targetPort(svc *corev1.Service, port int32, pod *corev1.Pod) (int32, error) {
+	for _, p := range svc.Spec.Ports {
+		if p.Port != port {
+			continue
+		}
+		switch {
+		case p.TargetPort.Type == intstr.String && p.TargetPort.StrVal != "":
+			for _, c := range pod.Spec.Containers {
+				for _, cp := range c.Ports {
+					if cp.Name == p.TargetPort.StrVal {
+						return cp.ContainerPort, nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("pod %s has no port named %q", pod.Name, p.TargetPort.StrVal)
+		case p.TargetPort.IntVal != 0:
+			return p.TargetPort.IntVal, nil
+		default:
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("service %s/%s has no port %d", svc.Namespace, svc.Name, port)
+}
+
+// browserTimeout bounds the opener; they hand the URL over and exit.
+const browserTimeout = 10 * time.Second
+
+// OpenBrowser opens url in the default browser through ex; a variable so
+// tests can catch it.
+var OpenBrowser = func(ctx context.Context, ex executor.CommandExecutor, url string) error {
+	name, args := browserCommand(runtime.GOOS, sysctl.InsideWSL(), exec.LookPath, url)
+	_, err := ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args, Timeout: browserTimeout})
+	// explorer.exe exits 1 even when it opened the URL.
+	var sandboxErr *executor.SandboxError
+	if name == "explorer.exe" && !errors.As(err, &sandboxErr) {
+		return nil
+	}
+	return err
+}
+
+// browserCommand is the command that opens url. On Windows the CLI runs
+// inside WSL, where xdg-open is usually missing: wslview (wslu) hands the URL
+// to the Windows browser, and explorer.exe does without wslu.
+func browserCommand(goos string, inWSL bool, lookPath func(string) (string, error), url string) (string, []string) {
+	switch {
+	case goos == "darwin":
+		return "open", []string{url}
+	case inWSL:
+		if _, err := lookPath("wslview"); err == nil {
+			return "wslview", []string{url}
+		}
+		return "explorer.exe", []string{url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
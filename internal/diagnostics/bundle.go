@@ -0,0 +1,128 @@
+// Package diagnostics gathers everything needed to debug a failed install —
+// CLI and host versions, Docker/k3d/WSL state, cluster nodes, pods and events,
+// and ArgoCD application status — into one sanitized tar.gz that can be
+// attached to a GitHub issue. Collection is best-effort: a step that fails is
+// recorded in the bundle's errors.txt instead of aborting the rest, because
+// the bundle is most needed exactly when things are broken.
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Bundle is the set of files being collected, keyed by their path inside the
+// archive.
+type Bundle struct {
+	files  map[string]string
+	errors []string
+}
+
+// NewBundle returns an empty bundle.
+func NewBundle() *Bundle {
+	return &Bundle{files: map[string]string{}}
+}
+
+// Add stores content under name. Content is sanitized when the archive is
+// written, not here, so every path goes through the same scrubbing.
+func (b *Bundle) Add(name, content string) {
+	b.files[name] = content
+}
+
+// AddJSON stores v as indented JSON under name.
+func (b *Bundle) AddJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+	b.Add(name, string(data)+"\n")
+}
+
+// Fail records that the step producing name could not be collected.
+func (b *Bundle) Fail(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", name, err))
+}
+
+// Names returns the collected file names, sorted.
+func (b *Bundle) Names() []string {
+	names := make([]string, 0, len(b.files))
+	for n := range b.files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultFileName is the bundle file name used when none is given.
+func DefaultFileName(t time.Time) string {
+	return fmt.Sprintf("openframe-diagnostics-%s.tar.gz", t.Format("20060102-150405"))
+}
+
+// WriteFile writes the bundle to path as a tar.gz whose top-level directory is
+// named after the file.
+func (b *Bundle) WriteFile(path string) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // G304: caller-chosen output path
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	root := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".gz"), ".tar")
+	if err := b.WriteTarGz(out, root); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteTarGz writes the bundle as a gzip-compressed tar under a top-level
+// directory named root, with every file sanitized.
+func (b *Bundle) WriteTarGz(w io.Writer, root string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	files := b.files
+	if len(b.errors) > 0 {
+		files = make(map[string]string, len(b.files)+1)
+		for k, v := range b.files {
+			files[k] = v
+		}
+		files["errors.txt"] = strings.Join(b.errors, "\n") + "\n"
+	}
+	names := make([]string, 0, len(files))
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := []byte(Sanitize(files[name]))
+		hdr := &tar.Header{
+			Name:    root + "/" + name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
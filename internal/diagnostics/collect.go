@@ -0,0 +1,373 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/dockerhost"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/winhost"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// stepTimeout bounds each collection step, so one hung tool (a wedged Docker
+// daemon, an unreachable API server) cannot stall the whole bundle.
+const stepTimeout = 20 * time.Second
+
+// maxEvents bounds the events file; the newest are kept.
+const maxEvents = 500
+
+// maxCrashLogs bounds how many crashed containers' logs are collected, and
+// crashLogLines how much of each.
+const (
+	maxCrashLogs  = 20
+	crashLogLines = 200
+)
+
+// AppLister lists the ArgoCD applications in the cluster.
+type AppLister interface {
+	ListApplications(ctx context.Context, verbose bool) ([]argocd.Application, error)
+}
+
+// Collector gathers a diagnostics bundle. Kube and Apps may be nil when no
+// cluster is reachable; their sections are then recorded as skipped.
+type Collector struct {
+	Exec    executor.CommandExecutor
+	Kube    kubernetes.Interface
+	Apps    AppLister
+	Version string
+
+	// home, readFile and goos are swapped in tests.
+	home     string
+	readFile func(string) ([]byte, error)
+	goos     string
+}
+
+// NewCollectorForContext returns a collector running commands through exec and
+// inspecting the cluster of kube-context contextName ("" for the current one).
+// The cluster is optional — a bundle from a machine whose cluster never came up
+// is still the most useful one — so when it cannot be reached the collector
+// covers the host only and the error says why.
+func NewCollectorForContext(exec executor.CommandExecutor, version, contextName string) (*Collector, error) {
+	c := &Collector{Exec: exec, Version: version}
+	cfg, err := k8s.RestConfigForContext(k8s.KubeconfigForContext(contextName), contextName)
+	if err != nil {
+		return c, err
+	}
+	if cs, err := kubernetes.NewForConfig(cfg); err == nil {
+		c.Kube = cs
+	}
+	if mgr, err := argocd.NewManagerWithConfig(exec, cfg); err == nil {
+		c.Apps = mgr
+	}
+	return c, nil
+}
+
+// Collect runs every step and returns the bundle. It never fails outright;
+// step failures are recorded in the bundle.
+func (c *Collector) Collect(ctx context.Context) *Bundle {
+	if c.readFile == nil {
+		c.readFile = os.ReadFile
+	}
+	if c.home == "" {
+		c.home, _ = os.UserHomeDir()
+	}
+	if c.goos == "" {
+		c.goos = runtime.GOOS
+	}
+
+	b := NewBundle()
+	b.Add("version.txt", fmt.Sprintf("openframe %s\nos: %s/%s\ngo: %s\ncollected: %s\n",
+		c.Version, runtime.GOOS, runtime.GOARCH, runtime.Version(), time.Now().UTC().Format(time.RFC3339)))
+
+	c.collectHost(ctx, b)
+	c.collectWSL(b)
+	c.collectWindows(ctx, b)
+	c.collectCluster(ctx, b)
+	c.collectArgoCD(ctx, b)
+	c.collectCLIState(b)
+	return b
+}
+
+func (c *Collector) collectHost(ctx context.Context, b *Bundle) {
+	if ep, err := dockerhost.Resolve(); err == nil {
+		b.Add("host/docker-endpoint.txt", ep.String()+"\n")
+	} else {
+		b.Fail("host/docker-endpoint.txt", err)
+	}
+	c.run(ctx, b, "host/docker-version.txt", "docker", "version")
+	c.run(ctx, b, "host/docker-info.txt", "docker", "info")
+	c.run(ctx, b, "host/docker-ps.txt", "docker", "ps", "-a", "--format", "table {{.Names}}\t{{.Image}}\t{{.Status}}")
+	c.run(ctx, b, "host/k3d-version.txt", "k3d", "version")
+	c.run(ctx, b, "host/k3d-clusters.json", "k3d", "cluster", "list", "-o", "json")
+}
+
+// collectWSL captures the WSL facts behind most Windows install failures
+// (DNS, networking mode, kernel) when running inside WSL.
+func (c *Collector) collectWSL(b *Bundle) {
+	kernel, err := c.readFile("/proc/version")
+	if err != nil || !strings.Contains(strings.ToLower(string(kernel)), "microsoft") {
+		return
+	}
+	b.Add("wsl/kernel.txt", string(kernel))
+	for _, f := range []string{"/etc/wsl.conf", "/etc/resolv.conf"} {
+		if data, err := c.readFile(f); err == nil {
+			b.Add("wsl/"+filepath.Base(f), string(data))
+		}
+	}
+}
+
+// windowsPorts are the host ports a cluster publishes: the API servers and the
+// ingress.
+var windowsPorts = []int{6550, 6551, 6552, 80, 443}
+
+// collectWindows records, on a Windows host, who holds the cluster's ports and
+// what the hosts file maps. Both are read with PowerShell, so they are there
+// when WSL is what broke.
+func (c *Collector) collectWindows(ctx context.Context, b *Bundle) {
+	if c.goos != "windows" {
+		return
+	}
+	host := winhost.New(c.Exec)
+	if listeners, err := host.Listeners(ctx, windowsPorts...); err == nil {
+		var sb strings.Builder
+		for _, l := range listeners {
+			sb.WriteString(l.String() + "\n")
+		}
+		if len(listeners) == 0 {
+			sb.WriteString("none of the cluster ports is in use\n")
+		}
+		b.Add("windows/listeners.txt", sb.String())
+	} else {
+		b.Fail("windows/listeners.txt", err)
+	}
+	if hosts, err := host.HostsFile(ctx); err == nil {
+		b.Add("windows/hosts", hosts)
+	} else {
+		b.Fail("windows/hosts", err)
+	}
+}
+
+func (c *Collector) collectCluster(ctx context.Context, b *Bundle) {
+	if c.Kube == nil {
+		b.Fail("cluster/", fmt.Errorf("no reachable cluster; cluster sections skipped"))
+		return
+	}
+	if v, err := c.Kube.Discovery().ServerVersion(); err == nil {
+		b.Add("cluster/version.txt", v.String()+"\n")
+	} else {
+		b.Fail("cluster/version.txt", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stepTimeout)
+	defer cancel()
+
+	if nodes, err := c.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err == nil {
+		for i := range nodes.Items {
+			nodes.Items[i].ManagedFields = nil
+		}
+		c.addYAML(b, "cluster/nodes.yaml", nodes)
+	} else {
+		b.Fail("cluster/nodes.yaml", err)
+	}
+
+	client := k8s.NewNativeClient(c.Kube)
+	if pods, err := client.Pods(ctx, "", ""); err == nil {
+		for i := range pods {
+			scrubPod(&pods[i])
+		}
+		b.Add("cluster/pods.txt", podSummary(pods))
+		c.addYAML(b, "cluster/pods.yaml", &corev1.PodList{Items: pods})
+		c.collectCrashLogs(ctx, b, client, pods)
+	} else {
+		b.Fail("cluster/pods.yaml", err)
+	}
+
+	if events, err := client.Events(ctx, ""); err == nil {
+		b.Add("cluster/events.txt", eventLog(events))
+	} else {
+		b.Fail("cluster/events.txt", err)
+	}
+
+	if top, err := topSummary(ctx, client); err == nil {
+		b.Add("cluster/top.txt", top)
+	} else {
+		b.Fail("cluster/top.txt", err)
+	}
+}
+
+// collectCrashLogs adds the previous log of every restarted container — the
+// crash output that is gone once the pod is replaced — up to maxCrashLogs.
+func (c *Collector) collectCrashLogs(ctx context.Context, b *Bundle, client k8s.KubeClient, pods []corev1.Pod) {
+	n := 0
+	for _, p := range pods {
+		for _, cs := range p.Status.ContainerStatuses {
+			if cs.RestartCount == 0 || n >= maxCrashLogs {
+				continue
+			}
+			n++
+			name := fmt.Sprintf("cluster/logs/%s_%s_%s.previous.log", p.Namespace, p.Name, cs.Name)
+			log, err := client.Logs(ctx, p.Namespace, p.Name, k8s.LogOptions{Container: cs.Name, TailLines: crashLogLines, Previous: true})
+			if err != nil {
+				b.Fail(name, err)
+				continue
+			}
+			b.Add(name, log)
+		}
+	}
+}
+
+// topSummary is a `kubectl top`-style view of node and pod usage.
+func topSummary(ctx context.Context, client k8s.KubeClient) (string, error) {
+	nodes, err := client.TopNodes(ctx)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-40s %-10s %s\n", "NODE", "CPU", "MEMORY")
+	for _, u := range nodes {
+		fmt.Fprintf(&sb, "%-40s %-10s %dMi\n", u.Name, fmt.Sprintf("%dm", u.CPUMillis), u.MemBytes/(1<<20))
+	}
+	if pods, err := client.TopPods(ctx, ""); err == nil {
+		fmt.Fprintf(&sb, "\n%-20s %-55s %-10s %s\n", "NAMESPACE", "POD", "CPU", "MEMORY")
+		for _, u := range pods {
+			fmt.Fprintf(&sb, "%-20s %-55s %-10s %dMi\n", u.Namespace, u.Name, fmt.Sprintf("%dm", u.CPUMillis), u.MemBytes/(1<<20))
+		}
+	}
+	return sb.String(), nil
+}
+
+func (c *Collector) collectArgoCD(ctx context.Context, b *Bundle) {
+	if c.Apps == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, stepTimeout)
+	defer cancel()
+	apps, err := c.Apps.ListApplications(ctx, false)
+	if err != nil {
+		b.Fail("argocd/applications.json", err)
+		return
+	}
+	b.AddJSON("argocd/applications.json", apps)
+}
+
+// collectCLIState adds the CLI's own state files and any logs under
+// ~/.openframe. Helm values files are deliberately left out: they hold
+// registry and Git credentials.
+func (c *Collector) collectCLIState(b *Bundle) {
+	if c.home == "" {
+		return
+	}
+	base := filepath.Join(c.home, ".openframe")
+	for _, pattern := range []string{filepath.Join(base, "state", "*.json"), filepath.Join(base, "*.log"), filepath.Join(base, "logs", "*.log")} {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			data, err := c.readFile(m)
+			if err != nil {
+				b.Fail("cli/"+filepath.Base(m), err)
+				continue
+			}
+			rel, _ := filepath.Rel(base, m)
+			b.Add("cli/"+filepath.ToSlash(rel), string(data))
+		}
+	}
+}
+
+// run executes a host command and stores its output; a failed command stores
+// what it printed along with the error, which is often the useful part.
+func (c *Collector) run(ctx context.Context, b *Bundle, name, command string, args ...string) {
+	ctx, cancel := context.WithTimeout(ctx, stepTimeout)
+	defer cancel()
+	res, err := c.Exec.Execute(ctx, command, args...)
+	out := ""
+	if res != nil {
+		out = res.Output()
+	}
+	if err != nil {
+		b.Fail(name, err)
+		if out == "" {
+			return
+		}
+	}
+	b.Add(name, out)
+}
+
+func (c *Collector) addYAML(b *Bundle, name string, v interface{}) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+	b.Add(name, string(data))
+}
+
+// scrubPod drops noise and literal environment values from a pod before it is
+// serialized. Env values are where charts most often inline credentials.
+func scrubPod(p *corev1.Pod) {
+	p.ManagedFields = nil
+	delete(p.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	scrub := func(cs []corev1.Container) {
+		for i := range cs {
+			for j := range cs[i].Env {
+				if cs[i].Env[j].Value != "" {
+					cs[i].Env[j].Value = redacted
+				}
+			}
+		}
+	}
+	scrub(p.Spec.InitContainers)
+	scrub(p.Spec.Containers)
+}
+
+// podSummary is a `kubectl get pods -A`-style overview, the first thing anyone
+// reading the bundle looks at.
+func podSummary(pods []corev1.Pod) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %-55s %-7s %-20s %s\n", "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS")
+	for _, p := range pods {
+		ready, total, restarts := 0, 0, int32(0)
+		status := string(p.Status.Phase)
+		for _, cs := range p.Status.ContainerStatuses {
+			total++
+			if cs.Ready {
+				ready++
+			}
+			restarts += cs.RestartCount
+			if w := cs.State.Waiting; w != nil && w.Reason != "" {
+				status = w.Reason
+			}
+		}
+		fmt.Fprintf(&sb, "%-20s %-55s %-7s %-20s %d\n", p.Namespace, p.Name, fmt.Sprintf("%d/%d", ready, total), status, restarts)
+	}
+	return sb.String()
+}
+
+// eventLog renders the newest events, oldest first.
+func eventLog(events []corev1.Event) string {
+	when := func(e corev1.Event) time.Time {
+		if !e.LastTimestamp.IsZero() {
+			return e.LastTimestamp.Time
+		}
+		return e.EventTime.Time
+	}
+	k8s.SortEvents(events)
+	if len(events) > maxEvents {
+		events = events[len(events)-maxEvents:]
+	}
+	var sb strings.Builder
+	for _, e := range events {
+		fmt.Fprintf(&sb, "%s %-7s %s/%s %s: %s\n",
+			when(e).UTC().Format(time.RFC3339), e.Type, e.InvolvedObject.Namespace, e.InvolvedObject.Name, e.Reason, strings.TrimSpace(e.Message))
+	}
+	return sb.String()
+}
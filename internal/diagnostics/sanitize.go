@@ -0,0 +1,41 @@
+package diagnostics
+
+import (
+	"regexp"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+)
+
+const redacted = "<redacted>"
+
+// secretPatterns catch credentials that were never registered with the redact
+// package: tokens in well-known formats, key material, and values of keys whose
+// names say they are secret.
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// GitHub tokens (classic and fine-grained).
+	{regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`), redacted},
+	// Authorization headers.
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`), "$1 " + redacted},
+	// PEM blocks (private keys, certificates).
+	{regexp.MustCompile(`(?s)-----BEGIN [A-Z ]+-----.*?-----END [A-Z ]+-----`), redacted},
+	// kubeconfig-style embedded key material.
+	{regexp.MustCompile(`(?i)("?\b(?:client-key-data|client-certificate-data|certificate-authority-data|token)"?\s*[:=]\s*"?)[^"\s,}]+`), "${1}" + redacted},
+	// key: value / key=value / "key": "value" where the key ENDS in a secret
+	// word ("adminPassword", "clientSecret") — not "secretName", which only
+	// names a Secret and is exactly what a missing-secret report needs.
+	{regexp.MustCompile(`(?i)("?[A-Za-z0-9_.-]*(?:password|passwd|secret|apikey|api_key|access_key|private_key)"?\s*[:=]\s*"?)[^"\s,}]+`), "${1}" + redacted},
+}
+
+// Sanitize removes registered secrets, URL credentials, and anything matching
+// secretPatterns from s. It errs on the side of over-redaction: a bundle is
+// posted publicly.
+func Sanitize(s string) string {
+	s = redact.Redact(s)
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
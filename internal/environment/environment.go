@@ -0,0 +1,219 @@
+// Package environment keeps named environments — dev, demo, test — each a
+// cluster with its own creation profile and the chart values laid over
+// openframe-helm-values.yaml when the platform is installed on it. `bootstrap
+// --env demo` builds the same configuration every time, and `cluster list
+// --env` shows which environment runs on which cluster.
+package environment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/sizing"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+)
+
+// Environment is one named configuration.
+type Environment struct {
+	Name string `json:"name"`
+	// Cluster is the cluster the environment runs on; ClusterName gives the
+	// default when empty.
+	Cluster string  `json:"cluster,omitempty"`
+	Profile Profile `json:"profile"`
+	// Values are YAML files laid over openframe-helm-values.yaml, in order,
+	// as absolute paths.
+	Values []string `json:"values,omitempty"`
+	// Ref is the git ref of the platform charts; empty is the default.
+	Ref string `json:"ref,omitempty"`
+	// UpAt is when the environment was last brought up.
+	UpAt time.Time `json:"upAt,omitempty"`
+}
+
+// Profile is how the environment's cluster is created and sized.
+type Profile struct {
+	// Nodes is the node count; zero keeps the bootstrap default.
+	Nodes int `json:"nodes,omitempty"`
+	// K8sVersion is the Kubernetes version; empty is the default.
+	K8sVersion string `json:"k8sVersion,omitempty"`
+	// Size is the platform size (small, medium, large); empty detects it.
+	Size string `json:"size,omitempty"`
+}
+
+// ClusterName is the cluster e runs on: Cluster, or openframe-<name>.
+func (e Environment) ClusterName() string {
+	if e.Cluster != "" {
+		return e.Cluster
+	}
+	return "openframe-" + e.Name
+}
+
+// storeFile is where environments are kept; a variable so tests can redirect
+// it.
+var storeFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "environments.json"), nil
+}
+
+// Load returns the environments sorted by name. A missing file is none.
+func Load() ([]Environment, error) {
+	path, err := storeFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var envs []Environment
+	if err := json.Unmarshal(data, &envs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
+	return envs, nil
+}
+
+// Get returns the environment called name.
+func Get(name string) (Environment, error) {
+	envs, err := Load()
+	if err != nil {
+		return Environment{}, err
+	}
+	for _, e := range envs {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+	return Environment{}, fmt.Errorf("no environment %q; create it with 'openframe environment set %s'", name, name)
+}
+
+// Set records e after checking it, replacing an environment of the same name.
+// Values files must exist; they are stored as absolute paths so the
+// environment works from any directory.
+func Set(e Environment) error {
+	if err := models.ValidateClusterName(e.Name); err != nil {
+		return fmt.Errorf("invalid environment name: %w", err)
+	}
+	if err := models.ValidateClusterName(e.ClusterName()); err != nil {
+		return err
+	}
+	if e.Profile.Nodes < 0 {
+		return fmt.Errorf("--nodes must be positive")
+	}
+	if e.Profile.Size != "" {
+		size, err := sizing.ParseSize(e.Profile.Size)
+		if err != nil {
+			return err
+		}
+		e.Profile.Size = size
+	}
+	e.Values = append([]string(nil), e.Values...)
+	for i, v := range e.Values {
+		abs, err := filepath.Abs(v)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return fmt.Errorf("values file: %w", err)
+		}
+		e.Values[i] = abs
+	}
+
+	envs, err := Load()
+	if err != nil {
+		return err
+	}
+	kept := envs[:0]
+	for _, other := range envs {
+		switch {
+		case other.Name == e.Name:
+			if e.UpAt.IsZero() {
+				e.UpAt = other.UpAt
+			}
+		case other.ClusterName() == e.ClusterName():
+			return fmt.Errorf("cluster %s already belongs to environment %s", e.ClusterName(), other.Name)
+		default:
+			kept = append(kept, other)
+		}
+	}
+	return save(append(kept, e))
+}
+
+// Delete forgets the environment called name; its cluster is left alone. It
+// reports whether there was one.
+func Delete(name string) (bool, error) {
+	envs, err := Load()
+	if err != nil {
+		return false, err
+	}
+	kept := envs[:0]
+	for _, e := range envs {
+		if e.Name != name {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(envs) {
+		return false, nil
+	}
+	return true, save(kept)
+}
+
+// RenameCluster points the environments that run on cluster at its new name.
+func RenameCluster(cluster, newName string) error {
+	envs, err := Load()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for i := range envs {
+		if envs[i].ClusterName() == cluster {
+			envs[i].Cluster = newName
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return save(envs)
+}
+
+// MarkUp records that the environment called name was just brought up.
+func MarkUp(name string) error {
+	envs, err := Load()
+	if err != nil {
+		return err
+	}
+	for i := range envs {
+		if envs[i].Name == name {
+			envs[i].UpAt = time.Now().UTC()
+			return save(envs)
+		}
+	}
+	return nil
+}
+
+func save(envs []Environment) error {
+	path, err := storeFile()
+	if err != nil {
+		return err
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
+	data, err := json.MarshalIndent(envs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
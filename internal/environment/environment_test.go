@@ -0,0 +1,73 @@
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempStore points the store at a file in a temporary directory.
+func useTempStore(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "state", "environments.json")
+	orig := storeFile
+	storeFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { storeFile = orig })
+	return path
+}
+
+func TestSetAndGet(t *testing.T) {
+	useTempStore(t)
+	dir := t.TempDir()
+	values := filepath.Join(dir, "demo-values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("registry: {}\n"), 0o600))
+	t.Chdir(dir)
+
+	require.NoError(t, Set(Environment{Name: "demo", Profile: Profile{Nodes: 2, Size: "Small"}, Values: []string{"demo-values.yaml"}}))
+	e, err := Get("demo")
+	require.NoError(t, err)
+	assert.Equal(t, "openframe-demo", e.ClusterName())
+	assert.Equal(t, Profile{Nodes: 2, Size: "small"}, e.Profile)
+	assert.Equal(t, []string{values}, e.Values, "values files are stored as absolute paths")
+
+	require.NoError(t, MarkUp("demo"))
+	require.NoError(t, Set(Environment{Name: "demo", Ref: "v1.2.0"}))
+	e, err = Get("demo")
+	require.NoError(t, err)
+	assert.False(t, e.UpAt.IsZero(), "replacing an environment keeps when it was last up")
+
+	_, err = Get("test")
+	assert.ErrorContains(t, err, "openframe environment set test")
+}
+
+func TestSetRejects(t *testing.T) {
+	useTempStore(t)
+
+	assert.Error(t, Set(Environment{Name: "Bad_Name"}))
+	assert.Error(t, Set(Environment{Name: "demo", Profile: Profile{Size: "huge"}}))
+	assert.ErrorContains(t, Set(Environment{Name: "demo", Values: []string{filepath.Join(t.TempDir(), "missing.yaml")}}), "values file")
+
+	require.NoError(t, Set(Environment{Name: "dev", Cluster: "shared"}))
+	assert.ErrorContains(t, Set(Environment{Name: "test", Cluster: "shared"}), "already belongs to environment dev")
+}
+
+func TestDelete(t *testing.T) {
+	useTempStore(t)
+	require.NoError(t, Set(Environment{Name: "dev"}))
+	require.NoError(t, Set(Environment{Name: "demo", Cluster: "demo-box"}))
+
+	deleted, err := Delete("dev")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = Delete("dev")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	envs, err := Load()
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	assert.Equal(t, "demo", envs[0].Name)
+}
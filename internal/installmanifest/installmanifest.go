@@ -0,0 +1,229 @@
+// Package installmanifest records what a bootstrap installed: the cluster's
+// profile, the platform charts' repository, ref and the commit installed, the
+// pinned ArgoCD chart, the helm values the install used and the images the
+// cluster ended up running. The values — openframe-helm-values.yaml with the
+// wizard's answers and any overlays applied — are written next to the
+// manifest, which holds their digest. Both are meant to be checked in;
+// `bootstrap --from-manifest` installs that commit with those values and
+// refuses to go ahead when the values or the CLI differ from what was
+// recorded, so a teammate gets the same environment rather than a similar one.
+package installmanifest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// APIVersion and Kind identify a manifest file.
+const (
+	APIVersion = "openframe.io/v1"
+	Kind       = "InstallManifest"
+)
+
+// DefaultFile is where bootstrap writes the manifest, in the working
+// directory next to openframe-helm-values.yaml.
+const DefaultFile = "openframe-manifest.yaml"
+
+// Manifest is the effective configuration of one install.
+type Manifest struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// CLIVersion is the version of the CLI that wrote the manifest.
+	CLIVersion string  `json:"cliVersion,omitempty"`
+	Cluster    Cluster `json:"cluster"`
+	Chart      Chart   `json:"chart"`
+	// Values are the helm values the install used, written next to the
+	// manifest (see ValuesPath).
+	Values ValuesFile `json:"values"`
+	// Images are the container images the cluster's pods run, sorted.
+	Images []string `json:"images,omitempty"`
+}
+
+// Cluster is how the cluster was created and the platform sized.
+type Cluster struct {
+	Name       string `json:"name"`
+	Nodes      int    `json:"nodes,omitempty"`
+	K8sVersion string `json:"k8sVersion,omitempty"`
+	Size       string `json:"size,omitempty"`
+}
+
+// Chart is where the platform was deployed from.
+type Chart struct {
+	Repo string `json:"repo"`
+	Ref  string `json:"ref"`
+	// Commit is the commit installed, which Ref pointed to at the time; a
+	// replay installs it rather than wherever Ref has moved since. Empty when
+	// it could not be determined.
+	Commit string `json:"commit,omitempty"`
+	// ArgoCDVersion is the ArgoCD chart version the CLI pins.
+	ArgoCDVersion string `json:"argocdVersion"`
+}
+
+// ValuesFile is a values file and the SHA-256 of its content.
+type ValuesFile struct {
+	// Path is relative to the manifest's directory when written; Read makes
+	// it absolute.
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// digest returns the hex SHA-256 of data.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValuesPath is where the values of the manifest at path are written:
+// openframe-manifest.yaml keeps them in openframe-manifest.values.yaml.
+func ValuesPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".values.yaml"
+}
+
+// Write stores m at path and values, the helm values the install used, at
+// ValuesPath(path). The manifest names the values file relative to itself,
+// so the two can be checked in together and used from another clone. Both
+// files are private to the user: the values can hold credentials.
+func Write(path string, m Manifest, values []byte) error {
+	m.APIVersion, m.Kind = APIVersion, Kind
+	valuesPath := ValuesPath(path)
+	m.Values = ValuesFile{Path: filepath.Base(valuesPath), SHA256: digest(values)}
+	if err := os.WriteFile(valuesPath, values, 0o600); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	header := "# Written by openframe bootstrap. Replay with: openframe bootstrap --from-manifest " + filepath.Base(path) + "\n"
+	return os.WriteFile(path, append([]byte(header), data...), 0o600)
+}
+
+// Read loads the manifest at path, with the values paths made absolute.
+func Read(path string) (Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- a manifest the user named
+	if err != nil {
+		return Manifest{}, err
+	}
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if m.APIVersion != APIVersion || m.Kind != Kind {
+		return Manifest{}, fmt.Errorf("%s is not an install manifest (want apiVersion %s, kind %s)", path, APIVersion, Kind)
+	}
+	if m.Cluster.Name == "" {
+		return Manifest{}, fmt.Errorf("%s: cluster.name is missing", path)
+	}
+	if m.Values.Path == "" {
+		return Manifest{}, fmt.Errorf("%s: values.path is missing", path)
+	}
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return Manifest{}, err
+	}
+	m.Values.Path = absolute(dir, m.Values.Path)
+	return m, nil
+}
+
+func absolute(dir, path string) string {
+	path = filepath.FromSlash(path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// VerifyValues checks that the values file is the one the manifest was
+// written with.
+func (m Manifest) VerifyValues() error {
+	data, err := os.ReadFile(m.Values.Path) // #nosec G304 -- next to the manifest the user named
+	switch {
+	case os.IsNotExist(err):
+		return fmt.Errorf("%s is missing; it holds the values the manifest was written with", m.Values.Path)
+	case err != nil:
+		return err
+	case digest(data) != m.Values.SHA256:
+		return fmt.Errorf("%s changed since the manifest was written", m.Values.Path)
+	}
+	return nil
+}
+
+// Observe fills in what the cluster itself reports: its node count, its
+// Kubernetes version as a k3s image tag, and the images its pods run. The
+// version comes out as the tag the cluster was created from, so a replay with
+// a newer CLI, whose default version moved on, still creates the same one.
+func (m *Manifest) Observe(ctx context.Context, client kubernetes.Interface) error {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+	m.Cluster.Nodes = len(nodes.Items)
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("reading the server version: %w", err)
+	}
+	m.Cluster.K8sVersion = strings.ReplaceAll(info.GitVersion, "+", "-") // v1.31.5+k3s1 is tagged v1.31.5-k3s1
+	m.Images, err = RunningImages(ctx, client)
+	return err
+}
+
+// RunningImages returns the images of every container, init container
+// included, of the pods in the cluster, deduplicated and sorted.
+func RunningImages(ctx context.Context, client kubernetes.Interface) ([]string, error) {
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.InitContainers {
+			seen[c.Image] = true
+		}
+		for _, c := range pod.Spec.Containers {
+			seen[c.Image] = true
+		}
+	}
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// DiffImages returns the images of want that are not in got, and those of
+// got that are not in want.
+func DiffImages(want, got []string) (missing, extra []string) {
+	in := func(list []string) map[string]bool {
+		set := make(map[string]bool, len(list))
+		for _, s := range list {
+			set[s] = true
+		}
+		return set
+	}
+	wantSet, gotSet := in(want), in(got)
+	for _, image := range want {
+		if !gotSet[image] {
+			missing = append(missing, image)
+		}
+	}
+	for _, image := range got {
+		if !wantSet[image] {
+			extra = append(extra, image)
+		}
+	}
+	return missing, extra
+}
@@ -0,0 +1,109 @@
+package installmanifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestWriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultFile)
+	require.NoError(t, Write(path, Manifest{
+		Cluster: Cluster{Name: "openframe-demo", Nodes: 2, K8sVersion: "v1.31.5-k3s1", Size: "small"},
+		Chart:   Chart{Repo: "https://github.com/acme/platform", Ref: "main", Commit: "abc", ArgoCDVersion: "10.1.4"},
+		Images:  []string{"nginx:1.27"},
+	}, []byte("a: 1\nb: 2\n")))
+
+	data, err := os.ReadFile(filepath.Join(dir, "openframe-manifest.values.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\nb: 2\n", string(data), "the values the install used are written next to the manifest")
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "path: openframe-manifest.values.yaml", "the values path is kept relative to the manifest")
+
+	// Moved to another clone, the relative path follows the manifest.
+	moved := t.TempDir()
+	require.NoError(t, os.Rename(dir, filepath.Join(moved, "repo")))
+	m, err := Read(filepath.Join(moved, "repo", DefaultFile))
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion, m.APIVersion)
+	assert.Equal(t, "openframe-demo", m.Cluster.Name)
+	assert.Equal(t, "abc", m.Chart.Commit)
+	assert.Equal(t, filepath.Join(moved, "repo", "openframe-manifest.values.yaml"), m.Values.Path)
+	assert.NoError(t, m.VerifyValues())
+}
+
+func TestReadRejectsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Read(writeFile(t, filepath.Join(dir, "x.yaml"), "apiVersion: v1\nkind: ConfigMap\n"))
+	assert.ErrorContains(t, err, "not an install manifest")
+
+	_, err = Read(writeFile(t, filepath.Join(dir, "y.yaml"), "apiVersion: openframe.io/v1\nkind: InstallManifest\nsurprise: 1\n"))
+	assert.ErrorContains(t, err, "parsing")
+
+	_, err = Read(writeFile(t, filepath.Join(dir, "z.yaml"), "apiVersion: openframe.io/v1\nkind: InstallManifest\ncluster: {name: dev}\n"))
+	assert.ErrorContains(t, err, "values.path is missing")
+}
+
+func TestVerifyValues(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "env.yaml")
+	require.NoError(t, Write(path, Manifest{Cluster: Cluster{Name: "dev"}}, []byte("a: 1\n")))
+	m, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "env.values.yaml"), m.Values.Path)
+	assert.NoError(t, m.VerifyValues())
+
+	writeFile(t, m.Values.Path, "a: 2\n")
+	assert.ErrorContains(t, m.VerifyValues(), "changed since the manifest was written")
+
+	require.NoError(t, os.Remove(m.Values.Path))
+	assert.ErrorContains(t, m.VerifyValues(), "is missing")
+}
+
+func TestObserve(t *testing.T) {
+	pod := func(name string, images ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openframe"}}
+		p.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox:1.36"}}
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: name, Image: image})
+		}
+		return p
+	}
+	client := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "server-0"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "agent-0"}},
+		pod("api", "ghcr.io/acme/api:1.4.0"),
+		pod("web", "nginx:1.27", "ghcr.io/acme/api:1.4.0"),
+	)
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.5+k3s1"}
+
+	var m Manifest
+	require.NoError(t, m.Observe(context.Background(), client))
+	assert.Equal(t, 2, m.Cluster.Nodes)
+	assert.Equal(t, "v1.31.5-k3s1", m.Cluster.K8sVersion)
+	assert.Equal(t, []string{"busybox:1.36", "ghcr.io/acme/api:1.4.0", "nginx:1.27"}, m.Images)
+}
+
+func TestDiffImages(t *testing.T) {
+	missing, extra := DiffImages([]string{"a:1", "b:1"}, []string{"b:1", "c:1"})
+	assert.Equal(t, []string{"a:1"}, missing)
+	assert.Equal(t, []string{"c:1"}, extra)
+}
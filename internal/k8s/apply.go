@@ -0,0 +1,42 @@
+package k8s
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FieldManager is the server-side apply field manager for every object the
+// CLI writes, so `kubectl get -o yaml --show-managed-fields` shows which
+// fields are ours.
+const FieldManager = "openframe-cli"
+
+// ApplyOptions returns the options for a server-side apply by FieldManager.
+// Force takes over fields last written by a plain create/update (older CLI
+// versions); fields owned by other appliers are left alone.
+func ApplyOptions() metav1.ApplyOptions {
+	return metav1.ApplyOptions{FieldManager: FieldManager, Force: true}
+}
+
+// ApplySecret server-side applies s: its labels, type and data become the
+// CLI's desired state. Keys the CLI applied before but no longer sets are
+// removed; labels, annotations and keys added by anyone else are kept. Repeat
+// installs therefore never hit resourceVersion conflicts. StringData is folded
+// into Data, since the API server never persists it.
+func ApplySecret(ctx context.Context, client kubernetes.Interface, s *corev1.Secret) (*corev1.Secret, error) {
+	data := make(map[string][]byte, len(s.Data)+len(s.StringData))
+	for k, v := range s.Data {
+		data[k] = v
+	}
+	for k, v := range s.StringData {
+		data[k] = []byte(v)
+	}
+	cfg := corev1ac.Secret(s.Name, s.Namespace).
+		WithLabels(s.Labels).
+		WithType(s.Type).
+		WithData(data)
+	return client.CoreV1().Secrets(s.Namespace).Apply(ctx, cfg, ApplyOptions())
+}
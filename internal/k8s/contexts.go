@@ -7,6 +7,8 @@
 package k8s
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -35,7 +37,8 @@ func DefaultKubeconfigPath() string {
 }
 
 // ResolveContextForCluster returns the kube-context to use for a named cluster.
-// It prefers a context whose name matches the cluster exactly, otherwise the
+// An attached external cluster uses the context it was attached with.
+// Otherwise it prefers a context whose name matches the cluster exactly, or the
 // k3d convention "k3d-<name>" — which is also the fallback when the kubeconfig
 // cannot be read, preserving prior behavior. This stops the chart/helm layer
 // from hardcoding the k3d naming and so breaking on renamed or non-k3d contexts.
@@ -44,6 +47,9 @@ func ResolveContextForCluster(kubeconfigPath, clusterName string) string {
 	if clusterName == "" {
 		return ""
 	}
+	if ext, ok := LookupExternalCluster(clusterName); ok {
+		return ext.Context
+	}
 	k3d := "k3d-" + clusterName
 	contexts, _, err := LoadContexts(kubeconfigPath)
 	if err != nil {
@@ -82,3 +88,61 @@ func LoadContexts(path string) (contexts []ContextInfo, current string, err erro
 	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
 	return contexts, current, nil
 }
+
+// UseContext makes contextName the current-context of the kubeconfig at path
+// and returns the one it replaces. The context must exist.
+func UseContext(path, contextName string) (previous string, err error) {
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := cfg.Contexts[contextName]; !ok {
+		return "", fmt.Errorf("context %q not found in %s", contextName, path)
+	}
+	previous = cfg.CurrentContext
+	cfg.CurrentContext = contextName
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return previous, nil
+}
+
+// RemoveContext deletes contextName from the kubeconfig at path, with the
+// cluster and user it points at when no other context uses them. A missing
+// kubeconfig or context is nothing to remove.
+func RemoveContext(path, contextName string) error {
+	cfg, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctx, ok := cfg.Contexts[contextName]
+	if !ok {
+		return nil
+	}
+	delete(cfg.Contexts, contextName)
+	if ctx != nil {
+		clusterUsed, userUsed := false, false
+		for _, c := range cfg.Contexts {
+			if c != nil {
+				clusterUsed = clusterUsed || c.Cluster == ctx.Cluster
+				userUsed = userUsed || c.AuthInfo == ctx.AuthInfo
+			}
+		}
+		if !clusterUsed {
+			delete(cfg.Clusters, ctx.Cluster)
+		}
+		if !userUsed {
+			delete(cfg.AuthInfos, ctx.AuthInfo)
+		}
+	}
+	if cfg.CurrentContext == contextName {
+		cfg.CurrentContext = ""
+	}
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 const sampleKubeconfig = `apiVersion: v1
@@ -96,3 +97,57 @@ func TestDefaultKubeconfigPath_EnvWins(t *testing.T) {
 	t.Setenv("KUBECONFIG", "/custom/kubeconfig")
 	assert.Equal(t, "/custom/kubeconfig", DefaultKubeconfigPath())
 }
+
+func TestUseContext(t *testing.T) {
+	path := writeKubeconfig(t, sampleKubeconfig)
+
+	previous, err := UseContext(path, "ctx-a")
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-b", previous)
+	_, current, err := LoadContexts(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-a", current)
+
+	_, err = UseContext(path, "k3d-missing")
+	assert.ErrorContains(t, err, `context "k3d-missing" not found`)
+	_, current, _ = LoadContexts(path)
+	assert.Equal(t, "ctx-a", current, "a failed switch leaves the kubeconfig alone")
+}
+
+func TestRemoveContext(t *testing.T) {
+	path := writeKubeconfig(t, `apiVersion: v1
+kind: Config
+current-context: ctx-b
+contexts:
+- name: ctx-a
+  context: {cluster: cluster-a, user: user-a}
+- name: ctx-b
+  context: {cluster: cluster-b, user: user-b}
+- name: ctx-c
+  context: {cluster: cluster-a, user: user-c}
+clusters:
+- name: cluster-a
+  cluster: {server: https://a.example}
+- name: cluster-b
+  cluster: {server: https://b.example}
+users:
+- name: user-a
+- name: user-b
+- name: user-c
+`)
+
+	require.NoError(t, RemoveContext(path, "ctx-b"))
+	require.NoError(t, RemoveContext(path, "ctx-a"))
+	require.NoError(t, RemoveContext(path, "ctx-z"), "a missing context is nothing to remove")
+
+	cfg, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "", cfg.CurrentContext)
+	assert.Len(t, cfg.Contexts, 1)
+	assert.Contains(t, cfg.Clusters, "cluster-a", "still used by ctx-c")
+	assert.NotContains(t, cfg.Clusters, "cluster-b")
+	assert.NotContains(t, cfg.AuthInfos, "user-a")
+	assert.Contains(t, cfg.AuthInfos, "user-c")
+
+	require.NoError(t, RemoveContext(filepath.Join(t.TempDir(), "missing"), "ctx-a"))
+}
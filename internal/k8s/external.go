@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ExternalCluster is a cluster the CLI did not create and does not manage: a
+// name the user attached to an existing kubeconfig context (a shared dev
+// cluster, a cloud cluster). Platform commands reach it by that name; cluster
+// lifecycle commands refuse it.
+type ExternalCluster struct {
+	Name    string `json:"name"`
+	Context string `json:"context"`
+	// Kubeconfig is the file defining Context; empty means the default
+	// kubeconfig.
+	Kubeconfig string    `json:"kubeconfig,omitempty"`
+	AttachedAt time.Time `json:"attachedAt"`
+}
+
+// externalFile is where attached clusters are recorded; a variable so tests
+// can redirect it.
+var externalFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "external-clusters.json"), nil
+}
+
+// LoadExternalClusters returns the attached clusters sorted by name. A missing
+// file is no clusters.
+func LoadExternalClusters() ([]ExternalCluster, error) {
+	path, err := externalFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var clusters []ExternalCluster
+	if err := json.Unmarshal(data, &clusters); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
+// LookupExternalCluster returns the attached cluster called name, if any.
+func LookupExternalCluster(name string) (ExternalCluster, bool) {
+	if name == "" {
+		return ExternalCluster{}, false
+	}
+	clusters, err := LoadExternalClusters()
+	if err != nil {
+		return ExternalCluster{}, false
+	}
+	for _, c := range clusters {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ExternalCluster{}, false
+}
+
+// AttachExternalCluster records c after checking that its kubeconfig defines
+// its context, replacing an earlier attachment of the same name.
+func AttachExternalCluster(c ExternalCluster) error {
+	if c.Name == "" || c.Context == "" {
+		return fmt.Errorf("an external cluster needs a name and a kube-context")
+	}
+	if c.Kubeconfig != "" {
+		abs, err := filepath.Abs(c.Kubeconfig)
+		if err != nil {
+			return err
+		}
+		c.Kubeconfig = abs
+	}
+	path := c.Kubeconfig
+	if path == "" {
+		path = DefaultKubeconfigPath()
+	}
+	contexts, _, err := LoadContexts(path)
+	if err != nil {
+		return fmt.Errorf("could not read kubeconfig %s: %w", path, err)
+	}
+	if !hasContext(contexts, c.Context) {
+		return fmt.Errorf("kubeconfig %s has no context %q", path, c.Context)
+	}
+	if c.AttachedAt.IsZero() {
+		c.AttachedAt = time.Now().UTC()
+	}
+
+	clusters, err := LoadExternalClusters()
+	if err != nil {
+		return err
+	}
+	kept := clusters[:0]
+	for _, e := range clusters {
+		if e.Name != c.Name {
+			kept = append(kept, e)
+		}
+	}
+	return saveExternalClusters(append(kept, c))
+}
+
+// DetachExternalCluster forgets the attached cluster called name; the cluster
+// itself and its kubeconfig are left alone. It reports whether name was
+// attached.
+func DetachExternalCluster(name string) (bool, error) {
+	clusters, err := LoadExternalClusters()
+	if err != nil {
+		return false, err
+	}
+	kept := clusters[:0]
+	for _, c := range clusters {
+		if c.Name != name {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept) == len(clusters) {
+		return false, nil
+	}
+	return true, saveExternalClusters(kept)
+}
+
+// RenameExternalCluster gives the attached cluster called name a new name;
+// its context and kubeconfig stay as they are.
+func RenameExternalCluster(name, newName string) error {
+	clusters, err := LoadExternalClusters()
+	if err != nil {
+		return err
+	}
+	found := -1
+	for i, c := range clusters {
+		switch c.Name {
+		case name:
+			found = i
+		case newName:
+			return fmt.Errorf("a cluster named %s is already attached", newName)
+		}
+	}
+	if found < 0 {
+		return fmt.Errorf("no attached cluster named %s", name)
+	}
+	clusters[found].Name = newName
+	return saveExternalClusters(clusters)
+}
+
+func saveExternalClusters(clusters []ExternalCluster) error {
+	path, err := externalFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	data, err := json.MarshalIndent(clusters, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// externalKubeconfig reports whether path is a kubeconfig an attached cluster
+// named explicitly, i.e. one tools must be pointed at.
+func externalKubeconfig(path string) bool {
+	clusters, _ := LoadExternalClusters()
+	for _, c := range clusters {
+		if c.Kubeconfig != "" && c.Kubeconfig == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasContext(contexts []ContextInfo, name string) bool {
+	for _, c := range contexts {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveContextName maps the name of an attached external cluster to its
+// kube-context, so --context accepts either; any other name is returned as is.
+func ResolveContextName(name string) string {
+	if ext, ok := LookupExternalCluster(name); ok {
+		return ext.Context
+	}
+	return name
+}
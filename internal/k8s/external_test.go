@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalCluster_AttachResolveDetach(t *testing.T) {
+	withIsolatedDir(t)
+	shared := filepath.Join(t.TempDir(), "shared.yaml")
+	require.NoError(t, os.WriteFile(shared, []byte(sampleKubeconfig), 0o600))
+
+	require.NoError(t, AttachExternalCluster(ExternalCluster{Name: "shared", Context: "ctx-a", Kubeconfig: shared}))
+	require.NoError(t, AttachExternalCluster(ExternalCluster{Name: "dev", Context: "ctx-b"}))
+
+	clusters, err := LoadExternalClusters()
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "dev", clusters[0].Name, "sorted by name")
+
+	assert.Equal(t, shared, KubeconfigForCluster("shared"))
+	assert.Equal(t, "ctx-a", ResolveContextForCluster(KubeconfigForCluster("shared"), "shared"))
+	assert.Equal(t, shared, KubeconfigForContext("ctx-a"))
+	assert.Equal(t, []string{"--kubeconfig", shared}, KubeconfigArgs(shared), "helm must be pointed at an attached file")
+	assert.Equal(t, "ctx-b", ResolveContextName("dev"))
+	assert.Equal(t, "ctx-z", ResolveContextName("ctx-z"), "plain contexts pass through")
+	assert.Equal(t, DefaultKubeconfigPath(), KubeconfigForCluster("dev"), "no file: the default kubeconfig")
+	assert.Nil(t, KubeconfigArgs(DefaultKubeconfigPath()))
+
+	found, err := DetachExternalCluster("shared")
+	require.NoError(t, err)
+	assert.True(t, found)
+	_, ok := LookupExternalCluster("shared")
+	assert.False(t, ok)
+	found, err = DetachExternalCluster("shared")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestAttachExternalCluster_RejectsUnknownContext(t *testing.T) {
+	withIsolatedDir(t)
+
+	assert.Error(t, AttachExternalCluster(ExternalCluster{Name: "shared", Context: "nope"}))
+	assert.Error(t, AttachExternalCluster(ExternalCluster{Name: "shared"}))
+	clusters, err := LoadExternalClusters()
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
+
+func TestRenameExternalCluster(t *testing.T) {
+	withIsolatedDir(t)
+	require.NoError(t, AttachExternalCluster(ExternalCluster{Name: "shared", Context: "ctx-a"}))
+	require.NoError(t, AttachExternalCluster(ExternalCluster{Name: "dev", Context: "ctx-b"}))
+
+	assert.ErrorContains(t, RenameExternalCluster("shared", "dev"), "already attached")
+	assert.ErrorContains(t, RenameExternalCluster("nope", "staging"), "no attached cluster")
+	require.NoError(t, RenameExternalCluster("shared", "staging"))
+
+	c, ok := LookupExternalCluster("staging")
+	require.True(t, ok)
+	assert.Equal(t, "ctx-a", c.Context)
+	_, ok = LookupExternalCluster("shared")
+	assert.False(t, ok)
+}
@@ -0,0 +1,206 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// IsolationEnv turns on kubeconfig isolation: `cluster create` writes each
+// cluster's kubeconfig to its own file under ~/.openframe/kubeconfigs instead
+// of merging it into ~/.kube/config (and switching its current-context).
+// Lookups below find the isolated files whether or not the mode is on, so a
+// cluster created in isolation stays reachable after the variable is unset.
+const IsolationEnv = "OPENFRAME_KUBECONFIG_ISOLATION"
+
+// IsolationEnabled reports whether new clusters get an isolated kubeconfig.
+func IsolationEnabled() bool { return sharedconfig.EnvBool(IsolationEnv) }
+
+// isolatedDir is where the per-cluster kubeconfigs live; a variable so tests
+// can redirect it.
+var isolatedDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "kubeconfigs"), nil
+}
+
+// IsolatedKubeconfigPath returns the isolated kubeconfig path of cluster,
+// whether or not it exists.
+func IsolatedKubeconfigPath(cluster string) (string, error) {
+	dir, err := isolatedDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cluster+".yaml"), nil
+}
+
+// WriteIsolatedKubeconfig stores data as cluster's isolated kubeconfig
+// (owner-only: it holds the cluster's admin credentials) and returns its path.
+func WriteIsolatedKubeconfig(cluster string, data []byte) (string, error) {
+	path, err := IsolatedKubeconfigPath(cluster)
+	if err != nil {
+		return "", err
+	}
+	if _, err := clientcmd.Load(data); err != nil {
+		return "", fmt.Errorf("kubeconfig for %s is not valid: %w", cluster, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// RemoveIsolatedKubeconfig deletes cluster's isolated kubeconfig; a missing
+// file is not an error.
+func RemoveIsolatedKubeconfig(cluster string) error {
+	path, err := IsolatedKubeconfigPath(cluster)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// IsolatedClusters lists the clusters that have an isolated kubeconfig, sorted.
+func IsolatedClusters() []string {
+	files := isolatedKubeconfigs()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isolatedKubeconfigs lists the isolated kubeconfig files by cluster name.
+func isolatedKubeconfigs() map[string]string {
+	dir, err := isolatedDir()
+	if err != nil {
+		return nil
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	out := make(map[string]string, len(matches))
+	for _, m := range matches {
+		out[strings.TrimSuffix(filepath.Base(m), ".yaml")] = m
+	}
+	return out
+}
+
+// KubeconfigForCluster returns the kubeconfig that reaches cluster: the file an
+// attached external cluster named, then an explicit $KUBECONFIG, then the
+// cluster's isolated file, then the default kubeconfig.
+func KubeconfigForCluster(cluster string) string {
+	if ext, ok := LookupExternalCluster(cluster); ok && ext.Kubeconfig != "" {
+		return ext.Kubeconfig
+	}
+	if os.Getenv("KUBECONFIG") == "" && cluster != "" {
+		if path, ok := isolatedKubeconfigs()[cluster]; ok {
+			return path
+		}
+	}
+	return DefaultKubeconfigPath()
+}
+
+// KubeconfigForContext returns the kubeconfig that defines contextName: the
+// file of an attached external cluster using that context, then an explicit
+// $KUBECONFIG, then an isolated file defining the context, then the default
+// kubeconfig.
+func KubeconfigForContext(contextName string) string {
+	if contextName != "" {
+		clusters, _ := LoadExternalClusters()
+		for _, c := range clusters {
+			if c.Context == contextName && c.Kubeconfig != "" {
+				return c.Kubeconfig
+			}
+		}
+	}
+	if os.Getenv("KUBECONFIG") == "" && contextName != "" {
+		for _, path := range sortedValues(isolatedKubeconfigs()) {
+			if cfg, err := clientcmd.LoadFromFile(path); err == nil {
+				if _, ok := cfg.Contexts[contextName]; ok {
+					return path
+				}
+			}
+		}
+	}
+	return DefaultKubeconfigPath()
+}
+
+// IsIsolated reports whether path is one of the isolated kubeconfigs, i.e.
+// whether tools need to be pointed at it explicitly.
+func IsIsolated(path string) bool {
+	dir, err := isolatedDir()
+	return err == nil && filepath.Dir(path) == dir
+}
+
+// LoadAllContexts is LoadContexts over the default kubeconfig plus every
+// isolated one and every kubeconfig an external cluster was attached from. The
+// default kubeconfig's current-context is reported as current; a default
+// kubeconfig that cannot be read is skipped when the other files supply
+// contexts.
+func LoadAllContexts() (contexts []ContextInfo, current string, err error) {
+	contexts, current, err = LoadContexts(DefaultKubeconfigPath())
+	if os.Getenv("KUBECONFIG") != "" {
+		return contexts, current, err
+	}
+	seen := map[string]bool{}
+	for _, c := range contexts {
+		seen[c.Name] = true
+	}
+	paths := sortedValues(isolatedKubeconfigs())
+	if clusters, lerr := LoadExternalClusters(); lerr == nil {
+		for _, c := range clusters {
+			if c.Kubeconfig != "" {
+				paths = append(paths, c.Kubeconfig)
+			}
+		}
+	}
+	for _, path := range paths {
+		extra, _, lerr := LoadContexts(path)
+		if lerr != nil {
+			continue
+		}
+		for _, c := range extra {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				c.Current = false
+				contexts = append(contexts, c)
+			}
+		}
+	}
+	if len(contexts) > 0 {
+		err = nil
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, current, err
+}
+
+func sortedValues(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// KubeconfigArgs returns the --kubeconfig flag for helm (and kubectl) when
+// path is an isolated or attached kubeconfig the tool would not find by itself.
+func KubeconfigArgs(path string) []string {
+	if !IsIsolated(path) && !externalKubeconfig(path) {
+		return nil
+	}
+	return []string{"--kubeconfig", path}
+}
@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const isolatedKubeconfig = `apiVersion: v1
+kind: Config
+current-context: k3d-iso
+contexts:
+- name: k3d-iso
+  context:
+    cluster: k3d-iso
+    user: admin@k3d-iso
+clusters:
+- name: k3d-iso
+  cluster:
+    server: https://127.0.0.1:6551
+users:
+- name: admin@k3d-iso
+`
+
+// withIsolatedDir points isolatedDir at a temp dir and the default
+// kubeconfig at sampleKubeconfig via HOME.
+func withIsolatedDir(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBECONFIG", "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".kube"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".kube", "config"), []byte(sampleKubeconfig), 0o600))
+
+	dir := filepath.Join(home, ".openframe", "kubeconfigs")
+	orig := isolatedDir
+	isolatedDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { isolatedDir = orig })
+	return dir
+}
+
+func TestIsolatedKubeconfig_RoundTrip(t *testing.T) {
+	dir := withIsolatedDir(t)
+
+	path, err := WriteIsolatedKubeconfig("iso", []byte(isolatedKubeconfig))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "iso.yaml"), path)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.Equal(t, path, KubeconfigForCluster("iso"))
+	assert.Equal(t, path, KubeconfigForContext("k3d-iso"))
+	assert.True(t, IsIsolated(path))
+	assert.Equal(t, []string{"--kubeconfig", path}, KubeconfigArgs(path))
+
+	require.NoError(t, RemoveIsolatedKubeconfig("iso"))
+	require.NoError(t, RemoveIsolatedKubeconfig("iso"), "removing twice is fine")
+	assert.Equal(t, DefaultKubeconfigPath(), KubeconfigForCluster("iso"))
+}
+
+func TestWriteIsolatedKubeconfig_RejectsGarbage(t *testing.T) {
+	withIsolatedDir(t)
+	_, err := WriteIsolatedKubeconfig("bad", []byte("{not yaml"))
+	assert.Error(t, err)
+}
+
+func TestKubeconfigFor_FallsBackToDefault(t *testing.T) {
+	withIsolatedDir(t)
+	assert.Equal(t, DefaultKubeconfigPath(), KubeconfigForCluster("other"))
+	assert.Equal(t, DefaultKubeconfigPath(), KubeconfigForContext("ctx-a"))
+	assert.Nil(t, KubeconfigArgs(DefaultKubeconfigPath()))
+}
+
+func TestKubeconfigFor_ExplicitEnvWins(t *testing.T) {
+	withIsolatedDir(t)
+	_, err := WriteIsolatedKubeconfig("iso", []byte(isolatedKubeconfig))
+	require.NoError(t, err)
+
+	t.Setenv("KUBECONFIG", "/custom/kubeconfig")
+	assert.Equal(t, "/custom/kubeconfig", KubeconfigForCluster("iso"))
+	assert.Equal(t, "/custom/kubeconfig", KubeconfigForContext("k3d-iso"))
+}
+
+func TestLoadAllContexts_MergesIsolated(t *testing.T) {
+	withIsolatedDir(t)
+	_, err := WriteIsolatedKubeconfig("iso", []byte(isolatedKubeconfig))
+	require.NoError(t, err)
+
+	contexts, current, err := LoadAllContexts()
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-b", current, "the default kubeconfig's current-context stays current")
+
+	var names []string
+	for _, c := range contexts {
+		names = append(names, c.Name)
+		if c.Name == "k3d-iso" {
+			assert.False(t, c.Current)
+		}
+	}
+	assert.Equal(t, []string{"ctx-a", "ctx-b", "k3d-iso"}, names)
+}
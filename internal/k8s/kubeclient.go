@@ -0,0 +1,437 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// KubectlFallbackEnv lets KubeClient fall back to an installed kubectl when
+// the native client fails. Off by default: the CLI needs no kubectl.
+const KubectlFallbackEnv = "OPENFRAME_KUBECTL_FALLBACK"
+
+// ErrMetricsUnavailable means the cluster does not serve the metrics API
+// (metrics-server is not installed or not ready yet).
+var ErrMetricsUnavailable = errors.New("metrics API not available (is metrics-server installed?)")
+
+// KubeClient is the read-only view of a cluster the CLI uses for pods, their
+// logs, events and resource usage — what would otherwise take `kubectl get`,
+// `kubectl logs` and `kubectl top`.
+type KubeClient interface {
+	// Pods lists the pods in namespace ("" for all) matching selector.
+	Pods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error)
+	// Logs returns the log of one container of a pod.
+	Logs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error)
+	// Events lists the events in namespace ("" for all), oldest first.
+	Events(ctx context.Context, namespace string) ([]corev1.Event, error)
+	// TopNodes reports each node's current usage.
+	TopNodes(ctx context.Context) ([]Usage, error)
+	// TopPods reports each pod's current usage in namespace ("" for all).
+	TopPods(ctx context.Context, namespace string) ([]Usage, error)
+}
+
+// LogOptions selects the part of a pod's log to read.
+type LogOptions struct {
+	Container string // "" for the pod's only container
+	TailLines int64  // 0 for the whole log
+	Previous  bool   // the previous, crashed instance
+}
+
+// Usage is a node's or pod's current CPU and memory use.
+type Usage struct {
+	Namespace string // empty for nodes
+	Name      string
+	CPUMillis int64
+	MemBytes  int64
+}
+
+// NewKubeClient returns the native KubeClient for cfg. With
+// OPENFRAME_KUBECTL_FALLBACK=1 and kubectl on PATH, calls that fail natively
+// are retried with kubectl against kubeconfig and kubeContext.
+func NewKubeClient(cfg *rest.Config, ex executor.CommandExecutor, kubeconfig, kubeContext string) (KubeClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("rest.Config cannot be nil")
+	}
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	native := NewNativeClient(cs)
+	if ex == nil || !sharedconfig.EnvBool(KubectlFallbackEnv) {
+		return native, nil
+	}
+	if _, err := lookPath("kubectl"); err != nil {
+		return native, nil
+	}
+	return WithFallback(native, NewKubectlClient(ex, kubeconfig, kubeContext)), nil
+}
+
+// lookPath finds kubectl; a variable so tests can pretend it is installed.
+var lookPath = exec.LookPath
+
+// NativeClient implements KubeClient with client-go alone.
+type NativeClient struct {
+	clientset kubernetes.Interface
+}
+
+var _ KubeClient = (*NativeClient)(nil)
+
+// NewNativeClient wraps a clientset.
+func NewNativeClient(cs kubernetes.Interface) *NativeClient {
+	return &NativeClient{clientset: cs}
+}
+
+// Pods implements KubeClient.
+func (c *NativeClient) Pods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	return list.Items, nil
+}
+
+// Logs implements KubeClient.
+func (c *NativeClient) Logs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error) {
+	req := &corev1.PodLogOptions{Container: opts.Container, Previous: opts.Previous}
+	if opts.TailLines > 0 {
+		req.TailLines = &opts.TailLines
+	}
+	data, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, req).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("reading logs of %s/%s: %w", namespace, pod, err)
+	}
+	return string(data), nil
+}
+
+// Events implements KubeClient.
+func (c *NativeClient) Events(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing events: %w", err)
+	}
+	SortEvents(list.Items)
+	return list.Items, nil
+}
+
+// TopNodes implements KubeClient through metrics.k8s.io.
+func (c *NativeClient) TopNodes(ctx context.Context) ([]Usage, error) {
+	return c.metrics(ctx, "/apis/metrics.k8s.io/v1beta1/nodes")
+}
+
+// TopPods implements KubeClient through metrics.k8s.io.
+func (c *NativeClient) TopPods(ctx context.Context, namespace string) ([]Usage, error) {
+	path := "/apis/metrics.k8s.io/v1beta1/pods"
+	if namespace != "" {
+		path = "/apis/metrics.k8s.io/v1beta1/namespaces/" + namespace + "/pods"
+	}
+	return c.metrics(ctx, path)
+}
+
+// metrics reads a metrics API list as raw JSON, which spares the CLI a
+// dependency on the metrics clientset for two fields.
+func (c *NativeClient) metrics(ctx context.Context, path string) ([]Usage, error) {
+	rc := c.clientset.Discovery().RESTClient()
+	if rc == nil {
+		return nil, ErrMetricsUnavailable
+	}
+	data, err := rc.Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return ParseMetrics(data)
+}
+
+// ParseMetrics decodes a metrics.k8s.io NodeMetricsList or PodMetricsList.
+// A pod's usage is the sum of its containers'.
+func ParseMetrics(data []byte) ([]Usage, error) {
+	type resources struct {
+		CPU    string `json:"cpu"`
+		Memory string `json:"memory"`
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Usage      *resources `json:"usage"`
+			Containers []struct {
+				Usage resources `json:"usage"`
+			} `json:"containers"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing metrics: %w", err)
+	}
+	out := make([]Usage, 0, len(list.Items))
+	for _, item := range list.Items {
+		u := Usage{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}
+		all := make([]resources, 0, len(item.Containers)+1)
+		if item.Usage != nil {
+			all = append(all, *item.Usage)
+		}
+		for _, ctr := range item.Containers {
+			all = append(all, ctr.Usage)
+		}
+		for _, r := range all {
+			cpu, mem, err := parseUsage(r.CPU, r.Memory)
+			if err != nil {
+				return nil, fmt.Errorf("parsing metrics of %s: %w", u.Name, err)
+			}
+			u.CPUMillis += cpu
+			u.MemBytes += mem
+		}
+		out = append(out, u)
+	}
+	sortUsage(out)
+	return out, nil
+}
+
+func parseUsage(cpu, mem string) (cpuMillis, memBytes int64, err error) {
+	if cpu != "" {
+		q, err := resource.ParseQuantity(cpu)
+		if err != nil {
+			return 0, 0, err
+		}
+		cpuMillis = q.MilliValue()
+	}
+	if mem != "" {
+		q, err := resource.ParseQuantity(mem)
+		if err != nil {
+			return 0, 0, err
+		}
+		memBytes = q.Value()
+	}
+	return cpuMillis, memBytes, nil
+}
+
+func sortUsage(u []Usage) {
+	sort.Slice(u, func(i, j int) bool {
+		if u[i].Namespace != u[j].Namespace {
+			return u[i].Namespace < u[j].Namespace
+		}
+		return u[i].Name < u[j].Name
+	})
+}
+
+// SortEvents orders events oldest first by their last occurrence.
+func SortEvents(events []corev1.Event) {
+	when := func(e corev1.Event) metav1.Time {
+		if !e.LastTimestamp.IsZero() {
+			return e.LastTimestamp
+		}
+		return metav1.NewTime(e.EventTime.Time)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		wi, wj := when(events[i]), when(events[j])
+		return wi.Before(&wj)
+	})
+}
+
+// KubectlClient implements KubeClient by running kubectl. It exists only as
+// the fallback NewKubeClient adds on request.
+type KubectlClient struct {
+	exec executor.CommandExecutor
+	args []string // --kubeconfig/--context
+}
+
+var _ KubeClient = (*KubectlClient)(nil)
+
+// NewKubectlClient runs kubectl against kubeconfig and kubeContext (either
+// may be empty for kubectl's own defaults).
+func NewKubectlClient(ex executor.CommandExecutor, kubeconfig, kubeContext string) *KubectlClient {
+	var args []string
+	if kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	return &KubectlClient{exec: ex, args: args}
+}
+
+func (c *KubectlClient) run(ctx context.Context, args ...string) (string, error) {
+	result, err := c.exec.Execute(ctx, "kubectl", append(append([]string{}, c.args...), args...)...)
+	if err != nil {
+		if result != nil && strings.TrimSpace(result.Stderr) != "" {
+			return "", fmt.Errorf("kubectl %s: %w: %s", args[0], err, strings.TrimSpace(result.Stderr))
+		}
+		return "", fmt.Errorf("kubectl %s: %w", args[0], err)
+	}
+	return result.Stdout, nil
+}
+
+func namespaceArgs(namespace string) []string {
+	if namespace == "" {
+		return []string{"--all-namespaces"}
+	}
+	return []string{"-n", namespace}
+}
+
+// Pods implements KubeClient.
+func (c *KubectlClient) Pods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	args := append([]string{"get", "pods", "-o", "json"}, namespaceArgs(namespace)...)
+	if selector != "" {
+		args = append(args, "-l", selector)
+	}
+	out, err := c.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	var list corev1.PodList
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		return nil, fmt.Errorf("parsing kubectl pods: %w", err)
+	}
+	return list.Items, nil
+}
+
+// Logs implements KubeClient.
+func (c *KubectlClient) Logs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error) {
+	args := []string{"logs", pod, "-n", namespace}
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	}
+	if opts.TailLines > 0 {
+		args = append(args, fmt.Sprintf("--tail=%d", opts.TailLines))
+	}
+	if opts.Previous {
+		args = append(args, "--previous")
+	}
+	return c.run(ctx, args...)
+}
+
+// Events implements KubeClient.
+func (c *KubectlClient) Events(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	out, err := c.run(ctx, append([]string{"get", "events", "-o", "json"}, namespaceArgs(namespace)...)...)
+	if err != nil {
+		return nil, err
+	}
+	var list corev1.EventList
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		return nil, fmt.Errorf("parsing kubectl events: %w", err)
+	}
+	SortEvents(list.Items)
+	return list.Items, nil
+}
+
+// TopNodes implements KubeClient.
+func (c *KubectlClient) TopNodes(ctx context.Context) ([]Usage, error) {
+	out, err := c.run(ctx, "top", "nodes", "--no-headers")
+	if err != nil {
+		return nil, err
+	}
+	return parseTop(out, false)
+}
+
+// TopPods implements KubeClient.
+func (c *KubectlClient) TopPods(ctx context.Context, namespace string) ([]Usage, error) {
+	out, err := c.run(ctx, append([]string{"top", "pods", "--no-headers"}, namespaceArgs(namespace)...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseTop(out, namespace == "")
+}
+
+// parseTop reads `kubectl top --no-headers` output: NAME CPU MEMORY for pods
+// in one namespace, NAMESPACE NAME CPU MEMORY across all of them, and
+// NAME CPU CPU% MEMORY MEMORY% for nodes.
+func parseTop(out string, withNamespace bool) ([]Usage, error) {
+	var usage []Usage
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		var u Usage
+		if withNamespace {
+			if len(f) < 4 {
+				return nil, fmt.Errorf("unexpected kubectl top line %q", line)
+			}
+			u.Namespace, f = f[0], f[1:]
+		}
+		if len(f) < 3 {
+			return nil, fmt.Errorf("unexpected kubectl top line %q", line)
+		}
+		u.Name = f[0]
+		mem := f[2]
+		if strings.HasSuffix(mem, "%") && len(f) >= 4 { // nodes: CPU CPU% MEMORY MEMORY%
+			mem = f[3]
+		}
+		cpu, memBytes, err := parseUsage(f[1], mem)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected kubectl top line %q: %w", line, err)
+		}
+		u.CPUMillis, u.MemBytes = cpu, memBytes
+		usage = append(usage, u)
+	}
+	sortUsage(usage)
+	return usage, nil
+}
+
+// fallbackClient tries primary first and fallback only when primary fails.
+type fallbackClient struct {
+	primary, fallback KubeClient
+}
+
+// WithFallback returns a KubeClient that retries each failed call of primary
+// with fallback. When both fail, primary's error is reported.
+func WithFallback(primary, fallback KubeClient) KubeClient {
+	return &fallbackClient{primary: primary, fallback: fallback}
+}
+
+func fallback[T any](primary func() (T, error), secondary func() (T, error)) (T, error) {
+	v, err := primary()
+	if err == nil {
+		return v, nil
+	}
+	if v2, err2 := secondary(); err2 == nil {
+		return v2, nil
+	}
+	return v, err
+}
+
+func (c *fallbackClient) Pods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	return fallback(
+		func() ([]corev1.Pod, error) { return c.primary.Pods(ctx, namespace, selector) },
+		func() ([]corev1.Pod, error) { return c.fallback.Pods(ctx, namespace, selector) })
+}
+
+func (c *fallbackClient) Logs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error) {
+	return fallback(
+		func() (string, error) { return c.primary.Logs(ctx, namespace, pod, opts) },
+		func() (string, error) { return c.fallback.Logs(ctx, namespace, pod, opts) })
+}
+
+func (c *fallbackClient) Events(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	return fallback(
+		func() ([]corev1.Event, error) { return c.primary.Events(ctx, namespace) },
+		func() ([]corev1.Event, error) { return c.fallback.Events(ctx, namespace) })
+}
+
+func (c *fallbackClient) TopNodes(ctx context.Context) ([]Usage, error) {
+	return fallback(
+		func() ([]Usage, error) { return c.primary.TopNodes(ctx) },
+		func() ([]Usage, error) { return c.fallback.TopNodes(ctx) })
+}
+
+func (c *fallbackClient) TopPods(ctx context.Context, namespace string) ([]Usage, error) {
+	return fallback(
+		func() ([]Usage, error) { return c.primary.TopPods(ctx, namespace) },
+		func() ([]Usage, error) { return c.fallback.TopPods(ctx, namespace) })
+}
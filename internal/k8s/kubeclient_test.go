@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseMetrics(t *testing.T) {
+	nodes, err := ParseMetrics([]byte(`{"kind":"NodeMetricsList","items":[
+		{"metadata":{"name":"k3d-dev-server-0"},"usage":{"cpu":"250m","memory":"1Gi"}},
+		{"metadata":{"name":"k3d-dev-agent-0"},"usage":{"cpu":"1","memory":"512Mi"}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Usage{
+		{Name: "k3d-dev-agent-0", CPUMillis: 1000, MemBytes: 512 << 20},
+		{Name: "k3d-dev-server-0", CPUMillis: 250, MemBytes: 1 << 30},
+	}, nodes, "sorted by name")
+
+	pods, err := ParseMetrics([]byte(`{"kind":"PodMetricsList","items":[
+		{"metadata":{"name":"api-1","namespace":"openframe"},"containers":[
+			{"name":"api","usage":{"cpu":"100m","memory":"200Mi"}},
+			{"name":"sidecar","usage":{"cpu":"5m","memory":"10Mi"}}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Usage{{Namespace: "openframe", Name: "api-1", CPUMillis: 105, MemBytes: 210 << 20}}, pods,
+		"a pod's usage is the sum of its containers'")
+
+	_, err = ParseMetrics([]byte(`{"items":[{"metadata":{"name":"n"},"usage":{"cpu":"lots"}}]}`))
+	assert.Error(t, err)
+}
+
+func TestParseTop(t *testing.T) {
+	nodes, err := parseTop("k3d-dev-server-0   250m   6%   1024Mi   13%\n", false)
+	require.NoError(t, err)
+	assert.Equal(t, []Usage{{Name: "k3d-dev-server-0", CPUMillis: 250, MemBytes: 1 << 30}}, nodes)
+
+	pods, err := parseTop("openframe   api-1   105m   210Mi\nargocd   repo-1   1   1Gi\n", true)
+	require.NoError(t, err)
+	assert.Equal(t, []Usage{
+		{Namespace: "argocd", Name: "repo-1", CPUMillis: 1000, MemBytes: 1 << 30},
+		{Namespace: "openframe", Name: "api-1", CPUMillis: 105, MemBytes: 210 << 20},
+	}, pods)
+
+	_, err = parseTop("garbage\n", false)
+	assert.Error(t, err)
+}
+
+func TestNativeClient(t *testing.T) {
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	newer := metav1.NewTime(time.Now())
+	cs := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "openframe", Labels: map[string]string{"app": "api"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "repo-1", Namespace: "argocd"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "openframe"}, LastTimestamp: newer},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "early", Namespace: "openframe"}, LastTimestamp: older},
+	)
+	c := NewNativeClient(cs)
+	ctx := context.Background()
+
+	pods, err := c.Pods(ctx, "", "app=api")
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "api-1", pods[0].Name)
+
+	events, err := c.Events(ctx, "openframe")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "early", events[0].Name, "oldest first")
+
+	logs, err := c.Logs(ctx, "openframe", "api-1", LogOptions{TailLines: 10})
+	require.NoError(t, err)
+	assert.NotEmpty(t, logs)
+
+	_, err = c.TopNodes(ctx)
+	assert.ErrorIs(t, err, ErrMetricsUnavailable, "the fake serves no metrics API")
+}
+
+func TestKubectlClient_PassesKubeconfigAndContext(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ex.SetResponse("logs api-1", &executor.CommandResult{Stdout: "boom\n"})
+	c := NewKubectlClient(ex, "/tmp/iso.yaml", "k3d-dev")
+
+	out, err := c.Logs(context.Background(), "openframe", "api-1", LogOptions{Container: "api", TailLines: 50, Previous: true})
+	require.NoError(t, err)
+	assert.Equal(t, "boom\n", out)
+
+	cmds := ex.Commands()
+	require.Len(t, cmds, 1)
+	assert.Equal(t, "kubectl", cmds[0].Name)
+	assert.Equal(t, []string{"--kubeconfig", "/tmp/iso.yaml", "--context", "k3d-dev",
+		"logs", "api-1", "-n", "openframe", "-c", "api", "--tail=50", "--previous"}, cmds[0].Args)
+}
+
+// stubClient answers TopNodes only; everything else is unused here.
+type stubClient struct {
+	KubeClient
+	usage []Usage
+	err   error
+	calls int
+}
+
+func (s *stubClient) TopNodes(context.Context) ([]Usage, error) {
+	s.calls++
+	return s.usage, s.err
+}
+
+func TestWithFallback(t *testing.T) {
+	ctx := context.Background()
+
+	ok := &stubClient{usage: []Usage{{Name: "native"}}}
+	unused := &stubClient{usage: []Usage{{Name: "kubectl"}}}
+	got, err := WithFallback(ok, unused).TopNodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "native", got[0].Name)
+	assert.Zero(t, unused.calls, "the fallback only runs when the native client fails")
+
+	failing := &stubClient{err: ErrMetricsUnavailable}
+	got, err = WithFallback(failing, &stubClient{usage: []Usage{{Name: "kubectl"}}}).TopNodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "kubectl", got[0].Name)
+
+	_, err = WithFallback(failing, &stubClient{err: errors.New("kubectl: not found")}).TopNodes(ctx)
+	assert.ErrorIs(t, err, ErrMetricsUnavailable, "the native error is the one reported")
+}
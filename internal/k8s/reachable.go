@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// DialAPI checks that the API server config points at accepts TCP
+// connections. It fails fast on a stopped cluster, where a client request
+// would wait for its own, longer timeout.
+func DialAPI(ctx context.Context, config *rest.Config, timeout time.Duration) error {
+	address, err := apiAddress(config.Host)
+	if err != nil {
+		return err
+	}
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("API server %s is not accepting connections: %w", address, err)
+	}
+	return conn.Close()
+}
+
+// apiAddress is the host:port of an API server URL, with the scheme's port
+// when it has none.
+func apiAddress(host string) (string, error) {
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid API server address %q", host)
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "443"
+	if u.Scheme == "http" {
+		port = "80"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestAPIAddress(t *testing.T) {
+	for host, want := range map[string]string{
+		"https://127.0.0.1:6550":   "127.0.0.1:6550",
+		"https://api.example.com":  "api.example.com:443",
+		"http://localhost":         "localhost:80",
+		"https://[::1]:6443/base/": "[::1]:6443",
+	} {
+		got, err := apiAddress(host)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, host)
+	}
+	_, err := apiAddress("127.0.0.1:6550")
+	assert.Error(t, err, "a host without a scheme is not a URL")
+}
+
+func TestDialAPI(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, DialAPI(context.Background(), &rest.Config{Host: "https://" + addr}, time.Second))
+
+	require.NoError(t, ln.Close())
+	err = DialAPI(context.Background(), &rest.Config{Host: "https://" + addr}, time.Second)
+	assert.ErrorContains(t, err, "not accepting connections")
+}
@@ -24,3 +24,20 @@ func RestConfigForContext(kubeconfigPath, contextName string) (*rest.Config, err
 	}
 	return cfg, nil
 }
+
+// RestConfigForCluster builds a *rest.Config for a managed or attached cluster
+// by name, from the kubeconfig and context that define it. Unlike the cluster
+// service's GetRestConfig it only reads: no kubeconfig is written and the
+// current context is left alone.
+func RestConfigForCluster(name string) (*rest.Config, error) {
+	path := KubeconfigForCluster(name)
+	return RestConfigForContext(path, ResolveContextForCluster(path, name))
+}
+
+// RestConfigForContextFlag builds a *rest.Config for the value of a --context
+// flag: a kube-context, the name of an attached external cluster, or empty for
+// the current context. The context's own kubeconfig is used when it has one.
+func RestConfigForContextFlag(contextName string) (*rest.Config, error) {
+	name := ResolveContextName(contextName)
+	return RestConfigForContext(KubeconfigForContext(name), name)
+}
@@ -32,3 +32,30 @@ func TestRestConfigForContext_UnknownContext(t *testing.T) {
 	_, err := RestConfigForContext(path, "does-not-exist")
 	require.Error(t, err)
 }
+
+func TestRestConfigForContextFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBECONFIG", writeKubeconfig(t, sampleKubeconfig))
+
+	cfg, err := RestConfigForContextFlag("ctx-a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example", cfg.Host)
+
+	cfg, err = RestConfigForContextFlag("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://b.example", cfg.Host, "empty is the current context")
+}
+
+func TestRestConfigForCluster_OnlyReads(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeKubeconfig(t, sampleKubeconfig)
+	t.Setenv("KUBECONFIG", path)
+
+	cfg, err := RestConfigForCluster("ctx-a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example", cfg.Host)
+
+	_, current, err := LoadContexts(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-b", current, "the current context is left alone")
+}
@@ -0,0 +1,159 @@
+// Package localdns implements `openframe dns`: wildcard name resolution for
+// local ingress testing. Every name under a domain (*.openframe.local by
+// default) resolves to the cluster's ingress address, so any number of
+// subdomains reach the ingress controller without a hosts-file entry each.
+//
+// Two ways to get there: a tiny embedded DNS server that answers for the
+// domain only (Server), with the OS resolver pointed at it for that domain, or
+// a dnsmasq rule that needs no server at all. ResolverSetup renders the OS
+// side of either.
+package localdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Defaults of `openframe dns`.
+const (
+	DefaultDomain = "openframe.local"
+	// DefaultListen is off the privileged port, so the server runs without
+	// root, and off mDNS's 5353, which avahi and mDNSResponder already hold;
+	// resolvers that cannot use another port need 127.0.0.1:53.
+	DefaultListen = "127.0.0.1:15353"
+	// DefaultIP is where k3d publishes the ingress ports 80 and 443.
+	DefaultIP = "127.0.0.1"
+)
+
+// ttl is how long answers may be cached. Short: the ingress address only
+// changes when the cluster is recreated elsewhere, but then it should not
+// take long to notice.
+const ttl = 60
+
+// Server answers A (or AAAA, for an IPv6 address) queries for Domain and
+// every name under it with IP. Other names are refused: it is not a
+// recursive resolver, and the OS only sends it the domain's queries.
+type Server struct {
+	Domain string
+	IP     net.IP
+}
+
+// ListenAndServe serves DNS over UDP on addr until ctx is done. ready, when
+// not nil, receives the bound address once the server listens.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, ready func(net.Addr)) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	if ready != nil {
+		ready(conn.LocalAddr())
+	}
+
+	buf := make([]byte, 512)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			continue
+		}
+		if reply := s.Handle(buf[:n]); reply != nil {
+			_, _ = conn.WriteTo(reply, from)
+		}
+	}
+}
+
+// Handle answers one DNS query message. It returns nil for a packet too
+// broken to answer.
+func (s *Server) Handle(query []byte) []byte {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(query)
+	if err != nil {
+		return nil
+	}
+	resp := dnsmessage.Header{
+		ID:                 hdr.ID,
+		Response:           true,
+		OpCode:             hdr.OpCode,
+		RecursionDesired:   hdr.RecursionDesired,
+		Authoritative:      true,
+		RecursionAvailable: false,
+	}
+	questions, err := p.AllQuestions()
+	if err != nil || len(questions) != 1 {
+		resp.RCode = dnsmessage.RCodeFormatError
+		return build(resp, nil, nil)
+	}
+	q := questions[0]
+	switch {
+	case hdr.OpCode != 0:
+		resp.RCode = dnsmessage.RCodeNotImplemented
+		return build(resp, &q, nil)
+	case q.Class != dnsmessage.ClassINET || !s.owns(q.Name.String()):
+		resp.Authoritative = false
+		resp.RCode = dnsmessage.RCodeRefused
+		return build(resp, &q, nil)
+	}
+	return build(resp, &q, s.answer(q))
+}
+
+// owns reports whether name is Domain or a name under it.
+func (s *Server) owns(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	domain := strings.ToLower(strings.Trim(s.Domain, "."))
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+// answer is the record for q, or nil when the address has no record of the
+// asked type (an empty NOERROR answer, so the client tries the other family).
+func (s *Server) answer(q dnsmessage.Question) dnsmessage.ResourceBody {
+	v4 := s.IP.To4()
+	switch {
+	case v4 != nil && (q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeALL):
+		var a dnsmessage.AResource
+		copy(a.A[:], v4)
+		return &a
+	case v4 == nil && s.IP != nil && (q.Type == dnsmessage.TypeAAAA || q.Type == dnsmessage.TypeALL):
+		var a dnsmessage.AAAAResource
+		copy(a.AAAA[:], s.IP.To16())
+		return &a
+	}
+	return nil
+}
+
+func build(hdr dnsmessage.Header, q *dnsmessage.Question, body dnsmessage.ResourceBody) []byte {
+	b := dnsmessage.NewBuilder(make([]byte, 0, 512), hdr)
+	b.EnableCompression()
+	if q != nil {
+		_ = b.StartQuestions()
+		_ = b.Question(*q)
+	}
+	if body != nil {
+		_ = b.StartAnswers()
+		rh := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: ttl}
+		switch r := body.(type) {
+		case *dnsmessage.AResource:
+			_ = b.AResource(rh, *r)
+		case *dnsmessage.AAAAResource:
+			_ = b.AAAAResource(rh, *r)
+		}
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		return nil
+	}
+	return msg
+}
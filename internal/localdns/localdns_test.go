@@ -0,0 +1,118 @@
+package localdns
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func query(t *testing.T, name string, qtype dnsmessage.Type) []byte {
+	t.Helper()
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, RecursionDesired: true})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(dnsmessage.Question{Name: dnsmessage.MustNewName(name), Type: qtype, Class: dnsmessage.ClassINET}))
+	msg, err := b.Finish()
+	require.NoError(t, err)
+	return msg
+}
+
+func TestHandle(t *testing.T) {
+	s := &Server{Domain: "openframe.local", IP: net.ParseIP("127.0.0.1")}
+	tests := []struct {
+		name    string
+		qname   string
+		qtype   dnsmessage.Type
+		rcode   dnsmessage.RCode
+		answers int
+	}{
+		{name: "subdomain", qname: "api.openframe.local.", qtype: dnsmessage.TypeA, answers: 1},
+		{name: "nested subdomain, any case", qname: "Grafana.Tenant.OpenFrame.local.", qtype: dnsmessage.TypeA, answers: 1},
+		{name: "apex", qname: "openframe.local.", qtype: dnsmessage.TypeA, answers: 1},
+		{name: "IPv6 of an IPv4 ingress: no data", qname: "api.openframe.local.", qtype: dnsmessage.TypeAAAA},
+		{name: "another domain: refused", qname: "example.com.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeRefused},
+		{name: "a look-alike domain: refused", qname: "notopenframe.local.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeRefused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msg dnsmessage.Message
+			require.NoError(t, msg.Unpack(s.Handle(query(t, tt.qname, tt.qtype))))
+			assert.Equal(t, uint16(42), msg.ID)
+			assert.True(t, msg.Response)
+			assert.Equal(t, tt.rcode, msg.RCode)
+			require.Len(t, msg.Answers, tt.answers)
+			if tt.answers > 0 {
+				assert.Equal(t, [4]byte{127, 0, 0, 1}, msg.Answers[0].Body.(*dnsmessage.AResource).A)
+			}
+		})
+	}
+
+	assert.Nil(t, s.Handle([]byte{0x01}), "garbage is dropped")
+}
+
+func TestListenAndServe(t *testing.T) {
+	s := &Server{Domain: "openframe.local", IP: net.ParseIP("10.0.0.7")}
+	ctx, cancel := context.WithCancel(context.Background())
+	addr := make(chan net.Addr, 1)
+	done := make(chan error, 1)
+	go func() { done <- s.ListenAndServe(ctx, "127.0.0.1:0", func(a net.Addr) { addr <- a }) }()
+	bound := (<-addr).String()
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", bound)
+		},
+	}
+	lookupCtx, lookupCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer lookupCancel()
+	ips, err := resolver.LookupIPAddr(lookupCtx, "argocd.openframe.local")
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.Equal(t, "10.0.0.7", ips[0].IP.String())
+
+	cancel()
+	assert.NoError(t, <-done, "a cancelled server stops cleanly")
+}
+
+func TestResolverSetup(t *testing.T) {
+	tests := []struct {
+		goos, mode, listen string
+		want               Setup
+		wantErr            bool
+	}{
+		{goos: "darwin", mode: ModeServer, listen: DefaultListen, want: Setup{Files: []File{
+			{Path: "/etc/resolver/openframe.local", Content: "nameserver 127.0.0.1\nport 15353\n"},
+		}}},
+		{goos: "linux", mode: ModeServer, listen: DefaultListen, want: Setup{
+			Files:    []File{{Path: "/etc/systemd/resolved.conf.d/openframe-openframe.local.conf", Content: "[Resolve]\nDNS=127.0.0.1:15353\nDomains=~openframe.local\n"}},
+			Commands: []string{"systemctl restart systemd-resolved"},
+		}},
+		{goos: "windows", mode: ModeServer, listen: DefaultListen, wantErr: true},
+		{goos: "windows", mode: ModeServer, listen: "127.0.0.1:53", want: Setup{Commands: []string{
+			`powershell -Command "Add-DnsClientNrptRule -Namespace '.openframe.local' -NameServers '127.0.0.1'"`,
+		}}},
+		{goos: "linux", mode: ModeDnsmasq, listen: DefaultListen, want: Setup{
+			Files:    []File{{Path: "/etc/dnsmasq.d/openframe-openframe.local.conf", Content: "address=/openframe.local/127.0.0.1\n"}},
+			Commands: []string{"systemctl restart dnsmasq"},
+		}},
+		{goos: "windows", mode: ModeDnsmasq, listen: DefaultListen, wantErr: true},
+		{goos: "linux", mode: "hosts", listen: DefaultListen, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.mode+"/"+tt.listen, func(t *testing.T) {
+			got, err := ResolverSetup(tt.goos, tt.mode, DefaultDomain, tt.listen, DefaultIP)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
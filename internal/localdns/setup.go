@@ -0,0 +1,96 @@
+package localdns
+
+import (
+	"fmt"
+	"net"
+)
+
+// Modes of ResolverSetup.
+const (
+	// ModeServer points the OS resolver at the embedded server for the domain.
+	ModeServer = "server"
+	// ModeDnsmasq answers for the domain with a dnsmasq rule instead.
+	ModeDnsmasq = "dnsmasq"
+)
+
+// Modes lists the supported modes, for help texts.
+var Modes = []string{ModeServer, ModeDnsmasq}
+
+// File is a resolver configuration file to write.
+type File struct {
+	Path    string
+	Content string
+}
+
+// Setup is what makes the OS resolve the domain: files to write, then
+// commands to run. Both need root.
+type Setup struct {
+	Files    []File
+	Commands []string
+}
+
+// ResolverSetup renders the resolver configuration for goos: in server mode
+// the domain is delegated to the server listening on listen; in dnsmasq mode
+// dnsmasq answers every name under the domain with ip itself.
+func ResolverSetup(goos, mode, domain, listen, ip string) (Setup, error) {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return Setup{}, fmt.Errorf("invalid listen address %q: %w", listen, err)
+	}
+	name := "openframe-" + domain + ".conf"
+
+	switch mode {
+	case ModeServer:
+		switch goos {
+		case "darwin":
+			// macOS sends queries for a domain to the resolver named after it.
+			return Setup{Files: []File{{
+				Path:    "/etc/resolver/" + domain,
+				Content: fmt.Sprintf("nameserver %s\nport %s\n", host, port),
+			}}}, nil
+		case "linux":
+			// systemd-resolved routes the ~domain to this server only.
+			return Setup{
+				Files: []File{{
+					Path:    "/etc/systemd/resolved.conf.d/" + name,
+					Content: fmt.Sprintf("[Resolve]\nDNS=%s\nDomains=~%s\n", listen, domain),
+				}},
+				Commands: []string{"systemctl restart systemd-resolved"},
+			}, nil
+		case "windows":
+			// The Name Resolution Policy Table has no port setting.
+			if port != "53" {
+				return Setup{}, fmt.Errorf("windows only queries DNS servers on port 53: serve with --listen %s", net.JoinHostPort(host, "53"))
+			}
+			return Setup{Commands: []string{
+				fmt.Sprintf(`powershell -Command "Add-DnsClientNrptRule -Namespace '.%s' -NameServers '%s'"`, domain, host),
+			}}, nil
+		}
+	case ModeDnsmasq:
+		rule := File{Content: fmt.Sprintf("address=/%s/%s\n", domain, ip)}
+		switch goos {
+		case "darwin":
+			// Homebrew's dnsmasq listens on port 53; the resolver file sends
+			// the domain there.
+			rule.Path = "/opt/homebrew/etc/dnsmasq.d/" + name
+			return Setup{
+				Files: []File{rule, {
+					Path:    "/etc/resolver/" + domain,
+					Content: "nameserver 127.0.0.1\n",
+				}},
+				Commands: []string{"brew services restart dnsmasq"},
+			}, nil
+		case "linux":
+			rule.Path = "/etc/dnsmasq.d/" + name
+			return Setup{
+				Files:    []File{rule},
+				Commands: []string{"systemctl restart dnsmasq"},
+			}, nil
+		case "windows":
+			return Setup{}, fmt.Errorf("dnsmasq is not available on windows: use --mode %s", ModeServer)
+		}
+	default:
+		return Setup{}, fmt.Errorf("unknown mode %q (want %s or %s)", mode, ModeServer, ModeDnsmasq)
+	}
+	return Setup{}, fmt.Errorf("no resolver setup for %s", goos)
+}
@@ -0,0 +1,162 @@
+// Package logs streams the logs of an OpenFrame application's pods: the
+// workloads ArgoCD manages for it are resolved to pods, and every container's
+// log is merged into one prefixed, colorized stream.
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/component"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxLine bounds one log line; a longer one ends that container's stream.
+const maxLine = 1 << 20
+
+// Source is one container whose log is streamed.
+type Source struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// Options selects the part of each log to stream.
+type Options struct {
+	Follow    bool
+	TailLines int64         // < 0 for the whole log
+	Since     time.Duration // 0 for no limit
+}
+
+// Sources resolves workloads to the containers of their running pods,
+// optionally only the container named container.
+func Sources(ctx context.Context, cs kubernetes.Interface, workloads []argocd.Workload, container string) ([]Source, error) {
+	pods, err := component.Pods(ctx, cs, workloads)
+	if err != nil {
+		return nil, err
+	}
+	var out []Source
+	for _, p := range pods {
+		if p.Status.Phase == corev1.PodPending {
+			continue
+		}
+		for _, c := range p.Spec.Containers {
+			if container == "" || c.Name == container {
+				out = append(out, Source{Namespace: p.Namespace, Pod: p.Name, Container: c.Name})
+			}
+		}
+	}
+	if len(out) == 0 {
+		if container != "" {
+			return nil, fmt.Errorf("no running pod has a container named %q", container)
+		}
+		return nil, fmt.Errorf("no running pods")
+	}
+	return out, nil
+}
+
+// palette colors the prefixes; sources take them in turn.
+var palette = []pterm.Color{pterm.FgCyan, pterm.FgGreen, pterm.FgYellow, pterm.FgMagenta, pterm.FgBlue, pterm.FgLightRed}
+
+// Stream copies the log of every source to out, one line at a time, each
+// prefixed with its pod (and container, when a pod has several) in that
+// source's color. A source that fails is reported on out without stopping the
+// others; Stream errors only when every source failed.
+func Stream(ctx context.Context, cs kubernetes.Interface, sources []Source, opts Options, out io.Writer) error {
+	prefixes := Prefixes(sources)
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed int
+		first  error
+	)
+	write := func(prefix, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = fmt.Fprintf(out, "%s %s\n", prefix, line)
+	}
+	for i, src := range sources {
+		prefix := palette[i%len(palette)].Sprint(prefixes[i])
+		wg.Add(1)
+		go func(src Source, prefix string) {
+			defer wg.Done()
+			if err := streamOne(ctx, cs, src, opts, func(line string) { write(prefix, line) }); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				write(prefix, pterm.Red("error: "+err.Error()))
+				mu.Lock()
+				failed++
+				if first == nil {
+					first = err
+				}
+				mu.Unlock()
+			}
+		}(src, prefix)
+	}
+	wg.Wait()
+	if failed == len(sources) && first != nil {
+		return first
+	}
+	return nil
+}
+
+func streamOne(ctx context.Context, cs kubernetes.Interface, src Source, opts Options, emit func(string)) error {
+	req := &corev1.PodLogOptions{Container: src.Container, Follow: opts.Follow}
+	if opts.TailLines >= 0 {
+		tail := opts.TailLines
+		req.TailLines = &tail
+	}
+	if opts.Since > 0 {
+		secs := int64(opts.Since.Seconds())
+		req.SinceSeconds = &secs
+	}
+	rc, err := cs.CoreV1().Pods(src.Namespace).GetLogs(src.Pod, req).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("streaming logs of %s/%s: %w", src.Pod, src.Container, err)
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+	for scanner.Scan() {
+		emit(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("reading logs of %s/%s: %w", src.Pod, src.Container, err)
+	}
+	return nil
+}
+
+// Prefixes returns each source's label, padded to a common width: the pod
+// name, plus "/container" for pods streamed with more than one container.
+func Prefixes(sources []Source) []string {
+	perPod := map[string]int{}
+	for _, s := range sources {
+		perPod[s.Namespace+"/"+s.Pod]++
+	}
+	labels := make([]string, len(sources))
+	width := 0
+	for i, s := range sources {
+		labels[i] = s.Pod
+		if perPod[s.Namespace+"/"+s.Pod] > 1 {
+			labels[i] += "/" + s.Container
+		}
+		if len(labels[i]) > width {
+			width = len(labels[i])
+		}
+	}
+	for i := range labels {
+		labels[i] += strings.Repeat(" ", width-len(labels[i]))
+	}
+	return labels
+}
@@ -0,0 +1,86 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var workloads = []argocd.Workload{
+	{App: "openframe-api", Kind: "Deployment", Namespace: "openframe", Name: "openframe-api"},
+	{App: "kafka", Kind: "StatefulSet", Namespace: "datasources", Name: "kafka"},
+	{App: "kafka", Kind: "Deployment", Namespace: "datasources", Name: "kafka-ui"},
+}
+
+func pod(name, app string, phase corev1.PodPhase, containers ...string) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openframe", Labels: map[string]string{"app": app}},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	for _, c := range containers {
+		p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+	}
+	return p
+}
+
+func TestSources_ResolvesPodsThroughTheSelector(t *testing.T) {
+	cs := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "openframe-api", Namespace: "openframe"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+		},
+		pod("api-b", "api", corev1.PodRunning, "api", "istio-proxy"),
+		pod("api-a", "api", corev1.PodRunning, "api"),
+		pod("api-c", "api", corev1.PodPending, "api"),
+		pod("ui-a", "ui", corev1.PodRunning, "ui"),
+	)
+	ctx := context.Background()
+
+	got, err := Sources(ctx, cs, workloads[:1], "")
+	require.NoError(t, err)
+	assert.Equal(t, []Source{
+		{Namespace: "openframe", Pod: "api-a", Container: "api"},
+		{Namespace: "openframe", Pod: "api-b", Container: "api"},
+		{Namespace: "openframe", Pod: "api-b", Container: "istio-proxy"},
+	}, got, "pending pods and other apps' pods are left out")
+
+	got, err = Sources(ctx, cs, workloads[:1], "istio-proxy")
+	require.NoError(t, err)
+	assert.Equal(t, []Source{{Namespace: "openframe", Pod: "api-b", Container: "istio-proxy"}}, got)
+
+	_, err = Sources(ctx, cs, workloads[:1], "missing")
+	assert.Error(t, err)
+}
+
+func TestPrefixes_AddContainerOnlyForMultiContainerPods(t *testing.T) {
+	got := Prefixes([]Source{
+		{Namespace: "ns", Pod: "api-a", Container: "api"},
+		{Namespace: "ns", Pod: "api-b", Container: "api"},
+		{Namespace: "ns", Pod: "api-b", Container: "proxy"},
+	})
+	assert.Equal(t, []string{"api-a      ", "api-b/api  ", "api-b/proxy"}, got)
+}
+
+func TestStream_PrefixesEveryLine(t *testing.T) {
+	pterm.DisableColor()
+	defer pterm.EnableColor()
+
+	cs := fake.NewSimpleClientset()
+	var out bytes.Buffer
+	sources := []Source{{Namespace: "ns", Pod: "api-a", Container: "api"}, {Namespace: "ns", Pod: "ui-a", Container: "ui"}}
+	require.NoError(t, Stream(context.Background(), cs, sources, Options{TailLines: 10}, &out))
+
+	// The fake clientset serves "fake logs" for every container.
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.ElementsMatch(t, []string{"api-a fake logs", "ui-a  fake logs"}, lines)
+}
@@ -0,0 +1,386 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// SetLabel marks every object applied as part of a named set, so a later
+// apply with Prune can find and delete the ones that were dropped.
+const SetLabel = "openframe.io/apply-set"
+
+// crdEstablishTimeout bounds the wait for CRDs applied in the same batch as
+// their custom resources.
+const crdEstablishTimeout = time.Minute
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// Options tune Apply and Delete.
+type Options struct {
+	// Namespace is used for namespaced objects that set none ("default" if empty).
+	Namespace string
+	// Set labels every applied object with SetLabel=Set. Required for Prune.
+	Set string
+	// Prune deletes objects of the set that are no longer in the manifests.
+	// Like `kubectl apply --prune`, only kinds present in this apply are
+	// searched.
+	Prune bool
+	// Wait blocks until every object is ready (Apply) or gone (Delete).
+	Wait    bool
+	Timeout time.Duration
+	// DryRun sends every request with server-side dry-run: the API server
+	// validates and defaults it but persists nothing.
+	DryRun bool
+}
+
+// Ref names one object in a Result.
+type Ref struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r Ref) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// Result lists what Apply or Delete touched, in order.
+type Result struct {
+	Applied []Ref `json:"applied,omitempty"`
+	Pruned  []Ref `json:"pruned,omitempty"`
+	Deleted []Ref `json:"deleted,omitempty"`
+}
+
+// Engine applies and deletes manifests through the dynamic client. Kinds are
+// resolved to resources with a discovery-backed RESTMapper, which is reset
+// after CRDs are applied so their custom resources in the same batch resolve.
+type Engine struct {
+	client dynamic.Interface
+	mapper meta.ResettableRESTMapper
+}
+
+// NewEngine builds an Engine for cfg.
+func NewEngine(cfg *rest.Config) (*Engine, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating discovery client: %w", err)
+	}
+	dyn, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+	return NewEngineWith(dyn, restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))), nil
+}
+
+// NewEngineWith builds an Engine from an existing client and mapper.
+func NewEngineWith(client dynamic.Interface, mapper meta.ResettableRESTMapper) *Engine {
+	return &Engine{client: client, mapper: mapper}
+}
+
+// target is one object resolved to its resource.
+type target struct {
+	obj        *unstructured.Unstructured
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+func (t target) ref() Ref {
+	return Ref{Kind: t.obj.GetKind(), Namespace: t.obj.GetNamespace(), Name: t.obj.GetName()}
+}
+
+func (e *Engine) resolve(obj *unstructured.Unstructured, defaultNS string) (target, error) {
+	gvk := obj.GroupVersionKind()
+	if obj.GetName() == "" {
+		return target{}, fmt.Errorf("%s without metadata.name", gvk.Kind)
+	}
+	m, err := e.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return target{}, fmt.Errorf("%s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+	t := target{obj: obj, gvr: m.Resource, namespaced: m.Scope.Name() == meta.RESTScopeNameNamespace}
+	switch {
+	case !t.namespaced:
+		obj.SetNamespace("")
+	case obj.GetNamespace() == "":
+		if defaultNS == "" {
+			defaultNS = metav1.NamespaceDefault
+		}
+		obj.SetNamespace(defaultNS)
+	}
+	return t, nil
+}
+
+func (e *Engine) resource(gvr schema.GroupVersionResource, namespaced bool, ns string) dynamic.ResourceInterface {
+	if namespaced {
+		return e.client.Resource(gvr).Namespace(ns)
+	}
+	return e.client.Resource(gvr)
+}
+
+// Apply server-side applies objs in dependency order (CRDs, namespaces,
+// config, services, then everything else). Objects are copied, not modified.
+func (e *Engine) Apply(ctx context.Context, objs []*unstructured.Unstructured, opts Options) (Result, error) {
+	var res Result
+	if opts.Prune && opts.Set == "" {
+		return res, fmt.Errorf("pruning needs a set name: only objects labelled with it are ever pruned")
+	}
+	applyOpts := k8s.ApplyOptions()
+	if opts.DryRun {
+		applyOpts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	var (
+		applied     []target
+		pendingCRDs []string
+	)
+	for _, obj := range Sort(objs) {
+		obj = obj.DeepCopy()
+		if len(pendingCRDs) > 0 && !isCRD(obj) {
+			if !opts.DryRun {
+				if err := e.waitEstablished(ctx, pendingCRDs); err != nil {
+					return res, err
+				}
+			}
+			e.mapper.Reset()
+			pendingCRDs = nil
+		}
+		t, err := e.resolve(obj, opts.Namespace)
+		if err != nil {
+			if opts.DryRun && meta.IsNoMatchError(err) {
+				// Its CRD is part of this batch and was only dry-run applied.
+				res.Applied = append(res.Applied, Ref{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()})
+				continue
+			}
+			return res, err
+		}
+		if opts.Set != "" {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[SetLabel] = opts.Set
+			obj.SetLabels(labels)
+		}
+		if _, err := e.resource(t.gvr, t.namespaced, obj.GetNamespace()).Apply(ctx, obj.GetName(), obj, applyOpts); err != nil {
+			return res, fmt.Errorf("applying %s: %w", t.ref(), err)
+		}
+		applied = append(applied, t)
+		res.Applied = append(res.Applied, t.ref())
+		if isCRD(obj) {
+			pendingCRDs = append(pendingCRDs, obj.GetName())
+		}
+	}
+
+	if opts.Prune {
+		pruned, err := e.prune(ctx, applied, opts)
+		res.Pruned = pruned
+		if err != nil {
+			return res, err
+		}
+	}
+	if opts.Wait && !opts.DryRun {
+		if err := e.waitReady(ctx, applied, opts.Timeout); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// prune deletes the set's objects, of the kinds and namespaces just applied,
+// that were not part of this apply.
+func (e *Engine) prune(ctx context.Context, applied []target, opts Options) ([]Ref, error) {
+	type scope struct {
+		gvr        schema.GroupVersionResource
+		namespaced bool
+		ns         string
+	}
+	keep := make(map[string]bool, len(applied))
+	var scopes []scope
+	seen := map[scope]bool{}
+	for _, t := range applied {
+		keep[t.gvr.String()+"|"+t.ref().String()] = true
+		s := scope{gvr: t.gvr, namespaced: t.namespaced, ns: t.obj.GetNamespace()}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+
+	var pruned []Ref
+	for _, s := range scopes {
+		list, err := e.resource(s.gvr, s.namespaced, s.ns).List(ctx, metav1.ListOptions{LabelSelector: SetLabel + "=" + opts.Set})
+		if err != nil {
+			return pruned, fmt.Errorf("listing %s for pruning: %w", s.gvr.Resource, err)
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			ref := Ref{Kind: item.GetKind(), Namespace: item.GetNamespace(), Name: item.GetName()}
+			if keep[s.gvr.String()+"|"+ref.String()] {
+				continue
+			}
+			if err := e.delete(ctx, s.gvr, s.namespaced, item, opts.DryRun); err != nil {
+				return pruned, fmt.Errorf("pruning %s: %w", ref, err)
+			}
+			pruned = append(pruned, ref)
+		}
+	}
+	return pruned, nil
+}
+
+// Delete deletes objs in reverse dependency order. Objects (or kinds) that
+// are already gone are skipped.
+func (e *Engine) Delete(ctx context.Context, objs []*unstructured.Unstructured, opts Options) (Result, error) {
+	var res Result
+	sorted := Sort(objs)
+	var deleted []target
+	for i := len(sorted) - 1; i >= 0; i-- {
+		obj := sorted[i].DeepCopy()
+		t, err := e.resolve(obj, opts.Namespace)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		err = e.delete(ctx, t.gvr, t.namespaced, obj, opts.DryRun)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return res, fmt.Errorf("deleting %s: %w", t.ref(), err)
+		}
+		deleted = append(deleted, t)
+		res.Deleted = append(res.Deleted, t.ref())
+	}
+	if opts.Wait && !opts.DryRun {
+		if err := e.waitGone(ctx, deleted, opts.Timeout); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func (e *Engine) delete(ctx context.Context, gvr schema.GroupVersionResource, namespaced bool, obj *unstructured.Unstructured, dryRun bool) error {
+	policy := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &policy}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return e.resource(gvr, namespaced, obj.GetNamespace()).Delete(ctx, obj.GetName(), opts)
+}
+
+// waitEstablished waits until the named CRDs are served, so custom resources
+// applied right after them resolve and validate.
+func (e *Engine) waitEstablished(ctx context.Context, names []string) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, crdEstablishTimeout, true, func(ctx context.Context) (bool, error) {
+		for _, name := range names {
+			crd, err := e.client.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			if ok, _ := Ready(crd); !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for CRDs %s to be established: %w", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+func (e *Engine) waitReady(ctx context.Context, targets []target, timeout time.Duration) error {
+	pending := map[string]string{}
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		clear(pending)
+		for _, t := range targets {
+			got, err := e.resource(t.gvr, t.namespaced, t.obj.GetNamespace()).Get(ctx, t.obj.GetName(), metav1.GetOptions{})
+			if err != nil {
+				pending[t.ref().String()] = err.Error()
+				continue
+			}
+			if ok, why := Ready(got); !ok {
+				pending[t.ref().String()] = why
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("not ready after %s: %s", timeout, describePending(pending))
+	}
+	return nil
+}
+
+func (e *Engine) waitGone(ctx context.Context, targets []target, timeout time.Duration) error {
+	pending := map[string]string{}
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		clear(pending)
+		for _, t := range targets {
+			_, err := e.resource(t.gvr, t.namespaced, t.obj.GetNamespace()).Get(ctx, t.obj.GetName(), metav1.GetOptions{})
+			if !apierrors.IsNotFound(err) {
+				pending[t.ref().String()] = "still present"
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("not deleted after %s: %s", timeout, describePending(pending))
+	}
+	return nil
+}
+
+func describePending(pending map[string]string) string {
+	parts := make([]string, 0, len(pending))
+	for ref, why := range pending {
+		parts = append(parts, ref+" ("+why+")")
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func isCRD(obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == "CustomResourceDefinition" && obj.GroupVersionKind().Group == crdGVR.Group
+}
+
+// Sort returns objs in apply order: CRDs, namespaces, configuration and RBAC,
+// services, then everything else. Objects of the same rank keep their order.
+func Sort(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	out := append([]*unstructured.Unstructured(nil), objs...)
+	sort.SliceStable(out, func(i, j int) bool { return rank(out[i]) < rank(out[j]) })
+	return out
+}
+
+func rank(obj *unstructured.Unstructured) int {
+	switch obj.GetKind() {
+	case "CustomResourceDefinition":
+		return 0
+	case "Namespace":
+		return 1
+	case "ServiceAccount", "Secret", "ConfigMap", "Role", "ClusterRole", "RoleBinding", "ClusterRoleBinding",
+		"StorageClass", "PersistentVolume", "PersistentVolumeClaim", "LimitRange", "ResourceQuota", "PriorityClass":
+		return 2
+	case "Service":
+		return 3
+	}
+	return 4
+}
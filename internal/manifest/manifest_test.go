@@ -0,0 +1,220 @@
+package manifest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const multiDoc = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+# only a comment
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: extras
+`
+
+func kinds(objs []*unstructured.Unstructured) []string {
+	out := make([]string, 0, len(objs))
+	for _, o := range objs {
+		out = append(out, o.GetKind()+"/"+o.GetName())
+	}
+	return out
+}
+
+func TestDecode_MultiDocumentAndList(t *testing.T) {
+	objs, err := Decode([]byte(multiDoc))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Deployment/web", "Namespace/extras"}, kinds(objs))
+
+	list := `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"}},{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"b"}}]}`
+	objs, err = Decode([]byte(list))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ConfigMap/a", "ConfigMap/b"}, kinds(objs))
+
+	_, err = Decode([]byte("metadata:\n  name: x\n"))
+	assert.ErrorContains(t, err, "apiVersion and kind are required")
+}
+
+func TestRead_DirectoryStdinAndURL(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "a.yml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0o600))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/svc.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: remote\n"))
+	}))
+	defer srv.Close()
+
+	stdin := strings.NewReader("apiVersion: v1\nkind: Secret\nmetadata:\n  name: piped\n")
+	objs, err := Read(context.Background(), []string{dir, Stdin, srv.URL + "/svc.yaml"}, stdin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ConfigMap/b", "ConfigMap/a", "Secret/piped", "Service/remote"}, kinds(objs))
+
+	_, err = Read(context.Background(), []string{srv.URL + "/missing.yaml"}, nil)
+	assert.ErrorContains(t, err, "HTTP 404")
+}
+
+func TestSort_DependenciesFirstStable(t *testing.T) {
+	objs, err := Decode([]byte(`apiVersion: example.com/v1
+kind: Widget
+metadata: {name: w}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: web}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: cfg}
+---
+apiVersion: v1
+kind: Namespace
+metadata: {name: extras}
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata: {name: widgets.example.com}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CustomResourceDefinition/widgets.example.com", "Namespace/extras", "ConfigMap/cfg",
+		"Service/web", "Widget/w", "Deployment/web",
+	}, kinds(Sort(objs)))
+}
+
+func TestReady(t *testing.T) {
+	cases := []struct {
+		name string
+		obj  map[string]any
+		want bool
+	}{
+		{"deployment available", map[string]any{"kind": "Deployment", "metadata": map[string]any{"generation": int64(2)},
+			"spec":   map[string]any{"replicas": int64(2)},
+			"status": map[string]any{"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)}}, true},
+		{"deployment rolling", map[string]any{"kind": "Deployment", "metadata": map[string]any{"generation": int64(2)},
+			"status": map[string]any{"observedGeneration": int64(1), "updatedReplicas": int64(1), "availableReplicas": int64(1)}}, false},
+		{"statefulset short", map[string]any{"kind": "StatefulSet", "spec": map[string]any{"replicas": int64(3)},
+			"status": map[string]any{"readyReplicas": int64(2)}}, false},
+		{"crd established", map[string]any{"kind": "CustomResourceDefinition",
+			"status": map[string]any{"conditions": []any{map[string]any{"type": "Established", "status": "True"}}}}, true},
+		{"job failed", map[string]any{"kind": "Job",
+			"status": map[string]any{"conditions": []any{map[string]any{"type": "Failed", "status": "True"}}}}, false},
+		{"pod succeeded", map[string]any{"kind": "Pod", "status": map[string]any{"phase": "Succeeded"}}, true},
+		{"configmap exists", map[string]any{"kind": "ConfigMap"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, why := Ready(&unstructured.Unstructured{Object: tc.obj})
+			assert.Equal(t, tc.want, ok, why)
+		})
+	}
+}
+
+// staticMapper is a fixed RESTMapper; Reset is a no-op.
+type staticMapper struct{ *meta.DefaultRESTMapper }
+
+func (staticMapper) Reset() {}
+
+func TestEngine_DeleteReverseOrderSkipsMissing(t *testing.T) {
+	cm := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	ns := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(cm, meta.RESTScopeNamespace)
+	mapper.Add(ns, meta.RESTScopeRoot)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(cm)
+	existing.SetNamespace("extras")
+	existing.SetName("present")
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), existing)
+
+	objs, err := Decode([]byte(`apiVersion: v1
+kind: Namespace
+metadata: {name: extras}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: present}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: gone}
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata: {name: unknown-kind}
+`))
+	require.NoError(t, err)
+
+	res, err := NewEngineWith(client, staticMapper{mapper}).Delete(context.Background(), objs, Options{Namespace: "extras"})
+	require.NoError(t, err)
+	assert.Equal(t, []Ref{{Kind: "ConfigMap", Namespace: "extras", Name: "present"}}, res.Deleted)
+
+	_, err = client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("extras").Get(context.Background(), "present", metav1.GetOptions{})
+	assert.Error(t, err, "the config map must be deleted")
+}
+
+func TestEngine_PruneNeedsSet(t *testing.T) {
+	_, err := NewEngineWith(nil, nil).Apply(context.Background(), nil, Options{Prune: true})
+	assert.ErrorContains(t, err, "set name")
+}
+
+func TestValidate_RemovedAPIsByClusterVersion(t *testing.T) {
+	obj := func(apiVersion, kind string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetName("x")
+		return u
+	}
+
+	// flowcontrol v1beta3 is removed in 1.32: fine for a 1.31 cluster, not for 1.32.
+	assert.NoError(t, Validate(obj("flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema"), 31))
+	assert.ErrorContains(t, Validate(obj("flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema"), 32), "removed in 1.32")
+
+	// policy/v1beta1 PodDisruptionBudget is removed in 1.25.
+	assert.NoError(t, Validate(obj("policy/v1beta1", "PodDisruptionBudget"), 24))
+	assert.ErrorContains(t, Validate(obj("policy/v1beta1", "PodDisruptionBudget"), 30), "removed in 1.25")
+}
+
+func TestKubernetesMinor(t *testing.T) {
+	for v, want := range map[string]int{"1.30": 30, "v1.31.5": 31, "v1.32.1+k3s1": 32, " 1.29 ": 29} {
+		got, err := KubernetesMinor(v)
+		require.NoError(t, err, v)
+		assert.Equal(t, want, got, v)
+	}
+	for _, v := range []string{"", "1", "2.1", "v1.x"} {
+		_, err := KubernetesMinor(v)
+		assert.Error(t, err, v)
+	}
+}
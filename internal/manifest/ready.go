@@ -0,0 +1,92 @@
+package manifest
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Ready reports whether a live object has converged, and if not, why. Kinds
+// with a well-known rollout status (workloads, pods, jobs, CRDs, namespaces)
+// are checked; everything else is ready as soon as it exists. Claims are not
+// waited on: local-path volumes bind only once a pod uses them.
+func Ready(obj *unstructured.Unstructured) (bool, string) {
+	switch obj.GetKind() {
+	case "CustomResourceDefinition":
+		if condition(obj, "Established") != "True" {
+			return false, "not established"
+		}
+	case "Namespace":
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase != "" && phase != "Active" {
+			return false, "namespace is " + phase
+		}
+	case "Deployment":
+		if !observed(obj) {
+			return false, "rollout not observed yet"
+		}
+		want := replicas(obj)
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		available, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+		if updated < want || available < want {
+			return false, fmt.Sprintf("%d/%d replicas available", available, want)
+		}
+	case "StatefulSet":
+		if !observed(obj) {
+			return false, "rollout not observed yet"
+		}
+		want := replicas(obj)
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if ready < want {
+			return false, fmt.Sprintf("%d/%d replicas ready", ready, want)
+		}
+	case "DaemonSet":
+		if !observed(obj) {
+			return false, "rollout not observed yet"
+		}
+		want, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		if ready < want {
+			return false, fmt.Sprintf("%d/%d pods ready", ready, want)
+		}
+	case "Job":
+		if condition(obj, "Failed") == "True" {
+			return false, "job failed"
+		}
+		if condition(obj, "Complete") != "True" {
+			return false, "job not complete"
+		}
+	case "Pod":
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase == "Succeeded" {
+			return true, ""
+		}
+		if condition(obj, "Ready") != "True" {
+			return false, "pod not ready"
+		}
+	}
+	return true, ""
+}
+
+func observed(obj *unstructured.Unstructured) bool {
+	gen, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return gen >= obj.GetGeneration()
+}
+
+func replicas(obj *unstructured.Unstructured) int64 {
+	n, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return n
+}
+
+func condition(obj *unstructured.Unstructured, condType string) string {
+	conds, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if ok && m["type"] == condType {
+			s, _ := m["status"].(string)
+			return s
+		}
+	}
+	return ""
+}
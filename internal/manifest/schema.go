@@ -0,0 +1,70 @@
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// strictDecoder decodes into the built-in Kubernetes types, rejecting unknown
+// and duplicate fields.
+var strictDecoder = serializer.NewCodecFactory(scheme.Scheme, serializer.EnableStrict).UniversalDeserializer()
+
+// removedAPI is implemented by the generated prerelease-lifecycle methods of
+// the k8s.io/api beta types.
+type removedAPI interface {
+	APILifecycleRemoved() (major, minor int)
+}
+
+// Validate checks obj offline against the built-in Kubernetes API types, the
+// way kubeconform checks against their schemas: unknown fields, wrong field
+// types, a missing name, and versions of built-in groups that no longer exist
+// (e.g. extensions/v1beta1 Ingress) are errors. Beta versions client-go still
+// carries are errors once removed in Kubernetes 1.kubeMinor, the cluster the
+// object is for. Kinds of other groups — custom resources — are only checked
+// for a name.
+func Validate(obj *unstructured.Unstructured, kubeMinor int) error {
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		return fmt.Errorf("metadata.name is required")
+	}
+	gvk := obj.GroupVersionKind()
+	if !scheme.Scheme.Recognizes(gvk) {
+		if scheme.Scheme.IsGroupRegistered(gvk.Group) {
+			return fmt.Errorf("%s %s is not a known Kubernetes API (removed or misspelled apiVersion/kind)", gvk.GroupVersion(), gvk.Kind)
+		}
+		return nil
+	}
+	if typed, err := scheme.Scheme.New(gvk); err == nil {
+		if r, ok := typed.(removedAPI); ok {
+			if major, minor := r.APILifecycleRemoved(); major == 1 && minor <= kubeMinor {
+				return fmt.Errorf("%s %s is not a known Kubernetes API (removed in 1.%d)", gvk.GroupVersion(), gvk.Kind, minor)
+			}
+		}
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if _, _, err := strictDecoder.Decode(data, nil, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// KubernetesMinor reads the minor of a Kubernetes version: 31 for "1.31",
+// "v1.31.5" or "v1.31.5+k3s1".
+func KubernetesMinor(version string) (int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, fmt.Errorf("invalid Kubernetes version %q: use 1.MINOR, e.g. 1.31", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return 0, fmt.Errorf("invalid Kubernetes version %q: use 1.MINOR, e.g. 1.31", version)
+	}
+	return minor, nil
+}
@@ -0,0 +1,23 @@
+package platform
+
+import sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+
+// NativeWindowsEnv selects Docker Desktop native mode on Windows: the CLI runs
+// as a Windows process and drives docker.exe/k3d.exe/helm.exe directly instead
+// of re-running itself inside WSL. The WSL launcher sets it when it detects
+// Docker Desktop; users can force it on (=1) or off (=0).
+const NativeWindowsEnv = "OPENFRAME_WINDOWS_NATIVE"
+
+// NativeWindows reports whether this is a Windows process running in Docker
+// Desktop native mode. The cluster's API port is published on the Windows
+// loopback by Docker Desktop, so the native Kubernetes client reaches it.
+func NativeWindows() bool {
+	return IsWindows() && sharedconfig.EnvBool(NativeWindowsEnv)
+}
+
+// UsesWSL reports whether cluster tooling lives inside WSL: a Windows process
+// that is not in native mode. Code that converts paths or shells into WSL must
+// check this rather than the bare OS.
+func UsesWSL() bool {
+	return IsWindows() && !NativeWindows()
+}
@@ -0,0 +1,19 @@
+package platform
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNativeWindows_OffWindowsIsFalse(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("host is Windows")
+	}
+	t.Setenv(NativeWindowsEnv, "1")
+	if NativeWindows() || UsesWSL() {
+		t.Fatal("native mode and WSL are Windows-only")
+	}
+	if err := WSLClusterHint("anything"); err != nil {
+		t.Fatalf("no WSL hint off Windows, got %v", err)
+	}
+}
@@ -11,13 +11,14 @@ var ErrWindowsNeedsWSL = fmt.Errorf("cluster operations require running OpenFram
 
 // WSLClusterHint returns a user-facing error explaining how to perform the given
 // operation on Windows, where cluster access must happen inside WSL. Returns nil
-// on non-Windows hosts, so callers can use it as a guard:
+// on non-Windows hosts and in Docker Desktop native mode (see NativeWindows),
+// so callers can use it as a guard:
 //
 //	if err := platform.WSLClusterHint("wait for ArgoCD"); err != nil {
 //	    return err
 //	}
 func WSLClusterHint(operation string) error {
-	if !IsWindows() {
+	if !UsesWSL() {
 		return nil
 	}
 	return windowsWSLError(operation)
@@ -0,0 +1,204 @@
+// Package plugin runs external commands as openframe subcommands, the way
+// kubectl runs its plugins: `openframe foo bar` with no built-in command foo
+// runs the first openframe-foo-bar, then openframe-foo, found on PATH, with
+// the remaining arguments. ~/.openframe/bin is on that PATH too.
+//
+// A plugin runs with openframe's environment plus the contract in Env, so it
+// can find the cluster the user is working with and the CLI's own state
+// without parsing openframe's output.
+package plugin
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+)
+
+// Prefix starts the name of every plugin executable.
+const Prefix = "openframe-"
+
+// Environment variables of the plugin contract; see Env.
+const (
+	EnvBin      = "OPENFRAME_BIN"
+	EnvVersion  = "OPENFRAME_VERSION"
+	EnvHome     = "OPENFRAME_HOME"
+	EnvStateDir = "OPENFRAME_STATE_DIR"
+	EnvCluster  = "OPENFRAME_CLUSTER"
+	EnvContext  = "OPENFRAME_CONTEXT"
+	EnvPlugin   = "OPENFRAME_PLUGIN"
+)
+
+// lookPath finds an executable on PATH; a variable so tests can fake it.
+var lookPath = exec.LookPath
+
+// Plugin is a plugin executable found on PATH.
+type Plugin struct {
+	// Name is what follows `openframe`, dashes for spaces: openframe-db-dump
+	// is "db-dump", run as `openframe db dump` or `openframe db-dump`.
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Shadowed lists executables of the same name later on PATH, which never
+	// run.
+	Shadowed []string `json:"shadowed,omitempty"`
+}
+
+// Find resolves args, the command line after `openframe`, to a plugin: the
+// longest run of leading words w1..wn for which openframe-w1-...-wn is on
+// PATH. rest is what the plugin is given. Words stop at the first flag.
+func Find(args []string) (path string, rest []string, ok bool) {
+	var words []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") || a == "" || strings.ContainsAny(a, `/\`) {
+			break
+		}
+		words = append(words, a)
+	}
+	for n := len(words); n > 0; n-- {
+		if p, err := lookPath(Prefix + strings.Join(words[:n], "-")); err == nil {
+			return p, args[n:], true
+		}
+	}
+	return "", nil, false
+}
+
+// List returns the plugins on PATH, sorted by name. The first executable of
+// a name on PATH is the one that runs.
+func List() []Plugin {
+	var out []Plugin
+	index := map[string]int{}
+	seenDir := map[string]bool{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || seenDir[dir] {
+			continue
+		}
+		seenDir[dir] = true
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || e.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			if i, ok := index[name]; ok {
+				out[i].Shadowed = append(out[i].Shadowed, path)
+				continue
+			}
+			index[name] = len(out)
+			out = append(out, Plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// NameOf is the plugin name of the executable at path.
+func NameOf(path string) string {
+	name, _ := pluginName(filepath.Base(path))
+	return name
+}
+
+// pluginName is the plugin name of an executable file name.
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		file = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	return name, name != file && name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0o111 != 0
+}
+
+// Env is the environment contract of a plugin, set on top of openframe's
+// own:
+//
+//	OPENFRAME_BIN        the openframe executable, to call back into the CLI
+//	OPENFRAME_VERSION    its version
+//	OPENFRAME_HOME       ~/.openframe
+//	OPENFRAME_STATE_DIR  ~/.openframe/state, where openframe keeps its state
+//	OPENFRAME_CLUSTER    the current cluster, by openframe's name for it
+//	OPENFRAME_CONTEXT    its kube-context
+//	KUBECONFIG           the kubeconfig that defines that context
+//	OPENFRAME_PLUGIN     the plugin's name
+//
+// The cluster variables are empty when no kube-context is current; an
+// explicit KUBECONFIG is passed on unchanged.
+func Env(name, version string) map[string]string {
+	env := map[string]string{
+		EnvVersion: version,
+		EnvPlugin:  name,
+	}
+	if self, err := os.Executable(); err == nil {
+		env[EnvBin] = self
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		env[EnvHome] = filepath.Join(home, ".openframe")
+		env[EnvStateDir] = filepath.Join(home, ".openframe", "state")
+	}
+	_, current, err := k8s.LoadAllContexts()
+	if err == nil && current != "" {
+		env[EnvContext] = current
+		env[EnvCluster] = clusterOf(current)
+		env["KUBECONFIG"] = k8s.KubeconfigForContext(current)
+	}
+	return env
+}
+
+// clusterOf names the cluster behind a kube-context the way openframe
+// commands take it: an attached cluster's name, a k3d cluster's without the
+// k3d- prefix, otherwise the context itself.
+func clusterOf(contextName string) string {
+	if clusters, err := k8s.LoadExternalClusters(); err == nil {
+		for _, c := range clusters {
+			if c.Context == contextName {
+				return c.Name
+			}
+		}
+	}
+	return strings.TrimPrefix(contextName, "k3d-")
+}
+
+// Run runs the plugin at path with args, the terminal and env, and returns
+// its exit code. The plugin gets Ctrl+C from the terminal itself; the caller
+// catches it, so openframe outlives the plugin and reports its exit code.
+func Run(path string, args []string, env map[string]string) (int, error) {
+	cmd := exec.Command(path, args...) // #nosec G204 -- the plugin the user ran
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code > 0 {
+			return code, nil
+		}
+		return 1, nil // killed by a signal
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind_LongestMatchFirst(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	onPath := map[string]bool{"openframe-db": true, "openframe-db-dump": true}
+	lookPath = func(file string) (string, error) {
+		if onPath[file] {
+			return "/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	path, rest, ok := Find([]string{"db", "dump", "mongo", "--gzip"})
+	require.True(t, ok)
+	assert.Equal(t, "/bin/openframe-db-dump", path)
+	assert.Equal(t, []string{"mongo", "--gzip"}, rest)
+
+	path, rest, ok = Find([]string{"db", "--dump", "x"})
+	require.True(t, ok, "words stop at the first flag")
+	assert.Equal(t, "/bin/openframe-db", path)
+	assert.Equal(t, []string{"--dump", "x"}, rest)
+
+	_, _, ok = Find([]string{"../db"})
+	assert.False(t, ok)
+	_, _, ok = Find([]string{"nothing"})
+	assert.False(t, ok)
+}
+
+func writeExecutable(t *testing.T, path string, mode os.FileMode) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), mode))
+}
+
+func TestList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by extension on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	writeExecutable(t, filepath.Join(first, "openframe-db-dump"), 0o755)
+	writeExecutable(t, filepath.Join(first, "openframe-notes"), 0o644)
+	writeExecutable(t, filepath.Join(first, "kubectl-foo"), 0o755)
+	writeExecutable(t, filepath.Join(second, "openframe-db-dump"), 0o755)
+	writeExecutable(t, filepath.Join(second, "openframe-lint"), 0o755)
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	assert.Equal(t, []Plugin{
+		{Name: "db-dump", Path: filepath.Join(first, "openframe-db-dump"), Shadowed: []string{filepath.Join(second, "openframe-db-dump")}},
+		{Name: "lint", Path: filepath.Join(second, "openframe-lint")},
+	}, List())
+}
+
+func TestEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	kubeconfig := filepath.Join(home, "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters: [{name: k3d-dev, cluster: {server: "https://127.0.0.1:6443"}}]
+users: [{name: admin, user: {}}]
+contexts: [{name: k3d-dev, context: {cluster: k3d-dev, user: admin}}]
+current-context: k3d-dev
+`), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	env := Env("db-dump", "1.2.3")
+	assert.Equal(t, "1.2.3", env[EnvVersion])
+	assert.Equal(t, "db-dump", env[EnvPlugin])
+	assert.Equal(t, filepath.Join(home, ".openframe", "state"), env[EnvStateDir])
+	assert.Equal(t, "dev", env[EnvCluster])
+	assert.Equal(t, "k3d-dev", env[EnvContext])
+	assert.Equal(t, kubeconfig, env["KUBECONFIG"])
+	assert.NotEmpty(t, env[EnvBin])
+}
+
+func TestRun_ReturnsTheExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+	script := filepath.Join(t.TempDir(), "openframe-fail")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n[ \"$OPENFRAME_PLUGIN\" = fail ] && [ \"$1\" = now ] && exit 3\nexit 0\n"), 0o755))
+
+	code, err := Run(script, []string{"now"}, map[string]string{EnvPlugin: NameOf(script)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+	code, err = Run(script, []string{"later"}, map[string]string{EnvPlugin: "fail"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+}
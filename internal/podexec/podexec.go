@@ -0,0 +1,152 @@
+// Package podexec runs commands in pod containers through the API server's
+// exec subresource — what `kubectl exec` does, without kubectl.
+package podexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// Shell starts bash when the image has it, sh otherwise.
+var Shell = []string{"/bin/sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+
+// Options describes one exec.
+type Options struct {
+	Namespace string
+	Pod       string
+	Container string // "" for the pod's first container
+	Command   []string
+
+	Stdin          io.Reader // nil for none
+	Stdout, Stderr io.Writer
+	// TTYFd is the local terminal's descriptor when the command should get a
+	// TTY, or -1. The terminal is put in raw mode while the command runs.
+	TTYFd int
+}
+
+// Primary picks the pod to exec into: the first running pod whose containers
+// are all ready, else the first running one.
+func Primary(pods []corev1.Pod) (*corev1.Pod, error) {
+	var running *corev1.Pod
+	for i := range pods {
+		p := &pods[i]
+		if p.Status.Phase != corev1.PodRunning || p.DeletionTimestamp != nil {
+			continue
+		}
+		if ready(p) {
+			return p, nil
+		}
+		if running == nil {
+			running = p
+		}
+	}
+	if running == nil {
+		return nil, fmt.Errorf("no running pods")
+	}
+	return running, nil
+}
+
+func ready(p *corev1.Pod) bool {
+	for _, c := range p.Status.ContainerStatuses {
+		if !c.Ready {
+			return false
+		}
+	}
+	return len(p.Status.ContainerStatuses) > 0
+}
+
+// Run runs opts.Command in the container and waits for it. A command that
+// exits non-zero is reported as an *executor.CommandError carrying its code.
+func Run(ctx context.Context, cfg *rest.Config, cs kubernetes.Interface, opts Options) error {
+	tty := opts.TTYFd >= 0
+	req := cs.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(opts.Namespace).Name(opts.Pod).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !tty, // a TTY merges stderr into stdout
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	// WebSockets first, SPDY for API servers that predate them — kubectl's order.
+	spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("preparing exec: %w", err)
+	}
+	ws, err := remotecommand.NewWebSocketExecutor(cfg, "GET", req.URL().String())
+	if err != nil {
+		return fmt.Errorf("preparing exec: %w", err)
+	}
+	exec, err := remotecommand.NewFallbackExecutor(ws, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("preparing exec: %w", err)
+	}
+
+	stream := remotecommand.StreamOptions{Stdin: opts.Stdin, Stdout: opts.Stdout, Tty: tty}
+	if !tty {
+		stream.Stderr = opts.Stderr
+	} else {
+		state, err := term.MakeRaw(opts.TTYFd)
+		if err != nil {
+			return fmt.Errorf("preparing the terminal: %w", err)
+		}
+		defer func() { _ = term.Restore(opts.TTYFd, state) }()
+		sizeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream.TerminalSizeQueue = &sizeQueue{ctx: sizeCtx, fd: opts.TTYFd}
+	}
+
+	if err := exec.StreamWithContext(ctx, stream); err != nil {
+		var exit utilexec.ExitError
+		if errors.As(err, &exit) && exit.Exited() {
+			return &executor.CommandError{Command: strings.Join(opts.Command, " "), ExitCode: exit.ExitStatus()}
+		}
+		return fmt.Errorf("exec in %s/%s: %w", opts.Namespace, opts.Pod, err)
+	}
+	return nil
+}
+
+// sizeQueue reports the local terminal size to the remote TTY: at once, then
+// on every change. It polls rather than waiting for SIGWINCH so it behaves the
+// same on every OS.
+type sizeQueue struct {
+	ctx  context.Context
+	fd   int
+	last remotecommand.TerminalSize
+}
+
+// Next implements remotecommand.TerminalSizeQueue; nil ends the queue.
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	for {
+		if w, h, err := term.GetSize(q.fd); err == nil {
+			size := remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)} //nolint:gosec // G115: terminal dimensions fit in uint16
+			if size != q.last {
+				q.last = size
+				return &size
+			}
+		}
+		select {
+		case <-q.ctx.Done():
+			return nil
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package podexec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func pod(name string, phase corev1.PodPhase, ready ...bool) corev1.Pod {
+	p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	for _, r := range ready {
+		p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, corev1.ContainerStatus{Ready: r})
+	}
+	return p
+}
+
+func TestPrimary_PrefersReadyRunningPods(t *testing.T) {
+	got, err := Primary([]corev1.Pod{
+		pod("api-a", corev1.PodPending),
+		pod("api-b", corev1.PodRunning, true, false),
+		pod("api-c", corev1.PodRunning, true, true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "api-c", got.Name)
+
+	got, err = Primary([]corev1.Pod{pod("api-a", corev1.PodPending), pod("api-b", corev1.PodRunning, false)})
+	require.NoError(t, err)
+	assert.Equal(t, "api-b", got.Name, "a running pod beats none")
+
+	_, err = Primary([]corev1.Pod{pod("api-a", corev1.PodSucceeded)})
+	assert.Error(t, err)
+}
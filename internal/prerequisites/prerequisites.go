@@ -1,5 +1,5 @@
 // Package prerequisites is the unified, OS-aware framework for the tools the CLI
-// needs (Docker, kubectl, k3d, helm, …).
+// needs (Docker, k3d, helm, …).
 //
 // It is one of the CLI's three abstractions (cluster, app, prerequisites). A
 // Prerequisite knows how to check itself, optionally install itself, and where
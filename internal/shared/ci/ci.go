@@ -0,0 +1,197 @@
+// Package ci implements --ci: one switch for running the CLI in a pipeline.
+// Output becomes plain, timestamped log lines; prompts and spinners are off;
+// install phases are folded into GitHub Actions log groups; and a failure is
+// raised as an error annotation with a diagnostics bundle exported for upload.
+//
+// Like the other global switches it is process-wide: the root command binds
+// the flag and calls Apply, the install flow calls Group through
+// telemetry.EnterPhase, and the root command calls Fail or Finish at the end.
+// Everything is a no-op unless --ci was given.
+package ci
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/spf13/pflag"
+)
+
+// enabled backs the global --ci flag (see BindFlags).
+var enabled bool
+
+var (
+	mu        sync.Mutex
+	log       *stampWriter
+	openGroup bool
+	applied   bool
+)
+
+// BindFlags registers --ci on fs.
+func BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&enabled, "ci", false, "Machine mode for CI: plain timestamped logs, no prompts or spinners, GitHub Actions groups and annotations, phase exit codes")
+}
+
+// Enabled reports whether --ci was given.
+func Enabled() bool { return enabled }
+
+// Apply switches the UI to plain, timestamped output on stdout. It runs once,
+// from every PersistentPreRunE, after --silent has been applied.
+func Apply() {
+	if !enabled {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if applied {
+		return
+	}
+	applied = true
+	log = newStampWriter(os.Stdout, time.Now)
+	ui.SetPlain(log)
+}
+
+// Group starts a collapsible log group named name, closing the previous one.
+func Group(name string) {
+	if !enabled {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	endGroupLocked()
+	command("group", "", name)
+	openGroup = true
+}
+
+// Finish closes the open log group, if any.
+func Finish() {
+	if !enabled {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	endGroupLocked()
+}
+
+// Fail closes the open log group and raises err as an error annotation titled
+// with the phase it happened in, when known.
+func Fail(err error, phase string) {
+	if !enabled || err == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	endGroupLocked()
+	title := "openframe failed"
+	if phase != "" {
+		title = fmt.Sprintf("openframe failed during %s", phase)
+	}
+	command("error", "title="+escapeProperty(title), err.Error())
+}
+
+// Notice raises msg as a notice annotation.
+func Notice(title, msg string) {
+	if !enabled {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	command("notice", "title="+escapeProperty(title), msg)
+}
+
+// SetOutput publishes name=value as a step output ($GITHUB_OUTPUT), so later
+// workflow steps can use it. Outside GitHub Actions it does nothing.
+func SetOutput(name, value string) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if !enabled || path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0) //nolint:gosec // G304: path given by the Actions runner
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s=%s\n", name, value); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func endGroupLocked() {
+	if openGroup {
+		command("endgroup", "", "")
+		openGroup = false
+	}
+}
+
+// command writes a workflow command ("::name props::message"). Commands must
+// start their line, so they bypass the timestamp prefix.
+func command(name, props, msg string) {
+	line := "::" + name
+	if props != "" {
+		line += " " + props
+	}
+	line += "::" + escapeData(msg) + "\n"
+	if log != nil {
+		log.writeRaw(line)
+		return
+	}
+	_, _ = io.WriteString(os.Stdout, line)
+}
+
+// escapeData escapes a workflow command message.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property value.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// stampWriter prefixes every line written through it with a UTC timestamp.
+// Lines go out as soon as they are complete, so a log tail shows progress
+// while a long step runs; a partial line waits for its newline.
+type stampWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	now     func() time.Time
+	pending []byte
+}
+
+func newStampWriter(w io.Writer, now func() time.Time) *stampWriter {
+	return &stampWriter{w: w, now: now}
+}
+
+func (s *stampWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, p...)
+	for {
+		i := bytes.IndexByte(s.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := s.pending[:i+1]
+		if _, err := fmt.Fprintf(s.w, "%s %s", s.now().UTC().Format(time.RFC3339), line); err != nil {
+			return 0, err
+		}
+		s.pending = s.pending[i+1:]
+	}
+}
+
+// writeRaw writes line unprefixed, after any partial line pending.
+func (s *stampWriter) writeRaw(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) > 0 {
+		_, _ = fmt.Fprintf(s.w, "%s %s\n", s.now().UTC().Format(time.RFC3339), s.pending)
+		s.pending = nil
+	}
+	_, _ = io.WriteString(s.w, line)
+}
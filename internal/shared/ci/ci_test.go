@@ -0,0 +1,81 @@
+package ci
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var clock = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+// capture enables --ci with the log going to a buffer, restoring state after.
+func capture(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	enabled, log, openGroup = true, newStampWriter(&buf, clock), false
+	t.Cleanup(func() { enabled, log, openGroup = false, nil, false })
+	return &buf
+}
+
+func TestStampWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newStampWriter(&buf, clock)
+
+	_, err := w.Write([]byte("INFO: one\nINFO: tw"))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01T12:00:00Z INFO: one\n", buf.String(), "a partial line waits for its newline")
+
+	_, _ = w.Write([]byte("o\n"))
+	assert.Equal(t, "2026-03-01T12:00:00Z INFO: one\n2026-03-01T12:00:00Z INFO: two\n", buf.String())
+}
+
+func TestGroupsAndAnnotations(t *testing.T) {
+	buf := capture(t)
+
+	Group("preflight")
+	_, _ = log.Write([]byte("checking"))
+	Group("cluster-create")
+	Fail(errors.New("k3d failed\nport 80 in use"), "cluster-create")
+	Finish()
+
+	assert.Equal(t, "::group::preflight\n"+
+		"2026-03-01T12:00:00Z checking\n"+
+		"::endgroup::\n"+
+		"::group::cluster-create\n"+
+		"::endgroup::\n"+
+		"::error title=openframe failed during cluster-create::k3d failed%0Aport 80 in use\n", buf.String())
+}
+
+func TestDisabledIsSilent(t *testing.T) {
+	buf := capture(t)
+	enabled = false
+
+	Group("preflight")
+	Notice("x", "y")
+	Fail(errors.New("boom"), "")
+	require.NoError(t, SetOutput("a", "b"))
+	assert.Empty(t, buf.String())
+}
+
+func TestEscapeProperty(t *testing.T) {
+	assert.Equal(t, "a%3A b%2C 100%25", escapeProperty("a: b, 100%"))
+	assert.Equal(t, "a: b, 100%25", escapeData("a: b, 100%"))
+}
+
+func TestSetOutput(t *testing.T) {
+	capture(t)
+	path := filepath.Join(t.TempDir(), "output")
+	require.NoError(t, os.WriteFile(path, []byte("earlier=1\n"), 0o600))
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	require.NoError(t, SetOutput("diagnostics-bundle", "/work/bundle.tar.gz"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "earlier=1\ndiagnostics-bundle=/work/bundle.tar.gz\n", string(data))
+}
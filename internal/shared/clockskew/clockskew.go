@@ -0,0 +1,235 @@
+// Package clockskew finds a WSL2 clock that has drifted from real time. The
+// WSL2 VM's clock stops while a laptop sleeps and is not always resynced on
+// resume, so it can trail the host by minutes or hours. The cluster's nodes
+// share that clock, and everything that checks a certificate's or a token's
+// validity window against it — TLS handshakes, ACME validation, image
+// registry logins — starts failing with errors that never mention time.
+//
+// The WSL clock is compared with an NTP server and, when that cannot be
+// reached, with the Windows clock, which Windows keeps in sync itself.
+// `hwclock -s` resets the WSL clock from the VM's hardware clock, which tracks
+// the host's.
+package clockskew
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/privilege"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
+)
+
+// Tolerance is the largest offset left alone. Registries and ACME servers
+// reject tokens a few seconds from their validity window; anything under
+// this is ordinary jitter.
+const Tolerance = 10 * time.Second
+
+const (
+	// queryTimeout bounds one reading of one clock; the first `wsl` call may
+	// have to boot the VM.
+	queryTimeout = 15 * time.Second
+
+	// ntpTimeout is shorter: networks that block NTP drop the packet rather
+	// than refuse it, and the Windows clock is a good fallback.
+	ntpTimeout = 3 * time.Second
+)
+
+// NTPServer is the time server the WSL clock is checked against; a variable so
+// tests can point it at a local one.
+var NTPServer = "pool.ntp.org"
+
+// Clock reads a clock's offset from this process's clock.
+type Clock struct {
+	Name   string
+	Offset func(ctx context.Context) (time.Duration, error)
+}
+
+// Skew is how far the checked clock is ahead of (positive) or behind
+// (negative) the reference.
+type Skew struct {
+	Clock     string
+	Reference string
+	Offset    time.Duration
+}
+
+// Drifted reports whether the offset is beyond Tolerance.
+func (s Skew) Drifted() bool { return s.Offset > Tolerance || s.Offset < -Tolerance }
+
+func (s Skew) String() string {
+	dir := "ahead of"
+	if s.Offset < 0 {
+		dir = "behind"
+	}
+	abs := s.Offset.Abs().Round(time.Second)
+	return fmt.Sprintf("%s clock is %s %s %s", s.Clock, abs, dir, s.Reference)
+}
+
+// Compare reads clock and then the references in turn, measuring against the
+// first that answers.
+func Compare(ctx context.Context, clock Clock, references ...Clock) (Skew, error) {
+	own, err := read(ctx, clock)
+	if err != nil {
+		return Skew{}, fmt.Errorf("reading the %s clock: %w", clock.Name, err)
+	}
+	var errs []error
+	for _, ref := range references {
+		offset, err := read(ctx, ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ref.Name, err))
+			continue
+		}
+		return Skew{Clock: clock.Name, Reference: ref.Name, Offset: own - offset}, nil
+	}
+	return Skew{}, fmt.Errorf("no reference clock answered: %w", errors.Join(errs...))
+}
+
+func read(ctx context.Context, c Clock) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	return c.Offset(ctx)
+}
+
+// Local is this process's clock.
+var Local = Clock{Name: "local", Offset: func(context.Context) (time.Duration, error) { return 0, nil }}
+
+// ntpEpoch is the NTP era's start, 1900-01-01, in Unix seconds.
+const ntpEpoch = -2208988800
+
+// NTP is the clock of an SNTP server; server may carry a port.
+func NTP(server string) Clock {
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, "123")
+	}
+	return Clock{Name: "NTP (" + server + ")", Offset: func(ctx context.Context) (time.Duration, error) {
+		ctx, cancel := context.WithTimeout(ctx, ntpTimeout)
+		defer cancel()
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "udp", addr)
+		if err != nil {
+			return 0, err
+		}
+		defer conn.Close()
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		req := make([]byte, 48)
+		req[0] = 0x23 // leap 0, version 4, mode 3 (client)
+		sent := time.Now()
+		if _, err := conn.Write(req); err != nil {
+			return 0, err
+		}
+		resp := make([]byte, 48)
+		if n, err := conn.Read(resp); err != nil {
+			return 0, err
+		} else if n < 48 || resp[0]&0x7 != 4 || resp[1] == 0 {
+			return 0, fmt.Errorf("not a usable NTP reply")
+		}
+		received := time.Now()
+		secs := int64(binary.BigEndian.Uint32(resp[40:44]))
+		frac := int64(binary.BigEndian.Uint32(resp[44:48]))
+		transmitted := time.Unix(secs+ntpEpoch, frac*int64(time.Second)>>32)
+		// The reply left the server about half a round trip ago.
+		return transmitted.Add(received.Sub(sent) / 2).Sub(received), nil
+	}}
+}
+
+// Command is the clock a command prints as Unix nanoseconds (`date +%s%N`),
+// read at the midpoint of its run.
+func Command(name string, ex executor.CommandExecutor, opts executor.ExecuteOptions) Clock {
+	return Clock{Name: name, Offset: func(ctx context.Context) (time.Duration, error) {
+		start := time.Now()
+		result, err := ex.ExecuteWithOptions(ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		end := time.Now()
+		ns, err := strconv.ParseInt(strings.TrimSpace(result.Stdout), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected clock reading %q", strings.TrimSpace(result.Stdout))
+		}
+		mid := start.Add(end.Sub(start) / 2)
+		return time.Unix(0, ns).Sub(mid), nil
+	}}
+}
+
+// WSL returns the WSL clock and the clocks to check it against: on Windows
+// the distro's clock against NTP and then the Windows clock; inside WSL this
+// clock against NTP and then the Windows clock through interop. ok is false
+// elsewhere, where there is no WSL clock to drift.
+func WSL(ex executor.CommandExecutor) (clock Clock, references []Clock, ok bool) {
+	ntp := NTP(NTPServer)
+	switch {
+	case platform.UsesWSL():
+		args := append(wslpath.DistroArgs(), "--", "date", "+%s%N")
+		wsl := Command("WSL", ex, executor.ExecuteOptions{Command: "wsl", Args: args})
+		windows := Local
+		windows.Name = "Windows"
+		return wsl, []Clock{ntp, windows}, true
+	case sysctl.InsideWSL():
+		wsl := Local
+		wsl.Name = "WSL"
+		windows := Command("Windows", ex, executor.ExecuteOptions{
+			Command: "powershell.exe",
+			Args:    []string{"-NoProfile", "-NonInteractive", "-Command", "[DateTimeOffset]::UtcNow.ToUnixTimeMilliseconds() * 1000000"},
+		})
+		return wsl, []Clock{ntp, windows}, true
+	}
+	return Clock{}, nil, false
+}
+
+// Check measures the WSL clock. ok is false when there is no WSL clock or it
+// could not be compared with anything; the reason is then in err, if any.
+func Check(ctx context.Context, ex executor.CommandExecutor) (skew Skew, ok bool, err error) {
+	clock, refs, wsl := WSL(ex)
+	if !wsl {
+		return Skew{}, false, nil
+	}
+	skew, err = Compare(ctx, clock, refs...)
+	if err != nil {
+		return Skew{}, false, err
+	}
+	return skew, true, nil
+}
+
+// FixCommand returns the command that resets the WSL clock from the hardware
+// clock, run as root: through wsl.exe from Windows, through sudo inside WSL.
+func FixCommand(ctx context.Context) ([]string, error) {
+	if platform.UsesWSL() {
+		return wslRoot(), nil
+	}
+	return privilege.Default().Command(ctx, "hwclock", "-s")
+}
+
+// FixHint is the command a user runs by hand to reset the WSL clock.
+func FixHint() string {
+	if platform.UsesWSL() {
+		return strings.Join(wslRoot(), " ")
+	}
+	return "sudo hwclock -s"
+}
+
+func wslRoot() []string {
+	return append(append([]string{"wsl"}, wslpath.DistroArgs()...), "-u", "root", "--", "hwclock", "-s")
+}
+
+// Fix resets the WSL clock with FixCommand.
+func Fix(ctx context.Context, ex executor.CommandExecutor) error {
+	argv, err := FixCommand(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := ex.Execute(ctx, argv[0], argv[1:]...); err != nil {
+		return fmt.Errorf("hwclock -s: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package clockskew
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNTP answers SNTP requests on a local port with the time offset from
+// this clock, and returns its address.
+func fakeNTP(t *testing.T, offset time.Duration) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	go func() {
+		buf := make([]byte, 48)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			now := time.Now().Add(offset)
+			resp := make([]byte, 48)
+			resp[0] = 0x24 // version 4, mode 4 (server)
+			resp[1] = 2    // stratum
+			binary.BigEndian.PutUint32(resp[40:], uint32(now.Unix()-ntpEpoch))
+			binary.BigEndian.PutUint32(resp[44:], uint32((int64(now.Nanosecond())<<32)/int64(time.Second)))
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func fixed(name string, offset time.Duration) Clock {
+	return Clock{Name: name, Offset: func(context.Context) (time.Duration, error) { return offset, nil }}
+}
+
+func failing(name string) Clock {
+	return Clock{Name: name, Offset: func(context.Context) (time.Duration, error) { return 0, errors.New("unreachable") }}
+}
+
+func TestNTP(t *testing.T) {
+	offset, err := NTP(fakeNTP(t, -90*time.Second)).Offset(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, float64(-90*time.Second), float64(offset), float64(time.Second))
+}
+
+func TestCompare(t *testing.T) {
+	ctx := context.Background()
+
+	skew, err := Compare(ctx, fixed("WSL", -5*time.Minute), failing("NTP"), fixed("Windows", 0))
+	require.NoError(t, err)
+	assert.Equal(t, "Windows", skew.Reference, "the first reference that answers is used")
+	assert.True(t, skew.Drifted())
+	assert.Equal(t, "WSL clock is 5m0s behind Windows", skew.String())
+
+	skew, err = Compare(ctx, fixed("WSL", 3*time.Second), fixed("NTP", -2*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, skew.Offset)
+	assert.False(t, skew.Drifted(), "offsets within Tolerance are left alone")
+
+	_, err = Compare(ctx, fixed("WSL", 0), failing("NTP"))
+	assert.ErrorContains(t, err, "no reference clock answered")
+
+	_, err = Compare(ctx, failing("WSL"), fixed("NTP", 0))
+	assert.ErrorContains(t, err, "reading the WSL clock")
+}
+
+func TestCommand(t *testing.T) {
+	ex := executor.NewMockCommandExecutor()
+	ahead := time.Now().Add(time.Hour).UnixNano()
+	ex.SetResponse("date", &executor.CommandResult{Stdout: strconv.FormatInt(ahead, 10) + "\n"})
+
+	offset, err := Command("WSL", ex, executor.ExecuteOptions{Command: "wsl", Args: []string{"--", "date", "+%s%N"}}).Offset(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, float64(time.Hour), float64(offset), float64(time.Second))
+
+	ex.SetResponse("date", &executor.CommandResult{Stdout: "Thu Oct 15 12:00:00 UTC 2026\n"})
+	_, err = Command("WSL", ex, executor.ExecuteOptions{Command: "wsl", Args: []string{"--", "date", "+%s%N"}}).Offset(context.Background())
+	assert.ErrorContains(t, err, "unexpected clock reading")
+}
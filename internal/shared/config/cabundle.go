@@ -0,0 +1,90 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CABundleEnv names a PEM bundle of extra certificate authorities like
+// --ca-bundle does, for automation that cannot pass flags.
+const CABundleEnv = "OPENFRAME_CA_BUNDLE"
+
+// caBundleFlag backs the global --ca-bundle flag (see BindTLSFlags).
+var caBundleFlag string
+
+var (
+	caBundleOnce sync.Once
+	caBundleErr  error
+)
+
+// CABundle returns the absolute path of the extra CA bundle, or "" when none
+// is configured.
+func CABundle() string {
+	path := strings.TrimSpace(caBundleFlag)
+	if path == "" {
+		path = strings.TrimSpace(os.Getenv(CABundleEnv))
+	}
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// ApplyCABundle makes the CLI's own HTTPS clients — chart and manifest
+// downloads, git clones, release checks — trust the CA bundle on top of the
+// system roots, which is what a TLS-intercepting proxy needs. It changes
+// http.DefaultTransport in place, so it must run before the first request;
+// the command groups call it from their PersistentPreRunE. helm and the
+// cluster's nodes are given the bundle separately (see CABundle).
+func ApplyCABundle() error {
+	path := CABundle()
+	if path == "" {
+		return nil
+	}
+	caBundleOnce.Do(func() {
+		pool, err := loadCABundle(path)
+		if err != nil {
+			caBundleErr = err
+			return
+		}
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			caBundleErr = fmt.Errorf("--ca-bundle: the default HTTP transport has been replaced")
+			return
+		}
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		tlsConfig.RootCAs = pool
+		transport.TLSClientConfig = tlsConfig
+	})
+	return caBundleErr
+}
+
+// loadCABundle returns the system roots plus every certificate in the PEM
+// file at path. A file without a certificate is an error: a typo would
+// otherwise leave the proxy untrusted with no hint why.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- a file the user named
+	if err != nil {
+		return nil, fmt.Errorf("--ca-bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("--ca-bundle: %s holds no PEM certificates", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCABundle(t *testing.T) {
+	saved := caBundleFlag
+	t.Cleanup(func() { caBundleFlag = saved })
+
+	caBundleFlag = ""
+	t.Setenv(CABundleEnv, "")
+	if got := CABundle(); got != "" {
+		t.Errorf("CABundle() = %q, want empty when unset", got)
+	}
+
+	t.Setenv(CABundleEnv, "/etc/env-ca.pem")
+	if got := CABundle(); got != "/etc/env-ca.pem" {
+		t.Errorf("CABundle() = %q, want the env value", got)
+	}
+
+	caBundleFlag = "flag-ca.pem"
+	got := CABundle()
+	if !filepath.IsAbs(got) || filepath.Base(got) != "flag-ca.pem" {
+		t.Errorf("CABundle() = %q, want the flag made absolute", got)
+	}
+}
+
+func TestLoadCABundle(t *testing.T) {
+	dir := t.TempDir()
+
+	bundle := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(bundle, selfSignedPEM(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if pool, err := loadCABundle(bundle); err != nil || pool == nil {
+		t.Fatalf("loadCABundle(valid) = %v, %v", pool, err)
+	}
+
+	junk := filepath.Join(dir, "junk.pem")
+	if err := os.WriteFile(junk, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCABundle(junk); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("loadCABundle(junk) error = %v, want a no-certificates error", err)
+	}
+
+	if _, err := loadCABundle(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("loadCABundle(missing) succeeded, want an error")
+	}
+}
+
+func selfSignedPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test proxy CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+)
+
+// OperationClass groups external calls by how long they may legitimately
+// take, so every caller of the same kind waits the same time.
+type OperationClass int
+
+const (
+	// Query reads state: listing or inspecting clusters, containers, nodes.
+	Query OperationClass = iota
+	// Mutation changes state in one step: deleting, starting, stopping.
+	Mutation
+	// LongRunning pulls images or waits on a whole cluster: creating one.
+	LongRunning
+)
+
+// TimeoutPolicy is how long each OperationClass may run before it is killed.
+type TimeoutPolicy struct {
+	Query       time.Duration
+	Mutation    time.Duration
+	LongRunning time.Duration
+}
+
+// DefaultTimeoutPolicy keeps the bounds the managers used before they shared
+// one: 30s for queries, 2m for a delete. Create had none; 30m still leaves room
+// for a cold image pull on a slow link.
+var DefaultTimeoutPolicy = TimeoutPolicy{
+	Query:       30 * time.Second,
+	Mutation:    2 * time.Minute,
+	LongRunning: 30 * time.Minute,
+}
+
+// For returns the timeout of class c.
+func (p TimeoutPolicy) For(c OperationClass) time.Duration {
+	switch c {
+	case Mutation:
+		return p.Mutation
+	case LongRunning:
+		return p.LongRunning
+	default:
+		return p.Query
+	}
+}
+
+// timeoutFlag backs the global --timeout flag (see BindTimeoutFlags); zero
+// means unset.
+var timeoutFlag time.Duration
+
+// BindTimeoutFlags registers --timeout on fs. Like --insecure-skip-tls-verify
+// it is read when a call is made, so command groups that shadow the root's
+// PersistentPreRunE still honor it.
+func BindTimeoutFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&timeoutFlag, "timeout", 0, "Timeout for every external command, overriding the per-operation defaults (e.g. 10m)")
+}
+
+// fileConfig is the timeouts section of UserConfigFile. Durations are Go duration strings
+// ("45s", "5m"); a missing or empty entry keeps its default.
+type fileConfig struct {
+	Timeouts struct {
+		Query       string `json:"query"`
+		Mutation    string `json:"mutation"`
+		LongRunning string `json:"longRunning"`
+	} `json:"timeouts"`
+}
+
+// filePolicy is the policy of UserConfigFile, read once per process. A config
+// that cannot be read is reported once and the defaults are used.
+var filePolicy = sync.OnceValue(func() TimeoutPolicy {
+	p, err := loadTimeoutPolicy()
+	if err != nil {
+		pterm.Warning.Printf("Ignoring the timeouts in the CLI config: %v\n", err)
+		return DefaultTimeoutPolicy
+	}
+	return p
+})
+
+// loadTimeoutPolicy reads the timeouts from UserConfigFile over the
+// defaults. A missing file is the defaults.
+func loadTimeoutPolicy() (TimeoutPolicy, error) {
+	p := DefaultTimeoutPolicy
+	var cfg fileConfig
+	if err := LoadUserConfig(&cfg); err != nil {
+		return p, err
+	}
+	for _, e := range []struct {
+		key   string
+		value string
+		dst   *time.Duration
+	}{
+		{"query", cfg.Timeouts.Query, &p.Query},
+		{"mutation", cfg.Timeouts.Mutation, &p.Mutation},
+		{"longRunning", cfg.Timeouts.LongRunning, &p.LongRunning},
+	} {
+		if e.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(e.value)
+		if err != nil || d <= 0 {
+			return DefaultTimeoutPolicy, fmt.Errorf("timeouts.%s in the CLI config must be a positive duration such as 45s or 5m, got %q", e.key, e.value)
+		}
+		*e.dst = d
+	}
+	return p, nil
+}
+
+// Timeouts returns the policy in effect: the defaults, overridden per class by
+// the CLI config, all overridden by --timeout.
+func Timeouts() TimeoutPolicy {
+	if timeoutFlag > 0 {
+		return TimeoutPolicy{Query: timeoutFlag, Mutation: timeoutFlag, LongRunning: timeoutFlag}
+	}
+	return filePolicy()
+}
+
+// Timeout returns the timeout in effect for class c.
+func Timeout(c OperationClass) time.Duration {
+	return Timeouts().For(c)
+}
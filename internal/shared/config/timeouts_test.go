@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func withConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if content != "" {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := UserConfigFile
+	UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { UserConfigFile = orig })
+}
+
+func TestLoadTimeoutPolicy_MissingFileIsDefaults(t *testing.T) {
+	withConfigFile(t, "")
+	p, err := loadTimeoutPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != DefaultTimeoutPolicy {
+		t.Errorf("policy = %+v, want the defaults", p)
+	}
+}
+
+func TestLoadTimeoutPolicy_OverridesPerClass(t *testing.T) {
+	withConfigFile(t, `{"timeouts": {"query": "45s", "longRunning": "1h"}}`)
+	p, err := loadTimeoutPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TimeoutPolicy{Query: 45 * time.Second, Mutation: DefaultTimeoutPolicy.Mutation, LongRunning: time.Hour}
+	if p != want {
+		t.Errorf("policy = %+v, want %+v", p, want)
+	}
+}
+
+func TestLoadTimeoutPolicy_RejectsBadDurations(t *testing.T) {
+	for _, content := range []string{
+		`{"timeouts": {"mutation": "five minutes"}}`,
+		`{"timeouts": {"query": "-1s"}}`,
+		`{"timeouts": `,
+	} {
+		withConfigFile(t, content)
+		if p, err := loadTimeoutPolicy(); err == nil || p != DefaultTimeoutPolicy {
+			t.Errorf("%s: policy = %+v, err = %v; want the defaults and an error", content, p, err)
+		}
+	}
+}
+
+func TestTimeouts_FlagOverridesEveryClass(t *testing.T) {
+	timeoutFlag = 7 * time.Minute
+	t.Cleanup(func() { timeoutFlag = 0 })
+	for _, c := range []OperationClass{Query, Mutation, LongRunning} {
+		if got := Timeout(c); got != 7*time.Minute {
+			t.Errorf("Timeout(%d) = %s, want 7m", c, got)
+		}
+	}
+}
+
+func TestTimeoutPolicy_For(t *testing.T) {
+	p := TimeoutPolicy{Query: 1, Mutation: 2, LongRunning: 3}
+	if p.For(Query) != 1 || p.For(Mutation) != 2 || p.For(LongRunning) != 3 {
+		t.Errorf("For does not pick the class's timeout: %+v", p)
+	}
+}
@@ -4,57 +4,107 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"sync"
 
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
 	"k8s.io/client-go/rest"
 )
 
-// ApplyInsecureTLSConfig configures the rest.Config to skip TLS certificate verification
-// while preserving client certificate authentication data.
-//
-// This approach lets client-go build the TLS config correctly (ingesting client certs/keys
-// from the kubeconfig), then overrides only the certificate verification part.
+// InsecureSkipTLSVerifyEnv turns the TLS bypass on like
+// --insecure-skip-tls-verify does, for automation that cannot pass flags.
+const InsecureSkipTLSVerifyEnv = "OPENFRAME_INSECURE_SKIP_TLS_VERIFY"
+
+// insecureSkipTLSVerifyFlag backs the global --insecure-skip-tls-verify flag
+// (see BindTLSFlags).
+var insecureSkipTLSVerifyFlag bool
+
+// insecureWarning makes sure the bypass is announced once per process, not
+// once per client built.
+var insecureWarning sync.Once
+
+// BindTLSFlags registers --insecure-skip-tls-verify and --ca-bundle on fs.
+// Like --no-sudo they are read when a client is built, so command groups that
+// shadow the root's PersistentPreRunE still honor them.
+func BindTLSFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&insecureSkipTLSVerifyFlag, "insecure-skip-tls-verify", false, "Skip API server certificate verification for local clusters (not recommended)")
+	fs.StringVar(&caBundleFlag, "ca-bundle", "", "PEM file of extra certificate authorities to trust for downloads, helm and the cluster's registries (e.g. a TLS-intercepting proxy's CA)")
+}
+
+// InsecureSkipTLSVerify reports whether the user asked to skip certificate
+// verification for local clusters.
+func InsecureSkipTLSVerify() bool {
+	return insecureSkipTLSVerifyFlag || EnvBool(InsecureSkipTLSVerifyEnv)
+}
+
+// ApplyLocalTLSConfig sets up TLS for a rest.Config built from a kubeconfig.
 //
-// This is necessary for k3d/WSL2 environments where:
-// - The API server's certificate is issued to a hostname that doesn't match 127.0.0.1
-// - Self-signed certificates are used for local development clusters
+// Remote servers are returned untouched. For a local cluster (k3d/kind on the
+// loopback or host interface) the server certificate is verified against the
+// cluster CA from the kubeconfig, as for any other cluster. Its certificate
+// names localhost and 127.0.0.1 among its SANs, but not the 0.0.0.0 or
+// host.docker.internal some kubeconfigs dial, so for those the expected name
+// is set to localhost — the connection still goes where the kubeconfig says.
 //
-// WARNING: Only use this for local development clusters. Never in production.
-func ApplyInsecureTLSConfig(config *rest.Config) *rest.Config {
+// Only with --insecure-skip-tls-verify (or OPENFRAME_INSECURE_SKIP_TLS_VERIFY)
+// is verification skipped, with a warning: the CA is cleared and Insecure set,
+// while the client certificate and key that authenticate us are kept.
+func ApplyLocalTLSConfig(config *rest.Config) *rest.Config {
 	if config == nil {
 		return nil
 	}
 
-	// Only bypass TLS verification for LOCAL clusters (k3d/kind on the
-	// loopback/host interface). For any other server — a cluster reached via
-	// --context, a remote/production cluster — honor the kubeconfig's TLS
-	// settings instead of silently disabling verification.
+	// For any server that is not local — a cluster reached via --context, a
+	// remote/production cluster — honor the kubeconfig's TLS settings.
 	if !isLocalAPIServer(config.Host) {
 		return config
 	}
 
-	// 1. Force TLS bypass - this tells client-go to skip server certificate verification
-	config.Insecure = true
-
-	// 2. Clear CA data to prevent certificate validation conflicts
-	// The CA data would otherwise be used to verify the server certificate
-	config.CAData = nil
-	config.CAFile = ""
+	if InsecureSkipTLSVerify() {
+		insecureWarning.Do(func() {
+			pterm.Warning.Printf("TLS verification is disabled for the local cluster at %s (--insecure-skip-tls-verify); anyone able to intercept that connection can impersonate the API server\n", config.Host)
+		})
 
-	// 3. Clear any custom transport that might conflict with client-go's handling
-	// This ensures client-go builds the transport itself with the auth data intact
-	config.Transport = nil
-	config.WrapTransport = nil
+		// Skip server certificate verification; a CA alongside Insecure is
+		// rejected by client-go, so it goes too.
+		config.Insecure = true
+		config.CAData = nil
+		config.CAFile = ""
+		config.ServerName = ""
 
-	// NOTE: We intentionally preserve these authentication fields:
-	// - config.TLSClientConfig.CertData (client certificate)
-	// - config.TLSClientConfig.KeyData (client private key)
-	// - config.TLSClientConfig.CertFile
-	// - config.TLSClientConfig.KeyFile
-	// These are used by client-go to authenticate to the API server
+		// Let client-go build the transport itself, with the client
+		// certificate and key (CertData/KeyData, CertFile/KeyFile) intact.
+		config.Transport = nil
+		config.WrapTransport = nil
+		return config
+	}
 
+	if config.ServerName == "" && needsServerName(config.Host) {
+		config.ServerName = "localhost"
+	}
 	return config
 }
 
+// HasClusterCA reports whether config can verify the API server: it carries
+// a CA, or verification is off.
+func HasClusterCA(config *rest.Config) bool {
+	return config != nil && (config.Insecure || len(config.CAData) > 0 || config.CAFile != "")
+}
+
+// needsServerName reports whether serverURL dials a local address the API
+// server certificate does not name.
+func needsServerName(serverURL string) bool {
+	host := serverURL
+	if u, err := url.Parse(serverURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	switch strings.ToLower(host) {
+	case "0.0.0.0", "host.docker.internal":
+		return true
+	}
+	return false
+}
+
 // isLocalAPIServer reports whether serverURL points at a cluster running on
 // this host — loopback (127.0.0.0/8, ::1), the unspecified address 0.0.0.0
 // (used by k3d), localhost, or host.docker.internal (Docker Desktop's alias
@@ -80,9 +130,3 @@ func isLocalAPIServer(serverURL string) bool {
 	}
 	return false
 }
-
-// ApplyInsecureTransport is an alias for ApplyInsecureTLSConfig for backward compatibility.
-// Deprecated: Use ApplyInsecureTLSConfig instead.
-func ApplyInsecureTransport(config *rest.Config) *rest.Config {
-	return ApplyInsecureTLSConfig(config)
-}
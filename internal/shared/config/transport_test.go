@@ -40,28 +40,69 @@ func TestIsLocalAPIServer(t *testing.T) {
 	}
 }
 
-func TestApplyInsecureTLSConfig_BypassesLocalOnly(t *testing.T) {
-	// Local (k3d) → bypass applied.
-	loc := ApplyInsecureTLSConfig(&rest.Config{Host: "https://0.0.0.0:63625", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
+func TestApplyLocalTLSConfig_VerifiesByDefault(t *testing.T) {
+	insecureSkipTLSVerifyFlag = false
+	t.Setenv(InsecureSkipTLSVerifyEnv, "")
+
+	// Local (k3d) → CA kept, verification on.
+	loc := ApplyLocalTLSConfig(&rest.Config{Host: "https://127.0.0.1:6550", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
+	if loc.Insecure {
+		t.Error("local cluster: TLS must NOT be bypassed without --insecure-skip-tls-verify")
+	}
+	if string(loc.CAData) != "ca" {
+		t.Error("local cluster: CA must be preserved")
+	}
+	if loc.ServerName != "" {
+		t.Errorf("127.0.0.1 is in the certificate; ServerName = %q, want empty", loc.ServerName)
+	}
+
+	// 0.0.0.0 is not in the certificate → verify it as localhost.
+	unspec := ApplyLocalTLSConfig(&rest.Config{Host: "https://0.0.0.0:63625", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
+	if unspec.Insecure || unspec.ServerName != "localhost" {
+		t.Errorf("0.0.0.0: Insecure=%v ServerName=%q, want false/localhost", unspec.Insecure, unspec.ServerName)
+	}
+
+	// Remote → TLS untouched.
+	rem := ApplyLocalTLSConfig(&rest.Config{Host: "https://api.prod.example.com:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
+	if rem.Insecure || rem.ServerName != "" || string(rem.CAData) != "ca" {
+		t.Error("remote cluster: TLS settings must be preserved")
+	}
+}
+
+func TestApplyLocalTLSConfig_InsecureOptIn(t *testing.T) {
+	insecureSkipTLSVerifyFlag = false
+	t.Setenv(InsecureSkipTLSVerifyEnv, "1")
+
+	// Local → bypass applied, client auth kept.
+	loc := ApplyLocalTLSConfig(&rest.Config{Host: "https://0.0.0.0:63625", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca"), CertData: []byte("cert")}})
 	if !loc.Insecure {
 		t.Error("local cluster: expected Insecure=true")
 	}
-	if loc.CAData != nil {
-		t.Error("local cluster: expected CA cleared")
+	if loc.CAData != nil || loc.ServerName != "" {
+		t.Error("local cluster: expected CA and ServerName cleared")
+	}
+	if string(loc.CertData) != "cert" {
+		t.Error("local cluster: client certificate must be preserved")
 	}
 
-	// Remote → TLS untouched (verification preserved).
-	rem := ApplyInsecureTLSConfig(&rest.Config{Host: "https://api.prod.example.com:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
+	// Remote → never bypassed, whatever the flag says.
+	rem := ApplyLocalTLSConfig(&rest.Config{Host: "https://api.prod.example.com:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}})
 	if rem.Insecure {
 		t.Error("remote cluster: TLS must NOT be bypassed")
 	}
-	if string(rem.CAData) != "ca" {
-		t.Error("remote cluster: CA must be preserved")
+}
+
+func TestHasClusterCA(t *testing.T) {
+	if HasClusterCA(&rest.Config{}) {
+		t.Error("no CA: want false")
+	}
+	if !HasClusterCA(&rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: "/ca.crt"}}) {
+		t.Error("CA file: want true")
 	}
 }
 
-func TestApplyInsecureTLSConfig_NilSafe(t *testing.T) {
-	if ApplyInsecureTLSConfig(nil) != nil {
+func TestApplyLocalTLSConfig_NilSafe(t *testing.T) {
+	if ApplyLocalTLSConfig(nil) != nil {
 		t.Error("nil config must return nil")
 	}
 }
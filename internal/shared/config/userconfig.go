@@ -0,0 +1,41 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UserConfigFile is the user's CLI configuration, ~/.openframe/config.json.
+// Each feature keeps a section of it (timeouts, helm, k3s, notify, …) and
+// reads it with LoadUserConfig. A variable so tests can redirect it.
+var UserConfigFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "config.json"), nil
+}
+
+// LoadUserConfig decodes UserConfigFile into v, a struct holding the sections
+// the caller reads; the rest of the file is ignored. A missing file leaves v
+// as it is. A file that cannot be read or parsed is an error naming it.
+func LoadUserConfig(v any) error {
+	path, err := UserConfigFile()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
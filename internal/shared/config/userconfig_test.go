@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUserConfig(t *testing.T) {
+	var cfg struct {
+		ReadOnly bool `json:"readOnly"`
+		Helm     struct {
+			RepoCacheTTL string `json:"repoCacheTTL"`
+		} `json:"helm"`
+	}
+
+	withConfigFile(t, "")
+	require.NoError(t, LoadUserConfig(&cfg), "a missing file is no error")
+	assert.False(t, cfg.ReadOnly)
+
+	withConfigFile(t, `{"timeouts": {"query": "1m"}, "readOnly": true, "helm": {"repoCacheTTL": "6h"}}`)
+	require.NoError(t, LoadUserConfig(&cfg))
+	assert.True(t, cfg.ReadOnly)
+	assert.Equal(t, "6h", cfg.Helm.RepoCacheTTL, "only the sections asked for are decoded")
+
+	withConfigFile(t, `{"readOnly": "yes"}`)
+	path, _ := UserConfigFile()
+	assert.ErrorContains(t, LoadUserConfig(&cfg), "parsing "+path)
+}
@@ -0,0 +1,256 @@
+// Package credentials keeps the secrets the CLI is given — private registry
+// logins, git tokens and the ArgoCD admin password — in the OS keychain (the
+// macOS Keychain, the Windows Credential Manager, also from inside WSL, or the
+// Secret Service through libsecret on Linux), so they no longer have to live
+// in flags, environment variables or files.
+//
+// The keychain holds the secrets; the names stored, and nothing else, are
+// recorded in ~/.openframe/credentials.json. Lookups consult the keychain only
+// for a recorded name, so a user who never stored anything is never prompted
+// to unlock it, and the stored registries can be listed without a keychain
+// that supports enumeration.
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+)
+
+// service is the keychain service (or target prefix) every entry is filed
+// under.
+const service = "openframe"
+
+// Name prefixes the install reads. registry/<host> holds "user:pass" for a
+// private registry; git/<host> holds a token for git repositories on host.
+const (
+	RegistryPrefix = "registry/"
+	GitPrefix      = "git/"
+)
+
+// ArgoCDAdminName holds the password ArgoCD's admin user is installed with,
+// in place of the one ArgoCD generates.
+const ArgoCDAdminName = "argocd/admin"
+
+// ErrNotFound reports a name with no stored secret.
+var ErrNotFound = errors.New("no stored credential")
+
+// ErrUnavailable reports a platform without a usable keychain.
+var ErrUnavailable = errors.New("no OS keychain available")
+
+// Keychain is an OS secret store.
+type Keychain interface {
+	Get(ctx context.Context, name string) (string, error)
+	Set(ctx context.Context, name, secret string) error
+	Delete(ctx context.Context, name string) error
+}
+
+// newKeychain returns the platform keychain; a variable so tests can replace
+// it.
+var newKeychain = platformKeychain
+
+// indexFile is where the stored names are recorded; a variable so tests can
+// redirect it.
+var indexFile = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "credentials.json"), nil
+}
+
+// validName keeps names to characters every keychain's tooling takes
+// unquoted.
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:@/-]*$`)
+
+// RegistryName is the name of host's registry login.
+func RegistryName(host string) string { return RegistryPrefix + host }
+
+// GitName is the name of the token for git repositories on host.
+func GitName(host string) string { return GitPrefix + host }
+
+// ValidateName rejects names the keychains cannot store verbatim.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid credential name %q: use letters, digits and . _ : @ / -, e.g. %s or %s",
+			name, RegistryName("ghcr.io"), GitName("github.com"))
+	}
+	return nil
+}
+
+// Set stores secret under name, replacing any earlier value.
+func Set(ctx context.Context, name, secret string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if secret == "" {
+		return fmt.Errorf("the secret for %s is empty", name)
+	}
+	if err := newKeychain().Set(ctx, name, secret); err != nil {
+		return fmt.Errorf("storing %s in the keychain: %w", name, err)
+	}
+	names, err := Names()
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if n == name {
+			return nil
+		}
+	}
+	return saveNames(append(names, name))
+}
+
+// Get returns the secret stored under name, registered for redaction.
+func Get(ctx context.Context, name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	secret, err := newKeychain().Get(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("reading %s from the keychain: %w", name, err)
+	}
+	redact.RegisterSecret(secret)
+	return secret, nil
+}
+
+// Delete removes name from the keychain and the index. It reports whether
+// anything was stored under name.
+func Delete(ctx context.Context, name string) (bool, error) {
+	if err := ValidateName(name); err != nil {
+		return false, err
+	}
+	err := newKeychain().Delete(ctx, name)
+	found := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return false, fmt.Errorf("removing %s from the keychain: %w", name, err)
+	}
+	names, err := Names()
+	if err != nil {
+		return found, err
+	}
+	kept := names[:0]
+	for _, n := range names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	if len(kept) == len(names) {
+		return found, nil
+	}
+	return true, saveNames(kept)
+}
+
+// Names returns the stored names, sorted. A missing index is no names.
+func Names() ([]string, error) {
+	path, err := indexFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: fixed path under ~/.openframe
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func saveNames(names []string) error {
+	path, err := indexFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	sort.Strings(names)
+	data, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// GitToken returns the stored token for an https repoURL's host, or "" when
+// none is stored or the keychain cannot be read — the clone then proceeds
+// without one, exactly as before.
+func GitToken(ctx context.Context, repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return ""
+	}
+	name := GitName(u.Hostname())
+	if !Stored(name) {
+		return ""
+	}
+	token, err := Get(ctx, name)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// Registries returns the stored registry logins as host → "user:pass". A
+// login the keychain cannot return is an error: an install that silently
+// dropped it would fail later with an image pull error instead.
+func Registries(ctx context.Context) (map[string]string, error) {
+	names, err := Names()
+	if err != nil {
+		return nil, err
+	}
+	logins := map[string]string{}
+	for _, name := range names {
+		host, ok := strings.CutPrefix(name, RegistryPrefix)
+		if !ok {
+			continue
+		}
+		secret, err := Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		logins[host] = secret
+	}
+	return logins, nil
+}
+
+// ArgoCDAdminPassword returns the stored ArgoCD admin password, or "" when
+// none is stored or the keychain cannot be read — ArgoCD then generates one,
+// exactly as before.
+func ArgoCDAdminPassword(ctx context.Context) string {
+	if !Stored(ArgoCDAdminName) {
+		return ""
+	}
+	password, err := Get(ctx, ArgoCDAdminName)
+	if err != nil {
+		return ""
+	}
+	return password
+}
+
+// Stored reports whether a secret is recorded under name. It reads only the
+// index, so it never prompts to unlock the keychain.
+func Stored(name string) bool {
+	names, _ := Names()
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package credentials
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKeychain is an in-memory keychain.
+type fakeKeychain map[string]string
+
+func (k fakeKeychain) Get(_ context.Context, name string) (string, error) {
+	if s, ok := k[name]; ok {
+		return s, nil
+	}
+	return "", ErrNotFound
+}
+
+func (k fakeKeychain) Set(_ context.Context, name, secret string) error {
+	k[name] = secret
+	return nil
+}
+
+func (k fakeKeychain) Delete(_ context.Context, name string) error {
+	if _, ok := k[name]; !ok {
+		return ErrNotFound
+	}
+	delete(k, name)
+	return nil
+}
+
+// useFakes points the package at an in-memory keychain and a temporary index.
+func useFakes(t *testing.T) fakeKeychain {
+	t.Helper()
+	k := fakeKeychain{}
+	index := filepath.Join(t.TempDir(), "credentials.json")
+	savedKeychain, savedIndex := newKeychain, indexFile
+	newKeychain = func() Keychain { return k }
+	indexFile = func() (string, error) { return index, nil }
+	t.Cleanup(func() { newKeychain, indexFile = savedKeychain, savedIndex })
+	return k
+}
+
+func TestSetGetDelete(t *testing.T) {
+	k := useFakes(t)
+	ctx := context.Background()
+
+	require.NoError(t, Set(ctx, GitName("github.com"), "ghp_one"))
+	require.NoError(t, Set(ctx, GitName("github.com"), "ghp_two"))
+	require.NoError(t, Set(ctx, RegistryName("ghcr.io"), "robot:s3cret"))
+
+	got, err := Get(ctx, "git/github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_two", got)
+
+	names, err := Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git/github.com", "registry/ghcr.io"}, names, "a replaced secret is indexed once")
+
+	found, err := Delete(ctx, "git/github.com")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.NotContains(t, k, "git/github.com")
+	names, _ = Names()
+	assert.Equal(t, []string{"registry/ghcr.io"}, names)
+
+	found, err = Delete(ctx, "git/github.com")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, err = Get(ctx, "git/github.com")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSetRejects(t *testing.T) {
+	useFakes(t)
+	ctx := context.Background()
+
+	assert.Error(t, Set(ctx, "", "x"))
+	assert.Error(t, Set(ctx, "git/github.com secret", "x"))
+	assert.Error(t, Set(ctx, "-s", "x"), "a name must not read as an option")
+	assert.Error(t, Set(ctx, "git/github.com", ""))
+}
+
+func TestGitToken(t *testing.T) {
+	k := useFakes(t)
+	ctx := context.Background()
+
+	assert.Empty(t, GitToken(ctx, "https://github.com/acme/platform"))
+
+	require.NoError(t, Set(ctx, GitName("github.com"), "ghp_x"))
+	assert.Equal(t, "ghp_x", GitToken(ctx, "https://github.com/acme/platform"))
+	assert.Empty(t, GitToken(ctx, "https://gitlab.com/acme/platform"))
+	assert.Empty(t, GitToken(ctx, "http://github.com/acme/platform"), "never sent in clear text")
+	assert.Empty(t, GitToken(ctx, "git@github.com:acme/platform.git"))
+
+	// An entry the keychain lost is no token, not an error.
+	delete(k, "git/github.com")
+	assert.Empty(t, GitToken(ctx, "https://github.com/acme/platform"))
+}
+
+func TestRegistries(t *testing.T) {
+	k := useFakes(t)
+	ctx := context.Background()
+
+	logins, err := Registries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, logins)
+
+	require.NoError(t, Set(ctx, RegistryName("ghcr.io"), "robot:s3cret"))
+	require.NoError(t, Set(ctx, GitName("github.com"), "ghp_x"))
+	logins, err = Registries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ghcr.io": "robot:s3cret"}, logins)
+
+	delete(k, "registry/ghcr.io")
+	_, err = Registries(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestKeychainCommandsKeepSecretsOffArgv(t *testing.T) {
+	ctx := context.Background()
+
+	mock := executor.NewMockCommandExecutor()
+	require.NoError(t, macKeychain{ex: mock}.Set(ctx, "git/github.com", "ghp_x"))
+	cmd := mock.Commands()[0]
+	assert.Equal(t, "security -i", cmd.String())
+	assert.Equal(t, "add-generic-password -U -s openframe -a git/github.com -X 6768705f78\n", string(cmd.Stdin))
+
+	mock = executor.NewMockCommandExecutor()
+	require.NoError(t, secretService{ex: mock}.Set(ctx, "git/github.com", "ghp_x"))
+	cmd = mock.Commands()[0]
+	assert.Equal(t, []string{"store", "--label=OpenFrame git/github.com", "service", "openframe", "account", "git/github.com"}, cmd.Args)
+	assert.Equal(t, "ghp_x", string(cmd.Stdin))
+
+	mock = executor.NewMockCommandExecutor()
+	mock.SetDefaultResult(&executor.CommandResult{Stdout: "ghp_x\n"})
+	got, err := macKeychain{ex: mock}.Get(ctx, "git/github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_x", got)
+}
+
+// TestHostCred covers the Windows Credential Manager reached from WSL: the
+// secret rides base64-encoded inside the script on stdin, never in argv.
+func TestHostCred(t *testing.T) {
+	ctx := context.Background()
+
+	mock := executor.NewMockCommandExecutor()
+	require.NoError(t, hostCred{ex: mock}.Set(ctx, "argocd/admin", "hunter2"))
+	cmd := mock.Commands()[0]
+	assert.Equal(t, "powershell.exe", cmd.Name)
+	assert.NotContains(t, strings.Join(cmd.Args, " "), "hunter2")
+	assert.NotContains(t, strings.Join(cmd.Args, " "), "aHVudGVyMg==")
+	assert.Contains(t, string(cmd.Stdin), "$Action = 'set'\n$Target = 'openframe:argocd/admin'\n$Secret = 'aHVudGVyMg=='\n")
+	assert.NotContains(t, string(cmd.Stdin), "hunter2")
+
+	mock = executor.NewMockCommandExecutor()
+	mock.SetDefaultResult(&executor.CommandResult{Stdout: "aHVudGVyMg==\r\n"})
+	got, err := hostCred{ex: mock}.Get(ctx, "argocd/admin")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", got)
+}
+
+func TestArgoCDAdminPassword(t *testing.T) {
+	k := useFakes(t)
+	ctx := context.Background()
+
+	assert.Empty(t, ArgoCDAdminPassword(ctx))
+	require.NoError(t, Set(ctx, ArgoCDAdminName, "hunter2"))
+	assert.Equal(t, "hunter2", ArgoCDAdminPassword(ctx))
+	delete(k, ArgoCDAdminName)
+	assert.Empty(t, ArgoCDAdminPassword(ctx), "an entry the keychain lost is no password")
+}
+
+func TestNotFound(t *testing.T) {
+	assert.ErrorIs(t, notFound(executor.NewCommandError("security", securityNotFound, ""), securityNotFound), ErrNotFound)
+	other := executor.NewCommandError("security", 1, "locked")
+	assert.Equal(t, other, notFound(other, securityNotFound))
+	assert.NoError(t, notFound(nil, securityNotFound))
+	assert.ErrorIs(t, notFound(executor.NewCommandError("powershell.exe", hostCredNotFound, ""), hostCredNotFound), ErrNotFound)
+}
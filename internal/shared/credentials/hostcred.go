@@ -0,0 +1,60 @@
+package credentials
+
+import (
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// hostCredScript is the PowerShell side of hostCred.
+//
+//go:embed hostcred.ps1
+var hostCredScript string
+
+// hostCredNotFound is hostcred.ps1's exit code for a missing credential.
+const hostCredNotFound = 3
+
+// hostCred is the Windows Credential Manager reached from inside WSL through
+// powershell.exe. The CLI forwards itself into WSL on Windows, where there is
+// rarely a Secret Service; this keeps its secrets on the Windows host under
+// the same targets winCred uses. The script travels on stdin with the secret
+// base64-encoded in it, so nothing secret is on a command line.
+type hostCred struct{ ex executor.CommandExecutor }
+
+// run executes hostcred.ps1 for action. name has passed ValidateName, so it
+// needs no escaping inside the single quotes.
+func (h hostCred) run(ctx context.Context, action, name, secret string) (*executor.CommandResult, error) {
+	header := fmt.Sprintf("$Action = '%s'\n$Target = '%s:%s'\n$Secret = '%s'\n",
+		action, service, name, base64.StdEncoding.EncodeToString([]byte(secret)))
+	return h.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "powershell.exe",
+		Args:    []string{"-NoProfile", "-NonInteractive", "-Command", "[Console]::In.ReadToEnd() | Invoke-Expression"},
+		Stdin:   []byte(header + hostCredScript),
+	})
+}
+
+func (h hostCred) Get(ctx context.Context, name string) (string, error) {
+	result, err := h.run(ctx, "get", name, "")
+	if err != nil {
+		return "", notFound(err, hostCredNotFound)
+	}
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(result.Stdout))
+	if err != nil {
+		return "", fmt.Errorf("reading the Windows Credential Manager: %w", err)
+	}
+	return string(secret), nil
+}
+
+func (h hostCred) Set(ctx context.Context, name, secret string) error {
+	_, err := h.run(ctx, "set", name, secret)
+	return err
+}
+
+func (h hostCred) Delete(ctx context.Context, name string) error {
+	_, err := h.run(ctx, "delete", name, "")
+	return notFound(err, hostCredNotFound)
+}
@@ -0,0 +1,87 @@
+# Reads, writes or deletes a generic credential in the Windows Credential
+# Manager for the CLI running in WSL; see hostCred. The lines before this set
+# $Action (get, set or delete), $Target and $Secret, base64-encoded. get
+# prints the secret base64-encoded; a missing credential exits with 3.
+$ErrorActionPreference = 'Stop'
+Add-Type -TypeDefinition @'
+using System;
+using System.Runtime.InteropServices;
+using System.Runtime.InteropServices.ComTypes;
+
+public static class OpenFrameCred {
+    [StructLayout(LayoutKind.Sequential, CharSet = CharSet.Unicode)]
+    public struct CREDENTIAL {
+        public int Flags;
+        public int Type;
+        public string TargetName;
+        public string Comment;
+        public FILETIME LastWritten;
+        public int CredentialBlobSize;
+        public IntPtr CredentialBlob;
+        public int Persist;
+        public int AttributeCount;
+        public IntPtr Attributes;
+        public string TargetAlias;
+        public string UserName;
+    }
+
+    [DllImport("advapi32.dll", EntryPoint = "CredReadW", CharSet = CharSet.Unicode, SetLastError = true)]
+    public static extern bool CredRead(string target, int type, int flags, out IntPtr credential);
+
+    [DllImport("advapi32.dll", EntryPoint = "CredWriteW", CharSet = CharSet.Unicode, SetLastError = true)]
+    public static extern bool CredWrite(ref CREDENTIAL credential, int flags);
+
+    [DllImport("advapi32.dll", EntryPoint = "CredDeleteW", CharSet = CharSet.Unicode, SetLastError = true)]
+    public static extern bool CredDelete(string target, int type, int flags);
+
+    [DllImport("advapi32.dll")]
+    public static extern void CredFree(IntPtr credential);
+}
+'@
+
+$generic = 1
+$notFound = 1168
+$marshal = [System.Runtime.InteropServices.Marshal]
+
+function Fail($call) {
+    $code = $marshal::GetLastWin32Error()
+    if ($code -eq $notFound) { [Environment]::Exit(3) }
+    [Console]::Error.WriteLine("$call failed with error $code")
+    [Environment]::Exit(1)
+}
+
+switch ($Action) {
+    'get' {
+        $ptr = [IntPtr]::Zero
+        if (-not [OpenFrameCred]::CredRead($Target, $generic, 0, [ref]$ptr)) { Fail 'CredRead' }
+        try {
+            $cred = $marshal::PtrToStructure($ptr, [type][OpenFrameCred+CREDENTIAL])
+            $blob = New-Object byte[] $cred.CredentialBlobSize
+            if ($cred.CredentialBlobSize -gt 0) {
+                $marshal::Copy($cred.CredentialBlob, $blob, 0, $cred.CredentialBlobSize)
+            }
+            [Console]::Out.Write([Convert]::ToBase64String($blob))
+        } finally {
+            [OpenFrameCred]::CredFree($ptr)
+        }
+    }
+    'set' {
+        $blob = [Convert]::FromBase64String($Secret)
+        $cred = New-Object OpenFrameCred+CREDENTIAL
+        $cred.Type = $generic
+        $cred.TargetName = $Target
+        $cred.UserName = 'openframe'
+        $cred.Persist = 2 # CRED_PERSIST_LOCAL_MACHINE, as winCred
+        $cred.CredentialBlobSize = $blob.Length
+        $cred.CredentialBlob = $marshal::AllocHGlobal($blob.Length)
+        try {
+            $marshal::Copy($blob, 0, $cred.CredentialBlob, $blob.Length)
+            if (-not [OpenFrameCred]::CredWrite([ref]$cred, 0)) { Fail 'CredWrite' }
+        } finally {
+            $marshal::FreeHGlobal($cred.CredentialBlob)
+        }
+    }
+    'delete' {
+        if (-not [OpenFrameCred]::CredDelete($Target, $generic, 0)) { Fail 'CredDelete' }
+    }
+}
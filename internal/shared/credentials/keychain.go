@@ -0,0 +1,118 @@
+package credentials
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/sysctl"
+)
+
+// platformKeychain picks the keychain for this OS. The secret always travels
+// on stdin or through the API, never on a command line other processes can
+// read.
+func platformKeychain() Keychain {
+	ex := executor.NewRealCommandExecutor(false, false)
+	switch runtime.GOOS {
+	case "darwin":
+		return macKeychain{ex: ex}
+	case "linux":
+		// Under WSL the Windows host's Credential Manager, which outlives the
+		// distribution and is there whether or not a Secret Service runs.
+		if sysctl.InsideWSL() {
+			if _, err := exec.LookPath("powershell.exe"); err == nil {
+				return hostCred{ex: ex}
+			}
+		}
+		if _, err := exec.LookPath("secret-tool"); err != nil {
+			return unavailable{reason: "secret-tool was not found; install libsecret-tools (Debian/Ubuntu) or libsecret (Fedora) and make sure a Secret Service such as GNOME Keyring is running"}
+		}
+		return secretService{ex: ex}
+	case "windows":
+		return winCred{}
+	}
+	return unavailable{reason: runtime.GOOS + " is not supported"}
+}
+
+// unavailable is the keychain of a host without one.
+type unavailable struct{ reason string }
+
+func (u unavailable) err() error { return fmt.Errorf("%w: %s", ErrUnavailable, u.reason) }
+
+func (u unavailable) Get(context.Context, string) (string, error) { return "", u.err() }
+func (u unavailable) Set(context.Context, string, string) error   { return u.err() }
+func (u unavailable) Delete(context.Context, string) error        { return u.err() }
+
+// macKeychain stores generic passwords in the login keychain with the
+// security tool.
+type macKeychain struct{ ex executor.CommandExecutor }
+
+// securityNotFound is security's exit code for a missing item.
+const securityNotFound = 44
+
+// Set feeds the command to `security -i` on stdin, with the secret hex-encoded
+// (-X), so it never appears in argv and needs no quoting.
+func (k macKeychain) Set(ctx context.Context, name, secret string) error {
+	line := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", service, name, hex.EncodeToString([]byte(secret)))
+	_, err := k.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: "security", Args: []string{"-i"}, Stdin: []byte(line)})
+	return err
+}
+
+func (k macKeychain) Get(ctx context.Context, name string) (string, error) {
+	result, err := k.ex.Execute(ctx, "security", "find-generic-password", "-s", service, "-a", name, "-w")
+	if err != nil {
+		return "", notFound(err, securityNotFound)
+	}
+	return strings.TrimSuffix(result.Stdout, "\n"), nil
+}
+
+func (k macKeychain) Delete(ctx context.Context, name string) error {
+	_, err := k.ex.Execute(ctx, "security", "delete-generic-password", "-s", service, "-a", name)
+	return notFound(err, securityNotFound)
+}
+
+// secretService stores secrets through libsecret's secret-tool, which talks to
+// whichever Secret Service (GNOME Keyring, KWallet) the session runs.
+type secretService struct{ ex executor.CommandExecutor }
+
+func (s secretService) Set(ctx context.Context, name, secret string) error {
+	_, err := s.ex.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "secret-tool",
+		Args:    []string{"store", "--label=OpenFrame " + name, "service", service, "account", name},
+		Stdin:   []byte(secret),
+	})
+	return err
+}
+
+// Get treats a silent exit 1 as not found: that is how secret-tool reports a
+// lookup that matched nothing.
+func (s secretService) Get(ctx context.Context, name string) (string, error) {
+	result, err := s.ex.Execute(ctx, "secret-tool", "lookup", "service", service, "account", name)
+	if err != nil {
+		var exit *executor.CommandError
+		if errors.As(err, &exit) && exit.ExitCode == 1 && strings.TrimSpace(exit.Stderr) == "" {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(result.Stdout, "\n"), nil
+}
+
+func (s secretService) Delete(ctx context.Context, name string) error {
+	_, err := s.ex.Execute(ctx, "secret-tool", "clear", "service", service, "account", name)
+	return err
+}
+
+// notFound maps a command that exited with code to ErrNotFound.
+func notFound(err error, code int) error {
+	var exit *executor.CommandError
+	if errors.As(err, &exit) && exit.ExitCode == code {
+		return ErrNotFound
+	}
+	return err
+}
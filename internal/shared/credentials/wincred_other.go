@@ -0,0 +1,12 @@
+//go:build !windows
+
+package credentials
+
+import "context"
+
+// winCred is the Windows Credential Manager, which exists only on Windows.
+type winCred struct{}
+
+func (winCred) Get(context.Context, string) (string, error) { return "", ErrUnavailable }
+func (winCred) Set(context.Context, string, string) error   { return ErrUnavailable }
+func (winCred) Delete(context.Context, string) error        { return ErrUnavailable }
@@ -0,0 +1,106 @@
+//go:build windows
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// credential mirrors CREDENTIALW.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// winCred stores generic credentials in the Windows Credential Manager under
+// "openframe:<name>", where they show up in the Control Panel.
+type winCred struct{}
+
+func target(name string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + name)
+}
+
+func (winCred) Get(_ context.Context, name string) (string, error) {
+	t, err := target(name)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	ok, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(t)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ok == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return "", ErrNotFound
+		}
+		return "", callErr
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) //nolint:errcheck // CredFree returns nothing
+	if cred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (winCred) Set(_ context.Context, name, secret string) error {
+	t, err := target(name)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(service)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         t,
+		CredentialBlobSize: uint32(len(blob)), // #nosec G115 -- a secret typed or piped by the user
+		CredentialBlob:     &blob[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if ok, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0); ok == 0 {
+		return callErr
+	}
+	return nil
+}
+
+func (winCred) Delete(_ context.Context, name string) error {
+	t, err := target(name)
+	if err != nil {
+		return err
+	}
+	if ok, _, callErr := procCredDeleteW.Call(uintptr(unsafe.Pointer(t)), credTypeGeneric, 0); ok == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return ErrNotFound
+		}
+		return callErr
+	}
+	return nil
+}
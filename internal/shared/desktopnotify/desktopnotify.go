@@ -0,0 +1,60 @@
+// Package desktopnotify shows desktop notifications through notify-send
+// (Linux) or osascript (macOS). It backs both `openframe watch` and
+// --notify desktop.
+package desktopnotify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// Overridden in tests.
+var (
+	lookPath = exec.LookPath
+	goos     = runtime.GOOS
+)
+
+// Notifier shows desktop notifications with the platform's tool.
+type Notifier struct {
+	exec executor.CommandExecutor
+	tool string
+}
+
+// New returns a notifier, or an error when this machine has no way to show
+// one (a headless Linux box, WSL without notify-send).
+func New(exec executor.CommandExecutor) (*Notifier, error) {
+	tool := "notify-send"
+	if goos == "darwin" {
+		tool = "osascript"
+	}
+	if _, err := lookPath(tool); err != nil {
+		return nil, fmt.Errorf("%s is not installed, so desktop notifications cannot be shown here", tool)
+	}
+	return &Notifier{exec: exec, tool: tool}, nil
+}
+
+// Show shows one notification. An urgent one asks notify-send for critical
+// urgency; osascript has no such level.
+func (n *Notifier) Show(ctx context.Context, title, message string, urgent bool) error {
+	if n.tool == "osascript" {
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		_, err := n.exec.Execute(ctx, "osascript", "-e", script)
+		return err
+	}
+	urgency := "normal"
+	if urgent {
+		urgency = "critical"
+	}
+	_, err := n.exec.Execute(ctx, "notify-send", "--app-name=openframe", "--urgency="+urgency, title, message)
+	return err
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
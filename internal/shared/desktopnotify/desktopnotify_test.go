@@ -0,0 +1,61 @@
+package desktopnotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubPlatform(t *testing.T, os string, found bool) {
+	t.Helper()
+	origGOOS, origLookPath := goos, lookPath
+	goos = os
+	lookPath = func(file string) (string, error) {
+		if found {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	t.Cleanup(func() { goos, lookPath = origGOOS, origLookPath })
+}
+
+func TestShow_NotifySend(t *testing.T) {
+	stubPlatform(t, "linux", true)
+	mock := executor.NewMockCommandExecutor()
+	n, err := New(mock)
+	require.NoError(t, err)
+
+	require.NoError(t, n.Show(context.Background(), "OpenFrame: dev degraded", "2/3 nodes Ready", true))
+	require.NoError(t, n.Show(context.Background(), "OpenFrame: dev recovered", "all nodes Ready", false))
+
+	cmds := mock.Commands()
+	require.Len(t, cmds, 2)
+	assert.Equal(t, "notify-send", cmds[0].Name)
+	assert.Equal(t, []string{"--app-name=openframe", "--urgency=critical", "OpenFrame: dev degraded", "2/3 nodes Ready"}, cmds[0].Args)
+	assert.Equal(t, "--urgency=normal", cmds[1].Args[1])
+}
+
+func TestShow_OsascriptQuotes(t *testing.T) {
+	stubPlatform(t, "darwin", true)
+	mock := executor.NewMockCommandExecutor()
+	n, err := New(mock)
+	require.NoError(t, err)
+
+	require.NoError(t, n.Show(context.Background(), "OpenFrame: dev recovered", `say "hi" \o/`, true))
+
+	cmds := mock.Commands()
+	require.Len(t, cmds, 1)
+	assert.Equal(t, "osascript", cmds[0].Name)
+	assert.Equal(t, []string{"-e", `display notification "say \"hi\" \\o/" with title "OpenFrame: dev recovered"`}, cmds[0].Args)
+}
+
+func TestNew_UnavailableWithoutTool(t *testing.T) {
+	stubPlatform(t, "darwin", false)
+	_, err := New(executor.NewMockCommandExecutor())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "osascript is not installed")
+}
@@ -0,0 +1,244 @@
+// Package dockerdesktop reads and raises the resources Docker Desktop gives
+// its VM on macOS. Every k3d node runs inside that VM, so a VM left at a small
+// default memory limit shows up as OOM-killed pods and evictions mid-install
+// rather than as a clear error. The limits live in Docker Desktop's settings
+// file; edits keep every other setting as it was.
+package dockerdesktop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// Resources are what Docker Desktop's VM has.
+type Resources struct {
+	MemoryMB int
+	CPUs     int
+}
+
+// dockerInfo runs `docker info` for the fields Detect reads; a variable so
+// tests can fake the daemon.
+var dockerInfo = func(ctx context.Context) ([]byte, error) {
+	res, err := run(ctx, "docker", "info", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Stdout), nil
+}
+
+// run runs a command through the executor, so the sandbox and --audit see it.
+func run(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	return executor.NewRealCommandExecutor(false, false).ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args})
+}
+
+// Detect returns the resources of Docker Desktop's VM, as `docker info`
+// reports them. ok is false off macOS, when the daemon is not Docker Desktop
+// or does not answer: the limits are only Docker Desktop's to raise there.
+func Detect(ctx context.Context) (Resources, bool) {
+	if runtime.GOOS != "darwin" {
+		return Resources{}, false
+	}
+	return detect(ctx)
+}
+
+func detect(ctx context.Context) (Resources, bool) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := dockerInfo(ctx)
+	if err != nil {
+		return Resources{}, false
+	}
+	var info struct {
+		OperatingSystem string `json:"OperatingSystem"`
+		MemTotal        int64  `json:"MemTotal"`
+		NCPU            int    `json:"NCPU"`
+	}
+	if err := json.Unmarshal(out, &info); err != nil || !strings.Contains(info.OperatingSystem, "Docker Desktop") {
+		return Resources{}, false
+	}
+	// The VM's kernel keeps some of the memory it was given, so `docker info`
+	// reports a little less than the setting: 7.7 GB for 8 GB. Settings are
+	// whole GB, so round up to the one it was set to.
+	mb := int(info.MemTotal / (1024 * 1024))
+	return Resources{MemoryMB: (mb + 1023) / 1024 * 1024, CPUs: info.NCPU}, true
+}
+
+// Settings files in Docker Desktop's group container, newest first: 4.34
+// moved the settings to settings-store.json and capitalised the keys.
+var settingsFiles = []struct {
+	name, memoryKey, cpusKey string
+}{
+	{"settings-store.json", "MemoryMiB", "Cpus"},
+	{"settings.json", "memoryMiB", "cpus"},
+}
+
+// settingsDir holds Docker Desktop's settings on macOS; a variable so tests
+// can redirect it.
+var settingsDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Group Containers", "group.com.docker"), nil
+}
+
+// Settings is Docker Desktop's settings file.
+type Settings struct {
+	Path      string
+	memoryKey string
+	cpusKey   string
+	values    map[string]interface{}
+}
+
+// LoadSettings reads the settings file this Docker Desktop uses.
+func LoadSettings() (*Settings, error) {
+	dir, err := settingsDir()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range settingsFiles {
+		path := filepath.Join(dir, f.name)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: Docker Desktop's own settings file
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		s := &Settings{Path: path, memoryKey: f.memoryKey, cpusKey: f.cpusKey}
+		if err := json.Unmarshal(data, &s.values); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if s.values == nil {
+			s.values = map[string]interface{}{}
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("no Docker Desktop settings file in %s", dir)
+}
+
+// Set writes the VM's memory and CPUs into the settings (Save persists them).
+func (s *Settings) Set(r Resources) {
+	s.values[s.memoryKey] = r.MemoryMB
+	s.values[s.cpusKey] = r.CPUs
+}
+
+// Save writes the settings, keeping the previous version as <file>.bak.
+func (s *Settings) Save() error {
+	data, err := json.MarshalIndent(s.values, "", "  ")
+	if err != nil {
+		return err
+	}
+	if old, err := os.ReadFile(s.Path); err == nil { //nolint:gosec // G304: Docker Desktop's own settings file
+		if err := os.WriteFile(s.Path+".bak", old, 0o600); err != nil {
+			return fmt.Errorf("backing up %s: %w", s.Path, err)
+		}
+	}
+	if err := os.WriteFile(s.Path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", s.Path, err)
+	}
+	return nil
+}
+
+// Change raises one limit.
+type Change struct {
+	Setting string
+	From    string
+	To      string
+}
+
+// hostReserveMB is left to macOS when raising the VM's memory.
+const hostReserveMB = 4096
+
+// Plan returns the resources that bring have up to need on a Mac with
+// hostMemoryMB and hostCPUs, and the changes that makes. Limits are only
+// raised, never lowered, and never past what the Mac has.
+func Plan(have, need Resources, hostMemoryMB, hostCPUs int) (Resources, []Change) {
+	want := have
+	var changes []Change
+	// Settings are whole GB: compare what would be set, or a target a little
+	// above the current setting proposes the same value and a needless restart.
+	if m := roundGB(min(need.MemoryMB, hostMemoryMB-hostReserveMB)); m > have.MemoryMB {
+		want.MemoryMB = m
+		changes = append(changes, Change{Setting: "Memory", From: FormatMemory(have.MemoryMB), To: FormatMemory(want.MemoryMB)})
+	}
+	if c := min(need.CPUs, hostCPUs); c > have.CPUs {
+		want.CPUs = c
+		changes = append(changes, Change{Setting: "CPUs", From: strconv.Itoa(have.CPUs), To: strconv.Itoa(c)})
+	}
+	return want, changes
+}
+
+// FormatMemory renders MB as GB with one decimal, e.g. "7.7 GB".
+func FormatMemory(mb int) string {
+	return fmt.Sprintf("%.1f GB", float64(mb)/1024)
+}
+
+// roundGB rounds mb down to whole GB (at least 1GB).
+func roundGB(mb int) int {
+	return max(mb/1024, 1) * 1024
+}
+
+// quitTimeout bounds the wait for Docker Desktop to exit; a variable so
+// tests can shorten it.
+var quitTimeout = 60 * time.Second
+
+// quitApp asks Docker Desktop to quit, as its menu's Quit does; a variable so
+// tests can fake it.
+var quitApp = func(ctx context.Context) error {
+	res, err := run(ctx, "osascript", "-e", `quit app "Docker"`)
+	if err != nil {
+		return fmt.Errorf("quitting Docker Desktop: %w: %s", err, strings.TrimSpace(res.Output()))
+	}
+	return nil
+}
+
+// desktopRunning reports whether a Docker Desktop process is left: the app,
+// or the backend that writes the settings on its way out. A variable so tests
+// can fake the processes.
+var desktopRunning = func(ctx context.Context) (bool, error) {
+	res, err := run(ctx, "pgrep", "-x", `com\.docker\.backend|Docker Desktop`)
+	if err == nil {
+		return true, nil
+	}
+	if res != nil && res.ExitCode == 1 { // no process matched
+		return false, nil
+	}
+	return false, err
+}
+
+// Quit asks Docker Desktop to quit and waits for its processes to exit. The
+// daemon stops answering well before that, and Docker Desktop writes its
+// settings back as it exits, so the settings file is only safe to edit once
+// they are gone.
+func Quit(ctx context.Context) error {
+	if err := quitApp(ctx); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, quitTimeout)
+	defer cancel()
+	for {
+		running, err := desktopRunning(ctx)
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("checking that Docker Desktop quit: %w", err)
+		}
+		if err == nil && !running {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for Docker Desktop to quit")
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package dockerdesktop
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeDockerInfo(t *testing.T, out string, err error) {
+	t.Helper()
+	orig := dockerInfo
+	dockerInfo = func(context.Context) ([]byte, error) { return []byte(out), err }
+	t.Cleanup(func() { dockerInfo = orig })
+}
+
+func TestDetect(t *testing.T) {
+	fakeDockerInfo(t, `{"OperatingSystem":"Docker Desktop","MemTotal":8217473024,"NCPU":4}`, nil)
+	r, ok := detect(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, Resources{MemoryMB: 8 * 1024, CPUs: 4}, r, "7.7 GB reported is the 8 GB setting")
+
+	fakeDockerInfo(t, `{"OperatingSystem":"Ubuntu 24.04 LTS","MemTotal":8217473024,"NCPU":4}`, nil)
+	_, ok = detect(context.Background())
+	assert.False(t, ok, "a daemon that is not Docker Desktop has no settings to raise")
+
+	fakeDockerInfo(t, "", errors.New("Cannot connect to the Docker daemon"))
+	_, ok = detect(context.Background())
+	assert.False(t, ok)
+}
+
+func TestPlan(t *testing.T) {
+	need := Resources{MemoryMB: 12 * 1024, CPUs: 4}
+
+	// A 16 GB Mac with the VM at 2 GB: raised as far as macOS can spare.
+	want, changes := Plan(Resources{MemoryMB: 2 * 1024, CPUs: 2}, need, 16*1024, 8)
+	assert.Equal(t, Resources{MemoryMB: 12 * 1024, CPUs: 4}, want)
+	assert.Equal(t, []Change{
+		{Setting: "Memory", From: "2.0 GB", To: "12.0 GB"},
+		{Setting: "CPUs", From: "2", To: "4"},
+	}, changes)
+
+	// An 8 GB Mac leaves 4 GB to macOS.
+	want, _ = Plan(Resources{MemoryMB: 2 * 1024, CPUs: 4}, need, 8*1024, 4)
+	assert.Equal(t, 4*1024, want.MemoryMB)
+
+	// A target that rounds down to the current setting changes nothing.
+	_, changes = Plan(Resources{MemoryMB: 8 * 1024, CPUs: 4}, Resources{MemoryMB: 8*1024 + 512, CPUs: 4}, 32*1024, 8)
+	assert.Empty(t, changes)
+
+	// Nothing is lowered.
+	_, changes = Plan(Resources{MemoryMB: 24 * 1024, CPUs: 10}, need, 64*1024, 12)
+	assert.Empty(t, changes)
+}
+
+func TestQuit_WaitsForTheProcessesToExit(t *testing.T) {
+	origQuit, origRunning, origTimeout := quitApp, desktopRunning, quitTimeout
+	t.Cleanup(func() { quitApp, desktopRunning, quitTimeout = origQuit, origRunning, origTimeout })
+	quitApp = func(context.Context) error { return nil }
+	fakeDockerInfo(t, "", errors.New("Cannot connect to the Docker daemon"))
+
+	checks := 0
+	desktopRunning = func(context.Context) (bool, error) {
+		checks++
+		return checks < 2, nil
+	}
+	require.NoError(t, Quit(context.Background()))
+	assert.Equal(t, 2, checks, "a stopped daemon is not enough: Quit waits for the backend to exit")
+
+	quitTimeout = 10 * time.Millisecond
+	desktopRunning = func(context.Context) (bool, error) { return true, nil }
+	assert.ErrorContains(t, Quit(context.Background()), "timed out")
+}
+
+func TestSettings(t *testing.T) {
+	dir := t.TempDir()
+	orig := settingsDir
+	settingsDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { settingsDir = orig })
+
+	_, err := LoadSettings()
+	assert.ErrorContains(t, err, "no Docker Desktop settings file")
+
+	t.Run("legacy settings.json", func(t *testing.T) {
+		path := filepath.Join(dir, "settings.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"memoryMiB": 2048, "cpus": 2, "diskSizeMiB": 65536}`), 0o600))
+		s, err := LoadSettings()
+		require.NoError(t, err)
+		s.Set(Resources{MemoryMB: 8192, CPUs: 4})
+		require.NoError(t, s.Save())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"memoryMiB": 8192, "cpus": 4, "diskSizeMiB": 65536}`, string(data), "other settings are kept")
+		backup, err := os.ReadFile(path + ".bak")
+		require.NoError(t, err)
+		assert.Contains(t, string(backup), `"memoryMiB": 2048`)
+	})
+
+	t.Run("settings-store.json wins", func(t *testing.T) {
+		path := filepath.Join(dir, "settings-store.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"MemoryMiB": 4096, "Cpus": 2}`), 0o600))
+		s, err := LoadSettings()
+		require.NoError(t, err)
+		assert.Equal(t, path, s.Path)
+		s.Set(Resources{MemoryMB: 12288, CPUs: 6})
+		require.NoError(t, s.Save())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"MemoryMiB": 12288, "Cpus": 6}`, string(data))
+	})
+}
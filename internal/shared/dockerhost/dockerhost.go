@@ -0,0 +1,193 @@
+// Package dockerhost works out which Docker daemon the user actually talks to.
+// The docker CLI follows the active `docker context` (Docker Desktop on Linux,
+// Colima, remote hosts), but k3d and the other tools OpenFrame drives only read
+// DOCKER_HOST — so without help they fall back to /var/run/docker.sock, which
+// is the wrong daemon or no daemon at all for context and rootless users.
+// Apply resolves the endpoint once and exports it for every child process.
+package dockerhost
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSocket is the rootful daemon's socket, which every tool assumes when
+// DOCKER_HOST is unset.
+const DefaultSocket = "/var/run/docker.sock"
+
+// Endpoint is the resolved Docker daemon.
+type Endpoint struct {
+	// Context is the docker context it came from ("" when DOCKER_HOST was
+	// already set or no context is configured).
+	Context string
+	// Host is a DOCKER_HOST value, or "" for the default socket.
+	Host string
+	// CertPath is the TLS material of a TLS-protected context ("" for none).
+	CertPath string
+	// SkipTLSVerify mirrors the context's setting.
+	SkipTLSVerify bool
+}
+
+// Rootless reports whether the endpoint is a per-user (rootless) daemon, which
+// must never be reached through sudo.
+func (e Endpoint) Rootless() bool {
+	return strings.HasPrefix(e.Host, "unix:///run/user/")
+}
+
+// Env returns the variables child processes need to reach the endpoint; it is
+// empty for the default socket.
+func (e Endpoint) Env() map[string]string {
+	if e.Host == "" {
+		return nil
+	}
+	env := map[string]string{"DOCKER_HOST": e.Host}
+	if e.CertPath != "" {
+		env["DOCKER_CERT_PATH"] = e.CertPath
+		if !e.SkipTLSVerify {
+			env["DOCKER_TLS_VERIFY"] = "1"
+		}
+	}
+	return env
+}
+
+// String describes the endpoint for diagnostics.
+func (e Endpoint) String() string {
+	host := e.Host
+	if host == "" {
+		host = "unix://" + DefaultSocket
+	}
+	switch {
+	case e.Context != "":
+		return fmt.Sprintf("%s (docker context %q)", host, e.Context)
+	case e.Rootless():
+		return host + " (rootless)"
+	}
+	return host
+}
+
+// host abstracts the process environment and filesystem for resolve.
+type host struct {
+	getenv func(string) string
+	home   string
+	exists func(string) bool
+	read   func(string) ([]byte, error)
+}
+
+func realHost() host {
+	home, _ := os.UserHomeDir()
+	return host{
+		getenv: os.Getenv,
+		home:   home,
+		exists: func(p string) bool { _, err := os.Stat(p); return err == nil },
+		read:   os.ReadFile,
+	}
+}
+
+// Resolve returns the endpoint the docker CLI would use.
+func Resolve() (Endpoint, error) {
+	return resolve(realHost())
+}
+
+// Apply resolves the endpoint and exports it to this process's environment
+// (and so to every tool it runs) unless DOCKER_HOST is already set. It returns
+// the endpoint in use.
+func Apply() (Endpoint, error) {
+	ep, err := Resolve()
+	if err != nil {
+		return Endpoint{}, err
+	}
+	if os.Getenv("DOCKER_HOST") != "" {
+		return ep, nil
+	}
+	for k, v := range ep.Env() {
+		if err := os.Setenv(k, v); err != nil {
+			return ep, err
+		}
+	}
+	return ep, nil
+}
+
+// resolve applies the docker CLI's precedence: DOCKER_HOST, then DOCKER_CONTEXT,
+// then the config's currentContext; with no context it falls back to the
+// rootless socket when only that one exists.
+func resolve(h host) (Endpoint, error) {
+	if dh := h.getenv("DOCKER_HOST"); dh != "" {
+		return Endpoint{Host: dh}, nil
+	}
+
+	configDir := h.getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		configDir = filepath.Join(h.home, ".docker")
+	}
+
+	name := h.getenv("DOCKER_CONTEXT")
+	if name == "" {
+		name = currentContext(h, configDir)
+	}
+	if name != "" && name != "default" {
+		return contextEndpoint(h, configDir, name)
+	}
+
+	if !h.exists(DefaultSocket) {
+		if runtimeDir := h.getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+			if sock := filepath.Join(runtimeDir, "docker.sock"); h.exists(sock) {
+				return Endpoint{Host: "unix://" + filepath.ToSlash(sock)}, nil
+			}
+		}
+	}
+	return Endpoint{}, nil
+}
+
+// currentContext reads "currentContext" from the docker CLI config; a missing
+// or unreadable config means the default context.
+func currentContext(h host, configDir string) string {
+	raw, err := h.read(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if json.Unmarshal(raw, &cfg) != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+// contextEndpoint reads a named context from the docker CLI's context store,
+// where each context lives under a directory named by the SHA-256 of its name.
+func contextEndpoint(h host, configDir, name string) (Endpoint, error) {
+	sum := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(sum[:])
+
+	raw, err := h.read(filepath.Join(configDir, "contexts", "meta", id, "meta.json"))
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("docker context %q is selected but could not be read (check `docker context ls`): %w", name, err)
+	}
+	var meta struct {
+		Endpoints struct {
+			Docker struct {
+				Host          string `json:"Host"`
+				SkipTLSVerify bool   `json:"SkipTLSVerify"`
+			} `json:"docker"`
+		} `json:"Endpoints"`
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return Endpoint{}, fmt.Errorf("docker context %q: invalid metadata: %w", name, err)
+	}
+
+	ep := Endpoint{
+		Context:       name,
+		Host:          meta.Endpoints.Docker.Host,
+		SkipTLSVerify: meta.Endpoints.Docker.SkipTLSVerify,
+	}
+	if certs := filepath.Join(configDir, "contexts", "tls", id, "docker"); h.exists(certs) {
+		ep.CertPath = certs
+	}
+	return ep, nil
+}
@@ -0,0 +1,107 @@
+package dockerhost
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHost is a host rooted at a temp home with the given environment. The
+// default socket is reported present unless noDefaultSocket is set.
+func testHost(t *testing.T, env map[string]string, noDefaultSocket bool) (host, string) {
+	home := t.TempDir()
+	return host{
+		getenv: func(k string) string { return env[k] },
+		home:   home,
+		exists: func(p string) bool {
+			if p == DefaultSocket {
+				return !noDefaultSocket
+			}
+			_, err := os.Stat(p)
+			return err == nil
+		},
+		read: os.ReadFile,
+	}, home
+}
+
+func writeContext(t *testing.T, configDir, name, meta string) string {
+	sum := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(sum[:])
+	dir := filepath.Join(configDir, "contexts", "meta", id)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0o600))
+	return id
+}
+
+func TestResolve_DockerHostWins(t *testing.T) {
+	h, _ := testHost(t, map[string]string{"DOCKER_HOST": "tcp://10.0.0.5:2376", "DOCKER_CONTEXT": "colima"}, false)
+	ep, err := resolve(h)
+	require.NoError(t, err)
+	assert.Equal(t, "tcp://10.0.0.5:2376", ep.Host)
+	assert.Empty(t, ep.Context)
+}
+
+func TestResolve_CurrentContextFromConfig(t *testing.T) {
+	h, home := testHost(t, nil, false)
+	configDir := filepath.Join(home, ".docker")
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{"currentContext":"desktop-linux"}`), 0o600))
+	writeContext(t, configDir, "desktop-linux", `{"Name":"desktop-linux","Endpoints":{"docker":{"Host":"unix:///home/u/.docker/desktop/docker.sock"}}}`)
+
+	ep, err := resolve(h)
+	require.NoError(t, err)
+	assert.Equal(t, "desktop-linux", ep.Context)
+	assert.Equal(t, map[string]string{"DOCKER_HOST": "unix:///home/u/.docker/desktop/docker.sock"}, ep.Env())
+}
+
+func TestResolve_DockerContextEnvAndTLS(t *testing.T) {
+	h, home := testHost(t, map[string]string{"DOCKER_CONTEXT": "remote"}, false)
+	configDir := filepath.Join(home, ".docker")
+	id := writeContext(t, configDir, "remote", `{"Endpoints":{"docker":{"Host":"tcp://build:2376","SkipTLSVerify":false}}}`)
+	certs := filepath.Join(configDir, "contexts", "tls", id, "docker")
+	require.NoError(t, os.MkdirAll(certs, 0o700))
+
+	ep, err := resolve(h)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"DOCKER_HOST":       "tcp://build:2376",
+		"DOCKER_CERT_PATH":  certs,
+		"DOCKER_TLS_VERIFY": "1",
+	}, ep.Env())
+}
+
+func TestResolve_MissingContextIsAnError(t *testing.T) {
+	h, _ := testHost(t, map[string]string{"DOCKER_CONTEXT": "gone"}, false)
+	_, err := resolve(h)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "docker context ls")
+}
+
+func TestResolve_DefaultContextUsesDefaultSocket(t *testing.T) {
+	h, _ := testHost(t, map[string]string{"DOCKER_CONTEXT": "default"}, false)
+	ep, err := resolve(h)
+	require.NoError(t, err)
+	assert.Nil(t, ep.Env(), "nothing to export for /var/run/docker.sock")
+	assert.False(t, ep.Rootless())
+}
+
+func TestResolve_RootlessSocket(t *testing.T) {
+	runtimeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(runtimeDir, "docker.sock"), nil, 0o600))
+	h, _ := testHost(t, map[string]string{"XDG_RUNTIME_DIR": runtimeDir}, true)
+
+	ep, err := resolve(h)
+	require.NoError(t, err)
+	assert.Equal(t, "unix://"+filepath.ToSlash(filepath.Join(runtimeDir, "docker.sock")), ep.Host)
+}
+
+func TestEndpoint_Rootless(t *testing.T) {
+	assert.True(t, Endpoint{Host: "unix:///run/user/1000/docker.sock"}.Rootless())
+	assert.False(t, Endpoint{Host: "unix:///var/run/docker.sock"}.Rootless())
+	assert.Contains(t, Endpoint{Host: "unix:///run/user/1000/docker.sock"}.String(), "rootless")
+}
@@ -61,6 +61,7 @@ func (eh *ErrorHandler) HandleError(err error) {
 	var validationErr *ValidationError
 	var commandErr *executor.CommandError
 	var branchErr *BranchNotFoundError
+	remedy := Diagnose(err)
 	switch {
 	case stderrors.As(err, &validationErr):
 		eh.handleValidationError(validationErr)
@@ -68,6 +69,8 @@ func (eh *ErrorHandler) HandleError(err error) {
 		eh.handleCommandError(commandErr, err)
 	case stderrors.As(err, &branchErr):
 		eh.handleBranchNotFoundError(branchErr)
+	case remedy != nil && !isInterruption(err):
+		eh.handleRemedy(remedy, err)
 	default:
 		eh.handleGenericError(err)
 	}
@@ -88,8 +91,8 @@ func (eh *ErrorHandler) handleValidationError(err *ValidationError) {
 // anywhere, so real failures (executor.CommandError) fell through to the
 // generic dump and the user saw "exit status 1" with no reason.
 //
-// outer is the full error chain, used for the friendly hint (which matches on
-// wrapper text such as "cluster create operation failed").
+// outer is the full error chain, used for the remedy or friendly hint (which
+// match on wrapper text such as "cluster create operation failed").
 func (eh *ErrorHandler) handleCommandError(err *executor.CommandError, outer error) {
 	// DefaultBasicText, not bare pterm.Printf: the latter writes straight to
 	// stdout, bypassing --silent redirection (and any test capture).
@@ -106,7 +109,10 @@ func (eh *ErrorHandler) handleCommandError(err *executor.CommandError, outer err
 		pterm.DefaultBasicText.Printf("  Error:     %v\n", err)
 	}
 
-	if hint := friendlyHint(outer); hint != "" {
+	if r := Diagnose(outer); r != nil {
+		pterm.Info.Println(r.Problem)
+		printRemedySteps(r)
+	} else if hint := friendlyHint(outer); hint != "" {
 		pterm.Info.Printf("%s\n", hint)
 	}
 }
@@ -32,6 +32,10 @@ func friendlyHint(err error) string {
 		return "The cluster address couldn't be resolved. Check your kubeconfig / current context."
 	case containsAny(msg, "context deadline exceeded", "timed out", "timeout"):
 		return "The operation timed out — the cluster may be slow or unreachable. Wait a moment and retry."
+	// The daemon is up but its socket refuses the user; the generic
+	// permission hint below would send them to their kubeconfig.
+	case strings.Contains(msg, "permission denied") && containsAny(msg, "docker.sock", "docker daemon socket"):
+		return "Your user may not use Docker. Join the docker group ('sudo usermod -aG docker $USER'), then run 'newgrp docker' or log out and back in."
 	case containsAny(msg, "permission denied", "forbidden", "unauthorized"):
 		return "Permission was denied. Check your credentials / kubeconfig for this cluster."
 	case strings.Contains(msg, "context") && strings.Contains(msg, "not exist"):
@@ -25,6 +25,7 @@ func TestFriendlyHint(t *testing.T) {
 		{"permission denied", errors.New("pods is forbidden: User cannot list"), "Permission was denied"},
 		{"missing context", errors.New(`context "k3d-foo" does not exist`), "kube-context doesn't exist"},
 		{"docker down", errors.New("Cannot connect to the Docker daemon"), "Docker"},
+		{"docker socket refuses the user", errors.New("permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock"), "docker group"},
 		{"unknown error", errors.New("some totally unrelated failure"), ""},
 	}
 	for _, tc := range cases {
@@ -0,0 +1,144 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+)
+
+// troubleshootingDoc is the Troubleshooting section of the quick start; each
+// Remedy links its own subsection.
+const troubleshootingDoc = "https://github.com/flamingo-stack/openframe-cli/blob/main/docs/getting-started/quick-start.md"
+
+// Remedy is what the user sees instead of the raw error chain for a failure
+// whose cause is known: what went wrong, what to do, and where to read more.
+type Remedy struct {
+	Problem string
+	Steps   []string
+	DocURL  string
+}
+
+// remedies maps failure signatures, matched case-insensitively anywhere in the
+// error chain, to their remedy. Order matters: the first match wins.
+var remedies = []struct {
+	signatures []string
+	remedy     Remedy
+}{
+	{
+		signatures: []string{"no space left on device", "disk quota exceeded"},
+		remedy: Remedy{
+			Problem: "The disk is full.",
+			Steps: []string{
+				"See what Docker uses: docker system df",
+				"Remove unused images: openframe cluster cleanup",
+				"Remove leftovers of deleted clusters: openframe cluster prune",
+			},
+			DocURL: troubleshootingDoc + "#disk-full",
+		},
+	},
+	{
+		signatures: []string{"port is already allocated", "address already in use", "ports are not available"},
+		remedy: Remedy{
+			Problem: "A port the cluster needs is already in use.",
+			Steps: []string{
+				"Find what holds it: lsof -i :6550-6552 -i :80 -i :443",
+				"On Windows: Get-NetTCPConnection -State Listen -LocalPort 6550,6551,6552,80,443",
+				"Stop that process, or delete the cluster using it: openframe cluster list",
+			},
+			DocURL: troubleshootingDoc + "#port-already-in-use",
+		},
+	},
+	{
+		signatures: []string{"cannot connect to the docker daemon", "is the docker daemon running", "docker daemon is not running", "docker desktop is not running"},
+		remedy: Remedy{
+			Problem: "Docker is not running.",
+			Steps: []string{
+				"Start Docker Desktop (macOS/Windows) or run: sudo systemctl start docker",
+				"Check it answers: docker info",
+			},
+			DocURL: troubleshootingDoc + "#docker-not-running",
+		},
+	},
+	{
+		signatures: []string{"there is no distribution with the supplied name", "wsl_e_distro_not_found"},
+		remedy:     wslDistroRemedy,
+	},
+	{
+		signatures: []string{"temporary failure in name resolution", "no such host", "server misbehaving"},
+		remedy: Remedy{
+			Problem: "A host name could not be resolved.",
+			Steps: []string{
+				"Check the network, VPN and proxy settings: nslookup github.com",
+				"For a cluster address, check the current kube-context: kubectl config current-context",
+			},
+			DocURL: troubleshootingDoc + "#dns-resolution-fails",
+		},
+	},
+}
+
+var wslDistroRemedy = Remedy{
+	Problem: "The WSL distribution the CLI runs in is missing or not reachable.",
+	Steps: []string{
+		"List the installed distributions: wsl --list --verbose",
+		"Install one if none is listed: wsl --install -d Ubuntu",
+	},
+	DocURL: troubleshootingDoc + "#wsl-distribution-missing",
+}
+
+// Diagnose returns the remedy for err, or nil when its cause is not a known
+// one.
+func Diagnose(err error) *Remedy {
+	if err == nil {
+		return nil
+	}
+	var wslErr *executor.WSLError
+	if stderrors.As(err, &wslErr) && (wslErr.ExitCode == executor.WSLExitCodeDistroNotFound || wslErr.ExitCode == -1) {
+		r := wslDistroRemedy
+		return &r
+	}
+	msg := strings.ToLower(err.Error())
+	for _, e := range remedies {
+		if containsAny(msg, e.signatures...) {
+			r := e.remedy
+			return &r
+		}
+	}
+	return nil
+}
+
+// handleRemedy prints r in place of err. The full chain is kept for --verbose.
+func (eh *ErrorHandler) handleRemedy(r *Remedy, err error) {
+	pterm.Error.Println(r.Problem)
+	printRemedySteps(r)
+	if eh.verbose {
+		pterm.DefaultBasicText.Printf("  Details: %v\n", err)
+	} else {
+		pterm.DefaultBasicText.Println("  Run with --verbose for the full error.")
+	}
+}
+
+// printRemedySteps prints the numbered next steps of r and its doc link.
+func printRemedySteps(r *Remedy) {
+	for i, step := range r.Steps {
+		pterm.DefaultBasicText.Printf("  %d. %s\n", i+1, step)
+	}
+	pterm.DefaultBasicText.Printf("  More: %s\n", r.DocURL)
+}
+
+// PresentRemedy shows err through the handler when it has a remedy and has not
+// been shown yet, and returns it marked as handled; any other error is
+// returned unchanged. It catches errors that reach the top without passing
+// through HandleGlobalError.
+func PresentRemedy(err error, verbose bool) error {
+	var handled *AlreadyHandledError
+	if err == nil || stderrors.As(err, &handled) || isInterruption(err) {
+		return err
+	}
+	if Diagnose(err) == nil {
+		return err
+	}
+	NewErrorHandler(verbose).HandleError(err)
+	return &AlreadyHandledError{OriginalError: err}
+}
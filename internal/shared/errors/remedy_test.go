@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnose(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		wantDoc string // anchor of the remedy ("" → expect none)
+	}{
+		{"nil", nil, ""},
+		{"port in use", errors.New(`Bind for 0.0.0.0:6550 failed: port is already allocated`), "#port-already-in-use"},
+		{"address in use", errors.New("listen tcp :8080: bind: address already in use"), "#port-already-in-use"},
+		{"docker down", errors.New("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?"), "#docker-not-running"},
+		{"wsl distro text", errors.New("There is no distribution with the supplied name."), "#wsl-distribution-missing"},
+		{"wsl distro exit code", fmt.Errorf("start: %w", &executor.WSLError{Operation: "k3d", ExitCode: executor.WSLExitCodeDistroNotFound}), "#wsl-distribution-missing"},
+		{"dns", errors.New("dial tcp: lookup github.com: Temporary failure in name resolution"), "#dns-resolution-fails"},
+		{"disk full", errors.New("write /var/lib/docker/tmp/x: no space left on device"), "#disk-full"},
+		// A full disk is the cause even when the failing write was a pull.
+		{"disk full beats dns wording", errors.New("pull failed: no such host; no space left on device"), "#disk-full"},
+		{"unknown", errors.New("some totally unrelated failure"), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Diagnose(tc.err)
+			if tc.wantDoc == "" {
+				assert.Nil(t, r)
+				return
+			}
+			if assert.NotNil(t, r) {
+				assert.Equal(t, troubleshootingDoc+tc.wantDoc, r.DocURL)
+				assert.NotEmpty(t, r.Problem)
+				assert.NotEmpty(t, r.Steps)
+			}
+		})
+	}
+}
+
+func captureHandlerOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	oldBasic, oldErr, oldInfo := pterm.DefaultBasicText, pterm.Error, pterm.Info
+	pterm.DefaultBasicText = *pterm.DefaultBasicText.WithWriter(&buf)
+	pterm.Error = *pterm.Error.WithWriter(&buf)
+	pterm.Info = *pterm.Info.WithWriter(&buf)
+	t.Cleanup(func() { pterm.DefaultBasicText, pterm.Error, pterm.Info = oldBasic, oldErr, oldInfo })
+	return &buf
+}
+
+func TestErrorHandler_Remedy_KeepsChainForVerbose(t *testing.T) {
+	err := fmt.Errorf("cluster create operation failed: pulling image: %w", errors.New("no space left on device"))
+
+	out := captureHandlerOutput(t)
+	NewErrorHandler(false).HandleError(err)
+	assert.Contains(t, out.String(), "The disk is full.")
+	assert.Contains(t, out.String(), "docker system df")
+	assert.Contains(t, out.String(), troubleshootingDoc+"#disk-full")
+	assert.NotContains(t, out.String(), "pulling image", "the raw chain is only for --verbose")
+
+	out.Reset()
+	NewErrorHandler(true).HandleError(err)
+	assert.Contains(t, out.String(), "The disk is full.")
+	assert.Contains(t, out.String(), "cluster create operation failed: pulling image")
+}
+
+func TestErrorHandler_CommandError_AddsRemedy(t *testing.T) {
+	out := captureHandlerOutput(t)
+	NewErrorHandler(false).HandleError(&executor.CommandError{
+		Command:  "k3d cluster create dev",
+		ExitCode: 1,
+		Stderr:   "Bind for 0.0.0.0:6550 failed: port is already allocated",
+	})
+	assert.Contains(t, out.String(), "port is already allocated", "the child's stderr still reaches the user")
+	assert.Contains(t, out.String(), "A port the cluster needs is already in use.")
+	assert.Contains(t, out.String(), troubleshootingDoc+"#port-already-in-use")
+}
+
+func TestPresentRemedy(t *testing.T) {
+	out := captureHandlerOutput(t)
+
+	known := errors.New("Cannot connect to the Docker daemon")
+	var handled *AlreadyHandledError
+	assert.ErrorAs(t, PresentRemedy(known, false), &handled)
+	assert.Contains(t, out.String(), "Docker is not running.")
+
+	// Unknown, already shown or cancelled errors are left to the caller.
+	out.Reset()
+	unknown := errors.New("something else")
+	assert.Same(t, unknown, PresentRemedy(unknown, false))
+	shown := &AlreadyHandledError{OriginalError: known}
+	assert.Same(t, error(shown), PresentRemedy(shown, false))
+	cancelled := fmt.Errorf("docker daemon is not running: %w", context.Canceled)
+	assert.Same(t, cancelled, PresentRemedy(cancelled, false))
+	assert.Empty(t, out.String())
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
@@ -12,7 +13,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/tracing"
 	"github.com/pterm/pterm"
 )
 
@@ -83,6 +88,12 @@ func (e *CommandError) Error() string {
 // Unwrap exposes the underlying exec error so errors.As/Is still reach it.
 func (e *CommandError) Unwrap() error { return e.cause }
 
+// NewCommandError returns the error of a command that exited with exitCode,
+// as the executor reports it. For fakes standing in for the executor.
+func NewCommandError(command string, exitCode int, stderr string) *CommandError {
+	return &CommandError{Command: command, ExitCode: exitCode, Stderr: stderr, cause: fmt.Errorf("exit status %d", exitCode)}
+}
+
 // wslAvailabilityCache caches the WSL availability check result
 var (
 	wslAvailable     bool
@@ -117,7 +128,8 @@ func IsWSLAvailable() bool {
 	return wslAvailable
 }
 
-// IsWSLUbuntuAvailable checks if the Ubuntu distribution is available and accessible in WSL
+// IsWSLUbuntuAvailable checks if OpenFrame's distribution (the WSL default, or
+// OPENFRAME_WSL_DISTRO) is available and accessible in WSL
 func IsWSLUbuntuAvailable() bool {
 	if runtime.GOOS != "windows" {
 		return false
@@ -130,11 +142,11 @@ func IsWSLUbuntuAvailable() bool {
 		return wslUbuntuAvail
 	}
 
-	// Try to run a simple command in Ubuntu
+	// Try to run a simple command in the distribution
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "wsl", "-d", "Ubuntu", "echo", "ok")
+	cmd := exec.CommandContext(ctx, "wsl", append(WSLDistroArgs(), "echo", "ok")...)
 	output, err := cmd.Output()
 	wslUbuntuAvail = err == nil && strings.TrimSpace(string(output)) == "ok"
 	wslUbuntuChecked = true
@@ -150,36 +162,47 @@ func ResetWSLCache() {
 	wslUbuntuChecked = false
 }
 
+// wslRecoverySettle is how long TryRecoverWSL lets a terminated distribution
+// shut down; a variable so tests do not wait.
+var wslRecoverySettle = 2 * time.Second
+
 // TryRecoverWSL attempts to recover WSL connectivity by terminating and restarting the distribution
 // This is a last-resort operation when WSL becomes completely unresponsive
 // Returns nil if recovery was successful, error otherwise
+//
+// A fault injected for FaultTargetWSL (see FaultsEnv) fails its wsl.exe calls
+// and runs it off Windows too, so its failure paths can be tested anywhere.
 func TryRecoverWSL() error {
-	if runtime.GOOS != "windows" {
+	if runtime.GOOS != "windows" && !faultAimedAt(FaultTargetWSL) {
 		return nil
 	}
+	metrics.Retry("wsl-recovery")
 
-	// First, try to terminate the Ubuntu distribution
+	// First, try to terminate the distribution. --terminate needs its name;
+	// the unnamed default distribution is stopped by shutting WSL down.
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	terminateCmd := exec.CommandContext(ctx, "wsl", "--terminate", "Ubuntu")
-	_ = terminateCmd.Run() // Ignore error - distribution might not be running
+	stop := []string{"--shutdown"}
+	if d := WSLDistroArgs(); d != nil {
+		stop = []string{"--terminate", d[1]}
+	}
+	_, _ = runWSL(ctx, ExecuteOptions{Command: "wsl", Args: stop}) // Ignore error - distribution might not be running
 
 	// Wait a moment for WSL to fully terminate
-	time.Sleep(2 * time.Second)
+	time.Sleep(wslRecoverySettle)
 
-	// Now try to start Ubuntu with a simple command
+	// Now try to start the distribution with a simple command
 	startCtx, startCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer startCancel()
 
-	startCmd := exec.CommandContext(startCtx, "wsl", "-d", "Ubuntu", "echo", "recovered")
-	output, err := startCmd.Output()
+	output, err := runWSL(startCtx, ExecuteOptions{Command: "wsl", Args: append(WSLDistroArgs(), "echo", "recovered")})
 	if err != nil {
-		return fmt.Errorf("WSL recovery failed - could not restart Ubuntu: %w", err)
+		return fmt.Errorf("WSL recovery failed - could not restart the distribution: %w", err)
 	}
 
-	if strings.TrimSpace(string(output)) != "recovered" {
-		return fmt.Errorf("WSL recovery returned unexpected output: %s", string(output))
+	if strings.TrimSpace(output) != "recovered" {
+		return fmt.Errorf("WSL recovery returned unexpected output: %s", output)
 	}
 
 	// Reset the cache since we just restarted WSL
@@ -195,47 +218,29 @@ func TryRecoverWSL() error {
 	return nil
 }
 
-// RestartDockerInWSL starts the Docker daemon inside WSL2 Ubuntu
+// RestartDockerInWSL starts the Docker daemon inside OpenFrame's WSL2 distribution
 // This is needed after WSL restart since Docker CE runs as a background process
 func RestartDockerInWSL() error {
-	if runtime.GOOS != "windows" {
+	if runtime.GOOS != "windows" && !faultAimedAt(FaultTargetWSL) {
 		return nil
 	}
 
 	// Start Docker daemon in WSL using the start-docker.sh script we created during installation
 	// If the script doesn't exist, fall back to starting dockerd directly
-	startScript := `
-if [ -x /usr/local/bin/start-docker.sh ]; then
-    sudo /usr/local/bin/start-docker.sh
-else
-    # Fallback: start dockerd directly if script doesn't exist
-    if ! pgrep -x dockerd > /dev/null; then
-        sudo dockerd > /dev/null 2>&1 &
-    fi
-fi
-
-# Wait for Docker to be ready (up to 30 seconds)
-for i in $(seq 1 30); do
-    if sudo docker ps > /dev/null 2>&1; then
-        echo "docker_ready"
-        exit 0
-    fi
-    sleep 1
-done
-echo "docker_timeout"
-exit 1
-`
+	startScript := scripts.MustRender(scripts.StartDockerWSL, nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "wsl", "-d", "Ubuntu", "-u", "root", "bash", "-c", startScript)
-	output, err := cmd.Output()
+	// Piped to `bash -s` rather than passed to `bash -c`: wsl.exe re-joins its
+	// argv into one command line, which mangles a multi-line script.
+	opts := WSLShellScript(append(WSLDistroArgs(), "-u", "root"), startScript)
+	output, err := runWSL(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to start Docker in WSL: %w", err)
 	}
 
-	result := strings.TrimSpace(string(output))
+	result := strings.TrimSpace(output)
 	if result == "docker_timeout" {
 		return fmt.Errorf("timeout waiting for Docker to start in WSL")
 	}
@@ -243,6 +248,28 @@ exit 1
 	return nil
 }
 
+// runWSL runs the wsl.exe invocation opts for the recovery above and returns
+// its stdout, or fails with the fault injected for FaultTargetWSL. It runs
+// outside any executor, so it applies the sandbox and --audit itself.
+func runWSL(ctx context.Context, opts ExecuteOptions) (string, error) {
+	fullCommand := opts.Command + " " + strings.Join(opts.Args, " ")
+	if err := checkSandbox(opts, fullCommand); err != nil {
+		return "", err
+	}
+	if err := confirmAudit(fullCommand); err != nil {
+		return "", err
+	}
+	if err := InjectedFault(FaultTargetWSL); err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, "wsl", opts.Args...) // #nosec G204 -- fixed wsl.exe argv; scripts go on stdin
+	if opts.Stdin != nil {
+		cmd.Stdin = bytes.NewReader(opts.Stdin)
+	}
+	output, err := cmd.Output()
+	return string(output), err
+}
+
 // GetWSLErrorSuggestion returns a helpful suggestion based on the WSL error
 func GetWSLErrorSuggestion(exitCode int, command string) string {
 	switch exitCode {
@@ -292,6 +319,22 @@ type ExecuteOptions struct {
 	Env     map[string]string // Environment variables
 	Timeout time.Duration     // Execution timeout
 	Stdin   []byte            // Data piped to the process stdin (e.g. `helm -f -`); nil = no stdin
+
+	// StdinReader and StdoutWriter stream data too large to buffer, such as
+	// a tar archive: StdinReader is read when Stdin is nil, and stdout goes to
+	// StdoutWriter instead of CommandResult.Stdout.
+	StdinReader  io.Reader
+	StdoutWriter io.Writer
+
+	// OnOutput, when set, receives each line the command writes to stdout or
+	// stderr as it is produced (redacted, never called concurrently), so long
+	// runs like `k3d cluster create` can show live progress. The full output
+	// is still collected into the CommandResult.
+	OnOutput func(line string)
+
+	// ownScript marks options built by ShellScript, the only bash the
+	// sandbox lets run.
+	ownScript bool
 }
 
 // RealCommandExecutor implements CommandExecutor using actual system commands
@@ -320,6 +363,13 @@ func (e *RealCommandExecutor) Execute(ctx context.Context, name string, args ...
 	return e.ExecuteWithOptions(ctx, options)
 }
 
+// observeCommand reports a command that ran to the metrics and the trace.
+func observeCommand(command, fullCommand string, start time.Time, result *CommandResult, err error) {
+	tool := toolName(command)
+	metrics.CommandExecuted(tool, err, result.Duration)
+	tracing.Command(tool, fullCommand, start, result.Duration, result.ExitCode, err)
+}
+
 // ExecuteWithOptions implements CommandExecutor.ExecuteWithOptions
 func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options ExecuteOptions) (*CommandResult, error) {
 	start := time.Now()
@@ -337,6 +387,12 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 		Stderr: "",
 	}
 
+	// The sandbox applies to dry runs too: a command it refuses would fail
+	// the real run just the same.
+	if err := checkSandbox(options, fullCommand); err != nil {
+		return result, err
+	}
+
 	// Handle dry-run mode. The "Would run:" line prints UNCONDITIONALLY (not
 	// only under --verbose): showing what would execute is dry-run's entire
 	// purpose — without it a dry-run was indistinguishable from a real
@@ -346,6 +402,14 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 		result.Duration = time.Since(start)
 		return result, nil
 	}
+	if err := confirmAudit(fullCommand); err != nil {
+		return result, err
+	}
+	if faulted, err := commandFault(options, redact.Redact(fullCommand)); faulted != nil {
+		faulted.Duration = time.Since(start)
+		observeCommand(options.Command, fullCommand, start, faulted, err)
+		return faulted, err
+	}
 
 	// Create the command with wrapped command/args
 	cmd := exec.CommandContext(ctx, command, args...) // #nosec G204 -- central executor: explicit argv (no shell); callers pass internal tool names + controlled args
@@ -382,12 +446,36 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 	// Set once here so it survives the timeout-driven command recreation above.
 	if len(options.Stdin) > 0 {
 		cmd.Stdin = bytes.NewReader(options.Stdin)
+	} else if options.StdinReader != nil {
+		cmd.Stdin = options.StdinReader
+	}
+
+	// Capture stdout and stderr into our own buffers (rather than cmd.Output)
+	// so an OnOutput callback can tee them and see each line as it is written.
+	var stdout, stderr bytes.Buffer
+	var stdoutSink io.Writer = &stdout
+	if options.StdoutWriter != nil {
+		stdoutSink = options.StdoutWriter
+	}
+	cmd.Stdout, cmd.Stderr = stdoutSink, &stderr
+	var streams []*lineWriter
+	if options.OnOutput != nil {
+		streamer := &lineStreamer{fn: options.OnOutput}
+		outLines, errLines := streamer.writer(), streamer.writer()
+		streams = []*lineWriter{outLines, errLines}
+		cmd.Stdout = io.MultiWriter(stdoutSink, outLines)
+		cmd.Stderr = io.MultiWriter(&stderr, errLines)
 	}
 
 	// Execute the command
-	stdout, err := cmd.Output()
+	err := cmd.Run()
+	for _, w := range streams {
+		w.flush()
+	}
 	result.Duration = time.Since(start)
-	result.Stdout = string(stdout)
+	result.Stdout = stdout.String()
+	// Reported on return, once the exit code below is set.
+	defer func() { observeCommand(options.Command, fullCommand, start, result, err) }()
 
 	if err != nil {
 		var exitError *exec.ExitError
@@ -398,7 +486,7 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 			// manager's "Helm output: %s"), and a child process can echo a
 			// token back. Control-flow substring checks downstream match
 			// generic phrases, never secret values, so redaction is safe here.
-			result.Stderr = redact.Redact(string(exitError.Stderr))
+			result.Stderr = redact.Redact(stderr.String())
 		} else {
 			result.ExitCode = -1
 		}
@@ -418,7 +506,8 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 		// above, these errors reach user-facing output through the error handler
 		// even in non-verbose mode, so a secret in argv or echoed back on stderr
 		// (e.g. a URL-embedded token) must never survive into them (audit B5).
-		if runtime.GOOS == "windows" && (command == "wsl" || options.Command == "helm" || options.Command == "k3d") {
+		// helm/k3d only go through WSL when not in Docker Desktop native mode.
+		if runtime.GOOS == "windows" && (command == "wsl" || platform.UsesWSL() && (options.Command == "helm" || options.Command == "k3d")) {
 			// For WSL commands, stderr is often redirected to stdout via 2>&1
 			// Use stdout as error output if stderr is empty
 			errorOutput := result.Stderr
@@ -1,7 +1,9 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -211,6 +213,21 @@ func TestRealCommandExecutor_ExecuteWithOptions_WithEnv(t *testing.T) {
 	assert.Contains(t, result.Stdout, "test_value")
 }
 
+func TestRealCommandExecutor_ExecuteWithOptions_Streams(t *testing.T) {
+	executor := NewRealCommandExecutor(false, false)
+
+	var out bytes.Buffer
+	result, err := executor.ExecuteWithOptions(context.Background(), ExecuteOptions{
+		Command:      "cat",
+		StdinReader:  strings.NewReader("streamed"),
+		StdoutWriter: &out,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "streamed", out.String())
+	assert.Equal(t, "", result.Stdout, "streamed stdout is not also buffered")
+}
+
 func TestRealCommandExecutor_ExecuteWithOptions_FailingCommand(t *testing.T) {
 	executor := NewRealCommandExecutor(false, true) // Enable verbose for error coverage
 
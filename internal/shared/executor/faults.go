@@ -0,0 +1,204 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pterm/pterm"
+)
+
+// FaultsEnv makes commands fail the way a broken host makes them fail, so the
+// recovery paths — a WSL distribution that vanished, a resolver that stops
+// answering, a Docker daemon that is down — can be driven by tests and on a
+// healthy machine. It is a comma-separated list of kind[:target[:count]]:
+//
+//	OPENFRAME_FAULTS=wsl-exit:kubectl:2,docker-down
+//
+// target is a command name (also matched inside `wsl ... <command>`),
+// FaultTargetKubeAPI or FaultTargetWSL; empty or * matches everything. count
+// is how many matching calls fail before the fault clears; without it every
+// call fails. Nothing is injected unless the variable is set.
+const FaultsEnv = "OPENFRAME_FAULTS"
+
+// Fault kinds.
+const (
+	// FaultWSLExit fails with exit code 4294967295, as wsl.exe does when the
+	// distribution is gone.
+	FaultWSLExit = "wsl-exit"
+	// FaultDNSTimeout fails resolving the cluster's host.
+	FaultDNSTimeout = "dns-timeout"
+	// FaultDockerDown fails reaching the Docker daemon.
+	FaultDockerDown = "docker-down"
+)
+
+// Fault targets besides command names: the Kubernetes API, which the CLI
+// reaches in-process with client-go, and the wsl.exe calls of TryRecoverWSL,
+// which bypass the executor.
+const (
+	FaultTargetKubeAPI = "kube-api"
+	FaultTargetWSL     = "wsl-recovery"
+)
+
+// faultStderr is what each kind of failure prints.
+var faultStderr = map[string]string{
+	FaultWSLExit:    "There is no distribution with the supplied name.",
+	FaultDNSTimeout: "Unable to connect to the server: dial tcp: lookup host.docker.internal: i/o timeout",
+	FaultDockerDown: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?",
+}
+
+type fault struct {
+	kind   string
+	target string
+	left   int // calls left to fail; negative is every call
+}
+
+var (
+	faultsMu   sync.Mutex
+	faultsSpec string
+	faults     []*fault
+)
+
+// parseFaults parses a FaultsEnv value; entries it cannot read are returned as
+// errors and left out.
+func parseFaults(spec string) ([]*fault, []error) {
+	var out []*fault
+	var errs []error
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		f := &fault{kind: parts[0], left: -1}
+		if _, ok := faultStderr[f.kind]; !ok {
+			errs = append(errs, fmt.Errorf("%q: unknown fault %q (want %s, %s or %s)", entry, f.kind, FaultWSLExit, FaultDNSTimeout, FaultDockerDown))
+			continue
+		}
+		if len(parts) > 1 && parts[1] != "*" {
+			f.target = parts[1]
+		}
+		if len(parts) > 2 {
+			n, err := strconv.Atoi(parts[2])
+			if err != nil || n < 1 {
+				errs = append(errs, fmt.Errorf("%q: the count must be a positive number", entry))
+				continue
+			}
+			f.left = n
+		}
+		out = append(out, f)
+	}
+	return out, errs
+}
+
+// takeFault returns the fault the next call to one of targets hits, using up
+// one of its calls, or nil.
+func takeFault(targets ...string) *fault {
+	if os.Getenv(FaultsEnv) == "" {
+		return nil
+	}
+	faultsMu.Lock()
+	defer faultsMu.Unlock()
+	loadFaultsLocked()
+	for _, f := range faults {
+		if f.left == 0 || (f.target != "" && !slices.Contains(targets, f.target)) {
+			continue
+		}
+		if f.left > 0 {
+			f.left--
+		}
+		return f
+	}
+	return nil
+}
+
+// faultAimedAt reports whether a fault names target itself, without using it
+// up.
+func faultAimedAt(target string) bool {
+	if os.Getenv(FaultsEnv) == "" {
+		return false
+	}
+	faultsMu.Lock()
+	defer faultsMu.Unlock()
+	loadFaultsLocked()
+	for _, f := range faults {
+		if f.target == target && f.left != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// loadFaultsLocked parses FaultsEnv again when it changed, which restarts the
+// faults' counts. faultsMu is held.
+func loadFaultsLocked() {
+	spec := os.Getenv(FaultsEnv)
+	if spec == faultsSpec {
+		return
+	}
+	var errs []error
+	faults, errs = parseFaults(spec)
+	faultsSpec = spec
+	if len(errs) > 0 {
+		pterm.Warning.Printf("Ignoring part of %s: %v\n", FaultsEnv, errors.Join(errs...))
+	}
+}
+
+// commandFault is the injected failure of a command about to run, if any:
+// the result and error the executor would return had it failed for real.
+func commandFault(options ExecuteOptions, fullCommand string) (*CommandResult, error) {
+	name := toolName(options.Command)
+	targets := []string{name}
+	if name == "wsl" {
+		if inner, _, ok := wslCommand(options.Args); ok {
+			targets = append(targets, toolName(inner))
+		}
+	}
+	f := takeFault(targets...)
+	if f == nil {
+		return nil, nil
+	}
+	if f.kind == FaultWSLExit {
+		result := &CommandResult{ExitCode: WSLExitCodeDistroNotFound, Stderr: faultStderr[f.kind]}
+		return result, &WSLError{
+			Operation:  fmt.Sprintf("executing %s", options.Command),
+			ExitCode:   result.ExitCode,
+			Stderr:     result.Stderr,
+			Suggestion: GetWSLErrorSuggestion(result.ExitCode, fullCommand),
+		}
+	}
+	result := &CommandResult{ExitCode: 1, Stderr: faultStderr[f.kind]}
+	return result, NewCommandError(fullCommand, result.ExitCode, result.Stderr)
+}
+
+// InjectedFault returns the injected failure of the next call to target, for
+// calls that do not go through the executor (FaultTargetKubeAPI,
+// FaultTargetWSL), or nil.
+func InjectedFault(target string) error {
+	f := takeFault(target)
+	if f == nil {
+		return nil
+	}
+	if f.kind == FaultWSLExit {
+		return &WSLError{
+			Operation:  fmt.Sprintf("reaching %s", target),
+			ExitCode:   WSLExitCodeDistroNotFound,
+			Stderr:     faultStderr[f.kind],
+			Suggestion: GetWSLErrorSuggestion(WSLExitCodeDistroNotFound, target),
+		}
+	}
+	if f.kind == FaultDockerDown && target == FaultTargetKubeAPI {
+		// With Docker down nothing listens on the cluster's API port.
+		return errors.New("dial tcp 127.0.0.1:6550: connect: connection refused")
+	}
+	return errors.New(faultStderr[f.kind])
+}
+
+// toolName is a command's name without its directory or .exe.
+func toolName(command string) string {
+	return strings.TrimSuffix(command[strings.LastIndexAny(command, `/\`)+1:], ".exe")
+}
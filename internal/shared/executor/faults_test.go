@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"context"
+	stderrors "errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaults(t *testing.T) {
+	faults, errs := parseFaults("wsl-exit:kubectl:2, docker-down ,dns-timeout:*,flood:k3d,docker-down:k3d:0")
+	require.Len(t, faults, 3)
+	assert.Equal(t, fault{kind: FaultWSLExit, target: "kubectl", left: 2}, *faults[0])
+	assert.Equal(t, fault{kind: FaultDockerDown, left: -1}, *faults[1])
+	assert.Equal(t, fault{kind: FaultDNSTimeout, left: -1}, *faults[2], "* is every target")
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], `unknown fault "flood"`)
+	assert.ErrorContains(t, errs[1], "the count must be a positive number")
+}
+
+// TestRealExecutor_InjectsFaults guards what the faults are for: a faulted
+// command fails with the error a broken host gives, and a counted fault
+// clears, so a test can watch the recovery succeed.
+func TestRealExecutor_InjectsFaults(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("runs the true binary")
+	}
+	t.Setenv(FaultsEnv, "docker-down:true:1,wsl-exit:kubectl")
+	e := NewRealCommandExecutor(false, false)
+	ctx := context.Background()
+
+	result, err := e.Execute(ctx, "true")
+	var cmdErr *CommandError
+	require.True(t, stderrors.As(err, &cmdErr), "got %v", err)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, cmdErr.Error(), "Is the docker daemon running?")
+
+	_, err = e.Execute(ctx, "true")
+	assert.NoError(t, err, "the fault was for one call")
+
+	result, err = e.Execute(ctx, "wsl", "-d", "Ubuntu", "--", "kubectl", "get", "pods")
+	var wslErr *WSLError
+	require.True(t, stderrors.As(err, &wslErr), "a command run through wsl is matched by its own name; got %v", err)
+	assert.Equal(t, WSLExitCodeDistroNotFound, result.ExitCode)
+	assert.Contains(t, wslErr.Suggestion, "wsl --terminate Ubuntu")
+}
+
+func TestInjectedFault(t *testing.T) {
+	assert.NoError(t, InjectedFault(FaultTargetKubeAPI), "nothing is injected without the variable")
+
+	t.Setenv(FaultsEnv, "dns-timeout:kube-api:1")
+	assert.ErrorContains(t, InjectedFault(FaultTargetKubeAPI), "lookup host.docker.internal: i/o timeout")
+	assert.NoError(t, InjectedFault(FaultTargetKubeAPI))
+
+	t.Setenv(FaultsEnv, "dns-timeout:kube-api:1 ")
+	assert.Error(t, InjectedFault(FaultTargetKubeAPI), "a changed variable starts the counts again")
+}
+
+func TestTryRecoverWSL_Faults(t *testing.T) {
+	orig := wslRecoverySettle
+	wslRecoverySettle = 0
+	t.Cleanup(func() { wslRecoverySettle = orig })
+
+	t.Setenv(FaultsEnv, "wsl-exit:wsl-recovery")
+	result, err := commandFault(ExecuteOptions{Command: "wsl", Args: []string{"--status"}}, "wsl --status")
+	assert.Nil(t, result, "the recovery's target is not the wsl command")
+	assert.NoError(t, err)
+
+	err = TryRecoverWSL()
+	var wslErr *WSLError
+	require.True(t, stderrors.As(err, &wslErr), "got %v", err)
+	assert.ErrorContains(t, err, "WSL recovery failed - could not restart the distribution")
+
+	if runtime.GOOS != "windows" {
+		t.Setenv(FaultsEnv, "docker-down:kubectl")
+		assert.NoError(t, TryRecoverWSL(), "off Windows only a fault aimed at the recovery runs it")
+	}
+}
@@ -121,6 +121,7 @@ func (m *MockCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 		if strings.Contains(fullCommand, pattern) {
 			result := *response // Copy the response
 			result.Duration = time.Since(start)
+			replayOutput(options, &result)
 			if result.ExitCode != 0 {
 				return &result, fmt.Errorf("mock command failed with exit code %d", result.ExitCode)
 			}
@@ -131,10 +132,26 @@ func (m *MockCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 	// Return default result
 	result := *m.defaultResult // Copy the default result
 	result.Duration = time.Since(start)
+	replayOutput(options, &result)
 
 	return &result, nil
 }
 
+// replayOutput feeds a canned result's stdout then stderr, line by line, to
+// the OnOutput callback, as the real executor would have streamed them.
+func replayOutput(options ExecuteOptions, result *CommandResult) {
+	if options.OnOutput == nil {
+		return
+	}
+	for _, text := range []string{result.Stdout, result.Stderr} {
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				options.OnOutput(line)
+			}
+		}
+	}
+}
+
 // copyEnv returns a defensive copy of an env map (nil-safe).
 func copyEnv(env map[string]string) map[string]string {
 	if env == nil {
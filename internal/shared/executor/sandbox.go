@@ -0,0 +1,246 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+)
+
+// SandboxEnv sets the sandbox mode like --sandbox does, for automation that
+// cannot pass flags through.
+const SandboxEnv = "OPENFRAME_SANDBOX"
+
+// Sandbox modes: off runs anything, log warns about commands outside the
+// allowlist and runs them, enforce refuses them.
+//
+// The sandbox and --audit see only what runs through RealCommandExecutor.
+// A few host actions still start their process directly and are not covered:
+// installing prerequisites (package managers, mkcert, starting Docker),
+// sudo's password check, launching the CLI inside WSL, restarting WSL after a
+// .wslconfig change, plugins and restarting Docker Desktop.
+const (
+	SandboxOff     = "off"
+	SandboxLog     = "log"
+	SandboxEnforce = "enforce"
+)
+
+// sandboxAllowed are the binaries the executor may run in a sandbox: the
+// tools the stack is driven with and the few host helpers the CLI calls
+// (kernel limits, desktop notifications, path conversion in WSL, PowerShell
+// for the Windows host checks that must not depend on WSL, the host firewall
+// tools, the OS keychain tools, reading and resetting the WSL clock, echo for
+// the WSL liveness probe, the browser openers, pgrep for Docker Desktop's
+// exit). bash, sudo, tee and wsl are not listed: argvViolation checks them
+// itself. bash only runs the CLI's own scripts, fed on stdin (see
+// ShellScript); tee only writes teeTargets; sudo and wsl only run what they
+// wrap, which is checked in turn.
+var sandboxAllowed = []string{
+	"k3d", "kubectl", "helm", "docker", "kind", "minikube",
+	"sysctl", "wslpath", "osascript", "notify-send", "powershell",
+	"firewall-cmd", "iptables", "security", "secret-tool",
+	"hwclock", "date", "echo",
+	"open", "xdg-open", "wslview", "explorer.exe", "pgrep",
+}
+
+// teeTargets are the only files tee may write: the sysctl drop-in
+// (sysctl.PersistFile) the kernel limits are persisted in.
+var teeTargets = []string{"/etc/sysctl.d/99-openframe.conf"}
+
+func parseSandboxMode(v string) (string, error) {
+	switch v {
+	case SandboxOff, SandboxLog, SandboxEnforce:
+		return v, nil
+	}
+	return "", fmt.Errorf("must be %s, %s or %s", SandboxOff, SandboxLog, SandboxEnforce)
+}
+
+// sandboxValue implements pflag.Value so an unknown mode fails flag parsing.
+type sandboxValue struct{}
+
+func (sandboxValue) String() string {
+	if sandboxFlag == "" {
+		return SandboxOff
+	}
+	return sandboxFlag
+}
+func (sandboxValue) Type() string { return "string" }
+
+func (sandboxValue) Set(v string) error {
+	mode, err := parseSandboxMode(v)
+	if err != nil {
+		return err
+	}
+	sandboxFlag = mode
+	return nil
+}
+
+// sandboxFlag and auditFlag back the global --sandbox and --audit flags (see
+// BindSandboxFlags); sandboxFlag is "" until the flag is given.
+var (
+	sandboxFlag string
+	auditFlag   bool
+)
+
+// auditMu serializes the --audit prompts of commands run concurrently.
+var auditMu sync.Mutex
+
+// BindSandboxFlags registers --sandbox and --audit on fs. Like --no-sudo they
+// are read on every command, so command groups that shadow the root's
+// PersistentPreRunE still honor them.
+func BindSandboxFlags(fs *pflag.FlagSet) {
+	fs.Var(sandboxValue{}, "sandbox", "Restrict the commands OpenFrame runs through its executor to its known tools: off, log (warn about others) or enforce (refuse them); prerequisite installs, plugins and the WSL launcher are not covered")
+	fs.BoolVar(&auditFlag, "audit", false, "Print every command run through the executor before it runs and ask for confirmation")
+}
+
+// SandboxMode is the sandbox mode in effect: --sandbox when given, else
+// OPENFRAME_SANDBOX, else off.
+func SandboxMode() string {
+	if sandboxFlag != "" {
+		return sandboxFlag
+	}
+	if mode, err := parseSandboxMode(os.Getenv(SandboxEnv)); err == nil {
+		return mode
+	}
+	return SandboxOff
+}
+
+// SandboxError is returned for a command the sandbox refused.
+type SandboxError struct {
+	Command string
+	Reason  string
+}
+
+func (e *SandboxError) Error() string {
+	return fmt.Sprintf("sandbox refused %q: %s (--sandbox log runs it with a warning)", e.Command, e.Reason)
+}
+
+// sandboxViolation says why options falls outside the allowlist, or "".
+func sandboxViolation(options ExecuteOptions) string {
+	return argvViolation(options.Command, options.Args, options)
+}
+
+func argvViolation(command string, args []string, options ExecuteOptions) string {
+	// Windows paths too: the CLI may be running k3d.exe from a Windows host.
+	name := toolName(command)
+	switch name {
+	case "bash":
+		// Only a script ShellScript piped on stdin, never one spliced into -c.
+		if !options.ownScript || len(args) == 0 || args[0] != "-s" {
+			return "bash only runs the CLI's own scripts on stdin"
+		}
+		return ""
+	case "sudo":
+		inner, ok := sudoCommand(args)
+		if !ok {
+			return "sudo without a command"
+		}
+		return argvViolation(args[inner], args[inner+1:], options)
+	case "tee":
+		// tee runs under sudo: anything but the CLI's own files would be an
+		// arbitrary write as root.
+		for _, a := range args {
+			if !strings.HasPrefix(a, "-") && !slices.Contains(teeTargets, a) {
+				return fmt.Sprintf("tee only writes %s", strings.Join(teeTargets, ", "))
+			}
+		}
+		return ""
+	case "wsl":
+		if inner, rest, ok := wslCommand(args); ok {
+			return argvViolation(inner, rest, options)
+		}
+		return "" // a wsl management command (--status, --shutdown, ...)
+	}
+	if !slices.Contains(sandboxAllowed, name) {
+		return fmt.Sprintf("%s is not one of the tools OpenFrame uses", name)
+	}
+	return ""
+}
+
+// sudoOptionsWithValue are the sudo options whose value is the next argument.
+var sudoOptionsWithValue = []string{
+	"-u", "--user", "-g", "--group", "-C", "--close-from", "-D", "--chdir",
+	"-h", "--host", "-p", "--prompt", "-R", "--chroot", "-r", "--role",
+	"-t", "--type", "-T", "--command-timeout", "-U", "--other-user",
+}
+
+// sudoCommand returns the index of the command a sudo invocation runs: the
+// first argument past sudo's options and their values, or past "--".
+func sudoCommand(args []string) (int, bool) {
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "--":
+			return i + 1, i+1 < len(args)
+		case slices.Contains(sudoOptionsWithValue, a):
+			i++
+		case strings.HasPrefix(a, "-"):
+		default:
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// wslCommand finds the command a wsl invocation runs in the distribution:
+// after "--" or --exec, or the first argument past the distribution and user
+// options. ok is false for the management commands, which run nothing there.
+func wslCommand(args []string) (command string, rest []string, ok bool) {
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "--" || a == "-e" || a == "--exec":
+			if i+1 < len(args) {
+				return args[i+1], args[i+2:], true
+			}
+			return "", nil, false
+		case a == "-d" || a == "--distribution" || a == "-u" || a == "--user" || a == "--cd":
+			i++
+		case strings.HasPrefix(a, "-"):
+			return "", nil, false
+		default:
+			return a, args[i+1:], true
+		}
+	}
+	return "", nil, false
+}
+
+// checkSandbox applies the sandbox mode to options before it runs.
+func checkSandbox(options ExecuteOptions, fullCommand string) error {
+	mode := SandboxMode()
+	if mode == SandboxOff {
+		return nil
+	}
+	reason := sandboxViolation(options)
+	if reason == "" {
+		return nil
+	}
+	if mode == SandboxEnforce {
+		return &SandboxError{Command: redact.Redact(fullCommand), Reason: reason}
+	}
+	pterm.Warning.Printf("Sandbox: running %s (%s)\n", redact.Redact(fullCommand), reason)
+	return nil
+}
+
+// confirmAudit prints the command under --audit and asks before running it.
+// A non-interactive session cannot confirm, so nothing runs there.
+func confirmAudit(fullCommand string) error {
+	if !auditFlag {
+		return nil
+	}
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	pterm.Info.Printf("Audit: %s\n", redact.Redact(fullCommand))
+	ok, err := ui.RequireConfirmation("Run this command?", "without --audit", false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("command declined: %s", redact.Redact(fullCommand))
+	}
+	return nil
+}
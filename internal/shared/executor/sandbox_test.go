@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withSandbox sets --sandbox for the test.
+func withSandbox(t *testing.T, mode string) {
+	t.Helper()
+	orig := sandboxFlag
+	sandboxFlag = mode
+	t.Cleanup(func() { sandboxFlag = orig })
+}
+
+func TestSandboxViolation(t *testing.T) {
+	script := []byte("echo hi\n")
+	tests := []struct {
+		name    string
+		options ExecuteOptions
+		allowed bool
+	}{
+		{"known tool", ExecuteOptions{Command: "helm", Args: []string{"list"}}, true},
+		{"windows binary", ExecuteOptions{Command: `C:\tools\k3d.exe`, Args: []string{"version"}}, true},
+		{"unknown tool", ExecuteOptions{Command: "curl", Args: []string{"https://example.com"}}, false},
+		{"bash script on stdin", ShellScript("echo hi"), true},
+		{"bash -c", ExecuteOptions{Command: "bash", Args: []string{"-c", "rm -rf ~"}}, false},
+		{"bash -s without a script", ExecuteOptions{Command: "bash", Args: []string{"-s"}}, false},
+		{"bash -s with another script", ExecuteOptions{Command: "bash", Args: []string{"-s"}, Stdin: script}, false},
+		{"sh", ExecuteOptions{Command: "sh", Args: []string{"-c", "id"}, Stdin: script}, false},
+		{"sudo known tool", ExecuteOptions{Command: "sudo", Args: []string{"-n", "tee", "/etc/sysctl.d/99-openframe.conf"}}, true},
+		{"sudo firewall tool", ExecuteOptions{Command: "sudo", Args: []string{"-n", "firewall-cmd", "--permanent", "--add-port=443/tcp"}}, true},
+		{"macOS keychain", ExecuteOptions{Command: "security", Args: []string{"-i"}, Stdin: script}, true},
+		{"Secret Service", ExecuteOptions{Command: "secret-tool", Args: []string{"lookup", "service", "openframe"}}, true},
+		{"sudo as a user", ExecuteOptions{Command: "sudo", Args: []string{"-u", "k3d", "kubectl", "get", "pods"}}, true},
+		{"sudo user is not the command", ExecuteOptions{Command: "sudo", Args: []string{"-u", "helm", "rm", "-rf", "/"}}, false},
+		{"sudo after --", ExecuteOptions{Command: "sudo", Args: []string{"-n", "--", "sysctl", "-w", "a=1"}}, true},
+		{"sudo tee elsewhere", ExecuteOptions{Command: "sudo", Args: []string{"-n", "tee", "-a", "/etc/sudoers"}}, false},
+		{"sudo unknown tool", ExecuteOptions{Command: "sudo", Args: []string{"-n", "rm", "-rf", "/"}}, false},
+		{"wsl script", WSLShellScript([]string{"-d", "Ubuntu", "-u", "root"}, "echo hi"), true},
+		{"wsl inner command", ExecuteOptions{Command: "wsl", Args: []string{"-d", "docker-desktop", "sysctl", "-w", "a=1"}}, true},
+		{"wsl after --", ExecuteOptions{Command: "wsl", Args: []string{"-d", "Ubuntu", "--", "wslpath", "-a", "-u", "C:/x"}}, true},
+		{"wsl unknown inner", ExecuteOptions{Command: "wsl", Args: []string{"-d", "Ubuntu", "--", "curl", "x"}}, false},
+		{"wsl clock", ExecuteOptions{Command: "wsl", Args: []string{"-d", "Ubuntu", "--", "date", "+%s%N"}}, true},
+		{"wsl clock reset", ExecuteOptions{Command: "wsl", Args: []string{"-d", "Ubuntu", "-u", "root", "--", "hwclock", "-s"}}, true},
+		{"sudo clock reset", ExecuteOptions{Command: "sudo", Args: []string{"-n", "hwclock", "-s"}}, true},
+		{"wsl recovery probe", ExecuteOptions{Command: "wsl", Args: []string{"-d", "Ubuntu", "echo", "recovered"}}, true},
+		{"wsl management", ExecuteOptions{Command: "wsl", Args: []string{"--shutdown"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := sandboxViolation(tt.options)
+			if tt.allowed {
+				assert.Empty(t, reason)
+			} else {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestSandboxMode(t *testing.T) {
+	withSandbox(t, "")
+	t.Setenv(SandboxEnv, "")
+	assert.Equal(t, SandboxOff, SandboxMode())
+
+	t.Setenv(SandboxEnv, "enforce")
+	assert.Equal(t, SandboxEnforce, SandboxMode())
+	t.Setenv(SandboxEnv, "bogus")
+	assert.Equal(t, SandboxOff, SandboxMode(), "an unknown value is ignored")
+
+	t.Setenv(SandboxEnv, "enforce")
+	require.NoError(t, sandboxValue{}.Set("off"))
+	assert.Equal(t, SandboxOff, SandboxMode(), "the flag wins over the environment")
+	assert.Error(t, sandboxValue{}.Set("strict"))
+}
+
+func TestRealExecutor_SandboxEnforce(t *testing.T) {
+	withSandbox(t, SandboxEnforce)
+	exec := NewRealCommandExecutor(false, false)
+
+	_, err := exec.Execute(context.Background(), "sh", "-c", "echo escaped")
+	var sandboxErr *SandboxError
+	require.True(t, stderrors.As(err, &sandboxErr))
+	assert.Equal(t, "sh -c echo escaped", sandboxErr.Command)
+
+	result, err := exec.ExecuteWithOptions(context.Background(), ShellScript("echo allowed"))
+	require.NoError(t, err)
+	assert.Equal(t, "allowed\n", result.Stdout)
+}
+
+func TestRealExecutor_SandboxLogRuns(t *testing.T) {
+	withSandbox(t, SandboxLog)
+	result, err := NewRealCommandExecutor(false, false).Execute(context.Background(), "sh", "-c", "echo ran")
+	require.NoError(t, err)
+	assert.Equal(t, "ran\n", result.Stdout)
+}
+
+func TestConfirmAudit_NonInteractiveRefuses(t *testing.T) {
+	orig := auditFlag
+	auditFlag = true
+	t.Cleanup(func() { auditFlag = orig })
+	t.Setenv("CI", "true")
+
+	_, err := NewRealCommandExecutor(false, false).Execute(context.Background(), "helm", "version")
+	assert.ErrorContains(t, err, "without --audit")
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"os"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
+)
+
+// WSLDistroEnv overrides which WSL distribution OpenFrame uses. When unset,
+// the WSL *default* distribution is used (no `-d` flag). Hardcoding a name
+// like "Ubuntu" breaks on hosts whose distro is registered as "Ubuntu-24.04",
+// "Ubuntu-22.04", etc. (WSL_E_DISTRO_NOT_FOUND).
+const WSLDistroEnv = "OPENFRAME_WSL_DISTRO"
+
+// WSLDistroArgs returns the `-d <distro>` selector when OPENFRAME_WSL_DISTRO
+// is set, else nil so the WSL default distribution is targeted.
+func WSLDistroArgs() []string {
+	if d := strings.TrimSpace(os.Getenv(WSLDistroEnv)); d != "" {
+		return []string{"-d", d}
+	}
+	return nil
+}
+
+// ShellScript returns options that run script with `bash -s`, piping it on
+// stdin rather than passing it as a `bash -c` argument. args become the
+// script's positional parameters ($1, $2, ...), so values never need to be
+// quoted into the script text, and the script's size is not bounded by the
+// command-line limit.
+func ShellScript(script string, args ...string) ExecuteOptions {
+	return ExecuteOptions{
+		Command:   "bash",
+		Args:      append([]string{"-s", "--"}, args...),
+		Stdin:     []byte(script),
+		ownScript: true,
+	}
+}
+
+// WSLShellScript is ShellScript inside WSL: `wsl <wslArgs> -- bash -s`. wslArgs
+// select the distribution and user (e.g. WSLDistroArgs() and "-u", "root").
+// wsl.exe re-joins its argv into one command line for the Linux side, so a
+// multi-line or quote-heavy `bash -c` script is mangled on the way in; stdin
+// passes it through, and the script is wrapped with its checksum so one that
+// arrives altered all the same does not run.
+func WSLShellScript(wslArgs []string, script string, args ...string) ExecuteOptions {
+	opts := ShellScript(scripts.WithChecksum(script), args...)
+	opts.Args = append(append(append([]string{}, wslArgs...), "--", opts.Command), opts.Args...)
+	opts.Command = "wsl"
+	return opts
+}
@@ -0,0 +1,31 @@
+package executor
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellScript_PipesScriptAndPassesArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses bash")
+	}
+	// The argument carries quotes and a command substitution: as a positional
+	// parameter it stays literal, which a spliced `bash -c` string would not.
+	script := "set -e\nprintf '%s|%s' \"$1\" \"$2\"\n"
+	res, err := NewRealCommandExecutor(false, false).ExecuteWithOptions(context.Background(),
+		ShellScript(script, `it's $(whoami)`, "two"))
+	require.NoError(t, err)
+	assert.Equal(t, `it's $(whoami)|two`, res.Stdout)
+}
+
+func TestWSLShellScript_Argv(t *testing.T) {
+	opts := WSLShellScript([]string{"-d", "Ubuntu", "-u", "root"}, "echo ok\n", "a")
+	assert.Equal(t, "wsl", opts.Command)
+	assert.Equal(t, []string{"-d", "Ubuntu", "-u", "root", "--", "bash", "-s", "--", "a"}, opts.Args)
+	assert.Equal(t, scripts.WithChecksum("echo ok\n"), string(opts.Stdin), "guarded against changes in transit")
+}
@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+)
+
+// contextKey is used for context values
+type contextKey string
+
+const progressKey contextKey = "progress"
+
+// WithProgress returns a context carrying fn as the progress sink for the
+// long-running commands started under it. The layer that owns the spinner sets
+// it; providers read it with ProgressFrom and pass it as OnOutput, so neither
+// has to know about the other.
+func WithProgress(ctx context.Context, fn func(line string)) context.Context {
+	return context.WithValue(ctx, progressKey, fn)
+}
+
+// ProgressFrom returns the progress sink carried by ctx, or nil.
+func ProgressFrom(ctx context.Context) func(line string) {
+	fn, _ := ctx.Value(progressKey).(func(line string))
+	return fn
+}
+
+// lineStreamer turns the child's stdout and stderr into redacted lines for an
+// ExecuteOptions.OnOutput callback. os/exec copies each pipe on its own
+// goroutine, so the two writers share one mutex: the callback never runs
+// concurrently with itself.
+type lineStreamer struct {
+	mu sync.Mutex
+	fn func(line string)
+}
+
+// writer returns an io.Writer for one stream. Partial lines are buffered until
+// their newline (or a carriage return, which progress bars use to redraw).
+func (s *lineStreamer) writer() *lineWriter {
+	return &lineWriter{s: s}
+}
+
+type lineWriter struct {
+	s       *lineStreamer
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.s.mu.Lock()
+	defer w.s.mu.Unlock()
+
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexAny(data, "\r\n")
+		if i < 0 {
+			break
+		}
+		w.emit(data[:i])
+		data = data[i+1:]
+	}
+	w.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// flush emits a trailing line that had no newline. Called once the command
+// has exited and the copy goroutines are done.
+func (w *lineWriter) flush() {
+	w.s.mu.Lock()
+	defer w.s.mu.Unlock()
+	w.emit(w.partial)
+	w.partial = nil
+}
+
+func (w *lineWriter) emit(line []byte) {
+	if text := strings.TrimSpace(string(line)); text != "" {
+		w.s.fn(redact.Redact(text))
+	}
+}
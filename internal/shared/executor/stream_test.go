@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+)
+
+// TestExecuteWithOptions_OnOutputStreamsLines: both streams reach the callback
+// line by line (carriage-return redraws split too, a trailing partial line is
+// flushed), while the result still carries the full stdout.
+func TestExecuteWithOptions_OnOutputStreamsLines(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a unix shell")
+	}
+	exec := NewRealCommandExecutor(false, false)
+
+	var lines []string
+	res, err := exec.ExecuteWithOptions(context.Background(), ExecuteOptions{
+		Command:  "sh",
+		Args:     []string{"-c", `printf 'one\ntwo\n'; printf 'INFO[0001] progress 50%%\rINFO[0002] progress 100%%\n' >&2; printf tail`},
+		OnOutput: func(line string) { lines = append(lines, line) },
+	})
+	if err != nil {
+		t.Fatalf("streaming command: %v", err)
+	}
+	if res.Stdout != "one\ntwo\ntail" {
+		t.Errorf("stdout = %q, the full output must still be collected", res.Stdout)
+	}
+
+	want := map[string]bool{"one": true, "two": true, "INFO[0001] progress 50%": true, "INFO[0002] progress 100%": true, "tail": true}
+	if len(lines) != len(want) {
+		t.Fatalf("streamed lines = %q, want %d lines", lines, len(want))
+	}
+	for _, line := range lines {
+		if !want[line] {
+			t.Errorf("unexpected streamed line %q", line)
+		}
+	}
+}
+
+// TestExecuteWithOptions_OnOutputIsRedacted: streamed lines go through the same
+// redaction as CommandResult.Stderr — verbose relays print them verbatim.
+func TestExecuteWithOptions_OnOutputIsRedacted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a unix echo")
+	}
+	redact.ClearSecrets()
+	defer redact.ClearSecrets()
+	secret := "ghp_streamedSecret123"
+	redact.RegisterSecret(secret)
+
+	var lines []string
+	_, err := NewRealCommandExecutor(false, false).ExecuteWithOptions(context.Background(), ExecuteOptions{
+		Command:  "echo",
+		Args:     []string{"token=" + secret},
+		OnOutput: func(line string) { lines = append(lines, line) },
+	})
+	if err != nil {
+		t.Fatalf("echo: %v", err)
+	}
+	if len(lines) != 1 || strings.Contains(lines[0], secret) {
+		t.Fatalf("streamed line must be redacted, got %q", lines)
+	}
+}
+
+// TestExecuteWithOptions_FailureKeepsStderrWhenStreaming: teeing stderr must not
+// lose it from the CommandError.
+func TestExecuteWithOptions_FailureKeepsStderrWhenStreaming(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a unix shell")
+	}
+	var streamed []string
+	res, err := NewRealCommandExecutor(false, false).ExecuteWithOptions(context.Background(), ExecuteOptions{
+		Command:  "sh",
+		Args:     []string{"-c", "echo 'port 6550 already allocated' >&2; exit 3"},
+		OnOutput: func(line string) { streamed = append(streamed, line) },
+	})
+	if err == nil || res.ExitCode != 3 {
+		t.Fatalf("want exit 3 error, got code=%d err=%v", res.ExitCode, err)
+	}
+	if !strings.Contains(res.Stderr, "already allocated") || !strings.Contains(err.Error(), "already allocated") {
+		t.Errorf("stderr lost: result=%q err=%v", res.Stderr, err)
+	}
+	if len(streamed) != 1 {
+		t.Errorf("streamed = %q, want the single stderr line", streamed)
+	}
+}
@@ -0,0 +1,95 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long Lock waits for another process to release a lock.
+const lockTimeout = 10 * time.Second
+
+// lockStale is the age at which a lock is taken to be left behind by a killed
+// process. Its holder refreshes it every lockRefresh, so a slow holder does
+// not make it look stale. Overridden in tests.
+var (
+	lockStale   = 30 * time.Second
+	lockRefresh = 5 * time.Second
+)
+
+// Lock takes an exclusive lock on the state file at path by creating
+// path.lock, waiting for another process to release it, and returns the
+// function that releases it. Together with WriteAtomic it lets several CLI
+// processes read, change and write one state file without losing updates.
+func Lock(path string) (func(), error) {
+	lock := path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lock), 0o700); err != nil {
+		return nil, fmt.Errorf("creating state directory: %w", err)
+	}
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gosec // G304: state paths under ~/.openframe
+		if err == nil {
+			_ = f.Close()
+			done, stopped := make(chan struct{}), make(chan struct{})
+			go func() {
+				defer close(stopped)
+				refreshLock(lock, done)
+			}()
+			return func() {
+				close(done)
+				<-stopped
+				_ = os.Remove(lock)
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		if info, serr := os.Stat(lock); serr == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s; remove it if no other openframe is running", lock)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// refreshLock touches the lock at path every lockRefresh until done is
+// closed.
+func refreshLock(path string, done <-chan struct{}) {
+	ticker := time.NewTicker(lockRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			_ = os.Chtimes(path, now, now)
+		}
+	}
+}
+
+// WriteAtomic replaces path with data through a temp file and a rename, so a
+// reader never sees a half-written file.
+func WriteAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
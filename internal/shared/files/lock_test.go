@@ -0,0 +1,78 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock_HeldLockIsRefreshed(t *testing.T) {
+	origStale, origRefresh := lockStale, lockRefresh
+	lockStale, lockRefresh = 200*time.Millisecond, 20*time.Millisecond
+	t.Cleanup(func() { lockStale, lockRefresh = origStale, origRefresh })
+	state := filepath.Join(t.TempDir(), "state", "ports.json")
+	lock := state + ".lock"
+
+	unlock, err := Lock(state)
+	require.NoError(t, err)
+	time.Sleep(3 * lockStale)
+	info, err := os.Stat(lock)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(info.ModTime()), lockStale, "a lock held past the stale age is not taken as left behind")
+	unlock()
+	_, err = os.Stat(lock)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLock_RemovesStaleLock(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "ports.json")
+	lock := state + ".lock"
+	require.NoError(t, os.WriteFile(lock, nil, 0o600))
+	old := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(lock, old, old))
+
+	unlock, err := Lock(state)
+	require.NoError(t, err)
+	unlock()
+	_, err = os.Stat(lock)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLock_SerializesUpdates(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "counter")
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := Lock(state)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer unlock()
+			data, _ := os.ReadFile(state)
+			assert.NoError(t, WriteAtomic(state, append(data, 'x')))
+		}()
+	}
+	wg.Wait()
+	data, err := os.ReadFile(state)
+	require.NoError(t, err)
+	assert.Len(t, data, 10, "no update is lost")
+}
+
+func TestWriteAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "runs.json")
+	require.NoError(t, WriteAtomic(path, []byte("one")))
+	require.NoError(t, WriteAtomic(path, []byte("two")))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temp file is left behind")
+}
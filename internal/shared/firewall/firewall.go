@@ -0,0 +1,280 @@
+// Package firewall finds host firewalls that may stop other machines from
+// reaching the ports a local cluster publishes. k3d publishes them through
+// Docker, which DNATs the traffic to the load balancer container: it is
+// forwarded, not delivered to the host, so ufw and the iptables INPUT chain
+// never see it, and this machine reaches the ports on localhost whatever the
+// firewall says. What does apply is the DOCKER-USER chain, where Docker leaves
+// room for the admin's own rules (ufw-docker puts its rules there too), and,
+// with firewalld, the zone Docker's bridge interfaces are in. The package reads
+// those and names the commands that would open the missing ports; it never
+// applies them.
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// Firewalls Detect knows.
+const (
+	Firewalld = "firewalld"
+	IPTables  = "iptables"
+)
+
+// dockerInterface is the Docker bridge whose firewalld zone Docker adds its
+// other bridges, k3d's among them, to.
+const dockerInterface = "docker0"
+
+// Finding is a firewall that stops some of the wanted ports.
+type Finding struct {
+	Firewall string
+	// Zone is the firewalld zone of Docker's interfaces.
+	Zone string
+	// Ports are the wanted TCP ports its rules stop — all of them when the
+	// rules could not be read.
+	Ports []int
+	// Unreadable is set when the firewall is active but reading its rules
+	// needs root.
+	Unreadable bool
+}
+
+// Commands returns the commands, to run as root, that open f's ports. The
+// firewalld ones are permanent; an iptables rule lasts until the next reboot
+// or firewall reload unless the user saves it with their distribution's tool.
+func (f Finding) Commands() [][]string {
+	var out [][]string
+	for _, p := range f.Ports {
+		switch f.Firewall {
+		case Firewalld:
+			out = append(out, []string{"firewall-cmd", "--permanent", "--zone=" + f.Zone, fmt.Sprintf("--add-port=%d/tcp", p)})
+		case IPTables:
+			out = append(out, []string{"iptables", "-I", "DOCKER-USER", "-p", "tcp", "-m", "conntrack", "--ctorigdstport", strconv.Itoa(p), "-j", "RETURN"})
+		}
+	}
+	if f.Firewall == Firewalld && len(out) > 0 {
+		out = append(out, []string{"firewall-cmd", "--reload"})
+	}
+	return out
+}
+
+// Runner runs a command and returns its standard output.
+type Runner func(ctx context.Context, name string, args ...string) (string, error)
+
+// ExecRunner runs commands with ex, as the current user.
+func ExecRunner(ex executor.CommandExecutor) Runner {
+	return func(ctx context.Context, name string, args ...string) (string, error) {
+		result, err := ex.Execute(ctx, name, args...)
+		if err != nil {
+			return "", err
+		}
+		return result.Stdout, nil
+	}
+}
+
+// Detect returns the firewalls that stop other machines from reaching some
+// port in ports. Reading DOCKER-USER takes root: an unreadable chain is
+// assumed open rather than reported. A probe the sandbox refuses says nothing
+// about the firewall, so it is an error rather than a firewall taken to be
+// missing.
+func Detect(ctx context.Context, run Runner, ports []int) ([]Finding, error) {
+	var refused error
+	probe := func(ctx context.Context, name string, args ...string) (string, error) {
+		out, err := run(ctx, name, args...)
+		var sandboxErr *executor.SandboxError
+		if refused == nil && errors.As(err, &sandboxErr) {
+			refused = err
+		}
+		return out, err
+	}
+	var findings []Finding
+
+	if state, err := probe(ctx, "firewall-cmd", "--state"); err == nil && strings.TrimSpace(state) == "running" {
+		f := Finding{Firewall: Firewalld}
+		zone, err := probe(ctx, "firewall-cmd", "--get-zone-of-interface="+dockerInterface)
+		if err != nil || strings.TrimSpace(zone) == "" {
+			zone, err = probe(ctx, "firewall-cmd", "--get-default-zone")
+		}
+		f.Zone = strings.TrimSpace(zone)
+		if err != nil || f.Zone == "" {
+			f.Ports, f.Unreadable = ports, true
+		} else if all, err := probe(ctx, "firewall-cmd", "--zone="+f.Zone, "--list-all"); err != nil {
+			f.Ports, f.Unreadable = ports, true
+		} else {
+			f.Ports = missing(ports, firewalldZoneOpen(all))
+		}
+		if len(f.Ports) > 0 {
+			findings = append(findings, f)
+		}
+	}
+
+	if out, err := probe(ctx, "iptables", "-S", "DOCKER-USER"); err == nil {
+		if p := dockerUserBlocked(out, ports); len(p) > 0 {
+			findings = append(findings, Finding{Firewall: IPTables, Ports: p})
+		}
+	}
+
+	if refused != nil {
+		return nil, fmt.Errorf("cannot check the host firewall: %w", refused)
+	}
+	return findings, nil
+}
+
+// portSet answers whether a port is open; all means every port is.
+type portSet struct {
+	all    bool
+	ranges [][2]int
+}
+
+func (s *portSet) add(lo, hi int) { s.ranges = append(s.ranges, [2]int{lo, hi}) }
+
+func (s portSet) has(port int) bool {
+	if s.all {
+		return true
+	}
+	for _, r := range s.ranges {
+		if port >= r[0] && port <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// addSpec adds a port list such as "80", "80,443" or "8000:8100" (sep is the
+// range separator); it reports whether spec was one.
+func (s *portSet) addSpec(spec, sep string) bool {
+	for _, part := range strings.Split(spec, ",") {
+		lo, hi, isRange := strings.Cut(part, sep)
+		if !isRange {
+			hi = lo
+		}
+		l, err1 := strconv.Atoi(lo)
+		h, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		s.add(l, h)
+	}
+	return true
+}
+
+func missing(ports []int, open portSet) []int {
+	var out []int
+	for _, p := range ports {
+		if !open.has(p) {
+			out = append(out, p)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+// firewalldServices are the predefined firewalld services that open a port
+// the cluster publishes.
+var firewalldServices = map[string]int{"http": 80, "https": 443, "kube-apiserver": 6443}
+
+// firewalldZoneOpen reads `firewall-cmd --zone=Z --list-all`. A zone whose
+// target is ACCEPT, like the docker zone firewalld creates for Docker, opens
+// everything; otherwise its ports ("80/tcp 8000-8100/tcp") and services do.
+func firewalldZoneOpen(out string) portSet {
+	var open portSet
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "target":
+			open.all = open.all || strings.TrimSpace(value) == "ACCEPT"
+		case "ports":
+			for _, p := range strings.Fields(value) {
+				if spec, ok := strings.CutSuffix(p, "/tcp"); ok {
+					open.addSpec(spec, "-")
+				}
+			}
+		case "services":
+			for _, svc := range strings.Fields(value) {
+				if p, ok := firewalldServices[svc]; ok {
+					open.add(p, p)
+				}
+			}
+		}
+	}
+	return open
+}
+
+// dockerUserBlocked reads `iptables -S DOCKER-USER` and returns the ports a
+// DROP or REJECT rule stops before a RETURN or ACCEPT lets them through; the
+// chain ends by returning to Docker's own rules, which let them in. A drop
+// limited to an interface or a source still counts, since that usually singles
+// out the LAN; rules with other matches are not understood and are skipped.
+func dockerUserBlocked(out string, ports []int) []int {
+	decided := map[int]bool{} // port -> blocked
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 4 || f[0] != "-A" || f[1] != "DOCKER-USER" {
+			continue
+		}
+		target := ""
+		var dports portSet
+		narrowed, unknown := false, false
+		for i := 2; i < len(f); i++ {
+			switch f[i] {
+			case "-j":
+				if i+1 < len(f) {
+					target = f[i+1]
+				}
+				i = len(f)
+			case "--dport", "--dports", "--ctorigdstport":
+				if i+1 < len(f) && !dports.addSpec(f[i+1], ":") {
+					unknown = true
+				}
+				i++
+			case "-i", "-s":
+				narrowed = true
+				i++
+			case "-p":
+				if i+1 < len(f) && f[i+1] != "tcp" {
+					unknown = true
+				}
+				i++
+			case "-m":
+				i++
+			default:
+				unknown = true
+			}
+		}
+		if unknown {
+			continue
+		}
+		if len(dports.ranges) == 0 {
+			dports.all = true
+		}
+		for _, p := range ports {
+			if _, done := decided[p]; done || !dports.has(p) {
+				continue
+			}
+			switch target {
+			case "DROP", "REJECT":
+				decided[p] = true
+			case "RETURN", "ACCEPT":
+				if !narrowed {
+					decided[p] = false
+				}
+			}
+		}
+	}
+	var blocked []int
+	for _, p := range ports {
+		if decided[p] {
+			blocked = append(blocked, p)
+		}
+	}
+	sort.Ints(blocked)
+	return blocked
+}
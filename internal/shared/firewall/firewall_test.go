@@ -0,0 +1,100 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHost answers commands from outputs keyed by "name args"; anything else
+// fails as if the tool were missing or needed root.
+func fakeHost(outputs map[string]string) Runner {
+	return func(_ context.Context, name string, args ...string) (string, error) {
+		if out, ok := outputs[strings.Join(append([]string{name}, args...), " ")]; ok {
+			return out, nil
+		}
+		return "", errors.New("exit status 1")
+	}
+}
+
+// detect runs Detect and requires it to succeed.
+func detect(t *testing.T, run Runner, ports []int) []Finding {
+	t.Helper()
+	findings, err := Detect(context.Background(), run, ports)
+	require.NoError(t, err)
+	return findings
+}
+
+func TestDetect_FirewalldReadsTheDockerZone(t *testing.T) {
+	public := `public (active)
+  target: default
+  interfaces: eth0
+  services: ssh http dhcpv6-client
+  ports: 6550/tcp 8000-8100/tcp
+`
+	run := fakeHost(map[string]string{
+		"firewall-cmd --state":                         "running\n",
+		"firewall-cmd --get-zone-of-interface=docker0": "public\n",
+		"firewall-cmd --zone=public --list-all":        public,
+	})
+	got := detect(t, run, []int{80, 443, 8080, 6550})
+	require.Equal(t, []Finding{{Firewall: Firewalld, Zone: "public", Ports: []int{443}}}, got)
+	assert.Equal(t, [][]string{
+		{"firewall-cmd", "--permanent", "--zone=public", "--add-port=443/tcp"},
+		{"firewall-cmd", "--reload"},
+	}, got[0].Commands())
+
+	docker := "docker (active)\n  target: ACCEPT\n  interfaces: br-1a2b docker0\n  ports: \n"
+	run = fakeHost(map[string]string{
+		"firewall-cmd --state":                         "running\n",
+		"firewall-cmd --get-zone-of-interface=docker0": "docker\n",
+		"firewall-cmd --zone=docker --list-all":        docker,
+		"firewall-cmd --zone=public --list-all":        public,
+	})
+	assert.Empty(t, detect(t, run, []int{80, 443}), "the docker zone accepts everything, whatever the default zone says")
+}
+
+func TestDetect_FirewalldFallsBackToTheDefaultZone(t *testing.T) {
+	run := fakeHost(map[string]string{
+		"firewall-cmd --state":                "running\n",
+		"firewall-cmd --get-default-zone":     "home\n",
+		"firewall-cmd --zone=home --list-all": "home (active)\n  target: default\n  services: https\n",
+	})
+	assert.Equal(t, []Finding{{Firewall: Firewalld, Zone: "home", Ports: []int{80}}}, detect(t, run, []int{80, 443}))
+}
+
+func TestDetect_DockerUser(t *testing.T) {
+	chain := `-N DOCKER-USER
+-A DOCKER-USER -s 10.0.0.0/8 -j RETURN
+-A DOCKER-USER -p tcp -m conntrack --ctorigdstport 80 -j RETURN
+-A DOCKER-USER -i eth0 -p tcp -m multiport --dports 80,443,6000:7000 -j DROP
+-A DOCKER-USER -j RETURN
+`
+	got := detect(t, fakeHost(map[string]string{"iptables -S DOCKER-USER": chain}), []int{80, 443, 6550, 8080})
+	require.Equal(t, []Finding{{Firewall: IPTables, Ports: []int{443, 6550}}}, got,
+		"a RETURN limited to some sources does not open a port; an earlier unconditional one does")
+	assert.Equal(t, [][]string{
+		{"iptables", "-I", "DOCKER-USER", "-p", "tcp", "-m", "conntrack", "--ctorigdstport", "443", "-j", "RETURN"},
+		{"iptables", "-I", "DOCKER-USER", "-p", "tcp", "-m", "conntrack", "--ctorigdstport", "6550", "-j", "RETURN"},
+	}, got[0].Commands())
+
+	stock := "-N DOCKER-USER\n-A DOCKER-USER -j RETURN\n"
+	assert.Empty(t, detect(t, fakeHost(map[string]string{"iptables -S DOCKER-USER": stock}), []int{80}))
+	assert.Empty(t, detect(t, fakeHost(nil), []int{80}), "an unreadable chain is assumed open")
+}
+
+func TestDetect_SandboxRefusalIsAnError(t *testing.T) {
+	run := func(_ context.Context, name string, args ...string) (string, error) {
+		return "", &executor.SandboxError{Command: name, Reason: name + " is not one of the tools OpenFrame uses"}
+	}
+
+	_, err := Detect(context.Background(), run, []int{80})
+	var sandboxErr *executor.SandboxError
+	require.True(t, errors.As(err, &sandboxErr), "a refused probe must not read as no firewall")
+	assert.Equal(t, "firewall-cmd", sandboxErr.Command)
+}
@@ -0,0 +1,273 @@
+// Package installstatus publishes the progress of a long install for other
+// programs: a JSON status file rewritten on every change and, optionally, the
+// same JSON POSTed to a webhook. IDE plugins and CI dashboards follow the
+// install through it instead of scraping console output.
+//
+// Like timeline, reporting is process-wide: a command that accepts
+// --status-file/--webhook-url calls Start, the install path calls Phase and
+// ReportApps, and the root command calls Finish. Everything is a no-op until Start,
+// so libraries and tests can call it freely.
+package installstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/spf13/pflag"
+)
+
+// States of a reported install.
+const (
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+)
+
+// webhookTimeout bounds each POST, and the wait for the last one on Finish.
+const webhookTimeout = 5 * time.Second
+
+// Apps counts the applications that are ready out of those deployed.
+type Apps struct {
+	Ready int `json:"ready"`
+	Total int `json:"total"`
+}
+
+// Status is the document written to the status file and the webhook.
+type Status struct {
+	Command   string    `json:"command"`
+	State     string    `json:"state"`
+	Phase     string    `json:"phase,omitempty"`
+	Apps      *Apps     `json:"apps,omitempty"`
+	Errors    []string  `json:"errors,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+var (
+	mu      sync.Mutex
+	current *reporter
+	now     = time.Now
+)
+
+type reporter struct {
+	status  Status
+	file    string
+	webhook *sender
+}
+
+// AddFlags registers --status-file and --webhook-url on fs.
+func AddFlags(fs *pflag.FlagSet) {
+	fs.String("status-file", "", "Keep a JSON file updated with the install's phase, application readiness and errors")
+	fs.String("webhook-url", "", "POST the same JSON as --status-file to this URL on every change")
+}
+
+// Start begins reporting for command from the flags AddFlags registered. With
+// neither flag set it does nothing.
+func Start(fs *pflag.FlagSet, command string) error {
+	file, _ := fs.GetString("status-file")
+	webhook, _ := fs.GetString("webhook-url")
+	return Begin(command, file, webhook)
+}
+
+// Begin starts reporting command to file and/or webhook; both empty is a
+// no-op. The file is written once right away, so a bad path fails the
+// command before any work is done.
+func Begin(command, file, webhook string) error {
+	if file == "" && webhook == "" {
+		return nil
+	}
+	r := &reporter{file: file}
+	if webhook != "" {
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--webhook-url %q is not an http(s) URL", webhook)
+		}
+		r.webhook = newSender(webhook)
+	}
+	t := now()
+	r.status = Status{Command: command, State: StateRunning, StartedAt: t, UpdatedAt: t}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = r
+	if err := r.publish(); err != nil {
+		current = nil
+		return err
+	}
+	return nil
+}
+
+// Phase records that the install entered phase.
+func Phase(name string) {
+	update(func(s *Status) bool {
+		if s.Phase == name {
+			return false
+		}
+		s.Phase = name
+		return true
+	})
+}
+
+// ReportApps records how many applications are ready out of total.
+func ReportApps(ready, total int) {
+	update(func(s *Status) bool {
+		if s.Apps != nil && s.Apps.Ready == ready && s.Apps.Total == total {
+			return false
+		}
+		s.Apps = &Apps{Ready: ready, Total: total}
+		return true
+	})
+}
+
+// Finish records the outcome, publishes it and waits (briefly) for the
+// webhook to receive it.
+func Finish(err error) {
+	mu.Lock()
+	r := current
+	current = nil
+	if r == nil {
+		mu.Unlock()
+		return
+	}
+	r.status.State = StateSucceeded
+	if err != nil {
+		r.status.State = StateFailed
+		r.status.Errors = append(r.status.Errors, redact.Redact(err.Error()))
+	}
+	r.status.UpdatedAt = now()
+	_ = r.publish()
+	mu.Unlock()
+
+	if r.webhook != nil {
+		r.webhook.close()
+	}
+}
+
+// Current returns a copy of the status being reported, if any.
+func Current() (Status, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		return Status{}, false
+	}
+	return current.status, true
+}
+
+func update(change func(*Status) bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil || !change(&current.status) {
+		return
+	}
+	current.status.UpdatedAt = now()
+	// Publishing mid-install is best-effort: a full disk must not fail the
+	// install it reports on.
+	_ = current.publish()
+}
+
+// publish writes the status file and queues the webhook. Called with mu held.
+func (r *reporter) publish() error {
+	data, err := json.MarshalIndent(r.status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if r.file != "" {
+		if err := writeAtomic(r.file, append(data, '\n')); err != nil {
+			return fmt.Errorf("writing status file: %w", err)
+		}
+	}
+	if r.webhook != nil {
+		r.webhook.send(data)
+	}
+	return nil
+}
+
+// writeAtomic replaces path with data through a rename, so readers never see
+// a half-written document.
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// sender POSTs documents to a webhook from one goroutine. Only the newest
+// pending document is kept: a slow endpoint gets the latest state, not a
+// backlog, and never slows the install down.
+type sender struct {
+	url    string
+	client *http.Client
+	queue  chan []byte
+	done   chan struct{}
+}
+
+func newSender(webhook string) *sender {
+	s := &sender{
+		url:    webhook,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan []byte, 1),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *sender) run() {
+	defer close(s.done)
+	for body := range s.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+			if resp, err := s.client.Do(req); err == nil {
+				_ = resp.Body.Close()
+			}
+		}
+		cancel()
+	}
+}
+
+// send queues body, replacing a document still waiting to be sent. Callers
+// hold mu, so there is a single producer.
+func (s *sender) send(body []byte) {
+	select {
+	case s.queue <- body:
+		return
+	default:
+	}
+	select {
+	case <-s.queue:
+	default:
+	}
+	s.queue <- body
+}
+
+// close sends what is queued and stops, giving up after webhookTimeout.
+func (s *sender) close() {
+	close(s.queue)
+	select {
+	case <-s.done:
+	case <-time.After(webhookTimeout):
+	}
+}
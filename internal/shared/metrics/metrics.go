@@ -0,0 +1,269 @@
+// Package metrics exposes what a long install is doing as Prometheus metrics:
+// the external commands it ran, the retries it needed, how many applications
+// are ready and how long each phase took. CI and a local Grafana scrape them
+// while the install runs, instead of parsing its log.
+//
+// Like installstatus, it is process-wide: a command that accepts
+// --metrics-listen calls Start, the install path calls Phase, ReportApps,
+// Retry and (through the executor) CommandExecuted, and the root command calls
+// Finish. Everything is a no-op until Start, so libraries and tests can call it
+// freely.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+)
+
+// Result labels of openframe_commands_executed_total.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// finishLinger is how long Finish keeps serving for a scrape of the final
+// values: Prometheus' default scrape interval. A variable so tests can
+// shorten it.
+var finishLinger = 15 * time.Second
+
+type commandKey struct{ tool, result string }
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	ep      *endpoint
+	now     = time.Now
+
+	commands       map[commandKey]float64
+	commandSeconds map[string]float64
+	retries        map[string]float64
+	apps           *[2]int // ready, total; nil until reported
+	phaseSeconds   map[string]float64
+	phase          string
+	phaseSince     time.Time
+	finished       time.Time // when Finish froze the values; zero until then
+)
+
+// endpoint is the /metrics server of one command.
+type endpoint struct {
+	srv *http.Server
+	// final is closed by Finish; scraped is closed once a scrape that started
+	// after it has been served.
+	final, scraped chan struct{}
+	once           sync.Once
+}
+
+// AddFlags registers --metrics-listen on fs.
+func AddFlags(fs *pflag.FlagSet) {
+	fs.String("metrics-listen", "", "Serve Prometheus metrics of the install (commands run, retries, application readiness, phase durations) on this address, e.g. :9464")
+}
+
+// Start serves /metrics on the address given to --metrics-listen while the
+// command runs. Without the flag it does nothing. An address that cannot be
+// listened on fails the command before any work is done.
+func Start(fs *pflag.FlagSet) error {
+	addr, _ := fs.GetString("metrics-listen")
+	if addr == "" {
+		return nil
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("--metrics-listen: %w", err)
+	}
+	e := &endpoint{final: make(chan struct{}), scraped: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.handler(newRegistry()))
+	e.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	mu.Lock()
+	if ep != nil {
+		// Already serving for this command.
+		mu.Unlock()
+		_ = ln.Close()
+		return nil
+	}
+	resetLocked()
+	enabled, ep = true, e
+	mu.Unlock()
+
+	go func() { _ = e.srv.Serve(ln) }()
+	pterm.Info.Printf("Serving Prometheus metrics on http://%s/metrics\n", ln.Addr())
+	return nil
+}
+
+// Finish freezes the metrics and keeps serving them until they have been
+// scraped once more, at most finishLinger, so the final values are not lost
+// with the process. Metrics recorded after it are dropped.
+func Finish() {
+	mu.Lock()
+	e := ep
+	enabled, ep = false, nil
+	if e != nil {
+		finished = now()
+	}
+	mu.Unlock()
+	if e == nil {
+		return
+	}
+	close(e.final)
+	pterm.Info.Printf("Waiting up to %s for a final metrics scrape\n", finishLinger)
+	select {
+	case <-e.scraped:
+	case <-time.After(finishLinger):
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = e.srv.Shutdown(ctx)
+}
+
+// handler serves reg, noting the first scrape after Finish.
+func (e *endpoint) handler(reg *prometheus.Registry) http.Handler {
+	h := promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		final := false
+		select {
+		case <-e.final:
+			final = true
+		default:
+		}
+		h.ServeHTTP(w, r)
+		if final {
+			e.once.Do(func() { close(e.scraped) })
+		}
+	})
+}
+
+func resetLocked() {
+	commands = map[commandKey]float64{}
+	commandSeconds = map[string]float64{}
+	retries = map[string]float64{}
+	phaseSeconds = map[string]float64{}
+	apps, phase, finished = nil, "", time.Time{}
+}
+
+// CommandExecuted records that tool ran for d and failed with err, if any.
+func CommandExecuted(tool string, err error, d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	result := ResultOK
+	if err != nil {
+		result = ResultError
+	}
+	commands[commandKey{tool, result}]++
+	commandSeconds[tool] += d.Seconds()
+}
+
+// Retry records that operation was tried again: an install attempt, a WSL
+// recovery, a repo-server restart, a sync the CLI triggered.
+func Retry(operation string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if enabled {
+		retries[operation]++
+	}
+}
+
+// ReportApps records how many applications are ready out of total.
+func ReportApps(ready, total int) {
+	mu.Lock()
+	defer mu.Unlock()
+	if enabled {
+		apps = &[2]int{ready, total}
+	}
+}
+
+// Phase records that the install entered phase, ending the previous one.
+func Phase(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled || name == phase {
+		return
+	}
+	t := now()
+	if phase != "" {
+		phaseSeconds[phase] += t.Sub(phaseSince).Seconds()
+	}
+	phase, phaseSince = name, t
+}
+
+func newRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collector{})
+	return reg
+}
+
+var (
+	commandsDesc = prometheus.NewDesc("openframe_commands_executed_total",
+		"External commands the CLI ran, by tool and result.", []string{"tool", "result"}, nil)
+	commandSecondsDesc = prometheus.NewDesc("openframe_command_seconds_total",
+		"Time spent in external commands, by tool.", []string{"tool"}, nil)
+	retriesDesc = prometheus.NewDesc("openframe_retries_total",
+		"Operations the CLI tried again, by operation.", []string{"operation"}, nil)
+	appsReadyDesc = prometheus.NewDesc("openframe_applications_ready",
+		"Applications that are Healthy and Synced.", nil, nil)
+	appsTotalDesc = prometheus.NewDesc("openframe_applications_total",
+		"Applications deployed.", nil, nil)
+	phaseDurationDesc = prometheus.NewDesc("openframe_phase_duration_seconds",
+		"Time spent in each install phase so far.", []string{"phase"}, nil)
+	phaseDesc = prometheus.NewDesc("openframe_phase",
+		"The phase the install is in (1).", []string{"phase"}, nil)
+)
+
+// collector reports the recorded values at each scrape.
+type collector struct{}
+
+func (collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{commandsDesc, commandSecondsDesc, retriesDesc,
+		appsReadyDesc, appsTotalDesc, phaseDurationDesc, phaseDesc} {
+		ch <- d
+	}
+}
+
+func (collector) Collect(ch chan<- prometheus.Metric) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for k, n := range commands {
+		ch <- prometheus.MustNewConstMetric(commandsDesc, prometheus.CounterValue, n, k.tool, k.result)
+	}
+	for tool, s := range commandSeconds {
+		ch <- prometheus.MustNewConstMetric(commandSecondsDesc, prometheus.CounterValue, s, tool)
+	}
+	for op, n := range retries {
+		ch <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, n, op)
+	}
+	if apps != nil {
+		ch <- prometheus.MustNewConstMetric(appsReadyDesc, prometheus.GaugeValue, float64(apps[0]))
+		ch <- prometheus.MustNewConstMetric(appsTotalDesc, prometheus.GaugeValue, float64(apps[1]))
+	}
+
+	// The running phase counts up to now, so a scrape sees it grow, and
+	// stops with Finish.
+	end := now()
+	if !finished.IsZero() {
+		end = finished
+	}
+	durations := make(map[string]float64, len(phaseSeconds)+1)
+	for name, s := range phaseSeconds {
+		durations[name] = s
+	}
+	if phase != "" {
+		durations[phase] += end.Sub(phaseSince).Seconds()
+		ch <- prometheus.MustNewConstMetric(phaseDesc, prometheus.GaugeValue, 1, phase)
+	}
+	for name, s := range durations {
+		ch <- prometheus.MustNewConstMetric(phaseDurationDesc, prometheus.GaugeValue, s, name)
+	}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// freeAddr returns a local address nothing listens on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
+
+func start(t *testing.T, addr string) error {
+	t.Helper()
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(fs)
+	require.NoError(t, fs.Set("metrics-listen", addr))
+	orig := finishLinger
+	finishLinger = 100 * time.Millisecond
+	t.Cleanup(func() {
+		Finish()
+		finishLinger = orig
+	})
+	return Start(fs)
+}
+
+func scrape(t *testing.T, addr string) string {
+	t.Helper()
+	resp, err := http.Get("http://" + addr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// gather renders the metrics as a scrape would, without a server.
+func gather(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(newRegistry(), promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
+
+func TestStart_ServesMetrics(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	orig := now
+	now = func() time.Time { return clock }
+	t.Cleanup(func() { now = orig })
+
+	addr := freeAddr(t)
+	require.NoError(t, start(t, addr))
+
+	CommandExecuted("kubectl", nil, 2*time.Second)
+	CommandExecuted("kubectl", errors.New("exit status 1"), time.Second)
+	CommandExecuted("helm", nil, 500*time.Millisecond)
+	Retry("repo-server-restart")
+	ReportApps(3, 5)
+	Phase("argocd-install")
+	clock = clock.Add(90 * time.Second)
+	Phase("argocd-sync")
+	clock = clock.Add(30 * time.Second)
+
+	got := scrape(t, addr)
+	for _, line := range []string{
+		"# TYPE openframe_commands_executed_total counter",
+		`openframe_commands_executed_total{result="ok",tool="helm"} 1`,
+		`openframe_commands_executed_total{result="error",tool="kubectl"} 1`,
+		`openframe_commands_executed_total{result="ok",tool="kubectl"} 1`,
+		`openframe_command_seconds_total{tool="kubectl"} 3`,
+		`openframe_retries_total{operation="repo-server-restart"} 1`,
+		"openframe_applications_ready 3",
+		"openframe_applications_total 5",
+		`openframe_phase_duration_seconds{phase="argocd-install"} 90`,
+		`openframe_phase_duration_seconds{phase="argocd-sync"} 30`,
+		`openframe_phase{phase="argocd-sync"} 1`,
+	} {
+		assert.Contains(t, got, line+"\n")
+	}
+}
+
+func TestStart_WithoutFlagRecordsNothing(t *testing.T) {
+	require.NoError(t, start(t, ""))
+	Retry("chart-install")
+	assert.NotContains(t, gather(t), "chart-install")
+}
+
+func TestFinish_ServesTheFinalValues(t *testing.T) {
+	addr := freeAddr(t)
+	require.NoError(t, start(t, addr))
+	finishLinger = 10 * time.Second
+	Retry("chart-install")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Finish()
+	}()
+	// Finish is waiting for this scrape, not for the linger to run out.
+	var got string
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + addr + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		got = string(body)
+		select {
+		case <-done:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, got, `openframe_retries_total{operation="chart-install"} 1`)
+
+	Retry("chart-install")
+	assert.Contains(t, gather(t), `openframe_retries_total{operation="chart-install"} 1`, "values recorded after Finish are dropped")
+}
+
+func TestStart_AddressInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	err = start(t, ln.Addr().String())
+	assert.ErrorContains(t, err, "--metrics-listen")
+}
@@ -0,0 +1,319 @@
+// Package notify tells the user an install has ended — it can take well over
+// half an hour, long enough to walk away from the terminal. Each --notify
+// target gets one message, rendered from a template, when the command
+// succeeds or fails:
+//
+//	desktop                            a desktop notification (notify-send, osascript)
+//	slack://hooks.slack.com/services/… a Slack incoming webhook
+//	cmd:<shell command>                any command, with the outcome in its environment
+//
+// Like installstatus it is process-wide: a command that accepts --notify calls
+// Start, and the root command calls Finish. Everything is a no-op until Start.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/desktopnotify"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+)
+
+// DefaultTemplate renders the message when neither --notify-template nor the
+// user config sets one.
+const DefaultTemplate = `{{.Command}} {{.Status}} after {{.Duration}}{{if .Phase}} during {{.Phase}}{{end}}{{if .Error}}: {{.Error}}{{end}}`
+
+// deliveryTimeout bounds each target, so a dead webhook cannot hold the
+// command's exit.
+const deliveryTimeout = 10 * time.Second
+
+// Message is what a template is rendered with.
+type Message struct {
+	Command  string        // e.g. "openframe bootstrap"
+	Status   string        // installstatus.StateSucceeded or StateFailed
+	Duration time.Duration // wall clock, rounded to the second
+	Phase    string        // the phase a failed install was in; empty on success
+	Error    string        // first line of the error, redacted; empty on success
+}
+
+// Failed reports whether the command failed.
+func (m Message) Failed() bool { return m.Status == installstatus.StateFailed }
+
+// target delivers one rendered message.
+type target interface {
+	deliver(ctx context.Context, m Message, text string) error
+	String() string
+}
+
+// Overridden in tests.
+var (
+	now        = time.Now
+	goos       = runtime.GOOS
+	newDesktop = func(exec executor.CommandExecutor) (desktop, error) { return desktopnotify.New(exec) }
+)
+
+var (
+	mu      sync.Mutex
+	current *run
+)
+
+type run struct {
+	command string
+	started time.Time
+	targets []target
+	tmpl    *template.Template
+}
+
+// AddFlags registers --notify and --notify-template on fs.
+func AddFlags(fs *pflag.FlagSet) {
+	fs.StringArray("notify", nil, "Notify when the command finishes or fails: desktop, slack://<webhook host/path> or cmd:<command> (repeatable)")
+	fs.String("notify-template", "", "Go template for the notification text; fields: .Command .Status .Failed .Duration .Phase .Error")
+}
+
+// Start begins a run for command from the flags AddFlags registered, falling
+// back to notify.targets and notify.template in the user config. With no
+// target anywhere it does nothing. A bad target or template fails the command
+// before any work is done.
+func Start(fs *pflag.FlagSet, command string) error {
+	targets, _ := fs.GetStringArray("notify")
+	tmpl, _ := fs.GetString("notify-template")
+	if len(targets) == 0 || tmpl == "" {
+		cfg := userConfig()
+		if len(targets) == 0 {
+			targets = cfg.Targets
+		}
+		if tmpl == "" {
+			tmpl = cfg.Template
+		}
+	}
+	verbose, _ := fs.GetBool("verbose")
+	return Begin(command, targets, tmpl, executor.NewRealCommandExecutor(false, verbose))
+}
+
+// Begin starts a run for command notifying specs; no specs is a no-op. exec
+// runs desktop and cmd: targets, so --sandbox and --audit apply to them.
+func Begin(command string, specs []string, tmpl string, exec executor.CommandExecutor) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New("notify").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("--notify-template: %w", err)
+	}
+	if err := t.Execute(&bytes.Buffer{}, Message{}); err != nil {
+		return fmt.Errorf("--notify-template: %w", err)
+	}
+	r := &run{command: command, started: now(), tmpl: t}
+	for _, spec := range specs {
+		target, err := parseTarget(spec, exec)
+		if err != nil {
+			return err
+		}
+		r.targets = append(r.targets, target)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = r
+	return nil
+}
+
+// Finish sends the outcome to every target, phase naming where a failure
+// happened. A delivery failure is printed to stderr; it never changes the
+// command's result. A cancelled command notifies no one: the user was there
+// to cancel it.
+func Finish(err error, phase string) {
+	mu.Lock()
+	r := current
+	current = nil
+	mu.Unlock()
+	if r == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	m := Message{
+		Command:  r.command,
+		Status:   installstatus.StateSucceeded,
+		Duration: now().Sub(r.started).Round(time.Second),
+	}
+	if err != nil {
+		m.Status = installstatus.StateFailed
+		m.Phase = phase
+		m.Error = firstLine(redact.Redact(err.Error()))
+	}
+	var text bytes.Buffer
+	if err := r.tmpl.Execute(&text, m); err != nil {
+		warn("notification template: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range r.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			defer cancel()
+			if err := t.deliver(ctx, m, text.String()); err != nil {
+				warn("%s notification failed: %v", t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func warn(format string, args ...any) {
+	pterm.Warning.WithWriter(os.Stderr).Printfln(format, args...)
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return strings.TrimSpace(s)
+}
+
+// fileConfig is the notify section of the user config.
+type fileConfig struct {
+	Targets  []string `json:"targets"`
+	Template string   `json:"template"`
+}
+
+// userConfig reads the notify section of the user config. A missing or
+// unreadable config configures nothing.
+func userConfig() fileConfig {
+	var cfg struct {
+		Notify fileConfig `json:"notify"`
+	}
+	_ = sharedconfig.LoadUserConfig(&cfg)
+	return cfg.Notify
+}
+
+func parseTarget(spec string, exec executor.CommandExecutor) (target, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "desktop":
+		return newDesktopTarget(exec)
+	case strings.HasPrefix(spec, "cmd:"):
+		command := strings.TrimSpace(strings.TrimPrefix(spec, "cmd:"))
+		if command == "" {
+			return nil, fmt.Errorf("--notify %q: no command after cmd:", spec)
+		}
+		if executor.SandboxMode() == executor.SandboxEnforce {
+			return nil, fmt.Errorf("--notify %q: a cmd: target runs a shell command, which --sandbox enforce refuses; use desktop or slack://, or --sandbox log", spec)
+		}
+		return &commandTarget{exec: exec, command: command}, nil
+	case strings.HasPrefix(spec, "slack://"):
+		u, err := url.Parse("https://" + strings.TrimPrefix(spec, "slack://"))
+		if err != nil || u.Host == "" || u.Path == "" {
+			return nil, fmt.Errorf("--notify: a Slack target is slack://<webhook host>/<path>, e.g. slack://hooks.slack.com/services/T000/B000/XXXX")
+		}
+		// The webhook path is the credential; keep it out of logs.
+		redact.RegisterSecret(u.Path)
+		return &slackTarget{url: u.String(), client: &http.Client{Timeout: deliveryTimeout}}, nil
+	}
+	return nil, fmt.Errorf("--notify %q: use desktop, slack://<webhook host/path> or cmd:<command>", spec)
+}
+
+// slackTarget posts to a Slack incoming webhook.
+type slackTarget struct {
+	url    string
+	client *http.Client
+}
+
+func (s *slackTarget) String() string { return "Slack" }
+
+func (s *slackTarget) deliver(ctx context.Context, _ Message, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The error quotes the URL, whose path is the webhook's secret.
+		return fmt.Errorf("%s", redact.Redact(err.Error()))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// desktop shows one desktop notification; *desktopnotify.Notifier in
+// production.
+type desktop interface {
+	Show(ctx context.Context, title, message string, urgent bool) error
+}
+
+// desktopTarget shows a desktop notification through notify-send (Linux) or
+// osascript (macOS).
+type desktopTarget struct {
+	desktop desktop
+}
+
+func newDesktopTarget(exec executor.CommandExecutor) (*desktopTarget, error) {
+	d, err := newDesktop(exec)
+	if err != nil {
+		return nil, fmt.Errorf("--notify desktop: %w", err)
+	}
+	return &desktopTarget{desktop: d}, nil
+}
+
+func (d *desktopTarget) String() string { return "desktop" }
+
+func (d *desktopTarget) deliver(ctx context.Context, m Message, text string) error {
+	return d.desktop.Show(ctx, "OpenFrame: "+m.Command+" "+m.Status, text, m.Failed())
+}
+
+// commandTarget runs a shell command with the outcome in its environment.
+type commandTarget struct {
+	exec    executor.CommandExecutor
+	command string
+}
+
+func (c *commandTarget) String() string { return "command" }
+
+func (c *commandTarget) deliver(ctx context.Context, m Message, text string) error {
+	shell, flag := "sh", "-c"
+	if goos == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	_, err := c.exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: shell,
+		Args:    []string{flag, c.command},
+		Timeout: deliveryTimeout,
+		Env: map[string]string{
+			"OPENFRAME_NOTIFY_MESSAGE":  text,
+			"OPENFRAME_NOTIFY_COMMAND":  m.Command,
+			"OPENFRAME_NOTIFY_STATUS":   m.Status,
+			"OPENFRAME_NOTIFY_DURATION": m.Duration.String(),
+			"OPENFRAME_NOTIFY_PHASE":    m.Phase,
+			"OPENFRAME_NOTIFY_ERROR":    m.Error,
+		},
+	})
+	return err
+}
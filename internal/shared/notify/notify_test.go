@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/pkg/executortest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock makes runs last exactly d.
+func fixedClock(t *testing.T, d time.Duration) {
+	t.Helper()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	now = func() time.Time {
+		calls++
+		if calls == 1 {
+			return start
+		}
+		return start.Add(d)
+	}
+	t.Cleanup(func() { now = time.Now })
+}
+
+// onLinux pins the platform the desktop and command targets are built for.
+func onLinux(t *testing.T) {
+	t.Helper()
+	orig := goos
+	goos = "linux"
+	t.Cleanup(func() { goos = orig })
+}
+
+func TestFinish_CommandGetsOutcomeInEnvironment(t *testing.T) {
+	onLinux(t)
+	fixedClock(t, 32*time.Minute+10*time.Second+400*time.Millisecond)
+	exec := executortest.New()
+	require.NoError(t, Begin("openframe bootstrap", []string{"cmd: ./notify.sh"}, "", exec))
+
+	Finish(errors.New("waiting for applications: timed out\nmore detail"), "argocd-sync")
+
+	calls := exec.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "sh -c ./notify.sh", calls[0].String())
+	assert.Equal(t, "openframe bootstrap failed after 32m10s during argocd-sync: waiting for applications: timed out", calls[0].Env["OPENFRAME_NOTIFY_MESSAGE"])
+	assert.Equal(t, "failed", calls[0].Env["OPENFRAME_NOTIFY_STATUS"])
+	assert.Equal(t, "argocd-sync", calls[0].Env["OPENFRAME_NOTIFY_PHASE"])
+	assert.Equal(t, "32m10s", calls[0].Env["OPENFRAME_NOTIFY_DURATION"])
+}
+
+func TestFinish_Slack(t *testing.T) {
+	fixedClock(t, 45*time.Minute)
+	var body map[string]string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/T0/B0/secret", r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+	}))
+	defer srv.Close()
+
+	require.NoError(t, Begin("openframe app install", []string{"slack://" + strings.TrimPrefix(srv.URL, "https://") + "/services/T0/B0/secret"},
+		"{{if .Failed}}:x:{{else}}:white_check_mark:{{end}} {{.Command}} took {{.Duration}}", executortest.New()))
+	current.targets[0].(*slackTarget).client = srv.Client()
+
+	Finish(nil, "argocd-sync")
+	assert.Equal(t, map[string]string{"text": ":white_check_mark: openframe app install took 45m0s"}, body, "the phase is only reported on failure")
+}
+
+func TestFinish_CancelledNotifiesNoOne(t *testing.T) {
+	exec := executortest.New()
+	require.NoError(t, Begin("openframe bootstrap", []string{"cmd:true"}, "", exec))
+	Finish(fmt.Errorf("operation cancelled: %w", context.Canceled), "cluster-create")
+	assert.Empty(t, exec.Calls())
+
+	Finish(nil, "")
+	assert.Empty(t, exec.Calls(), "Finish outside a run is a no-op")
+}
+
+// fakeDesktop records the notifications it is asked to show.
+type fakeDesktop struct{ shown [][]string }
+
+func (f *fakeDesktop) Show(_ context.Context, title, message string, urgent bool) error {
+	f.shown = append(f.shown, []string{title, message, fmt.Sprint(urgent)})
+	return nil
+}
+
+// stubDesktop stands in for the desktop notifier, or for its absence.
+func stubDesktop(t *testing.T, available bool) *fakeDesktop {
+	t.Helper()
+	fake := &fakeDesktop{}
+	orig := newDesktop
+	newDesktop = func(executor.CommandExecutor) (desktop, error) {
+		if !available {
+			return nil, errors.New("notify-send is not installed")
+		}
+		return fake, nil
+	}
+	t.Cleanup(func() { newDesktop = orig })
+	return fake
+}
+
+func TestFinish_Desktop(t *testing.T) {
+	fake := stubDesktop(t, true)
+	require.NoError(t, Begin("openframe bootstrap", []string{"desktop"}, "", executortest.New()))
+
+	Finish(errors.New("boom"), "cluster-create")
+	assert.Equal(t, [][]string{{"OpenFrame: openframe bootstrap failed", "openframe bootstrap failed after 0s during cluster-create: boom", "true"}}, fake.shown)
+}
+
+func TestBegin_RejectsBadTargetsAndTemplates(t *testing.T) {
+	stubDesktop(t, false)
+
+	for _, spec := range []string{"email:me@example.com", "cmd:", "slack://", "desktop"} {
+		assert.Error(t, Begin("openframe bootstrap", []string{spec}, "", executortest.New()), spec)
+	}
+	t.Setenv(executor.SandboxEnv, executor.SandboxEnforce)
+	assert.ErrorContains(t, Begin("openframe bootstrap", []string{"cmd:true"}, "", executortest.New()), "--sandbox enforce refuses",
+		"a target the sandbox would refuse fails up front, not at the end of the run")
+	t.Setenv(executor.SandboxEnv, "")
+	assert.Error(t, Begin("openframe bootstrap", []string{"cmd:true"}, "{{.Nope}}", executortest.New()), "unknown fields fail up front")
+	assert.Error(t, Begin("openframe bootstrap", []string{"cmd:true"}, "{{.Command", executortest.New()))
+	assert.Nil(t, current)
+}
+
+func TestStart_FallsBackToUserConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"notify": {"targets": ["cmd:echo done"], "template": "{{.Status}}"}}`), 0o600))
+	orig := sharedconfig.UserConfigFile
+	sharedconfig.UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { sharedconfig.UserConfigFile = orig; current = nil })
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(fs)
+	require.NoError(t, Start(fs, "openframe bootstrap"))
+	require.NotNil(t, current)
+	assert.Equal(t, "echo done", current.targets[0].(*commandTarget).command)
+
+	require.NoError(t, fs.Parse([]string{"--notify", "cmd:other"}))
+	require.NoError(t, Start(fs, "openframe bootstrap"))
+	assert.Equal(t, "other", current.targets[0].(*commandTarget).command, "the flag replaces the configured targets")
+}
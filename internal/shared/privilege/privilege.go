@@ -0,0 +1,167 @@
+// Package privilege decides how OpenFrame runs the few commands that need root
+// (package installs, sysctl writes, starting the Docker service). Call sites
+// used to prepend `sudo` ad hoc, which hung on a hidden password prompt or
+// failed cryptically on hosts without passwordless sudo. An Escalator checks
+// once per process: running as root needs nothing, passwordless sudo is used
+// with -n, an interactive session is asked for the password once (sudo caches
+// it), and everything else — including --no-sudo — gets an error naming the
+// command to run by hand.
+package privilege
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+)
+
+// NoSudoEnv disables sudo like --no-sudo does, for automation that cannot
+// pass flags through.
+const NoSudoEnv = "OPENFRAME_NO_SUDO"
+
+// noSudoFlag backs the global --no-sudo flag (see BindFlags).
+var noSudoFlag bool
+
+// BindFlags registers --no-sudo on fs. The flag is read when privileges are
+// first needed, so command groups that shadow the root's PersistentPreRunE
+// still honor it.
+func BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&noSudoFlag, "no-sudo", false, "Never run commands with sudo; report what needs root instead")
+}
+
+// Error reports a command that needs root privileges OpenFrame may not obtain.
+type Error struct {
+	Command string
+	Reason  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%q needs root privileges, but %s; run it yourself: sudo %s", e.Command, e.Reason, e.Command)
+}
+
+// Escalator wraps commands so they run as root. The zero value is not usable;
+// use Default or New.
+type Escalator struct {
+	noSudo      func() bool
+	euid        func() int
+	lookPath    func(string) (string, error)
+	interactive func() bool
+	// probe runs `sudo -n true`; prompt runs `sudo -v` on the terminal.
+	probe  func(ctx context.Context) error
+	prompt func(ctx context.Context) error
+
+	mu      sync.Mutex
+	checked bool
+	reason  string // why sudo is unusable; "" once it is ready
+}
+
+var (
+	defaultEscalator     *Escalator
+	defaultEscalatorOnce sync.Once
+)
+
+// Default returns the process-wide Escalator, so the password prompt happens
+// at most once per run.
+func Default() *Escalator {
+	defaultEscalatorOnce.Do(func() {
+		defaultEscalator = New()
+	})
+	return defaultEscalator
+}
+
+// New returns an Escalator for the current process and terminal.
+func New() *Escalator {
+	return &Escalator{
+		noSudo:      func() bool { return noSudoFlag || sharedconfig.EnvBool(NoSudoEnv) },
+		euid:        os.Geteuid,
+		lookPath:    exec.LookPath,
+		interactive: func() bool { return !ui.IsNonInteractive() },
+		probe: func(ctx context.Context) error {
+			return exec.CommandContext(ctx, "sudo", "-n", "true").Run()
+		},
+		prompt: func(ctx context.Context) error {
+			cmd := exec.CommandContext(ctx, "sudo", "-v")
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+			return cmd.Run()
+		},
+	}
+}
+
+// Assume returns an Escalator with a predetermined outcome and no host probes:
+// asRoot simulates running as root, and reason is why sudo is unusable ("" for
+// working passwordless sudo). Tests use it to drive call sites.
+func Assume(asRoot bool, reason string) *Escalator {
+	euid := 1000
+	if asRoot {
+		euid = 0
+	}
+	return &Escalator{euid: func() int { return euid }, checked: true, reason: reason}
+}
+
+// IsRoot reports whether the process already runs as root.
+func (e *Escalator) IsRoot() bool {
+	return e.euid() == 0
+}
+
+// Command returns the argv that runs name with root privileges: unchanged when
+// already root, else prefixed with `sudo -n`. When sudo cannot be used it
+// returns an *Error instead, so callers never block on a hidden prompt.
+func (e *Escalator) Command(ctx context.Context, name string, args ...string) ([]string, error) {
+	argv := append([]string{name}, args...)
+	if e.IsRoot() {
+		return argv, nil
+	}
+	if reason := e.ensure(ctx); reason != "" {
+		return nil, &Error{Command: strings.Join(argv, " "), Reason: reason}
+	}
+	return append([]string{"sudo", "-n"}, argv...), nil
+}
+
+// Run runs name as root, discarding its output. See Command.
+func (e *Escalator) Run(ctx context.Context, name string, args ...string) error {
+	argv, err := e.Command(ctx, name, args...)
+	if err != nil {
+		return err
+	}
+	return exec.CommandContext(ctx, argv[0], argv[1:]...).Run() // #nosec G204 -- explicit argv, no shell; callers pass internal tool names
+}
+
+// ensure checks (once) whether sudo is usable, prompting for the password on
+// an interactive terminal. It returns why it is not, or "".
+func (e *Escalator) ensure(ctx context.Context) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.checked {
+		return e.reason
+	}
+	e.checked = true
+
+	switch {
+	case e.noSudo():
+		e.reason = "--no-sudo (or " + NoSudoEnv + ") is set"
+	case !e.available():
+		e.reason = "sudo is not installed"
+	case e.probe(ctx) == nil:
+		// Passwordless sudo (or credentials already cached).
+	case !e.interactive():
+		e.reason = "sudo needs a password and this session is non-interactive"
+	default:
+		pterm.Info.Println("Administrator privileges are needed; sudo may ask for your password once.")
+		if err := e.prompt(ctx); err != nil {
+			e.reason = "sudo authentication failed"
+		}
+	}
+	return e.reason
+}
+
+func (e *Escalator) available() bool {
+	_, err := e.lookPath("sudo")
+	return err == nil
+}
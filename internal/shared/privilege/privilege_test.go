@@ -0,0 +1,91 @@
+package privilege
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEscalator is a non-root Escalator whose host probes are scripted.
+func fakeEscalator(passwordless, interactive bool, promptErr error) (*Escalator, *int) {
+	prompts := 0
+	return &Escalator{
+		noSudo:      func() bool { return false },
+		euid:        func() int { return 1000 },
+		lookPath:    func(string) (string, error) { return "/usr/bin/sudo", nil },
+		interactive: func() bool { return interactive },
+		probe: func(context.Context) error {
+			if passwordless {
+				return nil
+			}
+			return errors.New("a password is required")
+		},
+		prompt: func(context.Context) error {
+			prompts++
+			return promptErr
+		},
+	}, &prompts
+}
+
+func TestCommand_RootRunsDirectly(t *testing.T) {
+	argv, err := Assume(true, "").Command(context.Background(), "sysctl", "-w", "a=1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sysctl", "-w", "a=1"}, argv)
+}
+
+func TestCommand_PasswordlessSudo(t *testing.T) {
+	e, prompts := fakeEscalator(true, false, nil)
+	argv, err := e.Command(context.Background(), "apt", "update")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sudo", "-n", "apt", "update"}, argv, "-n: sudo must never prompt behind our back")
+	assert.Zero(t, *prompts)
+}
+
+func TestCommand_PromptsOnceInteractively(t *testing.T) {
+	e, prompts := fakeEscalator(false, true, nil)
+	for i := 0; i < 3; i++ {
+		_, err := e.Command(context.Background(), "apt", "update")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, *prompts, "the password is asked for once per run")
+}
+
+func TestCommand_NonInteractiveWithoutPasswordlessSudo(t *testing.T) {
+	e, prompts := fakeEscalator(false, false, nil)
+	_, err := e.Command(context.Background(), "systemctl", "start", "docker")
+
+	var perr *Error
+	require.ErrorAs(t, err, &perr)
+	assert.Contains(t, err.Error(), "non-interactive")
+	assert.Contains(t, err.Error(), "run it yourself: sudo systemctl start docker")
+	assert.Zero(t, *prompts)
+}
+
+func TestCommand_FailedPromptIsRemembered(t *testing.T) {
+	e, prompts := fakeEscalator(false, true, errors.New("3 incorrect password attempts"))
+	_, err := e.Command(context.Background(), "apt", "update")
+	require.Error(t, err)
+	_, err = e.Command(context.Background(), "apt", "update")
+	require.Error(t, err)
+	assert.Equal(t, 1, *prompts, "a failed authentication is not retried for every command")
+}
+
+func TestCommand_NoSudo(t *testing.T) {
+	e, prompts := fakeEscalator(true, true, nil)
+	e.noSudo = func() bool { return true }
+	_, err := e.Command(context.Background(), "apt", "update")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--no-sudo")
+	assert.Zero(t, *prompts)
+}
+
+func TestCommand_SudoMissing(t *testing.T) {
+	e, _ := fakeEscalator(true, true, nil)
+	e.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	_, err := e.Command(context.Background(), "apt", "update")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sudo is not installed")
+}
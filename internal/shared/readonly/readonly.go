@@ -0,0 +1,120 @@
+// Package readonly implements --read-only: an observer mode for looking at a
+// machine that is not yours — a colleague's laptop, a CI runner — without
+// changing it. Only the commands annotated read-only run (list, status,
+// diagnostics and the like); everything else is refused before it starts, and
+// the side effects the read-only commands have otherwise — resuming a cluster
+// idle-watch paused, installing a missing prerequisite — are skipped.
+//
+// Like the other global switches it is process-wide: the root command binds
+// the flag, and every PersistentPreRunE calls Check.
+package readonly
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Env turns read-only mode on like --read-only does, for shells and CI jobs
+// that cannot pass the flag to every command.
+const Env = "OPENFRAME_READ_ONLY"
+
+// Annotation marks a command that changes nothing, so it runs in read-only
+// mode: Annotations: map[string]string{"readonly": "true"}.
+const Annotation = "readonly"
+
+// ErrReadOnly is wrapped by the error of a command refused in read-only mode.
+var ErrReadOnly = errors.New("read-only mode")
+
+// flagValue backs --read-only; given records that the flag was passed, so
+// --read-only=false can turn off a mode the environment or config turned on.
+var flagValue, given bool
+
+type boolFlag struct{}
+
+func (boolFlag) String() string   { return strconv.FormatBool(flagValue) }
+func (boolFlag) Type() string     { return "bool" }
+func (boolFlag) IsBoolFlag() bool { return true }
+func (boolFlag) Set(v string) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	flagValue, given = b, true
+	return nil
+}
+
+// BindFlags registers --read-only on fs.
+func BindFlags(fs *pflag.FlagSet) {
+	fs.Var(boolFlag{}, "read-only", "Observer mode: refuse every command that changes something; only list, status and diagnostics commands run")
+	fs.Lookup("read-only").NoOptDefVal = "true"
+}
+
+// fromConfig is readOnly in the CLI config, which turns the mode on for good,
+// read once per process. A config that
+// cannot be read is reported and taken as off, like its other settings.
+var fromConfig = sync.OnceValue(func() bool {
+	on, err := readConfig()
+	if err != nil {
+		pterm.Warning.Printf("Ignoring readOnly in the CLI config: %v\n", err)
+	}
+	return on
+})
+
+// readConfig reads readOnly from the CLI config. A missing file is off.
+func readConfig() (bool, error) {
+	var cfg struct {
+		ReadOnly bool `json:"readOnly"`
+	}
+	err := config.LoadUserConfig(&cfg)
+	return cfg.ReadOnly, err
+}
+
+// Enabled reports whether read-only mode is on: --read-only when given, else
+// OPENFRAME_READ_ONLY when set, else readOnly in the CLI config.
+func Enabled() bool {
+	on, _ := mode()
+	return on
+}
+
+// mode returns whether read-only mode is on and what turned it on.
+func mode() (bool, string) {
+	if given {
+		return flagValue, "--read-only"
+	}
+	if v, ok := os.LookupEnv(Env); ok && strings.TrimSpace(v) != "" {
+		return config.EnvBool(Env), Env
+	}
+	if fromConfig() {
+		return true, "readOnly in ~/.openframe/config.json"
+	}
+	return false, ""
+}
+
+// Allowed reports whether cmd runs in read-only mode: it is annotated
+// read-only, or it is cobra's help or shell-completion command.
+func Allowed(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return cmd.Annotations[Annotation] == "true"
+}
+
+// Check refuses cmd when read-only mode is on and cmd may change something.
+func Check(cmd *cobra.Command) error {
+	on, source := mode()
+	if !on || Allowed(cmd) {
+		return nil
+	}
+	return fmt.Errorf("%w (%s): '%s' may change this machine or its clusters, so it does not run; only list, status and diagnostics commands do",
+		ErrReadOnly, source, cmd.CommandPath())
+}
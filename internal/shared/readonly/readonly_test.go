@@ -0,0 +1,90 @@
+package readonly
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setMode sets the three sources of the mode, restoring them after the test.
+// flag is "" for a flag not given.
+func setMode(t *testing.T, flag, env string, config bool) {
+	t.Helper()
+	origValue, origGiven, origConfig := flagValue, given, fromConfig
+	t.Cleanup(func() { flagValue, given, fromConfig = origValue, origGiven, origConfig })
+
+	flagValue, given = false, false
+	if flag != "" {
+		fs := pflag.NewFlagSet("t", pflag.ContinueOnError)
+		BindFlags(fs)
+		require.NoError(t, fs.Parse([]string{flag}))
+	}
+	t.Setenv(Env, env)
+	fromConfig = func() bool { return config }
+}
+
+func TestEnabled_Precedence(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		flag   string
+		env    string
+		config bool
+		want   bool
+	}{
+		{name: "nothing set", want: false},
+		{name: "flag", flag: "--read-only", want: true},
+		{name: "env", env: "1", want: true},
+		{name: "config", config: true, want: true},
+		{name: "env off overrides config", env: "0", config: true, want: false},
+		{name: "flag off overrides env and config", flag: "--read-only=false", env: "true", config: true, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			setMode(t, tc.flag, tc.env, tc.config)
+			assert.Equal(t, tc.want, Enabled())
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	root := &cobra.Command{Use: "openframe"}
+	list := &cobra.Command{Use: "list", Annotations: map[string]string{Annotation: "true"}}
+	create := &cobra.Command{Use: "create"}
+	help := &cobra.Command{Use: "help"}
+	root.AddCommand(list, create, help)
+
+	setMode(t, "", "", false)
+	assert.NoError(t, Check(create), "off, everything runs")
+
+	setMode(t, "", "1", false)
+	assert.NoError(t, Check(list))
+	assert.NoError(t, Check(help))
+	err := Check(create)
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorContains(t, err, "(OPENFRAME_READ_ONLY): 'openframe create' may change")
+}
+
+func TestReadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	orig := config.UserConfigFile
+	config.UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { config.UserConfigFile = orig })
+
+	on, err := readConfig()
+	require.NoError(t, err)
+	assert.False(t, on, "no config is off")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"timeouts": {"query": "1m"}, "readOnly": true}`), 0o600))
+	on, err = readConfig()
+	require.NoError(t, err)
+	assert.True(t, on)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"readOnly": "yes"}`), 0o600))
+	_, err = readConfig()
+	assert.ErrorContains(t, err, "parsing "+path)
+}
@@ -0,0 +1,6 @@
+# Raises the inotify limits in WSL, where the node containers' kernel runs.
+# sudo -n: never prompt; a limit that cannot be raised is not fatal.
+sudo -n sysctl -w{{range .Assignments}} {{quote .}}{{end}} 2>/dev/null || true
+{{- if .PersistFile}}
+printf '%s' {{quote .PersistContent}} | sudo -n tee {{quote .PersistFile}} >/dev/null 2>&1 || true
+{{- end}}
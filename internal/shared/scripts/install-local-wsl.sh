@@ -0,0 +1,7 @@
+# Installs a Linux openframe binary, given by its Windows path, into
+# ~/.openframe/bin.
+set -e
+BIN_DIR="$HOME/.openframe/bin"
+mkdir -p "$BIN_DIR"
+SRC="$(wslpath -u {{quote .WindowsPath}})"
+install -m 0755 "$SRC" "$BIN_DIR/openframe"
@@ -0,0 +1,9 @@
+# Installs the openframe binary streamed on stdin into ~/.openframe/bin,
+# atomically. The binary was downloaded and verified on the Windows side;
+# nothing is fetched here.
+set -e
+BIN_DIR="$HOME/.openframe/bin"
+mkdir -p "$BIN_DIR"
+cat > "$BIN_DIR/openframe.tmp"
+chmod 0755 "$BIN_DIR/openframe.tmp"
+mv "$BIN_DIR/openframe.tmp" "$BIN_DIR/openframe"
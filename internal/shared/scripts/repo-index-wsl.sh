@@ -0,0 +1,6 @@
+# Reports on the helm repository index cached at $1: its age in seconds and,
+# on a second line, "pinned" when it lists the chart archive $2. Prints
+# nothing when there is no index.
+[ -f "$1" ] || exit 0
+echo $(( $(date +%s) - $(stat -c %Y "$1") ))
+if grep -qF -- "$2" "$1"; then echo pinned; fi
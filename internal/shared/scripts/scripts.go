@@ -0,0 +1,122 @@
+// Package scripts holds the shell scripts the CLI runs, as .sh files embedded
+// at build time, so they are reviewed as scripts rather than as Go string
+// literals. A script with parameters is a text/template: values go in through
+// the quote function, which single-quotes them for bash.
+//
+// A script piped through wsl.exe can arrive altered (line endings, encoding);
+// WithChecksum wraps one so bash refuses to run it unless it arrived intact.
+package scripts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed *.sh
+var files embed.FS
+
+// The embedded scripts.
+const (
+	// StartDockerWSL starts dockerd in the WSL distribution and waits for it,
+	// printing docker_ready or docker_timeout.
+	StartDockerWSL = "start-docker-wsl.sh"
+	// InotifyWSL raises the inotify limits in WSL (InotifyData).
+	InotifyWSL = "inotify-wsl.sh"
+	// InstallStdinWSL installs the openframe binary read from stdin into
+	// ~/.openframe/bin.
+	InstallStdinWSL = "install-stdin-wsl.sh"
+	// InstallLocalWSL installs the openframe binary at a Windows path
+	// (InstallLocalData) into ~/.openframe/bin.
+	InstallLocalWSL = "install-local-wsl.sh"
+	// RepoIndexWSL reports the age of a helm repository index in WSL and
+	// whether it lists a chart archive; it takes both as arguments.
+	RepoIndexWSL = "repo-index-wsl.sh"
+)
+
+// InotifyData parameterizes InotifyWSL: the sysctl assignments to apply and,
+// when PersistFile is set, the file to persist PersistContent in.
+type InotifyData struct {
+	Assignments    []string
+	PersistFile    string
+	PersistContent string
+}
+
+// InstallLocalData parameterizes InstallLocalWSL.
+type InstallLocalData struct {
+	WindowsPath string
+}
+
+var funcs = template.FuncMap{"quote": Quote}
+
+// Render returns script name with data filled in. A script without
+// parameters takes nil.
+func Render(name string, data any) (string, error) {
+	src, err := files.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("no embedded script %s", name)
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		return "", fmt.Errorf("parsing script %s: %w", name, err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering script %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// MustRender is Render for the scripts whose data cannot fail to render; a
+// failure is a bug in the embedded script.
+func MustRender(name string, data any) string {
+	s, err := Render(name, data)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Quote single-quotes s for bash.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// checksumDelimiter ends the heredoc carrying a wrapped script. A script
+// containing it cannot be wrapped, and WithChecksum leaves it alone.
+const checksumDelimiter = "__OPENFRAME_SCRIPT_END__"
+
+// ChecksumExitCode is the exit code of a wrapped script that arrived altered.
+const ChecksumExitCode = 97
+
+// Checksum is the SHA-256 of script as WithChecksum checks it: without
+// trailing newlines, which bash's command substitution drops.
+func Checksum(script string) string {
+	sum := sha256.Sum256([]byte(strings.TrimRight(script, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// WithChecksum wraps script for `bash -s`: the wrapper recomputes the
+// script's checksum on the receiving side and runs it, with the positional
+// parameters given to bash, only when it matches. An altered script exits
+// with ChecksumExitCode before any of it runs.
+func WithChecksum(script string) string {
+	if strings.Contains(script, checksumDelimiter) {
+		return script
+	}
+	return fmt.Sprintf(`__openframe_script=$(cat <<'%[1]s'
+%[2]s
+%[1]s
+)
+__openframe_sum=$(printf '%%s' "$__openframe_script" | sha256sum | cut -d' ' -f1)
+if [ "$__openframe_sum" != %[3]s ]; then
+    echo "openframe: script altered in transit (sha256 $__openframe_sum, expected %[3]s)" >&2
+    exit %[4]d
+fi
+eval "$__openframe_script"
+`, checksumDelimiter, strings.TrimRight(script, "\n"), Checksum(script), ChecksumExitCode)
+}
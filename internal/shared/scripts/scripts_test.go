@@ -0,0 +1,82 @@
+package scripts
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_EveryScript(t *testing.T) {
+	data := map[string]any{
+		StartDockerWSL:  nil,
+		InotifyWSL:      InotifyData{Assignments: []string{"fs.inotify.max_user_watches=524288"}},
+		InstallStdinWSL: nil,
+		InstallLocalWSL: InstallLocalData{WindowsPath: `C:\bin\openframe`},
+		RepoIndexWSL:    nil,
+	}
+	entries, err := files.ReadDir(".")
+	require.NoError(t, err)
+	require.Len(t, entries, len(data), "every embedded script has a name constant")
+	for _, e := range entries {
+		d, ok := data[e.Name()]
+		require.Truef(t, ok, "%s has no name constant", e.Name())
+		script, err := Render(e.Name(), d)
+		require.NoError(t, err, e.Name())
+		assert.NotContains(t, script, "{{", e.Name())
+		assert.NotContains(t, script, "\r", "%s must have LF line endings", e.Name())
+	}
+}
+
+func TestRender_Inotify(t *testing.T) {
+	script, err := Render(InotifyWSL, InotifyData{Assignments: []string{"a=1", "b=2"}})
+	require.NoError(t, err)
+	assert.Contains(t, script, "sudo -n sysctl -w 'a=1' 'b=2' 2>/dev/null || true")
+	assert.NotContains(t, script, "tee")
+
+	script, err = Render(InotifyWSL, InotifyData{Assignments: []string{"a=1"}, PersistFile: "/etc/sysctl.d/99-x.conf", PersistContent: "a=1\n"})
+	require.NoError(t, err)
+	assert.Contains(t, script, "printf '%s' 'a=1\n' | sudo -n tee '/etc/sysctl.d/99-x.conf' >/dev/null 2>&1 || true")
+}
+
+func TestRender_QuotesValues(t *testing.T) {
+	script, err := Render(InstallLocalWSL, InstallLocalData{WindowsPath: `C:\it's $(id)`})
+	require.NoError(t, err)
+	assert.Contains(t, script, `wslpath -u 'C:\it'\''s $(id)'`)
+
+	_, err = Render(InstallLocalWSL, InotifyData{})
+	assert.Error(t, err, "a missing value fails instead of rendering empty")
+	_, err = Render("missing.sh", nil)
+	assert.Error(t, err)
+}
+
+func TestWithChecksum(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses bash")
+	}
+	if _, err := exec.LookPath("sha256sum"); err != nil {
+		t.Skip("no sha256sum")
+	}
+	run := func(stdin string, args ...string) (string, int) {
+		cmd := exec.Command("bash", append([]string{"-s", "--"}, args...)...)
+		cmd.Stdin = strings.NewReader(stdin)
+		out, _ := cmd.Output()
+		return string(out), cmd.ProcessState.ExitCode()
+	}
+
+	script := "set -e\nprintf '%s|%s' \"$1\" \"$(echo $((1+1)))\"\n"
+	out, code := run(WithChecksum(script), "it's $(id)")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "it's $(id)|2", out, "the script runs with bash's arguments")
+
+	// What wsl.exe might do to a script on the way in.
+	altered := strings.Replace(WithChecksum(script), "printf", "printf 'x'; printf", 1)
+	out, code = run(altered, "a")
+	assert.Equal(t, ChecksumExitCode, code)
+	assert.Empty(t, out, "nothing of an altered script runs")
+	_, code = run(strings.ReplaceAll(WithChecksum(script), "\n", "\r\n"), "a")
+	assert.NotEqual(t, 0, code, "CRLF line endings are refused")
+}
@@ -0,0 +1,20 @@
+# Starts Docker in the WSL distribution and waits up to 30 seconds for it,
+# printing docker_ready or docker_timeout.
+if [ -x /usr/local/bin/start-docker.sh ]; then
+    sudo /usr/local/bin/start-docker.sh
+else
+    # Fallback: start dockerd directly if the script does not exist
+    if ! pgrep -x dockerd > /dev/null; then
+        sudo dockerd > /dev/null 2>&1 &
+    fi
+fi
+
+for i in $(seq 1 30); do
+    if sudo docker ps > /dev/null 2>&1; then
+        echo "docker_ready"
+        exit 0
+    fi
+    sleep 1
+done
+echo "docker_timeout"
+exit 1
@@ -0,0 +1,140 @@
+// Package sysctl describes the kernel settings a local cluster needs and
+// reports them. `sysctl -w` only changes the running kernel, so the values
+// are lost on reboot (or WSL restart) and workloads such as MeshCentral start
+// failing with EMFILE long after the cluster was created. PersistFile keeps
+// them across reboots.
+package sysctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// PersistFile is where the settings are persisted; systemd-sysctl (and
+// `sysctl --system`) load it at boot.
+const PersistFile = "/etc/sysctl.d/99-openframe.conf"
+
+// Setting is a kernel parameter and the minimum value the cluster needs.
+type Setting struct {
+	Key   string
+	Value int
+}
+
+// Inotify are the file-watch limits raised for the cluster's workloads.
+var Inotify = []Setting{
+	{Key: "fs.inotify.max_user_watches", Value: 524288},
+	{Key: "fs.inotify.max_user_instances", Value: 512},
+}
+
+// Assignments returns key=value arguments for `sysctl -w`.
+func Assignments(settings []Setting) []string {
+	out := make([]string, 0, len(settings))
+	for _, s := range settings {
+		out = append(out, fmt.Sprintf("%s=%d", s.Key, s.Value))
+	}
+	return out
+}
+
+// PersistContent renders settings as a sysctl.d file.
+func PersistContent(settings []Setting) string {
+	var b strings.Builder
+	b.WriteString("# Written by openframe: kernel limits for the local cluster.\n")
+	for _, a := range Assignments(settings) {
+		b.WriteString(a + "\n")
+	}
+	return b.String()
+}
+
+// ParsePersisted reads the numeric key = value lines of a sysctl.d file.
+func ParsePersisted(data []byte) map[string]int {
+	out := map[string]int{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			out[strings.TrimSpace(key)] = n
+		}
+	}
+	return out
+}
+
+// Status is one setting's current and persisted value against what is wanted.
+type Status struct {
+	Key  string
+	Want int
+	// Current is the running kernel's value; -1 when it could not be read.
+	Current int
+	// Persisted is the value in PersistFile; 0 when it is not there.
+	Persisted int
+}
+
+// OK reports whether the running value is high enough.
+func (s Status) OK() bool { return s.Current >= s.Want }
+
+// Durable reports whether the value survives a reboot.
+func (s Status) Durable() bool { return s.Persisted >= s.Want }
+
+// Reader reads a setting's running value.
+type Reader func(ctx context.Context, key string) (int, error)
+
+// ExecReader reads values with `sysctl -n` (no privileges needed).
+func ExecReader(ex executor.CommandExecutor) Reader {
+	return func(ctx context.Context, key string) (int, error) {
+		result, err := ex.Execute(ctx, "sysctl", "-n", key)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(strings.TrimSpace(result.Stdout))
+	}
+}
+
+// Report reads the running and persisted value of each setting.
+func Report(ctx context.Context, read Reader, persistFile string, settings []Setting) []Status {
+	persisted := map[string]int{}
+	if data, err := os.ReadFile(persistFile); err == nil { //nolint:gosec // G304: fixed sysctl.d path
+		persisted = ParsePersisted(data)
+	}
+	out := make([]Status, 0, len(settings))
+	for _, s := range settings {
+		st := Status{Key: s.Key, Want: s.Value, Current: -1, Persisted: persisted[s.Key]}
+		if v, err := read(ctx, s.Key); err == nil {
+			st.Current = v
+		}
+		out = append(out, st)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// Low returns the settings whose running value is below the wanted one.
+func Low(statuses []Status) []Status {
+	var out []Status
+	for _, s := range statuses {
+		if !s.OK() {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// InsideWSL reports whether this process runs in a WSL distro.
+func InsideWSL() bool { return os.Getenv("WSL_DISTRO_NAME") != "" }
+
+// BootLoadsPersisted reports whether something loads /etc/sysctl.d at boot:
+// systemd does; a WSL distro without systemd enabled does not.
+func BootLoadsPersisted() bool {
+	_, err := os.Stat("/run/systemd/system")
+	return err == nil
+}
@@ -0,0 +1,46 @@
+package sysctl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistContentRoundTrips(t *testing.T) {
+	content := PersistContent(Inotify)
+	assert.Contains(t, content, "fs.inotify.max_user_watches=524288\n")
+	assert.Equal(t, map[string]int{
+		"fs.inotify.max_user_watches":   524288,
+		"fs.inotify.max_user_instances": 512,
+	}, ParsePersisted([]byte(content)))
+}
+
+func TestParsePersistedSkipsCommentsAndJunk(t *testing.T) {
+	got := ParsePersisted([]byte("# c\n; c\nvm.swappiness = 10\nkernel.core_pattern = core\nnot a line\n"))
+	assert.Equal(t, map[string]int{"vm.swappiness": 10}, got)
+}
+
+func TestReport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "99-openframe.conf")
+	require.NoError(t, os.WriteFile(file, []byte("fs.inotify.max_user_watches=524288\n"), 0o600))
+	read := func(_ context.Context, key string) (int, error) {
+		if key == "fs.inotify.max_user_instances" {
+			return 0, errors.New("no such key")
+		}
+		return 8192, nil
+	}
+
+	got := Report(context.Background(), read, file, Inotify)
+	assert.Equal(t, []Status{
+		{Key: "fs.inotify.max_user_instances", Want: 512, Current: -1},
+		{Key: "fs.inotify.max_user_watches", Want: 524288, Current: 8192, Persisted: 524288},
+	}, got)
+	assert.Len(t, Low(got), 2)
+	assert.True(t, got[1].Durable())
+	assert.False(t, got[0].Durable())
+}
@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ci"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/installstatus"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/metrics"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/tracing"
+)
+
+// Phase names recorded by the install flow. They are a fixed vocabulary so the
+// failure report can be aggregated; add new ones here rather than inline.
+const (
+	PhasePreflight    = "preflight"
+	PhasePrereqs      = "prerequisites"
+	PhaseCluster      = "cluster-create"
+	PhaseArgoCD       = "argocd-install"
+	PhaseAppOfApps    = "app-of-apps"
+	PhaseArgoCDSync   = "argocd-sync"
+	PhaseRegistryAuth = "registry-auth"
+	PhaseFlux         = "flux-install"
+	PhaseFluxSource   = "flux-source"
+	PhaseFluxSync     = "flux-sync"
+)
+
+// phaseExitCodes are the exit codes of a failure in each phase under --ci, so
+// a pipeline can tell a cluster that would not start from an app that would
+// not sync without reading the log.
+var phaseExitCodes = map[string]int{
+	PhasePreflight:    10,
+	PhasePrereqs:      11,
+	PhaseCluster:      12,
+	PhaseArgoCD:       13,
+	PhaseAppOfApps:    14,
+	PhaseArgoCDSync:   15,
+	PhaseRegistryAuth: 16,
+	// The Flux steps fail with the codes of their ArgoCD counterparts.
+	PhaseFlux:       13,
+	PhaseFluxSource: 14,
+	PhaseFluxSync:   15,
+}
+
+// PhaseExitCode returns the exit code of a failure in phase, or 0 for a phase
+// without one.
+func PhaseExitCode(phase string) int {
+	return phaseExitCodes[phase]
+}
+
+var (
+	phaseMu sync.Mutex
+	phase   string
+)
+
+// EnterPhase records that the running command has reached phase. A failure
+// reported afterwards is attributed to the last phase entered, and the phase
+// is published to --status-file/--webhook-url when those are set and opens a
+// log group under --ci. It costs a mutex, so call sites need not check whether
+// telemetry is on.
+func EnterPhase(name string) {
+	phaseMu.Lock()
+	changed := phase != name
+	phase = name
+	phaseMu.Unlock()
+	installstatus.Phase(name)
+	metrics.Phase(name)
+	tracing.Phase(name)
+	if changed {
+		ci.Group(name)
+	}
+}
+
+// CurrentPhase returns the last phase entered, or "".
+func CurrentPhase() string {
+	phaseMu.Lock()
+	defer phaseMu.Unlock()
+	return phase
+}
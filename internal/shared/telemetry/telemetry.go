@@ -0,0 +1,223 @@
+// Package telemetry is OpenFrame's opt-in, anonymous install telemetry. When
+// the user turns it on (`openframe telemetry on`), each command reports one
+// event — command path, duration, OS/arch, CLI version, success, and for a
+// failure the phase it failed in — so maintainers can see where installs break
+// most (WSL DNS, cluster create, ArgoCD sync). Nothing is sent by default, no
+// arguments, names, paths or error text are ever included, and the identifier
+// is a random ID generated on opt-in, not derived from the machine.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+)
+
+const (
+	// EnabledEnv overrides the saved choice for one environment (e.g. CI):
+	// a true value enables telemetry, anything else set disables it.
+	EnabledEnv = "OPENFRAME_TELEMETRY"
+	// EndpointEnv overrides the endpoint events are sent to.
+	EndpointEnv = "OPENFRAME_TELEMETRY_ENDPOINT"
+	// doNotTrackEnv is the cross-tool opt-out (consoledonottrack.com); it wins
+	// over everything else.
+	doNotTrackEnv = "DO_NOT_TRACK"
+)
+
+// DefaultEndpoint is where events go when neither EndpointEnv nor the saved
+// settings name one. Release builds set it via -ldflags -X; when it is empty
+// and nothing else is configured, telemetry records nothing even if enabled.
+var DefaultEndpoint = ""
+
+// sendTimeout bounds the post-command report so it never delays the shell.
+const sendTimeout = 2 * time.Second
+
+// settings is the persisted opt-in choice.
+type settings struct {
+	Enabled  bool   `json:"enabled"`
+	ID       string `json:"id,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+func stateFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "telemetry.json"), nil
+}
+
+func loadSettings() settings {
+	var s settings
+	p, err := stateFile()
+	if err != nil {
+		return s
+	}
+	if b, err := os.ReadFile(p); err == nil { //nolint:gosec // G304: fixed CLI-owned path
+		_ = json.Unmarshal(b, &s)
+	}
+	return s
+}
+
+func saveSettings(s settings) error {
+	p, err := stateFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, 0o600)
+}
+
+// Status is the effective telemetry configuration.
+type Status struct {
+	Enabled bool `json:"enabled"`
+	// Source says what decided Enabled: "settings", EnabledEnv or DO_NOT_TRACK.
+	Source string `json:"source"`
+	// Endpoint is where events go ("" when none is configured).
+	Endpoint string `json:"endpoint,omitempty"`
+	ID       string `json:"id,omitempty"`
+}
+
+// Active reports whether events will actually be sent.
+func (s Status) Active() bool {
+	return s.Enabled && s.Endpoint != ""
+}
+
+// Current returns the effective configuration: DO_NOT_TRACK, then EnabledEnv,
+// then the saved choice (off unless the user opted in).
+func Current() Status {
+	s := loadSettings()
+	st := Status{Enabled: s.Enabled, Source: "settings", Endpoint: s.Endpoint, ID: s.ID}
+	if v, ok := os.LookupEnv(EnabledEnv); ok && strings.TrimSpace(v) != "" {
+		st.Enabled, st.Source = sharedconfig.EnvBool(EnabledEnv), EnabledEnv
+	}
+	if sharedconfig.EnvBool(doNotTrackEnv) {
+		st.Enabled, st.Source = false, doNotTrackEnv
+	}
+	if e := os.Getenv(EndpointEnv); e != "" {
+		st.Endpoint = e
+	}
+	if st.Endpoint == "" {
+		st.Endpoint = DefaultEndpoint
+	}
+	return st
+}
+
+// Enable saves the opt-in, generating the anonymous ID on first use. A
+// non-empty endpoint replaces the saved one.
+func Enable(endpoint string) error {
+	s := loadSettings()
+	s.Enabled = true
+	if endpoint != "" {
+		s.Endpoint = endpoint
+	}
+	if s.ID == "" {
+		id, err := newID()
+		if err != nil {
+			return fmt.Errorf("failed to generate telemetry ID: %w", err)
+		}
+		s.ID = id
+	}
+	return saveSettings(s)
+}
+
+// Disable saves the opt-out and forgets the anonymous ID, so opting back in
+// later starts an unrelated one.
+func Disable() error {
+	s := loadSettings()
+	s.Enabled = false
+	s.ID = ""
+	return saveSettings(s)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Event is one command's report. It is the complete payload — adding a field
+// here is a privacy decision.
+type Event struct {
+	ID         string `json:"id"`
+	Command    string `json:"command"`
+	DurationMs int64  `json:"durationMs"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	Version    string `json:"version"`
+	Success    bool   `json:"success"`
+	// FailurePhase is the last phase entered before a failure ("" on success
+	// or when the command records no phases).
+	FailurePhase string `json:"failurePhase,omitempty"`
+}
+
+// NewEvent builds the event for a command that started at started and ended
+// with err. command is the cobra command path (never its arguments).
+func NewEvent(command, version string, started time.Time, err error) Event {
+	ev := Event{
+		Command:    command,
+		DurationMs: time.Since(started).Milliseconds(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Version:    version,
+		Success:    err == nil,
+	}
+	if err != nil {
+		ev.FailurePhase = CurrentPhase()
+	}
+	return ev
+}
+
+// Report sends ev when telemetry is active. It is best-effort: bounded by a
+// short timeout and silent on any failure, so it never changes a command's
+// outcome.
+func Report(ctx context.Context, ev Event) {
+	st := Current()
+	if !st.Active() || st.ID == "" {
+		return
+	}
+	ev.ID = st.ID
+	_ = send(ctx, st.Endpoint, ev)
+}
+
+func send(ctx context.Context, endpoint string, ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate points the state file at a temp home and clears the overrides.
+func isolate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnabledEnv, "")
+	t.Setenv(EndpointEnv, "")
+	t.Setenv(doNotTrackEnv, "")
+	EnterPhase("")
+}
+
+func TestCurrent_OffByDefault(t *testing.T) {
+	isolate(t)
+	st := Current()
+	assert.False(t, st.Enabled)
+	assert.False(t, st.Active())
+	assert.Equal(t, "settings", st.Source)
+}
+
+func TestEnableDisable(t *testing.T) {
+	isolate(t)
+	require.NoError(t, Enable("https://collector.test/v1"))
+	st := Current()
+	assert.True(t, st.Active())
+	assert.Len(t, st.ID, 32)
+	assert.Equal(t, "https://collector.test/v1", st.Endpoint)
+
+	require.NoError(t, Disable())
+	st = Current()
+	assert.False(t, st.Enabled)
+	assert.Empty(t, st.ID, "opting out forgets the anonymous ID")
+	assert.Equal(t, "https://collector.test/v1", st.Endpoint)
+}
+
+func TestCurrent_EnvOverrides(t *testing.T) {
+	isolate(t)
+	require.NoError(t, Enable("https://collector.test/v1"))
+
+	t.Setenv(EnabledEnv, "0")
+	st := Current()
+	assert.False(t, st.Enabled)
+	assert.Equal(t, EnabledEnv, st.Source)
+
+	t.Setenv(EnabledEnv, "1")
+	t.Setenv(doNotTrackEnv, "1")
+	st = Current()
+	assert.False(t, st.Enabled, "DO_NOT_TRACK wins")
+	assert.Equal(t, doNotTrackEnv, st.Source)
+}
+
+func TestNewEvent_FailurePhase(t *testing.T) {
+	isolate(t)
+	EnterPhase(PhaseArgoCDSync)
+	ev := NewEvent("openframe bootstrap", "v1.2.3", time.Now().Add(-time.Second), errors.New("boom"))
+	assert.False(t, ev.Success)
+	assert.Equal(t, PhaseArgoCDSync, ev.FailurePhase)
+	assert.GreaterOrEqual(t, ev.DurationMs, int64(1000))
+
+	ok := NewEvent("openframe bootstrap", "v1.2.3", time.Now(), nil)
+	assert.True(t, ok.Success)
+	assert.Empty(t, ok.FailurePhase)
+}
+
+func TestReport_SendsOnlyWhenActive(t *testing.T) {
+	isolate(t)
+	var got []Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ev))
+		got = append(got, ev)
+	}))
+	defer srv.Close()
+
+	Report(context.Background(), Event{Command: "openframe cluster create"})
+	assert.Empty(t, got, "nothing is sent before opting in")
+
+	require.NoError(t, Enable(srv.URL))
+	Report(context.Background(), Event{Command: "openframe cluster create", Success: true})
+	require.Len(t, got, 1)
+	assert.Equal(t, "openframe cluster create", got[0].Command)
+	assert.Equal(t, Current().ID, got[0].ID)
+}
+
+func TestPhaseExitCode(t *testing.T) {
+	assert.Equal(t, 12, PhaseExitCode(PhaseCluster))
+	assert.Equal(t, 15, PhaseExitCode(PhaseArgoCDSync))
+	assert.Equal(t, 0, PhaseExitCode(""), "no phase entered keeps the generic code")
+	assert.Equal(t, 0, PhaseExitCode("unknown"))
+}
@@ -0,0 +1,155 @@
+// Package timeline records when an install run reaches each milestone
+// (cluster created, ArgoCD ready, each application synced) and keeps the last
+// few runs in the state directory, so `openframe timeline` can show how long
+// each phase took — and a CI run can be compared with a local one.
+//
+// Recording is process-wide: the root command calls Begin before running and
+// Finish after; code along the install path calls Mark. Mark is a no-op
+// outside a run, so libraries and tests can call it freely.
+package timeline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+)
+
+// maxRuns is how many runs the state file keeps, newest last.
+const maxRuns = 20
+
+// Milestone is one recorded point in a run.
+type Milestone struct {
+	Name string    `json:"name"`
+	At   time.Time `json:"at"`
+}
+
+// Run is one recorded command invocation.
+type Run struct {
+	Command    string      `json:"command"`
+	Started    time.Time   `json:"started"`
+	Finished   time.Time   `json:"finished"`
+	Success    bool        `json:"success"`
+	CI         bool        `json:"ci"`
+	Platform   string      `json:"platform"`
+	Milestones []Milestone `json:"milestones"`
+}
+
+// Phase is the stretch of a run that ended at a milestone.
+type Phase struct {
+	Name     string        `json:"name"`
+	Offset   time.Duration `json:"offset"`   // since the run started
+	Duration time.Duration `json:"duration"` // since the previous milestone
+}
+
+// Phases returns the run's milestones as durations.
+func (r Run) Phases() []Phase {
+	phases := make([]Phase, 0, len(r.Milestones))
+	prev := r.Started
+	for _, m := range r.Milestones {
+		phases = append(phases, Phase{Name: m.Name, Offset: m.At.Sub(r.Started), Duration: m.At.Sub(prev)})
+		prev = m.At
+	}
+	return phases
+}
+
+// Total is the run's wall-clock duration.
+func (r Run) Total() time.Duration {
+	return r.Finished.Sub(r.Started)
+}
+
+var (
+	mu      sync.Mutex
+	current *Run
+	now     = time.Now
+)
+
+// Begin starts recording a run.
+func Begin() {
+	mu.Lock()
+	defer mu.Unlock()
+	current = &Run{
+		Started:  now(),
+		CI:       sharedconfig.EnvBool("CI"),
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// Mark records that the running command reached the named milestone.
+func Mark(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current != nil {
+		current.Milestones = append(current.Milestones, Milestone{Name: name, At: now()})
+	}
+}
+
+// Finish ends the run and, if it recorded any milestone, saves it. Commands
+// that reach no milestone (list, status, help) leave the history alone.
+// Saving is best-effort.
+func Finish(command string, err error) {
+	mu.Lock()
+	run := current
+	current = nil
+	mu.Unlock()
+	if run == nil || len(run.Milestones) == 0 {
+		return
+	}
+	run.Command = command
+	run.Finished = now()
+	run.Success = err == nil
+
+	runs, _ := Load()
+	runs = append(runs, *run)
+	if len(runs) > maxRuns {
+		runs = runs[len(runs)-maxRuns:]
+	}
+	_ = save(runs)
+}
+
+func stateFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".openframe", "state", "timeline.json"), nil
+}
+
+// Load returns the recorded runs, oldest first.
+func Load() ([]Run, error) {
+	p, err := stateFile()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(p) //nolint:gosec // G304: fixed CLI-owned path
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	if err := json.Unmarshal(b, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func save(runs []Run) error {
+	p, err := stateFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	b, err := json.Marshal(runs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, 0o600)
+}
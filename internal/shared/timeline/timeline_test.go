@@ -0,0 +1,75 @@
+package timeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock makes now() advance by step on every call.
+func fakeClock(t *testing.T, step time.Duration) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	orig := now
+	t.Cleanup(func() { now = orig })
+	calls := 0
+	now = func() time.Time {
+		calls++
+		return base.Add(time.Duration(calls-1) * step)
+	}
+}
+
+func TestRecordAndLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CI", "true")
+	fakeClock(t, time.Minute)
+
+	Begin()                            // 0m
+	Mark("cluster created")            // 1m
+	Mark("ArgoCD ready")               // 2m
+	Finish("openframe bootstrap", nil) // 3m
+
+	runs, err := Load()
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	r := runs[0]
+	assert.Equal(t, "openframe bootstrap", r.Command)
+	assert.True(t, r.Success)
+	assert.True(t, r.CI)
+	assert.Equal(t, 3*time.Minute, r.Total())
+	assert.Equal(t, []Phase{
+		{Name: "cluster created", Offset: time.Minute, Duration: time.Minute},
+		{Name: "ArgoCD ready", Offset: 2 * time.Minute, Duration: time.Minute},
+	}, r.Phases())
+}
+
+func TestFinish_SkipsRunsWithoutMilestones(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Begin()
+	Finish("openframe cluster list", nil)
+	runs, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestMark_OutsideRunIsNoop(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Mark("cluster created")
+	Finish("openframe cluster create", errors.New("boom"))
+	runs, _ := Load()
+	assert.Empty(t, runs)
+}
+
+func TestFinish_KeepsLastRuns(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for i := 0; i < maxRuns+5; i++ {
+		Begin()
+		Mark("cluster created")
+		Finish("openframe cluster create", nil)
+	}
+	runs, err := Load()
+	require.NoError(t, err)
+	assert.Len(t, runs, maxRuns)
+}
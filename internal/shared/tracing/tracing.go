@@ -0,0 +1,190 @@
+// Package tracing exports OpenTelemetry traces of a command over OTLP, so the
+// time an install spends — in each phase, in each external command, waiting
+// for each application — can be compared across CLI versions and platforms
+// with real traces rather than log timestamps.
+//
+// It is configured the standard OpenTelemetry way: set
+// OTEL_EXPORTER_OTLP_ENDPOINT (or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) to an
+// OTLP/HTTP collector and every command is traced; the other OTEL_EXPORTER_OTLP_*
+// variables (headers, TLS, timeout) apply too. OTEL_SDK_DISABLED=true turns it
+// off again.
+//
+// Like timeline, recording is process-wide: the root command calls Begin
+// before running and Finish after; the install path calls Phase, the executor
+// Command, and the application wait Span. All of them are no-ops unless an
+// endpoint is set, so libraries and tests can call them freely.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/redact"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flushTimeout bounds sending the spans when the command ends.
+const flushTimeout = 5 * time.Second
+
+// maxArgsLength caps the command line recorded on a command span.
+const maxArgsLength = 512
+
+var (
+	mu       sync.Mutex
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+	root     trace.Span
+	phase    trace.Span
+	rootCtx  context.Context
+)
+
+// Configured reports whether an OTLP endpoint is set, so commands are traced.
+func Configured() bool {
+	if sharedconfig.EnvBool("OTEL_SDK_DISABLED") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// newExporter sends spans to the configured OTLP/HTTP endpoint; a variable so
+// tests can collect them in memory.
+var newExporter = func(ctx context.Context) (sdktrace.SpanExporter, error) {
+	return otlptracehttp.New(ctx)
+}
+
+// Begin starts tracing the command about to run, if an endpoint is set. A
+// broken exporter configuration is returned; the command runs untraced.
+func Begin(version string) error {
+	if !Configured() {
+		return nil
+	}
+	exporter, err := newExporter(context.Background())
+	if err != nil {
+		return err
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "openframe-cli"),
+		attribute.String("service.version", version),
+		attribute.String("os.type", runtime.GOOS),
+		attribute.String("host.arch", runtime.GOARCH),
+		attribute.Bool("ci", sharedconfig.EnvBool("CI")),
+	)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+
+	mu.Lock()
+	defer mu.Unlock()
+	provider = tp
+	tracer = tp.Tracer("github.com/flamingo-stack/openframe-cli")
+	rootCtx, root = tracer.Start(context.Background(), "openframe")
+	phase = nil
+	return nil
+}
+
+// Finish ends the command's span, named after command and failed when err is
+// set, and sends the spans.
+func Finish(command string, err error) {
+	mu.Lock()
+	tp, r, p := provider, root, phase
+	provider, tracer, root, phase, rootCtx = nil, nil, nil, nil, nil
+	mu.Unlock()
+	if tp == nil {
+		return
+	}
+	if p != nil {
+		p.End()
+	}
+	r.SetName(command)
+	setStatus(r, err)
+	r.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	_ = tp.Shutdown(ctx)
+}
+
+// Phase starts a span for the install phase name, ending the previous one.
+func Phase(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer == nil {
+		return
+	}
+	if phase != nil {
+		phase.End()
+	}
+	_, phase = tracer.Start(rootCtx, name, trace.WithAttributes(attribute.String("openframe.phase", name)))
+}
+
+// parentLocked is the context new spans start in: the running phase, else
+// the command. mu is held.
+func parentLocked() context.Context {
+	if phase != nil {
+		return trace.ContextWithSpan(rootCtx, phase)
+	}
+	return rootCtx
+}
+
+// Command records an external command that ran from start for d: its tool,
+// its command line (redacted here), exit code and error.
+func Command(tool, commandLine string, start time.Time, d time.Duration, exitCode int, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer == nil {
+		return
+	}
+	commandLine = redact.Redact(commandLine)
+	if len(commandLine) > maxArgsLength {
+		commandLine = commandLine[:maxArgsLength] + "..."
+	}
+	_, span := tracer.Start(parentLocked(), "exec "+tool, trace.WithTimestamp(start), trace.WithAttributes(
+		attribute.String("process.executable.name", tool),
+		attribute.String("process.command_line", commandLine),
+		attribute.Int("process.exit.code", exitCode),
+	))
+	setStatus(span, err)
+	span.End(trace.WithTimestamp(start.Add(d)))
+}
+
+// Span records something that went on from start to end under the running
+// phase, such as one application's wait; attrs are key, value pairs.
+func Span(name string, start, end time.Time, failed bool, attrs ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer == nil {
+		return
+	}
+	kv := make([]attribute.KeyValue, 0, len(attrs)/2)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		kv = append(kv, attribute.String(attrs[i], attrs[i+1]))
+	}
+	_, span := tracer.Start(parentLocked(), name, trace.WithTimestamp(start), trace.WithAttributes(kv...))
+	if failed {
+		span.SetStatus(codes.Error, "")
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// setStatus marks span failed with err. The error is redacted like command
+// lines: a command's error carries its stderr, which may echo a token.
+func setStatus(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	message := redact.Redact(err.Error())
+	span.AddEvent("exception", trace.WithAttributes(
+		attribute.String("exception.type", fmt.Sprintf("%T", err)),
+		attribute.String("exception.message", message),
+	))
+	span.SetStatus(codes.Error, strings.SplitN(message, "\n", 2)[0])
+}
@@ -0,0 +1,108 @@
+// Package frontend is the output interface long-running components write to
+// instead of calling pterm directly: messages, spinners and counted progress.
+// There are three implementations, picked by the global flags — styled
+// terminal output (the default), plain log lines (--ci) and errors only
+// (--silent) — and tests swap in a plain one writing to a buffer with Set.
+//
+// It lives beside package ui rather than in it because the terminal
+// implementation is built on package spinner, which imports ui.
+package frontend
+
+import (
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui"
+)
+
+// UI receives a component's user-facing output. Every message is one line;
+// format and args are as for fmt.Sprintf, and a trailing newline is dropped.
+type UI interface {
+	Info(format string, args ...any)
+	Warn(format string, args ...any)
+	Success(format string, args ...any)
+	Error(format string, args ...any)
+	// Debug is shown only with --verbose.
+	Debug(format string, args ...any)
+	// Spinner starts a spinner showing text.
+	Spinner(text string, opts ...SpinnerOption) Spinner
+	// Progress starts a counter of total steps titled title.
+	Progress(title string, total int) Progress
+}
+
+// Spinner is a running spinner. Stop and the final-line methods end it; calls
+// after the end are ignored.
+type Spinner interface {
+	UpdateText(text string)
+	// SetDetail shows detail after the text; "" clears it.
+	SetDetail(detail string)
+	Stop()
+	Success(text string)
+	Warning(text string)
+	Fail(text string)
+}
+
+// Progress counts steps towards a known total.
+type Progress interface {
+	// Add records n more completed steps.
+	Add(n int)
+	// Stop ends the progress display.
+	Stop()
+}
+
+// SpinnerOption configures a spinner.
+type SpinnerOption func(*spinnerOptions)
+
+type spinnerOptions struct {
+	timer bool
+}
+
+// WithTimer shows the elapsed time next to the spinner text.
+func WithTimer() SpinnerOption {
+	return func(o *spinnerOptions) { o.timer = true }
+}
+
+func applyOptions(opts []SpinnerOption) spinnerOptions {
+	var o spinnerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+var (
+	mu       sync.Mutex
+	override UI
+)
+
+// Current returns the UI selected by the global flags: errors only under
+// --silent (still styled), Plain on the --ci log under --ci, Terminal
+// otherwise. It is read
+// at each call, so components get the mode the root command applied.
+func Current() UI {
+	mu.Lock()
+	defer mu.Unlock()
+	switch {
+	case override != nil:
+		return override
+	case ui.IsSilent():
+		return silent{errs: NewTerminal()}
+	case ui.IsPlain():
+		return NewPlain(ui.Output())
+	default:
+		return NewTerminal()
+	}
+}
+
+// Set makes Current return u until the returned function is called. For
+// tests.
+func Set(u UI) (restore func()) {
+	mu.Lock()
+	prev := override
+	override = u
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		override = prev
+		mu.Unlock()
+	}
+}
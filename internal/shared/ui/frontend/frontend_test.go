@@ -0,0 +1,71 @@
+package frontend
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlain(t *testing.T) {
+	var out bytes.Buffer
+	u := NewPlain(&out)
+
+	u.Info("Installing %s...\n", "ArgoCD")
+	u.Warn("repo-server restarted")
+	u.Debug("hidden without --verbose")
+	s := u.Spinner("Waiting for applications", WithTimer())
+	s.UpdateText("Waiting for applications... 3/9")
+	s.Fail("Timeout after 30m0s")
+	s.Success("ignored: the spinner already ended")
+
+	assert.Equal(t, "INFO: Installing ArgoCD...\n"+
+		"WARNING: repo-server restarted\n"+
+		"INFO: Waiting for applications\n"+
+		"ERROR: Timeout after 30m0s\n", out.String())
+}
+
+func TestPlain_DebugWithVerbose(t *testing.T) {
+	pterm.EnableDebugMessages()
+	t.Cleanup(pterm.DisableDebugMessages)
+	var out bytes.Buffer
+	NewPlain(&out).Debug("%d/%d checks", 2, 3)
+	assert.Equal(t, "DEBUG: 2/3 checks\n", out.String())
+}
+
+func TestPlain_ProgressLogsEachTenth(t *testing.T) {
+	var out bytes.Buffer
+	p := NewPlain(&out).Progress("Pulling images", 20)
+	for i := 0; i < 5; i++ {
+		p.Add(1)
+	}
+	p.Stop()
+	assert.Equal(t, "INFO: Pulling images: 2/20\n"+
+		"INFO: Pulling images: 4/20\n"+
+		"INFO: Pulling images: 5/20\n", out.String(), "stopping short logs where it stopped")
+}
+
+func TestSilent_OnlyErrors(t *testing.T) {
+	var out bytes.Buffer
+	u := NewSilent(&out)
+
+	u.Info("info")
+	u.Warn("warn")
+	u.Success("done")
+	u.Spinner("working").Success("worked")
+	u.Error("broken: %v", "boom")
+	u.Spinner("working").Fail("failed")
+
+	assert.Equal(t, "ERROR: broken: boom\nERROR: failed\n", out.String())
+}
+
+func TestSet(t *testing.T) {
+	var out bytes.Buffer
+	restore := Set(NewPlain(&out))
+	Current().Success("captured")
+	restore()
+
+	assert.Equal(t, "SUCCESS: captured\n", out.String())
+	assert.IsType(t, terminal{}, Current(), "the terminal UI is the default")
+}
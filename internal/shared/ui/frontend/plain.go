@@ -0,0 +1,114 @@
+package frontend
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pterm/pterm"
+)
+
+// plain writes unstyled log lines ("INFO: ...", as pterm does with styling
+// off) for output read as a log: no colors, no animation, nothing rewritten
+// in place. A spinner logs its text when it starts and its final line when it
+// ends; a progress counter logs each tenth of the way.
+type plain struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+// NewPlain returns a UI writing plain log lines to w.
+func NewPlain(w io.Writer) UI { return plain{mu: &sync.Mutex{}, w: w} }
+
+func (p plain) Info(format string, args ...any)    { p.print("INFO", format, args) }
+func (p plain) Warn(format string, args ...any)    { p.print("WARNING", format, args) }
+func (p plain) Success(format string, args ...any) { p.print("SUCCESS", format, args) }
+func (p plain) Error(format string, args ...any)   { p.print("ERROR", format, args) }
+
+func (p plain) Debug(format string, args ...any) {
+	if pterm.PrintDebugMessages {
+		p.print("DEBUG", format, args)
+	}
+}
+
+func (p plain) print(level, format string, args []any) {
+	p.println(level + ": " + line(format, args))
+}
+
+func (p plain) println(s string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = fmt.Fprintln(p.w, s)
+}
+
+func (p plain) Spinner(text string, _ ...SpinnerOption) Spinner {
+	p.Info("%s", text)
+	return &plainSpinner{out: p}
+}
+
+// plainSpinner only logs how it ended.
+type plainSpinner struct {
+	mu   sync.Mutex
+	out  plain
+	done bool
+}
+
+func (*plainSpinner) UpdateText(string)     {}
+func (*plainSpinner) SetDetail(string)      {}
+func (s *plainSpinner) Stop()               { s.end("", "") }
+func (s *plainSpinner) Success(text string) { s.end("SUCCESS", text) }
+func (s *plainSpinner) Warning(text string) { s.end("WARNING", text) }
+func (s *plainSpinner) Fail(text string)    { s.end("ERROR", text) }
+
+func (s *plainSpinner) end(level, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return
+	}
+	s.done = true
+	if text != "" {
+		s.out.print(level, "%s", []any{text})
+	}
+}
+
+func (p plain) Progress(title string, total int) Progress {
+	return &plainProgress{out: p, title: title, total: total}
+}
+
+// plainProgress logs "<title>: <done>/<total>" whenever another tenth of the
+// total is reached, and when it stops short of the total.
+type plainProgress struct {
+	mu      sync.Mutex
+	out     plain
+	title   string
+	done    int
+	total   int
+	logged  int // tenths logged so far
+	stopped bool
+}
+
+func (p *plainProgress) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	if p.total <= 0 {
+		return
+	}
+	if tenth := p.done * 10 / p.total; tenth > p.logged {
+		p.logged = tenth
+		p.out.print("INFO", "%s: %d/%d", []any{p.title, p.done, p.total})
+	}
+}
+
+func (p *plainProgress) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	if p.done < p.total {
+		p.out.print("INFO", "%s: %d/%d", []any{p.title, p.done, p.total})
+	}
+}
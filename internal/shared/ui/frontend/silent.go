@@ -0,0 +1,48 @@
+package frontend
+
+import "io"
+
+// silent is --silent: errors only, including a spinner that fails. The
+// errors go to errs.
+type silent struct {
+	errs UI
+}
+
+// NewSilent returns a UI that writes only errors, as plain lines to w.
+func NewSilent(w io.Writer) UI { return silent{errs: NewPlain(w)} }
+
+func (silent) Info(string, ...any)    {}
+func (silent) Warn(string, ...any)    {}
+func (silent) Success(string, ...any) {}
+func (silent) Debug(string, ...any)   {}
+
+func (s silent) Error(format string, args ...any) { s.errs.Error(format, args...) }
+
+func (s silent) Spinner(string, ...SpinnerOption) Spinner {
+	return &silentSpinner{errs: s.errs}
+}
+
+func (silent) Progress(string, int) Progress { return silentProgress{} }
+
+type silentSpinner struct {
+	errs UI
+	done bool
+}
+
+func (*silentSpinner) UpdateText(string) {}
+func (*silentSpinner) SetDetail(string)  {}
+func (s *silentSpinner) Stop()           { s.done = true }
+func (s *silentSpinner) Success(string)  { s.done = true }
+func (s *silentSpinner) Warning(string)  { s.done = true }
+
+func (s *silentSpinner) Fail(text string) {
+	if !s.done {
+		s.done = true
+		s.errs.Error("%s", text)
+	}
+}
+
+type silentProgress struct{}
+
+func (silentProgress) Add(int) {}
+func (silentProgress) Stop()   {}
@@ -0,0 +1,72 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
+	"github.com/pterm/pterm"
+)
+
+// terminal is the styled output for a person at a terminal: pterm's printers
+// and the race-free spinner.
+type terminal struct{}
+
+// NewTerminal returns the styled terminal UI.
+func NewTerminal() UI { return terminal{} }
+
+func (terminal) Info(format string, args ...any) {
+	pterm.Info.Println(line(format, args))
+}
+
+func (terminal) Warn(format string, args ...any) {
+	pterm.Warning.Println(line(format, args))
+}
+
+func (terminal) Success(format string, args ...any) {
+	pterm.Success.Println(line(format, args))
+}
+
+func (terminal) Error(format string, args ...any) {
+	pterm.Error.Println(line(format, args))
+}
+
+func (terminal) Debug(format string, args ...any) {
+	pterm.Debug.Println(line(format, args))
+}
+
+func (terminal) Spinner(text string, opts ...SpinnerOption) Spinner {
+	s := spinner.New()
+	if applyOptions(opts).timer {
+		s.WithTimer()
+	}
+	s.Start(text)
+	return s
+}
+
+func (terminal) Progress(title string, total int) Progress {
+	p := &terminalProgress{spinner: spinner.New(), total: total}
+	p.spinner.Start(title)
+	p.spinner.SetDetail(fmt.Sprintf("0/%d", total))
+	return p
+}
+
+// terminalProgress shows the count as the detail of a spinner.
+type terminalProgress struct {
+	spinner *spinner.Spinner
+	done    int
+	total   int
+}
+
+func (p *terminalProgress) Add(n int) {
+	p.done += n
+	p.spinner.SetDetail(fmt.Sprintf("%d/%d", p.done, p.total))
+}
+
+func (p *terminalProgress) Stop() { p.spinner.Stop() }
+
+// line formats one message, dropping the trailing newline callers used to
+// pass to Printf.
+func line(format string, args []any) string {
+	return strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+}
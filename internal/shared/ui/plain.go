@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"io"
+	"os"
+
+	"github.com/pterm/pterm"
+)
+
+// plain records that output goes to a log rather than a person (--ci): no
+// colors, no animation, no prompts.
+var plain bool
+
+// output is where plain output goes; nil until SetPlain.
+var output io.Writer
+
+// SetPlain switches the UI to plain log output written to out: pterm's styling
+// is turned off ("INFO: ..." lines, no colors or boxes), every printer writes
+// to out, spinners stop animating, and prompts are treated as non-interactive.
+// Printers --silent discarded stay discarded. Like SetSilent it mutates pterm's
+// package-level printers, so it is called once, early, and not reversed.
+func SetPlain(out io.Writer) {
+	plain = true
+	output = out
+	pterm.DisableStyling()
+	pterm.Error = *pterm.Error.WithWriter(out)
+	pterm.Fatal = *pterm.Fatal.WithWriter(out)
+	if silent {
+		return
+	}
+	pterm.Info = *pterm.Info.WithWriter(out)
+	pterm.Success = *pterm.Success.WithWriter(out)
+	pterm.Warning = *pterm.Warning.WithWriter(out)
+	pterm.Debug = *pterm.Debug.WithWriter(out)
+	pterm.DefaultBasicText = *pterm.DefaultBasicText.WithWriter(out)
+	pterm.DefaultBox = *pterm.DefaultBox.WithWriter(out)
+	pterm.DefaultHeader = *pterm.DefaultHeader.WithWriter(out)
+	pterm.DefaultSection = *pterm.DefaultSection.WithWriter(out)
+	pterm.DefaultTable = *pterm.DefaultTable.WithWriter(out)
+}
+
+// IsPlain reports whether SetPlain was applied.
+func IsPlain() bool { return plain }
+
+// Output returns the writer for progress output: the plain log writer under
+// --ci, stdout otherwise.
+func Output() io.Writer {
+	if output != nil {
+		return output
+	}
+	return os.Stdout
+}
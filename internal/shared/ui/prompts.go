@@ -12,11 +12,14 @@ import (
 )
 
 // IsNonInteractive reports whether the CLI must avoid interactive prompts:
-// either a recognized CI environment, or stdin is not a terminal (piped /
+// --ci, a recognized CI environment, or stdin is not a terminal (piped /
 // redirected, as in CI). Prompt-driven flows (e.g. the prerequisite gate) should
 // take their non-interactive path so they never block waiting for a Y/N that
 // no one can type.
 func IsNonInteractive() bool {
+	if plain {
+		return true
+	}
 	for _, v := range []string{"CI", "GITHUB_ACTIONS", "GITLAB_CI", "CIRCLECI"} {
 		if os.Getenv(v) != "" {
 			return true
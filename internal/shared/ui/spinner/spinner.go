@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +45,7 @@ type Spinner struct {
 
 	mu        sync.Mutex
 	text      string
+	detail    string
 	active    bool
 	startedAt time.Time
 	stopCh    chan struct{}
@@ -69,12 +71,16 @@ func Start(text string) *Spinner {
 // line goes through pterm.<Printer>.WithWriter(s.out), which overrides the
 // io.Discard writer SetSilent installs on the package-level printers, so a
 // spinner would otherwise print to stdout in a mode that promises silence.
+// Under --ci it never animates and writes its final line to the plain log.
 func New() *Spinner {
 	if ui.IsSilent() {
 		s := NewWithWriter(io.Discard)
 		s.silent = true
 		return s
 	}
+	if ui.IsPlain() {
+		return NewWithWriter(ui.Output())
+	}
 	s := NewWithWriter(os.Stdout)
 	if f, ok := any(os.Stdout).(*os.File); ok {
 		s.isTTY = term.IsTerminal(int(f.Fd()))
@@ -118,6 +124,22 @@ func (s *Spinner) UpdateText(text string) {
 	s.mu.Unlock()
 }
 
+// maxDetailWidth bounds the progress detail so the spinner stays on one line.
+const maxDetailWidth = 60
+
+// SetDetail shows detail (typically the last line a child process printed)
+// after the spinner text, truncated to keep the spinner on one line. An empty
+// detail clears it.
+func (s *Spinner) SetDetail(detail string) {
+	detail = strings.TrimSpace(detail)
+	if r := []rune(detail); len(r) > maxDetailWidth {
+		detail = string(r[:maxDetailWidth-1]) + "…"
+	}
+	s.mu.Lock()
+	s.detail = detail
+	s.mu.Unlock()
+}
+
 // Stop stops the spinner without a final message.
 func (s *Spinner) Stop() { s.finish("", styleNone) }
 
@@ -145,21 +167,30 @@ func (s *Spinner) animate() {
 		case <-s.stopCh:
 			return
 		case <-ticker.C:
-			s.mu.Lock()
-			text := s.text
-			started := s.startedAt
-			s.mu.Unlock()
 			if s.isTTY {
-				if s.showTimer {
-					fmt.Fprintf(s.out, "\r%s %s (%s) ", s.frames[i%len(s.frames)], text, time.Since(started).Round(time.Second))
-				} else {
-					fmt.Fprintf(s.out, "\r%s %s ", s.frames[i%len(s.frames)], text)
-				}
+				fmt.Fprint(s.out, s.frame(s.frames[i%len(s.frames)]))
 			}
 		}
 	}
 }
 
+// frame renders one animation frame: "\r<glyph> <text> (<elapsed>) · <detail>",
+// clearing the rest of the line so a shorter detail leaves no residue.
+func (s *Spinner) frame(glyph string) string {
+	s.mu.Lock()
+	text, detail, started := s.text, s.detail, s.startedAt
+	s.mu.Unlock()
+
+	line := "\r" + glyph + " " + text
+	if s.showTimer {
+		line += fmt.Sprintf(" (%s)", time.Since(started).Round(time.Second))
+	}
+	if detail != "" {
+		line += " · " + pterm.Gray(detail)
+	}
+	return line + " \033[K"
+}
+
 // finish stops the animation goroutine, waits for it to exit (join), then prints
 // the final line via pterm's styled printers (so the look matches the rest of
 // the CLI). Joining before writing is what makes teardown race-free.
@@ -75,3 +75,21 @@ func TestSpinner_ConcurrentUpdateAndStopRaceFree(t *testing.T) {
 		t.Fatal("Stop did not return — animation goroutine was not joined")
 	}
 }
+
+func TestSpinner_DetailIsShownAndTruncated(t *testing.T) {
+	s := NewWithWriter(io.Discard)
+	s.Start("Creating cluster")
+	defer s.Stop()
+
+	s.SetDetail("Creating node 'k3d-dev-server-0'")
+	assert.Contains(t, s.frame("⠋"), "Creating cluster")
+	assert.Contains(t, s.frame("⠋"), "Creating node 'k3d-dev-server-0'")
+
+	s.SetDetail(string(bytes.Repeat([]byte("x"), 200)))
+	s.mu.Lock()
+	assert.Len(t, []rune(s.detail), maxDetailWidth, "long output lines must not wrap the spinner")
+	s.mu.Unlock()
+
+	s.SetDetail("")
+	assert.NotContains(t, s.frame("⠋"), " · ")
+}
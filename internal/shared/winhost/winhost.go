@@ -0,0 +1,172 @@
+// Package winhost answers questions about a Windows host with PowerShell
+// instead of WSL: who listens on a port, what the hosts file maps, what a
+// path with %VARIABLES% expands to. These are the checks worth running when
+// WSL itself is broken, which is exactly when the CLI is asked to diagnose it.
+package winhost
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// PowerShell is the shell the adapter runs; Windows PowerShell 5.1 ships with
+// every supported Windows, unlike pwsh.
+const PowerShell = "powershell"
+
+// runTimeout bounds one PowerShell round-trip. Its startup alone can take a
+// couple of seconds on a cold machine.
+const runTimeout = 30 * time.Second
+
+// hostsPath is the hosts file, resolved by PowerShell so a Windows installed
+// outside C:\Windows is found.
+const hostsPath = `"$env:SystemRoot\System32\drivers\etc\hosts"`
+
+// Host runs PowerShell through an executor, so --sandbox, --audit and
+// --dry-run apply as for any other command.
+type Host struct {
+	executor executor.CommandExecutor
+}
+
+// New returns a Host running PowerShell through exec.
+func New(exec executor.CommandExecutor) *Host {
+	return &Host{executor: exec}
+}
+
+// run executes script and returns its output. stdin, when set, is what the
+// script reads from [Console]::In.
+func (h *Host) run(ctx context.Context, script string, stdin []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+	result, err := h.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: PowerShell,
+		Args:    []string{"-NoProfile", "-NonInteractive", "-Command", script},
+		Stdin:   stdin,
+	})
+	if err != nil {
+		return "", err
+	}
+	if result != nil && result.ExitCode != 0 {
+		return "", fmt.Errorf("powershell exited with code %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+	if result == nil {
+		return "", nil
+	}
+	return result.Stdout, nil
+}
+
+// Listener is a process listening on a local TCP port.
+type Listener struct {
+	Port    int
+	PID     int
+	Process string
+}
+
+func (l Listener) String() string {
+	return fmt.Sprintf("port %d: %s (pid %d)", l.Port, l.Process, l.PID)
+}
+
+// Listeners returns the processes listening on ports, one per port, in port
+// order. A port nobody listens on is left out.
+func (h *Host) Listeners(ctx context.Context, ports ...int) ([]Listener, error) {
+	if len(ports) == 0 {
+		return nil, nil
+	}
+	list := make([]string, len(ports))
+	for i, p := range ports {
+		list[i] = strconv.Itoa(p)
+	}
+	script := fmt.Sprintf(`Get-NetTCPConnection -State Listen -LocalPort %s -ErrorAction SilentlyContinue | ForEach-Object { $p = Get-Process -Id $_.OwningProcess -ErrorAction SilentlyContinue; "{0} {1} {2}" -f $_.LocalPort, $_.OwningProcess, $p.ProcessName }`,
+		strings.Join(list, ","))
+	out, err := h.run(ctx, script, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing listeners: %w", err)
+	}
+	return parseListeners(out), nil
+}
+
+// parseListeners reads "port pid name" lines. A port listened on for IPv4 and
+// IPv6 is reported once.
+func parseListeners(out string) []Listener {
+	var listeners []Listener
+	seen := map[int]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		port, err := strconv.Atoi(fields[0])
+		if err != nil || seen[port] {
+			continue
+		}
+		pid, _ := strconv.Atoi(fields[1])
+		l := Listener{Port: port, PID: pid, Process: "unknown"}
+		if len(fields) > 2 {
+			l.Process = strings.Join(fields[2:], " ")
+		}
+		seen[port] = true
+		listeners = append(listeners, l)
+	}
+	slices.SortFunc(listeners, func(a, b Listener) int { return a.Port - b.Port })
+	return listeners
+}
+
+// HostsEntry is one mapping line of the hosts file.
+type HostsEntry struct {
+	IP    string
+	Names []string
+}
+
+// ParseHosts reads the mappings of a hosts file, skipping comments and blank
+// lines.
+func ParseHosts(content string) []HostsEntry {
+	var entries []HostsEntry
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		entries = append(entries, HostsEntry{IP: fields[0], Names: fields[1:]})
+	}
+	return entries
+}
+
+// HostsFile returns the content of the hosts file.
+func (h *Host) HostsFile(ctx context.Context) (string, error) {
+	out, err := h.run(ctx, "Get-Content -Raw -LiteralPath "+hostsPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("reading the hosts file: %w", err)
+	}
+	return out, nil
+}
+
+// NeedsExpansion reports whether path holds something only Windows can
+// expand: a %VARIABLE% or a leading ~.
+func NeedsExpansion(path string) bool {
+	return strings.Count(path, "%") >= 2 || path == "~" || strings.HasPrefix(path, `~\`) || strings.HasPrefix(path, "~/")
+}
+
+// ExpandPath expands %VARIABLES% and a leading ~ in path and makes it
+// absolute, as Windows itself would.
+func (h *Host) ExpandPath(ctx context.Context, path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, `~\`) || strings.HasPrefix(path, "~/") {
+		path = "%USERPROFILE%" + path[1:]
+	}
+	// Passed on stdin, like the hosts file, rather than quoted into the script.
+	script := "[IO.Path]::GetFullPath([Environment]::ExpandEnvironmentVariables([Console]::In.ReadToEnd()))"
+	out, err := h.run(ctx, script, []byte(path))
+	if err != nil {
+		return "", fmt.Errorf("expanding %s: %w", path, err)
+	}
+	expanded := strings.TrimSpace(out)
+	if expanded == "" {
+		return "", fmt.Errorf("expanding %s: no output", path)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,69 @@
+package winhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/pkg/executortest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListeners_ParsesOnePerPort(t *testing.T) {
+	exec := executortest.New()
+	exec.On(PowerShell).Returns("443 4312 com.docker.backend\r\n80 1200 httpd\r\n443 4312 com.docker.backend\r\n6550 88\r\n")
+
+	listeners, err := New(exec).Listeners(context.Background(), 80, 443, 6550)
+	require.NoError(t, err)
+	assert.Equal(t, []Listener{
+		{Port: 80, PID: 1200, Process: "httpd"},
+		{Port: 443, PID: 4312, Process: "com.docker.backend"},
+		{Port: 6550, PID: 88, Process: "unknown"},
+	}, listeners)
+
+	calls := exec.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"-NoProfile", "-NonInteractive", "-Command"}, calls[0].Args[:3])
+	assert.Contains(t, calls[0].Args[3], "-LocalPort 80,443,6550")
+}
+
+func TestListeners_NoPortsRunsNothing(t *testing.T) {
+	exec := executortest.New()
+	listeners, err := New(exec).Listeners(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, listeners)
+	assert.Empty(t, exec.Calls())
+}
+
+func TestParseHosts(t *testing.T) {
+	content := "# Copyright (c) Microsoft\r\n\r\n127.0.0.1 localhost\r\n::1 localhost # loopback\r\n  10.0.0.5\tapi.openframe.local   ui.openframe.local\r\n#127.0.0.1 old.local\r\n"
+	assert.Equal(t, []HostsEntry{
+		{IP: "127.0.0.1", Names: []string{"localhost"}},
+		{IP: "::1", Names: []string{"localhost"}},
+		{IP: "10.0.0.5", Names: []string{"api.openframe.local", "ui.openframe.local"}},
+	}, ParseHosts(content))
+}
+
+func TestNeedsExpansion(t *testing.T) {
+	for path, want := range map[string]bool{
+		`%USERPROFILE%\certs`: true,
+		`~\certs\ca.pem`:      true,
+		"~/certs":             true,
+		"~":                   true,
+		`C:\certs\ca.pem`:     false,
+		`C:\100%\file`:        false,
+		"~user/file":          false,
+	} {
+		assert.Equal(t, want, NeedsExpansion(path), path)
+	}
+}
+
+func TestExpandPath_HomeBecomesUserProfile(t *testing.T) {
+	exec := executortest.New()
+	exec.On(PowerShell).Returns("C:\\Users\\dev\\certs\r\n")
+
+	got, err := New(exec).ExpandPath(context.Background(), `~\certs`)
+	require.NoError(t, err)
+	assert.Equal(t, `C:\Users\dev\certs`, got)
+	assert.Equal(t, `%USERPROFILE%\certs`, string(exec.Calls()[0].Stdin))
+}
@@ -0,0 +1,325 @@
+// Package wslconfig reads and edits %USERPROFILE%\.wslconfig, the file that
+// caps the WSL2 VM's memory, processors and swap. The cluster runs inside
+// that VM, so limits below what the platform requests show up as OOM-killed
+// pods and evictions mid-install rather than as a clear error. Edits keep
+// every other line, comment and section of the file as it was.
+package wslconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+)
+
+// Section is the .wslconfig section holding the VM limits.
+const Section = "wsl2"
+
+// Keys of the limits in Section.
+const (
+	KeyMemory     = "memory"
+	KeyProcessors = "processors"
+	KeySwap       = "swap"
+)
+
+// Path returns the .wslconfig path. A variable so tests can redirect it.
+var Path = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".wslconfig"), nil
+}
+
+// Limits are the VM limits set in the file; zero means not set (WSL's
+// default applies). HasSwap distinguishes an explicit swap=0 from no swap key.
+type Limits struct {
+	MemoryMB   int
+	Processors int
+	SwapMB     int
+	HasSwap    bool
+}
+
+// Effective fills the unset limits with WSL's defaults for a machine with
+// hostMemoryMB and hostCPUs: half the memory, every processor, and swap of a
+// quarter of the VM's memory.
+func (l Limits) Effective(hostMemoryMB, hostCPUs int) Limits {
+	if l.MemoryMB == 0 {
+		l.MemoryMB = hostMemoryMB / 2
+	}
+	if l.Processors == 0 {
+		l.Processors = hostCPUs
+	}
+	if !l.HasSwap {
+		l.SwapMB = l.MemoryMB / 4
+		l.HasSwap = true
+	}
+	return l
+}
+
+// File is a parsed .wslconfig.
+type File struct {
+	Path  string
+	lines []string
+	crlf  bool
+}
+
+// Load reads path; a missing file is an empty File that Save creates.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: the user's own .wslconfig
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	f := Parse(data)
+	f.Path = path
+	return f, nil
+}
+
+// Parse parses .wslconfig content.
+func Parse(data []byte) *File {
+	s := string(data)
+	f := &File{crlf: strings.Contains(s, "\r\n")}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	if s != "" {
+		f.lines = strings.Split(s, "\n")
+	}
+	return f
+}
+
+// Bytes renders the file, keeping its line endings.
+func (f *File) Bytes() []byte {
+	eol := "\n"
+	if f.crlf {
+		eol = "\r\n"
+	}
+	if len(f.lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(f.lines, eol) + eol)
+}
+
+// Get returns the value of key in Section.
+func (f *File) Get(key string) (string, bool) {
+	if i := f.find(key); i >= 0 {
+		_, v, _ := strings.Cut(f.lines[i], "=")
+		return strings.TrimSpace(v), true
+	}
+	return "", false
+}
+
+// Set sets key in Section: the existing line is replaced in place, otherwise
+// the key is added at the end of the section (created if missing).
+func (f *File) Set(key, value string) {
+	line := key + "=" + value
+	if i := f.find(key); i >= 0 {
+		f.lines[i] = line
+		return
+	}
+	start, end := f.section()
+	if start < 0 {
+		if len(f.lines) > 0 && strings.TrimSpace(f.lines[len(f.lines)-1]) != "" {
+			f.lines = append(f.lines, "")
+		}
+		f.lines = append(f.lines, "["+Section+"]", line)
+		return
+	}
+	// Insert after the section's last non-blank line.
+	at := end
+	for at > start+1 && strings.TrimSpace(f.lines[at-1]) == "" {
+		at--
+	}
+	f.lines = append(f.lines[:at], append([]string{line}, f.lines[at:]...)...)
+}
+
+// Limits returns the limits set in the file.
+func (f *File) Limits() Limits {
+	var l Limits
+	if v, ok := f.Get(KeyMemory); ok {
+		l.MemoryMB = ParseSize(v)
+	}
+	if v, ok := f.Get(KeyProcessors); ok {
+		l.Processors, _ = strconv.Atoi(v)
+	}
+	if v, ok := f.Get(KeySwap); ok {
+		l.SwapMB, l.HasSwap = ParseSize(v), true
+	}
+	return l
+}
+
+// Save writes the file, keeping the previous version as .wslconfig.bak.
+func (f *File) Save() error {
+	if old, err := os.ReadFile(f.Path); err == nil { //nolint:gosec // G304: the user's own .wslconfig
+		if err := os.WriteFile(f.Path+".bak", old, 0o600); err != nil {
+			return fmt.Errorf("backing up %s: %w", f.Path, err)
+		}
+	}
+	if err := os.WriteFile(f.Path, f.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// find returns the line index of key in Section, or -1.
+func (f *File) find(key string) int {
+	start, end := f.section()
+	if start < 0 {
+		return -1
+	}
+	for i := start + 1; i < end; i++ {
+		k, _, ok := strings.Cut(f.lines[i], "=")
+		if ok && strings.EqualFold(strings.TrimSpace(k), key) && !isComment(f.lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// section returns the header line of Section and the index where the section
+// ends (the next header or len(lines)); start is -1 when there is none.
+func (f *File) section() (start, end int) {
+	start = -1
+	for i, line := range f.lines {
+		name, ok := header(line)
+		if !ok {
+			continue
+		}
+		if start >= 0 {
+			return start, i
+		}
+		if strings.EqualFold(name, Section) {
+			start = i
+		}
+	}
+	return start, len(f.lines)
+}
+
+func header(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+		return strings.TrimSpace(line[1 : len(line)-1]), true
+	}
+	return "", false
+}
+
+func isComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";")
+}
+
+// ParseSize parses a .wslconfig size (8GB, 4096MB, 6G, bare bytes) into MB;
+// 0 when it cannot be parsed.
+func ParseSize(s string) int {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimSuffix(s, "B")
+	mult := 1.0 / (1024 * 1024)
+	switch {
+	case strings.HasSuffix(s, "T"):
+		mult, s = 1024*1024, strings.TrimSuffix(s, "T")
+	case strings.HasSuffix(s, "G"):
+		mult, s = 1024, strings.TrimSuffix(s, "G")
+	case strings.HasSuffix(s, "M"):
+		mult, s = 1, strings.TrimSuffix(s, "M")
+	case strings.HasSuffix(s, "K"):
+		mult, s = 1.0/1024, strings.TrimSuffix(s, "K")
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return int(n * mult)
+}
+
+// FormatSize renders MB the way .wslconfig is usually written: whole GB, else MB.
+func FormatSize(mb int) string {
+	if mb%1024 == 0 {
+		return fmt.Sprintf("%dGB", mb/1024)
+	}
+	return fmt.Sprintf("%dMB", mb)
+}
+
+// Requirements are the VM limits a local cluster needs.
+type Requirements struct {
+	MemoryMB   int
+	Processors int
+	SwapMB     int
+}
+
+// ClusterRequirements is what a full local OpenFrame install needs from the
+// VM; it matches the memory the install prerequisites recommend.
+var ClusterRequirements = Requirements{MemoryMB: 15 * 1024, Processors: 4, SwapMB: 4 * 1024}
+
+// Change raises one limit.
+type Change struct {
+	Key  string
+	From string // current effective value, e.g. "8GB (WSL default)"
+	To   string
+}
+
+// hostReserveMB is left to Windows when raising the VM's memory.
+const hostReserveMB = 2048
+
+// Plan returns the changes that bring the effective limits up to req on a
+// machine with hostMemoryMB and hostCPUs. Limits are only raised, never
+// lowered, and never past what the machine has.
+func Plan(set Limits, hostMemoryMB, hostCPUs int, req Requirements) []Change {
+	eff := set.Effective(hostMemoryMB, hostCPUs)
+	var changes []Change
+	if want := min(req.MemoryMB, hostMemoryMB-hostReserveMB); want > eff.MemoryMB {
+		changes = append(changes, Change{Key: KeyMemory, From: describe(FormatSize(eff.MemoryMB), set.MemoryMB == 0), To: FormatSize(roundGB(want))})
+	}
+	if want := min(req.Processors, hostCPUs); want > eff.Processors {
+		changes = append(changes, Change{Key: KeyProcessors, From: describe(strconv.Itoa(eff.Processors), set.Processors == 0), To: strconv.Itoa(want)})
+	}
+	if eff.SwapMB < req.SwapMB {
+		changes = append(changes, Change{Key: KeySwap, From: describe(FormatSize(eff.SwapMB), !set.HasSwap), To: FormatSize(req.SwapMB)})
+	}
+	return changes
+}
+
+// Apply writes changes into the file (Save persists them).
+func (f *File) Apply(changes []Change) {
+	for _, c := range changes {
+		f.Set(c.Key, c.To)
+	}
+}
+
+func describe(v string, isDefault bool) string {
+	if isDefault {
+		return v + " (WSL default)"
+	}
+	return v
+}
+
+// roundGB rounds mb down to whole GB (at least 1GB).
+func roundGB(mb int) int {
+	return max(mb/1024, 1) * 1024
+}
+
+// Host returns the machine's memory in MB and its logical CPUs; memory is 0
+// when it cannot be read.
+func Host() (memoryMB, cpus int) {
+	cpus = runtime.NumCPU()
+	if h, err := sysinfo.Host(); err == nil {
+		if mem, err := h.Memory(); err == nil {
+			memoryMB = int(mem.Total / (1024 * 1024))
+		}
+	}
+	return memoryMB, cpus
+}
+
+// Shutdown runs `wsl --shutdown`, which stops every distro so the new limits
+// take effect on the next start.
+func Shutdown(ctx context.Context) error {
+	out, err := exec.CommandContext(ctx, "wsl", "--shutdown").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("wsl --shutdown: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package wslconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits(t *testing.T) {
+	f := Parse([]byte("# comment\n[experimental]\nmemory=64GB\n\n[wsl2]\nmemory = 6GB\nprocessors=3\nswap=0\n"))
+	assert.Equal(t, Limits{MemoryMB: 6 * 1024, Processors: 3, SwapMB: 0, HasSwap: true}, f.Limits())
+
+	assert.Equal(t, Limits{}, Parse(nil).Limits())
+}
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int{"8GB": 8192, "4096MB": 4096, "6g": 6144, "1.5GB": 1536, "0": 0, "junk": 0} {
+		assert.Equal(t, want, ParseSize(in), in)
+	}
+	assert.Equal(t, "16GB", FormatSize(16*1024))
+	assert.Equal(t, "1536MB", FormatSize(1536))
+}
+
+func TestSetKeepsTheRestOfTheFile(t *testing.T) {
+	in := "# my settings\r\n[wsl2]\r\nmemory=4GB\r\nguiApplications=false\r\n\r\n[experimental]\r\nsparseVhd=true\r\n"
+	f := Parse([]byte(in))
+	f.Set(KeyMemory, "16GB")
+	f.Set(KeySwap, "4GB")
+
+	want := "# my settings\r\n[wsl2]\r\nmemory=16GB\r\nguiApplications=false\r\nswap=4GB\r\n\r\n[experimental]\r\nsparseVhd=true\r\n"
+	assert.Equal(t, want, string(f.Bytes()))
+}
+
+func TestSetCreatesTheSection(t *testing.T) {
+	f := Parse([]byte("[experimental]\nsparseVhd=true\n"))
+	f.Set(KeyProcessors, "4")
+	assert.Equal(t, "[experimental]\nsparseVhd=true\n\n[wsl2]\nprocessors=4\n", string(f.Bytes()))
+
+	empty := Parse(nil)
+	empty.Set(KeyMemory, "8GB")
+	assert.Equal(t, "[wsl2]\nmemory=8GB\n", string(empty.Bytes()))
+}
+
+func TestPlan(t *testing.T) {
+	req := Requirements{MemoryMB: 15 * 1024, Processors: 4, SwapMB: 4 * 1024}
+
+	// 16GB machine with no .wslconfig: WSL gives the VM 8GB and 2GB swap.
+	changes := Plan(Limits{}, 16*1024, 8, req)
+	require.Len(t, changes, 2)
+	assert.Equal(t, Change{Key: KeyMemory, From: "8GB (WSL default)", To: "14GB"}, changes[0])
+	assert.Equal(t, Change{Key: KeySwap, From: "2GB (WSL default)", To: "4GB"}, changes[1])
+
+	// Limits already generous: nothing to do, and nothing is ever lowered.
+	assert.Empty(t, Plan(Limits{MemoryMB: 24 * 1024, Processors: 12, SwapMB: 8 * 1024, HasSwap: true}, 64*1024, 16, req))
+
+	// Processors are capped at what the machine has.
+	changes = Plan(Limits{MemoryMB: 20 * 1024, Processors: 1, SwapMB: 4096, HasSwap: true}, 32*1024, 2, req)
+	assert.Equal(t, []Change{{Key: KeyProcessors, From: "1", To: "2"}}, changes)
+
+	// Unknown host memory proposes no memory change.
+	assert.Empty(t, Plan(Limits{Processors: 8, SwapMB: 4096, HasSwap: true}, 0, 8, req))
+}
+
+func TestSaveKeepsABackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".wslconfig")
+	require.NoError(t, os.WriteFile(path, []byte("[wsl2]\nmemory=4GB\n"), 0o600))
+
+	f, err := Load(path)
+	require.NoError(t, err)
+	f.Apply([]Change{{Key: KeyMemory, To: "12GB"}})
+	require.NoError(t, f.Save())
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "[wsl2]\nmemory=12GB\n", string(got))
+	backup, err := os.ReadFile(path + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "[wsl2]\nmemory=4GB\n", string(backup))
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flamingo-stack/openframe-cli/internal/shared/scripts"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/selfupdate"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/ui/spinner"
 )
@@ -36,14 +37,7 @@ func isReleaseVersion(version string) bool {
 // selfupdate.FetchVerifiedLinuxBinary — nothing unverified ever reaches WSL,
 // and the distro needs no curl. Pure and testable.
 func stdinInstallScript() string {
-	return strings.Join([]string{
-		"set -e",
-		`BIN_DIR="$HOME/.openframe/bin"`,
-		`mkdir -p "$BIN_DIR"`,
-		`cat > "$BIN_DIR/openframe.tmp"`,
-		`chmod 0755 "$BIN_DIR/openframe.tmp"`,
-		`mv "$BIN_DIR/openframe.tmp" "$BIN_DIR/openframe"`,
-	}, "\n")
+	return scripts.MustRender(scripts.InstallStdinWSL, nil)
 }
 
 // ensureOpenframeInWSL makes sure the openframe binary is available inside WSL,
@@ -100,19 +94,16 @@ func shellSingleQuote(s string) string {
 // openframe binary — given by its Windows path — into ~/.openframe/bin. `wslpath`
 // converts the Windows path to a WSL path. Pure and testable.
 func localInstallScript(windowsPath string) string {
-	return strings.Join([]string{
-		"set -e",
-		`BIN_DIR="$HOME/.openframe/bin"`,
-		`mkdir -p "$BIN_DIR"`,
-		`SRC="$(wslpath -u ` + shellSingleQuote(windowsPath) + `)"`,
-		`install -m 0755 "$SRC" "$BIN_DIR/openframe"`,
-	}, "\n")
+	return scripts.MustRender(scripts.InstallLocalWSL, scripts.InstallLocalData{WindowsPath: windowsPath})
 }
 
 // installLocalBinaryInWSL copies the Linux binary at the given Windows path into
 // WSL. Thin exec wrapper around the tested localInstallScript.
 func installLocalBinaryInWSL(windowsPath string) error {
-	cmd := exec.Command("wsl", wslArgv("bash", "-lc", localInstallScript(windowsPath))...) // #nosec G204 -- path is single-quoted into a self-contained script
+	// The script goes in on stdin (`bash -ls`): wsl.exe re-joins argv into one
+	// command line, and a multi-line `bash -c` script does not survive that.
+	cmd := exec.Command("wsl", wslArgv("bash", "-ls")...) // #nosec G204 -- path is single-quoted into a self-contained script
+	cmd.Stdin = strings.NewReader(scripts.WithChecksum(localInstallScript(windowsPath)))
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("installing local openframe binary into WSL failed: %w\n%s", err, string(out))
 	}
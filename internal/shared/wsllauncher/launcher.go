@@ -20,32 +20,27 @@ import (
 	"time"
 
 	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/wslpath"
 )
 
 const (
-	// distroEnv overrides which WSL distribution OpenFrame uses. When unset, the
-	// WSL *default* distribution is used (no `-d` flag). Hardcoding a name like
-	// "Ubuntu" breaks on hosts whose distro is registered as "Ubuntu-24.04",
-	// "Ubuntu-22.04", etc. (WSL_E_DISTRO_NOT_FOUND).
-	distroEnv = "OPENFRAME_WSL_DISTRO"
+	// distroEnv overrides which WSL distribution OpenFrame uses (see
+	// wslpath.DistroEnv).
+	distroEnv = wslpath.DistroEnv
 	// BinaryInWSL is the OpenFrame executable name expected on the PATH in WSL.
 	BinaryInWSL = "openframe"
 	// disableEnv, when set, bypasses forwarding and runs the CLI natively on
-	// Windows. UNSUPPORTED, and not merely "untested": the cluster (Docker +
-	// k3d) lives inside WSL, and the native code paths that used to reach it
-	// from the outside were removed as dead. Read-only commands (--help,
-	// --version, completion) work; anything touching a cluster will fail with
-	// a platform error. It exists only to debug the launcher itself.
+	// Windows against a cluster inside WSL. UNSUPPORTED: read-only commands
+	// (--help, --version, completion) work; anything touching a cluster fails
+	// with a platform error. It exists only to debug the launcher itself. The
+	// supported native path is Docker Desktop mode (platform.NativeWindowsEnv).
 	disableEnv = "OPENFRAME_NO_WSL_FORWARD"
 )
 
 // wslDistroArgs returns the `-d <distro>` selector when OPENFRAME_WSL_DISTRO is
 // set, else nil so the WSL default distribution is targeted.
 func wslDistroArgs() []string {
-	if d := strings.TrimSpace(os.Getenv(distroEnv)); d != "" {
-		return []string{"-d", d}
-	}
-	return nil
+	return wslpath.DistroArgs()
 }
 
 // wslArgvWith builds the argv for `wsl <distroArgs> -- <cmd...>`. Pure/testable.
@@ -62,21 +57,30 @@ func wslArgv(cmd ...string) []string {
 }
 
 // forwardedEnvVars are host (Windows) environment variables shared into WSL via
-// WSLENV so credentials/config reach the Linux process.
+// WSLENV so credentials/config reach the Linux process. A "/p" suffix has WSL
+// translate the value from a Windows path: the helm directories
+// (helm.CacheHomeEnv and friends) set on Windows name the same place in WSL.
 var forwardedEnvVars = []string{
 	"GITHUB_TOKEN",
 	"OPENFRAME_GITHUB_TOKEN",
+	"OPENFRAME_HELM_CACHE_HOME/p",
+	"OPENFRAME_HELM_CONFIG_HOME/p",
+	"OPENFRAME_HELM_DATA_HOME/p",
 }
 
 // ShouldForward reports whether this process must re-run itself inside WSL: only
-// the native Windows build forwards, and only when not explicitly disabled.
+// the native Windows build forwards, and only when not explicitly disabled and
+// not in Docker Desktop native mode (see useNativeMode).
 // The opt-out is strictly parsed: OPENFRAME_NO_WSL_FORWARD=0/false still
 // forwards (the old any-non-empty check treated them as "disable").
 func ShouldForward() bool {
 	if runtime.GOOS != "windows" {
 		return false
 	}
-	return !sharedconfig.EnvBool(disableEnv)
+	if sharedconfig.EnvBool(disableEnv) {
+		return false
+	}
+	return !useNativeMode()
 }
 
 // Forward re-runs `openframe <args>` inside WSL, passing through stdio, the
@@ -99,17 +103,59 @@ func Forward(version string, args []string) (int, error) {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	cmd.Env = withWSLEnv(os.Environ(), os.LookupEnv)
+	env := withConfiguredHelmDirs(os.Environ())
+	cmd.Env = withWSLEnv(env, lookupIn(env))
 	// The child's own output already surfaced any failure; propagate its code.
 	return exitCodeOf(cmd.Run()), nil
 }
 
+// configuredHelmDirs maps the helm directory variables to their keys under
+// "helm" in the user config. The process in WSL reads its own home's config,
+// not this one, so the launcher passes them on as the variables.
+var configuredHelmDirs = []struct{ env, key string }{
+	{"OPENFRAME_HELM_CACHE_HOME", "cacheHome"},
+	{"OPENFRAME_HELM_CONFIG_HOME", "configHome"},
+	{"OPENFRAME_HELM_DATA_HOME", "dataHome"},
+}
+
+// withConfiguredHelmDirs adds the helm directories set in the user config to
+// env, unless env sets them already — a variable wins over the file, as it
+// does in WSL.
+func withConfiguredHelmDirs(env []string) []string {
+	var cfg struct {
+		Helm map[string]string `json:"helm"`
+	}
+	if err := sharedconfig.LoadUserConfig(&cfg); err != nil || len(cfg.Helm) == 0 {
+		return env
+	}
+	lookup := lookupIn(env)
+	for _, d := range configuredHelmDirs {
+		dir := strings.TrimSpace(cfg.Helm[d.key])
+		if _, set := lookup(d.env); !set && dir != "" {
+			env = append(env, d.env+"="+dir)
+		}
+	}
+	return env
+}
+
+// lookupIn is os.LookupEnv over env.
+func lookupIn(env []string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		for i := len(env) - 1; i >= 0; i-- {
+			if k, v, ok := strings.Cut(env[i], "="); ok && strings.EqualFold(k, name) {
+				return v, true
+			}
+		}
+		return "", false
+	}
+}
+
 // withWSLEnv returns env with WSLENV extended so the forwarded vars that are
 // actually set on the host are shared into WSL.
 func withWSLEnv(env []string, lookup func(string) (string, bool)) []string {
 	var share []string
 	for _, v := range forwardedEnvVars {
-		if _, ok := lookup(v); ok {
+		if _, ok := lookup(wslenvName(v)); ok {
 			share = append(share, v)
 		}
 	}
@@ -140,21 +186,27 @@ func mergeWSLENV(existing string, add []string) string {
 		if p == "" {
 			continue
 		}
-		name := strings.SplitN(p, "/", 2)[0]
+		name := wslenvName(p)
 		if !seen[name] {
 			seen[name] = true
 			parts = append(parts, p)
 		}
 	}
 	for _, v := range add {
-		if !seen[v] {
-			seen[v] = true
+		if name := wslenvName(v); !seen[name] {
+			seen[name] = true
 			parts = append(parts, v)
 		}
 	}
 	return strings.Join(parts, ":")
 }
 
+// wslenvName is the variable name of a WSLENV entry, without its flags.
+func wslenvName(entry string) string {
+	name, _, _ := strings.Cut(entry, "/")
+	return name
+}
+
 // exitCodeOf maps a Cmd.Run() error to a process exit code.
 func exitCodeOf(err error) int {
 	if err == nil {
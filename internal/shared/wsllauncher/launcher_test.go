@@ -2,11 +2,15 @@ package wsllauncher
 
 import (
 	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
 )
 
 func TestShouldForward_OffWindowsIsFalse(t *testing.T) {
@@ -86,6 +90,40 @@ func TestWithWSLEnv_SharesOnlySetVars(t *testing.T) {
 	}
 }
 
+func TestWithWSLEnv_TranslatesHelmDirs(t *testing.T) {
+	lookup := func(k string) (string, bool) { return `D:\helm\cache`, k == "OPENFRAME_HELM_CACHE_HOME" }
+
+	out := withWSLEnv([]string{"HOME=/h", "WSLENV=OPENFRAME_HELM_CACHE_HOME"}, lookup)
+	if got := out[len(out)-1]; got != "WSLENV=OPENFRAME_HELM_CACHE_HOME" {
+		t.Errorf("an entry the user already shares is kept as they wrote it: %q", got)
+	}
+
+	out = withWSLEnv([]string{"HOME=/h"}, lookup)
+	if got := out[len(out)-1]; got != "WSLENV=OPENFRAME_HELM_CACHE_HOME/p" {
+		t.Errorf("helm dirs are shared as paths: %q", got)
+	}
+}
+
+func TestWithConfiguredHelmDirs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"helm": {"cacheHome": "D:\\helm\\cache", "dataHome": "D:\\helm\\data"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := sharedconfig.UserConfigFile
+	sharedconfig.UserConfigFile = func() (string, error) { return path, nil }
+	t.Cleanup(func() { sharedconfig.UserConfigFile = orig })
+
+	env := withConfiguredHelmDirs([]string{"HOME=/h", `OPENFRAME_HELM_DATA_HOME=E:\data`})
+	want := []string{"HOME=/h", `OPENFRAME_HELM_DATA_HOME=E:\data`, `OPENFRAME_HELM_CACHE_HOME=D:\helm\cache`}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("the config's helm dirs are passed on unless set: %q", env)
+	}
+	out := withWSLEnv(env, lookupIn(env))
+	if got := out[len(out)-1]; got != "WSLENV=OPENFRAME_HELM_CACHE_HOME/p:OPENFRAME_HELM_DATA_HOME/p" {
+		t.Errorf("the configured helm dirs are shared as paths: %q", got)
+	}
+}
+
 func TestWithWSLEnv_NoForwardedVarsLeavesEnvUntouched(t *testing.T) {
 	lookup := func(string) (string, bool) { return "", false }
 	in := []string{"HOME=/h"}
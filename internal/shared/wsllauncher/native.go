@@ -0,0 +1,53 @@
+package wsllauncher
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/platform"
+	sharedconfig "github.com/flamingo-stack/openframe-cli/internal/shared/config"
+)
+
+// nativeTools must all be on the Windows PATH for native mode: without them
+// the WSL path (which auto-installs its tools) is the better experience.
+var nativeTools = []string{"docker", "k3d", "helm"}
+
+// useNativeMode decides Docker Desktop native mode. An explicit
+// OPENFRAME_WINDOWS_NATIVE wins either way; otherwise native mode is chosen
+// when Docker Desktop's engine answers and k3d/helm are installed natively.
+// The decision is exported to the environment so the rest of the process (see
+// platform.NativeWindows) and any child openframe agree on it.
+func useNativeMode() bool {
+	if v := strings.TrimSpace(os.Getenv(platform.NativeWindowsEnv)); v != "" {
+		return sharedconfig.EnvBool(platform.NativeWindowsEnv)
+	}
+	if !nativeReady(exec.LookPath, dockerOperatingSystem) {
+		return false
+	}
+	_ = os.Setenv(platform.NativeWindowsEnv, "1")
+	return true
+}
+
+// nativeReady reports whether native mode can work: every native tool
+// resolves and the Docker engine identifies as Docker Desktop.
+func nativeReady(lookPath func(string) (string, error), dockerOS func() (string, error)) bool {
+	for _, tool := range nativeTools {
+		if _, err := lookPath(tool); err != nil {
+			return false
+		}
+	}
+	osName, err := dockerOS()
+	return err == nil && strings.Contains(osName, "Docker Desktop")
+}
+
+// dockerOperatingSystem asks the Docker engine what it runs on; Docker
+// Desktop reports "Docker Desktop". A stopped engine fails within seconds.
+func dockerOperatingSystem() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "docker", "info", "--format", "{{.OperatingSystem}}").Output()
+	return strings.TrimSpace(string(out)), err
+}
@@ -0,0 +1,38 @@
+package wsllauncher
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNativeReady(t *testing.T) {
+	found := func(string) (string, error) { return `C:\bin\tool.exe`, nil }
+	missingK3d := func(name string) (string, error) {
+		if name == "k3d" {
+			return "", errors.New("not found")
+		}
+		return `C:\bin\tool.exe`, nil
+	}
+	desktop := func() (string, error) { return "Docker Desktop", nil }
+	engineDown := func() (string, error) { return "", errors.New("cannot connect to the Docker daemon") }
+	otherEngine := func() (string, error) { return "Ubuntu 24.04 LTS", nil }
+
+	tests := []struct {
+		name     string
+		lookPath func(string) (string, error)
+		dockerOS func() (string, error)
+		want     bool
+	}{
+		{"docker desktop with native tools", found, desktop, true},
+		{"k3d only inside WSL", missingK3d, desktop, false},
+		{"docker desktop not running", found, engineDown, false},
+		{"remote or WSL engine", found, otherEngine, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nativeReady(tt.lookPath, tt.dockerOS); got != tt.want {
+				t.Fatalf("nativeReady = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 //go:build !windows
 
-package helm
+package wslpath
 
 // expandShortPath is a no-op on non-Windows platforms.
 // Windows short filenames (8.3 format) are only relevant on Windows.
@@ -1,6 +1,6 @@
 //go:build windows
 
-package helm
+package wslpath
 
 import (
 	"syscall"
@@ -0,0 +1,206 @@
+// Package wslpath translates Windows paths into the form a command running
+// inside WSL2 can open. It prefers WSL's own `wslpath -a -u` (which knows about
+// custom automount roots and drvfs mounts) and falls back to a pure conversion
+// when WSL cannot be asked.
+package wslpath
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/winhost"
+	"github.com/pterm/pterm"
+)
+
+// DistroEnv overrides which WSL distribution OpenFrame uses (see
+// executor.WSLDistroEnv).
+const DistroEnv = executor.WSLDistroEnv
+
+// convertTimeout bounds a single `wslpath` round-trip into WSL.
+const convertTimeout = 5 * time.Second
+
+// DistroArgs returns the `-d <distro>` selector when OPENFRAME_WSL_DISTRO is
+// set, else nil so the WSL default distribution is targeted.
+func DistroArgs() []string {
+	return executor.WSLDistroArgs()
+}
+
+// Converter turns Windows paths into WSL paths, asking WSL through the
+// executor first.
+type Converter struct {
+	executor executor.CommandExecutor
+	verbose  bool
+}
+
+// NewConverter returns a converter that runs `wslpath` through exec.
+func NewConverter(exec executor.CommandExecutor, verbose bool) *Converter {
+	return &Converter{executor: exec, verbose: verbose}
+}
+
+// ToWSL converts windowsPath for use inside WSL, e.g.
+// C:\Users\foo\file.txt -> /mnt/c/Users/foo/file.txt. Relative paths are made
+// absolute against the Windows working directory and 8.3 short names
+// (RUNNER~1) are expanded first, since WSL understands neither; so are
+// %VARIABLES% and a leading ~, by PowerShell, which works when WSL does not.
+// If `wslpath` cannot be run, the path is converted by Manual.
+func (c *Converter) ToWSL(ctx context.Context, windowsPath string) (string, error) {
+	if windowsPath == "" {
+		return "", fmt.Errorf("empty path provided")
+	}
+
+	if winhost.NeedsExpansion(windowsPath) {
+		expanded, err := winhost.New(c.executor).ExpandPath(ctx, windowsPath)
+		if err != nil {
+			return "", err
+		}
+		if c.verbose {
+			pterm.Debug.Printf("Expanded path: %s -> %s\n", windowsPath, expanded)
+		}
+		windowsPath = expanded
+	}
+
+	absPath := normalize(windowsPath)
+	if expanded, err := expandShortPath(absPath); err == nil && expanded != "" && expanded != absPath {
+		if c.verbose {
+			pterm.Debug.Printf("Expanded short path: %s -> %s\n", absPath, expanded)
+		}
+		absPath = expanded
+	}
+
+	if wslPath, ok := c.viaWSL(ctx, absPath); ok {
+		if c.verbose {
+			pterm.Debug.Printf("Converted path via wslpath: %s -> %s\n", windowsPath, wslPath)
+		}
+		return wslPath, nil
+	}
+
+	if c.verbose {
+		pterm.Debug.Printf("Using manual path conversion for: %s\n", absPath)
+	}
+	return Manual(absPath)
+}
+
+// viaWSL asks `wslpath -a -u` inside the configured distribution. Backslashes
+// are sent as forward slashes so the Linux side does not treat them as escape
+// characters; wslpath accepts both.
+func (c *Converter) viaWSL(ctx context.Context, absPath string) (string, bool) {
+	ctx, cancel := context.WithTimeout(ctx, convertTimeout)
+	defer cancel()
+
+	args := append(DistroArgs(), "--", "wslpath", "-a", "-u", strings.ReplaceAll(absPath, `\`, "/"))
+	result, err := c.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "wsl",
+		Args:    args,
+	})
+	if err == nil && result != nil && result.ExitCode == 0 {
+		if out := strings.TrimSpace(result.Stdout); out != "" {
+			return out, true
+		}
+	}
+
+	if c.verbose {
+		var wslErr *executor.WSLError
+		switch {
+		case stderrors.As(err, &wslErr):
+			pterm.Warning.Printf("WSL error during path conversion: %s\n", wslErr.Error())
+			pterm.Info.Printf("Falling back to manual path conversion\n")
+		case err != nil:
+			pterm.Debug.Printf("wslpath command failed: %v\n", err)
+		case result != nil && result.ExitCode != 0:
+			pterm.Debug.Printf("wslpath returned exit code %d, stderr: %s\n", result.ExitCode, result.Stderr)
+		}
+	}
+	return "", false
+}
+
+// Manual converts a Windows path without asking WSL, assuming the default
+// automount root (/mnt):
+//
+//	C:\Users\foo           -> /mnt/c/Users/foo
+//	Z:\share\file (mapped) -> /mnt/z/share/file
+//	\\wsl$\Ubuntu\home\foo -> /home/foo (also \\wsl.localhost\...)
+//	\\?\C:\very\long       -> /mnt/c/very/long
+//
+// Paths that are already POSIX are returned unchanged. Plain UNC shares
+// (\\server\share) have no fixed location inside WSL and are rejected with a
+// hint to map or mount them; `wslpath` handles them once mounted.
+func Manual(windowsPath string) (string, error) {
+	if windowsPath == "" {
+		return "", fmt.Errorf("empty path provided")
+	}
+	if strings.HasPrefix(windowsPath, "/") && !strings.HasPrefix(windowsPath, "//") {
+		return windowsPath, nil
+	}
+
+	path := strings.ReplaceAll(stripLongPathPrefix(windowsPath), `\`, "/")
+
+	if strings.HasPrefix(path, "//") {
+		host, rest := splitUNC(path[2:])
+		if strings.EqualFold(host, "wsl$") || strings.EqualFold(host, "wsl.localhost") {
+			// rest is "<distro>/<path inside the distro>".
+			_, inside, _ := strings.Cut(rest, "/")
+			return "/" + inside, nil
+		}
+		share, _, _ := strings.Cut(rest, "/")
+		return "", fmt.Errorf("network path %s is not mounted in WSL: map it to a drive letter, or mount it with `sudo mount -t drvfs '\\\\%s\\%s' /mnt/%s`",
+			windowsPath, host, share, strings.ToLower(share))
+	}
+
+	if hasDriveLetter(path) {
+		return "/mnt/" + strings.ToLower(path[:1]) + path[2:], nil
+	}
+	return path, nil
+}
+
+// normalize makes windowsPath absolute against the working directory unless it
+// already carries a drive letter or UNC prefix (which filepath.Abs only
+// recognises when built for Windows).
+func normalize(windowsPath string) string {
+	if isWindowsAbs(windowsPath) {
+		return windowsPath
+	}
+	if abs, err := filepath.Abs(windowsPath); err == nil {
+		return abs
+	}
+	return windowsPath
+}
+
+func isWindowsAbs(path string) bool {
+	return strings.HasPrefix(path, `\\`) || strings.HasPrefix(path, "//") ||
+		(hasDriveLetter(path) && len(path) > 2 && (path[2] == '\\' || path[2] == '/'))
+}
+
+func hasDriveLetter(path string) bool {
+	if len(path) < 2 || path[1] != ':' {
+		return false
+	}
+	c := path[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// stripLongPathPrefix removes the Win32 extended-length prefix: \\?\C:\x
+// becomes C:\x and \\?\UNC\server\share becomes \\server\share.
+func stripLongPathPrefix(path string) string {
+	for _, prefix := range []string{`\\?\`, `//?/`} {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		rest := path[len(prefix):]
+		if len(rest) >= 4 && strings.EqualFold(strings.ReplaceAll(rest[:4], "/", `\`), `UNC\`) {
+			return `\\` + rest[4:]
+		}
+		return rest
+	}
+	return path
+}
+
+// splitUNC splits "server/share/dir" into "server" and "share/dir".
+func splitUNC(path string) (string, string) {
+	host, rest, _ := strings.Cut(path, "/")
+	return host, rest
+}
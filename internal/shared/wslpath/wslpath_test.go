@@ -0,0 +1,92 @@
+package wslpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManual(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`C:\Users\foo\file.txt`, "/mnt/c/Users/foo/file.txt"},
+		{`D:/charts/app`, "/mnt/d/charts/app"},
+		{`Z:\mapped\share`, "/mnt/z/mapped/share"},
+		{`C:`, "/mnt/c"},
+		{`\\?\C:\very\long\path`, "/mnt/c/very/long/path"},
+		{`\\wsl$\Ubuntu\home\foo\values.yaml`, "/home/foo/values.yaml"},
+		{`\\wsl.localhost\Ubuntu-22.04\tmp\x`, "/tmp/x"},
+		{`//wsl$/Ubuntu/etc`, "/etc"},
+		{"/home/foo/already-posix", "/home/foo/already-posix"},
+	}
+	for _, tt := range tests {
+		got, err := Manual(tt.in)
+		require.NoErrorf(t, err, "input %q", tt.in)
+		assert.Equalf(t, tt.want, got, "input %q", tt.in)
+	}
+}
+
+func TestManual_UnmountedUNCShare(t *testing.T) {
+	for _, in := range []string{`\\fileserver\Charts\app`, `\\?\UNC\fileserver\Charts\app`} {
+		_, err := Manual(in)
+		require.Errorf(t, err, "input %q", in)
+		assert.Contains(t, err.Error(), "mount -t drvfs")
+		assert.Contains(t, err.Error(), "/mnt/charts")
+	}
+}
+
+func TestManual_Empty(t *testing.T) {
+	_, err := Manual("")
+	assert.Error(t, err)
+}
+
+func TestConverter_PrefersWSLPath(t *testing.T) {
+	t.Setenv(DistroEnv, "Ubuntu-24.04")
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("wslpath", &executor.CommandResult{Stdout: "/mnt/host/c/Users/foo\n"})
+
+	got, err := NewConverter(mock, false).ToWSL(context.Background(), `C:\Users\foo`)
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/host/c/Users/foo", got, "wslpath knows custom automount roots")
+
+	cmds := mock.Commands()
+	require.Len(t, cmds, 1)
+	assert.Equal(t, "wsl", cmds[0].Name)
+	assert.Equal(t, []string{"-d", "Ubuntu-24.04", "--", "wslpath", "-a", "-u", "C:/Users/foo"}, cmds[0].Args)
+}
+
+func TestConverter_FallsBackToManual(t *testing.T) {
+	t.Setenv(DistroEnv, "")
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("wslpath", &executor.CommandResult{ExitCode: 1, Stderr: "wslpath: not found"})
+
+	got, err := NewConverter(mock, false).ToWSL(context.Background(), `C:\Users\foo`)
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/c/Users/foo", got)
+	assert.Equal(t, []string{"--", "wslpath", "-a", "-u", "C:/Users/foo"}, mock.Commands()[0].Args,
+		"without OPENFRAME_WSL_DISTRO the default distro is used")
+}
+
+func TestConverter_ExpandsVariablesNatively(t *testing.T) {
+	t.Setenv(DistroEnv, "")
+	mock := executor.NewMockCommandExecutor()
+	mock.SetResponse("powershell", &executor.CommandResult{Stdout: "C:\\Users\\foo\\certs\r\n"})
+	mock.SetResponse("wslpath", &executor.CommandResult{ExitCode: 1, Stderr: "WSL is not running"})
+
+	got, err := NewConverter(mock, false).ToWSL(context.Background(), `%USERPROFILE%\certs`)
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/c/Users/foo/certs", got)
+	assert.Equal(t, "powershell", mock.Commands()[0].Name, "expansion does not need WSL")
+}
+
+func TestDistroArgs(t *testing.T) {
+	t.Setenv(DistroEnv, "")
+	assert.Nil(t, DistroArgs())
+	t.Setenv(DistroEnv, " Debian ")
+	assert.Equal(t, []string{"-d", "Debian"}, DistroArgs())
+}
@@ -0,0 +1,190 @@
+// Package statusserver implements the HTTP endpoint of `openframe status
+// serve`: the readiness of the managed clusters and their ArgoCD applications
+// as JSON, for CI jobs and scripts to poll.
+package statusserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// probeTimeout bounds one cluster's probe, so a hung API server cannot stall
+// the response for the others.
+const probeTimeout = 20 * time.Second
+
+// Application is the sync and health of one ArgoCD application.
+type Application struct {
+	Name   string `json:"name"`
+	Sync   string `json:"sync"`
+	Health string `json:"health"`
+}
+
+// Cluster is the readiness of one managed cluster.
+type Cluster struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Paused     bool   `json:"paused,omitempty"`
+	Reachable  bool   `json:"reachable"`
+	NodesReady int    `json:"nodesReady"`
+	NodesTotal int    `json:"nodesTotal"`
+	// Applications is empty until ArgoCD is installed and lists some.
+	Applications []Application `json:"applications"`
+	Ready        bool          `json:"ready"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Status is the body of /status.
+type Status struct {
+	Ready     bool      `json:"ready"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Clusters  []Cluster `json:"clusters"`
+}
+
+// Collector gathers the status of the managed clusters.
+type Collector struct {
+	// Clusters lists the managed clusters.
+	Clusters func(ctx context.Context) ([]models.ClusterInfo, error)
+	// Paused names the clusters idle-watch stopped on purpose; may be nil.
+	Paused func() ([]string, error)
+	// Client connects to a cluster by name, within ctx.
+	Client func(ctx context.Context, cluster string) (kubernetes.Interface, error)
+	// Applications lists a cluster's ArgoCD applications.
+	Applications func(ctx context.Context, cluster string) ([]argocd.Application, error)
+	// Only restricts the status to these clusters; empty means all.
+	Only []string
+}
+
+// Collect probes every cluster in parallel. It fails only when the clusters
+// cannot be listed; a cluster that cannot be probed is reported not ready.
+func (c Collector) Collect(ctx context.Context) (Status, error) {
+	infos, err := c.Clusters(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	paused := map[string]bool{}
+	if c.Paused != nil {
+		names, _ := c.Paused()
+		for _, name := range names {
+			paused[name] = true
+		}
+	}
+	wanted := map[string]bool{}
+	for _, name := range c.Only {
+		wanted[name] = true
+	}
+
+	st := Status{CheckedAt: time.Now().UTC(), Clusters: []Cluster{}}
+	for _, info := range infos {
+		if len(wanted) > 0 && !wanted[info.Name] {
+			continue
+		}
+		st.Clusters = append(st.Clusters, Cluster{Name: info.Name, Type: string(info.Type), Paused: paused[info.Name], Applications: []Application{}})
+	}
+
+	var wg sync.WaitGroup
+	for i := range st.Clusters {
+		if st.Clusters[i].Paused {
+			continue
+		}
+		wg.Add(1)
+		go func(cl *Cluster) {
+			defer wg.Done()
+			c.probe(ctx, cl)
+		}(&st.Clusters[i])
+	}
+	wg.Wait()
+
+	st.Ready = len(st.Clusters) > 0
+	for _, cl := range st.Clusters {
+		st.Ready = st.Ready && cl.Ready
+	}
+	return st, nil
+}
+
+// probe fills in the nodes and applications of cl. A cluster is ready when
+// every node is Ready and every application is Synced and Healthy.
+func (c Collector) probe(ctx context.Context, cl *Cluster) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	client, err := c.Client(ctx, cl.Name)
+	if err != nil {
+		cl.Error = err.Error()
+		return
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		cl.Error = err.Error()
+		return
+	}
+	cl.Reachable = true
+	cl.NodesTotal = len(nodes.Items)
+	for _, node := range nodes.Items {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				cl.NodesReady++
+			}
+		}
+	}
+
+	apps, err := c.Applications(ctx, cl.Name)
+	if err != nil {
+		cl.Error = "listing ArgoCD applications: " + err.Error()
+		return
+	}
+	appsReady := len(apps) > 0
+	for _, a := range apps {
+		cl.Applications = append(cl.Applications, Application{Name: a.Name, Sync: a.Sync, Health: a.Health})
+		appsReady = appsReady && a.Sync == argocd.ArgoCDSyncSynced && a.Health == argocd.ArgoCDHealthHealthy
+	}
+	cl.Ready = cl.NodesTotal > 0 && cl.NodesReady == cl.NodesTotal && appsReady
+}
+
+// NewHandler serves the status collect gathers:
+//
+//   - /status answers 200 with the full Status.
+//   - /healthz answers 200 when every cluster is ready and 503 otherwise, with
+//     {"ready": ...}, so `curl -f` can gate a CI step.
+//
+// Either answers 500 when the clusters cannot be listed.
+func NewHandler(collect func(ctx context.Context) (Status, error)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		st, err := collect(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, st)
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		st, err := collect(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"ready": false, "error": err.Error()})
+			return
+		}
+		code := http.StatusOK
+		if !st.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, map[string]bool{"ready": st.Ready})
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
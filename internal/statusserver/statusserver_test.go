@@ -0,0 +1,135 @@
+package statusserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func node(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+	}
+}
+
+func collector(apps map[string][]argocd.Application) Collector {
+	clients := map[string]kubernetes.Interface{
+		"dev":   fake.NewClientset(node("k3d-dev-server-0", corev1.ConditionTrue)),
+		"stage": fake.NewClientset(node("k3d-stage-server-0", corev1.ConditionTrue), node("k3d-stage-agent-0", corev1.ConditionFalse)),
+	}
+	return Collector{
+		Clusters: func(context.Context) ([]models.ClusterInfo, error) {
+			return []models.ClusterInfo{
+				{Name: "dev", Type: models.ClusterTypeK3d},
+				{Name: "stage", Type: models.ClusterTypeK3d},
+				{Name: "old", Type: models.ClusterTypeK3d},
+				{Name: "gone", Type: models.ClusterTypeK3d},
+			}, nil
+		},
+		Paused: func() ([]string, error) { return []string{"old"}, nil },
+		Client: func(_ context.Context, name string) (kubernetes.Interface, error) {
+			if c, ok := clients[name]; ok {
+				return c, nil
+			}
+			return nil, errors.New("no kubeconfig for " + name)
+		},
+		Applications: func(_ context.Context, name string) ([]argocd.Application, error) {
+			return apps[name], nil
+		},
+	}
+}
+
+var healthy = []argocd.Application{
+	{Name: "openframe-api", Sync: argocd.ArgoCDSyncSynced, Health: argocd.ArgoCDHealthHealthy},
+	{Name: "openframe-ui", Sync: argocd.ArgoCDSyncSynced, Health: argocd.ArgoCDHealthHealthy},
+}
+
+func TestCollect(t *testing.T) {
+	st, err := collector(map[string][]argocd.Application{
+		"dev":   healthy,
+		"stage": healthy,
+	}).Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, st.Clusters, 4)
+
+	dev, stage, old, gone := st.Clusters[0], st.Clusters[1], st.Clusters[2], st.Clusters[3]
+	assert.True(t, dev.Ready)
+	assert.Equal(t, 1, dev.NodesReady)
+	assert.Len(t, dev.Applications, 2)
+
+	assert.False(t, stage.Ready, "a NotReady node")
+	assert.Equal(t, 1, stage.NodesReady)
+	assert.Equal(t, 2, stage.NodesTotal)
+
+	assert.True(t, old.Paused)
+	assert.False(t, old.Reachable, "paused clusters are not probed")
+	assert.Equal(t, "no kubeconfig for gone", gone.Error)
+	assert.False(t, st.Ready)
+}
+
+func TestCollect_AppsDecideReadiness(t *testing.T) {
+	c := collector(map[string][]argocd.Application{
+		"dev": {{Name: "openframe-api", Sync: argocd.ArgoCDSyncOutOfSync, Health: argocd.ArgoCDHealthHealthy}},
+	})
+	c.Only = []string{"dev"}
+	st, err := c.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, st.Clusters, 1)
+	assert.False(t, st.Ready, "an out-of-sync application")
+
+	c = collector(nil)
+	c.Only = []string{"dev"}
+	st, err = c.Collect(context.Background())
+	require.NoError(t, err)
+	assert.False(t, st.Ready, "no applications installed yet")
+	assert.NotNil(t, st.Clusters[0].Applications, "an empty list, not null")
+}
+
+func TestHandler(t *testing.T) {
+	ready := true
+	h := NewHandler(func(context.Context) (Status, error) {
+		return Status{Ready: ready, Clusters: []Cluster{{Name: "dev", Ready: ready}}}, nil
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"ready": true}`, rec.Body.String())
+
+	rec = get("/status")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var st Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &st))
+	assert.Equal(t, "dev", st.Clusters[0].Name)
+
+	ready = false
+	assert.Equal(t, http.StatusServiceUnavailable, get("/healthz").Code)
+	assert.Equal(t, http.StatusOK, get("/status").Code, "/status reports, it does not gate")
+	assert.Equal(t, http.StatusNotFound, get("/other").Code)
+}
+
+func TestHandler_ListFailure(t *testing.T) {
+	h := NewHandler(func(context.Context) (Status, error) { return Status{}, errors.New("k3d not found") })
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "k3d not found")
+}
@@ -0,0 +1,396 @@
+// Package volumes backs up and restores the data of a k3d cluster's
+// local-path PersistentVolumes. k3s keeps that data in directories on the node
+// containers, so it is gone once the cluster is deleted; a backup streams each
+// directory out of its node with `docker exec ... tar` into one local archive,
+// and a restore streams it into the matching claims of another cluster.
+package volumes
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StorageClass is the class of the volumes k3s' local-path provisioner
+// creates — the only ones backed up.
+const StorageClass = "local-path"
+
+// manifestName is the archive's first entry; volume data follows under
+// volumes/<namespace>/<claim>/.
+const manifestName = "manifest.json"
+
+// Volume is one local-path volume: the claim it is bound to and the node
+// directory holding its data.
+type Volume struct {
+	Namespace string `json:"namespace"`
+	Claim     string `json:"claim"`
+	Node      string `json:"node"`
+	Path      string `json:"path"`
+}
+
+// Key names the volume by its claim, which, unlike the PV name, is the same
+// in every cluster the same applications are installed into.
+func (v Volume) Key() string { return v.Namespace + "/" + v.Claim }
+
+func (v Volume) prefix() string { return "volumes/" + v.Key() + "/" }
+
+// Manifest describes a backup archive.
+type Manifest struct {
+	Cluster string    `json:"cluster"`
+	Created time.Time `json:"created"`
+	Volumes []Volume  `json:"volumes"`
+}
+
+// List returns the bound local-path volumes of the cluster, sorted by claim.
+func List(ctx context.Context, cs kubernetes.Interface) ([]Volume, error) {
+	pvs, err := cs.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing persistent volumes: %w", err)
+	}
+	var out []Volume
+	for _, pv := range pvs.Items {
+		if pv.Spec.StorageClassName != StorageClass || pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+			continue
+		}
+		v := Volume{Namespace: pv.Spec.ClaimRef.Namespace, Claim: pv.Spec.ClaimRef.Name, Node: pvNode(&pv)}
+		switch {
+		case pv.Spec.HostPath != nil:
+			v.Path = pv.Spec.HostPath.Path
+		case pv.Spec.Local != nil:
+			v.Path = pv.Spec.Local.Path
+		}
+		if v.Node == "" || v.Path == "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key() < out[j].Key() })
+	return out, nil
+}
+
+// pvNode returns the node a local volume is pinned to; for k3d it is also the
+// name of the node's container.
+func pvNode(pv *corev1.PersistentVolume) string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelHostname && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) > 0 {
+				return expr.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// Backup writes a gzip-compressed tar archive of every volume of cluster to
+// out and returns its manifest. progress, when set, is called before each
+// volume.
+func Backup(ctx context.Context, exec executor.CommandExecutor, cs kubernetes.Interface, cluster string, out io.Writer, progress func(Volume)) (*Manifest, error) {
+	vols, err := List(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{Cluster: cluster, Created: time.Now().UTC(), Volumes: vols}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(data)), ModTime: m.Created}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return nil, err
+	}
+
+	for _, v := range vols {
+		if progress != nil {
+			progress(v)
+		}
+		if err := backupVolume(ctx, exec, v, tw); err != nil {
+			return nil, fmt.Errorf("backing up %s: %w", v.Key(), err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return m, gz.Close()
+}
+
+// backupVolume streams the tar of v's directory out of its node and copies its
+// entries into tw under v's prefix.
+func backupVolume(ctx context.Context, exec executor.CommandExecutor, v Volume, tw *tar.Writer) error {
+	pr, pw := io.Pipe()
+	copied := make(chan error, 1)
+	go func() {
+		err := copyEntries(tar.NewReader(pr), tw, func(name string) string { return v.prefix() + name })
+		if err != nil {
+			_ = pr.CloseWithError(err) // stop docker exec
+		} else {
+			_, _ = io.Copy(io.Discard, pr) // tar pads its output past the end marker
+		}
+		copied <- err
+	}()
+	_, err := exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command:      "docker",
+		Args:         []string{"exec", v.Node, "tar", "-C", v.Path, "-cf", "-", "."},
+		StdoutWriter: pw,
+	})
+	_ = pw.CloseWithError(err)
+	if copyErr := <-copied; copyErr != nil {
+		return copyErr
+	}
+	return err
+}
+
+// copyEntries copies every entry of tr to tw, renaming it with rename. The
+// root directory is renamed from "" so that even an empty volume has an entry.
+func copyEntries(tr *tar.Reader, tw *tar.Writer, rename func(string) string) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path.Clean(hdr.Name), "./")
+		if name == "." {
+			name = ""
+		}
+		hdr.Name = rename(name)
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = rename(strings.TrimPrefix(path.Clean(hdr.Linkname), "./"))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil { //nolint:gosec // G110: archives come from the user's own clusters
+			return err
+		}
+	}
+}
+
+// RestoreResult reports what Restore did.
+type RestoreResult struct {
+	Manifest  *Manifest
+	Restored  []Volume
+	Skipped   []string // claims in the archive the cluster does not have
+	Stopped   []string // workloads scaled to zero for the restore and back
+	Restarted int      // other pods restarted to pick up the restored data
+}
+
+// Restore reads an archive written by Backup and replaces the data of each
+// volume whose claim exists in the cluster with the archived data. The
+// StatefulSets and Deployments using those claims are scaled to zero first,
+// so no database writes into a directory being replaced, and scaled back and
+// waited for once the data is in place; any other pod using a restored claim
+// is deleted afterwards so its controller starts it again on the restored
+// data. progress, when set, is called before each volume.
+func Restore(ctx context.Context, exec executor.CommandExecutor, cs kubernetes.Interface, in io.Reader, progress func(Volume)) (*RestoreResult, error) {
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("not a volume backup: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a volume backup: %s is missing", manifestName)
+	}
+	res := &RestoreResult{Manifest: &Manifest{}}
+	if err := json.NewDecoder(tr).Decode(res.Manifest); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", manifestName, err)
+	}
+
+	current, err := List(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+	targets := map[string]Volume{}
+	for _, v := range current {
+		targets[v.Key()] = v
+	}
+	var restoring []Volume
+	for _, v := range res.Manifest.Volumes {
+		if target, ok := targets[v.Key()]; ok {
+			restoring = append(restoring, target)
+		} else {
+			res.Skipped = append(res.Skipped, v.Key())
+		}
+	}
+
+	stopped, err := stopWorkloads(ctx, cs, restoring)
+	for _, w := range stopped {
+		res.Stopped = append(res.Stopped, w.String())
+	}
+	if err == nil {
+		err = restoreVolumes(ctx, exec, cs, tr, targets, res, progress)
+	}
+	if startErr := startWorkloads(ctx, cs, stopped); startErr != nil {
+		err = errors.Join(err, startErr)
+	}
+	return res, err
+}
+
+// restoreVolumes streams the archive's volume entries from tr into the
+// matching targets and restarts the remaining pods using them.
+func restoreVolumes(ctx context.Context, exec executor.CommandExecutor, cs kubernetes.Interface, tr *tar.Reader, targets map[string]Volume, res *RestoreResult, progress func(Volume)) error {
+	// Entries are grouped by volume, in manifest order; each volume's entries
+	// are streamed into a `tar -x` on its node.
+	var w *volumeWriter
+	finish := func() error {
+		if w == nil {
+			return nil
+		}
+		err := w.close()
+		if err == nil {
+			res.Restored = append(res.Restored, w.target)
+		}
+		w = nil
+		return err
+	}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading the archive: %w", err)
+		}
+		key, name, ok := splitEntry(hdr.Name)
+		if !ok {
+			continue
+		}
+		if w == nil || w.key != key {
+			if err := finish(); err != nil {
+				return err
+			}
+			target, ok := targets[key]
+			if !ok {
+				continue
+			}
+			if progress != nil {
+				progress(target)
+			}
+			if w, err = startVolume(ctx, exec, key, target); err != nil {
+				return err
+			}
+		}
+		hdr.Name = name
+		if hdr.Typeflag == tar.TypeLink {
+			if _, link, ok := splitEntry(hdr.Linkname); ok {
+				hdr.Linkname = link
+			}
+		}
+		if err := w.tw.WriteHeader(hdr); err != nil {
+			return w.fail(err)
+		}
+		if _, err := io.Copy(w.tw, tr); err != nil { //nolint:gosec // G110: archives come from the user's own clusters
+			return w.fail(err)
+		}
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+
+	for _, v := range res.Restored {
+		n, err := restartClaimPods(ctx, cs, v)
+		if err != nil {
+			return err
+		}
+		res.Restarted += n
+	}
+	return nil
+}
+
+// splitEntry splits "volumes/<ns>/<claim>/<name>" into the volume key and
+// "./<name>". Names that would escape the volume directory are rejected.
+func splitEntry(entry string) (key, name string, ok bool) {
+	rest, found := strings.CutPrefix(entry, "volumes/")
+	parts := strings.SplitN(rest, "/", 3)
+	if !found || len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	if c := path.Clean(parts[2]); c == ".." || strings.HasPrefix(c, "../") || path.IsAbs(c) {
+		return "", "", false
+	}
+	return parts[0] + "/" + parts[1], "./" + parts[2], true
+}
+
+// volumeWriter feeds one volume's entries to `tar -x` on its node.
+type volumeWriter struct {
+	key    string
+	target Volume
+	pw     *io.PipeWriter
+	tw     *tar.Writer
+	done   chan error
+}
+
+// startVolume empties target's directory and starts extracting into it.
+func startVolume(ctx context.Context, exec executor.CommandExecutor, key string, target Volume) (*volumeWriter, error) {
+	if _, err := exec.Execute(ctx, "docker", "exec", target.Node,
+		"sh", "-c", `cd "$1" && rm -rf -- * .[!.]* ..?*`, "sh", target.Path); err != nil {
+		return nil, fmt.Errorf("clearing %s: %w", key, err)
+	}
+	pr, pw := io.Pipe()
+	w := &volumeWriter{key: key, target: target, pw: pw, tw: tar.NewWriter(pw), done: make(chan error, 1)}
+	go func() {
+		_, err := exec.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+			Command:     "docker",
+			Args:        []string{"exec", "-i", target.Node, "tar", "-C", target.Path, "-xf", "-"},
+			StdinReader: pr,
+		})
+		_ = pr.CloseWithError(err) // unblock the writer if tar exited early
+		w.done <- err
+	}()
+	return w, nil
+}
+
+func (w *volumeWriter) close() error {
+	err := w.tw.Close()
+	_ = w.pw.CloseWithError(err)
+	if execErr := <-w.done; execErr != nil {
+		return fmt.Errorf("restoring %s: %w", w.key, execErr)
+	}
+	if err != nil {
+		return fmt.Errorf("restoring %s: %w", w.key, err)
+	}
+	return nil
+}
+
+func (w *volumeWriter) fail(err error) error {
+	_ = w.pw.CloseWithError(err)
+	<-w.done
+	return fmt.Errorf("restoring %s: %w", w.key, err)
+}
+
+// restartClaimPods deletes the pods mounting v's claim and returns how many.
+func restartClaimPods(ctx context.Context, cs kubernetes.Interface, v Volume) (int, error) {
+	pods, err := mountingPods(ctx, cs, v)
+	if err != nil {
+		return 0, err
+	}
+	for i, p := range pods {
+		if err := cs.CoreV1().Pods(v.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{}); err != nil {
+			return i, fmt.Errorf("restarting pod %s/%s: %w", v.Namespace, p.Name, err)
+		}
+	}
+	return len(pods), nil
+}
@@ -0,0 +1,275 @@
+package volumes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeNodes stands in for `docker exec <node> tar|sh` over an in-memory file
+// tree per node directory.
+type fakeNodes struct {
+	mu     sync.Mutex
+	files  map[string]map[string]string // node:dir -> relative name -> content
+	runs   []string
+	record func(string) // called with each command, when set
+}
+
+func (f *fakeNodes) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	return f.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args})
+}
+
+func (f *fakeNodes) ExecuteWithOptions(_ context.Context, o executor.ExecuteOptions) (*executor.CommandResult, error) {
+	args := o.Args
+	if len(args) > 1 && args[1] == "-i" {
+		args = append([]string{args[0]}, args[2:]...)
+	}
+	node := args[1]
+	f.mu.Lock()
+	f.runs = append(f.runs, strings.Join(args[2:], " "))
+	f.mu.Unlock()
+	if f.record != nil {
+		f.record(args[2])
+	}
+	switch {
+	case args[2] == "sh": // clear: sh -c '...' sh DIR
+		f.mu.Lock()
+		f.files[node+":"+args[6]] = map[string]string{}
+		f.mu.Unlock()
+	case args[2] == "tar" && args[5] == "-cf":
+		tw := tar.NewWriter(o.StdoutWriter)
+		_ = tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755})
+		f.mu.Lock()
+		tree := f.files[node+":"+args[4]]
+		names := make([]string, 0, len(tree))
+		for n := range tree {
+			names = append(names, n)
+		}
+		f.mu.Unlock()
+		sort.Strings(names)
+		for _, n := range names {
+			_ = tw.WriteHeader(&tar.Header{Name: "./" + n, Mode: 0o644, Size: int64(len(tree[n]))})
+			_, _ = tw.Write([]byte(tree[n]))
+		}
+		_ = tw.Close()
+		_, _ = o.StdoutWriter.Write(make([]byte, 1024)) // record padding
+	case args[2] == "tar" && args[5] == "-xf":
+		tr := tar.NewReader(o.StdinReader)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if hdr.Typeflag == tar.TypeDir {
+				continue
+			}
+			b, _ := io.ReadAll(tr)
+			f.mu.Lock()
+			f.files[node+":"+args[4]][path.Clean(hdr.Name)] = string(b)
+			f.mu.Unlock()
+		}
+		_, _ = io.Copy(io.Discard, o.StdinReader)
+	default:
+		return nil, fmt.Errorf("unexpected command %v", args)
+	}
+	return &executor.CommandResult{}, nil
+}
+
+func pv(name, ns, claim, node, dir string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName:       StorageClass,
+			ClaimRef:               &corev1.ObjectReference{Namespace: ns, Name: claim},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: dir}},
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{node}}},
+			}}}},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+func TestBackupRestore_RoundTripsByClaim(t *testing.T) {
+	ctx := context.Background()
+	src := fake.NewClientset(
+		pv("pvc-1", "datasources", "data-mongodb-0", "k3d-old-server-0", "/var/lib/rancher/k3s/storage/pvc-1"),
+		pv("pvc-2", "datasources", "data-kafka-0", "k3d-old-agent-0", "/var/lib/rancher/k3s/storage/pvc-2"),
+		pv("pvc-3", "datasources", "data-redis-0", "k3d-old-agent-0", "/var/lib/rancher/k3s/storage/pvc-3"),
+	)
+	srcNodes := &fakeNodes{files: map[string]map[string]string{
+		"k3d-old-server-0:/var/lib/rancher/k3s/storage/pvc-1": {"WiredTiger": "mongo", "journal/log.1": "j"},
+		"k3d-old-agent-0:/var/lib/rancher/k3s/storage/pvc-2":  {"meta.properties": "kafka"},
+		"k3d-old-agent-0:/var/lib/rancher/k3s/storage/pvc-3":  {},
+	}}
+	var archive bytes.Buffer
+	m, err := Backup(ctx, srcNodes, src, "old", &archive, nil)
+	require.NoError(t, err)
+	assert.Len(t, m.Volumes, 3)
+
+	// The new cluster has new PV names and nodes, and no redis claim yet.
+	dst := fake.NewClientset(
+		pv("pvc-a", "datasources", "data-mongodb-0", "k3d-new-server-0", "/var/lib/rancher/k3s/storage/pvc-a"),
+		pv("pvc-b", "datasources", "data-kafka-0", "k3d-new-server-0", "/var/lib/rancher/k3s/storage/pvc-b"),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb-0", Namespace: "datasources"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-mongodb-0"}}}}},
+		},
+	)
+	dstNodes := &fakeNodes{files: map[string]map[string]string{
+		"k3d-new-server-0:/var/lib/rancher/k3s/storage/pvc-a": {"stale": "x"},
+		"k3d-new-server-0:/var/lib/rancher/k3s/storage/pvc-b": {},
+	}}
+	res, err := Restore(ctx, dstNodes, dst, &archive, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "old", res.Manifest.Cluster)
+	assert.Equal(t, []string{"datasources/data-redis-0"}, res.Skipped)
+	require.Len(t, res.Restored, 2)
+	assert.Equal(t, map[string]string{"WiredTiger": "mongo", "journal/log.1": "j"},
+		dstNodes.files["k3d-new-server-0:/var/lib/rancher/k3s/storage/pvc-a"], "stale files are cleared first")
+	assert.Equal(t, map[string]string{"meta.properties": "kafka"},
+		dstNodes.files["k3d-new-server-0:/var/lib/rancher/k3s/storage/pvc-b"])
+
+	assert.Equal(t, 1, res.Restarted)
+	pods, err := dst.CoreV1().Pods("datasources").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+}
+
+func TestRestore_StopsWorkloadsAroundTheRestore(t *testing.T) {
+	ctx := context.Background()
+	withFastPolling(t)
+	src := fake.NewClientset(
+		pv("pvc-1", "datasources", "data-mongodb-0", "k3d-old-server-0", "/storage/pvc-1"),
+		pv("pvc-2", "datasources", "kafka-data", "k3d-old-server-0", "/storage/pvc-2"),
+	)
+	srcNodes := &fakeNodes{files: map[string]map[string]string{
+		"k3d-old-server-0:/storage/pvc-1": {"WiredTiger": "mongo"},
+		"k3d-old-server-0:/storage/pvc-2": {"meta.properties": "kafka"},
+	}}
+	var archive bytes.Buffer
+	_, err := Backup(ctx, srcNodes, src, "old", &archive, nil)
+	require.NoError(t, err)
+
+	one, two := int32(1), int32(2)
+	dst := fake.NewClientset(
+		pv("pvc-a", "datasources", "data-mongodb-0", "k3d-new-server-0", "/storage/pvc-a"),
+		pv("pvc-b", "datasources", "kafka-data", "k3d-new-server-0", "/storage/pvc-b"),
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: "datasources"}, Spec: appsv1.StatefulSetSpec{Replicas: &one}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "datasources"}, Spec: appsv1.DeploymentSpec{Replicas: &two}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "kafka-7d9f", Namespace: "datasources",
+			OwnerReferences: []metav1.OwnerReference{controller("Deployment", "kafka")}}},
+		claimPod("mongodb-0", "data-mongodb-0", controller("StatefulSet", "mongodb")),
+		claimPod("kafka-7d9f-x", "kafka-data", controller("ReplicaSet", "kafka-7d9f")),
+		claimPod("kafka-7d9f-y", "kafka-data", controller("ReplicaSet", "kafka-7d9f")),
+	)
+
+	// Stand in for the controllers: scaling to zero removes the pods, scaling
+	// up makes the replicas Ready.
+	var events []string
+	var mu sync.Mutex
+	record := func(e string) { mu.Lock(); events = append(events, e); mu.Unlock() }
+	podsGVR := corev1.SchemeGroupVersion.WithResource("pods")
+	dst.PrependReactor("update", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sts := action.(k8stesting.UpdateAction).GetObject().(*appsv1.StatefulSet)
+		record(fmt.Sprintf("scale %s %d", sts.Name, *sts.Spec.Replicas))
+		if *sts.Spec.Replicas == 0 {
+			_ = dst.Tracker().Delete(podsGVR, "datasources", "mongodb-0")
+		}
+		sts.Status.ReadyReplicas = *sts.Spec.Replicas
+		return false, nil, nil
+	})
+	dst.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		dep := action.(k8stesting.UpdateAction).GetObject().(*appsv1.Deployment)
+		record(fmt.Sprintf("scale %s %d", dep.Name, *dep.Spec.Replicas))
+		if *dep.Spec.Replicas == 0 {
+			_ = dst.Tracker().Delete(podsGVR, "datasources", "kafka-7d9f-x")
+			_ = dst.Tracker().Delete(podsGVR, "datasources", "kafka-7d9f-y")
+		}
+		dep.Status.ReadyReplicas = *dep.Spec.Replicas
+		return false, nil, nil
+	})
+	dstNodes := &fakeNodes{record: record, files: map[string]map[string]string{
+		"k3d-new-server-0:/storage/pvc-a": {},
+		"k3d-new-server-0:/storage/pvc-b": {},
+	}}
+
+	res, err := Restore(ctx, dstNodes, dst, &archive, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"scale mongodb 0", "scale kafka 0",
+		"sh", "tar", "sh", "tar",
+		"scale mongodb 1", "scale kafka 2",
+	}, events, "no workload runs while its data is replaced")
+	assert.Equal(t, []string{"StatefulSet datasources/mongodb", "Deployment datasources/kafka"}, res.Stopped)
+	assert.Zero(t, res.Restarted)
+	sts, err := dst.AppsV1().StatefulSets("datasources").Get(ctx, "mongodb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, one, *sts.Spec.Replicas)
+}
+
+// withFastPolling makes the workload waits poll without delay.
+func withFastPolling(t *testing.T) {
+	t.Helper()
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = orig })
+}
+
+func controller(kind, name string) metav1.OwnerReference {
+	yes := true
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: &yes}
+}
+
+func claimPod(name, claim string, owner metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "datasources", OwnerReferences: []metav1.OwnerReference{owner}},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}}}}},
+	}
+}
+
+func TestRestore_RejectsOtherArchives(t *testing.T) {
+	_, err := Restore(context.Background(), &fakeNodes{}, fake.NewClientset(), strings.NewReader("not gzip"), nil)
+	assert.ErrorContains(t, err, "not a volume backup")
+}
+
+func TestSplitEntry(t *testing.T) {
+	for entry, want := range map[string][2]string{
+		"volumes/ns/claim/":           {"ns/claim", "./"},
+		"volumes/ns/claim/a/b":        {"ns/claim", "./a/b"},
+		"volumes/ns/claim/../../etc":  {},
+		"volumes/ns/claim/a/../../..": {},
+		"volumes/ns":                  {},
+		"manifest.json":               {},
+	} {
+		key, name, ok := splitEntry(entry)
+		assert.Equal(t, want[0] != "", ok, entry)
+		assert.Equal(t, want, [2]string{key, name}, entry)
+	}
+}
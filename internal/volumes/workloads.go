@@ -0,0 +1,200 @@
+package volumes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Overridden in tests.
+var (
+	pollInterval = 2 * time.Second
+	stopTimeout  = 5 * time.Minute
+	readyTimeout = 10 * time.Minute
+)
+
+// workload is a StatefulSet or Deployment that mounts a restored volume. It
+// is scaled to zero for the restore, so no database writes into the directory
+// while it is replaced, and back to replicas afterwards.
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+	replicas  int32
+	pods      []string // the pods that must be gone before the restore
+}
+
+func (w *workload) String() string { return w.kind + " " + w.namespace + "/" + w.name }
+
+// mountingPods returns the pods of v's namespace that mount v's claim.
+func mountingPods(ctx context.Context, cs kubernetes.Interface, v Volume) ([]corev1.Pod, error) {
+	pods, err := cs.CoreV1().Pods(v.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods in %s: %w", v.Namespace, err)
+	}
+	var out []corev1.Pod
+	for _, p := range pods.Items {
+		for _, vol := range p.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == v.Claim {
+				out = append(out, p)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// owningWorkload returns the StatefulSet or Deployment that runs pod, or nil
+// for a pod no scalable controller owns.
+func owningWorkload(ctx context.Context, cs kubernetes.Interface, pod *corev1.Pod) (*workload, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+	switch owner.Kind {
+	case "StatefulSet":
+		return &workload{kind: "StatefulSet", namespace: pod.Namespace, name: owner.Name}, nil
+	case "ReplicaSet":
+		rs, err := cs.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("reading replica set %s/%s: %w", pod.Namespace, owner.Name, err)
+		}
+		if dep := metav1.GetControllerOf(rs); dep != nil && dep.Kind == "Deployment" {
+			return &workload{kind: "Deployment", namespace: pod.Namespace, name: dep.Name}, nil
+		}
+	}
+	return nil, nil
+}
+
+// stopWorkloads scales every StatefulSet and Deployment mounting one of vols
+// to zero and waits for their pods to go. It returns the workloads it
+// stopped, for startWorkloads.
+func stopWorkloads(ctx context.Context, cs kubernetes.Interface, vols []Volume) ([]*workload, error) {
+	var stopped []*workload
+	seen := map[string]*workload{}
+	for _, v := range vols {
+		pods, err := mountingPods(ctx, cs, v)
+		if err != nil {
+			return stopped, err
+		}
+		for i := range pods {
+			w, err := owningWorkload(ctx, cs, &pods[i])
+			if err != nil {
+				return stopped, err
+			}
+			if w == nil {
+				continue
+			}
+			if known, ok := seen[w.String()]; ok {
+				known.pods = append(known.pods, pods[i].Name)
+				continue
+			}
+			w.pods = []string{pods[i].Name}
+			seen[w.String()] = w
+			replicas, err := scale(ctx, cs, w, 0)
+			if err != nil {
+				return stopped, err
+			}
+			w.replicas = replicas
+			stopped = append(stopped, w)
+		}
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, stopTimeout, true, func(ctx context.Context) (bool, error) {
+		for _, w := range stopped {
+			for _, name := range w.pods {
+				_, err := cs.CoreV1().Pods(w.namespace).Get(ctx, name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return stopped, fmt.Errorf("waiting for the pods using the volumes to stop: %w", err)
+	}
+	return stopped, nil
+}
+
+// startWorkloads scales each stopped workload back to its replicas and waits
+// until they are all Ready again.
+func startWorkloads(ctx context.Context, cs kubernetes.Interface, stopped []*workload) error {
+	var errs []error
+	for _, w := range stopped {
+		if _, err := scale(ctx, cs, w, w.replicas); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, readyTimeout, true, func(ctx context.Context) (bool, error) {
+		for _, w := range stopped {
+			ready, err := readyReplicas(ctx, cs, w)
+			if err != nil || ready < w.replicas {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the restarted workloads to become Ready: %w", err)
+	}
+	return nil
+}
+
+// scale sets w's replicas and returns what they were.
+func scale(ctx context.Context, cs kubernetes.Interface, w *workload, replicas int32) (int32, error) {
+	var before int32 = 1
+	var err error
+	switch w.kind {
+	case "StatefulSet":
+		var sts *appsv1.StatefulSet
+		if sts, err = cs.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{}); err == nil {
+			if sts.Spec.Replicas != nil {
+				before = *sts.Spec.Replicas
+			}
+			sts.Spec.Replicas = &replicas
+			_, err = cs.AppsV1().StatefulSets(w.namespace).Update(ctx, sts, metav1.UpdateOptions{})
+		}
+	case "Deployment":
+		var dep *appsv1.Deployment
+		if dep, err = cs.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{}); err == nil {
+			if dep.Spec.Replicas != nil {
+				before = *dep.Spec.Replicas
+			}
+			dep.Spec.Replicas = &replicas
+			_, err = cs.AppsV1().Deployments(w.namespace).Update(ctx, dep, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("scaling %s to %d: %w", w, replicas, err)
+	}
+	return before, nil
+}
+
+func readyReplicas(ctx context.Context, cs kubernetes.Interface, w *workload) (int32, error) {
+	if w.kind == "StatefulSet" {
+		sts, err := cs.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return sts.Status.ReadyReplicas, nil
+	}
+	dep, err := cs.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return dep.Status.ReadyReplicas, nil
+}
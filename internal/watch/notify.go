@@ -0,0 +1,69 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/desktopnotify"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+)
+
+// LogNotifier writes each event as one line, e.g. to a log file.
+type LogNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogNotifier returns a notifier appending event lines to w.
+func NewLogNotifier(w io.Writer) *LogNotifier {
+	return &LogNotifier{w: w}
+}
+
+// Notify implements Notifier.
+func (n *LogNotifier) Notify(e Event) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, _ = fmt.Fprintln(n.w, e.String())
+}
+
+// desktop shows one desktop notification; *desktopnotify.Notifier in
+// production.
+type desktop interface {
+	Show(ctx context.Context, title, message string, urgent bool) error
+}
+
+// Overridden in tests.
+var newDesktop = func(exec executor.CommandExecutor) (desktop, error) {
+	return desktopnotify.New(exec)
+}
+
+// DesktopNotifier shows events as desktop notifications through notify-send
+// (Linux) or osascript (macOS). Delivery is best effort.
+type DesktopNotifier struct {
+	desktop desktop
+}
+
+// NewDesktopNotifier returns a desktop notifier, or false when this machine
+// has no way to show one (a headless Linux box, WSL without notify-send).
+func NewDesktopNotifier(exec executor.CommandExecutor) (*DesktopNotifier, bool) {
+	d, err := newDesktop(exec)
+	if err != nil {
+		return nil, false
+	}
+	return &DesktopNotifier{desktop: d}, true
+}
+
+// Notify implements Notifier.
+func (n *DesktopNotifier) Notify(e Event) {
+	title := fmt.Sprintf("OpenFrame: %s recovered", e.Cluster)
+	if e.Degraded {
+		title = fmt.Sprintf("OpenFrame: %s degraded", e.Cluster)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = n.desktop.Show(ctx, title, e.Message, e.Degraded)
+}
@@ -0,0 +1,64 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shown records what a desktop notifier was asked to show.
+type shown struct {
+	title, message string
+	urgent         bool
+}
+
+type fakeDesktop struct{ shown []shown }
+
+func (f *fakeDesktop) Show(_ context.Context, title, message string, urgent bool) error {
+	f.shown = append(f.shown, shown{title, message, urgent})
+	return nil
+}
+
+func stubDesktop(t *testing.T, available bool) *fakeDesktop {
+	t.Helper()
+	fake := &fakeDesktop{}
+	orig := newDesktop
+	newDesktop = func(executor.CommandExecutor) (desktop, error) {
+		if !available {
+			return nil, errors.New("notify-send is not installed")
+		}
+		return fake, nil
+	}
+	t.Cleanup(func() { newDesktop = orig })
+	return fake
+}
+
+func TestDesktopNotifier_Degraded(t *testing.T) {
+	fake := stubDesktop(t, true)
+	n, ok := NewDesktopNotifier(executor.NewMockCommandExecutor())
+	require.True(t, ok)
+
+	n.Notify(Event{Cluster: "dev", Check: CheckNodes, Degraded: true, Message: "2/3 nodes Ready"})
+
+	assert.Equal(t, []shown{{"OpenFrame: dev degraded", "2/3 nodes Ready", true}}, fake.shown)
+}
+
+func TestDesktopNotifier_Recovered(t *testing.T) {
+	fake := stubDesktop(t, true)
+	n, ok := NewDesktopNotifier(executor.NewMockCommandExecutor())
+	require.True(t, ok)
+
+	n.Notify(Event{Cluster: "dev", Check: CheckApps, Message: `say "hi"`})
+
+	assert.Equal(t, []shown{{"OpenFrame: dev recovered", `say "hi"`, false}}, fake.shown)
+}
+
+func TestDesktopNotifier_UnavailableWithoutTool(t *testing.T) {
+	stubDesktop(t, false)
+	_, ok := NewDesktopNotifier(executor.NewMockCommandExecutor())
+	assert.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package watch
+
+import (
+	"context"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// probeTimeout bounds one cluster's probe, so a hung API server cannot stall
+// the round for the others.
+const probeTimeout = 20 * time.Second
+
+// KubeProber probes clusters through their API servers.
+type KubeProber struct {
+	// RestConfig resolves a cluster name to its API server. It is called on
+	// every round, so it must only read the kubeconfig, as
+	// k8s.RestConfigForCluster does.
+	RestConfig func(cluster string) (*rest.Config, error)
+	Exec       executor.CommandExecutor
+}
+
+// Probe implements Prober.
+func (p KubeProber) Probe(ctx context.Context, cluster string) Snapshot {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	cfg, err := p.RestConfig(cluster)
+	if err != nil {
+		return Snapshot{Error: err.Error()}
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return Snapshot{Error: err.Error()}
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Snapshot{Error: err.Error()}
+	}
+
+	snap := Snapshot{Reachable: true, NodesTotal: len(nodes.Items)}
+	for _, node := range nodes.Items {
+		for _, c := range node.Status.Conditions {
+			switch {
+			case c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue:
+				snap.NodesReady++
+			case c.Type == corev1.NodeDiskPressure && c.Status == corev1.ConditionTrue:
+				snap.DiskPressure = append(snap.DiskPressure, node.Name)
+			}
+		}
+	}
+
+	// No ArgoCD (not installed yet) leaves the applications unchecked rather
+	// than reporting them all as gone.
+	mgr, err := argocd.NewManagerWithConfig(p.Exec, cfg)
+	if err != nil {
+		return snap
+	}
+	apps, err := mgr.ListApplications(ctx, false)
+	if err != nil {
+		return snap
+	}
+	snap.AppsChecked = true
+	snap.Apps = map[string]string{}
+	for _, app := range apps {
+		switch {
+		case app.Health == argocd.ArgoCDHealthDegraded || app.Health == argocd.ArgoCDHealthMissing:
+			snap.Apps[app.Name] = app.Health
+		case app.Sync != argocd.ArgoCDSyncSynced:
+			snap.Apps[app.Name] = app.Sync
+		}
+	}
+	return snap
+}
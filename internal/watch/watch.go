@@ -0,0 +1,266 @@
+// Package watch monitors local clusters in the background and reports when
+// one degrades: the API stops answering, a node drops out of Ready or comes
+// under disk pressure, or an ArgoCD application drifts out of sync. It reports
+// changes, not states, so a cluster that stays broken is reported once and
+// again when it recovers.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is how often every cluster is probed.
+const DefaultInterval = time.Minute
+
+// Check names one thing the watcher looks at.
+type Check string
+
+const (
+	CheckAPI   Check = "api"
+	CheckNodes Check = "nodes"
+	CheckDisk  Check = "disk"
+	CheckApps  Check = "apps"
+)
+
+// Snapshot is one look at a cluster. Node and application fields are only
+// meaningful when Reachable; Apps is nil when ArgoCD could not be read (not
+// installed yet).
+type Snapshot struct {
+	Reachable  bool
+	Error      string
+	NodesReady int
+	NodesTotal int
+	// DiskPressure lists the nodes reporting the DiskPressure condition.
+	DiskPressure []string
+	// Apps maps each ArgoCD application to its drift ("OutOfSync",
+	// "Degraded"); in-sync, healthy applications are left out.
+	Apps map[string]string
+	// AppsChecked is whether the applications could be listed.
+	AppsChecked bool
+}
+
+// Event is a change in one check of one cluster.
+type Event struct {
+	Time    time.Time
+	Cluster string
+	Check   Check
+	// Degraded is true when the check went bad, false when it recovered.
+	Degraded bool
+	Message  string
+}
+
+// String is the event as one log line.
+func (e Event) String() string {
+	state := "recovered"
+	if e.Degraded {
+		state = "DEGRADED"
+	}
+	return fmt.Sprintf("%s %s [%s] %s: %s", e.Time.Format(time.RFC3339), e.Cluster, e.Check, state, e.Message)
+}
+
+// Prober takes a snapshot of a cluster.
+type Prober interface {
+	Probe(ctx context.Context, cluster string) Snapshot
+}
+
+// Notifier delivers events (a log, a desktop notification).
+type Notifier interface {
+	Notify(e Event)
+}
+
+// Watcher probes Clusters every Interval and hands each change to Notifiers.
+type Watcher struct {
+	// Clusters returns the clusters to probe this round; clusters that
+	// disappear are forgotten.
+	Clusters  func(ctx context.Context) ([]string, error)
+	Prober    Prober
+	Notifiers []Notifier
+	Interval  time.Duration
+
+	last map[string]Snapshot
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewWatcher returns a watcher probing every DefaultInterval.
+func NewWatcher(clusters func(ctx context.Context) ([]string, error), prober Prober, notifiers ...Notifier) *Watcher {
+	return &Watcher{
+		Clusters:  clusters,
+		Prober:    prober,
+		Notifiers: notifiers,
+		Interval:  DefaultInterval,
+		last:      map[string]Snapshot{},
+		now:       time.Now,
+		sleep:     sleepContext,
+	}
+}
+
+// Run probes until ctx is cancelled, returning its error.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.Interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", w.Interval)
+	}
+	for {
+		if err := w.Tick(ctx); err != nil {
+			return err
+		}
+		if err := w.sleep(ctx, w.Interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Tick runs one round: probe every cluster and notify about what changed
+// since the last round. The first round reports only what is already broken.
+// Failing to list the clusters is not fatal; the round is skipped.
+func (w *Watcher) Tick(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	clusters, err := w.Clusters(ctx)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, name := range clusters {
+		seen[name] = true
+		cur := w.Prober.Probe(ctx, name)
+		prev, known := w.last[name]
+		var events []Event
+		if known {
+			events = diff(name, prev, cur)
+		} else {
+			events = diff(name, healthy(), cur)
+		}
+		for _, e := range events {
+			e.Time = w.now()
+			for _, n := range w.Notifiers {
+				n.Notify(e)
+			}
+		}
+		w.last[name] = carryOver(prev, cur)
+	}
+	for name := range w.last {
+		if !seen[name] {
+			delete(w.last, name)
+		}
+	}
+	return nil
+}
+
+// healthy is the baseline a newly seen cluster is compared with.
+func healthy() Snapshot {
+	return Snapshot{Reachable: true, AppsChecked: true}
+}
+
+// carryOver keeps the node and application state of prev while cur could not
+// see them, so an API outage does not read as every node and app recovering
+// (or degrading) at once.
+func carryOver(prev, cur Snapshot) Snapshot {
+	if !cur.Reachable {
+		cur.NodesReady, cur.NodesTotal = prev.NodesReady, prev.NodesTotal
+		cur.DiskPressure = prev.DiskPressure
+		cur.Apps, cur.AppsChecked = prev.Apps, prev.AppsChecked
+	} else if !cur.AppsChecked {
+		cur.Apps, cur.AppsChecked = prev.Apps, prev.AppsChecked
+	}
+	return cur
+}
+
+// diff returns the events between two snapshots of cluster.
+func diff(cluster string, prev, cur Snapshot) []Event {
+	var events []Event
+	add := func(check Check, degraded bool, format string, args ...any) {
+		events = append(events, Event{Cluster: cluster, Check: check, Degraded: degraded, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if prev.Reachable != cur.Reachable {
+		if cur.Reachable {
+			add(CheckAPI, false, "API server answering again")
+		} else {
+			add(CheckAPI, true, "API server not reachable: %s", cur.Error)
+		}
+	}
+	if !cur.Reachable {
+		return events
+	}
+
+	prevDown, curDown := prev.NodesTotal-prev.NodesReady, cur.NodesTotal-cur.NodesReady
+	switch {
+	case curDown > 0 && curDown != prevDown:
+		add(CheckNodes, true, "%d/%d nodes Ready", cur.NodesReady, cur.NodesTotal)
+	case curDown == 0 && prevDown > 0:
+		add(CheckNodes, false, "all %d nodes Ready", cur.NodesTotal)
+	}
+
+	if added, cleared := changed(prev.DiskPressure, cur.DiskPressure); len(added) > 0 {
+		add(CheckDisk, true, "disk pressure on %s (free space on the Docker host)", strings.Join(added, ", "))
+	} else if len(cleared) > 0 && len(cur.DiskPressure) == 0 {
+		add(CheckDisk, false, "no node under disk pressure")
+	}
+
+	if cur.AppsChecked {
+		prevApps := drifted(prev.Apps)
+		if added, cleared := changed(prevApps, drifted(cur.Apps)); len(added) > 0 {
+			details := make([]string, len(added))
+			for i, app := range added {
+				details[i] = fmt.Sprintf("%s (%s)", app, cur.Apps[app])
+			}
+			add(CheckApps, true, "drifted: %s", strings.Join(details, ", "))
+		} else if len(cleared) > 0 && len(cur.Apps) == 0 {
+			add(CheckApps, false, "all applications synced and healthy")
+		}
+	}
+	return events
+}
+
+func drifted(apps map[string]string) []string {
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	return names
+}
+
+// changed returns the names in cur but not prev, and in prev but not cur,
+// each sorted.
+func changed(prev, cur []string) (added, cleared []string) {
+	in := func(list []string, s string) bool {
+		for _, x := range list {
+			if x == s {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range cur {
+		if !in(prev, s) {
+			added = append(added, s)
+		}
+	}
+	for _, s := range prev {
+		if !in(cur, s) {
+			cleared = append(cleared, s)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(cleared)
+	return added, cleared
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedProber replays one snapshot per round for each cluster.
+type scriptedProber map[string][]Snapshot
+
+func (p scriptedProber) Probe(_ context.Context, cluster string) Snapshot {
+	snaps := p[cluster]
+	s := snaps[0]
+	if len(snaps) > 1 {
+		p[cluster] = snaps[1:]
+	}
+	return s
+}
+
+type recorder struct{ events []Event }
+
+func (r *recorder) Notify(e Event) { r.events = append(r.events, e) }
+
+func ok(ready, total int) Snapshot {
+	return Snapshot{Reachable: true, NodesReady: ready, NodesTotal: total, AppsChecked: true, Apps: map[string]string{}}
+}
+
+func newTestWatcher(clusters []string, p Prober) (*Watcher, *recorder) {
+	rec := &recorder{}
+	w := NewWatcher(func(context.Context) ([]string, error) { return clusters, nil }, p, rec)
+	w.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return w, rec
+}
+
+func ticks(t *testing.T, w *Watcher, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, w.Tick(context.Background()))
+	}
+}
+
+func TestTick_ReportsChangesOnce(t *testing.T) {
+	lost := ok(2, 3)
+	w, rec := newTestWatcher([]string{"dev"}, scriptedProber{"dev": {ok(3, 3), lost, lost, ok(3, 3)}})
+	ticks(t, w, 4)
+
+	require.Len(t, rec.events, 2)
+	assert.Equal(t, CheckNodes, rec.events[0].Check)
+	assert.True(t, rec.events[0].Degraded)
+	assert.Equal(t, "2/3 nodes Ready", rec.events[0].Message)
+	assert.False(t, rec.events[1].Degraded)
+}
+
+func TestTick_FirstRoundReportsExistingProblems(t *testing.T) {
+	sick := ok(1, 1)
+	sick.DiskPressure = []string{"k3d-dev-server-0"}
+	sick.Apps = map[string]string{"openframe-api": "OutOfSync"}
+	w, rec := newTestWatcher([]string{"dev"}, scriptedProber{"dev": {sick}})
+	ticks(t, w, 1)
+
+	require.Len(t, rec.events, 2)
+	assert.Equal(t, CheckDisk, rec.events[0].Check)
+	assert.Contains(t, rec.events[0].Message, "k3d-dev-server-0")
+	assert.Equal(t, CheckApps, rec.events[1].Check)
+	assert.Equal(t, "drifted: openframe-api (OutOfSync)", rec.events[1].Message)
+}
+
+func TestTick_OutageDoesNotFlapNodesAndApps(t *testing.T) {
+	drift := ok(3, 3)
+	drift.Apps = map[string]string{"openframe-api": "Degraded"}
+	down := Snapshot{Error: "connection refused"}
+	w, rec := newTestWatcher([]string{"dev"}, scriptedProber{"dev": {drift, down, drift}})
+	ticks(t, w, 3)
+
+	var checks []Check
+	for _, e := range rec.events {
+		checks = append(checks, e.Check)
+	}
+	assert.Equal(t, []Check{CheckApps, CheckAPI, CheckAPI}, checks,
+		"the app drift is reported once, not again after the outage")
+	assert.Contains(t, rec.events[1].Message, "connection refused")
+}
+
+func TestTick_UncheckedAppsKeepTheirState(t *testing.T) {
+	drift := ok(1, 1)
+	drift.Apps = map[string]string{"openframe-api": "OutOfSync"}
+	noArgo := ok(1, 1)
+	noArgo.AppsChecked, noArgo.Apps = false, nil
+	w, rec := newTestWatcher([]string{"dev"}, scriptedProber{"dev": {drift, noArgo, drift}})
+	ticks(t, w, 3)
+
+	assert.Len(t, rec.events, 1)
+}
+
+func TestTick_ListErrorSkipsRound(t *testing.T) {
+	rec := &recorder{}
+	w := NewWatcher(func(context.Context) ([]string, error) { return nil, errors.New("k3d missing") }, scriptedProber{}, rec)
+	assert.NoError(t, w.Tick(context.Background()))
+	assert.Empty(t, rec.events)
+}
+
+func TestRun_StopsOnCancel(t *testing.T) {
+	w, _ := newTestWatcher([]string{"dev"}, scriptedProber{"dev": {ok(1, 1)}})
+	ctx, cancel := context.WithCancel(context.Background())
+	w.sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+	assert.ErrorIs(t, w.Run(ctx), context.Canceled)
+}
+
+func TestEventString(t *testing.T) {
+	e := Event{Time: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), Cluster: "dev", Check: CheckAPI, Degraded: true, Message: "API server not reachable: timeout"}
+	assert.Equal(t, "2026-01-01T09:00:00Z dev [api] DEGRADED: API server not reachable: timeout", e.String())
+}
@@ -6,8 +6,10 @@ import (
 	"os"
 
 	"github.com/flamingo-stack/openframe-cli/cmd"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/ci"
 	sharederrors "github.com/flamingo-stack/openframe-cli/internal/shared/errors"
 	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/telemetry"
 )
 
 func main() {
@@ -25,7 +27,14 @@ func main() {
 
 // exitCode preserves a failed external command's exit code (exit-code fidelity
 // for automation) when it is a valid Unix code; otherwise it is a generic 1.
+// Under --ci a failure during a known install phase exits with that phase's
+// code instead, so a pipeline can tell which stage broke.
 func exitCode(err error) int {
+	if ci.Enabled() {
+		if c := telemetry.PhaseExitCode(telemetry.CurrentPhase()); c != 0 {
+			return c
+		}
+	}
 	var ce *executor.CommandError
 	if stderrors.As(err, &ce) && ce.ExitCode > 0 && ce.ExitCode < 256 {
 		return ce.ExitCode
@@ -0,0 +1,179 @@
+// Package chart is the public Go API for deploying OpenFrame onto a cluster:
+// ArgoCD and the app-of-apps, as `openframe app install` does, and the Helm
+// releases behind them.
+//
+// Like package cluster, its types are separate from the CLI's internal ones
+// and only ever gain fields, with zero values meaning the CLI's defaults. An
+// install never prompts: it reads openframe-helm-values.yaml from the working
+// directory when there is one, and deploys the chart defaults otherwise.
+package chart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/services"
+	"github.com/flamingo-stack/openframe-cli/internal/chart/utils/types"
+	internalcluster "github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/k8s"
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultRepo and DefaultRef are the app-of-apps repository and ref of an
+// InstallConfig that does not set them.
+const (
+	DefaultRepo = models.RepoOSSTenant
+	DefaultRef  = models.DefaultGitBranch
+)
+
+// InstallConfig describes an install.
+type InstallConfig struct {
+	// Cluster is the managed cluster to install into. KubeContext targets any
+	// kubeconfig context instead and wins when both are set.
+	Cluster     string
+	KubeContext string
+	// Repo and Ref are the app-of-apps Git repository and branch, tag or
+	// commit; empty means DefaultRepo and DefaultRef. A Ref that is set
+	// overrides the branch in openframe-helm-values.yaml.
+	Repo string
+	Ref  string
+	// CertDir holds the TLS certificate for the platform; empty detects it.
+	CertDir string
+	// Force reinstalls a release that is already installed.
+	Force bool
+	// DryRun shows what would be installed without installing it.
+	DryRun bool
+	// Verbose logs the commands run and their output.
+	Verbose bool
+	// Size scales the platform to the host: "auto" or "" detects it, or
+	// "small", "medium", "large".
+	Size string
+	// GitOpsEngine is "argocd" (the default) or "flux".
+	GitOpsEngine string
+	// ReadinessGates is a gates file waited for once the applications are
+	// ready; empty uses the default file when there is one.
+	ReadinessGates string
+	// SkipVerify skips the endpoint smoke test after the install.
+	SkipVerify bool
+	// Resume picks up an install interrupted during the application wait.
+	Resume bool
+	// SkipRepoUpdate uses the cached Helm repository index however old.
+	SkipRepoUpdate bool
+	// SkipRepoCheck skips checking the applications' repositories are
+	// reachable before the install.
+	SkipRepoCheck bool
+}
+
+// Install deploys OpenFrame as config describes and returns once the
+// applications are ready, with what it did.
+func Install(ctx context.Context, config InstallConfig) (*Result, error) {
+	req, err := config.request()
+	if err != nil {
+		return nil, err
+	}
+	result, err := services.InstallChartsWithResult(ctx, req)
+	return resultFrom(result), err
+}
+
+// request converts c to the CLI's installation request.
+func (c InstallConfig) request() (types.InstallationRequest, error) {
+	req := types.InstallationRequest{
+		Force:             c.Force,
+		DryRun:            c.DryRun,
+		Verbose:           c.Verbose,
+		GitHubRepo:        c.Repo,
+		GitHubBranch:      c.Ref,
+		GitHubRefExplicit: c.Ref != "",
+		CertDir:           c.CertDir,
+		NonInteractive:    true,
+		GitOpsEngine:      c.GitOpsEngine,
+		ReadinessGates:    c.ReadinessGates,
+		SkipVerify:        c.SkipVerify,
+		Size:              c.Size,
+		Resume:            c.Resume,
+		SkipRepoUpdate:    c.SkipRepoUpdate,
+		SkipRepoCheck:     c.SkipRepoCheck,
+		ClusterAccess:     internalcluster.NewClusterServiceSuppressed(executor.NewRealCommandExecutor(false, c.Verbose)),
+	}
+	if req.GitHubRepo == "" {
+		req.GitHubRepo = DefaultRepo
+	}
+	if req.GitHubBranch == "" {
+		req.GitHubBranch = DefaultRef
+	}
+	switch {
+	case c.KubeContext != "":
+		cfg, err := k8s.RestConfigForContext(k8s.KubeconfigForContext(c.KubeContext), c.KubeContext)
+		if err != nil {
+			return req, fmt.Errorf("could not use context %q: %w", c.KubeContext, err)
+		}
+		req.KubeConfig = cfg
+		req.KubeContext = c.KubeContext
+	case c.Cluster != "":
+		req.Args = []string{c.Cluster}
+	default:
+		return req, fmt.Errorf("no install target: set Cluster or KubeContext")
+	}
+	return req, nil
+}
+
+// Release describes an installed Helm release.
+type Release struct {
+	Name       string
+	Namespace  string
+	Status     string
+	Version    string // chart version
+	AppVersion string
+}
+
+// HelmManager inspects and removes the Helm releases of an install. Installed
+// and Status query the kubeconfig's current context; Uninstall targets the
+// context it was created for.
+type HelmManager interface {
+	// Installed reports whether release exists in namespace.
+	Installed(ctx context.Context, release, namespace string) (bool, error)
+	// Status returns release's chart and state.
+	Status(ctx context.Context, release, namespace string) (Release, error)
+	// Uninstall removes release; a missing release is not an error.
+	Uninstall(ctx context.Context, release, namespace string) error
+}
+
+// NewHelmManager returns a HelmManager for the cluster config points at,
+// reached through kubeContext ("" for the current context).
+func NewHelmManager(config *rest.Config, kubeContext string, verbose bool) (HelmManager, error) {
+	h, err := helm.NewHelmManager(executor.NewRealCommandExecutor(false, verbose), config, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return &helmManager{helm: h, kubeContext: kubeContext}, nil
+}
+
+type helmManager struct {
+	helm        *helm.HelmManager
+	kubeContext string
+}
+
+func (h *helmManager) Installed(ctx context.Context, release, namespace string) (bool, error) {
+	return h.helm.IsChartInstalled(ctx, release, namespace)
+}
+
+func (h *helmManager) Status(ctx context.Context, release, namespace string) (Release, error) {
+	info, err := h.helm.GetChartStatus(ctx, release, namespace)
+	if err != nil {
+		return Release{}, err
+	}
+	return Release{
+		Name:       info.Name,
+		Namespace:  info.Namespace,
+		Status:     info.Status,
+		Version:    info.Version,
+		AppVersion: info.AppVersion,
+	}, nil
+}
+
+func (h *helmManager) Uninstall(ctx context.Context, release, namespace string) error {
+	return h.helm.UninstallRelease(ctx, release, namespace, h.kubeContext)
+}
@@ -0,0 +1,42 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallConfig_NamedCluster(t *testing.T) {
+	req, err := InstallConfig{Cluster: "dev", Size: "small", SkipRepoUpdate: true}.request()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev"}, req.Args)
+	assert.True(t, req.NonInteractive, "the SDK never prompts")
+	assert.Equal(t, DefaultRepo, req.GitHubRepo)
+	assert.Equal(t, DefaultRef, req.GitHubBranch)
+	assert.False(t, req.GitHubRefExplicit, "the values file branch is kept")
+	assert.Equal(t, "small", req.Size)
+	assert.True(t, req.SkipRepoUpdate)
+	assert.NotNil(t, req.ClusterAccess)
+	assert.Nil(t, req.KubeConfig, "the service resolves a named cluster")
+}
+
+func TestInstallConfig_ExplicitRef(t *testing.T) {
+	req, err := InstallConfig{Cluster: "dev", Repo: "https://github.com/acme/tenant", Ref: "v1.2.3"}.request()
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/tenant", req.GitHubRepo)
+	assert.Equal(t, "v1.2.3", req.GitHubBranch)
+	assert.True(t, req.GitHubRefExplicit)
+}
+
+func TestInstallConfig_NeedsTarget(t *testing.T) {
+	_, err := InstallConfig{}.request()
+	assert.ErrorContains(t, err, "no install target")
+}
+
+func TestInstallConfig_UnknownContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBECONFIG", t.TempDir()+"/missing")
+	_, err := InstallConfig{KubeContext: "nope"}.request()
+	assert.ErrorContains(t, err, `could not use context "nope"`)
+}
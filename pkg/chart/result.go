@@ -0,0 +1,94 @@
+package chart
+
+import (
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+)
+
+// Result is what an install did. Install returns it also when it fails, to
+// say how far the install got; it is nil only when nothing started.
+type Result struct {
+	Cluster     string
+	KubeContext string
+	// Engine is the GitOps engine that deployed the platform.
+	Engine   string
+	DryRun   bool
+	Started  time.Time
+	Finished time.Time
+	// Phases are the install phases in the order they ran.
+	Phases       []Phase
+	Applications []Application
+	// Endpoints are the post-install smoke test checks; none with SkipVerify.
+	Endpoints   []Endpoint
+	Credentials []Credential
+	// Warnings are problems that did not fail the install.
+	Warnings []string
+}
+
+// Phase is one install phase ("argocd-install", "app-of-apps",
+// "argocd-sync", ...). A skipped phase was left in place by a previous run.
+type Phase struct {
+	Name     string
+	Duration time.Duration
+	Skipped  bool
+}
+
+// Application is a deployed application as the install left it.
+type Application struct {
+	Name   string
+	Sync   string
+	Health string
+}
+
+// Endpoint is one smoke test check; Outcome is "pass", "fail" or "skip".
+type Endpoint struct {
+	Check   string
+	Target  string
+	Outcome string
+	Detail  string
+}
+
+// Credential says where a credential is kept: Key in Secret in Namespace, or
+// the OS keychain under the `openframe credentials` name Keychain.
+type Credential struct {
+	Description string
+	Namespace   string
+	Secret      string
+	Key         string
+	Keychain    string
+}
+
+// Duration is the install's wall-clock time.
+func (r *Result) Duration() time.Duration {
+	return r.Finished.Sub(r.Started)
+}
+
+// resultFrom converts the CLI's install result.
+func resultFrom(in *models.InstallResult) *Result {
+	if in == nil {
+		return nil
+	}
+	out := &Result{
+		Cluster:     in.Cluster,
+		KubeContext: in.KubeContext,
+		Engine:      in.Engine,
+		DryRun:      in.DryRun,
+		Started:     in.Started,
+		Finished:    in.Finished,
+		Warnings:    append([]string(nil), in.Warnings...),
+	}
+	for _, p := range in.Phases {
+		out.Phases = append(out.Phases, Phase(p))
+	}
+	for _, a := range in.Applications {
+		out.Applications = append(out.Applications, Application(a))
+	}
+	for _, e := range in.Endpoints {
+		out.Endpoints = append(out.Endpoints, Endpoint(e))
+	}
+	for _, c := range in.Credentials {
+		out.Credentials = append(out.Credentials, Credential(c))
+	}
+	return out
+}
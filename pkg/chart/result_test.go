@@ -0,0 +1,34 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultFrom(t *testing.T) {
+	assert.Nil(t, resultFrom(nil))
+
+	started := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	in := &models.InstallResult{
+		Cluster:      "dev",
+		Engine:       "argocd",
+		Started:      started,
+		Finished:     started.Add(time.Minute),
+		Phases:       []models.PhaseResult{{Name: "argocd-install", Duration: 30 * time.Second}},
+		Applications: []models.ApplicationResult{{Name: "api", Sync: "Synced", Health: "Healthy"}},
+		Endpoints:    []models.EndpointResult{{Check: "ui", Target: "https://localhost", Outcome: "pass"}},
+		Credentials:  []models.CredentialRef{models.ArgoCDAdminCredential},
+		Warnings:     []string{"smoke test: no values file"},
+	}
+	out := resultFrom(in)
+	assert.Equal(t, "dev", out.Cluster)
+	assert.Equal(t, time.Minute, out.Duration())
+	assert.Equal(t, []Phase{{Name: "argocd-install", Duration: 30 * time.Second}}, out.Phases)
+	assert.Equal(t, []Application{{Name: "api", Sync: "Synced", Health: "Healthy"}}, out.Applications)
+	assert.Equal(t, "https://localhost", out.Endpoints[0].Target)
+	assert.Equal(t, Credential{Description: "ArgoCD admin password (user admin)", Namespace: "argocd", Secret: "argocd-initial-admin-secret", Key: "password"}, out.Credentials[0])
+	assert.Equal(t, in.Warnings, out.Warnings)
+}
@@ -0,0 +1,213 @@
+// Package cluster is the public Go API for the local clusters openframe
+// manages: create, list, inspect and delete them, and get a client config for
+// one, without running the CLI.
+//
+// The types here are the API. They are deliberately separate from the CLI's
+// internal ones, so the internals can change without breaking embedders:
+// fields are only ever added, with zero values meaning the CLI's defaults.
+// Options given as text (registry mirrors, node labels, k3s arguments) use
+// the syntax of the matching `openframe cluster create` flag.
+//
+// The calls do what the CLI does and report progress the same way, on
+// standard output; they never prompt.
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/argocd"
+	internal "github.com/flamingo-stack/openframe-cli/internal/cluster"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	internalexecutor "github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/flamingo-stack/openframe-cli/pkg/executor"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultNodeCount is the node count of a Config that does not set one.
+const DefaultNodeCount = 3
+
+// Config describes a cluster to create.
+type Config struct {
+	// Name of the cluster; required.
+	Name string
+	// NodeCount is the number of nodes, one server and the rest agents;
+	// 0 means DefaultNodeCount.
+	NodeCount int
+	// K8sVersion is the Kubernetes or k3s version ("1.31", "v1.31.5+k3s1");
+	// empty means the CLI's pinned default.
+	K8sVersion string
+	// RegistryMirrors are "registry=endpoint" pairs (--registry-mirror).
+	RegistryMirrors []string
+	// NodeLabels and NodeTaints are "key=value[@nodefilter]" and
+	// "key[=value]:Effect[@nodefilter]" (--node-label, --node-taint).
+	NodeLabels []string
+	NodeTaints []string
+	// K3sArgs are extra k3s arguments, "ARG[@nodefilter]" (--k3s-arg). The
+	// k3s section of ~/.openframe/config.json is not read.
+	K3sArgs []string
+	// Ingress is "traefik", "nginx" or "none" (the default).
+	Ingress string
+	// GPUs requests NVIDIA GPU passthrough: "all" or a device count.
+	GPUs string
+	// ImageCache keeps node images across recreations: "volume" or a host
+	// directory (--image-cache).
+	ImageCache string
+	// SkipImagePrePull turns off pre-pulling the stack's images (--no-prepull).
+	SkipImagePrePull bool
+}
+
+// Info describes an existing cluster.
+type Info struct {
+	Name string
+	// Type is the backend owning the cluster: "k3d", or "external" for a
+	// cluster attached by kube-context.
+	Type         string
+	ReadyServers int
+	TotalServers int
+	NodeCount    int
+	K8sVersion   string
+	CreatedAt    time.Time
+}
+
+// Manager creates and manages clusters.
+type Manager interface {
+	// Create creates the cluster and returns a client config for it, once its
+	// nodes are Ready. Creating a cluster that exists returns its config.
+	Create(ctx context.Context, config Config) (*rest.Config, error)
+	// Delete deletes the cluster; force skips the graceful steps.
+	Delete(ctx context.Context, name string, force bool) error
+	// List returns every cluster, including attached ones.
+	List(ctx context.Context) ([]Info, error)
+	// Status returns one cluster.
+	Status(ctx context.Context, name string) (Info, error)
+	// RestConfig returns a client config for an existing cluster, waiting for
+	// its API server within ctx. It only reads the kubeconfig: the current
+	// context is left alone.
+	RestConfig(ctx context.Context, name string) (*rest.Config, error)
+}
+
+// Options configure NewManager.
+type Options struct {
+	// Verbose logs the commands run and their output.
+	Verbose bool
+	// Spinners shows animated progress instead of plain lines.
+	Spinners bool
+	// Executor runs the external commands (k3d, docker, helm); nil runs them
+	// for real. Any executor.CommandExecutor works; tests pass an
+	// executortest.FakeExecutor.
+	Executor executor.CommandExecutor
+}
+
+// NewManager returns a Manager for the local clusters.
+func NewManager(opts Options) Manager {
+	exec := opts.Executor
+	if exec == nil {
+		exec = internalexecutor.NewRealCommandExecutor(false, opts.Verbose)
+	}
+	service := internal.NewClusterServiceSuppressed(exec)
+	if opts.Spinners {
+		service = internal.NewClusterService(exec)
+	}
+	return &manager{service: service.WithPrePullCharts(argocd.PrePullChart())}
+}
+
+// GetRestConfig returns a client config for the cluster called name. Like
+// Manager.RestConfig, it does not change the kubeconfig.
+func GetRestConfig(name string) (*rest.Config, error) {
+	return NewManager(Options{}).RestConfig(context.Background(), name)
+}
+
+type manager struct {
+	service *internal.ClusterService
+}
+
+func (m *manager) Create(ctx context.Context, config Config) (*rest.Config, error) {
+	cfg, err := config.internal()
+	if err != nil {
+		return nil, err
+	}
+	return m.service.CreateCluster(ctx, cfg)
+}
+
+func (m *manager) Delete(ctx context.Context, name string, force bool) error {
+	clusterType, err := m.service.DetectClusterType(name)
+	if err != nil {
+		return err
+	}
+	return m.service.DeleteCluster(ctx, name, clusterType, force)
+}
+
+func (m *manager) List(context.Context) ([]Info, error) {
+	clusters, err := m.service.ListClusters()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]Info, 0, len(clusters))
+	for _, c := range clusters {
+		infos = append(infos, infoFrom(c))
+	}
+	return infos, nil
+}
+
+func (m *manager) Status(_ context.Context, name string) (Info, error) {
+	info, err := m.service.GetClusterStatus(name)
+	if err != nil {
+		return Info{}, err
+	}
+	return infoFrom(info), nil
+}
+
+func (m *manager) RestConfig(ctx context.Context, name string) (*rest.Config, error) {
+	return m.service.RestConfig(ctx, name)
+}
+
+// internal validates c and converts it to the CLI's cluster config.
+func (c Config) internal() (models.ClusterConfig, error) {
+	cfg := models.ClusterConfig{
+		Name:             c.Name,
+		Type:             models.ClusterTypeK3d,
+		NodeCount:        c.NodeCount,
+		K8sVersion:       c.K8sVersion,
+		GPUs:             c.GPUs,
+		SkipImagePrePull: c.SkipImagePrePull,
+	}
+	if cfg.NodeCount == 0 {
+		cfg.NodeCount = DefaultNodeCount
+	}
+	var err error
+	if cfg.RegistryMirrors, err = models.ParseRegistryMirrors(c.RegistryMirrors); err != nil {
+		return cfg, err
+	}
+	if cfg.NodeLabels, err = models.ParseNodeLabels(c.NodeLabels); err != nil {
+		return cfg, err
+	}
+	if cfg.NodeTaints, err = models.ParseNodeTaints(c.NodeTaints); err != nil {
+		return cfg, err
+	}
+	if cfg.K3sArgs, err = models.ParseK3sArgs(c.K3sArgs); err != nil {
+		return cfg, err
+	}
+	if cfg.Ingress, err = models.ParseIngress(c.Ingress); err != nil {
+		return cfg, err
+	}
+	if err = models.ValidateGPURequest(c.GPUs); err != nil {
+		return cfg, err
+	}
+	if cfg.ImageCache, err = models.ParseImageCache(c.ImageCache); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func infoFrom(c models.ClusterInfo) Info {
+	return Info{
+		Name:         c.Name,
+		Type:         string(c.Type),
+		ReadyServers: c.ReadyServers,
+		TotalServers: c.TotalServers,
+		NodeCount:    c.NodeCount,
+		K8sVersion:   c.K8sVersion,
+		CreatedAt:    c.CreatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/models"
+	"github.com/flamingo-stack/openframe-cli/pkg/executortest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Defaults(t *testing.T) {
+	cfg, err := Config{Name: "dev"}.internal()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", cfg.Name)
+	assert.Equal(t, models.ClusterTypeK3d, cfg.Type)
+	assert.Equal(t, DefaultNodeCount, cfg.NodeCount)
+	assert.Equal(t, models.IngressNone, cfg.Ingress)
+}
+
+func TestConfig_ParsesFlagSyntax(t *testing.T) {
+	cfg, err := Config{
+		Name:            "dev",
+		NodeCount:       2,
+		RegistryMirrors: []string{"docker.io=https://mirror.example.com"},
+		NodeLabels:      []string{"workload=db@agent:0"},
+		NodeTaints:      []string{"dedicated=db:NoSchedule@agent:0"},
+		K3sArgs:         []string{"--kube-proxy-arg=proxy-mode=ipvs"},
+		Ingress:         "nginx",
+		ImageCache:      "volume",
+	}.internal()
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.NodeCount)
+	require.Len(t, cfg.RegistryMirrors, 1)
+	assert.Equal(t, []models.NodeLabel{{Key: "workload", Value: "db", NodeFilters: []string{"agent:0"}}}, cfg.NodeLabels)
+	assert.Equal(t, "dedicated=db:NoSchedule", cfg.NodeTaints[0].String())
+	assert.Equal(t, []models.K3sArg{{Arg: "--kube-proxy-arg=proxy-mode=ipvs", NodeFilters: []string{"server:*"}}}, cfg.K3sArgs)
+	assert.Equal(t, models.IngressNginx, cfg.Ingress)
+	assert.Equal(t, models.ImageCacheVolume, cfg.ImageCache)
+}
+
+func TestConfig_RejectsInvalidOptions(t *testing.T) {
+	for _, c := range []Config{
+		{Name: "dev", NodeLabels: []string{"novalue"}},
+		{Name: "dev", K3sArgs: []string{"disable=traefik"}},
+		{Name: "dev", Ingress: "istio"},
+		{Name: "dev", GPUs: "some"},
+	} {
+		_, err := c.internal()
+		assert.Errorf(t, err, "%+v should be rejected", c)
+	}
+}
+
+func TestInfoFrom(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	info := infoFrom(models.ClusterInfo{
+		Name: "dev", Type: models.ClusterTypeK3d, Status: "1/1", ReadyServers: 1, TotalServers: 1,
+		NodeCount: 3, K8sVersion: "v1.31.5+k3s1", CreatedAt: created,
+	})
+	assert.Equal(t, Info{Name: "dev", Type: "k3d", ReadyServers: 1, TotalServers: 1, NodeCount: 3, K8sVersion: "v1.31.5+k3s1", CreatedAt: created}, info)
+}
+
+func TestManager_WithFakeExecutor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // no attached clusters
+	exec := executortest.New()
+	exec.On("k3d", "cluster", "list").Returns(executortest.K3dClusterList(executortest.K3dCluster{Name: "dev", Agents: 2}))
+
+	infos, err := NewManager(Options{Executor: exec}).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "dev", infos[0].Name)
+	assert.Equal(t, 3, infos[0].NodeCount)
+	assert.True(t, exec.Called("k3d", "cluster", "list"))
+}
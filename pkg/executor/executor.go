@@ -0,0 +1,29 @@
+// Package executor is the public face of the executor openframe runs its
+// external commands through (k3d, docker, helm, kubectl). A program embedding
+// pkg/cluster implements CommandExecutor to run, record or fake those
+// commands, and passes it as cluster.Options.Executor.
+//
+// The types are aliases of the CLI's own, so an implementation is used as
+// is; like the rest of pkg/, they only ever gain fields.
+package executor
+
+import "github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+
+type (
+	// CommandExecutor runs a command and returns its result. A command that
+	// exits non-zero returns an error, a *CommandError for one that ran.
+	CommandExecutor = executor.CommandExecutor
+	// CommandResult is what a command printed, how it exited and how long it ran.
+	CommandResult = executor.CommandResult
+	// ExecuteOptions describe a command to run: its arguments, working
+	// directory, environment, timeout, stdin and a callback for live output.
+	ExecuteOptions = executor.ExecuteOptions
+	// CommandError is the error of a command that exited non-zero.
+	CommandError = executor.CommandError
+)
+
+// NewCommandError returns the error of command exiting with exitCode after
+// printing stderr, as the CLI's executor reports it.
+func NewCommandError(command string, exitCode int, stderr string) *CommandError {
+	return executor.NewCommandError(command, exitCode, stderr)
+}
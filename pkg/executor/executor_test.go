@@ -0,0 +1,47 @@
+package executor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/pkg/cluster"
+	"github.com/flamingo-stack/openframe-cli/pkg/executor"
+	"github.com/flamingo-stack/openframe-cli/pkg/executortest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingExecutor is an executor written against pkg/ alone, as a program
+// embedding pkg/cluster would write one.
+type recordingExecutor struct {
+	commands []string
+}
+
+func (r *recordingExecutor) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	return r.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args})
+}
+
+func (r *recordingExecutor) ExecuteWithOptions(_ context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	r.commands = append(r.commands, options.Command)
+	if options.Command != "k3d" {
+		return nil, executor.NewCommandError(options.Command, 1, "not scripted")
+	}
+	return &executor.CommandResult{Stdout: executortest.K3dClusterList(executortest.K3dCluster{Name: "dev"})}, nil
+}
+
+func TestCommandExecutor_ImplementedOutsideTheModule(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // no attached clusters
+	exec := &recordingExecutor{}
+
+	infos, err := cluster.NewManager(cluster.Options{Executor: exec}).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "dev", infos[0].Name)
+	assert.Contains(t, exec.commands, "k3d")
+}
+
+func TestNewCommandError(t *testing.T) {
+	err := executor.NewCommandError("k3d cluster list", 2, "boom")
+	assert.Equal(t, 2, err.ExitCode)
+	assert.ErrorContains(t, err, "boom")
+}
@@ -0,0 +1,199 @@
+// Package executortest fakes the executor openframe runs its external
+// commands through (k3d, helm, docker, kubectl), for provider tests in this
+// repository and in programs embedding pkg/cluster:
+//
+//	exec := executortest.New()
+//	exec.On("k3d", "cluster", "list").Returns(executortest.K3dClusterList(executortest.K3dCluster{Name: "dev"}))
+//	manager := cluster.NewManager(cluster.Options{Executor: exec})
+//
+// Nothing is run: each command is recorded and answered from the responses
+// scripted with On. The fixtures render k3d and helm output in the formats
+// the CLI parses.
+package executortest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/flamingo-stack/openframe-cli/pkg/executor"
+)
+
+// Call is a command the fake was asked to run.
+type Call struct {
+	Name  string
+	Args  []string
+	Env   map[string]string
+	Stdin []byte
+}
+
+// String renders the call as a command line.
+func (c Call) String() string {
+	return strings.TrimSpace(c.Name + " " + strings.Join(c.Args, " "))
+}
+
+// Response is the scripted answer to the commands a rule matches. The zero
+// Response succeeds with no output.
+type Response struct {
+	stdout, stderr string
+	exitCode       int
+	err            error
+}
+
+// Returns sets the command's standard output.
+func (r *Response) Returns(stdout string) *Response {
+	r.stdout = stdout
+	return r
+}
+
+// Stderr sets the command's standard error.
+func (r *Response) Stderr(stderr string) *Response {
+	r.stderr = stderr
+	return r
+}
+
+// Exits makes the command exit with code; a non-zero code fails the call
+// with the executor's *CommandError, as a real failing command would.
+func (r *Response) Exits(code int) *Response {
+	r.exitCode = code
+	return r
+}
+
+// Errors makes the call fail with err before the command ran, as for a
+// missing binary or a timeout.
+func (r *Response) Errors(err error) *Response {
+	r.err = err
+	return r
+}
+
+type rule struct {
+	match    func(Call) bool
+	response *Response
+}
+
+// FakeExecutor answers commands from scripted responses and records every
+// call. It is safe for concurrent use.
+type FakeExecutor struct {
+	mu     sync.Mutex
+	rules  []rule
+	calls  []Call
+	strict bool
+}
+
+// New returns a FakeExecutor on which every command succeeds with no output
+// until responses are scripted.
+func New() *FakeExecutor {
+	return &FakeExecutor{}
+}
+
+// Strict makes commands no response matches fail instead of succeeding, for
+// tests that must account for everything run.
+func (f *FakeExecutor) Strict() *FakeExecutor {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.strict = true
+	return f
+}
+
+// On scripts the response to command name run with args as its leading
+// arguments: On("k3d", "cluster", "list") matches "k3d cluster list
+// --output json". When several responses match, the last scripted wins, so a
+// test can override a response set up by a shared helper.
+func (f *FakeExecutor) On(name string, args ...string) *Response {
+	return f.OnMatch(func(c Call) bool {
+		return c.Name == name && len(c.Args) >= len(args) && slices.Equal(c.Args[:len(args)], args)
+	})
+}
+
+// OnMatch scripts the response to the calls match accepts.
+func (f *FakeExecutor) OnMatch(match func(Call) bool) *Response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r := &Response{}
+	f.rules = append(f.rules, rule{match: match, response: r})
+	return r
+}
+
+// Calls returns the calls made so far, in order.
+func (f *FakeExecutor) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+// Called reports whether command name was run with args as its leading
+// arguments.
+func (f *FakeExecutor) Called(name string, args ...string) bool {
+	for _, c := range f.Calls() {
+		if c.Name == name && len(c.Args) >= len(args) && slices.Equal(c.Args[:len(args)], args) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset forgets the calls made and the responses scripted.
+func (f *FakeExecutor) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls, f.rules = nil, nil
+}
+
+// Execute runs name with args.
+func (f *FakeExecutor) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	return f.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args})
+}
+
+// ExecuteWithOptions records the call and answers it. Output is streamed to
+// options.OnOutput line by line, as the real executor does.
+func (f *FakeExecutor) ExecuteWithOptions(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	call := Call{Name: options.Command, Args: slices.Clone(options.Args), Stdin: slices.Clone(options.Stdin)}
+	if options.Env != nil {
+		call.Env = make(map[string]string, len(options.Env))
+		for k, v := range options.Env {
+			call.Env[k] = v
+		}
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	var response *Response
+	for i := len(f.rules) - 1; i >= 0; i-- {
+		if f.rules[i].match(call) {
+			response = f.rules[i].response
+			break
+		}
+	}
+	strict := f.strict
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if response == nil {
+		if strict {
+			return nil, fmt.Errorf("executortest: unexpected command: %s", call)
+		}
+		response = &Response{}
+	}
+	if response.err != nil {
+		return nil, response.err
+	}
+
+	result := &executor.CommandResult{ExitCode: response.exitCode, Stdout: response.stdout, Stderr: response.stderr}
+	if options.OnOutput != nil {
+		for _, text := range []string{result.Stdout, result.Stderr} {
+			for _, line := range strings.Split(text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					options.OnOutput(line)
+				}
+			}
+		}
+	}
+	if result.ExitCode != 0 {
+		return result, executor.NewCommandError(call.String(), result.ExitCode, result.Stderr)
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package executortest
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeExecutor_MatchesLeadingArgs(t *testing.T) {
+	exec := New()
+	exec.On("k3d", "cluster", "list").Returns("[]")
+	exec.On("k3d").Returns("any k3d")
+
+	result, err := exec.Execute(context.Background(), "k3d", "cluster", "list", "--output", "json")
+	require.NoError(t, err)
+	assert.Equal(t, "any k3d", result.Stdout, "the last scripted response wins")
+
+	exec.On("k3d", "cluster", "list").Returns("[]")
+	result, err = exec.Execute(context.Background(), "k3d", "cluster", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "[]", result.Stdout)
+
+	result, err = exec.Execute(context.Background(), "helm", "version")
+	require.NoError(t, err)
+	assert.Empty(t, result.Stdout, "unmatched commands succeed")
+}
+
+func TestFakeExecutor_Failures(t *testing.T) {
+	exec := New()
+	exec.On("docker", "pull").Stderr("manifest unknown").Exits(1)
+	notFound := stderrors.New(`exec: "helm": executable file not found in $PATH`)
+	exec.On("helm").Errors(notFound)
+
+	result, err := exec.Execute(context.Background(), "docker", "pull", "alpine")
+	var cmdErr *executor.CommandError
+	require.True(t, stderrors.As(err, &cmdErr))
+	assert.Equal(t, 1, cmdErr.ExitCode)
+	assert.Equal(t, "command failed: docker pull alpine (exit code: 1): manifest unknown", err.Error())
+	assert.Equal(t, 1, result.ExitCode)
+
+	_, err = exec.Execute(context.Background(), "helm", "list")
+	assert.ErrorIs(t, err, notFound)
+
+	_, err = exec.Strict().Execute(context.Background(), "kubectl", "get", "nodes")
+	assert.EqualError(t, err, "executortest: unexpected command: kubectl get nodes")
+}
+
+func TestFakeExecutor_RecordsCalls(t *testing.T) {
+	exec := New()
+	exec.On("helm").Returns("line one\nline two\n")
+	var lines []string
+	_, err := exec.ExecuteWithOptions(context.Background(), executor.ExecuteOptions{
+		Command:  "helm",
+		Args:     []string{"upgrade", "--install", "-f", "-"},
+		Env:      map[string]string{"KUBECONFIG": "/tmp/kc"},
+		Stdin:    []byte("replicas: 1\n"),
+		OnOutput: func(line string) { lines = append(lines, line) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line one", "line two"}, lines)
+
+	calls := exec.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "helm upgrade --install -f -", calls[0].String())
+	assert.Equal(t, "/tmp/kc", calls[0].Env["KUBECONFIG"])
+	assert.Equal(t, "replicas: 1\n", string(calls[0].Stdin))
+	assert.True(t, exec.Called("helm", "upgrade"))
+	assert.False(t, exec.Called("helm", "uninstall"))
+
+	exec.Reset()
+	assert.Empty(t, exec.Calls())
+}
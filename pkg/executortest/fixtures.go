@@ -0,0 +1,100 @@
+package executortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// K3dCluster describes a cluster in `k3d cluster list --output json`.
+type K3dCluster struct {
+	Name string
+	// Servers and Agents are the node counts; 0 servers means 1.
+	Servers int
+	Agents  int
+	// Stopped reports the servers as not running.
+	Stopped bool
+	// Created is when the nodes were created; zero means a fixed date.
+	Created time.Time
+}
+
+// K3dClusterList renders `k3d cluster list --output json` listing clusters.
+func K3dClusterList(clusters ...K3dCluster) string {
+	type node struct {
+		Name    string    `json:"name"`
+		Role    string    `json:"role"`
+		Created time.Time `json:"created"`
+	}
+	type cluster struct {
+		Name           string `json:"name"`
+		ServersCount   int    `json:"serversCount"`
+		ServersRunning int    `json:"serversRunning"`
+		AgentsCount    int    `json:"agentsCount"`
+		AgentsRunning  int    `json:"agentsRunning"`
+		Nodes          []node `json:"nodes"`
+	}
+	out := make([]cluster, 0, len(clusters))
+	for _, c := range clusters {
+		servers := max(c.Servers, 1)
+		created := c.Created
+		if created.IsZero() {
+			created = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+		k := cluster{Name: c.Name, ServersCount: servers, AgentsCount: c.Agents}
+		if !c.Stopped {
+			k.ServersRunning, k.AgentsRunning = servers, c.Agents
+		}
+		for i := range servers {
+			k.Nodes = append(k.Nodes, node{Name: fmt.Sprintf("k3d-%s-server-%d", c.Name, i), Role: "server", Created: created})
+		}
+		for i := range c.Agents {
+			k.Nodes = append(k.Nodes, node{Name: fmt.Sprintf("k3d-%s-agent-%d", c.Name, i), Role: "agent", Created: created})
+		}
+		out = append(out, k)
+	}
+	return mustJSON(out)
+}
+
+// HelmRelease describes a release in helm's output.
+type HelmRelease struct {
+	Name      string
+	Namespace string
+	// Status is the release status; empty means "deployed".
+	Status string
+	// Version is the chart version, AppVersion the packaged app's.
+	Version    string
+	AppVersion string
+}
+
+// HelmListNames renders `helm list -q`: the release names, one per line.
+func HelmListNames(releases ...HelmRelease) string {
+	var b strings.Builder
+	for _, r := range releases {
+		b.WriteString(r.Name + "\n")
+	}
+	return b.String()
+}
+
+// HelmMetadata renders `helm get metadata --output json` for release.
+func HelmMetadata(release HelmRelease) string {
+	status := release.Status
+	if status == "" {
+		status = "deployed"
+	}
+	return mustJSON(map[string]string{
+		"name":       release.Name,
+		"namespace":  release.Namespace,
+		"status":     status,
+		"version":    release.Version,
+		"appVersion": release.AppVersion,
+	})
+}
+
+func mustJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,51 @@
+package executortest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe-cli/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe-cli/internal/cluster/providers/k3d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+// The fixtures are only useful while the CLI parses them, so they are checked
+// through the providers that consume the real output.
+
+func TestK3dClusterList_ParsedByProvider(t *testing.T) {
+	exec := New()
+	exec.On("k3d", "cluster", "list").Returns(K3dClusterList(
+		K3dCluster{Name: "dev", Agents: 2},
+		K3dCluster{Name: "old", Stopped: true},
+	))
+
+	clusters, err := k3d.NewK3dManager(exec, false).ListClusters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "dev", clusters[0].Name)
+	assert.Equal(t, 3, clusters[0].NodeCount)
+	assert.Equal(t, 1, clusters[0].ReadyServers)
+	assert.False(t, clusters[0].CreatedAt.IsZero())
+	assert.Equal(t, 0, clusters[1].ReadyServers)
+}
+
+func TestHelmFixtures_ParsedByProvider(t *testing.T) {
+	exec := New()
+	argo := HelmRelease{Name: "argo-cd", Namespace: "argocd", Version: "8.1.3", AppVersion: "v3.0.6"}
+	exec.On("helm", "list").Returns(HelmListNames(argo))
+	exec.On("helm", "get", "metadata").Returns(HelmMetadata(argo))
+
+	manager, err := helm.NewHelmManager(exec, &rest.Config{Host: "https://127.0.0.1:6550"}, false)
+	require.NoError(t, err)
+	installed, err := manager.IsChartInstalled(context.Background(), "argo-cd", "argocd")
+	require.NoError(t, err)
+	assert.True(t, installed)
+
+	info, err := manager.GetChartStatus(context.Background(), "argo-cd", "argocd")
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", info.Status)
+	assert.Equal(t, "8.1.3", info.Version)
+	assert.Equal(t, "v3.0.6", info.AppVersion)
+}
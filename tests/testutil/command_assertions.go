@@ -117,9 +117,16 @@ func AssertNoCurlPipeShell(t *testing.T, cmds []executor.RecordedCommand) {
 // --- helpers ---
 
 // shellCScript returns the script body of a `bash -c <script>` / `sh -c <script>`
-// invocation (possibly wrapped by `wsl -d ... -u ... bash -c <script>`).
+// invocation (possibly wrapped by `wsl -d ... -u ... bash -c <script>`), or the
+// stdin of a `bash -s` one (executor.ShellScript / WSLShellScript), which is
+// just as much a shell script.
 func shellCScript(c executor.RecordedCommand) (string, bool) {
 	args := c.Args
+	for i, a := range args {
+		if a == "-s" && (i > 0 && isShell(args[i-1]) || i == 0 && isShell(c.Name)) {
+			return string(c.Stdin), true
+		}
+	}
 	for i := 0; i+1 < len(args); i++ {
 		if args[i] == "-c" && (i > 0 && isShell(args[i-1]) || isShell(c.Name)) {
 			return args[i+1], true